@@ -42,6 +42,17 @@ type AriesController interface {
 	// RegisterHandler registers handler for handling notifications
 	RegisterHandler(h Handler, topics string) string
 
+	// RegisterEventHandler registers handler for handling typed notifications
+	RegisterEventHandler(h EventHandler, topics string) string
+
 	// UnregisterHandler unregisters handler
 	UnregisterHandler(id string)
+
+	// Suspend must be called when the app moves to the background: notifications are queued and storage
+	// operations wait until Resume is called.
+	Suspend() error
+
+	// Resume must be called when the app returns to the foreground: queued notifications are delivered and
+	// the connections closed by Suspend are opened again.
+	Resume() error
 }
@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+// StateEvent is a protocol state transition notification, published on the "<protocol>_states" topics.
+type StateEvent struct {
+	Topic        string
+	ProtocolName string
+	// Type is either "pre_state" or "post_state".
+	Type    string
+	StateID string
+	// PIID is the protocol instance ID, if any.
+	PIID string
+	// ConnectionID is the ID of the connection, if any.
+	ConnectionID string
+	// Message is the DIDComm message (JSON) which triggered the transition, if any.
+	Message []byte
+	// Properties are the properties (JSON object) of the transition.
+	Properties []byte
+}
+
+// ActionEvent is a protocol action notification, published on the "<protocol>_actions" topics. The protocol waits
+// for the app to continue or stop the action with the PIID (or the connection ID for didexchange).
+type ActionEvent struct {
+	Topic        string
+	ProtocolName string
+	// PIID is the protocol instance ID, if any.
+	PIID string
+	// ConnectionID is the ID of the connection, if any.
+	ConnectionID string
+	// Message is the DIDComm message (JSON) which triggered the action.
+	Message []byte
+	// Properties are the properties (JSON object) of the action.
+	Properties []byte
+}
+
+// EventHandler handles the typed notifications from the framework.
+// USAGE: The client e.g (android, IOS) implements this interface instead of Handler
+// to receive the protocol events already decoded.
+type EventHandler interface {
+	// HandleState handles a protocol state transition.
+	HandleState(event *StateEvent) error
+
+	// HandleAction handles a protocol action.
+	HandleAction(event *ActionEvent) error
+
+	// HandleMessage handles the notifications of the other topics, e.g. the messages of the message services.
+	HandleMessage(topic string, message []byte) error
+}
@@ -16,4 +16,13 @@ type KMSController interface {
 
 	// ImportKey imports a key.
 	ImportKey(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// Sign signs a message with a key created or imported with the sign usage.
+	Sign(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// Verify verifies a signature with a key created or imported with the verify usage.
+	Verify(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// WrapKey wraps a content encryption key for a recipient.
+	WrapKey(request *models.RequestEnvelope) *models.ResponseEnvelope
 }
@@ -48,11 +48,20 @@ type Aries struct {
 	notifications <-chan notifier.NotificationPayload
 	mutex         sync.RWMutex
 	subscribers   map[string]map[string][]api.Handler
+	storage       *storage.Provider
+	delivery      sync.Mutex
+	suspended     bool
+	queued        []notifier.NotificationPayload
 }
 
 // NewAries returns a new Aries instance that contains handlers and an Aries framework instance.
 func NewAries(opts *config.Options) (*Aries, error) {
-	options, err := prepareFrameworkOptions(opts)
+	var store *storage.Provider
+	if opts.Storage != nil {
+		store = storage.New(opts.Storage)
+	}
+
+	options, err := prepareFrameworkOptions(opts, store)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare framework options: %w", err)
 	}
@@ -85,6 +94,7 @@ func NewAries(opts *config.Options) (*Aries, error) {
 		handlers:      handlers,
 		notifications: notifications,
 		subscribers:   make(map[string]map[string][]api.Handler),
+		storage:       store,
 	}
 
 	go a.startNotificationListener()
@@ -92,7 +102,7 @@ func NewAries(opts *config.Options) (*Aries, error) {
 	return a, nil
 }
 
-func prepareFrameworkOptions(opts *config.Options) ([]aries.Option, error) {
+func prepareFrameworkOptions(opts *config.Options, store *storage.Provider) ([]aries.Option, error) {
 	msgHandler := msghandler.NewRegistrar()
 
 	var options []aries.Option
@@ -102,8 +112,8 @@ func prepareFrameworkOptions(opts *config.Options) ([]aries.Option, error) {
 		options = append(options, aries.WithTransportReturnRoute(opts.TransportReturnRoute))
 	}
 
-	if opts.Storage != nil {
-		options = append(options, aries.WithStoreProvider(storage.New(opts.Storage)))
+	if store != nil {
+		options = append(options, aries.WithStoreProvider(store))
 	} else {
 		options = append(options, aries.WithStoreProvider(mem.NewProvider()))
 	}
@@ -176,20 +186,77 @@ func populateHandlers(commands []command.Handler, pkgMap map[string]map[string]c
 func (a *Aries) startNotificationListener() {
 	// listens for notifications
 	for notification := range a.notifications {
-		a.mutex.RLock()
-		// gets all handlers that were subscribed for the topic
-		for _, handlers := range a.subscribers[notification.Topic] {
-			// send the payload to the subscribers
-			for _, handler := range handlers {
-				if err := handler.Handle(notification.Topic, notification.Raw); err != nil {
-					logger.Errorf("notification listener: %v", err)
-				}
+		a.delivery.Lock()
+
+		a.mutex.Lock()
+		suspended := a.suspended
+		if suspended {
+			// delivered by Resume
+			a.queued = append(a.queued, notification)
+		}
+		a.mutex.Unlock()
+
+		if !suspended {
+			a.notifySubscribers(notification)
+		}
+
+		a.delivery.Unlock()
+	}
+}
+
+func (a *Aries) notifySubscribers(notification notifier.NotificationPayload) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	// gets all handlers that were subscribed for the topic
+	for _, handlers := range a.subscribers[notification.Topic] {
+		// send the payload to the subscribers
+		for _, handler := range handlers {
+			if err := handler.Handle(notification.Topic, notification.Raw); err != nil {
+				logger.Errorf("notification listener: %v", err)
 			}
 		}
-		a.mutex.RUnlock()
 	}
 }
 
+// Suspend queues the notifications and blocks the storage operations until Resume is called.
+// It must be called by the mobile app when moving to the background.
+func (a *Aries) Suspend() error {
+	a.mutex.Lock()
+	a.suspended = true
+	a.mutex.Unlock()
+
+	if a.storage != nil {
+		a.storage.Suspend()
+	}
+
+	return nil
+}
+
+// Resume unblocks the storage operations and delivers the queued notifications.
+// It must be called by the mobile app when returning to the foreground. The outbound connections closed while
+// in the background are opened again on demand.
+func (a *Aries) Resume() error {
+	if a.storage != nil {
+		a.storage.Resume()
+	}
+
+	a.delivery.Lock()
+	defer a.delivery.Unlock()
+
+	a.mutex.Lock()
+	queued := a.queued
+	a.queued = nil
+	a.suspended = false
+	a.mutex.Unlock()
+
+	for _, notification := range queued {
+		a.notifySubscribers(notification)
+	}
+
+	return nil
+}
+
 // RegisterHandler registers a handler to process incoming notifications from the framework.
 // Handler is implemented by mobile apps.
 func (a *Aries) RegisterHandler(h api.Handler, topics string) string {
@@ -209,6 +276,12 @@ func (a *Aries) RegisterHandler(h api.Handler, topics string) string {
 	return id
 }
 
+// RegisterEventHandler registers a handler to process the typed incoming notifications from the framework.
+// EventHandler is implemented by mobile apps.
+func (a *Aries) RegisterEventHandler(h api.EventHandler, topics string) string {
+	return a.RegisterHandler(notifier.NewEventHandler(h), topics)
+}
+
 // UnregisterHandler unregisters a handler by given id.
 func (a *Aries) UnregisterHandler(id string) {
 	a.mutex.Lock()
@@ -15,6 +15,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/api"
 	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/config"
 	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
@@ -38,6 +39,73 @@ func (hf handlerFunc) Handle(topic string, message []byte) error {
 	return hf(topic, message)
 }
 
+type eventHandler struct {
+	states  chan *api.StateEvent
+	actions chan *api.ActionEvent
+}
+
+func (h *eventHandler) HandleState(event *api.StateEvent) error {
+	h.states <- event
+
+	return nil
+}
+
+func (h *eventHandler) HandleAction(event *api.ActionEvent) error {
+	h.actions <- event
+
+	return nil
+}
+
+func (h *eventHandler) HandleMessage(topic string, message []byte) error {
+	return errors.New("unexpected message")
+}
+
+func TestAries_RegisterEventHandler(t *testing.T) {
+	a, err := NewAries(&config.Options{})
+	require.NoError(t, err)
+	require.NotNil(t, a)
+
+	h := &eventHandler{states: make(chan *api.StateEvent, 100), actions: make(chan *api.ActionEvent, 100)}
+
+	defer a.UnregisterHandler(a.RegisterEventHandler(h, "didexchange_states"))
+
+	require.NoError(t, a.Suspend())
+
+	ctrl, err := a.GetDIDExchangeController()
+	require.NoError(t, err)
+
+	inv := ctrl.CreateInvitation(&models.RequestEnvelope{Payload: []byte(`{}`)})
+
+	var resp *didexchange.CreateInvitationResponse
+
+	require.NoError(t, json.Unmarshal(inv.Payload, &resp))
+
+	src, err := json.Marshal(resp.Invitation)
+	require.NoError(t, err)
+
+	ctrl.ReceiveInvitation(&models.RequestEnvelope{Payload: src})
+
+	select {
+	case <-h.states:
+		t.Fatal("state delivered while suspended")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, a.Resume())
+
+	select {
+	case event := <-h.states:
+		require.Equal(t, "didexchange_states", event.Topic)
+		require.Equal(t, "didexchange", event.ProtocolName)
+		require.Equal(t, "pre_state", event.Type)
+		require.NotEmpty(t, event.StateID)
+		require.NotEmpty(t, event.ConnectionID)
+		require.NotEmpty(t, event.Message)
+	case <-time.After(time.Second):
+		t.Error("timeout")
+	}
+}
+
 func TestAries_RegisterHandler(t *testing.T) {
 	const topic = "didexchange_states"
 
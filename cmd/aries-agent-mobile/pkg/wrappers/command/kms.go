@@ -44,3 +44,51 @@ func (k *KMS) ImportKey(request *models.RequestEnvelope) *models.ResponseEnvelop
 
 	return &models.ResponseEnvelope{Payload: response}
 }
+
+// Sign signs a message with a key created or imported with the sign usage.
+func (k *KMS) Sign(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := kms.SignRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(k.handlers[kms.SignCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// Verify verifies a signature with a key created or imported with the verify usage.
+func (k *KMS) Verify(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := kms.VerifyRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(k.handlers[kms.VerifyCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// WrapKey wraps a content encryption key for a recipient.
+func (k *KMS) WrapKey(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := kms.WrapKeyRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(k.handlers[kms.WrapKeyCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
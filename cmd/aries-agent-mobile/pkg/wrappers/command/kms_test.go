@@ -72,3 +72,24 @@ func TestKMS_ImportKey(t *testing.T) {
 			string(resp.Payload))
 	})
 }
+
+func TestKMS_Sign(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		mockResponse := `{"signature":"c2lnbmF0dXJl"}`
+
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		controller.handlers[kms.SignCommandMethod] = fakeHandler.exec
+
+		payload := `{"keyID":"keyID","message":"bWVzc2FnZQ"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := controller.Sign(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
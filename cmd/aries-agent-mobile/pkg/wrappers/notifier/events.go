@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/api"
+)
+
+const (
+	statesTopicSuffix  = "_states"
+	actionsTopicSuffix = "_actions"
+
+	piidProperty         = "piid"
+	connectionIDProperty = "connectionID"
+)
+
+// topicMessage is the notification published by the framework on a topic.
+type topicMessage struct {
+	Message json.RawMessage `json:"message"`
+}
+
+// protocolMessage is the message of the state and action notifications.
+type protocolMessage struct {
+	ProtocolName string
+	Type         string
+	StateID      string
+	Message      json.RawMessage
+	Properties   map[string]interface{}
+}
+
+type eventHandler struct {
+	handler api.EventHandler
+}
+
+// NewEventHandler returns a Handler decoding the notifications for the given typed handler.
+func NewEventHandler(h api.EventHandler) api.Handler {
+	return &eventHandler{handler: h}
+}
+
+// Handle decodes the notification and dispatches it to the typed handler.
+func (e *eventHandler) Handle(topic string, message []byte) error {
+	if !strings.HasSuffix(topic, statesTopicSuffix) && !strings.HasSuffix(topic, actionsTopicSuffix) {
+		return e.handler.HandleMessage(topic, message)
+	}
+
+	var topicMsg topicMessage
+
+	if err := json.Unmarshal(message, &topicMsg); err != nil {
+		return fmt.Errorf("decode topic message: %w", err)
+	}
+
+	var msg protocolMessage
+
+	if err := json.Unmarshal(topicMsg.Message, &msg); err != nil {
+		return fmt.Errorf("decode protocol message: %w", err)
+	}
+
+	properties, err := json.Marshal(msg.Properties)
+	if err != nil {
+		return fmt.Errorf("encode properties: %w", err)
+	}
+
+	if strings.HasSuffix(topic, actionsTopicSuffix) {
+		return e.handler.HandleAction(&api.ActionEvent{
+			Topic:        topic,
+			ProtocolName: msg.ProtocolName,
+			PIID:         stringProperty(msg.Properties, piidProperty),
+			ConnectionID: stringProperty(msg.Properties, connectionIDProperty),
+			Message:      msg.Message,
+			Properties:   properties,
+		})
+	}
+
+	return e.handler.HandleState(&api.StateEvent{
+		Topic:        topic,
+		ProtocolName: msg.ProtocolName,
+		Type:         msg.Type,
+		StateID:      msg.StateID,
+		PIID:         stringProperty(msg.Properties, piidProperty),
+		ConnectionID: stringProperty(msg.Properties, connectionIDProperty),
+		Message:      msg.Message,
+		Properties:   properties,
+	})
+}
+
+func stringProperty(properties map[string]interface{}, name string) string {
+	s, _ := properties[name].(string)
+
+	return s
+}
@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package notifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/api"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+)
+
+type mockEventHandler struct {
+	state   *api.StateEvent
+	action  *api.ActionEvent
+	topic   string
+	message []byte
+}
+
+func (h *mockEventHandler) HandleState(event *api.StateEvent) error {
+	h.state = event
+
+	return nil
+}
+
+func (h *mockEventHandler) HandleAction(event *api.ActionEvent) error {
+	h.action = event
+
+	return errors.New("action error")
+}
+
+func (h *mockEventHandler) HandleMessage(topic string, message []byte) error {
+	h.topic = topic
+	h.message = message
+
+	return nil
+}
+
+func TestEventHandler_Handle(t *testing.T) {
+	t.Run("state", func(t *testing.T) {
+		msg, err := webnotifier.PrepareTopicMessage("issue-credential_states", []byte(`{
+			"ProtocolName": "issue-credential",
+			"Type": "post_state",
+			"StateID": "done",
+			"Message": {"@type": "https://didcomm.org/issue-credential/2.0/ack"},
+			"Properties": {"piid": "piid-1"}
+		}`))
+		require.NoError(t, err)
+
+		h := &mockEventHandler{}
+
+		require.NoError(t, NewEventHandler(h).Handle("issue-credential_states", msg))
+		require.Equal(t, &api.StateEvent{
+			Topic:        "issue-credential_states",
+			ProtocolName: "issue-credential",
+			Type:         "post_state",
+			StateID:      "done",
+			PIID:         "piid-1",
+			Message:      []byte(`{"@type":"https://didcomm.org/issue-credential/2.0/ack"}`),
+			Properties:   []byte(`{"piid":"piid-1"}`),
+		}, h.state)
+	})
+
+	t.Run("action", func(t *testing.T) {
+		msg, err := webnotifier.PrepareTopicMessage("didexchange_actions", []byte(`{
+			"ProtocolName": "didexchange",
+			"Message": {"@type": "https://didcomm.org/didexchange/1.0/request"},
+			"Properties": {"connectionID": "conn-1"}
+		}`))
+		require.NoError(t, err)
+
+		h := &mockEventHandler{}
+
+		require.EqualError(t, NewEventHandler(h).Handle("didexchange_actions", msg), "action error")
+		require.Equal(t, "didexchange", h.action.ProtocolName)
+		require.Equal(t, "conn-1", h.action.ConnectionID)
+		require.Empty(t, h.action.PIID)
+	})
+
+	t.Run("message", func(t *testing.T) {
+		h := &mockEventHandler{}
+
+		require.NoError(t, NewEventHandler(h).Handle("generic-invite", []byte(`{}`)))
+		require.Equal(t, "generic-invite", h.topic)
+		require.Equal(t, []byte(`{}`), h.message)
+	})
+
+	t.Run("invalid topic message", func(t *testing.T) {
+		err := NewEventHandler(&mockEventHandler{}).Handle("didexchange_states", []byte(`[]`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode topic message")
+	})
+
+	t.Run("invalid protocol message", func(t *testing.T) {
+		err := NewEventHandler(&mockEventHandler{}).Handle("didexchange_states", []byte(`{"message": []}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode protocol message")
+	})
+}
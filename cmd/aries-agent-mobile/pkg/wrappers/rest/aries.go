@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/api"
 	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/config"
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/notifier"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
@@ -42,6 +43,8 @@ type Aries struct {
 	Token        string
 	mutex        sync.RWMutex
 	subscribers  map[string]map[string][]api.Handler
+	listener     sync.Mutex
+	stopListener func()
 }
 
 // NewAries returns a new Aries instance.
@@ -61,7 +64,7 @@ func NewAries(opts *config.Options) (*Aries, error) {
 		subscribers:  make(map[string]map[string][]api.Handler),
 	}
 
-	go a.startNotificationListener()
+	a.listen()
 
 	return a, nil
 }
@@ -73,12 +76,54 @@ type Incoming struct {
 	Message interface{} `json:"message"`
 }
 
-func (ar *Aries) startNotificationListener() {
-	if ar.WebsocketURL == "" {
+// listen starts the notification listener, unless already started.
+func (ar *Aries) listen() {
+	ar.listener.Lock()
+	defer ar.listener.Unlock()
+
+	if ar.WebsocketURL == "" || ar.stopListener != nil {
 		return
 	}
 
-	conn, _, err := websocket.Dial(context.Background(), ar.WebsocketURL, nil) // nolint: bodyclose
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	ar.stopListener = func() {
+		cancel()
+		<-done
+	}
+
+	go func() {
+		defer close(done)
+
+		ar.startNotificationListener(ctx)
+	}()
+}
+
+// Suspend closes the websocket connection of the notification listener.
+// It must be called by the mobile app when moving to the background.
+func (ar *Aries) Suspend() error {
+	ar.listener.Lock()
+	defer ar.listener.Unlock()
+
+	if ar.stopListener != nil {
+		ar.stopListener()
+		ar.stopListener = nil
+	}
+
+	return nil
+}
+
+// Resume opens again the websocket connection of the notification listener.
+// It must be called by the mobile app when returning to the foreground.
+func (ar *Aries) Resume() error {
+	ar.listen()
+
+	return nil
+}
+
+func (ar *Aries) startNotificationListener(ctx context.Context) {
+	conn, _, err := websocket.Dial(ctx, ar.WebsocketURL, nil) // nolint: bodyclose
 	if err != nil {
 		logger.Errorf("notification listener: websocket dial: %v", err)
 
@@ -95,7 +140,12 @@ func (ar *Aries) startNotificationListener() {
 	for {
 		var incoming *Incoming
 		// listens for notifications
-		if err := wsjson.Read(context.Background(), conn, &incoming); err != nil {
+		if err := wsjson.Read(ctx, conn, &incoming); err != nil {
+			// exit if suspended
+			if ctx.Err() != nil {
+				return
+			}
+
 			if websocket.CloseStatus(err) != websocket.StatusNormalClosure {
 				logger.Errorf("notification listener: read: close connection: %v", err)
 			}
@@ -153,6 +203,12 @@ func (ar *Aries) RegisterHandler(h api.Handler, topics string) string {
 	return id
 }
 
+// RegisterEventHandler registers a handler to process the typed incoming notifications from the framework.
+// EventHandler is implemented by mobile apps.
+func (ar *Aries) RegisterEventHandler(h api.EventHandler, topics string) string {
+	return ar.RegisterHandler(notifier.NewEventHandler(h), topics)
+}
+
 // UnregisterHandler unregisters a handler by given id.
 func (ar *Aries) UnregisterHandler(id string) {
 	ar.mutex.Lock()
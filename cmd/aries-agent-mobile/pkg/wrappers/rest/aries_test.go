@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -129,6 +130,56 @@ func TestAries_RegisterHandler(t *testing.T) {
 	}
 }
 
+func TestAries_SuspendResume(t *testing.T) {
+	connected := make(chan struct{})
+	disconnected := make(chan struct{})
+
+	srv := httptest.NewServer(wsFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := websocket.Accept(w, r, &websocket.AcceptOptions{})
+		require.NoError(t, err)
+
+		connected <- struct{}{}
+
+		// blocks until the client closes the connection
+		_, _, err = c.Read(context.Background())
+		require.Error(t, err)
+
+		disconnected <- struct{}{}
+	}))
+	defer srv.Close()
+
+	a, err := NewAries(&config.Options{
+		AgentURL:     mockAgentURL,
+		WebsocketURL: strings.Replace(srv.URL, "http", "ws", 1),
+	})
+	require.NoError(t, err)
+
+	wait := func(ch chan struct{}) {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	wait(connected)
+
+	require.NoError(t, a.Suspend())
+	wait(disconnected)
+
+	// suspending twice is a no-op
+	require.NoError(t, a.Suspend())
+
+	require.NoError(t, a.Resume())
+	wait(connected)
+
+	// resuming twice is a no-op
+	require.NoError(t, a.Resume())
+
+	require.NoError(t, a.Suspend())
+	wait(disconnected)
+}
+
 func TestAries_GetVerifiableController(t *testing.T) {
 	t.Run("it creates a controller", func(t *testing.T) {
 		a, err := NewAries(&config.Options{AgentURL: mockAgentURL})
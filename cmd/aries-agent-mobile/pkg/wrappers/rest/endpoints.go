@@ -122,7 +122,7 @@ func getVerifiableEndpoints() map[string]*endpoint {
 			Method: http.MethodGet,
 		},
 		cmdverifiable.SignCredentialCommandMethod: {
-			Path:   opverifiable.SignCredentialsPath,
+			Path:   opverifiable.SignCredentialPath,
 			Method: http.MethodPost,
 		},
 		cmdverifiable.GetPresentationCommandMethod: {
@@ -437,5 +437,17 @@ func getKMSEndpoints() map[string]*endpoint {
 			Path:   opkms.ImportKeyPath,
 			Method: http.MethodPost,
 		},
+		cmdkms.SignCommandMethod: {
+			Path:   opkms.SignPath,
+			Method: http.MethodPost,
+		},
+		cmdkms.VerifyCommandMethod: {
+			Path:   opkms.VerifyPath,
+			Method: http.MethodPost,
+		},
+		cmdkms.WrapKeyCommandMethod: {
+			Path:   opkms.WrapKeyPath,
+			Method: http.MethodPost,
+		},
 	}
 }
@@ -30,6 +30,21 @@ func (k *KMS) ImportKey(request *models.RequestEnvelope) *models.ResponseEnvelop
 	return k.createRespEnvelope(request, kms.ImportKeyCommandMethod)
 }
 
+// Sign signs a message with a key created or imported with the sign usage.
+func (k *KMS) Sign(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return k.createRespEnvelope(request, kms.SignCommandMethod)
+}
+
+// Verify verifies a signature with a key created or imported with the verify usage.
+func (k *KMS) Verify(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return k.createRespEnvelope(request, kms.VerifyCommandMethod)
+}
+
+// WrapKey wraps a content encryption key for a recipient.
+func (k *KMS) WrapKey(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return k.createRespEnvelope(request, kms.WrapKeyCommandMethod)
+}
+
 func (k *KMS) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
 	return exec(&restOperation{
 		url:        k.URL,
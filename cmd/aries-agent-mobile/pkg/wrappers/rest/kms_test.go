@@ -73,3 +73,24 @@ func TestKMS_ImportKey(t *testing.T) {
 		require.Equal(t, mockResponse, string(resp.Payload))
 	})
 }
+
+func TestKMS_Sign(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		reqData := `{"keyID":"keyID","message":"bWVzc2FnZQ"}`
+		mockResponse := `{"signature":"c2lnbmF0dXJl"}`
+
+		controller.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + kms.SignPath,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := controller.Sign(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
@@ -205,7 +205,7 @@ func TestVerifiable_SignCredential(t *testing.T) {
 		mockResponse := fmt.Sprintf(`{"verifiableCredential": %s}`, strconv.Quote(mockSignedVC))
 		v.httpClient = &mockHTTPClient{
 			data:   mockResponse,
-			method: http.MethodPost, url: mockAgentURL + opverifiable.SignCredentialsPath,
+			method: http.MethodPost, url: mockAgentURL + opverifiable.SignCredentialPath,
 		}
 
 		reqData := fmt.Sprintf(`{"credential": %s, "did": "%s", "signatureType": "%s"}`,
@@ -8,32 +8,104 @@ SPDX-License-Identifier: Apache-2.0
 package storage
 
 import (
+	"sync"
+
 	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/api"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
 // Provider represents storage provider.
-type Provider struct{ api.StorageProvider }
+type Provider struct {
+	api.StorageProvider
+	gate *gate
+}
 
 // New returns new storage provider.
 func New(p api.StorageProvider) *Provider {
-	return &Provider{StorageProvider: p}
+	return &Provider{StorageProvider: p, gate: &gate{}}
 }
 
 // OpenStore overwrites original method to satisfy the interface.
 func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	p.gate.enter()
+	defer p.gate.leave()
+
 	_store, err := p.StorageProvider.OpenStore(name)
 	if err != nil {
 		return nil, err
 	}
 
-	return &store{Store: _store}, nil
+	return &store{Store: _store, gate: p.gate}, nil
+}
+
+// Suspend waits for the pending storage operations, then blocks the new ones until Resume is called.
+// The mobile platforms may deny the access to the files of the apps in the background.
+func (p *Provider) Suspend() {
+	p.gate.suspend()
+}
+
+// Resume unblocks the storage operations.
+func (p *Provider) Resume() {
+	p.gate.resume()
+}
+
+// gate blocks the storage operations while suspended.
+type gate struct {
+	ops       sync.RWMutex
+	mutex     sync.Mutex
+	suspended bool
+}
+
+func (g *gate) enter() { g.ops.RLock() }
+
+func (g *gate) leave() { g.ops.RUnlock() }
+
+func (g *gate) suspend() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if !g.suspended {
+		g.ops.Lock()
+		g.suspended = true
+	}
+}
+
+func (g *gate) resume() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.suspended {
+		g.ops.Unlock()
+		g.suspended = false
+	}
+}
+
+type store struct {
+	api.Store
+	gate *gate
 }
 
-type store struct{ api.Store }
+// Put stores the key and the record.
+func (s *store) Put(k string, v []byte) error {
+	s.gate.enter()
+	defer s.gate.leave()
+
+	return s.Store.Put(k, v)
+}
+
+// Delete deletes the record with key k.
+func (s *store) Delete(k string) error {
+	s.gate.enter()
+	defer s.gate.leave()
+
+	return s.Store.Delete(k)
+}
 
 // Get fetches the record based on key.
 func (s *store) Get(k string) ([]byte, error) {
+	s.gate.enter()
+	defer s.gate.leave()
+
 	res, err := s.Store.Get(k)
 
 	if err != nil && err.Error() == storage.ErrDataNotFound.Error() {
@@ -43,8 +115,16 @@ func (s *store) Get(k string) ([]byte, error) {
 	return res, err
 }
 
+// Batch performs the batch operations one by one, since the mobile storage has no batch.
+func (s *store) Batch(operations []storage.Operation) error {
+	return storage.ApplyOperations(s, operations)
+}
+
 // Iterator overwrites original method to satisfy the interface.
 func (s *store) Iterator(startKey, endKey string) storage.StoreIterator {
+	s.gate.enter()
+	defer s.gate.leave()
+
 	return &iterator{StoreIterator: s.Store.Iterator(startKey, endKey)}
 }
 
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -284,3 +285,43 @@ func TestMemStore_Delete(t *testing.T) {
 	require.EqualError(t, err, storage.ErrDataNotFound.Error())
 	require.Empty(t, doc)
 }
+
+func TestSuspend(t *testing.T) {
+	prov := New(newMock(mem.NewProvider()))
+	store, err := prov.OpenStore("test")
+	require.NoError(t, err)
+
+	prov.Suspend()
+	// suspending twice is a no-op
+	prov.Suspend()
+
+	done := make(chan struct{})
+
+	go func() {
+		require.NoError(t, store.Put("key", []byte("value")))
+
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("storage operation executed while suspended")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	prov.Resume()
+	// resuming twice is a no-op
+	prov.Resume()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	value, err := store.Get("key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	require.NoError(t, store.Delete("key"))
+}
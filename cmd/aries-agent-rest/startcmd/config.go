@@ -0,0 +1,268 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+// configAnnotation marks the flags whose value was set from the configuration file.
+const configAnnotation = "config-file"
+
+// reloadableSettings are the settings applied again when the agent receives SIGHUP.
+type reloadableSettings struct {
+	logLevel    string
+	webhookURLs []string
+}
+
+// reloadableFlags are the names of the flags of the reloadableSettings.
+// nolint:gochecknoglobals
+var reloadableFlags = map[string]bool{
+	agentLogLevelFlagName: true,
+	agentWebhookFlagName:  true,
+}
+
+// applyConfigFile sets the flags which are not set on the command line to the values of the given YAML or JSON
+// configuration file, keyed by flag name. The environment variables still take precedence over these values (see
+// getUserSetVar). The flags set by a previous call are reset first, so that the settings removed from the file fall
+// back to their default.
+func applyConfigFile(cmd *cobra.Command, path string) error {
+	config, err := loadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string][]string, len(config))
+
+	for name, value := range config {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || name == agentConfigFileFlagName {
+			return fmt.Errorf("invalid configuration file %s : unknown setting %s", path, name)
+		}
+
+		v, err := configValues(value)
+		if err != nil {
+			return fmt.Errorf("invalid configuration file %s : setting %s : %w", path, name, err)
+		}
+
+		if _, ok := flag.Value.(pflag.SliceValue); !ok && len(v) > 1 {
+			return fmt.Errorf("invalid configuration file %s : setting %s takes a single value", path, name)
+		}
+
+		values[name] = v
+	}
+
+	var resetErr error
+
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if _, ok := flag.Annotations[configAnnotation]; ok && resetErr == nil {
+			delete(flag.Annotations, configAnnotation)
+
+			resetErr = setFlagValues(flag, nil)
+		}
+	})
+
+	if resetErr != nil {
+		return resetErr
+	}
+
+	for _, name := range sortedKeys(values) {
+		flag := cmd.Flags().Lookup(name)
+		if flag.Changed {
+			continue
+		}
+
+		if err := setFlagValues(flag, values[name]); err != nil {
+			return fmt.Errorf("invalid configuration file %s : setting %s : %w", path, name, err)
+		}
+
+		if flag.Annotations == nil {
+			flag.Annotations = make(map[string][]string)
+		}
+
+		flag.Annotations[configAnnotation] = []string{path}
+	}
+
+	return nil
+}
+
+func loadConfigFile(path string) (map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file %s : %w", path, err)
+	}
+
+	config := make(map[string]interface{})
+
+	// JSON being a subset of YAML, both formats are parsed by the YAML decoder.
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s : %w", path, err)
+	}
+
+	return config, nil
+}
+
+// configValues returns the flag values of a setting: its string representation, or that of its elements for a list.
+func configValues(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+
+		for _, e := range v {
+			s, err := configValue(e)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, s)
+		}
+
+		return values, nil
+	default:
+		s, err := configValue(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{s}, nil
+	}
+}
+
+func configValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string, bool, int, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", v)
+	}
+}
+
+// setFlagValues sets the value of the flag without marking it as changed, the default value if values is empty.
+func setFlagValues(flag *pflag.Flag, values []string) error {
+	if sv, ok := flag.Value.(pflag.SliceValue); ok {
+		if values == nil {
+			values = []string{}
+		}
+
+		return sv.Replace(values)
+	}
+
+	if len(values) == 0 {
+		return flag.Value.Set(flag.DefValue)
+	}
+
+	return flag.Value.Set(values[0])
+}
+
+// isConfigured tells whether the value of the flag was set from the configuration file.
+func isConfigured(cmd *cobra.Command, flagName string) bool {
+	flag := cmd.Flags().Lookup(flagName)
+	if flag == nil {
+		return false
+	}
+
+	_, ok := flag.Annotations[configAnnotation]
+
+	return ok
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// reloadConfigFile applies the configuration file again and returns the reloadable settings, warning about the other
+// settings which changed as they require a restart of the agent.
+func reloadConfigFile(cmd *cobra.Command, path string, autoAccept bool) (*reloadableSettings, error) {
+	before := flagValues(cmd)
+
+	if err := applyConfigFile(cmd, path); err != nil {
+		return nil, err
+	}
+
+	for name, value := range flagValues(cmd) {
+		if !reloadableFlags[name] && before[name] != value {
+			logger.Warnf("setting %s changed, restart the agent to apply it", name)
+		}
+	}
+
+	logLevel, err := getUserSetVar(cmd, agentLogLevelFlagName, agentLogLevelEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if logLevel != "" {
+		if _, err = log.ParseLevel(logLevel); err != nil {
+			return nil, fmt.Errorf("failed to parse log level '%s' : %w", logLevel, err)
+		}
+	}
+
+	webhookURLs, err := getUserSetVars(cmd, agentWebhookFlagName, agentWebhookEnvKey, autoAccept)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reloadableSettings{logLevel: logLevel, webhookURLs: webhookURLs}, nil
+}
+
+func flagValues(cmd *cobra.Command) map[string]string {
+	values := make(map[string]string)
+
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		values[flag.Name] = flag.Value.String()
+	})
+
+	return values
+}
+
+// watchReload calls reload and applies the settings whenever a signal is received, until the channel is closed.
+func watchReload(signals <-chan os.Signal, reload func() (*reloadableSettings, error),
+	apply func(*reloadableSettings)) {
+	for range signals {
+		logger.Infof("reloading the configuration")
+
+		settings, err := reload()
+		if err != nil {
+			logger.Errorf("failed to reload the configuration, keeping the current settings : %s", err)
+
+			continue
+		}
+
+		apply(settings)
+	}
+}
+
+// notifyReload returns the channel of the SIGHUP signals and the function stopping their delivery.
+func notifyReload() (<-chan os.Signal, func()) {
+	signals := make(chan os.Signal, 1)
+
+	signal.Notify(signals, syscall.SIGHUP)
+
+	return signals, func() {
+		signal.Stop(signals)
+		close(signals)
+	}
+}
@@ -0,0 +1,299 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+)
+
+func TestApplyConfigFile(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		cmd := newTestStartCmd(t)
+
+		path := writeConfigFile(t, "config.yaml", `
+api-host: localhost:8080
+inbound-host:
+  - http@localhost:8081
+  - ws@localhost:8082
+auto-accept: true
+webhook-max-retries: 3
+`)
+
+		require.NoError(t, applyConfigFile(cmd, path))
+
+		host, err := getUserSetVar(cmd, agentHostFlagName, agentHostEnvKey, false)
+		require.NoError(t, err)
+		require.Equal(t, "localhost:8080", host)
+
+		inboundHosts, err := getUserSetVars(cmd, agentInboundHostFlagName, agentInboundHostEnvKey, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"http@localhost:8081", "ws@localhost:8082"}, inboundHosts)
+
+		autoAccept, err := getAutoAcceptValue(cmd)
+		require.NoError(t, err)
+		require.True(t, autoAccept)
+
+		retries, err := getWebhookMaxRetries(cmd)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, retries)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		cmd := newTestStartCmd(t)
+
+		path := writeConfigFile(t, "config.json", `{"api-host": "localhost:8080", "webhook-url": ["http://localhost:8083"]}`)
+
+		require.NoError(t, applyConfigFile(cmd, path))
+
+		host, err := getUserSetVar(cmd, agentHostFlagName, agentHostEnvKey, false)
+		require.NoError(t, err)
+		require.Equal(t, "localhost:8080", host)
+
+		webhookURLs, err := getUserSetVars(cmd, agentWebhookFlagName, agentWebhookEnvKey, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://localhost:8083"}, webhookURLs)
+	})
+
+	t.Run("flags and environment variables take precedence", func(t *testing.T) {
+		cmd := newTestStartCmd(t)
+		require.NoError(t, cmd.Flags().Set(agentHostFlagName, "localhost:9090"))
+
+		require.NoError(t, os.Setenv(agentDefaultLabelEnvKey, "env-label"))
+
+		defer func() {
+			require.NoError(t, os.Unsetenv(agentDefaultLabelEnvKey))
+		}()
+
+		path := writeConfigFile(t, "config.yaml", "api-host: localhost:8080\nagent-default-label: file-label\n")
+
+		require.NoError(t, applyConfigFile(cmd, path))
+
+		host, err := getUserSetVar(cmd, agentHostFlagName, agentHostEnvKey, false)
+		require.NoError(t, err)
+		require.Equal(t, "localhost:9090", host)
+
+		label, err := getUserSetVar(cmd, agentDefaultLabelFlagName, agentDefaultLabelEnvKey, true)
+		require.NoError(t, err)
+		require.Equal(t, "env-label", label)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			content string
+			err     string
+		}{
+			{name: "unknown setting", content: "unknown: value", err: "unknown setting unknown"},
+			{name: "nested config file", content: "config-file: other.yaml", err: "unknown setting config-file"},
+			{name: "list of single value", content: "api-host: [a, b]", err: "setting api-host takes a single value"},
+			{name: "unsupported value", content: "api-host: {a: b}", err: "unsupported value"},
+			{name: "invalid yaml", content: "api-host: [", err: "failed to parse configuration file"},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				err := applyConfigFile(newTestStartCmd(t), writeConfigFile(t, "config.yaml", tc.content))
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			})
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		err := applyConfigFile(newTestStartCmd(t), filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read configuration file")
+	})
+}
+
+func TestStartCmdWithConfigFile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		path := writeConfigFile(t, "config.yaml", `
+api-host: `+randomURL()+`
+inbound-host: http@`+randomURL()+`
+database-type: mem
+auto-accept: true
+`)
+
+		startCmd.SetArgs([]string{"--" + agentConfigFileFlagName, path})
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{"--" + agentConfigFileFlagName, writeConfigFile(t, "config.yaml", "unknown: 1")})
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unknown setting unknown")
+	})
+}
+
+func TestReloadConfigFile(t *testing.T) {
+	cmd := newTestStartCmd(t)
+
+	path := writeConfigFile(t, "config.yaml", `
+api-host: localhost:8080
+log-level: INFO
+webhook-url: http://localhost:8083
+agent-default-label: agent
+`)
+
+	require.NoError(t, applyConfigFile(cmd, path))
+
+	t.Run("reloadable settings", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(path, []byte(`
+api-host: localhost:9090
+log-level: DEBUG
+webhook-url: [http://localhost:8084, http://localhost:8085]
+`), 0600))
+
+		settings, err := reloadConfigFile(cmd, path, false)
+		require.NoError(t, err)
+		require.Equal(t, "DEBUG", settings.logLevel)
+		require.Equal(t, []string{"http://localhost:8084", "http://localhost:8085"}, settings.webhookURLs)
+
+		// the settings removed from the file fall back to their default
+		label, err := getUserSetVar(cmd, agentDefaultLabelFlagName, agentDefaultLabelEnvKey, true)
+		require.NoError(t, err)
+		require.Empty(t, label)
+	})
+
+	t.Run("invalid log level", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(path, []byte("log-level: INVALID\n"), 0600))
+
+		_, err := reloadConfigFile(cmd, path, true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse log level")
+	})
+
+	t.Run("missing webhook url", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(path, []byte("log-level: INFO\n"), 0600))
+
+		_, err := reloadConfigFile(cmd, path, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "webhook-url not set")
+	})
+}
+
+func TestWatchReload(t *testing.T) {
+	signals := make(chan os.Signal)
+	applied := make(chan *reloadableSettings)
+	reloadErrs := make(chan error, 1)
+	done := make(chan struct{})
+
+	go func() {
+		watchReload(signals, func() (*reloadableSettings, error) {
+			if err := <-reloadErrs; err != nil {
+				return nil, err
+			}
+
+			return &reloadableSettings{logLevel: "DEBUG"}, nil
+		}, func(settings *reloadableSettings) {
+			applied <- settings
+		})
+
+		close(done)
+	}()
+
+	reloadErrs <- errors.New("reload failed")
+	signals <- syscall.SIGHUP
+
+	// the failed reload is not applied
+	select {
+	case <-applied:
+		require.FailNow(t, "failed reload applied")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	reloadErrs <- nil
+	signals <- syscall.SIGHUP
+
+	select {
+	case settings := <-applied:
+		require.Equal(t, "DEBUG", settings.logLevel)
+	case <-time.After(time.Second):
+		require.FailNow(t, "reload not applied")
+	}
+
+	close(signals)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "watcher not stopped")
+	}
+}
+
+func TestCreateSecretLock(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		key := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+		secretLock, err := createSecretLock(writeConfigFile(t, "master.key", key))
+		require.NoError(t, err)
+		require.NotNil(t, secretLock)
+	})
+
+	t.Run("missing key file", func(t *testing.T) {
+		_, err := createSecretLock(filepath.Join(t.TempDir(), "missing.key"))
+		require.Error(t, err)
+	})
+}
+
+func TestRegisterWithMediators(t *testing.T) {
+	framework, err := aries.New()
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, framework.Close())
+	}()
+
+	ctx, err := framework.Context()
+	require.NoError(t, err)
+
+	require.NoError(t, registerWithMediators(ctx, nil))
+
+	// the failed registrations are skipped
+	require.NoError(t, registerWithMediators(ctx, []string{"unknown-connection"}))
+}
+
+func newTestStartCmd(t *testing.T) *cobra.Command {
+	t.Helper()
+
+	cmd, err := Cmd(&mockServer{})
+	require.NoError(t, err)
+
+	return cmd
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
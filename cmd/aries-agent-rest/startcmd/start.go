@@ -6,11 +6,14 @@ SPDX-License-Identifier: Apache-2.0
 package startcmd
 
 import (
-	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -20,10 +23,18 @@ import (
 	"github.com/rs/cors"
 	"github.com/spf13/cobra"
 
+	"github.com/hyperledger/aries-framework-go/component/storage/bbolt"
 	"github.com/hyperledger/aries-framework-go/component/storage/leveldb"
+	mediatorclient "github.com/hyperledger/aries-framework-go/pkg/client/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics/prometheus"
 	"github.com/hyperledger/aries-framework-go/pkg/controller"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/auth"
+	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
+	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
@@ -31,8 +42,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/instrumented"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 )
 
@@ -49,13 +63,41 @@ const (
 	agentTokenEnvKey        = "ARIESD_API_TOKEN" // nolint:gosec
 	agentTokenFlagShorthand = "t"
 	agentTokenFlagUsage     = "Check for bearer token in the authorization header (optional)." +
+		" The token grants all the routes of the API." +
 		" Alternatively, this can be set with the following environment variable: " + agentTokenEnvKey
 
+	// api read token flag.
+	agentReadTokenFlagName  = "api-read-token"
+	agentReadTokenEnvKey    = "ARIESD_API_READ_TOKEN" // nolint:gosec
+	agentReadTokenFlagUsage = "Bearer token granting the read-only routes of the API (optional)." +
+		" Alternatively, this can be set with the following environment variable: " + agentReadTokenEnvKey
+
+	// api signing token flag.
+	agentSigningTokenFlagName  = "api-signing-token"
+	agentSigningTokenEnvKey    = "ARIESD_API_SIGNING_TOKEN" // nolint:gosec
+	agentSigningTokenFlagUsage = "Bearer token granting the KMS sign, verify and wrap routes of the API (optional)." +
+		" Alternatively, this can be set with the following environment variable: " + agentSigningTokenEnvKey
+
+	// oidc issuer flag.
+	agentOIDCIssuerFlagName  = "oidc-issuer"
+	agentOIDCIssuerEnvKey    = "ARIESD_OIDC_ISSUER"
+	agentOIDCIssuerFlagUsage = "URL of the OpenID Connect issuer of the bearer tokens accepted by the API (optional)." +
+		" The tokens grant the routes of their scope claim: read for the read-only routes, signing for the KMS" +
+		" sign, verify and wrap routes, operational for all." +
+		" Alternatively, this can be set with the following environment variable: " + agentOIDCIssuerEnvKey
+
+	// oidc audience flag.
+	agentOIDCAudienceFlagName  = "oidc-audience"
+	agentOIDCAudienceEnvKey    = "ARIESD_OIDC_AUDIENCE"
+	agentOIDCAudienceFlagUsage = "Audience of the OpenID Connect bearer tokens, required with " +
+		agentOIDCIssuerFlagName + "." +
+		" Alternatively, this can be set with the following environment variable: " + agentOIDCAudienceEnvKey
+
 	databaseTypeFlagName      = "database-type"
 	databaseTypeEnvKey        = "ARIESD_DATABASE_TYPE"
 	databaseTypeFlagShorthand = "q"
 	databaseTypeFlagUsage     = "The type of database to use for everything except key storage. " +
-		"Supported options: mem, leveldb. " +
+		"Supported options: mem, leveldb, bbolt. " +
 		" Alternatively, this can be set with the following environment variable: " + databaseTypeEnvKey
 
 	databasePrefixFlagName      = "database-prefix"
@@ -79,6 +121,30 @@ const (
 		" This flag can be repeated, allowing for multiple listeners." +
 		" Alternatively, this can be set with the following environment variable (in CSV format): " + agentWebhookEnvKey
 
+	// webhook signing key flag.
+	agentWebhookSigningKeyFlagName  = "webhook-signing-key"
+	agentWebhookSigningKeyEnvKey    = "ARIESD_WEBHOOK_SIGNING_KEY"
+	agentWebhookSigningKeyFlagUsage = "Secret key signing the webhook notifications with HMAC-SHA256." +
+		" The signature is sent in the Aries-Signature header. Notifications are not signed if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentWebhookSigningKeyEnvKey
+
+	// webhook max retries flag.
+	agentWebhookMaxRetriesFlagName  = "webhook-max-retries"
+	agentWebhookMaxRetriesEnvKey    = "ARIESD_WEBHOOK_MAX_RETRIES"
+	agentWebhookMaxRetriesFlagUsage = "Number of times a failed webhook notification is retried, with an exponential" +
+		" backoff, before being persisted as a dead letter. Defaults to 0 (no retries nor dead letters) if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentWebhookMaxRetriesEnvKey
+	webhookInitialBackoff = time.Second
+
+	// durable events flag.
+	agentDurableEventsFlagName  = "durable-events"
+	agentDurableEventsEnvKey    = "ARIESD_DURABLE_EVENTS"
+	agentDurableEventsFlagUsage = "Persist the notifications in an event bus, so that the webhooks and the event" +
+		" stream clients get the events published while they, or the agent, were down." +
+		" The failed webhook notifications are retried until they're delivered." +
+		" Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentDurableEventsEnvKey
+
 	// default label flag.
 	agentDefaultLabelFlagName      = "agent-default-label"
 	agentDefaultLabelEnvKey        = "ARIESD_DEFAULT_LABEL"
@@ -123,6 +189,13 @@ const (
 	agentTLSKeyFileFlagUsage     = "tls key file." +
 		" Alternatively, this can be set with the following environment variable: " + agentTLSKeyFileEnvKey
 
+	agentTLSClientCAFileFlagName  = "tls-client-ca-file"
+	agentTLSClientCAFileEnvKey    = "TLS_CLIENT_CA_FILE"
+	agentTLSClientCAFileFlagUsage = "CA certificates file of the tls client certificates (mutual tls) accepted by" +
+		" the API (optional). The clients are granted the routes of the organizational units of their" +
+		" certificate (read, signing or operational), all the routes if none." +
+		" Alternatively, this can be set with the following environment variable: " + agentTLSClientCAFileEnvKey
+
 	// inbound host url flag.
 	agentInboundHostFlagName      = "inbound-host"
 	agentInboundHostEnvKey        = "ARIESD_INBOUND_HOST"
@@ -149,6 +222,39 @@ const (
 		" Possible values [true] [false]. Defaults to false if not set." +
 		" Alternatively, this can be set with the following environment variable: " + agentAutoAcceptEnvKey
 
+	// metrics flag.
+	agentMetricsFlagName  = "metrics"
+	agentMetricsEnvKey    = "ARIESD_METRICS"
+	agentMetricsFlagUsage = "Expose the Prometheus metrics of the agent on the /metrics endpoint." +
+		" Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentMetricsEnvKey
+
+	// metricsPath is the path of the Prometheus metrics endpoint.
+	metricsPath = "/metrics"
+
+	// config file flag.
+	agentConfigFileFlagName  = "config-file"
+	agentConfigFileEnvKey    = "ARIESD_CONFIG_FILE"
+	agentConfigFileFlagUsage = "Path of a YAML or JSON configuration file, whose keys are the names of the other flags." +
+		" The command line flags and the environment variables take precedence over the file." +
+		" On SIGHUP, the file is loaded again and the log-level and webhook-url settings are applied." +
+		" Alternatively, this can be set with the following environment variable: " + agentConfigFileEnvKey
+
+	// kms secret lock key path flag.
+	agentKMSSecretLockKeyPathFlagName  = "kms-secret-lock-key-path"
+	agentKMSSecretLockKeyPathEnvKey    = "ARIESD_KMS_SECRET_LOCK_KEY_PATH"
+	agentKMSSecretLockKeyPathFlagUsage = "Path of the file with the base64url encoded master key protecting" +
+		" the keys of the KMS. The keys are not protected if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentKMSSecretLockKeyPathEnvKey
+
+	// mediator connection flag.
+	agentMediatorConnectionFlagName  = "mediator-connection"
+	agentMediatorConnectionEnvKey    = "ARIESD_MEDIATOR_CONNECTION"
+	agentMediatorConnectionFlagUsage = "ID of the connection to a router the agent registers with at startup," +
+		" unless already registered. This flag can be repeated, allowing for multiple routers." +
+		" Alternatively, this can be set with the following environment variable (in CSV format): " +
+		agentMediatorConnectionEnvKey
+
 	// transport return route option flag.
 	agentTransportReturnRouteFlagName  = "transport-return-route"
 	agentTransportReturnRouteEnvKey    = "ARIESD_TRANSPORT_RETURN_ROUTE"
@@ -161,6 +267,10 @@ const (
 
 	databaseTypeMemOption     = "mem"
 	databaseTypeLevelDBOption = "leveldb"
+	databaseTypeBoltDBOption  = "bbolt"
+
+	// boltDBFileSuffix is appended to the database prefix to name the bbolt database file.
+	boltDBFileSuffix = "aries.db"
 )
 
 var (
@@ -171,12 +281,21 @@ var (
 type agentParameters struct {
 	server                                         server
 	host, defaultLabel, transportReturnRoute       string
-	tlsCertFile, tlsKeyFile                        string
-	token                                          string
+	tlsCertFile, tlsKeyFile, tlsClientCAFile       string
+	token, readToken, signingToken                 string
+	oidcIssuer, oidcAudience                       string
 	webhookURLs, httpResolvers, outboundTransports []string
+	mediatorConnections                            []string
+	kmsSecretLockKeyPath                           string
+	webhookSigningKey                              string
+	webhookMaxRetries                              uint64
 	inboundHostInternals, inboundHostExternals     []string
-	autoAccept                                     bool
+	autoAccept, metrics, durableEvents             bool
+	metricsProvider                                *prometheus.Provider
+	instrumentedStore                              *instrumented.Provider
+	healthChecker                                  *health.Checker
 	msgHandler                                     command.MessageHandler
+	reload                                         func() (*reloadableSettings, error)
 	dbParam                                        *dbParam
 }
 
@@ -194,22 +313,47 @@ var supportedStorageProviders = map[string]func(prefix string) (storage.Provider
 	databaseTypeLevelDBOption: func(path string) (storage.Provider, error) { // nolint:unparam
 		return leveldb.NewProvider(path), nil
 	},
+	databaseTypeBoltDBOption: func(path string) (storage.Provider, error) {
+		return bbolt.NewProvider(path + boltDBFileSuffix)
+	},
 }
 
 type server interface {
-	ListenAndServe(host string, router http.Handler, certFile, keyFile string) error
+	ListenAndServe(host string, router http.Handler, certFile, keyFile, clientCAFile string) error
 }
 
 // HTTPServer represents an actual server implementation.
 type HTTPServer struct{}
 
-// ListenAndServe starts the server using the standard Go HTTP server implementation.
-func (s *HTTPServer) ListenAndServe(host string, router http.Handler, certFile, keyFile string) error {
-	if certFile != "" && keyFile != "" {
-		return http.ListenAndServeTLS(host, certFile, keyFile, router)
+// ListenAndServe starts the server using the standard Go HTTP server implementation. The clients presenting a
+// certificate are verified against the CA certificates of clientCAFile, if set.
+func (s *HTTPServer) ListenAndServe(host string, router http.Handler, certFile, keyFile, clientCAFile string) error {
+	if certFile == "" || keyFile == "" {
+		return http.ListenAndServe(host, router)
+	}
+
+	srv := &http.Server{Addr: host, Handler: router}
+
+	if clientCAFile != "" {
+		caCerts, err := ioutil.ReadFile(filepath.Clean(clientCAFile))
+		if err != nil {
+			return fmt.Errorf("failed to read tls client CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCerts) {
+			return fmt.Errorf("no certificate found in tls client CA file %s", clientCAFile)
+		}
+
+		srv.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ClientCAs:  pool,
+			// the clients without certificate are authenticated with bearer tokens
+			ClientAuth: tls.VerifyClientCertIfGiven,
+		}
 	}
 
-	return http.ListenAndServe(host, router)
+	return srv.ListenAndServeTLS(certFile, keyFile)
 }
 
 // Cmd returns the Cobra start command.
@@ -227,6 +371,17 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen, gocyclo
 		Short: "Start an agent",
 		Long:  `Start an Aries agent controller`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			configFile, err := getUserSetVar(cmd, agentConfigFileFlagName, agentConfigFileEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			if configFile != "" {
+				if err = applyConfigFile(cmd, configFile); err != nil {
+					return err
+				}
+			}
+
 			// log level
 			logLevel, err := getUserSetVar(cmd, agentLogLevelFlagName, agentLogLevelEnvKey, true)
 			if err != nil {
@@ -248,6 +403,26 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen, gocyclo
 				return err
 			}
 
+			readToken, err := getUserSetVar(cmd, agentReadTokenFlagName, agentReadTokenEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			signingToken, err := getUserSetVar(cmd, agentSigningTokenFlagName, agentSigningTokenEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			oidcIssuer, err := getUserSetVar(cmd, agentOIDCIssuerFlagName, agentOIDCIssuerEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			oidcAudience, err := getUserSetVar(cmd, agentOIDCAudienceFlagName, agentOIDCAudienceEnvKey, true)
+			if err != nil {
+				return err
+			}
+
 			inboundHosts, err := getUserSetVars(cmd, agentInboundHostFlagName, agentInboundHostEnvKey, true)
 			if err != nil {
 				return err
@@ -274,11 +449,32 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen, gocyclo
 				return err
 			}
 
+			metricsEnabled, err := getMetricsValue(cmd)
+			if err != nil {
+				return err
+			}
+
 			webhookURLs, err := getUserSetVars(cmd, agentWebhookFlagName, agentWebhookEnvKey, autoAccept)
 			if err != nil {
 				return err
 			}
 
+			webhookSigningKey, err := getUserSetVar(cmd, agentWebhookSigningKeyFlagName,
+				agentWebhookSigningKeyEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			webhookMaxRetries, err := getWebhookMaxRetries(cmd)
+			if err != nil {
+				return err
+			}
+
+			durableEvents, err := getBoolValue(cmd, agentDurableEventsFlagName, agentDurableEventsEnvKey)
+			if err != nil {
+				return err
+			}
+
 			httpResolvers, err := getUserSetVars(cmd, agentHTTPResolverFlagName, agentHTTPResolverEnvKey, true)
 			if err != nil {
 				return err
@@ -306,21 +502,55 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen, gocyclo
 				return err
 			}
 
+			tlsClientCAFile, err := getUserSetVar(cmd, agentTLSClientCAFileFlagName, agentTLSClientCAFileEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			kmsSecretLockKeyPath, err := getUserSetVar(cmd, agentKMSSecretLockKeyPathFlagName,
+				agentKMSSecretLockKeyPathEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			mediatorConnections, err := getUserSetVars(cmd, agentMediatorConnectionFlagName,
+				agentMediatorConnectionEnvKey, true)
+			if err != nil {
+				return err
+			}
+
 			parameters := &agentParameters{
 				server:               server,
 				host:                 host,
 				token:                token,
+				readToken:            readToken,
+				signingToken:         signingToken,
+				oidcIssuer:           oidcIssuer,
+				oidcAudience:         oidcAudience,
 				inboundHostInternals: inboundHosts,
 				inboundHostExternals: inboundHostExternals,
 				dbParam:              dbParam,
 				defaultLabel:         defaultLabel,
 				webhookURLs:          webhookURLs,
+				webhookSigningKey:    webhookSigningKey,
+				webhookMaxRetries:    webhookMaxRetries,
+				durableEvents:        durableEvents,
 				httpResolvers:        httpResolvers,
 				outboundTransports:   outboundTransports,
 				autoAccept:           autoAccept,
+				metrics:              metricsEnabled,
 				transportReturnRoute: transportReturnRoute,
 				tlsCertFile:          tlsCertFile,
 				tlsKeyFile:           tlsKeyFile,
+				tlsClientCAFile:      tlsClientCAFile,
+				kmsSecretLockKeyPath: kmsSecretLockKeyPath,
+				mediatorConnections:  mediatorConnections,
+			}
+
+			if configFile != "" {
+				parameters.reload = func() (*reloadableSettings, error) {
+					return reloadConfigFile(cmd, configFile, autoAccept)
+				}
 			}
 
 			return startAgent(parameters)
@@ -362,6 +592,24 @@ func getDBParam(cmd *cobra.Command) (*dbParam, error) {
 	return dbParam, nil
 }
 
+func getWebhookMaxRetries(cmd *cobra.Command) (uint64, error) {
+	v, err := getUserSetVar(cmd, agentWebhookMaxRetriesFlagName, agentWebhookMaxRetriesEnvKey, true)
+	if err != nil {
+		return 0, err
+	}
+
+	if v == "" {
+		return 0, nil
+	}
+
+	retries, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse webhook max retries %s: %w", v, err)
+	}
+
+	return retries, nil
+}
+
 func getAutoAcceptValue(cmd *cobra.Command) (bool, error) {
 	v, err := getUserSetVar(cmd, agentAutoAcceptFlagName, agentAutoAcceptEnvKey, true)
 	if err != nil {
@@ -375,6 +623,32 @@ func getAutoAcceptValue(cmd *cobra.Command) (bool, error) {
 	return strconv.ParseBool(v)
 }
 
+func getMetricsValue(cmd *cobra.Command) (bool, error) {
+	v, err := getUserSetVar(cmd, agentMetricsFlagName, agentMetricsEnvKey, true)
+	if err != nil {
+		return false, err
+	}
+
+	if v == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(v)
+}
+
+func getBoolValue(cmd *cobra.Command, flagName, envKey string) (bool, error) {
+	v, err := getUserSetVar(cmd, flagName, envKey, true)
+	if err != nil {
+		return false, err
+	}
+
+	if v == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(v)
+}
+
 func createFlags(startCmd *cobra.Command) {
 	// agent host flag
 	startCmd.Flags().StringP(agentHostFlagName, agentHostFlagShorthand, "", agentHostFlagUsage)
@@ -382,6 +656,18 @@ func createFlags(startCmd *cobra.Command) {
 	// agent token flag
 	startCmd.Flags().StringP(agentTokenFlagName, agentTokenFlagShorthand, "", agentTokenFlagUsage)
 
+	// agent read token flag
+	startCmd.Flags().StringP(agentReadTokenFlagName, "", "", agentReadTokenFlagUsage)
+
+	// agent signing token flag
+	startCmd.Flags().StringP(agentSigningTokenFlagName, "", "", agentSigningTokenFlagUsage)
+
+	// oidc issuer flag
+	startCmd.Flags().StringP(agentOIDCIssuerFlagName, "", "", agentOIDCIssuerFlagUsage)
+
+	// oidc audience flag
+	startCmd.Flags().StringP(agentOIDCAudienceFlagName, "", "", agentOIDCAudienceFlagUsage)
+
 	// inbound host flag
 	startCmd.Flags().StringSliceP(agentInboundHostFlagName, agentInboundHostFlagShorthand, []string{},
 		agentInboundHostFlagUsage)
@@ -399,6 +685,15 @@ func createFlags(startCmd *cobra.Command) {
 	// webhook url flag
 	startCmd.Flags().StringSliceP(agentWebhookFlagName, agentWebhookFlagShorthand, []string{}, agentWebhookFlagUsage)
 
+	// webhook signing key flag
+	startCmd.Flags().StringP(agentWebhookSigningKeyFlagName, "", "", agentWebhookSigningKeyFlagUsage)
+
+	// webhook max retries flag
+	startCmd.Flags().StringP(agentWebhookMaxRetriesFlagName, "", "", agentWebhookMaxRetriesFlagUsage)
+
+	// durable events flag
+	startCmd.Flags().StringP(agentDurableEventsFlagName, "", "", agentDurableEventsFlagUsage)
+
 	// log level
 	startCmd.Flags().StringP(agentLogLevelFlagName, "", "", agentLogLevelFlagUsage)
 
@@ -417,6 +712,18 @@ func createFlags(startCmd *cobra.Command) {
 	// auto accept flag
 	startCmd.Flags().StringP(agentAutoAcceptFlagName, "", "", agentAutoAcceptFlagUsage)
 
+	// metrics flag
+	startCmd.Flags().StringP(agentMetricsFlagName, "", "", agentMetricsFlagUsage)
+
+	// config file flag
+	startCmd.Flags().StringP(agentConfigFileFlagName, "", "", agentConfigFileFlagUsage)
+
+	// kms secret lock key path flag
+	startCmd.Flags().StringP(agentKMSSecretLockKeyPathFlagName, "", "", agentKMSSecretLockKeyPathFlagUsage)
+
+	// mediator connection flag
+	startCmd.Flags().StringSliceP(agentMediatorConnectionFlagName, "", []string{}, agentMediatorConnectionFlagUsage)
+
 	// transport return route option flag
 	startCmd.Flags().StringP(agentTransportReturnRouteFlagName, "", "", agentTransportReturnRouteFlagUsage)
 
@@ -428,6 +735,9 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringP(agentTLSKeyFileFlagName,
 		agentTLSKeyFileFlagShorthand, "", agentTLSKeyFileFlagUsage)
 
+	// tls client CA file
+	startCmd.Flags().StringP(agentTLSClientCAFileFlagName, "", "", agentTLSClientCAFileFlagUsage)
+
 	// db timeout
 	startCmd.Flags().StringP(databaseTimeoutFlagName, "", "", databaseTimeoutFlagUsage)
 }
@@ -444,6 +754,10 @@ func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool)
 
 	value, isSet := os.LookupEnv(envKey)
 
+	if !isSet && isConfigured(cmd, flagName) {
+		return cmd.Flags().GetString(flagName)
+	}
+
 	if isOptional || isSet {
 		return value, nil
 	}
@@ -464,6 +778,10 @@ func getUserSetVars(cmd *cobra.Command, flagName, envKey string, isOptional bool
 
 	value, isSet := os.LookupEnv(envKey)
 
+	if !isSet && isConfigured(cmd, flagName) {
+		return cmd.Flags().GetStringSlice(flagName)
+	}
+
 	var values []string
 
 	if isSet {
@@ -591,30 +909,77 @@ func setLogLevel(logLevel string) error {
 	return nil
 }
 
-func validateAuthorizationBearerToken(w http.ResponseWriter, r *http.Request, token string) bool {
-	actHdr := r.Header.Get("Authorization")
-	expHdr := "Bearer " + token
+// nolint:gochecknoglobals
+var readOnlyPOSTRoutes = []string{
+	verifiablerest.VerifyCredentialPath,
+	verifiablerest.VerifyPresentationPath,
+	verifiablerest.ValidateCredentialPath,
+	verifiablerest.EvaluatePresentationDefinitionPath,
+}
+
+// signingRoutes are the POST routes using the agent keys on behalf of companion services.
+// nolint:gochecknoglobals
+var signingRoutes = []string{
+	kmsrest.SignPath,
+	kmsrest.VerifyPath,
+	kmsrest.WrapKeyPath,
+}
+
+// getAuthenticators returns the authenticators of the API requests, none if the API is not secured.
+func getAuthenticators(parameters *agentParameters) ([]auth.Authenticator, error) {
+	var authenticators []auth.Authenticator
+
+	tokens := make(map[string][]auth.Scope)
+
+	if parameters.token != "" {
+		tokens[parameters.token] = []auth.Scope{auth.OperationalScope}
+	}
+
+	if parameters.readToken != "" {
+		tokens[parameters.readToken] = []auth.Scope{auth.ReadScope}
+	}
+
+	if parameters.signingToken != "" {
+		tokens[parameters.signingToken] = []auth.Scope{auth.SigningScope}
+	}
+
+	if len(tokens) > 0 {
+		authenticators = append(authenticators, auth.NewTokenAuthenticator(tokens))
+	}
+
+	if parameters.tlsClientCAFile != "" {
+		if parameters.tlsCertFile == "" || parameters.tlsKeyFile == "" {
+			return nil, fmt.Errorf("%s requires %s and %s", agentTLSClientCAFileFlagName,
+				agentTLSCertFileFlagName, agentTLSKeyFileFlagName)
+		}
+
+		authenticators = append(authenticators, auth.NewMTLSAuthenticator(auth.OperationalScope))
+	}
 
-	if subtle.ConstantTimeCompare([]byte(actHdr), []byte(expHdr)) != 1 {
-		w.WriteHeader(http.StatusUnauthorized)
-		w.Write([]byte("Unauthorised.\n")) // nolint:gosec,errcheck
+	if parameters.oidcIssuer != "" {
+		if parameters.oidcAudience == "" {
+			return nil, fmt.Errorf("%s requires %s", agentOIDCIssuerFlagName, agentOIDCAudienceFlagName)
+		}
 
-		return false
+		authenticators = append(authenticators, auth.NewOIDCAuthenticator(parameters.oidcIssuer,
+			parameters.oidcAudience))
 	}
 
-	return true
+	return authenticators, nil
 }
 
-func authorizationMiddleware(token string) mux.MiddlewareFunc {
-	middleware := func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if validateAuthorizationBearerToken(w, r, token) {
-				next.ServeHTTP(w, r)
-			}
-		})
+func authMiddleware(authenticators []auth.Authenticator) mux.MiddlewareFunc {
+	var opts []auth.Opt
+
+	for _, path := range readOnlyPOSTRoutes {
+		opts = append(opts, auth.WithRouteScope(http.MethodPost, path, auth.ReadScope))
 	}
 
-	return middleware
+	for _, path := range signingRoutes {
+		opts = append(opts, auth.WithRouteScope(http.MethodPost, path, auth.SigningScope))
+	}
+
+	return auth.Middleware(authenticators, opts...)
 }
 
 func startAgent(parameters *agentParameters) error {
@@ -622,6 +987,12 @@ func startAgent(parameters *agentParameters) error {
 		return errMissingHost
 	}
 
+	authenticators, err := getAuthenticators(parameters)
+	if err != nil {
+		return fmt.Errorf("failed to start aries agent rest on port [%s], failed to configure authentication :  %w",
+			parameters.host, err)
+	}
+
 	// set message handler
 	parameters.msgHandler = msghandler.NewRegistrar()
 
@@ -630,25 +1001,61 @@ func startAgent(parameters *agentParameters) error {
 		return err
 	}
 
+	webhookOpts, err := getWebhookOpts(ctx, parameters)
+	if err != nil {
+		return fmt.Errorf("failed to start aries agent rest on port [%s], failed to configure webhooks :  %w",
+			parameters.host, err)
+	}
+
+	notifier, err := createNotifier(ctx, parameters, webhookOpts)
+	if err != nil {
+		return fmt.Errorf("failed to start aries agent rest on port [%s], failed to create notifier :  %w",
+			parameters.host, err)
+	}
+
+	defer notifier.Close()
+
 	// get all HTTP REST API handlers available for controller API
-	handlers, err := controller.GetRESTHandlers(ctx, controller.WithWebhookURLs(parameters.webhookURLs...),
+	handlers, err := controller.GetRESTHandlers(ctx, controller.WithNotifier(notifier),
 		controller.WithDefaultLabel(parameters.defaultLabel), controller.WithAutoAccept(parameters.autoAccept),
-		controller.WithMessageHandler(parameters.msgHandler))
+		controller.WithMessageHandler(parameters.msgHandler), controller.WithHealthChecker(parameters.healthChecker))
 	if err != nil {
 		return fmt.Errorf("failed to start aries agent rest on port [%s], failed to get rest service api :  %w",
 			parameters.host, err)
 	}
 
+	if err = registerWithMediators(ctx, parameters.mediatorConnections); err != nil {
+		return fmt.Errorf("failed to start aries agent rest on port [%s], failed to register with mediators :  %w",
+			parameters.host, err)
+	}
+
+	if parameters.reload != nil {
+		signals, stop := notifyReload()
+		defer stop()
+
+		go watchReload(signals, parameters.reload, func(settings *reloadableSettings) {
+			if err := setLogLevel(settings.logLevel); err != nil {
+				logger.Errorf("failed to reload log level : %s", err)
+			}
+
+			notifier.SetWebhookURLs(settings.webhookURLs)
+		})
+	}
+
 	router := mux.NewRouter()
 
-	if parameters.token != "" {
-		router.Use(authorizationMiddleware(parameters.token))
+	if len(authenticators) > 0 {
+		router.Use(authMiddleware(authenticators))
 	}
 
 	for _, handler := range handlers {
 		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
 	}
 
+	if parameters.metrics {
+		router.HandleFunc(metricsPath, metricsHandler(parameters)).Methods(http.MethodGet)
+	}
+
 	logger.Infof("Starting aries agent rest on host [%s]", parameters.host)
 	// start server on given port and serve using given handlers
 	handler := cors.New(
@@ -658,7 +1065,8 @@ func startAgent(parameters *agentParameters) error {
 		},
 	).Handler(router)
 
-	err = parameters.server.ListenAndServe(parameters.host, handler, parameters.tlsCertFile, parameters.tlsKeyFile)
+	err = parameters.server.ListenAndServe(parameters.host, handler, parameters.tlsCertFile, parameters.tlsKeyFile,
+		parameters.tlsClientCAFile)
 	if err != nil {
 		return fmt.Errorf("failed to start aries agent rest on port [%s], cause:  %w", parameters.host, err)
 	}
@@ -666,6 +1074,99 @@ func startAgent(parameters *agentParameters) error {
 	return nil
 }
 
+// metricsHandler refreshes the store sizes and writes the metrics in the Prometheus text format.
+func metricsHandler(parameters *agentParameters) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := parameters.instrumentedStore.RecordStoreSizes(); err != nil {
+			logger.Warnf("failed to record store sizes : %s", err)
+		}
+
+		parameters.metricsProvider.ServeHTTP(w, r)
+	}
+}
+
+// registerWithMediators registers the agent with the routers of the given connections it is not registered with yet.
+// The registrations failing, e.g. while a router is unavailable, are logged and skipped.
+func registerWithMediators(ctx *context.Provider, connectionIDs []string) error {
+	if len(connectionIDs) == 0 {
+		return nil
+	}
+
+	client, err := mediatorclient.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	registered, err := client.GetConnections()
+	if err != nil {
+		return err
+	}
+
+	for _, connectionID := range connectionIDs {
+		if contains(registered, connectionID) {
+			continue
+		}
+
+		if err := client.Register(connectionID); err != nil {
+			logger.Warnf("failed to register with the mediator of connection %s : %s", connectionID, err)
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createNotifier creates the notifier of the agent, appending the notifications to an event bus persisted in the
+// storage of the agent if durable events are enabled.
+func createNotifier(ctx *context.Provider, parameters *agentParameters,
+	webhookOpts []webnotifier.HTTPNotifierOpt) (*webnotifier.WebNotifier, error) {
+	if !parameters.durableEvents {
+		return webnotifier.New(webnotifier.WSPath, webnotifier.EventsPath, parameters.webhookURLs, webhookOpts...), nil
+	}
+
+	store, err := ctx.StorageProvider().OpenStore(webnotifier.EventStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event bus store: %w", err)
+	}
+
+	bus, err := webnotifier.NewEventBus(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event bus: %w", err)
+	}
+
+	return webnotifier.NewDurable(webnotifier.WSPath, webnotifier.EventsPath, bus, parameters.webhookURLs,
+		webhookOpts...)
+}
+
+func getWebhookOpts(ctx *context.Provider, parameters *agentParameters) ([]webnotifier.HTTPNotifierOpt, error) {
+	opts := []webnotifier.HTTPNotifierOpt{webnotifier.WithClock(ctx.Clock())}
+
+	if parameters.webhookSigningKey != "" {
+		opts = append(opts, webnotifier.WithSigningKey([]byte(parameters.webhookSigningKey)))
+	}
+
+	if parameters.webhookMaxRetries > 0 {
+		deadLetters, err := ctx.StorageProvider().OpenStore(webnotifier.DeadLetterStoreName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open webhook dead-letter store: %w", err)
+		}
+
+		opts = append(opts, webnotifier.WithRetries(parameters.webhookMaxRetries, webhookInitialBackoff),
+			webnotifier.WithDeadLetterStore(deadLetters))
+	}
+
+	return opts, nil
+}
+
 func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
 	var opts []aries.Option
 
@@ -674,8 +1175,26 @@ func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
 		return nil, err
 	}
 
+	if parameters.metrics {
+		parameters.metricsProvider = prometheus.NewProvider()
+		parameters.instrumentedStore = instrumented.NewProvider(storePro, parameters.metricsProvider)
+		storePro = parameters.instrumentedStore
+
+		opts = append(opts, aries.WithMetricsProvider(parameters.metricsProvider))
+	}
+
 	opts = append(opts, aries.WithStoreProvider(storePro))
 
+	if parameters.kmsSecretLockKeyPath != "" {
+		secretLock, err := createSecretLock(parameters.kmsSecretLockKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to create secret lock : %w",
+				parameters.host, err)
+		}
+
+		opts = append(opts, aries.WithSecretLock(secretLock))
+	}
+
 	if parameters.transportReturnRoute != "" {
 		opts = append(opts, aries.WithTransportReturnRoute(parameters.transportReturnRoute))
 	}
@@ -718,9 +1237,20 @@ func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
 			parameters.host, err)
 	}
 
+	parameters.healthChecker = framework.HealthChecker()
+
 	return ctx, nil
 }
 
+func createSecretLock(keyPath string) (secretlock.Service, error) {
+	masterKeyReader, err := local.MasterKeyFromPath(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return local.NewService(masterKeyReader, nil)
+}
+
 func createStoreProviders(parameters *agentParameters) (storage.Provider, error) {
 	provider, supported := supportedStorageProviders[parameters.dbParam.dbType]
 	if !supported {
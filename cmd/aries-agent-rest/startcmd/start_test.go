@@ -22,13 +22,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
 )
 
 type mockServer struct{}
 
 const agentUnexpectedExitErrMsg = "agent server exited unexpectedly"
 
-func (s *mockServer) ListenAndServe(host string, handler http.Handler, certFile, keyFile string) error {
+func (s *mockServer) ListenAndServe(host string, handler http.Handler, certFile, keyFile, clientCAFile string) error {
 	return nil
 }
 
@@ -478,6 +479,100 @@ func TestStartCmdValidArgs(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestStartCmdWithWebhookDelivery(t *testing.T) {
+	t.Run("start with webhook signing key and retries - success", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		args := []string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentInboundHostFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName,
+			databaseTypeMemOption,
+			"--" + agentWebhookFlagName,
+			"http://localhost:8080",
+			"--" + agentWebhookSigningKeyFlagName,
+			"secret",
+			"--" + agentWebhookMaxRetriesFlagName,
+			"3",
+		}
+		startCmd.SetArgs(args)
+
+		err = startCmd.Execute()
+		require.NoError(t, err)
+	})
+
+	t.Run("start with webhook max retries - invalid", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		args := []string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentInboundHostFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName,
+			databaseTypeMemOption,
+			"--" + agentWebhookFlagName,
+			"http://localhost:8080",
+			"--" + agentWebhookMaxRetriesFlagName,
+			"-1",
+		}
+		startCmd.SetArgs(args)
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse webhook max retries")
+	})
+
+	t.Run("start with durable events", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		args := []string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentInboundHostFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName,
+			databaseTypeMemOption,
+			"--" + agentWebhookFlagName,
+			"http://localhost:8080",
+			"--" + agentDurableEventsFlagName,
+			"true",
+		}
+		startCmd.SetArgs(args)
+
+		err = startCmd.Execute()
+		require.NoError(t, err)
+	})
+
+	t.Run("start with durable events - invalid", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		args := []string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentInboundHostFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName,
+			databaseTypeMemOption,
+			"--" + agentWebhookFlagName,
+			"http://localhost:8080",
+			"--" + agentDurableEventsFlagName,
+			"invalid",
+		}
+		startCmd.SetArgs(args)
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid syntax")
+	})
+}
+
 func TestStartCmdValidArgsEnvVar(t *testing.T) {
 	startCmd, err := Cmd(&mockServer{})
 	require.NoError(t, err)
@@ -734,6 +829,215 @@ func TestStartAriesWithAuthorization(t *testing.T) {
 	})
 }
 
+func TestStartAriesWithReadToken(t *testing.T) {
+	const readToken = "ABCD"
+
+	testHostURL := randomURL()
+	testInboundHostURL := randomURL()
+
+	go func() {
+		parameters := &agentParameters{
+			server:               &HTTPServer{},
+			host:                 testHostURL,
+			readToken:            readToken,
+			inboundHostInternals: []string{httpProtocol + "@" + testInboundHostURL},
+			dbParam:              &dbParam{dbType: databaseTypeMemOption},
+			defaultLabel:         "x",
+		}
+
+		err := startAgent(parameters)
+		require.FailNow(t, agentUnexpectedExitErrMsg+": "+err.Error())
+	}()
+
+	waitForServerToStart(t, testHostURL, testInboundHostURL)
+
+	newreq := func(method, url string) *http.Request {
+		r, err := http.NewRequest(method, url, strings.NewReader("{}"))
+		require.NoError(t, err)
+
+		r.Header.Add("Authorization", "Bearer "+readToken)
+
+		return r
+	}
+
+	runRequestTests(t, []requestTestParams{
+		{
+			name:               "read route",
+			r:                  newreq(http.MethodGet, fmt.Sprintf("http://%s/connections", testHostURL)),
+			expectedStatus:     http.StatusOK,
+			expectResponseData: true,
+		},
+		{
+			name:           "operational route",
+			r:              newreq(http.MethodPost, fmt.Sprintf("http://%s/connections/create-invitation", testHostURL)),
+			expectedStatus: http.StatusForbidden,
+		},
+	})
+}
+
+func TestStartAriesWithSigningToken(t *testing.T) {
+	const signingToken = "EFGH"
+
+	testHostURL := randomURL()
+	testInboundHostURL := randomURL()
+
+	go func() {
+		parameters := &agentParameters{
+			server:               &HTTPServer{},
+			host:                 testHostURL,
+			signingToken:         signingToken,
+			inboundHostInternals: []string{httpProtocol + "@" + testInboundHostURL},
+			dbParam:              &dbParam{dbType: databaseTypeMemOption},
+			defaultLabel:         "x",
+		}
+
+		err := startAgent(parameters)
+		require.FailNow(t, agentUnexpectedExitErrMsg+": "+err.Error())
+	}()
+
+	waitForServerToStart(t, testHostURL, testInboundHostURL)
+
+	newreq := func(method, url string) *http.Request {
+		r, err := http.NewRequest(method, url, strings.NewReader("{}"))
+		require.NoError(t, err)
+
+		r.Header.Add("Authorization", "Bearer "+signingToken)
+
+		return r
+	}
+
+	runRequestTests(t, []requestTestParams{
+		{
+			name:           "signing route",
+			r:              newreq(http.MethodPost, fmt.Sprintf("http://%s%s", testHostURL, kmsrest.SignPath)),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "read route",
+			r:              newreq(http.MethodGet, fmt.Sprintf("http://%s/connections", testHostURL)),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "operational route",
+			r:              newreq(http.MethodPost, fmt.Sprintf("http://%s%s", testHostURL, kmsrest.CreateKeySetPath)),
+			expectedStatus: http.StatusForbidden,
+		},
+	})
+}
+
+func TestStartAriesWithMetrics(t *testing.T) {
+	testHostURL := randomURL()
+	testInboundHostURL := randomURL()
+
+	go func() {
+		parameters := &agentParameters{
+			server:               &HTTPServer{},
+			host:                 testHostURL,
+			metrics:              true,
+			inboundHostInternals: []string{httpProtocol + "@" + testInboundHostURL},
+			dbParam:              &dbParam{dbType: databaseTypeMemOption},
+			defaultLabel:         "x",
+		}
+
+		err := startAgent(parameters)
+		require.FailNow(t, agentUnexpectedExitErrMsg+": "+err.Error())
+	}()
+
+	waitForServerToStart(t, testHostURL, testInboundHostURL)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s%s", testHostURL, metricsPath)) // nolint: noctx
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "aries_storage_records")
+}
+
+func TestStartCmdWithInvalidMetrics(t *testing.T) {
+	startCmd, err := Cmd(&mockServer{})
+	require.NoError(t, err)
+
+	startCmd.SetArgs([]string{
+		"--" + agentHostFlagName,
+		randomURL(),
+		"--" + agentInboundHostFlagName,
+		httpProtocol + "@" + randomURL(),
+		"--" + databaseTypeFlagName,
+		databaseTypeMemOption,
+		"--" + agentMetricsFlagName,
+		"invalid",
+	})
+
+	err = startCmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid syntax")
+}
+
+func TestGetAuthenticators(t *testing.T) {
+	t.Run("no authentication", func(t *testing.T) {
+		authenticators, err := getAuthenticators(&agentParameters{})
+		require.NoError(t, err)
+		require.Empty(t, authenticators)
+	})
+
+	t.Run("all authentications", func(t *testing.T) {
+		authenticators, err := getAuthenticators(&agentParameters{
+			token:           "ABCD",
+			readToken:       "BCDE",
+			tlsCertFile:     "cert.pem",
+			tlsKeyFile:      "key.pem",
+			tlsClientCAFile: "ca.pem",
+			oidcIssuer:      "https://issuer.example.com",
+			oidcAudience:    "aries",
+		})
+		require.NoError(t, err)
+		require.Len(t, authenticators, 3)
+	})
+
+	t.Run("oidc issuer without audience", func(t *testing.T) {
+		_, err := getAuthenticators(&agentParameters{oidcIssuer: "https://issuer.example.com"})
+		require.EqualError(t, err, "oidc-issuer requires oidc-audience")
+	})
+
+	t.Run("tls client CA without tls certificate", func(t *testing.T) {
+		_, err := getAuthenticators(&agentParameters{tlsClientCAFile: "ca.pem"})
+		require.EqualError(t, err, "tls-client-ca-file requires tls-cert-file and tls-key-file")
+	})
+
+	t.Run("start agent with invalid authentication", func(t *testing.T) {
+		err := startAgent(&agentParameters{
+			server:     &mockServer{},
+			host:       randomURL(),
+			dbParam:    &dbParam{dbType: databaseTypeMemOption},
+			oidcIssuer: "https://issuer.example.com",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to configure authentication")
+	})
+}
+
+func TestHTTPServerWithInvalidClientCAFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "ca")
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, os.Remove(file.Name())) }()
+
+	s := &HTTPServer{}
+
+	err = s.ListenAndServe(randomURL(), http.NewServeMux(), "cert.pem", "key.pem", "invalid")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read tls client CA file")
+
+	err = s.ListenAndServe(randomURL(), http.NewServeMux(), "cert.pem", "key.pem", file.Name())
+	require.EqualError(t, err, "no certificate found in tls client CA file "+file.Name())
+}
+
 func TestStoreProvider(t *testing.T) {
 	t.Run("test invalid database type", func(t *testing.T) {
 		_, err := createAriesAgent(&agentParameters{dbParam: &dbParam{dbType: "data1"}})
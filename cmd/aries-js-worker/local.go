@@ -0,0 +1,171 @@
+// +build js,wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/jsindexeddb"
+	cmdctrl "github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+)
+
+const (
+	ariesStartLocalFn = "StartLocal"
+	localKMSKeyURI    = "local-lock://"
+)
+
+// localStartOpts contains opts for starting the local (agent-less) commands.
+type localStartOpts struct {
+	HTTPResolvers []string `json:"http-resolver-url"`
+	LogLevel      string   `json:"log-level"`
+	DBNamespace   string   `json:"db-namespace"`
+}
+
+// addLocalStartHandler registers the function starting the verifiable, kms and vcwallet commands without the full
+// agent, for the browser apps parsing and verifying credentials, evaluating presentation definitions, creating
+// presentations with the key material of the in-browser KMS and holding credentials in a wallet.
+func addLocalStartHandler(pkgMap map[string]map[string]func(*command) *result) {
+	pkgMap[ariesCommandPkg][ariesStartLocalFn] = func(c *command) *result {
+		opts := &localStartOpts{}
+		if err := decodePayload(c.Payload, opts); err != nil {
+			return newErrResult(c.ID, err.Error())
+		}
+
+		if err := setLogLevel(opts.LogLevel); err != nil {
+			return newErrResult(c.ID, err.Error())
+		}
+
+		ctx, err := localContext(opts)
+		if err != nil {
+			return newErrResult(c.ID, err.Error())
+		}
+
+		verifiableCmd, err := verifiable.New(ctx)
+		if err != nil {
+			_ = ctx.StorageProvider().Close()
+
+			return newErrResult(c.ID, fmt.Sprintf("failed to create verifiable command : %s", err))
+		}
+
+		kmsCmd, err := kms.New(ctx)
+		if err != nil {
+			_ = ctx.StorageProvider().Close()
+
+			return newErrResult(c.ID, fmt.Sprintf("failed to create kms command : %s", err))
+		}
+
+		var commands []cmdctrl.Handler
+
+		commands = append(commands, verifiableCmd.GetHandlers()...)
+		commands = append(commands, kmsCmd.GetHandlers()...)
+		commands = append(commands, vcwallet.New(ctx).GetHandlers()...)
+
+		// add command handlers
+		addCommandHandlers(commands, pkgMap)
+
+		// add stop handler
+		addStopAriesHandler(ctx.StorageProvider(), pkgMap)
+
+		return &result{
+			ID:      c.ID,
+			Payload: map[string]interface{}{"message": "aries local commands started successfully"},
+		}
+	}
+}
+
+// localContext returns the context of the local commands: the in-browser storage, KMS and crypto, and the key, peer
+// and http binding VDRs.
+func localContext(opts *localStartOpts) (*context.Provider, error) {
+	store, err := jsindexeddb.NewProvider(opts.DBNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	kp := &kmsProvider{storageProvider: store, secretLock: &noop.NoLock{}}
+
+	kp.km, err = localkms.New(localKMSKeyURI, kp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local kms : %w", err)
+	}
+
+	cr, err := tinkcrypto.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local crypto : %w", err)
+	}
+
+	vdrOpts, err := localVDROpts(store, opts.HTTPResolvers)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := context.New(
+		context.WithStorageProvider(store),
+		context.WithSecretLock(kp.secretLock),
+		context.WithKMS(kp.km),
+		context.WithCrypto(cr),
+		context.WithVDRegistry(vdr.New(kp, vdrOpts...)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local context : %w", err)
+	}
+
+	return ctx, nil
+}
+
+// kmsProvider provides the store and the secret lock of the local KMS, and the KMS to the VDR registry.
+type kmsProvider struct {
+	storageProvider storage.Provider
+	secretLock      secretlock.Service
+	km              kmsapi.KeyManager
+}
+
+func (p *kmsProvider) StorageProvider() storage.Provider {
+	return p.storageProvider
+}
+
+func (p *kmsProvider) SecretLock() secretlock.Service {
+	return p.secretLock
+}
+
+func (p *kmsProvider) KMS() kmsapi.KeyManager {
+	return p.km
+}
+
+func localVDROpts(store *jsindexeddb.Provider, httpResolvers []string) ([]vdr.Option, error) {
+	var opts []vdr.Option
+
+	resolverOpts, err := getResolverVDRs(httpResolvers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare http resolver opts : %w", err)
+	}
+
+	for _, v := range resolverOpts {
+		opts = append(opts, vdr.WithVDR(v))
+	}
+
+	p, err := peer.New(store)
+	if err != nil {
+		return nil, fmt.Errorf("create new vdr peer failed: %w", err)
+	}
+
+	return append(opts, vdr.WithVDR(p), vdr.WithVDR(key.New())), nil
+}
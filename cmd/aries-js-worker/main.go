@@ -28,6 +28,7 @@ import (
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/ws"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 )
 
@@ -225,12 +226,16 @@ func isStartCommand(c *command) bool {
 	return c.Pkg == ariesCommandPkg && c.Fn == ariesStartFn
 }
 
+func isStartLocalCommand(c *command) bool {
+	return c.Pkg == ariesCommandPkg && c.Fn == ariesStartLocalFn
+}
+
 func isStopCommand(c *command) bool {
 	return c.Pkg == ariesCommandPkg && c.Fn == ariesStopFn
 }
 
 func handlerNotFoundErr(c *command) *result {
-	if isStartCommand(c) {
+	if isStartCommand(c) || isStartLocalCommand(c) {
 		return newErrResult(c.ID, "Aries agent already started")
 	} else if isStopCommand(c) {
 		return newErrResult(c.ID, "Aries agent not running")
@@ -289,6 +294,8 @@ func addAriesHandlers(pkgMap map[string]map[string]func(*command) *result) {
 	}
 
 	pkgMap[ariesCommandPkg] = fnMap
+
+	addLocalStartHandler(pkgMap)
 }
 
 func addCommandHandlers(commands []cmdctrl.Handler, pkgMap map[string]map[string]func(*command) *result) {
@@ -303,7 +310,12 @@ func addCommandHandlers(commands []cmdctrl.Handler, pkgMap map[string]map[string
 	}
 }
 
-func addStopAriesHandler(a *aries.Aries, pkgMap map[string]map[string]func(*command) *result) {
+// closer releases the resources of the started commands.
+type closer interface {
+	Close() error
+}
+
+func addStopAriesHandler(a closer, pkgMap map[string]map[string]func(*command) *result) {
 	fnMap := make(map[string]func(*command) *result)
 	fnMap[ariesStopFn] = func(c *command) *result {
 		err := a.Close()
@@ -338,20 +350,23 @@ func newErrResult(id, msg string) *result {
 func startOpts(payload map[string]interface{}) (*ariesStartOpts, error) {
 	opts := &ariesStartOpts{}
 
+	if err := decodePayload(payload, opts); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+func decodePayload(payload map[string]interface{}, opts interface{}) error {
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		TagName: "json",
 		Result:  opts,
 	})
 	if err != nil {
-		return nil, err
-	}
-
-	err = decoder.Decode(payload)
-	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return opts, nil
+	return decoder.Decode(payload)
 }
 
 func ariesOpts(opts *ariesStartOpts) ([]aries.Option, error) {
@@ -400,8 +415,23 @@ func ariesOpts(opts *ariesStartOpts) ([]aries.Option, error) {
 }
 
 func getResolverOpts(httpResolvers []string) ([]aries.Option, error) {
+	vdrs, err := getResolverVDRs(httpResolvers)
+	if err != nil {
+		return nil, err
+	}
+
 	var opts []aries.Option
 
+	for _, v := range vdrs {
+		opts = append(opts, aries.WithVDR(v))
+	}
+
+	return opts, nil
+}
+
+func getResolverVDRs(httpResolvers []string) ([]vdrapi.VDR, error) {
+	var vdrs []vdrapi.VDR
+
 	const numPartsResolverOption = 2
 
 	if len(httpResolvers) > 0 {
@@ -417,11 +447,11 @@ func getResolverOpts(httpResolvers []string) ([]aries.Option, error) {
 				return nil, fmt.Errorf("failed to setup http resolver :  %w", err)
 			}
 
-			opts = append(opts, aries.WithVDR(httpVDR))
+			vdrs = append(vdrs, httpVDR)
 		}
 	}
 
-	return opts, nil
+	return vdrs, nil
 }
 
 func setLogLevel(logLevel string) error {
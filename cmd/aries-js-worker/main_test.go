@@ -83,6 +83,26 @@ func TestErrorCmd(t *testing.T) {
 	}
 }
 
+func TestStartLocalCmdWithInvalidLogLevel(t *testing.T) {
+	startCmd := newCommand("aries", "StartLocal", map[string]interface{}{"log-level": "invalid"})
+	result := make(chan *result)
+	callbacks[startCmd.ID] = result
+
+	defer delete(callbacks, startCmd.ID)
+
+	js.Global().Call("handleMsg", toString(startCmd))
+
+	select {
+	case r := <-result:
+		assert.Equal(t, startCmd.ID, r.ID)
+		assert.True(t, r.IsErr)
+		assert.Contains(t, r.ErrMsg, "invalid")
+		assert.Empty(t, r.Payload)
+	case <-time.After(5 * time.Second):
+		t.Error("test timeout")
+	}
+}
+
 func acceptResults(in chan *result) func(js.Value, []js.Value) interface{} {
 	return func(_ js.Value, args []js.Value) interface{} {
 		r := &result{}
@@ -151,7 +151,23 @@ const pkgs = {
             method: "GET",
         },
         SignCredential: {
-            path: "/verifiable/signcredential",
+            path: "/verifiable/credential/sign",
+            method: "POST"
+        },
+        VerifyCredential: {
+            path: "/verifiable/credential/verify",
+            method: "POST"
+        },
+        VerifyPresentation: {
+            path: "/verifiable/presentation/verify",
+            method: "POST"
+        },
+        EvaluatePresentationDefinition: {
+            path: "/verifiable/presentation/definition/evaluate",
+            method: "POST"
+        },
+        CreatePresentationSubmission: {
+            path: "/verifiable/presentation/submission",
             method: "POST"
         },
         GeneratePresentation: {
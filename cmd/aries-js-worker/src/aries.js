@@ -99,6 +99,7 @@ function newMsg(pkg, fn, payload) {
  *      "outbound-transport": ["ws", "http"],
  *      "transport-return-route": "all",
  *      "log-level": "debug",
+ *      "local-only": false,
  *      "agent-rest-url": "http://controller.api.example.com",
  *      "agent-rest-wshook": "ws://controller.api.example.com"
  * }
@@ -996,6 +997,47 @@ const Aries = function (opts) {
                 return invoke(aw, pending,  this.pkgname, "SignCredential", req, "timeout while adding proof to credential")
             },
 
+            /**
+             * Verifies the proof of given credential, the proof options (signature type, verification method,
+             * challenge and domain) being matched against its linked data proofs.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            verifyCredential: async function (req) {
+                return invoke(aw, pending,  this.pkgname, "VerifyCredential", req, "timeout while verifying credential")
+            },
+
+            /**
+             * Verifies the proof of given presentation and the proofs of its credentials.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            verifyPresentation: async function (req) {
+                return invoke(aw, pending,  this.pkgname, "VerifyPresentation", req, "timeout while verifying presentation")
+            },
+
+            /**
+             * Retrieves the stored credentials matching each input descriptor of a presentation definition.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            evaluatePresentationDefinition: async function (req) {
+                return invoke(aw, pending,  this.pkgname, "EvaluatePresentationDefinition", req, "timeout while evaluating presentation definition")
+            },
+
+            /**
+             * Creates a presentation submitting stored credentials against a presentation definition.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            createPresentationSubmission: async function (req) {
+                return invoke(aw, pending,  this.pkgname, "CreatePresentationSubmission", req, "timeout while creating presentation submission")
+            },
+
             /**
              * Generates a verifiable presentation from a verifiable credential.
              *
@@ -1071,6 +1113,158 @@ const Aries = function (opts) {
                 return invoke(aw, pending, this.pkgname, "ImportKey", req, "timeout while importing key")
             },
         },
+
+        /**
+         * Verifiable Credential Wallet - Refer to [OpenAPI spec](docs/rest/openapi_spec.md#generate-openapi-spec) for
+         * input params and output return json values.
+         */
+        vcwallet: {
+            pkgname: "vcwallet",
+
+            /**
+             * Creates the wallet profile of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            createProfile: async function (req) {
+                return invoke(aw, pending, this.pkgname, "CreateProfile", req, "timeout while creating wallet profile")
+            },
+
+            /**
+             * Removes the wallet profile of a user, with all the contents and keys of the wallet.
+             *
+             * @returns {Promise<Object>}
+             */
+            removeProfile: async function (req) {
+                return invoke(aw, pending, this.pkgname, "RemoveProfile", req, "timeout while removing wallet profile")
+            },
+
+            /**
+             * Unlocks the wallet of a user, returning the auth token of the wallet session.
+             *
+             * @returns {Promise<Object>}
+             */
+            unlock: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Unlock", req, "timeout while unlocking wallet")
+            },
+
+            /**
+             * Locks the wallet of a user, expiring the auth token of its session.
+             *
+             * @returns {Promise<Object>}
+             */
+            lock: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Lock", req, "timeout while locking wallet")
+            },
+
+            /**
+             * Replaces the auth token of the wallet session of a user with a new one.
+             *
+             * @returns {Promise<Object>}
+             */
+            refreshToken: async function (req) {
+                return invoke(aw, pending, this.pkgname, "RefreshToken", req, "timeout while refreshing wallet auth token")
+            },
+
+            /**
+             * Adds a content to the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            add: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Add", req, "timeout while adding wallet content")
+            },
+
+            /**
+             * Removes a content from the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            remove: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Remove", req, "timeout while removing wallet content")
+            },
+
+            /**
+             * Gets a content from the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            get: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Get", req, "timeout while getting wallet content")
+            },
+
+            /**
+             * Gets all the contents of a type from the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            getAll: async function (req) {
+                return invoke(aw, pending, this.pkgname, "GetAll", req, "timeout while getting wallet contents")
+            },
+
+            /**
+             * Exports the contents of the wallet of a user into an archive protected with a passphrase.
+             *
+             * @returns {Promise<Object>}
+             */
+            export: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Export", req, "timeout while exporting wallet")
+            },
+
+            /**
+             * Imports the contents of an archive exported from a wallet into the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            import: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Import", req, "timeout while importing wallet")
+            },
+
+            /**
+             * Runs the queries of a verifiable presentation request over the credentials of the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            query: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Query", req, "timeout while querying wallet")
+            },
+
+            /**
+             * Derives the BBS+ selective disclosure of a credential of the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            derive: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Derive", req, "timeout while deriving wallet credential")
+            },
+
+            /**
+             * Answers a pending present-proof request presentation with the credentials of the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            presentProof: async function (req) {
+                return invoke(aw, pending, this.pkgname, "PresentProof", req, "timeout while presenting proof from wallet")
+            },
+
+            /**
+             * Returns the records of the disclosures of the credentials of the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            auditLog: async function (req) {
+                return invoke(aw, pending, this.pkgname, "AuditLog", req, "timeout while reviewing wallet audit log")
+            },
+
+            /**
+             * Creates a key pair in the KMS of the wallet of a user.
+             *
+             * @returns {Promise<Object>}
+             */
+            createKeyPair: async function (req) {
+                return invoke(aw, pending, this.pkgname, "CreateKeyPair", req, "timeout while creating wallet key pair")
+            },
+        },
     }
 
     // start aries worker
@@ -1089,7 +1283,9 @@ const Aries = function (opts) {
         const timer = setTimeout(_ => reject(new Error("timout waiting for aries to initialize")), 15000)
         notifications.set("asset-ready", new Map().set("asset", async (result) => {
             clearTimeout(timer)
-            invoke(aw, pending, "aries", "Start", opts, "timeout while starting aries").then(
+            // "local-only" starts the verifiable, kms and vcwallet commands without the rest of the agent
+            const startFn = opts["local-only"] ? "StartLocal" : "Start"
+            invoke(aw, pending, "aries", startFn, opts, "timeout while starting aries").then(
                 resp => resolve(instance),
                 err => reject(new Error(err.message))
             )
@@ -0,0 +1,11 @@
+module github.com/hyperledger/aries-framework-go/component/log/logrus
+
+go 1.15
+
+replace github.com/hyperledger/aries-framework-go => ../../..
+
+require (
+	github.com/hyperledger/aries-framework-go v0.1.5-0.20201017112511-5734c20820a9
+	github.com/sirupsen/logrus v1.7.0
+	github.com/stretchr/testify v1.6.1
+)
@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logrus
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+// ModuleKey is the key of the field of the entries holding the module of the framework logger.
+const ModuleKey = "module"
+
+// Provider is a log.LoggerProvider routing the framework logs to a logrus logger.
+type Provider struct {
+	logger *logrus.Logger
+}
+
+// NewProvider returns the logger provider of the logrus logger.
+func NewProvider(logger *logrus.Logger) *Provider {
+	return &Provider{logger: logger}
+}
+
+// GetLogger returns the logger of the module.
+func (p *Provider) GetLogger(module string) log.Logger {
+	return &Logger{entry: p.logger.WithField(ModuleKey, module)}
+}
+
+// Logger is a structured logger adding the fields of the framework logs to the logrus entries.
+type Logger struct {
+	entry *logrus.Entry
+}
+
+// Fatalf logs the message at the fatal level, then calls the exit function of the logrus logger.
+func (l *Logger) Fatalf(msg string, args ...interface{}) {
+	l.entry.Fatalf(msg, args...)
+}
+
+// Panicf logs the message at the panic level, then panics.
+func (l *Logger) Panicf(msg string, args ...interface{}) {
+	l.entry.Panicf(msg, args...)
+}
+
+// Debugf logs the message at the debug level.
+func (l *Logger) Debugf(msg string, args ...interface{}) {
+	l.entry.Debugf(msg, args...)
+}
+
+// Infof logs the message at the info level.
+func (l *Logger) Infof(msg string, args ...interface{}) {
+	l.entry.Infof(msg, args...)
+}
+
+// Warnf logs the message at the warning level.
+func (l *Logger) Warnf(msg string, args ...interface{}) {
+	l.entry.Warnf(msg, args...)
+}
+
+// Errorf logs the message at the error level.
+func (l *Logger) Errorf(msg string, args ...interface{}) {
+	l.entry.Errorf(msg, args...)
+}
+
+// With returns a logger adding the fields to the logrus entries.
+func (l *Logger) With(fields ...log.Field) log.StructuredLogger {
+	logrusFields := make(logrus.Fields, len(fields))
+
+	for _, field := range fields {
+		logrusFields[field.Key] = field.Value
+	}
+
+	return &Logger{entry: l.entry.WithFields(logrusFields)}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logrus
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+func TestLogger(t *testing.T) {
+	logrusLogger, hook := test.NewNullLogger()
+	logrusLogger.SetLevel(logrus.DebugLevel)
+
+	logger := NewProvider(logrusLogger).GetLogger("aries-framework/test")
+
+	logger.Infof("%s exchange", "did")
+	logger.(log.StructuredLogger).With(log.NewField("connectionID", "abc")).Warnf("request sent")
+	logger.Debugf("debug")
+	logger.Errorf("error")
+
+	entries := hook.AllEntries()
+	require.Len(t, entries, 4)
+
+	require.Equal(t, "did exchange", entries[0].Message)
+	require.Equal(t, logrus.InfoLevel, entries[0].Level)
+	require.Equal(t, logrus.Fields{ModuleKey: "aries-framework/test"}, entries[0].Data)
+
+	require.Equal(t, logrus.WarnLevel, entries[1].Level)
+	require.Equal(t, logrus.Fields{ModuleKey: "aries-framework/test", "connectionID": "abc"}, entries[1].Data)
+
+	require.Equal(t, logrus.DebugLevel, entries[2].Level)
+	require.Equal(t, logrus.ErrorLevel, entries[3].Level)
+
+	require.Panics(t, func() {
+		logger.Panicf("panic")
+	})
+	require.Equal(t, logrus.PanicLevel, hook.LastEntry().Level)
+
+	var exited bool
+
+	logrusLogger.ExitFunc = func(int) { exited = true }
+
+	logger.Fatalf("fatal")
+	require.True(t, exited)
+	require.Equal(t, logrus.FatalLevel, hook.LastEntry().Level)
+}
+
+func TestFrameworkLogger(t *testing.T) {
+	logrusLogger, hook := test.NewNullLogger()
+
+	log.Initialize(NewProvider(logrusLogger))
+
+	log.New("aries-framework/test").With(log.NewField("connectionID", "abc")).Infof("%s exchange", "did")
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	require.Equal(t, "did exchange", entry.Message)
+	require.Equal(t, logrus.Fields{ModuleKey: "aries-framework/test", "connectionID": "abc"}, entry.Data)
+}
@@ -0,0 +1,16 @@
+module github.com/hyperledger/aries-framework-go/component/log/slog
+
+go 1.21
+
+replace github.com/hyperledger/aries-framework-go => ../../..
+
+require (
+	github.com/hyperledger/aries-framework-go v0.1.5-0.20201017112511-5734c20820a9
+	github.com/stretchr/testify v1.6.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package slog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+const (
+	// ModuleKey is the key of the attribute of the records holding the module of the framework logger.
+	ModuleKey = "module"
+	// LevelCritical is the level of the fatal and panic messages.
+	LevelCritical = slog.LevelError + 4
+
+	// callerSkip skips the frames of runtime.Callers and of the logging functions, for the records to hold the
+	// caller of the framework logger.
+	callerSkip = 5
+)
+
+// Provider is a log.LoggerProvider routing the framework logs to a slog logger.
+type Provider struct {
+	logger *slog.Logger
+}
+
+// NewProvider returns the logger provider of the slog logger.
+func NewProvider(logger *slog.Logger) *Provider {
+	return &Provider{logger: logger}
+}
+
+// GetLogger returns the logger of the module.
+func (p *Provider) GetLogger(module string) log.Logger {
+	return &Logger{logger: p.logger.With(ModuleKey, module)}
+}
+
+// Logger is a structured logger adding the fields of the framework logs to the slog records as attributes.
+type Logger struct {
+	logger *slog.Logger
+}
+
+// Fatalf logs the message at the critical level, then calls os.Exit(1).
+func (l *Logger) Fatalf(msg string, args ...interface{}) {
+	l.log(LevelCritical, msg, args...)
+	os.Exit(1)
+}
+
+// Panicf logs the message at the critical level, then panics.
+func (l *Logger) Panicf(msg string, args ...interface{}) {
+	l.log(LevelCritical, msg, args...)
+	panic(fmt.Sprintf(msg, args...))
+}
+
+// Debugf logs the message at the debug level.
+func (l *Logger) Debugf(msg string, args ...interface{}) {
+	l.log(slog.LevelDebug, msg, args...)
+}
+
+// Infof logs the message at the info level.
+func (l *Logger) Infof(msg string, args ...interface{}) {
+	l.log(slog.LevelInfo, msg, args...)
+}
+
+// Warnf logs the message at the warn level.
+func (l *Logger) Warnf(msg string, args ...interface{}) {
+	l.log(slog.LevelWarn, msg, args...)
+}
+
+// Errorf logs the message at the error level.
+func (l *Logger) Errorf(msg string, args ...interface{}) {
+	l.log(slog.LevelError, msg, args...)
+}
+
+// With returns a logger adding the fields to the slog records.
+func (l *Logger) With(fields ...log.Field) log.StructuredLogger {
+	attrs := make([]interface{}, len(fields))
+
+	for i, field := range fields {
+		attrs[i] = slog.Any(field.Key, field.Value)
+	}
+
+	return &Logger{logger: l.logger.With(attrs...)}
+}
+
+func (l *Logger) log(level slog.Level, msg string, args ...interface{}) {
+	ctx := context.Background()
+
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+
+	runtime.Callers(callerSkip, pcs[:])
+
+	record := slog.NewRecord(time.Now(), level, fmt.Sprintf(msg, args...), pcs[0])
+
+	if err := l.logger.Handler().Handle(ctx, record); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to log message : %s\n", err)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewProvider(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))).
+		GetLogger("aries-framework/test")
+
+	logger.Infof("%s exchange", "did")
+	logger.(log.StructuredLogger).With(log.NewField("connectionID", "abc")).Warnf("request sent")
+	logger.Debugf("not logged")
+	logger.Errorf("error")
+
+	require.Panics(t, func() {
+		logger.Panicf("panic")
+	})
+
+	records := decodeRecords(t, &buf)
+	require.Len(t, records, 4)
+
+	require.Equal(t, "did exchange", records[0]["msg"])
+	require.Equal(t, "INFO", records[0]["level"])
+	require.Equal(t, "aries-framework/test", records[0][ModuleKey])
+
+	require.Equal(t, "WARN", records[1]["level"])
+	require.Equal(t, "abc", records[1]["connectionID"])
+
+	require.Equal(t, "ERROR", records[2]["level"])
+	require.Equal(t, "ERROR+4", records[3]["level"])
+}
+
+func TestFrameworkLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	log.Initialize(NewProvider(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true}))))
+
+	log.New("aries-framework/test").With(log.NewField("connectionID", "abc")).Infof("%s exchange", "did")
+
+	var record map[string]interface{}
+
+	for _, r := range decodeRecords(t, &buf) {
+		if r["msg"] == "did exchange" {
+			record = r
+		}
+	}
+
+	require.NotNil(t, record)
+	require.Equal(t, "aries-framework/test", record[ModuleKey])
+	require.Equal(t, "abc", record["connectionID"])
+
+	// the caller of the framework logger is reported
+	source, ok := record[slog.SourceKey].(map[string]interface{})
+	require.True(t, ok)
+	require.True(t, strings.HasSuffix(source["file"].(string), "slog_test.go"), source["file"])
+}
+
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var records []map[string]interface{}
+
+	decoder := json.NewDecoder(buf)
+
+	for decoder.More() {
+		var record map[string]interface{}
+
+		require.NoError(t, decoder.Decode(&record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zap
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+// callerSkip skips the framework logging frames, for zap to report the caller of the framework logger.
+const callerSkip = 3
+
+// Provider is a log.LoggerProvider routing the framework logs to a zap logger, named after the modules.
+type Provider struct {
+	logger *zap.Logger
+}
+
+// NewProvider returns the logger provider of the zap logger.
+func NewProvider(logger *zap.Logger) *Provider {
+	return &Provider{logger: logger.WithOptions(zap.AddCallerSkip(callerSkip))}
+}
+
+// GetLogger returns the logger of the module.
+func (p *Provider) GetLogger(module string) log.Logger {
+	return &Logger{logger: p.logger.Named(module).Sugar()}
+}
+
+// Logger is a structured logger adding the fields of the framework logs to the zap entries.
+type Logger struct {
+	logger *zap.SugaredLogger
+}
+
+// Fatalf logs the message at the fatal level, then calls os.Exit(1).
+func (l *Logger) Fatalf(msg string, args ...interface{}) {
+	l.logger.Fatalf(msg, args...)
+}
+
+// Panicf logs the message at the panic level, then panics.
+func (l *Logger) Panicf(msg string, args ...interface{}) {
+	l.logger.Panicf(msg, args...)
+}
+
+// Debugf logs the message at the debug level.
+func (l *Logger) Debugf(msg string, args ...interface{}) {
+	l.logger.Debugf(msg, args...)
+}
+
+// Infof logs the message at the info level.
+func (l *Logger) Infof(msg string, args ...interface{}) {
+	l.logger.Infof(msg, args...)
+}
+
+// Warnf logs the message at the warn level.
+func (l *Logger) Warnf(msg string, args ...interface{}) {
+	l.logger.Warnf(msg, args...)
+}
+
+// Errorf logs the message at the error level.
+func (l *Logger) Errorf(msg string, args ...interface{}) {
+	l.logger.Errorf(msg, args...)
+}
+
+// With returns a logger adding the fields to the zap entries.
+func (l *Logger) With(fields ...log.Field) log.StructuredLogger {
+	zapFields := make([]interface{}, len(fields))
+
+	for i, field := range fields {
+		zapFields[i] = zap.Any(field.Key, field.Value)
+	}
+
+	return &Logger{logger: l.logger.With(zapFields...)}
+}
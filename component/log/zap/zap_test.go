@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	logger := NewProvider(zap.New(core)).GetLogger("aries-framework/test")
+
+	logger.Infof("%s exchange", "did")
+	logger.(log.StructuredLogger).With(log.NewField("connectionID", "abc")).Warnf("request sent")
+	logger.Debugf("debug")
+	logger.Errorf("error")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4)
+
+	require.Equal(t, "did exchange", entries[0].Message)
+	require.Equal(t, "aries-framework/test", entries[0].LoggerName)
+	require.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	require.Empty(t, entries[0].Context)
+
+	require.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	require.Equal(t, map[string]interface{}{"connectionID": "abc"}, entries[1].ContextMap())
+
+	require.Equal(t, zapcore.DebugLevel, entries[2].Level)
+	require.Equal(t, zapcore.ErrorLevel, entries[3].Level)
+
+	require.Panics(t, func() {
+		logger.Panicf("panic")
+	})
+	require.Equal(t, zapcore.PanicLevel, logs.AllUntimed()[4].Level)
+}
+
+func TestFrameworkLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	log.Initialize(NewProvider(zap.New(core, zap.AddCaller())))
+
+	log.New("aries-framework/test").With(log.NewField("connectionID", "abc")).Infof("%s exchange", "did")
+
+	entries := logs.FilterMessage("did exchange").AllUntimed()
+	require.Len(t, entries, 1)
+	require.Equal(t, "aries-framework/test", entries[0].LoggerName)
+	require.Equal(t, map[string]interface{}{"connectionID": "abc"}, entries[0].ContextMap())
+
+	// the caller of the framework logger is reported
+	require.True(t, strings.HasSuffix(entries[0].Caller.File, "zap_test.go"), entries[0].Caller.File)
+}
@@ -0,0 +1,247 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbolt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// Option configures the bbolt provider.
+type Option func(opts *bolt.Options)
+
+// WithTimeout sets the time to wait for the lock of the database file, held by another process (5 seconds by default).
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *bolt.Options) {
+		opts.Timeout = timeout
+	}
+}
+
+// WithNoSync disables the synchronization of the database file after each write, which speeds up the writes
+// at the risk of losing the last ones if the system crashes.
+func WithNoSync() Option {
+	return func(opts *bolt.Options) {
+		opts.NoSync = true
+	}
+}
+
+// Provider bbolt implementation of storage.Provider interface. All the stores are kept in a single database file,
+// each store in its own bucket.
+type Provider struct {
+	db     *bolt.DB
+	stores map[string]*boltStore
+	lock   sync.RWMutex
+}
+
+// NewProvider opens (or creates) the bbolt database file at dbPath and instantiates Provider.
+func NewProvider(dbPath string, opts ...Option) (*Provider, error) {
+	options := &bolt.Options{Timeout: defaultTimeout}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	db, err := bolt.Open(dbPath, 0600, options) // nolint: gomnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bbolt database: %w", err)
+	}
+
+	return &Provider{db: db, stores: make(map[string]*boltStore)}, nil
+}
+
+// OpenStore opens and returns a store for given name space, creating its bucket if needed.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	name = strings.ToLower(name)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if store, ok := p.stores[name]; ok {
+		return store, nil
+	}
+
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(name))
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bucket: %w", err)
+	}
+
+	store := &boltStore{db: p.db, bucket: []byte(name)}
+	p.stores[name] = store
+
+	return store, nil
+}
+
+// CloseStore closes the store of given name. The bucket of the store is kept in the database file.
+func (p *Provider) CloseStore(name string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.stores, strings.ToLower(name))
+
+	return nil
+}
+
+// Close closes the database file and all stores created under this store provider.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.stores = make(map[string]*boltStore)
+
+	return p.db.Close()
+}
+
+type boltStore struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+// Put stores the key and the record.
+func (s *boltStore) Put(k string, v []byte) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Put([]byte(k), v)
+	})
+}
+
+// Get fetches the record based on key.
+func (s *boltStore) Get(k string) ([]byte, error) {
+	if k == "" {
+		return nil, errors.New("key is mandatory")
+	}
+
+	var data []byte
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(s.bucket).Get([]byte(k))
+		if v == nil {
+			return storage.ErrDataNotFound
+		}
+
+		// the value is only valid within the transaction
+		data = append([]byte(nil), v...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// GetWithVersion fetches the record based on key and returns its current version, derived from its content.
+func (s *boltStore) GetWithVersion(k string) ([]byte, string, error) {
+	data, err := s.Get(k)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, storage.ContentVersion(data), nil
+}
+
+// PutIfVersion stores the key and the record if the current version of the record is version, an empty version
+// meaning the record must not exist. The version is checked and the record put in a single transaction.
+func (s *boltStore) PutIfVersion(k string, v []byte, version string) (string, error) {
+	if k == "" || v == nil {
+		return "", errors.New("key and value are mandatory")
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+
+		current := b.Get([]byte(k))
+		if current == nil && version != "" || current != nil && storage.ContentVersion(current) != version {
+			return storage.ErrVersionMismatch
+		}
+
+		return b.Put([]byte(k), v)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return storage.ContentVersion(v), nil
+}
+
+// Iterator returns an iterator over the records of the key range. Since the bbolt cursors are only valid within
+// their transaction, the records of the range are read at once.
+func (s *boltStore) Iterator(start, limit string) storage.StoreIterator {
+	end := []byte(strings.ReplaceAll(limit, storage.EndKeySuffix, "~"))
+
+	var batch [][]string
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(s.bucket).Cursor()
+
+		for k, v := c.Seek([]byte(start)); k != nil && bytes.Compare(k, end) < 0; k, v = c.Next() {
+			batch = append(batch, []string{string(k), string(v)})
+		}
+
+		return nil
+	})
+
+	return mem.NewMemIterator(batch, err)
+}
+
+// Delete will delete record with k key.
+func (s *boltStore) Delete(k string) error {
+	if k == "" {
+		return errors.New("key is mandatory")
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Delete([]byte(k))
+	})
+}
+
+// Batch performs the batch operations atomically, in a single transaction.
+func (s *boltStore) Batch(operations []storage.Operation) error {
+	for _, op := range operations {
+		if op.Key == "" {
+			return errors.New("key is mandatory")
+		}
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+
+		for _, op := range operations {
+			var err error
+
+			if op.Value == nil {
+				err = b.Delete([]byte(op.Key))
+			} else {
+				err = b.Put([]byte(op.Key), op.Value)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
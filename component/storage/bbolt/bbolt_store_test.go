@@ -0,0 +1,318 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbolt
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+func setupBoltDB(t testing.TB) (string, func()) {
+	dir, err := ioutil.TempDir("", "db")
+	if err != nil {
+		t.Fatalf("Failed to create bbolt directory: %s", err)
+	}
+
+	return filepath.Join(dir, "aries.db"), func() {
+		err := os.RemoveAll(dir)
+		if err != nil {
+			t.Fatalf("Failed to clear bbolt directory: %s", err)
+		}
+	}
+}
+
+func TestBoltStore(t *testing.T) {
+	t.Run("Test bbolt store put and get", func(t *testing.T) {
+		path, cleanup := setupBoltDB(t)
+		defer cleanup()
+
+		prov, err := NewProvider(path, WithNoSync())
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		const key = "did:example:123"
+		data := []byte("value")
+
+		require.NoError(t, store.Put(key, data))
+
+		doc, err := store.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, data, doc)
+
+		// test update
+		update := []byte(`{"key1":"value1"}`)
+		require.NoError(t, store.Put(key, update))
+
+		doc, err = store.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, update, doc)
+
+		_, err = store.Get("did:example:789")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = store.Get("")
+		require.EqualError(t, err, "key is mandatory")
+
+		require.EqualError(t, store.Put(key, nil), "key and value are mandatory")
+		require.EqualError(t, store.Put("", data), "key and value are mandatory")
+
+		require.NoError(t, prov.Close())
+
+		// try to get after provider is closed
+		_, err = store.Get(key)
+		require.Error(t, err)
+	})
+
+	t.Run("Test bbolt store records persisted", func(t *testing.T) {
+		path, cleanup := setupBoltDB(t)
+		defer cleanup()
+
+		prov, err := NewProvider(path)
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore("Test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, prov.CloseStore("test"))
+		require.NoError(t, prov.Close())
+
+		prov, err = NewProvider(path)
+		require.NoError(t, err)
+
+		store, err = prov.OpenStore("test")
+		require.NoError(t, err)
+
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, "v1", string(v))
+
+		store2, err := prov.OpenStore("TEST")
+		require.NoError(t, err)
+		require.Equal(t, store, store2)
+
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("Test bbolt multi store put and get", func(t *testing.T) {
+		path, cleanup := setupBoltDB(t)
+		defer cleanup()
+
+		prov, err := NewProvider(path)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, prov.Close())
+		}()
+
+		store1, err := prov.OpenStore("store1")
+		require.NoError(t, err)
+
+		store2, err := prov.OpenStore("store2")
+		require.NoError(t, err)
+
+		require.NoError(t, store1.Put("did:example:1", []byte("value1")))
+
+		_, err = store2.Get("did:example:1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("Test bbolt store iterator", func(t *testing.T) {
+		path, cleanup := setupBoltDB(t)
+		defer cleanup()
+
+		prov, err := NewProvider(path)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, prov.Close())
+		}()
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		for _, k := range []string{"abc_3", "abc_1", "abc_2", "abd_1", "xyz_1"} {
+			require.NoError(t, store.Put(k, []byte("value-"+k)))
+		}
+
+		keys := func(start, limit string) []string {
+			itr := store.Iterator(start, limit)
+			defer itr.Release()
+
+			var keys []string
+
+			for itr.Next() {
+				keys = append(keys, string(itr.Key()))
+				require.Equal(t, "value-"+string(itr.Key()), string(itr.Value()))
+			}
+
+			require.NoError(t, itr.Error())
+
+			return keys
+		}
+
+		require.Equal(t, []string{"abc_1", "abc_2", "abc_3"}, keys("abc_", "abc_"+storage.EndKeySuffix))
+		require.Equal(t, []string{"abc_2", "abc_3", "abd_1"}, keys("abc_2", "abd_2"))
+		require.Equal(t, []string{"abc_1", "abc_2", "abc_3", "abd_1", "xyz_1"}, keys("", storage.EndKeySuffix))
+		require.Empty(t, keys("xyz_2", "xyz_"+storage.EndKeySuffix))
+		require.Empty(t, keys("abc_", ""))
+	})
+
+	t.Run("Test bbolt store delete", func(t *testing.T) {
+		path, cleanup := setupBoltDB(t)
+		defer cleanup()
+
+		prov, err := NewProvider(path)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, prov.Close())
+		}()
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, store.Delete("k1"))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		// deleting a missing record is not an error
+		require.NoError(t, store.Delete("k1"))
+		require.EqualError(t, store.Delete(""), "key is mandatory")
+	})
+
+	t.Run("Test bbolt database locked", func(t *testing.T) {
+		path, cleanup := setupBoltDB(t)
+		defer cleanup()
+
+		prov, err := NewProvider(path)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, prov.Close())
+		}()
+
+		_, err = NewProvider(path, WithTimeout(10*time.Millisecond))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open bbolt database")
+	})
+
+	t.Run("Test bbolt provider errors", func(t *testing.T) {
+		path, cleanup := setupBoltDB(t)
+		defer cleanup()
+
+		prov, err := NewProvider(path)
+		require.NoError(t, err)
+
+		_, err = prov.OpenStore("")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create bucket")
+
+		require.NoError(t, prov.Close())
+
+		_, err = prov.OpenStore("test")
+		require.Error(t, err)
+	})
+}
+
+func TestBoltStore_Batch(t *testing.T) {
+	path, cleanup := setupBoltDB(t)
+	defer cleanup()
+
+	prov, err := NewProvider(path)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, prov.Close())
+	}()
+
+	store, err := prov.OpenStore("test")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("k1", []byte("v1")))
+
+	require.NoError(t, store.Batch([]storage.Operation{
+		{Key: "k1"},
+		{Key: "k2", Value: []byte("v2")},
+		{Key: "k3", Value: []byte("v3")},
+	}))
+
+	_, err = store.Get("k1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	for _, k := range []string{"k2", "k3"} {
+		v, e := store.Get(k)
+		require.NoError(t, e)
+		require.Equal(t, fmt.Sprintf("v%s", k[1:]), string(v))
+	}
+
+	// no operation is performed when one of them is invalid
+	err = store.Batch([]storage.Operation{{Key: "k2"}, {Key: ""}})
+	require.EqualError(t, err, "key is mandatory")
+
+	_, err = store.Get("k2")
+	require.NoError(t, err)
+}
+
+func TestBoltStore_PutIfVersion(t *testing.T) {
+	path, cleanup := setupBoltDB(t)
+	defer cleanup()
+
+	prov, err := NewProvider(path)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, prov.Close())
+	}()
+
+	store, err := prov.OpenStore("test")
+	require.NoError(t, err)
+
+	version, err := storage.PutIfVersion(store, "k1", []byte("v1"), "")
+	require.NoError(t, err)
+
+	// the record already exists
+	_, err = storage.PutIfVersion(store, "k1", []byte("v2"), "")
+	require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+	// the record was updated
+	require.NoError(t, store.Put("k1", []byte("v1-updated")))
+
+	_, err = storage.PutIfVersion(store, "k1", []byte("v2"), version)
+	require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+	doc, version, err := storage.GetWithVersion(store, "k1")
+	require.NoError(t, err)
+	require.Equal(t, "v1-updated", string(doc))
+
+	version, err = storage.PutIfVersion(store, "k1", []byte("v2"), version)
+	require.NoError(t, err)
+
+	doc, current, err := storage.GetWithVersion(store, "k1")
+	require.NoError(t, err)
+	require.Equal(t, "v2", string(doc))
+	require.Equal(t, version, current)
+
+	_, _, err = storage.GetWithVersion(store, "k2")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, err = storage.PutIfVersion(store, "", []byte("v1"), "")
+	require.EqualError(t, err, "key and value are mandatory")
+}
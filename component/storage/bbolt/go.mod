@@ -0,0 +1,11 @@
+module github.com/hyperledger/aries-framework-go/component/storage/bbolt
+
+go 1.15
+
+replace github.com/hyperledger/aries-framework-go => ../../..
+
+require (
+	github.com/hyperledger/aries-framework-go v0.1.5-0.20201017112511-5734c20820a9
+	github.com/stretchr/testify v1.6.1
+	go.etcd.io/bbolt v1.3.5
+)
@@ -2,6 +2,12 @@ module github.com/hyperledger/aries-framework-go/component/storage/jsindexeddb
 
 go 1.15
 
+replace (
+	github.com/hyperledger/aries-framework-go => ../../..
+	github.com/kilic/bls12-381 => github.com/trustbloc/bls12-381 v0.0.0-20201104214312-31de2a204df8
+	github.com/piprate/json-gold => github.com/trustbloc/json-gold v0.3.1-0.20200414173446-30d742ee949e
+)
+
 require (
 	github.com/hyperledger/aries-framework-go v0.1.5-0.20201017112511-5734c20820a9
 	github.com/stretchr/testify v1.6.1
@@ -196,6 +196,103 @@ func (s *store) Delete(k string) error {
 	return nil
 }
 
+// Batch performs the batch operations atomically within a single readwrite transaction. The operations are validated
+// before the transaction begins, and the transaction is aborted on any error.
+func (s *store) Batch(operations []storage.Operation) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	for _, op := range operations {
+		if op.Key == "" {
+			return errors.New("key is mandatory")
+		}
+	}
+
+	tx := s.db.Call("transaction", s.name, "readwrite")
+
+	err := queueOperations(tx.Call("objectStore", s.name), operations)
+	if err == nil {
+		err = getTransactionResult(tx)
+	}
+
+	if err != nil {
+		abortTransaction(tx)
+
+		return fmt.Errorf("failed to perform batch operations: %w", err)
+	}
+
+	return nil
+}
+
+// queueOperations queues the put and delete requests of the operations on the object store of a transaction.
+func queueOperations(objectStore js.Value, operations []storage.Operation) (err error) {
+	defer func() {
+		// the object store throws if a request can't be queued, e.g. the transaction is no longer active
+		if r := recover(); r != nil {
+			err = fmt.Errorf("queue operation: %v", r)
+		}
+	}()
+
+	for _, op := range operations {
+		if op.Value == nil {
+			objectStore.Call("delete", op.Key)
+		} else {
+			objectStore.Call("put", map[string]interface{}{"key": op.Key, "value": string(op.Value)})
+		}
+	}
+
+	return nil
+}
+
+// abortTransaction aborts the transaction, unless it's already committed or aborted.
+func abortTransaction(tx js.Value) {
+	defer func() {
+		// the transaction throws an InvalidStateError if it's already committed or aborted
+		recover() // nolint: errcheck
+	}()
+
+	tx.Call("abort")
+}
+
+// getTransactionResult waits until all the requests of the transaction succeed and it's committed, or it fails.
+func getTransactionResult(tx js.Value) error {
+	oncomplete := make(chan struct{}, 1)
+	onerror := make(chan js.Value, 1)
+
+	const timeout = 10
+
+	tx.Set("oncomplete", js.FuncOf(func(this js.Value, inputs []js.Value) interface{} {
+		oncomplete <- struct{}{}
+		return nil
+	}))
+
+	// the errors of the requests bubble to the transaction, which is then aborted
+	failed := js.FuncOf(func(this js.Value, inputs []js.Value) interface{} {
+		select {
+		case onerror <- inputs[0].Get("target").Get("error"):
+		default:
+		}
+
+		return nil
+	})
+	tx.Set("onerror", failed)
+	tx.Set("onabort", failed)
+
+	select {
+	case <-oncomplete:
+		return nil
+	case value := <-onerror:
+		if !value.Truthy() {
+			return errors.New("transaction aborted")
+		}
+
+		return fmt.Errorf("%s %s", value.Get("name").String(), value.Get("message").String())
+	case <-time.After(timeout * time.Second):
+		return errors.New("timeout waiting for transaction")
+	}
+}
+
 type iterator struct {
 	batch    *js.Value
 	err      error
@@ -246,3 +246,40 @@ func TestStore_Delete(t *testing.T) {
 	require.EqualError(t, err, storage.ErrDataNotFound.Error())
 	require.Empty(t, doc)
 }
+
+func TestStore_Batch(t *testing.T) {
+	prov, err := NewProvider("sampledb")
+	require.NoError(t, err)
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	require.NoError(t, store1.Put("k1", []byte("v1")))
+
+	require.NoError(t, store1.Batch([]storage.Operation{
+		{Key: "k2", Value: []byte("v2")},
+		{Key: "k1"},
+		{Key: "k3", Value: []byte("v3")},
+	}))
+
+	_, err = store1.Get("k1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	for k, v := range map[string]string{"k2": "v2", "k3": "v3"} {
+		doc, err := store1.Get(k)
+		require.NoError(t, err)
+		require.Equal(t, []byte(v), doc)
+	}
+
+	// an invalid operation fails the whole batch before any of its operations is performed
+	err = store1.Batch([]storage.Operation{
+		{Key: "k4", Value: []byte("v4")},
+		{Key: ""},
+	})
+	require.EqualError(t, err, "key is mandatory")
+
+	_, err = store1.Get("k4")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	require.NoError(t, store1.Batch(nil))
+}
@@ -154,3 +154,22 @@ func (s *leveldbStore) Delete(k string) error {
 
 	return s.db.Delete([]byte(k), nil)
 }
+
+// Batch performs the batch operations atomically.
+func (s *leveldbStore) Batch(operations []storage.Operation) error {
+	batch := new(leveldb.Batch)
+
+	for _, op := range operations {
+		if op.Key == "" {
+			return errors.New("key is mandatory")
+		}
+
+		if op.Value == nil {
+			batch.Delete([]byte(op.Key))
+		} else {
+			batch.Put([]byte(op.Key), op.Value)
+		}
+	}
+
+	return s.db.Write(batch, nil)
+}
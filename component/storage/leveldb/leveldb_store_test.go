@@ -320,3 +320,32 @@ func TestLeveldbStore_Delete(t *testing.T) {
 	require.EqualError(t, err, storage.ErrDataNotFound.Error())
 	require.Empty(t, doc)
 }
+
+func TestLeveldbStore_Batch(t *testing.T) {
+	path, cleanup := setupLevelDB(t)
+	defer cleanup()
+
+	prov := NewProvider(path)
+
+	store, err := prov.OpenStore("test")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("k1", []byte("v1")))
+
+	err = store.Batch([]storage.Operation{{Key: "k2", Value: []byte("v2")}, {Key: "k1"}})
+	require.NoError(t, err)
+
+	_, err = store.Get("k1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	doc, err := store.Get("k2")
+	require.NoError(t, err)
+	require.Equal(t, "v2", string(doc))
+
+	// none of the operations is performed if one is invalid
+	err = store.Batch([]storage.Operation{{Key: "k3", Value: []byte("v3")}, {Key: ""}})
+	require.EqualError(t, err, "key is mandatory")
+
+	_, err = store.Get("k3")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package otel adapts an OpenTelemetry tracer provider to the tracing.Tracer of the framework. The spans are
+// exported by the exporters of the tracer provider, and the trace context is propagated between the agents in
+// the W3C Trace Context format, e.g.:
+//
+//	exporter, err := otlptracegrpc.New(ctx)
+//	...
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+//	framework, err := aries.New(aries.WithTracer(otel.NewTracer(tp)))
+package otel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+)
+
+// InstrumentationName is the name of the OpenTelemetry tracer of the framework.
+const InstrumentationName = "github.com/hyperledger/aries-framework-go"
+
+// Tracer is a tracing.Tracer creating the spans with an OpenTelemetry tracer.
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracer returns a Tracer creating the spans with the tracer of tp.
+func NewTracer(tp trace.TracerProvider) *Tracer {
+	return &Tracer{
+		tracer:     tp.Tracer(InstrumentationName),
+		propagator: propagation.TraceContext{},
+	}
+}
+
+// Start starts the span of the operation at the given time, child of the span of ctx if any.
+func (t *Tracer) Start(ctx context.Context, name string, start time.Time) (context.Context, tracing.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithTimestamp(start))
+
+	return ctx, &Span{span: span}
+}
+
+// Inject writes the trace context of the span of ctx to the carrier.
+func (t *Tracer) Inject(ctx context.Context, carrier tracing.Carrier) {
+	t.propagator.Inject(ctx, propagation.MapCarrier(carrier))
+}
+
+// Extract returns the context holding the remote span of the trace context of the carrier.
+func (t *Tracer) Extract(ctx context.Context, carrier tracing.Carrier) context.Context {
+	return t.propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// Span is a tracing.Span wrapping an OpenTelemetry span.
+type Span struct {
+	span trace.Span
+}
+
+// SetAttributes adds the attributes to the span.
+func (s *Span) SetAttributes(attrs tracing.Attributes) {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+
+	for k, v := range attrs {
+		kvs = append(kvs, attribute.String(k, v))
+	}
+
+	s.span.SetAttributes(kvs...)
+}
+
+// End ends the span, recording the error and setting the error status if err is not nil.
+func (s *Span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+
+	s.span.End()
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+)
+
+type agent struct {
+	tracer tracing.Tracer
+}
+
+func (a *agent) Tracer() tracing.Tracer {
+	return a.tracer
+}
+
+func newTracer() (*Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+
+	return NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))), recorder
+}
+
+func TestTracer(t *testing.T) {
+	t.Run("Exports the spans", func(t *testing.T) {
+		tracer, recorder := newTracer()
+		start := time.Now().Add(-time.Second)
+
+		ctx, parent := tracer.Start(context.Background(), "parent", start)
+		_, child := tracer.Start(ctx, "child", start)
+		child.SetAttributes(tracing.Attributes{"key": "value"})
+		child.End(errors.New("child error"))
+		parent.End(nil)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		require.Equal(t, "child", spans[0].Name())
+		require.Equal(t, start, spans[0].StartTime())
+		require.Equal(t, []attribute.KeyValue{attribute.String("key", "value")}, spans[0].Attributes())
+		require.Equal(t, codes.Error, spans[0].Status().Code)
+		require.Equal(t, "child error", spans[0].Status().Description)
+		require.Len(t, spans[0].Events(), 1)
+
+		require.Equal(t, "parent", spans[1].Name())
+		require.Equal(t, codes.Unset, spans[1].Status().Code)
+		require.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+		require.Equal(t, spans[1].SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	})
+
+	t.Run("Propagates the trace context", func(t *testing.T) {
+		tracer, recorder := newTracer()
+
+		ctx, span := tracer.Start(context.Background(), "sender", time.Now())
+
+		carrier := tracing.Carrier{}
+		tracer.Inject(ctx, carrier)
+		require.Contains(t, carrier, "traceparent")
+
+		_, remote := tracer.Start(tracer.Extract(context.Background(), carrier), "receiver", time.Now())
+		remote.End(nil)
+		span.End(nil)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		require.True(t, spans[0].Parent().IsRemote())
+		require.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	})
+
+	t.Run("Traces a message across the agents", func(t *testing.T) {
+		tracer, recorder := newTracer()
+		msg := map[string]interface{}{"@id": "msg-1", "@type": "https://didcomm.org/issue-credential/2.0/offer-credential"}
+
+		sent := tracing.ForDIDComm(&agent{tracer: tracer}).Start(msg, tracing.SendSpan)
+		require.Contains(t, msg, tracing.Decorator)
+
+		received := tracing.ForDIDComm(&agent{tracer: tracer}).Start(msg, tracing.ReceiveSpan)
+		received.End(nil)
+		sent.End(nil)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		require.Equal(t, tracing.ReceiveSpan, spans[0].Name())
+		require.Equal(t, tracing.SendSpan, spans[1].Name())
+		require.Equal(t, spans[1].SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+		require.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	})
+}
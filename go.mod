@@ -19,6 +19,7 @@ require (
 	github.com/gorilla/mux v1.7.3
 	github.com/jinzhu/copier v0.0.0-20190924061706-b57f9002281a
 	github.com/kilic/bls12-381 v0.0.0-20200820230200-6b2c19996391
+	github.com/klauspost/compress v1.10.0
 	github.com/minio/sha256-simd v0.1.1 // indirect
 	github.com/mitchellh/mapstructure v1.1.2
 	github.com/multiformats/go-multibase v0.0.1
@@ -33,6 +34,7 @@ require (
 	github.com/xeipuuv/gojsonschema v1.2.0
 	golang.org/x/crypto v0.0.0-20201002170205-7f63de1d35b0
 	golang.org/x/sys v0.0.0-20201009025420-dfb3f7c4e634 // indirect
+	google.golang.org/grpc v1.31.1
 	nhooyr.io/websocket v1.8.3
 )
 
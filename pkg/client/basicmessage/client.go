@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package basicmessage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/basic"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+// ServiceName is the name of the message service registered by the client to receive basic messages.
+const ServiceName = "basicmessage-client"
+
+type (
+	// Message is a basic message.
+	Message basic.Message
+	// Record is a basic message persisted for a connection.
+	Record basic.Record
+	// Page is a page of stored basic messages, ordered from the most recent one.
+	Page basic.Page
+)
+
+var logger = log.New("aries-framework/client/basicmessage")
+
+// provider contains dependencies for the basic message client and is typically created by using aries.Context().
+type provider interface {
+	Messenger() service.Messenger
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// MessageHandler allows dynamic registration of message services.
+type MessageHandler interface {
+	// Register registers given message services to this message handler
+	Register(msgSvcs ...dispatcher.MessageService) error
+	// Unregister unregisters message service with given name from this message handler
+	Unregister(name string) error
+}
+
+// Option configures the basic message client.
+type Option func(c *Client)
+
+// WithMessageHandle sets the handle invoked for every incoming basic message, once it is persisted.
+func WithMessageHandle(handle basic.MessageHandle) Option {
+	return func(c *Client) {
+		c.handle = handle
+	}
+}
+
+// SendOption configures a basic message being sent.
+type SendOption func(msg *basic.Message)
+
+// WithThreadID sends the message in the existing thread (conversation) with given ID.
+func WithThreadID(threadID string) SendOption {
+	return func(msg *basic.Message) {
+		msg.Thread = &decorator.Thread{ID: threadID}
+	}
+}
+
+// WithLocale sets the locale of the message content.
+func WithLocale(locale string) SendOption {
+	return func(msg *basic.Message) {
+		msg.I10n.Locale = locale
+	}
+}
+
+// Client sends basic messages and persists the conversations of every connection, so applications can
+// build chat UIs without their own storage layer.
+type Client struct {
+	messenger   service.Messenger
+	store       *basic.Store
+	connections *connection.Lookup
+	handle      basic.MessageHandle
+}
+
+// New returns new instance of the basic message client. It registers a message service receiving the
+// basic messages to the given message handler.
+func New(ctx provider, registrar MessageHandler, opts ...Option) (*Client, error) {
+	store, err := basic.NewStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	connections, err := connection.NewLookup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize connection lookup : %w", err)
+	}
+
+	c := &Client{
+		messenger:   ctx.Messenger(),
+		store:       store,
+		connections: connections,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	msgSvc, err := basic.NewMessageService(ServiceName, c.handleInbound)
+	if err != nil {
+		return nil, err
+	}
+
+	err = registrar.Register(msgSvc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register basic message service : %w", err)
+	}
+
+	return c, nil
+}
+
+// Send sends the basic message with given content to the connection and persists it.
+// A new thread is started unless WithThreadID is given.
+func (c *Client) Send(connectionID, content string, opts ...SendOption) (*Record, error) {
+	conn, err := c.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection record: %w", err)
+	}
+
+	msg := basic.Message{
+		ID:       uuid.New().String(),
+		Type:     basic.MessageRequestType,
+		SentTime: time.Now().UTC(),
+		Content:  content,
+	}
+
+	for _, opt := range opts {
+		opt(&msg)
+	}
+
+	threadID := msg.ID
+
+	if msg.Thread != nil {
+		threadID = msg.Thread.ID
+		// the messenger sets the ~thread decorator
+		msg.Thread = nil
+
+		err = c.messenger.ReplyToMsg(service.DIDCommMsgMap{"@id": threadID}, service.NewDIDCommMsgMap(msg),
+			conn.MyDID, conn.TheirDID)
+	} else {
+		err = c.messenger.Send(service.NewDIDCommMsgMap(msg), conn.MyDID, conn.TheirDID)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("send basic message: %w", err)
+	}
+
+	record := &basic.Record{
+		ConnectionID: connectionID,
+		ThreadID:     threadID,
+		Read:         true,
+		Time:         msg.SentTime,
+		Message:      msg,
+	}
+
+	err = c.store.Save(record)
+	if err != nil {
+		return nil, fmt.Errorf("save sent basic message: %w", err)
+	}
+
+	return (*Record)(record), nil
+}
+
+// Messages returns a page of the messages exchanged with the connection, most recent first.
+func (c *Client) Messages(connectionID string, opts ...basic.QueryOption) (*Page, error) {
+	page, err := c.store.Query(connectionID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*Page)(page), nil
+}
+
+// MarkRead marks the received messages of the connection as read.
+func (c *Client) MarkRead(connectionID string, msgIDs ...string) error {
+	return c.store.MarkRead(connectionID, msgIDs...)
+}
+
+// UnreadCount returns the number of unread messages received from the connection.
+func (c *Client) UnreadCount(connectionID string) (int, error) {
+	return c.store.UnreadCount(connectionID)
+}
+
+func (c *Client) handleInbound(msg basic.Message, myDID, theirDID string) error {
+	connectionID, err := c.connections.GetConnectionIDByDIDs(myDID, theirDID)
+
+	switch {
+	case errors.Is(err, storage.ErrDataNotFound):
+		logger.Debugf("basic message %s received outside of a connection is not persisted", msg.ID)
+	case err != nil:
+		return fmt.Errorf("get connection ID: %w", err)
+	default:
+		threadID := msg.ID
+		if msg.Thread != nil && msg.Thread.ID != "" {
+			threadID = msg.Thread.ID
+		}
+
+		err = c.store.Save(&basic.Record{
+			ConnectionID: connectionID,
+			ThreadID:     threadID,
+			Inbound:      true,
+			Time:         time.Now().UTC(),
+			Message:      msg,
+		})
+		if err != nil {
+			return fmt.Errorf("save received basic message: %w", err)
+		}
+	}
+
+	if c.handle != nil {
+		return c.handle(msg, myDID, theirDID)
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package basicmessage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/basic"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	connectionID = "connection-id"
+	myDID        = "did:example:alice"
+	theirDID     = "did:example:bob"
+)
+
+type mockProvider struct {
+	messenger          service.Messenger
+	store              storage.Provider
+	protocolStateStore storage.Provider
+}
+
+func (p *mockProvider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+func (p *mockProvider) StorageProvider() storage.Provider {
+	return p.store
+}
+
+func (p *mockProvider) ProtocolStateStorageProvider() storage.Provider {
+	return p.protocolStateStore
+}
+
+func newProvider(t *testing.T, messenger service.Messenger) *mockProvider {
+	t.Helper()
+
+	p := &mockProvider{
+		messenger:          messenger,
+		store:              mockstore.NewMockStoreProvider(),
+		protocolStateStore: mockstore.NewMockStoreProvider(),
+	}
+
+	recorder, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connectionID,
+		State:        connection.StateNameCompleted,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+	}))
+
+	return p
+}
+
+func TestNew(t *testing.T) {
+	t.Run("registers the message service", func(t *testing.T) {
+		registrar := msghandler.NewMockMsgServiceProvider()
+
+		client, err := New(newProvider(t, nil), registrar)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+		require.Len(t, registrar.Services(), 1)
+		require.Equal(t, ServiceName, registrar.Services()[0].Name())
+		require.True(t, registrar.Services()[0].Accept(basic.MessageRequestType, nil))
+	})
+
+	t.Run("register error", func(t *testing.T) {
+		registrar := msghandler.NewMockMsgServiceProvider()
+		registrar.RegisterErr = errors.New("register error")
+
+		_, err := New(newProvider(t, nil), registrar)
+		require.EqualError(t, err, "failed to register basic message service : register error")
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockProvider{
+			store: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		}, msghandler.NewMockMsgServiceProvider())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open basic message store")
+	})
+
+	t.Run("connection lookup error", func(t *testing.T) {
+		_, err := New(&mockProvider{
+			store:              mockstore.NewMockStoreProvider(),
+			protocolStateStore: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		}, msghandler.NewMockMsgServiceProvider())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to initialize connection lookup")
+	})
+}
+
+func TestClient_Send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("new thread and reply in thread", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), myDID, theirDID).
+			DoAndReturn(func(msg service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, basic.MessageRequestType, msg.Type())
+
+				return nil
+			})
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), myDID, theirDID).
+			DoAndReturn(func(in, out service.DIDCommMsgMap, _, _ string) error {
+				thID, err := in.ThreadID()
+				require.NoError(t, err)
+				require.NotEmpty(t, thID)
+
+				msg := basic.Message{}
+				require.NoError(t, out.Decode(&msg))
+				require.Equal(t, "second", msg.Content)
+				require.Equal(t, "en", msg.I10n.Locale)
+
+				return nil
+			})
+
+		client, err := New(newProvider(t, messenger), msghandler.NewMockMsgServiceProvider())
+		require.NoError(t, err)
+
+		first, err := client.Send(connectionID, "first")
+		require.NoError(t, err)
+		require.Equal(t, first.Message.ID, first.ThreadID)
+		require.True(t, first.Read)
+		require.False(t, first.Inbound)
+
+		second, err := client.Send(connectionID, "second", WithThreadID(first.ThreadID), WithLocale("en"))
+		require.NoError(t, err)
+		require.Equal(t, first.ThreadID, second.ThreadID)
+
+		page, err := client.Messages(connectionID, basic.WithThreadID(first.ThreadID))
+		require.NoError(t, err)
+		require.Equal(t, 2, page.Total)
+
+		count, err := client.UnreadCount(connectionID)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+
+	t.Run("unknown connection", func(t *testing.T) {
+		client, err := New(newProvider(t, nil), msghandler.NewMockMsgServiceProvider())
+		require.NoError(t, err)
+
+		_, err = client.Send("unknown", "hello")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), myDID, theirDID).Return(errors.New("send error"))
+
+		client, err := New(newProvider(t, messenger), msghandler.NewMockMsgServiceProvider())
+		require.NoError(t, err)
+
+		_, err = client.Send(connectionID, "hello")
+		require.EqualError(t, err, "send basic message: send error")
+
+		page, err := client.Messages(connectionID)
+		require.NoError(t, err)
+		require.Zero(t, page.Total)
+	})
+}
+
+func TestClient_HandleInbound(t *testing.T) {
+	t.Run("received messages are persisted", func(t *testing.T) {
+		handled := make(chan basic.Message, 2)
+
+		registrar := msghandler.NewMockMsgServiceProvider()
+
+		client, err := New(newProvider(t, nil), registrar, WithMessageHandle(
+			func(msg basic.Message, _, _ string) error {
+				handled <- msg
+
+				return nil
+			}))
+		require.NoError(t, err)
+
+		msgSvc := registrar.Services()[0]
+
+		_, err = msgSvc.HandleInbound(service.NewDIDCommMsgMap(&basic.Message{
+			ID:       "msg-1",
+			Type:     basic.MessageRequestType,
+			SentTime: time.Now(),
+			Content:  "hello",
+		}), myDID, theirDID)
+		require.NoError(t, err)
+
+		_, err = msgSvc.HandleInbound(service.NewDIDCommMsgMap(&basic.Message{
+			ID:      "msg-2",
+			Type:    basic.MessageRequestType,
+			Content: "again",
+			Thread:  &decorator.Thread{ID: "msg-1"},
+		}), myDID, theirDID)
+		require.NoError(t, err)
+
+		require.Equal(t, "msg-1", (<-handled).ID)
+		require.Equal(t, "msg-2", (<-handled).ID)
+
+		page, err := client.Messages(connectionID, basic.WithThreadID("msg-1"), basic.WithUnreadOnly())
+		require.NoError(t, err)
+		require.Equal(t, 2, page.Total)
+		require.True(t, page.Records[0].Inbound)
+
+		require.NoError(t, client.MarkRead(connectionID, "msg-1"))
+
+		count, err := client.UnreadCount(connectionID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("messages outside of a connection are not persisted", func(t *testing.T) {
+		registrar := msghandler.NewMockMsgServiceProvider()
+
+		client, err := New(newProvider(t, nil), registrar)
+		require.NoError(t, err)
+
+		_, err = registrar.Services()[0].HandleInbound(service.NewDIDCommMsgMap(&basic.Message{
+			ID:   "msg-1",
+			Type: basic.MessageRequestType,
+		}), myDID, "did:example:unknown")
+		require.NoError(t, err)
+
+		page, err := client.Messages(connectionID)
+		require.NoError(t, err)
+		require.Zero(t, page.Total)
+	})
+
+	t.Run("connection lookup error", func(t *testing.T) {
+		registrar := msghandler.NewMockMsgServiceProvider()
+
+		_, err := New(&mockProvider{
+			store: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store:  map[string][]byte{},
+				ErrGet: errors.New("get error"),
+			}),
+			protocolStateStore: mockstore.NewMockStoreProvider(),
+		}, registrar)
+		require.NoError(t, err)
+
+		_, err = registrar.Services()[0].HandleInbound(service.NewDIDCommMsgMap(&basic.Message{
+			ID:   "msg-1",
+			Type: basic.MessageRequestType,
+		}), myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection ID")
+	})
+}
@@ -0,0 +1,32 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package basicmessage provides threaded, persisted conversations over the Basic Message Protocol 1.0:
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0095-basic-message.
+//
+// The client persists the sent and received messages per connection, with their thread IDs and read status.
+// It receives the basic messages through a message service registered to the framework's message registrar
+// (see msghandler.NewRegistrar and aries.WithMessageServiceProvider).
+//
+// 	client, err := basicmessage.New(ctx, msgRegistrar, basicmessage.WithMessageHandle(
+// 	 func(msg basic.Message, myDID, theirDID string) error {
+// 	   // notify the UI
+// 	   return nil
+// 	 }))
+// 	if err != nil {
+// 	 panic(err)
+// 	}
+//
+// 	record, err := client.Send(connectionID, "Hello")
+// 	// reply in the same conversation
+// 	_, err = client.Send(connectionID, "Are you there?", basicmessage.WithThreadID(record.ThreadID))
+//
+// 	// the 20 most recent unread messages of the conversation
+// 	page, err := client.Messages(connectionID, basic.WithThreadID(record.ThreadID), basic.WithUnreadOnly(),
+// 	 basic.WithPage(0, 20))
+//
+// 	err = client.MarkRead(connectionID, page.Records[0].Message.ID)
+package basicmessage
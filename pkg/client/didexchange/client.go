@@ -10,6 +10,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -18,6 +21,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
@@ -38,6 +42,7 @@ var ErrConnectionNotFound = errors.New("connection not found")
 type options struct {
 	routerConnections  []string
 	routerConnectionID string
+	legacy             bool
 }
 
 func applyOptions(args ...Opt) *options {
@@ -63,6 +68,14 @@ func WithRouterConnectionID(conn string) InvOpt {
 	}
 }
 
+// WithLegacyConnection creates an invitation to connect with the RFC 0160 connection protocol, for interop
+// with the agents that do not support did-exchange.
+func WithLegacyConnection() InvOpt {
+	return func(opts *options) {
+		opts.legacy = true
+	}
+}
+
 // WithRouterConnections allows you to specify the router connections.
 func WithRouterConnections(conns ...string) Opt {
 	return func(opts *options) {
@@ -82,6 +95,7 @@ type provider interface {
 	ServiceEndpoint() string
 	StorageProvider() storage.Provider
 	ProtocolStateStorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
 }
 
 // Client enable access to didexchange api.
@@ -90,8 +104,10 @@ type Client struct {
 	didexchangeSvc  protocolService
 	routeSvc        mediator.ProtocolService
 	kms             kms.KeyManager
+	vdRegistry      vdrapi.Registry
 	serviceEndpoint string
 	connectionStore *connection.Recorder
+	subscriptions   *service.ActionSubscriptions
 }
 
 // protocolService defines DID Exchange service.
@@ -112,6 +128,9 @@ type protocolService interface {
 
 	// CreateConnection saves the connection record.
 	CreateConnection(*connection.Record, *did.Doc) error
+
+	// ResumeAll triggers the action events for the pending invitations and requests.
+	ResumeAll(timeout time.Duration) error
 }
 
 // New return new instance of didexchange client.
@@ -146,8 +165,10 @@ func New(ctx provider) (*Client, error) {
 		didexchangeSvc:  didexchangeSvc,
 		routeSvc:        routeSvc,
 		kms:             ctx.KMS(),
+		vdRegistry:      ctx.VDRegistry(),
 		serviceEndpoint: ctx.ServiceEndpoint(),
 		connectionStore: connectionStore,
+		subscriptions:   service.NewActionSubscriptions(didexchangeSvc),
 	}, nil
 }
 
@@ -197,6 +218,10 @@ func (c *Client) CreateInvitation(label string, args ...InvOpt) (*Invitation, er
 		RoutingKeys:     routingKeys,
 	}
 
+	if opts.legacy {
+		invitation.Type = didexchange.LegacyInvitationMsgType
+	}
+
 	err = c.connectionStore.SaveInvitation(invitation.ID, invitation)
 	if err != nil {
 		return nil, fmt.Errorf("createInvitation: failed to save invitation: %w", err)
@@ -271,6 +296,17 @@ func (c *Client) AcceptExchangeRequest(connectionID, publicDID, label string, ar
 	return nil
 }
 
+// ResumeAll triggers the action events again for the invitations and requests that were pending when the agent
+// was stopped. It should be called once the action events are registered after the agent restart. The connections
+// pending longer than the given timeout are abandoned, zero timeout means they never expire.
+func (c *Client) ResumeAll(timeout time.Duration) error {
+	if err := c.didexchangeSvc.ResumeAll(timeout); err != nil {
+		return fmt.Errorf("did exchange client - resume all: %w", err)
+	}
+
+	return nil
+}
+
 // CreateImplicitInvitation enables invitee to create and send an exchange request using inviter public DID.
 func (c *Client) CreateImplicitInvitation(inviterLabel, inviterDID string, args ...Opt) (string, error) {
 	return c.didexchangeSvc.CreateImplicitInvitation(inviterLabel, inviterDID,
@@ -286,16 +322,58 @@ func (c *Client) CreateImplicitInvitationWithDID(inviter, invitee *DIDInfo) (str
 	return c.didexchangeSvc.CreateImplicitInvitation(inviter.Label, inviter.DID, invitee.Label, invitee.DID, nil)
 }
 
-// QueryConnections queries connections matching given criteria(parameters).
+// QueryConnections queries connections matching given criteria(parameters). The connections are sorted with the
+// Sort parameter and paged with the Offset and Limit parameters, CountConnections returning the total number of
+// matching connections.
 func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) {
-	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - query all connections from all criteria and
-	//  also results needs to be paged.
+	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - query all connections from all criteria.
+	records, err := c.queryConnectionRecords(request)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := request.Offset
+
+	switch {
+	case offset < 0:
+		offset = 0
+	case offset > len(records):
+		offset = len(records)
+	}
+
+	records = records[offset:]
+
+	if request.Limit > 0 && request.Limit < len(records) {
+		records = records[:request.Limit]
+	}
+
+	var result []*Connection
+
+	for _, record := range records {
+		result = append(result, &Connection{Record: record})
+	}
+
+	return result, nil
+}
+
+// CountConnections returns the number of connections matching given criteria(parameters), ignoring the paging
+// parameters.
+func (c *Client) CountConnections(request *QueryConnectionsParams) (int, error) {
+	records, err := c.queryConnectionRecords(request)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(records), nil
+}
+
+func (c *Client) queryConnectionRecords(request *QueryConnectionsParams) ([]*connection.Record, error) {
 	records, err := c.connectionStore.QueryConnectionRecords()
 	if err != nil {
 		return nil, fmt.Errorf("failed query connections: %w", err)
 	}
 
-	var result []*Connection
+	var result []*connection.Record
 
 	for _, record := range records {
 		if request.State != "" && request.State != record.State {
@@ -310,12 +388,57 @@ func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connectio
 			continue
 		}
 
-		result = append(result, &Connection{Record: record})
+		if request.TheirLabel != "" && request.TheirLabel != record.TheirLabel {
+			continue
+		}
+
+		if request.InvitationID != "" && request.InvitationID != record.InvitationID {
+			continue
+		}
+
+		result = append(result, record)
+	}
+
+	if err := sortConnectionRecords(result, request.Sort); err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
 
+// nolint: gochecknoglobals
+var connectionSortFields = map[string]func(*connection.Record) string{
+	"connection_id": func(r *connection.Record) string { return r.ConnectionID },
+	"state":         func(r *connection.Record) string { return r.State },
+	"their_label":   func(r *connection.Record) string { return r.TheirLabel },
+	"my_did":        func(r *connection.Record) string { return r.MyDID },
+	"their_did":     func(r *connection.Record) string { return r.TheirDID },
+}
+
+func sortConnectionRecords(records []*connection.Record, sortParam string) error {
+	if sortParam == "" {
+		return nil
+	}
+
+	field := strings.TrimPrefix(sortParam, "-")
+	desc := field != sortParam
+
+	value, ok := connectionSortFields[field]
+	if !ok {
+		return fmt.Errorf("cannot sort connections by %s", field)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if desc {
+			return value(records[j]) < value(records[i])
+		}
+
+		return value(records[i]) < value(records[j])
+	})
+
+	return nil
+}
+
 // GetConnection fetches single connection record for given id.
 func (c *Client) GetConnection(connectionID string) (*Connection, error) {
 	conn, err := c.connectionStore.GetConnectionRecord(connectionID)
@@ -389,6 +512,18 @@ func (c *Client) RemoveConnection(connectionID string) error {
 	return nil
 }
 
+// SetAutoAccept configures which inbound credential offers, presentation requests and didexchange requests
+// of the connection are accepted without triggering an action event. It overrides the goal code and
+// the global auto-accept configuration of the agent for this connection.
+func (c *Client) SetAutoAccept(connectionID string, autoAccept connection.AutoAccept) error {
+	err := c.connectionStore.SaveAutoAccept(connectionID, autoAccept)
+	if err != nil {
+		return fmt.Errorf("cannot save auto accept configuration of the connection: err=%w", err)
+	}
+
+	return nil
+}
+
 // ConnectionOption allows you to customize details of the connection record.
 type ConnectionOption func(*Connection)
 
@@ -433,3 +568,27 @@ func WithImplicit(i bool) ConnectionOption {
 		c.Implicit = i
 	}
 }
+
+// SubscribeInvitations returns a subscription for the invitations received by the invitee, matching all the given
+// filters (e.g. service.FilterByProperty("connectionID", connectionID)). The client registers the action events of
+// the service on the first subscription, see service.ActionSubscriptions.
+func (c *Client) SubscribeInvitations(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, didexchange.InvitationMsgType, didexchange.LegacyInvitationMsgType)
+}
+
+// SubscribeRequests returns a subscription for the exchange requests received by the inviter, matching all the
+// given filters.
+func (c *Client) SubscribeRequests(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, didexchange.RequestMsgType, didexchange.LegacyRequestMsgType)
+}
+
+// CloseSubscriptions closes all the subscriptions of the client and unregisters their action router, the action
+// events can then be registered again.
+func (c *Client) CloseSubscriptions() error {
+	return c.subscriptions.Close()
+}
+
+func (c *Client) subscribe(filters []service.ActionFilter, msgTypes ...string) (*service.ActionSubscription, error) {
+	return c.subscriptions.Subscribe(append([]service.ActionFilter{service.FilterByMessageType(msgTypes...)},
+		filters...)...)
+}
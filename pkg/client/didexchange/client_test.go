@@ -166,6 +166,11 @@ func TestClient_CreateInvitation(t *testing.T) {
 		require.NotEmpty(t, inviteReq.ID)
 		require.Nil(t, inviteReq.RoutingKeys)
 		require.Equal(t, "endpoint", inviteReq.ServiceEndpoint)
+		require.Equal(t, didexchange.InvitationMsgType, inviteReq.Type)
+
+		inviteReq, err = c.CreateInvitation("agent", WithLegacyConnection())
+		require.NoError(t, err)
+		require.Equal(t, didexchange.LegacyInvitationMsgType, inviteReq.Type)
 	})
 
 	t.Run("test error from createSigningKey", func(t *testing.T) {
@@ -677,6 +682,43 @@ func TestClient_CreateConnection(t *testing.T) {
 	})
 }
 
+func TestClient_SetAutoAccept(t *testing.T) {
+	svc, err := didexchange.New(&mockprotocol.MockProvider{
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+	})
+	require.NoError(t, err)
+
+	c, err := New(&mockprovider.Provider{
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ServiceMap: map[string]interface{}{
+			didexchange.DIDExchange: svc,
+			mediator.Coordination:   &mockroute.MockMediatorSvc{},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("test success", func(t *testing.T) {
+		connRec := &connection.Record{ConnectionID: "id1", ThreadID: "thid1", State: "complete"}
+		require.NoError(t, c.connectionStore.SaveConnectionRecord(connRec))
+
+		autoAccept := connection.AutoAccept{connection.AutoAcceptCredentialOffer: true}
+		require.NoError(t, c.SetAutoAccept(connRec.ConnectionID, autoAccept))
+
+		conn, err := c.GetConnection(connRec.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, autoAccept, conn.AutoAccept)
+	})
+
+	t.Run("test error data not found", func(t *testing.T) {
+		err := c.SetAutoAccept("unknown", connection.AutoAccept{connection.AutoAcceptCredentialOffer: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot save auto accept configuration of the connection")
+	})
+}
+
 func TestClient_RemoveConnection(t *testing.T) {
 	t.Run("test success", func(t *testing.T) {
 		connID := "id1"
@@ -832,6 +874,40 @@ func TestClient_CreateImplicitInvitation(t *testing.T) {
 	})
 }
 
+func TestClient_ResumeAll(t *testing.T) {
+	t.Run("test success", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: &mocksvc.MockDIDExchangeSvc{},
+				mediator.Coordination:   &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, c.ResumeAll(time.Hour))
+	})
+
+	t.Run("test error from service", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: &mocksvc.MockDIDExchangeSvc{
+					ResumeAllErr: errors.New("resume error"),
+				},
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		err = c.ResumeAll(time.Hour)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resume error")
+	})
+}
+
 func TestClient_CreateImplicitInvitationWithDID(t *testing.T) {
 	inviter := &DIDInfo{Label: "alice", DID: "did:example:alice"}
 	invitee := &DIDInfo{Label: "bob", DID: "did:example:bob"}
@@ -1031,6 +1107,82 @@ func TestClient_QueryConnectionsByParams(t *testing.T) {
 			require.Equal(t, result.MyDID, params.MyDID)
 			require.Equal(t, result.TheirDID, params.TheirDID)
 		}
+
+		total, err := c.CountConnections(&QueryConnectionsParams{State: state, Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, countWithState, total)
+
+		all, err := c.QueryConnections(&QueryConnectionsParams{State: state})
+		require.NoError(t, err)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{State: state, Offset: 1, Limit: 3})
+		require.NoError(t, err)
+		require.Equal(t, all[1:4], results)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{State: state, Offset: 3, Limit: 3})
+		require.NoError(t, err)
+		require.Equal(t, all[3:], results)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{State: state, Offset: countWithState})
+		require.NoError(t, err)
+		require.Empty(t, results)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{State: state, Offset: -1})
+		require.NoError(t, err)
+		require.Equal(t, all, results)
+	})
+
+	t.Run("test filter and sort connections", func(t *testing.T) {
+		svc, err := didexchange.New(&mockprotocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		storageProvider := mockstore.NewMockStoreProvider()
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			StorageProviderValue:              storageProvider,
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: svc,
+				mediator.Coordination:   &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		for i, label := range []string{"bob", "alice", "carol", "alice"} {
+			val, e := json.Marshal(&connection.Record{
+				ConnectionID: fmt.Sprint(i),
+				State:        "completed",
+				TheirLabel:   label,
+				InvitationID: "invitation-" + label,
+			})
+			require.NoError(t, e)
+			require.NoError(t, storageProvider.Store.Put(fmt.Sprintf("conn_abc%d", i), val))
+		}
+
+		results, err := c.QueryConnections(&QueryConnectionsParams{TheirLabel: "alice"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{InvitationID: "invitation-carol"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "2", results[0].ConnectionID)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{Sort: "their_label", Offset: 1, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, "alice", results[0].TheirLabel)
+		require.Equal(t, "bob", results[1].TheirLabel)
+
+		results, err = c.QueryConnections(&QueryConnectionsParams{Sort: "-their_label"})
+		require.NoError(t, err)
+		require.Equal(t, "carol", results[0].TheirLabel)
+
+		_, err = c.QueryConnections(&QueryConnectionsParams{Sort: "label"})
+		require.EqualError(t, err, "cannot sort connections by label")
 	})
 
 	t.Run("test get connections error", func(t *testing.T) {
@@ -1059,6 +1211,9 @@ func TestClient_QueryConnectionsByParams(t *testing.T) {
 		results, err := c.QueryConnections(&QueryConnectionsParams{})
 		require.Error(t, err)
 		require.Empty(t, results)
+
+		_, err = c.CountConnections(&QueryConnectionsParams{})
+		require.Error(t, err)
 	})
 }
 
@@ -1378,3 +1533,41 @@ func newPeerDID(t *testing.T) *did.Doc {
 
 	return d
 }
+
+func TestClient_Subscribe(t *testing.T) {
+	svc, err := didexchange.New(&mockprotocol.MockProvider{
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+	})
+	require.NoError(t, err)
+
+	c, err := New(&mockprovider.Provider{
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ServiceMap: map[string]interface{}{
+			didexchange.DIDExchange: svc,
+			mediator.Coordination:   &mockroute.MockMediatorSvc{},
+		},
+	})
+	require.NoError(t, err)
+
+	invitations, err := c.SubscribeInvitations()
+	require.NoError(t, err)
+
+	_, err = c.SubscribeRequests()
+	require.NoError(t, err)
+
+	// the subscriptions share the action events of the service
+	require.EqualError(t, c.RegisterActionEvent(make(chan service.DIDCommAction)), service.ErrChannelRegistered.Error())
+
+	require.NoError(t, c.CloseSubscriptions())
+
+	select {
+	case <-invitations.Done():
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	require.NoError(t, c.RegisterActionEvent(make(chan service.DIDCommAction)))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didexchange
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+
+// ErrConnectionExists is returned when importing a connection the agent already has.
+var ErrConnectionExists = errors.New("connection already exists")
+
+// ConnectionExport is a completed connection exported from an agent, e.g. to back it up or to migrate it to another
+// deployment of the agent. It holds the connection record (the routing configuration of the other party and the
+// metadata of the connection) and both DID documents (the DID document of the agent holds its own routing
+// configuration). The private keys aren't exported: the export references them by their ID in the KMS, they must be
+// available to the KMS of the agent importing the connection (e.g. a shared remote KMS, or migrated beforehand).
+type ConnectionExport struct {
+	Record      *connection.Record `json:"record"`
+	MyDIDDoc    *did.Doc           `json:"myDIDDoc"`
+	TheirDIDDoc *did.Doc           `json:"theirDIDDoc"`
+	// KeyIDs are the IDs in the KMS of the Ed25519 keys of the DID of the agent.
+	KeyIDs []string `json:"keyIDs,omitempty"`
+}
+
+// ExportConnection exports a completed connection, see ConnectionExport.
+func (c *Client) ExportConnection(connectionID string) (*ConnectionExport, error) {
+	record, err := c.connectionStore.GetConnectionRecord(connectionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrConnectionNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("exportConnection: fetch connection %s: %w", connectionID, err)
+	}
+
+	if record.State != connection.StateNameCompleted {
+		return nil, fmt.Errorf("exportConnection: connection %s isn't completed: %s", connectionID, record.State)
+	}
+
+	myDoc, err := c.vdRegistry.Resolve(record.MyDID)
+	if err != nil {
+		return nil, fmt.Errorf("exportConnection: resolve my DID %s: %w", record.MyDID, err)
+	}
+
+	theirDoc, err := c.vdRegistry.Resolve(record.TheirDID)
+	if err != nil {
+		return nil, fmt.Errorf("exportConnection: resolve their DID %s: %w", record.TheirDID, err)
+	}
+
+	keyIDs, err := ed25519KeyIDs(myDoc)
+	if err != nil {
+		return nil, fmt.Errorf("exportConnection: %w", err)
+	}
+
+	return &ConnectionExport{
+		Record:      record,
+		MyDIDDoc:    myDoc,
+		TheirDIDDoc: theirDoc,
+		KeyIDs:      keyIDs,
+	}, nil
+}
+
+// ImportConnection imports a connection exported from another agent, returning its ID. The connection keeps its ID,
+// the import fails with ErrConnectionExists if the agent already has it, and it fails if the keys of the connection
+// aren't available to the KMS of the agent.
+func (c *Client) ImportConnection(export *ConnectionExport) (string, error) {
+	if err := validateExport(export); err != nil {
+		return "", fmt.Errorf("importConnection: %w", err)
+	}
+
+	record := export.Record
+
+	_, err := c.connectionStore.GetConnectionRecord(record.ConnectionID)
+	if err == nil {
+		return "", fmt.Errorf("importConnection: %w: %s", ErrConnectionExists, record.ConnectionID)
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return "", fmt.Errorf("importConnection: fetch connection %s: %w", record.ConnectionID, err)
+	}
+
+	for _, kid := range export.KeyIDs {
+		if _, err = c.kms.Get(kid); err != nil {
+			return "", fmt.Errorf("importConnection: key %s of the connection: %w", kid, err)
+		}
+	}
+
+	// the connection store maps my DID to its keys by resolving it
+	if err = c.vdRegistry.Store(export.MyDIDDoc); err != nil {
+		return "", fmt.Errorf("importConnection: store my DID document: %w", err)
+	}
+
+	if err = c.didexchangeSvc.CreateConnection(record, export.TheirDIDDoc); err != nil {
+		return "", fmt.Errorf("importConnection: %w", err)
+	}
+
+	return record.ConnectionID, nil
+}
+
+func validateExport(export *ConnectionExport) error {
+	switch {
+	case export == nil || export.Record == nil:
+		return errors.New("missing connection record")
+	case export.Record.State != connection.StateNameCompleted:
+		return fmt.Errorf("connection %s isn't completed: %s", export.Record.ConnectionID, export.Record.State)
+	case export.MyDIDDoc == nil || export.MyDIDDoc.ID != export.Record.MyDID:
+		return fmt.Errorf("missing DID document of my DID %s", export.Record.MyDID)
+	case export.TheirDIDDoc == nil || export.TheirDIDDoc.ID != export.Record.TheirDID:
+		return fmt.Errorf("missing DID document of their DID %s", export.Record.TheirDID)
+	}
+
+	return nil
+}
+
+// ed25519KeyIDs returns the KMS IDs of the Ed25519 keys of the DID document.
+func ed25519KeyIDs(doc *did.Doc) ([]string, error) {
+	seen := map[string]bool{}
+
+	var kids []string
+
+	for _, verifications := range doc.VerificationMethods() {
+		for _, v := range verifications {
+			if v.VerificationMethod.Type != ed25519VerificationKey2018 {
+				continue
+			}
+
+			kid, err := localkms.CreateKID(v.VerificationMethod.Value, kms.ED25519Type)
+			if err != nil {
+				return nil, fmt.Errorf("key ID of %s: %w", v.VerificationMethod.ID, err)
+			}
+
+			if !seen[kid] {
+				seen[kid] = true
+				kids = append(kids, kid)
+			}
+		}
+	}
+
+	sort.Strings(kids)
+
+	return kids, nil
+}
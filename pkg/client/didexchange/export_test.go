@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didexchange
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/testutil/sim"
+)
+
+func TestClient_ExportConnection(t *testing.T) {
+	net := sim.New(1)
+
+	alice, aliceCtx := newSimClient(t, net, "alice")
+	bob, _ := newSimClient(t, net, "bob")
+
+	inv, err := alice.CreateInvitation("alice")
+	require.NoError(t, err)
+
+	_, err = bob.HandleInvitation(inv)
+	require.NoError(t, err)
+
+	require.NoError(t, net.Run())
+
+	conns, err := alice.QueryConnections(&QueryConnectionsParams{})
+	require.NoError(t, err)
+	require.Len(t, conns, 1)
+
+	connID := conns[0].ConnectionID
+
+	t.Run("export and import", func(t *testing.T) {
+		export, err := alice.ExportConnection(connID)
+		require.NoError(t, err)
+		require.Equal(t, conns[0].Record, export.Record)
+		require.Equal(t, export.Record.MyDID, export.MyDIDDoc.ID)
+		require.Equal(t, export.Record.TheirDID, export.TheirDIDDoc.ID)
+		require.NotEmpty(t, export.KeyIDs)
+
+		exportBytes, err := json.Marshal(export)
+		require.NoError(t, err)
+
+		var imported ConnectionExport
+		require.NoError(t, json.Unmarshal(exportBytes, &imported))
+
+		// carol is another deployment of alice, sharing her KMS
+		carol, carolCtx := newSimClient(t, net, "carol", aries.WithKMS(func(kms.Provider) (kms.KeyManager, error) {
+			return aliceCtx.KMS(), nil
+		}))
+
+		id, err := carol.ImportConnection(&imported)
+		require.NoError(t, err)
+		require.Equal(t, connID, id)
+
+		conn, err := carol.GetConnection(id)
+		require.NoError(t, err)
+		require.Equal(t, conns[0].Record, conn.Record)
+
+		lookup, err := connection.NewLookup(carolCtx)
+		require.NoError(t, err)
+
+		id, err = lookup.GetConnectionIDByDIDs(conn.MyDID, conn.TheirDID)
+		require.NoError(t, err)
+		require.Equal(t, connID, id)
+
+		reexport, err := carol.ExportConnection(connID)
+		require.NoError(t, err)
+		require.Equal(t, export.KeyIDs, reexport.KeyIDs)
+
+		_, err = carol.ImportConnection(&imported)
+		require.True(t, errors.Is(err, ErrConnectionExists))
+	})
+
+	t.Run("import without the keys", func(t *testing.T) {
+		export, err := alice.ExportConnection(connID)
+		require.NoError(t, err)
+
+		dave, _ := newSimClient(t, net, "dave")
+
+		_, err = dave.ImportConnection(export)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "importConnection: key "+export.KeyIDs[0])
+
+		_, err = dave.GetConnection(connID)
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("export unknown connection", func(t *testing.T) {
+		_, err := alice.ExportConnection("unknown")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("import invalid export", func(t *testing.T) {
+		export, err := alice.ExportConnection(connID)
+		require.NoError(t, err)
+
+		record := *export.Record
+		record.State = "requested"
+
+		tests := []struct {
+			name   string
+			export *ConnectionExport
+			err    string
+		}{
+			{name: "nil", err: "missing connection record"},
+			{name: "no record", export: &ConnectionExport{}, err: "missing connection record"},
+			{
+				name:   "pending connection",
+				export: &ConnectionExport{Record: &record},
+				err:    "isn't completed: requested",
+			},
+			{
+				name:   "no DID documents",
+				export: &ConnectionExport{Record: export.Record},
+				err:    "missing DID document of my DID " + export.Record.MyDID,
+			},
+			{
+				name: "wrong DID document",
+				export: &ConnectionExport{
+					Record: export.Record, MyDIDDoc: export.MyDIDDoc, TheirDIDDoc: &did.Doc{ID: "did:peer:123"},
+				},
+				err: "missing DID document of their DID " + export.Record.TheirDID,
+			},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := alice.ImportConnection(tc.export)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			})
+		}
+	})
+}
+
+func newSimClient(t *testing.T, net *sim.Network, name string, opts ...aries.Option) (*Client, *context.Provider) {
+	t.Helper()
+
+	a, err := net.Agent(name, opts...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, net.Run())
+		require.NoError(t, a.Close())
+	})
+
+	ctx, err := a.Context()
+	require.NoError(t, err)
+
+	c, err := New(ctx)
+	require.NoError(t, err)
+
+	actions := make(chan service.DIDCommAction)
+	require.NoError(t, c.RegisterActionEvent(actions))
+
+	go service.AutoExecuteActionEvent(actions)
+
+	return c, ctx
+}
@@ -37,6 +37,22 @@ type QueryConnectionsParams struct {
 
 	// TheirRole is other party's role
 	TheirRole string `json:"their_role,omitempty"`
+
+	// TheirLabel is other party's label
+	TheirLabel string `json:"their_label,omitempty"`
+
+	// InvitationID is the ID of the invitation the connection was established from
+	InvitationID string `json:"invitation_id,omitempty"`
+
+	// Sort is the field to sort the connections by (connection_id, state, their_label, my_did or their_did),
+	// prefixed with "-" for the descending order
+	Sort string `json:"sort,omitempty"`
+
+	// Offset is the number of matching connections to skip
+	Offset int `json:"offset,omitempty"`
+
+	// Limit is the maximum number of connections to return, all the connections are returned when zero
+	Limit int `json:"limit,omitempty"`
 }
 
 // Connection model
@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+type (
+	// Query matches the features of given type whose ID matches Match. Match may end with the "*" wildcard.
+	Query discoverfeatures.Query
+	// Disclosure describes a feature supported by the other agent.
+	Disclosure discoverfeatures.Disclosure
+	// Props are the properties of the events fired for received disclosures.
+	Props discoverfeatures.Props
+)
+
+const (
+	// FeatureTypeProtocol is the feature type of protocols, identified by their PIURI.
+	FeatureTypeProtocol = discoverfeatures.FeatureTypeProtocol
+	// FeatureTypeGoalCode is the feature type of goal codes.
+	FeatureTypeGoalCode = discoverfeatures.FeatureTypeGoalCode
+	// FeatureTypeDecorator is the feature type of decorators (e.g. "~thread").
+	FeatureTypeDecorator = discoverfeatures.FeatureTypeDecorator
+)
+
+var errEmptyQueries = errors.New("at least one query is required")
+
+// Provider contains dependencies for the protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the discover features service.
+type ProtocolService interface {
+	service.DIDComm
+	Register(featureType, id string, roles ...string)
+	Disclose(queries ...*discoverfeatures.Query) []*discoverfeatures.Disclosure
+}
+
+// Client enable access to discover features API
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0557-discover-features-v2
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New returns new instance of the discover features client.
+func New(ctx Provider) (*Client, error) {
+	raw, err := ctx.Service(discoverfeatures.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := raw.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to discover features service failed")
+	}
+
+	return &Client{
+		Event:   svc,
+		service: svc,
+	}, nil
+}
+
+// Query sends the queries to the other agent. The disclosures are delivered as message events
+// whose properties' ThreadID is the returned ID of the queries message.
+func (c *Client) Query(queries []*Query, myDID, theirDID string) (string, error) {
+	if len(queries) == 0 {
+		return "", errEmptyQueries
+	}
+
+	msg := &discoverfeatures.Queries{
+		Type:    discoverfeatures.QueriesMsgType,
+		ID:      uuid.New().String(),
+		Queries: make([]*discoverfeatures.Query, len(queries)),
+	}
+
+	for i, q := range queries {
+		msg.Queries[i] = (*discoverfeatures.Query)(q)
+	}
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(msg), myDID, theirDID)
+}
+
+// Register adds a feature (e.g. a goal code) to the features disclosed by the agent. The protocols of the
+// framework's services and the supported decorators are disclosed without registration.
+func (c *Client) Register(featureType, id string, roles ...string) {
+	c.service.Register(featureType, id, roles...)
+}
+
+// Disclose returns the agent's own features matching the queries.
+func (c *Client) Disclose(queries ...*Query) []*Disclosure {
+	q := make([]*discoverfeatures.Query, len(queries))
+	for i := range queries {
+		q[i] = (*discoverfeatures.Query)(queries[i])
+	}
+
+	disclosures := c.service.Disclose(q...)
+
+	result := make([]*Disclosure, len(disclosures))
+	for i := range disclosures {
+		result[i] = (*Disclosure)(disclosures[i])
+	}
+
+	return result
+}
@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/discoverfeatures"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func TestNew(t *testing.T) {
+	const errMsg = "test err"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, errors.New(errMsg))
+		_, err := New(provider)
+		require.EqualError(t, err, errMsg)
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to discover features service failed")
+	})
+}
+
+func TestClient_Query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, discoverfeatures.QueriesMsgType, msg.Type())
+
+				queries := &discoverfeatures.Queries{}
+				require.NoError(t, msg.Decode(queries))
+				require.Equal(t, []*discoverfeatures.Query{{FeatureType: FeatureTypeProtocol, Match: "*"}},
+					queries.Queries)
+
+				return msg.ID(), nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		id, err := client.Query([]*Query{{FeatureType: FeatureTypeProtocol, Match: "*"}}, Alice, Bob)
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+	})
+
+	t.Run("Empty queries", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.Query(nil, Alice, Bob)
+		require.EqualError(t, err, errEmptyQueries.Error())
+	})
+}
+
+func TestClient_RegisterAndDisclose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().Register(FeatureTypeGoalCode, "aries.vc.issue", "issuer")
+	svc.EXPECT().Disclose(&discoverfeatures.Query{FeatureType: FeatureTypeGoalCode, Match: "aries.*"}).
+		Return([]*discoverfeatures.Disclosure{{FeatureType: FeatureTypeGoalCode, ID: "aries.vc.issue"}})
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	client.Register(FeatureTypeGoalCode, "aries.vc.issue", "issuer")
+
+	result := client.Disclose(&Query{FeatureType: FeatureTypeGoalCode, Match: "aries.*"})
+	require.Equal(t, []*Disclosure{{FeatureType: FeatureTypeGoalCode, ID: "aries.vc.issue"}}, result)
+}
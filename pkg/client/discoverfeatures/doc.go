@@ -0,0 +1,36 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package discoverfeatures provides support for the Discover Features Protocol 2.0:
+// https://github.com/hyperledger/aries-rfcs/blob/master/features/0557-discover-features-v2/README.md.
+//
+// Agents disclose the protocols of the framework's registered services and the decorators supported by the
+// framework. Other features, like goal codes, are registered with the client:
+//
+// 	client, err := discoverfeatures.New(ctx)
+// 	if err != nil {
+// 	 panic(err)
+// 	}
+//
+// 	client.Register(discoverfeatures.FeatureTypeGoalCode, "aries.vc.issue")
+//
+// Query the features of the other agent and wait for its disclosures:
+//
+// 	events := make(chan service.StateMsg)
+// 	client.RegisterMsgEvent(events)
+//
+// 	queryID, err := client.Query([]*discoverfeatures.Query{{
+// 	 FeatureType: discoverfeatures.FeatureTypeProtocol,
+// 	 Match:       "https://didcomm.org/issue-credential/*",
+// 	}}, myDID, theirDID)
+//
+// 	for event := range events {
+// 	  props := event.Properties.(discoverfeatures.Props)
+// 	  if props.ThreadID() == queryID {
+// 	    fmt.Println(props.Disclosures())
+// 	  }
+// 	}
+package discoverfeatures
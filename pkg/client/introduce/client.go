@@ -97,6 +97,19 @@ func (c *Client) SendProposalWithOOBRequest(req *outofband.Request, recipient *R
 	return c.service.HandleOutbound(proposal, recipient.MyDID, recipient.TheirDID)
 }
 
+// SendProposalWithOOBAttachment sends a proposal with the out-of-band request attached to the introducee
+// (the client has published an out-of-band request). The introducee uses the request as soon as the proposal is
+// approved, so the introducer does not need to deliver it after receiving the response.
+func (c *Client) SendProposalWithOOBAttachment(req *outofband.Request, recipient *Recipient) (string, error) {
+	_recipient := introduce.Recipient(*recipient)
+	_req := outofbandsvc.Request(*req)
+
+	proposal := introduce.CreateProposal(&_recipient)
+	introduce.WrapWithOOBAttachment(proposal, &_req)
+
+	return c.service.HandleOutbound(proposal, recipient.MyDID, recipient.TheirDID)
+}
+
 // SendRequest sends a request.
 // Sending a request means that the introducee is willing to share their own out-of-band message.
 func (c *Client) SendRequest(to *PleaseIntroduceTo, myDID, theirDID string) (string, error) {
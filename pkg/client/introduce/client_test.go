@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/client/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
+	outofbandsvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 	mocksintroduce "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/introduce"
 )
 
@@ -156,6 +157,39 @@ func TestClient_SendProposalWithOOBRequest(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_SendProposalWithOOBAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocksintroduce.NewMockProvider(ctrl)
+
+	svc := mocksintroduce.NewMockProtocolService(ctrl)
+	svc.EXPECT().
+		HandleOutbound(gomock.Any(), "firstMyDID", "firstTheirDID").
+		DoAndReturn(func(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+			require.Equal(t, msg.Type(), introduce.ProposalMsgType)
+			require.NotEmpty(t, msg.Metadata())
+
+			proposal := introduce.Proposal{}
+			require.NoError(t, msg.(service.DIDCommMsgMap).Decode(&proposal))
+			require.Len(t, proposal.Attachments, 1)
+
+			return expectedPIID, nil
+		})
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	req := &outofband.Request{Type: outofbandsvc.RequestMsgType}
+	piid, err := client.SendProposalWithOOBAttachment(req, &Recipient{
+		MyDID:    "firstMyDID",
+		TheirDID: "firstTheirDID",
+	})
+	require.Equal(t, expectedPIID, piid)
+	require.NoError(t, err)
+}
+
 func TestClient_SendRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -27,8 +27,10 @@ SPDX-License-Identifier: Apache-2.0
 // 	}
 //
 // Possible use cases:
-// 1) The introducer wants to commit an introduction. To do that SendProposal or SendProposalWithOOBRequest
-// functions should be used. SendProposalWithOOBRequest is used in case if introducer has a public out-of-band request.
+// 1) The introducer wants to commit an introduction. To do that SendProposal, SendProposalWithOOBRequest or
+// SendProposalWithOOBAttachment functions should be used. SendProposalWithOOBRequest is used in case if introducer
+// has a public out-of-band request. SendProposalWithOOBAttachment attaches that request to the proposal (skip proposal),
+// the introducee connects as soon as the proposal is approved.
 // Otherwise, SendProposal function is used. A request, in that case, should be provided by one of the introducees.
 // 2) Introducee asks the introducer about the agent. SendRequest function is used to do that.
 //
@@ -8,6 +8,8 @@ package issuecredential
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
@@ -38,6 +40,9 @@ type (
 	IssueCredential issuecredential.IssueCredential
 	// Action contains helpful information about action.
 	Action issuecredential.Action
+	// Ack is sent by the Holder to acknowledge the issued credentials, it reports the status of each credential
+	// when several credentials are issued in the same protocol instance.
+	Ack issuecredential.Ack
 )
 
 // Provider contains dependencies for the issuecredential protocol and is typically created by using aries.Context().
@@ -51,12 +56,14 @@ type ProtocolService interface {
 	Actions() ([]issuecredential.Action, error)
 	ActionContinue(piID string, opt issuecredential.Opt) error
 	ActionStop(piID string, err error) error
+	ResumeAll(timeout time.Duration) error
 }
 
 // Client enable access to issuecredential API.
 type Client struct {
 	service.Event
-	service ProtocolService
+	service       ProtocolService
+	subscriptions *service.ActionSubscriptions
 }
 
 // New return new instance of the issuecredential client.
@@ -72,8 +79,9 @@ func New(ctx Provider) (*Client, error) {
 	}
 
 	return &Client{
-		Event:   svc,
-		service: svc,
+		Event:         svc,
+		service:       svc,
+		subscriptions: service.NewActionSubscriptions(svc),
 	}, nil
 }
 
@@ -92,23 +100,42 @@ func (c *Client) Actions() ([]Action, error) {
 	return result, nil
 }
 
-// SendOffer is used by the Issuer to send an offer.
+// ResumeAll triggers the action events again for the actions that were pending when the agent was stopped.
+// It should be called once the action events are registered after the agent restart. The actions pending
+// longer than the given timeout are stopped, zero timeout means the actions never expire.
+func (c *Client) ResumeAll(timeout time.Duration) error {
+	if err := c.service.ResumeAll(timeout); err != nil {
+		return fmt.Errorf("resume all: %w", err)
+	}
+
+	return nil
+}
+
+// SendOffer is used by the Issuer to send an offer. The credential preview is validated before sending.
 func (c *Client) SendOffer(offer *OfferCredential, myDID, theirDID string) (string, error) {
 	if offer == nil {
 		return "", errEmptyOffer
 	}
 
+	if err := offer.CredentialPreview.Validate(); err != nil {
+		return "", fmt.Errorf("invalid credential preview: %w", err)
+	}
+
 	offer.Type = issuecredential.OfferCredentialMsgType
 
 	return c.service.HandleOutbound(service.NewDIDCommMsgMap(offer), myDID, theirDID)
 }
 
-// SendProposal is used by the Holder to send a proposal.
+// SendProposal is used by the Holder to send a proposal. The credential proposal is validated before sending.
 func (c *Client) SendProposal(proposal *ProposeCredential, myDID, theirDID string) (string, error) {
 	if proposal == nil {
 		return "", errEmptyProposal
 	}
 
+	if err := proposal.CredentialProposal.Validate(); err != nil {
+		return "", fmt.Errorf("invalid credential proposal: %w", err)
+	}
+
 	proposal.Type = issuecredential.ProposeCredentialMsgType
 
 	return c.service.HandleOutbound(service.NewDIDCommMsgMap(proposal), myDID, theirDID)
@@ -157,6 +184,13 @@ func (c *Client) NegotiateProposal(piID string, msg *ProposeCredential) error {
 // AcceptRequest is used when the Issuer is willing to accept the request.
 // NOTE: For async usage.
 func (c *Client) AcceptRequest(piID string, msg *IssueCredential) error {
+	if msg != nil {
+		issue := issuecredential.IssueCredential(*msg)
+		if err := issue.Validate(); err != nil {
+			return fmt.Errorf("invalid issue credential: %w", err)
+		}
+	}
+
 	return c.service.ActionContinue(piID, WithIssueCredential(msg))
 }
 
@@ -172,6 +206,14 @@ func (c *Client) AcceptCredential(piID string, names ...string) error {
 	return c.service.ActionContinue(piID, WithFriendlyNames(names...))
 }
 
+// AcceptCredentialExcept is used when the Holder is willing to accept only some of the credentials issued
+// in the same protocol instance. The credentials with the given attachment IDs are not saved and are reported
+// as rejected in the ack, the names are given to the accepted credentials.
+// NOTE: For async usage.
+func (c *Client) AcceptCredentialExcept(piID string, rejectedAttachIDs []string, names ...string) error {
+	return c.service.ActionContinue(piID, WithRejectedCredentials(rejectedAttachIDs, names...))
+}
+
 // DeclineCredential is used when the Holder does not want to accept the IssueCredential.
 // NOTE: For async usage.
 func (c *Client) DeclineCredential(piID, reason string) error {
@@ -220,3 +262,51 @@ func WithIssueCredential(msg *IssueCredential) issuecredential.Opt {
 func WithFriendlyNames(names ...string) issuecredential.Opt {
 	return issuecredential.WithFriendlyNames(names...)
 }
+
+// WithRejectedCredentials allows accepting only some of the issued credentials, the credentials with the given
+// attachment IDs are rejected and the names are given to the accepted credentials.
+// USAGE: This function should be used when the Holder receives IssueCredential message.
+func WithRejectedCredentials(attachIDs []string, names ...string) issuecredential.Opt {
+	return issuecredential.WithRejectedCredentials(attachIDs, names...)
+}
+
+// SubscribeProposals returns a subscription for the credential proposals received by the issuer, matching all the
+// given filters (e.g. service.FilterByConnection). The client registers the action events of the service on the
+// first subscription, see service.ActionSubscriptions.
+func (c *Client) SubscribeProposals(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, issuecredential.ProposeCredentialMsgType)
+}
+
+// SubscribeOffers returns a subscription for the credential offers received by the holder, matching all the given
+// filters.
+func (c *Client) SubscribeOffers(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, issuecredential.OfferCredentialMsgType)
+}
+
+// SubscribeRequests returns a subscription for the credential requests received by the issuer, matching all the
+// given filters.
+func (c *Client) SubscribeRequests(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, issuecredential.RequestCredentialMsgType)
+}
+
+// SubscribeCredentials returns a subscription for the credentials issued to the holder, matching all the given
+// filters.
+func (c *Client) SubscribeCredentials(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, issuecredential.IssueCredentialMsgType)
+}
+
+// SubscribeProblemReports returns a subscription for the problem reports received, matching all the given filters.
+func (c *Client) SubscribeProblemReports(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, issuecredential.ProblemReportMsgType)
+}
+
+// CloseSubscriptions closes all the subscriptions of the client and unregisters their action router, the action
+// events can then be registered again.
+func (c *Client) CloseSubscriptions() error {
+	return c.subscriptions.Close()
+}
+
+func (c *Client) subscribe(filters []service.ActionFilter, msgTypes ...string) (*service.ActionSubscription, error) {
+	return c.subscriptions.Subscribe(append([]service.ActionFilter{service.FilterByMessageType(msgTypes...)},
+		filters...)...)
+}
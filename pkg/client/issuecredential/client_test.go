@@ -9,11 +9,13 @@ package issuecredential
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/issuecredential"
 )
@@ -81,6 +83,20 @@ func TestClient_SendOffer(t *testing.T) {
 		require.Empty(t, piid)
 		require.EqualError(t, err, errEmptyOffer.Error())
 	})
+
+	t.Run("Invalid credential preview", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		piid, err := client.SendOffer(&OfferCredential{
+			CredentialPreview: issuecredential.NewPreviewCredential(issuecredential.NewAttribute("", "value")),
+		}, Alice, Bob)
+		require.Empty(t, piid)
+		require.EqualError(t, err, "invalid credential preview: attribute 0: attribute name is empty")
+	})
 }
 
 func TestClient_SendProposal(t *testing.T) {
@@ -118,6 +134,23 @@ func TestClient_SendProposal(t *testing.T) {
 		require.Empty(t, piid)
 		require.EqualError(t, err, errEmptyProposal.Error())
 	})
+
+	t.Run("Invalid credential proposal", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		piid, err := client.SendProposal(&ProposeCredential{
+			CredentialProposal: issuecredential.NewPreviewCredential(
+				issuecredential.NewAttribute("name", "Alice"),
+				issuecredential.NewAttribute("name", "Bob"),
+			),
+		}, Alice, Bob)
+		require.Empty(t, piid)
+		require.EqualError(t, err, "invalid credential proposal: duplicate attribute name")
+	})
 }
 
 func TestClient_SendRequest(t *testing.T) {
@@ -235,6 +268,11 @@ func TestClient_AcceptRequest(t *testing.T) {
 	require.NoError(t, err)
 
 	require.NoError(t, client.AcceptRequest("PIID", &IssueCredential{}))
+
+	err = client.AcceptRequest("PIID", &IssueCredential{
+		CredentialsAttach: []decorator.Attachment{{ID: "1"}, {ID: "1"}},
+	})
+	require.EqualError(t, err, "invalid issue credential: duplicate credential attachment 1")
 }
 
 func TestClient_DeclineRequest(t *testing.T) {
@@ -301,6 +339,22 @@ func TestClient_AcceptCredential(t *testing.T) {
 	require.NoError(t, client.AcceptCredential("PIID"))
 }
 
+func TestClient_AcceptCredentialExcept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.AcceptCredentialExcept("PIID", []string{"attach-id"}, "name"))
+}
+
 func TestClient_DeclineCredential(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -316,3 +370,99 @@ func TestClient_DeclineCredential(t *testing.T) {
 
 	require.NoError(t, client.DeclineCredential("PIID", "the reason"))
 }
+
+func TestClient_ResumeAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().ResumeAll(time.Hour).Return(nil)
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		require.NoError(t, client.ResumeAll(time.Hour))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().ResumeAll(time.Duration(0)).Return(errors.New("test err"))
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		require.EqualError(t, client.ResumeAll(0), "resume all: test err")
+	})
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	var events chan<- service.DIDCommAction
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().RegisterActionEvent(gomock.Any()).DoAndReturn(func(ch chan<- service.DIDCommAction) error {
+		events = ch
+
+		return nil
+	})
+	svc.EXPECT().UnregisterActionEvent(gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	proposals, err := client.SubscribeProposals()
+	require.NoError(t, err)
+
+	offers, err := client.SubscribeOffers()
+	require.NoError(t, err)
+
+	requests, err := client.SubscribeRequests()
+	require.NoError(t, err)
+
+	credentials, err := client.SubscribeCredentials()
+	require.NoError(t, err)
+
+	problemReports, err := client.SubscribeProblemReports()
+	require.NoError(t, err)
+
+	result := make(chan interface{}, 1)
+
+	for _, msgType := range []string{
+		issuecredential.ProposeCredentialMsgType,
+		issuecredential.OfferCredentialMsgType,
+		issuecredential.RequestCredentialMsgType,
+		issuecredential.IssueCredentialMsgType,
+		issuecredential.ProblemReportMsgType,
+	} {
+		events <- service.DIDCommAction{
+			ProtocolName: issuecredential.Name,
+			Message:      service.DIDCommMsgMap{"@type": msgType},
+			Continue:     func(args interface{}) { result <- args },
+			Stop:         func(err error) { result <- err },
+		}
+	}
+
+	for i, sub := range []*service.ActionSubscription{proposals, offers, requests, credentials, problemReports} {
+		select {
+		case action := <-sub.Actions():
+			action.Continue(i)
+			require.Equal(t, i, <-result)
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	require.NoError(t, client.CloseSubscriptions())
+}
@@ -43,6 +43,9 @@ type protocolService interface {
 
 	// Config returns the router's configuration.
 	Config(connID string) (*mediator.Config, error)
+
+	// SetLiveDelivery enables or disables the live delivery of the messages by the router
+	SetLiveDelivery(connID string, enabled bool) error
 }
 
 // WithTimeout option is for definition timeout value waiting for responses received from the router.
@@ -52,6 +55,13 @@ func WithTimeout(t time.Duration) mediator.ClientOption {
 	}
 }
 
+// WithCoordinationV2 option is for registering with the router using coordinate mediation 2.0.
+func WithCoordinationV2() mediator.ClientOption {
+	return func(opts *mediator.ClientOptions) {
+		opts.CoordinationV2 = true
+	}
+}
+
 // New return new instance of route client.
 func New(ctx provider, options ...mediator.ClientOption) (*Client, error) {
 	svc, err := ctx.Service(mediator.Coordination)
@@ -109,3 +119,14 @@ func (c *Client) GetConfig(connID string) (*mediator.Config, error) {
 
 	return conf, nil
 }
+
+// SetLiveDelivery switches the router between pushing the messages to the agent as soon as they are received
+// (live delivery over a duplex connection e.g. websocket) and keeping them until the agent picks them up.
+// Agents with an intermittent connectivity disable the live delivery and poll for the messages instead.
+func (c *Client) SetLiveDelivery(connID string, enabled bool) error {
+	if err := c.routeSvc.SetLiveDelivery(connID, enabled); err != nil {
+		return fmt.Errorf("set live delivery: %w", err)
+	}
+
+	return nil
+}
@@ -54,6 +54,13 @@ func TestNew(t *testing.T) {
 
 		require.Equal(t, timeout, opts.Timeout)
 	})
+
+	t.Run("test coordination v2 is applied to options", func(t *testing.T) {
+		opts := &mediator.ClientOptions{}
+		WithCoordinationV2()(opts)
+
+		require.True(t, opts.CoordinationV2)
+	})
 }
 
 func TestRegister(t *testing.T) {
@@ -170,3 +177,30 @@ func TestClient_GetConfig(t *testing.T) {
 		require.True(t, errors.Is(err, expected))
 	})
 }
+
+func TestClient_SetLiveDelivery(t *testing.T) {
+	t.Run("test set live delivery - success", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{},
+		})
+		require.NoError(t, err)
+
+		err = c.SetLiveDelivery("conn", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("test set live delivery - error", func(t *testing.T) {
+		expected := errors.New("test")
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				SetLiveDeliveryErr: expected,
+			},
+		})
+		require.NoError(t, err)
+
+		err = c.SetLiveDelivery("conn", true)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+		require.Contains(t, err.Error(), "set live delivery")
+	})
+}
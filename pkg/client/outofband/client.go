@@ -7,8 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package outofband
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -27,6 +31,8 @@ type (
 	Request outofband.Request
 	// Invitation is this protocol's `invitation` message.
 	Invitation outofband.Invitation
+	// InvitationV2 is the Out-Of-Band 2.0 `invitation` message.
+	InvitationV2 outofband.InvitationV2
 	// Action contains helpful information about action.
 	Action outofband.Action
 )
@@ -36,6 +42,15 @@ const (
 	RequestMsgType = outofband.RequestMsgType
 	// InvitationMsgType is the '@type' for the invitation message.
 	InvitationMsgType = outofband.InvitationMsgType
+	// InvitationV2MsgType is the 'type' for the Out-Of-Band 2.0 invitation message.
+	InvitationV2MsgType = outofband.InvitationV2MsgType
+
+	// StateConnectionReused is the state of the post-state event fired once an existing connection is reused for
+	// an invitation, instead of creating a new one.
+	StateConnectionReused = outofband.StateConnectionReused
+
+	// invitationURLParam is the query parameter holding an encoded out-of-band invitation.
+	invitationURLParam = "_oob"
 )
 
 // EventOptions are is a container of options that you can pass to an event's
@@ -75,6 +90,9 @@ type message struct {
 	GoalCode          string
 	RouterConnections []string
 	Service           []interface{}
+	From              string
+	Accept            []string
+	Attachments       []*decorator.Attachment
 }
 
 func (m *message) RouterConnection() string {
@@ -92,6 +110,9 @@ type OobService interface {
 	AcceptInvitation(*outofband.Invitation, string, []string) (string, error)
 	SaveRequest(*outofband.Request) error
 	SaveInvitation(*outofband.Invitation) error
+	AcceptInvitationV2(*outofband.InvitationV2, string, []string) (string, error)
+	AcceptImplicitInvitation(string, string, []string) (string, error)
+	SaveInvitationV2(*outofband.InvitationV2) error
 	Actions() ([]outofband.Action, error)
 	ActionContinue(string, outofband.Options) error
 	ActionStop(string, error) error
@@ -110,10 +131,25 @@ type Client struct {
 	service.Event
 	didDocSvcFunc func(routerConnID string) (*did.Service, error)
 	oobService    OobService
+	urlShortener  URLShortener
+	subscriptions *service.ActionSubscriptions
+}
+
+// URLShortener shortens a long invitation URL, eg. by registering it with a URL shortening service.
+type URLShortener func(longURL string) (string, error)
+
+// Option configures the client.
+type Option func(*Client)
+
+// WithURLShortener sets the hook used by InvitationURL to shorten invitation URLs.
+func WithURLShortener(shortener URLShortener) Option {
+	return func(c *Client) {
+		c.urlShortener = shortener
+	}
 }
 
 // New returns a new Client for the Out-Of-Band protocol.
-func New(p Provider) (*Client, error) {
+func New(p Provider, opts ...Option) (*Client, error) {
 	s, err := p.Service(outofband.Name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to look up service %s : %w", outofband.Name, err)
@@ -124,11 +160,18 @@ func New(p Provider) (*Client, error) {
 		return nil, fmt.Errorf("failed to cast service %s as a dependency", outofband.Name)
 	}
 
-	return &Client{
+	client := &Client{
 		Event:         oobSvc,
 		didDocSvcFunc: didServiceBlockFunc(p),
 		oobService:    oobSvc,
-	}, nil
+		subscriptions: service.NewActionSubscriptions(oobSvc),
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client, nil
 }
 
 // CreateRequest creates and saves an Out-Of-Band request message.
@@ -181,7 +224,8 @@ func (c *Client) CreateRequest(attchmnts []*decorator.Attachment, opts ...Messag
 
 // CreateInvitation creates and saves an out-of-band invitation.
 // Protocols is an optional list of protocol identifier URIs that can be used to form connections. A default
-// will be set if none are provided.
+// will be set if none are provided. Requests to be processed by the invitee once connected can be attached
+// with WithAttachments.
 func (c *Client) CreateInvitation(protocols []string, opts ...MessageOption) (*Invitation, error) {
 	msg := &message{}
 
@@ -199,6 +243,7 @@ func (c *Client) CreateInvitation(protocols []string, opts ...MessageOption) (*I
 		GoalCode:  msg.GoalCode,
 		Service:   msg.Service,
 		Protocols: protocols,
+		Requests:  msg.Attachments,
 	}
 
 	if len(inv.Service) == 0 {
@@ -226,6 +271,160 @@ func (c *Client) CreateInvitation(protocols []string, opts ...MessageOption) (*I
 	return inv, nil
 }
 
+// CreateInvitationV2 creates and saves an Out-Of-Band 2.0 invitation.
+// The 'from' DID is mandatory (see WithFrom), requests to be processed by the invitee once
+// connected can be attached with WithAttachments.
+func (c *Client) CreateInvitationV2(opts ...MessageOption) (*InvitationV2, error) {
+	msg := &message{}
+
+	for _, opt := range opts {
+		if err := opt(msg); err != nil {
+			return nil, fmt.Errorf("failed to create invitation v2: %w", err)
+		}
+	}
+
+	inv := &InvitationV2{
+		ID:    uuid.New().String(),
+		Type:  InvitationV2MsgType,
+		Label: msg.Label,
+		From:  msg.From,
+		Body: &outofband.InvitationV2Body{
+			Goal:     msg.Goal,
+			GoalCode: msg.GoalCode,
+			Accept:   msg.Accept,
+		},
+		Attachments: msg.Attachments,
+	}
+
+	cast := outofband.InvitationV2(*inv)
+
+	err := c.oobService.SaveInvitationV2(&cast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save outofband invitation v2 : %w", err)
+	}
+
+	return inv, nil
+}
+
+// AcceptInvitationV2 from another agent and return the ID of the new connection record.
+func (c *Client) AcceptInvitationV2(i *InvitationV2, myLabel string, opts ...MessageOption) (string, error) {
+	msg := &message{}
+
+	for _, opt := range opts {
+		if err := opt(msg); err != nil {
+			return "", fmt.Errorf("accept invitation v2: %w", err)
+		}
+	}
+
+	cast := outofband.InvitationV2(*i)
+
+	connID, err := c.oobService.AcceptInvitationV2(&cast, myLabel, msg.RouterConnections)
+	if err != nil {
+		return "", fmt.Errorf("out-of-band service failed to accept invitation v2 : %w", err)
+	}
+
+	return connID, nil
+}
+
+// AcceptImplicitInvitation connects to the agent of the given resolvable public DID, without any invitation message,
+// and return the ID of the new connection record (or of the existing connection with that agent).
+// The implicit invitation is synthesized from the DIDComm service endpoint of the DID document.
+func (c *Client) AcceptImplicitInvitation(publicDID, myLabel string, opts ...MessageOption) (string, error) {
+	if publicDID == "" {
+		return "", errors.New("accept implicit invitation: public DID is mandatory")
+	}
+
+	msg := &message{}
+
+	for _, opt := range opts {
+		if err := opt(msg); err != nil {
+			return "", fmt.Errorf("accept implicit invitation: %w", err)
+		}
+	}
+
+	connID, err := c.oobService.AcceptImplicitInvitation(publicDID, myLabel, msg.RouterConnections)
+	if err != nil {
+		return "", fmt.Errorf("out-of-band service failed to accept implicit invitation : %w", err)
+	}
+
+	return connID, nil
+}
+
+// InvitationURL encodes the invitation in the `_oob` query parameter of the given base URL.
+// The URL is shortened if a URLShortener was configured.
+func (c *Client) InvitationURL(baseURL string, i *InvitationV2) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invitation URL: parse base URL: %w", err)
+	}
+
+	raw, err := json.Marshal(i)
+	if err != nil {
+		return "", fmt.Errorf("invitation URL: marshal invitation: %w", err)
+	}
+
+	query := u.Query()
+	query.Set(invitationURLParam, base64.RawURLEncoding.EncodeToString(raw))
+	u.RawQuery = query.Encode()
+
+	if c.urlShortener == nil {
+		return u.String(), nil
+	}
+
+	short, err := c.urlShortener(u.String())
+	if err != nil {
+		return "", fmt.Errorf("invitation URL: shorten: %w", err)
+	}
+
+	return short, nil
+}
+
+// ParseInvitationURL decodes the invitation from the `_oob` query parameter of the given URL.
+// Shortened URLs must be expanded by the caller beforehand.
+func ParseInvitationURL(invitationURL string) (*InvitationV2, error) {
+	u, err := url.Parse(invitationURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse invitation URL: %w", err)
+	}
+
+	encoded := u.Query().Get(invitationURLParam)
+	if encoded == "" {
+		return nil, fmt.Errorf("parse invitation URL: missing '%s' query parameter", invitationURLParam)
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return nil, fmt.Errorf("parse invitation URL: decode invitation: %w", err)
+	}
+
+	inv := &InvitationV2{}
+
+	err = json.Unmarshal(raw, inv)
+	if err != nil {
+		return nil, fmt.Errorf("parse invitation URL: unmarshal invitation: %w", err)
+	}
+
+	return inv, nil
+}
+
+// ToV1 converts the v2 invitation into its v1 equivalent for invitees that only support v1:
+// a Request if the invitation has attachments, otherwise an Invitation offering the given protocols
+// (did-exchange by default). Invitees accepting the v1 message are connected to the same inviter thread.
+func ToV1(i *InvitationV2, protocols ...string) (*Invitation, *Request) {
+	if len(protocols) == 0 {
+		protocols = []string{didexchange.PIURI}
+	}
+
+	cast := outofband.InvitationV2(*i)
+
+	inv, req := cast.ToV1(protocols)
+	if req != nil {
+		return nil, (*Request)(req)
+	}
+
+	return (*Invitation)(inv), nil
+}
+
 // Actions returns unfinished actions for the async usage.
 func (c *Client) Actions() ([]Action, error) {
 	actions, err := c.oobService.Actions()
@@ -321,6 +520,33 @@ func WithGoal(goal, goalCode string) MessageOption {
 	}
 }
 
+// WithFrom allows you to specify the inviter's DID on an Out-Of-Band 2.0 invitation.
+func WithFrom(didID string) MessageOption {
+	return func(m *message) error {
+		m.From = didID
+
+		return nil
+	}
+}
+
+// WithAccept allows you to specify the media types accepted by the inviter on an Out-Of-Band 2.0 invitation.
+func WithAccept(mediaTypes ...string) MessageOption {
+	return func(m *message) error {
+		m.Accept = mediaTypes
+
+		return nil
+	}
+}
+
+// WithAttachments allows you to attach requests to an out-of-band invitation or an Out-Of-Band 2.0 invitation.
+func WithAttachments(attachments ...*decorator.Attachment) MessageOption {
+	return func(m *message) error {
+		m.Attachments = attachments
+
+		return nil
+	}
+}
+
 // WithRouterConnections allows you to specify the router connections.
 func WithRouterConnections(conn ...string) MessageOption {
 	return func(m *message) error {
@@ -414,3 +640,26 @@ func didServiceBlockFunc(p Provider) func(routerConnID string) (*did.Service, er
 		}, nil
 	}
 }
+
+// SubscribeInvitations returns a subscription for the out-of-band invitations received, matching all the given
+// filters. The client registers the action events of the service on the first subscription,
+// see service.ActionSubscriptions.
+func (c *Client) SubscribeInvitations(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, outofband.InvitationMsgType, outofband.InvitationV2MsgType)
+}
+
+// SubscribeRequests returns a subscription for the out-of-band requests received, matching all the given filters.
+func (c *Client) SubscribeRequests(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, outofband.RequestMsgType)
+}
+
+// CloseSubscriptions closes all the subscriptions of the client and unregisters their action router, the action
+// events can then be registered again.
+func (c *Client) CloseSubscriptions() error {
+	return c.subscriptions.Close()
+}
+
+func (c *Client) subscribe(filters []service.ActionFilter, msgTypes ...string) (*service.ActionSubscription, error) {
+	return c.subscriptions.Subscribe(append([]service.ActionFilter{service.FilterByMessageType(msgTypes...)},
+		filters...)...)
+}
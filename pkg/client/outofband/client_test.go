@@ -293,6 +293,14 @@ func TestCreateInvitation(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, expected, inv.Label)
 	})
+	t.Run("WithAttachments", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		attachment := dummyAttachment(t)
+		inv, err := c.CreateInvitation(nil, WithAttachments(attachment))
+		require.NoError(t, err)
+		require.Equal(t, []*decorator.Attachment{attachment}, inv.Requests)
+	})
 	t.Run("with router connection", func(t *testing.T) {
 		const expectedConn = "conn-xyz"
 
@@ -444,6 +452,49 @@ func TestClient_Actions(t *testing.T) {
 	})
 }
 
+func TestClient_Subscribe(t *testing.T) {
+	event := &struct {
+		service.Action
+		service.Message
+	}{}
+
+	provider := withTestProvider()
+	provider.ServiceMap[outofband.Name] = &stubOOBService{Event: event}
+
+	c, err := New(provider)
+	require.NoError(t, err)
+
+	invitations, err := c.SubscribeInvitations()
+	require.NoError(t, err)
+
+	requests, err := c.SubscribeRequests()
+	require.NoError(t, err)
+
+	result := make(chan interface{}, 1)
+
+	for _, msgType := range []string{outofband.InvitationV2MsgType, outofband.RequestMsgType} {
+		event.ActionEvent() <- service.DIDCommAction{
+			ProtocolName: outofband.Name,
+			Message:      service.DIDCommMsgMap{"@type": msgType},
+			Continue:     func(args interface{}) { result <- args },
+			Stop:         func(err error) { result <- err },
+		}
+	}
+
+	for i, sub := range []*service.ActionSubscription{invitations, requests} {
+		select {
+		case action := <-sub.Actions():
+			action.Continue(i)
+			require.Equal(t, i, <-result)
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	require.NoError(t, c.CloseSubscriptions())
+	require.Nil(t, event.ActionEvent())
+}
+
 func TestAcceptRequest(t *testing.T) {
 	t.Run("returns connection ID", func(t *testing.T) {
 		expected := "123456"
@@ -514,6 +565,222 @@ func TestAcceptInvitation(t *testing.T) {
 	})
 }
 
+func TestCreateInvitationV2(t *testing.T) {
+	t.Run("sets id, type, from, goal and attachments", func(t *testing.T) {
+		attachment := dummyAttachment(t)
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		inv, err := c.CreateInvitationV2(
+			WithFrom("did:example:inviter"),
+			WithLabel("inviter"),
+			WithGoal("issue a credential", "issue-vc"),
+			WithAccept("didcomm/v2"),
+			WithAttachments(attachment),
+		)
+		require.NoError(t, err)
+		require.NotEmpty(t, inv.ID)
+		require.Equal(t, InvitationV2MsgType, inv.Type)
+		require.Equal(t, "did:example:inviter", inv.From)
+		require.Equal(t, "inviter", inv.Label)
+		require.Equal(t, "issue a credential", inv.Body.Goal)
+		require.Equal(t, "issue-vc", inv.Body.GoalCode)
+		require.Equal(t, []string{"didcomm/v2"}, inv.Body.Accept)
+		require.Equal(t, []*decorator.Attachment{attachment}, inv.Attachments)
+	})
+	t.Run("wraps error from outofband service", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := withTestProvider()
+		provider.ServiceMap[outofband.Name] = &stubOOBService{
+			saveInvV2Func: func(*outofband.InvitationV2) error {
+				return expected
+			},
+		}
+		c, err := New(provider)
+		require.NoError(t, err)
+		_, err = c.CreateInvitationV2(WithFrom("did:example:inviter"))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+	t.Run("fails with invalid option", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		_, err = c.CreateInvitationV2(WithServices(1))
+		require.Error(t, err)
+	})
+}
+
+func TestAcceptInvitationV2(t *testing.T) {
+	t.Run("returns connection ID", func(t *testing.T) {
+		expected := "123456"
+		provider := withTestProvider()
+		provider.ServiceMap = map[string]interface{}{
+			outofband.Name: &stubOOBService{
+				acceptInvV2Func: func(*outofband.InvitationV2, string, []string) (string, error) {
+					return expected, nil
+				},
+			},
+		}
+		c, err := New(provider)
+		require.NoError(t, err)
+		result, err := c.AcceptInvitationV2(&InvitationV2{}, "")
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	})
+	t.Run("wraps error from outofband service", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := withTestProvider()
+		provider.ServiceMap = map[string]interface{}{
+			outofband.Name: &stubOOBService{
+				acceptInvV2Func: func(*outofband.InvitationV2, string, []string) (string, error) {
+					return "", expected
+				},
+			},
+		}
+		c, err := New(provider)
+		require.NoError(t, err)
+		_, err = c.AcceptInvitationV2(&InvitationV2{}, "")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+}
+
+func TestAcceptImplicitInvitation(t *testing.T) {
+	t.Run("returns connection ID", func(t *testing.T) {
+		expected := "123456"
+		provider := withTestProvider()
+		provider.ServiceMap = map[string]interface{}{
+			outofband.Name: &stubOOBService{
+				acceptImplicitFunc: func(publicDID, myLabel string, conns []string) (string, error) {
+					require.Equal(t, "did:example:public", publicDID)
+					require.Equal(t, "Bob", myLabel)
+					require.Equal(t, []string{"router"}, conns)
+
+					return expected, nil
+				},
+			},
+		}
+		c, err := New(provider)
+		require.NoError(t, err)
+		result, err := c.AcceptImplicitInvitation("did:example:public", "Bob", WithRouterConnections("router"))
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	})
+	t.Run("wraps error from outofband service", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := withTestProvider()
+		provider.ServiceMap = map[string]interface{}{
+			outofband.Name: &stubOOBService{
+				acceptImplicitFunc: func(string, string, []string) (string, error) {
+					return "", expected
+				},
+			},
+		}
+		c, err := New(provider)
+		require.NoError(t, err)
+		_, err = c.AcceptImplicitInvitation("did:example:public", "")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+	t.Run("requires the public DID", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		_, err = c.AcceptImplicitInvitation("", "")
+		require.EqualError(t, err, "accept implicit invitation: public DID is mandatory")
+	})
+}
+
+func TestInvitationURL(t *testing.T) {
+	inv := &InvitationV2{
+		ID:   uuid.New().String(),
+		Type: InvitationV2MsgType,
+		From: "did:example:inviter",
+		Body: &outofband.InvitationV2Body{GoalCode: "connect"},
+	}
+
+	t.Run("encodes and parses invitation", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		u, err := c.InvitationURL("https://example.com/path?x=y", inv)
+		require.NoError(t, err)
+		require.Contains(t, u, "_oob=")
+
+		result, err := ParseInvitationURL(u)
+		require.NoError(t, err)
+		require.Equal(t, inv, result)
+	})
+	t.Run("shortens url", func(t *testing.T) {
+		c, err := New(withTestProvider(), WithURLShortener(func(longURL string) (string, error) {
+			require.Contains(t, longURL, "_oob=")
+
+			return "https://sho.rt/abc", nil
+		}))
+		require.NoError(t, err)
+		u, err := c.InvitationURL("https://example.com", inv)
+		require.NoError(t, err)
+		require.Equal(t, "https://sho.rt/abc", u)
+	})
+	t.Run("wraps error from shortener", func(t *testing.T) {
+		expected := errors.New("test")
+		c, err := New(withTestProvider(), WithURLShortener(func(string) (string, error) {
+			return "", expected
+		}))
+		require.NoError(t, err)
+		_, err = c.InvitationURL("https://example.com", inv)
+		require.True(t, errors.Is(err, expected))
+	})
+	t.Run("fails with invalid base url", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		_, err = c.InvitationURL("://", inv)
+		require.Error(t, err)
+	})
+	t.Run("fails to parse url without invitation", func(t *testing.T) {
+		_, err := ParseInvitationURL("https://example.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing '_oob' query parameter")
+
+		_, err = ParseInvitationURL("https://example.com?_oob=%%%")
+		require.Error(t, err)
+
+		_, err = ParseInvitationURL("https://example.com?_oob=***")
+		require.Error(t, err)
+
+		_, err = ParseInvitationURL("https://example.com?_oob=" + base64.RawURLEncoding.EncodeToString([]byte("[]")))
+		require.Error(t, err)
+	})
+}
+
+func TestToV1(t *testing.T) {
+	inv := &InvitationV2{
+		ID:    uuid.New().String(),
+		Type:  InvitationV2MsgType,
+		Label: "inviter",
+		From:  "did:example:inviter",
+		Body:  &outofband.InvitationV2Body{Goal: "connect", GoalCode: "p2p"},
+	}
+
+	t.Run("converts to invitation", func(t *testing.T) {
+		v1Inv, req := ToV1(inv)
+		require.Nil(t, req)
+		require.Equal(t, inv.ID, v1Inv.ID)
+		require.Equal(t, InvitationMsgType, v1Inv.Type)
+		require.Equal(t, []interface{}{inv.From}, v1Inv.Service)
+		require.Equal(t, []string{didexchange.PIURI}, v1Inv.Protocols)
+		require.Equal(t, "p2p", v1Inv.GoalCode)
+	})
+	t.Run("converts to request", func(t *testing.T) {
+		withAttachment := *inv
+		withAttachment.Attachments = []*decorator.Attachment{dummyAttachment(t)}
+
+		v1Inv, req := ToV1(&withAttachment, "protocol")
+		require.Nil(t, v1Inv)
+		require.Equal(t, inv.ID, req.ID)
+		require.Equal(t, RequestMsgType, req.Type)
+		require.Equal(t, withAttachment.Attachments, req.Requests)
+		require.Equal(t, "connect", req.Goal)
+	})
+}
+
 func dummyAttachment(t *testing.T) *decorator.Attachment {
 	return base64Attachment(t, &didcommMsg{
 		ID:   uuid.New().String(),
@@ -567,6 +834,9 @@ type stubOOBService struct {
 	acceptInvFunc      func(*outofband.Invitation, string, []string) (string, error)
 	saveReqFunc        func(*outofband.Request) error
 	saveInvFunc        func(*outofband.Invitation) error
+	acceptInvV2Func    func(*outofband.InvitationV2, string, []string) (string, error)
+	acceptImplicitFunc func(string, string, []string) (string, error)
+	saveInvV2Func      func(*outofband.InvitationV2) error
 	actionsFunc        func() ([]outofband.Action, error)
 	actionContinueFunc func(string, outofband.Options) error
 	actionStopFunc     func(piid string, err error) error
@@ -604,6 +874,30 @@ func (s *stubOOBService) SaveInvitation(i *outofband.Invitation) error {
 	return nil
 }
 
+func (s *stubOOBService) AcceptInvitationV2(i *outofband.InvitationV2, myLabel string, conns []string) (string, error) {
+	if s.acceptInvV2Func != nil {
+		return s.acceptInvV2Func(i, myLabel, conns)
+	}
+
+	return "", nil
+}
+
+func (s *stubOOBService) AcceptImplicitInvitation(publicDID, myLabel string, conns []string) (string, error) {
+	if s.acceptImplicitFunc != nil {
+		return s.acceptImplicitFunc(publicDID, myLabel, conns)
+	}
+
+	return "", nil
+}
+
+func (s *stubOOBService) SaveInvitationV2(i *outofband.InvitationV2) error {
+	if s.saveInvV2Func != nil {
+		return s.saveInvV2Func(i)
+	}
+
+	return nil
+}
+
 func (s *stubOOBService) Actions() ([]outofband.Action, error) {
 	if s.actionsFunc != nil {
 		return s.actionsFunc()
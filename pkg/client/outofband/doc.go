@@ -23,6 +23,21 @@ SPDX-License-Identifier: Apache-2.0
 // client.AcceptInvitation() respectively. These return the ID of the newly-created connection
 // record.
 //
+// Out-Of-Band 2.0 invitations carry the goal and the attached requests in a single message. They are
+// created with client.CreateInvitationV2(outofband.WithFrom(publicDID), ...) and accepted with
+// client.AcceptInvitationV2(). client.InvitationURL() encodes an invitation into an URL (shortened with the
+// hook given to outofband.WithURLShortener) and outofband.ParseInvitationURL() decodes it. Invitees that only
+// support v1 can be handed the equivalent v1 message returned by outofband.ToV1().
+//
+// An agent with a resolvable public DID exposing a DIDComm service can be connected to without any invitation
+// message, with client.AcceptImplicitInvitation(publicDID, myLabel).
+//
+// When the invitation comes from a party the agent is already connected to (its DID is the invitation's
+// service entry or 'from'), the existing connection is reused instead of creating a duplicate: the Accept
+// functions return the ID of the existing connection and a handshake-reuse message is sent over it. A post-state
+// event with state outofband.StateConnectionReused informs both parties of the reused connection ID once the
+// inviter accepts the reuse; the requests attached to the invitation are then sent over that connection.
+//
 // If you're expecting to receive out-of-band invitations or requests via a DIDComm channel then
 // you should register to the action event stream and the state event stream:
 //
@@ -8,6 +8,8 @@ package presentproof
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
@@ -42,13 +44,15 @@ type ProtocolService interface {
 	Actions() ([]presentproof.Action, error)
 	ActionContinue(piID string, opt presentproof.Opt) error
 	ActionStop(piID string, err error) error
+	ResumeAll(timeout time.Duration) error
 }
 
 // Client enable access to presentproof API
 // https://github.com/hyperledger/aries-rfcs/tree/master/features/0037-present-proof
 type Client struct {
 	service.Event
-	service ProtocolService
+	service       ProtocolService
+	subscriptions *service.ActionSubscriptions
 }
 
 // New returns new instance of the presentproof client.
@@ -64,8 +68,9 @@ func New(ctx Provider) (*Client, error) {
 	}
 
 	return &Client{
-		Event:   svc,
-		service: svc,
+		Event:         svc,
+		service:       svc,
+		subscriptions: service.NewActionSubscriptions(svc),
 	}, nil
 }
 
@@ -84,6 +89,17 @@ func (c *Client) Actions() ([]Action, error) {
 	return result, nil
 }
 
+// ResumeAll triggers the action events again for the actions that were pending when the agent was stopped.
+// It should be called once the action events are registered after the agent restart. The actions pending
+// longer than the given timeout are stopped, zero timeout means the actions never expire.
+func (c *Client) ResumeAll(timeout time.Duration) error {
+	if err := c.service.ResumeAll(timeout); err != nil {
+		return fmt.Errorf("resume all: %w", err)
+	}
+
+	return nil
+}
+
 // SendRequestPresentation is used by the Verifier to send a request presentation.
 // It returns the threadID of the new instance of the protocol.
 func (c *Client) SendRequestPresentation(msg *RequestPresentation, myDID, theirDID string) (string, error) {
@@ -176,3 +192,38 @@ func WithRequestPresentation(msg *RequestPresentation) presentproof.Opt {
 func WithFriendlyNames(names ...string) presentproof.Opt {
 	return presentproof.WithFriendlyNames(names...)
 }
+
+// SubscribeProposals returns a subscription for the presentation proposals received by the verifier, matching all
+// the given filters (e.g. service.FilterByConnection). The client registers the action events of the service on the
+// first subscription, see service.ActionSubscriptions.
+func (c *Client) SubscribeProposals(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, presentproof.ProposePresentationMsgType)
+}
+
+// SubscribeRequests returns a subscription for the presentation requests received by the prover, matching all the
+// given filters.
+func (c *Client) SubscribeRequests(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, presentproof.RequestPresentationMsgType)
+}
+
+// SubscribePresentations returns a subscription for the presentations received by the verifier, matching all the
+// given filters.
+func (c *Client) SubscribePresentations(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, presentproof.PresentationMsgType)
+}
+
+// SubscribeProblemReports returns a subscription for the problem reports received, matching all the given filters.
+func (c *Client) SubscribeProblemReports(filters ...service.ActionFilter) (*service.ActionSubscription, error) {
+	return c.subscribe(filters, presentproof.ProblemReportMsgType)
+}
+
+// CloseSubscriptions closes all the subscriptions of the client and unregisters their action router, the action
+// events can then be registered again.
+func (c *Client) CloseSubscriptions() error {
+	return c.subscriptions.Close()
+}
+
+func (c *Client) subscribe(filters []service.ActionFilter, msgTypes ...string) (*service.ActionSubscription, error) {
+	return c.subscriptions.Subscribe(append([]service.ActionFilter{service.FilterByMessageType(msgTypes...)},
+		filters...)...)
+}
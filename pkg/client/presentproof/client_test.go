@@ -9,6 +9,7 @@ package presentproof
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -246,3 +247,95 @@ func TestClient_NegotiateProposePresentation(t *testing.T) {
 
 	require.NoError(t, client.NegotiateRequestPresentation("PIID", &ProposePresentation{}))
 }
+
+func TestClient_ResumeAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().ResumeAll(time.Hour).Return(nil)
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		require.NoError(t, client.ResumeAll(time.Hour))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().ResumeAll(time.Duration(0)).Return(errors.New("test err"))
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		require.EqualError(t, client.ResumeAll(0), "resume all: test err")
+	})
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	var events chan<- service.DIDCommAction
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().RegisterActionEvent(gomock.Any()).DoAndReturn(func(ch chan<- service.DIDCommAction) error {
+		events = ch
+
+		return nil
+	})
+	svc.EXPECT().UnregisterActionEvent(gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	proposals, err := client.SubscribeProposals()
+	require.NoError(t, err)
+
+	requests, err := client.SubscribeRequests()
+	require.NoError(t, err)
+
+	presentations, err := client.SubscribePresentations()
+	require.NoError(t, err)
+
+	problemReports, err := client.SubscribeProblemReports()
+	require.NoError(t, err)
+
+	result := make(chan interface{}, 1)
+
+	for _, msgType := range []string{
+		presentproof.ProposePresentationMsgType,
+		presentproof.RequestPresentationMsgType,
+		presentproof.PresentationMsgType,
+		presentproof.ProblemReportMsgType,
+	} {
+		events <- service.DIDCommAction{
+			ProtocolName: presentproof.Name,
+			Message:      service.DIDCommMsgMap{"@type": msgType},
+			Continue:     func(args interface{}) { result <- args },
+			Stop:         func(err error) { result <- err },
+		}
+	}
+
+	for i, sub := range []*service.ActionSubscription{proposals, requests, presentations, problemReports} {
+		select {
+		case action := <-sub.Actions():
+			action.Continue(i)
+			require.Equal(t, i, <-result)
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	require.NoError(t, client.CloseSubscriptions())
+}
@@ -17,6 +17,12 @@ SPDX-License-Identifier: Apache-2.0
 // 	 panic(err)
 // 	}
 //
+// Verifiers may attach a DIF Presentation Exchange presentation definition to the request:
+//
+// 	request := &presentproof.RequestPresentation{}
+// 	presentproof.AddPresentationDefinition(request, definition)
+// 	client.SendRequestPresentation((*RequestPresentation)(request), myDID, theirDID)
+//
 // 2. Register an action event channel.
 //
 // 	actions := make(chan service.DIDCommAction)
@@ -37,6 +43,11 @@ SPDX-License-Identifier: Apache-2.0
 //        }
 //
 //        if event.Message.Type() == presentproof.RequestPresentationMsgType {
+//          // If the request carries a presentation definition, the presentation built from the matching
+//          // wallet credentials is proposed in the event properties and can be sent as is or overridden.
+//          if msg, ok := event.Properties.All()["presentation"].(*presentproof.Presentation); ok {
+//            client.AcceptRequestPresentation(piid, (*Presentation)(msg))
+//          }
 //          // If Prover is willing to accept a request.
 //          client.AcceptRequestPresentation(piid, &Presentation{})
 //          // If Prover wants to counter a request they received with a proposal.
@@ -0,0 +1,298 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemreport
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/problemcode"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+)
+
+const (
+	// WhoRetriesYou means the recipient of the problem report should retry.
+	WhoRetriesYou = model.WhoRetriesYou
+	// WhoRetriesMe means the sender of the problem report will retry.
+	WhoRetriesMe = model.WhoRetriesMe
+	// WhoRetriesBoth means both parties may retry.
+	WhoRetriesBoth = model.WhoRetriesBoth
+	// WhoRetriesNone means the operation should not be retried.
+	WhoRetriesNone = model.WhoRetriesNone
+
+	// ImpactMessage means only the message the problem report refers to failed.
+	ImpactMessage = model.ImpactMessage
+	// ImpactThread means the whole thread (protocol instance) failed.
+	ImpactThread = model.ImpactThread
+	// ImpactConnection means the connection itself is affected.
+	ImpactConnection = model.ImpactConnection
+
+	problemReportSuffix = "/problem-report"
+
+	// the protocol services fire a message event for every state transition,
+	// the IDs of the last reports are kept to notify each report once.
+	seenReportsSize = 100
+)
+
+// ProblemReport is the problem report message.
+type ProblemReport model.ProblemReport
+
+// Err returns the error of the problem report, which is the error of the framework its code maps to for errors.Is
+// (e.g. verifiable.ErrProofInvalid if the other agent failed to verify a proof), see the problemcode package.
+func (r *ProblemReport) Err() error {
+	return problemcode.Error(r.Description)
+}
+
+var logger = log.New("aries-framework/client/problemreport")
+
+// Provider contains dependencies for the problem report client and is typically created by using aries.Context().
+type Provider interface {
+	Messenger() service.Messenger
+	AllServices() []dispatcher.ProtocolService
+}
+
+// Event is a problem report received by the agent for one of its protocols.
+type Event struct {
+	// ProtocolName is the name of the protocol service that received the problem report.
+	ProtocolName string
+	// PIID is the protocol instance ID, if the protocol has one.
+	PIID     string
+	MyDID    string
+	TheirDID string
+	Report   *ProblemReport
+}
+
+// msgEvents is implemented by the protocol services firing message events.
+type msgEvents interface {
+	RegisterMsgEvent(chan<- service.StateMsg) error
+	UnregisterMsgEvent(chan<- service.StateMsg) error
+}
+
+// Client enable access to the problem reports of all the protocols
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0035-report-problem
+type Client struct {
+	messenger   service.Messenger
+	services    []msgEvents
+	msgEvents   chan service.StateMsg
+	done        chan struct{}
+	subscribers []chan<- Event
+	seen        map[string]struct{}
+	seenOrder   []string
+	lock        sync.RWMutex
+}
+
+// New returns new instance of the problem report client.
+// The client observes the message events of all the protocol services, from their creation.
+func New(ctx Provider) (*Client, error) {
+	c := &Client{
+		messenger: ctx.Messenger(),
+		msgEvents: make(chan service.StateMsg),
+		done:      make(chan struct{}),
+		seen:      map[string]struct{}{},
+	}
+
+	for _, svc := range ctx.AllServices() {
+		events, ok := svc.(msgEvents)
+		if !ok {
+			continue
+		}
+
+		if err := events.RegisterMsgEvent(c.msgEvents); err != nil {
+			c.unregister()
+
+			return nil, fmt.Errorf("register msg event for %s: %w", svc.Name(), err)
+		}
+
+		c.services = append(c.services, events)
+	}
+
+	go c.listen()
+
+	return c, nil
+}
+
+// RegisterEvent registers a channel the problem reports received for any protocol are sent to.
+func (c *Client) RegisterEvent(ch chan<- Event) error {
+	if ch == nil {
+		return errors.New("channel is mandatory")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.subscribers = append(c.subscribers, ch)
+
+	return nil
+}
+
+// UnregisterEvent unregisters a channel registered with RegisterEvent.
+func (c *Client) UnregisterEvent(ch chan<- Event) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i := range c.subscribers {
+		if c.subscribers[i] == ch {
+			c.subscribers = append(c.subscribers[:i], c.subscribers[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return errors.New("channel is not registered")
+}
+
+// Close stops observing the protocol services.
+func (c *Client) Close() {
+	c.unregister()
+	close(c.done)
+}
+
+// Send sends the problem report in the thread with given ID. The report type must be set to the problem report
+// message type of the protocol the thread belongs to, see the per-protocol Send functions.
+func (c *Client) Send(report *ProblemReport, threadID, myDID, theirDID string) error {
+	if report == nil {
+		return errors.New("problem report is mandatory")
+	}
+
+	if report.Type == "" {
+		return errors.New("problem report type is mandatory")
+	}
+
+	if report.Description.Code == "" {
+		return errors.New("problem report code is mandatory")
+	}
+
+	if report.ID == "" {
+		report.ID = uuid.New().String()
+	}
+
+	err := c.messenger.ReplyToNested(service.NewDIDCommMsgMap(report), &service.NestedReplyOpts{
+		ThreadID: threadID,
+		MyDID:    myDID,
+		TheirDID: theirDID,
+	})
+	if err != nil {
+		return fmt.Errorf("send problem report: %w", err)
+	}
+
+	return nil
+}
+
+// SendIssueCredential sends the problem report in the issue credential thread with given ID.
+func (c *Client) SendIssueCredential(report *ProblemReport, threadID, myDID, theirDID string) error {
+	return c.sendAs(issuecredential.ProblemReportMsgType, report, threadID, myDID, theirDID)
+}
+
+// SendPresentProof sends the problem report in the present proof thread with given ID.
+func (c *Client) SendPresentProof(report *ProblemReport, threadID, myDID, theirDID string) error {
+	return c.sendAs(presentproof.ProblemReportMsgType, report, threadID, myDID, theirDID)
+}
+
+// SendIntroduce sends the problem report in the introduce thread with given ID.
+func (c *Client) SendIntroduce(report *ProblemReport, threadID, myDID, theirDID string) error {
+	return c.sendAs(introduce.ProblemReportMsgType, report, threadID, myDID, theirDID)
+}
+
+func (c *Client) sendAs(msgType string, report *ProblemReport, threadID, myDID, theirDID string) error {
+	if report == nil {
+		return errors.New("problem report is mandatory")
+	}
+
+	report.Type = msgType
+
+	return c.Send(report, threadID, myDID, theirDID)
+}
+
+func (c *Client) unregister() {
+	for _, svc := range c.services {
+		if err := svc.UnregisterMsgEvent(c.msgEvents); err != nil {
+			logger.Warnf("unregister msg event: %s", err)
+		}
+	}
+}
+
+func (c *Client) listen() {
+	for {
+		select {
+		case msg := <-c.msgEvents:
+			c.handle(msg)
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *Client) handle(msg service.StateMsg) {
+	if msg.Msg == nil || !strings.HasSuffix(msg.Msg.Type(), problemReportSuffix) {
+		return
+	}
+
+	if !c.firstSeen(msg.Msg.ID()) {
+		return
+	}
+
+	report := &ProblemReport{}
+
+	if err := msg.Msg.Decode(report); err != nil {
+		logger.Warnf("decode problem report %s: %s", msg.Msg.ID(), err)
+
+		return
+	}
+
+	event := Event{
+		ProtocolName: msg.ProtocolName,
+		Report:       report,
+	}
+
+	if props, ok := msg.Properties.(interface{ PIID() string }); ok {
+		event.PIID = props.PIID()
+	}
+
+	if props, ok := msg.Properties.(interface {
+		MyDID() string
+		TheirDID() string
+	}); ok {
+		event.MyDID, event.TheirDID = props.MyDID(), props.TheirDID()
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for _, subscriber := range c.subscribers {
+		subscriber <- event
+	}
+}
+
+func (c *Client) firstSeen(id string) bool {
+	if id == "" {
+		return true
+	}
+
+	if _, ok := c.seen[id]; ok {
+		return false
+	}
+
+	c.seen[id] = struct{}{}
+	c.seenOrder = append(c.seenOrder, id)
+
+	if len(c.seenOrder) > seenReportsSize {
+		delete(c.seen, c.seenOrder[0])
+		c.seenOrder = c.seenOrder[1:]
+	}
+
+	return true
+}
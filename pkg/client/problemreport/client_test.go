@@ -0,0 +1,256 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemreport
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("register error", func(t *testing.T) {
+		svc := &protocolService{name: "test", registerErr: errors.New("test error")}
+
+		_, err := New(&provider{services: []dispatcher.ProtocolService{svc}})
+		require.EqualError(t, err, "register msg event for test: test error")
+	})
+}
+
+func TestClient_Events(t *testing.T) {
+	svc := &protocolService{name: issuecredential.Name}
+
+	client, err := New(&provider{services: []dispatcher.ProtocolService{svc, &noEventsService{}}})
+	require.NoError(t, err)
+
+	defer client.Close()
+
+	events := make(chan Event, 2)
+	require.NoError(t, client.RegisterEvent(events))
+
+	report := service.NewDIDCommMsgMap(&ProblemReport{
+		Type:        issuecredential.ProblemReportMsgType,
+		ID:          "report-id",
+		Description: model.Code{Code: "rejected", En: "rejected by the issuer"},
+		FixHint:     &model.FixHint{En: "try again"},
+		WhoRetries:  WhoRetriesYou,
+		Impact:      ImpactThread,
+	})
+
+	// the same report fires an event for each state transition
+	svc.fire(service.StateMsg{ProtocolName: issuecredential.Name, Type: service.PreState, Msg: report,
+		Properties: &props{piid: "piid"}})
+	svc.fire(service.StateMsg{ProtocolName: issuecredential.Name, Type: service.PostState, Msg: report,
+		Properties: &props{piid: "piid"}})
+	svc.fire(service.StateMsg{ProtocolName: issuecredential.Name, Type: service.PostState,
+		Msg: service.NewDIDCommMsgMap(&model.Ack{Type: issuecredential.AckMsgType})})
+
+	select {
+	case event := <-events:
+		require.Equal(t, issuecredential.Name, event.ProtocolName)
+		require.Equal(t, "piid", event.PIID)
+		require.Equal(t, Alice, event.MyDID)
+		require.Equal(t, Bob, event.TheirDID)
+		require.Equal(t, "rejected", event.Report.Description.Code)
+		require.Equal(t, "rejected by the issuer", event.Report.Description.En)
+		require.Equal(t, "try again", event.Report.FixHint.En)
+		require.Equal(t, WhoRetriesYou, event.Report.WhoRetries)
+		require.Equal(t, ImpactThread, event.Report.Impact)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, client.UnregisterEvent(events))
+	require.EqualError(t, client.UnregisterEvent(events), "channel is not registered")
+	require.EqualError(t, client.RegisterEvent(nil), "channel is mandatory")
+}
+
+func TestClient_FirstSeen(t *testing.T) {
+	client, err := New(&provider{})
+	require.NoError(t, err)
+
+	defer client.Close()
+
+	require.True(t, client.firstSeen(""))
+	require.True(t, client.firstSeen(""))
+
+	for i := 0; i <= seenReportsSize; i++ {
+		require.True(t, client.firstSeen(string(rune('a'+i))))
+	}
+
+	require.Len(t, client.seen, seenReportsSize)
+	require.True(t, client.firstSeen("a"))
+	require.False(t, client.firstSeen("c"))
+}
+
+func TestClient_Send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("per-protocol helpers", func(t *testing.T) {
+		for msgType, send := range map[string]func(*Client) func(*ProblemReport, string, string, string) error{
+			issuecredential.ProblemReportMsgType: func(c *Client) func(*ProblemReport, string, string, string) error {
+				return c.SendIssueCredential
+			},
+			presentproof.ProblemReportMsgType: func(c *Client) func(*ProblemReport, string, string, string) error {
+				return c.SendPresentProof
+			},
+			introduce.ProblemReportMsgType: func(c *Client) func(*ProblemReport, string, string, string) error {
+				return c.SendIntroduce
+			},
+		} {
+			messenger := serviceMocks.NewMockMessenger(ctrl)
+			messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+					require.Equal(t, msgType, msg.Type())
+					require.NotEmpty(t, msg.ID())
+					require.Equal(t, "thid", opts.ThreadID)
+					require.Equal(t, Alice, opts.MyDID)
+					require.Equal(t, Bob, opts.TheirDID)
+
+					return nil
+				})
+
+			client, err := New(&provider{messenger: messenger})
+			require.NoError(t, err)
+
+			require.NoError(t, send(client)(&ProblemReport{Description: model.Code{Code: "internal"}}, "thid", Alice, Bob))
+
+			client.Close()
+		}
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).Return(errors.New("test error"))
+
+		client, err := New(&provider{messenger: messenger})
+		require.NoError(t, err)
+
+		defer client.Close()
+
+		err = client.SendPresentProof(&ProblemReport{Description: model.Code{Code: "internal"}}, "thid", Alice, Bob)
+		require.EqualError(t, err, "send problem report: test error")
+	})
+
+	t.Run("invalid report", func(t *testing.T) {
+		client, err := New(&provider{})
+		require.NoError(t, err)
+
+		defer client.Close()
+
+		require.EqualError(t, client.Send(nil, "thid", Alice, Bob), "problem report is mandatory")
+		require.EqualError(t, client.SendIntroduce(nil, "thid", Alice, Bob), "problem report is mandatory")
+		require.EqualError(t, client.Send(&ProblemReport{}, "thid", Alice, Bob), "problem report type is mandatory")
+		require.EqualError(t, client.SendIntroduce(&ProblemReport{}, "thid", Alice, Bob),
+			"problem report code is mandatory")
+	})
+}
+
+type provider struct {
+	messenger service.Messenger
+	services  []dispatcher.ProtocolService
+}
+
+func (p *provider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+func (p *provider) AllServices() []dispatcher.ProtocolService {
+	return p.services
+}
+
+type protocolService struct {
+	service.Message
+	name        string
+	registerErr error
+}
+
+func (s *protocolService) RegisterMsgEvent(ch chan<- service.StateMsg) error {
+	if s.registerErr != nil {
+		return s.registerErr
+	}
+
+	return s.Message.RegisterMsgEvent(ch)
+}
+
+func (s *protocolService) fire(msg service.StateMsg) {
+	for _, handler := range s.MsgEvents() {
+		handler <- msg
+	}
+}
+
+func (s *protocolService) HandleInbound(service.DIDCommMsg, string, string) (string, error) {
+	return "", nil
+}
+
+func (s *protocolService) HandleOutbound(service.DIDCommMsg, string, string) (string, error) {
+	return "", nil
+}
+
+func (s *protocolService) Accept(string) bool {
+	return false
+}
+
+func (s *protocolService) Name() string {
+	return s.name
+}
+
+type noEventsService struct {
+	dispatcher.ProtocolService
+}
+
+type props struct {
+	piid string
+}
+
+func (p *props) PIID() string {
+	return p.piid
+}
+
+func (p *props) MyDID() string {
+	return Alice
+}
+
+func (p *props) TheirDID() string {
+	return Bob
+}
+
+func (p *props) All() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func TestProblemReport_Err(t *testing.T) {
+	report := &ProblemReport{Description: model.Code{Code: model.CodeTrustCrypto}}
+	require.True(t, errors.Is(report.Err(), verifiable.ErrProofInvalid))
+
+	report = &ProblemReport{Description: model.Code{Code: "custom"}}
+	require.EqualError(t, report.Err(), "problem report: custom")
+}
@@ -0,0 +1,38 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package problemreport provides a single place to observe and send the problem reports of all the protocols:
+// https://github.com/hyperledger/aries-rfcs/blob/master/features/0035-report-problem/README.md.
+//
+// Create the client once the framework is started, it observes all the protocol services:
+//
+// 	client, err := problemreport.New(ctx)
+// 	if err != nil {
+// 	 panic(err)
+// 	}
+//
+// 	events := make(chan problemreport.Event)
+// 	client.RegisterEvent(events)
+//
+// 	for event := range events {
+// 	  fmt.Println(event.ProtocolName, event.PIID, event.Report.Description.Code, event.Report.WhoRetries)
+// 	}
+//
+// The errors of the reports tell the failures of the other agents with the errors of the framework:
+//
+// 	if errors.Is(event.Report.Err(), verifiable.ErrProofInvalid) {
+// 	  fmt.Println("the other agent failed to verify the proof")
+// 	}
+//
+// Problem reports are sent in the thread of a protocol instance with the per-protocol helpers:
+//
+// 	err = client.SendIssueCredential(&problemreport.ProblemReport{
+// 	 Description: model.Code{Code: "rejected", En: "the credential schema is not supported"},
+// 	 FixHint:     &model.FixHint{En: "request a credential of another type"},
+// 	 Impact:      problemreport.ImpactThread,
+// 	 WhoRetries:  problemreport.WhoRetriesNone,
+// 	}, piid, myDID, theirDID)
+package problemreport
@@ -0,0 +1,129 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+)
+
+type (
+	// Question is sent to ask the other agent to pick one of the valid responses.
+	Question questionanswer.Question
+	// Props are the properties of the action events fired for received questions and of the message events
+	// fired for received answers.
+	Props questionanswer.Props
+)
+
+var (
+	errEmptyQuestion = errors.New("question is empty")
+
+	// ErrQuestionExpired is returned when the question is answered after its expiration time.
+	ErrQuestionExpired = questionanswer.ErrQuestionExpired
+	// ErrInvalidResponse is returned when the response is not one of the valid responses of the question.
+	ErrInvalidResponse = questionanswer.ErrInvalidResponse
+)
+
+// Provider contains dependencies for the protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the question answer service.
+type ProtocolService interface {
+	service.DIDComm
+	ActionContinue(questionID string, opt questionanswer.Opt) error
+	ActionStop(questionID string) error
+}
+
+// Client enable access to question answer API
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0113-question-answer
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New returns new instance of the question answer client.
+func New(ctx Provider) (*Client, error) {
+	raw, err := ctx.Service(questionanswer.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := raw.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to question answer service failed")
+	}
+
+	return &Client{
+		Event:   svc,
+		service: svc,
+	}, nil
+}
+
+// NewQuestion returns the question with given text and valid responses.
+func NewQuestion(text string, validResponses ...string) *Question {
+	question := &Question{QuestionText: text}
+
+	for _, response := range validResponses {
+		question.ValidResponses = append(question.ValidResponses, questionanswer.ValidResponse{Text: response})
+	}
+
+	return question
+}
+
+// AskQuestion sends the question to the other agent, the answer is notified to the message event subscribers.
+// The ID and the nonce of the question are generated when they are not provided.
+// It returns the ID of the question.
+func (c *Client) AskQuestion(question *Question, myDID, theirDID string) (string, error) {
+	if question == nil {
+		return "", errEmptyQuestion
+	}
+
+	question.Type = questionanswer.QuestionMsgType
+
+	if question.ID == "" {
+		question.ID = uuid.New().String()
+	}
+
+	if question.Nonce == "" {
+		question.Nonce = uuid.New().String()
+	}
+
+	id, err := c.service.HandleOutbound(service.NewDIDCommMsgMap(question), myDID, theirDID)
+	if err != nil {
+		return "", fmt.Errorf("ask question: %w", err)
+	}
+
+	return id, nil
+}
+
+// AnswerQuestion answers the received question with given ID, the response must be one of the valid
+// responses of the question. The response is signed when the question requires the signature.
+func (c *Client) AnswerQuestion(questionID, response string) error {
+	err := c.service.ActionContinue(questionID, questionanswer.WithResponse(response))
+	if err != nil {
+		return fmt.Errorf("answer question: %w", err)
+	}
+
+	return nil
+}
+
+// DeclineQuestion declines the received question with given ID, the other agent is not answered.
+func (c *Client) DeclineQuestion(questionID string) error {
+	err := c.service.ActionStop(questionID)
+	if err != nil {
+		return fmt.Errorf("decline question: %w", err)
+	}
+
+	return nil
+}
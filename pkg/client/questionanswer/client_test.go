@@ -0,0 +1,139 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/questionanswer"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func newClient(t *testing.T, ctrl *gomock.Controller, svc ProtocolService) *Client {
+	t.Helper()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(questionanswer.Name).Return(svc, nil)
+
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestNew(t *testing.T) {
+	const errMsg = "test err"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, errors.New(errMsg))
+		_, err := New(provider)
+		require.EqualError(t, err, errMsg)
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to question answer service failed")
+	})
+}
+
+func TestClient_AskQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, questionanswer.QuestionMsgType, msg.Type())
+				require.NotEmpty(t, msg.ID())
+
+				question := &Question{}
+				require.NoError(t, msg.Decode(question))
+				require.Equal(t, "Approve this login?", question.QuestionText)
+				require.NotEmpty(t, question.Nonce)
+				require.Equal(t, []questionanswer.ValidResponse{{Text: "Yes"}, {Text: "No"}},
+					question.ValidResponses)
+
+				return msg.ID(), nil
+			})
+
+		question := NewQuestion("Approve this login?", "Yes", "No")
+
+		id, err := newClient(t, ctrl, svc).AskQuestion(question, Alice, Bob)
+		require.NoError(t, err)
+		require.Equal(t, question.ID, id)
+	})
+
+	t.Run("Empty question", func(t *testing.T) {
+		_, err := newClient(t, ctrl, mocks.NewMockProtocolService(ctrl)).AskQuestion(nil, Alice, Bob)
+		require.EqualError(t, err, "question is empty")
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).Return("", errors.New("test err"))
+
+		_, err := newClient(t, ctrl, svc).AskQuestion(NewQuestion("Approve this login?", "Yes"), Alice, Bob)
+		require.EqualError(t, err, "ask question: test err")
+	})
+}
+
+func TestClient_AnswerQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().ActionContinue("questionID", gomock.Any()).Return(nil)
+
+		require.NoError(t, newClient(t, ctrl, svc).AnswerQuestion("questionID", "Yes"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().ActionContinue("questionID", gomock.Any()).Return(ErrInvalidResponse)
+
+		err := newClient(t, ctrl, svc).AnswerQuestion("questionID", "Maybe")
+		require.True(t, errors.Is(err, ErrInvalidResponse))
+	})
+}
+
+func TestClient_DeclineQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().ActionStop("questionID").Return(nil)
+
+		require.NoError(t, newClient(t, ctrl, svc).DeclineQuestion("questionID"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().ActionStop("questionID").Return(errors.New("test err"))
+
+		err := newClient(t, ctrl, svc).DeclineQuestion("questionID")
+		require.EqualError(t, err, "decline question: test err")
+	})
+}
@@ -0,0 +1,40 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package questionanswer provides support for the Question Answer Protocol 1.0:
+// https://github.com/hyperledger/aries-rfcs/blob/master/features/0113-question-answer/README.md.
+//
+// The protocol is typically used for human authorization steps, the questioner asks the other agent's user
+// to pick one of the valid responses and may require the answer to be signed:
+//
+// 	client, err := questionanswer.New(ctx)
+// 	if err != nil {
+// 	 panic(err)
+// 	}
+//
+// 	question := questionanswer.NewQuestion("Approve this login?", "Yes", "No")
+// 	question.SignatureRequired = true
+//
+// 	questionID, err := client.AskQuestion(question, myDID, theirDID)
+//
+// 	events := make(chan service.StateMsg)
+// 	client.RegisterMsgEvent(events)
+//
+// 	for event := range events {
+// 	  props := event.Properties.(questionanswer.Props)
+// 	  fmt.Println(props.QuestionID(), props.Response(), props.Signed())
+// 	}
+//
+// The received questions trigger the action events, the question is answered (or declined) by its ID:
+//
+// 	actions := make(chan service.DIDCommAction)
+// 	client.RegisterActionEvent(actions)
+//
+// 	for action := range actions {
+// 	  props := action.Properties.(questionanswer.Props)
+// 	  err = client.AnswerQuestion(props.QuestionID(), "Yes")
+// 	}
+package questionanswer
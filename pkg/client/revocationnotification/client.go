@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revocationnotification
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/revocationnotification"
+)
+
+type (
+	// Revoke is sent by the issuer to notify the holder that a credential was revoked.
+	Revoke revocationnotification.Revoke
+	// Props are the properties of the events fired for received revoke messages.
+	Props revocationnotification.Props
+)
+
+var errEmptyRevoke = errors.New("revoke message is empty")
+
+// Provider contains dependencies for the protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the revocation notification service.
+type ProtocolService interface {
+	service.DIDComm
+}
+
+// Client enable access to revocation notification API
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0721-revocation-notification-v2
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New returns new instance of the revocation notification client.
+func New(ctx Provider) (*Client, error) {
+	raw, err := ctx.Service(revocationnotification.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := raw.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to revocation notification service failed")
+	}
+
+	return &Client{
+		Event:   svc,
+		service: svc,
+	}, nil
+}
+
+// Notify is used by the Issuer to notify the Holder that the credential was revoked.
+// The ThreadID of the message should be set to the thread ID of the issue-credential protocol instance
+// the credential was issued in, so the Holder can correlate the notification.
+// It returns the ID of the revoke message.
+func (c *Client) Notify(msg *Revoke, myDID, theirDID string) (string, error) {
+	if msg == nil {
+		return "", errEmptyRevoke
+	}
+
+	msg.Type = revocationnotification.RevokeMsgType
+
+	if msg.ID == "" {
+		msg.ID = uuid.New().String()
+	}
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(msg), myDID, theirDID)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revocationnotification
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/revocationnotification"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/revocationnotification"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func TestNew(t *testing.T) {
+	const errMsg = "test err"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, errors.New(errMsg))
+		_, err := New(provider)
+		require.EqualError(t, err, errMsg)
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to revocation notification service failed")
+	})
+}
+
+func TestClient_Notify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, revocationnotification.RevokeMsgType, msg.Type())
+				require.NotEmpty(t, msg.ID())
+
+				revoke := &Revoke{}
+				require.NoError(t, msg.Decode(revoke))
+				require.Equal(t, "credential-id", revoke.CredentialID)
+				require.Equal(t, "thread-id", revoke.ThreadID)
+
+				return msg.ID(), nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		id, err := client.Notify(&Revoke{CredentialID: "credential-id", ThreadID: "thread-id"}, Alice, Bob)
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+	})
+
+	t.Run("Empty message", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.Notify(nil, Alice, Bob)
+		require.EqualError(t, err, errEmptyRevoke.Error())
+	})
+}
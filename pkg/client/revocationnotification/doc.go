@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package revocationnotification provides support for the Revocation Notification Protocol 2.0:
+// https://github.com/hyperledger/aries-rfcs/blob/master/features/0721-revocation-notification-v2/README.md.
+//
+// The Issuer notifies the Holder that a credential it issued was revoked:
+//
+// 	client, err := revocationnotification.New(ctx)
+// 	if err != nil {
+// 	 panic(err)
+// 	}
+//
+// 	_, err = client.Notify(&revocationnotification.Revoke{
+// 	 RevocationFormat: "indy-anoncreds",
+// 	 CredentialID:     credentialID,
+// 	 ThreadID:         issueCredentialThreadID,
+// 	}, myDID, theirDID)
+//
+// The Holder (e.g. a wallet) registers a message event channel and is notified of every revocation. When the
+// framework's verifiable store holds the credential, its record is marked as revoked as well.
+//
+// 	events := make(chan service.StateMsg)
+// 	client.RegisterMsgEvent(events)
+//
+// 	for event := range events {
+// 	  props := event.Properties.(revocationnotification.Props)
+// 	  fmt.Println(props.CredentialID(), props.ThreadID(), props.Revoked())
+// 	}
+package revocationnotification
@@ -0,0 +1,262 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+// DefaultUnreachableThreshold is the number of consecutive unanswered pings after which a connection is
+// considered unreachable.
+const DefaultUnreachableThreshold = 3
+
+// Props are the properties of the events fired for received ping responses.
+type Props trustping.Props
+
+// ErrNoResponse is returned when the ping is not answered in time.
+var ErrNoResponse = trustping.ErrNoResponse
+
+var logger = log.New("aries-framework/client/trustping")
+
+// Provider contains dependencies for the protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// ProtocolService defines the trust ping service.
+type ProtocolService interface {
+	service.DIDComm
+	Ping(myDID, theirDID string, timeout time.Duration) (time.Duration, error)
+}
+
+// Status is the reachability of a connection, as observed by the pings sent to it.
+type Status struct {
+	// Reachable is false once the connection did not answer DefaultUnreachableThreshold pings in a row
+	// (or the threshold set with WithUnreachableThreshold).
+	Reachable bool `json:"reachable"`
+	// LastLatency is the round-trip time of the last answered ping.
+	LastLatency time.Duration `json:"last_latency,omitempty"`
+	// LastPingTime is the time the last ping was sent.
+	LastPingTime time.Time `json:"last_ping_time,omitempty"`
+	// LastResponseTime is the time the last ping response was received.
+	LastResponseTime time.Time `json:"last_response_time,omitempty"`
+	// ConsecutiveFailures is the number of pings not answered since the last response.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+}
+
+// Option configures the trust ping client.
+type Option func(c *Client)
+
+// WithUnreachableThreshold sets the number of consecutive unanswered pings after which a connection is
+// considered unreachable.
+func WithUnreachableThreshold(threshold int) Option {
+	return func(c *Client) {
+		c.unreachableThreshold = threshold
+	}
+}
+
+// Client enable access to trust ping API
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0048-trust-ping
+type Client struct {
+	service.Event
+	service              ProtocolService
+	connections          *connection.Lookup
+	unreachableThreshold int
+	statuses             map[string]*Status
+	lock                 sync.RWMutex
+	stopHealthCheck      chan struct{}
+	healthCheckWG        sync.WaitGroup
+}
+
+// New returns new instance of the trust ping client.
+func New(ctx Provider, opts ...Option) (*Client, error) {
+	raw, err := ctx.Service(trustping.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := raw.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to trust ping service failed")
+	}
+
+	connections, err := connection.NewLookup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new connection lookup: %w", err)
+	}
+
+	c := &Client{
+		Event:                svc,
+		service:              svc,
+		connections:          connections,
+		unreachableThreshold: DefaultUnreachableThreshold,
+		statuses:             map[string]*Status{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Ping sends a ping over the connection with given ID and waits for the response.
+// It returns the round-trip latency, or ErrNoResponse if the ping is not answered within the timeout.
+// The outcome is reflected in the Status of the connection.
+func (c *Client) Ping(connectionID string, timeout time.Duration) (time.Duration, error) {
+	record, err := c.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return 0, fmt.Errorf("get connection record: %w", err)
+	}
+
+	sent := time.Now()
+
+	latency, err := c.service.Ping(record.MyDID, record.TheirDID, timeout)
+
+	c.updateStatus(connectionID, sent, latency, err)
+
+	if err != nil {
+		return 0, fmt.Errorf("ping connection %s: %w", connectionID, err)
+	}
+
+	return latency, nil
+}
+
+// Status returns the reachability of the connection with given ID.
+// The second return value is false if no ping was ever sent over the connection.
+func (c *Client) Status(connectionID string) (Status, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	status, ok := c.statuses[connectionID]
+	if !ok {
+		return Status{}, false
+	}
+
+	return *status, true
+}
+
+// UnreachableConnections returns the IDs of the connections considered unreachable.
+func (c *Client) UnreachableConnections() []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var unreachable []string
+
+	for connectionID, status := range c.statuses {
+		if !status.Reachable {
+			unreachable = append(unreachable, connectionID)
+		}
+	}
+
+	return unreachable
+}
+
+// StartHealthCheck pings the connections with given IDs every interval, until StopHealthCheck is called.
+// The pings not answered within the timeout are counted as failures in the connection Status.
+// A running health check is stopped first.
+func (c *Client) StartHealthCheck(interval, timeout time.Duration, connectionIDs ...string) error {
+	if interval <= 0 {
+		return errors.New("health check interval must be positive")
+	}
+
+	c.StopHealthCheck()
+
+	stop := make(chan struct{})
+
+	c.lock.Lock()
+	c.stopHealthCheck = stop
+	c.lock.Unlock()
+
+	c.healthCheckWG.Add(1)
+
+	go func() {
+		defer c.healthCheckWG.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			c.pingAll(timeout, connectionIDs)
+
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// StopHealthCheck stops the periodic pings started with StartHealthCheck.
+func (c *Client) StopHealthCheck() {
+	c.lock.Lock()
+	stop := c.stopHealthCheck
+	c.stopHealthCheck = nil
+	c.lock.Unlock()
+
+	if stop != nil {
+		close(stop)
+		c.healthCheckWG.Wait()
+	}
+}
+
+func (c *Client) pingAll(timeout time.Duration, connectionIDs []string) {
+	var wg sync.WaitGroup
+
+	for _, connectionID := range connectionIDs {
+		wg.Add(1)
+
+		go func(connectionID string) {
+			defer wg.Done()
+
+			if _, err := c.Ping(connectionID, timeout); err != nil {
+				logger.Warnf("health check: %s", err)
+			}
+		}(connectionID)
+	}
+
+	wg.Wait()
+}
+
+func (c *Client) updateStatus(connectionID string, sent time.Time, latency time.Duration, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	status, ok := c.statuses[connectionID]
+	if !ok {
+		status = &Status{}
+		c.statuses[connectionID] = status
+	}
+
+	status.LastPingTime = sent
+
+	if err != nil {
+		status.ConsecutiveFailures++
+		status.Reachable = status.ConsecutiveFailures < c.unreachableThreshold
+
+		return
+	}
+
+	status.Reachable = true
+	status.ConsecutiveFailures = 0
+	status.LastLatency = latency
+	status.LastResponseTime = sent.Add(latency)
+}
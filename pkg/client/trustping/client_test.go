@@ -0,0 +1,193 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	Alice        = "Alice"
+	Bob          = "Bob"
+	connectionID = "connection-id"
+)
+
+func newProvider(t *testing.T, svc interface{}) *mockprovider.Provider {
+	t.Helper()
+
+	p := &mockprovider.Provider{
+		ServiceValue:                      svc,
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+	}
+
+	recorder, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connectionID,
+		State:        connection.StateNameCompleted,
+		MyDID:        Alice,
+		TheirDID:     Bob,
+	}))
+
+	return p
+}
+
+func TestNew(t *testing.T) {
+	const errMsg = "test err"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, errors.New(errMsg))
+		_, err := New(provider)
+		require.EqualError(t, err, errMsg)
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to trust ping service failed")
+	})
+
+	t.Run("connection lookup error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			ServiceValue:         mocks.NewMockProtocolService(ctrl),
+			StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New(errMsg)},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "new connection lookup")
+	})
+}
+
+func TestClient_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Ping(Alice, Bob, time.Second).Return(42*time.Millisecond, nil)
+
+		client, err := New(newProvider(t, svc))
+		require.NoError(t, err)
+
+		_, ok := client.Status(connectionID)
+		require.False(t, ok)
+
+		latency, err := client.Ping(connectionID, time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 42*time.Millisecond, latency)
+
+		status, ok := client.Status(connectionID)
+		require.True(t, ok)
+		require.True(t, status.Reachable)
+		require.Equal(t, 42*time.Millisecond, status.LastLatency)
+		require.Equal(t, 0, status.ConsecutiveFailures)
+		require.False(t, status.LastPingTime.IsZero())
+		require.Equal(t, status.LastPingTime.Add(latency), status.LastResponseTime)
+		require.Empty(t, client.UnreachableConnections())
+	})
+
+	t.Run("unreachable after consecutive failures", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Ping(Alice, Bob, time.Second).Return(time.Duration(0), trustping.ErrNoResponse).Times(2)
+
+		client, err := New(newProvider(t, svc), WithUnreachableThreshold(2))
+		require.NoError(t, err)
+
+		_, err = client.Ping(connectionID, time.Second)
+		require.True(t, errors.Is(err, ErrNoResponse))
+
+		status, _ := client.Status(connectionID)
+		require.True(t, status.Reachable)
+		require.Equal(t, 1, status.ConsecutiveFailures)
+
+		_, err = client.Ping(connectionID, time.Second)
+		require.True(t, errors.Is(err, ErrNoResponse))
+
+		status, _ = client.Status(connectionID)
+		require.False(t, status.Reachable)
+		require.Equal(t, 2, status.ConsecutiveFailures)
+		require.Equal(t, []string{connectionID}, client.UnreachableConnections())
+
+		svc.EXPECT().Ping(Alice, Bob, time.Second).Return(time.Millisecond, nil)
+
+		_, err = client.Ping(connectionID, time.Second)
+		require.NoError(t, err)
+
+		status, _ = client.Status(connectionID)
+		require.True(t, status.Reachable)
+		require.Equal(t, 0, status.ConsecutiveFailures)
+	})
+
+	t.Run("unknown connection", func(t *testing.T) {
+		client, err := New(newProvider(t, mocks.NewMockProtocolService(ctrl)))
+		require.NoError(t, err)
+
+		_, err = client.Ping("unknown", time.Second)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+}
+
+func TestClient_HealthCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("pings periodically until stopped", func(t *testing.T) {
+		pinged := make(chan struct{}, 10)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Ping(Alice, Bob, time.Second).
+			DoAndReturn(func(string, string, time.Duration) (time.Duration, error) {
+				pinged <- struct{}{}
+
+				return time.Millisecond, nil
+			}).MinTimes(2)
+
+		client, err := New(newProvider(t, svc))
+		require.NoError(t, err)
+
+		require.NoError(t, client.StartHealthCheck(10*time.Millisecond, time.Second, connectionID))
+
+		for i := 0; i < 2; i++ {
+			select {
+			case <-pinged:
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for ping")
+			}
+		}
+
+		client.StopHealthCheck()
+		client.StopHealthCheck()
+
+		status, ok := client.Status(connectionID)
+		require.True(t, ok)
+		require.True(t, status.Reachable)
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		client, err := New(newProvider(t, mocks.NewMockProtocolService(ctrl)))
+		require.NoError(t, err)
+
+		require.EqualError(t, client.StartHealthCheck(0, time.Second), "health check interval must be positive")
+	})
+}
@@ -0,0 +1,40 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package trustping provides support for the Trust Ping Protocol 1.0:
+// https://github.com/hyperledger/aries-rfcs/blob/master/features/0048-trust-ping/README.md.
+//
+// Ping a connection and get the round-trip latency:
+//
+// 	client, err := trustping.New(ctx)
+// 	if err != nil {
+// 	 panic(err)
+// 	}
+//
+// 	latency, err := client.Ping(connectionID, 5*time.Second)
+// 	if errors.Is(err, trustping.ErrNoResponse) {
+// 	 // the connection did not answer in time
+// 	}
+//
+// Connections can be pinged periodically as a health check. A connection is reported as unreachable once it
+// misses trustping.DefaultUnreachableThreshold pings in a row:
+//
+// 	err = client.StartHealthCheck(time.Minute, 5*time.Second, connectionIDs...)
+// 	defer client.StopHealthCheck()
+//
+// 	status, ok := client.Status(connectionID)
+// 	unreachable := client.UnreachableConnections()
+//
+// Responses to pings sent by the agent are notified on the message event channels, with the round-trip latency:
+//
+// 	events := make(chan service.StateMsg)
+// 	client.RegisterMsgEvent(events)
+//
+// 	for event := range events {
+// 	  props := event.Properties.(trustping.Props)
+// 	  fmt.Println(props.TheirDID(), props.Latency())
+// 	}
+package trustping
@@ -0,0 +1,252 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package cache provides the cache service of the framework, shared by the verifiable, vdr and wallet packages for
+// the parsed credentials, the resolved DID documents and the downloaded JSON-LD contexts, so that a busy verifier
+// does not resolve, download and check the same documents over and over.
+//
+// The cache is bounded in size, its least recently used entries being evicted first, and its entries expire after
+// a time to live. The entries are shared: their values must not be modified. The entries are invalidated when their
+// documents change (e.g. a DID document stored in the VDR), the invalidation hooks being called for each of them.
+//
+// A nil cache caches nothing, so that the users of the cache of the framework context don't check it's enabled.
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+)
+
+const (
+	// DefaultSize is the maximum number of entries of the cache if not set.
+	DefaultSize = 1000
+	// DefaultTTL is the time to live of the entries of the cache if not set.
+	DefaultTTL = 5 * time.Minute
+)
+
+// Prefixes of the keys of the entries cached by the framework.
+const (
+	// CredentialKeyPrefix prefixes the keys of the parsed credentials.
+	CredentialKeyPrefix = "vc:"
+	// DIDDocKeyPrefix prefixes the keys of the resolved DID documents, followed by the DID.
+	DIDDocKeyPrefix = "diddoc:"
+	// JSONLDKeyPrefix prefixes the keys of the loaded JSON-LD documents (e.g. contexts), followed by the URL.
+	JSONLDKeyPrefix = "jsonld:"
+)
+
+// Cache is a cache of bounded size whose entries expire. It's safe for concurrent use.
+type Cache struct {
+	size  int
+	ttl   time.Duration
+	clock clock.Clock
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	hooks   []func(key string)
+}
+
+type entry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// Option configures the cache.
+type Option func(c *Cache)
+
+// WithSize sets the maximum number of entries of the cache, DefaultSize if not set.
+func WithSize(size int) Option {
+	return func(c *Cache) {
+		c.size = size
+	}
+}
+
+// WithTTL sets the time to live of the entries of the cache, DefaultTTL if not set. The entries of a zero time to
+// live don't expire.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.ttl = ttl
+	}
+}
+
+// WithClock sets the clock of the expiration of the entries, the clock of the system if not set.
+func WithClock(cl clock.Clock) Option {
+	return func(c *Cache) {
+		c.clock = cl
+	}
+}
+
+// New returns a new cache.
+func New(opts ...Option) *Cache {
+	c := &Cache{
+		size:    DefaultSize,
+		ttl:     DefaultTTL,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.clock = clock.OrSystem(c.clock)
+
+	return c
+}
+
+// Get returns the value of the entry with given key, false if there is no such entry or it's expired.
+func (c *Cache) Get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := elem.Value.(*entry) // nolint: errcheck
+
+	if !e.expires.IsZero() && !c.clock.Now().Before(e.expires) {
+		c.remove(elem)
+
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+
+	return e.value, true
+}
+
+// Put sets the value of the entry with given key, evicting the least recently used entry if the cache is full.
+func (c *Cache) Put(key string, value interface{}) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.clock.Now().Add(c.ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = &entry{key: key, value: value, expires: expires}
+		c.lru.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&entry{key: key, value: value, expires: expires})
+
+	for c.size > 0 && c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Len returns the number of entries of the cache, including the expired entries not removed yet.
+func (c *Cache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.lru.Len()
+}
+
+// OnInvalidate registers a hook called with the key of each entry invalidated with Invalidate, InvalidatePrefix
+// or Clear. The hooks are not called for the evicted and the expired entries.
+func (c *Cache) OnInvalidate(hook func(key string)) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.hooks = append(c.hooks, hook)
+}
+
+// Invalidate removes the entry with given key.
+func (c *Cache) Invalidate(key string) {
+	c.invalidate(func(k string) bool {
+		return k == key
+	})
+}
+
+// InvalidatePrefix removes the entries whose key has given prefix, e.g. CredentialKeyPrefix.
+func (c *Cache) InvalidatePrefix(prefix string) {
+	c.invalidate(func(k string) bool {
+		return strings.HasPrefix(k, prefix)
+	})
+}
+
+// Clear removes all the entries.
+func (c *Cache) Clear() {
+	c.invalidate(func(string) bool {
+		return true
+	})
+}
+
+func (c *Cache) invalidate(match func(key string) bool) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+
+	var keys []string
+
+	for key, elem := range c.entries {
+		if match(key) {
+			c.remove(elem)
+
+			keys = append(keys, key)
+		}
+	}
+
+	hooks := c.hooks
+
+	c.lock.Unlock()
+
+	// the hooks are called unlocked, they may use the cache
+	for _, key := range keys {
+		for _, hook := range hooks {
+			hook(key)
+		}
+	}
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*entry).key) // nolint: errcheck
+}
+
+// Provider is implemented by the framework context, whose cache is shared by the components of the framework.
+type Provider interface {
+	Cache() *Cache
+}
+
+// Of returns the cache of ctx, nil (i.e. no caching) if ctx is not a Provider.
+func Of(ctx interface{}) *Cache {
+	if p, ok := ctx.(Provider); ok {
+		return p.Cache()
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("get and put", func(t *testing.T) {
+		c := New()
+
+		_, ok := c.Get("a")
+		require.False(t, ok)
+
+		c.Put("a", 1)
+		c.Put("a", 2)
+
+		v, ok := c.Get("a")
+		require.True(t, ok)
+		require.Equal(t, 2, v)
+		require.Equal(t, 1, c.Len())
+	})
+
+	t.Run("evicts the least recently used entries", func(t *testing.T) {
+		c := New(WithSize(2))
+
+		c.Put("a", 1)
+		c.Put("b", 2)
+		c.Get("a")
+		c.Put("c", 3)
+
+		_, ok := c.Get("b")
+		require.False(t, ok)
+
+		_, ok = c.Get("a")
+		require.True(t, ok)
+
+		_, ok = c.Get("c")
+		require.True(t, ok)
+	})
+
+	t.Run("entries expire", func(t *testing.T) {
+		now := time.Now()
+		c := New(WithTTL(time.Minute), WithClock(clock.Func(func() time.Time {
+			return now
+		})))
+
+		c.Put("a", 1)
+
+		now = now.Add(59 * time.Second)
+		_, ok := c.Get("a")
+		require.True(t, ok)
+
+		now = now.Add(time.Second)
+		_, ok = c.Get("a")
+		require.False(t, ok)
+		require.Equal(t, 0, c.Len())
+
+		c = New(WithTTL(0))
+		c.Put("a", 1)
+
+		now = now.Add(time.Hour)
+		_, ok = c.Get("a")
+		require.True(t, ok)
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		c := New()
+
+		var invalidated []string
+
+		c.OnInvalidate(func(key string) {
+			invalidated = append(invalidated, key)
+		})
+
+		c.Put(DIDDocKeyPrefix+"did:example:1", 1)
+		c.Put(DIDDocKeyPrefix+"did:example:2", 2)
+		c.Put(CredentialKeyPrefix+"1", 3)
+		c.Put(JSONLDKeyPrefix+"https://www.w3.org/2018/credentials/v1", 4)
+
+		c.Invalidate(DIDDocKeyPrefix + "did:example:1")
+		c.Invalidate("unknown")
+		require.Equal(t, []string{DIDDocKeyPrefix + "did:example:1"}, invalidated)
+
+		invalidated = nil
+
+		c.InvalidatePrefix(DIDDocKeyPrefix)
+		require.Equal(t, []string{DIDDocKeyPrefix + "did:example:2"}, invalidated)
+		require.Equal(t, 2, c.Len())
+
+		invalidated = nil
+
+		c.Clear()
+		sort.Strings(invalidated)
+		require.Equal(t, []string{JSONLDKeyPrefix + "https://www.w3.org/2018/credentials/v1", CredentialKeyPrefix + "1"},
+			invalidated)
+		require.Equal(t, 0, c.Len())
+	})
+
+	t.Run("nil cache", func(t *testing.T) {
+		var c *Cache
+
+		c.Put("a", 1)
+		c.OnInvalidate(func(string) {})
+		c.Invalidate("a")
+		c.Clear()
+
+		_, ok := c.Get("a")
+		require.False(t, ok)
+		require.Equal(t, 0, c.Len())
+	})
+}
+
+type provider struct {
+	cache *Cache
+}
+
+func (p *provider) Cache() *Cache {
+	return p.cache
+}
+
+func TestOf(t *testing.T) {
+	c := New()
+
+	require.Equal(t, c, Of(&provider{cache: c}))
+	require.Nil(t, Of(&provider{}))
+	require.Nil(t, Of(struct{}{}))
+}
+
+type countingLoader struct {
+	loads int
+}
+
+func (l *countingLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	l.loads++
+
+	if u == "https://example.com/unknown" {
+		return nil, errors.New("not found")
+	}
+
+	return &ld.RemoteDocument{DocumentURL: u}, nil
+}
+
+func TestJSONLDLoader(t *testing.T) {
+	c := New()
+	loader := &countingLoader{}
+	cachingLoader := JSONLDLoader(c, loader)
+
+	for i := 0; i < 2; i++ {
+		doc, err := cachingLoader.LoadDocument("https://www.w3.org/2018/credentials/v1")
+		require.NoError(t, err)
+		require.Equal(t, "https://www.w3.org/2018/credentials/v1", doc.DocumentURL)
+	}
+
+	require.Equal(t, 1, loader.loads)
+
+	_, err := cachingLoader.LoadDocument("https://example.com/unknown")
+	require.Error(t, err)
+
+	_, ok := c.Get(JSONLDKeyPrefix + "https://example.com/unknown")
+	require.False(t, ok)
+
+	require.Equal(t, loader, JSONLDLoader(nil, loader))
+}
@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"github.com/piprate/json-gold/ld"
+)
+
+// JSONLDLoader returns a JSON-LD document loader caching the documents (e.g. the contexts) loaded by loader.
+// It returns loader if the cache is nil.
+func JSONLDLoader(c *Cache, loader ld.DocumentLoader) ld.DocumentLoader {
+	if c == nil {
+		return loader
+	}
+
+	return &jsonldLoader{cache: c, loader: loader}
+}
+
+type jsonldLoader struct {
+	cache  *Cache
+	loader ld.DocumentLoader
+}
+
+// LoadDocument returns the cached document, loading it if it isn't cached yet.
+func (l *jsonldLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	key := JSONLDKeyPrefix + u
+
+	if doc, ok := l.cache.Get(key); ok {
+		return doc.(*ld.RemoteDocument), nil // nolint: errcheck
+	}
+
+	doc, err := l.loader.LoadDocument(u)
+	if err != nil {
+		return nil, err
+	}
+
+	l.cache.Put(key, doc)
+
+	return doc, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package clock defines the clock of the time-dependent checks of the framework (e.g. the expiry of the
+// credentials, the timeouts of the protocols), so that the tests can simulate the time and the agents can apply
+// their clock skew policies.
+package clock
+
+import "time"
+
+// Clock tells the current time.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
+
+// Func is a function telling the current time.
+type Func func() time.Time
+
+// Now calls f().
+func (f Func) Now() time.Time {
+	return f()
+}
+
+// System returns the clock of the system.
+func System() Clock {
+	return Func(time.Now)
+}
+
+// Source is implemented by the framework context, the protocol services check their timeouts with its clock.
+type Source interface {
+	Clock() Clock
+}
+
+// Of returns the clock of ctx, the clock of the system when ctx is not a Source or it has no clock.
+func Of(ctx interface{}) Clock {
+	var c Clock
+
+	if src, ok := ctx.(Source); ok {
+		c = src.Clock()
+	}
+
+	if c == nil {
+		c = System()
+	}
+
+	return c
+}
+
+// OrSystem returns the clock, the clock of the system if it's nil.
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return System()
+	}
+
+	return c
+}
+
+// Since returns the time elapsed since t according to the clock.
+func Since(c Clock, t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
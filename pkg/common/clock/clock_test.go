@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+)
+
+type source struct {
+	clock clock.Clock
+}
+
+func (s *source) Clock() clock.Clock {
+	return s.clock
+}
+
+func TestOf(t *testing.T) {
+	epoch := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c := mockclock.New(epoch)
+
+	require.Equal(t, epoch, clock.Of(&source{clock: c}).Now())
+
+	// defaults to the clock of the system
+	for _, ctx := range []interface{}{nil, "not a source", &source{}} {
+		now := clock.Of(ctx).Now()
+		require.WithinDuration(t, time.Now(), now, time.Second)
+	}
+
+	require.Equal(t, c, clock.OrSystem(c))
+	require.WithinDuration(t, time.Now(), clock.OrSystem(nil).Now(), time.Second)
+}
+
+func TestSince(t *testing.T) {
+	epoch := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c := mockclock.New(epoch)
+
+	c.Add(time.Hour)
+	require.Equal(t, time.Hour, clock.Since(c, epoch))
+
+	c.Set(epoch.Add(-time.Minute))
+	require.Equal(t, -time.Minute, clock.Since(c, epoch))
+}
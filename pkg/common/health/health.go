@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package health aggregates the probes of the components of an agent (e.g. its stores, its KMS, its inbound
+// transports) into liveness and readiness reports, such as those of the Kubernetes probes.
+//
+// Usage:
+//
+//	checker := health.NewChecker()
+//	checker.Register("storage", health.Readiness, health.Storage(storeProvider))
+//
+//	report := checker.Ready(ctx)
+//	if report.Status != health.StatusUp {
+//	    // e.g. stop routing the requests to the agent
+//	}
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultTimeout is the default timeout of a probe.
+const defaultTimeout = 5 * time.Second
+
+// Status is the status of an agent or of one of its components.
+type Status string
+
+const (
+	// StatusUp is the status of a healthy component.
+	StatusUp Status = "up"
+	// StatusDown is the status of a failing component.
+	StatusDown Status = "down"
+)
+
+// Kind is the kind of a probe.
+type Kind int
+
+const (
+	// Liveness probes fail when the agent has to be restarted, e.g. once it's stopped.
+	Liveness Kind = iota
+	// Readiness probes fail when the agent can't serve requests for now, e.g. when its stores are unreachable.
+	Readiness
+)
+
+// Probe checks a component, it returns an error if the component is failing.
+type Probe func(ctx context.Context) error
+
+// ComponentStatus is the status of a component of the agent.
+type ComponentStatus struct {
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the status of the agent and of its components.
+type Report struct {
+	Status     Status                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+// Option configures the checker.
+type Option func(c *Checker)
+
+// WithTimeout sets the timeout of each probe, five seconds by default. A probe timing out is failing.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) {
+		c.timeout = timeout
+	}
+}
+
+type component struct {
+	name  string
+	kind  Kind
+	probe Probe
+}
+
+// Checker aggregates the probes of the components of an agent.
+type Checker struct {
+	timeout time.Duration
+
+	mu         sync.RWMutex
+	components []component
+}
+
+// NewChecker returns a new checker without probes.
+func NewChecker(opts ...Option) *Checker {
+	c := &Checker{timeout: defaultTimeout}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Register registers the probe of the component. Registering a component again replaces its probe.
+func (c *Checker) Register(name string, kind Kind, probe Probe) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.components {
+		if c.components[i].name == name {
+			c.components[i] = component{name: name, kind: kind, probe: probe}
+
+			return
+		}
+	}
+
+	c.components = append(c.components, component{name: name, kind: kind, probe: probe})
+}
+
+// Unregister removes the probe of the component.
+func (c *Checker) Unregister(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.components {
+		if c.components[i].name == name {
+			c.components = append(c.components[:i], c.components[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Live runs the liveness probes, the agent is live if all of them succeed.
+func (c *Checker) Live(ctx context.Context) *Report {
+	return c.check(ctx, func(kind Kind) bool { return kind == Liveness })
+}
+
+// Ready runs all the probes, the agent is ready if all of them succeed.
+func (c *Checker) Ready(ctx context.Context) *Report {
+	return c.check(ctx, func(Kind) bool { return true })
+}
+
+// check runs the selected probes concurrently.
+func (c *Checker) check(ctx context.Context, selected func(Kind) bool) *Report {
+	c.mu.RLock()
+
+	var components []component
+
+	for _, comp := range c.components {
+		if selected(comp.kind) {
+			components = append(components, comp)
+		}
+	}
+
+	c.mu.RUnlock()
+
+	report := &Report{Status: StatusUp, Components: make(map[string]ComponentStatus, len(components))}
+	results := make([]error, len(components))
+
+	var wg sync.WaitGroup
+
+	for i := range components {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			results[i] = c.run(ctx, components[i].probe)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, comp := range components {
+		if results[i] != nil {
+			report.Status = StatusDown
+			report.Components[comp.name] = ComponentStatus{Status: StatusDown, Error: results[i].Error()}
+
+			continue
+		}
+
+		report.Components[comp.name] = ComponentStatus{Status: StatusUp}
+	}
+
+	return report
+}
+
+// run runs the probe until it returns or times out.
+func (c *Checker) run(ctx context.Context, probe Probe) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+
+	go func() {
+		result <- probe(ctx)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
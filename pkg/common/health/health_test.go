@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	t.Run("reports the status of the components", func(t *testing.T) {
+		checker := NewChecker()
+
+		require.Equal(t, &Report{Status: StatusUp, Components: map[string]ComponentStatus{}},
+			checker.Ready(context.Background()))
+
+		checker.Register("framework", Liveness, func(context.Context) error { return nil })
+		checker.Register("storage", Readiness, func(context.Context) error { return errors.New("unreachable") })
+		checker.Register("kms", Readiness, func(context.Context) error { return nil })
+
+		require.Equal(t, &Report{
+			Status:     StatusUp,
+			Components: map[string]ComponentStatus{"framework": {Status: StatusUp}},
+		}, checker.Live(context.Background()))
+
+		require.Equal(t, &Report{
+			Status: StatusDown,
+			Components: map[string]ComponentStatus{
+				"framework": {Status: StatusUp},
+				"storage":   {Status: StatusDown, Error: "unreachable"},
+				"kms":       {Status: StatusUp},
+			},
+		}, checker.Ready(context.Background()))
+
+		// registering a component again replaces its probe
+		checker.Register("storage", Readiness, func(context.Context) error { return nil })
+		require.Equal(t, StatusUp, checker.Ready(context.Background()).Status)
+
+		checker.Register("framework", Liveness, func(context.Context) error { return errors.New("stopped") })
+		checker.Unregister("framework")
+		checker.Unregister("unknown")
+		require.Equal(t, &Report{Status: StatusUp, Components: map[string]ComponentStatus{}},
+			checker.Live(context.Background()))
+	})
+
+	t.Run("times out the probes", func(t *testing.T) {
+		checker := NewChecker(WithTimeout(10 * time.Millisecond))
+
+		stop := make(chan struct{})
+		defer close(stop)
+
+		checker.Register("mediator", Readiness, func(context.Context) error {
+			<-stop
+			return nil
+		})
+
+		require.Equal(t, &Report{
+			Status:     StatusDown,
+			Components: map[string]ComponentStatus{"mediator": {Status: StatusDown, Error: "context deadline exceeded"}},
+		}, checker.Ready(context.Background()))
+	})
+}
@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// StoreName is the name of the store of the probes.
+	StoreName = "health"
+
+	storageProbeKey = "storage-probe"
+	kmsProbeKey     = "kms-probe-key"
+)
+
+// Storage probes the storage provider: the provider is reachable if a record of the health store can be read.
+func Storage(provider storage.Provider) Probe {
+	return func(context.Context) error {
+		store, err := provider.OpenStore(StoreName)
+		if err != nil {
+			return fmt.Errorf("open store: %w", err)
+		}
+
+		if _, err = store.Get(storageProbeKey); err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+			return fmt.Errorf("read store: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// KMS probes the key manager: the key manager is unlocked if a key it created can be read back, which requires
+// the secret lock to decrypt it. The key is created once and its ID is saved in the health store of the storage
+// provider.
+func KMS(km kms.KeyManager, provider storage.Provider) Probe {
+	return func(context.Context) error {
+		store, err := provider.OpenStore(StoreName)
+		if err != nil {
+			return fmt.Errorf("open store: %w", err)
+		}
+
+		kid, err := store.Get(kmsProbeKey)
+
+		switch {
+		case errors.Is(err, storage.ErrDataNotFound):
+			id, _, e := km.Create(kms.ED25519Type)
+			if e != nil {
+				return fmt.Errorf("create key: %w", e)
+			}
+
+			kid = []byte(id)
+
+			if e = store.Put(kmsProbeKey, kid); e != nil {
+				return fmt.Errorf("save key ID: %w", e)
+			}
+		case err != nil:
+			return fmt.Errorf("read key ID: %w", err)
+		}
+
+		if _, err = km.Get(string(kid)); err != nil {
+			return fmt.Errorf("get key: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Listening probes the server listening at the address, e.g. the internal address of an inbound transport.
+func Listening(address string) Probe {
+	return func(ctx context.Context) error {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+		if err != nil {
+			return fmt.Errorf("not listening at %s: %w", address, err)
+		}
+
+		return conn.Close()
+	}
+}
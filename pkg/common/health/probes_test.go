@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestStorage(t *testing.T) {
+	require.NoError(t, Storage(mem.NewProvider())(context.Background()))
+
+	err := Storage(&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("unreachable")})(context.Background())
+	require.EqualError(t, err, "open store: unreachable")
+
+	provider := mockstorage.NewMockStoreProvider()
+	provider.Store.ErrGet = errors.New("unreachable")
+
+	require.EqualError(t, Storage(provider)(context.Background()), "read store: unreachable")
+}
+
+func TestKMS(t *testing.T) {
+	t.Run("creates the probe key once", func(t *testing.T) {
+		provider := mem.NewProvider()
+		km := &mockkms.KeyManager{CreateKeyID: "kid1"}
+
+		require.NoError(t, KMS(km, provider)(context.Background()))
+
+		// the saved key is read back
+		km.CreateKeyErr = errors.New("create error")
+		require.NoError(t, KMS(km, provider)(context.Background()))
+
+		store, err := provider.OpenStore(StoreName)
+		require.NoError(t, err)
+
+		kid, err := store.Get(kmsProbeKey)
+		require.NoError(t, err)
+		require.Equal(t, "kid1", string(kid))
+
+		km.GetKeyErr = errors.New("locked")
+		require.EqualError(t, KMS(km, provider)(context.Background()), "get key: locked")
+	})
+
+	t.Run("fails to create the probe key", func(t *testing.T) {
+		km := &mockkms.KeyManager{CreateKeyErr: errors.New("locked")}
+
+		require.EqualError(t, KMS(km, mem.NewProvider())(context.Background()), "create key: locked")
+	})
+
+	t.Run("fails to access the store", func(t *testing.T) {
+		err := KMS(&mockkms.KeyManager{},
+			&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("unreachable")})(context.Background())
+		require.EqualError(t, err, "open store: unreachable")
+
+		provider := mockstorage.NewMockStoreProvider()
+		provider.Store.ErrGet = errors.New("unreachable")
+
+		require.EqualError(t, KMS(&mockkms.KeyManager{}, provider)(context.Background()), "read key ID: unreachable")
+
+		provider = mockstorage.NewMockStoreProvider()
+		provider.Store.ErrPut = errors.New("read-only")
+
+		require.EqualError(t, KMS(&mockkms.KeyManager{}, provider)(context.Background()), "save key ID: read-only")
+	})
+}
+
+func TestListening(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := listener.Addr().String()
+
+	require.NoError(t, Listening(address)(context.Background()))
+	require.NoError(t, listener.Close())
+
+	err = Listening(address)(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not listening at "+address)
+}
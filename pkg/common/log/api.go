@@ -40,6 +40,26 @@ type Logger interface {
 	Errorf(msg string, args ...interface{})
 }
 
+// Field is a key-value pair of contextual data logged with the messages, such as a connection ID.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// NewField returns the field of the key and value.
+func NewField(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// StructuredLogger is a Logger which keeps the fields apart from the messages, so that they can be indexed by the
+// logging pipelines. The loggers which aren't structured get the fields formatted at the end of the messages.
+type StructuredLogger interface {
+	Logger
+
+	// With returns a logger adding the fields to the logged messages
+	With(fields ...Field) StructuredLogger
+}
+
 // LoggerProvider is a factory for moduled loggers.
 type LoggerProvider interface {
 	GetLogger(module string) Logger
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package log
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/metadata"
@@ -24,55 +27,119 @@ const (
 type Log struct {
 	instance Logger
 	module   string
-	once     sync.Once
+	fields   []Field
+	// suffix is the fields formatted for the loggers which aren't structured
+	suffix string
+	// version is the version of the logger provider of the instance
+	version uint64
+	mutex   sync.Mutex
 }
 
 // New creates and returns a Logger implementation based on given module name.
 // note: the underlying logger instance is lazy initialized on first use.
-// To use your own logger implementation provide logger provider in 'Initialize()' before logging any line.
-// If 'Initialize()' is not called before logging any line then default logging implementation will be used.
+// To use your own logger implementation provide logger provider in 'Initialize()'.
+// Until 'Initialize()' is called the default logging implementation is used.
 func New(module string) *Log {
 	return &Log{module: module}
 }
 
+// With returns a logger of the module adding the fields to the messages, after the fields of this logger.
+func (l *Log) With(fields ...Field) *Log {
+	return &Log{module: l.module, fields: append(append([]Field{}, l.fields...), fields...)}
+}
+
+// Enabled returns if the level is enabled for the module of the logger, for the callers to skip computing the
+// messages and fields which wouldn't be logged.
+func (l *Log) Enabled(level Level) bool {
+	return IsEnabledFor(l.module, level)
+}
+
 // Fatalf calls Fatalf function of underlying logger
 // should possibly cause system shutdown based on implementation.
 func (l *Log) Fatalf(msg string, args ...interface{}) {
-	l.logger().Fatalf(msg, args...)
+	logger, msg, args := l.withFields(msg, args)
+	logger.Fatalf(msg, args...)
 }
 
 // Panicf calls Panic function of underlying logger
 // should possibly cause panic based on implementation.
 func (l *Log) Panicf(msg string, args ...interface{}) {
-	l.logger().Panicf(msg, args...)
+	logger, msg, args := l.withFields(msg, args)
+	logger.Panicf(msg, args...)
 }
 
 // Debugf calls Debugf function of underlying logger.
 func (l *Log) Debugf(msg string, args ...interface{}) {
-	l.logger().Debugf(msg, args...)
+	logger, msg, args := l.withFields(msg, args)
+	logger.Debugf(msg, args...)
 }
 
 // Infof calls Infof function of underlying logger.
 func (l *Log) Infof(msg string, args ...interface{}) {
-	l.logger().Infof(msg, args...)
+	logger, msg, args := l.withFields(msg, args)
+	logger.Infof(msg, args...)
 }
 
 // Warnf calls Warnf function of underlying logger.
 func (l *Log) Warnf(msg string, args ...interface{}) {
-	l.logger().Warnf(msg, args...)
+	logger, msg, args := l.withFields(msg, args)
+	logger.Warnf(msg, args...)
 }
 
 // Errorf calls Errorf function of underlying logger.
 func (l *Log) Errorf(msg string, args ...interface{}) {
-	l.logger().Errorf(msg, args...)
+	logger, msg, args := l.withFields(msg, args)
+	logger.Errorf(msg, args...)
 }
 
-func (l *Log) logger() Logger {
-	l.once.Do(func() {
-		l.instance = loggerProvider().GetLogger(l.module)
-	})
+// logger returns the underlying logger and the suffix of its messages, getting them again from the logger provider
+// when it was replaced.
+func (l *Log) logger() (Logger, string) {
+	provider, version := loggerProvider()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.instance == nil || l.version != version {
+		var structured bool
+
+		l.instance, structured = provider.getLogger(l.module, l.fields)
+		l.version = version
+
+		l.suffix = ""
+		if !structured {
+			l.suffix = formatFields(l.fields)
+		}
+	}
+
+	return l.instance, l.suffix
+}
+
+// withFields returns the underlying logger, formatting the fields at the end of the message if it isn't structured.
+func (l *Log) withFields(msg string, args []interface{}) (Logger, string, []interface{}) {
+	logger, suffix := l.logger()
+
+	if suffix == "" {
+		return logger, msg, args
+	}
+
+	return logger, "%s", []interface{}{fmt.Sprintf(msg, args...) + suffix}
+}
+
+// formatFields formats the fields as key=value pairs, quoting the values with spaces.
+func formatFields(fields []Field) string {
+	var b strings.Builder
+
+	for _, field := range fields {
+		value := fmt.Sprint(field.Value)
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+
+		fmt.Fprintf(&b, " %s=%s", field.Key, value)
+	}
 
-	return l.instance
+	return b.String()
 }
 
 // SetLevel - setting log level for given module
@@ -7,7 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package log
 
 import (
-	"sync"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -18,7 +18,7 @@ import (
 
 // TestDefaultLogger tests default logging feature when no custom logging provider is supplied via 'Initialize()' call.
 func TestDefaultLogger(t *testing.T) {
-	defer func() { loggerProviderOnce = sync.Once{} }()
+	defer resetLoggerProvider()
 
 	const module = "sample-module"
 
@@ -123,3 +123,69 @@ func verifyLevels(t *testing.T, module string, enabled, disabled []Level) {
 			"expected level [%s] to be disabled for module [%s]", levelStr, module)
 	}
 }
+
+// TestFields tests the fields added to the messages, by the structured loggers or at the end of the messages.
+func TestFields(t *testing.T) {
+	const module = "sample-module-fields"
+
+	fields := []Field{NewField("connectionID", "abc"), NewField("state", "request sent")}
+
+	t.Run("logger which isn't structured", func(t *testing.T) {
+		defer resetLoggerProvider()
+
+		logger := &recordingLogger{}
+		Initialize(&sampleProvider{logger})
+
+		New(module).With(fields[0]).With(fields[1]).Infof("%s exchange %d%%", "did", 100)
+		require.Equal(t, []string{`did exchange 100% connectionID=abc state="request sent"`}, logger.messages)
+	})
+
+	t.Run("structured logger", func(t *testing.T) {
+		defer resetLoggerProvider()
+		defer SetLevel(module, INFO)
+
+		logger := &recordingLogger{}
+		Initialize(&sampleProvider{&structuredLogger{recordingLogger: logger}})
+
+		log := New(module).With(fields...)
+
+		log.Warnf("%s exchange", "did")
+		require.Equal(t, []string{"did exchange"}, logger.messages)
+		require.Equal(t, fields, logger.fields)
+
+		// the levels of the module still apply
+		SetLevel(module, ERROR)
+		require.False(t, log.Enabled(WARNING))
+
+		log.Warnf("not logged")
+		require.Len(t, logger.messages, 1)
+	})
+}
+
+// recordingLogger records the messages it logs.
+type recordingLogger struct {
+	messages []string
+	fields   []Field
+}
+
+func (l *recordingLogger) Fatalf(msg string, args ...interface{}) { l.record(msg, args) }
+func (l *recordingLogger) Panicf(msg string, args ...interface{}) { l.record(msg, args) }
+func (l *recordingLogger) Debugf(msg string, args ...interface{}) { l.record(msg, args) }
+func (l *recordingLogger) Infof(msg string, args ...interface{})  { l.record(msg, args) }
+func (l *recordingLogger) Warnf(msg string, args ...interface{})  { l.record(msg, args) }
+func (l *recordingLogger) Errorf(msg string, args ...interface{}) { l.record(msg, args) }
+
+func (l *recordingLogger) record(msg string, args []interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(msg, args...))
+}
+
+// structuredLogger records the fields it is given apart from the messages.
+type structuredLogger struct {
+	*recordingLogger
+}
+
+func (l *structuredLogger) With(fields ...Field) StructuredLogger {
+	l.fields = append(l.fields, fields...)
+
+	return l
+}
@@ -13,32 +13,62 @@ import (
 )
 
 // loggerProviderInstance is logger factory singleton - access only via loggerProvider()
+// loggerProviderVersion is incremented each time the provider is replaced, for the loggers to switch to it.
 //nolint:gochecknoglobals
 var (
-	loggerProviderInstance LoggerProvider
-	loggerProviderOnce     sync.Once
+	loggerProviderInstance *modlogProvider
+	loggerProviderVersion  uint64
+	loggerProviderMutex    sync.RWMutex
 )
 
 // Initialize sets new custom logging provider which takes over logging operations.
-// It is required to call this function before making any loggings for using custom loggers.
+// The provider can be replaced at any time: the loggers already in use switch to it on their next message.
+// A nil provider restores the default logger.
 func Initialize(l LoggerProvider) {
-	loggerProviderOnce.Do(func() {
-		loggerProviderInstance = &modlogProvider{l}
-		logger := loggerProviderInstance.GetLogger(loggerModule)
-		logger.Debugf("Logger provider initialized")
-	})
+	loggerProviderMutex.Lock()
+	loggerProviderInstance = &modlogProvider{l}
+	loggerProviderVersion++
+	provider := loggerProviderInstance
+	loggerProviderMutex.Unlock()
+
+	provider.GetLogger(loggerModule).Debugf("Logger provider initialized")
 }
 
-func loggerProvider() LoggerProvider {
-	loggerProviderOnce.Do(func() {
-		// A custom logger must be initialized prior to the first log output
-		// Otherwise the built-in logger is used
+// loggerProvider returns the logger provider and its version, the default provider if none was initialized.
+func loggerProvider() (*modlogProvider, uint64) {
+	loggerProviderMutex.RLock()
+	provider, version := loggerProviderInstance, loggerProviderVersion
+	loggerProviderMutex.RUnlock()
+
+	if provider != nil {
+		return provider, version
+	}
+
+	loggerProviderMutex.Lock()
+
+	initialized := loggerProviderInstance == nil
+	if initialized {
+		// the built-in logger is used until a custom logger is initialized
 		loggerProviderInstance = &modlogProvider{}
-		logger := loggerProviderInstance.GetLogger(loggerModule)
-		logger.Debugf(loggerNotInitializedMsg)
-	})
+		loggerProviderVersion++
+	}
+
+	provider, version = loggerProviderInstance, loggerProviderVersion
+	loggerProviderMutex.Unlock()
+
+	if initialized {
+		provider.GetLogger(loggerModule).Debugf(loggerNotInitializedMsg)
+	}
+
+	return provider, version
+}
 
-	return loggerProviderInstance
+// resetLoggerProvider removes the logger provider, for the default provider to be used again.
+func resetLoggerProvider() {
+	loggerProviderMutex.Lock()
+	loggerProviderInstance = nil
+	loggerProviderVersion++
+	loggerProviderMutex.Unlock()
 }
 
 // modlogProvider is a module based logger provider wrapped on given custom logging provider
@@ -49,6 +79,14 @@ type modlogProvider struct {
 
 // GetLogger returns moduled logger implementation.
 func (p *modlogProvider) GetLogger(module string) Logger {
+	logger, _ := p.getLogger(module, nil)
+
+	return logger
+}
+
+// getLogger returns the moduled logger adding the fields to the messages if the underlying logger is structured,
+// and whether it is.
+func (p *modlogProvider) getLogger(module string, fields []Field) (Logger, bool) {
 	var logger Logger
 	if p.custom != nil {
 		logger = p.custom.GetLogger(module)
@@ -56,5 +94,10 @@ func (p *modlogProvider) GetLogger(module string) Logger {
 		logger = modlog.NewDefLog(module)
 	}
 
-	return modlog.NewModLog(logger, module)
+	structured, ok := logger.(StructuredLogger)
+	if ok && len(fields) > 0 {
+		logger = structured.With(fields...)
+	}
+
+	return modlog.NewModLog(logger, module), ok
 }
@@ -7,15 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package log
 
 import (
-	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/modlog"
 )
 
 // TestDefaultLogger tests custom logging feature when custom logging provider is supplied through 'Initialize()' call.
 func TestCustomLogger(t *testing.T) {
-	defer func() { loggerProviderOnce = sync.Once{} }()
+	defer resetLoggerProvider()
 
 	const module = "sample-module"
 
@@ -28,6 +29,30 @@ func TestCustomLogger(t *testing.T) {
 	modlog.VerifyCustomLogger(t, logger, module)
 }
 
+// TestReplaceLogger tests the custom logging provider replacing the provider in use after the first log output.
+func TestReplaceLogger(t *testing.T) {
+	defer resetLoggerProvider()
+
+	const module = "sample-module-replace"
+
+	logger := New(module).With(NewField("state", "done"))
+	logger.Infof("logged by the default logger")
+
+	first := &recordingLogger{}
+	Initialize(&sampleProvider{first})
+
+	logger.Infof("logged by the %s logger", "first")
+	require.Equal(t, []string{"logged by the first logger state=done"}, first.messages)
+
+	second := &recordingLogger{}
+	Initialize(&sampleProvider{&structuredLogger{recordingLogger: second}})
+
+	logger.Infof("logged by the %s logger", "second")
+	require.Len(t, first.messages, 1)
+	require.Equal(t, []string{"logged by the second logger"}, second.messages)
+	require.Equal(t, []Field{NewField("state", "done")}, second.fields)
+}
+
 // newCustomProvider return new sample logging provider to demonstrate custom logging provider.
 func newCustomProvider(module string) *sampleProvider {
 	return &sampleProvider{modlog.GetSampleCustomLogger(module)}
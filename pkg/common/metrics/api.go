@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+// Names of the metrics recorded by the protocol services.
+const (
+	// ExchangesStarted counts the protocol instances started by the agent or its counterparts.
+	ExchangesStarted = "aries_protocol_exchanges_started_total"
+	// ExchangesCompleted counts the protocol instances which reached their final (successful) state.
+	ExchangesCompleted = "aries_protocol_exchanges_completed_total"
+	// ExchangesFailed counts the protocol instances which were abandoned.
+	ExchangesFailed = "aries_protocol_exchanges_failed_total"
+	// StateTransitionDuration observes the time (in seconds) taken by the transitions to the states.
+	StateTransitionDuration = "aries_protocol_state_transition_duration_seconds"
+)
+
+// Label names of the metrics recorded by the protocol services.
+const (
+	// ProtocolLabel is the name of the protocol.
+	ProtocolLabel = "protocol"
+	// StateLabel is the name of the state the protocol transitions to.
+	StateLabel = "state"
+)
+
+// Labels are the dimensions (label name to value) of the recorded metric value.
+type Labels map[string]string
+
+// Counter is a cumulative metric which can only be increased.
+type Counter interface {
+	// Inc increments the counter of the given labels by one
+	Inc(labels Labels)
+}
+
+// Histogram samples observations (e.g. durations) and counts them in buckets.
+type Histogram interface {
+	// Observe adds a single observation of the given labels
+	Observe(value float64, labels Labels)
+}
+
+// Gauge is a metric which can arbitrarily go up and down (e.g. a number of records).
+type Gauge interface {
+	// Set sets the gauge of the given labels to the value
+	Set(value float64, labels Labels)
+}
+
+// Provider creates the metrics, it is implemented by the monitoring system adapter (e.g. Prometheus).
+// The provider is called once per metric name, the returned metrics must be safe for concurrent use.
+type Provider interface {
+	// Counter returns the counter of the given name
+	Counter(name string) Counter
+	// Histogram returns the histogram of the given name
+	Histogram(name string) Histogram
+	// Gauge returns the gauge of the given name
+	Gauge(name string) Gauge
+}
@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import "time"
+
+// Names of the metrics recorded by the instrumented key managers.
+const (
+	// KMSOperationDuration observes the time (in seconds) taken by the key manager operations.
+	KMSOperationDuration = "aries_kms_operation_duration_seconds"
+
+	// KMSOperationErrors counts the key manager operations which failed.
+	KMSOperationErrors = "aries_kms_operation_errors_total"
+)
+
+// KMS records the metrics of the key manager.
+type KMS struct {
+	durations Histogram
+	errors    Counter
+}
+
+// ForKMS returns the recorder of the key manager metrics. The metrics are discarded when p is nil.
+func ForKMS(p Provider) *KMS {
+	if p == nil {
+		p = nop{}
+	}
+
+	return &KMS{
+		durations: p.Histogram(KMSOperationDuration),
+		errors:    p.Counter(KMSOperationErrors),
+	}
+}
+
+// Operation observes the duration of the key manager operation and counts it if it failed.
+func (k *KMS) Operation(operation string, duration time.Duration, failed bool) {
+	labels := Labels{OperationLabel: operation}
+
+	k.durations.Observe(duration.Seconds(), labels)
+
+	if failed {
+		k.errors.Inc(labels)
+	}
+}
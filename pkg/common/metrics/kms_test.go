@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
+)
+
+func TestForKMS(t *testing.T) {
+	t.Run("Records the metrics", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		labels := metrics.Labels{metrics.OperationLabel: "create"}
+
+		durations := mocks.NewMockHistogram(ctrl)
+		durations.EXPECT().Observe(0.5, labels).Times(2)
+
+		errors := mocks.NewMockCounter(ctrl)
+		errors.EXPECT().Inc(labels)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Histogram(metrics.KMSOperationDuration).Return(durations)
+		provider.EXPECT().Counter(metrics.KMSOperationErrors).Return(errors)
+
+		recorder := metrics.ForKMS(provider)
+		recorder.Operation("create", 500*time.Millisecond, false)
+		recorder.Operation("create", 500*time.Millisecond, true)
+	})
+
+	t.Run("Discards the metrics without the provider", func(t *testing.T) {
+		metrics.ForKMS(nil).Operation("create", time.Second, true)
+	})
+}
@@ -0,0 +1,290 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package prometheus offers a metrics.Provider keeping the metrics in memory and exposing them in the Prometheus
+// text format, e.g. on the /metrics endpoint of the agent.
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+)
+
+// ContentType is the content type of the Prometheus text format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var logger = log.New("aries-framework/metrics/prometheus")
+
+// DefaultBuckets are the upper bounds (in seconds) of the histogram buckets, suited to the durations of the
+// storage, KMS and protocol operations.
+// nolint:gochecknoglobals
+var DefaultBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Opt configures the Provider.
+type Opt func(p *Provider)
+
+// WithBuckets sets the upper bounds of the histogram buckets, DefaultBuckets if not set.
+func WithBuckets(buckets ...float64) Opt {
+	return func(p *Provider) {
+		p.buckets = append([]float64{}, buckets...)
+		sort.Float64s(p.buckets)
+	}
+}
+
+// Provider creates the metrics and writes them in the Prometheus text format.
+type Provider struct {
+	buckets []float64
+	mutex   sync.RWMutex
+	metrics map[string]*metric
+}
+
+// NewProvider returns a new Provider.
+func NewProvider(opts ...Opt) *Provider {
+	p := &Provider{
+		buckets: DefaultBuckets,
+		metrics: make(map[string]*metric),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Counter returns the counter of the given name.
+func (p *Provider) Counter(name string) metrics.Counter {
+	return p.metric(name, counterType)
+}
+
+// Histogram returns the histogram of the given name.
+func (p *Provider) Histogram(name string) metrics.Histogram {
+	return p.metric(name, histogramType)
+}
+
+// Gauge returns the gauge of the given name.
+func (p *Provider) Gauge(name string) metrics.Gauge {
+	return p.metric(name, gaugeType)
+}
+
+func (p *Provider) metric(name, typ string) *metric {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	m, ok := p.metrics[name]
+	if !ok {
+		m = &metric{name: name, typ: typ, buckets: p.buckets, series: make(map[string]*series)}
+		p.metrics[name] = m
+	} else if m.typ != typ {
+		logger.Warnf("metric %s is a %s, not a %s", name, m.typ, typ)
+	}
+
+	return m
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (p *Provider) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+
+	if err := p.Write(w); err != nil {
+		logger.Errorf("failed to write metrics: %s", err)
+	}
+}
+
+// Write writes the metrics in the Prometheus text format, sorted by name and labels.
+func (p *Provider) Write(w io.Writer) error {
+	p.mutex.RLock()
+
+	names := make([]string, 0, len(p.metrics))
+	for name := range p.metrics {
+		names = append(names, name)
+	}
+
+	p.mutex.RUnlock()
+
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+
+	for _, name := range names {
+		p.mutex.RLock()
+		m := p.metrics[name]
+		p.mutex.RUnlock()
+
+		m.write(bw)
+	}
+
+	return bw.Flush()
+}
+
+const (
+	counterType   = "counter"
+	histogramType = "histogram"
+	gaugeType     = "gauge"
+)
+
+// metric is a metric of any type, its series are the values of each set of labels.
+type metric struct {
+	name    string
+	typ     string
+	buckets []float64
+	mutex   sync.Mutex
+	series  map[string]*series
+}
+
+type series struct {
+	labels string
+	value  float64
+	// histograms only
+	counts []uint64
+	count  uint64
+}
+
+// Inc increments the counter of the given labels by one.
+func (m *metric) Inc(labels metrics.Labels) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.get(labels).value++
+}
+
+// Set sets the gauge of the given labels to the value.
+func (m *metric) Set(value float64, labels metrics.Labels) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.get(labels).value = value
+}
+
+// Observe adds a single observation of the given labels.
+func (m *metric) Observe(value float64, labels metrics.Labels) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s := m.get(labels)
+
+	if s.counts == nil {
+		s.counts = make([]uint64, len(m.buckets))
+	}
+
+	for i, upperBound := range m.buckets {
+		if value <= upperBound {
+			s.counts[i]++
+		}
+	}
+
+	s.value += value
+	s.count++
+}
+
+func (m *metric) get(labels metrics.Labels) *series {
+	key := formatLabels(labels)
+
+	s, ok := m.series[key]
+	if !ok {
+		s = &series{labels: key}
+		m.series[key] = s
+	}
+
+	return s
+}
+
+func (m *metric) write(w io.Writer) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	fmt.Fprintf(w, "# TYPE %s %s\n", m.name, m.typ)
+
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := m.series[key]
+
+		if m.typ != histogramType {
+			fmt.Fprintf(w, "%s%s %s\n", m.name, braces(s.labels), formatFloat(s.value))
+
+			continue
+		}
+
+		for i, upperBound := range m.buckets {
+			var count uint64
+			if s.counts != nil {
+				count = s.counts[i]
+			}
+
+			fmt.Fprintf(w, "%s_bucket%s %d\n", m.name, braces(withLE(s.labels, formatFloat(upperBound))), count)
+		}
+
+		fmt.Fprintf(w, "%s_bucket%s %d\n", m.name, braces(withLE(s.labels, "+Inf")), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", m.name, braces(s.labels), formatFloat(s.value))
+		fmt.Fprintf(w, "%s_count%s %d\n", m.name, braces(s.labels), s.count)
+	}
+}
+
+// formatLabels formats the labels sorted by name, e.g. `protocol="didexchange",state="completed"`.
+func formatLabels(labels metrics.Labels) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + escape(labels[name]) + `"`
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func withLE(labels, upperBound string) string {
+	le := `le="` + upperBound + `"`
+
+	if labels == "" {
+		return le
+	}
+
+	return labels + "," + le
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+
+	return "{" + labels + "}"
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
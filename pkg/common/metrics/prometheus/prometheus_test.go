@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prometheus
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+)
+
+func TestProvider(t *testing.T) {
+	t.Run("writes the metrics in the text format", func(t *testing.T) {
+		p := NewProvider(WithBuckets(1, 0.5))
+
+		started := p.Counter(metrics.ExchangesStarted)
+		started.Inc(metrics.Labels{metrics.ProtocolLabel: "didexchange"})
+		started.Inc(metrics.Labels{metrics.ProtocolLabel: "didexchange"})
+		// the same metric is returned for the same name
+		p.Counter(metrics.ExchangesStarted).Inc(metrics.Labels{metrics.ProtocolLabel: "issue-credential"})
+
+		durations := p.Histogram(metrics.StorageOperationDuration)
+		durations.Observe(0.25, metrics.Labels{metrics.StoreLabel: "connections", metrics.OperationLabel: "put"})
+		durations.Observe(0.75, metrics.Labels{metrics.StoreLabel: "connections", metrics.OperationLabel: "put"})
+		durations.Observe(2, metrics.Labels{metrics.StoreLabel: "connections", metrics.OperationLabel: "put"})
+
+		records := p.Gauge(metrics.StorageRecords)
+		records.Set(3, metrics.Labels{metrics.StoreLabel: `a "quoted\" store`})
+		records.Set(5, nil)
+
+		var buf bytes.Buffer
+
+		require.NoError(t, p.Write(&buf))
+		require.Equal(t, `# TYPE aries_protocol_exchanges_started_total counter
+aries_protocol_exchanges_started_total{protocol="didexchange"} 2
+aries_protocol_exchanges_started_total{protocol="issue-credential"} 1
+# TYPE aries_storage_operation_duration_seconds histogram
+aries_storage_operation_duration_seconds_bucket{operation="put",store="connections",le="0.5"} 1
+aries_storage_operation_duration_seconds_bucket{operation="put",store="connections",le="1"} 2
+aries_storage_operation_duration_seconds_bucket{operation="put",store="connections",le="+Inf"} 3
+aries_storage_operation_duration_seconds_sum{operation="put",store="connections"} 3
+aries_storage_operation_duration_seconds_count{operation="put",store="connections"} 3
+# TYPE aries_storage_records gauge
+aries_storage_records 5
+aries_storage_records{store="a \"quoted\\\" store"} 3
+`, buf.String())
+	})
+
+	t.Run("serves the metrics", func(t *testing.T) {
+		p := NewProvider()
+		p.Counter("test_total").Inc(nil)
+		p.Gauge("test_total").Set(2, metrics.Labels{"a": "b"})
+
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+		require.Equal(t, "# TYPE test_total counter\ntest_total 1\ntest_total{a=\"b\"} 2\n", rec.Body.String())
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		p := NewProvider()
+		p.Counter("test_total").Inc(nil)
+
+		require.EqualError(t, p.Write(&failingWriter{}), "write error")
+
+		p.ServeHTTP(&failingResponseWriter{ResponseRecorder: httptest.NewRecorder()}, nil)
+	})
+
+	t.Run("formats the infinite values", func(t *testing.T) {
+		require.Equal(t, "+Inf", formatFloat(math.Inf(1)))
+		require.Equal(t, "-Inf", formatFloat(math.Inf(-1)))
+	})
+}
+
+type failingWriter struct{}
+
+func (w *failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+type failingResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w *failingResponseWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
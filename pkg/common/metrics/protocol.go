@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import "time"
+
+// Source is implemented by the framework context, the protocol services record their metrics with its provider.
+type Source interface {
+	Metrics() Provider
+}
+
+// Protocol records the metrics of the protocol service.
+type Protocol struct {
+	name        string
+	started     Counter
+	completed   Counter
+	failed      Counter
+	transitions Histogram
+}
+
+// ForProtocol returns the recorder of the protocol metrics. The metrics are discarded when the protocol
+// context is not a Source or it has no metrics provider.
+func ForProtocol(ctx interface{}, name string) *Protocol {
+	var p Provider
+
+	if src, ok := ctx.(Source); ok {
+		p = src.Metrics()
+	}
+
+	if p == nil {
+		p = nop{}
+	}
+
+	return &Protocol{
+		name:        name,
+		started:     p.Counter(ExchangesStarted),
+		completed:   p.Counter(ExchangesCompleted),
+		failed:      p.Counter(ExchangesFailed),
+		transitions: p.Histogram(StateTransitionDuration),
+	}
+}
+
+// Started counts the protocol instance started.
+func (p *Protocol) Started() {
+	p.started.Inc(Labels{ProtocolLabel: p.name})
+}
+
+// Completed counts the protocol instance completed.
+func (p *Protocol) Completed() {
+	p.completed.Inc(Labels{ProtocolLabel: p.name})
+}
+
+// Failed counts the protocol instance abandoned.
+func (p *Protocol) Failed() {
+	p.failed.Inc(Labels{ProtocolLabel: p.name})
+}
+
+// Transition observes the duration of the transition to the state.
+func (p *Protocol) Transition(state string, duration time.Duration) {
+	p.transitions.Observe(duration.Seconds(), Labels{
+		ProtocolLabel: p.name,
+		StateLabel:    state,
+	})
+}
+
+type nop struct{}
+
+func (nop) Counter(string) Counter { return nop{} }
+
+func (nop) Histogram(string) Histogram { return nop{} }
+
+func (nop) Gauge(string) Gauge { return nop{} }
+
+func (nop) Inc(Labels) {}
+
+func (nop) Observe(float64, Labels) {}
+
+func (nop) Set(float64, Labels) {}
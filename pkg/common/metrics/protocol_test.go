@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
+)
+
+type source struct {
+	provider metrics.Provider
+}
+
+func (s *source) Metrics() metrics.Provider {
+	return s.provider
+}
+
+func TestForProtocol(t *testing.T) {
+	const protocol = "protocol"
+
+	t.Run("Records the metrics", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		labels := metrics.Labels{metrics.ProtocolLabel: protocol}
+
+		started := mocks.NewMockCounter(ctrl)
+		started.EXPECT().Inc(labels)
+
+		completed := mocks.NewMockCounter(ctrl)
+		completed.EXPECT().Inc(labels)
+
+		failed := mocks.NewMockCounter(ctrl)
+		failed.EXPECT().Inc(labels)
+
+		transitions := mocks.NewMockHistogram(ctrl)
+		transitions.EXPECT().Observe(1.5, metrics.Labels{
+			metrics.ProtocolLabel: protocol,
+			metrics.StateLabel:    "done",
+		})
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Counter(metrics.ExchangesStarted).Return(started)
+		provider.EXPECT().Counter(metrics.ExchangesCompleted).Return(completed)
+		provider.EXPECT().Counter(metrics.ExchangesFailed).Return(failed)
+		provider.EXPECT().Histogram(metrics.StateTransitionDuration).Return(transitions)
+
+		recorder := metrics.ForProtocol(&source{provider: provider}, protocol)
+		recorder.Started()
+		recorder.Completed()
+		recorder.Failed()
+		recorder.Transition("done", 1500*time.Millisecond)
+	})
+
+	t.Run("Discards the metrics without the provider", func(t *testing.T) {
+		for _, ctx := range []interface{}{nil, &source{}} {
+			recorder := metrics.ForProtocol(ctx, protocol)
+			recorder.Started()
+			recorder.Completed()
+			recorder.Failed()
+			recorder.Transition("done", time.Second)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import "time"
+
+// Names of the metrics recorded by the instrumented storage providers.
+const (
+	// StorageOperationDuration observes the time (in seconds) taken by the store operations.
+	StorageOperationDuration = "aries_storage_operation_duration_seconds"
+	// StorageOperationErrors counts the store operations which failed.
+	StorageOperationErrors = "aries_storage_operation_errors_total"
+	// StorageRecords is the number of records of the stores.
+	StorageRecords = "aries_storage_records"
+)
+
+// Label names of the metrics recorded by the instrumented storage providers.
+const (
+	// StoreLabel is the name of the store.
+	StoreLabel = "store"
+	// OperationLabel is the store or key manager operation (e.g. put or create).
+	OperationLabel = "operation"
+)
+
+// Storage records the metrics of the stores.
+type Storage struct {
+	durations Histogram
+	errors    Counter
+	records   Gauge
+}
+
+// ForStorage returns the recorder of the storage metrics. The metrics are discarded when p is nil.
+func ForStorage(p Provider) *Storage {
+	if p == nil {
+		p = nop{}
+	}
+
+	return &Storage{
+		durations: p.Histogram(StorageOperationDuration),
+		errors:    p.Counter(StorageOperationErrors),
+		records:   p.Gauge(StorageRecords),
+	}
+}
+
+// Operation observes the duration of the store operation and counts it if it failed.
+func (s *Storage) Operation(store, operation string, duration time.Duration, failed bool) {
+	labels := Labels{
+		StoreLabel:     store,
+		OperationLabel: operation,
+	}
+
+	s.durations.Observe(duration.Seconds(), labels)
+
+	if failed {
+		s.errors.Inc(labels)
+	}
+}
+
+// Records sets the number of records of the store.
+func (s *Storage) Records(store string, count int) {
+	s.records.Set(float64(count), Labels{StoreLabel: store})
+}
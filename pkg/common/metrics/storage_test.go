@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
+)
+
+func TestForStorage(t *testing.T) {
+	t.Run("Records the metrics", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		labels := metrics.Labels{metrics.StoreLabel: "connections", metrics.OperationLabel: "put"}
+
+		durations := mocks.NewMockHistogram(ctrl)
+		durations.EXPECT().Observe(0.5, labels).Times(2)
+
+		errors := mocks.NewMockCounter(ctrl)
+		errors.EXPECT().Inc(labels)
+
+		records := mocks.NewMockGauge(ctrl)
+		records.EXPECT().Set(float64(3), metrics.Labels{metrics.StoreLabel: "connections"})
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Histogram(metrics.StorageOperationDuration).Return(durations)
+		provider.EXPECT().Counter(metrics.StorageOperationErrors).Return(errors)
+		provider.EXPECT().Gauge(metrics.StorageRecords).Return(records)
+
+		recorder := metrics.ForStorage(provider)
+		recorder.Operation("connections", "put", 500*time.Millisecond, false)
+		recorder.Operation("connections", "put", 500*time.Millisecond, true)
+		recorder.Records("connections", 3)
+	})
+
+	t.Run("Discards the metrics without the provider", func(t *testing.T) {
+		recorder := metrics.ForStorage(nil)
+		recorder.Operation("connections", "put", time.Second, true)
+		recorder.Records("connections", 3)
+	})
+}
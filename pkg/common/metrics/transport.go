@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+// Names of the metrics recorded by the transports and the outbound dispatcher.
+const (
+	// TransportMessages counts the DIDComm messages received and sent.
+	TransportMessages = "aries_transport_messages_total"
+
+	// TransportErrors counts the DIDComm messages which failed to be received (unpacked or handled) or sent.
+	TransportErrors = "aries_transport_errors_total"
+)
+
+// Label names and values of the metrics recorded by the transports and the outbound dispatcher.
+const (
+	// TransportLabel is the transport of the message, e.g. http or ws.
+	TransportLabel = "transport"
+
+	// DirectionLabel is the direction of the message, Inbound or Outbound.
+	DirectionLabel = "direction"
+
+	// Inbound is the direction of the received messages.
+	Inbound = "inbound"
+
+	// Outbound is the direction of the sent messages.
+	Outbound = "outbound"
+)
+
+// Transport records the metrics of the messages received or sent with a transport.
+type Transport struct {
+	messages Counter
+	errors   Counter
+}
+
+// ForTransport returns the recorder of the transport metrics. The metrics are discarded when the transport
+// context is not a Source or it has no metrics provider.
+func ForTransport(ctx interface{}) *Transport {
+	var p Provider
+
+	if src, ok := ctx.(Source); ok {
+		p = src.Metrics()
+	}
+
+	if p == nil {
+		p = nop{}
+	}
+
+	return &Transport{
+		messages: p.Counter(TransportMessages),
+		errors:   p.Counter(TransportErrors),
+	}
+}
+
+// Received counts the message received with the transport and whether it failed.
+func (t *Transport) Received(transport string, failed bool) {
+	t.record(transport, Inbound, failed)
+}
+
+// Sent counts the message sent with the transport and whether it failed.
+func (t *Transport) Sent(transport string, failed bool) {
+	t.record(transport, Outbound, failed)
+}
+
+func (t *Transport) record(transport, direction string, failed bool) {
+	labels := Labels{
+		TransportLabel: transport,
+		DirectionLabel: direction,
+	}
+
+	t.messages.Inc(labels)
+
+	if failed {
+		t.errors.Inc(labels)
+	}
+}
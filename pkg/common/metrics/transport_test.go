@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
+)
+
+func TestForTransport(t *testing.T) {
+	t.Run("Records the metrics", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		inbound := metrics.Labels{metrics.TransportLabel: "ws", metrics.DirectionLabel: metrics.Inbound}
+		outbound := metrics.Labels{metrics.TransportLabel: "http", metrics.DirectionLabel: metrics.Outbound}
+
+		messages := mocks.NewMockCounter(ctrl)
+		messages.EXPECT().Inc(inbound)
+		messages.EXPECT().Inc(outbound).Times(2)
+
+		errors := mocks.NewMockCounter(ctrl)
+		errors.EXPECT().Inc(outbound)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Counter(metrics.TransportMessages).Return(messages)
+		provider.EXPECT().Counter(metrics.TransportErrors).Return(errors)
+
+		recorder := metrics.ForTransport(&source{provider: provider})
+		recorder.Received("ws", false)
+		recorder.Sent("http", false)
+		recorder.Sent("http", true)
+	})
+
+	t.Run("Discards the metrics without the provider", func(t *testing.T) {
+		for _, ctx := range []interface{}{nil, &source{}} {
+			recorder := metrics.ForTransport(ctx)
+			recorder.Received("ws", true)
+			recorder.Sent("http", true)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package retry retries the operations failing on transient errors (e.g. a remote service restarting), with an
+// exponential backoff and jitter, up to a maximum number of attempts.
+//
+// The errors are retryable unless the operation marks them as permanent, e.g. the HTTP clients return a permanent
+// error for the responses whose status isn't retryable (see RetryableStatus):
+//
+//	err := retry.Do(ctx, retry.DefaultPolicy(), func() error {
+//	    resp, err := client.Do(req)
+//	    if err != nil {
+//	        return err // network error, retried
+//	    }
+//
+//	    if !retry.RetryableStatus(resp.StatusCode) {
+//	        return retry.Permanent(fmt.Errorf("unexpected status %d", resp.StatusCode))
+//	    }
+//	    ...
+//	})
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+const (
+	defaultMaxAttempts     = 3
+	defaultInitialInterval = 200 * time.Millisecond
+	defaultMaxInterval     = 5 * time.Second
+	defaultMultiplier      = 2
+	defaultJitter          = 0.5
+)
+
+var logger = log.New("aries-framework/retry")
+
+// Policy is a retry policy: a failed operation is attempted again after an interval starting at InitialInterval
+// and multiplied by Multiplier after each attempt, up to MaxInterval.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one: 1 disables the retries.
+	MaxAttempts int
+	// InitialInterval is the interval before the second attempt.
+	InitialInterval time.Duration
+	// MaxInterval caps the intervals between the attempts.
+	MaxInterval time.Duration
+	// Multiplier is the growth factor of the intervals.
+	Multiplier float64
+	// Jitter is the randomization factor of the intervals, between 0 and 1: an interval i is drawn in
+	// [i - Jitter*i, i + Jitter*i], so that the clients failing together don't retry together.
+	Jitter float64
+	// Retryable classifies the errors of the operation, IsRetryable if nil.
+	Retryable func(err error) bool
+}
+
+// DefaultPolicy returns the default policy: 3 attempts, after 200ms then 400ms, with a jitter of 50%.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:     defaultMaxAttempts,
+		InitialInterval: defaultInitialInterval,
+		MaxInterval:     defaultMaxInterval,
+		Multiplier:      defaultMultiplier,
+		Jitter:          defaultJitter,
+	}
+}
+
+// NoRetry returns the policy disabling the retries: the operations are attempted once.
+func NoRetry() Policy {
+	return Policy{MaxAttempts: 1}
+}
+
+// Do runs the operation until it succeeds, it fails with an error that isn't retryable, the attempts of the policy
+// are exhausted or the context is done. It returns the last error of the operation.
+func Do(ctx context.Context, p Policy, op func() error) error {
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.InitialInterval
+	b.MaxInterval = p.MaxInterval
+	b.Multiplier = p.Multiplier
+	b.RandomizationFactor = p.Jitter
+	b.MaxElapsedTime = 0
+
+	retries := 0
+	if p.MaxAttempts > 1 {
+		retries = p.MaxAttempts - 1
+	}
+
+	var last error
+
+	err := backoff.RetryNotify(func() error {
+		last = op()
+		if last != nil && (ctx.Err() != nil || !retryable(last)) {
+			return backoff.Permanent(last)
+		}
+
+		return last
+	}, backoff.WithContext(backoff.WithMaxRetries(b, uint64(retries)), ctx), func(err error, next time.Duration) {
+		logger.Debugf("retrying in %s after: %s", next, err)
+	})
+	if err == nil || last == nil {
+		return err
+	}
+
+	// the last error of the operation is returned, rather than the error of the context if it's done between the
+	// attempts, and without the permanent mark
+	if permanent, ok := last.(*permanentError); ok {
+		return permanent.err
+	}
+
+	return last
+}
+
+// Permanent marks an error as permanent: the operation isn't retried.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// IsRetryable is the default classification of the errors: all the errors are retryable, except the permanent
+// errors and the errors of the done contexts.
+func IsRetryable(err error) bool {
+	var permanent *permanentError
+
+	switch {
+	case err == nil, errors.As(err, &permanent):
+		return false
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	default:
+		return true
+	}
+}
+
+// RetryableStatus tells whether a request answered with the HTTP status is worth retrying: the request timed out,
+// was throttled, or the server (or a gateway) is failing or unavailable.
+func RetryableStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
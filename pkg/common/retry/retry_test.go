@@ -0,0 +1,156 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testPolicy(attempts int) Policy {
+	p := DefaultPolicy()
+	p.MaxAttempts = attempts
+	p.InitialInterval = time.Millisecond
+
+	return p
+}
+
+func TestDo(t *testing.T) {
+	errTransient := errors.New("transient")
+
+	t.Run("succeeds after transient errors", func(t *testing.T) {
+		attempts := 0
+
+		err := Do(context.Background(), testPolicy(3), func() error {
+			attempts++
+			if attempts < 3 {
+				return errTransient
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("fails after the max attempts", func(t *testing.T) {
+		attempts := 0
+
+		err := Do(context.Background(), testPolicy(3), func() error {
+			attempts++
+
+			return fmt.Errorf("attempt %d: %w", attempts, errTransient)
+		})
+		require.EqualError(t, err, "attempt 3: transient")
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("single attempt", func(t *testing.T) {
+		for _, max := range []int{0, 1} {
+			attempts := 0
+
+			err := Do(context.Background(), testPolicy(max), func() error {
+				attempts++
+
+				return errTransient
+			})
+			require.True(t, errors.Is(err, errTransient))
+			require.Equal(t, 1, attempts)
+		}
+	})
+
+	t.Run("permanent error", func(t *testing.T) {
+		attempts := 0
+
+		err := Do(context.Background(), testPolicy(3), func() error {
+			attempts++
+
+			return Permanent(errTransient)
+		})
+		require.Equal(t, errTransient, err)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("custom classification", func(t *testing.T) {
+		p := testPolicy(3)
+		p.Retryable = func(err error) bool {
+			return !errors.Is(err, errTransient)
+		}
+
+		attempts := 0
+
+		err := Do(context.Background(), p, func() error {
+			attempts++
+
+			return errTransient
+		})
+		require.Equal(t, errTransient, err)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		p := testPolicy(10)
+		p.InitialInterval = time.Hour
+
+		attempts := 0
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		err := Do(ctx, p, func() error {
+			attempts++
+
+			return errTransient
+		})
+		require.Equal(t, errTransient, err)
+		require.Equal(t, 1, attempts)
+	})
+}
+
+func TestNoRetry(t *testing.T) {
+	errTransient := errors.New("transient")
+	attempts := 0
+
+	err := Do(context.Background(), NoRetry(), func() error {
+		attempts++
+
+		return errTransient
+	})
+	require.Equal(t, errTransient, err)
+	require.Equal(t, 1, attempts)
+}
+
+func TestIsRetryable(t *testing.T) {
+	require.False(t, IsRetryable(nil))
+	require.False(t, IsRetryable(Permanent(errors.New("permanent"))))
+	require.False(t, IsRetryable(fmt.Errorf("wrapped: %w", Permanent(errors.New("permanent")))))
+	require.False(t, IsRetryable(context.Canceled))
+	require.False(t, IsRetryable(fmt.Errorf("request: %w", context.DeadlineExceeded)))
+	require.True(t, IsRetryable(errors.New("connection refused")))
+
+	require.NoError(t, Permanent(nil))
+}
+
+func TestRetryableStatus(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusBadGateway} {
+		require.True(t, RetryableStatus(status), status)
+	}
+
+	for _, status := range []int{http.StatusOK, http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized} {
+		require.False(t, RetryableStatus(status), status)
+	}
+}
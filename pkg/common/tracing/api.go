@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"time"
+)
+
+// Attributes are the attributes (name to value) of a span.
+type Attributes map[string]string
+
+// Carrier carries the trace context of a span between the agents, as the W3C traceparent and tracestate keys.
+type Carrier map[string]string
+
+// Span is a traced operation.
+type Span interface {
+	// SetAttributes adds the attributes to the span
+	SetAttributes(attrs Attributes)
+	// End ends the span, recording the error of the operation if not nil
+	End(err error)
+}
+
+// Tracer creates the spans, it is implemented by the tracing system adapter (e.g. OpenTelemetry), which exports
+// them. The tracer must be safe for concurrent use.
+type Tracer interface {
+	// Start starts the span of the operation at the given time, child of the span of ctx if any, and returns
+	// the context holding the span
+	Start(ctx context.Context, name string, start time.Time) (context.Context, Span)
+	// Inject writes the trace context of the span of ctx to the carrier
+	Inject(ctx context.Context, carrier Carrier)
+	// Extract returns the context holding the remote span of the trace context of the carrier
+	Extract(ctx context.Context, carrier Carrier) context.Context
+}
+
+// Source is implemented by the framework context, the protocol services and the outbound dispatcher trace the
+// messages with its tracer.
+type Source interface {
+	Tracer() Tracer
+}
+
+// tracerOf returns the tracer of ctx, a tracer discarding the spans when ctx is not a Source or it has no tracer.
+func tracerOf(ctx interface{}) Tracer {
+	var t Tracer
+
+	if src, ok := ctx.(Source); ok {
+		t = src.Tracer()
+	}
+
+	if t == nil {
+		t = nop{}
+	}
+
+	return t
+}
+
+type nop struct{}
+
+func (nop) Start(ctx context.Context, _ string, _ time.Time) (context.Context, Span) {
+	return ctx, nop{}
+}
+
+func (nop) Inject(context.Context, Carrier) {}
+
+func (nop) Extract(ctx context.Context, _ Carrier) context.Context { return ctx }
+
+func (nop) SetAttributes(Attributes) {}
+
+func (nop) End(error) {}
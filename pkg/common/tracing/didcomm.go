@@ -0,0 +1,147 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"time"
+)
+
+// Decorator is the DIDComm message decorator carrying the trace context of the span of the message, so that the
+// spans of the agents exchanging the messages of a thread are linked into one trace.
+const Decorator = "~trace_context"
+
+// Names of the spans of the DIDComm messages.
+const (
+	// SendSpan is the span of a message sent by the outbound dispatcher.
+	SendSpan = "didcomm.send"
+	// ReceiveSpan is the span of a message received and handled by the framework.
+	ReceiveSpan = "didcomm.receive"
+)
+
+// Attribute names of the spans of the DIDComm messages and protocol services.
+const (
+	// MessageTypeAttribute is the type of the message.
+	MessageTypeAttribute = "didcomm.message.type"
+	// MessageIDAttribute is the ID of the message.
+	MessageIDAttribute = "didcomm.message.id"
+	// ThreadIDAttribute is the thread ID of the message.
+	ThreadIDAttribute = "didcomm.thread.id"
+	// TransportAttribute is the transport of the message sent, e.g. http or ws.
+	TransportAttribute = "didcomm.transport"
+	// ProtocolInstanceAttribute is the ID of the protocol instance handling the message.
+	ProtocolInstanceAttribute = "aries.protocol.piid"
+	// StateAttribute is the state the protocol instance transitioned to.
+	StateAttribute = "aries.protocol.state"
+)
+
+// DIDComm traces the DIDComm messages, carrying the trace context in their decorator.
+type DIDComm struct {
+	tracer Tracer
+}
+
+// ForDIDComm returns the tracer of the DIDComm messages. The spans are discarded when ctx is not a Source or it
+// has no tracer.
+func ForDIDComm(ctx interface{}) *DIDComm {
+	return &DIDComm{tracer: tracerOf(ctx)}
+}
+
+// Start starts the span of the operation on the message, child of the span of its trace context decorator if any,
+// and sets the decorator to the trace context of the span: the messages sent in reply to, or on behalf of, the
+// message are then traced as its children. The message may be nil.
+func (d *DIDComm) Start(msg map[string]interface{}, name string) Span {
+	ctx := context.Background()
+
+	if carrier := messageCarrier(msg); len(carrier) > 0 {
+		ctx = d.tracer.Extract(ctx, carrier)
+	}
+
+	ctx, span := d.tracer.Start(ctx, name, time.Now())
+
+	if msg == nil {
+		return span
+	}
+
+	span.SetAttributes(messageAttributes(msg))
+
+	carrier := Carrier{}
+	d.tracer.Inject(ctx, carrier)
+
+	if len(carrier) > 0 {
+		decorator := make(map[string]interface{}, len(carrier))
+
+		for k, v := range carrier {
+			decorator[k] = v
+		}
+
+		msg[Decorator] = decorator
+	}
+
+	return span
+}
+
+// Protocol traces the messages handled by a protocol service.
+type Protocol struct {
+	didcomm *DIDComm
+	name    string
+}
+
+// ForProtocol returns the tracer of the protocol service. The spans are discarded when the protocol context is
+// not a Source or it has no tracer.
+func ForProtocol(ctx interface{}, name string) *Protocol {
+	return &Protocol{didcomm: ForDIDComm(ctx), name: name}
+}
+
+// Start starts the span of the handling of the message by the protocol instance, named after the protocol.
+func (p *Protocol) Start(msg map[string]interface{}, piID string) Span {
+	span := p.didcomm.Start(msg, p.name)
+	span.SetAttributes(Attributes{ProtocolInstanceAttribute: piID})
+
+	return span
+}
+
+// messageCarrier returns the trace context of the decorator of the message.
+func messageCarrier(msg map[string]interface{}) Carrier {
+	carrier := Carrier{}
+
+	switch decorator := msg[Decorator].(type) {
+	case map[string]interface{}:
+		for k, v := range decorator {
+			if s, ok := v.(string); ok {
+				carrier[k] = s
+			}
+		}
+	case map[string]string:
+		for k, v := range decorator {
+			carrier[k] = v
+		}
+	}
+
+	return carrier
+}
+
+func messageAttributes(msg map[string]interface{}) Attributes {
+	attrs := Attributes{}
+
+	if t, ok := msg["@type"].(string); ok {
+		attrs[MessageTypeAttribute] = t
+	}
+
+	// the ID of the message is the thread ID of the messages starting a thread
+	if id, ok := msg["@id"].(string); ok && id != "" {
+		attrs[MessageIDAttribute] = id
+		attrs[ThreadIDAttribute] = id
+	}
+
+	if thread, ok := msg["~thread"].(map[string]interface{}); ok {
+		if thID, ok := thread["thid"].(string); ok && thID != "" {
+			attrs[ThreadIDAttribute] = thID
+		}
+	}
+
+	return attrs
+}
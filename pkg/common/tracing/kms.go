@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"time"
+)
+
+// KMSSpan is the span of a key manager operation.
+const KMSSpan = "kms"
+
+// OperationAttribute is the key manager or store operation (e.g. create or put).
+const OperationAttribute = "aries.operation"
+
+// KMS traces the operations of the key manager.
+type KMS struct {
+	tracer Tracer
+}
+
+// ForKMS returns the tracer of the key manager operations. The spans are discarded when t is nil.
+func ForKMS(t Tracer) *KMS {
+	if t == nil {
+		t = nop{}
+	}
+
+	return &KMS{tracer: t}
+}
+
+// Operation traces the key manager operation started at the given time, and its error.
+func (k *KMS) Operation(operation string, start time.Time, err error) {
+	_, span := k.tracer.Start(context.Background(), KMSSpan, start)
+	span.SetAttributes(Attributes{OperationAttribute: operation})
+	span.End(err)
+}
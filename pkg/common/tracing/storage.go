@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"time"
+)
+
+// StorageSpan is the span of a store operation.
+const StorageSpan = "storage"
+
+// StoreAttribute is the name of the store.
+const StoreAttribute = "aries.store"
+
+// Storage traces the operations of the stores.
+type Storage struct {
+	tracer Tracer
+}
+
+// ForStorage returns the tracer of the store operations. The spans are discarded when t is nil.
+func ForStorage(t Tracer) *Storage {
+	if t == nil {
+		t = nop{}
+	}
+
+	return &Storage{tracer: t}
+}
+
+// Operation traces the operation of the store started at the given time, and its error.
+func (s *Storage) Operation(store, operation string, start time.Time, err error) {
+	_, span := s.tracer.Start(context.Background(), StorageSpan, start)
+	span.SetAttributes(Attributes{StoreAttribute: store, OperationAttribute: operation})
+	span.End(err)
+}
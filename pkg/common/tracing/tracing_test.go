@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
+)
+
+type source struct {
+	tracer tracing.Tracer
+}
+
+func (s *source) Tracer() tracing.Tracer {
+	return s.tracer
+}
+
+func TestForDIDComm(t *testing.T) {
+	t.Run("Links the spans of the messages", func(t *testing.T) {
+		tracer := &mocktracing.Tracer{}
+
+		msg := map[string]interface{}{"@type": "https://didcomm.org/issue-credential/2.0/offer-credential", "@id": "1"}
+
+		sent := tracing.ForDIDComm(&source{tracer}).Start(msg, tracing.SendSpan)
+		sent.End(nil)
+
+		// the remote agent gets the trace context of the sent message
+		reply := map[string]interface{}{
+			"@id":             "2",
+			"~thread":         map[string]interface{}{"thid": "1"},
+			tracing.Decorator: msg[tracing.Decorator],
+		}
+
+		handled := tracing.ForProtocol(&source{tracer}, "issuecredential").Start(reply, "piid")
+		handled.End(errors.New("failed"))
+
+		spans := tracer.Spans()
+		require.Len(t, spans, 2)
+
+		require.Equal(t, tracing.SendSpan, spans[0].Name)
+		require.Empty(t, spans[0].ParentID)
+		require.Equal(t, tracing.Attributes{
+			tracing.MessageTypeAttribute: "https://didcomm.org/issue-credential/2.0/offer-credential",
+			tracing.MessageIDAttribute:   "1",
+			tracing.ThreadIDAttribute:    "1",
+		}, spans[0].Attributes)
+
+		require.Equal(t, "issuecredential", spans[1].Name)
+		require.Equal(t, spans[0].ID, spans[1].ParentID)
+		require.Equal(t, tracing.Attributes{
+			tracing.MessageIDAttribute:        "2",
+			tracing.ThreadIDAttribute:         "1",
+			tracing.ProtocolInstanceAttribute: "piid",
+		}, spans[1].Attributes)
+		require.EqualError(t, spans[1].Err, "failed")
+
+		// the decorator holds the trace context of the last span
+		require.Equal(t, map[string]interface{}{"traceparent": spans[1].ID}, reply[tracing.Decorator])
+
+		tracing.ForDIDComm(&source{tracer}).Start(nil, tracing.SendSpan).End(nil)
+		require.Len(t, tracer.Spans(tracing.SendSpan), 2)
+	})
+
+	t.Run("Discards the spans without the tracer", func(t *testing.T) {
+		msg := map[string]interface{}{"@id": "1"}
+
+		tracing.ForDIDComm(nil).Start(msg, tracing.ReceiveSpan).End(nil)
+		tracing.ForProtocol(&source{}, "issuecredential").Start(msg, "piid").End(nil)
+
+		require.NotContains(t, msg, tracing.Decorator)
+	})
+}
+
+func TestForKMS(t *testing.T) {
+	tracer := &mocktracing.Tracer{}
+	start := time.Now()
+
+	tracing.ForKMS(tracer).Operation("create", start, errors.New("failed"))
+	tracing.ForKMS(nil).Operation("create", start, nil)
+
+	spans := tracer.Spans(tracing.KMSSpan)
+	require.Len(t, spans, 1)
+	require.Equal(t, start, spans[0].Start)
+	require.Equal(t, tracing.Attributes{tracing.OperationAttribute: "create"}, spans[0].Attributes)
+	require.EqualError(t, spans[0].Err, "failed")
+	require.True(t, spans[0].Ended)
+}
+
+func TestForStorage(t *testing.T) {
+	tracer := &mocktracing.Tracer{}
+
+	tracing.ForStorage(tracer).Operation("messenger_store", "put", time.Now(), nil)
+	tracing.ForStorage(nil).Operation("messenger_store", "put", time.Now(), nil)
+
+	spans := tracer.Spans(tracing.StorageSpan)
+	require.Len(t, spans, 1)
+	require.Equal(t, tracing.Attributes{
+		tracing.StoreAttribute:     "messenger_store",
+		tracing.OperationAttribute: "put",
+	}, spans[0].Attributes)
+	require.NoError(t, spans[0].Err)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -34,6 +35,7 @@ const (
 	// error messages.
 	errEmptyInviterDID = "empty inviter DID"
 	errEmptyConnID     = "empty connection ID"
+	errEmptyConnection = "empty connection"
 
 	AcceptExchangeRequestCommandMethod    = "AcceptExchangeRequest"
 	AcceptInvitationCommandMethod         = "AcceptInvitation"
@@ -44,6 +46,8 @@ const (
 	ReceiveInvitationCommandMethod        = "ReceiveInvitation"
 	CreateConnectionCommandMethod         = "CreateConnection"
 	RemoveConnectionCommandMethod         = "RemoveConnection"
+	ExportConnectionCommandMethod         = "ExportConnection"
+	ImportConnectionCommandMethod         = "ImportConnection"
 
 	// log constants.
 	connectionIDString = "connectionID"
@@ -80,6 +84,12 @@ const (
 	// CreateConnectionErrorCode is for failures in create connection command.
 	CreateConnectionErrorCode
 
+	// ExportConnectionErrorCode is for failures in export connection command.
+	ExportConnectionErrorCode
+
+	// ImportConnectionErrorCode is for failures in import connection command.
+	ImportConnectionErrorCode
+
 	_actions = "_actions"
 	_states  = "_states"
 )
@@ -91,6 +101,7 @@ type provider interface {
 	ServiceEndpoint() string
 	StorageProvider() storage.Provider
 	ProtocolStateStorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
 }
 
 // New returns new DID Exchange controller command instance.
@@ -163,6 +174,8 @@ func (c *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, AcceptInvitationCommandMethod, c.AcceptInvitation),
 		cmdutil.NewCommandHandler(CommandName, CreateConnectionCommandMethod, c.CreateConnection),
 		cmdutil.NewCommandHandler(CommandName, RemoveConnectionCommandMethod, c.RemoveConnection),
+		cmdutil.NewCommandHandler(CommandName, ExportConnectionCommandMethod, c.ExportConnection),
+		cmdutil.NewCommandHandler(CommandName, ImportConnectionCommandMethod, c.ImportConnection),
 		cmdutil.NewCommandHandler(CommandName, QueryConnectionByIDCommandMethod, c.QueryConnectionByID),
 		cmdutil.NewCommandHandler(CommandName, QueryConnectionsCommandMethod, c.QueryConnections),
 		cmdutil.NewCommandHandler(CommandName, AcceptExchangeRequestCommandMethod, c.AcceptExchangeRequest),
@@ -369,6 +382,13 @@ func (c *Command) QueryConnections(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
+	request.Offset, err = command.PageOffset(request.Offset, request.Cursor)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, QueryConnectionsCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
 	results, err := c.client.QueryConnections(&request.QueryConnectionsParams)
 	if err != nil {
 		logutil.LogError(logger, CommandName, QueryConnectionsCommandMethod, err.Error())
@@ -376,8 +396,21 @@ func (c *Command) QueryConnections(rw io.Writer, req io.Reader) command.Error {
 		return command.NewExecuteError(QueryConnectionsErrorCode, err)
 	}
 
+	total := len(results)
+
+	if request.Offset > 0 || request.Limit > 0 {
+		total, err = c.client.CountConnections(&request.QueryConnectionsParams)
+		if err != nil {
+			logutil.LogError(logger, CommandName, QueryConnectionsCommandMethod, err.Error())
+
+			return command.NewExecuteError(QueryConnectionsErrorCode, err)
+		}
+	}
+
 	command.WriteNillableResponse(rw, &QueryConnectionsResponse{
-		Results: results,
+		Results:    results,
+		Total:      total,
+		NextCursor: command.NextCursor(request.Offset, len(results), total),
 	}, logger)
 
 	logutil.LogDebug(logger, CommandName, QueryConnectionsCommandMethod, successString)
@@ -493,3 +526,72 @@ func (c *Command) RemoveConnection(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// ExportConnection exports a completed connection, to be imported into another agent.
+func (c *Command) ExportConnection(rw io.Writer, req io.Reader) command.Error {
+	var request ConnectionIDArg
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportConnectionCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ID == "" {
+		logutil.LogDebug(logger, CommandName, ExportConnectionCommandMethod, errEmptyConnID)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyConnID))
+	}
+
+	export, err := c.client.ExportConnection(request.ID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ExportConnectionCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, request.ID))
+
+		return command.NewExecuteError(ExportConnectionErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportConnectionResponse{
+		Result: export,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportConnectionCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionIDString, request.ID))
+
+	return nil
+}
+
+// ImportConnection imports a connection exported from another agent and returns its connectionID.
+func (c *Command) ImportConnection(rw io.Writer, req io.Reader) command.Error {
+	var request ImportConnectionRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportConnectionCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.Connection == nil {
+		logutil.LogDebug(logger, CommandName, ImportConnectionCommandMethod, errEmptyConnection)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyConnection))
+	}
+
+	id, err := c.client.ImportConnection(request.Connection)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ImportConnectionCommandMethod, err.Error())
+
+		return command.NewExecuteError(ImportConnectionErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ConnectionIDArg{
+		ID: id,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, ImportConnectionCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionIDString, id))
+
+	return nil
+}
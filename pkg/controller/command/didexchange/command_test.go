@@ -397,6 +397,47 @@ func TestCommand_QueryConnections(t *testing.T) {
 		require.Empty(t, response)
 	})
 
+	t.Run("test query connections with paging", func(t *testing.T) {
+		prov := mockProvider()
+		store := mockstore.MockStore{Store: make(map[string][]byte)}
+
+		for _, connID := range []string{"1", "2", "3"} {
+			connBytes, err := json.Marshal(&connection.Record{State: "completed", ConnectionID: connID})
+			require.NoError(t, err)
+			require.NoError(t, store.Put("conn_"+connID, connBytes))
+		}
+
+		prov.StorageProviderValue = &mockstore.MockStoreProvider{Store: &store}
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.QueryConnections(&b, bytes.NewBufferString(`{"offset":1,"limit":1}`))
+		require.NoError(t, cmdErr)
+
+		response := QueryConnectionsResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Len(t, response.Results, 1)
+		require.Equal(t, "2", response.Results[0].ConnectionID)
+		require.Equal(t, 3, response.Total)
+		require.Equal(t, command.EncodeCursor(2), response.NextCursor)
+
+		b.Reset()
+		cmdErr = cmd.QueryConnections(&b, bytes.NewBufferString(`{"cursor":"`+response.NextCursor+`","limit":1}`))
+		require.NoError(t, cmdErr)
+
+		response = QueryConnectionsResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Len(t, response.Results, 1)
+		require.Equal(t, "3", response.Results[0].ConnectionID)
+		require.Empty(t, response.NextCursor)
+
+		cmdErr = cmd.QueryConnections(&b, bytes.NewBufferString(`{"cursor":"!"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
 	t.Run("test query connections without state filter", func(t *testing.T) {
 		// prepare data
 		const connID = "1234"
@@ -936,6 +977,107 @@ func TestCommand_RemoveConnection(t *testing.T) {
 	})
 }
 
+func TestCommand_ExportImportConnection(t *testing.T) {
+	doc, err := (&mockvdr.MockVDRegistry{}).Create("peer")
+	require.NoError(t, err)
+
+	connRec := &connection.Record{
+		State: connection.StateNameCompleted, ConnectionID: "1234", ThreadID: "th1234", MyDID: doc.ID, TheirDID: doc.ID,
+	}
+
+	newCommand := func(t *testing.T) *Command {
+		t.Helper()
+
+		prov := mockProvider()
+		prov.VDRegistryValue = &mockvdr.MockVDRegistry{ResolveValue: doc}
+		prov.KMSValue = &mockkms.KeyManager{}
+
+		store := mockstore.MockStore{Store: make(map[string][]byte)}
+
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		require.NoError(t, store.Put("conn_"+connRec.ConnectionID, connBytes))
+		prov.StorageProviderValue = &mockstore.MockStoreProvider{Store: &store}
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	t.Run("export and import connection", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := newCommand(t).ExportConnection(&b, bytes.NewBufferString(`{"id":"1234"}`))
+		require.NoError(t, cmdErr)
+
+		var export ExportConnectionResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &export))
+		require.Equal(t, connRec, export.Result.Record)
+		require.Equal(t, doc.ID, export.Result.MyDIDDoc.ID)
+
+		request, err := json.Marshal(&ImportConnectionRequest{Connection: export.Result})
+		require.NoError(t, err)
+
+		prov := mockProvider()
+		prov.VDRegistryValue = &mockvdr.MockVDRegistry{}
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		b.Reset()
+
+		cmdErr = cmd.ImportConnection(&b, bytes.NewBuffer(request))
+		require.NoError(t, cmdErr)
+
+		var response ConnectionIDArg
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Equal(t, connRec.ConnectionID, response.ID)
+	})
+
+	t.Run("export connection errors", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.ExportConnection(&b, bytes.NewBufferString(`--`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.ExportConnection(&b, bytes.NewBufferString(`{"id":""}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyConnID)
+
+		cmdErr = cmd.ExportConnection(&b, bytes.NewBufferString(`{"id":"unknown"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, ExportConnectionErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("import connection errors", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.ImportConnection(&b, bytes.NewBufferString(`--`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.ImportConnection(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyConnection)
+
+		cmdErr = cmd.ImportConnection(&b, bytes.NewBufferString(`{"connection":{}}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, ImportConnectionErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
+
 func mockProvider() *mockprovider.Provider {
 	return &mockprovider.Provider{
 		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
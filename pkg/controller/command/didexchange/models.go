@@ -202,6 +202,10 @@ type GetConnectionRequest struct {
 type QueryConnectionsArgs struct {
 	// Params for querying connections
 	didexchange.QueryConnectionsParams
+
+	// Cursor of the page of connections to return, as returned in the next_cursor of the previous page.
+	// It takes precedence over the offset.
+	Cursor string `json:"cursor,omitempty"`
 }
 
 // QueryConnectionResponse model
@@ -218,6 +222,10 @@ type QueryConnectionResponse struct {
 //
 type QueryConnectionsResponse struct {
 	Results []*didexchange.Connection `json:"results,omitempty"`
+	// Total number of connections matching the query, ignoring the paging parameters
+	Total int `json:"total,omitempty"`
+	// NextCursor is the cursor of the next page of connections, empty for the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // AcceptExchangeRequestArgs model
@@ -312,6 +320,22 @@ type ConnectionIDArg struct {
 	ID string `json:"id"`
 }
 
+// ExportConnectionResponse model
+//
+// This is used for returning an exported connection
+//
+type ExportConnectionResponse struct {
+	Result *didexchange.ConnectionExport `json:"result,omitempty"`
+}
+
+// ImportConnectionRequest model
+//
+// This is used for importing a connection exported from another agent
+//
+type ImportConnectionRequest struct {
+	Connection *didexchange.ConnectionExport `json:"connection"`
+}
+
 // CreateConnectionRequest model
 //
 type CreateConnectionRequest struct {
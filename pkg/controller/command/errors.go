@@ -64,6 +64,12 @@ const (
 
 	// Outofband error group for outofband command errors.
 	Outofband = 11000
+
+	// Batch error group for batch operation errors.
+	Batch = 12000
+
+	// VCWallet error group for verifiable credential wallet command errors.
+	VCWallet = 13000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
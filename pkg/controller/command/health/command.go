@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"context"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+)
+
+var logger = log.New("aries-framework/command/health")
+
+// constants for health commands.
+const (
+	// command name.
+	CommandName = "health"
+
+	// command methods.
+	LiveCommandMethod  = "Live"
+	ReadyCommandMethod = "Ready"
+)
+
+// Command contains the health commands, reporting the liveness and readiness of the agent.
+type Command struct {
+	checker *health.Checker
+}
+
+// New returns a new health command reporting the probes of the checker (see aries.HealthChecker).
+func New(checker *health.Checker) *Command {
+	return &Command{checker: checker}
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, LiveCommandMethod, c.Live),
+		cmdutil.NewCommandHandler(CommandName, ReadyCommandMethod, c.Ready),
+	}
+}
+
+// Live reports the liveness of the agent. A failing agent isn't a command error, the status of the report is down.
+func (c *Command) Live(rw io.Writer, _ io.Reader) command.Error {
+	command.WriteNillableResponse(rw, &Response{Report: c.checker.Live(context.Background())}, logger)
+
+	return nil
+}
+
+// Ready reports the readiness of the agent. A failing agent isn't a command error, the status of the report is down.
+func (c *Command) Ready(rw io.Writer, _ io.Reader) command.Error {
+	command.WriteNillableResponse(rw, &Response{Report: c.checker.Ready(context.Background())}, logger)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
+)
+
+func TestCommand(t *testing.T) {
+	checker := health.NewChecker()
+	checker.Register("framework", health.Liveness, func(context.Context) error { return nil })
+	checker.Register("storage", health.Readiness, func(context.Context) error { return errors.New("unreachable") })
+
+	cmd := New(checker)
+	require.Len(t, cmd.GetHandlers(), 2)
+
+	var b bytes.Buffer
+
+	require.Nil(t, cmd.Live(&b, nil))
+
+	var res Response
+
+	require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+	require.Equal(t, &health.Report{
+		Status:     health.StatusUp,
+		Components: map[string]health.ComponentStatus{"framework": {Status: health.StatusUp}},
+	}, res.Report)
+
+	b.Reset()
+
+	require.Nil(t, cmd.Ready(&b, nil))
+
+	res = Response{}
+
+	require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+	require.Equal(t, &health.Report{
+		Status: health.StatusDown,
+		Components: map[string]health.ComponentStatus{
+			"framework": {Status: health.StatusUp},
+			"storage":   {Status: health.StatusDown, Error: "unreachable"},
+		},
+	}, res.Report)
+}
@@ -0,0 +1,16 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
+)
+
+// Response is the liveness or the readiness of the agent and of its components.
+type Response struct {
+	*health.Report
+}
@@ -511,6 +511,7 @@ func (c *Command) DeclineRequest(rw io.Writer, req io.Reader) command.Error {
 }
 
 // AcceptCredential is used when the Holder is willing to accept the IssueCredential.
+// The issued credentials with the rejected attachment IDs (if any) are not saved and are reported in the ack.
 // nolint: dupl
 func (c *Command) AcceptCredential(rw io.Writer, req io.Reader) command.Error {
 	var args AcceptCredentialArgs
@@ -525,7 +526,7 @@ func (c *Command) AcceptCredential(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
 	}
 
-	if err := c.client.AcceptCredential(args.PIID, args.Names...); err != nil {
+	if err := c.client.AcceptCredentialExcept(args.PIID, args.RejectedAttachIDs, args.Names...); err != nil {
 		logutil.LogError(logger, CommandName, AcceptCredential, err.Error())
 		return command.NewExecuteError(AcceptCredentialErrorCode, err)
 	}
@@ -67,6 +67,8 @@ type AcceptCredentialArgs struct {
 	PIID string `json:"piid"`
 	// Names represent the names of how credentials will be stored
 	Names []string `json:"names"`
+	// RejectedAttachIDs are the attachment IDs of the issued credentials the Holder does not accept
+	RejectedAttachIDs []string `json:"rejected_attach_ids,omitempty"`
 }
 
 // AcceptCredentialResponse model
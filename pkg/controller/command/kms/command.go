@@ -16,9 +16,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
 var logger = log.New("aries-framework/command/kms")
@@ -31,6 +33,14 @@ const (
 	CreateKeySetError
 	// ImportKeyError is for failures while importing key.
 	ImportKeyError
+	// KeyUsageError is for the keys which can't be used for the requested operation.
+	KeyUsageError
+	// SignError is for failures while signing a message.
+	SignError
+	// VerifyError is for failures while verifying a signature.
+	VerifyError
+	// WrapKeyError is for failures while wrapping a key.
+	WrapKeyError
 )
 
 // constants for KMS commands.
@@ -41,33 +51,49 @@ const (
 	// command methods.
 	CreateKeySetCommandMethod = "CreateKeySet"
 	ImportKeyCommandMethod    = "ImportKey"
+	SignCommandMethod         = "Sign"
+	VerifyCommandMethod       = "Verify"
+	WrapKeyCommandMethod      = "WrapKey"
 
 	// error messages.
-	errEmptyKeyType = "key type is mandatory"
-	errEmptyKeyID   = "key id is mandatory"
+	errEmptyKeyType      = "key type is mandatory"
+	errEmptyKeyID        = "key id is mandatory"
+	errEmptyMessage      = "message is mandatory"
+	errEmptySignature    = "signature is mandatory"
+	errEmptyCEK          = "cek is mandatory"
+	errEmptyRecipientKey = "recipient key is mandatory"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
 type provider interface {
 	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	StorageProvider() storage.Provider
 }
 
 // Command contains command operations provided by verifiable credential controller.
 type Command struct {
-	ctx       provider
-	importKey func(privKey interface{}, kt kms.KeyType,
+	ctx         provider
+	policyStore storage.Store
+	importKey   func(privKey interface{}, kt kms.KeyType,
 		opts ...kms.PrivateKeyOpts) (string, interface{}, error) // needed for unit test
 }
 
 // New returns new kms command instance.
-func New(p provider) *Command {
+func New(p provider) (*Command, error) {
+	policyStore, err := p.StorageProvider().OpenStore(KeyUsageStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open key usage store : %w", err)
+	}
+
 	return &Command{
-		ctx: p,
+		ctx:         p,
+		policyStore: policyStore,
 		importKey: func(privKey interface{}, kt kms.KeyType,
 			opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
 			return p.KMS().ImportPrivateKey(privKey, kt, opts...)
 		},
-	}
+	}, nil
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -75,10 +101,14 @@ func (o *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, CreateKeySetCommandMethod, o.CreateKeySet),
 		cmdutil.NewCommandHandler(CommandName, ImportKeyCommandMethod, o.ImportKey),
+		cmdutil.NewCommandHandler(CommandName, SignCommandMethod, o.Sign),
+		cmdutil.NewCommandHandler(CommandName, VerifyCommandMethod, o.Verify),
+		cmdutil.NewCommandHandler(CommandName, WrapKeyCommandMethod, o.WrapKey),
 	}
 }
 
-// CreateKeySet create a new public/private encryption and signature key pairs set.
+// CreateKeySet create a new public/private encryption and signature key pairs set. The key can be used by the sign,
+// verify and wrap commands for the requested usages, all of them if none is requested.
 func (o *Command) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
 	var request CreateKeySetRequest
 
@@ -93,12 +123,23 @@ func (o *Command) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyType))
 	}
 
+	usages, err := parseUsages(request.Usages)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, CreateKeySetCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
 	keyID, pubKeyBytes, err := o.ctx.KMS().CreateAndExportPubKeyBytes(kms.KeyType(request.KeyType))
 	if err != nil {
 		logutil.LogError(logger, CommandName, CreateKeySetCommandMethod, err.Error())
 		return command.NewExecuteError(CreateKeySetError, err)
 	}
 
+	if err = o.savePolicy(keyID, kms.KeyType(request.KeyType), usages); err != nil {
+		logutil.LogError(logger, CommandName, CreateKeySetCommandMethod, err.Error())
+		return command.NewExecuteError(CreateKeySetError, err)
+	}
+
 	command.WriteNillableResponse(rw, &CreateKeySetResponse{
 		KeyID:     keyID,
 		PublicKey: base64.RawURLEncoding.EncodeToString(pubKeyBytes),
@@ -109,7 +150,8 @@ func (o *Command) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
-// ImportKey import key.
+// ImportKey import key. The JWK "use" parameter sets the usages of the key: "sig" for the sign and verify commands,
+// "enc" for the wrap command and all of them if not set.
 func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 	buf := new(bytes.Buffer)
 
@@ -142,15 +184,205 @@ func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 			fmt.Errorf("import key type not supported %s", jwk.Crv))
 	}
 
+	usages, err := jwkUsages(jwk.Use)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, ImportKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
 	_, _, err = o.importKey(jwk.Key, kType, kms.WithKeyID(jwk.KeyID))
 	if err != nil {
 		logutil.LogError(logger, CommandName, ImportKeyCommandMethod, err.Error())
 		return command.NewExecuteError(ImportKeyError, err)
 	}
 
+	if err = o.savePolicy(jwk.KeyID, kType, usages); err != nil {
+		logutil.LogError(logger, CommandName, ImportKeyCommandMethod, err.Error())
+		return command.NewExecuteError(ImportKeyError, err)
+	}
+
 	command.WriteNillableResponse(rw, nil, logger)
 
 	logutil.LogDebug(logger, CommandName, ImportKeyCommandMethod, "success")
 
 	return nil
 }
+
+// Sign signs a message with a key created or imported with the sign usage.
+func (o *Command) Sign(rw io.Writer, req io.Reader) command.Error {
+	var request SignRequest
+
+	if err := json.NewDecoder(req).Decode(&request); err != nil {
+		logutil.LogInfo(logger, CommandName, SignCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, SignCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	msg, err := decodeField(request.Message, errEmptyMessage)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, SignCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if _, err = o.checkUsage(request.KeyID, SignUsage); err != nil {
+		logutil.LogInfo(logger, CommandName, SignCommandMethod, err.Error())
+		return command.NewValidationError(KeyUsageError, err)
+	}
+
+	kh, err := o.ctx.KMS().Get(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SignCommandMethod, err.Error())
+		return command.NewExecuteError(SignError, fmt.Errorf("failed to get key : %w", err))
+	}
+
+	signature, err := o.ctx.Crypto().Sign(msg, kh)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SignCommandMethod, err.Error())
+		return command.NewExecuteError(SignError, fmt.Errorf("failed to sign message : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &SignResponse{
+		Signature: base64.RawURLEncoding.EncodeToString(signature),
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, SignCommandMethod, "success")
+
+	return nil
+}
+
+// Verify verifies a signature with the public key of a key created or imported with the verify usage.
+func (o *Command) Verify(rw io.Writer, req io.Reader) command.Error {
+	var request VerifyRequest
+
+	if err := json.NewDecoder(req).Decode(&request); err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, VerifyCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	msg, err := decodeField(request.Message, errEmptyMessage)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, VerifyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	signature, err := decodeField(request.Signature, errEmptySignature)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, VerifyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	policy, err := o.checkUsage(request.KeyID, VerifyUsage)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCommandMethod, err.Error())
+		return command.NewValidationError(KeyUsageError, err)
+	}
+
+	pubKeyBytes, err := o.ctx.KMS().ExportPubKeyBytes(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, VerifyCommandMethod, err.Error())
+		return command.NewExecuteError(VerifyError, fmt.Errorf("failed to export public key : %w", err))
+	}
+
+	kh, err := o.ctx.KMS().PubKeyBytesToHandle(pubKeyBytes, policy.KeyType)
+	if err != nil {
+		logutil.LogError(logger, CommandName, VerifyCommandMethod, err.Error())
+		return command.NewExecuteError(VerifyError, fmt.Errorf("failed to get public key handle : %w", err))
+	}
+
+	if err = o.ctx.Crypto().Verify(signature, msg, kh); err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCommandMethod, err.Error())
+		return command.NewValidationError(VerifyError, fmt.Errorf("invalid signature : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyCommandMethod, "success")
+
+	return nil
+}
+
+// WrapKey wraps a content encryption key for a recipient public key, with ECDH-1PU (authcrypt) if the sender key,
+// created or imported with the wrap usage, is set or with ECDH-ES (anoncrypt) otherwise.
+func (o *Command) WrapKey(rw io.Writer, req io.Reader) command.Error {
+	var request WrapKeyRequest
+
+	if err := json.NewDecoder(req).Decode(&request); err != nil {
+		logutil.LogInfo(logger, CommandName, WrapKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.RecipientKey == nil {
+		logutil.LogDebug(logger, CommandName, WrapKeyCommandMethod, errEmptyRecipientKey)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyRecipientKey))
+	}
+
+	cek, err := decodeField(request.CEK, errEmptyCEK)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, WrapKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	apu, err := base64.RawURLEncoding.DecodeString(request.APU)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, WrapKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("invalid apu : %w", err))
+	}
+
+	apv, err := base64.RawURLEncoding.DecodeString(request.APV)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, WrapKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("invalid apv : %w", err))
+	}
+
+	var opts []crypto.WrapKeyOpts
+
+	if request.SenderKeyID != "" {
+		if _, err = o.checkUsage(request.SenderKeyID, WrapUsage); err != nil {
+			logutil.LogInfo(logger, CommandName, WrapKeyCommandMethod, err.Error())
+			return command.NewValidationError(KeyUsageError, err)
+		}
+
+		senderKH, errGet := o.ctx.KMS().Get(request.SenderKeyID)
+		if errGet != nil {
+			logutil.LogError(logger, CommandName, WrapKeyCommandMethod, errGet.Error())
+			return command.NewExecuteError(WrapKeyError, fmt.Errorf("failed to get sender key : %w", errGet))
+		}
+
+		opts = append(opts, crypto.WithSender(senderKH))
+	}
+
+	wrappedKey, err := o.ctx.Crypto().WrapKey(cek, apu, apv, request.RecipientKey, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, WrapKeyCommandMethod, err.Error())
+		return command.NewExecuteError(WrapKeyError, fmt.Errorf("failed to wrap key : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &WrapKeyResponse{RecipientWrappedKey: wrappedKey}, logger)
+
+	logutil.LogDebug(logger, CommandName, WrapKeyCommandMethod, "success")
+
+	return nil
+}
+
+// decodeField decodes a mandatory base64url field of a request.
+func decodeField(value, errEmpty string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf(errEmpty)
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64url value : %w", err)
+	}
+
+	return decoded, nil
+}
@@ -20,25 +20,33 @@ import (
 	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	ariesjose "github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
 )
 
 func TestNew(t *testing.T) {
 	t.Run("test new command - success", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{
+		cmd := newCommand(t, &mockprovider.Provider{
 			KMSValue: &mockkms.KeyManager{},
 		})
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 2, len(handlers))
+		require.Equal(t, 5, len(handlers))
 	})
 
 	t.Run("test new command - error from import key", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{
+		cmd := newCommand(t, &mockprovider.Provider{
 			KMSValue: &mockkms.KeyManager{ImportPrivateKeyErr: fmt.Errorf("error import priv key")},
 		})
 		require.NotNil(t, cmd)
@@ -46,11 +54,19 @@ func TestNew(t *testing.T) {
 		_, _, err := cmd.importKey("", "")
 		require.EqualError(t, err, "error import priv key")
 	})
+
+	t.Run("test new command - error opening key usage store", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: fmt.Errorf("error opening store")},
+		})
+		require.Nil(t, cmd)
+		require.EqualError(t, err, "failed to open key usage store : error opening store")
+	})
 }
 
 func TestCreateKeySet(t *testing.T) {
 	t.Run("test create key set - success", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{
+		cmd := newCommand(t, &mockprovider.Provider{
 			KMSValue: &mockkms.KeyManager{CrAndExportPubKeyID: "keyID", CrAndExportPubKeyValue: []byte("publicKey")},
 		})
 		require.NotNil(t, cmd)
@@ -75,7 +91,7 @@ func TestCreateKeySet(t *testing.T) {
 	})
 
 	t.Run("test create key set - error", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{
+		cmd := newCommand(t, &mockprovider.Provider{
 			KMSValue: &mockkms.KeyManager{CrAndExportPubKeyErr: fmt.Errorf("error create key set")},
 		})
 		require.NotNil(t, cmd)
@@ -93,7 +109,7 @@ func TestCreateKeySet(t *testing.T) {
 	})
 
 	t.Run("test create key set - error request decode", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{})
+		cmd := newCommand(t, &mockprovider.Provider{})
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
@@ -103,7 +119,7 @@ func TestCreateKeySet(t *testing.T) {
 	})
 
 	t.Run("test create key set - error key type is empty", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{})
+		cmd := newCommand(t, &mockprovider.Provider{})
 		require.NotNil(t, cmd)
 
 		reqBytes, err := json.Marshal(CreateKeySetRequest{})
@@ -116,7 +132,7 @@ func TestCreateKeySet(t *testing.T) {
 	})
 
 	t.Run("test create key set - error from export public key", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{
+		cmd := newCommand(t, &mockprovider.Provider{
 			KMSValue: &mockkms.KeyManager{
 				CrAndExportPubKeyErr: fmt.Errorf("error export public key"),
 			},
@@ -134,7 +150,7 @@ func TestCreateKeySet(t *testing.T) {
 
 func TestImportKey(t *testing.T) {
 	t.Run("test import key - success", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{})
+		cmd := newCommand(t, &mockprovider.Provider{})
 		require.NotNil(t, cmd)
 
 		cmd.importKey = func(privKey interface{}, kt kms.KeyType,
@@ -162,7 +178,7 @@ func TestImportKey(t *testing.T) {
 	})
 
 	t.Run("test import key - error", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{})
+		cmd := newCommand(t, &mockprovider.Provider{})
 		require.NotNil(t, cmd)
 
 		cmd.importKey = func(privKey interface{}, kt kms.KeyType,
@@ -191,7 +207,7 @@ func TestImportKey(t *testing.T) {
 	})
 
 	t.Run("test import key - unsupported key", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{})
+		cmd := newCommand(t, &mockprovider.Provider{})
 		require.NotNil(t, cmd)
 
 		cmd.importKey = func(privKey interface{}, kt kms.KeyType,
@@ -220,7 +236,7 @@ func TestImportKey(t *testing.T) {
 	})
 
 	t.Run("test import key - jwk without keyID", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{})
+		cmd := newCommand(t, &mockprovider.Provider{})
 		require.NotNil(t, cmd)
 
 		cmd.importKey = func(privKey interface{}, kt kms.KeyType,
@@ -248,7 +264,7 @@ func TestImportKey(t *testing.T) {
 	})
 
 	t.Run("test import key - error request decode", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{})
+		cmd := newCommand(t, &mockprovider.Provider{})
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
@@ -257,3 +273,374 @@ func TestImportKey(t *testing.T) {
 		require.Contains(t, err.Error(), "failed request decode")
 	})
 }
+
+func TestKeyUsagePolicy(t *testing.T) {
+	t.Run("test create key set - usages", func(t *testing.T) {
+		cmd := newCommand(t, &mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{CrAndExportPubKeyID: "keyID", CrAndExportPubKeyValue: []byte("publicKey")},
+		})
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreateKeySet(&b, bytes.NewBufferString(`{"keyType":"ED25519","usages":["verify"]}`))
+		require.NoError(t, cmdErr)
+
+		policy, err := cmd.checkUsage("keyID", VerifyUsage)
+		require.NoError(t, err)
+		require.Equal(t, kms.ED25519Type, policy.KeyType)
+
+		_, err = cmd.checkUsage("keyID", SignUsage)
+		require.EqualError(t, err, "key keyID is not allowed to sign")
+
+		_, err = cmd.checkUsage("otherKeyID", SignUsage)
+		require.EqualError(t, err, "key otherKeyID was not created or imported through the kms command")
+	})
+
+	t.Run("test create key set - unsupported usage", func(t *testing.T) {
+		cmd := newCommand(t, &mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreateKeySet(&b, bytes.NewBufferString(`{"keyType":"ED25519","usages":["decrypt"]}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.EqualError(t, cmdErr, "unsupported key usage decrypt")
+	})
+
+	t.Run("test import key - usages from the jwk use", func(t *testing.T) {
+		cmd := newCommand(t, &mockprovider.Provider{})
+		cmd.importKey = func(privKey interface{}, kt kms.KeyType,
+			opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+			return "", nil, nil
+		}
+
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		for _, use := range []string{"enc", "unknown"} {
+			jwkBytes, err := json.Marshal(&ariesjose.JWK{
+				JSONWebKey: jose.JSONWebKey{Key: privateKey, KeyID: "kid-" + use, Algorithm: "EdDSA", Use: use},
+			})
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			cmdErr := cmd.ImportKey(&b, bytes.NewBuffer(jwkBytes))
+
+			if use == "unknown" {
+				require.EqualError(t, cmdErr, "unsupported key use unknown")
+				continue
+			}
+
+			require.NoError(t, cmdErr)
+		}
+
+		_, err = cmd.checkUsage("kid-enc", WrapUsage)
+		require.NoError(t, err)
+
+		_, err = cmd.checkUsage("kid-enc", SignUsage)
+		require.EqualError(t, err, "key kid-enc is not allowed to sign")
+	})
+
+	t.Run("test create key set - error saving policy", func(t *testing.T) {
+		storeProvider := mockstorage.NewMockStoreProvider()
+		storeProvider.Store.ErrPut = fmt.Errorf("put error")
+
+		cmd := newCommand(t, &mockprovider.Provider{
+			KMSValue:             &mockkms.KeyManager{CrAndExportPubKeyID: "keyID"},
+			StorageProviderValue: storeProvider,
+		})
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreateKeySet(&b, bytes.NewBufferString(`{"keyType":"ED25519"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreateKeySetError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "failed to save key policy : put error")
+	})
+}
+
+func TestSignVerify(t *testing.T) {
+	storeProvider := mem.NewProvider()
+
+	localKMS, err := localkms.New("local-lock://test/key-uri/", mockkms.NewProviderForKMS(storeProvider, &noop.NoLock{}))
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	cmd := newCommand(t, &mockprovider.Provider{
+		KMSValue:             localKMS,
+		CryptoValue:          tinkCrypto,
+		StorageProviderValue: storeProvider,
+	})
+
+	createKeySet := func(t *testing.T, usages ...string) string {
+		reqBytes, err := json.Marshal(&CreateKeySetRequest{KeyType: string(kms.ED25519Type), Usages: usages})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.CreateKeySet(&b, bytes.NewBuffer(reqBytes)))
+
+		response := CreateKeySetResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		return response.KeyID
+	}
+
+	message := base64.RawURLEncoding.EncodeToString([]byte("message"))
+
+	t.Run("test sign and verify - success", func(t *testing.T) {
+		keyID := createKeySet(t)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Sign(&b, bytes.NewBufferString(`{"keyID":"`+keyID+`","message":"`+message+`"}`))
+		require.NoError(t, cmdErr)
+
+		response := SignResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.NotEmpty(t, response.Signature)
+
+		reqBytes, err := json.Marshal(&VerifyRequest{KeyID: keyID, Message: message, Signature: response.Signature})
+		require.NoError(t, err)
+
+		b.Reset()
+		require.NoError(t, cmd.Verify(&b, bytes.NewBuffer(reqBytes)))
+
+		reqBytes, err = json.Marshal(&VerifyRequest{
+			KeyID:     keyID,
+			Message:   base64.RawURLEncoding.EncodeToString([]byte("other message")),
+			Signature: response.Signature,
+		})
+		require.NoError(t, err)
+
+		cmdErr = cmd.Verify(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyError, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("test sign - key without sign usage", func(t *testing.T) {
+		keyID := createKeySet(t, string(VerifyUsage))
+
+		var b bytes.Buffer
+		cmdErr := cmd.Sign(&b, bytes.NewBufferString(`{"keyID":"`+keyID+`","message":"`+message+`"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, KeyUsageError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "is not allowed to sign")
+	})
+
+	t.Run("test sign - key not created through the command", func(t *testing.T) {
+		keyID, _, err := localKMS.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Sign(&b, bytes.NewBufferString(`{"keyID":"`+keyID+`","message":"`+message+`"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, KeyUsageError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "was not created or imported through the kms command")
+	})
+}
+
+func TestSign(t *testing.T) {
+	t.Run("test sign - invalid request", func(t *testing.T) {
+		cmd := newCommand(t, &mockprovider.Provider{})
+
+		tests := []struct {
+			name string
+			req  string
+			err  string
+		}{
+			{name: "decode", req: "{", err: "failed request decode"},
+			{name: "empty key id", req: `{"message":"bWVzc2FnZQ"}`, err: errEmptyKeyID},
+			{name: "empty message", req: `{"keyID":"keyID"}`, err: errEmptyMessage},
+			{name: "invalid message", req: `{"keyID":"keyID","message":"!"}`, err: "invalid base64url value"},
+		}
+
+		for _, tc := range tests {
+			var b bytes.Buffer
+			cmdErr := cmd.Sign(&b, bytes.NewBufferString(tc.req))
+			require.Error(t, cmdErr, tc.name)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), tc.name)
+			require.Contains(t, cmdErr.Error(), tc.err, tc.name)
+		}
+	})
+
+	t.Run("test sign - errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			kms    *mockkms.KeyManager
+			crypto *mockcrypto.Crypto
+			err    string
+		}{
+			{
+				name: "get key", kms: &mockkms.KeyManager{GetKeyErr: fmt.Errorf("get error")},
+				crypto: &mockcrypto.Crypto{}, err: "failed to get key : get error",
+			},
+			{
+				name: "sign", kms: &mockkms.KeyManager{},
+				crypto: &mockcrypto.Crypto{SignErr: fmt.Errorf("sign error")}, err: "failed to sign message : sign error",
+			},
+		}
+
+		for _, tc := range tests {
+			cmd := newCommand(t, &mockprovider.Provider{KMSValue: tc.kms, CryptoValue: tc.crypto})
+			require.NoError(t, cmd.savePolicy("keyID", kms.ED25519Type, allUsages))
+
+			var b bytes.Buffer
+			cmdErr := cmd.Sign(&b, bytes.NewBufferString(`{"keyID":"keyID","message":"bWVzc2FnZQ"}`))
+			require.Error(t, cmdErr, tc.name)
+			require.Equal(t, SignError, cmdErr.Code(), tc.name)
+			require.EqualError(t, cmdErr, tc.err, tc.name)
+		}
+	})
+}
+
+func TestVerify(t *testing.T) {
+	const req = `{"keyID":"keyID","message":"bWVzc2FnZQ","signature":"c2lnbmF0dXJl"}`
+
+	t.Run("test verify - invalid request", func(t *testing.T) {
+		cmd := newCommand(t, &mockprovider.Provider{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Verify(&b, bytes.NewBufferString(`{"keyID":"keyID","message":"bWVzc2FnZQ"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.EqualError(t, cmdErr, errEmptySignature)
+	})
+
+	t.Run("test verify - errors", func(t *testing.T) {
+		tests := []struct {
+			name string
+			kms  *mockkms.KeyManager
+			err  string
+		}{
+			{
+				name: "export public key", kms: &mockkms.KeyManager{ExportPubKeyBytesErr: fmt.Errorf("export error")},
+				err: "failed to export public key : export error",
+			},
+			{
+				name: "public key handle", kms: &mockkms.KeyManager{PubKeyBytesToHandleErr: fmt.Errorf("handle error")},
+				err: "failed to get public key handle : handle error",
+			},
+		}
+
+		for _, tc := range tests {
+			cmd := newCommand(t, &mockprovider.Provider{KMSValue: tc.kms, CryptoValue: &mockcrypto.Crypto{}})
+			require.NoError(t, cmd.savePolicy("keyID", kms.ED25519Type, allUsages))
+
+			var b bytes.Buffer
+			cmdErr := cmd.Verify(&b, bytes.NewBufferString(req))
+			require.Error(t, cmdErr, tc.name)
+			require.Equal(t, VerifyError, cmdErr.Code(), tc.name)
+			require.EqualError(t, cmdErr, tc.err, tc.name)
+		}
+	})
+}
+
+func TestWrapKey(t *testing.T) {
+	wrappedKey := &crypto.RecipientWrappedKey{KID: "recipient", EncryptedCEK: []byte("encrypted cek"), Alg: "ECDH-1PU+A256KW"}
+
+	newRequest := func(t *testing.T, senderKeyID string) *bytes.Buffer {
+		reqBytes, err := json.Marshal(&WrapKeyRequest{
+			CEK:          base64.RawURLEncoding.EncodeToString([]byte("cek")),
+			APU:          base64.RawURLEncoding.EncodeToString([]byte("sender")),
+			RecipientKey: &crypto.PublicKey{KID: "recipient", Curve: "P-256", Type: "EC"},
+			SenderKeyID:  senderKeyID,
+		})
+		require.NoError(t, err)
+
+		return bytes.NewBuffer(reqBytes)
+	}
+
+	t.Run("test wrap key - success", func(t *testing.T) {
+		cmd := newCommand(t, &mockprovider.Provider{
+			KMSValue:    &mockkms.KeyManager{},
+			CryptoValue: &mockcrypto.Crypto{WrapValue: wrappedKey},
+		})
+		require.NoError(t, cmd.savePolicy("sender", kms.ECDH256KWAES256GCMType, []KeyUsage{WrapUsage}))
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.WrapKey(&b, newRequest(t, "sender")))
+
+		response := WrapKeyResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, wrappedKey, response.RecipientWrappedKey)
+	})
+
+	t.Run("test wrap key - sender key without wrap usage", func(t *testing.T) {
+		cmd := newCommand(t, &mockprovider.Provider{
+			KMSValue:    &mockkms.KeyManager{},
+			CryptoValue: &mockcrypto.Crypto{WrapValue: wrappedKey},
+		})
+		require.NoError(t, cmd.savePolicy("sender", kms.ED25519Type, []KeyUsage{SignUsage}))
+
+		var b bytes.Buffer
+		cmdErr := cmd.WrapKey(&b, newRequest(t, "sender"))
+		require.Error(t, cmdErr)
+		require.Equal(t, KeyUsageError, cmdErr.Code())
+		require.EqualError(t, cmdErr, "key sender is not allowed to wrap")
+	})
+
+	t.Run("test wrap key - invalid request", func(t *testing.T) {
+		cmd := newCommand(t, &mockprovider.Provider{})
+
+		tests := []struct {
+			name string
+			req  string
+			err  string
+		}{
+			{name: "decode", req: "{", err: "failed request decode"},
+			{name: "empty recipient key", req: `{"cek":"Y2Vr"}`, err: errEmptyRecipientKey},
+			{name: "empty cek", req: `{"recipientKey":{"kid":"recipient"}}`, err: errEmptyCEK},
+			{name: "invalid apu", req: `{"cek":"Y2Vr","apu":"!","recipientKey":{}}`, err: "invalid apu"},
+			{name: "invalid apv", req: `{"cek":"Y2Vr","apv":"!","recipientKey":{}}`, err: "invalid apv"},
+		}
+
+		for _, tc := range tests {
+			var b bytes.Buffer
+			cmdErr := cmd.WrapKey(&b, bytes.NewBufferString(tc.req))
+			require.Error(t, cmdErr, tc.name)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), tc.name)
+			require.Contains(t, cmdErr.Error(), tc.err, tc.name)
+		}
+	})
+
+	t.Run("test wrap key - errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			kms    *mockkms.KeyManager
+			crypto *mockcrypto.Crypto
+			err    string
+		}{
+			{
+				name: "get sender key", kms: &mockkms.KeyManager{GetKeyErr: fmt.Errorf("get error")},
+				crypto: &mockcrypto.Crypto{}, err: "failed to get sender key : get error",
+			},
+			{
+				name: "wrap", kms: &mockkms.KeyManager{},
+				crypto: &mockcrypto.Crypto{WrapError: fmt.Errorf("wrap error")}, err: "failed to wrap key : wrap error",
+			},
+		}
+
+		for _, tc := range tests {
+			cmd := newCommand(t, &mockprovider.Provider{KMSValue: tc.kms, CryptoValue: tc.crypto})
+			require.NoError(t, cmd.savePolicy("sender", kms.ECDH256KWAES256GCMType, allUsages))
+
+			var b bytes.Buffer
+			cmdErr := cmd.WrapKey(&b, newRequest(t, "sender"))
+			require.Error(t, cmdErr, tc.name)
+			require.Equal(t, WrapKeyError, cmdErr.Code(), tc.name)
+			require.EqualError(t, cmdErr, tc.err, tc.name)
+		}
+	})
+}
+
+func newCommand(t *testing.T, p *mockprovider.Provider) *Command {
+	t.Helper()
+
+	if p.StorageProviderValue == nil {
+		p.StorageProviderValue = mem.NewProvider()
+	}
+
+	cmd, err := New(p)
+	require.NoError(t, err)
+
+	return cmd
+}
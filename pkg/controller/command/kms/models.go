@@ -6,9 +6,15 @@ SPDX-License-Identifier: Apache-2.0
 
 package kms
 
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+)
+
 // CreateKeySetRequest is model for createKeySey request.
 type CreateKeySetRequest struct {
 	KeyType string `json:"keyType,omitempty"`
+	// usages of the key: "sign", "verify" and "wrap", all of them if not set
+	Usages []string `json:"usages,omitempty"`
 }
 
 // CreateKeySetResponse for returning key pair.
@@ -30,3 +36,46 @@ type JSONWebKey struct {
 	Y   string `json:"y,omitempty"`
 	D   string `json:"d,omitempty"`
 }
+
+// SignRequest is model for sign request.
+type SignRequest struct {
+	// id of the key created or imported with the sign usage
+	KeyID string `json:"keyID,omitempty"`
+	// message base64url encoded
+	Message string `json:"message,omitempty"`
+}
+
+// SignResponse is model for sign response.
+type SignResponse struct {
+	// signature base64url encoded
+	Signature string `json:"signature,omitempty"`
+}
+
+// VerifyRequest is model for verify request.
+type VerifyRequest struct {
+	// id of the key created or imported with the verify usage
+	KeyID string `json:"keyID,omitempty"`
+	// message base64url encoded
+	Message string `json:"message,omitempty"`
+	// signature base64url encoded
+	Signature string `json:"signature,omitempty"`
+}
+
+// WrapKeyRequest is model for wrap key request.
+type WrapKeyRequest struct {
+	// content encryption key base64url encoded
+	CEK string `json:"cek,omitempty"`
+	// agreement PartyUInfo base64url encoded
+	APU string `json:"apu,omitempty"`
+	// agreement PartyVInfo base64url encoded
+	APV string `json:"apv,omitempty"`
+	// public key of the recipient
+	RecipientKey *crypto.PublicKey `json:"recipientKey,omitempty"`
+	// id of the sender key created or imported with the wrap usage, for ECDH-1PU key wrapping
+	SenderKeyID string `json:"senderKeyID,omitempty"`
+}
+
+// WrapKeyResponse is model for wrap key response.
+type WrapKeyResponse struct {
+	*crypto.RecipientWrappedKey
+}
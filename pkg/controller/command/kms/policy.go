@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// KeyUsageStoreName is the name of the store of the key usage policies.
+const KeyUsageStoreName = "kms_key_usage"
+
+// KeyUsage is an operation a key created or imported through the kms command can be used for.
+type KeyUsage string
+
+// key usages.
+const (
+	// SignUsage allows signing messages with the key.
+	SignUsage KeyUsage = "sign"
+	// VerifyUsage allows verifying signatures with the key.
+	VerifyUsage KeyUsage = "verify"
+	// WrapUsage allows wrapping content encryption keys with the key as sender key.
+	WrapUsage KeyUsage = "wrap"
+)
+
+// allUsages are the usages of the keys without explicit usages.
+// nolint:gochecknoglobals
+var allUsages = []KeyUsage{SignUsage, VerifyUsage, WrapUsage}
+
+// keyPolicy is the record of a key created or imported through the kms command. The keys without record, like the
+// keys of the agent DIDs, can't be used by the sign, verify and wrap commands.
+type keyPolicy struct {
+	KeyType kms.KeyType `json:"keyType"`
+	Usages  []KeyUsage  `json:"usages"`
+}
+
+func (p *keyPolicy) allows(usage KeyUsage) bool {
+	for _, u := range p.Usages {
+		if u == usage {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseUsages validates the requested usages, all the usages if none is requested.
+func parseUsages(usages []string) ([]KeyUsage, error) {
+	if len(usages) == 0 {
+		return allUsages, nil
+	}
+
+	parsed := make([]KeyUsage, 0, len(usages))
+
+	for _, u := range usages {
+		switch KeyUsage(u) {
+		case SignUsage, VerifyUsage, WrapUsage:
+			parsed = append(parsed, KeyUsage(u))
+		default:
+			return nil, fmt.Errorf("unsupported key usage %s", u)
+		}
+	}
+
+	return parsed, nil
+}
+
+// jwkUsages returns the usages of the key with the given JWK "use" parameter.
+func jwkUsages(use string) ([]KeyUsage, error) {
+	switch use {
+	case "":
+		return allUsages, nil
+	case "sig":
+		return []KeyUsage{SignUsage, VerifyUsage}, nil
+	case "enc":
+		return []KeyUsage{WrapUsage}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key use %s", use)
+	}
+}
+
+func (o *Command) savePolicy(keyID string, kt kms.KeyType, usages []KeyUsage) error {
+	policyBytes, err := json.Marshal(&keyPolicy{KeyType: kt, Usages: usages})
+	if err != nil {
+		return fmt.Errorf("failed to marshal key policy : %w", err)
+	}
+
+	if err := o.policyStore.Put(keyID, policyBytes); err != nil {
+		return fmt.Errorf("failed to save key policy : %w", err)
+	}
+
+	return nil
+}
+
+// checkUsage returns the policy of the key, or an error if the key can't be used for the given usage.
+func (o *Command) checkUsage(keyID string, usage KeyUsage) (*keyPolicy, error) {
+	policyBytes, err := o.policyStore.Get(keyID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("key %s was not created or imported through the kms command", keyID)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key policy : %w", err)
+	}
+
+	policy := &keyPolicy{}
+
+	if err := json.Unmarshal(policyBytes, policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key policy : %w", err)
+	}
+
+	if !policy.allows(usage) {
+		return nil, fmt.Errorf("key %s is not allowed to %s", keyID, usage)
+	}
+
+	return policy, nil
+}
@@ -166,6 +166,7 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 		outofband.WithLabel(args.Label),
 		outofband.WithServices(args.Service...),
 		outofband.WithRouterConnections(args.RouterConnectionID),
+		outofband.WithAttachments(args.Attachments...),
 	}...)
 	if err != nil {
 		logutil.LogError(logger, CommandName, CreateInvitation, err.Error())
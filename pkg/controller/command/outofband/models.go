@@ -45,6 +45,9 @@ type CreateInvitationArgs struct {
 	Service            []interface{} `json:"service"`
 	Protocols          []string      `json:"protocols"`
 	RouterConnectionID string        `json:"router_connection_id"`
+
+	// Attachments are the requests (e.g a request-presentation) to be processed by the invitee once connected.
+	Attachments []*decorator.Attachment `json:"attachments"`
 }
 
 // CreateInvitationResponse model
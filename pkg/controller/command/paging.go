@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EncodeCursor returns the opaque cursor of the page of records starting at offset.
+func EncodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// DecodeCursor returns the offset of the page of records of the cursor returned by EncodeCursor.
+func DecodeCursor(cursor string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor %s", cursor)
+	}
+
+	return offset, nil
+}
+
+// PageOffset returns the offset of the page of records, given by the cursor when it is set.
+func PageOffset(offset int, cursor string) (int, error) {
+	if cursor == "" {
+		return offset, nil
+	}
+
+	return DecodeCursor(cursor)
+}
+
+// NextCursor returns the cursor of the page following the page of count records at offset, or an empty string when
+// the page is the last one of the total records.
+func NextCursor(offset, count, total int) string {
+	if offset < 0 {
+		offset = 0
+	}
+
+	if count == 0 || offset+count >= total {
+		return ""
+	}
+
+	return EncodeCursor(offset + count)
+}
+
+// PageBounds returns the bounds of the page of records at offset of at most limit records, out of total records.
+// A limit of zero (or less) includes all the records following the offset.
+func PageBounds(offset, limit, total int) (start, end int) {
+	switch {
+	case offset < 0:
+		offset = 0
+	case offset > total:
+		offset = total
+	}
+
+	end = total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+
+	return offset, end
+}
+
+// SortFunc returns the value of the field of the i-th record to sort by.
+type SortFunc func(i int) string
+
+// Sort sorts the n records in the order given by the sort parameter: the name of the field to sort by, prefixed with
+// "-" for the descending order. The fields map the names of the fields that can be sorted by to their values.
+func Sort(n int, swap func(i, j int), sortParam string, fields map[string]SortFunc) error {
+	if sortParam == "" {
+		return nil
+	}
+
+	desc := strings.HasPrefix(sortParam, "-")
+
+	value, ok := fields[strings.TrimPrefix(sortParam, "-")]
+	if !ok {
+		return fmt.Errorf("cannot sort by %s", strings.TrimPrefix(sortParam, "-"))
+	}
+
+	sort.Stable(&sorter{n: n, swap: swap, value: value, desc: desc})
+
+	return nil
+}
+
+type sorter struct {
+	n     int
+	swap  func(i, j int)
+	value SortFunc
+	desc  bool
+}
+
+func (s *sorter) Len() int {
+	return s.n
+}
+
+func (s *sorter) Swap(i, j int) {
+	s.swap(i, j)
+}
+
+func (s *sorter) Less(i, j int) bool {
+	if s.desc {
+		return s.value(j) < s.value(i)
+	}
+
+	return s.value(i) < s.value(j)
+}
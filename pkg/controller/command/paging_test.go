@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	offset, err := DecodeCursor(EncodeCursor(42))
+	require.NoError(t, err)
+	require.Equal(t, 42, offset)
+
+	_, err = DecodeCursor("!")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid cursor")
+
+	_, err = DecodeCursor(EncodeCursor(-1))
+	require.Error(t, err)
+
+	offset, err = PageOffset(5, "")
+	require.NoError(t, err)
+	require.Equal(t, 5, offset)
+
+	offset, err = PageOffset(5, EncodeCursor(10))
+	require.NoError(t, err)
+	require.Equal(t, 10, offset)
+}
+
+func TestNextCursor(t *testing.T) {
+	require.Equal(t, EncodeCursor(4), NextCursor(2, 2, 5))
+	require.Equal(t, EncodeCursor(2), NextCursor(-1, 2, 5))
+	require.Empty(t, NextCursor(4, 1, 5))
+	require.Empty(t, NextCursor(5, 0, 5))
+}
+
+func TestPageBounds(t *testing.T) {
+	for _, tc := range []struct {
+		offset, limit, total, start, end int
+	}{
+		{0, 2, 5, 0, 2},
+		{4, 2, 5, 4, 5},
+		{6, 2, 5, 5, 5},
+		{-1, 0, 5, 0, 5},
+		{1, 0, 5, 1, 5},
+	} {
+		start, end := PageBounds(tc.offset, tc.limit, tc.total)
+		require.Equal(t, tc.start, start)
+		require.Equal(t, tc.end, end)
+	}
+}
+
+func TestSort(t *testing.T) {
+	values := []string{"b", "c", "a"}
+	swap := func(i, j int) { values[i], values[j] = values[j], values[i] }
+	fields := map[string]SortFunc{"value": func(i int) string { return values[i] }}
+
+	require.NoError(t, Sort(len(values), swap, "value", fields))
+	require.Equal(t, []string{"a", "b", "c"}, values)
+
+	require.NoError(t, Sort(len(values), swap, "-value", fields))
+	require.Equal(t, []string{"c", "b", "a"}, values)
+
+	require.NoError(t, Sort(len(values), swap, "", fields))
+	require.Equal(t, []string{"c", "b", "a"}, values)
+
+	err := Sort(len(values), swap, "-name", fields)
+	require.EqualError(t, err, "cannot sort by name")
+}
@@ -0,0 +1,686 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcwallet
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/wallet"
+)
+
+var logger = log.New("aries-framework/command/vcwallet")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.VCWallet)
+
+	// CreateProfileErrorCode for create wallet profile error.
+	CreateProfileErrorCode
+
+	// UnlockWalletErrorCode for unlock wallet error.
+	UnlockWalletErrorCode
+
+	// LockWalletErrorCode for lock wallet error.
+	LockWalletErrorCode
+
+	// AddContentErrorCode for add wallet content error.
+	AddContentErrorCode
+
+	// RemoveContentErrorCode for remove wallet content error.
+	RemoveContentErrorCode
+
+	// GetContentErrorCode for get wallet content error.
+	GetContentErrorCode
+
+	// GetAllContentErrorCode for get all wallet contents error.
+	GetAllContentErrorCode
+
+	// ExportWalletErrorCode for export wallet error.
+	ExportWalletErrorCode
+
+	// ImportWalletErrorCode for import wallet error.
+	ImportWalletErrorCode
+
+	// QueryErrorCode for query wallet credentials error.
+	QueryErrorCode
+
+	// PresentProofErrorCode for present proof from wallet error.
+	PresentProofErrorCode
+
+	// RemoveProfileErrorCode for remove wallet profile error.
+	RemoveProfileErrorCode
+
+	// CreateKeyPairErrorCode for create wallet key pair error.
+	CreateKeyPairErrorCode
+
+	// RefreshTokenErrorCode for refresh wallet auth token error.
+	RefreshTokenErrorCode
+
+	// DeriveErrorCode for derive wallet credential error.
+	DeriveErrorCode
+
+	// AuditLogErrorCode for review wallet audit log error.
+	AuditLogErrorCode
+)
+
+// constants for the wallet controller's methods.
+const (
+	// command name.
+	CommandName = "vcwallet"
+
+	// command methods.
+	CreateProfileMethod = "CreateProfile"
+	UnlockWalletMethod  = "Unlock"
+	LockWalletMethod    = "Lock"
+	AddContentMethod    = "Add"
+	RemoveContentMethod = "Remove"
+	GetContentMethod    = "Get"
+	GetAllContentMethod = "GetAll"
+	ExportWalletMethod  = "Export"
+	ImportWalletMethod  = "Import"
+	QueryMethod         = "Query"
+	PresentProofMethod  = "PresentProof"
+	RemoveProfileMethod = "RemoveProfile"
+	CreateKeyPairMethod = "CreateKeyPair"
+	RefreshTokenMethod  = "RefreshToken"
+	DeriveMethod        = "Derive"
+	AuditLogMethod      = "AuditLog"
+
+	// error messages.
+	errEmptyUserID       = "user ID is mandatory"
+	errEmptyPIID         = "protocol instance ID is mandatory"
+	errEmptyKeyType      = "key type is mandatory"
+	errEmptyCredentialID = "credential ID is mandatory"
+
+	// log constants.
+	logUserIDKey = "userID"
+	logPIIDKey   = "piID"
+)
+
+// provider contains dependencies for the wallet command and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+	Service(id string) (interface{}, error)
+}
+
+// Command contains operations provided by the verifiable credential wallet controller.
+type Command struct {
+	ctx provider
+	// wallets are the wallets of the users, keeping their sessions
+	wallets map[string]*wallet.Wallet
+	lock    sync.Mutex
+}
+
+// New returns new verifiable credential wallet controller command instance.
+func New(p provider) *Command {
+	return &Command{ctx: p, wallets: make(map[string]*wallet.Wallet)}
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (o *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, CreateProfileMethod, o.CreateProfile),
+		cmdutil.NewCommandHandler(CommandName, RemoveProfileMethod, o.RemoveProfile),
+		cmdutil.NewCommandHandler(CommandName, UnlockWalletMethod, o.Unlock),
+		cmdutil.NewCommandHandler(CommandName, LockWalletMethod, o.Lock),
+		cmdutil.NewCommandHandler(CommandName, RefreshTokenMethod, o.RefreshToken),
+		cmdutil.NewCommandHandler(CommandName, AddContentMethod, o.Add),
+		cmdutil.NewCommandHandler(CommandName, RemoveContentMethod, o.Remove),
+		cmdutil.NewCommandHandler(CommandName, GetContentMethod, o.Get),
+		cmdutil.NewCommandHandler(CommandName, GetAllContentMethod, o.GetAll),
+		cmdutil.NewCommandHandler(CommandName, ExportWalletMethod, o.Export),
+		cmdutil.NewCommandHandler(CommandName, ImportWalletMethod, o.Import),
+		cmdutil.NewCommandHandler(CommandName, QueryMethod, o.Query),
+		cmdutil.NewCommandHandler(CommandName, DeriveMethod, o.Derive),
+		cmdutil.NewCommandHandler(CommandName, PresentProofMethod, o.PresentProof),
+		cmdutil.NewCommandHandler(CommandName, AuditLogMethod, o.AuditLog),
+		cmdutil.NewCommandHandler(CommandName, CreateKeyPairMethod, o.CreateKeyPair),
+	}
+}
+
+// CreateProfile creates the wallet profile of a user, kept in an EDV vault if one is given.
+func (o *Command) CreateProfile(rw io.Writer, req io.Reader) command.Error {
+	request := &CreateProfileRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, CreateProfileMethod); err != nil {
+		return err
+	}
+
+	var options []wallet.ProfileOpt
+
+	if request.EDVServerURL != "" || request.EDVVaultID != "" {
+		options = append(options, wallet.WithEDVStorage(request.EDVServerURL, request.EDVVaultID))
+	}
+
+	if err := wallet.CreateProfile(request.UserID, request.Passphrase, o.ctx, options...); err != nil {
+		return logWalletError(CreateProfileMethod, CreateProfileErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, CreateProfileMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// RemoveProfile removes the wallet profile of a user, with all the contents and keys of the wallet.
+func (o *Command) RemoveProfile(rw io.Writer, req io.Reader) command.Error {
+	request := &RemoveProfileRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, RemoveProfileMethod); err != nil {
+		return err
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if err := wallet.RemoveProfile(request.UserID, request.Passphrase, o.ctx); err != nil {
+		return logWalletError(RemoveProfileMethod, RemoveProfileErrorCode, request.UserID, err)
+	}
+
+	if w, ok := o.wallets[request.UserID]; ok {
+		w.Close()
+		delete(o.wallets, request.UserID)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RemoveProfileMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Unlock unlocks the wallet of a user, returning the auth token of the wallet session.
+func (o *Command) Unlock(rw io.Writer, req io.Reader) command.Error {
+	request := &UnlockWalletRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, UnlockWalletMethod); err != nil {
+		return err
+	}
+
+	if request.IdleTimeout < 0 {
+		logutil.LogDebug(logger, CommandName, UnlockWalletMethod, "invalid idle timeout")
+		return command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf("invalid idle timeout %d", request.IdleTimeout))
+	}
+
+	var options []wallet.UnlockOpt
+
+	if request.IdleTimeout > 0 {
+		options = append(options, wallet.WithIdleTimeout(time.Duration(request.IdleTimeout)*time.Second))
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(UnlockWalletMethod, UnlockWalletErrorCode, request.UserID, err)
+	}
+
+	token, err := w.Open(request.Passphrase, options...)
+	if err != nil {
+		return logWalletError(UnlockWalletMethod, UnlockWalletErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, &UnlockWalletResponse{Token: token}, logger)
+
+	logutil.LogDebug(logger, CommandName, UnlockWalletMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Lock locks the wallet of a user, expiring the auth token of its session.
+func (o *Command) Lock(rw io.Writer, req io.Reader) command.Error {
+	request := &LockWalletRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, LockWalletMethod); err != nil {
+		return err
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(LockWalletMethod, LockWalletErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, &LockWalletResponse{Closed: w.Close()}, logger)
+
+	logutil.LogDebug(logger, CommandName, LockWalletMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// RefreshToken replaces the auth token of the wallet session of a user with a new one.
+func (o *Command) RefreshToken(rw io.Writer, req io.Reader) command.Error {
+	request := &RefreshTokenRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, RefreshTokenMethod); err != nil {
+		return err
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(RefreshTokenMethod, RefreshTokenErrorCode, request.UserID, err)
+	}
+
+	token, err := w.Refresh(request.Auth)
+	if err != nil {
+		return logWalletError(RefreshTokenMethod, RefreshTokenErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, &RefreshTokenResponse{Token: token}, logger)
+
+	logutil.LogDebug(logger, CommandName, RefreshTokenMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Add adds a content to the wallet of a user.
+func (o *Command) Add(rw io.Writer, req io.Reader) command.Error {
+	request := &AddContentRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, AddContentMethod); err != nil {
+		return err
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err == nil {
+		err = w.Add(request.Auth, request.ContentType, request.Content,
+			wallet.AddToCollection(request.CollectionID), wallet.WithTags(request.Tags...))
+	}
+
+	if err != nil {
+		return logWalletError(AddContentMethod, AddContentErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, AddContentMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Remove removes a content from the wallet of a user.
+func (o *Command) Remove(rw io.Writer, req io.Reader) command.Error {
+	request := &RemoveContentRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, RemoveContentMethod); err != nil {
+		return err
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err == nil {
+		err = w.Remove(request.Auth, request.ContentType, request.ContentID)
+	}
+
+	if err != nil {
+		return logWalletError(RemoveContentMethod, RemoveContentErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RemoveContentMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Get returns a content of the wallet of a user.
+func (o *Command) Get(rw io.Writer, req io.Reader) command.Error {
+	request := &GetContentRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, GetContentMethod); err != nil {
+		return err
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(GetContentMethod, GetContentErrorCode, request.UserID, err)
+	}
+
+	content, err := w.Get(request.Auth, request.ContentType, request.ContentID)
+	if err != nil {
+		return logWalletError(GetContentMethod, GetContentErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, &GetContentResponse{Content: content}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetContentMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// GetAll returns the contents of a type of the wallet of a user, optionally filtered by collection and tags.
+func (o *Command) GetAll(rw io.Writer, req io.Reader) command.Error {
+	request := &GetAllContentRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, GetAllContentMethod); err != nil {
+		return err
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(GetAllContentMethod, GetAllContentErrorCode, request.UserID, err)
+	}
+
+	contents, err := w.GetAll(request.Auth, request.ContentType,
+		wallet.FilterByCollection(request.CollectionID), wallet.FilterByTags(request.Tags...))
+	if err != nil {
+		return logWalletError(GetAllContentMethod, GetAllContentErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, &GetAllContentResponse{Contents: contents}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetAllContentMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Export exports the contents of the wallet of a user into an archive protected with a passphrase.
+func (o *Command) Export(rw io.Writer, req io.Reader) command.Error {
+	request := &ExportWalletRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, ExportWalletMethod); err != nil {
+		return err
+	}
+
+	var archive bytes.Buffer
+
+	w, err := o.wallet(request.UserID)
+	if err == nil {
+		err = w.Export(request.Auth, &archive, request.Passphrase)
+	}
+
+	if err != nil {
+		return logWalletError(ExportWalletMethod, ExportWalletErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportWalletResponse{Archive: bytes.TrimSpace(archive.Bytes())}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportWalletMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Import imports the contents of an archive exported from a wallet into the wallet of a user.
+func (o *Command) Import(rw io.Writer, req io.Reader) command.Error {
+	request := &ImportWalletRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, ImportWalletMethod); err != nil {
+		return err
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err == nil {
+		err = w.Import(request.Auth, bytes.NewReader(request.Archive), request.Passphrase)
+	}
+
+	if err != nil {
+		return logWalletError(ImportWalletMethod, ImportWalletErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, ImportWalletMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Query runs the queries of a verifiable presentation request over the credentials of the wallet of a user, and
+// returns the presentations answering them.
+func (o *Command) Query(rw io.Writer, req io.Reader) command.Error {
+	request := &QueryRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, QueryMethod); err != nil {
+		return err
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(QueryMethod, QueryErrorCode, request.UserID, err)
+	}
+
+	presentations, err := w.Query(request.Auth, request.Query, wallet.WithDisclosureNonce([]byte(request.Challenge)),
+		wallet.WithQueryRecipient(request.Recipient))
+	if err != nil {
+		return logWalletError(QueryMethod, QueryErrorCode, request.UserID, err)
+	}
+
+	response := &QueryResponse{Results: make([]json.RawMessage, len(presentations))}
+
+	for i, vp := range presentations {
+		response.Results[i], err = vp.MarshalJSON()
+		if err != nil {
+			return logWalletError(QueryMethod, QueryErrorCode, request.UserID,
+				fmt.Errorf("failed to marshal presentation : %w", err))
+		}
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, QueryMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Derive derives the BBS+ selective disclosure of a credential of the wallet of a user, revealing only the fields of
+// the frame.
+func (o *Command) Derive(rw io.Writer, req io.Reader) command.Error {
+	request := &DeriveRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, DeriveMethod); err != nil {
+		return err
+	}
+
+	if request.CredentialID == "" {
+		logutil.LogDebug(logger, CommandName, DeriveMethod, errEmptyCredentialID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyCredentialID))
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(DeriveMethod, DeriveErrorCode, request.UserID, err)
+	}
+
+	vc, err := w.Derive(request.Auth, request.CredentialID, request.Frame,
+		wallet.WithDeriveNonce([]byte(request.Nonce)), wallet.WithDeriveRecipient(request.Recipient))
+	if err != nil {
+		return logWalletError(DeriveMethod, DeriveErrorCode, request.UserID, err)
+	}
+
+	vcBytes, err := vc.MarshalJSON()
+	if err != nil {
+		return logWalletError(DeriveMethod, DeriveErrorCode, request.UserID,
+			fmt.Errorf("failed to marshal credential : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &DeriveResponse{Credential: vcBytes}, logger)
+
+	logutil.LogDebug(logger, CommandName, DeriveMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// PresentProof answers a pending present-proof request presentation with the credentials of the wallet of a user
+// satisfying its presentation definition.
+func (o *Command) PresentProof(rw io.Writer, req io.Reader) command.Error {
+	request := &PresentProofRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, PresentProofMethod); err != nil {
+		return err
+	}
+
+	if request.PIID == "" {
+		logutil.LogDebug(logger, CommandName, PresentProofMethod, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(PresentProofMethod, PresentProofErrorCode, request.UserID, err)
+	}
+
+	// the request is the consent of the user, the credentials presented are the selected ones if any
+	vp, err := w.PresentProof(request.Auth, request.PIID, wallet.WithSelectedCredentials(request.Credentials...))
+	if err != nil {
+		return logWalletError(PresentProofMethod, PresentProofErrorCode, request.UserID, err)
+	}
+
+	vpBytes, err := vp.MarshalJSON()
+	if err != nil {
+		return logWalletError(PresentProofMethod, PresentProofErrorCode, request.UserID,
+			fmt.Errorf("failed to marshal presentation : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &PresentProofResponse{Presentation: vpBytes}, logger)
+
+	logutil.LogDebug(logger, CommandName, PresentProofMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID),
+		logutil.CreateKeyValueString(logPIIDKey, request.PIID))
+
+	return nil
+}
+
+// AuditLog returns the records of the disclosures of the credentials of the wallet of a user, optionally filtered by
+// recipient, credential and period.
+func (o *Command) AuditLog(rw io.Writer, req io.Reader) command.Error {
+	request := &AuditLogRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, AuditLogMethod); err != nil {
+		return err
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(AuditLogMethod, AuditLogErrorCode, request.UserID, err)
+	}
+
+	options := []wallet.AuditLogOpt{wallet.DisclosedBetween(request.From, request.To)}
+
+	if request.Recipient != "" {
+		options = append(options, wallet.DisclosedTo(request.Recipient))
+	}
+
+	if request.CredentialID != "" {
+		options = append(options, wallet.DisclosingCredential(request.CredentialID))
+	}
+
+	records, err := w.AuditLog(request.Auth, options...)
+	if err != nil {
+		return logWalletError(AuditLogMethod, AuditLogErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, &AuditLogResponse{Records: records}, logger)
+
+	logutil.LogDebug(logger, CommandName, AuditLogMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// CreateKeyPair creates a key pair in the KMS of the wallet of a user.
+func (o *Command) CreateKeyPair(rw io.Writer, req io.Reader) command.Error {
+	request := &CreateKeyPairRequest{}
+
+	if err := decodeRequest(req, request, &request.UserID, CreateKeyPairMethod); err != nil {
+		return err
+	}
+
+	if request.KeyType == "" {
+		logutil.LogDebug(logger, CommandName, CreateKeyPairMethod, errEmptyKeyType)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyKeyType))
+	}
+
+	w, err := o.wallet(request.UserID)
+	if err != nil {
+		return logWalletError(CreateKeyPairMethod, CreateKeyPairErrorCode, request.UserID, err)
+	}
+
+	keyManager, err := w.KMS(request.Auth)
+	if err != nil {
+		return logWalletError(CreateKeyPairMethod, CreateKeyPairErrorCode, request.UserID, err)
+	}
+
+	keyID, pubKeyBytes, err := keyManager.CreateAndExportPubKeyBytes(kms.KeyType(request.KeyType))
+	if err != nil {
+		return logWalletError(CreateKeyPairMethod, CreateKeyPairErrorCode, request.UserID, err)
+	}
+
+	command.WriteNillableResponse(rw, &CreateKeyPairResponse{
+		KeyID:     keyID,
+		PublicKey: base64.RawURLEncoding.EncodeToString(pubKeyBytes),
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreateKeyPairMethod, "success",
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// wallet returns the wallet of the user, created on first use so that it keeps its session between requests.
+func (o *Command) wallet(userID string) (*wallet.Wallet, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if w, ok := o.wallets[userID]; ok {
+		return w, nil
+	}
+
+	w, err := wallet.New(userID, o.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o.wallets[userID] = w
+
+	return w, nil
+}
+
+func decodeRequest(req io.Reader, request interface{}, userID *string, method string) command.Error {
+	if err := json.NewDecoder(req).Decode(request); err != nil {
+		logutil.LogInfo(logger, CommandName, method, "request decode : "+err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if *userID == "" {
+		logutil.LogDebug(logger, CommandName, method, errEmptyUserID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyUserID))
+	}
+
+	return nil
+}
+
+func logWalletError(method string, code command.Code, userID string, err error) command.Error {
+	logutil.LogError(logger, CommandName, method, err.Error(), logutil.CreateKeyValueString(logUserIDKey, userID))
+
+	return command.NewValidationError(code, err)
+}
@@ -0,0 +1,452 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcwallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/wallet"
+)
+
+const (
+	sampleUserID     = "sample-user"
+	samplePassphrase = "sample-passphrase"
+	sampleMetadata   = `{"id": "m1", "name": "work"}`
+	sampleCredential = `{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"id": "http://example.edu/credentials/1872",
+		"type": ["VerifiableCredential"],
+		"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+	}`
+)
+
+func TestNew(t *testing.T) {
+	cmd := New(newProvider())
+	require.NotNil(t, cmd)
+	require.Len(t, cmd.GetHandlers(), 16)
+}
+
+func TestCommand_Lifecycle(t *testing.T) {
+	cmd := New(newProvider())
+
+	execute(t, cmd.CreateProfile, &CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+
+	cmdErr := executeErr(t, cmd.CreateProfile,
+		&CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	require.Equal(t, CreateProfileErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrProfileExists.Error())
+
+	// the wallet is locked
+	cmdErr = executeErr(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, ContentType: wallet.Metadata, Content: json.RawMessage(sampleMetadata),
+	})
+	require.Equal(t, AddContentErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrWalletLocked.Error())
+
+	cmdErr = executeErr(t, cmd.Unlock, &UnlockWalletRequest{UserID: sampleUserID, Passphrase: "wrong"})
+	require.Equal(t, UnlockWalletErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrInvalidPassphrase.Error())
+
+	auth := unlock(t, cmd)
+
+	cmdErr = executeErr(t, cmd.Unlock, &UnlockWalletRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	require.Equal(t, UnlockWalletErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrWalletOpen.Error())
+
+	cmdErr = executeErr(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: "other-token", ContentType: wallet.Metadata, Content: json.RawMessage(sampleMetadata),
+	})
+	require.Equal(t, AddContentErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrInvalidAuthToken.Error())
+
+	refreshResponse := &RefreshTokenResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.RefreshToken,
+		&RefreshTokenRequest{UserID: sampleUserID, Auth: auth}), refreshResponse))
+	require.NotEqual(t, auth, refreshResponse.Token)
+
+	cmdErr = executeErr(t, cmd.RefreshToken, &RefreshTokenRequest{UserID: sampleUserID, Auth: auth})
+	require.Equal(t, RefreshTokenErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrInvalidAuthToken.Error())
+
+	auth = refreshResponse.Token
+
+	execute(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata, Content: json.RawMessage(sampleMetadata),
+	})
+
+	getResponse := &GetContentResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.Get, &GetContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata, ContentID: "m1",
+	}), getResponse))
+	require.JSONEq(t, sampleMetadata, string(getResponse.Content))
+
+	getAllResponse := &GetAllContentResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.GetAll, &GetAllContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata,
+	}), getAllResponse))
+	require.Len(t, getAllResponse.Contents, 1)
+	require.JSONEq(t, sampleMetadata, string(getAllResponse.Contents["m1"]))
+
+	execute(t, cmd.Remove, &RemoveContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata, ContentID: "m1",
+	})
+
+	cmdErr = executeErr(t, cmd.Get, &GetContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata, ContentID: "m1",
+	})
+	require.Equal(t, GetContentErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrContentNotFound.Error())
+
+	lockResponse := &LockWalletResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.Lock, &LockWalletRequest{UserID: sampleUserID}), lockResponse))
+	require.True(t, lockResponse.Closed)
+
+	require.NoError(t, json.Unmarshal(execute(t, cmd.Lock, &LockWalletRequest{UserID: sampleUserID}), lockResponse))
+	require.False(t, lockResponse.Closed)
+
+	cmdErr = executeErr(t, cmd.GetAll, &GetAllContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata,
+	})
+	require.Equal(t, GetAllContentErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrWalletLocked.Error())
+}
+
+func TestCommand_Collections(t *testing.T) {
+	cmd := New(newProvider())
+
+	execute(t, cmd.CreateProfile, &CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	auth := unlock(t, cmd)
+
+	cmdErr := executeErr(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata, Content: json.RawMessage(sampleMetadata),
+		CollectionID: "work",
+	})
+	require.Equal(t, AddContentErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrContentNotFound.Error())
+
+	execute(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Collection,
+		Content: json.RawMessage(`{"id": "work", "name": "Work"}`),
+	})
+	execute(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata, Content: json.RawMessage(sampleMetadata),
+		CollectionID: "work", Tags: []string{"display"},
+	})
+	execute(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata, Content: json.RawMessage(`{"id": "m2"}`),
+	})
+
+	getAllResponse := &GetAllContentResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.GetAll, &GetAllContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata, CollectionID: "work",
+	}), getAllResponse))
+	require.Len(t, getAllResponse.Contents, 1)
+	require.JSONEq(t, sampleMetadata, string(getAllResponse.Contents["m1"]))
+
+	getAllResponse = &GetAllContentResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.GetAll, &GetAllContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Metadata, Tags: []string{"display"},
+	}), getAllResponse))
+	require.Len(t, getAllResponse.Contents, 1)
+	require.Contains(t, getAllResponse.Contents, "m1")
+}
+
+func TestCommand_ExportImport(t *testing.T) {
+	source := New(newProvider())
+	target := New(newProvider())
+
+	for _, cmd := range []*Command{source, target} {
+		execute(t, cmd.CreateProfile, &CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	}
+
+	sourceAuth, targetAuth := unlock(t, source), unlock(t, target)
+
+	execute(t, source.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: sourceAuth, ContentType: wallet.Metadata, Content: json.RawMessage(sampleMetadata),
+	})
+
+	exportResponse := &ExportWalletResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, source.Export,
+		&ExportWalletRequest{UserID: sampleUserID, Auth: sourceAuth, Passphrase: "export-passphrase"}),
+		exportResponse))
+	require.NotEmpty(t, exportResponse.Archive)
+
+	cmdErr := executeErr(t, target.Import, &ImportWalletRequest{
+		UserID: sampleUserID, Auth: targetAuth, Passphrase: "wrong-passphrase", Archive: exportResponse.Archive,
+	})
+	require.Equal(t, ImportWalletErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrInvalidPassphrase.Error())
+
+	execute(t, target.Import, &ImportWalletRequest{
+		UserID: sampleUserID, Auth: targetAuth, Passphrase: "export-passphrase", Archive: exportResponse.Archive,
+	})
+
+	getResponse := &GetContentResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, target.Get, &GetContentRequest{
+		UserID: sampleUserID, Auth: targetAuth, ContentType: wallet.Metadata, ContentID: "m1",
+	}), getResponse))
+	require.JSONEq(t, sampleMetadata, string(getResponse.Content))
+}
+
+func TestCommand_Query(t *testing.T) {
+	cmd := New(newProvider())
+
+	execute(t, cmd.CreateProfile, &CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	auth := unlock(t, cmd)
+	execute(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Credential, Content: json.RawMessage(sampleCredential),
+	})
+
+	queryResponse := &QueryResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.Query, &QueryRequest{
+		UserID: sampleUserID, Auth: auth,
+		Query: []*wallet.QueryParams{{
+			Type: wallet.QueryByExample,
+			CredentialQuery: json.RawMessage(`{
+				"example": {"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}}
+			}`),
+		}},
+		Challenge: "challenge",
+	}), queryResponse))
+	require.Len(t, queryResponse.Results, 1)
+
+	vp := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(queryResponse.Results[0], &vp))
+	require.Equal(t, "VerifiablePresentation", vp["type"])
+
+	cmdErr := executeErr(t, cmd.Query, &QueryRequest{
+		UserID: sampleUserID, Auth: auth,
+		Query: []*wallet.QueryParams{{
+			Type: wallet.QueryByExample, CredentialQuery: json.RawMessage(`{"example": {"type": "DriversLicense"}}`),
+		}},
+	})
+	require.Equal(t, QueryErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrNoQueryResults.Error())
+}
+
+func TestCommand_Derive(t *testing.T) {
+	cmd := New(newProvider())
+
+	execute(t, cmd.CreateProfile, &CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	auth := unlock(t, cmd)
+	execute(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Credential, Content: json.RawMessage(sampleCredential),
+	})
+
+	cmdErr := executeErr(t, cmd.Derive, &DeriveRequest{UserID: sampleUserID, Auth: auth})
+	require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), errEmptyCredentialID)
+
+	cmdErr = executeErr(t, cmd.Derive, &DeriveRequest{
+		UserID: sampleUserID, Auth: auth, CredentialID: "http://example.edu/credentials/1872",
+		Frame: map[string]interface{}{"type": "VerifiableCredential"}, Nonce: "nonce",
+	})
+	require.Equal(t, DeriveErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrNotDerivable.Error())
+}
+
+func TestCommand_AuditLog(t *testing.T) {
+	cmd := New(newProvider())
+
+	execute(t, cmd.CreateProfile, &CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	auth := unlock(t, cmd)
+	execute(t, cmd.Add, &AddContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Credential, Content: json.RawMessage(sampleCredential),
+	})
+	execute(t, cmd.Query, &QueryRequest{
+		UserID: sampleUserID, Auth: auth, Recipient: "did:example:verifier", Query: []*wallet.QueryParams{{
+			Type: wallet.QueryByExample, CredentialQuery: json.RawMessage(`{"example": {"type": "VerifiableCredential"}}`),
+		}},
+	})
+
+	response := &AuditLogResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.AuditLog, &AuditLogRequest{
+		UserID: sampleUserID, Auth: auth, Recipient: "did:example:verifier",
+		CredentialID: "http://example.edu/credentials/1872", From: time.Now().Add(-time.Minute),
+	}), response))
+	require.Len(t, response.Records, 1)
+	require.Equal(t, wallet.QueryOperation, response.Records[0].Operation)
+
+	response = &AuditLogResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.AuditLog, &AuditLogRequest{
+		UserID: sampleUserID, Auth: auth, Recipient: "did:example:other",
+	}), response))
+	require.Empty(t, response.Records)
+
+	cmdErr := executeErr(t, cmd.AuditLog, &AuditLogRequest{UserID: sampleUserID, Auth: "unknown"})
+	require.Equal(t, AuditLogErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrInvalidAuthToken.Error())
+}
+
+func TestCommand_Profiles(t *testing.T) {
+	cmd := New(newProvider())
+
+	execute(t, cmd.CreateProfile, &CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+
+	cmdErr := executeErr(t, cmd.CreateKeyPair, &CreateKeyPairRequest{UserID: sampleUserID, KeyType: "ED25519"})
+	require.Equal(t, CreateKeyPairErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrWalletLocked.Error())
+
+	auth := unlock(t, cmd)
+
+	cmdErr = executeErr(t, cmd.CreateKeyPair, &CreateKeyPairRequest{UserID: sampleUserID, Auth: auth})
+	require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), errEmptyKeyType)
+
+	keyPairResponse := &CreateKeyPairResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.CreateKeyPair,
+		&CreateKeyPairRequest{UserID: sampleUserID, Auth: auth, KeyType: "ED25519"}), keyPairResponse))
+	require.NotEmpty(t, keyPairResponse.KeyID)
+	require.NotEmpty(t, keyPairResponse.PublicKey)
+
+	cmdErr = executeErr(t, cmd.RemoveProfile, &RemoveProfileRequest{UserID: sampleUserID, Passphrase: "wrong"})
+	require.Equal(t, RemoveProfileErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrInvalidPassphrase.Error())
+
+	execute(t, cmd.RemoveProfile, &RemoveProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+
+	cmdErr = executeErr(t, cmd.CreateProfile,
+		&CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase, EDVVaultID: "vault"})
+	require.Equal(t, CreateProfileErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), "EDV server URL and vault ID are mandatory")
+
+	cmdErr = executeErr(t, cmd.Unlock, &UnlockWalletRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	require.Equal(t, UnlockWalletErrorCode, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), wallet.ErrProfileNotFound.Error())
+}
+
+func TestCommand_Errors(t *testing.T) {
+	cmd := New(newProvider())
+
+	methods := map[string]command.Exec{
+		CreateProfileMethod: cmd.CreateProfile,
+		UnlockWalletMethod:  cmd.Unlock,
+		RefreshTokenMethod:  cmd.RefreshToken,
+		LockWalletMethod:    cmd.Lock,
+		AddContentMethod:    cmd.Add,
+		RemoveContentMethod: cmd.Remove,
+		GetContentMethod:    cmd.Get,
+		GetAllContentMethod: cmd.GetAll,
+		ExportWalletMethod:  cmd.Export,
+		ImportWalletMethod:  cmd.Import,
+		QueryMethod:         cmd.Query,
+		PresentProofMethod:  cmd.PresentProof,
+		RemoveProfileMethod: cmd.RemoveProfile,
+		CreateKeyPairMethod: cmd.CreateKeyPair,
+		AuditLogMethod:      cmd.AuditLog,
+	}
+
+	for name, exec := range methods {
+		cmdErr := exec(&bytes.Buffer{}, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr, name)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), name)
+		require.Equal(t, command.ValidationError, cmdErr.Type(), name)
+
+		cmdErr = exec(&bytes.Buffer{}, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr, name)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), name)
+		require.Contains(t, cmdErr.Error(), errEmptyUserID, name)
+	}
+
+	t.Run("profile not found", func(t *testing.T) {
+		tests := []struct {
+			exec    command.Exec
+			request interface{}
+			code    command.Code
+		}{
+			{cmd.Unlock, &UnlockWalletRequest{UserID: sampleUserID, Passphrase: samplePassphrase}, UnlockWalletErrorCode},
+			{cmd.Lock, &LockWalletRequest{UserID: sampleUserID}, LockWalletErrorCode},
+			{cmd.RefreshToken, &RefreshTokenRequest{UserID: sampleUserID}, RefreshTokenErrorCode},
+			{cmd.Add, &AddContentRequest{UserID: sampleUserID}, AddContentErrorCode},
+			{cmd.Remove, &RemoveContentRequest{UserID: sampleUserID}, RemoveContentErrorCode},
+			{cmd.Get, &GetContentRequest{UserID: sampleUserID}, GetContentErrorCode},
+			{cmd.GetAll, &GetAllContentRequest{UserID: sampleUserID}, GetAllContentErrorCode},
+			{cmd.Export, &ExportWalletRequest{UserID: sampleUserID}, ExportWalletErrorCode},
+			{cmd.Import, &ImportWalletRequest{UserID: sampleUserID}, ImportWalletErrorCode},
+			{cmd.Query, &QueryRequest{UserID: sampleUserID}, QueryErrorCode},
+			{cmd.PresentProof, &PresentProofRequest{UserID: sampleUserID, PIID: "piid"}, PresentProofErrorCode},
+			{cmd.RemoveProfile, &RemoveProfileRequest{UserID: sampleUserID}, RemoveProfileErrorCode},
+			{cmd.CreateKeyPair, &CreateKeyPairRequest{UserID: sampleUserID, KeyType: "ED25519"}, CreateKeyPairErrorCode},
+		}
+
+		for _, tc := range tests {
+			cmdErr := executeErr(t, tc.exec, tc.request)
+			require.Equal(t, tc.code, cmdErr.Code())
+			require.Contains(t, cmdErr.Error(), wallet.ErrProfileNotFound.Error())
+		}
+	})
+
+	t.Run("present proof", func(t *testing.T) {
+		cmdErr := executeErr(t, cmd.PresentProof, &PresentProofRequest{UserID: sampleUserID})
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyPIID)
+
+		execute(t, cmd.CreateProfile, &CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+		auth := unlock(t, cmd)
+
+		// the agent has no present-proof service
+		cmdErr = executeErr(t, cmd.PresentProof, &PresentProofRequest{UserID: sampleUserID, Auth: auth, PIID: "piid"})
+		require.Equal(t, PresentProofErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "cast service to present-proof service failed")
+	})
+
+	t.Run("invalid idle timeout", func(t *testing.T) {
+		cmdErr := executeErr(t, cmd.Unlock, &UnlockWalletRequest{UserID: sampleUserID, IdleTimeout: -1})
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "invalid idle timeout -1")
+	})
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{StorageProviderValue: mem.NewProvider()}
+}
+
+func unlock(t *testing.T, cmd *Command) string {
+	t.Helper()
+
+	response := &UnlockWalletResponse{}
+	require.NoError(t, json.Unmarshal(execute(t, cmd.Unlock,
+		&UnlockWalletRequest{UserID: sampleUserID, Passphrase: samplePassphrase}), response))
+	require.NotEmpty(t, response.Token)
+
+	return response.Token
+}
+
+func execute(t *testing.T, exec command.Exec, request interface{}) []byte {
+	t.Helper()
+
+	reqBytes, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	var rw bytes.Buffer
+
+	require.NoError(t, exec(&rw, bytes.NewReader(reqBytes)))
+
+	return rw.Bytes()
+}
+
+func executeErr(t *testing.T, exec command.Exec, request interface{}) command.Error {
+	t.Helper()
+
+	reqBytes, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	cmdErr := exec(&bytes.Buffer{}, bytes.NewReader(reqBytes))
+	require.Error(t, cmdErr)
+
+	return cmdErr
+}
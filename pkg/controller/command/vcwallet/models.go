@@ -0,0 +1,269 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcwallet
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/wallet"
+)
+
+// CreateProfileRequest is model for create wallet profile request.
+type CreateProfileRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// passphrase unlocking the wallet
+	Passphrase string `json:"passphrase"`
+	// URL of the EDV server whose vault keeps the contents and keys of the wallet, optional
+	EDVServerURL string `json:"edvServerURL,omitempty"`
+	// ID of the EDV vault, shared by the devices of the user
+	EDVVaultID string `json:"edvVaultID,omitempty"`
+}
+
+// RemoveProfileRequest is model for remove wallet profile request.
+type RemoveProfileRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// passphrase of the wallet profile
+	Passphrase string `json:"passphrase"`
+}
+
+// UnlockWalletRequest is model for unlock wallet request.
+type UnlockWalletRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// passphrase of the wallet profile
+	Passphrase string `json:"passphrase"`
+	// number of seconds after which the wallet is locked again if its auth token isn't used, 600 if not set
+	IdleTimeout int `json:"idleTimeout,omitempty"`
+}
+
+// UnlockWalletResponse is model for unlock wallet response.
+type UnlockWalletResponse struct {
+	// auth token of the wallet session, required by the operations on the wallet contents and keys
+	Token string `json:"token"`
+}
+
+// RefreshTokenRequest is model for refresh wallet auth token request.
+type RefreshTokenRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+}
+
+// RefreshTokenResponse is model for refresh wallet auth token response.
+type RefreshTokenResponse struct {
+	// new auth token of the wallet session, replacing the previous one
+	Token string `json:"token"`
+}
+
+// LockWalletRequest is model for lock wallet request.
+type LockWalletRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+}
+
+// LockWalletResponse is model for lock wallet response.
+type LockWalletResponse struct {
+	// false if the wallet was already locked
+	Closed bool `json:"closed"`
+}
+
+// AddContentRequest is model for add wallet content request.
+type AddContentRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// type of the content: "collection", "credential", "didResolutionResponse", "metadata", "connection" or "key"
+	ContentType wallet.ContentType `json:"contentType"`
+	// content, identified by its "id" or, for a DID resolution response, the ID of its DID document
+	Content json.RawMessage `json:"content"`
+	// ID of the collection of the content, a content of type "collection" of the wallet
+	CollectionID string `json:"collectionID,omitempty"`
+	// tags of the content, to search the contents with
+	Tags []string `json:"tags,omitempty"`
+}
+
+// RemoveContentRequest is model for remove wallet content request.
+type RemoveContentRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// type of the content
+	ContentType wallet.ContentType `json:"contentType"`
+	// ID of the content
+	ContentID string `json:"contentID"`
+}
+
+// GetContentRequest is model for get wallet content request.
+type GetContentRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// type of the content
+	ContentType wallet.ContentType `json:"contentType"`
+	// ID of the content
+	ContentID string `json:"contentID"`
+}
+
+// GetContentResponse is model for get wallet content response.
+type GetContentResponse struct {
+	Content json.RawMessage `json:"content"`
+}
+
+// GetAllContentRequest is model for get all wallet contents request.
+type GetAllContentRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// type of the contents
+	ContentType wallet.ContentType `json:"contentType"`
+	// returns only the contents of the collection of this ID
+	CollectionID string `json:"collectionID,omitempty"`
+	// returns only the contents having all these tags
+	Tags []string `json:"tags,omitempty"`
+}
+
+// GetAllContentResponse is model for get all wallet contents response.
+type GetAllContentResponse struct {
+	// contents by ID
+	Contents map[string]json.RawMessage `json:"contents"`
+}
+
+// ExportWalletRequest is model for export wallet request.
+type ExportWalletRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// passphrase protecting the archive
+	Passphrase string `json:"passphrase"`
+}
+
+// ExportWalletResponse is model for export wallet response.
+type ExportWalletResponse struct {
+	// archive of the wallet contents, protected with the passphrase
+	Archive json.RawMessage `json:"archive"`
+}
+
+// ImportWalletRequest is model for import wallet request.
+type ImportWalletRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// passphrase the archive was exported with
+	Passphrase string `json:"passphrase"`
+	// archive of the wallet contents
+	Archive json.RawMessage `json:"archive"`
+}
+
+// QueryRequest is model for query wallet credentials request.
+type QueryRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// queries of the verifiable presentation request: "QueryByExample", "QueryByFrame" or "PresentationExchange"
+	Query []*wallet.QueryParams `json:"query"`
+	// challenge of the verifiable presentation request, the nonce of the selective disclosures derived by frames
+	Challenge string `json:"challenge,omitempty"`
+	// who the presentations are for, as recorded in the wallet audit log
+	Recipient string `json:"recipient,omitempty"`
+}
+
+// QueryResponse is model for query wallet credentials response.
+type QueryResponse struct {
+	// presentations answering the queries, unsigned
+	Results []json.RawMessage `json:"results"`
+}
+
+// DeriveRequest is model for derive wallet credential request.
+type DeriveRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// ID of the credential signed with BBS+
+	CredentialID string `json:"credentialID"`
+	// JSON-LD frame of the fields to disclose
+	Frame map[string]interface{} `json:"frame"`
+	// nonce of the selective disclosure, typically the challenge of the verifier
+	Nonce string `json:"nonce,omitempty"`
+	// who the selective disclosure is for, as recorded in the wallet audit log
+	Recipient string `json:"recipient,omitempty"`
+}
+
+// DeriveResponse is model for derive wallet credential response.
+type DeriveResponse struct {
+	// credential derived, disclosing only the fields of the frame
+	Credential json.RawMessage `json:"credential"`
+}
+
+// PresentProofRequest is model for present proof from wallet request.
+type PresentProofRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// protocol instance ID of the pending present-proof request presentation
+	PIID string `json:"piid"`
+	// IDs of the credentials selected by the user, the first credentials matching the presentation definition if not set
+	Credentials []string `json:"credentials,omitempty"`
+}
+
+// PresentProofResponse is model for present proof from wallet response.
+type PresentProofResponse struct {
+	// presentation sent to the verifier, with its presentation submission
+	Presentation json.RawMessage `json:"presentation"`
+}
+
+// CreateKeyPairRequest is model for create wallet key pair request.
+type CreateKeyPairRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// type of the key, e.g. "ED25519"
+	KeyType string `json:"keyType"`
+}
+
+// CreateKeyPairResponse is model for create wallet key pair response.
+type CreateKeyPairResponse struct {
+	// ID of the key in the KMS of the wallet
+	KeyID string `json:"keyID"`
+	// public key base64 encoded
+	PublicKey string `json:"publicKey"`
+}
+
+// AuditLogRequest is model for review wallet audit log request.
+type AuditLogRequest struct {
+	// ID of the wallet user
+	UserID string `json:"userID"`
+	// auth token of the wallet session
+	Auth string `json:"auth"`
+	// only the disclosures to the recipient
+	Recipient string `json:"recipient,omitempty"`
+	// only the disclosures of the credential
+	CredentialID string `json:"credentialID,omitempty"`
+	// only the disclosures since then
+	From time.Time `json:"from,omitempty"`
+	// only the disclosures until then
+	To time.Time `json:"to,omitempty"`
+}
+
+// AuditLogResponse is model for review wallet audit log response.
+type AuditLogResponse struct {
+	// records of the disclosures, oldest first
+	Records []*wallet.DisclosureRecord `json:"records"`
+}
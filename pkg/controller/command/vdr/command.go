@@ -8,8 +8,10 @@ package vdr
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
@@ -36,6 +38,9 @@ const (
 
 	// ResolveDIDErrorCode for get did error.
 	ResolveDIDErrorCode
+
+	// GetDIDsErrorCode for get did records error.
+	GetDIDsErrorCode
 )
 
 // constants for the VDR controller's methods.
@@ -211,15 +216,108 @@ func (o *Command) GetDID(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
-// GetDIDRecords retrieves the did doc containing name and didID. //TODO Add pagination feature #1566.
+// GetDIDRecords retrieves the did doc records containing name and didID. The records are paged, filtered and
+// sorted with the optional PageArgs of the request.
 func (o *Command) GetDIDRecords(rw io.Writer, req io.Reader) command.Error {
-	didRecords := o.didStore.GetDIDRecords()
+	page, err := decodePageArgs(req)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetDIDsCommandMethod, "request decode : "+err.Error())
 
-	command.WriteNillableResponse(rw, &DIDRecordResult{
-		Result: didRecords,
-	}, logger)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	result, err := o.queryDIDRecords(page)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetDIDsCommandMethod, "get did records : "+err.Error())
+
+		return command.NewExecuteError(GetDIDsErrorCode, fmt.Errorf("get did records : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, result, logger)
 
 	logutil.LogDebug(logger, CommandName, GetDIDsCommandMethod, "success")
 
 	return nil
 }
+
+// queryDIDRecords returns the did records of the page. The records which are only paged are paged by the store, the
+// others are filtered, sorted and paged once all retrieved.
+func (o *Command) queryDIDRecords(page *PageArgs) (*DIDRecordResult, error) {
+	if page == nil {
+		return &DIDRecordResult{Result: o.didStore.GetDIDRecords()}, nil
+	}
+
+	if page.Sort == "" && page.Method == "" {
+		p, err := o.didStore.GetDIDRecordsPage(page.Offset, page.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DIDRecordResult{
+			Result:     p.Records,
+			Total:      p.Total,
+			NextCursor: command.NextCursor(page.Offset, len(p.Records), p.Total),
+		}, nil
+	}
+
+	var records []*didstore.Record
+
+	for _, r := range o.didStore.GetDIDRecords() {
+		if page.Method != "" && !strings.HasPrefix(r.ID, "did:"+page.Method+":") {
+			continue
+		}
+
+		records = append(records, r)
+	}
+
+	err := command.Sort(len(records), func(i, j int) { records[i], records[j] = records[j], records[i] },
+		page.Sort, map[string]command.SortFunc{
+			"name": func(i int) string { return records[i].Name },
+			"id":   func(i int) string { return records[i].ID },
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := command.PageBounds(page.Offset, page.Limit, len(records))
+
+	return &DIDRecordResult{
+		Result:     records[start:end],
+		Total:      len(records),
+		NextCursor: command.NextCursor(start, end-start, len(records)),
+	}, nil
+}
+
+// decodePageArgs decodes the optional paging arguments of the request, nil is returned when the records are neither
+// paged, filtered nor sorted. The cursor of the arguments is resolved into their offset.
+func decodePageArgs(req io.Reader) (*PageArgs, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	page := &PageArgs{}
+
+	err := json.NewDecoder(req).Decode(page)
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if *page == (PageArgs{}) {
+		return nil, nil
+	}
+
+	if field := strings.TrimPrefix(page.Sort, "-"); field != "" && field != "name" && field != "id" {
+		return nil, fmt.Errorf("cannot sort did records by %s", field)
+	}
+
+	page.Offset, err = command.PageOffset(page.Offset, page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
@@ -9,11 +9,13 @@ package vdr
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
@@ -341,4 +343,63 @@ func TestGetDIDRecords(t *testing.T) {
 		require.NotEmpty(t, response)
 		require.Equal(t, 1, len(response.Result))
 	})
+
+	t.Run("test get did records page", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: storeProvider,
+		})
+		require.NoError(t, err)
+
+		for _, id := range []string{"did:peer:b", "did:example:a", "did:peer:c"} {
+			require.NoError(t, storeProvider.Store.Put("didname_"+id, []byte(id)))
+		}
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.GetDIDRecords(&getRW, bytes.NewBufferString(`{"offset":1,"limit":1}`))
+		require.NoError(t, cmdErr)
+
+		var response DIDRecordResult
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 1)
+		require.Equal(t, "did:peer:b", response.Result[0].ID)
+		require.Equal(t, 3, response.Total)
+		require.Equal(t, command.EncodeCursor(2), response.NextCursor)
+
+		getRW.Reset()
+		cmdErr = cmd.GetDIDRecords(&getRW, bytes.NewBufferString(`{"method":"peer","sort":"-id"}`))
+		require.NoError(t, cmdErr)
+
+		response = DIDRecordResult{}
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 2)
+		require.Equal(t, "did:peer:c", response.Result[0].ID)
+		require.Equal(t, "did:peer:b", response.Result[1].ID)
+		require.Equal(t, 2, response.Total)
+
+		getRW.Reset()
+		cmdErr = cmd.GetDIDRecords(&getRW, bytes.NewBufferString(`{}`))
+		require.NoError(t, cmdErr)
+
+		response = DIDRecordResult{}
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 3)
+		require.Zero(t, response.Total)
+
+		cmdErr = cmd.GetDIDRecords(&getRW, bytes.NewBufferString(`--`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.GetDIDRecords(&getRW, bytes.NewBufferString(`{"cursor":"!"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		storeProvider.Store.ErrItr = errors.New("iterator error")
+
+		cmdErr = cmd.GetDIDRecords(&getRW, bytes.NewBufferString(`{"limit":1}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetDIDsErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "iterator error")
+	})
 }
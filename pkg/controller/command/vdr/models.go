@@ -36,6 +36,35 @@ type IDArg struct {
 type DIDRecordResult struct {
 	// Result
 	Result []*storeDID.Record `json:"result,omitempty"`
+
+	// Total number of records, set when the records are paged
+	Total int `json:"total,omitempty"`
+
+	// NextCursor is the cursor of the next page of records, empty for the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PageArgs model
+//
+// This is used for paging, filtering and sorting the did records.
+//
+type PageArgs struct {
+	// Offset is the number of records to skip
+	Offset int `json:"offset,omitempty"`
+
+	// Limit is the maximum number of records to return, all the records are returned when zero
+	Limit int `json:"limit,omitempty"`
+
+	// Cursor of the page of records to return, as returned in the NextCursor of the previous page.
+	// It takes precedence over the offset.
+	Cursor string `json:"cursor,omitempty"`
+
+	// Sort is the field to sort the records by (name or id), prefixed with "-" for the descending order.
+	// The records are sorted by name by default.
+	Sort string `json:"sort,omitempty"`
+
+	// Method of the DIDs of the records (e.g. "peer" for did:peer DIDs)
+	Method string `json:"method,omitempty"`
 }
 
 // NameArg model
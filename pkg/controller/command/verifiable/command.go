@@ -13,16 +13,16 @@ import (
 	"io"
 	"strings"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	ariescrypto "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
-	verifiablesigner "github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
-	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
-	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
-	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
@@ -77,6 +77,18 @@ const (
 
 	// RemovePresentationByNameErrorCode for remove vp by name errors.
 	RemovePresentationByNameErrorCode
+
+	// VerifyCredentialErrorCode for verify vc error.
+	VerifyCredentialErrorCode
+
+	// VerifyPresentationErrorCode for verify vp error.
+	VerifyPresentationErrorCode
+
+	// EvaluatePresentationDefinitionErrorCode for evaluate presentation definition error.
+	EvaluatePresentationDefinitionErrorCode
+
+	// CreatePresentationSubmissionErrorCode for create presentation submission error.
+	CreatePresentationSubmissionErrorCode
 )
 
 // constants for the Verifiable protocol.
@@ -97,6 +109,11 @@ const (
 	GeneratePresentationByIDCommandMethod = "GeneratePresentationByID"
 	RemoveCredentialByNameCommandMethod   = "RemoveCredentialByName"
 	RemovePresentationByNameCommandMethod = "RemovePresentationByName"
+	VerifyCredentialCommandMethod         = "VerifyCredential"
+	VerifyPresentationCommandMethod       = "VerifyPresentation"
+
+	EvaluatePresentationDefinitionCommandMethod = "EvaluatePresentationDefinition"
+	CreatePresentationSubmissionCommandMethod   = "CreatePresentationSubmission"
 
 	// error messages.
 	errEmptyCredentialName   = "credential name is mandatory"
@@ -104,6 +121,7 @@ const (
 	errEmptyCredentialID     = "credential id is mandatory"
 	errEmptyPresentationID   = "presentation id is mandatory"
 	errEmptyDID              = "did is mandatory"
+	errEmptyDefinition       = "presentation definition is mandatory"
 
 	// log constants.
 	vcID   = "vcID"
@@ -178,6 +196,9 @@ type Command struct {
 	didStore        *didstore.Store
 	kResolver       keyResolver
 	ctx             provider
+	jsonLimits      jsonutil.Limits
+	cache           *cache.Cache
+	signatureSuites *suiteregistry.Registry
 }
 
 // New returns new verifiable credential controller command instance.
@@ -197,6 +218,9 @@ func New(p provider) (*Command, error) {
 		didStore:        didStore,
 		kResolver:       verifiable.NewDIDKeyResolver(p.VDRegistry()),
 		ctx:             p,
+		jsonLimits:      jsonutil.LimitsOf(p),
+		cache:           cache.Of(p),
+		signatureSuites: suiteregistry.Of(p),
 	}, nil
 }
 
@@ -216,6 +240,12 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetPresentationsCommandMethod, o.GetPresentations),
 		cmdutil.NewCommandHandler(CommandName, RemoveCredentialByNameCommandMethod, o.RemoveCredentialByName),
 		cmdutil.NewCommandHandler(CommandName, RemovePresentationByNameCommandMethod, o.RemovePresentationByName),
+		cmdutil.NewCommandHandler(CommandName, VerifyCredentialCommandMethod, o.VerifyCredential),
+		cmdutil.NewCommandHandler(CommandName, VerifyPresentationCommandMethod, o.VerifyPresentation),
+		cmdutil.NewCommandHandler(CommandName, EvaluatePresentationDefinitionCommandMethod,
+			o.EvaluatePresentationDefinition),
+		cmdutil.NewCommandHandler(CommandName, CreatePresentationSubmissionCommandMethod,
+			o.CreatePresentationSubmission),
 	}
 }
 
@@ -233,7 +263,9 @@ func (o *Command) ValidateCredential(rw io.Writer, req io.Reader) command.Error
 	// we are only validating the VerifiableCredential here, hence ignoring other return values
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/1316 VC Validate Command - Add keys for proof
 	//  verification as options to the function.
-	_, err = verifiable.ParseCredential([]byte(request.VerifiableCredential))
+	_, err = verifiable.ParseCredential([]byte(request.VerifiableCredential),
+		verifiable.WithJSONLimits(o.jsonLimits), verifiable.WithCache(o.cache),
+		verifiable.WithSignatureSuiteRegistry(o.signatureSuites))
 	if err != nil {
 		logutil.LogInfo(logger, CommandName, ValidateCredentialCommandMethod, "validate vc : "+err.Error())
 
@@ -263,7 +295,8 @@ func (o *Command) SaveCredential(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(SaveCredentialErrorCode, fmt.Errorf(errEmptyCredentialName))
 	}
 
-	vc, err := verifiable.ParseUnverifiedCredential([]byte(request.VerifiableCredential))
+	vc, err := verifiable.ParseUnverifiedCredential([]byte(request.VerifiableCredential),
+		verifiable.WithJSONLimits(o.jsonLimits), verifiable.WithCache(o.cache))
 	if err != nil {
 		logutil.LogError(logger, CommandName, SaveCredentialCommandMethod, "parse vc : "+err.Error())
 
@@ -301,7 +334,7 @@ func (o *Command) SavePresentation(rw io.Writer, req io.Reader) command.Error {
 	}
 
 	vp, err := verifiable.ParsePresentation([]byte(request.VerifiablePresentation),
-		verifiable.WithPresDisabledProofCheck())
+		verifiable.WithPresDisabledProofCheck(), verifiable.WithPresJSONLimits(o.jsonLimits))
 	if err != nil {
 		logutil.LogError(logger, CommandName, SavePresentationCommandMethod, "parse vp : "+err.Error())
 
@@ -387,7 +420,8 @@ func (o *Command) SignCredential(rw io.Writer, req io.Reader) command.Error {
 		}
 	}
 
-	vc, err := verifiable.ParseUnverifiedCredential(request.Credential)
+	vc, err := verifiable.ParseUnverifiedCredential(request.Credential,
+		verifiable.WithJSONLimits(o.jsonLimits), verifiable.WithCache(o.cache))
 	if err != nil {
 		logutil.LogError(logger, CommandName, SignCredentialCommandMethod, "parse credential : "+err.Error())
 
@@ -417,6 +451,171 @@ func (o *Command) SignCredential(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// VerifyCredential verifies the proof of the verifiable credential, either its linked data proofs or its JWS.
+func (o *Command) VerifyCredential(rw io.Writer, req io.Reader) command.Error {
+	request := &VerifyCredentialRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	err = o.verifyCredential(unquote(request.VerifiableCredential), request.ProofOptions)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "verify vc : "+err.Error())
+
+		return command.NewValidationError(VerifyCredentialErrorCode, fmt.Errorf("verify vc : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyCredentialCommandMethod, "success")
+
+	return nil
+}
+
+// VerifyPresentation verifies the proof of the verifiable presentation and the proofs of its credentials.
+func (o *Command) VerifyPresentation(rw io.Writer, req io.Reader) command.Error {
+	request := &VerifyPresentationRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyPresentationCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	err = o.verifyPresentation(unquote(request.VerifiablePresentation), request.ProofOptions)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyPresentationCommandMethod, "verify vp : "+err.Error())
+
+		return command.NewValidationError(VerifyPresentationErrorCode, fmt.Errorf("verify vp : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyPresentationCommandMethod, "success")
+
+	return nil
+}
+
+// EvaluatePresentationDefinition returns the records of the stored credentials matching each input descriptor of
+// the presentation definition, the revoked credentials being left out.
+func (o *Command) EvaluatePresentationDefinition(rw io.Writer, req io.Reader) command.Error {
+	request := &PresentationDefinitionRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, EvaluatePresentationDefinitionCommandMethod,
+			"request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.PresentationDefinition == nil {
+		logutil.LogDebug(logger, CommandName, EvaluatePresentationDefinitionCommandMethod, errEmptyDefinition)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDefinition))
+	}
+
+	credentials, records, err := o.storedCredentials(nil)
+	if err != nil {
+		logutil.LogError(logger, CommandName, EvaluatePresentationDefinitionCommandMethod,
+			"get stored credentials : "+err.Error())
+
+		return command.NewValidationError(EvaluatePresentationDefinitionErrorCode,
+			fmt.Errorf("get stored credentials : %w", err))
+	}
+
+	result := &PresentationDefinitionResult{
+		Matches:   make(map[string][]*verifiablestore.Record),
+		Satisfied: true,
+	}
+
+	matches := request.PresentationDefinition.Evaluate(credentials...)
+
+	for _, descriptor := range request.PresentationDefinition.InputDescriptors {
+		for _, vc := range matches[descriptor.ID] {
+			result.Matches[descriptor.ID] = append(result.Matches[descriptor.ID], records[vc])
+		}
+
+		if len(matches[descriptor.ID]) == 0 {
+			result.Satisfied = false
+		}
+	}
+
+	command.WriteNillableResponse(rw, result, logger)
+
+	logutil.LogDebug(logger, CommandName, EvaluatePresentationDefinitionCommandMethod, "success")
+
+	return nil
+}
+
+// CreatePresentationSubmission creates a presentation submitting stored credentials against the presentation
+// definition, signed by the holder if a DID is given.
+func (o *Command) CreatePresentationSubmission(rw io.Writer, req io.Reader) command.Error {
+	request := &PresentationSubmissionRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreatePresentationSubmissionCommandMethod,
+			"request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.PresentationDefinition == nil {
+		logutil.LogDebug(logger, CommandName, CreatePresentationSubmissionCommandMethod, errEmptyDefinition)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDefinition))
+	}
+
+	credentials, _, err := o.storedCredentials(request.CredentialIDs)
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreatePresentationSubmissionCommandMethod,
+			"get stored credentials : "+err.Error())
+
+		return command.NewValidationError(CreatePresentationSubmissionErrorCode,
+			fmt.Errorf("get stored credentials : %w", err))
+	}
+
+	vp, err := request.PresentationDefinition.CreateVP(credentials...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreatePresentationSubmissionCommandMethod,
+			"create submission : "+err.Error())
+
+		return command.NewValidationError(CreatePresentationSubmissionErrorCode,
+			fmt.Errorf("create submission : %w", err))
+	}
+
+	if request.DID != "" {
+		err = o.signSubmission(vp, request.DID, request.ProofOptions)
+		if err != nil {
+			logutil.LogError(logger, CommandName, CreatePresentationSubmissionCommandMethod,
+				"sign submission : "+err.Error())
+
+			return command.NewValidationError(CreatePresentationSubmissionErrorCode,
+				fmt.Errorf("sign submission : %w", err))
+		}
+	}
+
+	vpBytes, err := vp.MarshalJSON()
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreatePresentationSubmissionCommandMethod,
+			"marshal vp : "+err.Error())
+
+		return command.NewValidationError(CreatePresentationSubmissionErrorCode, fmt.Errorf("marshal vp : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &Presentation{
+		VerifiablePresentation: vpBytes,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreatePresentationSubmissionCommandMethod, "success")
+
+	return nil
+}
+
 // GetPresentation retrieves the verifiable presentation from the store.
 func (o *Command) GetPresentation(rw io.Writer, req io.Reader) command.Error {
 	var request IDArg
@@ -494,16 +693,21 @@ func (o *Command) GetCredentialByName(rw io.Writer, req io.Reader) command.Error
 
 // GetCredentials retrieves the verifiable credential records containing name and fields of interest.
 func (o *Command) GetCredentials(rw io.Writer, req io.Reader) command.Error {
-	vcRecords, err := o.verifiableStore.GetCredentials()
+	page, err := decodePageArgs(req)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetCredentialsCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	result, err := queryRecords(page, o.verifiableStore.GetCredentials, o.verifiableStore.GetCredentialsPage)
 	if err != nil {
 		logutil.LogError(logger, CommandName, GetCredentialsCommandMethod, "get credential records : "+err.Error())
 
 		return command.NewValidationError(GetCredentialsErrorCode, fmt.Errorf("get credential records : %w", err))
 	}
 
-	command.WriteNillableResponse(rw, &RecordResult{
-		Result: vcRecords,
-	}, logger)
+	command.WriteNillableResponse(rw, result, logger)
 
 	logutil.LogDebug(logger, CommandName, GetCredentialsCommandMethod, "success")
 
@@ -512,16 +716,21 @@ func (o *Command) GetCredentials(rw io.Writer, req io.Reader) command.Error {
 
 // GetPresentations retrieves the verifiable presentation records containing name and fields of interest.
 func (o *Command) GetPresentations(rw io.Writer, req io.Reader) command.Error {
-	vpRecords, err := o.verifiableStore.GetPresentations()
+	page, err := decodePageArgs(req)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetPresentationsCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	result, err := queryRecords(page, o.verifiableStore.GetPresentations, o.verifiableStore.GetPresentationsPage)
 	if err != nil {
 		logutil.LogError(logger, CommandName, GetPresentationsCommandMethod, "get presentation records : "+err.Error())
 
 		return command.NewValidationError(GetPresentationsErrorCode, fmt.Errorf("get presentation records : %w", err))
 	}
 
-	command.WriteNillableResponse(rw, &RecordResult{
-		Result: vpRecords,
-	}, logger)
+	command.WriteNillableResponse(rw, result, logger)
 
 	logutil.LogDebug(logger, CommandName, GetPresentationsCommandMethod, "success")
 
@@ -760,14 +969,8 @@ func (o *Command) addLinkedDataProof(p provable, opts *ProofOptions) error {
 		return err
 	}
 
-	var signatureSuite verifiablesigner.SignatureSuite
-
-	switch opts.SignatureType {
-	case Ed25519Signature2018:
-		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
-	case JSONWebSignature2020:
-		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
-	default:
+	signatureSuite, err := o.signatureSuites.Signer(opts.SignatureType, s)
+	if err != nil {
 		return fmt.Errorf("signature type unsupported %s", opts.SignatureType)
 	}
 
@@ -795,7 +998,10 @@ func (o *Command) parseVerifiableCredentials(request *PresentationRequest,
 	var vcs []interface{}
 
 	for _, vcRaw := range request.VerifiableCredentials {
-		var credOpts []verifiable.CredentialOpt
+		credOpts := []verifiable.CredentialOpt{
+			verifiable.WithJSONLimits(o.jsonLimits), verifiable.WithCache(o.cache),
+			verifiable.WithSignatureSuiteRegistry(o.signatureSuites),
+		}
 		if request.SkipVerify {
 			credOpts = append(credOpts, verifiable.WithDisabledProofCheck())
 		} else {
@@ -826,7 +1032,8 @@ func (o *Command) parseVerifiableCredentials(request *PresentationRequest,
 
 func (o *Command) parsePresentation(request *PresentationRequest,
 	didDoc *did.Doc) ([]interface{}, *verifiable.Presentation, *ProofOptions, error) {
-	presentation, err := verifiable.ParseUnverifiedPresentation(request.Presentation)
+	presentation, err := verifiable.ParseUnverifiedPresentation(request.Presentation,
+		verifiable.WithPresJSONLimits(o.jsonLimits))
 	if err != nil {
 		logutil.LogError(logger, CommandName, GeneratePresentationCommandMethod,
 			"failed to parse presentation from request: "+err.Error())
@@ -957,6 +1164,161 @@ func (o *Command) addCredentialProof(vc *verifiable.Credential, didDoc *did.Doc,
 	return o.addLinkedDataProof(vc, opts)
 }
 
+func (o *Command) verifyCredential(vcBytes []byte, opts *ProofOptions) error {
+	vc, err := verifiable.ParseCredential(vcBytes, verifiable.WithPublicKeyFetcher(o.kResolver.PublicKeyFetcher()),
+		verifiable.WithJSONLimits(o.jsonLimits), verifiable.WithCache(o.cache),
+		verifiable.WithSignatureSuiteRegistry(o.signatureSuites))
+	if err != nil {
+		return err
+	}
+
+	return checkProofs(vc.Proofs, jwt.IsJWS(string(vcBytes)), opts)
+}
+
+func (o *Command) verifyPresentation(vpBytes []byte, opts *ProofOptions) error {
+	vp, err := verifiable.ParsePresentation(vpBytes,
+		verifiable.WithPresPublicKeyFetcher(o.kResolver.PublicKeyFetcher()), verifiable.WithPresJSONLimits(o.jsonLimits),
+		verifiable.WithPresSignatureSuiteRegistry(o.signatureSuites))
+	if err != nil {
+		return err
+	}
+
+	err = checkProofs(vp.Proofs, jwt.IsJWS(string(vpBytes)), opts)
+	if err != nil {
+		return err
+	}
+
+	// the JWS credentials are verified when parsing the presentation, the embedded ones are verified here without
+	// the proof options, the challenge and the domain being those of the presentation
+	for i, c := range vp.Credentials() {
+		if _, ok := c.(map[string]interface{}); !ok {
+			continue
+		}
+
+		vcBytes, err := json.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("credential %d: %w", i, err)
+		}
+
+		if err := o.verifyCredential(vcBytes, nil); err != nil {
+			return fmt.Errorf("credential %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// checkProofs checks that the document is proved, by a JWS or by linked data proofs matching the proof options.
+func checkProofs(proofs []verifiable.Proof, jws bool, opts *ProofOptions) error {
+	if len(proofs) == 0 && !jws {
+		return errors.New("document has no proof")
+	}
+
+	if opts == nil {
+		return nil
+	}
+
+	expected := []struct{ field, value string }{
+		{"type", opts.SignatureType},
+		{"verificationMethod", opts.VerificationMethod},
+		{"challenge", opts.Challenge},
+		{"domain", opts.Domain},
+	}
+
+	for _, e := range expected {
+		if e.value == "" {
+			continue
+		}
+
+		if len(proofs) == 0 {
+			return fmt.Errorf("no linked data proof to check the %s against", e.field)
+		}
+
+		for _, proof := range proofs {
+			if proof[e.field] != e.value {
+				return fmt.Errorf("proof %s mismatch: expected %s", e.field, e.value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// unquote returns the string of a JSON string (e.g a JWT), and the raw document otherwise.
+func unquote(raw json.RawMessage) []byte {
+	var s string
+
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []byte(s)
+	}
+
+	return raw
+}
+
+// storedCredentials returns the stored credentials with the given IDs, in the same order, or all the stored
+// credentials which are not revoked if no IDs are given, along with their records.
+func (o *Command) storedCredentials(ids []string) ([]*verifiable.Credential,
+	map[*verifiable.Credential]*verifiablestore.Record, error) {
+	var records []*verifiablestore.Record
+
+	if len(ids) == 0 {
+		all, err := o.verifiableStore.GetCredentials()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, record := range all {
+			if !record.Revoked {
+				records = append(records, record)
+			}
+		}
+	}
+
+	for _, id := range ids {
+		records = append(records, &verifiablestore.Record{ID: id})
+	}
+
+	credentials := make([]*verifiable.Credential, len(records))
+	credentialRecords := make(map[*verifiable.Credential]*verifiablestore.Record, len(records))
+
+	for i, record := range records {
+		vc, err := o.verifiableStore.GetCredential(record.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get vc %s : %w", record.ID, err)
+		}
+
+		credentials[i] = vc
+		credentialRecords[vc] = record
+	}
+
+	return credentials, credentialRecords, nil
+}
+
+// signSubmission signs the presentation submission on behalf of the holder.
+func (o *Command) signSubmission(vp *verifiable.Presentation, holder string, opts *ProofOptions) error {
+	if opts == nil || opts.SignatureType == "" {
+		return errors.New("signature type empty")
+	}
+
+	didDoc, err := o.ctx.VDRegistry().Resolve(holder)
+	//  if did not found in VDR, look through in local storage
+	if err != nil {
+		didDoc, err = o.didStore.GetDID(holder)
+		if err != nil {
+			return fmt.Errorf("failed to get did doc from store or vdr : %w", err)
+		}
+	}
+
+	opts, err = prepareOpts(opts, didDoc, did.Authentication)
+	if err != nil {
+		return fmt.Errorf("failed to prepare proof options: %w", err)
+	}
+
+	vp.Holder = didDoc.ID
+
+	return o.addLinkedDataProof(vp, opts)
+}
+
 func isDID(str string) bool {
 	return strings.HasPrefix(str, "did:")
 }
@@ -972,3 +1334,136 @@ func getProofPurpose(method did.VerificationRelationship) (string, error) {
 
 	return "assertionMethod", nil
 }
+
+// decodePageArgs decodes the optional paging arguments of the request, nil is returned when the records are neither
+// paged, filtered nor sorted. The cursor of the arguments is resolved into their offset.
+func decodePageArgs(req io.Reader) (*PageArgs, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	page := &PageArgs{}
+
+	err := json.NewDecoder(req).Decode(page)
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if *page == (PageArgs{}) {
+		return nil, nil
+	}
+
+	page.Offset, err = command.PageOffset(page.Offset, page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := recordSortFields[strings.TrimPrefix(page.Sort, "-")]; page.Sort != "" && !ok {
+		return nil, fmt.Errorf("cannot sort records by %s", strings.TrimPrefix(page.Sort, "-"))
+	}
+
+	return page, nil
+}
+
+// queryRecords returns the records of the page. The records which are only paged are paged by the store, the
+// others are filtered, sorted and paged once all retrieved.
+func queryRecords(page *PageArgs, all func() ([]*verifiablestore.Record, error),
+	paged func(offset, limit int) (*verifiablestore.RecordPage, error)) (*RecordResult, error) {
+	if page == nil {
+		records, err := all()
+
+		return &RecordResult{Result: records}, err
+	}
+
+	if page.Sort == "" && page.Type == "" && page.SubjectID == "" && page.MyDID == "" && page.TheirDID == "" {
+		p, err := paged(page.Offset, page.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return &RecordResult{
+			Result:     p.Records,
+			Total:      p.Total,
+			NextCursor: command.NextCursor(page.Offset, len(p.Records), p.Total),
+		}, nil
+	}
+
+	records, err := all()
+	if err != nil {
+		return nil, err
+	}
+
+	records = filterRecords(records, page)
+
+	err = command.Sort(len(records), func(i, j int) { records[i], records[j] = records[j], records[i] },
+		page.Sort, recordSortFuncs(records))
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := command.PageBounds(page.Offset, page.Limit, len(records))
+
+	return &RecordResult{
+		Result:     records[start:end],
+		Total:      len(records),
+		NextCursor: command.NextCursor(start, end-start, len(records)),
+	}, nil
+}
+
+func filterRecords(records []*verifiablestore.Record, page *PageArgs) []*verifiablestore.Record {
+	var result []*verifiablestore.Record
+
+	for _, r := range records {
+		if page.Type != "" && !stringsContain(r.Type, page.Type) {
+			continue
+		}
+
+		if page.SubjectID != "" && page.SubjectID != r.SubjectID {
+			continue
+		}
+
+		if page.MyDID != "" && page.MyDID != r.MyDID {
+			continue
+		}
+
+		if page.TheirDID != "" && page.TheirDID != r.TheirDID {
+			continue
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// nolint: gochecknoglobals
+var recordSortFields = map[string]func(r *verifiablestore.Record) string{
+	"name":      func(r *verifiablestore.Record) string { return r.Name },
+	"id":        func(r *verifiablestore.Record) string { return r.ID },
+	"subjectId": func(r *verifiablestore.Record) string { return r.SubjectID },
+}
+
+func recordSortFuncs(records []*verifiablestore.Record) map[string]command.SortFunc {
+	funcs := make(map[string]command.SortFunc, len(recordSortFields))
+
+	for name, value := range recordSortFields {
+		value := value
+		funcs[name] = func(i int) string { return value(records[i]) }
+	}
+
+	return funcs
+}
+
+func stringsContain(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -12,14 +12,20 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	cryptomock "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	kmsmock "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
@@ -319,7 +325,7 @@ func TestNew(t *testing.T) {
 		require.NoError(t, err)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 13, len(handlers))
+		require.Equal(t, 17, len(handlers))
 	})
 
 	t.Run("test new command - vc store error", func(t *testing.T) {
@@ -352,6 +358,24 @@ func TestValidateVC(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test register - credential exceeding the JSON limits", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			JSONLimitsValue:      &jsonutil.Limits{MaxBytes: 100},
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		vcReq := Credential{VerifiableCredential: vc}
+		vcReqBytes, err := json.Marshal(vcReq)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.ValidateCredential(&b, bytes.NewBuffer(vcReqBytes))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), jsonutil.ErrLimitExceeded.Error())
+	})
+
 	t.Run("test register - invalid request", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
 			StorageProviderValue: mockstore.NewMockStoreProvider(),
@@ -656,6 +680,129 @@ func TestGetCredentials(t *testing.T) {
 		require.Len(t, response.Result[0].Context, 2)
 		require.Len(t, response.Result[0].Type, 1)
 	})
+
+	t.Run("test get credentials page", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: storeProvider,
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			recordBytes, e := json.Marshal(&verifiablestore.Record{Name: "vc" + strconv.Itoa(i), ID: strconv.Itoa(i)})
+			require.NoError(t, e)
+			require.NoError(t, storeProvider.Store.Put("vcname_vc"+strconv.Itoa(i), recordBytes))
+		}
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.GetCredentials(&getRW, bytes.NewBufferString(`{"offset":1,"limit":1}`))
+		require.NoError(t, cmdErr)
+
+		var response RecordResult
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 1)
+		require.Equal(t, "vc1", response.Result[0].Name)
+		require.Equal(t, 3, response.Total)
+
+		getRW.Reset()
+		cmdErr = cmd.GetCredentials(&getRW, bytes.NewBufferString(`{}`))
+		require.NoError(t, cmdErr)
+
+		response = RecordResult{}
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 3)
+		require.Zero(t, response.Total)
+
+		cmdErr = cmd.GetCredentials(&getRW, bytes.NewBufferString(`--`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		storeProvider.Store.ErrItr = errors.New("iterator error")
+
+		cmdErr = cmd.GetCredentials(&getRW, bytes.NewBufferString(`{"limit":1}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetCredentialsErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "iterator error")
+	})
+
+	t.Run("test get credentials filtered and sorted", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: storeProvider,
+		})
+		require.NoError(t, err)
+
+		for i, subject := range []string{"did:example:b", "did:example:a", "did:example:c"} {
+			recordBytes, e := json.Marshal(&verifiablestore.Record{
+				Name:      "vc" + strconv.Itoa(i),
+				ID:        strconv.Itoa(i),
+				Type:      []string{"VerifiableCredential", "Type" + strconv.Itoa(i%2)},
+				SubjectID: subject,
+			})
+			require.NoError(t, e)
+			require.NoError(t, storeProvider.Store.Put("vcname_vc"+strconv.Itoa(i), recordBytes))
+		}
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.GetCredentials(&getRW, bytes.NewBufferString(`{"type":"Type0","sort":"-name"}`))
+		require.NoError(t, cmdErr)
+
+		var response RecordResult
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 2)
+		require.Equal(t, "vc2", response.Result[0].Name)
+		require.Equal(t, "vc0", response.Result[1].Name)
+		require.Equal(t, 2, response.Total)
+		require.Empty(t, response.NextCursor)
+
+		getRW.Reset()
+		cmdErr = cmd.GetCredentials(&getRW, bytes.NewBufferString(`{"sort":"subjectId","limit":2}`))
+		require.NoError(t, cmdErr)
+
+		response = RecordResult{}
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 2)
+		require.Equal(t, "did:example:a", response.Result[0].SubjectID)
+		require.Equal(t, "did:example:b", response.Result[1].SubjectID)
+		require.NotEmpty(t, response.NextCursor)
+
+		getRW.Reset()
+		cmdErr = cmd.GetCredentials(&getRW,
+			bytes.NewBufferString(`{"sort":"subjectId","limit":2,"cursor":"`+response.NextCursor+`"}`))
+		require.NoError(t, cmdErr)
+
+		response = RecordResult{}
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 1)
+		require.Equal(t, "did:example:c", response.Result[0].SubjectID)
+		require.Empty(t, response.NextCursor)
+
+		getRW.Reset()
+		cmdErr = cmd.GetCredentials(&getRW, bytes.NewBufferString(`{"subject_id":"did:example:a"}`))
+		require.NoError(t, cmdErr)
+
+		response = RecordResult{}
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 1)
+		require.Equal(t, "vc1", response.Result[0].Name)
+
+		cmdErr = cmd.GetCredentials(&getRW, bytes.NewBufferString(`{"sort":"type"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "cannot sort records by type")
+
+		cmdErr = cmd.GetCredentials(&getRW, bytes.NewBufferString(`{"cursor":"!"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		require.NoError(t, storeProvider.Store.Put("vcname_invalid", []byte("{")))
+
+		cmdErr = cmd.GetCredentials(&getRW, bytes.NewBufferString(`{"my_did":"did:example:me"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetCredentialsErrorCode, cmdErr.Code())
+	})
 }
 
 func TestGeneratePresentation(t *testing.T) {
@@ -1620,6 +1767,41 @@ func TestGetPresentations(t *testing.T) {
 		require.Len(t, response.Result[0].Context, 2)
 		require.Len(t, response.Result[0].Type, 1)
 	})
+
+	t.Run("test get presentations page", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: storeProvider,
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			recordBytes, e := json.Marshal(&verifiablestore.Record{Name: "vp" + strconv.Itoa(i), ID: strconv.Itoa(i)})
+			require.NoError(t, e)
+			require.NoError(t, storeProvider.Store.Put("vpname_vp"+strconv.Itoa(i), recordBytes))
+		}
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.GetPresentations(&getRW, bytes.NewBufferString(`{"offset":2,"limit":2}`))
+		require.NoError(t, cmdErr)
+
+		var response RecordResult
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Len(t, response.Result, 1)
+		require.Equal(t, "vp2", response.Result[0].Name)
+		require.Equal(t, 3, response.Total)
+
+		cmdErr = cmd.GetPresentations(&getRW, bytes.NewBufferString(`--`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		storeProvider.Store.ErrItr = errors.New("iterator error")
+
+		cmdErr = cmd.GetPresentations(&getRW, bytes.NewBufferString(`{"limit":1}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetPresentationsErrorCode, cmdErr.Code())
+	})
 }
 
 func TestGeneratePresentation_prepareOpts(t *testing.T) {
@@ -2326,3 +2508,483 @@ func TestCommand_RemoveVPByName(t *testing.T) {
 		require.Contains(t, err.Error(), "remove vp by name")
 	})
 }
+
+// w3VC is a credential with the base context only, which is processed without fetching remote contexts.
+const w3VC = `{
+   "@context":"https://www.w3.org/2018/credentials/v1",
+   "id":"http://example.edu/credentials/1989",
+   "type":"VerifiableCredential",
+   "credentialSubject":{
+      "id":"did:example:iuajk1f712ebc6f1c276e12ec21"
+   },
+   "issuer":"did:example:09s12ec712ebc6f1c671ebfeb1f",
+   "issuanceDate":"2020-01-01T10:54:01Z"
+}`
+
+func TestCommand_VerifyCredential(t *testing.T) {
+	verifier := newJWTVerifier(t)
+
+	t.Run("test verify credential - success", func(t *testing.T) {
+		reqBytes, err := json.Marshal(VerifyCredentialRequest{VerifiableCredential: verifier.quote(verifier.vcJWT)})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = verifier.cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, err)
+	})
+
+	t.Run("test verify credential - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+		err := verifier.cmd.VerifyCredential(&b, bytes.NewBufferString("--"))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+		require.Equal(t, command.ValidationError, err.Type())
+	})
+
+	t.Run("test verify credential - invalid signature", func(t *testing.T) {
+		other := newJWTVerifier(t)
+
+		reqBytes, err := json.Marshal(VerifyCredentialRequest{VerifiableCredential: verifier.quote(other.vcJWT)})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := verifier.cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "verify vc : decode new credential: JWS decoding")
+	})
+
+	t.Run("test verify credential - no proof", func(t *testing.T) {
+		reqBytes, err := json.Marshal(VerifyCredentialRequest{VerifiableCredential: []byte(w3VC)})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := verifier.cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "verify vc : document has no proof")
+	})
+
+	t.Run("test verify credential - challenge of a JWS", func(t *testing.T) {
+		reqBytes, err := json.Marshal(VerifyCredentialRequest{
+			VerifiableCredential: verifier.quote(verifier.vcJWT),
+			ProofOptions:         &ProofOptions{Challenge: "challenge"},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := verifier.cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "no linked data proof to check the challenge against")
+	})
+}
+
+func TestCommand_VerifyPresentation(t *testing.T) {
+	verifier := newJWTVerifier(t)
+
+	t.Run("test verify presentation - success", func(t *testing.T) {
+		reqBytes, err := json.Marshal(VerifyPresentationRequest{VerifiablePresentation: verifier.quote(verifier.vpJWT)})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = verifier.cmd.VerifyPresentation(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, err)
+	})
+
+	t.Run("test verify presentation - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+		err := verifier.cmd.VerifyPresentation(&b, bytes.NewBufferString("--"))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+		require.Equal(t, command.ValidationError, err.Type())
+	})
+
+	t.Run("test verify presentation - invalid signature", func(t *testing.T) {
+		other := newJWTVerifier(t)
+
+		reqBytes, err := json.Marshal(VerifyPresentationRequest{VerifiablePresentation: verifier.quote(other.vpJWT)})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := verifier.cmd.VerifyPresentation(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyPresentationErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "verify vp :")
+	})
+
+	t.Run("test verify presentation - invalid credential", func(t *testing.T) {
+		other := newJWTVerifier(t)
+
+		vp := verifier.presentation(t, other.vcJWT)
+
+		reqBytes, err := json.Marshal(VerifyPresentationRequest{VerifiablePresentation: verifier.quote(vp)})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := verifier.cmd.VerifyPresentation(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyPresentationErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "verify vp : decode credentials of presentation")
+	})
+
+	t.Run("test verify presentation - embedded credential without proof", func(t *testing.T) {
+		credential, err := verifiable.ParseUnverifiedCredential([]byte(w3VC))
+		require.NoError(t, err)
+
+		vp := verifier.presentation(t, credential)
+
+		reqBytes, err := json.Marshal(VerifyPresentationRequest{VerifiablePresentation: verifier.quote(vp)})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := verifier.cmd.VerifyPresentation(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyPresentationErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "verify vp : credential 0: document has no proof")
+	})
+}
+
+func TestCheckProofs(t *testing.T) {
+	proofs := []verifiable.Proof{{
+		"type":               Ed25519Signature2018,
+		"verificationMethod": "did:example:123#key-1",
+		"challenge":          "challenge",
+		"domain":             "domain",
+	}}
+
+	require.NoError(t, checkProofs(proofs, false, nil))
+	require.NoError(t, checkProofs(proofs, false, &ProofOptions{
+		SignatureType:      Ed25519Signature2018,
+		VerificationMethod: "did:example:123#key-1",
+		Challenge:          "challenge",
+		Domain:             "domain",
+	}))
+	require.NoError(t, checkProofs(nil, true, &ProofOptions{}))
+
+	require.EqualError(t, checkProofs(nil, false, nil), "document has no proof")
+	require.EqualError(t, checkProofs(proofs, false, &ProofOptions{SignatureType: JSONWebSignature2020}),
+		"proof type mismatch: expected JsonWebSignature2020")
+	require.EqualError(t, checkProofs(proofs, false, &ProofOptions{VerificationMethod: "did:example:123#key-2"}),
+		"proof verificationMethod mismatch: expected did:example:123#key-2")
+	require.EqualError(t, checkProofs(proofs, false, &ProofOptions{Challenge: "other"}),
+		"proof challenge mismatch: expected other")
+	require.EqualError(t, checkProofs(proofs, false, &ProofOptions{Domain: "other"}),
+		"proof domain mismatch: expected other")
+	require.EqualError(t, checkProofs(nil, true, &ProofOptions{Domain: "domain"}),
+		"no linked data proof to check the domain against")
+}
+
+func TestCommand_EvaluatePresentationDefinition(t *testing.T) {
+	cmd, ids := newPresentationExchangeCommand(t)
+
+	definition := &presexch.PresentationDefinition{
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID:     "university",
+			Schema: []presexch.Schema{{URI: "https://trustbloc.github.io/context/vc/examples-v1.jsonld"}},
+		}, {
+			ID:     "any",
+			Schema: []presexch.Schema{{URI: "https://www.w3.org/2018/credentials/v1"}},
+		}},
+	}
+
+	t.Run("test evaluate presentation definition - success", func(t *testing.T) {
+		reqBytes, err := json.Marshal(PresentationDefinitionRequest{PresentationDefinition: definition})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.EvaluatePresentationDefinition(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, cmdErr)
+
+		var response PresentationDefinitionResult
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.True(t, response.Satisfied)
+		require.Len(t, response.Matches, 2)
+		require.Len(t, response.Matches["university"], 1)
+		require.Equal(t, ids[0], response.Matches["university"][0].ID)
+		require.Len(t, response.Matches["any"], 2)
+	})
+
+	t.Run("test evaluate presentation definition - not satisfied", func(t *testing.T) {
+		reqBytes, err := json.Marshal(PresentationDefinitionRequest{
+			PresentationDefinition: &presexch.PresentationDefinition{
+				InputDescriptors: append(definition.InputDescriptors, &presexch.InputDescriptor{
+					ID:     "unknown",
+					Schema: []presexch.Schema{{URI: "https://example.com/unknown"}},
+				}),
+			},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.EvaluatePresentationDefinition(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, cmdErr)
+
+		var response PresentationDefinitionResult
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.False(t, response.Satisfied)
+		require.Len(t, response.Matches, 2)
+	})
+
+	t.Run("test evaluate presentation definition - revoked credential", func(t *testing.T) {
+		cmd, ids := newPresentationExchangeCommand(t)
+		require.NoError(t, cmd.verifiableStore.MarkCredentialRevoked(ids[0]))
+
+		reqBytes, err := json.Marshal(PresentationDefinitionRequest{PresentationDefinition: definition})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.EvaluatePresentationDefinition(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, cmdErr)
+
+		var response PresentationDefinitionResult
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.False(t, response.Satisfied)
+		require.Len(t, response.Matches["any"], 1)
+		require.Equal(t, ids[1], response.Matches["any"][0].ID)
+	})
+
+	t.Run("test evaluate presentation definition - invalid request", func(t *testing.T) {
+		for _, req := range []string{"--", "{}"} {
+			var b bytes.Buffer
+			cmdErr := cmd.EvaluatePresentationDefinition(&b, bytes.NewBufferString(req))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+		}
+	})
+
+	t.Run("test evaluate presentation definition - store error", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: storeProvider})
+		require.NoError(t, err)
+
+		credential, err := verifiable.ParseUnverifiedCredential([]byte(vc))
+		require.NoError(t, err)
+		require.NoError(t, cmd.verifiableStore.SaveCredential(sampleCredentialName, credential))
+
+		storeProvider.Store.ErrGet = errors.New("get error")
+
+		reqBytes, err := json.Marshal(PresentationDefinitionRequest{PresentationDefinition: definition})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.EvaluatePresentationDefinition(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, EvaluatePresentationDefinitionErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "get stored credentials : get vc "+sampleVCID+" : failed to get vc: get error")
+	})
+}
+
+func TestCommand_CreatePresentationSubmission(t *testing.T) {
+	cmd, ids := newPresentationExchangeCommand(t)
+
+	definition := &presexch.PresentationDefinition{
+		ID: "definition",
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID:     "any",
+			Schema: []presexch.Schema{{URI: "https://www.w3.org/2018/credentials/v1"}},
+		}},
+	}
+
+	t.Run("test create presentation submission - success", func(t *testing.T) {
+		for _, credentialIDs := range [][]string{nil, {ids[1], ids[0]}} {
+			reqBytes, err := json.Marshal(PresentationSubmissionRequest{
+				PresentationDefinition: definition,
+				CredentialIDs:          credentialIDs,
+			})
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			cmdErr := cmd.CreatePresentationSubmission(&b, bytes.NewBuffer(reqBytes))
+			require.NoError(t, cmdErr)
+
+			var response Presentation
+			require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+			vp, err := verifiable.ParseUnverifiedPresentation(response.VerifiablePresentation)
+			require.NoError(t, err)
+			require.Contains(t, vp.Type, presexch.PresentationSubmissionJSONLDType)
+			require.Empty(t, vp.Proofs)
+
+			vcs, err := vp.MarshalledCredentials()
+			require.NoError(t, err)
+			require.Len(t, vcs, 1)
+
+			vc, err := verifiable.ParseUnverifiedCredential(vcs[0])
+			require.NoError(t, err)
+
+			if credentialIDs != nil {
+				require.Equal(t, credentialIDs[0], vc.ID)
+			}
+		}
+	})
+
+	t.Run("test create presentation submission - invalid request", func(t *testing.T) {
+		for _, req := range []string{"--", "{}"} {
+			var b bytes.Buffer
+			cmdErr := cmd.CreatePresentationSubmission(&b, bytes.NewBufferString(req))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+		}
+	})
+
+	t.Run("test create presentation submission - errors", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			request PresentationSubmissionRequest
+			err     string
+		}{{
+			name: "unknown credential",
+			request: PresentationSubmissionRequest{
+				PresentationDefinition: definition,
+				CredentialIDs:          []string{"http://example.edu/credentials/unknown"},
+			},
+			err: "get stored credentials : get vc http://example.edu/credentials/unknown",
+		}, {
+			name: "unsatisfied definition",
+			request: PresentationSubmissionRequest{
+				PresentationDefinition: &presexch.PresentationDefinition{
+					InputDescriptors: []*presexch.InputDescriptor{{
+						ID:     "unknown",
+						Schema: []presexch.Schema{{URI: "https://example.com/unknown"}},
+					}},
+				},
+			},
+			err: "create submission : input descriptor unknown: credentials do not satisfy requirements",
+		}, {
+			name:    "signature type empty",
+			request: PresentationSubmissionRequest{PresentationDefinition: definition, DID: "did:peer:123"},
+			err:     "sign submission : signature type empty",
+		}, {
+			name: "did not found",
+			request: PresentationSubmissionRequest{
+				PresentationDefinition: definition,
+				DID:                    invalidDID,
+				ProofOptions:           &ProofOptions{SignatureType: Ed25519Signature2018},
+			},
+			err: "sign submission : failed to get did doc from store or vdr",
+		}}
+
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				reqBytes, err := json.Marshal(tc.request)
+				require.NoError(t, err)
+
+				var b bytes.Buffer
+				cmdErr := cmd.CreatePresentationSubmission(&b, bytes.NewBuffer(reqBytes))
+				require.Error(t, cmdErr)
+				require.Equal(t, CreatePresentationSubmissionErrorCode, cmdErr.Code())
+				require.Contains(t, cmdErr.Error(), tc.err)
+			})
+		}
+	})
+}
+
+// newPresentationExchangeCommand returns a command storing two credentials, with and without the example context.
+func newPresentationExchangeCommand(t *testing.T) (*Command, []string) {
+	t.Helper()
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdr.ResolveOpts) (*did.Doc, error) {
+				return nil, errors.New("not found")
+			},
+		},
+		KMSValue:    &kmsmock.KeyManager{},
+		CryptoValue: &cryptomock.Crypto{},
+	})
+	require.NoError(t, err)
+
+	var ids []string
+
+	for i, raw := range []string{vc, strings.Replace(w3VC, sampleVCID, sampleVCID+"-w3", 1)} {
+		credential, err := verifiable.ParseUnverifiedCredential([]byte(raw))
+		require.NoError(t, err)
+
+		require.NoError(t, cmd.verifiableStore.SaveCredential(fmt.Sprintf("%s-%d", sampleCredentialName, i), credential))
+
+		ids = append(ids, credential.ID)
+	}
+
+	return cmd, ids
+}
+
+// jwtVerifier is a command resolving the issuer and holder DID of a JWT credential and presentation to its key.
+type jwtVerifier struct {
+	cmd    *Command
+	signer signature.Signer
+	vcJWT  string
+	vpJWT  string
+}
+
+func newJWTVerifier(t *testing.T) *jwtVerifier {
+	t.Helper()
+
+	signer, err := signature.NewSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	issuer := "did:example:09s12ec712ebc6f1c671ebfeb1f"
+
+	vm := did.NewVerificationMethodFromBytes(issuer+"#key-1", "Ed25519VerificationKey2018", issuer,
+		signer.PublicKeyBytes())
+
+	didDoc := &did.Doc{
+		ID:                 issuer,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		AssertionMethod:    []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+	}
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue:      &mockvdr.MockVDRegistry{ResolveValue: didDoc},
+		KMSValue:             &kmsmock.KeyManager{},
+		CryptoValue:          &cryptomock.Crypto{},
+	})
+	require.NoError(t, err)
+
+	credential, err := verifiable.ParseUnverifiedCredential([]byte(w3VC))
+	require.NoError(t, err)
+
+	claims, err := credential.JWTClaims(false)
+	require.NoError(t, err)
+
+	v := &jwtVerifier{cmd: cmd, signer: signer}
+
+	v.vcJWT, err = claims.MarshalJWS(verifiable.EdDSA, signer, issuer+"#key-1")
+	require.NoError(t, err)
+
+	v.vpJWT = v.presentation(t, v.vcJWT)
+
+	return v
+}
+
+// presentation returns the JWT presentation of the credential (a JWT or an embedded credential), signed by the holder.
+func (v *jwtVerifier) presentation(t *testing.T, credential interface{}) string {
+	t.Helper()
+
+	holder := "did:example:09s12ec712ebc6f1c671ebfeb1f"
+
+	vp := &verifiable.Presentation{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		Type:    []string{"VerifiablePresentation"},
+		Holder:  holder,
+	}
+	require.NoError(t, vp.SetCredentials(credential))
+
+	claims, err := vp.JWTClaims(nil, false)
+	require.NoError(t, err)
+
+	vpJWT, err := claims.MarshalJWS(verifiable.EdDSA, v.signer, holder+"#key-1")
+	require.NoError(t, err)
+
+	return vpJWT
+}
+
+func (v *jwtVerifier) quote(s string) []byte {
+	return []byte(strconv.Quote(s))
+}
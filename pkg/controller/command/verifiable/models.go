@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
@@ -47,7 +48,8 @@ type ProofOptions struct {
 	Domain string `json:"domain,omitempty"`
 	// Challenge is a random or pseudo-random value option authentication
 	Challenge string `json:"challenge,omitempty"`
-	// SignatureType signature type used for signing
+	// SignatureType signature type used for signing, the proof type of a signature suite of the framework (e.g.
+	// Ed25519Signature2018) or registered to it (see aries.WithSignatureSuite)
 	SignatureType string `json:"signatureType,omitempty"`
 	// proofPurpose is purpose of the proof.
 	proofPurpose string
@@ -71,6 +73,46 @@ type SignCredentialResponse struct {
 	VerifiableCredential json.RawMessage `json:"verifiableCredential,omitempty"`
 }
 
+// VerifyCredentialRequest is model for verify credential request, the proof options (signature type, verification
+// method, challenge and domain) being matched against the linked data proofs of the credential.
+type VerifyCredentialRequest struct {
+	VerifiableCredential json.RawMessage `json:"verifiableCredential,omitempty"`
+	*ProofOptions
+}
+
+// VerifyPresentationRequest is model for verify presentation request, the proof options (signature type, verification
+// method, challenge and domain) being matched against the linked data proofs of the presentation.
+type VerifyPresentationRequest struct {
+	VerifiablePresentation json.RawMessage `json:"verifiablePresentation,omitempty"`
+	*ProofOptions
+}
+
+// PresentationDefinitionRequest is model for evaluating a presentation definition against the stored credentials.
+type PresentationDefinitionRequest struct {
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentationDefinition,omitempty"`
+}
+
+// PresentationDefinitionResult is model for the stored credentials matching a presentation definition.
+type PresentationDefinitionResult struct {
+	// Matches are the records of the credentials matching each input descriptor, by input descriptor ID
+	Matches map[string][]*verifiable.Record `json:"matches,omitempty"`
+
+	// Satisfied is set when every input descriptor is matched by a credential
+	Satisfied bool `json:"satisfied"`
+}
+
+// PresentationSubmissionRequest is model for creating a presentation submitting stored credentials against a
+// presentation definition.
+type PresentationSubmissionRequest struct {
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentationDefinition,omitempty"`
+	// CredentialIDs are the IDs of the stored credentials to submit, by order of preference, all the stored
+	// credentials which are not revoked being considered if empty.
+	CredentialIDs []string `json:"credentialIDs,omitempty"`
+	// DID of the holder signing the presentation, which is not signed if empty.
+	DID string `json:"did,omitempty"`
+	*ProofOptions
+}
+
 // PresentationExt is model for presentation with fields related to command features.
 type PresentationExt struct {
 	Presentation
@@ -101,10 +143,48 @@ type NameArg struct {
 	Name string `json:"name"`
 }
 
+// PageArgs model
+//
+// This is used for paging, filtering and sorting the credential or presentation records.
+//
+type PageArgs struct {
+	// Offset is the number of records to skip
+	Offset int `json:"offset,omitempty"`
+
+	// Limit is the maximum number of records to return, all the records are returned when zero
+	Limit int `json:"limit,omitempty"`
+
+	// Cursor of the page of records to return, as returned in the NextCursor of the previous page.
+	// It takes precedence over the offset.
+	Cursor string `json:"cursor,omitempty"`
+
+	// Sort is the field to sort the records by (name, id or subjectId), prefixed with "-" for the descending order.
+	// The records are sorted by name by default.
+	Sort string `json:"sort,omitempty"`
+
+	// Type the records must have
+	Type string `json:"type,omitempty"`
+
+	// SubjectID of the records
+	SubjectID string `json:"subject_id,omitempty"`
+
+	// MyDID of the records
+	MyDID string `json:"my_did,omitempty"`
+
+	// TheirDID of the records
+	TheirDID string `json:"their_did,omitempty"`
+}
+
 // RecordResult holds the credential records.
 type RecordResult struct {
 	// Result
 	Result []*verifiable.Record `json:"result,omitempty"`
+
+	// Total number of records, set when the records are paged
+	Total int `json:"total,omitempty"`
+
+	// NextCursor is the cursor of the next page of records, empty for the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // Presentation is model for verifiable presentation.
@@ -9,8 +9,10 @@ package controller
 import (
 	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	healthcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/health"
 	introducecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	issuecredentialcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
@@ -18,10 +20,13 @@ import (
 	messagingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	outofbandcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	presentproofcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	vcwalletcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
 	vdrcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	batchrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/batch"
 	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
+	healthrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/health"
 	introducerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	issuecredentialrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
 	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
@@ -29,22 +34,26 @@ import (
 	messagingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/messaging"
 	outofbandrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
 	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
+	vcwalletrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcwallet"
 	vdrrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/plugin"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
 type allOpts struct {
 	webhookURLs  []string
+	webhookOpts  []webnotifier.HTTPNotifierOpt
 	defaultLabel string
 	autoAccept   bool
 	msgHandler   command.MessageHandler
 	notifier     command.Notifier
+	eventBus     *webnotifier.EventBus
+	health       *health.Checker
 }
 
-const wsPath = "/ws"
-
 // Opt represents a controller option.
 type Opt func(opts *allOpts)
 
@@ -55,6 +64,14 @@ func WithWebhookURLs(webhookURLs ...string) Opt {
 	}
 }
 
+// WithWebhookOpts is an option for configuring the delivery of the notifications to the webhook URLs, e.g. their
+// signing, retries and dead-letter store.
+func WithWebhookOpts(webhookOpts ...webnotifier.HTTPNotifierOpt) Opt {
+	return func(opts *allOpts) {
+		opts.webhookOpts = webhookOpts
+	}
+}
+
 // WithNotifier is an option for setting up a notifier which will notify clients of events.
 func WithNotifier(notifier command.Notifier) Opt {
 	return func(opts *allOpts) {
@@ -62,6 +79,16 @@ func WithNotifier(notifier command.Notifier) Opt {
 	}
 }
 
+// WithEventBus is an option for publishing the events of the framework (the protocol states, actions and messages
+// notified by the controller) on the event bus, so that the webhooks and the event stream clients don't miss the
+// events published while they, or the agent, were down (see webnotifier.NewDurable). The webhook URLs are durable
+// subscriptions of the bus, closing the bus stops their deliveries. It's ignored if a notifier is set with WithNotifier.
+func WithEventBus(bus *webnotifier.EventBus) Opt {
+	return func(opts *allOpts) {
+		opts.eventBus = bus
+	}
+}
+
 // WithDefaultLabel is an option allowing for the defaultLabel to be set.
 func WithDefaultLabel(defaultLabel string) Opt {
 	return func(opts *allOpts) {
@@ -83,6 +110,34 @@ func WithMessageHandler(handler command.MessageHandler) Opt {
 	}
 }
 
+// WithHealthChecker is an option exposing the liveness and readiness of the agent reported by the checker
+// (see aries.HealthChecker), e.g. for the probes of Kubernetes.
+func WithHealthChecker(checker *health.Checker) Opt {
+	return func(opts *allOpts) {
+		opts.health = checker
+	}
+}
+
+// getNotifier returns the notifier of the options, by default a web notifier publishing the events on the event bus
+// if one is set, serving them on eventsPath unless it's empty.
+func (o *allOpts) getNotifier(eventsPath string) (command.Notifier, error) {
+	if o.notifier != nil {
+		return o.notifier, nil
+	}
+
+	if o.eventBus == nil {
+		return webnotifier.New(webnotifier.WSPath, eventsPath, o.webhookURLs, o.webhookOpts...), nil
+	}
+
+	notifier, err := webnotifier.NewDurable(webnotifier.WSPath, eventsPath, o.eventBus, o.webhookURLs,
+		o.webhookOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create durable notifier: %w", err)
+	}
+
+	return notifier, nil
+}
+
 // GetRESTHandlers returns all REST handlers provided by controller.
 func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error) { // nolint: funlen,gocyclo
 	restAPIOpts := &allOpts{}
@@ -91,9 +146,9 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		opt(restAPIOpts)
 	}
 
-	notifier := restAPIOpts.notifier
-	if notifier == nil {
-		notifier = webnotifier.New(wsPath, restAPIOpts.webhookURLs)
+	notifier, err := restAPIOpts.getNotifier(webnotifier.EventsPath)
+	if err != nil {
+		return nil, err
 	}
 
 	// DID Exchange REST operation
@@ -152,7 +207,10 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	}
 
 	// kms command operation
-	kmscmd := kmsrest.New(ctx)
+	kmscmd, err := kmsrest.New(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// creat handlers from all operations
 	var allHandlers []rest.Handler
@@ -166,6 +224,26 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, introduceOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, outofbandOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, kmscmd.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, vcwalletrest.New(ctx).GetRESTHandlers()...)
+
+	// REST handlers of the protocol plugins
+	pluginHandlers, err := plugin.RESTHandlers(ctx, notifier)
+	if err != nil {
+		return nil, err
+	}
+
+	allHandlers = append(allHandlers, pluginHandlers...)
+
+	// batch REST operation, invoking the operations above
+	batchOp := batchrest.New(allHandlers, batchrest.WithTransactions(ctx.StorageProvider(),
+		func(txStorage storage.Provider) ([]rest.Handler, error) {
+			return storageBackedHandlers(&storageOverride{Provider: ctx, storage: txStorage})
+		}))
+	allHandlers = append(allHandlers, batchOp.GetRESTHandlers()...)
+
+	if restAPIOpts.health != nil {
+		allHandlers = append(allHandlers, healthrest.New(restAPIOpts.health).GetRESTHandlers()...)
+	}
 
 	nhp, ok := notifier.(handlerProvider)
 	if ok {
@@ -179,6 +257,33 @@ type handlerProvider interface {
 	GetRESTHandlers() []rest.Handler
 }
 
+// storageOverride is the context of the operations making their updates through another storage provider.
+type storageOverride struct {
+	*context.Provider
+	storage storage.Provider
+}
+
+// StorageProvider returns the storage provider overriding that of the context.
+func (p *storageOverride) StorageProvider() storage.Provider {
+	return p.storage
+}
+
+// storageBackedHandlers returns the REST handlers of the operations whose updates are only made through the storage
+// provider of the context, which can be invoked in transactional batches.
+func storageBackedHandlers(ctx *storageOverride) ([]rest.Handler, error) {
+	vdrOp, err := vdrrest.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	verifiableOp, err := verifiablerest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create verifiable rest command : %w", err)
+	}
+
+	return append(vdrOp.GetRESTHandlers(), verifiableOp.GetRESTHandlers()...), nil
+}
+
 // GetCommandHandlers returns all command handlers provided by controller.
 func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler, error) { // nolint: funlen,gocyclo
 	cmdOpts := &allOpts{}
@@ -187,9 +292,9 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		opt(cmdOpts)
 	}
 
-	notifier := cmdOpts.notifier
-	if notifier == nil {
-		notifier = webnotifier.New(wsPath, cmdOpts.webhookURLs)
+	notifier, err := cmdOpts.getNotifier("")
+	if err != nil {
+		return nil, err
 	}
 
 	// did exchange command operation
@@ -248,7 +353,10 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	}
 
 	// kms command operation
-	kmscmd := kms.New(ctx)
+	kmscmd, err := kms.New(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	var allHandlers []command.Handler
 	allHandlers = append(allHandlers, didexcmd.GetHandlers()...)
@@ -261,6 +369,19 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, presentproof.GetHandlers()...)
 	allHandlers = append(allHandlers, introduce.GetHandlers()...)
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
+	allHandlers = append(allHandlers, vcwalletcmd.New(ctx).GetHandlers()...)
+
+	if cmdOpts.health != nil {
+		allHandlers = append(allHandlers, healthcmd.New(cmdOpts.health).GetHandlers()...)
+	}
+
+	// commands of the protocol plugins
+	pluginHandlers, err := plugin.CommandHandlers(ctx, notifier)
+	if err != nil {
+		return nil, err
+	}
+
+	allHandlers = append(allHandlers, pluginHandlers...)
 
 	return allHandlers, nil
 }
@@ -7,16 +7,25 @@ SPDX-License-Identifier: Apache-2.0
 package controller
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/plugin"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
+	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
 )
 
 func TestGetRESTHandlers(t *testing.T) {
@@ -115,6 +124,75 @@ func TestWithWebhookNotifierOption(t *testing.T) {
 	require.Equal(t, webhookURLs, controllerOpts.webhookURLs)
 }
 
+func TestWithWebhookOptsOption(t *testing.T) {
+	controllerOpts := &allOpts{}
+
+	webhookOptsOpt := WithWebhookOpts(webnotifier.WithSigningKey([]byte("secret")))
+
+	webhookOptsOpt(controllerOpts)
+
+	require.Len(t, controllerOpts.webhookOpts, 1)
+}
+
+func TestWithEventBusOption(t *testing.T) {
+	newBus := func(t *testing.T) *webnotifier.EventBus {
+		t.Helper()
+
+		store, err := mem.NewProvider().OpenStore(webnotifier.EventStoreName)
+		require.NoError(t, err)
+
+		bus, err := webnotifier.NewEventBus(store)
+		require.NoError(t, err)
+
+		return bus
+	}
+
+	t.Run("events published on the bus", func(t *testing.T) {
+		bus := newBus(t)
+		defer bus.Close()
+
+		framework, err := aries.New(defaults.WithInboundHTTPAddr(":26508", "", "", ""))
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+
+		handlers, err := GetRESTHandlers(ctx, WithEventBus(bus), WithWebhookURLs("http://localhost:8080"))
+		require.NoError(t, err)
+		require.NotEmpty(t, handlers)
+
+		// the webhook is a durable subscription of the bus
+		require.Equal(t, []string{"webhook_http://localhost:8080"}, bus.Subscriptions())
+	})
+
+	t.Run("notifier set", func(t *testing.T) {
+		notifier := webhook.NewMockWebhookNotifier()
+
+		opts := &allOpts{}
+		WithEventBus(newBus(t))(opts)
+		WithNotifier(notifier)(opts)
+
+		n, err := opts.getNotifier("")
+		require.NoError(t, err)
+		require.Equal(t, notifier, n)
+	})
+
+	t.Run("bus closed", func(t *testing.T) {
+		bus := newBus(t)
+		bus.Close()
+
+		opts := &allOpts{}
+		WithEventBus(bus)(opts)
+		WithWebhookURLs("http://localhost:8080")(opts)
+
+		_, err := opts.getNotifier("")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create durable notifier")
+	})
+}
+
 func TestWithDefaultLabelOption(t *testing.T) {
 	controllerOpts := &allOpts{}
 
@@ -145,3 +223,92 @@ func TestWithMessageHandler(t *testing.T) {
 
 	require.NotNil(t, controllerOpts.msgHandler)
 }
+
+func TestPluginHandlers(t *testing.T) {
+	plugin.Register(plugin.Plugin{
+		Name: "loyalty",
+		Service: func(api.Provider) (dispatcher.ProtocolService, error) {
+			return &mockdidexchange.MockDIDExchangeSvc{ProtocolName: "loyalty"}, nil
+		},
+		Commands: func(*context.Provider, command.Notifier) ([]command.Handler, error) {
+			return []command.Handler{cmdutil.NewCommandHandler("loyalty", "GetPoints", nil)}, nil
+		},
+		REST: func(*context.Provider, command.Notifier) ([]rest.Handler, error) {
+			return []rest.Handler{cmdutil.NewHTTPHandler("/loyalty/points", http.MethodGet, nil)}, nil
+		},
+	})
+	defer plugin.Unregister("loyalty")
+
+	t.Run("commands", func(t *testing.T) {
+		framework, err := aries.New()
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+
+		handlers, err := GetCommandHandlers(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "GetPoints", handlers[len(handlers)-1].Method())
+	})
+
+	t.Run("REST handlers", func(t *testing.T) {
+		framework, err := aries.New()
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+
+		handlers, err := GetRESTHandlers(ctx)
+		require.NoError(t, err)
+
+		var found bool
+
+		for _, h := range handlers {
+			found = found || h.Path() == "/loyalty/points"
+		}
+
+		require.True(t, found)
+	})
+}
+
+func TestHealthHandlers(t *testing.T) {
+	t.Run("commands", func(t *testing.T) {
+		framework, err := aries.New()
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+
+		handlers, err := GetCommandHandlers(ctx, WithHealthChecker(framework.HealthChecker()))
+		require.NoError(t, err)
+		require.Equal(t, "health", handlers[len(handlers)-1].Name())
+		require.Equal(t, "Ready", handlers[len(handlers)-1].Method())
+	})
+
+	t.Run("REST handlers", func(t *testing.T) {
+		framework, err := aries.New()
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+
+		handlers, err := GetRESTHandlers(ctx, WithHealthChecker(framework.HealthChecker()))
+		require.NoError(t, err)
+
+		var found bool
+
+		for _, h := range handlers {
+			found = found || h.Path() == "/health/ready"
+		}
+
+		require.True(t, found)
+	})
+}
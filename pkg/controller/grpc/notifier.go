@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc
+
+import (
+	"errors"
+	"sync"
+
+	pb "github.com/hyperledger/aries-framework-go/pkg/controller/grpc/proto/controller_go_proto"
+)
+
+// subscriptionBufferSize is the number of events buffered for a subscriber, the events are dropped for the
+// subscribers not keeping up.
+const subscriptionBufferSize = 100
+
+// Notifier is a dispatcher streaming the notified events to the subscribers of the gRPC controller service.
+type Notifier struct {
+	subscriptions map[*subscription]struct{}
+	lock          sync.RWMutex
+}
+
+type subscription struct {
+	topics map[string]struct{}
+	events chan *pb.Event
+}
+
+// NewNotifier returns a new instance of a Notifier.
+func NewNotifier() *Notifier {
+	return &Notifier{subscriptions: make(map[*subscription]struct{})}
+}
+
+// Notify sends the message to the subscribers of the topic.
+func (n *Notifier) Notify(topic string, message []byte) error {
+	if topic == "" {
+		return errors.New("cannot notify with an empty topic")
+	}
+
+	if len(message) == 0 {
+		return errors.New("cannot notify with an empty message")
+	}
+
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	for sub := range n.subscriptions {
+		if !sub.matches(topic) {
+			continue
+		}
+
+		select {
+		case sub.events <- &pb.Event{Topic: topic, Message: message}:
+		default:
+			logger.Warnf("dropped %s event of a gRPC subscriber not keeping up", topic)
+		}
+	}
+
+	return nil
+}
+
+func (n *Notifier) subscribe(topics []string) *subscription {
+	sub := &subscription{
+		topics: make(map[string]struct{}, len(topics)),
+		events: make(chan *pb.Event, subscriptionBufferSize),
+	}
+
+	for _, topic := range topics {
+		sub.topics[topic] = struct{}{}
+	}
+
+	n.lock.Lock()
+	n.subscriptions[sub] = struct{}{}
+	n.lock.Unlock()
+
+	return sub
+}
+
+func (n *Notifier) unsubscribe(sub *subscription) {
+	n.lock.Lock()
+	delete(n.subscriptions, sub)
+	n.lock.Unlock()
+}
+
+// matches tells whether the subscription is to the topic, a subscription to no topics being to all of them.
+func (s *subscription) matches(topic string) bool {
+	if len(s.topics) == 0 {
+		return true
+	}
+
+	_, ok := s.topics[topic]
+
+	return ok
+}
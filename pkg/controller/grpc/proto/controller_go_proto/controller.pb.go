@@ -0,0 +1,554 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: controller/controller.proto
+
+package controller_go_proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Command identifies a command of the controller, e.g didexchange CreateInvitation.
+type Command struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Method               string   `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Command) Reset()         { *m = Command{} }
+func (m *Command) String() string { return proto.CompactTextString(m) }
+func (*Command) ProtoMessage()    {}
+func (*Command) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8156a3278bcb17a, []int{0}
+}
+
+func (m *Command) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Command.Unmarshal(m, b)
+}
+func (m *Command) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Command.Marshal(b, m, deterministic)
+}
+func (m *Command) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Command.Merge(m, src)
+}
+func (m *Command) XXX_Size() int {
+	return xxx_messageInfo_Command.Size(m)
+}
+func (m *Command) XXX_DiscardUnknown() {
+	xxx_messageInfo_Command.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Command proto.InternalMessageInfo
+
+func (m *Command) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Command) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+type CommandsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandsRequest) Reset()         { *m = CommandsRequest{} }
+func (m *CommandsRequest) String() string { return proto.CompactTextString(m) }
+func (*CommandsRequest) ProtoMessage()    {}
+func (*CommandsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8156a3278bcb17a, []int{1}
+}
+
+func (m *CommandsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandsRequest.Unmarshal(m, b)
+}
+func (m *CommandsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandsRequest.Marshal(b, m, deterministic)
+}
+func (m *CommandsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandsRequest.Merge(m, src)
+}
+func (m *CommandsRequest) XXX_Size() int {
+	return xxx_messageInfo_CommandsRequest.Size(m)
+}
+func (m *CommandsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandsRequest proto.InternalMessageInfo
+
+type CommandsResponse struct {
+	Commands             []*Command `protobuf:"bytes,1,rep,name=commands,proto3" json:"commands,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CommandsResponse) Reset()         { *m = CommandsResponse{} }
+func (m *CommandsResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandsResponse) ProtoMessage()    {}
+func (*CommandsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8156a3278bcb17a, []int{2}
+}
+
+func (m *CommandsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandsResponse.Unmarshal(m, b)
+}
+func (m *CommandsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandsResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandsResponse.Merge(m, src)
+}
+func (m *CommandsResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandsResponse.Size(m)
+}
+func (m *CommandsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandsResponse proto.InternalMessageInfo
+
+func (m *CommandsResponse) GetCommands() []*Command {
+	if m != nil {
+		return m.Commands
+	}
+	return nil
+}
+
+// CommandRequest carries the JSON request of the command, the same as the body of the REST API request.
+type CommandRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Method               string   `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Payload              []byte   `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandRequest) Reset()         { *m = CommandRequest{} }
+func (m *CommandRequest) String() string { return proto.CompactTextString(m) }
+func (*CommandRequest) ProtoMessage()    {}
+func (*CommandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8156a3278bcb17a, []int{3}
+}
+
+func (m *CommandRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandRequest.Unmarshal(m, b)
+}
+func (m *CommandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandRequest.Marshal(b, m, deterministic)
+}
+func (m *CommandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandRequest.Merge(m, src)
+}
+func (m *CommandRequest) XXX_Size() int {
+	return xxx_messageInfo_CommandRequest.Size(m)
+}
+func (m *CommandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandRequest proto.InternalMessageInfo
+
+func (m *CommandRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommandRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *CommandRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+// CommandResponse carries the JSON response of the command, the same as the body of the REST API response.
+type CommandResponse struct {
+	Payload              []byte   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandResponse) Reset()         { *m = CommandResponse{} }
+func (m *CommandResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandResponse) ProtoMessage()    {}
+func (*CommandResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8156a3278bcb17a, []int{4}
+}
+
+func (m *CommandResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandResponse.Unmarshal(m, b)
+}
+func (m *CommandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandResponse.Merge(m, src)
+}
+func (m *CommandResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandResponse.Size(m)
+}
+func (m *CommandResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandResponse proto.InternalMessageInfo
+
+func (m *CommandResponse) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	Topics               []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8156a3278bcb17a, []int{5}
+}
+
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRequest.Unmarshal(m, b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRequest.Size(m)
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+// Event carries the JSON message of an event, the same as the message delivered by the webhooks.
+type Event struct {
+	Topic                string   `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Message              []byte   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8156a3278bcb17a, []int{6}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *Event) GetMessage() []byte {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Command)(nil), "aries.controller.Command")
+	proto.RegisterType((*CommandsRequest)(nil), "aries.controller.CommandsRequest")
+	proto.RegisterType((*CommandsResponse)(nil), "aries.controller.CommandsResponse")
+	proto.RegisterType((*CommandRequest)(nil), "aries.controller.CommandRequest")
+	proto.RegisterType((*CommandResponse)(nil), "aries.controller.CommandResponse")
+	proto.RegisterType((*SubscribeRequest)(nil), "aries.controller.SubscribeRequest")
+	proto.RegisterType((*Event)(nil), "aries.controller.Event")
+}
+
+func init() {
+	proto.RegisterFile("controller/controller.proto", fileDescriptor_e8156a3278bcb17a)
+}
+
+var fileDescriptor_e8156a3278bcb17a = []byte{
+	// 369 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xcd, 0x4f, 0xea, 0x40,
+	0x14, 0xc5, 0xd3, 0xc7, 0xe3, 0xeb, 0x3e, 0xf2, 0x1e, 0x6f, 0x62, 0xb0, 0xe2, 0xa6, 0x74, 0x45,
+	0x34, 0xb4, 0x06, 0x43, 0xdc, 0x4b, 0x58, 0xe8, 0xc2, 0xc4, 0x9a, 0x68, 0x74, 0x43, 0xfa, 0x71,
+	0x2d, 0x0d, 0x6d, 0xa7, 0xce, 0x4c, 0x55, 0xfe, 0x76, 0x37, 0xc6, 0x61, 0x5a, 0x10, 0x85, 0xc4,
+	0x55, 0xe7, 0x9c, 0x9c, 0x7b, 0x7b, 0xe6, 0x97, 0x81, 0x43, 0x9f, 0xa6, 0x82, 0xd1, 0x38, 0x46,
+	0x66, 0xaf, 0x8e, 0x56, 0xc6, 0xa8, 0xa0, 0xa4, 0xed, 0xb2, 0x08, 0xb9, 0xb5, 0xf2, 0xcd, 0x11,
+	0xd4, 0xc7, 0x34, 0x49, 0xdc, 0x34, 0x20, 0x04, 0x7e, 0xa7, 0x6e, 0x82, 0xba, 0x66, 0x68, 0xfd,
+	0xa6, 0x23, 0xcf, 0xa4, 0x03, 0xb5, 0x04, 0xc5, 0x8c, 0x06, 0xfa, 0x2f, 0xe9, 0x2a, 0x65, 0xfe,
+	0x87, 0x7f, 0x6a, 0x8c, 0x3b, 0xf8, 0x94, 0x23, 0x17, 0xe6, 0x05, 0xb4, 0x57, 0x16, 0xcf, 0x68,
+	0xca, 0x91, 0x8c, 0xa0, 0xe1, 0x2b, 0x4f, 0xd7, 0x8c, 0x4a, 0xff, 0xcf, 0xf0, 0xc0, 0xda, 0xac,
+	0x60, 0xa9, 0x29, 0xa7, 0x8c, 0x9a, 0xb7, 0xf0, 0xb7, 0x30, 0x97, 0xcb, 0x7f, 0xd2, 0x8d, 0xe8,
+	0x50, 0xcf, 0xdc, 0x45, 0x4c, 0xdd, 0x40, 0xaf, 0x18, 0x5a, 0xbf, 0xe5, 0x14, 0xd2, 0x3c, 0x2e,
+	0x5b, 0x97, 0x0d, 0xd7, 0xc2, 0xda, 0xe7, 0xf0, 0x11, 0xb4, 0x6f, 0x72, 0x8f, 0xfb, 0x2c, 0xf2,
+	0xb0, 0xa8, 0xd1, 0x81, 0x9a, 0xa0, 0x59, 0xe4, 0x2f, 0x6f, 0xd3, 0x74, 0x94, 0x32, 0xcf, 0xa0,
+	0x3a, 0x79, 0xc6, 0x54, 0x90, 0x3d, 0xa8, 0x4a, 0x4b, 0x15, 0x5d, 0x8a, 0x8f, 0x9f, 0x24, 0xc8,
+	0xb9, 0x1b, 0xa2, 0xac, 0xda, 0x72, 0x0a, 0x39, 0x7c, 0xd3, 0x00, 0xc6, 0x25, 0x0a, 0x72, 0x0d,
+	0x8d, 0x82, 0x21, 0xe9, 0x6d, 0x25, 0x55, 0x20, 0xef, 0x9a, 0xbb, 0x22, 0xea, 0x82, 0x57, 0x50,
+	0x9f, 0xbc, 0xa2, 0x9f, 0x0b, 0x24, 0xc6, 0x76, 0xf6, 0x6a, 0x61, 0x6f, 0x47, 0x42, 0xed, 0xbb,
+	0x84, 0x66, 0x89, 0x85, 0x7c, 0x53, 0x60, 0x93, 0x59, 0x77, 0xff, 0x6b, 0x46, 0xb2, 0x3a, 0xd1,
+	0xce, 0xef, 0x1f, 0xee, 0xc2, 0x48, 0xcc, 0x72, 0xcf, 0xf2, 0x69, 0x62, 0xcf, 0x16, 0x19, 0xb2,
+	0x18, 0x83, 0x10, 0x99, 0x2d, 0x47, 0x06, 0x8f, 0xcc, 0x4d, 0xf0, 0x85, 0xb2, 0xf9, 0x20, 0xa4,
+	0x76, 0x36, 0x0f, 0xd7, 0x1e, 0xb4, 0x1d, 0xb2, 0xcc, 0xb7, 0xe5, 0xab, 0x5e, 0x73, 0xa7, 0x21,
+	0x9d, 0x4a, 0xcf, 0xab, 0xc9, 0xcf, 0xe9, 0xfb, 0x00, 0xd2, 0xd3, 0x37, 0x4f, 0x0f, 0x03, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ControllerClient is the client API for Controller service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ControllerClient interface {
+	// Commands lists the commands of the controller.
+	Commands(ctx context.Context, in *CommandsRequest, opts ...grpc.CallOption) (*CommandsResponse, error)
+	// Execute executes a command of the controller.
+	Execute(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandResponse, error)
+	// Subscribe streams the events of the given topics, or of all the topics if none is given.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Controller_SubscribeClient, error)
+}
+
+type controllerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewControllerClient(cc grpc.ClientConnInterface) ControllerClient {
+	return &controllerClient{cc}
+}
+
+func (c *controllerClient) Commands(ctx context.Context, in *CommandsRequest, opts ...grpc.CallOption) (*CommandsResponse, error) {
+	out := new(CommandsResponse)
+	err := c.cc.Invoke(ctx, "/aries.controller.Controller/Commands", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) Execute(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, "/aries.controller.Controller/Execute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Controller_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Controller_serviceDesc.Streams[0], "/aries.controller.Controller/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controllerSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Controller_SubscribeClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type controllerSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *controllerSubscribeClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ControllerServer is the server API for Controller service.
+type ControllerServer interface {
+	// Commands lists the commands of the controller.
+	Commands(context.Context, *CommandsRequest) (*CommandsResponse, error)
+	// Execute executes a command of the controller.
+	Execute(context.Context, *CommandRequest) (*CommandResponse, error)
+	// Subscribe streams the events of the given topics, or of all the topics if none is given.
+	Subscribe(*SubscribeRequest, Controller_SubscribeServer) error
+}
+
+// UnimplementedControllerServer can be embedded to have forward compatible implementations.
+type UnimplementedControllerServer struct {
+}
+
+func (*UnimplementedControllerServer) Commands(ctx context.Context, req *CommandsRequest) (*CommandsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Commands not implemented")
+}
+func (*UnimplementedControllerServer) Execute(ctx context.Context, req *CommandRequest) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
+}
+func (*UnimplementedControllerServer) Subscribe(req *SubscribeRequest, srv Controller_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterControllerServer(s *grpc.Server, srv ControllerServer) {
+	s.RegisterService(&_Controller_serviceDesc, srv)
+}
+
+func _Controller_Commands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommandsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).Commands(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aries.controller.Controller/Commands",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).Commands(ctx, req.(*CommandsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_Execute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).Execute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aries.controller.Controller/Execute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).Execute(ctx, req.(*CommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControllerServer).Subscribe(m, &controllerSubscribeServer{stream})
+}
+
+type Controller_SubscribeServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type controllerSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *controllerSubscribeServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Controller_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "aries.controller.Controller",
+	HandlerType: (*ControllerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Commands",
+			Handler:    _Controller_Commands_Handler,
+		},
+		{
+			MethodName: "Execute",
+			Handler:    _Controller_Execute_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Controller_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "controller/controller.proto",
+}
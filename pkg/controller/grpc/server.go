@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package grpc offers the controller commands as a gRPC service, alongside the REST API, for the backends preferring
+// gRPC to REST and webhooks. The commands take and return the JSON payloads of their REST API counterparts, and their
+// events are streamed to the subscribers instead of being delivered via webhooks:
+//
+//	notifier := grpc.NewNotifier()
+//
+//	handlers, err := controller.GetCommandHandlers(ctx, controller.WithNotifier(notifier))
+//	if err != nil {
+//		return err
+//	}
+//
+//	server := googlegrpc.NewServer()
+//	grpc.New(handlers, notifier).Register(server)
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	pb "github.com/hyperledger/aries-framework-go/pkg/controller/grpc/proto/controller_go_proto"
+)
+
+// ErrorCodeKey is the key of the trailer metadata carrying the code of the command errors.
+const ErrorCodeKey = "aries-error-code"
+
+var logger = log.New("aries-framework/controller/grpc")
+
+// Server is the gRPC controller service, executing the controller commands and streaming the events of their
+// notifier.
+type Server struct {
+	handlers map[string]command.Exec
+	commands []*pb.Command
+	notifier *Notifier
+}
+
+// New returns the gRPC controller service of the command handlers, the events delivered to the notifier being
+// streamed to the subscribers.
+func New(handlers []command.Handler, notifier *Notifier) *Server {
+	s := &Server{
+		handlers: make(map[string]command.Exec, len(handlers)),
+		notifier: notifier,
+	}
+
+	for _, h := range handlers {
+		s.handlers[handlerKey(h.Name(), h.Method())] = h.Handle()
+		s.commands = append(s.commands, &pb.Command{Name: h.Name(), Method: h.Method()})
+	}
+
+	return s
+}
+
+// Register registers the controller service to the gRPC server.
+func (s *Server) Register(server *grpc.Server) {
+	pb.RegisterControllerServer(server, s)
+}
+
+// Commands lists the commands of the controller.
+func (s *Server) Commands(context.Context, *pb.CommandsRequest) (*pb.CommandsResponse, error) {
+	return &pb.CommandsResponse{Commands: s.commands}, nil
+}
+
+// Execute executes the command with the JSON request of the payload, and returns its JSON response.
+func (s *Server) Execute(ctx context.Context, req *pb.CommandRequest) (*pb.CommandResponse, error) {
+	exec, ok := s.handlers[handlerKey(req.Name, req.Method)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "command %s %s not found", req.Name, req.Method)
+	}
+
+	var rw bytes.Buffer
+
+	if cmdErr := exec(&rw, bytes.NewReader(req.Payload)); cmdErr != nil {
+		return nil, commandStatus(ctx, cmdErr)
+	}
+
+	return &pb.CommandResponse{Payload: rw.Bytes()}, nil
+}
+
+// Subscribe streams the events of the topics to the subscriber until it goes away.
+func (s *Server) Subscribe(req *pb.SubscribeRequest, stream pb.Controller_SubscribeServer) error {
+	sub := s.notifier.subscribe(req.Topics)
+	defer s.notifier.unsubscribe(sub)
+
+	for {
+		select {
+		case event := <-sub.events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// commandStatus returns the gRPC status of the command error, its code being set in the trailer metadata.
+func commandStatus(ctx context.Context, cmdErr command.Error) error {
+	err := grpc.SetTrailer(ctx, metadata.Pairs(ErrorCodeKey, strconv.Itoa(int(cmdErr.Code()))))
+	if err != nil {
+		logger.Warnf("failed to set the error code of command error [%s] : %s", cmdErr, err)
+	}
+
+	if cmdErr.Type() == command.ValidationError {
+		return status.Error(codes.InvalidArgument, cmdErr.Error())
+	}
+
+	return status.Error(codes.Internal, cmdErr.Error())
+}
+
+func handlerKey(name, method string) string {
+	return fmt.Sprintf("%s/%s", name, method)
+}
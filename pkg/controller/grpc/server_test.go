@@ -0,0 +1,199 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	pb "github.com/hyperledger/aries-framework-go/pkg/controller/grpc/proto/controller_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+)
+
+func newClient(t *testing.T, handlers []command.Handler, notifier *Notifier) pb.ControllerClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+
+	New(handlers, notifier).Register(server)
+
+	go func() {
+		require.NoError(t, server.Serve(listener))
+	}()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+		server.Stop()
+	})
+
+	return pb.NewControllerClient(conn)
+}
+
+func testHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler("test", "Echo", func(rw io.Writer, req io.Reader) command.Error {
+			payload, err := ioutil.ReadAll(req)
+			if err != nil {
+				return command.NewExecuteError(command.UnknownStatus, err)
+			}
+
+			_, err = rw.Write(payload)
+			if err != nil {
+				return command.NewExecuteError(command.UnknownStatus, err)
+			}
+
+			return nil
+		}),
+		cmdutil.NewCommandHandler("test", "Validate", func(io.Writer, io.Reader) command.Error {
+			return command.NewValidationError(command.Code(6001), errors.New("invalid request"))
+		}),
+		cmdutil.NewCommandHandler("test", "Fail", func(io.Writer, io.Reader) command.Error {
+			return command.NewExecuteError(command.Code(6002), errors.New("execution failed"))
+		}),
+	}
+}
+
+func TestServer_Commands(t *testing.T) {
+	client := newClient(t, testHandlers(), NewNotifier())
+
+	res, err := client.Commands(context.Background(), &pb.CommandsRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Commands, 3)
+	require.Equal(t, "test", res.Commands[0].Name)
+	require.Equal(t, "Echo", res.Commands[0].Method)
+}
+
+func TestServer_Execute(t *testing.T) {
+	client := newClient(t, testHandlers(), NewNotifier())
+
+	t.Run("test execute command", func(t *testing.T) {
+		res, err := client.Execute(context.Background(),
+			&pb.CommandRequest{Name: "test", Method: "Echo", Payload: []byte(`{"id":"1"}`)})
+		require.NoError(t, err)
+		require.Equal(t, `{"id":"1"}`, string(res.Payload))
+	})
+
+	t.Run("test command errors", func(t *testing.T) {
+		tests := []struct {
+			method string
+			code   codes.Code
+			errMsg string
+			cmdErr string
+		}{
+			{method: "Validate", code: codes.InvalidArgument, errMsg: "invalid request", cmdErr: "6001"},
+			{method: "Fail", code: codes.Internal, errMsg: "execution failed", cmdErr: "6002"},
+		}
+
+		for _, tc := range tests {
+			var trailer metadata.MD
+
+			_, err := client.Execute(context.Background(), &pb.CommandRequest{Name: "test", Method: tc.method},
+				grpc.Trailer(&trailer))
+			require.Error(t, err)
+
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, tc.code, st.Code())
+			require.Equal(t, tc.errMsg, st.Message())
+			require.Equal(t, []string{tc.cmdErr}, trailer.Get(ErrorCodeKey))
+		}
+	})
+
+	t.Run("test unknown command", func(t *testing.T) {
+		_, err := client.Execute(context.Background(), &pb.CommandRequest{Name: "test", Method: "Unknown"})
+		require.Error(t, err)
+		require.Equal(t, codes.NotFound, status.Code(err))
+		require.Contains(t, err.Error(), "command test Unknown not found")
+	})
+}
+
+func TestServer_Subscribe(t *testing.T) {
+	notifier := NewNotifier()
+	client := newClient(t, testHandlers(), notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	all, err := client.Subscribe(ctx, &pb.SubscribeRequest{})
+	require.NoError(t, err)
+
+	connections, err := client.Subscribe(ctx, &pb.SubscribeRequest{Topics: []string{"connections"}})
+	require.NoError(t, err)
+
+	waitForSubscriptions(t, notifier, 2)
+
+	require.NoError(t, notifier.Notify("issue-credential_actions", []byte(`{"id":"1"}`)))
+	require.NoError(t, notifier.Notify("connections", []byte(`{"id":"2"}`)))
+
+	event, err := all.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "issue-credential_actions", event.Topic)
+	require.Equal(t, `{"id":"1"}`, string(event.Message))
+
+	event, err = all.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "connections", event.Topic)
+
+	event, err = connections.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "connections", event.Topic)
+	require.Equal(t, `{"id":"2"}`, string(event.Message))
+
+	// the subscriptions are removed once the subscribers go away
+	cancel()
+	waitForSubscriptions(t, notifier, 0)
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	notifier := NewNotifier()
+
+	require.EqualError(t, notifier.Notify("", []byte("message")), "cannot notify with an empty topic")
+	require.EqualError(t, notifier.Notify("topic", nil), "cannot notify with an empty message")
+
+	t.Run("test events dropped for a subscriber not keeping up", func(t *testing.T) {
+		sub := notifier.subscribe(nil)
+		defer notifier.unsubscribe(sub)
+
+		for i := 0; i < subscriptionBufferSize+1; i++ {
+			require.NoError(t, notifier.Notify("topic", []byte(fmt.Sprint(i))))
+		}
+
+		require.Len(t, sub.events, subscriptionBufferSize)
+	})
+}
+
+func waitForSubscriptions(t *testing.T, notifier *Notifier, count int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		notifier.lock.RLock()
+		defer notifier.lock.RUnlock()
+
+		return len(notifier.subscriptions) == count
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package auth provides the authentication middleware of the controller REST API.
+//
+// The requests are authenticated by the first Authenticator finding its credentials in them (API tokens, OIDC
+// bearer tokens or TLS client certificates), the principal they authenticate being granted scopes. Each route
+// requires a scope: the read-only routes (GET and HEAD) require the ReadScope, the other ones the OperationalScope,
+// unless overridden with WithRouteScope, e.g. with the SigningScope for the routes signing with the agent keys.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+var logger = log.New("aries-framework/rest/auth")
+
+// Scope is a permission granted to the principals, required by the routes of the controller REST API.
+type Scope string
+
+const (
+	// ReadScope grants the read-only routes.
+	ReadScope Scope = "read"
+	// OperationalScope grants all the routes, including the read-only ones.
+	OperationalScope Scope = "operational"
+	// SigningScope grants the routes using the agent keys on behalf of companion services (e.g. signing).
+	SigningScope Scope = "signing"
+)
+
+// known tells whether the scope is one of the scopes of the package, the other scopes granted by the identity
+// providers being ignored.
+func (s Scope) known() bool {
+	return s == ReadScope || s == OperationalScope || s == SigningScope
+}
+
+// ErrNoCredentials is returned by the Authenticators when the request does not carry their credentials, in which
+// case the request is authenticated by the next authenticator.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Principal is the authenticated caller of the controller REST API.
+type Principal struct {
+	// Subject identifies the principal, e.g. the subject of its OIDC token or of its TLS certificate.
+	Subject string
+	// Scopes granted to the principal.
+	Scopes []Scope
+}
+
+// HasScope tells whether the principal is granted the scope. The OperationalScope grants all the scopes.
+func (p *Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == OperationalScope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authenticator authenticates the requests of the controller REST API.
+type Authenticator interface {
+	// Authenticate returns the principal of the request, or ErrNoCredentials if the request does not carry the
+	// credentials of the authenticator.
+	Authenticate(req *http.Request) (*Principal, error)
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the principal authenticated by the middleware, from the context of the request.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+
+	return p, ok
+}
+
+type options struct {
+	routeScopes map[string]Scope
+}
+
+// Opt represents a middleware option.
+type Opt func(opts *options)
+
+// WithRouteScope is an option for requiring the scope on the route of the method and path template
+// (e.g. "/connections/{id}"), instead of the scope required by default.
+func WithRouteScope(method, pathTemplate string, scope Scope) Opt {
+	return func(opts *options) {
+		opts.routeScopes[method+" "+pathTemplate] = scope
+	}
+}
+
+// Middleware returns the middleware authenticating the requests with the authenticators, in order. The
+// unauthenticated requests are rejected with 401 Unauthorized and the requests of the principals lacking the
+// scope of the route with 403 Forbidden.
+func Middleware(authenticators []Authenticator, opts ...Opt) mux.MiddlewareFunc {
+	o := &options{routeScopes: make(map[string]Scope)}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authenticate(authenticators, r)
+			if err != nil {
+				logger.Debugf("unauthenticated request %s %s : %s", r.Method, r.URL.Path, err)
+
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("Unauthorised.\n")) // nolint:gosec,errcheck
+
+				return
+			}
+
+			scope := o.requiredScope(r)
+			if !principal.HasScope(scope) {
+				logger.Debugf("%s lacks the %s scope of %s %s", principal.Subject, scope, r.Method, r.URL.Path)
+
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte("Forbidden.\n")) // nolint:gosec,errcheck
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+		})
+	}
+}
+
+func authenticate(authenticators []Authenticator, r *http.Request) (*Principal, error) {
+	for _, a := range authenticators {
+		principal, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+
+		return principal, err
+	}
+
+	return nil, ErrNoCredentials
+}
+
+func (o *options) requiredScope(r *http.Request) Scope {
+	path := r.URL.Path
+
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			path = tpl
+		}
+	}
+
+	if scope, ok := o.routeScopes[r.Method+" "+path]; ok {
+		return scope
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return ReadScope
+	}
+
+	return OperationalScope
+}
@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	operationalToken = "operational-token"
+	readToken        = "read-token"
+)
+
+func TestMiddleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(Middleware([]Authenticator{
+		NewTokenAuthenticator(map[string][]Scope{
+			operationalToken: {OperationalScope},
+			readToken:        {ReadScope},
+		}),
+	}, WithRouteScope(http.MethodPost, "/verify/{id}", ReadScope)))
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := PrincipalFromContext(r.Context())
+		require.True(t, ok)
+		require.Equal(t, tokenSubject, principal.Subject)
+	}
+
+	router.HandleFunc("/connections", handler).Methods(http.MethodGet)
+	router.HandleFunc("/connections", handler).Methods(http.MethodPost)
+	router.HandleFunc("/verify/{id}", handler).Methods(http.MethodPost)
+
+	for _, tc := range []struct {
+		name, method, path, token string
+		status                    int
+	}{
+		{"read with operational token", http.MethodGet, "/connections", operationalToken, http.StatusOK},
+		{"read with read token", http.MethodGet, "/connections", readToken, http.StatusOK},
+		{"operate with operational token", http.MethodPost, "/connections", operationalToken, http.StatusOK},
+		{"operate with read token", http.MethodPost, "/connections", readToken, http.StatusForbidden},
+		{"overridden route scope", http.MethodPost, "/verify/123", readToken, http.StatusOK},
+		{"unknown token", http.MethodGet, "/connections", "unknown", http.StatusUnauthorized},
+		{"no token", http.MethodGet, "/connections", "", http.StatusUnauthorized},
+	} {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+		})
+	}
+}
+
+func TestMiddleware_AuthenticatorError(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(Middleware([]Authenticator{
+		&mockAuthenticator{err: ErrNoCredentials},
+		&mockAuthenticator{err: errors.New("invalid token")},
+		&mockAuthenticator{principal: &Principal{Scopes: []Scope{OperationalScope}}},
+	}))
+	router.HandleFunc("/connections", func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodGet)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/connections", nil))
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestPrincipal_HasScope(t *testing.T) {
+	require.True(t, (&Principal{Scopes: []Scope{OperationalScope}}).HasScope(ReadScope))
+	require.True(t, (&Principal{Scopes: []Scope{ReadScope}}).HasScope(ReadScope))
+	require.True(t, (&Principal{Scopes: []Scope{OperationalScope}}).HasScope(SigningScope))
+	require.False(t, (&Principal{Scopes: []Scope{ReadScope}}).HasScope(OperationalScope))
+	require.False(t, (&Principal{Scopes: []Scope{SigningScope}}).HasScope(ReadScope))
+	require.False(t, (&Principal{}).HasScope(ReadScope))
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	a := NewTokenAuthenticator(map[string][]Scope{readToken: {ReadScope}})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	_, err := a.Authenticate(req)
+	require.True(t, errors.Is(err, ErrNoCredentials))
+
+	req.Header.Set("Authorization", "Bearer")
+
+	_, err = a.Authenticate(req)
+	require.True(t, errors.Is(err, ErrNoCredentials))
+
+	req.Header.Set("Authorization", "Basic "+readToken)
+
+	_, err = a.Authenticate(req)
+	require.True(t, errors.Is(err, ErrNoCredentials))
+
+	req.Header.Set("Authorization", "Bearer "+readToken)
+
+	principal, err := a.Authenticate(req)
+	require.NoError(t, err)
+	require.Equal(t, []Scope{ReadScope}, principal.Scopes)
+}
+
+type mockAuthenticator struct {
+	principal *Principal
+	err       error
+}
+
+func (a *mockAuthenticator) Authenticate(*http.Request) (*Principal, error) {
+	return a.principal, a.err
+}
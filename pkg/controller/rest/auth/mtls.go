@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"net/http"
+)
+
+// MTLSAuthenticator authenticates the requests of the clients presenting a TLS certificate verified by the server
+// (see tls.Config ClientCAs), for mutual TLS.
+type MTLSAuthenticator struct {
+	scopes []Scope
+}
+
+// NewMTLSAuthenticator returns a new MTLSAuthenticator. The clients are granted the scopes named after the
+// organizational units of their certificate, or the given scopes if none is.
+func NewMTLSAuthenticator(scopes ...Scope) *MTLSAuthenticator {
+	return &MTLSAuthenticator{scopes: scopes}
+}
+
+// Authenticate returns the principal of the verified client certificate of the request, ErrNoCredentials is
+// returned when the client did not present a verified certificate.
+func (a *MTLSAuthenticator) Authenticate(req *http.Request) (*Principal, error) {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	cert := req.TLS.VerifiedChains[0][0]
+
+	var scopes []Scope
+
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if s := Scope(ou); s.known() {
+			scopes = append(scopes, s)
+		}
+	}
+
+	if len(scopes) == 0 {
+		scopes = a.scopes
+	}
+
+	return &Principal{Subject: cert.Subject.CommonName, Scopes: scopes}, nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMTLSAuthenticator(t *testing.T) {
+	a := NewMTLSAuthenticator(OperationalScope)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	_, err := a.Authenticate(req)
+	require.True(t, errors.Is(err, ErrNoCredentials))
+
+	req.TLS = &tls.ConnectionState{}
+
+	_, err = a.Authenticate(req)
+	require.True(t, errors.Is(err, ErrNoCredentials))
+
+	req.TLS.VerifiedChains = [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "admin"}}}}
+
+	principal, err := a.Authenticate(req)
+	require.NoError(t, err)
+	require.Equal(t, "admin", principal.Subject)
+	require.Equal(t, []Scope{OperationalScope}, principal.Scopes)
+
+	req.TLS.VerifiedChains = [][]*x509.Certificate{{{Subject: pkix.Name{
+		CommonName:         "dashboard",
+		OrganizationalUnit: []string{"monitoring", string(ReadScope)},
+	}}}}
+
+	principal, err = a.Authenticate(req)
+	require.NoError(t, err)
+	require.Equal(t, "dashboard", principal.Subject)
+	require.Equal(t, []Scope{ReadScope}, principal.Scopes)
+}
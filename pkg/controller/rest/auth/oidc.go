@@ -0,0 +1,216 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// keysRefreshInterval is the minimum interval between two fetches of the keys of the issuer, which are fetched
+	// again when a token is signed with an unknown key (e.g. after a key rotation).
+	keysRefreshInterval = time.Minute
+	// defaultClockSkew tolerated when validating the expiry of the tokens.
+	defaultClockSkew = time.Minute
+)
+
+// OIDCAuthenticator authenticates the requests bearing an OpenID Connect access token (a signed JWT) issued by its
+// issuer for its audience. The principals are granted the scopes of the "scope" claim of their token.
+type OIDCAuthenticator struct {
+	issuer     string
+	audience   string
+	httpClient *http.Client
+	clock      clock.Clock
+	clockSkew  time.Duration
+
+	mu        sync.Mutex
+	keys      *jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+// OIDCOpt represents an OIDCAuthenticator option.
+type OIDCOpt func(a *OIDCAuthenticator)
+
+// WithOIDCHTTPClient is an option for fetching the configuration and keys of the issuer with the given HTTP client.
+func WithOIDCHTTPClient(httpClient *http.Client) OIDCOpt {
+	return func(a *OIDCAuthenticator) {
+		a.httpClient = httpClient
+	}
+}
+
+// WithOIDCClock is an option for validating the expiry of the tokens with the given clock, the clock of the system if
+// not set.
+func WithOIDCClock(c clock.Clock) OIDCOpt {
+	return func(a *OIDCAuthenticator) {
+		a.clock = c
+	}
+}
+
+// WithOIDCClockSkew is an option for tolerating the given clock skew when validating the expiry of the tokens, one
+// minute if not set.
+func WithOIDCClockSkew(skew time.Duration) OIDCOpt {
+	return func(a *OIDCAuthenticator) {
+		a.clockSkew = skew
+	}
+}
+
+// NewOIDCAuthenticator returns a new OIDCAuthenticator of the tokens of the issuer for the audience. The keys of the
+// issuer are discovered from its OpenID configuration, when the first token is authenticated.
+func NewOIDCAuthenticator(issuer, audience string, opts ...OIDCOpt) *OIDCAuthenticator {
+	a := &OIDCAuthenticator{
+		issuer:     strings.TrimSuffix(issuer, "/"),
+		audience:   audience,
+		httpClient: http.DefaultClient,
+		clock:      clock.System(),
+		clockSkew:  defaultClockSkew,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+type scopeClaims struct {
+	Scope string `json:"scope,omitempty"`
+}
+
+// Authenticate returns the principal of the bearer token of the request, ErrNoCredentials is returned when the
+// request bears no token.
+func (a *OIDCAuthenticator) Authenticate(req *http.Request) (*Principal, error) {
+	token, ok := bearerToken(req)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	tok, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("parse bearer token: %w", err)
+	}
+
+	if len(tok.Headers) != 1 {
+		return nil, fmt.Errorf("bearer token must have a single signature")
+	}
+
+	key, err := a.key(tok.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		claims jwt.Claims
+		scopes scopeClaims
+	)
+
+	if err = tok.Claims(key, &claims, &scopes); err != nil {
+		return nil, fmt.Errorf("verify bearer token: %w", err)
+	}
+
+	err = claims.ValidateWithLeeway(jwt.Expected{
+		Issuer:   a.issuer,
+		Audience: jwt.Audience{a.audience},
+		Time:     a.clock.Now(),
+	}, a.clockSkew)
+	if err != nil {
+		return nil, fmt.Errorf("validate bearer token: %w", err)
+	}
+
+	principal := &Principal{Subject: claims.Subject}
+
+	for _, s := range strings.Fields(scopes.Scope) {
+		if s := Scope(s); s.known() {
+			principal.Scopes = append(principal.Scopes, s)
+		}
+	}
+
+	return principal, nil
+}
+
+// key returns the key of the issuer of the key ID, fetching the keys of the issuer if it is unknown.
+func (a *OIDCAuthenticator) key(kid string) (*jose.JSONWebKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.keys != nil {
+		if keys := a.keys.Key(kid); len(keys) > 0 {
+			return &keys[0], nil
+		}
+
+		if clock.Since(a.clock, a.fetchedAt) < keysRefreshInterval {
+			return nil, fmt.Errorf("unknown key %s", kid)
+		}
+	}
+
+	keys, err := a.fetchKeys()
+	if err != nil {
+		return nil, fmt.Errorf("fetch keys of %s: %w", a.issuer, err)
+	}
+
+	a.keys = keys
+	a.fetchedAt = a.clock.Now()
+
+	if keys := a.keys.Key(kid); len(keys) > 0 {
+		return &keys[0], nil
+	}
+
+	return nil, fmt.Errorf("unknown key %s", kid)
+}
+
+func (a *OIDCAuthenticator) fetchKeys() (*jose.JSONWebKeySet, error) {
+	var config struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+
+	if err := a.get(a.issuer+discoveryPath, &config); err != nil {
+		return nil, fmt.Errorf("discover OpenID configuration: %w", err)
+	}
+
+	if strings.TrimSuffix(config.Issuer, "/") != a.issuer {
+		return nil, fmt.Errorf("OpenID configuration of issuer %s", config.Issuer)
+	}
+
+	keys := &jose.JSONWebKeySet{}
+
+	if err := a.get(config.JWKSURI, keys); err != nil {
+		return nil, fmt.Errorf("get JWKS: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (a *OIDCAuthenticator) get(url string, v interface{}) error {
+	resp, err := a.httpClient.Get(url) // nolint: noctx
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			logger.Warnf("failed to close response body of %s : %s", url, e)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,242 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+)
+
+const audience = "aries-agent"
+
+func TestOIDCAuthenticator(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	issuer := newIssuer(t, &jose.JSONWebKey{Key: key.Public(), KeyID: "key-1", Algorithm: string(jose.ES256)})
+	defer issuer.Close()
+
+	a := NewOIDCAuthenticator(issuer.URL+"/", audience, WithOIDCHTTPClient(issuer.Client()))
+
+	sign := func(kid string, claims *jwt.Claims, scope string) string {
+		signer, e := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key},
+			(&jose.SignerOptions{}).WithHeader(jose.HeaderKey("kid"), kid))
+		require.NoError(t, e)
+
+		token, e := jwt.Signed(signer).Claims(claims).Claims(&scopeClaims{Scope: scope}).CompactSerialize()
+		require.NoError(t, e)
+
+		return token
+	}
+
+	authenticate := func(token string) (*Principal, error) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		return a.Authenticate(req)
+	}
+
+	claims := func() *jwt.Claims {
+		return &jwt.Claims{
+			Issuer:   issuer.URL,
+			Subject:  "alice",
+			Audience: jwt.Audience{audience},
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		principal, err := authenticate(sign("key-1", claims(), "openid read signing"))
+		require.NoError(t, err)
+		require.Equal(t, "alice", principal.Subject)
+		require.Equal(t, []Scope{ReadScope, SigningScope}, principal.Scopes)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		_, err := a.Authenticate(httptest.NewRequest(http.MethodGet, "/", nil))
+		require.True(t, errors.Is(err, ErrNoCredentials))
+	})
+
+	t.Run("not a JWT", func(t *testing.T) {
+		_, err := authenticate("api-token")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse bearer token")
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := authenticate(sign("key-2", claims(), "read"))
+		require.EqualError(t, err, "unknown key key-2")
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		c := claims()
+		c.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+		_, err := authenticate(sign("key-1", c, "read"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validate bearer token")
+	})
+
+	t.Run("clock skew", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+sign("key-1", claims(), "read"))
+
+		clock := mockclock.New(time.Now().Add(2 * time.Hour))
+
+		_, err := NewOIDCAuthenticator(issuer.URL, audience, WithOIDCHTTPClient(issuer.Client()),
+			WithOIDCClock(clock)).Authenticate(req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validate bearer token")
+
+		principal, err := NewOIDCAuthenticator(issuer.URL, audience, WithOIDCHTTPClient(issuer.Client()),
+			WithOIDCClock(clock), WithOIDCClockSkew(2*time.Hour)).Authenticate(req)
+		require.NoError(t, err)
+		require.Equal(t, "alice", principal.Subject)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		c := claims()
+		c.Audience = jwt.Audience{"other"}
+
+		_, err := authenticate(sign("key-1", c, "read"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validate bearer token")
+	})
+
+	t.Run("wrong signature", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: otherKey},
+			(&jose.SignerOptions{}).WithHeader(jose.HeaderKey("kid"), "key-1"))
+		require.NoError(t, err)
+
+		token, err := jwt.Signed(signer).Claims(claims()).CompactSerialize()
+		require.NoError(t, err)
+
+		_, err = authenticate(token)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify bearer token")
+	})
+}
+
+func TestOIDCAuthenticator_Discovery(t *testing.T) {
+	t.Run("issuer unavailable", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		defer srv.Close()
+
+		_, err := NewOIDCAuthenticator(srv.URL, audience).key("key-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "discover OpenID configuration")
+	})
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{"issuer": "https://other.example.com"}))
+		}))
+		defer srv.Close()
+
+		_, err := NewOIDCAuthenticator(srv.URL, audience).key("key-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "OpenID configuration of issuer https://other.example.com")
+	})
+}
+
+func TestOIDCAuthenticator_KeyRefresh(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var (
+		srv  *httptest.Server
+		keys = &jose.JSONWebKeySet{}
+		mu   sync.Mutex
+	)
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case discoveryPath:
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{"issuer": srv.URL, "jwks_uri": srv.URL + "/jwks"}))
+		default:
+			require.NoError(t, json.NewEncoder(w).Encode(keys))
+		}
+	}))
+	defer srv.Close()
+
+	clock := mockclock.New(time.Now())
+	a := NewOIDCAuthenticator(srv.URL, audience, WithOIDCHTTPClient(srv.Client()), WithOIDCClock(clock))
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{}).WithHeader(jose.HeaderKey("kid"), "key-1"))
+	require.NoError(t, err)
+
+	token, err := jwt.Signed(signer).Claims(&jwt.Claims{
+		Issuer:   srv.URL,
+		Subject:  "alice",
+		Audience: jwt.Audience{audience},
+		Expiry:   jwt.NewNumericDate(clock.Now().Add(time.Hour)),
+	}).CompactSerialize()
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = a.Authenticate(req)
+	require.EqualError(t, err, "unknown key key-1")
+
+	// the issuer publishes the key, the known keys are refreshed once the refresh interval has elapsed
+	mu.Lock()
+	keys.Keys = append(keys.Keys, jose.JSONWebKey{Key: key.Public(), KeyID: "key-1", Algorithm: string(jose.ES256)})
+	mu.Unlock()
+
+	clock.Add(keysRefreshInterval / 2)
+
+	_, err = a.Authenticate(req)
+	require.EqualError(t, err, "unknown key key-1")
+
+	clock.Add(keysRefreshInterval)
+
+	principal, err := a.Authenticate(req)
+	require.NoError(t, err)
+	require.Equal(t, "alice", principal.Subject)
+}
+
+func newIssuer(t *testing.T, key *jose.JSONWebKey) *httptest.Server {
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case discoveryPath:
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{
+				"issuer":   srv.URL,
+				"jwks_uri": srv.URL + "/jwks",
+			}))
+		case "/jwks":
+			require.NoError(t, json.NewEncoder(w).Encode(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{*key}}))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	return srv
+}
@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+const (
+	bearerPrefix = "Bearer "
+
+	tokenSubject = "api-token"
+)
+
+// TokenAuthenticator authenticates the requests bearing one of its API tokens in their Authorization header.
+type TokenAuthenticator struct {
+	tokens map[string][]Scope
+}
+
+// NewTokenAuthenticator returns a new TokenAuthenticator of the API tokens, mapped to the scopes they grant.
+func NewTokenAuthenticator(tokens map[string][]Scope) *TokenAuthenticator {
+	return &TokenAuthenticator{tokens: tokens}
+}
+
+// Authenticate returns the principal of the API token of the request, ErrNoCredentials is returned when the request
+// bears none of the API tokens.
+func (a *TokenAuthenticator) Authenticate(req *http.Request) (*Principal, error) {
+	token, ok := bearerToken(req)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	for t, scopes := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			return &Principal{Subject: tokenSubject, Scopes: scopes}, nil
+		}
+	}
+
+	return nil, ErrNoCredentials
+}
+
+func bearerToken(req *http.Request) (string, bool) {
+	hdr := req.Header.Get("Authorization")
+	if !strings.HasPrefix(hdr, bearerPrefix) || len(hdr) == len(bearerPrefix) {
+		return "", false
+	}
+
+	return hdr[len(bearerPrefix):], true
+}
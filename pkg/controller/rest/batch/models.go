@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package batch
+
+import "encoding/json"
+
+// Request is the request of the batch endpoint.
+type Request struct {
+	// Transactional makes the batch all or nothing: the batch stops at the first failed invocation and the storage
+	// updates of the previous invocations are rolled back. Only the storage-backed operations (e.g. saving DIDs,
+	// credentials and presentations) can be invoked in a transactional batch.
+	Transactional bool `json:"transactional,omitempty"`
+
+	// Invocations are the REST operations to invoke, in order.
+	Invocations []Invocation `json:"invocations"`
+}
+
+// Invocation is the invocation of a REST operation of the controller.
+type Invocation struct {
+	// Method is the HTTP method of the operation, e.g. "POST".
+	Method string `json:"method"`
+
+	// Path is the path of the operation with its path and query parameters,
+	// e.g. "/connections/create-invitation?alias=bob".
+	Path string `json:"path"`
+
+	// Body is the JSON request of the operation.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// Response is the response of the batch endpoint.
+type Response struct {
+	// Results are the results of the invocations, in order. The results of a transactional batch stop at the
+	// failed invocation.
+	Results []Result `json:"results"`
+
+	// RolledBack tells whether the storage updates of a transactional batch were rolled back.
+	RolledBack bool `json:"rolledBack,omitempty"`
+}
+
+// Result is the result of an invocation.
+type Result struct {
+	// Status is the HTTP status of the operation response.
+	Status int `json:"status"`
+
+	// Body is the body of the operation response, a JSON string if the response is not JSON.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// batchReq model
+//
+// This is used for the batch request.
+//
+// swagger:parameters batchReq
+type batchReq struct { // nolint: unused,deadcode
+	// in: body
+	Request
+}
+
+// batchRes model
+//
+// This is used for returning the results of the batch invocations.
+//
+// swagger:response batchRes
+type batchRes struct { // nolint: unused,deadcode
+	// in: body
+	Response
+}
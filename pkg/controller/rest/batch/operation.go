@@ -0,0 +1,271 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+var logger = log.New("aries-framework/rest/batch")
+
+// Path is the path of the batch endpoint.
+const Path = "/batch"
+
+// defaultMaxInvocations is the default maximum number of invocations of a batch.
+const defaultMaxInvocations = 1000
+
+const (
+	// InvalidRequestErrorCode is the error code of the invalid batch requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Batch)
+	// CommitErrorCode is the error code of the transactional batches whose updates failed to be committed.
+	CommitErrorCode
+)
+
+// TransactionalHandlers returns the REST handlers of the storage-backed operations, making their updates through
+// the given storage provider.
+type TransactionalHandlers func(storage.Provider) ([]rest.Handler, error)
+
+// Opt configures the batch operation.
+type Opt func(o *Operation)
+
+// WithTransactions enables the transactional batches, invoking the operations returned by txHandlers over the
+// stores of the given provider.
+func WithTransactions(provider storage.Provider, txHandlers TransactionalHandlers) Opt {
+	return func(o *Operation) {
+		o.storage = provider
+		o.txHandlers = txHandlers
+	}
+}
+
+// WithMaxInvocations sets the maximum number of invocations of a batch, 1000 if not set.
+func WithMaxInvocations(max int) Opt {
+	return func(o *Operation) {
+		o.maxInvocations = max
+	}
+}
+
+// Operation is the batch endpoint, invoking an ordered list of REST operations in a single request.
+type Operation struct {
+	router         *mux.Router
+	storage        storage.Provider
+	txHandlers     TransactionalHandlers
+	maxInvocations int
+	handlers       []rest.Handler
+}
+
+// New returns the batch operation invoking the given REST handlers.
+func New(handlers []rest.Handler, opts ...Opt) *Operation {
+	o := &Operation{router: newRouter(handlers), maxInvocations: defaultMaxInvocations}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(Path, http.MethodPost, o.Execute),
+	}
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// Execute swagger:route POST /batch batch batchReq
+//
+// Invokes a list of REST operations in order, optionally as a transaction for the storage-backed operations.
+//
+// Responses:
+//    default: genericError
+//        200: batchRes
+func (o *Operation) Execute(rw http.ResponseWriter, req *http.Request) {
+	var request Request
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, InvalidRequestErrorCode,
+			fmt.Errorf("invalid batch request : %w", err))
+
+		return
+	}
+
+	if err := o.validate(&request); err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, InvalidRequestErrorCode, err)
+		return
+	}
+
+	if !request.Transactional {
+		sendResponse(rw, &Response{Results: invoke(req, o.router, request.Invocations, false)})
+		return
+	}
+
+	txs := newTxStorage(o.storage)
+
+	handlers, err := o.txHandlers(txs)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, command.UnknownStatus,
+			fmt.Errorf("failed to create transactional operations : %w", err))
+
+		return
+	}
+
+	router := newRouter(handlers)
+
+	for _, inv := range request.Invocations {
+		if !matches(router, &inv) {
+			txs.rollback()
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, InvalidRequestErrorCode,
+				fmt.Errorf("operation %s %s can't be invoked in a transactional batch", inv.Method, inv.Path))
+
+			return
+		}
+	}
+
+	results := invoke(req, router, request.Invocations, true)
+
+	if results[len(results)-1].Status >= http.StatusBadRequest {
+		txs.rollback()
+		sendResponse(rw, &Response{Results: results, RolledBack: true})
+
+		return
+	}
+
+	if err := txs.commit(); err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, CommitErrorCode, err)
+		return
+	}
+
+	sendResponse(rw, &Response{Results: results})
+}
+
+func (o *Operation) validate(request *Request) error {
+	if len(request.Invocations) == 0 {
+		return errors.New("empty batch")
+	}
+
+	if len(request.Invocations) > o.maxInvocations {
+		return fmt.Errorf("batch of %d invocations exceeds the maximum of %d", len(request.Invocations),
+			o.maxInvocations)
+	}
+
+	if request.Transactional && o.txHandlers == nil {
+		return errors.New("transactional batches are not supported")
+	}
+
+	for i, inv := range request.Invocations {
+		if inv.Method == "" {
+			return fmt.Errorf("invocation %d : missing method", i)
+		}
+
+		u, err := url.Parse(inv.Path)
+		if err != nil || u.Scheme != "" || u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+			return fmt.Errorf("invocation %d : invalid path %q", i, inv.Path)
+		}
+	}
+
+	return nil
+}
+
+// invoke invokes the operations in order, stopping at the first failed invocation if stopOnError is set.
+func invoke(req *http.Request, router *mux.Router, invocations []Invocation, stopOnError bool) []Result {
+	results := make([]Result, 0, len(invocations))
+
+	for i := range invocations {
+		result := invokeOne(req, router, &invocations[i])
+		results = append(results, result)
+
+		if stopOnError && result.Status >= http.StatusBadRequest {
+			break
+		}
+	}
+
+	return results
+}
+
+func invokeOne(req *http.Request, router *mux.Router, inv *Invocation) Result {
+	// the invocations keep the context of the batch request, e.g. its authenticated principal
+	invReq, err := http.NewRequestWithContext(req.Context(), strings.ToUpper(inv.Method), inv.Path,
+		bytes.NewReader(inv.Body))
+	if err != nil {
+		return errorResult(http.StatusBadRequest, fmt.Errorf("invalid invocation : %w", err))
+	}
+
+	invReq.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, invReq)
+
+	result := Result{Status: recorder.Code}
+
+	body := bytes.TrimSpace(recorder.Body.Bytes())
+
+	switch {
+	case len(body) == 0:
+	case json.Valid(body):
+		result.Body = body
+	default:
+		result.Body, err = json.Marshal(string(body))
+		if err != nil {
+			logger.Errorf("Unable to encode invocation response, %s", err)
+		}
+	}
+
+	return result
+}
+
+func errorResult(status int, err error) Result {
+	rw := httptest.NewRecorder()
+	rest.SendHTTPStatusError(rw, status, InvalidRequestErrorCode, err)
+
+	return Result{Status: status, Body: bytes.TrimSpace(rw.Body.Bytes())}
+}
+
+// matches tells whether the invocation matches an operation of the router.
+func matches(router *mux.Router, inv *Invocation) bool {
+	req, err := http.NewRequest(strings.ToUpper(inv.Method), inv.Path, nil) // nolint: noctx
+	if err != nil {
+		return false
+	}
+
+	var match mux.RouteMatch
+
+	return router.Match(req, &match)
+}
+
+func newRouter(handlers []rest.Handler) *mux.Router {
+	router := mux.NewRouter()
+
+	for _, handler := range handlers {
+		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+	}
+
+	return router
+}
+
+func sendResponse(rw http.ResponseWriter, response *Response) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(rw).Encode(response); err != nil {
+		logger.Errorf("Unable to send batch response, %s", err)
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const recordsStore = "records"
+
+func TestOperation_Execute(t *testing.T) {
+	t.Run("invocations in order", func(t *testing.T) {
+		op, _ := newOperation(t)
+
+		rw := execute(t, op, &Request{Invocations: []Invocation{
+			{Method: http.MethodPost, Path: "/records/a", Body: json.RawMessage(`{"value":1}`)},
+			{Method: http.MethodGet, Path: "/records/a"},
+			{Method: http.MethodGet, Path: "/records/b"},
+			{Method: http.MethodPost, Path: "/records/b", Body: json.RawMessage(`{"value":2}`)},
+			{Method: http.MethodGet, Path: "/records/b?format=text"},
+			{Method: http.MethodGet, Path: "/unknown"},
+		}})
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		response := &Response{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), response))
+		require.False(t, response.RolledBack)
+		require.Len(t, response.Results, 6)
+
+		require.Equal(t, http.StatusOK, response.Results[0].Status)
+		require.Equal(t, http.StatusOK, response.Results[1].Status)
+		require.JSONEq(t, `{"value":1}`, string(response.Results[1].Body))
+		require.Equal(t, http.StatusNotFound, response.Results[2].Status)
+		require.Equal(t, http.StatusOK, response.Results[3].Status)
+		require.Equal(t, http.StatusOK, response.Results[4].Status)
+		require.Equal(t, `"value 2"`, string(response.Results[4].Body))
+		require.Equal(t, http.StatusNotFound, response.Results[5].Status)
+	})
+
+	t.Run("transactional batch committed", func(t *testing.T) {
+		op, provider := newOperation(t)
+
+		rw := execute(t, op, &Request{Transactional: true, Invocations: []Invocation{
+			{Method: http.MethodPost, Path: "/records/a", Body: json.RawMessage(`{"value":1}`)},
+			{Method: http.MethodGet, Path: "/records/a"},
+			{Method: http.MethodPost, Path: "/records/b", Body: json.RawMessage(`{"value":2}`)},
+		}})
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		response := &Response{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), response))
+		require.False(t, response.RolledBack)
+		require.Len(t, response.Results, 3)
+
+		// the updates are visible within the transaction
+		require.JSONEq(t, `{"value":1}`, string(response.Results[1].Body))
+
+		requireRecord(t, provider, "a", `{"value":1}`)
+		requireRecord(t, provider, "b", `{"value":2}`)
+	})
+
+	t.Run("transactional batch rolled back", func(t *testing.T) {
+		op, provider := newOperation(t)
+
+		rw := execute(t, op, &Request{Transactional: true, Invocations: []Invocation{
+			{Method: http.MethodPost, Path: "/records/a", Body: json.RawMessage(`{"value":1}`)},
+			{Method: http.MethodPost, Path: "/records/b", Body: json.RawMessage(`[2]`)},
+			{Method: http.MethodPost, Path: "/records/c", Body: json.RawMessage(`{"value":3}`)},
+		}})
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		response := &Response{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), response))
+		require.True(t, response.RolledBack)
+		require.Len(t, response.Results, 2)
+		require.Equal(t, http.StatusBadRequest, response.Results[1].Status)
+
+		store, err := provider.OpenStore(recordsStore)
+		require.NoError(t, err)
+
+		for _, key := range []string{"a", "b", "c"} {
+			_, err = store.Get(key)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound), key)
+		}
+	})
+
+	t.Run("operation not supporting transactions", func(t *testing.T) {
+		op, _ := newOperation(t)
+
+		rw := execute(t, op, &Request{Transactional: true, Invocations: []Invocation{
+			{Method: http.MethodPost, Path: "/records/a", Body: json.RawMessage(`{"value":1}`)},
+			{Method: http.MethodPost, Path: "/notify"},
+		}})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "operation POST /notify can't be invoked in a transactional batch")
+	})
+
+	t.Run("transactional batches not supported", func(t *testing.T) {
+		op := New(nil)
+
+		rw := execute(t, op, &Request{Transactional: true, Invocations: []Invocation{
+			{Method: http.MethodGet, Path: "/records/a"},
+		}})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "transactional batches are not supported")
+	})
+
+	t.Run("failed to create transactional operations", func(t *testing.T) {
+		op := New(nil, WithTransactions(mem.NewProvider(), func(storage.Provider) ([]rest.Handler, error) {
+			return nil, errors.New("create error")
+		}))
+
+		rw := execute(t, op, &Request{Transactional: true, Invocations: []Invocation{
+			{Method: http.MethodGet, Path: "/records/a"},
+		}})
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		require.Contains(t, rw.Body.String(), "create error")
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		op, _ := newOperation(t)
+		op.maxInvocations = 2
+
+		tests := []struct {
+			name    string
+			request string
+			err     string
+		}{
+			{name: "invalid json", request: `{`, err: "invalid batch request"},
+			{name: "empty batch", request: `{"invocations":[]}`, err: "empty batch"},
+			{
+				name:    "too many invocations",
+				request: `{"invocations":[{"method":"GET","path":"/a"},{"method":"GET","path":"/b"},{"method":"GET","path":"/c"}]}`,
+				err:     "batch of 3 invocations exceeds the maximum of 2",
+			},
+			{name: "missing method", request: `{"invocations":[{"path":"/a"}]}`, err: "invocation 0 : missing method"},
+			{
+				name:    "absolute url",
+				request: `{"invocations":[{"method":"GET","path":"http://example.com/a"}]}`,
+				err:     "invocation 0 : invalid path",
+			},
+			{name: "relative path", request: `{"invocations":[{"method":"GET","path":"a"}]}`, err: "invalid path"},
+		}
+
+		for _, tc := range tests {
+			rw := httptest.NewRecorder()
+			op.Execute(rw, httptest.NewRequest(http.MethodPost, Path, bytes.NewBufferString(tc.request)))
+
+			require.Equal(t, http.StatusBadRequest, rw.Code, tc.name)
+			require.Contains(t, rw.Body.String(), tc.err, tc.name)
+			require.Contains(t, rw.Body.String(), fmt.Sprintf(`"code":%d`, InvalidRequestErrorCode), tc.name)
+		}
+	})
+}
+
+func TestTxStorage(t *testing.T) {
+	provider := mem.NewProvider()
+
+	store, err := provider.OpenStore(recordsStore)
+	require.NoError(t, err)
+	require.NoError(t, store.Put("a", []byte("1")))
+
+	txs := newTxStorage(provider)
+
+	txStore, err := txs.OpenStore(recordsStore)
+	require.NoError(t, err)
+
+	same, err := txs.OpenStore(recordsStore)
+	require.NoError(t, err)
+	require.Equal(t, txStore, same)
+
+	require.NoError(t, txStore.Batch([]storage.Operation{{Key: "a"}, {Key: "b", Value: []byte("2")}}))
+	require.NoError(t, txStore.Put("c", []byte("3")))
+	require.NoError(t, txStore.Delete("c"))
+
+	_, err = txStore.Get("a")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	v, err := store.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, "1", string(v))
+
+	require.NoError(t, txs.CloseStore(recordsStore))
+	require.NoError(t, txs.Close())
+
+	require.NoError(t, txs.commit())
+
+	_, err = store.Get("a")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	v, err = store.Get("b")
+	require.NoError(t, err)
+	require.Equal(t, "2", string(v))
+
+	// the transactions are done
+	err = txs.commit()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to commit updates of store records")
+}
+
+func newOperation(t *testing.T) (*Operation, storage.Provider) {
+	t.Helper()
+
+	provider := mem.NewProvider()
+
+	handlers, err := recordHandlers(provider)
+	require.NoError(t, err)
+
+	handlers = append(handlers, cmdutil.NewHTTPHandler("/notify", http.MethodPost,
+		func(rw http.ResponseWriter, req *http.Request) {}))
+
+	return New(handlers, WithTransactions(provider, recordHandlers)), provider
+}
+
+// recordHandlers returns the handlers of a storage-backed operation, saving and fetching JSON records.
+func recordHandlers(provider storage.Provider) ([]rest.Handler, error) {
+	store, err := provider.OpenStore(recordsStore)
+	if err != nil {
+		return nil, err
+	}
+
+	return []rest.Handler{
+		cmdutil.NewHTTPHandler("/records/{key}", http.MethodPost, func(rw http.ResponseWriter, req *http.Request) {
+			body, err := ioutil.ReadAll(req.Body)
+			if err == nil && !bytes.HasPrefix(body, []byte("{")) {
+				err = errors.New("record is not a JSON object")
+			}
+
+			if err != nil {
+				rest.SendHTTPStatusError(rw, http.StatusBadRequest, command.UnknownStatus, err)
+				return
+			}
+
+			if err := store.Put(mux.Vars(req)["key"], body); err != nil {
+				rest.SendHTTPStatusError(rw, http.StatusInternalServerError, command.UnknownStatus, err)
+			}
+		}),
+		cmdutil.NewHTTPHandler("/records/{key}", http.MethodGet, func(rw http.ResponseWriter, req *http.Request) {
+			v, err := store.Get(mux.Vars(req)["key"])
+			if err != nil {
+				rest.SendHTTPStatusError(rw, http.StatusNotFound, command.UnknownStatus, err)
+				return
+			}
+
+			if req.URL.Query().Get("format") == "text" {
+				record := struct{ Value int }{}
+				if err := json.Unmarshal(v, &record); err == nil {
+					v = []byte(fmt.Sprintf("value %d", record.Value))
+				}
+			}
+
+			_, _ = rw.Write(v) // nolint: errcheck
+		}),
+	}, nil
+}
+
+func execute(t *testing.T, op *Operation, request *Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	op.Execute(rw, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body)))
+
+	return rw
+}
+
+func requireRecord(t *testing.T, provider storage.Provider, key, value string) {
+	t.Helper()
+
+	store, err := provider.OpenStore(recordsStore)
+	require.NoError(t, err)
+
+	v, err := store.Get(key)
+	require.NoError(t, err)
+	require.JSONEq(t, value, string(v))
+}
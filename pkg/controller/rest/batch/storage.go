@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package batch
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// txStorage is the storage provider of a transactional batch: the stores it opens make their updates within a
+// transaction, the transactions of all the stores being committed or rolled back at the end of the batch.
+type txStorage struct {
+	provider storage.Provider
+	stores   map[string]*txStore
+	// names are the names of the opened stores, in opening order
+	names []string
+	lock  sync.Mutex
+}
+
+func newTxStorage(provider storage.Provider) *txStorage {
+	return &txStorage{provider: provider, stores: make(map[string]*txStore)}
+}
+
+// OpenStore opens the store of the underlying provider and begins a transaction on it.
+func (s *txStorage) OpenStore(name string) (storage.Store, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if store, ok := s.stores[name]; ok {
+		return store, nil
+	}
+
+	store, err := s.provider.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := storage.Begin(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction on store %s : %w", name, err)
+	}
+
+	s.stores[name] = &txStore{Store: store, tx: tx}
+	s.names = append(s.names, name)
+
+	return s.stores[name], nil
+}
+
+// CloseStore does nothing, the underlying stores being shared with the rest of the agent.
+func (s *txStorage) CloseStore(string) error {
+	return nil
+}
+
+// Close does nothing, the underlying stores being shared with the rest of the agent.
+func (s *txStorage) Close() error {
+	return nil
+}
+
+// commit commits the transactions of the stores in opening order. Each transaction is as atomic as the store
+// supports, but the transactions of different stores are committed one after the other.
+func (s *txStorage) commit() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i, name := range s.names {
+		if err := s.stores[name].tx.Commit(); err != nil {
+			s.rollbackFrom(i + 1)
+
+			return fmt.Errorf("failed to commit updates of store %s : %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// rollback discards the updates made within the transactions of all the stores.
+func (s *txStorage) rollback() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.rollbackFrom(0)
+}
+
+func (s *txStorage) rollbackFrom(i int) {
+	for _, name := range s.names[i:] {
+		if err := s.stores[name].tx.Rollback(); err != nil {
+			logger.Warnf("failed to roll back updates of store %s : %s", name, err)
+		}
+	}
+}
+
+// txStore makes the updates of the store within its transaction. The iterators only see the committed records.
+type txStore struct {
+	storage.Store
+	tx storage.Transaction
+}
+
+func (s *txStore) Put(k string, v []byte) error {
+	return s.tx.Put(k, v)
+}
+
+func (s *txStore) Get(k string) ([]byte, error) {
+	return s.tx.Get(k)
+}
+
+func (s *txStore) Delete(k string) error {
+	return s.tx.Delete(k)
+}
+
+func (s *txStore) Batch(operations []storage.Operation) error {
+	for _, op := range operations {
+		var err error
+
+		if op.Value == nil {
+			err = s.tx.Delete(op.Key)
+		} else {
+			err = s.tx.Put(op.Key, op.Value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to apply operation on key %s: %w", op.Key, err)
+		}
+	}
+
+	return nil
+}
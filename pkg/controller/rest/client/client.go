@@ -0,0 +1,238 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package client is the typed Go client of the controller REST API, generated from the operations of the OpenAPI
+// document (see pkg/controller/rest/openapi).
+package client
+
+//go:generate go run ../openapi/gen -client client_gen.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+)
+
+// nolint: gochecknoglobals
+var pathParam = regexp.MustCompile(`{([^}]+)}`)
+
+// Client is the client of the controller REST API.
+type Client struct {
+	url        string
+	httpClient *http.Client
+	token      string
+}
+
+// Opt represents a Client option.
+type Opt func(c *Client)
+
+// WithHTTPClient is an option for sending the requests with the given HTTP client, instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Opt {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken is an option for authorizing the requests with the given API token, as a bearer token.
+func WithToken(token string) Opt {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// New returns a new client of the controller REST API served at the given URL.
+func New(apiURL string, opts ...Opt) *Client {
+	c := &Client{
+		url:        strings.TrimSuffix(apiURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Error is the error returned by the controller REST API.
+type Error struct {
+	StatusCode int
+	Code       command.Code `json:"code"`
+	Message    string       `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("controller error (status %d, code %d): %s", e.StatusCode, e.Code, e.Message)
+}
+
+type operation struct {
+	method     string
+	path       string
+	query      bool
+	base64Path bool
+}
+
+// do sends the request of the operation and decodes its response, if any. The request fields named after the
+// path parameters are set in the path, the other fields being sent as query parameters or as the JSON body.
+func (c *Client) do(ctx context.Context, op *operation, request, response interface{}) error {
+	fields, err := requestFields(request)
+	if err != nil {
+		return err
+	}
+
+	path, err := c.path(op, fields)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+
+	if op.query {
+		query, e := queryValues(fields)
+		if e != nil {
+			return e
+		}
+
+		if len(query) > 0 {
+			path += "?" + query.Encode()
+		}
+	} else if request != nil {
+		b, e := json.Marshal(fields)
+		if e != nil {
+			return fmt.Errorf("marshal request: %w", e)
+		}
+
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, op.method, c.url+path, body)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close() // nolint: errcheck
+	}()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+
+		if e := json.Unmarshal(respBody, apiErr); e != nil {
+			apiErr.Message = string(respBody)
+		}
+
+		return apiErr
+	}
+
+	if response == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, response); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// path returns the path of the operation, removing the path parameters from the request fields.
+func (c *Client) path(op *operation, fields map[string]interface{}) (string, error) {
+	var err error
+
+	path := pathParam.ReplaceAllStringFunc(op.path, func(param string) string {
+		name := param[1 : len(param)-1]
+
+		value, ok := fields[name].(string)
+		if !ok || value == "" {
+			err = fmt.Errorf("missing path parameter %s", name)
+
+			return param
+		}
+
+		delete(fields, name)
+
+		if op.base64Path {
+			value = base64.StdEncoding.EncodeToString([]byte(value))
+		}
+
+		return url.PathEscape(value)
+	})
+
+	return path, err
+}
+
+func requestFields(request interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+
+	if request == nil {
+		return fields, nil
+	}
+
+	b, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	if err := d.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("request is not a JSON object: %w", err)
+	}
+
+	return fields, nil
+}
+
+func queryValues(fields map[string]interface{}) (url.Values, error) {
+	query := make(url.Values)
+
+	for name, value := range fields {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			query.Set(name, v)
+		case json.Number, bool:
+			query.Set(name, fmt.Sprint(v))
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("marshal query parameter %s: %w", name, err)
+			}
+
+			query.Set(name, string(b))
+		}
+	}
+
+	return query, nil
+}
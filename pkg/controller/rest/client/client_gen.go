@@ -0,0 +1,1728 @@
+// Code generated by pkg/controller/rest/openapi/gen. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	clientdidexchange "github.com/hyperledger/aries-framework-go/pkg/client/didexchange"
+	commanddidexchange "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	introduce "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
+	issuecredential "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
+	kms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
+	mediator "github.com/hyperledger/aries-framework-go/pkg/controller/command/mediator"
+	messaging "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
+	outofband "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
+	presentproof "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	vcwallet "github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
+	vdr "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
+	commandverifiable "github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	batch "github.com/hyperledger/aries-framework-go/pkg/controller/rest/batch"
+	webnotifier "github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	did "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+// Batch is the client of the batch operations.
+type Batch struct {
+	client *Client
+}
+
+// Batch returns the client of the batch operations.
+func (c *Client) Batch() *Batch {
+	return &Batch{client: c}
+}
+
+// Execute invokes a list of REST operations in order, optionally as a transaction.
+func (c *Batch) Execute(ctx context.Context, request *batch.Request) (*batch.Response, error) {
+	response := &batch.Response{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/batch",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DIDExchange is the client of the did-exchange operations.
+type DIDExchange struct {
+	client *Client
+}
+
+// DIDExchange returns the client of the did-exchange operations.
+func (c *Client) DIDExchange() *DIDExchange {
+	return &DIDExchange{client: c}
+}
+
+// CreateInvitation creates a new connection invitation.
+func (c *DIDExchange) CreateInvitation(ctx context.Context, request *commanddidexchange.CreateInvitationArgs) (*commanddidexchange.CreateInvitationResponse, error) {
+	response := &commanddidexchange.CreateInvitationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/connections/create-invitation",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ReceiveInvitation receives a new connection invitation.
+func (c *DIDExchange) ReceiveInvitation(ctx context.Context, request *clientdidexchange.Invitation) (*commanddidexchange.ReceiveInvitationResponse, error) {
+	response := &commanddidexchange.ReceiveInvitationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/connections/receive-invitation",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptInvitation accepts a stored connection invitation.
+func (c *DIDExchange) AcceptInvitation(ctx context.Context, request *commanddidexchange.AcceptInvitationArgs) (*commanddidexchange.AcceptInvitationResponse, error) {
+	response := &commanddidexchange.AcceptInvitationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/connections/{id}/accept-invitation",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CreateImplicitInvitation creates an implicit invitation using the inviter DID.
+func (c *DIDExchange) CreateImplicitInvitation(ctx context.Context, request *commanddidexchange.ImplicitInvitationArgs) (*commanddidexchange.ImplicitInvitationResponse, error) {
+	response := &commanddidexchange.ImplicitInvitationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/connections/create-implicit-invitation",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptExchangeRequest accepts a stored connection request.
+func (c *DIDExchange) AcceptExchangeRequest(ctx context.Context, request *commanddidexchange.AcceptExchangeRequestArgs) (*commanddidexchange.ExchangeResponse, error) {
+	response := &commanddidexchange.ExchangeResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/connections/{id}/accept-request",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// QueryConnections queries the agent to agent connections.
+func (c *DIDExchange) QueryConnections(ctx context.Context, request *commanddidexchange.QueryConnectionsArgs) (*commanddidexchange.QueryConnectionsResponse, error) {
+	response := &commanddidexchange.QueryConnectionsResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/connections",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// QueryConnectionByID fetches a single connection record.
+func (c *DIDExchange) QueryConnectionByID(ctx context.Context, request *commanddidexchange.ConnectionIDArg) (*commanddidexchange.QueryConnectionResponse, error) {
+	response := &commanddidexchange.QueryConnectionResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/connections/{id}",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CreateConnection saves the connection record.
+func (c *DIDExchange) CreateConnection(ctx context.Context, request *commanddidexchange.CreateConnectionRequest) (*commanddidexchange.ConnectionIDArg, error) {
+	response := &commanddidexchange.ConnectionIDArg{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/connections/create",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// RemoveConnection removes the connection record.
+func (c *DIDExchange) RemoveConnection(ctx context.Context, request *commanddidexchange.ConnectionIDArg) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/connections/{id}/remove",
+		query:  true,
+	}, request, nil)
+}
+
+// ExportConnection exports a completed connection, to be imported into another agent.
+func (c *DIDExchange) ExportConnection(ctx context.Context, request *commanddidexchange.ConnectionIDArg) (*commanddidexchange.ExportConnectionResponse, error) {
+	response := &commanddidexchange.ExportConnectionResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/connections/{id}/export",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ImportConnection imports a connection exported from another agent.
+func (c *DIDExchange) ImportConnection(ctx context.Context, request *commanddidexchange.ImportConnectionRequest) (*commanddidexchange.ConnectionIDArg, error) {
+	response := &commanddidexchange.ConnectionIDArg{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/connections/import",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Events is the client of the events operations.
+type Events struct {
+	client *Client
+}
+
+// Events returns the client of the events operations.
+func (c *Client) Events() *Events {
+	return &Events{client: c}
+}
+
+// Poll waits for the notifications published after the resume token.
+func (c *Events) Poll(ctx context.Context, request *webnotifier.PollArgs) (*webnotifier.PollResponse, error) {
+	response := &webnotifier.PollResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/events",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Introduce is the client of the introduce operations.
+type Introduce struct {
+	client *Client
+}
+
+// Introduce returns the client of the introduce operations.
+func (c *Client) Introduce() *Introduce {
+	return &Introduce{client: c}
+}
+
+// Actions returns the pending actions that have not yet been executed or cancelled.
+func (c *Introduce) Actions(ctx context.Context) (*introduce.ActionsResponse, error) {
+	response := &introduce.ActionsResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/introduce/actions",
+	}, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SendProposal sends a proposal.
+func (c *Introduce) SendProposal(ctx context.Context, request *introduce.SendProposalArgs) (*introduce.SendProposalResponse, error) {
+	response := &introduce.SendProposalResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/send-proposal",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SendProposalWithOOBRequest sends a proposal with an out-of-band request.
+func (c *Introduce) SendProposalWithOOBRequest(ctx context.Context, request *introduce.SendProposalWithOOBRequestArgs) (*introduce.SendProposalWithOOBRequestResponse, error) {
+	response := &introduce.SendProposalWithOOBRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/send-proposal-with-oob-request",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SendRequest sends a request.
+func (c *Introduce) SendRequest(ctx context.Context, request *introduce.SendRequestArgs) (*introduce.SendRequestResponse, error) {
+	response := &introduce.SendRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/send-request",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptProposalWithOOBRequest accepts a proposal with an out-of-band request.
+func (c *Introduce) AcceptProposalWithOOBRequest(ctx context.Context, request *introduce.AcceptProposalWithOOBRequestArgs) (*introduce.AcceptProposalWithOOBRequestResponse, error) {
+	response := &introduce.AcceptProposalWithOOBRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/{piid}/accept-proposal-with-oob-request",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptProposal accepts a proposal.
+func (c *Introduce) AcceptProposal(ctx context.Context, request *introduce.AcceptProposalArgs) (*introduce.AcceptProposalResponse, error) {
+	response := &introduce.AcceptProposalResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/{piid}/accept-proposal",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptRequestWithPublicOOBRequest accepts a request with a public out-of-band request.
+func (c *Introduce) AcceptRequestWithPublicOOBRequest(ctx context.Context, request *introduce.AcceptRequestWithPublicOOBRequestArgs) (*introduce.AcceptRequestWithPublicOOBRequestResponse, error) {
+	response := &introduce.AcceptRequestWithPublicOOBRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/{piid}/accept-request-with-public-oob-request",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptRequestWithRecipients accepts a request with recipients.
+func (c *Introduce) AcceptRequestWithRecipients(ctx context.Context, request *introduce.AcceptRequestWithRecipientsArgs) (*introduce.AcceptRequestWithRecipientsResponse, error) {
+	response := &introduce.AcceptRequestWithRecipientsResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/{piid}/accept-request-with-recipients",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeclineProposal declines a proposal.
+func (c *Introduce) DeclineProposal(ctx context.Context, request *introduce.DeclineProposalArgs) (*introduce.DeclineProposalResponse, error) {
+	response := &introduce.DeclineProposalResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/{piid}/decline-proposal",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeclineRequest declines a request.
+func (c *Introduce) DeclineRequest(ctx context.Context, request *introduce.DeclineRequestArgs) (*introduce.DeclineRequestResponse, error) {
+	response := &introduce.DeclineRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/{piid}/decline-request",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptProblemReport accepts a problem report.
+func (c *Introduce) AcceptProblemReport(ctx context.Context, request *introduce.AcceptProblemReportArgs) (*introduce.AcceptProblemReportResponse, error) {
+	response := &introduce.AcceptProblemReportResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/introduce/{piid}/accept-problem-report",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// IssueCredential is the client of the issue-credential operations.
+type IssueCredential struct {
+	client *Client
+}
+
+// IssueCredential returns the client of the issue-credential operations.
+func (c *Client) IssueCredential() *IssueCredential {
+	return &IssueCredential{client: c}
+}
+
+// Actions returns the pending actions that have not yet been executed or cancelled.
+func (c *IssueCredential) Actions(ctx context.Context) (*issuecredential.ActionsResponse, error) {
+	response := &issuecredential.ActionsResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/issuecredential/actions",
+	}, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SendOffer sends an offer.
+func (c *IssueCredential) SendOffer(ctx context.Context, request *issuecredential.SendOfferArgs) (*issuecredential.SendOfferResponse, error) {
+	response := &issuecredential.SendOfferResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/send-offer",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SendProposal sends a proposal.
+func (c *IssueCredential) SendProposal(ctx context.Context, request *issuecredential.SendProposalArgs) (*issuecredential.SendProposalResponse, error) {
+	response := &issuecredential.SendProposalResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/send-proposal",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SendRequest sends a request.
+func (c *IssueCredential) SendRequest(ctx context.Context, request *issuecredential.SendRequestArgs) (*issuecredential.SendRequestResponse, error) {
+	response := &issuecredential.SendRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/send-request",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptProposal accepts a proposal.
+func (c *IssueCredential) AcceptProposal(ctx context.Context, request *issuecredential.AcceptProposalArgs) (*issuecredential.AcceptProposalResponse, error) {
+	response := &issuecredential.AcceptProposalResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/accept-proposal",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeclineProposal declines a proposal.
+func (c *IssueCredential) DeclineProposal(ctx context.Context, request *issuecredential.DeclineProposalArgs) (*issuecredential.DeclineProposalResponse, error) {
+	response := &issuecredential.DeclineProposalResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/decline-proposal",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptOffer accepts an offer.
+func (c *IssueCredential) AcceptOffer(ctx context.Context, request *issuecredential.AcceptOfferArgs) (*issuecredential.AcceptOfferResponse, error) {
+	response := &issuecredential.AcceptOfferResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/accept-offer",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeclineOffer declines an offer.
+func (c *IssueCredential) DeclineOffer(ctx context.Context, request *issuecredential.DeclineOfferArgs) (*issuecredential.DeclineOfferResponse, error) {
+	response := &issuecredential.DeclineOfferResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/decline-offer",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// NegotiateProposal negotiates a received offer with a counter proposal.
+func (c *IssueCredential) NegotiateProposal(ctx context.Context, request *issuecredential.NegotiateProposalArgs) (*issuecredential.NegotiateProposalResponse, error) {
+	response := &issuecredential.NegotiateProposalResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/negotiate-proposal",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptRequest accepts a request.
+func (c *IssueCredential) AcceptRequest(ctx context.Context, request *issuecredential.AcceptRequestArgs) (*issuecredential.AcceptRequestResponse, error) {
+	response := &issuecredential.AcceptRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/accept-request",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeclineRequest declines a request.
+func (c *IssueCredential) DeclineRequest(ctx context.Context, request *issuecredential.DeclineRequestArgs) (*issuecredential.DeclineRequestResponse, error) {
+	response := &issuecredential.DeclineRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/decline-request",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptCredential accepts a credential.
+func (c *IssueCredential) AcceptCredential(ctx context.Context, request *issuecredential.AcceptCredentialArgs) (*issuecredential.AcceptCredentialResponse, error) {
+	response := &issuecredential.AcceptCredentialResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/accept-credential",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeclineCredential declines a credential.
+func (c *IssueCredential) DeclineCredential(ctx context.Context, request *issuecredential.DeclineCredentialArgs) (*issuecredential.DeclineCredentialResponse, error) {
+	response := &issuecredential.DeclineCredentialResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/decline-credential",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptProblemReport accepts a problem report.
+func (c *IssueCredential) AcceptProblemReport(ctx context.Context, request *issuecredential.AcceptProblemReportArgs) (*issuecredential.AcceptProblemReportResponse, error) {
+	response := &issuecredential.AcceptProblemReportResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/issuecredential/{piid}/accept-problem-report",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// KMS is the client of the kms operations.
+type KMS struct {
+	client *Client
+}
+
+// KMS returns the client of the kms operations.
+func (c *Client) KMS() *KMS {
+	return &KMS{client: c}
+}
+
+// CreateKeySet creates a key set.
+func (c *KMS) CreateKeySet(ctx context.Context, request *kms.CreateKeySetRequest) (*kms.CreateKeySetResponse, error) {
+	response := &kms.CreateKeySetResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/kms/keyset",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ImportKey imports a key.
+func (c *KMS) ImportKey(ctx context.Context, request *kms.JSONWebKey) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/kms/import",
+	}, request, nil)
+}
+
+// Sign signs a message with a key created or imported with the sign usage.
+func (c *KMS) Sign(ctx context.Context, request *kms.SignRequest) (*kms.SignResponse, error) {
+	response := &kms.SignResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/kms/sign",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Verify verifies a signature with a key created or imported with the verify usage.
+func (c *KMS) Verify(ctx context.Context, request *kms.VerifyRequest) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/kms/verify",
+	}, request, nil)
+}
+
+// WrapKey wraps a content encryption key for a recipient.
+func (c *KMS) WrapKey(ctx context.Context, request *kms.WrapKeyRequest) (*kms.WrapKeyResponse, error) {
+	response := &kms.WrapKeyResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/kms/wrap",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Mediator is the client of the mediator operations.
+type Mediator struct {
+	client *Client
+}
+
+// Mediator returns the client of the mediator operations.
+func (c *Client) Mediator() *Mediator {
+	return &Mediator{client: c}
+}
+
+// Register registers the agent with the router.
+func (c *Mediator) Register(ctx context.Context, request *mediator.RegisterRoute) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/mediator/register",
+	}, request, nil)
+}
+
+// Unregister unregisters the agent with the router.
+func (c *Mediator) Unregister(ctx context.Context, request *mediator.RegisterRoute) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodDelete,
+		path:   "/mediator/unregister",
+	}, request, nil)
+}
+
+// Connections retrieves the router connections.
+func (c *Mediator) Connections(ctx context.Context) (*mediator.ConnectionsResponse, error) {
+	response := &mediator.ConnectionsResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/mediator/connections",
+	}, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Reconnect reconnects the agent with the router to re-establish a lost connection.
+func (c *Mediator) Reconnect(ctx context.Context, request *mediator.RegisterRoute) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/mediator/reconnect",
+	}, request, nil)
+}
+
+// Status returns details about the pending messages of the connection.
+func (c *Mediator) Status(ctx context.Context, request *mediator.StatusRequest) (*mediator.StatusResponse, error) {
+	response := &mediator.StatusResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/mediator/status",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// BatchPickup dispatches the pending messages of the connection.
+func (c *Mediator) BatchPickup(ctx context.Context, request *mediator.BatchPickupRequest) (*mediator.BatchPickupResponse, error) {
+	response := &mediator.BatchPickupResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/mediator/batchpickup",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ReconnectAll re-establishes the network connections of all the mediator connections.
+func (c *Mediator) ReconnectAll(ctx context.Context) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/mediator/reconnect-all",
+	}, nil, nil)
+}
+
+// Messaging is the client of the message operations.
+type Messaging struct {
+	client *Client
+}
+
+// Messaging returns the client of the message operations.
+func (c *Client) Messaging() *Messaging {
+	return &Messaging{client: c}
+}
+
+// RegisterService registers a new message service to the message handler registrar.
+func (c *Messaging) RegisterService(ctx context.Context, request *messaging.RegisterMsgSvcArgs) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/message/register-service",
+	}, request, nil)
+}
+
+// UnregisterService unregisters the message service from the message handler registrar.
+func (c *Messaging) UnregisterService(ctx context.Context, request *messaging.UnregisterMsgSvcArgs) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/message/unregister-service",
+	}, request, nil)
+}
+
+// Services returns the names of the registered message services.
+func (c *Messaging) Services(ctx context.Context) (*messaging.RegisteredServicesResponse, error) {
+	response := &messaging.RegisteredServicesResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/message/services",
+	}, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Send sends a new message to the destination.
+func (c *Messaging) Send(ctx context.Context, request *messaging.SendNewMessageArgs) (*messaging.SendMessageResponse, error) {
+	response := &messaging.SendMessageResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/message/send",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Reply sends a reply to an existing message.
+func (c *Messaging) Reply(ctx context.Context, request *messaging.SendReplyMessageArgs) (*messaging.SendMessageResponse, error) {
+	response := &messaging.SendMessageResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/message/reply",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// RegisterHTTPService registers a new HTTP over DIDComm service to the message handler registrar.
+func (c *Messaging) RegisterHTTPService(ctx context.Context, request *messaging.RegisterHTTPMsgSvcArgs) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/http-over-didcomm/register",
+	}, request, nil)
+}
+
+// OutOfBand is the client of the outofband operations.
+type OutOfBand struct {
+	client *Client
+}
+
+// OutOfBand returns the client of the outofband operations.
+func (c *Client) OutOfBand() *OutOfBand {
+	return &OutOfBand{client: c}
+}
+
+// Actions returns the pending actions that have not yet been executed or cancelled.
+func (c *OutOfBand) Actions(ctx context.Context) (*outofband.ActionsResponse, error) {
+	response := &outofband.ActionsResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/outofband/actions",
+	}, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ActionContinue continues the protocol after an action event was triggered.
+func (c *OutOfBand) ActionContinue(ctx context.Context, request *outofband.ActionContinueArgs) (*outofband.ActionContinueResponse, error) {
+	response := &outofband.ActionContinueResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/outofband/{piid}/action-continue",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ActionStop stops the protocol after an action event was triggered.
+func (c *OutOfBand) ActionStop(ctx context.Context, request *outofband.ActionStopArgs) (*outofband.ActionStopResponse, error) {
+	response := &outofband.ActionStopResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/outofband/{piid}/action-stop",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CreateRequest creates a request.
+func (c *OutOfBand) CreateRequest(ctx context.Context, request *outofband.CreateRequestArgs) (*outofband.CreateRequestResponse, error) {
+	response := &outofband.CreateRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/outofband/create-request",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CreateInvitation creates an invitation.
+func (c *OutOfBand) CreateInvitation(ctx context.Context, request *outofband.CreateInvitationArgs) (*outofband.CreateInvitationResponse, error) {
+	response := &outofband.CreateInvitationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/outofband/create-invitation",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptRequest accepts a request.
+func (c *OutOfBand) AcceptRequest(ctx context.Context, request *outofband.AcceptRequestArgs) (*outofband.AcceptRequestResponse, error) {
+	response := &outofband.AcceptRequestResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/outofband/accept-request",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptInvitation accepts an invitation.
+func (c *OutOfBand) AcceptInvitation(ctx context.Context, request *outofband.AcceptInvitationArgs) (*outofband.AcceptInvitationResponse, error) {
+	response := &outofband.AcceptInvitationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/outofband/accept-invitation",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// PresentProof is the client of the present-proof operations.
+type PresentProof struct {
+	client *Client
+}
+
+// PresentProof returns the client of the present-proof operations.
+func (c *Client) PresentProof() *PresentProof {
+	return &PresentProof{client: c}
+}
+
+// Actions returns the pending actions that have not yet been executed or cancelled.
+func (c *PresentProof) Actions(ctx context.Context) (*presentproof.ActionsResponse, error) {
+	response := &presentproof.ActionsResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/presentproof/actions",
+	}, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SendRequestPresentation sends a request presentation.
+func (c *PresentProof) SendRequestPresentation(ctx context.Context, request *presentproof.SendRequestPresentationArgs) (*presentproof.SendRequestPresentationResponse, error) {
+	response := &presentproof.SendRequestPresentationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/send-request-presentation",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SendProposePresentation sends a propose presentation.
+func (c *PresentProof) SendProposePresentation(ctx context.Context, request *presentproof.SendProposePresentationArgs) (*presentproof.SendProposePresentationResponse, error) {
+	response := &presentproof.SendProposePresentationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/send-propose-presentation",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptRequestPresentation accepts a request presentation.
+func (c *PresentProof) AcceptRequestPresentation(ctx context.Context, request *presentproof.AcceptRequestPresentationArgs) (*presentproof.AcceptRequestPresentationResponse, error) {
+	response := &presentproof.AcceptRequestPresentationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/{piid}/accept-request-presentation",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// NegotiateRequestPresentation counters a received presentation request with a proposal.
+func (c *PresentProof) NegotiateRequestPresentation(ctx context.Context, request *presentproof.NegotiateRequestPresentationArgs) (*presentproof.NegotiateRequestPresentationResponse, error) {
+	response := &presentproof.NegotiateRequestPresentationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/{piid}/negotiate-request-presentation",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeclineRequestPresentation declines a request presentation.
+func (c *PresentProof) DeclineRequestPresentation(ctx context.Context, request *presentproof.DeclineRequestPresentationArgs) (*presentproof.DeclineRequestPresentationResponse, error) {
+	response := &presentproof.DeclineRequestPresentationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/{piid}/decline-request-presentation",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptProposePresentation accepts a propose presentation.
+func (c *PresentProof) AcceptProposePresentation(ctx context.Context, request *presentproof.AcceptProposePresentationArgs) (*presentproof.AcceptProposePresentationResponse, error) {
+	response := &presentproof.AcceptProposePresentationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/{piid}/accept-propose-presentation",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeclineProposePresentation declines a propose presentation.
+func (c *PresentProof) DeclineProposePresentation(ctx context.Context, request *presentproof.DeclineProposePresentationArgs) (*presentproof.DeclineProposePresentationResponse, error) {
+	response := &presentproof.DeclineProposePresentationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/{piid}/decline-propose-presentation",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptPresentation accepts a presentation.
+func (c *PresentProof) AcceptPresentation(ctx context.Context, request *presentproof.AcceptPresentationArgs) (*presentproof.AcceptPresentationResponse, error) {
+	response := &presentproof.AcceptPresentationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/{piid}/accept-presentation",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeclinePresentation declines a presentation.
+func (c *PresentProof) DeclinePresentation(ctx context.Context, request *presentproof.DeclinePresentationArgs) (*presentproof.DeclinePresentationResponse, error) {
+	response := &presentproof.DeclinePresentationResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/{piid}/decline-presentation",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AcceptProblemReport accepts a problem report.
+func (c *PresentProof) AcceptProblemReport(ctx context.Context, request *presentproof.AcceptProblemReportArgs) (*presentproof.AcceptProblemReportResponse, error) {
+	response := &presentproof.AcceptProblemReportResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/presentproof/{piid}/accept-problem-report",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// VCWallet is the client of the vcwallet operations.
+type VCWallet struct {
+	client *Client
+}
+
+// VCWallet returns the client of the vcwallet operations.
+func (c *Client) VCWallet() *VCWallet {
+	return &VCWallet{client: c}
+}
+
+// CreateProfile creates the wallet profile of a user.
+func (c *VCWallet) CreateProfile(ctx context.Context, request *vcwallet.CreateProfileRequest) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/create-profile",
+	}, request, nil)
+}
+
+// RemoveProfile removes the wallet profile of a user, with all the contents and keys of the wallet.
+func (c *VCWallet) RemoveProfile(ctx context.Context, request *vcwallet.RemoveProfileRequest) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/remove-profile",
+	}, request, nil)
+}
+
+// Unlock unlocks the wallet of a user, returning the auth token of the wallet session.
+func (c *VCWallet) Unlock(ctx context.Context, request *vcwallet.UnlockWalletRequest) (*vcwallet.UnlockWalletResponse, error) {
+	response := &vcwallet.UnlockWalletResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/unlock",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Lock locks the wallet of a user, expiring the auth token of its session.
+func (c *VCWallet) Lock(ctx context.Context, request *vcwallet.LockWalletRequest) (*vcwallet.LockWalletResponse, error) {
+	response := &vcwallet.LockWalletResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/lock",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// RefreshToken replaces the auth token of the wallet session of a user with a new one.
+func (c *VCWallet) RefreshToken(ctx context.Context, request *vcwallet.RefreshTokenRequest) (*vcwallet.RefreshTokenResponse, error) {
+	response := &vcwallet.RefreshTokenResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/refresh-token",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Add adds a content to the wallet of a user.
+func (c *VCWallet) Add(ctx context.Context, request *vcwallet.AddContentRequest) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/add",
+	}, request, nil)
+}
+
+// Remove removes a content from the wallet of a user.
+func (c *VCWallet) Remove(ctx context.Context, request *vcwallet.RemoveContentRequest) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/remove",
+	}, request, nil)
+}
+
+// Get gets a content from the wallet of a user.
+func (c *VCWallet) Get(ctx context.Context, request *vcwallet.GetContentRequest) (*vcwallet.GetContentResponse, error) {
+	response := &vcwallet.GetContentResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/get",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetAll gets all the contents of a type from the wallet of a user.
+func (c *VCWallet) GetAll(ctx context.Context, request *vcwallet.GetAllContentRequest) (*vcwallet.GetAllContentResponse, error) {
+	response := &vcwallet.GetAllContentResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/getall",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Export exports the contents of the wallet of a user into an archive protected with a passphrase.
+func (c *VCWallet) Export(ctx context.Context, request *vcwallet.ExportWalletRequest) (*vcwallet.ExportWalletResponse, error) {
+	response := &vcwallet.ExportWalletResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/export",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Import imports the contents of an archive exported from a wallet into the wallet of a user.
+func (c *VCWallet) Import(ctx context.Context, request *vcwallet.ImportWalletRequest) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/import",
+	}, request, nil)
+}
+
+// Query runs the queries of a verifiable presentation request over the credentials of the wallet of a user.
+func (c *VCWallet) Query(ctx context.Context, request *vcwallet.QueryRequest) (*vcwallet.QueryResponse, error) {
+	response := &vcwallet.QueryResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/query",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Derive derives the BBS+ selective disclosure of a credential of the wallet of a user.
+func (c *VCWallet) Derive(ctx context.Context, request *vcwallet.DeriveRequest) (*vcwallet.DeriveResponse, error) {
+	response := &vcwallet.DeriveResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/derive",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// PresentProof answers a pending present-proof request presentation with the credentials of the wallet of a user.
+func (c *VCWallet) PresentProof(ctx context.Context, request *vcwallet.PresentProofRequest) (*vcwallet.PresentProofResponse, error) {
+	response := &vcwallet.PresentProofResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/present-proof",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AuditLog returns the records of the disclosures of the credentials of the wallet of a user.
+func (c *VCWallet) AuditLog(ctx context.Context, request *vcwallet.AuditLogRequest) (*vcwallet.AuditLogResponse, error) {
+	response := &vcwallet.AuditLogResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/audit-log",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CreateKeyPair creates a key pair in the KMS of the wallet of a user.
+func (c *VCWallet) CreateKeyPair(ctx context.Context, request *vcwallet.CreateKeyPairRequest) (*vcwallet.CreateKeyPairResponse, error) {
+	response := &vcwallet.CreateKeyPairResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vcwallet/create-key-pair",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// VDR is the client of the vdr operations.
+type VDR struct {
+	client *Client
+}
+
+// VDR returns the client of the vdr operations.
+func (c *Client) VDR() *VDR {
+	return &VDR{client: c}
+}
+
+// SaveDID saves a DID document with its friendly name.
+func (c *VDR) SaveDID(ctx context.Context, request *vdr.DIDArgs) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/vdr/did",
+	}, request, nil)
+}
+
+// GetDID gets the saved DID document.
+func (c *VDR) GetDID(ctx context.Context, request *vdr.IDArg) (*vdr.Document, error) {
+	response := &vdr.Document{}
+
+	err := c.client.do(ctx, &operation{
+		method:     http.MethodGet,
+		path:       "/vdr/did/{id}",
+		query:      true,
+		base64Path: true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ResolveDID resolves the DID.
+func (c *VDR) ResolveDID(ctx context.Context, request *vdr.IDArg) (*vdr.Document, error) {
+	response := &vdr.Document{}
+
+	err := c.client.do(ctx, &operation{
+		method:     http.MethodGet,
+		path:       "/vdr/did/resolve/{id}",
+		query:      true,
+		base64Path: true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetDIDRecords retrieves the DID records.
+func (c *VDR) GetDIDRecords(ctx context.Context, request *vdr.PageArgs) (*vdr.DIDRecordResult, error) {
+	response := &vdr.DIDRecordResult{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/vdr/did/records",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ResolveIdentifier resolves the DID following the HTTP(S) binding of the DID Resolution spec.
+func (c *VDR) ResolveIdentifier(ctx context.Context, request *vdr.IDArg) (*did.Doc, error) {
+	response := &did.Doc{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/1.0/identifiers/{id}",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Verifiable is the client of the verifiable operations.
+type Verifiable struct {
+	client *Client
+}
+
+// Verifiable returns the client of the verifiable operations.
+func (c *Client) Verifiable() *Verifiable {
+	return &Verifiable{client: c}
+}
+
+// ValidateCredential validates the verifiable credential.
+func (c *Verifiable) ValidateCredential(ctx context.Context, request *commandverifiable.Credential) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/credential/validate",
+	}, request, nil)
+}
+
+// SaveCredential saves the verifiable credential.
+func (c *Verifiable) SaveCredential(ctx context.Context, request *commandverifiable.CredentialExt) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/credential",
+	}, request, nil)
+}
+
+// GetCredential retrieves the verifiable credential.
+func (c *Verifiable) GetCredential(ctx context.Context, request *commandverifiable.IDArg) (*commandverifiable.Credential, error) {
+	response := &commandverifiable.Credential{}
+
+	err := c.client.do(ctx, &operation{
+		method:     http.MethodGet,
+		path:       "/verifiable/credential/{id}",
+		query:      true,
+		base64Path: true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetCredentialByName retrieves the verifiable credential by name.
+func (c *Verifiable) GetCredentialByName(ctx context.Context, request *commandverifiable.NameArg) (*storeverifiable.Record, error) {
+	response := &storeverifiable.Record{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/verifiable/credential/name/{name}",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetCredentials retrieves the verifiable credential records, by page if an offset or a limit is given.
+func (c *Verifiable) GetCredentials(ctx context.Context, request *commandverifiable.PageArgs) (*commandverifiable.RecordResult, error) {
+	response := &commandverifiable.RecordResult{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/verifiable/credentials",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SignCredential signs the credential.
+func (c *Verifiable) SignCredential(ctx context.Context, request *commandverifiable.SignCredentialRequest) (*commandverifiable.SignCredentialResponse, error) {
+	response := &commandverifiable.SignCredentialResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/credential/sign",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// VerifyCredential verifies the proofs of the credential.
+func (c *Verifiable) VerifyCredential(ctx context.Context, request *commandverifiable.VerifyCredentialRequest) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/credential/verify",
+	}, request, nil)
+}
+
+// RemoveCredentialByName removes the verifiable credential by name.
+func (c *Verifiable) RemoveCredentialByName(ctx context.Context, request *commandverifiable.NameArg) (*commandverifiable.RemoveCredentialByNameResponse, error) {
+	response := &commandverifiable.RemoveCredentialByNameResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/credential/remove/name/{name}",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GeneratePresentation generates the verifiable presentation of the verifiable credentials.
+func (c *Verifiable) GeneratePresentation(ctx context.Context, request *commandverifiable.PresentationRequest) (*commandverifiable.Presentation, error) {
+	response := &commandverifiable.Presentation{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/presentation/generate",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GeneratePresentationByID generates the verifiable presentation of a stored verifiable credential.
+func (c *Verifiable) GeneratePresentationByID(ctx context.Context, request *commandverifiable.PresentationRequestByID) (*commandverifiable.Presentation, error) {
+	response := &commandverifiable.Presentation{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/presentation/generatebyid",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// VerifyPresentation verifies the proofs of the presentation and of its credentials.
+func (c *Verifiable) VerifyPresentation(ctx context.Context, request *commandverifiable.VerifyPresentationRequest) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/presentation/verify",
+	}, request, nil)
+}
+
+// EvaluatePresentationDefinition retrieves the stored credentials matching each input descriptor of the presentation definition.
+func (c *Verifiable) EvaluatePresentationDefinition(ctx context.Context, request *commandverifiable.PresentationDefinitionRequest) (*commandverifiable.PresentationDefinitionResult, error) {
+	response := &commandverifiable.PresentationDefinitionResult{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/presentation/definition/evaluate",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CreatePresentationSubmission creates a presentation submitting stored credentials against the presentation definition.
+func (c *Verifiable) CreatePresentationSubmission(ctx context.Context, request *commandverifiable.PresentationSubmissionRequest) (*commandverifiable.Presentation, error) {
+	response := &commandverifiable.Presentation{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/presentation/submission",
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SavePresentation saves the verifiable presentation.
+func (c *Verifiable) SavePresentation(ctx context.Context, request *commandverifiable.PresentationExt) error {
+	return c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/presentation",
+	}, request, nil)
+}
+
+// GetPresentation retrieves the verifiable presentation.
+func (c *Verifiable) GetPresentation(ctx context.Context, request *commandverifiable.IDArg) (*commandverifiable.Presentation, error) {
+	response := &commandverifiable.Presentation{}
+
+	err := c.client.do(ctx, &operation{
+		method:     http.MethodGet,
+		path:       "/verifiable/presentation/{id}",
+		query:      true,
+		base64Path: true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetPresentations retrieves the verifiable presentation records, by page if an offset or a limit is given.
+func (c *Verifiable) GetPresentations(ctx context.Context, request *commandverifiable.PageArgs) (*commandverifiable.RecordResult, error) {
+	response := &commandverifiable.RecordResult{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodGet,
+		path:   "/verifiable/presentations",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// RemovePresentationByName removes the verifiable presentation by name.
+func (c *Verifiable) RemovePresentationByName(ctx context.Context, request *commandverifiable.NameArg) (*commandverifiable.RemovePresentationByNameResponse, error) {
+	response := &commandverifiable.RemovePresentationByNameResponse{}
+
+	err := c.client.do(ctx, &operation{
+		method: http.MethodPost,
+		path:   "/verifiable/presentation/remove/name/{name}",
+		query:  true,
+	}, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
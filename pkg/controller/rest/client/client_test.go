@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	didexchange "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	outofband "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
+	vdr "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
+)
+
+func TestClient_Query(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/connections/conn-1/accept-invitation", r.URL.Path)
+		require.Equal(t, "did:example:public", r.URL.Query().Get("public"))
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Empty(t, body)
+
+		require.NoError(t, json.NewEncoder(rw).Encode(&didexchange.AcceptInvitationResponse{State: "requested"}))
+	}))
+	defer srv.Close()
+
+	resp, err := New(srv.URL+"/", WithToken("token")).DIDExchange().AcceptInvitation(context.Background(),
+		&didexchange.AcceptInvitationArgs{ID: "conn-1", Public: "did:example:public"})
+	require.NoError(t, err)
+	require.Equal(t, "requested", resp.State)
+}
+
+func TestClient_Body(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/outofband/accept-invitation", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Empty(t, r.Header.Get("Authorization"))
+
+		var args outofband.AcceptInvitationArgs
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&args))
+		require.Equal(t, "label", args.MyLabel)
+
+		require.NoError(t, json.NewEncoder(rw).Encode(&outofband.AcceptInvitationResponse{ConnectionID: "conn-1"}))
+	}))
+	defer srv.Close()
+
+	resp, err := New(srv.URL, WithHTTPClient(srv.Client())).OutOfBand().AcceptInvitation(context.Background(),
+		&outofband.AcceptInvitationArgs{MyLabel: "label"})
+	require.NoError(t, err)
+	require.Equal(t, "conn-1", resp.ConnectionID)
+}
+
+func TestClient_Base64Path(t *testing.T) {
+	const did = "did:example:123"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/vdr/did/"+base64.StdEncoding.EncodeToString([]byte(did)), r.URL.Path)
+
+		require.NoError(t, json.NewEncoder(rw).Encode(&vdr.Document{DID: json.RawMessage(`{"id":"` + did + `"}`)}))
+	}))
+	defer srv.Close()
+
+	resp, err := New(srv.URL).VDR().GetDID(context.Background(), &vdr.IDArg{ID: did})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"`+did+`"}`, string(resp.DID))
+}
+
+func TestClient_Errors(t *testing.T) {
+	t.Run("API error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, err := rw.Write([]byte(`{"code":2000,"message":"invalid request"}`))
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+
+		_, err := New(srv.URL).VDR().GetDID(context.Background(), &vdr.IDArg{ID: "did:example:123"})
+		require.Error(t, err)
+
+		apiErr, ok := err.(*Error)
+		require.True(t, ok)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		require.Equal(t, command.Code(2000), apiErr.Code)
+		require.Equal(t, "invalid request", apiErr.Message)
+		require.Contains(t, apiErr.Error(), "invalid request")
+	})
+
+	t.Run("non JSON error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			http.Error(rw, "unauthorized", http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+
+		_, err := New(srv.URL).VDR().GetDID(context.Background(), &vdr.IDArg{ID: "did:example:123"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unauthorized")
+	})
+
+	t.Run("missing path parameter", func(t *testing.T) {
+		_, err := New("http://localhost").VDR().GetDID(context.Background(), &vdr.IDArg{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing path parameter id")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			_, err := rw.Write([]byte(`[]`))
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+
+		_, err := New(srv.URL).VDR().GetDID(context.Background(), &vdr.IDArg{ID: "did:example:123"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal response")
+	})
+}
@@ -153,6 +153,11 @@ type queryConnections struct { // nolint: unused,deadcode
 	// in: path
 	// required: true
 	didexchangeSvc.QueryConnectionsParams
+
+	// Cursor of the page of connections to return, as returned in the next_cursor of the previous page
+	//
+	// in: query
+	Cursor string `json:"cursor"`
 }
 
 // queryConnectionResponse model
@@ -177,6 +182,10 @@ type queryConnectionsResponse struct { // nolint: unused,deadcode
 	// in: body
 	Body struct {
 		Results []*didexchangeSvc.Connection `json:"results,omitempty"`
+		// Total number of connections matching the query, ignoring the paging parameters
+		Total int `json:"total,omitempty"`
+		// Cursor of the next page of connections, empty for the last page
+		NextCursor string `json:"next_cursor,omitempty"`
 	}
 }
 
@@ -260,3 +269,53 @@ type createConnectionRequest struct { // nolint: unused,deadcode
 	// required: true
 	Request didexchange.CreateConnectionRequest
 }
+
+// exportConnectionRequest model
+//
+// This is used for exporting a connection
+//
+// swagger:parameters exportConnection
+type exportConnectionRequest struct { // nolint: unused,deadcode
+	// The ID of the connection to export
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// exportConnectionResponse model
+//
+// This is used as the response model for export connection api.
+//
+// swagger:response exportConnectionResponse
+type exportConnectionResponse struct { // nolint: unused,deadcode
+	// in: body
+	Result *didexchangeSvc.ConnectionExport `json:"result"`
+}
+
+// importConnectionRequest model
+//
+// Request to import a connection exported from another agent.
+//
+// swagger:parameters importConnection
+type importConnectionRequest struct { // nolint: unused,deadcode
+	// Params for importing a connection.
+	//
+	// in: body
+	// required: true
+	Request didexchange.ImportConnectionRequest
+}
+
+// importConnectionResponse model
+//
+// This is used as the response model for import connection api.
+//
+// swagger:response importConnectionResponse
+type importConnectionResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// The ID of the imported connection
+		//
+		ID string `json:"id"`
+	}
+}
@@ -8,10 +8,8 @@ package didexchange
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/gorilla/mux"
 
@@ -19,6 +17,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
@@ -35,6 +34,8 @@ const (
 	AcceptExchangeRequest        = OperationID + "/{id}/accept-request"
 	CreateConnection             = OperationID + "/create"
 	RemoveConnection             = OperationID + "/{id}/remove"
+	ExportConnection             = OperationID + "/{id}/export"
+	ImportConnection             = OperationID + "/import"
 )
 
 // provider contains dependencies for the Exchange protocol and is typically created by using aries.Context().
@@ -44,6 +45,7 @@ type provider interface {
 	ServiceEndpoint() string
 	StorageProvider() storage.Provider
 	ProtocolStateStorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
 }
 
 // New returns new DID Exchange rest client protocol instance.
@@ -83,6 +85,8 @@ func (c *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(AcceptExchangeRequest, http.MethodPost, c.AcceptExchangeRequest),
 		cmdutil.NewHTTPHandler(CreateConnection, http.MethodPost, c.CreateConnection),
 		cmdutil.NewHTTPHandler(RemoveConnection, http.MethodPost, c.RemoveConnection),
+		cmdutil.NewHTTPHandler(ExportConnection, http.MethodGet, c.ExportConnection),
+		cmdutil.NewHTTPHandler(ImportConnection, http.MethodPost, c.ImportConnection),
 	}
 }
 
@@ -94,7 +98,7 @@ func (c *Operation) registerHandler() {
 //    default: genericError
 //        200: createInvitationResponse
 func (c *Operation) CreateInvitation(rw http.ResponseWriter, req *http.Request) {
-	reqBytes, err := queryValuesAsJSON(req.URL.Query())
+	reqBytes, err := rest.QueryValuesAsJSON(req.URL.Query())
 	if err != nil {
 		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
 		return
@@ -141,7 +145,7 @@ func (c *Operation) AcceptInvitation(rw http.ResponseWriter, req *http.Request)
 //    default: genericError
 //        200: implicitInvitationResponse
 func (c *Operation) CreateImplicitInvitation(rw http.ResponseWriter, req *http.Request) {
-	reqBytes, err := queryValuesAsJSON(req.URL.Query())
+	reqBytes, err := rest.QueryValuesAsJSON(req.URL.Query())
 	if err != nil {
 		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
 		return
@@ -177,7 +181,7 @@ func (c *Operation) AcceptExchangeRequest(rw http.ResponseWriter, req *http.Requ
 //    default: genericError
 //        200: queryConnectionsResponse
 func (c *Operation) QueryConnections(rw http.ResponseWriter, req *http.Request) {
-	reqBytes, err := queryValuesAsJSON(req.URL.Query())
+	reqBytes, err := rest.QueryValuesAsJSON(req.URL.Query())
 	if err != nil {
 		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
 		return
@@ -233,19 +237,33 @@ func (c *Operation) RemoveConnection(rw http.ResponseWriter, req *http.Request)
 	rest.Execute(c.command.RemoveConnection, rw, bytes.NewBufferString(request))
 }
 
-// queryValuesAsJSON converts query strings to `map[string]string`
-// and marshals them to JSON bytes.
-func queryValuesAsJSON(vals url.Values) ([]byte, error) {
-	// normalize all query string key/values
-	args := make(map[string]string)
-
-	for k, v := range vals {
-		if len(v) > 0 {
-			args[k] = v[0]
-		}
+// ExportConnection swagger:route GET /connections/{id}/export did-exchange exportConnection
+//
+// Exports a completed connection, to be imported into another agent.
+//
+// Responses:
+//    default: genericError
+//    200: exportConnectionResponse
+func (c *Operation) ExportConnection(rw http.ResponseWriter, req *http.Request) {
+	id, found := getIDFromRequest(rw, req)
+	if !found {
+		return
 	}
 
-	return json.Marshal(args)
+	request := fmt.Sprintf(`{"id":"%s"}`, id)
+
+	rest.Execute(c.command.ExportConnection, rw, bytes.NewBufferString(request))
+}
+
+// ImportConnection swagger:route POST /connections/import did-exchange importConnection
+//
+// Imports a connection exported from another agent.
+//
+// Responses:
+//    default: genericError
+//    200: importConnectionResponse
+func (c *Operation) ImportConnection(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.ImportConnection, rw, req.Body)
 }
 
 // getIDFromRequest returns ID from request.
@@ -228,6 +228,25 @@ func TestOperation_QueryConnectionByParams(t *testing.T) {
 			require.NotNil(t, result.ConnectionID)
 		}
 	})
+
+	t.Run("test query connections with paging", func(t *testing.T) {
+		handler = getHandler(t, Connections)
+		buf, err := getSuccessResponseFromHandler(handler, nil,
+			OperationID+"?offset=0&limit=1")
+		require.NoError(t, err)
+
+		response := didexchange.QueryConnectionsResponse{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		require.Len(t, response.Results, 1)
+		require.Equal(t, 1, response.Total)
+
+		buf, code, err := sendRequestToHandler(handler, nil, OperationID+"?limit=ten")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyRESTError(t, didexchange.InvalidRequestErrorCode, buf.Bytes())
+	})
 }
 
 func TestOperation_ReceiveInvitationFailure(t *testing.T) {
@@ -400,6 +419,25 @@ func TestOperation_RemoveConnection(t *testing.T) {
 	})
 }
 
+func TestOperation_ExportImportConnection(t *testing.T) {
+	t.Run("test export pending connection", func(t *testing.T) {
+		handler := getHandler(t, ExportConnection)
+		buf, code, err := sendRequestToHandler(handler, nil, OperationID+"/1234/export")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Contains(t, buf.String(), fmt.Sprintf(`"code":%d`, didexchange.ExportConnectionErrorCode))
+		require.Contains(t, buf.String(), "isn't completed")
+	})
+
+	t.Run("test import empty connection", func(t *testing.T) {
+		handler := getHandler(t, ImportConnection)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), ImportConnection)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Contains(t, buf.String(), "empty connection")
+	})
+}
+
 func TestGetIDFromRequest(t *testing.T) {
 	id, found := getIDFromRequest(httptest.NewRecorder(), &http.Request{})
 	require.False(t, found)
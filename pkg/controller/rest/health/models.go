@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/health"
+)
+
+// healthRes model
+//
+// This is used for returning the liveness or the readiness of the agent and of its components.
+//
+// swagger:response healthRes
+type healthRes struct { // nolint: unused,deadcode
+
+	// in: body
+	health.Response
+}
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+var logger = log.New("aries-framework/rest/health")
+
+// constants for health operations.
+const (
+	HealthOperationID = "/health"
+	LivePath          = HealthOperationID + "/live"
+	ReadyPath         = HealthOperationID + "/ready"
+)
+
+// Operation contains the health endpoints, e.g. for the liveness and readiness probes of Kubernetes.
+type Operation struct {
+	handlers []rest.Handler
+	checker  *health.Checker
+}
+
+// New returns new health operations reporting the probes of the checker (see aries.HealthChecker).
+func New(checker *health.Checker) *Operation {
+	o := &Operation{checker: checker}
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(LivePath, http.MethodGet, o.Live),
+		cmdutil.NewHTTPHandler(ReadyPath, http.MethodGet, o.Ready),
+	}
+}
+
+// Live swagger:route GET /health/live health live
+//
+// Reports the liveness of the agent.
+//
+// Responses:
+//        200: healthRes
+//        503: healthRes
+func (o *Operation) Live(rw http.ResponseWriter, req *http.Request) {
+	writeReport(rw, o.checker.Live(req.Context()))
+}
+
+// Ready swagger:route GET /health/ready health ready
+//
+// Reports the readiness of the agent.
+//
+// Responses:
+//        200: healthRes
+//        503: healthRes
+func (o *Operation) Ready(rw http.ResponseWriter, req *http.Request) {
+	writeReport(rw, o.checker.Ready(req.Context()))
+}
+
+// writeReport writes the report with the 503 status if the agent is down.
+func writeReport(rw http.ResponseWriter, report *health.Report) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	if report.Status != health.StatusUp {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if err := json.NewEncoder(rw).Encode(report); err != nil {
+		logger.Errorf("Unable to send health report, %s", err)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
+)
+
+func TestOperation(t *testing.T) {
+	checker := health.NewChecker()
+	checker.Register("framework", health.Liveness, func(context.Context) error { return nil })
+	checker.Register("storage", health.Readiness, func(context.Context) error { return errors.New("unreachable") })
+
+	handlers := New(checker).GetRESTHandlers()
+	require.Len(t, handlers, 2)
+
+	get := func(path string) (int, *health.Report) {
+		for _, h := range handlers {
+			if h.Path() != path {
+				continue
+			}
+
+			require.Equal(t, http.MethodGet, h.Method())
+
+			rw := httptest.NewRecorder()
+			h.Handle().ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+
+			var report health.Report
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &report))
+
+			return rw.Code, &report
+		}
+
+		require.Failf(t, "handler not found", path)
+
+		return 0, nil
+	}
+
+	status, report := get(LivePath)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, health.StatusUp, report.Status)
+
+	status, report = get(ReadyPath)
+	require.Equal(t, http.StatusServiceUnavailable, status)
+	require.Equal(t, health.StatusDown, report.Status)
+	require.Equal(t, health.ComponentStatus{Status: health.StatusDown, Error: "unreachable"},
+		report.Components["storage"])
+}
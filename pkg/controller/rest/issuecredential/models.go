@@ -105,6 +105,8 @@ type issueCredentialAcceptCredentialRequest struct { // nolint: unused,deadcode
 	Body struct {
 		// required: true
 		Names []string `json:"names"`
+		// RejectedAttachIDs are the attachment IDs of the issued credentials the Holder does not accept
+		RejectedAttachIDs []string `json:"rejected_attach_ids,omitempty"`
 	}
 }
 
@@ -43,3 +43,58 @@ type importKeyReq struct {
 	// in: body
 	kms.JSONWebKey
 }
+
+// signReq model
+//
+// This is used for sign request.
+//
+// swagger:parameters signReq
+type signReq struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.SignRequest
+}
+
+// signRes model
+//
+// This is used for returning the sign response
+//
+// swagger:response signRes
+type signRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.SignResponse
+}
+
+// verifyReq model
+//
+// This is used for verify request.
+//
+// swagger:parameters verifyReq
+type verifyReq struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.VerifyRequest
+}
+
+// wrapKeyReq model
+//
+// This is used for wrap key request.
+//
+// swagger:parameters wrapKeyReq
+type wrapKeyReq struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.WrapKeyRequest
+}
+
+// wrapKeyRes model
+//
+// This is used for returning the wrap key response
+//
+// swagger:response wrapKeyRes
+type wrapKeyRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.WrapKeyResponse
+}
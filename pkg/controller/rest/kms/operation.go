@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package kms
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 
@@ -14,7 +15,9 @@ import (
 	cmdkms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
 // constants for KMS operations.
@@ -22,16 +25,24 @@ const (
 	KmsOperationID   = "/kms"
 	CreateKeySetPath = KmsOperationID + "/keyset"
 	ImportKeyPath    = KmsOperationID + "/import"
+	SignPath         = KmsOperationID + "/sign"
+	VerifyPath       = KmsOperationID + "/verify"
+	WrapKeyPath      = KmsOperationID + "/wrap"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
 type provider interface {
 	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	StorageProvider() storage.Provider
 }
 
 type kmsCommand interface {
 	CreateKeySet(rw io.Writer, req io.Reader) command.Error
 	ImportKey(rw io.Writer, req io.Reader) command.Error
+	Sign(rw io.Writer, req io.Reader) command.Error
+	Verify(rw io.Writer, req io.Reader) command.Error
+	WrapKey(rw io.Writer, req io.Reader) command.Error
 }
 
 // Operation contains basic common operations provided by controller REST API.
@@ -41,13 +52,16 @@ type Operation struct {
 }
 
 // New returns new kms operations rest client instance.
-func New(p provider) *Operation {
-	cmd := cmdkms.New(p)
+func New(p provider) (*Operation, error) {
+	cmd, err := cmdkms.New(p)
+	if err != nil {
+		return nil, fmt.Errorf("create kms command : %w", err)
+	}
 
 	o := &Operation{command: cmd}
 	o.registerHandler()
 
-	return o
+	return o, nil
 }
 
 // GetRESTHandlers get all controller API handler available for this service.
@@ -60,6 +74,9 @@ func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(CreateKeySetPath, http.MethodPost, o.CreateKeySet),
 		cmdutil.NewHTTPHandler(ImportKeyPath, http.MethodPost, o.ImportKey),
+		cmdutil.NewHTTPHandler(SignPath, http.MethodPost, o.Sign),
+		cmdutil.NewHTTPHandler(VerifyPath, http.MethodPost, o.Verify),
+		cmdutil.NewHTTPHandler(WrapKeyPath, http.MethodPost, o.WrapKey),
 	}
 }
 
@@ -83,3 +100,35 @@ func (o *Operation) CreateKeySet(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) ImportKey(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ImportKey, rw, req.Body)
 }
+
+// Sign swagger:route POST /kms/sign kms signReq
+//
+// Sign a message with a key created or imported with the sign usage.
+//
+// Responses:
+//    default: genericError
+//        200: signRes
+func (o *Operation) Sign(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Sign, rw, req.Body)
+}
+
+// Verify swagger:route POST /kms/verify kms verifyReq
+//
+// Verify a signature with a key created or imported with the verify usage.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) Verify(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Verify, rw, req.Body)
+}
+
+// WrapKey swagger:route POST /kms/wrap kms wrapKeyReq
+//
+// Wrap a content encryption key for a recipient.
+//
+// Responses:
+//    default: genericError
+//        200: wrapKeyRes
+func (o *Operation) WrapKey(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.WrapKey, rw, req.Body)
+}
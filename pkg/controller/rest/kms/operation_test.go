@@ -23,21 +23,31 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
 )
 
 func TestNew(t *testing.T) {
 	t.Run("test new command - success", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{
+		cmd := newOperation(t, &mockprovider.Provider{
 			KMSValue: &mockkms.KeyManager{},
 		})
 		require.NotNil(t, cmd)
-		require.Equal(t, 2, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 5, len(cmd.GetRESTHandlers()))
+	})
+
+	t.Run("test new command - error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: fmt.Errorf("error opening store")},
+		})
+		require.Nil(t, cmd)
+		require.EqualError(t, err, "create kms command : failed to open key usage store : error opening store")
 	})
 }
 
 func TestCreateKeySet(t *testing.T) {
 	t.Run("test create key set - success", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{
+		cmd := newOperation(t, &mockprovider.Provider{
 			KMSValue: &mockkms.KeyManager{},
 		})
 		cmd.command = &mockKMSCommand{}
@@ -48,7 +58,7 @@ func TestCreateKeySet(t *testing.T) {
 	})
 
 	t.Run("test create key set - error", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{
+		cmd := newOperation(t, &mockprovider.Provider{
 			KMSValue: &mockkms.KeyManager{CrAndExportPubKeyErr: fmt.Errorf("error create key set")},
 		})
 		require.NotNil(t, cmd)
@@ -72,7 +82,7 @@ func TestCreateKeySet(t *testing.T) {
 
 func TestImportKey(t *testing.T) {
 	t.Run("test import key - success", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{})
+		cmd := newOperation(t, &mockprovider.Provider{})
 		cmd.command = &mockKMSCommand{}
 
 		handler := lookupHandler(t, cmd, ImportKeyPath)
@@ -81,7 +91,7 @@ func TestImportKey(t *testing.T) {
 	})
 
 	t.Run("test import key - error", func(t *testing.T) {
-		cmd := New(&mockprovider.Provider{})
+		cmd := newOperation(t, &mockprovider.Provider{})
 		require.NotNil(t, cmd)
 
 		cmd.command = &mockKMSCommand{importKeyError: command.NewExecuteError(kms.ImportKeyError,
@@ -102,6 +112,67 @@ func TestImportKey(t *testing.T) {
 	})
 }
 
+func TestSign(t *testing.T) {
+	t.Run("test sign - success", func(t *testing.T) {
+		cmd := newOperation(t, &mockprovider.Provider{})
+		cmd.command = &mockKMSCommand{}
+
+		handler := lookupHandler(t, cmd, SignPath)
+		err := getSuccessResponseFromHandler(handler, SignPath)
+		require.NoError(t, err)
+	})
+
+	t.Run("test sign - key usage error", func(t *testing.T) {
+		cmd := newOperation(t, &mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		handler := lookupHandler(t, cmd, SignPath)
+
+		reqBytes, err := json.Marshal(&signReq{SignRequest: kms.SignRequest{KeyID: "k1", Message: "bWVzc2FnZQ"}})
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(reqBytes), SignPath)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, kms.KeyUsageError, "was not created or imported through the kms command", buf.Bytes())
+	})
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("test verify - success", func(t *testing.T) {
+		cmd := newOperation(t, &mockprovider.Provider{})
+		cmd.command = &mockKMSCommand{}
+
+		handler := lookupHandler(t, cmd, VerifyPath)
+		err := getSuccessResponseFromHandler(handler, VerifyPath)
+		require.NoError(t, err)
+	})
+}
+
+func TestWrapKey(t *testing.T) {
+	t.Run("test wrap key - success", func(t *testing.T) {
+		cmd := newOperation(t, &mockprovider.Provider{})
+		cmd.command = &mockKMSCommand{}
+
+		handler := lookupHandler(t, cmd, WrapKeyPath)
+		err := getSuccessResponseFromHandler(handler, WrapKeyPath)
+		require.NoError(t, err)
+	})
+}
+
+func newOperation(t *testing.T, p *mockprovider.Provider) *Operation {
+	t.Helper()
+
+	if p.StorageProviderValue == nil {
+		p.StorageProviderValue = mem.NewProvider()
+	}
+
+	op, err := New(p)
+	require.NoError(t, err)
+
+	return op
+}
+
 func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
@@ -181,3 +252,15 @@ func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error
 func (m *mockKMSCommand) ImportKey(rw io.Writer, req io.Reader) command.Error {
 	return m.importKeyError
 }
+
+func (m *mockKMSCommand) Sign(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) Verify(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) WrapKey(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// nolint: gochecknoglobals
+var httpMethods = map[string]string{
+	"GET":    "http.MethodGet",
+	"POST":   "http.MethodPost",
+	"PUT":    "http.MethodPut",
+	"DELETE": "http.MethodDelete",
+}
+
+// nolint: gochecknoglobals
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by pkg/controller/rest/openapi/gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"net/http"
+{{range .Imports}}
+	{{.Alias}} "{{.Path}}"{{end}}
+)
+{{range .Groups}}
+// {{.Name}} is the client of the {{.Tag}} operations.
+type {{.Name}} struct {
+	client *Client
+}
+
+// {{.Name}} returns the client of the {{.Tag}} operations.
+func (c *Client) {{.Name}}() *{{.Name}} {
+	return &{{.Name}}{client: c}
+}
+{{$group := .Name}}{{range .Methods}}
+// {{.Name}} {{.Doc}}
+func (c *{{$group}}) {{.Name}}(ctx context.Context{{if .Request}}, request *{{.Request}}{{end}}) {{if .Response}}(*{{.Response}}, error){{else}}error{{end}} {
+{{- if .Response}}
+	response := &{{.Response}}{}
+
+	err := c.client.do(ctx, &operation{
+		method: {{.Method}},
+		path: "{{.Path}}",{{if .Query}}
+		query: true,{{end}}{{if .Base64Path}}
+		base64Path: true,{{end}}
+	}, {{if .Request}}request{{else}}nil{{end}}, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+{{- else}}
+	return c.client.do(ctx, &operation{
+		method: {{.Method}},
+		path: "{{.Path}}",{{if .Query}}
+		query: true,{{end}}{{if .Base64Path}}
+		base64Path: true,{{end}}
+	}, {{if .Request}}request{{else}}nil{{end}}, nil)
+{{- end}}
+}
+{{end}}{{end}}`))
+
+type clientImport struct {
+	Alias string
+	Path  string
+}
+
+type clientGroup struct {
+	Name    string
+	Tag     string
+	Methods []clientMethod
+}
+
+type clientMethod struct {
+	Name       string
+	Doc        string
+	Method     string
+	Path       string
+	Request    string
+	Response   string
+	Query      bool
+	Base64Path bool
+}
+
+// GenerateClient generates the Go source of the typed client of the operations, the methods of each group of
+// operations being offered by a client of the group. The deprecated operations have no client methods.
+func GenerateClient(pkg string, ops []Operation) ([]byte, error) {
+	aliases := importAliases(ops)
+
+	data := struct {
+		Package string
+		Imports []clientImport
+		Groups  []*clientGroup
+	}{Package: pkg}
+
+	for path, alias := range aliases {
+		data.Imports = append(data.Imports, clientImport{Alias: alias, Path: path})
+	}
+
+	sort.Slice(data.Imports, func(i, j int) bool { return data.Imports[i].Path < data.Imports[j].Path })
+
+	groups := make(map[string]*clientGroup)
+
+	for i := range ops {
+		op := &ops[i]
+		if op.Deprecated {
+			continue
+		}
+
+		method, ok := httpMethods[op.Method]
+		if !ok {
+			return nil, fmt.Errorf("operation %s: unsupported HTTP method %s", op.ID(), op.Method)
+		}
+
+		group, ok := groups[op.Group]
+		if !ok {
+			group = &clientGroup{Name: op.Group, Tag: op.Tag}
+			groups[op.Group] = group
+			data.Groups = append(data.Groups, group)
+		}
+
+		group.Methods = append(group.Methods, clientMethod{
+			Name:       op.Name,
+			Doc:        methodDoc(op.Summary),
+			Method:     method,
+			Path:       op.Path,
+			Request:    typeName(op.Request, aliases),
+			Response:   typeName(op.Response, aliases),
+			Query:      op.Query,
+			Base64Path: op.Base64Path,
+		})
+	}
+
+	var src bytes.Buffer
+
+	if err := clientTemplate.Execute(&src, data); err != nil {
+		return nil, fmt.Errorf("execute client template: %w", err)
+	}
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format client source: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// importAliases returns the aliases of the packages of the models, by package path. The packages sharing the same
+// name are aliased with the name of their parent folder as prefix, e.g. commanddidexchange and clientdidexchange.
+func importAliases(ops []Operation) map[string]string {
+	names := make(map[string]string)
+	paths := make(map[string][]string)
+
+	for i := range ops {
+		for _, model := range []interface{}{ops[i].Request, ops[i].Response} {
+			if model == nil {
+				continue
+			}
+
+			t := reflect.TypeOf(model)
+			if _, ok := names[t.PkgPath()]; ok {
+				continue
+			}
+
+			name := strings.SplitN(t.String(), ".", 2)[0] // nolint: gomnd
+			names[t.PkgPath()] = name
+			paths[name] = append(paths[name], t.PkgPath())
+		}
+	}
+
+	aliases := make(map[string]string, len(names))
+
+	for path, name := range names {
+		if len(paths[name]) == 1 {
+			aliases[path] = name
+
+			continue
+		}
+
+		elems := strings.Split(path, "/")
+		aliases[path] = elems[len(elems)-2] + name // nolint: gomnd
+	}
+
+	return aliases
+}
+
+func typeName(model interface{}, aliases map[string]string) string {
+	if model == nil {
+		return ""
+	}
+
+	t := reflect.TypeOf(model)
+
+	return aliases[t.PkgPath()] + "." + t.Name()
+}
+
+// methodDoc turns the summary of the operation into the end of the doc comment of its method.
+func methodDoc(summary string) string {
+	r, size := utf8.DecodeRuneInString(summary)
+
+	return string(unicode.ToLower(r)) + summary[size:]
+}
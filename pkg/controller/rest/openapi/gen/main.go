@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Command gen generates the OpenAPI 3 document of the controller REST API and its typed Go client:
+//
+//	go run ./pkg/controller/rest/openapi/gen -spec build/rest/openapi/spec/openAPIv3.json
+//	go run ./pkg/controller/rest/openapi/gen -client pkg/controller/rest/client/client_gen.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/openapi"
+)
+
+const fileMode = 0o644
+
+func main() {
+	specPath := flag.String("spec", "", "path of the OpenAPI 3 document to generate")
+	clientPath := flag.String("client", "", "path of the Go client source to generate")
+	clientPkg := flag.String("package", "client", "package of the Go client")
+	version := flag.String("version", "0.1.5", "version of the API")
+
+	flag.Parse()
+
+	if err := generate(*specPath, *clientPath, *clientPkg, *version); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func generate(specPath, clientPath, clientPkg, version string) error {
+	if specPath == "" && clientPath == "" {
+		return fmt.Errorf("nothing to generate, the spec or client path must be set")
+	}
+
+	ops := openapi.Operations()
+
+	if specPath != "" {
+		doc, err := openapi.NewDocument(version, ops)
+		if err != nil {
+			return fmt.Errorf("create OpenAPI document: %w", err)
+		}
+
+		spec, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal OpenAPI document: %w", err)
+		}
+
+		if err := ioutil.WriteFile(specPath, append(spec, '\n'), fileMode); err != nil {
+			return fmt.Errorf("write OpenAPI document: %w", err)
+		}
+	}
+
+	if clientPath != "" {
+		src, err := openapi.GenerateClient(clientPkg, ops)
+		if err != nil {
+			return fmt.Errorf("generate client: %w", err)
+		}
+
+		if err := ioutil.WriteFile(clientPath, src, fileMode); err != nil {
+			return fmt.Errorf("write client: %w", err)
+		}
+	}
+
+	return nil
+}
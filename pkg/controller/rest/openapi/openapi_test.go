@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
+)
+
+func TestNewDocument(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		doc, err := NewDocument("1.0.0", Operations())
+		require.NoError(t, err)
+		require.Equal(t, "3.0.3", doc.OpenAPI)
+
+		_, err = json.Marshal(doc)
+		require.NoError(t, err)
+
+		ids := make(map[string]bool)
+
+		for path, item := range doc.Paths {
+			for method, op := range item {
+				require.False(t, ids[op.OperationID], "duplicate operation ID %s", op.OperationID)
+				ids[op.OperationID] = true
+
+				for _, param := range PathParams(path) {
+					require.True(t, hasParameter(op, param, "path"), "%s %s: missing %s", method, path, param)
+				}
+			}
+		}
+
+		require.NotEmpty(t, doc.Components.Schemas)
+
+		for name, schema := range doc.Components.Schemas {
+			require.Equal(t, "object", schema.Type, name)
+		}
+	})
+
+	t.Run("duplicate operation", func(t *testing.T) {
+		ops := Operations()
+
+		_, err := NewDocument("1.0.0", append(ops, ops[0]))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "duplicate operation")
+	})
+
+	t.Run("path parameter not in request", func(t *testing.T) {
+		_, err := NewDocument("1.0.0", []Operation{{
+			Group: "Test", Name: "Get", Method: "GET", Path: "/test/{id}",
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "path parameters [id] without request")
+
+		_, err = NewDocument("1.0.0", []Operation{{
+			Group: "Test", Name: "Get", Method: "GET", Path: "/test/{id}", Request: struct{ Name string }{},
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "path parameter id is not a request field")
+	})
+}
+
+func TestOperations_CoverRESTHandlers(t *testing.T) {
+	framework, err := aries.New(defaults.WithInboundHTTPAddr(":26518", "", "", ""))
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, framework.Close()) }()
+
+	ctx, err := framework.Context()
+	require.NoError(t, err)
+
+	handlers, err := controller.GetRESTHandlers(ctx)
+	require.NoError(t, err)
+
+	ops := make(map[string]bool)
+
+	for _, op := range Operations() {
+		ops[op.Method+" "+op.Path] = true
+	}
+
+	for _, handler := range handlers {
+		// the websocket and the event stream of the notifications are no REST operations
+		if handler.Path() == webnotifier.WSPath || handler.Path() == webnotifier.EventsPath+webnotifier.StreamPathSuffix {
+			continue
+		}
+
+		require.True(t, ops[handler.Method()+" "+handler.Path()],
+			"no operation for %s %s", handler.Method(), handler.Path())
+	}
+}
+
+func TestGenerateClient(t *testing.T) {
+	t.Run("generated client is up to date", func(t *testing.T) {
+		src, err := GenerateClient("client", Operations())
+		require.NoError(t, err)
+
+		committed, err := ioutil.ReadFile("../client/client_gen.go")
+		require.NoError(t, err)
+
+		require.Equal(t, string(committed), string(src),
+			"the client is outdated, run go generate ./pkg/controller/rest/client")
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		_, err := GenerateClient("client", []Operation{{Group: "Test", Name: "Patch", Method: "PATCH", Path: "/test"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported HTTP method PATCH")
+	})
+}
+
+func hasParameter(op *OperationObject, name, in string) bool {
+	for _, param := range op.Parameters {
+		if param.Name == name && param.In == in {
+			return true
+		}
+	}
+
+	return false
+}
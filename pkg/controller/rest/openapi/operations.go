@@ -0,0 +1,970 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"net/http"
+
+	didexchangesvc "github.com/hyperledger/aries-framework-go/pkg/client/didexchange"
+	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	introducecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
+	issuecredentialcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
+	kmscmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
+	mediatorcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/mediator"
+	messagingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
+	outofbandcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
+	presentproofcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	vcwalletcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
+	vdrcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
+	verifiablecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	batchrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/batch"
+	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
+	introducerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
+	issuecredentialrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
+	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
+	mediatorrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/mediator"
+	messagingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/messaging"
+	outofbandrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
+	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
+	vcwalletrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcwallet"
+	vdrrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
+	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+// Operation describes a REST operation of the controller. Its request and response are the command models the REST
+// handler exchanges, the path parameters being the request fields of the same JSON name.
+type Operation struct {
+	// Group of the operation, e.g. the protocol it belongs to, naming its client.
+	Group string
+	// Name of the operation in its group, naming its client method.
+	Name string
+	// Tag of the operation in the OpenAPI document.
+	Tag     string
+	Method  string
+	Path    string
+	Summary string
+	// Request is the zero value of the request model, nil if the operation takes no request.
+	Request interface{}
+	// Response is the zero value of the response model, nil if the operation returns no response.
+	Response interface{}
+	// Query tells whether the request fields other than the path parameters are sent as query parameters,
+	// instead of as the JSON body.
+	Query bool
+	// Base64Path tells whether the path parameters are base64 encoded.
+	Base64Path bool
+	// Deprecated tells whether the operation is deprecated, in which case it has no client method.
+	Deprecated bool
+}
+
+// ID returns the identifier of the operation in the OpenAPI document.
+func (o *Operation) ID() string {
+	return o.Group + o.Name
+}
+
+const (
+	batchTag           = "batch"
+	didExchangeTag     = "did-exchange"
+	eventsTag          = "events"
+	introduceTag       = "introduce"
+	issueCredentialTag = "issue-credential"
+	kmsTag             = "kms"
+	mediatorTag        = "mediator"
+	messagingTag       = "message"
+	outOfBandTag       = "outofband"
+	presentProofTag    = "present-proof"
+	vcWalletTag        = "vcwallet"
+	vdrTag             = "vdr"
+	verifiableTag      = "verifiable"
+)
+
+// Operations returns the REST operations of the controller.
+func Operations() []Operation {
+	var ops []Operation
+
+	ops = append(ops, batchOperations()...)
+	ops = append(ops, didExchangeOperations()...)
+	ops = append(ops, eventsOperations()...)
+	ops = append(ops, introduceOperations()...)
+	ops = append(ops, issueCredentialOperations()...)
+	ops = append(ops, kmsOperations()...)
+	ops = append(ops, mediatorOperations()...)
+	ops = append(ops, messagingOperations()...)
+	ops = append(ops, outOfBandOperations()...)
+	ops = append(ops, presentProofOperations()...)
+	ops = append(ops, vcWalletOperations()...)
+	ops = append(ops, vdrOperations()...)
+	ops = append(ops, verifiableOperations()...)
+
+	return ops
+}
+
+func batchOperations() []Operation {
+	return []Operation{
+		{
+			Group: "Batch", Name: "Execute", Tag: batchTag,
+			Method: http.MethodPost, Path: batchrest.Path,
+			Summary:  "Invokes a list of REST operations in order, optionally as a transaction.",
+			Request:  batchrest.Request{},
+			Response: batchrest.Response{},
+		},
+	}
+}
+
+func didExchangeOperations() []Operation { // nolint: funlen
+	return []Operation{
+		{
+			Group: "DIDExchange", Name: "CreateInvitation", Tag: didExchangeTag,
+			Method: http.MethodPost, Path: didexchangerest.CreateInvitationPath,
+			Summary:  "Creates a new connection invitation.",
+			Request:  didexchangecmd.CreateInvitationArgs{},
+			Response: didexchangecmd.CreateInvitationResponse{},
+			Query:    true,
+		},
+		{
+			Group: "DIDExchange", Name: "ReceiveInvitation", Tag: didExchangeTag,
+			Method: http.MethodPost, Path: didexchangerest.ReceiveInvitationPath,
+			Summary:  "Receives a new connection invitation.",
+			Request:  didexchangesvc.Invitation{},
+			Response: didexchangecmd.ReceiveInvitationResponse{},
+		},
+		{
+			Group: "DIDExchange", Name: "AcceptInvitation", Tag: didExchangeTag,
+			Method: http.MethodPost, Path: didexchangerest.AcceptInvitationPath,
+			Summary:  "Accepts a stored connection invitation.",
+			Request:  didexchangecmd.AcceptInvitationArgs{},
+			Response: didexchangecmd.AcceptInvitationResponse{},
+			Query:    true,
+		},
+		{
+			Group: "DIDExchange", Name: "CreateImplicitInvitation", Tag: didExchangeTag,
+			Method: http.MethodPost, Path: didexchangerest.CreateImplicitInvitationPath,
+			Summary:  "Creates an implicit invitation using the inviter DID.",
+			Request:  didexchangecmd.ImplicitInvitationArgs{},
+			Response: didexchangecmd.ImplicitInvitationResponse{},
+			Query:    true,
+		},
+		{
+			Group: "DIDExchange", Name: "AcceptExchangeRequest", Tag: didExchangeTag,
+			Method: http.MethodPost, Path: didexchangerest.AcceptExchangeRequest,
+			Summary:  "Accepts a stored connection request.",
+			Request:  didexchangecmd.AcceptExchangeRequestArgs{},
+			Response: didexchangecmd.ExchangeResponse{},
+			Query:    true,
+		},
+		{
+			Group: "DIDExchange", Name: "QueryConnections", Tag: didExchangeTag,
+			Method: http.MethodGet, Path: didexchangerest.Connections,
+			Summary:  "Queries the agent to agent connections.",
+			Request:  didexchangecmd.QueryConnectionsArgs{},
+			Response: didexchangecmd.QueryConnectionsResponse{},
+			Query:    true,
+		},
+		{
+			Group: "DIDExchange", Name: "QueryConnectionByID", Tag: didExchangeTag,
+			Method: http.MethodGet, Path: didexchangerest.ConnectionsByID,
+			Summary:  "Fetches a single connection record.",
+			Request:  didexchangecmd.ConnectionIDArg{},
+			Response: didexchangecmd.QueryConnectionResponse{},
+			Query:    true,
+		},
+		{
+			Group: "DIDExchange", Name: "CreateConnection", Tag: didExchangeTag,
+			Method: http.MethodPost, Path: didexchangerest.CreateConnection,
+			Summary:  "Saves the connection record.",
+			Request:  didexchangecmd.CreateConnectionRequest{},
+			Response: didexchangecmd.ConnectionIDArg{},
+		},
+		{
+			Group: "DIDExchange", Name: "RemoveConnection", Tag: didExchangeTag,
+			Method: http.MethodPost, Path: didexchangerest.RemoveConnection,
+			Summary: "Removes the connection record.",
+			Request: didexchangecmd.ConnectionIDArg{},
+			Query:   true,
+		},
+		{
+			Group: "DIDExchange", Name: "ExportConnection", Tag: didExchangeTag,
+			Method: http.MethodGet, Path: didexchangerest.ExportConnection,
+			Summary:  "Exports a completed connection, to be imported into another agent.",
+			Request:  didexchangecmd.ConnectionIDArg{},
+			Response: didexchangecmd.ExportConnectionResponse{},
+			Query:    true,
+		},
+		{
+			Group: "DIDExchange", Name: "ImportConnection", Tag: didExchangeTag,
+			Method: http.MethodPost, Path: didexchangerest.ImportConnection,
+			Summary:  "Imports a connection exported from another agent.",
+			Request:  didexchangecmd.ImportConnectionRequest{},
+			Response: didexchangecmd.ConnectionIDArg{},
+		},
+	}
+}
+
+func eventsOperations() []Operation {
+	return []Operation{
+		{
+			Group: "Events", Name: "Poll", Tag: eventsTag,
+			Method: http.MethodGet, Path: webnotifier.EventsPath,
+			Summary:  "Waits for the notifications published after the resume token.",
+			Request:  webnotifier.PollArgs{},
+			Response: webnotifier.PollResponse{},
+			Query:    true,
+		},
+	}
+}
+
+func introduceOperations() []Operation { // nolint: funlen
+	return []Operation{
+		{
+			Group: "Introduce", Name: "Actions", Tag: introduceTag,
+			Method: http.MethodGet, Path: introducerest.Actions,
+			Summary:  "Returns the pending actions that have not yet been executed or cancelled.",
+			Response: introducecmd.ActionsResponse{},
+		},
+		{
+			Group: "Introduce", Name: "SendProposal", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.SendProposal,
+			Summary:  "Sends a proposal.",
+			Request:  introducecmd.SendProposalArgs{},
+			Response: introducecmd.SendProposalResponse{},
+		},
+		{
+			Group: "Introduce", Name: "SendProposalWithOOBRequest", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.SendProposalWithOOBRequest,
+			Summary:  "Sends a proposal with an out-of-band request.",
+			Request:  introducecmd.SendProposalWithOOBRequestArgs{},
+			Response: introducecmd.SendProposalWithOOBRequestResponse{},
+		},
+		{
+			Group: "Introduce", Name: "SendRequest", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.SendRequest,
+			Summary:  "Sends a request.",
+			Request:  introducecmd.SendRequestArgs{},
+			Response: introducecmd.SendRequestResponse{},
+		},
+		{
+			Group: "Introduce", Name: "AcceptProposalWithOOBRequest", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.AcceptProposalWithOOBRequest,
+			Summary:  "Accepts a proposal with an out-of-band request.",
+			Request:  introducecmd.AcceptProposalWithOOBRequestArgs{},
+			Response: introducecmd.AcceptProposalWithOOBRequestResponse{},
+		},
+		{
+			Group: "Introduce", Name: "AcceptProposal", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.AcceptProposal,
+			Summary:  "Accepts a proposal.",
+			Request:  introducecmd.AcceptProposalArgs{},
+			Response: introducecmd.AcceptProposalResponse{},
+			Query:    true,
+		},
+		{
+			Group: "Introduce", Name: "AcceptRequestWithPublicOOBRequest", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.AcceptRequestWithPublicOOBRequest,
+			Summary:  "Accepts a request with a public out-of-band request.",
+			Request:  introducecmd.AcceptRequestWithPublicOOBRequestArgs{},
+			Response: introducecmd.AcceptRequestWithPublicOOBRequestResponse{},
+		},
+		{
+			Group: "Introduce", Name: "AcceptRequestWithRecipients", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.AcceptRequestWithRecipients,
+			Summary:  "Accepts a request with recipients.",
+			Request:  introducecmd.AcceptRequestWithRecipientsArgs{},
+			Response: introducecmd.AcceptRequestWithRecipientsResponse{},
+		},
+		{
+			Group: "Introduce", Name: "DeclineProposal", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.DeclineProposal,
+			Summary:  "Declines a proposal.",
+			Request:  introducecmd.DeclineProposalArgs{},
+			Response: introducecmd.DeclineProposalResponse{},
+			Query:    true,
+		},
+		{
+			Group: "Introduce", Name: "DeclineRequest", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.DeclineRequest,
+			Summary:  "Declines a request.",
+			Request:  introducecmd.DeclineRequestArgs{},
+			Response: introducecmd.DeclineRequestResponse{},
+			Query:    true,
+		},
+		{
+			Group: "Introduce", Name: "AcceptProblemReport", Tag: introduceTag,
+			Method: http.MethodPost, Path: introducerest.AcceptProblemReport,
+			Summary:  "Accepts a problem report.",
+			Request:  introducecmd.AcceptProblemReportArgs{},
+			Response: introducecmd.AcceptProblemReportResponse{},
+			Query:    true,
+		},
+	}
+}
+
+func issueCredentialOperations() []Operation { // nolint: funlen
+	return []Operation{
+		{
+			Group: "IssueCredential", Name: "Actions", Tag: issueCredentialTag,
+			Method: http.MethodGet, Path: issuecredentialrest.Actions,
+			Summary:  "Returns the pending actions that have not yet been executed or cancelled.",
+			Response: issuecredentialcmd.ActionsResponse{},
+		},
+		{
+			Group: "IssueCredential", Name: "SendOffer", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.SendOffer,
+			Summary:  "Sends an offer.",
+			Request:  issuecredentialcmd.SendOfferArgs{},
+			Response: issuecredentialcmd.SendOfferResponse{},
+		},
+		{
+			Group: "IssueCredential", Name: "SendProposal", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.SendProposal,
+			Summary:  "Sends a proposal.",
+			Request:  issuecredentialcmd.SendProposalArgs{},
+			Response: issuecredentialcmd.SendProposalResponse{},
+		},
+		{
+			Group: "IssueCredential", Name: "SendRequest", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.SendRequest,
+			Summary:  "Sends a request.",
+			Request:  issuecredentialcmd.SendRequestArgs{},
+			Response: issuecredentialcmd.SendRequestResponse{},
+		},
+		{
+			Group: "IssueCredential", Name: "AcceptProposal", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.AcceptProposal,
+			Summary:  "Accepts a proposal.",
+			Request:  issuecredentialcmd.AcceptProposalArgs{},
+			Response: issuecredentialcmd.AcceptProposalResponse{},
+		},
+		{
+			Group: "IssueCredential", Name: "DeclineProposal", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.DeclineProposal,
+			Summary:  "Declines a proposal.",
+			Request:  issuecredentialcmd.DeclineProposalArgs{},
+			Response: issuecredentialcmd.DeclineProposalResponse{},
+			Query:    true,
+		},
+		{
+			Group: "IssueCredential", Name: "AcceptOffer", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.AcceptOffer,
+			Summary:  "Accepts an offer.",
+			Request:  issuecredentialcmd.AcceptOfferArgs{},
+			Response: issuecredentialcmd.AcceptOfferResponse{},
+			Query:    true,
+		},
+		{
+			Group: "IssueCredential", Name: "DeclineOffer", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.DeclineOffer,
+			Summary:  "Declines an offer.",
+			Request:  issuecredentialcmd.DeclineOfferArgs{},
+			Response: issuecredentialcmd.DeclineOfferResponse{},
+			Query:    true,
+		},
+		{
+			Group: "IssueCredential", Name: "NegotiateProposal", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.NegotiateProposal,
+			Summary:  "Negotiates a received offer with a counter proposal.",
+			Request:  issuecredentialcmd.NegotiateProposalArgs{},
+			Response: issuecredentialcmd.NegotiateProposalResponse{},
+		},
+		{
+			Group: "IssueCredential", Name: "AcceptRequest", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.AcceptRequest,
+			Summary:  "Accepts a request.",
+			Request:  issuecredentialcmd.AcceptRequestArgs{},
+			Response: issuecredentialcmd.AcceptRequestResponse{},
+		},
+		{
+			Group: "IssueCredential", Name: "DeclineRequest", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.DeclineRequest,
+			Summary:  "Declines a request.",
+			Request:  issuecredentialcmd.DeclineRequestArgs{},
+			Response: issuecredentialcmd.DeclineRequestResponse{},
+			Query:    true,
+		},
+		{
+			Group: "IssueCredential", Name: "AcceptCredential", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.AcceptCredential,
+			Summary:  "Accepts a credential.",
+			Request:  issuecredentialcmd.AcceptCredentialArgs{},
+			Response: issuecredentialcmd.AcceptCredentialResponse{},
+		},
+		{
+			Group: "IssueCredential", Name: "DeclineCredential", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.DeclineCredential,
+			Summary:  "Declines a credential.",
+			Request:  issuecredentialcmd.DeclineCredentialArgs{},
+			Response: issuecredentialcmd.DeclineCredentialResponse{},
+			Query:    true,
+		},
+		{
+			Group: "IssueCredential", Name: "AcceptProblemReport", Tag: issueCredentialTag,
+			Method: http.MethodPost, Path: issuecredentialrest.AcceptProblemReport,
+			Summary:  "Accepts a problem report.",
+			Request:  issuecredentialcmd.AcceptProblemReportArgs{},
+			Response: issuecredentialcmd.AcceptProblemReportResponse{},
+			Query:    true,
+		},
+	}
+}
+
+func kmsOperations() []Operation {
+	return []Operation{
+		{
+			Group: "KMS", Name: "CreateKeySet", Tag: kmsTag,
+			Method: http.MethodPost, Path: kmsrest.CreateKeySetPath,
+			Summary:  "Creates a key set.",
+			Request:  kmscmd.CreateKeySetRequest{},
+			Response: kmscmd.CreateKeySetResponse{},
+		},
+		{
+			Group: "KMS", Name: "ImportKey", Tag: kmsTag,
+			Method: http.MethodPost, Path: kmsrest.ImportKeyPath,
+			Summary: "Imports a key.",
+			Request: kmscmd.JSONWebKey{},
+		},
+		{
+			Group: "KMS", Name: "Sign", Tag: kmsTag,
+			Method: http.MethodPost, Path: kmsrest.SignPath,
+			Summary:  "Signs a message with a key created or imported with the sign usage.",
+			Request:  kmscmd.SignRequest{},
+			Response: kmscmd.SignResponse{},
+		},
+		{
+			Group: "KMS", Name: "Verify", Tag: kmsTag,
+			Method: http.MethodPost, Path: kmsrest.VerifyPath,
+			Summary: "Verifies a signature with a key created or imported with the verify usage.",
+			Request: kmscmd.VerifyRequest{},
+		},
+		{
+			Group: "KMS", Name: "WrapKey", Tag: kmsTag,
+			Method: http.MethodPost, Path: kmsrest.WrapKeyPath,
+			Summary:  "Wraps a content encryption key for a recipient.",
+			Request:  kmscmd.WrapKeyRequest{},
+			Response: kmscmd.WrapKeyResponse{},
+		},
+	}
+}
+
+func mediatorOperations() []Operation { // nolint: funlen
+	return []Operation{
+		{
+			Group: "Mediator", Name: "Register", Tag: mediatorTag,
+			Method: http.MethodPost, Path: mediatorrest.RegisterPath,
+			Summary: "Registers the agent with the router.",
+			Request: mediatorcmd.RegisterRoute{},
+		},
+		{
+			Group: "Mediator", Name: "Unregister", Tag: mediatorTag,
+			Method: http.MethodDelete, Path: mediatorrest.UnregisterPath,
+			Summary: "Unregisters the agent with the router.",
+			Request: mediatorcmd.RegisterRoute{},
+		},
+		{
+			Group: "Mediator", Name: "Connections", Tag: mediatorTag,
+			Method: http.MethodGet, Path: mediatorrest.GetConnectionsPath,
+			Summary:  "Retrieves the router connections.",
+			Response: mediatorcmd.ConnectionsResponse{},
+		},
+		{
+			Group: "Mediator", Name: "Reconnect", Tag: mediatorTag,
+			Method: http.MethodPost, Path: mediatorrest.ReconnectPath,
+			Summary: "Reconnects the agent with the router to re-establish a lost connection.",
+			Request: mediatorcmd.RegisterRoute{},
+		},
+		{
+			Group: "Mediator", Name: "Status", Tag: mediatorTag,
+			Method: http.MethodPost, Path: mediatorrest.StatusPath,
+			Summary:  "Returns details about the pending messages of the connection.",
+			Request:  mediatorcmd.StatusRequest{},
+			Response: mediatorcmd.StatusResponse{},
+		},
+		{
+			Group: "Mediator", Name: "BatchPickup", Tag: mediatorTag,
+			Method: http.MethodPost, Path: mediatorrest.BatchPickupPath,
+			Summary:  "Dispatches the pending messages of the connection.",
+			Request:  mediatorcmd.BatchPickupRequest{},
+			Response: mediatorcmd.BatchPickupResponse{},
+		},
+		{
+			Group: "Mediator", Name: "ReconnectAll", Tag: mediatorTag,
+			Method: http.MethodGet, Path: mediatorrest.ReconnectAllPath,
+			Summary: "Re-establishes the network connections of all the mediator connections.",
+		},
+	}
+}
+
+func messagingOperations() []Operation {
+	return []Operation{
+		{
+			Group: "Messaging", Name: "RegisterService", Tag: messagingTag,
+			Method: http.MethodPost, Path: messagingrest.RegisterMsgService,
+			Summary: "Registers a new message service to the message handler registrar.",
+			Request: messagingcmd.RegisterMsgSvcArgs{},
+		},
+		{
+			Group: "Messaging", Name: "UnregisterService", Tag: messagingTag,
+			Method: http.MethodPost, Path: messagingrest.UnregisterMsgService,
+			Summary: "Unregisters the message service from the message handler registrar.",
+			Request: messagingcmd.UnregisterMsgSvcArgs{},
+		},
+		{
+			Group: "Messaging", Name: "Services", Tag: messagingTag,
+			Method: http.MethodGet, Path: messagingrest.MsgServiceList,
+			Summary:  "Returns the names of the registered message services.",
+			Response: messagingcmd.RegisteredServicesResponse{},
+		},
+		{
+			Group: "Messaging", Name: "Send", Tag: messagingTag,
+			Method: http.MethodPost, Path: messagingrest.SendNewMsg,
+			Summary:  "Sends a new message to the destination.",
+			Request:  messagingcmd.SendNewMessageArgs{},
+			Response: messagingcmd.SendMessageResponse{},
+		},
+		{
+			Group: "Messaging", Name: "Reply", Tag: messagingTag,
+			Method: http.MethodPost, Path: messagingrest.SendReplyMsg,
+			Summary:  "Sends a reply to an existing message.",
+			Request:  messagingcmd.SendReplyMessageArgs{},
+			Response: messagingcmd.SendMessageResponse{},
+		},
+		{
+			Group: "Messaging", Name: "RegisterHTTPService", Tag: messagingTag,
+			Method: http.MethodPost, Path: messagingrest.RegisterHTTPOverDIDCommService,
+			Summary: "Registers a new HTTP over DIDComm service to the message handler registrar.",
+			Request: messagingcmd.RegisterHTTPMsgSvcArgs{},
+		},
+	}
+}
+
+func outOfBandOperations() []Operation { // nolint: funlen
+	return []Operation{
+		{
+			Group: "OutOfBand", Name: "Actions", Tag: outOfBandTag,
+			Method: http.MethodGet, Path: outofbandrest.Actions,
+			Summary:  "Returns the pending actions that have not yet been executed or cancelled.",
+			Response: outofbandcmd.ActionsResponse{},
+		},
+		{
+			Group: "OutOfBand", Name: "ActionContinue", Tag: outOfBandTag,
+			Method: http.MethodPost, Path: outofbandrest.ActionContinue,
+			Summary:  "Continues the protocol after an action event was triggered.",
+			Request:  outofbandcmd.ActionContinueArgs{},
+			Response: outofbandcmd.ActionContinueResponse{},
+			Query:    true,
+		},
+		{
+			Group: "OutOfBand", Name: "ActionStop", Tag: outOfBandTag,
+			Method: http.MethodPost, Path: outofbandrest.ActionStop,
+			Summary:  "Stops the protocol after an action event was triggered.",
+			Request:  outofbandcmd.ActionStopArgs{},
+			Response: outofbandcmd.ActionStopResponse{},
+			Query:    true,
+		},
+		{
+			Group: "OutOfBand", Name: "CreateRequest", Tag: outOfBandTag,
+			Method: http.MethodPost, Path: outofbandrest.CreateRequest,
+			Summary:  "Creates a request.",
+			Request:  outofbandcmd.CreateRequestArgs{},
+			Response: outofbandcmd.CreateRequestResponse{},
+		},
+		{
+			Group: "OutOfBand", Name: "CreateInvitation", Tag: outOfBandTag,
+			Method: http.MethodPost, Path: outofbandrest.CreateInvitation,
+			Summary:  "Creates an invitation.",
+			Request:  outofbandcmd.CreateInvitationArgs{},
+			Response: outofbandcmd.CreateInvitationResponse{},
+		},
+		{
+			Group: "OutOfBand", Name: "AcceptRequest", Tag: outOfBandTag,
+			Method: http.MethodPost, Path: outofbandrest.AcceptRequest,
+			Summary:  "Accepts a request.",
+			Request:  outofbandcmd.AcceptRequestArgs{},
+			Response: outofbandcmd.AcceptRequestResponse{},
+		},
+		{
+			Group: "OutOfBand", Name: "AcceptInvitation", Tag: outOfBandTag,
+			Method: http.MethodPost, Path: outofbandrest.AcceptInvitation,
+			Summary:  "Accepts an invitation.",
+			Request:  outofbandcmd.AcceptInvitationArgs{},
+			Response: outofbandcmd.AcceptInvitationResponse{},
+		},
+	}
+}
+
+func presentProofOperations() []Operation { // nolint: funlen
+	return []Operation{
+		{
+			Group: "PresentProof", Name: "Actions", Tag: presentProofTag,
+			Method: http.MethodGet, Path: presentproofrest.Actions,
+			Summary:  "Returns the pending actions that have not yet been executed or cancelled.",
+			Response: presentproofcmd.ActionsResponse{},
+		},
+		{
+			Group: "PresentProof", Name: "SendRequestPresentation", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.SendRequestPresentation,
+			Summary:  "Sends a request presentation.",
+			Request:  presentproofcmd.SendRequestPresentationArgs{},
+			Response: presentproofcmd.SendRequestPresentationResponse{},
+		},
+		{
+			Group: "PresentProof", Name: "SendProposePresentation", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.SendProposePresentation,
+			Summary:  "Sends a propose presentation.",
+			Request:  presentproofcmd.SendProposePresentationArgs{},
+			Response: presentproofcmd.SendProposePresentationResponse{},
+		},
+		{
+			Group: "PresentProof", Name: "AcceptRequestPresentation", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.AcceptRequestPresentation,
+			Summary:  "Accepts a request presentation.",
+			Request:  presentproofcmd.AcceptRequestPresentationArgs{},
+			Response: presentproofcmd.AcceptRequestPresentationResponse{},
+		},
+		{
+			Group: "PresentProof", Name: "NegotiateRequestPresentation", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.NegotiateRequestPresentation,
+			Summary:  "Counters a received presentation request with a proposal.",
+			Request:  presentproofcmd.NegotiateRequestPresentationArgs{},
+			Response: presentproofcmd.NegotiateRequestPresentationResponse{},
+		},
+		{
+			Group: "PresentProof", Name: "DeclineRequestPresentation", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.DeclineRequestPresentation,
+			Summary:  "Declines a request presentation.",
+			Request:  presentproofcmd.DeclineRequestPresentationArgs{},
+			Response: presentproofcmd.DeclineRequestPresentationResponse{},
+			Query:    true,
+		},
+		{
+			Group: "PresentProof", Name: "AcceptProposePresentation", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.AcceptProposePresentation,
+			Summary:  "Accepts a propose presentation.",
+			Request:  presentproofcmd.AcceptProposePresentationArgs{},
+			Response: presentproofcmd.AcceptProposePresentationResponse{},
+		},
+		{
+			Group: "PresentProof", Name: "DeclineProposePresentation", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.DeclineProposePresentation,
+			Summary:  "Declines a propose presentation.",
+			Request:  presentproofcmd.DeclineProposePresentationArgs{},
+			Response: presentproofcmd.DeclineProposePresentationResponse{},
+			Query:    true,
+		},
+		{
+			Group: "PresentProof", Name: "AcceptPresentation", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.AcceptPresentation,
+			Summary:  "Accepts a presentation.",
+			Request:  presentproofcmd.AcceptPresentationArgs{},
+			Response: presentproofcmd.AcceptPresentationResponse{},
+		},
+		{
+			Group: "PresentProof", Name: "DeclinePresentation", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.DeclinePresentation,
+			Summary:  "Declines a presentation.",
+			Request:  presentproofcmd.DeclinePresentationArgs{},
+			Response: presentproofcmd.DeclinePresentationResponse{},
+			Query:    true,
+		},
+		{
+			Group: "PresentProof", Name: "AcceptProblemReport", Tag: presentProofTag,
+			Method: http.MethodPost, Path: presentproofrest.AcceptProblemReport,
+			Summary:  "Accepts a problem report.",
+			Request:  presentproofcmd.AcceptProblemReportArgs{},
+			Response: presentproofcmd.AcceptProblemReportResponse{},
+			Query:    true,
+		},
+	}
+}
+
+func vcWalletOperations() []Operation { // nolint: funlen
+	return []Operation{
+		{
+			Group: "VCWallet", Name: "CreateProfile", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.CreateProfilePath,
+			Summary: "Creates the wallet profile of a user.",
+			Request: vcwalletcmd.CreateProfileRequest{},
+		},
+		{
+			Group: "VCWallet", Name: "RemoveProfile", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.RemoveProfilePath,
+			Summary: "Removes the wallet profile of a user, with all the contents and keys of the wallet.",
+			Request: vcwalletcmd.RemoveProfileRequest{},
+		},
+		{
+			Group: "VCWallet", Name: "Unlock", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.UnlockWalletPath,
+			Summary:  "Unlocks the wallet of a user, returning the auth token of the wallet session.",
+			Request:  vcwalletcmd.UnlockWalletRequest{},
+			Response: vcwalletcmd.UnlockWalletResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "Lock", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.LockWalletPath,
+			Summary:  "Locks the wallet of a user, expiring the auth token of its session.",
+			Request:  vcwalletcmd.LockWalletRequest{},
+			Response: vcwalletcmd.LockWalletResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "RefreshToken", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.RefreshTokenPath,
+			Summary:  "Replaces the auth token of the wallet session of a user with a new one.",
+			Request:  vcwalletcmd.RefreshTokenRequest{},
+			Response: vcwalletcmd.RefreshTokenResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "Add", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.AddContentPath,
+			Summary: "Adds a content to the wallet of a user.",
+			Request: vcwalletcmd.AddContentRequest{},
+		},
+		{
+			Group: "VCWallet", Name: "Remove", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.RemoveContentPath,
+			Summary: "Removes a content from the wallet of a user.",
+			Request: vcwalletcmd.RemoveContentRequest{},
+		},
+		{
+			Group: "VCWallet", Name: "Get", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.GetContentPath,
+			Summary:  "Gets a content from the wallet of a user.",
+			Request:  vcwalletcmd.GetContentRequest{},
+			Response: vcwalletcmd.GetContentResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "GetAll", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.GetAllContentPath,
+			Summary:  "Gets all the contents of a type from the wallet of a user.",
+			Request:  vcwalletcmd.GetAllContentRequest{},
+			Response: vcwalletcmd.GetAllContentResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "Export", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.ExportWalletPath,
+			Summary:  "Exports the contents of the wallet of a user into an archive protected with a passphrase.",
+			Request:  vcwalletcmd.ExportWalletRequest{},
+			Response: vcwalletcmd.ExportWalletResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "Import", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.ImportWalletPath,
+			Summary: "Imports the contents of an archive exported from a wallet into the wallet of a user.",
+			Request: vcwalletcmd.ImportWalletRequest{},
+		},
+		{
+			Group: "VCWallet", Name: "Query", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.QueryPath,
+			Summary: "Runs the queries of a verifiable presentation request over the credentials of the wallet of a user.",
+			Request: vcwalletcmd.QueryRequest{}, Response: vcwalletcmd.QueryResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "Derive", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.DerivePath,
+			Summary: "Derives the BBS+ selective disclosure of a credential of the wallet of a user.",
+			Request: vcwalletcmd.DeriveRequest{}, Response: vcwalletcmd.DeriveResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "PresentProof", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.PresentProofPath,
+			Summary: "Answers a pending present-proof request presentation with the credentials of the wallet of a user.",
+			Request: vcwalletcmd.PresentProofRequest{}, Response: vcwalletcmd.PresentProofResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "AuditLog", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.AuditLogPath,
+			Summary: "Returns the records of the disclosures of the credentials of the wallet of a user.",
+			Request: vcwalletcmd.AuditLogRequest{}, Response: vcwalletcmd.AuditLogResponse{},
+		},
+		{
+			Group: "VCWallet", Name: "CreateKeyPair", Tag: vcWalletTag,
+			Method: http.MethodPost, Path: vcwalletrest.CreateKeyPairPath,
+			Summary: "Creates a key pair in the KMS of the wallet of a user.",
+			Request: vcwalletcmd.CreateKeyPairRequest{}, Response: vcwalletcmd.CreateKeyPairResponse{},
+		},
+	}
+}
+
+func vdrOperations() []Operation {
+	return []Operation{
+		{
+			Group: "VDR", Name: "SaveDID", Tag: vdrTag,
+			Method: http.MethodPost, Path: vdrrest.SaveDIDPath,
+			Summary: "Saves a DID document with its friendly name.",
+			Request: vdrcmd.DIDArgs{},
+		},
+		{
+			Group: "VDR", Name: "GetDID", Tag: vdrTag,
+			Method: http.MethodGet, Path: vdrrest.GetDIDPath,
+			Summary:    "Gets the saved DID document.",
+			Request:    vdrcmd.IDArg{},
+			Response:   vdrcmd.Document{},
+			Query:      true,
+			Base64Path: true,
+		},
+		{
+			Group: "VDR", Name: "ResolveDID", Tag: vdrTag,
+			Method: http.MethodGet, Path: vdrrest.ResolveDIDPath,
+			Summary:    "Resolves the DID.",
+			Request:    vdrcmd.IDArg{},
+			Response:   vdrcmd.Document{},
+			Query:      true,
+			Base64Path: true,
+		},
+		{
+			Group: "VDR", Name: "GetDIDRecords", Tag: vdrTag,
+			Method: http.MethodGet, Path: vdrrest.GetDIDRecordsPath,
+			Summary:  "Retrieves the DID records.",
+			Request:  vdrcmd.PageArgs{},
+			Response: vdrcmd.DIDRecordResult{},
+			Query:    true,
+		},
+		{
+			Group: "VDR", Name: "ResolveIdentifier", Tag: vdrTag,
+			Method: http.MethodGet, Path: vdrrest.ResolutionPath,
+			Summary:  "Resolves the DID following the HTTP(S) binding of the DID Resolution spec.",
+			Request:  vdrcmd.IDArg{},
+			Response: did.Doc{},
+			Query:    true,
+		},
+	}
+}
+
+func verifiableOperations() []Operation { // nolint: funlen
+	return []Operation{
+		{
+			Group: "Verifiable", Name: "ValidateCredential", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.ValidateCredentialPath,
+			Summary: "Validates the verifiable credential.",
+			Request: verifiablecmd.Credential{},
+		},
+		{
+			Group: "Verifiable", Name: "SaveCredential", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.SaveCredentialPath,
+			Summary: "Saves the verifiable credential.",
+			Request: verifiablecmd.CredentialExt{},
+		},
+		{
+			Group: "Verifiable", Name: "GetCredential", Tag: verifiableTag,
+			Method: http.MethodGet, Path: verifiablerest.GetCredentialPath,
+			Summary:    "Retrieves the verifiable credential.",
+			Request:    verifiablecmd.IDArg{},
+			Response:   verifiablecmd.Credential{},
+			Query:      true,
+			Base64Path: true,
+		},
+		{
+			Group: "Verifiable", Name: "GetCredentialByName", Tag: verifiableTag,
+			Method: http.MethodGet, Path: verifiablerest.GetCredentialByNamePath,
+			Summary:  "Retrieves the verifiable credential by name.",
+			Request:  verifiablecmd.NameArg{},
+			Response: verifiablestore.Record{},
+			Query:    true,
+		},
+		{
+			Group: "Verifiable", Name: "GetCredentials", Tag: verifiableTag,
+			Method: http.MethodGet, Path: verifiablerest.GetCredentialsPath,
+			Summary:  "Retrieves the verifiable credential records, by page if an offset or a limit is given.",
+			Request:  verifiablecmd.PageArgs{},
+			Response: verifiablecmd.RecordResult{},
+			Query:    true,
+		},
+		{
+			Group: "Verifiable", Name: "SignCredential", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.SignCredentialPath,
+			Summary:  "Signs the credential.",
+			Request:  verifiablecmd.SignCredentialRequest{},
+			Response: verifiablecmd.SignCredentialResponse{},
+		},
+		{
+			Group: "Verifiable", Name: "SignCredentialLegacy", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.SignCredentialsPath,
+			Summary:    "Signs the credential, use SignCredential instead.",
+			Request:    verifiablecmd.SignCredentialRequest{},
+			Response:   verifiablecmd.SignCredentialResponse{},
+			Deprecated: true,
+		},
+		{
+			Group: "Verifiable", Name: "VerifyCredential", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.VerifyCredentialPath,
+			Summary: "Verifies the proofs of the credential.",
+			Request: verifiablecmd.VerifyCredentialRequest{},
+		},
+		{
+			Group: "Verifiable", Name: "RemoveCredentialByName", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.RemoveCredentialByNamePath,
+			Summary:  "Removes the verifiable credential by name.",
+			Request:  verifiablecmd.NameArg{},
+			Response: verifiablecmd.RemoveCredentialByNameResponse{},
+			Query:    true,
+		},
+		{
+			Group: "Verifiable", Name: "GeneratePresentation", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.GeneratePresentationPath,
+			Summary:  "Generates the verifiable presentation of the verifiable credentials.",
+			Request:  verifiablecmd.PresentationRequest{},
+			Response: verifiablecmd.Presentation{},
+		},
+		{
+			Group: "Verifiable", Name: "GeneratePresentationByID", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.GeneratePresentationByIDPath,
+			Summary:  "Generates the verifiable presentation of a stored verifiable credential.",
+			Request:  verifiablecmd.PresentationRequestByID{},
+			Response: verifiablecmd.Presentation{},
+		},
+		{
+			Group: "Verifiable", Name: "VerifyPresentation", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.VerifyPresentationPath,
+			Summary: "Verifies the proofs of the presentation and of its credentials.",
+			Request: verifiablecmd.VerifyPresentationRequest{},
+		},
+		{
+			Group: "Verifiable", Name: "EvaluatePresentationDefinition", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.EvaluatePresentationDefinitionPath,
+			Summary:  "Retrieves the stored credentials matching each input descriptor of the presentation definition.",
+			Request:  verifiablecmd.PresentationDefinitionRequest{},
+			Response: verifiablecmd.PresentationDefinitionResult{},
+		},
+		{
+			Group: "Verifiable", Name: "CreatePresentationSubmission", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.CreatePresentationSubmissionPath,
+			Summary:  "Creates a presentation submitting stored credentials against the presentation definition.",
+			Request:  verifiablecmd.PresentationSubmissionRequest{},
+			Response: verifiablecmd.Presentation{},
+		},
+		{
+			Group: "Verifiable", Name: "SavePresentation", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.SavePresentationPath,
+			Summary: "Saves the verifiable presentation.",
+			Request: verifiablecmd.PresentationExt{},
+		},
+		{
+			Group: "Verifiable", Name: "GetPresentation", Tag: verifiableTag,
+			Method: http.MethodGet, Path: verifiablerest.GetPresentationPath,
+			Summary:    "Retrieves the verifiable presentation.",
+			Request:    verifiablecmd.IDArg{},
+			Response:   verifiablecmd.Presentation{},
+			Query:      true,
+			Base64Path: true,
+		},
+		{
+			Group: "Verifiable", Name: "GetPresentations", Tag: verifiableTag,
+			Method: http.MethodGet, Path: verifiablerest.GetPresentationsPath,
+			Summary:  "Retrieves the verifiable presentation records, by page if an offset or a limit is given.",
+			Request:  verifiablecmd.PageArgs{},
+			Response: verifiablecmd.RecordResult{},
+			Query:    true,
+		},
+		{
+			Group: "Verifiable", Name: "RemovePresentationByName", Tag: verifiableTag,
+			Method: http.MethodPost, Path: verifiablerest.RemovePresentationByNamePath,
+			Summary:  "Removes the verifiable presentation by name.",
+			Request:  verifiablecmd.NameArg{},
+			Response: verifiablecmd.RemovePresentationByNameResponse{},
+			Query:    true,
+		},
+	}
+}
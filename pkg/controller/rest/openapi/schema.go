@@ -0,0 +1,183 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	modulePath      = "github.com/hyperledger/aries-framework-go/"
+	commandPkgPath  = modulePath + "pkg/controller/command/"
+	frameworkPath   = modulePath + "pkg/"
+	schemaRefPrefix = "#/components/schemas/"
+)
+
+// nolint: gochecknoglobals
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaBuilder builds the JSON schemas of the Go types following the encoding/json rules, the named structs being
+// added to the component schemas.
+type schemaBuilder struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{schemas: make(map[string]*Schema)}
+}
+
+func (b *schemaBuilder) schema(t reflect.Type) *Schema { // nolint: gocyclo
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		// custom JSON encoding, which cannot be described by reflection
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+
+		name := schemaName(t)
+
+		if _, ok := b.schemas[name]; !ok {
+			// registered before building the properties, for the recursive types
+			b.schemas[name] = &Schema{}
+			*b.schemas[name] = *b.object(t)
+		}
+
+		return &Schema{Ref: schemaRef(name)}
+	default:
+		// interfaces accept any value
+		return &Schema{}
+	}
+}
+
+func (b *schemaBuilder) object(t reflect.Type) *Schema {
+	return &Schema{Type: "object", Properties: b.fields(t)}
+}
+
+// fields returns the schemas of the JSON fields of the struct, the fields of the embedded structs being promoted.
+func (b *schemaBuilder) fields(t reflect.Type) map[string]*Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fields := make(map[string]*Schema)
+
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	var embedded []reflect.Type
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, skip := jsonName(&field)
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = b.schema(field.Type)
+	}
+
+	// the fields of the struct take precedence over the promoted ones
+	for _, et := range embedded {
+		for name, schema := range b.fields(et) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = schema
+			}
+		}
+	}
+
+	return fields
+}
+
+func jsonName(field *reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+
+	return strings.Split(tag, ",")[0], false
+}
+
+// schemaName returns the component name of the struct: its package path relative to the controller commands or to
+// the framework, followed by its name, e.g. didexchange.CreateInvitationArgs or client.didexchange.Invitation.
+func schemaName(t reflect.Type) string {
+	path := t.PkgPath()
+
+	switch {
+	case strings.HasPrefix(path, commandPkgPath):
+		path = strings.TrimPrefix(path, commandPkgPath)
+	case strings.HasPrefix(path, frameworkPath):
+		path = strings.TrimPrefix(path, frameworkPath)
+	}
+
+	return strings.ReplaceAll(path, "/", ".") + "." + t.Name()
+}
+
+func schemaRef(name string) string {
+	return schemaRefPrefix + name
+}
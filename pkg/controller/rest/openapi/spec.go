@@ -0,0 +1,267 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package openapi describes the REST operations of the controller, from which the OpenAPI 3 document of the
+// controller API and its typed Go client are generated (see the gen command).
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	openAPIVersion  = "3.0.3"
+	jsonContentType = "application/json"
+
+	genericErrorSchema = "GenericError"
+)
+
+// nolint: gochecknoglobals
+var pathParamRegexp = regexp.MustCompile(`{([^}]+)}`)
+
+// Document is an OpenAPI 3 document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Tags       []Tag               `json:"tags,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info is the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Tag groups the operations.
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// PathItem holds the operations of a path, by lower case HTTP method.
+type PathItem map[string]*OperationObject
+
+// OperationObject describes an API operation.
+type OperationObject struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter of an operation.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is the request body of an operation.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a content.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas referenced by the operations.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is the JSON schema of a model, an empty schema accepting any value.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// NewDocument returns the OpenAPI 3 document of the operations.
+func NewDocument(version string, ops []Operation) (*Document, error) {
+	doc := &Document{
+		OpenAPI: openAPIVersion,
+		Info: Info{
+			Title:       "Aries Agent REST API",
+			Description: "Controller REST API of the Aries framework agent.",
+			Version:     version,
+		},
+		Paths: make(map[string]PathItem),
+	}
+
+	schemas := newSchemaBuilder()
+	schemas.schemas[genericErrorSchema] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":    {Type: "integer"},
+			"message": {Type: "string"},
+		},
+	}
+
+	tags := make(map[string]struct{})
+
+	for i := range ops {
+		op := &ops[i]
+
+		obj, err := newOperationObject(op, schemas)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", op.ID(), err)
+		}
+
+		item, ok := doc.Paths[op.Path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[op.Path] = item
+		}
+
+		method := strings.ToLower(op.Method)
+		if _, ok := item[method]; ok {
+			return nil, fmt.Errorf("duplicate operation %s %s", op.Method, op.Path)
+		}
+
+		item[method] = obj
+
+		if _, ok := tags[op.Tag]; !ok {
+			tags[op.Tag] = struct{}{}
+			doc.Tags = append(doc.Tags, Tag{Name: op.Tag})
+		}
+	}
+
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+
+	doc.Components.Schemas = schemas.schemas
+
+	return doc, nil
+}
+
+func newOperationObject(op *Operation, schemas *schemaBuilder) (*OperationObject, error) {
+	obj := &OperationObject{
+		OperationID: op.ID(),
+		Summary:     op.Summary,
+		Tags:        []string{op.Tag},
+		Deprecated:  op.Deprecated,
+		Responses: map[string]*Response{
+			"default": {
+				Description: "Error response.",
+				Content:     jsonContent(&Schema{Ref: schemaRef(genericErrorSchema)}),
+			},
+		},
+	}
+
+	params, err := newParameters(op, schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	obj.Parameters = params
+
+	if op.Request != nil && !op.Query {
+		obj.RequestBody = &RequestBody{
+			Required: true,
+			Content:  jsonContent(schemas.schema(reflect.TypeOf(op.Request))),
+		}
+	}
+
+	ok := &Response{Description: "Successful response."}
+	if op.Response != nil {
+		ok.Content = jsonContent(schemas.schema(reflect.TypeOf(op.Response)))
+	}
+
+	obj.Responses["200"] = ok
+
+	return obj, nil
+}
+
+// newParameters returns the path parameters of the operation, followed by its query parameters.
+func newParameters(op *Operation, schemas *schemaBuilder) ([]Parameter, error) {
+	pathParams := PathParams(op.Path)
+
+	if op.Request == nil {
+		if len(pathParams) > 0 {
+			return nil, fmt.Errorf("path parameters %v without request", pathParams)
+		}
+
+		return nil, nil
+	}
+
+	fields := schemas.fields(reflect.TypeOf(op.Request))
+
+	var params []Parameter
+
+	for _, name := range pathParams {
+		if _, ok := fields[name]; !ok {
+			return nil, fmt.Errorf("path parameter %s is not a request field", name)
+		}
+
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+
+	if !op.Query {
+		return params, nil
+	}
+
+	names := make([]string, 0, len(fields))
+
+	for name := range fields {
+		if !contains(pathParams, name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		params = append(params, Parameter{Name: name, In: "query", Schema: fields[name]})
+	}
+
+	return params, nil
+}
+
+// PathParams returns the names of the parameters of the path.
+func PathParams(path string) []string {
+	var names []string
+
+	for _, match := range pathParamRegexp.FindAllStringSubmatch(path, -1) {
+		names = append(names, match[1])
+	}
+
+	return names
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{jsonContentType: {Schema: schema}}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
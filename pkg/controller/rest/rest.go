@@ -8,8 +8,11 @@ package rest
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
@@ -17,6 +20,12 @@ import (
 
 var logger = log.New("aries-framework/rest")
 
+// query parameters paging the listed records.
+const (
+	OffsetParam = "offset"
+	LimitParam  = "limit"
+)
+
 // Handler http handler for each controller API endpoint.
 type Handler interface {
 	Path() string
@@ -73,3 +82,28 @@ func SendHTTPStatusError(rw http.ResponseWriter, httpStatus int, code command.Co
 		logger.Errorf("Unable to send error response, %s", e)
 	}
 }
+
+// QueryValuesAsJSON converts the query parameters to the JSON object of a command request, the values of the paging
+// parameters being numbers and the others strings.
+func QueryValuesAsJSON(vals url.Values) ([]byte, error) {
+	args := make(map[string]interface{})
+
+	for k, v := range vals {
+		if len(v) == 0 {
+			continue
+		}
+
+		args[k] = v[0]
+
+		if k == OffsetParam || k == LimitParam {
+			n, err := strconv.Atoi(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s query parameter: %w", k, err)
+			}
+
+			args[k] = n
+		}
+	}
+
+	return json.Marshal(args)
+}
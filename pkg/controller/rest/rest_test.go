@@ -12,6 +12,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -137,3 +138,18 @@ func (m *mockRWriter) Write([]byte) (int, error) {
 }
 
 func (m *mockRWriter) WriteHeader(statusCode int) {}
+
+func TestQueryValuesAsJSON(t *testing.T) {
+	args, err := QueryValuesAsJSON(url.Values{
+		"state":  []string{"completed"},
+		"offset": []string{"2"},
+		"limit":  []string{"10"},
+		"empty":  []string{},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"state":"completed","offset":2,"limit":10}`, string(args))
+
+	_, err = QueryValuesAsJSON(url.Values{"limit": []string{"ten"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid limit query parameter")
+}
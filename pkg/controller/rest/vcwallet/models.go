@@ -0,0 +1,281 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcwallet
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
+)
+
+// createProfileReq model
+//
+// This is used for create wallet profile request.
+//
+// swagger:parameters createProfileReq
+type createProfileReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.CreateProfileRequest
+}
+
+// removeProfileReq model
+//
+// This is used for remove wallet profile request.
+//
+// swagger:parameters removeProfileReq
+type removeProfileReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.RemoveProfileRequest
+}
+
+// unlockWalletReq model
+//
+// This is used for unlock wallet request.
+//
+// swagger:parameters unlockWalletReq
+type unlockWalletReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.UnlockWalletRequest
+}
+
+// unlockWalletRes model
+//
+// This is used for returning the unlock wallet response.
+//
+// swagger:response unlockWalletRes
+type unlockWalletRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.UnlockWalletResponse
+}
+
+// lockWalletReq model
+//
+// This is used for lock wallet request.
+//
+// swagger:parameters lockWalletReq
+type lockWalletReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.LockWalletRequest
+}
+
+// lockWalletRes model
+//
+// This is used for returning the lock wallet response.
+//
+// swagger:response lockWalletRes
+type lockWalletRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.LockWalletResponse
+}
+
+// refreshTokenReq model
+//
+// This is used for refresh wallet auth token request.
+//
+// swagger:parameters refreshTokenReq
+type refreshTokenReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.RefreshTokenRequest
+}
+
+// refreshTokenRes model
+//
+// This is used for returning the refresh wallet auth token response.
+//
+// swagger:response refreshTokenRes
+type refreshTokenRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.RefreshTokenResponse
+}
+
+// addContentReq model
+//
+// This is used for add wallet content request.
+//
+// swagger:parameters addContentReq
+type addContentReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.AddContentRequest
+}
+
+// removeContentReq model
+//
+// This is used for remove wallet content request.
+//
+// swagger:parameters removeContentReq
+type removeContentReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.RemoveContentRequest
+}
+
+// getContentReq model
+//
+// This is used for get wallet content request.
+//
+// swagger:parameters getContentReq
+type getContentReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.GetContentRequest
+}
+
+// getContentRes model
+//
+// This is used for returning the get wallet content response.
+//
+// swagger:response getContentRes
+type getContentRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.GetContentResponse
+}
+
+// getAllContentReq model
+//
+// This is used for get all wallet contents request.
+//
+// swagger:parameters getAllContentReq
+type getAllContentReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.GetAllContentRequest
+}
+
+// getAllContentRes model
+//
+// This is used for returning the get all wallet contents response.
+//
+// swagger:response getAllContentRes
+type getAllContentRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.GetAllContentResponse
+}
+
+// exportWalletReq model
+//
+// This is used for export wallet request.
+//
+// swagger:parameters exportWalletReq
+type exportWalletReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.ExportWalletRequest
+}
+
+// exportWalletRes model
+//
+// This is used for returning the export wallet response.
+//
+// swagger:response exportWalletRes
+type exportWalletRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.ExportWalletResponse
+}
+
+// importWalletReq model
+//
+// This is used for import wallet request.
+//
+// swagger:parameters importWalletReq
+type importWalletReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.ImportWalletRequest
+}
+
+// queryReq model
+//
+// This is used for query wallet credentials request.
+//
+// swagger:parameters queryReq
+type queryReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.QueryRequest
+}
+
+// queryRes model
+//
+// This is used for returning the query wallet credentials response.
+//
+// swagger:response queryRes
+type queryRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.QueryResponse
+}
+
+// deriveReq model
+//
+// This is used for derive wallet credential request.
+//
+// swagger:parameters deriveReq
+type deriveReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.DeriveRequest
+}
+
+// deriveRes model
+//
+// This is used for returning the derive wallet credential response.
+//
+// swagger:response deriveRes
+type deriveRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.DeriveResponse
+}
+
+// presentProofReq model
+//
+// This is used for present proof from wallet request.
+//
+// swagger:parameters presentProofReq
+type presentProofReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.PresentProofRequest
+}
+
+// presentProofRes model
+//
+// This is used for returning the present proof from wallet response.
+//
+// swagger:response presentProofRes
+type presentProofRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.PresentProofResponse
+}
+
+// createKeyPairReq model
+//
+// This is used for create wallet key pair request.
+//
+// swagger:parameters createKeyPairReq
+type createKeyPairReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.CreateKeyPairRequest
+}
+
+// createKeyPairRes model
+//
+// This is used for returning the create wallet key pair response.
+//
+// swagger:response createKeyPairRes
+type createKeyPairRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.CreateKeyPairResponse
+}
+
+// auditLogReq model
+//
+// This is used for review wallet audit log request.
+//
+// swagger:parameters auditLogReq
+type auditLogReq struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.AuditLogRequest
+}
+
+// auditLogRes model
+//
+// This is used for returning the review wallet audit log response.
+//
+// swagger:response auditLogRes
+type auditLogRes struct { // nolint: unused,deadcode
+	// in: body
+	vcwallet.AuditLogResponse
+}
@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcwallet
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// constants for the verifiable credential wallet operations.
+const (
+	OperationID       = "/vcwallet"
+	CreateProfilePath = OperationID + "/create-profile"
+	RemoveProfilePath = OperationID + "/remove-profile"
+	UnlockWalletPath  = OperationID + "/unlock"
+	LockWalletPath    = OperationID + "/lock"
+	RefreshTokenPath  = OperationID + "/refresh-token"
+	AddContentPath    = OperationID + "/add"
+	RemoveContentPath = OperationID + "/remove"
+	GetContentPath    = OperationID + "/get"
+	GetAllContentPath = OperationID + "/getall"
+	ExportWalletPath  = OperationID + "/export"
+	ImportWalletPath  = OperationID + "/import"
+	QueryPath         = OperationID + "/query"
+	DerivePath        = OperationID + "/derive"
+	PresentProofPath  = OperationID + "/present-proof"
+	AuditLogPath      = OperationID + "/audit-log"
+	CreateKeyPairPath = OperationID + "/create-key-pair"
+)
+
+// provider contains dependencies for the wallet command and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+	Service(id string) (interface{}, error)
+}
+
+// Operation contains REST operations provided by the verifiable credential wallet.
+type Operation struct {
+	handlers []rest.Handler
+	command  *vcwallet.Command
+}
+
+// New returns new verifiable credential wallet REST controller.
+func New(p provider) *Operation {
+	o := &Operation{command: vcwallet.New(p)}
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(CreateProfilePath, http.MethodPost, o.CreateProfile),
+		cmdutil.NewHTTPHandler(RemoveProfilePath, http.MethodPost, o.RemoveProfile),
+		cmdutil.NewHTTPHandler(UnlockWalletPath, http.MethodPost, o.Unlock),
+		cmdutil.NewHTTPHandler(LockWalletPath, http.MethodPost, o.Lock),
+		cmdutil.NewHTTPHandler(RefreshTokenPath, http.MethodPost, o.RefreshToken),
+		cmdutil.NewHTTPHandler(AddContentPath, http.MethodPost, o.Add),
+		cmdutil.NewHTTPHandler(RemoveContentPath, http.MethodPost, o.Remove),
+		cmdutil.NewHTTPHandler(GetContentPath, http.MethodPost, o.Get),
+		cmdutil.NewHTTPHandler(GetAllContentPath, http.MethodPost, o.GetAll),
+		cmdutil.NewHTTPHandler(ExportWalletPath, http.MethodPost, o.Export),
+		cmdutil.NewHTTPHandler(ImportWalletPath, http.MethodPost, o.Import),
+		cmdutil.NewHTTPHandler(QueryPath, http.MethodPost, o.Query),
+		cmdutil.NewHTTPHandler(DerivePath, http.MethodPost, o.Derive),
+		cmdutil.NewHTTPHandler(PresentProofPath, http.MethodPost, o.PresentProof),
+		cmdutil.NewHTTPHandler(AuditLogPath, http.MethodPost, o.AuditLog),
+		cmdutil.NewHTTPHandler(CreateKeyPairPath, http.MethodPost, o.CreateKeyPair),
+	}
+}
+
+// CreateProfile swagger:route POST /vcwallet/create-profile vcwallet createProfileReq
+//
+// Creates the wallet profile of a user.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) CreateProfile(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreateProfile, rw, req.Body)
+}
+
+// RemoveProfile swagger:route POST /vcwallet/remove-profile vcwallet removeProfileReq
+//
+// Removes the wallet profile of a user, with all the contents and keys of the wallet.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RemoveProfile(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RemoveProfile, rw, req.Body)
+}
+
+// Unlock swagger:route POST /vcwallet/unlock vcwallet unlockWalletReq
+//
+// Unlocks the wallet of a user, returning the auth token of the wallet session.
+//
+// Responses:
+//    default: genericError
+//        200: unlockWalletRes
+func (o *Operation) Unlock(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Unlock, rw, req.Body)
+}
+
+// Lock swagger:route POST /vcwallet/lock vcwallet lockWalletReq
+//
+// Locks the wallet of a user, expiring the auth token of its session.
+//
+// Responses:
+//    default: genericError
+//        200: lockWalletRes
+func (o *Operation) Lock(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Lock, rw, req.Body)
+}
+
+// RefreshToken swagger:route POST /vcwallet/refresh-token vcwallet refreshTokenReq
+//
+// Replaces the auth token of the wallet session of a user with a new one.
+//
+// Responses:
+//    default: genericError
+//        200: refreshTokenRes
+func (o *Operation) RefreshToken(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RefreshToken, rw, req.Body)
+}
+
+// Add swagger:route POST /vcwallet/add vcwallet addContentReq
+//
+// Adds a content to the wallet of a user.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) Add(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Add, rw, req.Body)
+}
+
+// Remove swagger:route POST /vcwallet/remove vcwallet removeContentReq
+//
+// Removes a content from the wallet of a user.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) Remove(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Remove, rw, req.Body)
+}
+
+// Get swagger:route POST /vcwallet/get vcwallet getContentReq
+//
+// Gets a content from the wallet of a user.
+//
+// Responses:
+//    default: genericError
+//        200: getContentRes
+func (o *Operation) Get(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Get, rw, req.Body)
+}
+
+// GetAll swagger:route POST /vcwallet/getall vcwallet getAllContentReq
+//
+// Gets all the contents of a type from the wallet of a user.
+//
+// Responses:
+//    default: genericError
+//        200: getAllContentRes
+func (o *Operation) GetAll(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.GetAll, rw, req.Body)
+}
+
+// Export swagger:route POST /vcwallet/export vcwallet exportWalletReq
+//
+// Exports the contents of the wallet of a user into an archive protected with a passphrase.
+//
+// Responses:
+//    default: genericError
+//        200: exportWalletRes
+func (o *Operation) Export(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Export, rw, req.Body)
+}
+
+// Import swagger:route POST /vcwallet/import vcwallet importWalletReq
+//
+// Imports the contents of an archive exported from a wallet into the wallet of a user.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) Import(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Import, rw, req.Body)
+}
+
+// Query swagger:route POST /vcwallet/query vcwallet queryReq
+//
+// Runs the queries of a verifiable presentation request over the credentials of the wallet of a user, and returns
+// the presentations answering them.
+//
+// Responses:
+//    default: genericError
+//        200: queryRes
+func (o *Operation) Query(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Query, rw, req.Body)
+}
+
+// Derive swagger:route POST /vcwallet/derive vcwallet deriveReq
+//
+// Derives the BBS+ selective disclosure of a credential of the wallet of a user, revealing only the fields of the
+// frame.
+//
+// Responses:
+//    default: genericError
+//        200: deriveRes
+func (o *Operation) Derive(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Derive, rw, req.Body)
+}
+
+// PresentProof swagger:route POST /vcwallet/present-proof vcwallet presentProofReq
+//
+// Answers a pending present-proof request presentation with the credentials of the wallet of a user satisfying its
+// presentation definition.
+//
+// Responses:
+//    default: genericError
+//        200: presentProofRes
+func (o *Operation) PresentProof(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.PresentProof, rw, req.Body)
+}
+
+// AuditLog swagger:route POST /vcwallet/audit-log vcwallet auditLogReq
+//
+// Returns the records of the disclosures of the credentials of the wallet of a user, optionally filtered by
+// recipient, credential and period.
+//
+// Responses:
+//    default: genericError
+//        200: auditLogRes
+func (o *Operation) AuditLog(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.AuditLog, rw, req.Body)
+}
+
+// CreateKeyPair swagger:route POST /vcwallet/create-key-pair vcwallet createKeyPairReq
+//
+// Creates a key pair in the KMS of the wallet of a user.
+//
+// Responses:
+//    default: genericError
+//        200: createKeyPairRes
+func (o *Operation) CreateKeyPair(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreateKeyPair, rw, req.Body)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcwallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/wallet"
+)
+
+const (
+	sampleUserID     = "sample-user"
+	samplePassphrase = "sample-passphrase"
+	sampleConnection = `{"id": "c1", "theirLabel": "bob"}`
+)
+
+func TestNew(t *testing.T) {
+	op := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+	require.Len(t, op.GetRESTHandlers(), 16)
+}
+
+func TestOperation(t *testing.T) {
+	router := mux.NewRouter()
+
+	for _, h := range New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}).GetRESTHandlers() {
+		router.HandleFunc(h.Path(), h.Handle()).Methods(h.Method())
+	}
+
+	rw := send(t, router, CreateProfilePath,
+		&vcwallet.CreateProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	rw = send(t, router, AddContentPath, &vcwallet.AddContentRequest{
+		UserID: sampleUserID, ContentType: wallet.Connection, Content: json.RawMessage(sampleConnection),
+	})
+	require.Equal(t, http.StatusBadRequest, rw.Code)
+	require.Contains(t, rw.Body.String(), wallet.ErrWalletLocked.Error())
+
+	rw = send(t, router, UnlockWalletPath,
+		&vcwallet.UnlockWalletRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	unlockResponse := &vcwallet.UnlockWalletResponse{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), unlockResponse))
+
+	rw = send(t, router, RefreshTokenPath, &vcwallet.RefreshTokenRequest{UserID: sampleUserID, Auth: unlockResponse.Token})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	refreshResponse := &vcwallet.RefreshTokenResponse{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), refreshResponse))
+
+	auth := refreshResponse.Token
+
+	rw = send(t, router, AddContentPath, &vcwallet.AddContentRequest{
+		UserID: sampleUserID, Auth: auth, ContentType: wallet.Connection, Content: json.RawMessage(sampleConnection),
+	})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	rw = send(t, router, GetContentPath,
+		&vcwallet.GetContentRequest{UserID: sampleUserID, Auth: auth, ContentType: wallet.Connection, ContentID: "c1"})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	getResponse := &vcwallet.GetContentResponse{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), getResponse))
+	require.JSONEq(t, sampleConnection, string(getResponse.Content))
+
+	rw = send(t, router, GetAllContentPath,
+		&vcwallet.GetAllContentRequest{UserID: sampleUserID, Auth: auth, ContentType: wallet.Connection})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	getAllResponse := &vcwallet.GetAllContentResponse{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), getAllResponse))
+	require.Len(t, getAllResponse.Contents, 1)
+
+	rw = send(t, router, QueryPath, &vcwallet.QueryRequest{UserID: sampleUserID, Auth: auth, Query: []*wallet.QueryParams{{
+		Type: wallet.QueryByExample, CredentialQuery: json.RawMessage(`{"example": {"type": "VerifiableCredential"}}`),
+	}}})
+	require.Equal(t, http.StatusBadRequest, rw.Code)
+	require.Contains(t, rw.Body.String(), wallet.ErrNoQueryResults.Error())
+
+	rw = send(t, router, DerivePath, &vcwallet.DeriveRequest{
+		UserID: sampleUserID, Auth: auth, CredentialID: "urn:unknown", Frame: map[string]interface{}{},
+	})
+	require.Equal(t, http.StatusBadRequest, rw.Code)
+	require.Contains(t, rw.Body.String(), wallet.ErrContentNotFound.Error())
+
+	rw = send(t, router, AuditLogPath, &vcwallet.AuditLogRequest{UserID: sampleUserID, Auth: auth})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	auditLogResponse := &vcwallet.AuditLogResponse{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), auditLogResponse))
+	require.Empty(t, auditLogResponse.Records)
+
+	rw = send(t, router, ExportWalletPath,
+		&vcwallet.ExportWalletRequest{UserID: sampleUserID, Auth: auth, Passphrase: "export-passphrase"})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	exportResponse := &vcwallet.ExportWalletResponse{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), exportResponse))
+
+	rw = send(t, router, ImportWalletPath, &vcwallet.ImportWalletRequest{
+		UserID: sampleUserID, Auth: auth, Passphrase: "export-passphrase", Archive: exportResponse.Archive,
+	})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	rw = send(t, router, RemoveContentPath,
+		&vcwallet.RemoveContentRequest{UserID: sampleUserID, Auth: auth, ContentType: wallet.Connection, ContentID: "c1"})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	rw = send(t, router, LockWalletPath, &vcwallet.LockWalletRequest{UserID: sampleUserID})
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	lockResponse := &vcwallet.LockWalletResponse{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), lockResponse))
+	require.True(t, lockResponse.Closed)
+
+	rw = send(t, router, CreateKeyPairPath, &vcwallet.CreateKeyPairRequest{
+		UserID: sampleUserID, Auth: auth, KeyType: "ED25519",
+	})
+	require.Equal(t, http.StatusBadRequest, rw.Code)
+	require.Contains(t, rw.Body.String(), wallet.ErrWalletLocked.Error())
+
+	rw = send(t, router, RemoveProfilePath,
+		&vcwallet.RemoveProfileRequest{UserID: sampleUserID, Passphrase: samplePassphrase})
+	require.Equal(t, http.StatusOK, rw.Code)
+}
+
+func send(t *testing.T, router *mux.Router, path string, request interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	reqBytes, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(reqBytes)))
+
+	return rw
+}
@@ -51,6 +51,30 @@ type resolveDIDReq struct { // nolint: unused,deadcode
 	ID string `json:"id"`
 }
 
+// resolveIdentifierReq model
+//
+// This is used to resolve the DID with the DID resolution endpoint.
+//
+// swagger:parameters resolveIdentifierReq
+type resolveIdentifierReq struct { // nolint: unused,deadcode
+	// DID to resolve
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// resolveIdentifierRes model
+//
+// This is used for returning the DID resolution result, or the DID document.
+//
+// swagger:response resolveIdentifierRes
+type resolveIdentifierRes struct { // nolint: unused,deadcode
+
+	// in: body
+	ResolutionResult
+}
+
 // documentRes model
 //
 // This is used for returning query connection result for single record search
@@ -70,4 +94,46 @@ type documentRes struct {
 type didRecordResult struct {
 	// in: body
 	Result []*didstore.Record `json:"result,omitempty"`
+
+	// Total number of records, set when the records are paged
+	//
+	// in: body
+	Total int `json:"total,omitempty"`
+
+	// Cursor of the next page of records, empty for the last page
+	//
+	// in: body
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// didRecordsReq model
+//
+// This is used to page, filter and sort the did records.
+//
+// swagger:parameters getDIDRecords
+type didRecordsReq struct { // nolint: unused,deadcode
+	// Number of records to skip
+	//
+	// in: query
+	Offset int `json:"offset"`
+
+	// Maximum number of records to return, all the records are returned when zero
+	//
+	// in: query
+	Limit int `json:"limit"`
+
+	// Cursor of the page of records to return, as returned in the next_cursor of the previous page
+	//
+	// in: query
+	Cursor string `json:"cursor"`
+
+	// Field to sort the records by (name or id), prefixed with "-" for the descending order
+	//
+	// in: query
+	Sort string `json:"sort"`
+
+	// Method of the DIDs of the records (e.g. peer)
+	//
+	// in: query
+	Method string `json:"method"`
 }
@@ -42,6 +42,7 @@ type provider interface {
 type Operation struct {
 	handlers []rest.Handler
 	command  *vdr.Command
+	registry vdrapi.Registry
 }
 
 // New returns new common operations rest client instance.
@@ -51,7 +52,7 @@ func New(ctx provider) (*Operation, error) {
 		return nil, fmt.Errorf("new vdr : %w", err)
 	}
 
-	o := &Operation{command: cmd}
+	o := &Operation{command: cmd, registry: ctx.VDRegistry()}
 	o.registerHandler()
 
 	return o, nil
@@ -70,6 +71,7 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(ResolveDIDPath, http.MethodGet, o.ResolveDID),
 		cmdutil.NewHTTPHandler(GetDIDRecordsPath, http.MethodGet, o.GetDIDRecords),
 		cmdutil.NewHTTPHandler(GetDIDPath, http.MethodGet, o.GetDID),
+		cmdutil.NewHTTPHandler(ResolutionPath, http.MethodGet, o.ResolveIdentifier),
 	}
 }
 
@@ -133,5 +135,16 @@ func (o *Operation) ResolveDID(rw http.ResponseWriter, req *http.Request) {
 //    default: genericError
 //        200: didRecordResult
 func (o *Operation) GetDIDRecords(rw http.ResponseWriter, req *http.Request) {
-	rest.Execute(o.command.GetDIDRecords, rw, req.Body)
+	if len(req.URL.Query()) == 0 {
+		rest.Execute(o.command.GetDIDRecords, rw, req.Body)
+		return
+	}
+
+	request, err := rest.QueryValuesAsJSON(req.URL.Query())
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, vdr.InvalidRequestErrorCode, err)
+		return
+	}
+
+	rest.Execute(o.command.GetDIDRecords, rw, bytes.NewReader(request))
 }
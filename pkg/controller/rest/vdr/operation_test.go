@@ -58,7 +58,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 4, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 5, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -244,6 +244,53 @@ func TestGetDIDRecords(t *testing.T) {
 		require.NotEmpty(t, response)
 		require.Equal(t, 1, len(response.Result))
 	})
+
+	t.Run("test get did records page", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, SaveDIDPath, http.MethodPost)
+
+		for _, name := range []string{"name1", "name2", "name3"} {
+			jsonStr, e := json.Marshal(vdr.DIDArgs{Document: vdr.Document{DID: json.RawMessage(doc)}, Name: name})
+			require.NoError(t, e)
+
+			_, e = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+			require.NoError(t, e)
+		}
+
+		handler = lookupHandler(t, cmd, GetDIDRecordsPath, http.MethodGet)
+		buf, err := getSuccessResponseFromHandler(handler, nil, GetDIDRecordsPath+"?limit=2")
+		require.NoError(t, err)
+
+		var response vdr.DIDRecordResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Len(t, response.Result, 2)
+		require.Equal(t, 3, response.Total)
+		require.NotEmpty(t, response.NextCursor)
+
+		buf, err = getSuccessResponseFromHandler(handler, nil,
+			GetDIDRecordsPath+"?method=peer&sort=-name&cursor="+response.NextCursor)
+		require.NoError(t, err)
+
+		response = vdr.DIDRecordResult{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Len(t, response.Result, 1)
+		require.Equal(t, "name1", response.Result[0].Name)
+		require.Empty(t, response.NextCursor)
+
+		buf, code, err := sendRequestToHandler(handler, nil, GetDIDRecordsPath+"?limit=ten")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdr.InvalidRequestErrorCode, "invalid limit query parameter", buf.Bytes())
+
+		buf, code, err = sendRequestToHandler(handler, nil, GetDIDRecordsPath+"?sort=method")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdr.InvalidRequestErrorCode, "cannot sort did records by method", buf.Bytes())
+	})
 }
 
 func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
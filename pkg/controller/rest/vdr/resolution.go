@@ -0,0 +1,216 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+var logger = log.New("aries-framework/rest/vdr")
+
+// constants of the DID resolution endpoint, see https://w3c-ccg.github.io/did-resolution/#bindings-https.
+const (
+	// ResolutionPath is the path of the DID resolution endpoint.
+	ResolutionPath = "/1.0/identifiers/{id}"
+
+	// DIDLDJSONMediaType is the media type of the DID documents returned by the DID resolution endpoint.
+	DIDLDJSONMediaType = "application/did+ld+json"
+	// ResolutionResultMediaType is the media type of the DID resolution results, returned by the DID resolution
+	// endpoint when the client prefers them to the DID documents.
+	ResolutionResultMediaType = `application/ld+json;profile="` + resolutionProfile + `"`
+
+	resolutionProfile = "https://w3id.org/did-resolution"
+	resolutionContext = "https://w3id.org/did-resolution/v1"
+)
+
+// DID resolution errors.
+const (
+	invalidDIDError                 = "invalidDid"
+	notFoundError                   = "notFound"
+	methodNotSupportedError         = "methodNotSupported"
+	representationNotSupportedError = "representationNotSupported"
+	internalError                   = "internalError"
+)
+
+// ResolutionResult is the DID resolution result returned by the DID resolution endpoint.
+type ResolutionResult struct {
+	Context string `json:"@context"`
+	// DIDDocument is the resolved DID document, null if the resolution failed.
+	DIDDocument           json.RawMessage        `json:"didDocument"`
+	DIDResolutionMetadata ResolutionMetadata     `json:"didResolutionMetadata"`
+	DIDDocumentMetadata   map[string]interface{} `json:"didDocumentMetadata"`
+}
+
+// ResolutionMetadata is the metadata of the DID resolution.
+type ResolutionMetadata struct {
+	// ContentType is the media type of the DID document.
+	ContentType string `json:"contentType,omitempty"`
+	// Duration is the number of milliseconds the resolution took.
+	Duration int64 `json:"duration"`
+	// Error is the DID resolution error, e.g. "notFound".
+	Error string `json:"error,omitempty"`
+}
+
+// resolutionError is a DID resolution error with its HTTP status.
+type resolutionError struct {
+	status int
+	name   string
+	err    error
+}
+
+// ResolveIdentifier swagger:route GET /1.0/identifiers/{id} vdr resolveIdentifierReq
+//
+// Resolves the DID following the HTTP(S) binding of the DID Resolution spec. Returns the DID document, or the DID
+// resolution result if preferred by the Accept header.
+//
+// Responses:
+//    default: genericError
+//        200: resolveIdentifierRes
+func (o *Operation) ResolveIdentifier(rw http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+
+	resolutionResult, ok := acceptsResolutionResult(req.Header.Get("Accept"))
+	if !ok {
+		rest.SendHTTPStatusError(rw, http.StatusNotAcceptable, vdr.InvalidRequestErrorCode,
+			fmt.Errorf("%s : accepted media types are %s and %s", representationNotSupportedError,
+				DIDLDJSONMediaType, ResolutionResultMediaType))
+
+		return
+	}
+
+	docBytes, resErr := o.resolveIdentifier(mux.Vars(req)["id"])
+
+	if !resolutionResult {
+		if resErr != nil {
+			rest.SendHTTPStatusError(rw, resErr.status, vdr.ResolveDIDErrorCode,
+				fmt.Errorf("%s : %w", resErr.name, resErr.err))
+
+			return
+		}
+
+		rw.Header().Set("Content-Type", DIDLDJSONMediaType)
+
+		if _, err := rw.Write(docBytes); err != nil {
+			logger.Errorf("Unable to send DID document, %s", err)
+		}
+
+		return
+	}
+
+	result := &ResolutionResult{
+		Context:             resolutionContext,
+		DIDDocument:         docBytes,
+		DIDDocumentMetadata: map[string]interface{}{},
+		DIDResolutionMetadata: ResolutionMetadata{
+			Duration: time.Since(start).Milliseconds(),
+		},
+	}
+
+	status := http.StatusOK
+
+	if resErr != nil {
+		status = resErr.status
+		result.DIDResolutionMetadata.Error = resErr.name
+	} else {
+		result.DIDResolutionMetadata.ContentType = DIDLDJSONMediaType
+	}
+
+	rw.Header().Set("Content-Type", ResolutionResultMediaType)
+	rw.WriteHeader(status)
+
+	if err := json.NewEncoder(rw).Encode(result); err != nil {
+		logger.Errorf("Unable to send DID resolution result, %s", err)
+	}
+}
+
+func (o *Operation) resolveIdentifier(id string) ([]byte, *resolutionError) {
+	if _, err := did.Parse(id); err != nil {
+		return nil, &resolutionError{status: http.StatusBadRequest, name: invalidDIDError, err: err}
+	}
+
+	doc, err := o.registry.Resolve(id)
+
+	switch {
+	case errors.Is(err, vdrapi.ErrNotFound):
+		return nil, &resolutionError{status: http.StatusNotFound, name: notFoundError, err: err}
+	case errors.Is(err, vdrapi.ErrMethodNotSupported):
+		return nil, &resolutionError{status: http.StatusNotImplemented, name: methodNotSupportedError, err: err}
+	case err != nil:
+		return nil, &resolutionError{status: http.StatusInternalServerError, name: internalError, err: err}
+	}
+
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return nil, &resolutionError{status: http.StatusInternalServerError, name: internalError, err: err}
+	}
+
+	return docBytes, nil
+}
+
+// acceptsResolutionResult tells whether the client prefers the DID resolution result to the DID document, ok being
+// false if the client accepts none of them. The DID document is returned to the clients without preference.
+func acceptsResolutionResult(accept string) (resolutionResult, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		return false, true
+	}
+
+	bestQ := 0.0
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+
+		if v, found := params["q"]; found {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		// the first media range wins among those of the same quality
+		if q <= bestQ {
+			continue
+		}
+
+		switch mediaType {
+		case "application/ld+json":
+			resolutionResult, ok, bestQ = hasResolutionProfile(params["profile"]), true, q
+		case DIDLDJSONMediaType, "application/json", "application/*", "*/*":
+			resolutionResult, ok, bestQ = false, true, q
+		}
+	}
+
+	return resolutionResult, ok
+}
+
+func hasResolutionProfile(profile string) bool {
+	for _, p := range strings.Fields(profile) {
+		if p == resolutionProfile {
+			return true
+		}
+	}
+
+	return false
+}
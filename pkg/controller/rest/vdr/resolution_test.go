@@ -0,0 +1,147 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const resolvedDID = "did:peer:21tDAKCERh95uGgKbJNHYp"
+
+func TestOperation_ResolveIdentifier(t *testing.T) {
+	didDoc, err := did.ParseDocument([]byte(doc))
+	require.NoError(t, err)
+
+	router := newResolutionRouter(t, &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.ResolveOpts) (*did.Doc, error) {
+			switch didID {
+			case resolvedDID:
+				return didDoc, nil
+			case "did:example:123":
+				return nil, fmt.Errorf("did method example not supported for vdr: %w", vdrapi.ErrMethodNotSupported)
+			case "did:peer:failure":
+				return nil, fmt.Errorf("read failure")
+			default:
+				return nil, vdrapi.ErrNotFound
+			}
+		},
+	})
+
+	t.Run("DID document", func(t *testing.T) {
+		for _, accept := range []string{"", DIDLDJSONMediaType, "application/json", "*/*",
+			ResolutionResultMediaType + ";q=0.5, application/did+ld+json"} {
+			rw := resolve(router, resolvedDID, accept)
+			require.Equal(t, http.StatusOK, rw.Code, accept)
+			require.Equal(t, DIDLDJSONMediaType, rw.Header().Get("Content-Type"), accept)
+
+			resolved, err := did.ParseDocument(rw.Body.Bytes())
+			require.NoError(t, err, accept)
+			require.Equal(t, resolvedDID, resolved.ID, accept)
+		}
+	})
+
+	t.Run("DID resolution result", func(t *testing.T) {
+		for _, accept := range []string{ResolutionResultMediaType,
+			`application/did+ld+json;q=0.9, application/ld+json;profile="https://w3id.org/did-resolution"`} {
+			rw := resolve(router, resolvedDID, accept)
+			require.Equal(t, http.StatusOK, rw.Code, accept)
+			require.Equal(t, ResolutionResultMediaType, rw.Header().Get("Content-Type"), accept)
+
+			result := &ResolutionResult{}
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), result), accept)
+			require.Equal(t, resolutionContext, result.Context)
+			require.Equal(t, DIDLDJSONMediaType, result.DIDResolutionMetadata.ContentType)
+			require.Empty(t, result.DIDResolutionMetadata.Error)
+			require.NotNil(t, result.DIDDocumentMetadata)
+
+			resolved, err := did.ParseDocument(result.DIDDocument)
+			require.NoError(t, err)
+			require.Equal(t, resolvedDID, resolved.ID)
+		}
+	})
+
+	t.Run("resolution errors", func(t *testing.T) {
+		tests := []struct {
+			did    string
+			status int
+			error  string
+		}{
+			{did: "invalid", status: http.StatusBadRequest, error: invalidDIDError},
+			{did: "did:peer:unknown", status: http.StatusNotFound, error: notFoundError},
+			{did: "did:example:123", status: http.StatusNotImplemented, error: methodNotSupportedError},
+			{did: "did:peer:failure", status: http.StatusInternalServerError, error: internalError},
+		}
+
+		for _, tc := range tests {
+			rw := resolve(router, tc.did, "")
+			require.Equal(t, tc.status, rw.Code, tc.did)
+			verifyError(t, vdr.ResolveDIDErrorCode, tc.error, rw.Body.Bytes())
+
+			rw = resolve(router, tc.did, ResolutionResultMediaType)
+			require.Equal(t, tc.status, rw.Code, tc.did)
+
+			result := &ResolutionResult{}
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), result), tc.did)
+			require.Equal(t, tc.error, result.DIDResolutionMetadata.Error)
+			require.Empty(t, result.DIDResolutionMetadata.ContentType)
+			require.Equal(t, "null", string(result.DIDDocument))
+		}
+	})
+
+	t.Run("representation not supported", func(t *testing.T) {
+		for _, accept := range []string{"text/html", "application/did+cbor", "application/did+ld+json;q=0"} {
+			rw := resolve(router, resolvedDID, accept)
+			require.Equal(t, http.StatusNotAcceptable, rw.Code, accept)
+			verifyError(t, vdr.InvalidRequestErrorCode, representationNotSupportedError, rw.Body.Bytes())
+		}
+	})
+}
+
+func newResolutionRouter(t *testing.T, registry vdrapi.Registry) *mux.Router {
+	t.Helper()
+
+	op, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue:      registry,
+	})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+
+	for _, h := range op.GetRESTHandlers() {
+		router.HandleFunc(h.Path(), h.Handle()).Methods(h.Method())
+	}
+
+	return router
+}
+
+func resolve(router *mux.Router, didID, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/1.0/identifiers/"+didID, nil)
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, req)
+
+	return rw
+}
@@ -147,6 +147,53 @@ type credentialRecord struct {
 	verifiablestore.Record
 }
 
+// pageReq model
+//
+// This is used to page, filter and sort the credential or presentation records.
+//
+// swagger:parameters getCredentials getPresentations
+type pageReq struct { // nolint: unused,deadcode
+	// Number of records to skip
+	//
+	// in: query
+	Offset int `json:"offset"`
+
+	// Maximum number of records to return, all the records are returned when zero
+	//
+	// in: query
+	Limit int `json:"limit"`
+
+	// Cursor of the page of records to return, as returned in the next_cursor of the previous page
+	//
+	// in: query
+	Cursor string `json:"cursor"`
+
+	// Field to sort the records by (name, id or subjectId), prefixed with "-" for the descending order
+	//
+	// in: query
+	Sort string `json:"sort"`
+
+	// Type the records must have
+	//
+	// in: query
+	Type string `json:"type"`
+
+	// Subject ID of the records
+	//
+	// in: query
+	SubjectID string `json:"subject_id"`
+
+	// DID of the agent involved in the records
+	//
+	// in: query
+	MyDID string `json:"my_did"`
+
+	// DID of the other party involved in the records
+	//
+	// in: query
+	TheirDID string `json:"their_did"`
+}
+
 // credentialRecordResult model
 //
 // This is used to return credential records.
@@ -155,6 +202,16 @@ type credentialRecord struct {
 type credentialRecordResult struct {
 	// in: body
 	Result []*verifiablestore.Record `json:"result,omitempty"`
+
+	// Total number of records, set when the records are paged
+	//
+	// in: body
+	Total int `json:"total,omitempty"`
+
+	// Cursor of the next page of records, empty for the last page
+	//
+	// in: body
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // presentationRecordResult model
@@ -165,6 +222,16 @@ type credentialRecordResult struct {
 type presentationRecordResult struct {
 	// in: body
 	Result []*verifiablestore.Record `json:"result,omitempty"`
+
+	// Total number of records, set when the records are paged
+	//
+	// in: body
+	Total int `json:"total,omitempty"`
+
+	// Cursor of the next page of records, empty for the last page
+	//
+	// in: body
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // generatePresentationReq model
@@ -202,6 +269,64 @@ type signCredentialReq struct { // nolint: unused,deadcode
 	Params verifiable.SignCredentialRequest
 }
 
+// verifyCredentialReq model
+//
+// This is used to verify a credential.
+//
+// swagger:parameters verifyCredentialReq
+type verifyCredentialReq struct { // nolint: unused,deadcode
+	// Params for verifying a credential
+	//
+	// in: body
+	Params verifiable.VerifyCredentialRequest
+}
+
+// verifyPresentationReq model
+//
+// This is used to verify a presentation.
+//
+// swagger:parameters verifyPresentationReq
+type verifyPresentationReq struct { // nolint: unused,deadcode
+	// Params for verifying a presentation
+	//
+	// in: body
+	Params verifiable.VerifyPresentationRequest
+}
+
+// evaluatePresentationDefinitionReq model
+//
+// This is used to evaluate a presentation definition against the stored credentials.
+//
+// swagger:parameters evaluatePresentationDefinitionReq
+type evaluatePresentationDefinitionReq struct { // nolint: unused,deadcode
+	// Params for evaluating a presentation definition
+	//
+	// in: body
+	Params verifiable.PresentationDefinitionRequest
+}
+
+// presentationDefinitionRes model
+//
+// This is used to return the records of the stored credentials matching a presentation definition.
+//
+// swagger:response presentationDefinitionRes
+type presentationDefinitionRes struct { // nolint: unused,deadcode
+	// in: body
+	verifiable.PresentationDefinitionResult
+}
+
+// createPresentationSubmissionReq model
+//
+// This is used to create a presentation submitting stored credentials against a presentation definition.
+//
+// swagger:parameters createPresentationSubmissionReq
+type createPresentationSubmissionReq struct { // nolint: unused,deadcode
+	// Params for creating a presentation submission
+	//
+	// in: body
+	Params verifiable.PresentationSubmissionRequest
+}
+
 // signCredentialRes model
 //
 // This is used for returning the sign credential response
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -36,16 +37,25 @@ const (
 	GetCredentialPath          = verifiableCredentialPath + "/{id}"
 	GetCredentialByNamePath    = verifiableCredentialPath + "/name" + "/{name}"
 	GetCredentialsPath         = VerifiableOperationID + "/credentials"
-	SignCredentialsPath        = VerifiableOperationID + "/signcredential"
+	SignCredentialPath         = verifiableCredentialPath + "/sign"
+	VerifyCredentialPath       = verifiableCredentialPath + "/verify"
 	RemoveCredentialByNamePath = verifiableCredentialPath + "/remove/name" + "/{name}"
 
+	// SignCredentialsPath is the former path of SignCredentialPath, kept for the existing clients.
+	SignCredentialsPath = VerifiableOperationID + "/signcredential"
+
 	// presentation paths.
 	GeneratePresentationPath     = verifiablePresentationPath + "/generate"
 	GeneratePresentationByIDPath = verifiablePresentationPath + "/generatebyid"
+	VerifyPresentationPath       = verifiablePresentationPath + "/verify"
 	SavePresentationPath         = verifiablePresentationPath
 	GetPresentationPath          = verifiablePresentationPath + "/{id}"
 	GetPresentationsPath         = VerifiableOperationID + "/presentations"
 	RemovePresentationByNamePath = verifiablePresentationPath + "/remove/name" + "/{name}"
+
+	// presentation exchange paths.
+	EvaluatePresentationDefinitionPath = verifiablePresentationPath + "/definition/evaluate"
+	CreatePresentationSubmissionPath   = verifiablePresentationPath + "/submission"
 )
 
 // provider contains dependencies for the verifiable command and is typically created by using aries.Context().
@@ -88,9 +98,14 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(GetCredentialPath, http.MethodGet, o.GetCredential),
 		cmdutil.NewHTTPHandler(GetCredentialByNamePath, http.MethodGet, o.GetCredentialByName),
 		cmdutil.NewHTTPHandler(GetCredentialsPath, http.MethodGet, o.GetCredentials),
+		cmdutil.NewHTTPHandler(SignCredentialPath, http.MethodPost, o.SignCredential),
 		cmdutil.NewHTTPHandler(SignCredentialsPath, http.MethodPost, o.SignCredential),
+		cmdutil.NewHTTPHandler(VerifyCredentialPath, http.MethodPost, o.VerifyCredential),
 		cmdutil.NewHTTPHandler(GeneratePresentationPath, http.MethodPost, o.GeneratePresentation),
 		cmdutil.NewHTTPHandler(GeneratePresentationByIDPath, http.MethodPost, o.GeneratePresentationByID),
+		cmdutil.NewHTTPHandler(VerifyPresentationPath, http.MethodPost, o.VerifyPresentation),
+		cmdutil.NewHTTPHandler(EvaluatePresentationDefinitionPath, http.MethodPost, o.EvaluatePresentationDefinition),
+		cmdutil.NewHTTPHandler(CreatePresentationSubmissionPath, http.MethodPost, o.CreatePresentationSubmission),
 		cmdutil.NewHTTPHandler(SavePresentationPath, http.MethodPost, o.SavePresentation),
 		cmdutil.NewHTTPHandler(GetPresentationPath, http.MethodGet, o.GetPresentation),
 		cmdutil.NewHTTPHandler(GetPresentationsPath, http.MethodGet, o.GetPresentations),
@@ -197,10 +212,16 @@ func (o *Operation) GetCredentialByName(rw http.ResponseWriter, req *http.Reques
 //    default: genericError
 //        200: credentialRecordResult
 func (o *Operation) GetCredentials(rw http.ResponseWriter, req *http.Request) {
-	rest.Execute(o.command.GetCredentials, rw, req.Body)
+	request, err := pageRequest(req)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, verifiable.InvalidRequestErrorCode, err)
+		return
+	}
+
+	rest.Execute(o.command.GetCredentials, rw, request)
 }
 
-// SignCredential swagger:route POST /verifiable/signcredential verifiable signCredentialReq
+// SignCredential swagger:route POST /verifiable/credential/sign verifiable signCredentialReq
 //
 // Signs given credential.
 //
@@ -211,7 +232,51 @@ func (o *Operation) SignCredential(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.SignCredential, rw, req.Body)
 }
 
-// GetPresentations swagger:route GET /verifiable/presentations verifiable
+// VerifyCredential swagger:route POST /verifiable/credential/verify verifiable verifyCredentialReq
+//
+// Verifies the proof of given credential.
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) VerifyCredential(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.VerifyCredential, rw, req.Body)
+}
+
+// VerifyPresentation swagger:route POST /verifiable/presentation/verify verifiable verifyPresentationReq
+//
+// Verifies the proof of given presentation and the proofs of its credentials.
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) VerifyPresentation(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.VerifyPresentation, rw, req.Body)
+}
+
+// EvaluatePresentationDefinition swagger:route POST /verifiable/presentation/definition/evaluate verifiable evaluatePresentationDefinitionReq
+//
+// Retrieves the stored credentials matching each input descriptor of given presentation definition.
+//
+// Responses:
+//    default: genericError
+//        200: presentationDefinitionRes
+func (o *Operation) EvaluatePresentationDefinition(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.EvaluatePresentationDefinition, rw, req.Body)
+}
+
+// CreatePresentationSubmission swagger:route POST /verifiable/presentation/submission verifiable createPresentationSubmissionReq
+//
+// Creates a presentation submitting stored credentials against given presentation definition.
+//
+// Responses:
+//    default: genericError
+//        200: presentationRes
+func (o *Operation) CreatePresentationSubmission(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreatePresentationSubmission, rw, req.Body)
+}
+
+// GetPresentations swagger:route GET /verifiable/presentations verifiable getPresentations
 //
 // Retrieves the verifiable credentials.
 //
@@ -219,7 +284,13 @@ func (o *Operation) SignCredential(rw http.ResponseWriter, req *http.Request) {
 //    default: genericError
 //        200: presentationRecordResult
 func (o *Operation) GetPresentations(rw http.ResponseWriter, req *http.Request) {
-	rest.Execute(o.command.GetPresentations, rw, req.Body)
+	request, err := pageRequest(req)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, verifiable.InvalidRequestErrorCode, err)
+		return
+	}
+
+	rest.Execute(o.command.GetPresentations, rw, request)
 }
 
 // GeneratePresentation swagger:route POST /verifiable/presentation/generate verifiable generatePresentationReq
@@ -274,3 +345,17 @@ func (o *Operation) RemovePresentationByName(rw http.ResponseWriter, req *http.R
 
 	rest.Execute(o.command.RemovePresentationByName, rw, bytes.NewBufferString(request))
 }
+
+// pageRequest returns the paging, filtering and sorting arguments given as query parameters as the command request.
+func pageRequest(req *http.Request) (io.Reader, error) {
+	if len(req.URL.Query()) == 0 {
+		return req.Body, nil
+	}
+
+	request, err := rest.QueryValuesAsJSON(req.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(request), nil
+}
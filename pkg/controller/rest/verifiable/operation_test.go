@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	verifiableapi "github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	cryptomock "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
@@ -33,6 +34,7 @@ import (
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
 const (
@@ -209,7 +211,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 13, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 18, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -415,6 +417,41 @@ func TestGetCredentialByName(t *testing.T) {
 }
 
 func TestGetCredentials(t *testing.T) {
+	t.Run("test get credentials page", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: storeProvider,
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			recordBytes, e := json.Marshal(&verifiablestore.Record{Name: "vc" + strconv.Itoa(i), ID: strconv.Itoa(i)})
+			require.NoError(t, e)
+			require.NoError(t, storeProvider.Store.Put("vcname_vc"+strconv.Itoa(i), recordBytes))
+		}
+
+		handler := lookupHandler(t, cmd, GetCredentialsPath, http.MethodGet)
+		buf, err := getSuccessResponseFromHandler(handler, nil, GetCredentialsPath+"?offset=1&limit=5")
+		require.NoError(t, err)
+
+		var response credentialRecordResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Len(t, response.Result, 2)
+		require.Equal(t, "vc1", response.Result[0].Name)
+		require.Equal(t, 3, response.Total)
+
+		buf, code, err := sendRequestToHandler(handler, nil, GetCredentialsPath+"?offset=one")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.InvalidRequestErrorCode, "invalid offset query parameter", buf.Bytes())
+
+		buf, code, err = sendRequestToHandler(handler, nil, GetCredentialsPath+"?limit=one")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.InvalidRequestErrorCode, "invalid limit query parameter", buf.Bytes())
+	})
+
 	t.Run("test get credentials", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
 			StorageProviderValue: mockstore.NewMockStoreProvider(),
@@ -920,7 +957,7 @@ func TestSignCredential(t *testing.T) {
 		reqBytes, err := json.Marshal(req)
 		require.NoError(t, err)
 
-		handler := lookupHandler(t, cmd, SignCredentialsPath, http.MethodPost)
+		handler := lookupHandler(t, cmd, SignCredentialPath, http.MethodPost)
 		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
 		require.NoError(t, err, err)
 
@@ -949,7 +986,7 @@ func TestSignCredential(t *testing.T) {
 		reqBytes, err := json.Marshal(req)
 		require.NoError(t, err)
 
-		handler := lookupHandler(t, cmd, SignCredentialsPath, http.MethodPost)
+		handler := lookupHandler(t, cmd, SignCredentialPath, http.MethodPost)
 		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
 		require.NoError(t, err)
 
@@ -990,7 +1027,7 @@ func TestSignCredential(t *testing.T) {
 		reqBytes, err := json.Marshal(req)
 		require.NoError(t, err)
 
-		handler := lookupHandler(t, cmd, SignCredentialsPath, http.MethodPost)
+		handler := lookupHandler(t, cmd, SignCredentialPath, http.MethodPost)
 		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
 		require.NoError(t, err)
 
@@ -1021,7 +1058,7 @@ func TestSignCredential(t *testing.T) {
             "did"  : "did:peer:21tDAKCERh95uGgKbJNHYp"
 		}`)
 
-		handler := lookupHandler(t, cmd, SignCredentialsPath, http.MethodPost)
+		handler := lookupHandler(t, cmd, SignCredentialPath, http.MethodPost)
 		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
 		require.NoError(t, err)
 		require.NotEmpty(t, buf)
@@ -1030,6 +1067,179 @@ func TestSignCredential(t *testing.T) {
 		verifyError(t, verifiable.SignCredentialErrorCode,
 			"parse vc : unmarshal new credential", buf.Bytes())
 	})
+
+	t.Run("test sign credential - former path", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, SignCredentialsPath, http.MethodPost)
+		_, code, err := sendRequestToHandler(handler, bytes.NewBufferString("{}"), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestVerifyCredential(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue:      &mockvdr.MockVDRegistry{},
+	})
+	require.NoError(t, err)
+
+	t.Run("test verify credential - invalid request", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, VerifyCredentialPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("--"), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.InvalidRequestErrorCode, "request decode", buf.Bytes())
+	})
+
+	t.Run("test verify credential - error", func(t *testing.T) {
+		reqBytes, err := json.Marshal(verifiable.VerifyCredentialRequest{
+			VerifiableCredential: []byte(`{"id":"http://example.edu/credentials/1989"}`),
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, VerifyCredentialPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.VerifyCredentialErrorCode, "verify vc :", buf.Bytes())
+	})
+}
+
+func TestVerifyPresentation(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue:      &mockvdr.MockVDRegistry{},
+	})
+	require.NoError(t, err)
+
+	t.Run("test verify presentation - invalid request", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, VerifyPresentationPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("--"), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.InvalidRequestErrorCode, "request decode", buf.Bytes())
+	})
+
+	t.Run("test verify presentation - error", func(t *testing.T) {
+		reqBytes, err := json.Marshal(verifiable.VerifyPresentationRequest{
+			VerifiablePresentation: []byte(`{"id":"http://example.edu/presentations/1989"}`),
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, VerifyPresentationPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.VerifyPresentationErrorCode, "verify vp :", buf.Bytes())
+	})
+}
+
+func TestEvaluatePresentationDefinition(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	vcReq := verifiable.CredentialExt{
+		Credential: verifiable.Credential{VerifiableCredential: vc},
+		Name:       sampleCredentialName,
+	}
+	jsonStr, err := json.Marshal(vcReq)
+	require.NoError(t, err)
+
+	handler := lookupHandler(t, cmd, SaveCredentialPath, http.MethodPost)
+	_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+	require.NoError(t, err)
+
+	t.Run("test evaluate presentation definition - success", func(t *testing.T) {
+		reqBytes, err := json.Marshal(verifiable.PresentationDefinitionRequest{
+			PresentationDefinition: &presexch.PresentationDefinition{
+				InputDescriptors: []*presexch.InputDescriptor{{
+					ID:     "any",
+					Schema: []presexch.Schema{{URI: "https://www.w3.org/2018/credentials/v1"}},
+				}},
+			},
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, EvaluatePresentationDefinitionPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
+		require.NoError(t, err)
+
+		response := presentationDefinitionRes{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.True(t, response.Satisfied)
+		require.Len(t, response.Matches["any"], 1)
+		require.Equal(t, sampleVCID, response.Matches["any"][0].ID)
+		require.Equal(t, sampleCredentialName, response.Matches["any"][0].Name)
+	})
+
+	t.Run("test evaluate presentation definition - error", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, EvaluatePresentationDefinitionPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("{}"), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.InvalidRequestErrorCode, "presentation definition is mandatory", buf.Bytes())
+	})
+}
+
+func TestCreatePresentationSubmission(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	definition := &presexch.PresentationDefinition{
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID:     "any",
+			Schema: []presexch.Schema{{URI: "https://www.w3.org/2018/credentials/v1"}},
+		}},
+	}
+
+	t.Run("test create presentation submission - error", func(t *testing.T) {
+		reqBytes, err := json.Marshal(verifiable.PresentationSubmissionRequest{PresentationDefinition: definition})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, CreatePresentationSubmissionPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.CreatePresentationSubmissionErrorCode, "create submission", buf.Bytes())
+	})
+
+	t.Run("test create presentation submission - success", func(t *testing.T) {
+		vcReq := verifiable.CredentialExt{
+			Credential: verifiable.Credential{VerifiableCredential: vc},
+			Name:       sampleCredentialName,
+		}
+		jsonStr, err := json.Marshal(vcReq)
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, SaveCredentialPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		reqBytes, err := json.Marshal(verifiable.PresentationSubmissionRequest{PresentationDefinition: definition})
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, cmd, CreatePresentationSubmissionPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
+		require.NoError(t, err)
+
+		response := presentationRes{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+
+		vp, err := verifiableapi.ParseUnverifiedPresentation(response.VerifiablePresentation)
+		require.NoError(t, err)
+		require.Contains(t, vp.Type, presexch.PresentationSubmissionJSONLDType)
+		require.Len(t, vp.Credentials(), 1)
+	})
 }
 
 func TestRemoveVCByName(t *testing.T) {
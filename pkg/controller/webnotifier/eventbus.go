@@ -0,0 +1,399 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// EventStoreName is the name of the store of the events and subscriptions of the event bus.
+	EventStoreName = "event_bus"
+
+	eventKeyPrefix        = "event_"
+	subscriptionKeyPrefix = "subscription_"
+	lastOffsetKey         = "last_offset"
+
+	defaultMaxEvents          = 10000
+	defaultMaxDeliveryBackoff = time.Minute
+)
+
+// Event is an event of the event bus.
+type Event struct {
+	// Offset is the position of the event in the bus, starting at 1.
+	Offset uint64 `json:"offset"`
+	// Topic is the topic of the event, e.g. "didexchange_states".
+	Topic string `json:"topic"`
+	// Message is the topic message, in the same format as the webhook notifications. Its ID is the same when the
+	// event is delivered again, so that the subscribers can drop the duplicates.
+	Message json.RawMessage `json:"message"`
+}
+
+// Sink receives the events of a durable subscription, the event is delivered again if it returns an error.
+type Sink interface {
+	Deliver(e *Event) error
+}
+
+// SinkFunc is a function receiving the events of a durable subscription.
+type SinkFunc func(e *Event) error
+
+// Deliver calls f(e).
+func (f SinkFunc) Deliver(e *Event) error {
+	return f(e)
+}
+
+// EventBusOpt configures the EventBus.
+type EventBusOpt func(b *EventBus)
+
+// WithMaxEvents sets the number of the most recent events kept for the replays, 10000 if not set.
+func WithMaxEvents(maxEvents uint64) EventBusOpt {
+	return func(b *EventBus) {
+		b.maxEvents = maxEvents
+	}
+}
+
+// WithDeliveryBackoff sets the initial and maximum intervals between the deliveries of an event failing to be
+// delivered to a subscription, the interval doubling after each failure. The maximum interval is one minute if not set.
+func WithDeliveryBackoff(initial, max time.Duration) EventBusOpt {
+	return func(b *EventBus) {
+		b.initialBackoff = initial
+		b.maxBackoff = max
+	}
+}
+
+// EventBus is a dispatcher persisting the notifications as the events of a log, so that they survive the restarts of
+// the agent. Each event has an offset, the events after an offset can be replayed, and the durable subscriptions
+// (e.g. of the webhooks) persist the offset of the last event they received: once restarted, they get the events they
+// missed. The events are delivered at least once to the subscriptions, an event failing to be delivered is retried
+// until it's delivered, the following events waiting for it.
+type EventBus struct {
+	store          storage.Store
+	maxEvents      uint64
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	lock       sync.RWMutex
+	lastOffset uint64
+	// published is closed, then replaced, when an event is published.
+	published     chan struct{}
+	subscriptions map[string]*subscription
+	closed        bool
+}
+
+type subscription struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewEventBus returns a new instance of an EventBus keeping its events and the offsets of its subscriptions in the
+// given store (see EventStoreName).
+func NewEventBus(store storage.Store, opts ...EventBusOpt) (*EventBus, error) {
+	b := &EventBus{
+		store:          store,
+		maxEvents:      defaultMaxEvents,
+		initialBackoff: time.Second,
+		maxBackoff:     defaultMaxDeliveryBackoff,
+		published:      make(chan struct{}),
+		subscriptions:  make(map[string]*subscription),
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	offset, err := b.readOffset(lastOffsetKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read last offset: %w", err)
+	}
+
+	b.lastOffset = offset
+
+	return b, nil
+}
+
+// Notify appends the given message to the events of the bus.
+func (b *EventBus) Notify(topic string, message []byte) error {
+	if topic == "" {
+		return fmt.Errorf(emptyTopicErrMsg)
+	}
+
+	if len(message) == 0 {
+		return fmt.Errorf(emptyMessageErrMsg)
+	}
+
+	topicMsg, err := PrepareTopicMessage(topic, message)
+	if err != nil {
+		return fmt.Errorf(failedToCreateErrMsg, err)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	offset := b.lastOffset + 1
+
+	event, err := json.Marshal(&Event{Offset: offset, Topic: topic, Message: topicMsg})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	operations := []storage.Operation{
+		{Key: eventKey(offset), Value: event},
+		{Key: lastOffsetKey, Value: []byte(strconv.FormatUint(offset, 10))},
+	}
+
+	if b.maxEvents > 0 && offset > b.maxEvents {
+		operations = append(operations, storage.Operation{Key: eventKey(offset - b.maxEvents)})
+	}
+
+	if err = b.store.Batch(operations); err != nil {
+		return fmt.Errorf("failed to store event: %w", err)
+	}
+
+	b.lastOffset = offset
+
+	close(b.published)
+	b.published = make(chan struct{})
+
+	return nil
+}
+
+// LastOffset returns the offset of the last event of the bus, 0 if there's none.
+func (b *EventBus) LastOffset() uint64 {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.lastOffset
+}
+
+// Replay returns the events after the offset matching the topics, all the events after the offset if no topic is
+// given. A topic ending with "*" matches the topics starting with it.
+func (b *EventBus) Replay(offset uint64, topics ...string) ([]*Event, error) {
+	events, _, _, err := b.eventsAfter(offset, topicFilter(topics))
+
+	return events, err
+}
+
+// eventsAfter returns the events after the offset matching the filter, the last offset and the channel closed on
+// the next event.
+func (b *EventBus) eventsAfter(offset uint64, filter topicFilter) ([]*Event, uint64, <-chan struct{}, error) {
+	b.lock.RLock()
+	lastOffset, published := b.lastOffset, b.published
+	b.lock.RUnlock()
+
+	if offset >= lastOffset {
+		return nil, lastOffset, published, nil
+	}
+
+	itr := b.store.Iterator(eventKeyPrefix, eventKeyPrefix+storage.EndKeySuffix)
+	defer itr.Release()
+
+	var events []*Event
+
+	for itr.Next() {
+		e := &Event{}
+
+		if err := json.Unmarshal(itr.Value(), e); err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to unmarshal event %s: %w", itr.Key(), err)
+		}
+
+		// the events published after the last offset was read are returned with the next call
+		if e.Offset > offset && e.Offset <= lastOffset && filter.matches(e.Topic) {
+			events = append(events, e)
+		}
+	}
+
+	if err := itr.Error(); err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to iterate events: %w", err)
+	}
+
+	return events, lastOffset, published, nil
+}
+
+// Subscribe starts the delivery of the events matching the topics to the sink, all the events if no topic is given.
+// The subscription is durable: the offset of the last event delivered to the sink is persisted under the name of
+// the subscription, and the delivery resumes after it when the subscription is started again with the same name,
+// e.g. once the agent is restarted. The delivery of a new subscription starts after the last event of the bus.
+func (b *EventBus) Subscribe(name string, sink Sink, topics ...string) error {
+	if name == "" {
+		return errors.New("subscription name is mandatory")
+	}
+
+	offset, err := b.readOffset(subscriptionKey(name))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		offset = b.LastOffset()
+		err = b.store.Put(subscriptionKey(name), []byte(strconv.FormatUint(offset, 10)))
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to initialize subscription %s: %w", name, err)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		return errors.New("the event bus is closed")
+	}
+
+	if _, ok := b.subscriptions[name]; ok {
+		return fmt.Errorf("subscription %s already exists", name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := &subscription{cancel: cancel, done: make(chan struct{})}
+	b.subscriptions[name] = sub
+
+	go b.deliver(ctx, name, offset, sink, topicFilter(topics), sub.done)
+
+	return nil
+}
+
+// Unsubscribe stops the delivery of the events to the subscription, the offset of the subscription is kept so that
+// the delivery resumes where it stopped if it's subscribed again.
+func (b *EventBus) Unsubscribe(name string) {
+	b.lock.Lock()
+	sub, ok := b.subscriptions[name]
+	delete(b.subscriptions, name)
+	b.lock.Unlock()
+
+	if ok {
+		sub.cancel()
+		<-sub.done
+	}
+}
+
+// Subscriptions returns the names of the running subscriptions.
+func (b *EventBus) Subscriptions() []string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	names := make([]string, 0, len(b.subscriptions))
+	for name := range b.subscriptions {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Close stops the delivery of the events to the subscriptions.
+func (b *EventBus) Close() {
+	b.lock.Lock()
+	b.closed = true
+	names := make([]string, 0, len(b.subscriptions))
+
+	for name := range b.subscriptions {
+		names = append(names, name)
+	}
+
+	b.lock.Unlock()
+
+	for _, name := range names {
+		b.Unsubscribe(name)
+	}
+}
+
+// deliver delivers the events after the offset to the sink until the context is canceled.
+func (b *EventBus) deliver(ctx context.Context, name string, offset uint64, sink Sink, filter topicFilter,
+	done chan struct{}) {
+	defer close(done)
+
+	for {
+		events, lastOffset, published, err := b.eventsAfter(offset, filter)
+		if err != nil {
+			logger.Errorf("failed to read the events of subscription %s : %s", name, err)
+
+			select {
+			case <-time.After(b.initialBackoff):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for _, e := range events {
+			if b.deliverEvent(ctx, name, sink, e) != nil {
+				// canceled
+				return
+			}
+
+			offset = e.Offset
+		}
+
+		// skips the events not matching the filter
+		if lastOffset > offset {
+			offset = lastOffset
+
+			b.saveOffset(name, offset)
+		}
+
+		select {
+		case <-published:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deliverEvent delivers the event to the sink until it succeeds or the context is canceled, then saves the offset of
+// the event. The event is delivered again if the offset isn't saved, hence the at-least-once delivery.
+func (b *EventBus) deliverEvent(ctx context.Context, name string, sink Sink, e *Event) error {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = b.initialBackoff
+	bo.MaxInterval = b.maxBackoff
+	bo.MaxElapsedTime = 0
+
+	err := backoff.RetryNotify(func() error {
+		return sink.Deliver(e)
+	}, backoff.WithContext(bo, ctx), func(err error, next time.Duration) {
+		logger.Warnf("failed to deliver event %d to subscription %s, retrying in %s : %s", e.Offset, name, next, err)
+	})
+	if err != nil {
+		return err
+	}
+
+	b.saveOffset(name, e.Offset)
+
+	return nil
+}
+
+func (b *EventBus) saveOffset(name string, offset uint64) {
+	if err := b.store.Put(subscriptionKey(name), []byte(strconv.FormatUint(offset, 10))); err != nil {
+		logger.Errorf("failed to save the offset of subscription %s : %s", name, err)
+	}
+}
+
+func (b *EventBus) readOffset(key string) (uint64, error) {
+	v, err := b.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) && key == lastOffsetKey {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(string(v), 10, 64)
+}
+
+// eventKey returns the key of the event, zero padded so that the events are iterated in order.
+func eventKey(offset uint64) string {
+	return fmt.Sprintf("%s%020d", eventKeyPrefix, offset)
+}
+
+func subscriptionKey(name string) string {
+	return subscriptionKeyPrefix + name
+}
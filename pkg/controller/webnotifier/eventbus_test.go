@@ -0,0 +1,329 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestEventBus_Replay(t *testing.T) {
+	t.Run("replays the events after the offset", func(t *testing.T) {
+		store := newEventStore(t)
+
+		bus, err := NewEventBus(store, WithMaxEvents(3))
+		require.NoError(t, err)
+
+		for _, topic := range []string{"didexchange_states", "issuecredential_actions", "didexchange_states", "other"} {
+			require.NoError(t, bus.Notify(topic, []byte(`{}`)))
+		}
+
+		require.EqualValues(t, 4, bus.LastOffset())
+
+		// the first event is dropped
+		events, err := bus.Replay(0)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		require.EqualValues(t, 2, events[0].Offset)
+
+		events, err = bus.Replay(2, "didexchange_*")
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.EqualValues(t, 3, events[0].Offset)
+		require.Equal(t, "didexchange_states", events[0].Topic)
+
+		events, err = bus.Replay(4)
+		require.NoError(t, err)
+		require.Empty(t, events)
+
+		// the events survive the restarts
+		bus, err = NewEventBus(store)
+		require.NoError(t, err)
+		require.EqualValues(t, 4, bus.LastOffset())
+
+		require.NoError(t, bus.Notify("other", []byte(`{}`)))
+
+		events, err = bus.Replay(3)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		require.EqualValues(t, 5, events[1].Offset)
+	})
+
+	t.Run("invalid notification", func(t *testing.T) {
+		bus, err := NewEventBus(newEventStore(t))
+		require.NoError(t, err)
+
+		require.EqualError(t, bus.Notify("", []byte(`{}`)), emptyTopicErrMsg)
+		require.EqualError(t, bus.Notify("a", nil), emptyMessageErrMsg)
+		require.Error(t, bus.Notify("a", []byte("invalid")))
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		_, err := NewEventBus(&mockstorage.MockStore{Store: map[string][]byte{
+			lastOffsetKey: []byte("invalid"),
+		}})
+		require.Contains(t, err.Error(), "failed to read last offset")
+
+		bus, err := NewEventBus(&mockstorage.MockStore{
+			Store: map[string][]byte{}, ErrBatch: errors.New("batch error"),
+		})
+		require.NoError(t, err)
+		require.EqualError(t, bus.Notify("a", []byte(`{}`)), "failed to store event: batch error")
+
+		store := newEventStore(t)
+
+		bus, err = NewEventBus(store)
+		require.NoError(t, err)
+		require.NoError(t, bus.Notify("a", []byte(`{}`)))
+		require.NoError(t, store.Put(eventKey(1), []byte("{")))
+
+		_, err = bus.Replay(0)
+		require.Contains(t, err.Error(), "failed to unmarshal event")
+	})
+}
+
+func TestEventBus_Subscribe(t *testing.T) {
+	t.Run("resumes the durable subscriptions", func(t *testing.T) {
+		store := newEventStore(t)
+
+		bus, err := NewEventBus(store)
+		require.NoError(t, err)
+
+		require.NoError(t, bus.Notify("a", []byte(`{"n":0}`)))
+
+		sink := newRecordingSink()
+
+		// a new subscription starts after the last event
+		require.NoError(t, bus.Subscribe("sub", sink, "a"))
+		require.EqualError(t, bus.Subscribe("sub", sink), "subscription sub already exists")
+		require.Equal(t, []string{"sub"}, bus.Subscriptions())
+
+		require.NoError(t, bus.Notify("a", []byte(`{"n":1}`)))
+		require.NoError(t, bus.Notify("b", []byte(`{"n":2}`)))
+		sink.waitFor(t, 2)
+
+		bus.Close()
+		require.Empty(t, bus.Subscriptions())
+		require.EqualError(t, bus.Subscribe("other", sink), "the event bus is closed")
+
+		// the events published while the subscription is stopped are delivered once it's started again
+		bus, err = NewEventBus(store)
+		require.NoError(t, err)
+
+		require.NoError(t, bus.Notify("a", []byte(`{"n":3}`)))
+		require.NoError(t, bus.Subscribe("sub", sink, "a"))
+		sink.waitFor(t, 4)
+
+		defer bus.Close()
+
+		require.Equal(t, []uint64{2, 4}, sink.offsets())
+	})
+
+	t.Run("retries the failed deliveries", func(t *testing.T) {
+		bus, err := NewEventBus(newEventStore(t), WithDeliveryBackoff(time.Millisecond, time.Millisecond))
+		require.NoError(t, err)
+
+		defer bus.Close()
+
+		var calls int32
+
+		sink := newRecordingSink()
+
+		require.NoError(t, bus.Subscribe("sub", SinkFunc(func(e *Event) error {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return errors.New("unavailable")
+			}
+
+			return sink.Deliver(e)
+		})))
+
+		require.NoError(t, bus.Notify("a", []byte(`{"n":1}`)))
+		require.NoError(t, bus.Notify("a", []byte(`{"n":2}`)))
+		sink.waitFor(t, 2)
+
+		require.Equal(t, []uint64{1, 2}, sink.offsets())
+		require.EqualValues(t, 4, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("unsubscribes while delivering", func(t *testing.T) {
+		store := newEventStore(t)
+
+		bus, err := NewEventBus(store, WithDeliveryBackoff(time.Millisecond, time.Millisecond))
+		require.NoError(t, err)
+
+		require.NoError(t, bus.Subscribe("sub", SinkFunc(func(*Event) error {
+			return errors.New("unavailable")
+		})))
+
+		require.NoError(t, bus.Notify("a", []byte(`{}`)))
+
+		bus.Unsubscribe("sub")
+		require.Empty(t, bus.Subscriptions())
+
+		// the event is delivered once subscribed again
+		offset, err := store.Get(subscriptionKey("sub"))
+		require.NoError(t, err)
+		require.Equal(t, "0", string(offset))
+	})
+
+	t.Run("invalid subscription", func(t *testing.T) {
+		bus, err := NewEventBus(newEventStore(t))
+		require.NoError(t, err)
+
+		require.EqualError(t, bus.Subscribe("", newRecordingSink()), "subscription name is mandatory")
+
+		bus, err = NewEventBus(&mockstorage.MockStore{
+			Store: map[string][]byte{}, ErrPut: errors.New("put error"),
+		})
+		require.NoError(t, err)
+		require.EqualError(t, bus.Subscribe("sub", newRecordingSink()),
+			"failed to initialize subscription sub: put error")
+	})
+}
+
+func TestNewDurable(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		received []map[string]interface{}
+		down     int32 = 1
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(&down) == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		msg := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&msg))
+
+		lock.Lock()
+		received = append(received, msg)
+		lock.Unlock()
+	}))
+	defer srv.Close()
+
+	bus, err := NewEventBus(newEventStore(t), WithDeliveryBackoff(time.Millisecond, 10*time.Millisecond))
+	require.NoError(t, err)
+
+	n, err := NewDurable(WSPath, EventsPath, bus, []string{srv.URL})
+	require.NoError(t, err)
+
+	defer n.Close()
+
+	require.Len(t, n.notifiers, 2)
+	require.Len(t, n.handlers, 3)
+	require.Equal(t, []string{webhookSubscriptionPrefix + srv.URL}, bus.Subscriptions())
+
+	// the webhook gets the notifications once it's up
+	require.NoError(t, n.Notify(topic, getTestBasicMessageJSON()))
+	require.NoError(t, n.Notify(topic, getTestBasicMessageJSON()))
+
+	atomic.StoreInt32(&down, 0)
+
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+
+		return len(received) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	require.Equal(t, topic, received[0]["topic"])
+	require.NotEqual(t, received[0]["id"], received[1]["id"])
+
+	n.SetWebhookURLs(nil)
+	require.Empty(t, bus.Subscriptions())
+}
+
+func TestStreamNotifier_EventBus(t *testing.T) {
+	bus, err := NewEventBus(newEventStore(t))
+	require.NoError(t, err)
+
+	n := NewStreamNotifier(EventsPath, WithEventBus(bus))
+
+	require.NoError(t, n.Notify("a", []byte(`{"n":1}`)))
+	require.NoError(t, bus.Notify("b", []byte(`{"n":2}`)))
+
+	id, err := n.resumeID("")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, id)
+
+	events, lastID, _ := n.eventsAfter(0, topicFilter{"b"})
+	require.Len(t, events, 1)
+	require.EqualValues(t, 2, events[0].id)
+	require.Equal(t, "b", events[0].topic)
+	require.EqualValues(t, 2, lastID)
+
+	require.NoError(t, bus.store.Put(eventKey(1), []byte("{")))
+
+	events, lastID, published := n.eventsAfter(0, nil)
+	require.Empty(t, events)
+	require.Zero(t, lastID)
+	require.Nil(t, published)
+}
+
+func newEventStore(t *testing.T) storage.Store {
+	t.Helper()
+
+	store, err := mem.NewProvider().OpenStore(EventStoreName)
+	require.NoError(t, err)
+
+	return store
+}
+
+type recordingSink struct {
+	lock   sync.Mutex
+	events []*Event
+}
+
+func newRecordingSink() *recordingSink {
+	return &recordingSink{}
+}
+
+func (s *recordingSink) Deliver(e *Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.events = append(s.events, e)
+
+	return nil
+}
+
+func (s *recordingSink) offsets() []uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	offsets := make([]uint64, len(s.events))
+	for i, e := range s.events {
+		offsets[i] = e.Offset
+	}
+
+	return offsets
+}
+
+func (s *recordingSink) waitFor(t *testing.T, offset uint64) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		offsets := s.offsets()
+
+		return len(offsets) > 0 && offsets[len(offsets)-1] == offset
+	}, time.Second, 5*time.Millisecond)
+}
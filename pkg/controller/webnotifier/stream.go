@@ -0,0 +1,391 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+const (
+	// InvalidRequestErrorCode is the error code of the invalid event stream requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Common)
+)
+
+const (
+	// EventsPath is the path of the long-poll endpoint of the controller.
+	EventsPath = "/events"
+	// StreamPathSuffix is appended to the events path to name the Server-Sent Events endpoint.
+	StreamPathSuffix = "/stream"
+
+	// TopicParam is the query parameter filtering the events by topic, e.g. "didexchange_states". It can be
+	// repeated, and a value ending with "*" matches the topics starting with the value.
+	TopicParam = "topic"
+	// SinceParam is the query parameter with the resume token, the events after it are returned.
+	SinceParam = "since"
+	// TimeoutParam is the query parameter with the number of seconds a long-poll request waits for events.
+	TimeoutParam = "timeout"
+
+	lastEventIDHeader = "Last-Event-ID"
+
+	defaultBufferSize      = 1000
+	defaultPollTimeout     = 30 * time.Second
+	maxPollTimeout         = 2 * time.Minute
+	defaultKeepAlivePeriod = 15 * time.Second
+)
+
+// StreamNotifierOpt configures the StreamNotifier.
+type StreamNotifierOpt func(n *StreamNotifier)
+
+// WithBufferSize sets the number of the most recent events kept for the clients resuming the stream, 1000 if not
+// set.
+func WithBufferSize(size int) StreamNotifierOpt {
+	return func(n *StreamNotifier) {
+		n.bufferSize = size
+	}
+}
+
+// WithKeepAlivePeriod sets the period of the comments keeping the idle Server-Sent Events connections open,
+// 15 seconds if not set.
+func WithKeepAlivePeriod(period time.Duration) StreamNotifierOpt {
+	return func(n *StreamNotifier) {
+		n.keepAlivePeriod = period
+	}
+}
+
+// WithEventBus serves the events of the bus instead of buffering the events in memory: the resume tokens are the
+// offsets of the events in the bus, so the clients can resume the stream after a restart of the agent. The
+// notifications of the StreamNotifier are appended to the bus.
+func WithEventBus(bus *EventBus) StreamNotifierOpt {
+	return func(n *StreamNotifier) {
+		n.bus = bus
+	}
+}
+
+// StreamNotifier is a dispatcher serving the notifications to the clients which can't receive webhooks, as a
+// Server-Sent Events stream and, as a fallback, by long polling. Each event has a resume token: the clients
+// reconnecting with it get the events they missed, as long as these are still in the buffer.
+type StreamNotifier struct {
+	bufferSize      int
+	keepAlivePeriod time.Duration
+	bus             *EventBus
+	lock            sync.RWMutex
+	events          []*streamEvent
+	lastID          uint64
+	// published is closed, then replaced, when an event is published.
+	published chan struct{}
+	handlers  []rest.Handler
+}
+
+type streamEvent struct {
+	id      uint64
+	topic   string
+	message json.RawMessage
+}
+
+// PollArgs are the query parameters of the long-poll requests.
+type PollArgs struct {
+	// Topic filters the events by topic, a value ending with "*" matching the topics starting with the value.
+	Topic string `json:"topic,omitempty"`
+	// Since is the resume token, only the events published after the request are returned if not set.
+	Since string `json:"since,omitempty"`
+	// Timeout is the number of seconds to wait for events, 30 if not set.
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// PollResponse is the response of the long-poll requests.
+type PollResponse struct {
+	// Events are the topic messages, in the same format as the webhook notifications.
+	Events []json.RawMessage `json:"events"`
+	// Token is the resume token of the next request.
+	Token string `json:"token"`
+}
+
+// NewStreamNotifier returns a new instance of a StreamNotifier, serving the long-poll requests on the given path
+// and the Server-Sent Events stream on the path followed by StreamPathSuffix.
+func NewStreamNotifier(path string, opts ...StreamNotifierOpt) *StreamNotifier {
+	n := &StreamNotifier{
+		bufferSize:      defaultBufferSize,
+		keepAlivePeriod: defaultKeepAlivePeriod,
+		published:       make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	n.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(path, http.MethodGet, n.poll),
+		cmdutil.NewHTTPHandler(path+StreamPathSuffix, http.MethodGet, n.stream),
+	}
+
+	return n
+}
+
+// Notify adds the given message to the events served to the clients.
+func (n *StreamNotifier) Notify(topic string, message []byte) error {
+	if n.bus != nil {
+		return n.bus.Notify(topic, message)
+	}
+
+	if topic == "" {
+		return fmt.Errorf(emptyTopicErrMsg)
+	}
+
+	if len(message) == 0 {
+		return fmt.Errorf(emptyMessageErrMsg)
+	}
+
+	topicMsg, err := PrepareTopicMessage(topic, message)
+	if err != nil {
+		return fmt.Errorf(failedToCreateErrMsg, err)
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.lastID++
+	n.events = append(n.events, &streamEvent{id: n.lastID, topic: topic, message: topicMsg})
+
+	if len(n.events) > n.bufferSize {
+		n.events = n.events[len(n.events)-n.bufferSize:]
+	}
+
+	close(n.published)
+	n.published = make(chan struct{})
+
+	return nil
+}
+
+// GetRESTHandlers returns all REST handlers provided by notifier.
+func (n *StreamNotifier) GetRESTHandlers() []rest.Handler {
+	return n.handlers
+}
+
+// eventsAfter returns the buffered events after the given ID matching the filter, the last ID and the channel
+// closed on the next event.
+func (n *StreamNotifier) eventsAfter(id uint64, filter topicFilter) ([]*streamEvent, uint64, <-chan struct{}) {
+	if n.bus != nil {
+		return n.busEventsAfter(id, filter)
+	}
+
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	var events []*streamEvent
+
+	for _, e := range n.events {
+		if e.id > id && filter.matches(e.topic) {
+			events = append(events, e)
+		}
+	}
+
+	return events, n.lastID, n.published
+}
+
+// busEventsAfter returns the events of the bus after the given ID matching the filter, the last ID and the channel
+// closed on the next event. The events are read again with the next call if they can't be read now.
+func (n *StreamNotifier) busEventsAfter(id uint64, filter topicFilter) ([]*streamEvent, uint64, <-chan struct{}) {
+	busEvents, lastID, published, err := n.bus.eventsAfter(id, filter)
+	if err != nil {
+		logger.Errorf("Unable to read the events of the event bus, %s", err)
+
+		return nil, id, nil
+	}
+
+	events := make([]*streamEvent, len(busEvents))
+
+	for i, e := range busEvents {
+		events[i] = &streamEvent{id: e.Offset, topic: e.Topic, message: e.Message}
+	}
+
+	return events, lastID, published
+}
+
+// resumeID returns the ID of the resume token, the last event ID if the token is empty.
+func (n *StreamNotifier) resumeID(token string) (uint64, error) {
+	if token == "" && n.bus != nil {
+		return n.bus.LastOffset(), nil
+	}
+
+	if token == "" {
+		n.lock.RLock()
+		defer n.lock.RUnlock()
+
+		return n.lastID, nil
+	}
+
+	id, err := strconv.ParseUint(token, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid resume token %q", token)
+	}
+
+	return id, nil
+}
+
+func (n *StreamNotifier) poll(rw http.ResponseWriter, req *http.Request) {
+	id, err := n.resumeID(req.URL.Query().Get(SinceParam))
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, InvalidRequestErrorCode, err)
+		return
+	}
+
+	timeout, err := pollTimeout(req.URL.Query().Get(TimeoutParam))
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, InvalidRequestErrorCode, err)
+		return
+	}
+
+	filter := topicFilter(req.URL.Query()[TopicParam])
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		events, lastID, published := n.eventsAfter(id, filter)
+		if len(events) > 0 {
+			sendPollResponse(rw, events, events[len(events)-1].id)
+			return
+		}
+
+		// skips the events not matching the filter
+		id = lastID
+
+		select {
+		case <-published:
+		case <-timer.C:
+			sendPollResponse(rw, nil, id)
+			return
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+func sendPollResponse(rw http.ResponseWriter, events []*streamEvent, id uint64) {
+	resp := PollResponse{Events: []json.RawMessage{}, Token: strconv.FormatUint(id, 10)}
+
+	for _, e := range events {
+		resp.Events = append(resp.Events, e.message)
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(rw).Encode(resp); err != nil {
+		logger.Errorf("Unable to send events response, %s", err)
+	}
+}
+
+func pollTimeout(v string) (time.Duration, error) {
+	if v == "" {
+		return defaultPollTimeout, nil
+	}
+
+	seconds, err := strconv.Atoi(v)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("invalid timeout %q", v)
+	}
+
+	timeout := time.Duration(seconds) * time.Second
+	if timeout > maxPollTimeout {
+		timeout = maxPollTimeout
+	}
+
+	return timeout, nil
+}
+
+func (n *StreamNotifier) stream(rw http.ResponseWriter, req *http.Request) {
+	token := req.Header.Get(lastEventIDHeader)
+	if token == "" {
+		token = req.URL.Query().Get(SinceParam)
+	}
+
+	id, err := n.resumeID(token)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, InvalidRequestErrorCode, err)
+		return
+	}
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, command.UnknownStatus,
+			fmt.Errorf("streaming not supported"))
+
+		return
+	}
+
+	filter := topicFilter(req.URL.Query()[TopicParam])
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	logger.Debugf("event stream client connected")
+
+	keepAlive := time.NewTicker(n.keepAlivePeriod)
+	defer keepAlive.Stop()
+
+	for {
+		events, lastID, published := n.eventsAfter(id, filter)
+
+		for _, e := range events {
+			// the topic messages are compact JSON, without line breaks
+			if _, err := fmt.Fprintf(rw, "id: %d\ndata: %s\n\n", e.id, e.message); err != nil {
+				logger.Debugf("event stream client dropped : %s", err)
+				return
+			}
+		}
+
+		if len(events) > 0 {
+			flusher.Flush()
+		}
+
+		id = lastID
+
+		select {
+		case <-published:
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(rw, ": keep-alive\n\n"); err != nil {
+				logger.Debugf("event stream client dropped : %s", err)
+				return
+			}
+
+			flusher.Flush()
+		case <-req.Context().Done():
+			logger.Debugf("event stream client dropped")
+			return
+		}
+	}
+}
+
+// topicFilter is the list of topics requested by the client, all the topics if empty.
+type topicFilter []string
+
+func (f topicFilter) matches(topic string) bool {
+	if len(f) == 0 {
+		return true
+	}
+
+	for _, t := range f {
+		if strings.HasSuffix(t, "*") && strings.HasPrefix(topic, strings.TrimSuffix(t, "*")) || t == topic {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,214 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamNotifier_Notify(t *testing.T) {
+	t.Run("buffer", func(t *testing.T) {
+		n := NewStreamNotifier(EventsPath, WithBufferSize(2))
+
+		require.NoError(t, n.Notify("a", []byte(`{"n":1}`)))
+		require.NoError(t, n.Notify("b", []byte(`{"n":2}`)))
+		require.NoError(t, n.Notify("c", []byte(`{"n":3}`)))
+
+		events, lastID, _ := n.eventsAfter(0, nil)
+		require.Len(t, events, 2)
+		require.Equal(t, uint64(2), events[0].id)
+		require.Equal(t, "c", events[1].topic)
+		require.Equal(t, uint64(3), lastID)
+	})
+
+	t.Run("invalid notification", func(t *testing.T) {
+		n := NewStreamNotifier(EventsPath)
+
+		require.EqualError(t, n.Notify("", []byte(`{}`)), emptyTopicErrMsg)
+		require.EqualError(t, n.Notify("a", nil), emptyMessageErrMsg)
+		require.Error(t, n.Notify("a", []byte("invalid")))
+	})
+}
+
+func TestStreamNotifier_Poll(t *testing.T) {
+	n := NewStreamNotifier(EventsPath)
+	router := newEventsRouter(n)
+
+	poll := func(t *testing.T, query string) *PollResponse {
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, EventsPath+"?"+query, nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		resp := &PollResponse{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), resp))
+
+		return resp
+	}
+
+	t.Run("timeout without events", func(t *testing.T) {
+		resp := poll(t, "timeout=0")
+		require.Empty(t, resp.Events)
+		require.Equal(t, "0", resp.Token)
+	})
+
+	require.NoError(t, n.Notify("didexchange_states", []byte(`{"StateID":"requested"}`)))
+	require.NoError(t, n.Notify("issuecredential_actions", []byte(`{"ProtocolName":"issuecredential"}`)))
+
+	t.Run("resume", func(t *testing.T) {
+		resp := poll(t, "since=0")
+		require.Len(t, resp.Events, 2)
+		require.Equal(t, "2", resp.Token)
+
+		msg := struct {
+			Topic string `json:"topic"`
+		}{}
+		require.NoError(t, json.Unmarshal(resp.Events[0], &msg))
+		require.Equal(t, "didexchange_states", msg.Topic)
+	})
+
+	t.Run("topic filter", func(t *testing.T) {
+		resp := poll(t, "since=0&topic=issuecredential_actions")
+		require.Len(t, resp.Events, 1)
+		require.Equal(t, "2", resp.Token)
+
+		resp = poll(t, "since=0&topic=didexchange_*&topic=outofband_states")
+		require.Len(t, resp.Events, 1)
+		require.Equal(t, "1", resp.Token)
+
+		resp = poll(t, "since=0&topic=outofband_states&timeout=0")
+		require.Empty(t, resp.Events)
+		require.Equal(t, "2", resp.Token)
+	})
+
+	t.Run("wait for event", func(t *testing.T) {
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			require.NoError(t, n.Notify("didexchange_states", []byte(`{"StateID":"completed"}`)))
+		}()
+
+		resp := poll(t, "since=2&timeout=5")
+		require.Len(t, resp.Events, 1)
+		require.Equal(t, "3", resp.Token)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		for _, query := range []string{"since=abc", "timeout=abc", "timeout=-1"} {
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, EventsPath+"?"+query, nil))
+			require.Equal(t, http.StatusBadRequest, rw.Code, query)
+		}
+	})
+}
+
+func TestStreamNotifier_Stream(t *testing.T) {
+	n := NewStreamNotifier(EventsPath, WithKeepAlivePeriod(20*time.Millisecond))
+
+	srv := httptest.NewServer(newEventsRouter(n))
+	defer srv.Close()
+
+	require.NoError(t, n.Notify("didexchange_states", []byte(`{"StateID":"requested"}`)))
+	require.NoError(t, n.Notify("issuecredential_actions", []byte(`{"ProtocolName":"issuecredential"}`)))
+
+	openStream := func(t *testing.T, query, lastEventID string) (*bufio.Reader, func()) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+EventsPath+StreamPathSuffix+"?"+query, nil)
+		require.NoError(t, err)
+
+		if lastEventID != "" {
+			req.Header.Set(lastEventIDHeader, lastEventID)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		return bufio.NewReader(resp.Body), func() {
+			cancel()
+			require.NoError(t, resp.Body.Close())
+		}
+	}
+
+	t.Run("resume with last event ID", func(t *testing.T) {
+		r, closeStream := openStream(t, "", "1")
+		defer closeStream()
+
+		id, data := readEvent(t, r)
+		require.Equal(t, "2", id)
+		require.Contains(t, data, `"topic":"issuecredential_actions"`)
+
+		require.NoError(t, n.Notify("didexchange_states", []byte(`{"StateID":"completed"}`)))
+
+		id, data = readEvent(t, r)
+		require.Equal(t, "3", id)
+		require.Contains(t, data, `"StateID":"completed"`)
+	})
+
+	t.Run("topic filter", func(t *testing.T) {
+		r, closeStream := openStream(t, "since=0&topic=didexchange_states", "")
+		defer closeStream()
+
+		id, _ := readEvent(t, r)
+		require.Equal(t, "1", id)
+
+		id, _ = readEvent(t, r)
+		require.Equal(t, "3", id)
+	})
+
+	t.Run("invalid resume token", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + EventsPath + StreamPathSuffix + "?since=abc") // nolint: noctx
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func newEventsRouter(n *StreamNotifier) *mux.Router {
+	router := mux.NewRouter()
+
+	for _, handler := range n.GetRESTHandlers() {
+		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+	}
+
+	return router
+}
+
+// readEvent reads the next event of the stream, skipping the keep-alive comments.
+func readEvent(t *testing.T, r *bufio.Reader) (string, string) {
+	var id, data string
+
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		case line == "" && data != "":
+			return id, data
+		case line == "" || strings.HasPrefix(line, ":"):
+		default:
+			require.FailNow(t, fmt.Sprintf("unexpected line %q", line))
+		}
+	}
+}
@@ -9,23 +9,218 @@ package webnotifier
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the webhook notifications, formatted as
+	// "sha256=<hex signature>". The signature is computed over the timestamp of the TimestampHeader, a dot
+	// and the body of the notification, so that the subscribers can reject the replayed notifications.
+	SignatureHeader = "Aries-Signature"
+	// TimestampHeader is the header of the Unix time (in seconds) at which the webhook notification was signed.
+	TimestampHeader = "Aries-Timestamp"
+
+	// DeadLetterStoreName is the name of the store of the webhook notifications which could not be delivered.
+	DeadLetterStoreName = "webhook_deadletter"
+
+	signaturePrefix = "sha256="
+
+	defaultDeliveryQueueSize = 1000
 )
 
 // HTTPNotifier is a webhook dispatcher capable of notifying multiple subscribers via HTTP.
 type HTTPNotifier struct {
-	urls []string
+	urls           []string
+	urlsLock       sync.RWMutex
+	topicURLs      map[string][]string
+	signingKey     []byte
+	maxRetries     uint64
+	initialBackoff time.Duration
+	deadLetters    storage.Store
+	clock          clock.Clock
+
+	// queue holds the deliveries posted by the worker when the retries are enabled.
+	queue      chan *delivery
+	queueLock  sync.RWMutex
+	workerOnce sync.Once
+	closed     bool
+	done       chan struct{}
+	stopped    chan struct{}
+}
+
+// delivery is a webhook notification queued for the worker, with its retry schedule.
+type delivery struct {
+	url     string
+	topic   string
+	message []byte
+	backoff backoff.BackOff
+	// due is when the failed delivery is retried.
+	due time.Time
+}
+
+// HTTPNotifierOpt represents an HTTPNotifier option.
+type HTTPNotifierOpt func(n *HTTPNotifier)
+
+// WithSigningKey is an option for signing the webhook notifications with HMAC-SHA256 using the given secret key,
+// the signature being sent in the SignatureHeader.
+func WithSigningKey(key []byte) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.signingKey = key
+	}
+}
+
+// WithRetries is an option for retrying the failed webhook notifications up to maxRetries times, with an exponential
+// backoff starting at initialBackoff. Notify then queues the notifications, which are delivered and retried by a worker
+// until the notifier is closed.
+func WithRetries(maxRetries uint64, initialBackoff time.Duration) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.maxRetries = maxRetries
+		n.initialBackoff = initialBackoff
+	}
+}
+
+// WithDeadLetterStore is an option for persisting the webhook notifications which could not be delivered in the
+// given store, so that they can be redelivered later on with RedeliverDeadLetters.
+func WithDeadLetterStore(store storage.Store) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.deadLetters = store
+	}
+}
+
+// WithDeliveryQueueSize is an option for the number of webhook notifications queued for delivery when the retries
+// are enabled, 1000 if not set. The notifications which don't fit in the queue are not delivered, Notify returns an
+// error and stores them in the dead-letter store.
+func WithDeliveryQueueSize(size int) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.queue = make(chan *delivery, size)
+	}
+}
+
+// WithClock is an option for the clock timestamping the signatures and the dead letters, the clock of the system if
+// not set.
+func WithClock(c clock.Clock) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.clock = c
+	}
+}
+
+// WithTopicURLs is an option for routing the notifications of the topic to the given webhook URLs instead of the
+// default ones.
+func WithTopicURLs(topic string, webhookURLs ...string) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.topicURLs[topic] = webhookURLs
+	}
 }
 
 // NewHTTPNotifier returns a new instance of an HTTPNotifier.
-func NewHTTPNotifier(webhookURLs []string) *HTTPNotifier {
-	return &HTTPNotifier{urls: webhookURLs}
+func NewHTTPNotifier(webhookURLs []string, opts ...HTTPNotifierOpt) *HTTPNotifier {
+	n := &HTTPNotifier{
+		urls:      webhookURLs,
+		topicURLs: make(map[string][]string),
+		queue:     make(chan *delivery, defaultDeliveryQueueSize),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	n.clock = clock.OrSystem(n.clock)
+
+	return n
+}
+
+// SetURLs replaces the default webhook URLs, e.g. when the configuration of the agent is reloaded. The topics routed
+// with WithTopicURLs are not affected.
+func (n *HTTPNotifier) SetURLs(webhookURLs []string) {
+	n.urlsLock.Lock()
+	defer n.urlsLock.Unlock()
+
+	n.urls = webhookURLs
+}
+
+// URLs returns the webhook URLs, the default ones followed by those of the topics routed with WithTopicURLs.
+func (n *HTTPNotifier) URLs() []string {
+	n.urlsLock.RLock()
+	urls := append([]string(nil), n.urls...)
+	n.urlsLock.RUnlock()
+
+	for _, topicURLs := range n.topicURLs {
+		for _, u := range topicURLs {
+			if !contains(urls, u) {
+				urls = append(urls, u)
+			}
+		}
+	}
+
+	return urls
+}
+
+// Sink returns the sink of a durable subscription of an EventBus posting the events to the webhook URL, the events
+// of the topics not routed to the URL being skipped. The events are signed if a signing key is set, the bus
+// retrying the failed deliveries instead of the notifier.
+func (n *HTTPNotifier) Sink(webhookURL string) Sink {
+	return SinkFunc(func(e *Event) error {
+		if !contains(n.topicDestinations(e.Topic), webhookURL) {
+			return nil
+		}
+
+		return n.post(webhookURL, e.Message)
+	})
+}
+
+// topicDestinations returns the webhook URLs of the topic.
+func (n *HTTPNotifier) topicDestinations(topic string) []string {
+	if urls, ok := n.topicURLs[topic]; ok {
+		return urls
+	}
+
+	n.urlsLock.RLock()
+	defer n.urlsLock.RUnlock()
+
+	return n.urls
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
 }
 
-// Notify sends the given message to all of the urls.
+// deadLetter is a webhook notification which could not be delivered.
+type deadLetter struct {
+	URL     string          `json:"url"`
+	Topic   string          `json:"topic"`
+	Message json.RawMessage `json:"message"`
+	Error   string          `json:"error"`
+	Time    time.Time       `json:"time"`
+}
+
+// Notify sends the given message to all of the urls of the topic.
 // Topic is appended to the end of the webhook (subscriber) URL. E.g. localhost:8080/topic
+// When the retries are enabled the message is queued for the worker and Notify only returns the errors of the
+// queuing, the failed deliveries being retried in the background then stored in the dead-letter store.
 // If multiple errors are encountered, then the first one is returned.
 func (n *HTTPNotifier) Notify(topic string, message []byte) error {
 	if topic == "" {
@@ -43,22 +238,297 @@ func (n *HTTPNotifier) Notify(topic string, message []byte) error {
 
 	var allErrs error
 
-	for _, webhookURL := range n.urls {
-		err := notifyWH(webhookURL, topicMsg)
+	for _, webhookURL := range n.topicDestinations(topic) {
+		if n.maxRetries > 0 {
+			err = n.enqueue(&delivery{url: webhookURL, topic: topic, message: topicMsg, backoff: n.newBackoff()})
+		} else {
+			err = n.post(webhookURL, topicMsg)
+		}
+
+		if err != nil {
+			n.storeDeadLetter(webhookURL, topic, topicMsg, err)
+		}
+
 		allErrs = appendError(allErrs, err)
 	}
 
 	return allErrs
 }
 
+// Close stops the worker delivering the queued notifications, the notifications which are not delivered yet are
+// stored in the dead-letter store.
+func (n *HTTPNotifier) Close() {
+	n.queueLock.Lock()
+
+	if n.closed {
+		n.queueLock.Unlock()
+
+		return
+	}
+
+	n.closed = true
+	close(n.done)
+	n.queueLock.Unlock()
+
+	// the worker is started, if not already, to drain the queue
+	n.workerOnce.Do(func() { go n.work() })
+	<-n.stopped
+}
+
+// enqueue queues the delivery for the worker, starting it on the first delivery.
+func (n *HTTPNotifier) enqueue(d *delivery) error {
+	n.queueLock.RLock()
+	defer n.queueLock.RUnlock()
+
+	if n.closed {
+		return fmt.Errorf("webhook notifier closed, the notification to %s is not delivered", d.url)
+	}
+
+	n.workerOnce.Do(func() { go n.work() })
+
+	select {
+	case n.queue <- d:
+		return nil
+	default:
+		return fmt.Errorf("webhook delivery queue full, the notification to %s is not delivered", d.url)
+	}
+}
+
+// work delivers the queued notifications, retrying the failed deliveries when they're due without blocking the
+// other deliveries, until the notifier is closed.
+func (n *HTTPNotifier) work() {
+	defer close(n.stopped)
+
+	var retries []*delivery
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case d := <-n.queue:
+			retries = n.attempt(d, retries)
+		case <-timer.C:
+			var due []*delivery
+
+			due, retries = dueDeliveries(retries, time.Now())
+
+			for _, d := range due {
+				retries = n.attempt(d, retries)
+			}
+		case <-n.done:
+			n.abandon(retries)
+
+			return
+		}
+
+		resetTimer(timer, retries)
+	}
+}
+
+// attempt posts the delivery, returning the retries with the delivery if it failed and can be retried, or storing it
+// in the dead-letter store.
+func (n *HTTPNotifier) attempt(d *delivery, retries []*delivery) []*delivery {
+	err := n.post(d.url, d.message)
+	if err == nil {
+		return retries
+	}
+
+	var permanent *backoff.PermanentError
+
+	next := d.backoff.NextBackOff()
+	if errors.As(err, &permanent) || next == backoff.Stop {
+		logger.Errorf("failed to notify %s : %s", d.url, err)
+		n.storeDeadLetter(d.url, d.topic, d.message, err)
+
+		return retries
+	}
+
+	logger.Warnf("failed to notify %s, retrying in %s : %s", d.url, next, err)
+
+	d.due = time.Now().Add(next)
+
+	return append(retries, d)
+}
+
+// abandon stores the retries and the queued deliveries in the dead-letter store.
+func (n *HTTPNotifier) abandon(retries []*delivery) {
+	for {
+		select {
+		case d := <-n.queue:
+			retries = append(retries, d)
+		default:
+			for _, d := range retries {
+				n.storeDeadLetter(d.url, d.topic, d.message, errors.New("webhook notifier closed before the delivery"))
+			}
+
+			return
+		}
+	}
+}
+
+// dueDeliveries splits the retries in the deliveries which are due at the time and the others.
+func dueDeliveries(retries []*delivery, now time.Time) ([]*delivery, []*delivery) {
+	var due, later []*delivery
+
+	for _, d := range retries {
+		if d.due.After(now) {
+			later = append(later, d)
+		} else {
+			due = append(due, d)
+		}
+	}
+
+	return due, later
+}
+
+// resetTimer sets the timer to fire when the earliest retry is due, it's stopped if there are no retries.
+func resetTimer(timer *time.Timer, retries []*delivery) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+
+	if len(retries) == 0 {
+		return
+	}
+
+	earliest := retries[0].due
+
+	for _, d := range retries[1:] {
+		if d.due.Before(earliest) {
+			earliest = d.due
+		}
+	}
+
+	timer.Reset(time.Until(earliest))
+}
+
+// RedeliverDeadLetters redelivers the webhook notifications persisted in the dead-letter store, removing the ones
+// which are delivered. If multiple errors are encountered, then the first one is returned.
+func (n *HTTPNotifier) RedeliverDeadLetters() error {
+	if n.deadLetters == nil {
+		return fmt.Errorf("no dead-letter store configured")
+	}
+
+	itr := n.deadLetters.Iterator("", storage.EndKeySuffix)
+	defer itr.Release()
+
+	var (
+		allErrs   error
+		delivered []storage.Operation
+	)
+
+	for itr.Next() {
+		var letter deadLetter
+
+		err := json.Unmarshal(itr.Value(), &letter)
+		if err != nil {
+			allErrs = appendError(allErrs, fmt.Errorf("failed to unmarshal dead letter %s: %w", itr.Key(), err))
+
+			continue
+		}
+
+		err = n.post(letter.URL, letter.Message)
+		if err != nil {
+			allErrs = appendError(allErrs, err)
+
+			continue
+		}
+
+		delivered = append(delivered, storage.Operation{Key: string(itr.Key())})
+	}
+
+	if err := itr.Error(); err != nil {
+		return fmt.Errorf("failed to iterate dead letters: %w", err)
+	}
+
+	if len(delivered) > 0 {
+		if err := n.deadLetters.Batch(delivered); err != nil {
+			return fmt.Errorf("failed to delete redelivered dead letters: %w", err)
+		}
+	}
+
+	return allErrs
+}
+
+// newBackoff returns the exponential backoff of the retries of a delivery.
+func (n *HTTPNotifier) newBackoff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = n.initialBackoff
+	b.MaxElapsedTime = 0
+	b.Reset()
+
+	return backoff.WithMaxRetries(b, n.maxRetries)
+}
+
+func (n *HTTPNotifier) post(destination string, message []byte) error {
+	header := make(http.Header)
+
+	if len(n.signingKey) > 0 {
+		timestamp := strconv.FormatInt(n.clock.Now().Unix(), 10)
+
+		header.Set(TimestampHeader, timestamp)
+		header.Set(SignatureHeader, signaturePrefix+Sign(n.signingKey, timestamp, message))
+	}
+
+	return postWH(destination, message, header)
+}
+
+func (n *HTTPNotifier) storeDeadLetter(destination, topic string, message []byte, deliveryErr error) {
+	if n.deadLetters == nil {
+		return
+	}
+
+	letter, err := json.Marshal(&deadLetter{
+		URL:     destination,
+		Topic:   topic,
+		Message: message,
+		Error:   deliveryErr.Error(),
+		Time:    n.clock.Now().UTC(),
+	})
+	if err != nil {
+		logger.Errorf("failed to marshal dead letter for %s : %s", destination, err)
+
+		return
+	}
+
+	err = n.deadLetters.Put(uuid.New().String(), letter)
+	if err != nil {
+		logger.Errorf("failed to store dead letter for %s : %s", destination, err)
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the webhook notification sent at the timestamp, as set in
+// the SignatureHeader (without its "sha256=" prefix). Subscribers use it to verify the notifications they receive.
+func Sign(key []byte, timestamp string, message []byte) string {
+	mac := hmac.New(sha256.New, key)
+
+	// hash.Hash never returns an error on Write
+	_, _ = mac.Write([]byte(timestamp + "."))
+	_, _ = mac.Write(message)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 func notifyWH(destination string, message []byte) error {
+	return postWH(destination, message, nil)
+}
+
+func postWH(destination string, message []byte, header http.Header) error {
 	ctx, cancel := context.WithTimeout(context.Background(), notificationSendTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, destination,
 		bytes.NewBuffer(message))
 	if err != nil {
-		return fmt.Errorf("failed to create new http post request for %s: %s", destination, err)
+		return backoff.Permanent(fmt.Errorf("failed to create new http post request for %s: %s", destination, err))
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
 	}
 
 	resp, err := http.DefaultClient.Do(req)
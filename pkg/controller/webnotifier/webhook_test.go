@@ -11,6 +11,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -18,6 +22,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/internal/test/transportutil"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
 )
 
 const (
@@ -225,6 +232,255 @@ func TestWebhookNotificationClient500Response(t *testing.T) {
 	require.Contains(t, err.Error(), "500 Internal Server Error", err.Error())
 }
 
+func TestNotifySigned(t *testing.T) {
+	key := []byte("secret")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		signature := req.Header.Get(SignatureHeader)
+		require.True(t, strings.HasPrefix(signature, signaturePrefix))
+		require.Equal(t, Sign(key, req.Header.Get(TimestampHeader), body),
+			strings.TrimPrefix(signature, signaturePrefix))
+		require.NotEqual(t, Sign([]byte("other"), req.Header.Get(TimestampHeader), body),
+			strings.TrimPrefix(signature, signaturePrefix))
+	}))
+	defer srv.Close()
+
+	err := NewHTTPNotifier([]string{srv.URL}, WithSigningKey(key)).Notify(topic, getTestBasicMessageJSON())
+	require.NoError(t, err)
+
+	t.Run("timestamped with the clock", func(t *testing.T) {
+		now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			require.Equal(t, strconv.FormatInt(now.Unix(), 10), req.Header.Get(TimestampHeader))
+		}))
+		defer srv.Close()
+
+		err := NewHTTPNotifier([]string{srv.URL}, WithSigningKey(key), WithClock(mockclock.New(now))).
+			Notify(topic, getTestBasicMessageJSON())
+		require.NoError(t, err)
+	})
+}
+
+func TestNotifyWithRetries(t *testing.T) {
+	t.Run("delivered after retries", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				resp.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer srv.Close()
+
+		deadLetters, err := mem.NewProvider().OpenStore(DeadLetterStoreName)
+		require.NoError(t, err)
+
+		testNotifier := NewHTTPNotifier([]string{srv.URL}, WithRetries(3, time.Millisecond),
+			WithDeadLetterStore(deadLetters))
+		defer testNotifier.Close()
+
+		err = testNotifier.Notify(topic, getTestBasicMessageJSON())
+		require.NoError(t, err)
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 3 }, time.Second, time.Millisecond)
+		require.Empty(t, deadLetterKeys(t, deadLetters))
+	})
+
+	t.Run("persisted as dead letter and redelivered", func(t *testing.T) {
+		var (
+			calls     int32
+			available int32
+		)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&calls, 1)
+
+			if atomic.LoadInt32(&available) == 0 {
+				resp.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer srv.Close()
+
+		deadLetters, err := mem.NewProvider().OpenStore(DeadLetterStoreName)
+		require.NoError(t, err)
+
+		now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+		testNotifier := NewHTTPNotifier([]string{srv.URL}, WithRetries(2, time.Millisecond),
+			WithDeadLetterStore(deadLetters), WithClock(mockclock.New(now)))
+		defer testNotifier.Close()
+
+		err = testNotifier.Notify(topic, getTestBasicMessageJSON())
+		require.NoError(t, err)
+		require.Eventually(t, func() bool { return len(deadLetterKeys(t, deadLetters)) == 1 },
+			time.Second, time.Millisecond)
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+
+		keys := deadLetterKeys(t, deadLetters)
+
+		letterBytes, err := deadLetters.Get(keys[0])
+		require.NoError(t, err)
+
+		var letter deadLetter
+		require.NoError(t, json.Unmarshal(letterBytes, &letter))
+		require.Equal(t, srv.URL, letter.URL)
+		require.Equal(t, topic, letter.Topic)
+		require.Contains(t, letter.Error, "503 Service Unavailable")
+		require.Equal(t, now, letter.Time)
+
+		atomic.StoreInt32(&available, 1)
+
+		require.NoError(t, testNotifier.RedeliverDeadLetters())
+		require.Empty(t, deadLetterKeys(t, deadLetters))
+	})
+
+	t.Run("invalid URL not retried", func(t *testing.T) {
+		deadLetters, err := mem.NewProvider().OpenStore(DeadLetterStoreName)
+		require.NoError(t, err)
+
+		testNotifier := NewHTTPNotifier([]string{"%"}, WithRetries(3, time.Hour), WithDeadLetterStore(deadLetters))
+		defer testNotifier.Close()
+
+		require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+		require.Eventually(t, func() bool { return len(deadLetterKeys(t, deadLetters)) == 1 },
+			time.Second, time.Millisecond)
+
+		letterBytes, err := deadLetters.Get(deadLetterKeys(t, deadLetters)[0])
+		require.NoError(t, err)
+		require.Contains(t, string(letterBytes), `invalid URL escape`)
+	})
+
+	t.Run("pending retries persisted as dead letters on close", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			resp.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		deadLetters, err := mem.NewProvider().OpenStore(DeadLetterStoreName)
+		require.NoError(t, err)
+
+		testNotifier := NewHTTPNotifier([]string{srv.URL}, WithRetries(3, time.Hour),
+			WithDeadLetterStore(deadLetters))
+
+		// the notification is not delayed by the retries
+		require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+		require.Empty(t, deadLetterKeys(t, deadLetters))
+
+		testNotifier.Close()
+		testNotifier.Close()
+
+		require.Len(t, deadLetterKeys(t, deadLetters), 1)
+
+		err = testNotifier.Notify(topic, getTestBasicMessageJSON())
+		require.Contains(t, err.Error(), "webhook notifier closed")
+		require.Len(t, deadLetterKeys(t, deadLetters), 2)
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		deadLetters, err := mem.NewProvider().OpenStore(DeadLetterStoreName)
+		require.NoError(t, err)
+
+		testNotifier := NewHTTPNotifier([]string{localhost8080URL}, WithRetries(3, time.Hour),
+			WithDeadLetterStore(deadLetters), WithDeliveryQueueSize(0))
+
+		// no worker receives the deliveries
+		testNotifier.workerOnce.Do(func() {})
+
+		err = testNotifier.Notify(topic, getTestBasicMessageJSON())
+		require.Contains(t, err.Error(), "webhook delivery queue full")
+		require.Len(t, deadLetterKeys(t, deadLetters), 1)
+	})
+}
+
+func TestRedeliverDeadLetters(t *testing.T) {
+	t.Run("no dead-letter store", func(t *testing.T) {
+		err := NewHTTPNotifier([]string{localhost8080URL}).RedeliverDeadLetters()
+		require.EqualError(t, err, "no dead-letter store configured")
+	})
+
+	t.Run("invalid dead letter", func(t *testing.T) {
+		deadLetters, err := mem.NewProvider().OpenStore(DeadLetterStoreName)
+		require.NoError(t, err)
+		require.NoError(t, deadLetters.Put("letter", []byte("{")))
+
+		err = NewHTTPNotifier(nil, WithDeadLetterStore(deadLetters)).RedeliverDeadLetters()
+		require.Contains(t, err.Error(), "failed to unmarshal dead letter letter")
+		require.Len(t, deadLetterKeys(t, deadLetters), 1)
+	})
+}
+
+func TestNotifyTopicURLs(t *testing.T) {
+	var defaultCalls, topicCalls int32
+
+	defaultSrv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		atomic.AddInt32(&defaultCalls, 1)
+	}))
+	defer defaultSrv.Close()
+
+	topicSrv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		atomic.AddInt32(&topicCalls, 1)
+	}))
+	defer topicSrv.Close()
+
+	testNotifier := NewHTTPNotifier([]string{defaultSrv.URL}, WithTopicURLs(topic, topicSrv.URL))
+
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+	require.EqualValues(t, 0, atomic.LoadInt32(&defaultCalls))
+	require.EqualValues(t, 1, atomic.LoadInt32(&topicCalls))
+
+	require.NoError(t, testNotifier.Notify("other", getTestBasicMessageJSON()))
+	require.EqualValues(t, 1, atomic.LoadInt32(&defaultCalls))
+	require.EqualValues(t, 1, atomic.LoadInt32(&topicCalls))
+}
+
+func TestNotifySetURLs(t *testing.T) {
+	var oldCalls, newCalls int32
+
+	oldSrv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		atomic.AddInt32(&oldCalls, 1)
+	}))
+	defer oldSrv.Close()
+
+	newSrv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		atomic.AddInt32(&newCalls, 1)
+	}))
+	defer newSrv.Close()
+
+	testNotifier := New(WSPath, "", []string{oldSrv.URL})
+
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+
+	testNotifier.SetWebhookURLs([]string{newSrv.URL})
+
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+	require.EqualValues(t, 1, atomic.LoadInt32(&oldCalls))
+	require.EqualValues(t, 1, atomic.LoadInt32(&newCalls))
+}
+
+func deadLetterKeys(t *testing.T, store storage.Store) []string {
+	t.Helper()
+
+	itr := store.Iterator("", storage.EndKeySuffix)
+	defer itr.Release()
+
+	var keys []string
+
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+
+	require.NoError(t, itr.Error())
+
+	return keys
+}
+
 func getTestBasicMessageJSON() []byte {
 	return []byte(`
    {
@@ -9,6 +9,7 @@ package webnotifier
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,29 +24,75 @@ const (
 	emptyTopicErrMsg        = "cannot notify with an empty topic"
 	emptyMessageErrMsg      = "cannot notify with an empty message"
 	failedToCreateErrMsg    = "failed to create topic message : %w"
+
+	// webhookSubscriptionPrefix prefixes the webhook URLs to name their subscriptions of the event bus.
+	webhookSubscriptionPrefix = "webhook_"
 )
 
 var logger = log.New("aries-framework/webnotifier")
 
-// WebNotifier is a dispatcher capable of notifying multiple subscribers via HTTP Webhooks and WebSockets.
+// WebNotifier is a dispatcher capable of notifying multiple subscribers via HTTP Webhooks, WebSockets and, if
+// enabled, Server-Sent Events and long polling.
 type WebNotifier struct {
+	webhook   *HTTPNotifier
+	bus       *EventBus
 	notifiers []command.Notifier
 	handlers  []rest.Handler
 }
 
-// New returns a new instance of a WebNotifier.
-func New(wsPath string, webhookURLs []string) *WebNotifier {
-	webhook := NewHTTPNotifier(webhookURLs)
+// New returns a new instance of a WebNotifier, the webhook options configuring the delivery of the notifications
+// to the webhook URLs. The events are served on eventsPath by a StreamNotifier, unless eventsPath is empty.
+func New(wsPath, eventsPath string, webhookURLs []string, webhookOpts ...HTTPNotifierOpt) *WebNotifier {
+	webhook := NewHTTPNotifier(webhookURLs, webhookOpts...)
 	ws := NewWSNotifier(wsPath)
 
 	n := WebNotifier{
+		webhook:   webhook,
 		notifiers: []command.Notifier{webhook, ws},
 		handlers:  ws.GetRESTHandlers(),
 	}
 
+	if eventsPath != "" {
+		stream := NewStreamNotifier(eventsPath)
+
+		n.notifiers = append(n.notifiers, stream)
+		n.handlers = append(n.handlers, stream.GetRESTHandlers()...)
+	}
+
 	return &n
 }
 
+// NewDurable returns a new instance of a WebNotifier appending the notifications to the event bus, so that the
+// webhooks and the event stream clients don't miss the events published while they, or the agent, were down:
+//   - each webhook URL is a durable subscription of the bus, the notifications failing to be delivered being retried
+//     by the bus until they're delivered (the retries and the dead-letter store of the webhook options are not used)
+//   - the events are served on eventsPath from the bus, unless eventsPath is empty, the resume tokens being the
+//     offsets of the events in the bus.
+//
+// The WebSocket clients are notified as with New.
+func NewDurable(wsPath, eventsPath string, bus *EventBus, webhookURLs []string,
+	webhookOpts ...HTTPNotifierOpt) (*WebNotifier, error) {
+	webhook := NewHTTPNotifier(webhookURLs, webhookOpts...)
+	ws := NewWSNotifier(wsPath)
+
+	n := WebNotifier{
+		webhook:   webhook,
+		bus:       bus,
+		notifiers: []command.Notifier{bus, ws},
+		handlers:  ws.GetRESTHandlers(),
+	}
+
+	if eventsPath != "" {
+		n.handlers = append(n.handlers, NewStreamNotifier(eventsPath, WithEventBus(bus)).GetRESTHandlers()...)
+	}
+
+	if err := n.subscribeWebhooks(); err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
 // Notify sends the given message to all of the subscribers.
 // If multiple errors are encountered, then the first one is returned.
 func (n *WebNotifier) Notify(topic string, message []byte) error {
@@ -59,6 +106,54 @@ func (n *WebNotifier) Notify(topic string, message []byte) error {
 	return allErrs
 }
 
+// SetWebhookURLs replaces the webhook URLs the notifications are sent to.
+func (n *WebNotifier) SetWebhookURLs(webhookURLs []string) {
+	n.webhook.SetURLs(webhookURLs)
+
+	if n.bus == nil {
+		return
+	}
+
+	if err := n.subscribeWebhooks(); err != nil {
+		logger.Errorf("failed to subscribe the webhooks to the event bus : %s", err)
+	}
+}
+
+// subscribeWebhooks subscribes the webhook URLs to the event bus, and unsubscribes the URLs which were removed.
+func (n *WebNotifier) subscribeWebhooks() error {
+	urls := n.webhook.URLs()
+
+	for _, name := range n.bus.Subscriptions() {
+		if strings.HasPrefix(name, webhookSubscriptionPrefix) &&
+			!contains(urls, strings.TrimPrefix(name, webhookSubscriptionPrefix)) {
+			n.bus.Unsubscribe(name)
+		}
+	}
+
+	subscribed := n.bus.Subscriptions()
+
+	for _, u := range urls {
+		if contains(subscribed, webhookSubscriptionPrefix+u) {
+			continue
+		}
+
+		if err := n.bus.Subscribe(webhookSubscriptionPrefix+u, n.webhook.Sink(u)); err != nil {
+			return fmt.Errorf("failed to subscribe webhook %s: %w", u, err)
+		}
+	}
+
+	return nil
+}
+
+// Close stops the delivery of the events of the event bus, and of the queued notifications, to the webhooks.
+func (n *WebNotifier) Close() {
+	if n.bus != nil {
+		n.bus.Close()
+	}
+
+	n.webhook.Close()
+}
+
 // GetRESTHandlers returns all REST handlers provided by notifier.
 func (n *WebNotifier) GetRESTHandlers() []rest.Handler {
 	return n.handlers
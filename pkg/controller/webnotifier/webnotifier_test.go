@@ -14,22 +14,29 @@ import (
 
 func TestNew(t *testing.T) {
 	t.Run("New WebNotifier (populated)", func(t *testing.T) {
-		n := New("/", []string{"http://localhost:8080"})
+		n := New("/", "", []string{"http://localhost:8080"})
 		require.NotNil(t, n)
 		require.Equal(t, 2, len(n.notifiers))
 		require.Equal(t, 1, len(n.handlers))
 	})
 
 	t.Run("New WebNotifier (nil)", func(t *testing.T) {
-		n := New("", nil)
+		n := New("", "", nil)
 		require.NotNil(t, n)
 		require.Equal(t, 2, len(n.notifiers))
 		require.Equal(t, 1, len(n.handlers))
 	})
+
+	t.Run("New WebNotifier (events)", func(t *testing.T) {
+		n := New("/ws", "/events", nil)
+		require.NotNil(t, n)
+		require.Equal(t, 3, len(n.notifiers))
+		require.Equal(t, 3, len(n.handlers))
+	})
 }
 
 func TestNotify(t *testing.T) {
-	n := New("/", []string{"http://localhost:8080"})
+	n := New("/", "", []string{"http://localhost:8080"})
 	require.NotNil(t, n)
 
 	err := n.Notify("example", []byte("payload"))
@@ -37,7 +44,7 @@ func TestNotify(t *testing.T) {
 }
 
 func TestGetHandlers(t *testing.T) {
-	n := New("/", []string{"http://localhost:8080"})
+	n := New("/", "", []string{"http://localhost:8080"})
 	require.NotNil(t, n)
 
 	handlers := n.GetRESTHandlers()
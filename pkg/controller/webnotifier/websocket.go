@@ -18,6 +18,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 )
 
+// WSPath is the path of the websocket notifications of the controller.
+const WSPath = "/ws"
+
 // WSNotifier is a dispatcher capable of notifying multiple subscribers via WebSocket.
 type WSNotifier struct {
 	conns     []*websocket.Conn
@@ -8,6 +8,7 @@ package webkms
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
@@ -22,6 +23,7 @@ import (
 	"github.com/bluele/gcache"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	webkmsimpl "github.com/hyperledger/aries-framework-go/pkg/kms/webkms"
 )
@@ -85,6 +87,7 @@ type RemoteCrypto struct {
 	marshalFunc   marshalFunc
 	unmarshalFunc unmarshalFunc
 	opts          *webkmsimpl.Opts
+	retryPolicy   retry.Policy
 }
 
 const (
@@ -113,6 +116,7 @@ func New(keystoreURL string, client *http.Client, opts ...webkmsimpl.Opt) *Remot
 		marshalFunc:   json.Marshal,
 		unmarshalFunc: json.Unmarshal,
 		opts:          rOpts,
+		retryPolicy:   rOpts.GetRetryPolicy(),
 	}
 }
 
@@ -120,9 +124,45 @@ func (r *RemoteCrypto) postHTTPRequest(destination string, mReq []byte) (*http.R
 	return r.doHTTPRequest(http.MethodPost, destination, mReq)
 }
 
+// doHTTPRequest sends the request, retrying it on the network errors and on the statuses of the transient server
+// failures. If the server keeps failing, the response of the last attempt is returned.
 func (r *RemoteCrypto) doHTTPRequest(method, destination string, mReq []byte) (*http.Response, error) {
 	start := time.Now()
 
+	var resp *http.Response
+
+	err := retry.Do(context.Background(), r.retryPolicy, func() error {
+		if resp != nil {
+			closeResponseBody(resp.Body, logger, "retried "+method)
+		}
+
+		httpReq, err := r.newHTTPRequest(method, destination, mReq)
+		if err != nil {
+			return retry.Permanent(err)
+		}
+
+		resp, err = r.httpClient.Do(httpReq)
+		if err != nil {
+			return err
+		}
+
+		if retry.RetryableStatus(resp.StatusCode) {
+			return fmt.Errorf("HTTP %s %s: %s", method, destination, resp.Status)
+		}
+
+		return nil
+	})
+
+	logger.Infof("  HTTP %s %s call duration: %s", method, destination, time.Since(start))
+
+	if resp != nil {
+		return resp, nil
+	}
+
+	return nil, err
+}
+
+func (r *RemoteCrypto) newHTTPRequest(method, destination string, mReq []byte) (*http.Request, error) {
 	httpReq, err := http.NewRequest(method, destination, bytes.NewBuffer(mReq))
 	if err != nil {
 		return nil, fmt.Errorf("build request error: %w", err)
@@ -143,11 +183,7 @@ func (r *RemoteCrypto) doHTTPRequest(method, destination string, mReq []byte) (*
 		}
 	}
 
-	resp, err := r.httpClient.Do(httpReq)
-
-	logger.Infof("  HTTP %s %s call duration: %s", method, destination, time.Since(start))
-
-	return resp, err
+	return httpReq, nil
 }
 
 // Encrypt will remotely encrypt msg and aad using a matching AEAD primitive in a remote key handle at keyURL of
@@ -18,6 +18,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -33,6 +34,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/chacha20poly1305"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
@@ -1031,6 +1033,61 @@ func unwrapKeyPostHandle(w http.ResponseWriter, reqBody []byte, senderKH, recKH
 	return nil
 }
 
+func TestDoHTTPRequestRetry(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		if r.URL.Path == "/bad" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	retryPolicy := retry.DefaultPolicy()
+	retryPolicy.InitialInterval = time.Millisecond
+
+	remote := New(server.URL, &http.Client{}, webkmsimpl.WithRetryPolicy(retryPolicy))
+
+	resp, err := remote.postHTTPRequest(server.URL, []byte("{}"))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 2, requests)
+	require.NoError(t, resp.Body.Close())
+
+	t.Run("client error isn't retried", func(t *testing.T) {
+		requests = 1
+
+		resp, err := remote.postHTTPRequest(server.URL+"/bad", []byte("{}"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Equal(t, 2, requests)
+		require.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("retries disabled", func(t *testing.T) {
+		requests = 0
+
+		resp, err := New(server.URL, &http.Client{}, webkmsimpl.WithRetryPolicy(retry.NoRetry())).
+			postHTTPRequest(server.URL, []byte("{}"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, 1, requests)
+		require.NoError(t, resp.Body.Close())
+	})
+}
+
 func TestCloseResponseBody(t *testing.T) {
 	closeResponseBody(&errFailingCloser{}, logger, "testing close fail should log: errFailingCloser always fails")
 }
@@ -6,15 +6,83 @@ SPDX-License-Identifier: Apache-2.0
 
 package model
 
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Possible values of the problem report 'who_retries' field.
+const (
+	// WhoRetriesYou means the recipient of the problem report should retry.
+	WhoRetriesYou = "you"
+	// WhoRetriesMe means the sender of the problem report will retry.
+	WhoRetriesMe = "me"
+	// WhoRetriesBoth means both parties may retry.
+	WhoRetriesBoth = "both"
+	// WhoRetriesNone means the operation should not be retried.
+	WhoRetriesNone = "none"
+)
+
+// Possible values of the problem report 'impact' field.
+const (
+	// ImpactMessage means only the message the problem report refers to failed.
+	ImpactMessage = "message"
+	// ImpactThread means the whole thread (protocol instance) failed.
+	ImpactThread = "thread"
+	// ImpactConnection means the connection itself is affected.
+	ImpactConnection = "connection"
+)
+
+// Descriptor codes of the problem reports sent by the protocols of the framework, named after the problem codes
+// of https://github.com/hyperledger/aries-rfcs/tree/master/features/0035-report-problem.
+// See the problemcode package for their mapping to the errors of the framework.
+const (
+	// CodeInternal means the sender failed for a reason of its own.
+	CodeInternal = "internal"
+	// CodeRejected means the sender (e.g. its user) rejected the message.
+	CodeRejected = "rejected"
+	// CodeTrust means the sender does not trust the message, e.g. a credential is expired.
+	CodeTrust = "trust"
+	// CodeTrustCrypto means a cryptographic check of the message failed, e.g. a proof is invalid.
+	CodeTrustCrypto = "trust.crypto"
+	// CodeDID means a DID of the message could not be resolved.
+	CodeDID = "did"
+	// CodeMessage means the message is malformed, e.g. a credential does not conform to its schema.
+	CodeMessage = "msg"
+	// CodeRequestTime means the message was not handled in time.
+	CodeRequestTime = "req.time"
+)
+
 // ProblemReport problem report definition
-// TODO: need to provide full ProblemReport structure https://github.com/hyperledger/aries-framework-go/issues/912
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0035-report-problem#the-problem-report-message-type
 type ProblemReport struct {
 	Type        string `json:"@type"`
 	ID          string `json:"@id"`
 	Description Code   `json:"description"`
+	// ProblemItems are the parameters of the problem, as key-value pairs.
+	ProblemItems []map[string]string `json:"problem_items,omitempty"`
+	// WhoRetries is one of the WhoRetries constants.
+	WhoRetries string   `json:"who_retries,omitempty"`
+	FixHint    *FixHint `json:"fix_hint,omitempty"`
+	// Impact is one of the Impact constants.
+	Impact string `json:"impact,omitempty"`
+	// Where the problem occurred, eg. "you - agency".
+	Where string `json:"where,omitempty"`
+	// NoticedTime is the time the problem was noticed, in RFC3339 format.
+	NoticedTime string `json:"noticed_time,omitempty"`
+	// TrackingURI is where the progress of the problem resolution can be tracked.
+	TrackingURI string `json:"tracking_uri,omitempty"`
+	// EscalationURI is where to escalate the problem, eg. a 'mailto:' URI.
+	EscalationURI string            `json:"escalation_uri,omitempty"`
+	Thread        *decorator.Thread `json:"~thread,omitempty"`
 }
 
 // Code represents a problem report code.
 type Code struct {
+	// Code is the descriptor code of the problem.
 	Code string `json:"code"`
+	// En is the human readable description of the problem.
+	En string `json:"en,omitempty"`
+}
+
+// FixHint is a human readable hint on how to fix the problem.
+type FixHint struct {
+	En string `json:"en,omitempty"`
 }
@@ -0,0 +1,113 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package problemcode maps the errors of the framework to the descriptor codes of the problem reports, and back.
+//
+// The protocol services report their failures with the code of the error that caused them:
+//
+// 	code := problemcode.Of(err) // e.g. model.CodeTrustCrypto for verifiable.ErrProofInvalid
+//
+// The applications interpret the problem reports they receive with the errors of the framework:
+//
+// 	err := problemcode.Error(report.Description)
+// 	if errors.Is(err, verifiable.ErrProofInvalid) {
+// 	  // the other agent failed to verify the proof of our credential
+// 	}
+package problemcode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+var (
+	// ErrInternal is the error of the problem reports with the model.CodeInternal code.
+	ErrInternal = errors.New("internal error of the other agent")
+	// ErrRejected is the error of the problem reports with the model.CodeRejected code.
+	ErrRejected = errors.New("rejected by the other agent")
+	// ErrNotTrusted is the error of the problem reports with the model.CodeTrust code.
+	ErrNotTrusted = errors.New("not trusted by the other agent")
+	// ErrTimeout is the error of the problem reports with the model.CodeRequestTime code.
+	ErrTimeout = errors.New("not handled in time by the other agent")
+)
+
+// codes maps the errors to the codes, the first error found in the chain giving the code: the DID resolution
+// failures go before the proof failures they cause.
+var codes = []struct { //nolint:gochecknoglobals
+	err  error
+	code string
+}{
+	{err: vdrapi.ErrNotFound, code: model.CodeDID},
+	{err: vdrapi.ErrMethodNotSupported, code: model.CodeDID},
+	{err: did.ErrKeyNotFound, code: model.CodeTrustCrypto},
+	{err: verifiable.ErrProofInvalid, code: model.CodeTrustCrypto},
+	{err: verifiable.ErrExpired, code: model.CodeTrust},
+	{err: verifiable.ErrNotYetValid, code: model.CodeTrust},
+	{err: verifiable.ErrRevoked, code: model.CodeTrust},
+	{err: verifiable.ErrSuspended, code: model.CodeTrust},
+	{err: verifiable.ErrSchemaValidation, code: model.CodeMessage},
+	{err: jsonutil.ErrLimitExceeded, code: model.CodeMessage},
+}
+
+// errs maps the codes back to the errors.
+var errs = map[string]error{ //nolint:gochecknoglobals
+	model.CodeInternal:    ErrInternal,
+	model.CodeRejected:    ErrRejected,
+	model.CodeTrust:       ErrNotTrusted,
+	model.CodeTrustCrypto: verifiable.ErrProofInvalid,
+	model.CodeDID:         vdrapi.ErrNotFound,
+	model.CodeMessage:     verifiable.ErrSchemaValidation,
+	model.CodeRequestTime: ErrTimeout,
+}
+
+// Of returns the descriptor code of the error, or an empty string if the error has no code. The code of
+// a ReportError is the code of its problem report.
+func Of(err error) string {
+	var reportErr *ReportError
+	if errors.As(err, &reportErr) {
+		return reportErr.Code.Code
+	}
+
+	for _, c := range codes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+
+	return ""
+}
+
+// ReportError is the error of a problem report received from another agent.
+type ReportError struct {
+	Code model.Code
+}
+
+// Error returns the error of the problem report with the given code. The error is, for errors.Is, the error
+// of the framework the code maps to (e.g. verifiable.ErrProofInvalid for model.CodeTrustCrypto), if any.
+func Error(code model.Code) error {
+	return &ReportError{Code: code}
+}
+
+func (e *ReportError) Error() string {
+	if e.Code.En == "" {
+		return fmt.Sprintf("problem report: %s", e.Code.Code)
+	}
+
+	return fmt.Sprintf("problem report: %s: %s", e.Code.Code, e.Code.En)
+}
+
+// Is tells whether the code of the problem report maps to target.
+func (e *ReportError) Is(target error) bool {
+	err, ok := errs[e.Code.Code]
+
+	return ok && err == target
+}
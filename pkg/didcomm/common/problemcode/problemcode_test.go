@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+func TestOf(t *testing.T) {
+	require.Equal(t, model.CodeTrustCrypto, Of(fmt.Errorf("save credentials: %w", verifiable.ErrProofInvalid)))
+	require.Equal(t, model.CodeTrust, Of(fmt.Errorf("%w: expired at 2020-01-01", verifiable.ErrExpired)))
+	require.Equal(t, model.CodeTrust, Of(fmt.Errorf("check: %w", verifiable.ErrRevoked)))
+	require.Equal(t, model.CodeMessage, Of(jsonutil.ErrLimitExceeded))
+	require.Empty(t, Of(errors.New("test")))
+	require.Empty(t, Of(nil))
+
+	t.Run("DID resolution failure of the proof check", func(t *testing.T) {
+		err := fmt.Errorf("%w: resolve DID: %v", verifiable.ErrProofInvalid, vdrapi.ErrNotFound)
+		require.Equal(t, model.CodeTrustCrypto, Of(err))
+
+		err = fmt.Errorf("%v: resolve DID: %w", verifiable.ErrProofInvalid, vdrapi.ErrNotFound)
+		require.Equal(t, model.CodeDID, Of(err))
+	})
+}
+
+func TestError(t *testing.T) {
+	err := Error(model.Code{Code: model.CodeTrustCrypto})
+	require.EqualError(t, err, "problem report: trust.crypto")
+	require.True(t, errors.Is(err, verifiable.ErrProofInvalid))
+	require.False(t, errors.Is(err, ErrRejected))
+
+	var reportErr *ReportError
+	require.True(t, errors.As(err, &reportErr))
+	require.Equal(t, model.CodeTrustCrypto, reportErr.Code.Code)
+
+	err = Error(model.Code{Code: model.CodeRejected, En: "not supported"})
+	require.EqualError(t, err, "problem report: rejected: not supported")
+	require.True(t, errors.Is(err, ErrRejected))
+
+	err = Error(model.Code{Code: "custom"})
+	require.False(t, errors.Is(err, ErrInternal))
+
+	t.Run("codes map back to their errors", func(t *testing.T) {
+		for code, err := range errs {
+			require.True(t, errors.Is(Error(model.Code{Code: code}), err))
+			require.Equal(t, code, Of(fmt.Errorf("relayed: %w", Error(model.Code{Code: code}))))
+		}
+	})
+}
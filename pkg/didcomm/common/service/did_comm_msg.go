@@ -21,6 +21,8 @@ import (
 const (
 	jsonID             = "@id"
 	jsonType           = "@type"
+	jsonIDV2           = "id"
+	jsonTypeV2         = "type"
 	jsonThread         = "~thread"
 	jsonThreadID       = "thid"
 	jsonParentThreadID = "pthid"
@@ -140,17 +142,9 @@ func (m DIDCommMsgMap) Metadata() map[string]interface{} {
 }
 
 // Type returns the message type.
+// For DIDComm V2 messages the `type` field is used when `@type` is absent.
 func (m DIDCommMsgMap) Type() string {
-	if m == nil || m[jsonType] == nil {
-		return ""
-	}
-
-	res, ok := m[jsonType].(string)
-	if !ok {
-		return ""
-	}
-
-	return res
+	return m.stringField(jsonType, jsonTypeV2)
 }
 
 // ParentThreadID returns the message parent threadID.
@@ -169,17 +163,31 @@ func (m DIDCommMsgMap) ParentThreadID() string {
 }
 
 // ID returns the message id.
+// For DIDComm V2 messages the `id` field is used when `@id` is absent.
 func (m DIDCommMsgMap) ID() string {
-	if m == nil || m[jsonID] == nil {
+	return m.stringField(jsonID, jsonIDV2)
+}
+
+// stringField returns the string value of the first present field.
+func (m DIDCommMsgMap) stringField(names ...string) string {
+	if m == nil {
 		return ""
 	}
 
-	res, ok := m[jsonID].(string)
-	if !ok {
-		return ""
+	for _, name := range names {
+		if m[name] == nil {
+			continue
+		}
+
+		res, ok := m[name].(string)
+		if !ok {
+			return ""
+		}
+
+		return res
 	}
 
-	return res
+	return ""
 }
 
 // SetID sets the message id.
@@ -38,6 +38,11 @@ func TestDIDCommMsgMap_ID(t *testing.T) {
 			msg:      DIDCommMsgMap{jsonID: "ID"},
 			expected: "ID",
 		},
+		{
+			name:     "Success (DIDComm V2)",
+			msg:      DIDCommMsgMap{jsonIDV2: "ID"},
+			expected: "ID",
+		},
 	}
 
 	for i := range tests {
@@ -114,6 +119,11 @@ func TestDIDCommMsgMap_Type(t *testing.T) {
 			msg:      DIDCommMsgMap{jsonType: "Type"},
 			expected: "Type",
 		},
+		{
+			name:     "Success (DIDComm V2)",
+			msg:      DIDCommMsgMap{jsonTypeV2: "Type"},
+			expected: "Type",
+		},
 	}
 
 	for i := range tests {
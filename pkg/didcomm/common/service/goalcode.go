@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+const goalCodeWildcard = "*"
+
+// GoalCodeHandler handles the action event carrying the goal code it was registered for. The action is stopped when
+// the handler returns an error and continued without arguments when the handler neither continued nor stopped it.
+type GoalCodeHandler func(goalCode string, action *PendingAction) error
+
+// GoalCode returns the goal code of the message, e.g the goal code of an out-of-band invitation or of a proposal.
+// An empty string is returned when the message has no goal code.
+func GoalCode(msg DIDCommMsg) string {
+	if msg == nil {
+		return ""
+	}
+
+	h := struct {
+		GoalCode       string `json:"goal_code"`
+		LegacyGoalCode string `json:"goal-code"`
+		Body           *struct {
+			GoalCode string `json:"goal_code"`
+		} `json:"body"`
+	}{}
+
+	if err := msg.Decode(&h); err != nil {
+		return ""
+	}
+
+	switch {
+	case h.GoalCode != "":
+		return h.GoalCode
+	case h.LegacyGoalCode != "":
+		return h.LegacyGoalCode
+	case h.Body != nil:
+		return h.Body.GoalCode
+	}
+
+	return ""
+}
+
+// FilterByGoalCode accepts the action events triggered by a message with one of the given goal codes.
+// A goal code ending with a wildcard (e.g "aries.vc.*") matches all the goal codes with the given prefix.
+func FilterByGoalCode(goalCodes ...string) ActionFilter {
+	return func(action DIDCommAction) bool {
+		goalCode := GoalCode(action.Message)
+		if goalCode == "" {
+			return false
+		}
+
+		for _, gc := range goalCodes {
+			if matchGoalCode(gc, goalCode) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// GoalCodeRegistry maps the goal codes to the application handlers, so that the out-of-band invitations
+// and the proposals with a goal code (e.g aries.vc.issue) are dispatched to the right handler.
+//
+// Usage:
+//
+//	registry := service.NewGoalCodeRegistry()
+//	registry.Register("aries.vc.issue", func(goalCode string, action *service.PendingAction) error {
+//	    // e.g accept the out-of-band invitation and wait for the credential offer
+//	    return nil
+//	})
+//	router, err := service.NewActionRouter(outofbandClient)
+//	registry.Attach(router)
+type GoalCodeRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]GoalCodeHandler
+}
+
+// NewGoalCodeRegistry returns a new empty goal code registry.
+func NewGoalCodeRegistry() *GoalCodeRegistry {
+	return &GoalCodeRegistry{handlers: map[string]GoalCodeHandler{}}
+}
+
+// Register registers the handler of the goal code, which can end with a wildcard (e.g "aries.vc.*") to match all
+// the goal codes with the given prefix. Registering a goal code again replaces its handler.
+func (r *GoalCodeRegistry) Register(goalCode string, handler GoalCodeHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers[goalCode] = handler
+}
+
+// Unregister removes the handler of the goal code.
+func (r *GoalCodeRegistry) Unregister(goalCode string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.handlers, goalCode)
+}
+
+// GoalCodes returns the registered goal codes (e.g to disclose them with discover-features).
+func (r *GoalCodeRegistry) GoalCodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	goalCodes := make([]string, 0, len(r.handlers))
+
+	for goalCode := range r.handlers {
+		goalCodes = append(goalCodes, goalCode)
+	}
+
+	sort.Strings(goalCodes)
+
+	return goalCodes
+}
+
+// Handler returns the handler of the goal code. An exact registration takes precedence over the wildcard ones,
+// and the longest wildcard prefix takes precedence over the shorter ones.
+func (r *GoalCodeRegistry) Handler(goalCode string) (GoalCodeHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if handler, ok := r.handlers[goalCode]; ok {
+		return handler, true
+	}
+
+	var (
+		match   string
+		handler GoalCodeHandler
+	)
+
+	for gc, h := range r.handlers {
+		if strings.HasSuffix(gc, goalCodeWildcard) && matchGoalCode(gc, goalCode) && len(gc) > len(match) {
+			match, handler = gc, h
+		}
+	}
+
+	return handler, handler != nil
+}
+
+// Dispatch invokes the handler of the goal code of the action. ErrNoActionSubscription is returned when no handler
+// is registered for the goal code of the action.
+func (r *GoalCodeRegistry) Dispatch(action *PendingAction) error {
+	goalCode := GoalCode(action.Message())
+
+	handler, ok := r.Handler(goalCode)
+	if !ok {
+		return ErrNoActionSubscription
+	}
+
+	return handler(goalCode, action)
+}
+
+// Attach subscribes the registry to the action events of the router with a registered goal code, which
+// are dispatched to their handler in a new goroutine. The goal codes registered afterwards are routed too,
+// as long as no other subscription of the router matches their action events first.
+func (r *GoalCodeRegistry) Attach(router *ActionRouter) *ActionSubscription {
+	sub := router.Subscribe(func(action DIDCommAction) bool {
+		_, ok := r.Handler(GoalCode(action.Message))
+
+		return ok
+	})
+
+	go sub.Handle(r.Dispatch)
+
+	return sub
+}
+
+// matchGoalCode reports whether the goal code matches the registered one, which can end with a wildcard.
+func matchGoalCode(registered, goalCode string) bool {
+	if strings.HasSuffix(registered, goalCodeWildcard) {
+		return strings.HasPrefix(goalCode, strings.TrimSuffix(registered, goalCodeWildcard))
+	}
+
+	return registered == goalCode
+}
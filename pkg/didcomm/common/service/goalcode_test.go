@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newGoalCodeAction(msg DIDCommMsgMap) (DIDCommAction, chan outcome) {
+	action, result := newAction(offerMsgType, nil)
+	action.Message = msg
+
+	return action, result
+}
+
+func TestGoalCode(t *testing.T) {
+	require.Equal(t, "aries.vc.issue", GoalCode(DIDCommMsgMap{"goal_code": "aries.vc.issue"}))
+	require.Equal(t, "aries.vc.issue", GoalCode(DIDCommMsgMap{"goal-code": "aries.vc.issue"}))
+	require.Equal(t, "aries.vc.issue", GoalCode(DIDCommMsgMap{
+		"body": map[string]interface{}{"goal_code": "aries.vc.issue"},
+	}))
+	require.Empty(t, GoalCode(DIDCommMsgMap{"@type": offerMsgType}))
+	require.Empty(t, GoalCode(DIDCommMsgMap{"goal_code": []string{"invalid"}}))
+	require.Empty(t, GoalCode(nil))
+}
+
+func TestFilterByGoalCode(t *testing.T) {
+	action, _ := newGoalCodeAction(DIDCommMsgMap{"goal-code": "aries.vc.issue"})
+
+	require.True(t, FilterByGoalCode("aries.vc.issue")(action))
+	require.True(t, FilterByGoalCode("aries.vc.verify", "aries.vc.*")(action))
+	require.False(t, FilterByGoalCode("aries.vc.verify")(action))
+	require.False(t, FilterByGoalCode("aries.vc.issue")(DIDCommAction{}))
+}
+
+func TestGoalCodeRegistry(t *testing.T) {
+	t.Run("handler lookup", func(t *testing.T) {
+		registry := NewGoalCodeRegistry()
+
+		var called string
+
+		handler := func(name string) GoalCodeHandler {
+			return func(string, *PendingAction) error {
+				called = name
+				return nil
+			}
+		}
+
+		registry.Register("aries.vc.issue", handler("issue"))
+		registry.Register("aries.*", handler("aries"))
+		registry.Register("aries.vc.*", handler("vc"))
+		require.Equal(t, []string{"aries.*", "aries.vc.*", "aries.vc.issue"}, registry.GoalCodes())
+
+		for goalCode, expected := range map[string]string{
+			"aries.vc.issue":  "issue",
+			"aries.vc.verify": "vc",
+			"aries.rel.build": "aries",
+		} {
+			h, ok := registry.Handler(goalCode)
+			require.True(t, ok)
+			require.NoError(t, h(goalCode, nil))
+			require.Equal(t, expected, called)
+		}
+
+		_, ok := registry.Handler("other")
+		require.False(t, ok)
+
+		registry.Unregister("aries.*")
+		_, ok = registry.Handler("aries.rel.build")
+		require.False(t, ok)
+	})
+
+	t.Run("dispatch", func(t *testing.T) {
+		registry := NewGoalCodeRegistry()
+		registry.Register("aries.vc.issue", func(goalCode string, action *PendingAction) error {
+			require.Equal(t, "aries.vc.issue", goalCode)
+			action.Continue("issue")
+
+			return nil
+		})
+
+		action, result := newGoalCodeAction(DIDCommMsgMap{"goal_code": "aries.vc.issue"})
+		require.NoError(t, registry.Dispatch(&PendingAction{action: action}))
+		require.Equal(t, outcome{args: "issue"}, receive(t, result))
+
+		action, _ = newGoalCodeAction(DIDCommMsgMap{"goal_code": "aries.vc.verify"})
+		require.True(t, errors.Is(registry.Dispatch(&PendingAction{action: action}), ErrNoActionSubscription))
+	})
+
+	t.Run("attach to action router", func(t *testing.T) {
+		e := &testEvent{}
+		router, err := NewActionRouter(e)
+		require.NoError(t, err)
+
+		registry := NewGoalCodeRegistry()
+		sub := registry.Attach(router)
+
+		// registered after the registry was attached
+		registry.Register("aries.vc.*", func(string, *PendingAction) error {
+			return errors.New("rejected")
+		})
+
+		action, result := newGoalCodeAction(DIDCommMsgMap{"goal_code": "aries.vc.issue"})
+		send(t, e, action)
+		require.EqualError(t, receive(t, result).err, "rejected")
+
+		action, result = newGoalCodeAction(DIDCommMsgMap{"goal_code": "aries.rel.build"})
+		send(t, e, action)
+		require.True(t, errors.Is(receive(t, result).err, ErrNoActionSubscription))
+
+		sub.Unsubscribe()
+		require.NoError(t, router.Close())
+	})
+}
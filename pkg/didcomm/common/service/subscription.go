@@ -0,0 +1,445 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	myDIDPropKey    = "myDID"
+	theirDIDPropKey = "theirDID"
+
+	defaultActionBufferSize = 10
+	defaultDeliveryTimeout  = 5 * time.Second
+)
+
+// ErrNoActionSubscription is used to stop the action events that are not delivered to any subscription: they do
+// not match any subscription, the matching subscriptions did not receive them in time, or were closed before
+// handling them.
+var ErrNoActionSubscription = errors.New("no subscription for the action event")
+
+// ActionFilter reports whether the action event is delivered to the subscription.
+type ActionFilter func(action DIDCommAction) bool
+
+// FilterByProtocol accepts the action events of the given protocol.
+func FilterByProtocol(name string) ActionFilter {
+	return func(action DIDCommAction) bool {
+		return action.ProtocolName == name
+	}
+}
+
+// FilterByMessageType accepts the action events triggered by one of the given message types.
+func FilterByMessageType(types ...string) ActionFilter {
+	return func(action DIDCommAction) bool {
+		if action.Message == nil {
+			return false
+		}
+
+		for _, t := range types {
+			if action.Message.Type() == t {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// FilterByProperty accepts the action events having the property with the given (comparable) value.
+func FilterByProperty(key string, value interface{}) ActionFilter {
+	return func(action DIDCommAction) bool {
+		if action.Properties == nil {
+			return false
+		}
+
+		v, ok := action.Properties.All()[key]
+
+		return ok && v == value
+	}
+}
+
+// FilterByConnection accepts the action events of the connection between myDID and theirDID.
+// An empty DID matches any DID.
+func FilterByConnection(myDID, theirDID string) ActionFilter {
+	return func(action DIDCommAction) bool {
+		return (myDID == "" || FilterByProperty(myDIDPropKey, myDID)(action)) &&
+			(theirDID == "" || FilterByProperty(theirDIDPropKey, theirDID)(action))
+	}
+}
+
+// PendingAction is an action event waiting for the consumer's decision. Continue and Stop may be called
+// several times, only the first call is passed to the protocol service.
+type PendingAction struct {
+	action DIDCommAction
+	once   sync.Once
+	mu     sync.RWMutex
+	acked  bool
+}
+
+// ProtocolName returns the name of the protocol which triggered the action event.
+func (a *PendingAction) ProtocolName() string {
+	return a.action.ProtocolName
+}
+
+// Message returns the DIDComm message which triggered the action event.
+func (a *PendingAction) Message() DIDCommMsg {
+	return a.action.Message
+}
+
+// Decode decodes the DIDComm message of the action event into the given protocol message.
+func (a *PendingAction) Decode(v interface{}) error {
+	return a.action.Message.Decode(v)
+}
+
+// Properties returns the protocol specific properties of the action event.
+func (a *PendingAction) Properties() EventProperties {
+	return a.action.Properties
+}
+
+// Property returns the property of the action event with the given key.
+func (a *PendingAction) Property(key string) (interface{}, bool) {
+	if a.action.Properties == nil {
+		return nil, false
+	}
+
+	v, ok := a.action.Properties.All()[key]
+
+	return v, ok
+}
+
+// Continue resumes the processing of the message, args are protocol specific (e.g client options).
+func (a *PendingAction) Continue(args interface{}) {
+	a.acknowledge(func() { a.action.Continue(args) })
+}
+
+// Stop stops the processing of the message with the given reason.
+func (a *PendingAction) Stop(err error) {
+	a.acknowledge(func() { a.action.Stop(err) })
+}
+
+// Acknowledged reports whether Continue or Stop was called.
+func (a *PendingAction) Acknowledged() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.acked
+}
+
+func (a *PendingAction) acknowledge(fn func()) {
+	a.once.Do(func() {
+		a.mu.Lock()
+		a.acked = true
+		a.mu.Unlock()
+
+		fn()
+	})
+}
+
+// ActionSubscription receives the action events matching its filters.
+type ActionSubscription struct {
+	router  *ActionRouter
+	filters []ActionFilter
+	actions chan *PendingAction
+	done    chan struct{}
+	mu      sync.RWMutex
+	closed  bool
+}
+
+// Actions returns the channel of the action events. The consumer must call Continue or Stop on every action,
+// use Handle to do it automatically.
+func (s *ActionSubscription) Actions() <-chan *PendingAction {
+	return s.actions
+}
+
+// Done is closed when the subscription is unsubscribed or the router is closed.
+func (s *ActionSubscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Handle invokes the handler for every action event until the subscription is done. The action is stopped when
+// the handler returns an error and continued without arguments when the handler neither continued nor stopped it.
+// This is a blocking function and use this function with a goroutine.
+func (s *ActionSubscription) Handle(handler func(action *PendingAction) error) {
+	for {
+		select {
+		case action := <-s.actions:
+			if err := handler(action); err != nil {
+				action.Stop(err)
+
+				continue
+			}
+
+			action.Continue(&Empty{})
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Unsubscribe stops the delivery of the action events to the subscription.
+func (s *ActionSubscription) Unsubscribe() {
+	s.router.remove(s)
+	s.close()
+}
+
+// close closes the subscription and stops the action events it buffered but did not handle.
+func (s *ActionSubscription) close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+
+		return
+	}
+
+	s.closed = true
+	close(s.done)
+	s.mu.Unlock()
+
+	for {
+		select {
+		case action := <-s.actions:
+			action.Stop(ErrNoActionSubscription)
+		default:
+			return
+		}
+	}
+}
+
+// deliver delivers the action event to the subscription, waiting at most the timeout when its buffer is full.
+// It reports whether the action was delivered.
+func (s *ActionSubscription) deliver(action *PendingAction, timeout time.Duration) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return false
+	}
+
+	select {
+	case s.actions <- action:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case s.actions <- action:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (s *ActionSubscription) matches(action DIDCommAction) bool {
+	for _, filter := range s.filters {
+		if !filter(action) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ActionRouter registers itself for the action events of a protocol service (or client) and dispatches them
+// to the first matching subscription. The subscriptions buffer the action events, when the buffer of a subscription
+// is full the router waits for the delivery timeout then tries the next matching subscription, so a slow consumer
+// does not block the protocol service. Action events that are not delivered to any subscription are stopped
+// with ErrNoActionSubscription, so none of them is left pending.
+//
+// The protocol clients provide typed subscriptions on top of it, e.g. issuecredential.Client.SubscribeOffers.
+//
+// Usage:
+//
+//	router, err := service.NewActionRouter(issuecredentialClient)
+//	offers := router.Subscribe(service.FilterByMessageType(issuecredential.OfferCredentialMsgType))
+//	go offers.Handle(func(action *service.PendingAction) error {
+//	    offer := &issuecredential.OfferCredential{}
+//	    return action.Decode(offer)
+//	})
+type ActionRouter struct {
+	event           Event
+	ch              chan DIDCommAction
+	done            chan struct{}
+	bufferSize      int
+	deliveryTimeout time.Duration
+	mu              sync.RWMutex
+	subscriptions   []*ActionSubscription
+}
+
+// ActionRouterOpt configures the action router.
+type ActionRouterOpt func(r *ActionRouter)
+
+// WithActionBufferSize sets the number of action events buffered by each subscription, 10 if not set.
+func WithActionBufferSize(size int) ActionRouterOpt {
+	return func(r *ActionRouter) {
+		r.bufferSize = size
+	}
+}
+
+// WithDeliveryTimeout sets how long the router waits for a subscription with a full buffer before trying the next
+// matching subscription, 5 seconds if not set.
+func WithDeliveryTimeout(timeout time.Duration) ActionRouterOpt {
+	return func(r *ActionRouter) {
+		r.deliveryTimeout = timeout
+	}
+}
+
+// NewActionRouter returns a new action router registered for the action events of the given service.
+func NewActionRouter(event Event, opts ...ActionRouterOpt) (*ActionRouter, error) {
+	r := &ActionRouter{
+		event:           event,
+		ch:              make(chan DIDCommAction),
+		done:            make(chan struct{}),
+		bufferSize:      defaultActionBufferSize,
+		deliveryTimeout: defaultDeliveryTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if err := event.RegisterActionEvent(r.ch); err != nil {
+		return nil, err
+	}
+
+	go r.listen()
+
+	return r, nil
+}
+
+// Subscribe returns a subscription for the action events matching all the given filters. The subscriptions
+// are matched in the order they were created, a subscription without filters matches every action event.
+func (r *ActionRouter) Subscribe(filters ...ActionFilter) *ActionSubscription {
+	s := &ActionSubscription{
+		router:  r,
+		filters: filters,
+		actions: make(chan *PendingAction, r.bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	r.subscriptions = append(r.subscriptions, s)
+	r.mu.Unlock()
+
+	return s
+}
+
+// Close unregisters the router from the action events and closes all the subscriptions.
+func (r *ActionRouter) Close() error {
+	if err := r.event.UnregisterActionEvent(r.ch); err != nil {
+		return err
+	}
+
+	close(r.done)
+
+	r.mu.Lock()
+	subscriptions := r.subscriptions
+	r.subscriptions = nil
+	r.mu.Unlock()
+
+	for _, s := range subscriptions {
+		s.close()
+	}
+
+	return nil
+}
+
+func (r *ActionRouter) listen() {
+	for {
+		select {
+		case action := <-r.ch:
+			r.dispatch(action)
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *ActionRouter) dispatch(action DIDCommAction) {
+	pending := &PendingAction{action: action}
+
+	r.mu.RLock()
+	subscriptions := append([]*ActionSubscription(nil), r.subscriptions...)
+	r.mu.RUnlock()
+
+	for _, s := range subscriptions {
+		if s.matches(action) && s.deliver(pending, r.deliveryTimeout) {
+			return
+		}
+	}
+
+	pending.Stop(ErrNoActionSubscription)
+}
+
+func (r *ActionRouter) remove(s *ActionSubscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.subscriptions {
+		if r.subscriptions[i] == s {
+			r.subscriptions = append(r.subscriptions[:i], r.subscriptions[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// ActionSubscriptions registers an action router for the action events of a protocol service (or client) on the
+// first subscription. The protocol clients use it for their typed subscriptions.
+type ActionSubscriptions struct {
+	event  Event
+	opts   []ActionRouterOpt
+	mu     sync.Mutex
+	router *ActionRouter
+}
+
+// NewActionSubscriptions returns the action subscriptions of the given service, the options configure its router.
+func NewActionSubscriptions(event Event, opts ...ActionRouterOpt) *ActionSubscriptions {
+	return &ActionSubscriptions{event: event, opts: opts}
+}
+
+// Subscribe returns a subscription for the action events matching all the given filters, registering the action
+// router of the service on the first call. See ActionRouter.Subscribe.
+func (s *ActionSubscriptions) Subscribe(filters ...ActionFilter) (*ActionSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.router == nil {
+		router, err := NewActionRouter(s.event, s.opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		s.router = router
+	}
+
+	return s.router.Subscribe(filters...), nil
+}
+
+// Close closes all the subscriptions and unregisters the action router, the action events of the service can then
+// be registered again.
+func (s *ActionSubscriptions) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.router == nil {
+		return nil
+	}
+
+	if err := s.router.Close(); err != nil {
+		return err
+	}
+
+	s.router = nil
+
+	return nil
+}
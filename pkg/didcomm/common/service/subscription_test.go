@@ -0,0 +1,342 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	offerMsgType   = "https://didcomm.org/test/1.0/offer"
+	requestMsgType = "https://didcomm.org/test/1.0/request"
+	timeout        = time.Second
+)
+
+type testEvent struct {
+	Action
+	Message
+}
+
+type testProps map[string]interface{}
+
+func (p testProps) All() map[string]interface{} {
+	return p
+}
+
+type outcome struct {
+	args interface{}
+	err  error
+}
+
+func newAction(msgType string, props testProps) (DIDCommAction, chan outcome) {
+	result := make(chan outcome, 2)
+
+	return DIDCommAction{
+		ProtocolName: "test",
+		Message:      DIDCommMsgMap{"@type": msgType, "@id": "ID", "label": "Alice"},
+		Continue:     func(args interface{}) { result <- outcome{args: args} },
+		Stop:         func(err error) { result <- outcome{err: err} },
+		Properties:   props,
+	}, result
+}
+
+func send(t *testing.T, e *testEvent, action DIDCommAction) {
+	t.Helper()
+
+	select {
+	case e.ActionEvent() <- action:
+	case <-time.After(timeout):
+		t.Fatal("timeout")
+	}
+}
+
+func receive(t *testing.T, result chan outcome) outcome {
+	t.Helper()
+
+	select {
+	case o := <-result:
+		return o
+	case <-time.After(timeout):
+		t.Fatal("timeout")
+	}
+
+	return outcome{}
+}
+
+func TestActionFilters(t *testing.T) {
+	action, _ := newAction(offerMsgType, testProps{myDIDPropKey: "did:alice", theirDIDPropKey: "did:bob"})
+
+	require.True(t, FilterByProtocol("test")(action))
+	require.False(t, FilterByProtocol("other")(action))
+	require.True(t, FilterByMessageType(requestMsgType, offerMsgType)(action))
+	require.False(t, FilterByMessageType(requestMsgType)(action))
+	require.False(t, FilterByMessageType(offerMsgType)(DIDCommAction{}))
+	require.True(t, FilterByProperty(myDIDPropKey, "did:alice")(action))
+	require.False(t, FilterByProperty(myDIDPropKey, "did:bob")(action))
+	require.False(t, FilterByProperty("unknown", "did:alice")(action))
+	require.False(t, FilterByProperty(myDIDPropKey, "did:alice")(DIDCommAction{}))
+	require.True(t, FilterByConnection("did:alice", "did:bob")(action))
+	require.True(t, FilterByConnection("", "did:bob")(action))
+	require.True(t, FilterByConnection("did:alice", "")(action))
+	require.False(t, FilterByConnection("did:alice", "did:carol")(action))
+}
+
+func TestPendingAction(t *testing.T) {
+	action, result := newAction(offerMsgType, testProps{"piid": "123"})
+	pending := &PendingAction{action: action}
+
+	require.Equal(t, "test", pending.ProtocolName())
+	require.Equal(t, offerMsgType, pending.Message().Type())
+	require.Equal(t, "123", pending.Properties().All()["piid"])
+
+	v, ok := pending.Property("piid")
+	require.True(t, ok)
+	require.Equal(t, "123", v)
+
+	_, ok = (&PendingAction{}).Property("piid")
+	require.False(t, ok)
+
+	msg := struct {
+		Label string `json:"label"`
+	}{}
+	require.NoError(t, pending.Decode(&msg))
+	require.Equal(t, "Alice", msg.Label)
+
+	require.False(t, pending.Acknowledged())
+	pending.Continue("args")
+	pending.Stop(errors.New("ignored"))
+	pending.Continue("ignored")
+	require.True(t, pending.Acknowledged())
+
+	require.Equal(t, outcome{args: "args"}, receive(t, result))
+	require.Empty(t, result)
+}
+
+func TestActionRouter(t *testing.T) {
+	t.Run("register error", func(t *testing.T) {
+		e := &testEvent{}
+		require.NoError(t, e.RegisterActionEvent(make(chan DIDCommAction)))
+
+		router, err := NewActionRouter(e)
+		require.EqualError(t, err, ErrChannelRegistered.Error())
+		require.Nil(t, router)
+	})
+
+	t.Run("dispatch to the first matching subscription", func(t *testing.T) {
+		e := &testEvent{}
+		router, err := NewActionRouter(e)
+		require.NoError(t, err)
+
+		offers := router.Subscribe(FilterByMessageType(offerMsgType))
+		all := router.Subscribe()
+
+		action, result := newAction(offerMsgType, nil)
+		send(t, e, action)
+
+		select {
+		case pending := <-offers.Actions():
+			pending.Continue("offer")
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+
+		require.Equal(t, outcome{args: "offer"}, receive(t, result))
+
+		action, result = newAction(requestMsgType, nil)
+		send(t, e, action)
+
+		select {
+		case pending := <-all.Actions():
+			pending.Stop(errors.New("request"))
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+
+		require.EqualError(t, receive(t, result).err, "request")
+
+		require.NoError(t, router.Close())
+		require.Nil(t, e.ActionEvent())
+
+		select {
+		case <-offers.Done():
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+
+		require.EqualError(t, router.Close(), ErrInvalidChannel.Error())
+	})
+
+	t.Run("stop actions without subscription", func(t *testing.T) {
+		e := &testEvent{}
+		router, err := NewActionRouter(e)
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, router.Close()) }()
+
+		router.Subscribe(FilterByMessageType(offerMsgType)).Unsubscribe()
+
+		action, result := newAction(offerMsgType, nil)
+		send(t, e, action)
+
+		require.True(t, errors.Is(receive(t, result).err, ErrNoActionSubscription))
+	})
+
+	t.Run("handle actions", func(t *testing.T) {
+		e := &testEvent{}
+		router, err := NewActionRouter(e)
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, router.Close()) }()
+
+		sub := router.Subscribe(FilterByConnection("", "did:bob"))
+
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			sub.Handle(func(action *PendingAction) error {
+				switch action.Message().Type() {
+				case offerMsgType:
+					return nil
+				case requestMsgType:
+					return errors.New("declined")
+				default:
+					action.Continue("custom")
+
+					return nil
+				}
+			})
+		}()
+
+		action, result := newAction(offerMsgType, testProps{theirDIDPropKey: "did:bob"})
+		send(t, e, action)
+		require.Equal(t, outcome{args: &Empty{}}, receive(t, result))
+
+		action, result = newAction(requestMsgType, testProps{theirDIDPropKey: "did:bob"})
+		send(t, e, action)
+		require.EqualError(t, receive(t, result).err, "declined")
+
+		action, result = newAction("other", testProps{theirDIDPropKey: "did:bob"})
+		send(t, e, action)
+		require.Equal(t, outcome{args: "custom"}, receive(t, result))
+
+		action, result = newAction(offerMsgType, testProps{theirDIDPropKey: "did:carol"})
+		send(t, e, action)
+		require.True(t, errors.Is(receive(t, result).err, ErrNoActionSubscription))
+
+		sub.Unsubscribe()
+
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("fall back when the subscription does not receive the action in time", func(t *testing.T) {
+		e := &testEvent{}
+		router, err := NewActionRouter(e, WithActionBufferSize(1), WithDeliveryTimeout(time.Millisecond))
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, router.Close()) }()
+
+		slow := router.Subscribe(FilterByMessageType(offerMsgType))
+		all := router.Subscribe()
+
+		action, _ := newAction(offerMsgType, nil)
+		send(t, e, action)
+
+		// the buffer of the slow subscription is full, the action is delivered to the next matching subscription
+		action, result := newAction(offerMsgType, nil)
+		send(t, e, action)
+
+		select {
+		case pending := <-all.Actions():
+			pending.Continue("all")
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+
+		require.Equal(t, outcome{args: "all"}, receive(t, result))
+
+		all.Unsubscribe()
+
+		// no other matching subscription, the action is stopped
+		action, result = newAction(offerMsgType, nil)
+		send(t, e, action)
+
+		require.True(t, errors.Is(receive(t, result).err, ErrNoActionSubscription))
+		require.Len(t, slow.Actions(), 1)
+	})
+
+	t.Run("stop the buffered actions on unsubscribe", func(t *testing.T) {
+		e := &testEvent{}
+		router, err := NewActionRouter(e)
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, router.Close()) }()
+
+		sub := router.Subscribe()
+
+		action, result := newAction(offerMsgType, nil)
+		send(t, e, action)
+
+		require.Eventually(t, func() bool { return len(sub.Actions()) == 1 }, timeout, time.Millisecond)
+
+		sub.Unsubscribe()
+
+		require.True(t, errors.Is(receive(t, result).err, ErrNoActionSubscription))
+	})
+}
+
+func TestActionSubscriptions(t *testing.T) {
+	e := &testEvent{}
+	subscriptions := NewActionSubscriptions(e)
+
+	require.NoError(t, subscriptions.Close())
+
+	offers, err := subscriptions.Subscribe(FilterByMessageType(offerMsgType))
+	require.NoError(t, err)
+
+	// the router is registered once
+	_, err = subscriptions.Subscribe()
+	require.NoError(t, err)
+	require.EqualError(t, e.RegisterActionEvent(make(chan DIDCommAction)), ErrChannelRegistered.Error())
+
+	action, result := newAction(offerMsgType, nil)
+	send(t, e, action)
+
+	select {
+	case pending := <-offers.Actions():
+		pending.Continue("offer")
+	case <-time.After(timeout):
+		t.Fatal("timeout")
+	}
+
+	require.Equal(t, outcome{args: "offer"}, receive(t, result))
+
+	require.NoError(t, subscriptions.Close())
+	require.Nil(t, e.ActionEvent())
+
+	select {
+	case <-offers.Done():
+	case <-time.After(timeout):
+		t.Fatal("timeout")
+	}
+
+	t.Run("register error", func(t *testing.T) {
+		e := &testEvent{}
+		require.NoError(t, e.RegisterActionEvent(make(chan DIDCommAction)))
+
+		_, err := NewActionSubscriptions(e).Subscribe()
+		require.EqualError(t, err, ErrChannelRegistered.Error())
+	})
+}
@@ -6,6 +6,18 @@ SPDX-License-Identifier: Apache-2.0
 
 package transport
 
+// DIDComm media type profiles as defined in Aries RFC 0519 (accept) and DIDComm v2.
+const (
+	// MediaTypeProfileAIP1 is the AIP 1.0 media type profile (legacy envelope).
+	MediaTypeProfileAIP1 = "didcomm/aip1"
+	// MediaTypeProfileRFC19 is the AIP 2.0 media type profile using RFC 0019 envelopes.
+	MediaTypeProfileRFC19 = "didcomm/aip2;env=rfc19"
+	// MediaTypeProfileRFC587 is the AIP 2.0 media type profile using RFC 0587 (JWE) envelopes.
+	MediaTypeProfileRFC587 = "didcomm/aip2;env=rfc587"
+	// MediaTypeProfileDIDCommV2 is the DIDComm v2 media type profile.
+	MediaTypeProfileDIDCommV2 = "didcomm/v2"
+)
+
 // Envelope holds message data and metadata for inbound and outbound messaging.
 type Envelope struct {
 	Message []byte
@@ -16,4 +28,7 @@ type Envelope struct {
 	ToKey   []byte
 	FromDID string
 	ToDID   string
+	// MediaTypeProfiles holds the media type profiles negotiated with the recipient, in order of preference.
+	// When empty, the packager's primary packer is used.
+	MediaTypeProfiles []string
 }
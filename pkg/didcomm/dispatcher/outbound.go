@@ -7,13 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
@@ -21,8 +29,15 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
+var logger = log.New("aries-framework/didcomm/dispatcher")
+
+// ErrShutdown is returned by the outbound dispatcher for the messages sent once it's shut down.
+var ErrShutdown = errors.New("outbound dispatcher is shut down")
+
 // provider interface for outbound ctx.
 type provider interface {
 	Packager() commontransport.Packager
@@ -30,6 +45,8 @@ type provider interface {
 	TransportReturnRoute() string
 	VDRegistry() vdr.Registry
 	KMS() kms.KeyManager
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
 }
 
 // OutboundDispatcher dispatch msgs to destination.
@@ -39,27 +56,54 @@ type OutboundDispatcher struct {
 	transportReturnRoute string
 	vdRegistry           vdr.Registry
 	kms                  kms.KeyManager
+	connections          *connection.Recorder
+	metrics              *metrics.Transport
+	tracing              *tracing.DIDComm
+	clock                clock.Clock
+	limiter              *rateLimiter
+	sending              sync.WaitGroup
+	shutdown             bool
+	lock                 sync.RWMutex
+}
+
+// sendOptions holds per-connection transport preferences used while sending.
+type sendOptions struct {
+	mediaTypeProfiles  []string
+	preferredTransport string
+	connectionID       string
 }
 
 // NewOutbound return new dispatcher outbound instance.
-func NewOutbound(prov provider) *OutboundDispatcher {
-	return &OutboundDispatcher{
+func NewOutbound(prov provider, opts ...OutboundOpt) (*OutboundDispatcher, error) {
+	connections, err := connection.NewRecorder(prov)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init connection recorder: %w", err)
+	}
+
+	o := &OutboundDispatcher{
 		outboundTransports:   prov.OutboundTransports(),
 		packager:             prov.Packager(),
 		transportReturnRoute: prov.TransportReturnRoute(),
 		vdRegistry:           prov.VDRegistry(),
 		kms:                  prov.KMS(),
+		connections:          connections,
+		metrics:              metrics.ForTransport(prov),
+		tracing:              tracing.ForDIDComm(prov),
+		clock:                clock.Of(prov),
+	}
+
+	for _, opt := range opts {
+		opt(o)
 	}
+
+	return o, nil
 }
 
 // SendToDID sends a message from myDID to the agent who owns theirDID.
+// When a connection exists between the DIDs, its negotiated media type profiles and preferred transport are honored,
+// and the message is routed to its last seen destination: the DID document of theirDID is resolved only when the
+// connection has none or the delivery to it fails.
 func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
-	dest, err := service.GetDestination(theirDID, o.vdRegistry)
-	if err != nil {
-		return fmt.Errorf(
-			"outboundDispatcher.SendToDID failed to get didcomm destination for theirDID [%s]: %w", theirDID, err)
-	}
-
 	src, err := service.GetDestination(myDID, o.vdRegistry)
 	if err != nil {
 		return fmt.Errorf("outboundDispatcher.SendToDID failed to get didcomm destination for myDID [%s]: %w", myDID, err)
@@ -70,12 +114,170 @@ func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string)
 	// TODO: relies on hardcoded key type
 	key := src.RecipientKeys[0]
 
-	return o.Send(msg, key, dest)
+	record, err := o.getConnectionRecord(myDID, theirDID)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.SendToDID failed to get connection record: %w", err)
+	}
+
+	if record == nil {
+		dest, err := o.getDestination(theirDID)
+		if err != nil {
+			return err
+		}
+
+		return o.send(msg, key, dest, &sendOptions{})
+	}
+
+	opts := &sendOptions{
+		mediaTypeProfiles:  record.MediaTypeProfiles,
+		preferredTransport: record.PreferredTransport,
+		connectionID:       record.ConnectionID,
+	}
+
+	lastSeen := lastSeenDestination(record)
+	if lastSeen == nil {
+		return o.sendToDIDDocument(msg, key, record, opts)
+	}
+
+	err = o.send(msg, key, lastSeen, opts)
+	if err == nil || errors.Is(err, ErrShutdown) {
+		return err
+	}
+
+	logger.Debugf("sending to the last seen endpoint [%s] of connection [%s] failed, resolving [%s]: %s",
+		record.LastSeenEndpoint, record.ConnectionID, theirDID, err)
+
+	if fallbackErr := o.sendToDIDDocument(msg, key, record, opts); fallbackErr != nil {
+		return &fallbackError{err: err, fallbackErr: fallbackErr}
+	}
+
+	return nil
+}
+
+// sendToDIDDocument sends the message to the destination of the DID document of the other party of the connection,
+// saved as its last seen destination once delivered. The message isn't sent again to the last seen destination.
+func (o *OutboundDispatcher) sendToDIDDocument(msg interface{}, senderVerKey string, record *connection.Record,
+	opts *sendOptions) error {
+	dest, err := o.getDestination(record.TheirDID)
+	if err != nil {
+		return err
+	}
+
+	lastSeen := lastSeenDestination(record)
+	if lastSeen != nil && sameDestination(lastSeen, dest) {
+		return fmt.Errorf("outboundDispatcher.SendToDID: the DID document of [%s] has no other destination",
+			record.TheirDID)
+	}
+
+	if err := o.send(msg, senderVerKey, dest, opts); err != nil {
+		return err
+	}
+
+	err = o.connections.SaveLastSeenDestination(record.ConnectionID, dest.ServiceEndpoint, dest.RecipientKeys,
+		dest.RoutingKeys)
+	if err != nil {
+		logger.Warnf("failed to save last seen destination for connection [%s]: %s", record.ConnectionID, err)
+	}
+
+	return nil
+}
+
+func (o *OutboundDispatcher) getDestination(theirDID string) (*service.Destination, error) {
+	dest, err := service.GetDestination(theirDID, o.vdRegistry)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"outboundDispatcher.SendToDID failed to get didcomm destination for theirDID [%s]: %w", theirDID, err)
+	}
+
+	return dest, nil
+}
+
+// lastSeenDestination returns the last seen destination of the connection, nil if it has none.
+func lastSeenDestination(record *connection.Record) *service.Destination {
+	if record.LastSeenEndpoint == "" || len(record.LastSeenRecipientKeys) == 0 {
+		return nil
+	}
+
+	return &service.Destination{
+		ServiceEndpoint: record.LastSeenEndpoint,
+		RecipientKeys:   record.LastSeenRecipientKeys,
+		RoutingKeys:     record.LastSeenRoutingKeys,
+	}
+}
+
+func sameDestination(a, b *service.Destination) bool {
+	return a.ServiceEndpoint == b.ServiceEndpoint && equalKeys(a.RecipientKeys, b.RecipientKeys) &&
+		equalKeys(a.RoutingKeys, b.RoutingKeys)
+}
+
+func equalKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fallbackError is the error of a message which was neither delivered to the last seen destination of the connection
+// nor to the destination of the DID document. It wraps both errors.
+type fallbackError struct {
+	err         error
+	fallbackErr error
+}
+
+func (e *fallbackError) Error() string {
+	return fmt.Sprintf("%s; fallback to the DID document: %s", e.err, e.fallbackErr)
+}
+
+func (e *fallbackError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the error of the fallback matches the target, the error of the last seen destination being
+// matched through Unwrap.
+func (e *fallbackError) Is(target error) bool {
+	return errors.Is(e.fallbackErr, target)
 }
 
 // Send sends the message after packing with the sender key and recipient keys.
 func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *service.Destination) error {
-	for _, v := range o.outboundTransports {
+	return o.send(msg, senderVerKey, des, &sendOptions{})
+}
+
+// send traces the message sent, the DIDComm messages carrying the trace context of the span to the recipient.
+func (o *OutboundDispatcher) send(msg interface{}, senderVerKey string, des *service.Destination,
+	opts *sendOptions) error {
+	if err := o.begin(); err != nil {
+		return err
+	}
+
+	defer o.sending.Done()
+
+	if err := o.waitTurn(des.ServiceEndpoint, opts.connectionID); err != nil {
+		return err
+	}
+
+	msgMap, _ := msg.(service.DIDCommMsgMap) // nolint: errcheck
+
+	span := o.tracing.Start(msgMap, tracing.SendSpan)
+	span.SetAttributes(tracing.Attributes{tracing.TransportAttribute: transportName(des.ServiceEndpoint)})
+
+	err := o.sendMessage(msg, senderVerKey, des, opts)
+
+	span.End(err)
+
+	return err
+}
+
+func (o *OutboundDispatcher) sendMessage(msg interface{}, senderVerKey string, des *service.Destination,
+	opts *sendOptions) error {
+	for _, v := range o.orderTransports(opts.preferredTransport) {
 		// check if outbound accepts routing keys, else use recipient keys
 		keys := des.RecipientKeys
 		if len(des.RoutingKeys) != 0 {
@@ -99,8 +301,12 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 			return fmt.Errorf("outboundDispatcher.Send: failed to add transport route options : %w", err)
 		}
 
-		packedMsg, err := o.packager.PackMessage(
-			&commontransport.Envelope{Message: req, FromKey: base58.Decode(senderVerKey), ToKeys: des.RecipientKeys})
+		packedMsg, err := o.packager.PackMessage(&commontransport.Envelope{
+			Message:           req,
+			FromKey:           base58.Decode(senderVerKey),
+			ToKeys:            des.RecipientKeys,
+			MediaTypeProfiles: opts.mediaTypeProfiles,
+		})
 		if err != nil {
 			return fmt.Errorf("outboundDispatcher.Send: failed to pack msg: %w", err)
 		}
@@ -114,6 +320,8 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 		}
 
 		_, err = v.Send(packedMsg, des)
+		o.metrics.Sent(transportName(des.ServiceEndpoint), err != nil)
+
 		if err != nil {
 			return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
 		}
@@ -126,6 +334,16 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 
 // Forward forwards the message without packing to the destination.
 func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination) error {
+	if err := o.begin(); err != nil {
+		return err
+	}
+
+	defer o.sending.Done()
+
+	if err := o.waitTurn(des.ServiceEndpoint, ""); err != nil {
+		return err
+	}
+
 	for _, v := range o.outboundTransports {
 		if !v.AcceptRecipient(des.RecipientKeys) {
 			if !v.Accept(des.ServiceEndpoint) {
@@ -139,6 +357,8 @@ func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination)
 		}
 
 		_, err = v.Send(req, des)
+		o.metrics.Sent(transportName(des.ServiceEndpoint), err != nil)
+
 		if err != nil {
 			return fmt.Errorf("outboundDispatcher.Forward: failed to send msg using outbound transport: %w", err)
 		}
@@ -149,6 +369,98 @@ func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination)
 	return fmt.Errorf("outboundDispatcher.Forward: no transport found for serviceEndpoint: %s", des.ServiceEndpoint)
 }
 
+// Shutdown stops sending the messages and waits until the messages being sent are delivered or ctx is done.
+// The messages sent once the dispatcher is shut down fail with ErrShutdown.
+func (o *OutboundDispatcher) Shutdown(ctx context.Context) error {
+	o.lock.Lock()
+	o.shutdown = true
+	o.lock.Unlock()
+
+	drained := make(chan struct{})
+
+	go func() {
+		o.sending.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("outbound dispatcher drain: %w", ctx.Err())
+	}
+}
+
+// begin registers a message being sent, to be done once sent.
+func (o *OutboundDispatcher) begin() error {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	if o.shutdown {
+		return ErrShutdown
+	}
+
+	o.sending.Add(1)
+
+	return nil
+}
+
+// waitTurn waits until the message to the endpoint, of the connection if any, can be sent according to the rate
+// limit of the dispatcher.
+func (o *OutboundDispatcher) waitTurn(endpoint, connectionID string) error {
+	if o.limiter == nil {
+		return nil
+	}
+
+	key := endpoint
+	if o.limiter.limit.PerConnection && connectionID != "" {
+		key = connectionID
+	}
+
+	return o.limiter.wait(key)
+}
+
+// transportName returns the transport of the metrics of the messages sent to the endpoint, i.e. its URL scheme.
+func transportName(endpoint string) string {
+	if i := strings.Index(endpoint, "://"); i > 0 {
+		return endpoint[:i]
+	}
+
+	return "unknown"
+}
+
+// getConnectionRecord returns the connection record between the given DIDs, or nil if there is none.
+func (o *OutboundDispatcher) getConnectionRecord(myDID, theirDID string) (*connection.Record, error) {
+	connectionID, err := o.connections.GetConnectionIDByDIDs(myDID, theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return o.connections.GetConnectionRecord(connectionID)
+}
+
+// orderTransports returns the outbound transports with those accepting the preferred scheme first.
+func (o *OutboundDispatcher) orderTransports(preferredTransport string) []transport.OutboundTransport {
+	if preferredTransport == "" {
+		return o.outboundTransports
+	}
+
+	transports := make([]transport.OutboundTransport, len(o.outboundTransports))
+	copy(transports, o.outboundTransports)
+
+	preferred := preferredTransport + "://"
+
+	sort.SliceStable(transports, func(i, j int) bool {
+		return transports[i].Accept(preferred) && !transports[j].Accept(preferred)
+	})
+
+	return transports
+}
+
 func (o *OutboundDispatcher) createForwardMessage(msg []byte, des *service.Destination) ([]byte, error) {
 	if len(des.RoutingKeys) == 0 {
 		return msg, nil
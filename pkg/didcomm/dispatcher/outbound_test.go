@@ -7,74 +7,119 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 func TestOutboundDispatcher_Send(t *testing.T) {
 	t.Run("test success", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{},
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
 		})
+		require.NoError(t, err)
 		require.NoError(t, o.Send("data", "", &service.Destination{ServiceEndpoint: "url"}))
 	})
 
 	t.Run("test no outbound transport found", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{},
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: false}},
 		})
-		err := o.Send("data", "", &service.Destination{ServiceEndpoint: "url"})
+		require.NoError(t, err)
+		err = o.Send("data", "", &service.Destination{ServiceEndpoint: "url"})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "outboundDispatcher.Send: no transport found for serviceEndpoint: url")
 	})
 
 	t.Run("test pack msg failure", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{PackErr: fmt.Errorf("pack error")},
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
 		})
-		err := o.Send("data", "", &service.Destination{ServiceEndpoint: "url"})
+		require.NoError(t, err)
+		err = o.Send("data", "", &service.Destination{ServiceEndpoint: "url"})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "pack error")
 	})
 
 	t.Run("test outbound send failure", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue: &mockpackager.Packager{},
 			outboundTransportsValue: []transport.OutboundTransport{
 				&mockdidcomm.MockOutboundTransport{AcceptValue: true, SendErr: fmt.Errorf("send error")},
 			},
 		})
-		err := o.Send("data", "", &service.Destination{ServiceEndpoint: "url"})
+		require.NoError(t, err)
+		err = o.Send("data", "", &service.Destination{ServiceEndpoint: "url"})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "send error")
 	})
 
+	t.Run("test send traces the message", func(t *testing.T) {
+		tracer := &mocktracing.Tracer{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue: &mockpackager.Packager{},
+			outboundTransportsValue: []transport.OutboundTransport{
+				&mockdidcomm.MockOutboundTransport{AcceptValue: true, SendErr: fmt.Errorf("send error")},
+			},
+			tracer: tracer,
+		})
+		require.NoError(t, err)
+
+		msg := service.DIDCommMsgMap{
+			"@id":             "msg-id",
+			tracing.Decorator: map[string]interface{}{"traceparent": "protocol-span"},
+		}
+
+		err = o.Send(msg, "", &service.Destination{ServiceEndpoint: "http://example.com"})
+		require.Error(t, err)
+
+		spans := tracer.Spans(tracing.SendSpan)
+		require.Len(t, spans, 1)
+		require.Equal(t, "protocol-span", spans[0].ParentID)
+		require.Equal(t, "http", spans[0].Attributes[tracing.TransportAttribute])
+		require.Equal(t, "msg-id", spans[0].Attributes[tracing.MessageIDAttribute])
+		require.Equal(t, err, spans[0].Err)
+
+		// the message carries the trace context of the span to the recipient
+		require.Equal(t, map[string]interface{}{"traceparent": spans[0].ID}, msg[tracing.Decorator])
+	})
+
 	t.Run("test send with forward message - success", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{PackValue: createPackedMsgForForward(t)},
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
 		})
+		require.NoError(t, err)
 
 		require.NoError(t, o.Send("data", "", &service.Destination{
 			ServiceEndpoint: "url",
@@ -84,15 +129,16 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 	})
 
 	t.Run("test send with forward message - create key failure", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{PackValue: createPackedMsgForForward(t)},
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
 			kms: &mockkms.KeyManager{
 				CrAndExportPubKeyErr: errors.New("create and export key error"),
 			},
 		})
+		require.NoError(t, err)
 
-		err := o.Send("data", "", &service.Destination{
+		err = o.Send("data", "", &service.Destination{
 			ServiceEndpoint: "url",
 			RecipientKeys:   []string{"abc"},
 			RoutingKeys:     []string{"xyz"},
@@ -102,12 +148,13 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 	})
 
 	t.Run("test send with forward message - packer error", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{PackErr: errors.New("pack error")},
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
 		})
+		require.NoError(t, err)
 
-		_, err := o.createForwardMessage(createPackedMsgForForward(t), &service.Destination{
+		_, err = o.createForwardMessage(createPackedMsgForForward(t), &service.Destination{
 			ServiceEndpoint: "url",
 			RecipientKeys:   []string{"abc"},
 			RoutingKeys:     []string{"xyz"},
@@ -117,12 +164,13 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 	})
 
 	t.Run("test send with forward message - envelop unmarshal error", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{},
 			outboundTransportsValue: []transport.OutboundTransport{},
 		})
+		require.NoError(t, err)
 
-		_, err := o.createForwardMessage([]byte("invalid json"), &service.Destination{
+		_, err = o.createForwardMessage([]byte("invalid json"), &service.Destination{
 			ServiceEndpoint: "url",
 			RecipientKeys:   []string{"abc"},
 			RoutingKeys:     []string{"xyz"},
@@ -136,7 +184,7 @@ func TestOutboundDispatcher_SendToDID(t *testing.T) {
 	mockDoc := mockdiddoc.GetMockDIDDoc()
 
 	t.Run("success", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue: &mockpackager.Packager{PackValue: createPackedMsgForForward(t)},
 			vdr: &mockvdr.MockVDRegistry{
 				ResolveValue: mockDoc,
@@ -145,12 +193,13 @@ func TestOutboundDispatcher_SendToDID(t *testing.T) {
 				&mockdidcomm.MockOutboundTransport{AcceptValue: true},
 			},
 		})
+		require.NoError(t, err)
 
 		require.NoError(t, o.SendToDID("data", "", ""))
 	})
 
 	t.Run("resolve err", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue: &mockpackager.Packager{},
 			vdr: &mockvdr.MockVDRegistry{
 				ResolveErr: fmt.Errorf("resolve error"),
@@ -159,13 +208,186 @@ func TestOutboundDispatcher_SendToDID(t *testing.T) {
 				&mockdidcomm.MockOutboundTransport{AcceptValue: true},
 			},
 		})
+		require.NoError(t, err)
 
-		err := o.SendToDID("data", "", "")
+		err = o.SendToDID("data", "", "")
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "resolve error")
 	})
 }
 
+func TestNewOutbound(t *testing.T) {
+	t.Run("error - open store", func(t *testing.T) {
+		_, err := NewOutbound(&mockProvider{
+			storageProvider: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open error")
+	})
+}
+
+func TestOutboundDispatcher_SendToDIDWithConnectionPreferences(t *testing.T) {
+	const (
+		myDID    = "did:example:alice"
+		theirDID = "did:example:bob"
+	)
+
+	storageProvider := mockstore.NewMockStoreProvider()
+	protocolStateStorage := mockstore.NewMockStoreProvider()
+
+	recorder, err := connection.NewRecorder(&mockProvider{
+		storageProvider:      storageProvider,
+		protocolStateStorage: protocolStateStorage,
+	})
+	require.NoError(t, err)
+
+	lastSeenKeys := []string{"did:example:bob#key-1"}
+
+	// newDispatcher returns a dispatcher sending to the last seen endpoint of the connection if any, else to the
+	// endpoint of the DID document of theirDID, with the DIDs resolved by the dispatcher.
+	newDispatcher := func(t *testing.T, lastSeenEndpoint, docEndpoint string,
+		transports ...transport.OutboundTransport) (*OutboundDispatcher, *recordingPackager, string, *[]string) {
+		t.Helper()
+
+		record := &connection.Record{
+			ConnectionID:       uuid.New().String(),
+			State:              connection.StateNameCompleted,
+			MyDID:              myDID,
+			TheirDID:           theirDID,
+			MediaTypeProfiles:  []string{commontransport.MediaTypeProfileRFC19},
+			PreferredTransport: "ws",
+		}
+
+		if lastSeenEndpoint != "" {
+			record.LastSeenEndpoint = lastSeenEndpoint
+			record.LastSeenRecipientKeys = lastSeenKeys
+		}
+
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		doc := mockdiddoc.GetMockDIDDoc()
+		doc.Service[0].ServiceEndpoint = docEndpoint
+
+		var resolved []string
+
+		packager := &recordingPackager{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue: packager,
+			vdr: &mockvdr.MockVDRegistry{ResolveFunc: func(didID string, _ ...vdrapi.ResolveOpts) (*did.Doc, error) {
+				resolved = append(resolved, didID)
+
+				return doc, nil
+			}},
+			outboundTransportsValue: transports,
+			storageProvider:         storageProvider,
+			protocolStateStorage:    protocolStateStorage,
+		})
+		require.NoError(t, err)
+
+		return o, packager, record.ConnectionID, &resolved
+	}
+
+	lastSeen := func(connectionID string) *connection.Record {
+		rec, err := recorder.GetConnectionRecord(connectionID)
+		require.NoError(t, err)
+
+		return rec
+	}
+
+	t.Run("success - honors connection preferences and saves the destination", func(t *testing.T) {
+		httpTransport := &schemeOutboundTransport{scheme: "http"}
+		wsTransport := &schemeOutboundTransport{scheme: "ws"}
+
+		o, packager, connectionID, _ := newDispatcher(t, "", "ws://bob.example.com", httpTransport, wsTransport)
+
+		require.NoError(t, o.SendToDID("data", myDID, theirDID))
+		require.Empty(t, httpTransport.endpoints)
+		require.Equal(t, []string{"ws://bob.example.com"}, wsTransport.endpoints)
+		require.Equal(t, []string{commontransport.MediaTypeProfileRFC19}, packager.mediaTypeProfiles)
+
+		// the destination is saved once sent
+		rec := lastSeen(connectionID)
+		require.Equal(t, "ws://bob.example.com", rec.LastSeenEndpoint)
+		require.NotEmpty(t, rec.LastSeenRecipientKeys)
+	})
+
+	t.Run("success - routes to the last seen destination without resolving theirDID", func(t *testing.T) {
+		wsTransport := &schemeOutboundTransport{scheme: "ws"}
+
+		o, _, connectionID, resolved := newDispatcher(t, "ws://old.example.com", "ws://new.example.com", wsTransport)
+
+		require.NoError(t, o.SendToDID("data", myDID, theirDID))
+		require.NoError(t, o.SendToDID("data", myDID, theirDID))
+		require.Equal(t, []string{"ws://old.example.com", "ws://old.example.com"}, wsTransport.endpoints)
+		require.Equal(t, []string{myDID, myDID}, *resolved)
+		require.Equal(t, "ws://old.example.com", lastSeen(connectionID).LastSeenEndpoint)
+	})
+
+	t.Run("success - resolves theirDID when the last seen destination fails", func(t *testing.T) {
+		wsTransport := &schemeOutboundTransport{scheme: "ws", failing: "ws://old.example.com"}
+
+		o, _, connectionID, resolved := newDispatcher(t, "ws://old.example.com", "ws://new.example.com", wsTransport)
+
+		require.NoError(t, o.SendToDID("data", myDID, theirDID))
+		require.Equal(t, []string{"ws://old.example.com", "ws://new.example.com"}, wsTransport.endpoints)
+		require.Equal(t, []string{myDID, theirDID}, *resolved)
+		require.Equal(t, "ws://new.example.com", lastSeen(connectionID).LastSeenEndpoint)
+	})
+
+	t.Run("error - delivery to both destinations fails", func(t *testing.T) {
+		wsTransport := &schemeOutboundTransport{scheme: "ws", failing: "ws://"}
+
+		o, _, connectionID, _ := newDispatcher(t, "ws://old.example.com", "ws://new.example.com", wsTransport)
+
+		err := o.SendToDID("data", myDID, theirDID)
+		require.EqualError(t, err, "outboundDispatcher.Send: failed to send msg using outbound transport: "+
+			"send to ws://old.example.com failed; fallback to the DID document: outboundDispatcher.Send: failed to "+
+			"send msg using outbound transport: send to ws://new.example.com failed")
+		require.Equal(t, []string{"ws://old.example.com", "ws://new.example.com"}, wsTransport.endpoints)
+		require.Equal(t, "ws://old.example.com", lastSeen(connectionID).LastSeenEndpoint)
+
+		// both errors are wrapped
+		lastSeenErr := errors.New("last seen destination error")
+
+		err = &fallbackError{err: fmt.Errorf("send: %w", lastSeenErr), fallbackErr: fmt.Errorf("send: %w", ErrShutdown)}
+		require.True(t, errors.Is(err, lastSeenErr))
+		require.True(t, errors.Is(err, ErrShutdown))
+	})
+
+	t.Run("error - the DID document has the failing last seen destination", func(t *testing.T) {
+		wsTransport := &schemeOutboundTransport{scheme: "ws", failing: "ws://bob.example.com"}
+
+		o, _, connectionID, _ := newDispatcher(t, "", "ws://bob.example.com", wsTransport)
+
+		dest, err := service.GetDestination(theirDID, o.vdRegistry)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveLastSeenDestination(connectionID, dest.ServiceEndpoint, dest.RecipientKeys,
+			dest.RoutingKeys))
+
+		err = o.SendToDID("data", myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the DID document of [did:example:bob] has no other destination")
+		require.Equal(t, []string{"ws://bob.example.com"}, wsTransport.endpoints)
+	})
+
+	t.Run("error - get connection record", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue: &recordingPackager{},
+			vdr:           &mockvdr.MockVDRegistry{ResolveValue: mockdiddoc.GetMockDIDDoc()},
+			storageProvider: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store:  make(map[string][]byte),
+				ErrGet: errors.New("get error"),
+			}),
+		})
+		require.NoError(t, err)
+
+		err = o.SendToDID("data", myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get error")
+	})
+}
+
 func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 	t.Run("transport route option - value set all", func(t *testing.T) {
 		transportReturnRoute := "all"
@@ -184,7 +406,7 @@ func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, expectedRequest)
 
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue: &mockPackager{},
 			outboundTransportsValue: []transport.OutboundTransport{
 				&mockOutboundTransport{
@@ -193,6 +415,7 @@ func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 			},
 			transportReturnRoute: transportReturnRoute,
 		})
+		require.NoError(t, err)
 
 		require.NoError(t, o.Send(req, "", &service.Destination{ServiceEndpoint: "url"}))
 	})
@@ -214,7 +437,7 @@ func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, expectedRequest)
 
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue: &mockPackager{},
 			outboundTransportsValue: []transport.OutboundTransport{
 				&mockOutboundTransport{
@@ -223,6 +446,7 @@ func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 			},
 			transportReturnRoute: transportReturnRoute,
 		})
+		require.NoError(t, err)
 
 		require.NoError(t, o.Send(req, "", &service.Destination{ServiceEndpoint: "url"}))
 	})
@@ -236,7 +460,7 @@ func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, expectedRequest)
 
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue: &mockPackager{},
 			outboundTransportsValue: []transport.OutboundTransport{
 				&mockOutboundTransport{
@@ -245,16 +469,18 @@ func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 			},
 			transportReturnRoute: "",
 		})
+		require.NoError(t, err)
 
 		require.NoError(t, o.Send(req, "", &service.Destination{ServiceEndpoint: "url"}))
 	})
 
 	t.Run("transport route option - forward message", func(t *testing.T) {
 		transportReturnRoute := "thread"
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:        &mockPackager{},
 			transportReturnRoute: transportReturnRoute,
 		})
+		require.NoError(t, err)
 
 		testData := []byte("testData")
 
@@ -266,36 +492,91 @@ func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 
 func TestOutboundDispatcher_Forward(t *testing.T) {
 	t.Run("test forward - success", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{},
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
 		})
+		require.NoError(t, err)
 		require.NoError(t, o.Forward("data", &service.Destination{ServiceEndpoint: "url"}))
 	})
 
 	t.Run("test forward - no outbound transport found", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{},
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: false}},
 		})
-		err := o.Forward("data", &service.Destination{ServiceEndpoint: "url"})
+		require.NoError(t, err)
+		err = o.Forward("data", &service.Destination{ServiceEndpoint: "url"})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "outboundDispatcher.Forward: no transport found for serviceEndpoint: url")
 	})
 
 	t.Run("test forward - outbound send failure", func(t *testing.T) {
-		o := NewOutbound(&mockProvider{
+		o, err := NewOutbound(&mockProvider{
 			packagerValue: &mockpackager.Packager{},
 			outboundTransportsValue: []transport.OutboundTransport{
 				&mockdidcomm.MockOutboundTransport{AcceptValue: true, SendErr: fmt.Errorf("send error")},
 			},
 		})
-		err := o.Forward("data", &service.Destination{ServiceEndpoint: "url"})
+		require.NoError(t, err)
+		err = o.Forward("data", &service.Destination{ServiceEndpoint: "url"})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "send error")
 	})
 }
 
+func TestOutboundDispatcher_Shutdown(t *testing.T) {
+	t.Run("test shutdown drains the messages being sent", func(t *testing.T) {
+		outbound := &blockingOutboundTransport{sending: make(chan struct{}), release: make(chan struct{})}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockpackager.Packager{},
+			outboundTransportsValue: []transport.OutboundTransport{outbound},
+		})
+		require.NoError(t, err)
+
+		sent := make(chan error)
+
+		go func() {
+			sent <- o.Send("data", "", &service.Destination{ServiceEndpoint: "url"})
+		}()
+
+		<-outbound.sending
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err = o.Shutdown(ctx)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		require.True(t, errors.Is(o.Send("data", "", &service.Destination{ServiceEndpoint: "url"}), ErrShutdown))
+		require.True(t, errors.Is(o.Forward("data", &service.Destination{ServiceEndpoint: "url"}), ErrShutdown))
+
+		close(outbound.release)
+
+		require.NoError(t, o.Shutdown(context.Background()))
+		require.NoError(t, <-sent)
+	})
+}
+
+// blockingOutboundTransport blocks the messages sent until released.
+type blockingOutboundTransport struct {
+	mockdidcomm.MockOutboundTransport
+	sending chan struct{}
+	release chan struct{}
+}
+
+func (o *blockingOutboundTransport) Send([]byte, *service.Destination) (string, error) {
+	o.sending <- struct{}{}
+	<-o.release
+
+	return "", nil
+}
+
+func (o *blockingOutboundTransport) Accept(string) bool {
+	return true
+}
+
 func createPackedMsgForForward(t *testing.T) []byte {
 	packedMsg := &model.Envelope{}
 
@@ -312,6 +593,13 @@ type mockProvider struct {
 	transportReturnRoute    string
 	vdr                     vdrapi.Registry
 	kms                     kms.KeyManager
+	storageProvider         storage.Provider
+	protocolStateStorage    storage.Provider
+	tracer                  tracing.Tracer
+}
+
+func (p *mockProvider) Tracer() tracing.Tracer {
+	return p.tracer
 }
 
 func (p *mockProvider) Packager() commontransport.Packager {
@@ -338,6 +626,22 @@ func (p *mockProvider) KMS() kms.KeyManager {
 	return &mockkms.KeyManager{}
 }
 
+func (p *mockProvider) StorageProvider() storage.Provider {
+	if p.storageProvider != nil {
+		return p.storageProvider
+	}
+
+	return mockstore.NewMockStoreProvider()
+}
+
+func (p *mockProvider) ProtocolStateStorageProvider() storage.Provider {
+	if p.protocolStateStorage != nil {
+		return p.protocolStateStorage
+	}
+
+	return mockstore.NewMockStoreProvider()
+}
+
 // mockOutboundTransport mock outbound transport.
 type mockOutboundTransport struct {
 	expectedRequest string
@@ -364,6 +668,53 @@ func (o *mockOutboundTransport) Accept(url string) bool {
 	return true
 }
 
+// schemeOutboundTransport accepts endpoints with the given scheme and records the endpoints it sent to, failing to
+// send to the endpoints with the failing prefix if set.
+type schemeOutboundTransport struct {
+	scheme    string
+	failing   string
+	endpoints []string
+}
+
+func (o *schemeOutboundTransport) Start(prov transport.Provider) error {
+	return nil
+}
+
+func (o *schemeOutboundTransport) Send(data []byte, destination *service.Destination) (string, error) {
+	o.endpoints = append(o.endpoints, destination.ServiceEndpoint)
+
+	if o.failing != "" && strings.HasPrefix(destination.ServiceEndpoint, o.failing) {
+		return "", fmt.Errorf("send to %s failed", destination.ServiceEndpoint)
+	}
+
+	return "", nil
+}
+
+func (o *schemeOutboundTransport) AcceptRecipient([]string) bool {
+	return false
+}
+
+func (o *schemeOutboundTransport) Accept(url string) bool {
+	return strings.HasPrefix(url, o.scheme)
+}
+
+// recordingPackager records the media type profiles of the first packed envelope.
+type recordingPackager struct {
+	mediaTypeProfiles []string
+}
+
+func (m *recordingPackager) PackMessage(e *commontransport.Envelope) ([]byte, error) {
+	if m.mediaTypeProfiles == nil {
+		m.mediaTypeProfiles = e.MediaTypeProfiles
+	}
+
+	return []byte("{}"), nil
+}
+
+func (m *recordingPackager) UnpackMessage(encMessage []byte) (*commontransport.Envelope, error) {
+	return nil, nil
+}
+
 // mockPackager mock packager.
 type mockPackager struct {
 }
@@ -0,0 +1,135 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+)
+
+// maxIdleBuckets is the number of rate limit buckets above which the buckets of the idle counterparties are dropped.
+const maxIdleBuckets = 1024
+
+// ErrRateLimited is returned by the outbound dispatcher for the messages which would wait longer than the maximum
+// delay of the rate limit to be sent.
+var ErrRateLimited = errors.New("outbound rate limit exceeded")
+
+// RateLimit is the politeness policy of the outbound dispatcher: the messages are sent to each counterparty (each
+// endpoint, or each connection) at a bounded rate, so that a bulk sender (e.g. an issuer) doesn't hammer the agent
+// or the mediator of the counterparty into throttling it. The messages exceeding the rate aren't dropped, they're
+// queued and delayed until their turn: the sending call blocks meanwhile.
+type RateLimit struct {
+	// Rate is the number of messages per second sent to a counterparty, zero disables the rate limit.
+	Rate float64
+	// Burst is the number of messages sent at once to a counterparty before the rate applies, 1 if zero.
+	Burst int
+	// MaxDelay is the maximum time a message waits to be sent, the message fails with ErrRateLimited if it would
+	// wait longer. Zero means the messages wait as long as needed.
+	MaxDelay time.Duration
+	// PerConnection limits the rate of the messages of each connection (sent with SendToDID), instead of the rate of
+	// the messages sent to each endpoint. The messages sent without a connection are limited per endpoint.
+	PerConnection bool
+}
+
+// OutboundOpt configures the outbound dispatcher.
+type OutboundOpt func(o *OutboundDispatcher)
+
+// WithRateLimit limits the rate of the messages sent to each counterparty, see RateLimit.
+func WithRateLimit(limit RateLimit) OutboundOpt {
+	return func(o *OutboundDispatcher) {
+		if limit.Rate > 0 {
+			o.limiter = newRateLimiter(limit, o.clock)
+		}
+	}
+}
+
+// rateLimiter delays the messages sent to a counterparty so that they're sent at the rate of the limit. It tracks
+// the theoretical arrival time (TAT) of the next message of each counterparty, the generic cell rate algorithm.
+type rateLimiter struct {
+	limit    RateLimit
+	interval time.Duration
+	clock    clock.Clock
+	sleep    func(d time.Duration)
+
+	lock    sync.Mutex
+	buckets map[string]time.Time
+}
+
+func newRateLimiter(limit RateLimit, c clock.Clock) *rateLimiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	return &rateLimiter{
+		limit:    limit,
+		interval: time.Duration(float64(time.Second) / limit.Rate),
+		clock:    c,
+		sleep:    time.Sleep,
+		buckets:  map[string]time.Time{},
+	}
+}
+
+// wait blocks until the message to the counterparty can be sent.
+func (l *rateLimiter) wait(key string) error {
+	delay, err := l.reserve(key)
+	if err != nil {
+		return err
+	}
+
+	if delay > 0 {
+		logger.Debugf("delaying outbound message to %s by %s", key, delay)
+
+		l.sleep(delay)
+	}
+
+	return nil
+}
+
+// reserve reserves the next slot of the counterparty, returning the time to wait until the slot.
+func (l *rateLimiter) reserve(key string) (time.Duration, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Now()
+
+	tat, ok := l.buckets[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	tat = tat.Add(l.interval)
+
+	delay := tat.Sub(now) - time.Duration(l.limit.Burst)*l.interval
+	if delay < 0 {
+		delay = 0
+	}
+
+	if l.limit.MaxDelay > 0 && delay > l.limit.MaxDelay {
+		return 0, fmt.Errorf("%w: %s would wait %s", ErrRateLimited, key, delay)
+	}
+
+	if !ok && len(l.buckets) >= maxIdleBuckets {
+		l.dropIdle(now)
+	}
+
+	l.buckets[key] = tat
+
+	return delay, nil
+}
+
+// dropIdle drops the buckets of the counterparties which didn't get messages for long enough to be sent a burst.
+func (l *rateLimiter) dropIdle(now time.Time) {
+	for key, tat := range l.buckets {
+		if !tat.After(now) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+)
+
+func TestRateLimiter(t *testing.T) {
+	epoch := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("delays the messages exceeding the rate", func(t *testing.T) {
+		clock := mockclock.New(epoch)
+		l := newRateLimiter(RateLimit{Rate: 10, Burst: 2}, clock)
+
+		for _, expected := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond} {
+			delay, err := l.reserve("a")
+			require.NoError(t, err)
+			require.Equal(t, expected, delay)
+		}
+
+		// the counterparties are limited independently
+		delay, err := l.reserve("b")
+		require.NoError(t, err)
+		require.Zero(t, delay)
+
+		// the burst is available again once the queued messages are sent
+		clock.Add(time.Second)
+
+		delay, err = l.reserve("a")
+		require.NoError(t, err)
+		require.Zero(t, delay)
+	})
+
+	t.Run("maximum delay", func(t *testing.T) {
+		l := newRateLimiter(RateLimit{Rate: 10, MaxDelay: 150 * time.Millisecond}, mockclock.New(epoch))
+
+		for _, expected := range []time.Duration{0, 100 * time.Millisecond} {
+			delay, err := l.reserve("a")
+			require.NoError(t, err)
+			require.Equal(t, expected, delay)
+		}
+
+		_, err := l.reserve("a")
+		require.True(t, errors.Is(err, ErrRateLimited))
+		require.EqualError(t, err, "outbound rate limit exceeded: a would wait 200ms")
+	})
+
+	t.Run("drops the idle buckets", func(t *testing.T) {
+		clock := mockclock.New(epoch)
+		l := newRateLimiter(RateLimit{Rate: 10}, clock)
+
+		for i := 0; i < maxIdleBuckets; i++ {
+			_, err := l.reserve(fmt.Sprint(i))
+			require.NoError(t, err)
+		}
+
+		clock.Add(time.Second)
+
+		_, err := l.reserve("a")
+		require.NoError(t, err)
+		require.Len(t, l.buckets, 1)
+	})
+}
+
+func TestOutboundDispatcher_RateLimit(t *testing.T) {
+	newDispatcher := func(t *testing.T, limit RateLimit) (*OutboundDispatcher, *[]time.Duration) {
+		t.Helper()
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockpackager.Packager{},
+			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
+		}, WithRateLimit(limit))
+		require.NoError(t, err)
+
+		clock := mockclock.New(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+		var delays []time.Duration
+
+		o.limiter.clock = clock
+		o.limiter.sleep = func(d time.Duration) {
+			delays = append(delays, d)
+		}
+
+		return o, &delays
+	}
+
+	t.Run("per endpoint", func(t *testing.T) {
+		o, delays := newDispatcher(t, RateLimit{Rate: 10})
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, o.Send("data", "", &service.Destination{ServiceEndpoint: "https://mediator.example.com"}))
+		}
+
+		require.NoError(t, o.Forward("data", &service.Destination{ServiceEndpoint: "https://other.example.com"}))
+		require.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, *delays)
+	})
+
+	t.Run("per connection", func(t *testing.T) {
+		o, delays := newDispatcher(t, RateLimit{Rate: 10, PerConnection: true})
+
+		require.NoError(t, o.waitTurn("https://mediator.example.com", "connection-1"))
+		require.NoError(t, o.waitTurn("https://mediator.example.com", "connection-2"))
+		require.Empty(t, *delays)
+
+		require.NoError(t, o.waitTurn("https://mediator.example.com", ""))
+		require.NoError(t, o.waitTurn("https://mediator.example.com", ""))
+		require.Equal(t, []time.Duration{100 * time.Millisecond}, *delays)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		o, _ := newDispatcher(t, RateLimit{Rate: 10, MaxDelay: time.Millisecond})
+
+		des := &service.Destination{ServiceEndpoint: "https://mediator.example.com"}
+
+		require.NoError(t, o.Send("data", "", des))
+		require.True(t, errors.Is(o.Send("data", "", des), ErrRateLimited))
+		require.True(t, errors.Is(o.Forward("data", des), ErrRateLimited))
+	})
+
+	t.Run("no rate limit", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{}, WithRateLimit(RateLimit{}))
+		require.NoError(t, err)
+		require.Nil(t, o.limiter)
+		require.NoError(t, o.waitTurn("https://mediator.example.com", ""))
+	})
+}
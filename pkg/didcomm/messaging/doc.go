@@ -18,4 +18,7 @@
 // This package also provides custom message service implementation under `service` package,
 // one which is `service/http` for `http-over-didcomm` service.
 // (RFC Reference : https://github.com/hyperledger/aries-rfcs/blob/master/features/0335-http-over-didcomm/README.md)
+//
+// The `service/approuter` message service dispatches the incoming messages to application handlers by their type and
+// goal code patterns, so that custom protocols can be prototyped without implementing a protocol service.
 package messaging
@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package approuter provides a message service routing the incoming DIDComm messages to application handlers by
+// their type and goal code, so that custom protocols can be prototyped without implementing a protocol service.
+//
+// Like the other message services, the router only receives the messages of the types not handled by any of the
+// protocol services of the framework:
+//
+//	router, err := approuter.New("loyalty", ctx.Messenger())
+//	router.Handle("https://example.org/loyalty/1.0/*", func(req *approuter.Request) error {
+//	    return req.Reply(service.NewDIDCommMsgMap(&ack{Type: "https://example.org/loyalty/1.0/ack"}))
+//	})
+//	router.HandleGoalCode("https://example.org/loyalty/1.0/offer", "acme.loyalty.*", handleOffer)
+//	err = registrar.Register(router)
+package approuter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+const (
+	// Wildcard ends the patterns matching all the values with the given prefix, e.g. "https://example.org/app/1.0/*"
+	// matches all the message types of the protocol and "*" matches all the message types.
+	Wildcard = "*"
+
+	appRouter = "appRouter"
+)
+
+var logger = log.New("aries-framework/approuter")
+
+// ErrNoRoute is returned when no route matches the type and the goal code of the incoming message.
+var ErrNoRoute = errors.New("no route found for the message")
+
+// Handler handles the incoming messages of the route it was registered for.
+type Handler func(req *Request) error
+
+// Request is an incoming message routed to an application handler.
+type Request struct {
+	// Message is the incoming message.
+	Message service.DIDCommMsgMap
+	// GoalCode is the goal code of the incoming message, if any.
+	GoalCode string
+	// MyDID is the DID of the agent receiving the message.
+	MyDID string
+	// TheirDID is the DID of the agent sending the message.
+	TheirDID string
+
+	messenger service.Messenger
+}
+
+// Reply sends the message to the sender of the request on the thread of the request.
+func (r *Request) Reply(msg service.DIDCommMsgMap) error {
+	return r.messenger.ReplyToMsg(r.Message, msg, r.MyDID, r.TheirDID)
+}
+
+// Send sends the message to the sender of the request on a new thread.
+func (r *Request) Send(msg service.DIDCommMsgMap) error {
+	return r.messenger.Send(msg, r.MyDID, r.TheirDID)
+}
+
+type route struct {
+	msgType  string
+	goalCode string
+	handler  Handler
+}
+
+// Router is a message service dispatching the incoming messages to the application handlers of their routes.
+type Router struct {
+	name      string
+	messenger service.Messenger
+
+	mu     sync.RWMutex
+	routes []route
+}
+
+// New returns a new router with the given message service name, the messenger is used to reply to the requests.
+func New(name string, messenger service.Messenger) (*Router, error) {
+	if name == "" || messenger == nil {
+		return nil, errors.New("router name and messenger are mandatory")
+	}
+
+	return &Router{name: name, messenger: messenger}, nil
+}
+
+// Handle registers the handler of the messages of the given type, which can end with a wildcard. Registering
+// a type again replaces its handler.
+func (r *Router) Handle(msgType string, handler Handler) {
+	r.HandleGoalCode(msgType, "", handler)
+}
+
+// HandleGoalCode registers the handler of the messages of the given type with the given goal code, both can end with
+// a wildcard. The routes with a goal code take precedence over the routes without one, so that e.g. the messages of
+// a protocol can be dispatched to different handlers depending on their goal. Registering a route again replaces
+// its handler.
+func (r *Router) HandleGoalCode(msgType, goalCode string, handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.routes {
+		if r.routes[i].msgType == msgType && r.routes[i].goalCode == goalCode {
+			r.routes[i].handler = handler
+
+			return
+		}
+	}
+
+	r.routes = append(r.routes, route{msgType: msgType, goalCode: goalCode, handler: handler})
+}
+
+// Remove removes the route of the given type and goal code, an empty goal code for the route registered with Handle.
+func (r *Router) Remove(msgType, goalCode string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.routes {
+		if r.routes[i].msgType == msgType && r.routes[i].goalCode == goalCode {
+			r.routes = append(r.routes[:i], r.routes[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Name of the router message service.
+func (r *Router) Name() string {
+	return r.name
+}
+
+// Accept accepts the messages of the types matched by a route. The goal code of a message is only known once
+// the message is handled, the messages of a type only matched by routes with a goal code fail with ErrNoRoute if
+// their goal code matches none of them.
+func (r *Router) Accept(msgType string, _ []string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, rt := range r.routes {
+		if match(rt.msgType, msgType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HandleInbound dispatches the message to the handler of the most specific route matching its type and goal code.
+func (r *Router) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	req := &Request{
+		Message:   msg.Clone(),
+		GoalCode:  service.GoalCode(msg),
+		MyDID:     myDID,
+		TheirDID:  theirDID,
+		messenger: r.messenger,
+	}
+
+	handler, ok := r.handler(msg.Type(), req.GoalCode)
+	if !ok {
+		return "", fmt.Errorf("%w: type [%s] goal code [%s]", ErrNoRoute, msg.Type(), req.GoalCode)
+	}
+
+	logutil.LogDebug(logger, appRouter, "handleInbound", "received",
+		logutil.CreateKeyValueString("msgType", msg.Type()),
+		logutil.CreateKeyValueString("msgID", msg.ID()))
+
+	return "", handler(req)
+}
+
+// handler returns the handler of the most specific route: the routes with a goal code take precedence, then the
+// exact patterns over the wildcard ones and the longest wildcard prefixes over the shorter ones.
+func (r *Router) handler(msgType, goalCode string) (Handler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		handler   Handler
+		bestScore = -1
+	)
+
+	for _, rt := range r.routes {
+		if !match(rt.msgType, msgType) {
+			continue
+		}
+
+		score := specificity(rt.msgType)
+
+		if rt.goalCode != "" {
+			if goalCode == "" || !match(rt.goalCode, goalCode) {
+				continue
+			}
+
+			// any route with a goal code is more specific than the routes without one
+			score += len(msgType) + 2 + specificity(rt.goalCode)
+		}
+
+		if score > bestScore {
+			bestScore, handler = score, rt.handler
+		}
+	}
+
+	return handler, handler != nil
+}
+
+// match reports whether the value matches the pattern, which can end with a wildcard.
+func match(pattern, value string) bool {
+	if strings.HasSuffix(pattern, Wildcard) {
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, Wildcard))
+	}
+
+	return pattern == value
+}
+
+// specificity ranks the patterns matching a value: an exact pattern ranks above all the wildcard ones.
+func specificity(pattern string) int {
+	if strings.HasSuffix(pattern, Wildcard) {
+		return len(pattern) - len(Wildcard)
+	}
+
+	return len(pattern) + 1
+}
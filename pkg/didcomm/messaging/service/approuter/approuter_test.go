@@ -0,0 +1,168 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package approuter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+)
+
+const (
+	myDID    = "did:example:alice"
+	theirDID = "did:example:bob"
+
+	offerType = "https://example.org/loyalty/1.0/offer"
+)
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router, err := New("loyalty", serviceMocks.NewMockMessenger(ctrl))
+	require.NoError(t, err)
+	require.Equal(t, "loyalty", router.Name())
+
+	_, err = New("", serviceMocks.NewMockMessenger(ctrl))
+	require.EqualError(t, err, "router name and messenger are mandatory")
+
+	_, err = New("loyalty", nil)
+	require.EqualError(t, err, "router name and messenger are mandatory")
+}
+
+func TestRouter_Accept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router, err := New("loyalty", serviceMocks.NewMockMessenger(ctrl))
+	require.NoError(t, err)
+
+	require.False(t, router.Accept(offerType, nil))
+
+	router.Handle("https://example.org/loyalty/1.0/*", recordingHandler(nil))
+	router.HandleGoalCode("https://example.org/rewards/1.0/claim", "acme.rewards.*", recordingHandler(nil))
+
+	require.True(t, router.Accept(offerType, nil))
+	require.True(t, router.Accept("https://example.org/loyalty/1.0/ack", []string{"purpose"}))
+	require.True(t, router.Accept("https://example.org/rewards/1.0/claim", nil))
+	require.False(t, router.Accept("https://example.org/rewards/1.0/ack", nil))
+	require.False(t, router.Accept("https://didcomm.org/basicmessage/1.0/message", nil))
+
+	router.Remove("https://example.org/loyalty/1.0/*", "")
+	require.False(t, router.Accept(offerType, nil))
+
+	router.Handle(Wildcard, recordingHandler(nil))
+	require.True(t, router.Accept("https://didcomm.org/basicmessage/1.0/message", nil))
+}
+
+func TestRouter_HandleInbound(t *testing.T) {
+	t.Run("dispatches to the most specific route", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		router, err := New("loyalty", serviceMocks.NewMockMessenger(ctrl))
+		require.NoError(t, err)
+
+		var routed []string
+
+		router.Handle(Wildcard, recordingHandler(&routed, "any"))
+		router.Handle("https://example.org/loyalty/1.0/*", recordingHandler(&routed, "protocol"))
+		router.Handle(offerType, recordingHandler(&routed, "type"))
+		router.HandleGoalCode("https://example.org/loyalty/*", "acme.loyalty.*", recordingHandler(&routed, "goal"))
+		router.HandleGoalCode("https://example.org/loyalty/*", "acme.loyalty.gold", recordingHandler(&routed, "gold"))
+
+		for _, msg := range []service.DIDCommMsgMap{
+			{"@id": "1", "@type": offerType},
+			{"@id": "2", "@type": "https://example.org/loyalty/1.0/ack"},
+			{"@id": "3", "@type": "https://example.org/rewards/1.0/claim"},
+			{"@id": "4", "@type": offerType, "goal_code": "acme.loyalty.silver"},
+			{"@id": "5", "@type": offerType, "body": map[string]interface{}{"goal_code": "acme.loyalty.gold"}},
+			{"@id": "6", "@type": offerType, "goal_code": "acme.rewards"},
+		} {
+			_, err = router.HandleInbound(msg, myDID, theirDID)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, []string{"type", "protocol", "any", "goal", "gold", "type"}, routed)
+	})
+
+	t.Run("passes the message to the handler", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		router, err := New("loyalty", serviceMocks.NewMockMessenger(ctrl))
+		require.NoError(t, err)
+
+		msg := service.DIDCommMsgMap{"@id": "1", "@type": offerType, "goal_code": "acme.loyalty.gold"}
+
+		router.Handle(offerType, func(req *Request) error {
+			require.Equal(t, msg, req.Message)
+			require.Equal(t, "acme.loyalty.gold", req.GoalCode)
+			require.Equal(t, myDID, req.MyDID)
+			require.Equal(t, theirDID, req.TheirDID)
+
+			return errors.New("handler error")
+		})
+
+		_, err = router.HandleInbound(msg, myDID, theirDID)
+		require.EqualError(t, err, "handler error")
+	})
+
+	t.Run("no route found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		router, err := New("loyalty", serviceMocks.NewMockMessenger(ctrl))
+		require.NoError(t, err)
+
+		router.HandleGoalCode(offerType, "acme.loyalty.*", recordingHandler(nil))
+
+		_, err = router.HandleInbound(service.DIDCommMsgMap{"@id": "1", "@type": offerType}, myDID, theirDID)
+		require.True(t, errors.Is(err, ErrNoRoute))
+		require.Contains(t, err.Error(), "type [https://example.org/loyalty/1.0/offer] goal code []")
+	})
+}
+
+func TestRequest_Reply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msg := service.DIDCommMsgMap{"@id": "1", "@type": offerType}
+	reply := service.DIDCommMsgMap{"@type": "https://example.org/loyalty/1.0/ack"}
+	other := service.DIDCommMsgMap{"@type": "https://example.org/loyalty/1.0/status"}
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyToMsg(msg, reply, myDID, theirDID).Return(nil)
+	messenger.EXPECT().Send(other, myDID, theirDID).Return(errors.New("send error"))
+
+	router, err := New("loyalty", messenger)
+	require.NoError(t, err)
+
+	router.Handle(offerType, func(req *Request) error {
+		require.NoError(t, req.Reply(reply))
+
+		return req.Send(other)
+	})
+
+	_, err = router.HandleInbound(msg, myDID, theirDID)
+	require.EqualError(t, err, "send error")
+}
+
+func recordingHandler(routed *[]string, name ...string) Handler {
+	return func(*Request) error {
+		if routed != nil {
+			*routed = append(*routed, name...)
+		}
+
+		return nil
+	}
+}
@@ -9,7 +9,11 @@
 
 package basic
 
-import "time"
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
 
 // Message is message model for basic message protocol
 // Reference:
@@ -20,6 +24,21 @@ type Message struct {
 	I10n struct {
 		Locale string `json:"locale"`
 	} `json:"~l10n"`
-	SentTime time.Time `json:"sent_time"`
-	Content  string    `json:"content"`
+	SentTime time.Time         `json:"sent_time"`
+	Content  string            `json:"content"`
+	Thread   *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Record is a basic message persisted for a connection.
+type Record struct {
+	ConnectionID string `json:"connection_id"`
+	// ThreadID groups the messages of a conversation, it is the ID of the first message of the thread.
+	ThreadID string `json:"thread_id"`
+	// Inbound is true for the messages received from the other party.
+	Inbound bool `json:"inbound"`
+	// Read is false for inbound messages until they are marked as read.
+	Read bool `json:"read"`
+	// Time the message was sent or received by the agent.
+	Time    time.Time `json:"time"`
+	Message Message   `json:"message"`
 }
@@ -0,0 +1,234 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package basic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// StoreNamespace is the namespace of the basic message store.
+	StoreNamespace = "basicmessage"
+
+	messageKeyPattern = "bmsg_%s_%s"
+)
+
+type storageProvider interface {
+	StorageProvider() storage.Provider
+}
+
+// QueryOption is an option for querying the stored basic messages.
+type QueryOption func(opts *queryOpts)
+
+type queryOpts struct {
+	threadID   string
+	unreadOnly bool
+	offset     int
+	limit      int
+}
+
+// WithThreadID returns the messages of given thread only.
+func WithThreadID(threadID string) QueryOption {
+	return func(opts *queryOpts) {
+		opts.threadID = threadID
+	}
+}
+
+// WithUnreadOnly returns the unread messages only.
+func WithUnreadOnly() QueryOption {
+	return func(opts *queryOpts) {
+		opts.unreadOnly = true
+	}
+}
+
+// WithPage returns at most limit messages, skipping the offset most recent ones.
+// A limit of zero returns all the messages.
+func WithPage(offset, limit int) QueryOption {
+	return func(opts *queryOpts) {
+		opts.offset = offset
+		opts.limit = limit
+	}
+}
+
+// Page is a page of stored basic messages, ordered from the most recent one.
+type Page struct {
+	Records []*Record `json:"records"`
+	// Total number of messages matching the query.
+	Total int `json:"total"`
+}
+
+// Store persists basic messages per connection.
+type Store struct {
+	store storage.Store
+}
+
+// NewStore returns a new basic message store.
+func NewStore(p storageProvider) (*Store, error) {
+	store, err := p.StorageProvider().OpenStore(StoreNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open basic message store: %w", err)
+	}
+
+	return &Store{store: store}, nil
+}
+
+// Save saves the basic message record, overwriting the record of the same connection and message ID.
+func (s *Store) Save(record *Record) error {
+	if record.ConnectionID == "" || record.Message.ID == "" {
+		return errors.New("connection ID and message ID are mandatory")
+	}
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal basic message record: %w", err)
+	}
+
+	return s.store.Put(messageKey(record.ConnectionID, record.Message.ID), recordBytes)
+}
+
+// Get returns the basic message record of given connection and message ID.
+func (s *Store) Get(connectionID, msgID string) (*Record, error) {
+	recordBytes, err := s.store.Get(messageKey(connectionID, msgID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get basic message record: %w", err)
+	}
+
+	record := &Record{}
+
+	err = json.Unmarshal(recordBytes, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal basic message record: %w", err)
+	}
+
+	return record, nil
+}
+
+// Query returns the page of basic messages of given connection matching the options.
+func (s *Store) Query(connectionID string, opts ...QueryOption) (*Page, error) {
+	qOpts := &queryOpts{}
+
+	for _, opt := range opts {
+		opt(qOpts)
+	}
+
+	records, err := s.connectionRecords(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*Record
+
+	for _, record := range records {
+		if qOpts.threadID != "" && record.ThreadID != qOpts.threadID {
+			continue
+		}
+
+		if qOpts.unreadOnly && record.Read {
+			continue
+		}
+
+		matched = append(matched, record)
+	}
+
+	page := &Page{Records: []*Record{}, Total: len(matched)}
+
+	if qOpts.offset < 0 {
+		qOpts.offset = 0
+	}
+
+	if qOpts.offset >= len(matched) {
+		return page, nil
+	}
+
+	matched = matched[qOpts.offset:]
+
+	if qOpts.limit > 0 && qOpts.limit < len(matched) {
+		matched = matched[:qOpts.limit]
+	}
+
+	page.Records = matched
+
+	return page, nil
+}
+
+// MarkRead marks the basic messages of given connection as read.
+func (s *Store) MarkRead(connectionID string, msgIDs ...string) error {
+	for _, msgID := range msgIDs {
+		record, err := s.Get(connectionID, msgID)
+		if err != nil {
+			return fmt.Errorf("mark read: %w", err)
+		}
+
+		if record.Read {
+			continue
+		}
+
+		record.Read = true
+
+		err = s.Save(record)
+		if err != nil {
+			return fmt.Errorf("mark read: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UnreadCount returns the number of unread basic messages of given connection.
+func (s *Store) UnreadCount(connectionID string) (int, error) {
+	page, err := s.Query(connectionID, WithUnreadOnly())
+	if err != nil {
+		return 0, err
+	}
+
+	return page.Total, nil
+}
+
+func (s *Store) connectionRecords(connectionID string) ([]*Record, error) {
+	searchKey := messageKey(connectionID, "")
+
+	itr := s.store.Iterator(searchKey, searchKey+storage.EndKeySuffix)
+	defer itr.Release()
+
+	var records []*Record
+
+	for itr.Next() {
+		record := &Record{}
+
+		err := json.Unmarshal(itr.Value(), record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal basic message record: %w", err)
+		}
+
+		// the key prefix of a connection can be the prefix of another connection's keys
+		if record.ConnectionID != connectionID {
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	if err := itr.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate basic message records: %w", err)
+	}
+
+	// most recent first
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.After(records[j].Time)
+	})
+
+	return records, nil
+}
+
+func messageKey(connectionID, msgID string) string {
+	return fmt.Sprintf(messageKeyPattern, connectionID, msgID)
+}
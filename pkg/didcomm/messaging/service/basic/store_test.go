@@ -0,0 +1,198 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package basic
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	connectionID      = "connection-id"
+	otherConnectionID = "other-connection-id"
+)
+
+func TestNewStore(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()})
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := NewStore(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}})
+		require.EqualError(t, err, "failed to open basic message store: open error")
+	})
+}
+
+func TestStore_Query(t *testing.T) {
+	s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()})
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	// 5 messages in two threads, every other message is inbound
+	for i := 0; i < 5; i++ {
+		thID := "thread-1"
+		if i >= 3 {
+			thID = "thread-2"
+		}
+
+		require.NoError(t, s.Save(&Record{
+			ConnectionID: connectionID,
+			ThreadID:     thID,
+			Inbound:      i%2 == 0,
+			Read:         i%2 != 0,
+			Time:         start.Add(time.Duration(i) * time.Second),
+			Message:      Message{ID: fmt.Sprintf("msg-%d", i), Content: "hello"},
+		}))
+	}
+
+	require.NoError(t, s.Save(&Record{
+		ConnectionID: otherConnectionID,
+		ThreadID:     "thread-3",
+		Time:         start,
+		Message:      Message{ID: "msg-other"},
+	}))
+
+	t.Run("all messages, most recent first", func(t *testing.T) {
+		page, err := s.Query(connectionID)
+		require.NoError(t, err)
+		require.Equal(t, 5, page.Total)
+		require.Len(t, page.Records, 5)
+
+		for i, record := range page.Records {
+			require.Equal(t, fmt.Sprintf("msg-%d", 4-i), record.Message.ID)
+		}
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		page, err := s.Query(connectionID, WithPage(1, 2))
+		require.NoError(t, err)
+		require.Equal(t, 5, page.Total)
+		require.Len(t, page.Records, 2)
+		require.Equal(t, "msg-3", page.Records[0].Message.ID)
+		require.Equal(t, "msg-2", page.Records[1].Message.ID)
+
+		page, err = s.Query(connectionID, WithPage(4, 2))
+		require.NoError(t, err)
+		require.Len(t, page.Records, 1)
+
+		page, err = s.Query(connectionID, WithPage(10, 2))
+		require.NoError(t, err)
+		require.Equal(t, 5, page.Total)
+		require.Empty(t, page.Records)
+
+		page, err = s.Query(connectionID, WithPage(-1, 1))
+		require.NoError(t, err)
+		require.Equal(t, "msg-4", page.Records[0].Message.ID)
+	})
+
+	t.Run("by thread", func(t *testing.T) {
+		page, err := s.Query(connectionID, WithThreadID("thread-2"))
+		require.NoError(t, err)
+		require.Equal(t, 2, page.Total)
+		require.Equal(t, "msg-4", page.Records[0].Message.ID)
+		require.Equal(t, "msg-3", page.Records[1].Message.ID)
+	})
+
+	t.Run("unread only and mark read", func(t *testing.T) {
+		count, err := s.UnreadCount(connectionID)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		page, err := s.Query(connectionID, WithUnreadOnly(), WithThreadID("thread-1"))
+		require.NoError(t, err)
+		require.Equal(t, 2, page.Total)
+
+		require.NoError(t, s.MarkRead(connectionID, "msg-0", "msg-1"))
+
+		count, err = s.UnreadCount(connectionID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		record, err := s.Get(connectionID, "msg-0")
+		require.NoError(t, err)
+		require.True(t, record.Read)
+	})
+
+	t.Run("mark read unknown message", func(t *testing.T) {
+		err := s.MarkRead(connectionID, "unknown")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("unknown connection", func(t *testing.T) {
+		page, err := s.Query("unknown")
+		require.NoError(t, err)
+		require.Zero(t, page.Total)
+		require.Empty(t, page.Records)
+	})
+}
+
+func TestStore_Errors(t *testing.T) {
+	t.Run("save without IDs", func(t *testing.T) {
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()})
+		require.NoError(t, err)
+
+		err = s.Save(&Record{ConnectionID: connectionID})
+		require.EqualError(t, err, "connection ID and message ID are mandatory")
+	})
+
+	t.Run("iterator error", func(t *testing.T) {
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstore.NewCustomMockStoreProvider(
+			&mockstore.MockStore{Store: map[string][]byte{}, ErrItr: errors.New("iterator error")},
+		)})
+		require.NoError(t, err)
+
+		_, err = s.Query(connectionID)
+		require.EqualError(t, err, "failed to iterate basic message records: iterator error")
+
+		_, err = s.UnreadCount(connectionID)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid records", func(t *testing.T) {
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstore.NewCustomMockStoreProvider(
+			&mockstore.MockStore{Store: map[string][]byte{
+				messageKey(connectionID, "msg-id"): []byte("invalid"),
+			}},
+		)})
+		require.NoError(t, err)
+
+		_, err = s.Query(connectionID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal basic message record")
+
+		_, err = s.Get(connectionID, "msg-id")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal basic message record")
+	})
+
+	t.Run("mark read save error", func(t *testing.T) {
+		store := &mockstore.MockStore{Store: map[string][]byte{}}
+		s, err := NewStore(&mockprovider.Provider{StorageProviderValue: mockstore.NewCustomMockStoreProvider(store)})
+		require.NoError(t, err)
+
+		require.NoError(t, s.Save(&Record{ConnectionID: connectionID, Message: Message{ID: "msg-id"}}))
+
+		store.ErrPut = errors.New("put error")
+
+		err = s.MarkRead(connectionID, "msg-id")
+		require.EqualError(t, err, "mark read: put error")
+	})
+}
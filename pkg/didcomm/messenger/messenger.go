@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -35,6 +36,8 @@ type record struct {
 	TheirDID       string `json:"their_did,omitempty"`
 	ThreadID       string `json:"thread_id,omitempty"`
 	ParentThreadID string `json:"parent_thread_id,omitempty"`
+	// TraceContext is the trace context decorator of the message, carried over to the replies
+	TraceContext interface{} `json:"trace_context,omitempty"`
 }
 
 // Provider contains dependencies for the Messenger.
@@ -85,6 +88,7 @@ func (m *Messenger) HandleInbound(msg service.DIDCommMsgMap, myDID, theirDID str
 		MyDID:          myDID,
 		TheirDID:       theirDID,
 		ThreadID:       thID,
+		TraceContext:   msg[tracing.Decorator],
 	})
 }
 
@@ -139,6 +143,8 @@ func (m *Messenger) ReplyTo(msgID string, msg service.DIDCommMsgMap) error {
 
 	msg[jsonThread] = thread
 
+	setTraceContext(msg, rec.TraceContext)
+
 	return m.dispatcher.SendToDID(msg, rec.MyDID, rec.TheirDID)
 }
 
@@ -166,6 +172,8 @@ func (m *Messenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID st
 
 	out[jsonThread] = thread
 
+	setTraceContext(out, in[tracing.Decorator])
+
 	return m.dispatcher.SendToDID(out, myDID, theirDID)
 }
 
@@ -177,16 +185,27 @@ func (m *Messenger) ReplyToNested(msg service.DIDCommMsgMap, opts *service.Neste
 	// fills missing fields
 	fillIfMissing(msg)
 
-	if err := m.fillNestedReplyOption(opts); err != nil {
+	traceContext, err := m.fillNestedReplyOption(opts)
+	if err != nil {
 		return fmt.Errorf("failed to prepare nested reply options: %w", err)
 	}
 
 	// sets parent threadID
 	msg[jsonThread] = map[string]interface{}{jsonParentThreadID: opts.ThreadID}
 
+	setTraceContext(msg, traceContext)
+
 	return m.dispatcher.SendToDID(msg, opts.MyDID, opts.TheirDID)
 }
 
+// setTraceContext sets the trace context decorator of the reply to the one of the message replied to, if any, for
+// the reply to be traced as a child of the handling of the message.
+func setTraceContext(msg service.DIDCommMsgMap, traceContext interface{}) {
+	if traceContext != nil {
+		msg[tracing.Decorator] = traceContext
+	}
+}
+
 // fillIfMissing populates message with common fields such as ID.
 func fillIfMissing(msg service.DIDCommMsgMap) {
 	// if ID is empty we will create a new one
@@ -220,20 +239,20 @@ func (m *Messenger) saveRecord(msgID string, rec record) error {
 	return m.store.Put(msgID, src)
 }
 
-// fillNestedReplyOption prefills missing nested reply options from record.
-func (m *Messenger) fillNestedReplyOption(opts *service.NestedReplyOpts) error {
+// fillNestedReplyOption prefills missing nested reply options from record, and returns its trace context.
+func (m *Messenger) fillNestedReplyOption(opts *service.NestedReplyOpts) (interface{}, error) {
 	if opts.ThreadID != "" && opts.TheirDID != "" && opts.MyDID != "" {
-		return nil
+		return nil, nil
 	}
 
 	if opts.MsgID == "" { // nolint: staticcheck
 		logger.Debugf("failed to prepare fill nested reply options, missing message ID")
-		return nil
+		return nil, nil
 	}
 
 	rec, err := m.getRecord(opts.MsgID) // nolint: staticcheck
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if opts.ThreadID == "" {
@@ -248,5 +267,5 @@ func (m *Messenger) fillNestedReplyOption(opts *service.NestedReplyOpts) error {
 		opts.MyDID = rec.MyDID
 	}
 
-	return nil
+	return rec.TraceContext, nil
 }
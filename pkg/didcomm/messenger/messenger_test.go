@@ -14,6 +14,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	dispatcherMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/dispatcher"
@@ -213,6 +214,31 @@ func TestMessenger_ReplyTo(t *testing.T) {
 		require.NoError(t, msgr.ReplyTo(ID, service.DIDCommMsgMap{jsonID: ID}))
 	})
 
+	t.Run("success carries the trace context", func(t *testing.T) {
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Get(ID).Return([]byte(`{"thread_id":"thID","trace_context":{"traceparent":"span"}}`), nil)
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
+
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().SendToDID(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, map[string]interface{}{"traceparent": "span"},
+					msg.(service.DIDCommMsgMap)[tracing.Decorator])
+
+				return nil
+			})
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+		provider.EXPECT().OutboundDispatcher().Return(outbound)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+		require.NoError(t, msgr.ReplyTo(ID, service.DIDCommMsgMap{jsonID: ID}))
+	})
+
 	t.Run("the message was not received", func(t *testing.T) {
 		store := storageMocks.NewMockStore(ctrl)
 		store.EXPECT().Get(ID).Return(nil, errors.New(errMsg))
@@ -368,6 +394,32 @@ func TestMessenger_ReplyToMsg(t *testing.T) {
 		}, service.DIDCommMsgMap{}, "", ""))
 	})
 
+	t.Run("success carries the trace context", func(t *testing.T) {
+		traceContext := map[string]interface{}{"traceparent": "span"}
+
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().SendToDID(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, traceContext, msg.(service.DIDCommMsgMap)[tracing.Decorator])
+
+				return nil
+			})
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+		provider.EXPECT().OutboundDispatcher().Return(outbound)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+		require.NoError(t, msgr.ReplyToMsg(service.DIDCommMsgMap{
+			jsonID:            "id",
+			tracing.Decorator: traceContext,
+		}, service.DIDCommMsgMap{}, "", ""))
+	})
+
 	t.Run("success msg without id", func(t *testing.T) {
 		outbound := dispatcherMocks.NewMockOutbound(ctrl)
 		outbound.EXPECT().SendToDID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -331,6 +331,19 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 		unpackedMsg, err = packager.UnpackMessage(packMsg)
 		require.NoError(t, err)
 		require.Equal(t, unpackedMsg.Message, []byte("msg2"))
+
+		// pack with legacy through the JWE default packager by negotiating the AIP1 media type profile
+		packMsg, err = packager.PackMessage(&transport.Envelope{
+			Message:           []byte("msg3"),
+			FromKey:           fromKey,
+			ToKeys:            []string{base58.Encode(toKey)},
+			MediaTypeProfiles: []string{"unknown/profile", transport.MediaTypeProfileAIP1},
+		})
+		require.NoError(t, err)
+
+		unpackedMsg, err = packager2.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, unpackedMsg.Message, []byte("msg3"))
 	})
 
 	t.Run("test success - dids not found", func(t *testing.T) {
@@ -23,7 +23,19 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
 )
 
-const authSuffix = "-authcrypt"
+const (
+	authSuffix         = "-authcrypt"
+	legacyEncodingType = "JWM/1.0"
+	jweEncodingType    = "didcomm-envelope-enc"
+)
+
+// mediaTypeProfilePackers maps DIDComm media type profiles to the ID of the packer producing them.
+var mediaTypeProfilePackers = map[string]string{ //nolint:gochecknoglobals
+	transport.MediaTypeProfileAIP1:      legacyEncodingType,
+	transport.MediaTypeProfileRFC19:     legacyEncodingType,
+	transport.MediaTypeProfileRFC587:    jweEncodingType + authSuffix,
+	transport.MediaTypeProfileDIDCommV2: jweEncodingType + authSuffix,
+}
 
 // Provider contains dependencies for the base packager and is typically created by using aries.Context().
 type Provider interface {
@@ -111,9 +123,11 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 		recipients = append(recipients, verKeyBytes)
 	}
 
-	// TODO find a way to dynamically select a packer based on FromKey, recipients and their types.
-	//      https://github.com/hyperledger/aries-framework-go/issues/1112 Configurable packing
-	bytes, err := bp.primaryPacker.Pack(messageEnvelope.Message, messageEnvelope.FromKey, recipients)
+	// the packer is selected by the media type profiles negotiated with the recipient, not yet by the key types
+	// of FromKey and the recipients: https://github.com/hyperledger/aries-framework-go/issues/1112
+	p := bp.selectPacker(messageEnvelope.MediaTypeProfiles)
+
+	bytes, err := p.Pack(messageEnvelope.Message, messageEnvelope.FromKey, recipients)
 	if err != nil {
 		return nil, fmt.Errorf("packMessage: failed to pack: %w", err)
 	}
@@ -121,6 +135,22 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 	return bytes, nil
 }
 
+// selectPacker returns the packer for the first supported media type profile, falling back to the primary packer.
+func (bp *Packager) selectPacker(mediaTypeProfiles []string) packer.Packer {
+	for _, profile := range mediaTypeProfiles {
+		packerID, ok := mediaTypeProfilePackers[profile]
+		if !ok {
+			continue
+		}
+
+		if p, ok := bp.packers[packerID]; ok {
+			return p
+		}
+	}
+
+	return bp.primaryPacker
+}
+
 type envelopeStub struct {
 	Protected string `json:"protected,omitempty"`
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
@@ -37,6 +38,7 @@ type Packer struct {
 	kms           kms.KeyManager
 	encAlg        jose.EncAlg
 	cryptoService cryptoapi.Crypto
+	jsonLimits    jsonutil.Limits
 }
 
 // New will create an Packer instance to 'AnonCrypt' payloads for a given list of recipients.
@@ -56,6 +58,7 @@ func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
 		kms:           k,
 		encAlg:        encAlg,
 		cryptoService: c,
+		jsonLimits:    jsonutil.LimitsOf(ctx),
 	}, nil
 }
 
@@ -116,7 +119,7 @@ func unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, error) {
 
 // Unpack will decode the envelope using a standard format.
 func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
-	jwe, err := jose.Deserialize(string(envelope))
+	jwe, err := jose.Deserialize(string(envelope), jose.WithJWEJSONLimits(p.jsonLimits))
 	if err != nil {
 		return nil, fmt.Errorf("anoncrypt Unpack: failed to deserialize JWE message: %w", err)
 	}
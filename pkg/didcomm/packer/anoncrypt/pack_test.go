@@ -8,6 +8,7 @@ package anoncrypt
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
@@ -108,6 +110,21 @@ func TestAnoncryptPackerFail(t *testing.T) {
 			"JWE: it must have five parts")
 	})
 
+	t.Run("pack success but unpack fails with the JSON limits of the provider", func(t *testing.T) {
+		provider := newMockProvider(k, cryptoSvc)
+		provider.JSONLimitsValue = &jsonutil.Limits{MaxBytes: 100}
+
+		limitedPacker, err := New(provider, jose.A256GCM)
+		require.NoError(t, err)
+
+		ct, err := limitedPacker.Pack(origMsg, nil, recipientsKeys)
+		require.NoError(t, err)
+
+		_, err = limitedPacker.Unpack(ct)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+	})
+
 	t.Run("pack success but unpack fails with missing keyID in protectedHeader", func(t *testing.T) {
 		validAnonPacker, err := New(newMockProvider(k, cryptoSvc), jose.A256GCM)
 		require.NoError(t, err)
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/prefix"
@@ -44,6 +45,7 @@ type Packer struct {
 	encAlg        jose.EncAlg
 	thirdPartyKS  storage.Store
 	cryptoService cryptoapi.Crypto
+	jsonLimits    jsonutil.Limits
 }
 
 // New will create an Packer instance to 'AuthCrypt' payloads for a given sender and list of recipients keys.
@@ -82,6 +84,7 @@ func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
 		encAlg:        encAlg,
 		thirdPartyKS:  store,
 		cryptoService: c,
+		jsonLimits:    jsonutil.LimitsOf(ctx),
 	}, nil
 }
 
@@ -151,7 +154,7 @@ func unmarshalRecipientKeys(keys [][]byte) ([]*cryptoapi.PublicKey, error) {
 
 // Unpack will decode the envelope using a standard format.
 func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
-	jwe, err := jose.Deserialize(string(envelope))
+	jwe, err := jose.Deserialize(string(envelope), jose.WithJWEJSONLimits(p.jsonLimits))
 	if err != nil {
 		return nil, fmt.Errorf("authcrypt Unpack: failed to deserialize JWE message: %w", err)
 	}
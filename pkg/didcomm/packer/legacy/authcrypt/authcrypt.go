@@ -11,6 +11,7 @@ import (
 	"io"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -18,6 +19,7 @@ import (
 type Packer struct {
 	randSource io.Reader
 	kms        kms.KeyManager
+	jsonLimits jsonutil.Limits
 }
 
 // encodingType is the `typ` string identifier in a message that identifies the format as being legacy.
@@ -31,6 +33,7 @@ func New(ctx packer.Provider) *Packer {
 	return &Packer{
 		randSource: rand.Reader,
 		kms:        k,
+		jsonLimits: jsonutil.LimitsOf(ctx),
 	}
 }
 
@@ -8,7 +8,6 @@ package authcrypt
 
 import (
 	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -16,6 +15,7 @@ import (
 	chacha "golang.org/x/crypto/chacha20poly1305"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -26,7 +26,7 @@ import (
 func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 	var envelopeData legacyEnvelope
 
-	err := json.Unmarshal(envelope, &envelopeData)
+	err := jsonutil.Unmarshal(envelope, &envelopeData, p.jsonLimits)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 
 	var protectedData protected
 
-	err = json.Unmarshal(protectedBytes, &protectedData)
+	err = jsonutil.Unmarshal(protectedBytes, &protectedData, p.jsonLimits)
 	if err != nil {
 		return nil, err
 	}
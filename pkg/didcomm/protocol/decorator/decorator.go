@@ -92,6 +92,20 @@ type AttachmentData struct {
 	// JSON is a directly embedded JSON data, when representing content inline instead of via links,
 	// and when the content is natively conveyable as JSON. Optional.
 	JSON interface{} `json:"json,omitempty"`
+	// JWS is a JSON web signature over the content of the attachment. Optional.
+	// https://github.com/hyperledger/aries-rfcs/tree/master/concepts/0017-attachments#signing-attachments
+	JWS *AttachmentJWS `json:"jws,omitempty"`
+}
+
+// AttachmentJWS is a detached JSON web signature of the attachment, the signed payload is the base64URL encoded
+// content of the attachment.
+type AttachmentJWS struct {
+	// Header is the unprotected JWS header.
+	Header map[string]interface{} `json:"header,omitempty"`
+	// Protected is the base64URL encoded protected JWS header.
+	Protected string `json:"protected,omitempty"`
+	// Signature is the base64URL encoded signature.
+	Signature string `json:"signature,omitempty"`
 }
 
 // Fetch this attachment's contents.
@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didexchange
+
+import (
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+// The RFC 0160 connection protocol is the predecessor of did-exchange, still used by the deployed agents that never
+// migrated. Both protocols share the same state machine: the inbound connection protocol messages are handled as
+// the equivalent did-exchange messages and the connection record remembers the protocol, so that the messages
+// sent over the connection are connection protocol messages.
+const (
+	// LegacyPIURI is the connection protocol identifier URI.
+	// https://github.com/hyperledger/aries-rfcs/tree/master/features/0160-connection-protocol
+	LegacyPIURI = "https://didcomm.org/connections/1.0"
+	// LegacyInvitationMsgType defines the connection protocol invitation message type.
+	LegacyInvitationMsgType = LegacyPIURI + "/invitation"
+	// LegacyRequestMsgType defines the connection protocol request message type.
+	LegacyRequestMsgType = LegacyPIURI + "/request"
+	// LegacyResponseMsgType defines the connection protocol response message type.
+	LegacyResponseMsgType = LegacyPIURI + "/response"
+	// LegacyAckMsgType defines the connection protocol ack message type.
+	LegacyAckMsgType = LegacyPIURI + "/ack"
+	// legacySovPIURI is the connection protocol identifier URI of the agents predating the didcomm.org prefix.
+	legacySovPIURI = "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/connections/1.0"
+)
+
+// fromLegacy returns the did-exchange equivalent of the connection protocol message. The second return value
+// is false when the message is not a connection protocol message, which is then returned as is.
+func fromLegacy(msg service.DIDCommMsg) (service.DIDCommMsg, bool) {
+	msgType, ok := toDIDExchangeMsgType(msg.Type())
+	if !ok {
+		return msg, false
+	}
+
+	didexMsg := msg.Clone()
+	didexMsg["@type"] = msgType
+
+	return didexMsg, true
+}
+
+// toDIDExchangeMsgType maps the connection protocol message type to the did-exchange message type.
+func toDIDExchangeMsgType(msgType string) (string, bool) {
+	for _, piuri := range []string{LegacyPIURI, legacySovPIURI} {
+		if strings.HasPrefix(msgType, piuri+"/") {
+			return PIURI + strings.TrimPrefix(msgType, piuri), true
+		}
+	}
+
+	return msgType, false
+}
+
+// isLegacy checks whether the connection is established with the connection protocol.
+func isLegacy(record *connection.Record) bool {
+	return record != nil && record.Protocol == LegacyPIURI
+}
+
+// messageType returns the type of the did-exchange message to be sent over the connection, which is the equivalent
+// connection protocol message type for the connections established with the connection protocol.
+func messageType(record *connection.Record, msgType string) string {
+	if !isLegacy(record) {
+		return msgType
+	}
+
+	return LegacyPIURI + strings.TrimPrefix(msgType, PIURI)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didexchange
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+func TestToDIDExchangeMsgType(t *testing.T) {
+	tests := []struct {
+		name     string
+		msgType  string
+		expected string
+		legacy   bool
+	}{{
+		name:     "connection protocol message",
+		msgType:  LegacyRequestMsgType,
+		expected: RequestMsgType,
+		legacy:   true,
+	}, {
+		name:     "connection protocol message with did:sov prefix",
+		msgType:  "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/connections/1.0/response",
+		expected: ResponseMsgType,
+		legacy:   true,
+	}, {
+		name:     "did-exchange message",
+		msgType:  InvitationMsgType,
+		expected: InvitationMsgType,
+	}, {
+		name:     "other message",
+		msgType:  "https://didcomm.org/connections/1.0-other/request",
+		expected: "https://didcomm.org/connections/1.0-other/request",
+	}}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			msgType, legacy := toDIDExchangeMsgType(tc.msgType)
+			require.Equal(t, tc.expected, msgType)
+			require.Equal(t, tc.legacy, legacy)
+		})
+	}
+}
+
+func TestFromLegacy(t *testing.T) {
+	t.Run("connection protocol message", func(t *testing.T) {
+		msg := service.NewDIDCommMsgMap(&model.Ack{Type: LegacyAckMsgType, ID: "id"})
+
+		didexMsg, legacy := fromLegacy(msg)
+		require.True(t, legacy)
+		require.Equal(t, AckMsgType, didexMsg.Type())
+		require.Equal(t, "id", didexMsg.ID())
+		require.Equal(t, LegacyAckMsgType, msg.Type())
+	})
+
+	t.Run("did-exchange message", func(t *testing.T) {
+		msg := service.NewDIDCommMsgMap(&model.Ack{Type: AckMsgType, ID: "id"})
+
+		didexMsg, legacy := fromLegacy(msg)
+		require.False(t, legacy)
+		require.Equal(t, msg, didexMsg)
+	})
+}
+
+func TestMessageType(t *testing.T) {
+	require.Equal(t, RequestMsgType, messageType(nil, RequestMsgType))
+	require.Equal(t, RequestMsgType, messageType(&connection.Record{}, RequestMsgType))
+	require.Equal(t, LegacyRequestMsgType, messageType(&connection.Record{Protocol: LegacyPIURI}, RequestMsgType))
+	require.Equal(t, LegacyAckMsgType, messageType(&connection.Record{Protocol: LegacyPIURI}, AckMsgType))
+}
+
+// connection protocol flow with role Invitee.
+func TestService_Handle_LegacyInvitee(t *testing.T) {
+	protocolStateStore := mockstorage.NewMockStoreProvider()
+	store := mockstorage.NewMockStoreProvider()
+	k := newKMS(t, store)
+	sent := make(chan interface{}, 2)
+	prov := &protocol.MockProvider{
+		StoreProvider:              store,
+		ProtocolStateStoreProvider: protocolStateStore,
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+		CustomKMS: k,
+		CustomOutbound: &mockdispatcher.MockOutbound{
+			ValidateSend: func(msg interface{}, _ string, _ *service.Destination) error {
+				sent <- msg
+				return nil
+			},
+		},
+	}
+
+	pubKey := newED25519Key(t, k)
+
+	cStore, err := newConnectionStore(prov)
+	require.NoError(t, err)
+
+	ctx := context{
+		vdRegistry:      &mockvdr.MockVDRegistry{CreateValue: createDIDDocWithKey(pubKey)},
+		crypto:          &tinkcrypto.Crypto{},
+		connectionStore: cStore,
+		kms:             k,
+	}
+
+	newDidDoc, err := ctx.vdRegistry.Create(testMethod)
+	require.NoError(t, err)
+
+	s, err := New(prov)
+	require.NoError(t, err)
+
+	s.ctx.vdRegistry = &mockvdr.MockVDRegistry{CreateValue: newDidDoc, ResolveValue: newDidDoc}
+	actionCh := make(chan service.DIDCommAction, 10)
+	require.NoError(t, s.RegisterActionEvent(actionCh))
+
+	statusCh := make(chan service.StateMsg, 10)
+	require.NoError(t, s.RegisterMsgEvent(statusCh))
+
+	requestedCh := make(chan string)
+	completedCh := make(chan struct{})
+
+	go handleMessagesInvitee(statusCh, requestedCh, completedCh)
+
+	go func() { service.AutoExecuteActionEvent(actionCh) }()
+
+	invitation := &Invitation{
+		Type:            LegacyInvitationMsgType,
+		ID:              randomString(),
+		Label:           "Bob",
+		RecipientKeys:   []string{pubKey},
+		ServiceEndpoint: "http://alice.agent.example.com:8081",
+	}
+
+	require.NoError(t, ctx.connectionStore.SaveInvitation(invitation.ID, invitation))
+
+	payloadBytes, err := json.Marshal(invitation)
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(payloadBytes)
+	require.NoError(t, err)
+
+	_, err = s.HandleInbound(didMsg, "", "")
+	require.NoError(t, err)
+
+	var connID string
+	select {
+	case connID = <-requestedCh:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "didn't receive post event requested")
+	}
+
+	// Alice sends a connection protocol request, without the did-exchange attachment
+	select {
+	case msg := <-sent:
+		request, ok := msg.(*Request)
+		require.True(t, ok)
+		require.Equal(t, LegacyRequestMsgType, request.Type)
+		require.NotNil(t, request.Connection)
+		require.Nil(t, request.DocAttach)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "didn't send the request")
+	}
+
+	connRecord, err := s.connectionStore.GetConnectionRecord(connID)
+	require.NoError(t, err)
+	require.Equal(t, LegacyPIURI, connRecord.Protocol)
+
+	connectionSignature, err := ctx.prepareConnectionSignature(&Connection{
+		DID:    newDidDoc.ID,
+		DIDDoc: newDidDoc,
+	}, invitation.ID)
+	require.NoError(t, err)
+
+	// Bob replies with a connection protocol response
+	payloadBytes, err = json.Marshal(&Response{
+		Type:                "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/connections/1.0/response",
+		ID:                  randomString(),
+		ConnectionSignature: connectionSignature,
+		Thread:              &decorator.Thread{ID: connRecord.ThreadID},
+	})
+	require.NoError(t, err)
+
+	didMsg, err = service.ParseDIDCommMsgMap(payloadBytes)
+	require.NoError(t, err)
+
+	_, err = s.HandleInbound(didMsg, "", "")
+	require.NoError(t, err)
+
+	select {
+	case <-completedCh:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "didn't receive post event complete")
+	}
+
+	// Alice acknowledges the response with a connection protocol ack
+	select {
+	case msg := <-sent:
+		ack, ok := msg.(*model.Ack)
+		require.True(t, ok)
+		require.Equal(t, LegacyAckMsgType, ack.Type)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "didn't send the ack")
+	}
+
+	validateState(t, s, connRecord.ThreadID, findNamespace(ResponseMsgType), (&completed{}).Name())
+}
@@ -25,6 +25,15 @@ type OOBInvitation struct {
 	TheirLabel string
 	// MyLabel is the label we will use during the did-exchange.
 	MyLabel string
+	// GoalCode is the goal code of the out-of-band invitation, it selects the auto-accept configuration
+	// of the connection.
+	GoalCode string
+	// Protocol is the identifier URI of the protocol to connect with, either did-exchange (default) or
+	// the RFC 0160 connection protocol (LegacyPIURI).
+	Protocol string
+	// Accept holds the media type profiles accepted by the inviter, in order of preference. They are the media type
+	// profiles of the connection.
+	Accept []string
 	// Target destination.
 	// This can be any on of:
 	// - a string with a valid DID
@@ -69,11 +78,15 @@ type Invitation struct {
 // Request defines a2a DID exchange request
 // https://github.com/hyperledger/aries-rfcs/tree/master/features/0023-did-exchange#1-exchange-request
 type Request struct {
-	Type       string            `json:"@type,omitempty"`
-	ID         string            `json:"@id,omitempty"`
-	Label      string            `json:"label,omitempty"`
-	Connection *Connection       `json:"connection,omitempty"`
-	Thread     *decorator.Thread `json:"~thread,omitempty"`
+	Type       string      `json:"@type,omitempty"`
+	ID         string      `json:"@id,omitempty"`
+	Label      string      `json:"label,omitempty"`
+	Connection *Connection `json:"connection,omitempty"`
+	// DID and DocAttach carry the requester's DID and DID document the way other Aries frameworks do,
+	// Connection takes precedence when both are present.
+	DID       string                `json:"did,omitempty"`
+	DocAttach *decorator.Attachment `json:"did_doc~attach,omitempty"`
+	Thread    *decorator.Thread     `json:"~thread,omitempty"`
 }
 
 // Response defines a2a DID exchange response
@@ -82,7 +95,11 @@ type Response struct {
 	Type                string               `json:"@type,omitempty"`
 	ID                  string               `json:"@id,omitempty"`
 	ConnectionSignature *ConnectionSignature `json:"connection~sig,omitempty"`
-	Thread              *decorator.Thread    `json:"~thread,omitempty"`
+	// DID and DocAttach carry the responder's DID and DID document, the attachment is signed with the invitation
+	// key (the DID rotation proof). DocAttach takes precedence over ConnectionSignature when both are present.
+	DID       string                `json:"did,omitempty"`
+	DocAttach *decorator.Attachment `json:"did_doc~attach,omitempty"`
+	Thread    *decorator.Thread     `json:"~thread,omitempty"`
 }
 
 // ConnectionSignature connection signature.
@@ -10,16 +10,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -29,6 +35,9 @@ import (
 
 var logger = log.New("aries-framework/did-exchange/service")
 
+// ErrActionExpired is the error of the connections abandoned because the action was pending longer than the timeout.
+var ErrActionExpired = errors.New("action expired")
+
 const (
 	// DIDExchange did exchange protocol.
 	DIDExchange = "didexchange"
@@ -54,6 +63,8 @@ type message struct {
 	Options       *options
 	NextStateName string
 	ConnRecord    *connection.Record
+	// CreatedAt is the time the action event was triggered, used to expire the actions resumed after a restart.
+	CreatedAt time.Time
 	// err is used to determine whether callback was stopped
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function
@@ -85,6 +96,10 @@ type Service struct {
 	ctx             *context
 	callbackChannel chan *message
 	connectionStore *connectionStore
+	metrics         *metrics.Protocol
+	clock           clock.Clock
+	tracing         *tracing.Protocol
+	autoAccepter    *connection.AutoAccepter
 }
 
 type context struct {
@@ -94,6 +109,7 @@ type context struct {
 	connectionStore    *connectionStore
 	vdRegistry         vdrapi.Registry
 	routeSvc           mediator.ProtocolService
+	jsonLimits         jsonutil.Limits
 }
 
 // opts are used to provide client properties to DID Exchange service.
@@ -125,6 +141,11 @@ func New(prov provider) (*Service, error) {
 		return nil, errors.New("cast service to Route Service failed")
 	}
 
+	autoAccepter, err := connection.NewAutoAccepter(prov)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize auto accepter : %w", err)
+	}
+
 	const callbackChannelSize = 10
 
 	svc := &Service{
@@ -135,10 +156,15 @@ func New(prov provider) (*Service, error) {
 			vdRegistry:         prov.VDRegistry(),
 			connectionStore:    connRecorder,
 			routeSvc:           routeSvc,
+			jsonLimits:         jsonutil.LimitsOf(prov),
 		},
 		// TODO channel size - https://github.com/hyperledger/aries-framework-go/issues/246
 		callbackChannel: make(chan *message, callbackChannelSize),
 		connectionStore: connRecorder,
+		metrics:         metrics.ForProtocol(prov, DIDExchange),
+		clock:           clock.Of(prov),
+		tracing:         tracing.ForProtocol(prov, DIDExchange),
+		autoAccepter:    autoAccepter,
 	}
 
 	// start the listener
@@ -165,6 +191,9 @@ func retrievingRouterConnections(msg service.DIDCommMsg) []string {
 func (s *Service) HandleInbound(msg service.DIDCommMsg, _, _ string) (string, error) {
 	logger.Debugf("receive inbound message : %s", msg)
 
+	// the connection protocol messages are handled as the equivalent did-exchange messages
+	msg, legacy := fromLegacy(msg)
+
 	// fetch the thread id
 	thID, err := msg.ThreadID()
 	if err != nil {
@@ -183,6 +212,10 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, _, _ string) (string, er
 		return "", fmt.Errorf("failed to fetch connection record : %w", err)
 	}
 
+	if legacy {
+		connRecord.Protocol = LegacyPIURI
+	}
+
 	internalMsg := &message{
 		Options:       &options{routerConnections: retrievingRouterConnections(msg)},
 		Msg:           msg.Clone(),
@@ -218,6 +251,11 @@ func (s *Service) Name() string {
 	return DIDExchange
 }
 
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{PIURI, LegacyPIURI}
+}
+
 func findNamespace(msgType string) string {
 	namespace := theirNSPrefix
 	if msgType == InvitationMsgType || msgType == ResponseMsgType || msgType == oobMsgType {
@@ -229,6 +267,8 @@ func findNamespace(msgType string) string {
 
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
+	msgType, _ = toDIDExchangeMsgType(msgType)
+
 	return msgType == InvitationMsgType ||
 		msgType == RequestMsgType ||
 		msgType == ResponseMsgType ||
@@ -266,10 +306,26 @@ func (s *Service) nextState(msgType, thID string) (state, error) {
 		return nil, fmt.Errorf("invalid state transition: %s -> %s", current.Name(), next.Name())
 	}
 
+	if current.Name() == stateNameNull {
+		s.metrics.Started()
+	}
+
 	return next, nil
 }
 
-func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) error { //nolint: funlen
+// handle traces the transitions of the connection triggered by the message.
+func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) error {
+	span := s.tracing.Start(msg.Msg, msg.ThreadID)
+	span.SetAttributes(tracing.Attributes{tracing.StateAttribute: msg.NextStateName})
+
+	err := s.transition(msg, aEvent)
+
+	span.End(err)
+
+	return err
+}
+
+func (s *Service) transition(msg *message, aEvent chan<- service.DIDCommAction) error { //nolint: funlen
 	logger.Debugf("handling msg: %+v", msg)
 
 	next, err := stateFromName(msg.NextStateName)
@@ -291,6 +347,7 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 			action           stateAction
 			followup         state
 			connectionRecord *connection.Record
+			startedAt        = time.Now()
 		)
 
 		connectionRecord, followup, action, err = next.ExecuteInbound(
@@ -309,6 +366,10 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 		connectionRecord.State = next.Name()
 		logger.Debugf("finished execute state: %s", next.Name())
 
+		if next.Name() == StateIDCompleted {
+			s.setTransportPreferences(connectionRecord)
+		}
+
 		if err = s.update(msg.Msg.Type(), connectionRecord); err != nil {
 			return fmt.Errorf("failed to persist state %s %w", next.Name(), err)
 		}
@@ -321,12 +382,19 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 
 		logger.Debugf("finish execute state action: '%s'", next.Name())
 
+		s.metrics.Transition(next.Name(), time.Since(startedAt))
+
+		if next.Name() == StateIDCompleted {
+			s.metrics.Completed()
+		}
+
 		prev := next
 		next = followup
 		haltExecution := false
 
 		// trigger action event based on message type for inbound messages
-		if msg.Msg.Type() != oobMsgType && canTriggerActionEvents(connectionRecord.State, connectionRecord.Namespace) {
+		if msg.Msg.Type() != oobMsgType && canTriggerActionEvents(connectionRecord.State, connectionRecord.Namespace) &&
+			!s.autoAccepted(connectionRecord) {
 			msg.NextStateName = next.Name()
 			if err = s.sendActionEvent(msg, aEvent); err != nil {
 				return fmt.Errorf("handle inbound: %w", err)
@@ -377,6 +445,10 @@ func createErrorEventProperties(connectionID, invitationID string, err error) *d
 // sendActionEvent triggers the action event. This function stores the state of current processing and passes a callback
 // function in the event message.
 func (s *Service) sendActionEvent(internalMsg *message, aEvent chan<- service.DIDCommAction) error {
+	if internalMsg.CreatedAt.IsZero() {
+		internalMsg.CreatedAt = s.clock.Now()
+	}
+
 	// save data to support AcceptExchangeRequest APIs (when client will not be able to invoke the callback function)
 	err := s.storeEventProtocolStateData(internalMsg)
 	if err != nil {
@@ -497,6 +569,94 @@ func (s *Service) accept(connectionID, publicDID, label, stateID, errMsg string,
 	return s.handleWithoutAction(msg)
 }
 
+// ResumeAll triggers the action events for the invitations and requests that were neither accepted nor rejected
+// when the agent was stopped, e.g. to let the new action event consumers handle them after the agent restart.
+// The connections pending longer than the given timeout are abandoned. Zero timeout means they never expire.
+func (s *Service) ResumeAll(timeout time.Duration) error {
+	aEvent := s.ActionEvent()
+	if aEvent == nil {
+		return errors.New("no clients are registered to handle the action events")
+	}
+
+	pending, err := s.pendingActions()
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range pending {
+		if s.expired(msg.CreatedAt, timeout) {
+			if err = s.abandon(msg.ThreadID, msg.Msg, ErrActionExpired); err != nil {
+				return fmt.Errorf("abandon expired connectionID=%s : %w", msg.ConnRecord.ConnectionID, err)
+			}
+
+			continue
+		}
+
+		if err = s.sendActionEvent(msg, aEvent); err != nil {
+			return fmt.Errorf("resume connectionID=%s : %w", msg.ConnRecord.ConnectionID, err)
+		}
+	}
+
+	return nil
+}
+
+// ExpireAll abandons the invitations and requests that were neither accepted nor rejected within the given timeout,
+// so the connection records do not stay in the intermediate states forever. The abandoned state message events
+// carry ErrActionExpired.
+func (s *Service) ExpireAll(timeout time.Duration) error {
+	pending, err := s.pendingActions()
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range pending {
+		if !s.expired(msg.CreatedAt, timeout) {
+			continue
+		}
+
+		if err = s.abandon(msg.ThreadID, msg.Msg, ErrActionExpired); err != nil {
+			return fmt.Errorf("abandon expired connectionID=%s : %w", msg.ConnRecord.ConnectionID, err)
+		}
+	}
+
+	return nil
+}
+
+// pendingActions returns the stored action events of the connections waiting for the user decision.
+func (s *Service) pendingActions() ([]*message, error) {
+	records, err := s.connectionStore.QueryConnectionRecords()
+	if err != nil {
+		return nil, fmt.Errorf("query connection records: %w", err)
+	}
+
+	var pending []*message
+
+	for _, record := range records {
+		if !canTriggerActionEvents(record.State, record.Namespace) {
+			continue
+		}
+
+		msg, err := s.getEventProtocolStateData(record.ConnectionID)
+		if errors.Is(err, storage.ErrDataNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("pending action connectionID=%s : %w", record.ConnectionID, err)
+		}
+
+		pending = append(pending, msg)
+	}
+
+	return pending, nil
+}
+
+// expired checks whether the action created at the given time is pending longer than the timeout.
+// Zero timeout means the actions never expire.
+func (s *Service) expired(createdAt time.Time, timeout time.Duration) bool {
+	return timeout > 0 && !createdAt.IsZero() && clock.Since(s.clock, createdAt) > timeout
+}
+
 func (s *Service) storeEventProtocolStateData(msg *message) error {
 	bytes, err := json.Marshal(msg)
 	if err != nil {
@@ -542,6 +702,8 @@ func (s *Service) abandon(thID string, msg service.DIDCommMsg, processErr error)
 		return fmt.Errorf("unable to update the state to abandoned: %w", err)
 	}
 
+	s.metrics.Failed()
+
 	// send the message event
 	s.sendMsgEvents(&service.StateMsg{
 		ProtocolName: DIDExchange,
@@ -644,6 +806,13 @@ func (s *Service) oobInvitationMsgRecord(msg service.DIDCommMsg) (*connection.Re
 		RecipientKeys:   svc.RecipientKeys,
 		TheirLabel:      oobInvitation.TheirLabel,
 		Namespace:       findNamespace(msg.Type()),
+		GoalCode:        oobInvitation.GoalCode,
+		// the media types accepted by the inviter are the profiles to use with them
+		MediaTypeProfiles: oobInvitation.Accept,
+	}
+
+	if oobInvitation.Protocol == LegacyPIURI {
+		connRecord.Protocol = LegacyPIURI
 	}
 
 	publicDID, ok := oobInvitation.Target.(string)
@@ -714,11 +883,18 @@ func (s *Service) requestMsgRecord(msg service.DIDCommMsg) (*connection.Record,
 		ConnectionID: generateRandomID(),
 		ThreadID:     request.ID,
 		State:        stateNameNull,
-		TheirDID:     request.Connection.DID,
+		TheirDID:     requestDID(&request),
 		InvitationID: invitationID,
 		Namespace:    theirNSPrefix,
 	}
 
+	// the goal code of the invitation selects the auto-accept configuration of the connection,
+	// implicit invitations (public DIDs) are not saved and have no goal code
+	var invitation OOBInvitation
+	if err := s.connectionStore.GetInvitation(invitationID, &invitation); err == nil {
+		connRecord.GoalCode = invitation.GoalCode
+	}
+
 	if err := s.connectionStore.SaveConnectionRecord(connRecord); err != nil {
 		return nil, err
 	}
@@ -759,6 +935,35 @@ func generateRandomID() string {
 // canTriggerActionEvents true based on role and state.
 // 1. Role is invitee and state is invited.
 // 2. Role is inviter and state is requested.
+// autoAccepted checks whether the exchange request of the connection is accepted without triggering
+// an action event.
+func (s *Service) autoAccepted(record *connection.Record) bool {
+	if record.State != StateIDRequested {
+		return false
+	}
+
+	accept, _ := s.autoAccepter.Resolve(connection.AutoAcceptDIDExchangeRequest, record)
+
+	return accept
+}
+
+// setTransportPreferences sets the transport preferences of the completed connection, saved with its state: the
+// media type profiles accepted by the inviter, kept from the out-of-band invitation, and the transport of the other
+// party's service endpoint.
+func (s *Service) setTransportPreferences(record *connection.Record) {
+	dest, err := service.GetDestination(record.TheirDID, s.ctx.vdRegistry)
+	if err != nil {
+		logger.Warnf("connection [%s]: no preferred transport, failed to get their destination: %s",
+			record.ConnectionID, err)
+
+		return
+	}
+
+	if i := strings.Index(dest.ServiceEndpoint, "://"); i > 0 {
+		record.PreferredTransport = dest.ServiceEndpoint[:i]
+	}
+}
+
 func canTriggerActionEvents(stateID, ns string) bool {
 	return (stateID == StateIDInvited && ns == myNSPrefix) || (stateID == StateIDRequested && ns == theirNSPrefix)
 }
@@ -814,6 +1019,8 @@ func (s *Service) CreateImplicitInvitation(inviterLabel, inviterDID,
 		return "", fmt.Errorf("failed to create DIDCommMsg for implicit invitation: %w", err)
 	}
 
+	s.metrics.Started()
+
 	next := &requested{}
 	internalMsg := &message{
 		Msg:           msg.Clone(),
@@ -23,11 +23,13 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
@@ -60,6 +62,8 @@ func TestService_Name(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.Equal(t, DIDExchange, prov.Name())
+		require.Equal(t, []string{"https://didcomm.org/didexchange/1.0", "https://didcomm.org/connections/1.0"},
+			prov.Protocols())
 	})
 }
 
@@ -388,6 +392,123 @@ func TestService_Handle_Invitee(t *testing.T) {
 	validateState(t, s, connRecord.ThreadID, findNamespace(ResponseMsgType), (&completed{}).Name())
 }
 
+// did-exchange flow with role Invitee, responding to an out-of-band invitation.
+func TestService_Handle_InviteeTransportPreferences(t *testing.T) {
+	store := mockstorage.NewMockStoreProvider()
+	k := newKMS(t, store)
+	prov := &protocol.MockProvider{
+		StoreProvider:              store,
+		ProtocolStateStoreProvider: mockstorage.NewMockStoreProvider(),
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+		CustomKMS: k,
+	}
+
+	pubKey := newED25519Key(t, k)
+
+	cStore, err := newConnectionStore(prov)
+	require.NoError(t, err)
+
+	ctx := context{
+		outboundDispatcher: prov.OutboundDispatcher(),
+		vdRegistry:         &mockvdr.MockVDRegistry{CreateValue: createDIDDocWithKey(pubKey)},
+		crypto:             &tinkcrypto.Crypto{},
+		connectionStore:    cStore,
+		kms:                k,
+	}
+
+	newDidDoc, err := ctx.vdRegistry.Create(testMethod)
+	require.NoError(t, err)
+
+	s, err := New(prov)
+	require.NoError(t, err)
+
+	s.ctx.vdRegistry = &mockvdr.MockVDRegistry{ResolveValue: newDidDoc}
+	actionCh := make(chan service.DIDCommAction, 10)
+	err = s.RegisterActionEvent(actionCh)
+	require.NoError(t, err)
+
+	statusCh := make(chan service.StateMsg, 10)
+	err = s.RegisterMsgEvent(statusCh)
+	require.NoError(t, err)
+
+	requestedCh := make(chan string)
+	completedCh := make(chan struct{})
+
+	go handleMessagesInvitee(statusCh, requestedCh, completedCh)
+
+	go func() { service.AutoExecuteActionEvent(actionCh) }()
+
+	svcBlock := newServiceBlock()
+	svcBlock.RecipientKeys = []string{pubKey}
+
+	invitation := &OOBInvitation{
+		ID:         uuid.New().String(),
+		ThreadID:   uuid.New().String(),
+		TheirLabel: "Bob",
+		Target:     svcBlock,
+		Accept:     []string{transport.MediaTypeProfileAIP1, transport.MediaTypeProfileRFC19},
+	}
+
+	_, err = s.RespondTo(invitation, nil)
+	require.NoError(t, err)
+
+	var connID string
+	select {
+	case connID = <-requestedCh:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "didn't receive post event requested")
+	}
+
+	connRecord, err := s.connectionStore.GetConnectionRecord(connID)
+	require.NoError(t, err)
+	require.Equal(t, invitation.Accept, connRecord.MediaTypeProfiles)
+	require.Empty(t, connRecord.PreferredTransport)
+
+	// Bob signs the response with the key of the saved invitation
+	err = ctx.connectionStore.SaveInvitation(invitation.ThreadID, invitation)
+	require.NoError(t, err)
+
+	connectionSignature, err := ctx.prepareConnectionSignature(&Connection{
+		DID:    newDidDoc.ID,
+		DIDDoc: newDidDoc,
+	}, invitation.ThreadID)
+	require.NoError(t, err)
+
+	// Bob replies with a Response
+	payloadBytes, err := json.Marshal(
+		&Response{
+			Type:                ResponseMsgType,
+			ID:                  randomString(),
+			ConnectionSignature: connectionSignature,
+			Thread: &decorator.Thread{
+				ID: connRecord.ThreadID,
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(payloadBytes)
+	require.NoError(t, err)
+
+	_, err = s.HandleInbound(didMsg, "", "")
+	require.NoError(t, err)
+
+	select {
+	case <-completedCh:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "didn't receive post event complete")
+	}
+
+	// the transport preferences are saved when the exchange completes
+	connRecord, err = s.connectionStore.GetConnectionRecord(connID)
+	require.NoError(t, err)
+	require.Equal(t, StateIDCompleted, connRecord.State)
+	require.Equal(t, invitation.Accept, connRecord.MediaTypeProfiles)
+	require.Equal(t, "http", connRecord.PreferredTransport)
+}
+
 func handleMessagesInvitee(statusCh chan service.StateMsg, requestedCh chan string, completedCh chan struct{}) {
 	for e := range statusCh {
 		if e.Type == service.PostState {
@@ -518,6 +639,11 @@ func TestService_Accept(t *testing.T) {
 	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/request"))
 	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/response"))
 	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/ack"))
+	require.Equal(t, true, s.Accept("https://didcomm.org/connections/1.0/invitation"))
+	require.Equal(t, true, s.Accept("https://didcomm.org/connections/1.0/request"))
+	require.Equal(t, true, s.Accept("did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/connections/1.0/response"))
+	require.Equal(t, true, s.Accept("https://didcomm.org/connections/1.0/ack"))
+	require.Equal(t, false, s.Accept("https://didcomm.org/connections/1.0/other"))
 	require.Equal(t, false, s.Accept("unsupported msg type"))
 }
 
@@ -738,6 +864,11 @@ func (m *mockStore) Iterator(start, limit string) storage.StoreIterator {
 	return nil
 }
 
+// Batch performs batch operations.
+func (m *mockStore) Batch(operations []storage.Operation) error {
+	return nil
+}
+
 func randomString() string {
 	u := uuid.New()
 	return u.String()
@@ -1257,6 +1388,322 @@ func TestAcceptExchangeRequest(t *testing.T) {
 	}
 }
 
+type autoAcceptProvider struct {
+	*protocol.MockProvider
+	config *connection.AutoAcceptConfig
+}
+
+func (p *autoAcceptProvider) AutoAcceptConfig() *connection.AutoAcceptConfig {
+	return p.config
+}
+
+func TestAutoAcceptExchangeRequest(t *testing.T) {
+	const goalCode = "aries.vc.issue"
+
+	handleRequest := func(t *testing.T, config *connection.AutoAcceptConfig,
+		saveInvitation func(svc *Service, pubKey string) string) {
+		t.Helper()
+
+		sp := mockstorage.NewMockStoreProvider()
+		svc, err := New(&autoAcceptProvider{
+			MockProvider: &protocol.MockProvider{
+				StoreProvider: sp,
+				ServiceMap: map[string]interface{}{
+					mediator.Coordination: &mockroute.MockMediatorSvc{},
+				},
+			},
+			config: config,
+		})
+		require.NoError(t, err)
+
+		actionCh := make(chan service.DIDCommAction, 10)
+		require.NoError(t, svc.RegisterActionEvent(actionCh))
+
+		statusCh := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(statusCh))
+
+		invitationID := saveInvitation(svc, newED25519Key(t, newKMS(t, sp)))
+
+		_, err = svc.HandleInbound(generateRequestMsgPayload(t, &protocol.MockProvider{
+			StoreProvider: mockstorage.NewMockStoreProvider(),
+		}, randomString(), invitationID), "", "")
+		require.NoError(t, err)
+
+		for {
+			select {
+			case <-actionCh:
+				require.Fail(t, "action event triggered for the auto accepted request")
+			case e := <-statusCh:
+				if e.Type == service.PostState && e.StateID == StateIDResponded {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "tests are not validated")
+			}
+		}
+	}
+
+	t.Run("global", func(t *testing.T) {
+		config := &connection.AutoAcceptConfig{
+			Global: connection.AutoAccept{connection.AutoAcceptDIDExchangeRequest: true},
+		}
+
+		handleRequest(t, config, func(svc *Service, pubKey string) string {
+			invitation := &Invitation{
+				Type:            InvitationMsgType,
+				ID:              randomString(),
+				Label:           "Bob",
+				RecipientKeys:   []string{pubKey},
+				ServiceEndpoint: "http://alice.agent.example.com:8081",
+			}
+			require.NoError(t, svc.connectionStore.SaveInvitation(invitation.ID, invitation))
+
+			return invitation.ID
+		})
+	})
+
+	t.Run("goal code", func(t *testing.T) {
+		config := &connection.AutoAcceptConfig{
+			Global: connection.AutoAccept{connection.AutoAcceptDIDExchangeRequest: false},
+			GoalCodes: map[string]connection.AutoAccept{
+				goalCode: {connection.AutoAcceptDIDExchangeRequest: true},
+			},
+		}
+
+		handleRequest(t, config, func(svc *Service, pubKey string) string {
+			svcBlock := newServiceBlock()
+			svcBlock.RecipientKeys = []string{pubKey}
+
+			invitation := &OOBInvitation{
+				ID:       uuid.New().String(),
+				ThreadID: uuid.New().String(),
+				Target:   svcBlock,
+				GoalCode: goalCode,
+			}
+			require.NoError(t, svc.SaveInvitation(invitation))
+
+			return invitation.ThreadID
+		})
+	})
+}
+
+func TestResumeAll(t *testing.T) {
+	t.Run("no clients", func(t *testing.T) {
+		svc, err := New(&protocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		require.EqualError(t, svc.ResumeAll(0), "no clients are registered to handle the action events")
+	})
+
+	t.Run("resumes the pending exchange request after restart", func(t *testing.T) {
+		sp := mockstorage.NewMockStoreProvider()
+		prov := &protocol.MockProvider{
+			StoreProvider:              sp,
+			ProtocolStateStoreProvider: mockstorage.NewMockStoreProvider(),
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		}
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		// the action event is never handled e.g. the agent is stopped
+		pendingCh := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(pendingCh))
+
+		invitation := &Invitation{
+			Type:            InvitationMsgType,
+			ID:              randomString(),
+			Label:           "Bob",
+			RecipientKeys:   []string{newED25519Key(t, newKMS(t, sp))},
+			ServiceEndpoint: "http://alice.agent.example.com:8081",
+		}
+
+		require.NoError(t, svc.connectionStore.SaveInvitation(invitation.ID, invitation))
+
+		_, err = svc.HandleInbound(generateRequestMsgPayload(t, &protocol.MockProvider{
+			StoreProvider: mockstorage.NewMockStoreProvider(),
+		}, randomString(), invitation.ID), "", "")
+		require.NoError(t, err)
+
+		select {
+		case <-pendingCh:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timeout")
+		}
+
+		restarted, err := New(prov)
+		require.NoError(t, err)
+
+		actionCh := make(chan service.DIDCommAction, 1)
+		require.NoError(t, restarted.RegisterActionEvent(actionCh))
+
+		statusCh := make(chan service.StateMsg, 10)
+		require.NoError(t, restarted.RegisterMsgEvent(statusCh))
+
+		require.NoError(t, restarted.ResumeAll(time.Hour))
+
+		select {
+		case e := <-actionCh:
+			require.Equal(t, RequestMsgType, e.Message.Type())
+			e.Continue(nil)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timeout")
+		}
+
+		for {
+			select {
+			case e := <-statusCh:
+				if e.Type == service.PostState && e.StateID == StateIDResponded {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timeout")
+
+				return
+			}
+		}
+	})
+
+	t.Run("abandons the expired exchange request", func(t *testing.T) {
+		sp := mockstorage.NewMockStoreProvider()
+		svc, err := New(&protocol.MockProvider{
+			StoreProvider: sp,
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		actionCh := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actionCh))
+
+		statusCh := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(statusCh))
+
+		invitation := &Invitation{
+			Type:            InvitationMsgType,
+			ID:              randomString(),
+			Label:           "Bob",
+			RecipientKeys:   []string{newED25519Key(t, newKMS(t, sp))},
+			ServiceEndpoint: "http://alice.agent.example.com:8081",
+		}
+
+		require.NoError(t, svc.connectionStore.SaveInvitation(invitation.ID, invitation))
+
+		_, err = svc.HandleInbound(generateRequestMsgPayload(t, &protocol.MockProvider{
+			StoreProvider: mockstorage.NewMockStoreProvider(),
+		}, randomString(), invitation.ID), "", "")
+		require.NoError(t, err)
+
+		select {
+		case <-actionCh:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timeout")
+		}
+
+		require.NoError(t, svc.ResumeAll(time.Nanosecond))
+
+		select {
+		case <-actionCh:
+			require.Fail(t, "expired action should not be resumed")
+		default:
+		}
+
+		for {
+			select {
+			case e := <-statusCh:
+				if e.Type == service.PostState && e.StateID == StateIDAbandoned {
+					prop, ok := e.Properties.(*didExchangeEventError)
+					require.True(t, ok)
+					require.Equal(t, ErrActionExpired.Error(), prop.Error())
+
+					return
+				}
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timeout")
+
+				return
+			}
+		}
+	})
+}
+
+func TestExpireAll(t *testing.T) {
+	sp := mockstorage.NewMockStoreProvider()
+	clock := mockclock.New(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC))
+	svc, err := New(&protocol.MockProvider{
+		StoreProvider: sp,
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+		CustomClock: clock,
+	})
+	require.NoError(t, err)
+
+	actionCh := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actionCh))
+
+	statusCh := make(chan service.StateMsg, 10)
+	require.NoError(t, svc.RegisterMsgEvent(statusCh))
+
+	invitation := &Invitation{
+		Type:            InvitationMsgType,
+		ID:              randomString(),
+		Label:           "Bob",
+		RecipientKeys:   []string{newED25519Key(t, newKMS(t, sp))},
+		ServiceEndpoint: "http://alice.agent.example.com:8081",
+	}
+
+	require.NoError(t, svc.connectionStore.SaveInvitation(invitation.ID, invitation))
+
+	_, err = svc.HandleInbound(generateRequestMsgPayload(t, &protocol.MockProvider{
+		StoreProvider: mockstorage.NewMockStoreProvider(),
+	}, randomString(), invitation.ID), "", "")
+	require.NoError(t, err)
+
+	var connectionID string
+
+	select {
+	case e := <-actionCh:
+		connectionID = e.Properties.All()["connectionID"].(string)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout")
+	}
+
+	clock.Add(time.Hour)
+	require.NoError(t, svc.ExpireAll(time.Hour))
+
+	record, err := svc.connectionStore.GetConnectionRecord(connectionID)
+	require.NoError(t, err)
+	require.Equal(t, StateIDRequested, record.State)
+
+	clock.Add(time.Second)
+	require.NoError(t, svc.ExpireAll(time.Hour))
+
+	for {
+		select {
+		case e := <-statusCh:
+			if e.Type == service.PostState && e.StateID == StateIDAbandoned {
+				prop, ok := e.Properties.(*didExchangeEventError)
+				require.True(t, ok)
+				require.Equal(t, ErrActionExpired.Error(), prop.Error())
+
+				return
+			}
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timeout")
+
+			return
+		}
+	}
+}
+
 func TestAcceptExchangeRequestWithPublicDID(t *testing.T) {
 	sp := mockstorage.NewMockStoreProvider()
 	svc, err := New(&protocol.MockProvider{
@@ -24,6 +24,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
@@ -32,6 +34,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	connectionstore "github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 const (
@@ -51,6 +54,8 @@ const (
 	didCommServiceType = "did-communication"
 	didMethod          = "peer"
 	timestamplen       = 8
+	didDocMimeType     = "application/json"
+	jwsAlgEdDSA        = "EdDSA"
 )
 
 var errVerKeyNotFound = errors.New("verkey not found")
@@ -315,7 +320,7 @@ func (ctx *context) handleInboundOOBInvitation(
 	}
 
 	request := &Request{
-		Type:       RequestMsgType,
+		Type:       messageType(msg.connRecord, RequestMsgType),
 		ID:         thid,
 		Label:      oobInvitation.MyLabel,
 		Connection: conn,
@@ -325,6 +330,14 @@ func (ctx *context) handleInboundOOBInvitation(
 		},
 	}
 
+	// the connection protocol request only carries the connection
+	if !isLegacy(msg.connRecord) {
+		err = attachRequestDIDDoc(request)
+		if err != nil {
+			return nil, nil, fmt.Errorf("handleInboundOOBInvitation - failed to attach did document: %w", err)
+		}
+	}
+
 	svc, err := ctx.getServiceBlock(&oobInvitation)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get service block: %w", err)
@@ -367,7 +380,7 @@ func (ctx *context) handleInboundInvitation(invitation *Invitation, thid string,
 	}
 
 	request := &Request{
-		Type:       RequestMsgType,
+		Type:       messageType(connRec, RequestMsgType),
 		ID:         thid,
 		Label:      getLabel(options),
 		Connection: conn,
@@ -377,6 +390,14 @@ func (ctx *context) handleInboundInvitation(invitation *Invitation, thid string,
 	}
 	connRec.MyDID = request.Connection.DID
 
+	// the connection protocol request only carries the connection
+	if !isLegacy(connRec) {
+		err = attachRequestDIDDoc(request)
+		if err != nil {
+			return nil, nil, fmt.Errorf("handle inbound invitation: %w", err)
+		}
+	}
+
 	senderKey, err := recipientKey(didDoc)
 	if err != nil {
 		return nil, nil, fmt.Errorf("handle inbound invitation: %w", err)
@@ -389,7 +410,12 @@ func (ctx *context) handleInboundInvitation(invitation *Invitation, thid string,
 
 func (ctx *context) handleInboundRequest(request *Request, options *options,
 	connRec *connectionstore.Record) (stateAction, *connectionstore.Record, error) {
-	requestDidDoc, err := ctx.resolveDidDocFromConnection(request.Connection)
+	requestConnection, err := connectionFromRequest(request, ctx.jsonLimits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestDidDoc, err := ctx.resolveDidDocFromConnection(requestConnection)
 	if err != nil {
 		return nil, nil, fmt.Errorf("resolve did doc from exchange request connection: %w", err)
 	}
@@ -409,7 +435,7 @@ func (ctx *context) handleInboundRequest(request *Request, options *options,
 
 	// prepare the response
 	response := &Response{
-		Type: ResponseMsgType,
+		Type: messageType(connRec, ResponseMsgType),
 		ID:   uuid.New().String(),
 		Thread: &decorator.Thread{
 			ID: request.ID,
@@ -417,7 +443,20 @@ func (ctx *context) handleInboundRequest(request *Request, options *options,
 		ConnectionSignature: encodedConnectionSignature,
 	}
 
-	connRec.TheirDID = request.Connection.DID
+	// the connection protocol response only carries the signed connection
+	if !isLegacy(connRec) {
+		response.DID = connection.DID
+	}
+
+	if connection.DIDDoc != nil && !isLegacy(connRec) {
+		// sign the attached did document with the invitation key to prove the rotation to the connection DID
+		response.DocAttach, err = ctx.prepareDIDDocAttachment(connection.DIDDoc, request.Thread.PID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	connRec.TheirDID = requestConnection.DID
 	connRec.MyDID = connection.DID
 	connRec.TheirLabel = request.Label
 
@@ -579,35 +618,167 @@ func (ctx *context) prepareConnectionSignature(connection *Connection,
 	binary.BigEndian.PutUint64(timestampBuf, uint64(now))
 	concatenateSignData := append(timestampBuf, connAttributeBytes...)
 
+	pubKey, signature, err := ctx.signWithInvitationKey(concatenateSignData, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConnectionSignature{
+		Type:       "https://didcomm.org/signature/1.0/ed25519Sha512_single",
+		SignedData: base64.URLEncoding.EncodeToString(concatenateSignData),
+		SignVerKey: base64.URLEncoding.EncodeToString(base58.Decode(pubKey)),
+		Signature:  base64.URLEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// signWithInvitationKey signs the data with the recipient key of the invitation and returns the base58 encoded
+// public key along with the signature.
+func (ctx *context) signWithInvitationKey(data []byte, invitationID string) (string, []byte, error) {
 	pubKey, err := ctx.getVerKey(invitationID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get verkey: %w", err)
+		return "", nil, fmt.Errorf("failed to get verkey: %w", err)
 	}
 
 	signingKID, err := localkms.CreateKID(base58.Decode(pubKey), kms.ED25519Type)
 	if err != nil {
-		return nil, fmt.Errorf("prepareConnectionSignature: failed to generate KID from public key: %w", err)
+		return "", nil, fmt.Errorf("failed to generate KID from public key: %w", err)
 	}
 
 	kh, err := ctx.kms.Get(signingKID)
 	if err != nil {
-		return nil, fmt.Errorf("prepareConnectionSignature: failed to get key handle: %w", err)
+		return "", nil, fmt.Errorf("failed to get key handle: %w", err)
 	}
 
-	// TODO: Replace with signed attachments issue-626
-	signature, err := ctx.crypto.Sign(concatenateSignData, kh)
+	signature, err := ctx.crypto.Sign(data, kh)
 	if err != nil {
-		return nil, fmt.Errorf("sign response message: %w", err)
+		return "", nil, fmt.Errorf("sign response message: %w", err)
 	}
 
-	return &ConnectionSignature{
-		Type:       "https://didcomm.org/signature/1.0/ed25519Sha512_single",
-		SignedData: base64.URLEncoding.EncodeToString(concatenateSignData),
-		SignVerKey: base64.URLEncoding.EncodeToString(base58.Decode(pubKey)),
-		Signature:  base64.URLEncoding.EncodeToString(signature),
+	return pubKey, signature, nil
+}
+
+// Attach the did document as per the did_doc~attach decorator:
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0023-did-exchange#request-message-attributes
+func didDocAttachment(doc *did.Doc) (*decorator.Attachment, error) {
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return nil, fmt.Errorf("marshal did document: %w", err)
+	}
+
+	return &decorator.Attachment{
+		ID:       uuid.New().String(),
+		MimeType: didDocMimeType,
+		Data: decorator.AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString(docBytes),
+		},
 	}, nil
 }
 
+// attachRequestDIDDoc populates the did and the did_doc~attach of the request from its connection.
+func attachRequestDIDDoc(request *Request) error {
+	request.DID = request.Connection.DID
+
+	if request.Connection.DIDDoc == nil {
+		return nil
+	}
+
+	attachment, err := didDocAttachment(request.Connection.DIDDoc)
+	if err != nil {
+		return err
+	}
+
+	request.DocAttach = attachment
+
+	return nil
+}
+
+// connectionFromRequest returns the requester's connection, falling back to the did and the did_doc~attach
+// of the request when the connection attribute is absent.
+func connectionFromRequest(request *Request, limits jsonutil.Limits) (*Connection, error) {
+	if request.Connection != nil {
+		return request.Connection, nil
+	}
+
+	if request.DID == "" {
+		return nil, errors.New("missing connection and did in exchange request")
+	}
+
+	conn := &Connection{DID: request.DID}
+
+	if request.DocAttach != nil {
+		doc, err := didDocFromAttachment(request.DocAttach, limits)
+		if err != nil {
+			return nil, err
+		}
+
+		conn.DIDDoc = doc
+	}
+
+	return conn, nil
+}
+
+func didDocFromAttachment(attachment *decorator.Attachment, limits jsonutil.Limits) (*did.Doc, error) {
+	docBytes, err := attachment.Data.Fetch()
+	if err != nil {
+		return nil, fmt.Errorf("fetch did_doc~attach: %w", err)
+	}
+
+	doc, err := did.ParseDocument(docBytes, did.WithJSONLimits(limits))
+	if err != nil {
+		return nil, fmt.Errorf("parse did_doc~attach: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Sign the did_doc~attach with the invitation key as a detached JWS, as per the signing attachments spec:
+// https://github.com/hyperledger/aries-rfcs/tree/master/concepts/0017-attachments#signing-attachments
+func (ctx *context) prepareDIDDocAttachment(doc *did.Doc, invitationID string) (*decorator.Attachment, error) {
+	attachment, err := didDocAttachment(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := ctx.getVerKey(invitationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verkey: %w", err)
+	}
+
+	didKey, _ := fingerprint.CreateDIDKey(base58.Decode(pubKey))
+
+	protected, err := json.Marshal(map[string]interface{}{
+		jose.HeaderAlgorithm: jwsAlgEdDSA,
+		jose.HeaderKeyID:     didKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal jws protected header: %w", err)
+	}
+
+	docBytes, err := attachment.Data.Fetch()
+	if err != nil {
+		return nil, fmt.Errorf("fetch did_doc~attach: %w", err)
+	}
+
+	b64Protected := base64.RawURLEncoding.EncodeToString(protected)
+
+	_, signature, err := ctx.signWithInvitationKey(jwsSigningInput(b64Protected, docBytes), invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.Data.JWS = &decorator.AttachmentJWS{
+		Header:    map[string]interface{}{jose.HeaderKeyID: didKey},
+		Protected: b64Protected,
+		Signature: base64.RawURLEncoding.EncodeToString(signature),
+	}
+
+	return attachment, nil
+}
+
+func jwsSigningInput(b64Protected string, payload []byte) []byte {
+	return []byte(b64Protected + "." + base64.RawURLEncoding.EncodeToString(payload))
+}
+
 func (ctx *context) handleInboundResponse(response *Response) (stateAction, *connectionstore.Record, error) {
 	ack := &model.Ack{
 		Type:   AckMsgType,
@@ -628,7 +799,9 @@ func (ctx *context) handleInboundResponse(response *Response) (stateAction, *con
 		return nil, nil, fmt.Errorf("get connection record: %w", err)
 	}
 
-	conn, err := verifySignature(response.ConnectionSignature, connRecord.RecipientKeys[0])
+	ack.Type = messageType(connRecord, AckMsgType)
+
+	conn, err := verifyResponse(response, connRecord.RecipientKeys[0], ctx.jsonLimits)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -677,17 +850,7 @@ func verifySignature(connSignature *ConnectionSignature, recipientKeys string) (
 	}
 
 	// The signature data must be used to verify against the invitation's recipientKeys for continuity.
-	pubKey := base58.Decode(recipientKeys)
-
-	// TODO: Replace with signed attachments issue-626
-	suiteVerifier := ed25519signature2018.NewPublicKeyVerifier()
-	signatureSuite := ed25519signature2018.New(suite.WithVerifier(suiteVerifier))
-
-	err = signatureSuite.Verify(&verifier.PublicKey{
-		Type:  kms.ED25519,
-		Value: pubKey,
-	},
-		sigData, signature)
+	err = verifyWithRecipientKey(recipientKeys, sigData, signature)
 	if err != nil {
 		return nil, fmt.Errorf("verify signature: %w", err)
 	}
@@ -708,6 +871,69 @@ func verifySignature(connSignature *ConnectionSignature, recipientKeys string) (
 	return conn, nil
 }
 
+// verifyResponse verifies the signed did_doc~attach of the response, or its connection~sig when the did document
+// is not attached, and returns the responder's connection.
+func verifyResponse(response *Response, recipientKey string, limits jsonutil.Limits) (*Connection, error) {
+	if response.DocAttach != nil {
+		return verifyDIDDocAttachment(response.DID, response.DocAttach, recipientKey, limits)
+	}
+
+	if response.ConnectionSignature == nil {
+		return nil, errors.New("missing connection signature and did_doc~attach in exchange response")
+	}
+
+	return verifySignature(response.ConnectionSignature, recipientKey)
+}
+
+// verifyDIDDocAttachment verifies the JWS of the did_doc~attach against the invitation's recipient key and returns
+// the connection with the attached did document.
+func verifyDIDDocAttachment(theirDID string, attachment *decorator.Attachment,
+	recipientKey string, limits jsonutil.Limits) (*Connection, error) {
+	if attachment.Data.JWS == nil {
+		return nil, errors.New("did_doc~attach is not signed")
+	}
+
+	docBytes, err := attachment.Data.Fetch()
+	if err != nil {
+		return nil, fmt.Errorf("fetch did_doc~attach: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(attachment.Data.JWS.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decode did_doc~attach signature: %w", err)
+	}
+
+	err = verifyWithRecipientKey(recipientKey, jwsSigningInput(attachment.Data.JWS.Protected, docBytes), signature)
+	if err != nil {
+		return nil, fmt.Errorf("verify did_doc~attach signature: %w", err)
+	}
+
+	doc, err := did.ParseDocument(docBytes, did.WithJSONLimits(limits))
+	if err != nil {
+		return nil, fmt.Errorf("parse did_doc~attach: %w", err)
+	}
+
+	if theirDID == "" {
+		theirDID = doc.ID
+	}
+
+	if theirDID != doc.ID {
+		return nil, fmt.Errorf("did %s does not match did_doc~attach %s", theirDID, doc.ID)
+	}
+
+	return &Connection{DID: theirDID, DIDDoc: doc}, nil
+}
+
+func verifyWithRecipientKey(recipientKey string, data, signature []byte) error {
+	suiteVerifier := ed25519signature2018.NewPublicKeyVerifier()
+	signatureSuite := ed25519signature2018.New(suite.WithVerifier(suiteVerifier))
+
+	return signatureSuite.Verify(&verifier.PublicKey{
+		Type:  kms.ED25519,
+		Value: base58.Decode(recipientKey),
+	}, data, signature)
+}
+
 func getEpochTime() int64 {
 	return time.Now().Unix()
 }
@@ -856,3 +1082,12 @@ func recipientKey(doc *did.Doc) (string, error) {
 
 	return dest.RecipientKeys[0], nil
 }
+
+// requestDID returns the requester's DID, either from the connection or from the did attribute of the request.
+func requestDID(request *Request) string {
+	if request.Connection != nil {
+		return request.Connection.DID
+	}
+
+	return request.DID
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -989,6 +990,42 @@ func TestNewResponseFromRequest(t *testing.T) {
 		require.NotNil(t, connRec.MyDID)
 		require.NotNil(t, connRec.TheirDID)
 	})
+	t.Run("successful new response from request with attached did document", func(t *testing.T) {
+		ctx := getContext(t, &prov)
+		request, err := createRequest(t, ctx)
+		require.NoError(t, err)
+		require.NoError(t, attachRequestDIDDoc(request))
+
+		theirDID := request.Connection.DID
+		request.Connection = nil
+
+		_, connRec, err := ctx.handleInboundRequest(request, &options{}, &connection.Record{})
+		require.NoError(t, err)
+		require.NotEmpty(t, connRec.MyDID)
+		require.Equal(t, theirDID, connRec.TheirDID)
+	})
+	t.Run("successful new connection protocol response from request", func(t *testing.T) {
+		ctx := getContext(t, &prov)
+		var response *Response
+		ctx.outboundDispatcher = &mockdispatcher.MockOutbound{
+			ValidateSend: func(msg interface{}, _ string, _ *service.Destination) error {
+				response = msg.(*Response)
+				return nil
+			},
+		}
+		request, err := createRequest(t, ctx)
+		require.NoError(t, err)
+		request.Type = LegacyRequestMsgType
+
+		action, _, err := ctx.handleInboundRequest(request, &options{}, &connection.Record{Protocol: LegacyPIURI})
+		require.NoError(t, err)
+		require.NoError(t, action())
+		require.NotNil(t, response)
+		require.Equal(t, LegacyResponseMsgType, response.Type)
+		require.NotNil(t, response.ConnectionSignature)
+		require.Empty(t, response.DID)
+		require.Nil(t, response.DocAttach)
+	})
 	t.Run("unsuccessful new response from request due to create did error", func(t *testing.T) {
 		didDoc := mockdiddoc.GetMockDIDDoc()
 		ctx := &context{
@@ -1066,6 +1103,187 @@ func TestHandleInboundResponse(t *testing.T) {
 		require.Contains(t, e.Error(), "missing or invalid signature data")
 		require.Nil(t, connRec)
 	})
+	t.Run("handle inbound responses did_doc~attach signed with another key", func(t *testing.T) {
+		resp, err := saveMockConnectionRecord(t, request, ctx)
+		require.NoError(t, err)
+		resp.DocAttach, err = ctx.prepareDIDDocAttachment(createDIDDoc(t, ctx.kms), request.Thread.PID)
+		require.NoError(t, err)
+		_, connRec, e := ctx.handleInboundResponse(resp)
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "verify did_doc~attach signature")
+		require.Nil(t, connRec)
+	})
+}
+
+func TestDIDDocAttachment(t *testing.T) {
+	prov := getProvider(t)
+	pubKey := newED25519Key(t, prov.CustomKMS)
+	ctx := getContext(t, &prov)
+	invitation, err := createMockInvitation(pubKey, ctx)
+	require.NoError(t, err)
+
+	newDidDoc, err := ctx.vdRegistry.Create(testMethod)
+	require.NoError(t, err)
+
+	t.Run("signed attachment verified", func(t *testing.T) {
+		attachment, err := ctx.prepareDIDDocAttachment(newDidDoc, invitation.ID)
+		require.NoError(t, err)
+		require.Equal(t, "application/json", attachment.MimeType)
+		require.NotNil(t, attachment.Data.JWS)
+		require.NotEmpty(t, attachment.Data.JWS.Header["kid"])
+
+		conn, err := verifyDIDDocAttachment(newDidDoc.ID, attachment, pubKey, jsonutil.DefaultLimits())
+		require.NoError(t, err)
+		require.Equal(t, newDidDoc.ID, conn.DID)
+		require.Equal(t, newDidDoc.ID, conn.DIDDoc.ID)
+
+		conn, err = verifyDIDDocAttachment("", attachment, pubKey, jsonutil.DefaultLimits())
+		require.NoError(t, err)
+		require.Equal(t, newDidDoc.ID, conn.DID)
+	})
+	t.Run("signed attachment survives json round trip", func(t *testing.T) {
+		attachment, err := ctx.prepareDIDDocAttachment(newDidDoc, invitation.ID)
+		require.NoError(t, err)
+
+		response := &Response{Type: ResponseMsgType, DID: newDidDoc.ID, DocAttach: attachment}
+		received := &Response{}
+		require.NoError(t, toDIDCommMsg(t, response).Decode(received))
+
+		conn, err := verifyResponse(received, pubKey, jsonutil.DefaultLimits())
+		require.NoError(t, err)
+		require.Equal(t, newDidDoc.ID, conn.DID)
+	})
+	t.Run("prepare attachment get invitation error", func(t *testing.T) {
+		attachment, err := ctx.prepareDIDDocAttachment(newDidDoc, "test")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get invitation for signature: data not found")
+		require.Nil(t, attachment)
+	})
+	t.Run("prepare attachment sign error", func(t *testing.T) {
+		ctx := &context{
+			crypto:          &mockcrypto.Crypto{SignErr: errors.New("sign error")},
+			connectionStore: ctx.connectionStore,
+			kms:             prov.CustomKMS,
+		}
+		attachment, err := ctx.prepareDIDDocAttachment(newDidDoc, invitation.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "sign error")
+		require.Nil(t, attachment)
+	})
+	t.Run("unsigned attachment", func(t *testing.T) {
+		attachment, err := didDocAttachment(newDidDoc)
+		require.NoError(t, err)
+
+		conn, err := verifyDIDDocAttachment(newDidDoc.ID, attachment, pubKey, jsonutil.DefaultLimits())
+		require.EqualError(t, err, "did_doc~attach is not signed")
+		require.Nil(t, conn)
+	})
+	t.Run("signed with another key", func(t *testing.T) {
+		attachment, err := ctx.prepareDIDDocAttachment(newDidDoc, invitation.ID)
+		require.NoError(t, err)
+
+		conn, err := verifyDIDDocAttachment(newDidDoc.ID, attachment, newED25519Key(t, prov.CustomKMS),
+			jsonutil.DefaultLimits())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify did_doc~attach signature")
+		require.Nil(t, conn)
+	})
+	t.Run("tampered did document", func(t *testing.T) {
+		attachment, err := ctx.prepareDIDDocAttachment(newDidDoc, invitation.ID)
+		require.NoError(t, err)
+
+		otherDoc, err := didDocAttachment(createDIDDoc(t, prov.CustomKMS))
+		require.NoError(t, err)
+
+		attachment.Data.Base64 = otherDoc.Data.Base64
+
+		conn, err := verifyDIDDocAttachment("", attachment, pubKey, jsonutil.DefaultLimits())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify did_doc~attach signature")
+		require.Nil(t, conn)
+	})
+	t.Run("invalid signature encoding", func(t *testing.T) {
+		attachment, err := ctx.prepareDIDDocAttachment(newDidDoc, invitation.ID)
+		require.NoError(t, err)
+
+		attachment.Data.JWS.Signature = "!invalid"
+
+		conn, err := verifyDIDDocAttachment(newDidDoc.ID, attachment, pubKey, jsonutil.DefaultLimits())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode did_doc~attach signature")
+		require.Nil(t, conn)
+	})
+	t.Run("did does not match did document", func(t *testing.T) {
+		attachment, err := ctx.prepareDIDDocAttachment(newDidDoc, invitation.ID)
+		require.NoError(t, err)
+
+		conn, err := verifyDIDDocAttachment("did:test:other", attachment, pubKey, jsonutil.DefaultLimits())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match did_doc~attach")
+		require.Nil(t, conn)
+	})
+	t.Run("response without signature", func(t *testing.T) {
+		conn, err := verifyResponse(&Response{}, pubKey, jsonutil.DefaultLimits())
+		require.EqualError(t, err, "missing connection signature and did_doc~attach in exchange response")
+		require.Nil(t, conn)
+	})
+}
+
+func TestConnectionFromRequest(t *testing.T) {
+	doc := mockdiddoc.GetMockDIDDoc()
+
+	t.Run("connection takes precedence", func(t *testing.T) {
+		request := &Request{Connection: &Connection{DID: doc.ID}, DID: "did:test:other"}
+		conn, err := connectionFromRequest(request, jsonutil.DefaultLimits())
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, conn.DID)
+		require.Equal(t, doc.ID, requestDID(request))
+	})
+	t.Run("did and attached did document", func(t *testing.T) {
+		request := &Request{Connection: &Connection{DID: doc.ID, DIDDoc: doc}}
+		require.NoError(t, attachRequestDIDDoc(request))
+		require.Equal(t, doc.ID, request.DID)
+		require.NotNil(t, request.DocAttach)
+
+		request.Connection = nil
+
+		conn, err := connectionFromRequest(request, jsonutil.DefaultLimits())
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, conn.DID)
+		require.Equal(t, doc.ID, conn.DIDDoc.ID)
+		require.Equal(t, doc.ID, requestDID(request))
+	})
+	t.Run("did only", func(t *testing.T) {
+		conn, err := connectionFromRequest(&Request{DID: doc.ID}, jsonutil.DefaultLimits())
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, conn.DID)
+		require.Nil(t, conn.DIDDoc)
+	})
+	t.Run("missing did", func(t *testing.T) {
+		conn, err := connectionFromRequest(&Request{}, jsonutil.DefaultLimits())
+		require.EqualError(t, err, "missing connection and did in exchange request")
+		require.Nil(t, conn)
+	})
+	t.Run("invalid attachment", func(t *testing.T) {
+		request := &Request{DID: doc.ID, DocAttach: &decorator.Attachment{
+			Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte("{}"))},
+		}}
+		conn, err := connectionFromRequest(request, jsonutil.DefaultLimits())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse did_doc~attach")
+		require.Nil(t, conn)
+	})
+	t.Run("attached did document exceeding the JSON limits", func(t *testing.T) {
+		request := &Request{Connection: &Connection{DID: doc.ID, DIDDoc: doc}}
+		require.NoError(t, attachRequestDIDDoc(request))
+
+		request.Connection = nil
+
+		conn, err := connectionFromRequest(request, jsonutil.Limits{MaxBytes: 10})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+		require.Nil(t, conn)
+	})
 }
 
 func TestGetInvitationRecipientKey(t *testing.T) {
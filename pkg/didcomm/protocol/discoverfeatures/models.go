@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Queries is sent to discover which features the other agent supports.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0557-discover-features-v2#queries-message-type
+type Queries struct {
+	Type    string   `json:"@type,omitempty"`
+	ID      string   `json:"@id,omitempty"`
+	Queries []*Query `json:"queries"`
+}
+
+// Query matches the features of given type whose ID matches Match. Match may end with the "*" wildcard.
+type Query struct {
+	FeatureType string `json:"feature-type"`
+	Match       string `json:"match"`
+}
+
+// Disclosures is sent in response to the Queries message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0557-discover-features-v2#disclosures-message-type
+type Disclosures struct {
+	Type        string            `json:"@type,omitempty"`
+	ID          string            `json:"@id,omitempty"`
+	Thread      *decorator.Thread `json:"~thread,omitempty"`
+	Disclosures []*Disclosure     `json:"disclosures"`
+}
+
+// Disclosure describes a supported feature.
+type Disclosure struct {
+	FeatureType string   `json:"feature-type"`
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+}
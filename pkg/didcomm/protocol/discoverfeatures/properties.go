@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+const (
+	myDIDPropKey       = "myDID"
+	theirDIDPropKey    = "theirDID"
+	threadIDPropKey    = "threadID"
+	disclosuresPropKey = "disclosures"
+)
+
+// Props are the properties of the events fired for received disclosures.
+type Props interface {
+	MyDID() string
+	TheirDID() string
+	// ThreadID is the ID of the queries message the disclosures answer.
+	ThreadID() string
+	Disclosures() []*Disclosure
+	All() map[string]interface{}
+}
+
+type eventProps struct {
+	myDID       string
+	theirDID    string
+	threadID    string
+	disclosures []*Disclosure
+}
+
+func (e *eventProps) MyDID() string {
+	return e.myDID
+}
+
+func (e *eventProps) TheirDID() string {
+	return e.theirDID
+}
+
+func (e *eventProps) ThreadID() string {
+	return e.threadID
+}
+
+func (e *eventProps) Disclosures() []*Disclosure {
+	return e.disclosures
+}
+
+// All implements EventProperties interface.
+func (e *eventProps) All() map[string]interface{} {
+	return map[string]interface{}{
+		myDIDPropKey:       e.myDID,
+		theirDIDPropKey:    e.theirDID,
+		threadIDPropKey:    e.threadID,
+		disclosuresPropKey: e.disclosures,
+	}
+}
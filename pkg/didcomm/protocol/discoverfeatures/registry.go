@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// FeatureTypeProtocol is the feature type of protocols, identified by their PIURI.
+	FeatureTypeProtocol = "protocol"
+	// FeatureTypeGoalCode is the feature type of goal codes.
+	FeatureTypeGoalCode = "goal-code"
+	// FeatureTypeDecorator is the feature type of decorators (e.g. "~thread").
+	FeatureTypeDecorator = "decorator"
+
+	wildcard = "*"
+)
+
+// Registry holds the features an agent discloses.
+type Registry struct {
+	mu       sync.RWMutex
+	features map[string]map[string][]string
+}
+
+// NewRegistry returns a new empty feature registry.
+func NewRegistry() *Registry {
+	return &Registry{features: map[string]map[string][]string{}}
+}
+
+// Register adds the feature of given type and ID (with the roles the agent may play in it) to the registry.
+// Registering a feature again replaces its roles.
+func (r *Registry) Register(featureType, id string, roles ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.features[featureType] == nil {
+		r.features[featureType] = map[string][]string{}
+	}
+
+	r.features[featureType][id] = roles
+}
+
+func (r *Registry) registerIfMissing(featureType, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.features[featureType] == nil {
+		r.features[featureType] = map[string][]string{}
+	}
+
+	if _, ok := r.features[featureType][id]; !ok {
+		r.features[featureType][id] = nil
+	}
+}
+
+// Query returns the disclosures of the registered features matching any of the queries.
+func (r *Registry) Query(queries ...*Query) []*Disclosure {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := map[string]map[string]struct{}{}
+
+	var result []*Disclosure
+
+	for _, q := range queries {
+		if q == nil {
+			continue
+		}
+
+		if seen[q.FeatureType] == nil {
+			seen[q.FeatureType] = map[string]struct{}{}
+		}
+
+		for _, id := range r.sortedIDs(q.FeatureType) {
+			if _, ok := seen[q.FeatureType][id]; ok || !matches(q.Match, id) {
+				continue
+			}
+
+			seen[q.FeatureType][id] = struct{}{}
+
+			result = append(result, &Disclosure{
+				FeatureType: q.FeatureType,
+				ID:          id,
+				Roles:       r.features[q.FeatureType][id],
+			})
+		}
+	}
+
+	return result
+}
+
+func (r *Registry) sortedIDs(featureType string) []string {
+	ids := make([]string, 0, len(r.features[featureType]))
+
+	for id := range r.features[featureType] {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// matches reports whether the ID matches the query match, which can end with a wildcard.
+func matches(match, id string) bool {
+	if strings.HasSuffix(match, wildcard) {
+		return strings.HasPrefix(id, strings.TrimSuffix(match, wildcard))
+	}
+
+	return match == id
+}
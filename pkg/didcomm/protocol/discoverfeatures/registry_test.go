@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Query(t *testing.T) {
+	r := NewRegistry()
+	r.Register(FeatureTypeProtocol, "https://didcomm.org/issue-credential/2.0", "holder", "issuer")
+	r.Register(FeatureTypeProtocol, "https://didcomm.org/present-proof/2.0")
+	r.Register(FeatureTypeGoalCode, "aries.vc.issue")
+
+	t.Run("exact match", func(t *testing.T) {
+		result := r.Query(&Query{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/issue-credential/2.0"})
+		require.Equal(t, []*Disclosure{{
+			FeatureType: FeatureTypeProtocol,
+			ID:          "https://didcomm.org/issue-credential/2.0",
+			Roles:       []string{"holder", "issuer"},
+		}}, result)
+	})
+
+	t.Run("wildcard match", func(t *testing.T) {
+		result := r.Query(&Query{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/*"})
+		require.Len(t, result, 2)
+		require.Equal(t, "https://didcomm.org/issue-credential/2.0", result[0].ID)
+		require.Equal(t, "https://didcomm.org/present-proof/2.0", result[1].ID)
+
+		result = r.Query(&Query{FeatureType: FeatureTypeGoalCode, Match: "*"})
+		require.Len(t, result, 1)
+		require.Equal(t, "aries.vc.issue", result[0].ID)
+	})
+
+	t.Run("feature type mismatch", func(t *testing.T) {
+		require.Empty(t, r.Query(&Query{FeatureType: FeatureTypeDecorator, Match: "*"}))
+		require.Empty(t, r.Query(&Query{FeatureType: FeatureTypeGoalCode, Match: "https://didcomm.org/*"}))
+	})
+
+	t.Run("multiple queries are not disclosed twice", func(t *testing.T) {
+		result := r.Query(
+			&Query{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/present-proof/*"},
+			nil,
+			&Query{FeatureType: FeatureTypeProtocol, Match: "*"},
+			&Query{FeatureType: FeatureTypeGoalCode, Match: "aries.*"},
+		)
+		require.Len(t, result, 3)
+		require.Equal(t, "https://didcomm.org/present-proof/2.0", result[0].ID)
+		require.Equal(t, "https://didcomm.org/issue-credential/2.0", result[1].ID)
+		require.Equal(t, "aries.vc.issue", result[2].ID)
+	})
+
+	t.Run("register if missing keeps roles", func(t *testing.T) {
+		r.registerIfMissing(FeatureTypeProtocol, "https://didcomm.org/issue-credential/2.0")
+		r.registerIfMissing(FeatureTypeProtocol, "https://didcomm.org/didexchange/1.0")
+		r.registerIfMissing(FeatureTypeDecorator, "~thread")
+
+		result := r.Query(&Query{FeatureType: FeatureTypeProtocol, Match: "*"})
+		require.Len(t, result, 3)
+		require.Equal(t, "https://didcomm.org/didexchange/1.0", result[0].ID)
+		require.Equal(t, []string{"holder", "issuer"}, result[1].Roles)
+		require.Len(t, r.Query(&Query{FeatureType: FeatureTypeDecorator, Match: "~thread"}), 1)
+	})
+}
@@ -0,0 +1,224 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const (
+	// Name defines the protocol name.
+	Name = "discover-features"
+	// PIURI is the discover features protocol identifier URI.
+	PIURI = "https://didcomm.org/discover-features/2.0"
+	// QueriesMsgType defines the protocol queries message type.
+	QueriesMsgType = PIURI + "/queries"
+	// DisclosuresMsgType defines the protocol disclosures message type.
+	DisclosuresMsgType = PIURI + "/disclosures"
+
+	// StateDisclosed is the state ID of the events fired for received disclosures.
+	StateDisclosed = "disclosed"
+)
+
+// decorators supported by the framework, disclosed by default.
+var decorators = []string{"~attach", "~thread", "~timing", "~transport"} //nolint:gochecknoglobals
+
+var logger = log.New("aries-framework/discoverfeatures")
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+}
+
+// servicesProvider is implemented by the framework context, the protocols of its services are disclosed.
+type servicesProvider interface {
+	AllServices() []dispatcher.ProtocolService
+}
+
+// protocolsProvider is implemented by the protocol services advertising the protocols they handle.
+type protocolsProvider interface {
+	Protocols() []string
+}
+
+// Service for the discover features protocol.
+type Service struct {
+	service.Action
+	service.Message
+	outbound dispatcher.Outbound
+	registry *Registry
+	services servicesProvider
+}
+
+// New returns the discover features service.
+func New(prov provider) (*Service, error) {
+	svc := &Service{
+		outbound: prov.OutboundDispatcher(),
+		registry: NewRegistry(),
+	}
+
+	if sp, ok := prov.(servicesProvider); ok {
+		svc.services = sp
+	}
+
+	for _, d := range decorators {
+		svc.registry.Register(FeatureTypeDecorator, d)
+	}
+
+	return svc, nil
+}
+
+// Register adds a feature (e.g. a goal code) to the features disclosed by the agent.
+// The protocols of the framework's services are disclosed without registration.
+func (s *Service) Register(featureType, id string, roles ...string) {
+	s.registry.Register(featureType, id, roles...)
+}
+
+// Disclose returns the disclosures of the agent's features matching the queries.
+func (s *Service) Disclose(queries ...*Query) []*Disclosure {
+	s.populate()
+
+	return s.registry.Query(queries...)
+}
+
+// HandleInbound answers the queries messages and notifies the message event subscribers of the disclosures.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	switch msg.Type() {
+	case QueriesMsgType:
+		return s.handleQueries(msg, myDID, theirDID)
+	case DisclosuresMsgType:
+		return s.handleDisclosures(msg, myDID, theirDID)
+	}
+
+	return "", fmt.Errorf("unsupported message type %s", msg.Type())
+}
+
+// HandleOutbound sends the queries message.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if msg.Type() != QueriesMsgType {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	queries := &Queries{}
+
+	err := msg.Decode(queries)
+	if err != nil {
+		return "", fmt.Errorf("queries message decode: %w", err)
+	}
+
+	if len(queries.Queries) == 0 {
+		return "", errors.New("queries message: at least one query is required")
+	}
+
+	err = s.outbound.SendToDID(msg, myDID, theirDID)
+	if err != nil {
+		return "", fmt.Errorf("send queries message: %w", err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == QueriesMsgType || msgType == DisclosuresMsgType
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return Name
+}
+
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{PIURI}
+}
+
+func (s *Service) handleQueries(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	queries := &Queries{}
+
+	err := msg.Decode(queries)
+	if err != nil {
+		return "", fmt.Errorf("queries message decode: %w", err)
+	}
+
+	disclosures := s.Disclose(queries.Queries...)
+	if disclosures == nil {
+		// the disclosures message is sent even if no features matched
+		disclosures = []*Disclosure{}
+	}
+
+	logger.Debugf("disclosing %d features to %s", len(disclosures), theirDID)
+
+	err = s.outbound.SendToDID(&Disclosures{
+		Type:        DisclosuresMsgType,
+		ID:          uuid.New().String(),
+		Thread:      &decorator.Thread{ID: msg.ID()},
+		Disclosures: disclosures,
+	}, myDID, theirDID)
+	if err != nil {
+		return "", fmt.Errorf("send disclosures message: %w", err)
+	}
+
+	return msg.ID(), nil
+}
+
+func (s *Service) handleDisclosures(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	disclosures := &Disclosures{}
+
+	err := msg.Decode(disclosures)
+	if err != nil {
+		return "", fmt.Errorf("disclosures message decode: %w", err)
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("disclosures message thread ID: %w", err)
+	}
+
+	props := &eventProps{
+		myDID:       myDID,
+		theirDID:    theirDID,
+		threadID:    thID,
+		disclosures: disclosures.Disclosures,
+	}
+
+	for _, handler := range s.MsgEvents() {
+		handler <- service.StateMsg{
+			ProtocolName: Name,
+			Type:         service.PostState,
+			Msg:          msg,
+			StateID:      StateDisclosed,
+			Properties:   props,
+		}
+	}
+
+	return msg.ID(), nil
+}
+
+// populate registers the protocols of the framework's services which are not registered yet.
+func (s *Service) populate() {
+	if s.services == nil {
+		return
+	}
+
+	for _, svc := range s.services.AllServices() {
+		pp, ok := svc.(protocolsProvider)
+		if !ok {
+			continue
+		}
+
+		for _, piuri := range pp.Protocols() {
+			s.registry.registerIfMissing(FeatureTypeProtocol, piuri)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/revocationnotification"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+const (
+	myDID    = "did:example:alice"
+	theirDID = "did:example:bob"
+)
+
+type servicesMockProvider struct {
+	mockprovider.Provider
+	services []dispatcher.ProtocolService
+}
+
+func (p *servicesMockProvider) AllServices() []dispatcher.ProtocolService {
+	return p.services
+}
+
+func TestNew(t *testing.T) {
+	svc, err := New(&mockprovider.Provider{})
+	require.NoError(t, err)
+	require.Equal(t, Name, svc.Name())
+	require.Equal(t, []string{PIURI}, svc.Protocols())
+	require.True(t, svc.Accept(QueriesMsgType))
+	require.True(t, svc.Accept(DisclosuresMsgType))
+	require.False(t, svc.Accept("unknown"))
+
+	// framework decorators are disclosed by default
+	require.Len(t, svc.Disclose(&Query{FeatureType: FeatureTypeDecorator, Match: "*"}), len(decorators))
+}
+
+func TestService_Disclose(t *testing.T) {
+	revocation, err := revocationnotification.New(&mockprovider.Provider{})
+	require.NoError(t, err)
+
+	prov := &servicesMockProvider{}
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	prov.services = []dispatcher.ProtocolService{
+		revocation, svc, &mockdidexchange.MockDIDExchangeSvc{ProtocolName: "no-protocols"},
+	}
+
+	svc.Register(FeatureTypeGoalCode, "aries.vc.revoke", "issuer")
+
+	result := svc.Disclose(&Query{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/*"})
+	require.Len(t, result, 2)
+	require.Equal(t, PIURI, result[0].ID)
+	require.Equal(t, revocationnotification.PIURI, result[1].ID)
+
+	result = svc.Disclose(&Query{FeatureType: FeatureTypeGoalCode, Match: "aries.vc.*"})
+	require.Equal(t, []*Disclosure{{FeatureType: FeatureTypeGoalCode, ID: "aries.vc.revoke", Roles: []string{"issuer"}}},
+		result)
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("queries are answered with disclosures", func(t *testing.T) {
+		sent := make(chan *Disclosures, 1)
+
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, my, their string) error {
+				require.Equal(t, myDID, my)
+				require.Equal(t, theirDID, their)
+
+				sent <- msg.(*Disclosures)
+
+				return nil
+			},
+		}})
+		require.NoError(t, err)
+
+		id, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Queries{
+			Type:    QueriesMsgType,
+			ID:      "queries-id",
+			Queries: []*Query{{FeatureType: FeatureTypeDecorator, Match: "~thread"}},
+		}), myDID, theirDID)
+		require.NoError(t, err)
+		require.Equal(t, "queries-id", id)
+
+		disclosures := <-sent
+		require.Equal(t, DisclosuresMsgType, disclosures.Type)
+		require.NotEmpty(t, disclosures.ID)
+		require.Equal(t, "queries-id", disclosures.Thread.ID)
+		require.Equal(t, []*Disclosure{{FeatureType: FeatureTypeDecorator, ID: "~thread"}}, disclosures.Disclosures)
+	})
+
+	t.Run("queries without match are answered with empty disclosures", func(t *testing.T) {
+		sent := make(chan *Disclosures, 1)
+
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg.(*Disclosures)
+
+				return nil
+			},
+		}})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Queries{
+			Type:    QueriesMsgType,
+			ID:      "queries-id",
+			Queries: []*Query{{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/unknown/*"}},
+		}), myDID, theirDID)
+		require.NoError(t, err)
+
+		disclosures := <-sent
+		require.NotNil(t, disclosures.Disclosures)
+		require.Empty(t, disclosures.Disclosures)
+	})
+
+	t.Run("send disclosures error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			SendErr: errors.New("send error"),
+		}})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Queries{
+			Type: QueriesMsgType,
+			ID:   "queries-id",
+		}), myDID, theirDID)
+		require.EqualError(t, err, "send disclosures message: send error")
+	})
+
+	t.Run("disclosures fire message events", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		disclosed := []*Disclosure{{FeatureType: FeatureTypeProtocol, ID: PIURI, Roles: []string{"responder"}}}
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Disclosures{
+			Type:        DisclosuresMsgType,
+			ID:          "disclosures-id",
+			Thread:      &decorator.Thread{ID: "queries-id"},
+			Disclosures: disclosed,
+		}), myDID, theirDID)
+		require.NoError(t, err)
+
+		select {
+		case event := <-events:
+			require.Equal(t, Name, event.ProtocolName)
+			require.Equal(t, StateDisclosed, event.StateID)
+
+			props, ok := event.Properties.(Props)
+			require.True(t, ok)
+			require.Equal(t, myDID, props.MyDID())
+			require.Equal(t, theirDID, props.TheirDID())
+			require.Equal(t, "queries-id", props.ThreadID())
+			require.Equal(t, disclosed, props.Disclosures())
+			require.Equal(t, "queries-id", props.All()[threadIDPropKey])
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	})
+
+	t.Run("decode errors", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@type": QueriesMsgType, "queries": "invalid"}, myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "queries message decode")
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{
+			"@type": DisclosuresMsgType, "disclosures": "invalid",
+		}, myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "disclosures message decode")
+	})
+
+	t.Run("disclosures without ID", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Disclosures{
+			Type:   DisclosuresMsgType,
+			Thread: &decorator.Thread{ID: "queries-id"},
+		}), myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "disclosures message thread ID")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@type": "unknown"}, myDID, theirDID)
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+}
+
+func TestService_HandleOutbound(t *testing.T) {
+	t.Run("sends the queries message", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{}})
+		require.NoError(t, err)
+
+		id, err := svc.HandleOutbound(service.NewDIDCommMsgMap(&Queries{
+			Type:    QueriesMsgType,
+			ID:      "queries-id",
+			Queries: []*Query{{FeatureType: FeatureTypeProtocol, Match: "*"}},
+		}), myDID, theirDID)
+		require.NoError(t, err)
+		require.Equal(t, "queries-id", id)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			SendErr: errors.New("send error"),
+		}})
+		require.NoError(t, err)
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Queries{
+			Type:    QueriesMsgType,
+			Queries: []*Query{{FeatureType: FeatureTypeProtocol, Match: "*"}},
+		}), myDID, theirDID)
+		require.EqualError(t, err, "send queries message: send error")
+	})
+
+	t.Run("invalid messages", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleOutbound(service.DIDCommMsgMap{"@type": DisclosuresMsgType}, myDID, theirDID)
+		require.EqualError(t, err, "unsupported message type "+DisclosuresMsgType)
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Queries{Type: QueriesMsgType}), myDID, theirDID)
+		require.EqualError(t, err, "queries message: at least one query is required")
+
+		_, err = svc.HandleOutbound(service.DIDCommMsgMap{"@type": QueriesMsgType, "queries": "invalid"}, myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "queries message decode")
+	})
+}
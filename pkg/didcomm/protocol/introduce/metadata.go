@@ -21,6 +21,10 @@ const (
 	metaOOBMessage   = Introduce + "_oobmessage"
 	metaRecipients   = Introduce + "_recipients"
 	metaAttachment   = Introduce + "_attachment"
+	metaOOBAttached  = Introduce + "_oob_attached"
+
+	jsonAttach   = "~attach"
+	mimeTypeJSON = "application/json"
 )
 
 // Opt describes option signature for the Continue function.
@@ -80,6 +84,19 @@ func WrapWithMetadataPublicOOBRequest(msg service.DIDCommMsgMap, req *outofband.
 	msg.Metadata()[metaSkipProposal] = true
 }
 
+// WrapWithOOBAttachment attaches the out-of-band request to the proposal.
+// The function is used by the introduce client to define skip proposal without the delivery round trip.
+// The introducee receives the request together with the proposal and uses it as soon as the proposal is approved.
+func WrapWithOOBAttachment(msg service.DIDCommMsgMap, req *outofband.Request) {
+	msg[jsonAttach] = []*decorator.Attachment{{
+		ID:       uuid.New().String(),
+		MimeType: mimeTypeJSON,
+		Data:     decorator.AttachmentData{JSON: req},
+	}}
+	msg.Metadata()[metaSkipProposal] = true
+	msg.Metadata()[metaOOBAttached] = true
+}
+
 func copyMetadata(from, to service.DIDCommMsg) {
 	for k, v := range from.Metadata() {
 		to.Metadata()[k] = v
@@ -10,14 +10,15 @@ import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator
 
 // Proposal defines proposal request.
 type Proposal struct {
-	Type     string            `json:"@type,omitempty"`
-	ID       string            `json:"@id,omitempty"`
-	To       *To               `json:"to,omitempty"`
-	NWise    bool              `json:"nwise,omitempty"`
-	Thread   *decorator.Thread `json:"~thread,omitempty"`
-	Timing   *decorator.Timing `json:"~timing,omitempty"`
-	Goal     string            `json:"goal,omitempty"`
-	GoalCode string            `json:"goal-code,omitempty"`
+	Type        string                  `json:"@type,omitempty"`
+	ID          string                  `json:"@id,omitempty"`
+	To          *To                     `json:"to,omitempty"`
+	NWise       bool                    `json:"nwise,omitempty"`
+	Thread      *decorator.Thread       `json:"~thread,omitempty"`
+	Timing      *decorator.Timing       `json:"~timing,omitempty"`
+	Goal        string                  `json:"goal,omitempty"`
+	GoalCode    string                  `json:"goal-code,omitempty"`
+	Attachments []*decorator.Attachment `json:"~attach,omitempty"`
 }
 
 // To introducee descriptor keeps information about the introduction
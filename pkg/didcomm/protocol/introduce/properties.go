@@ -6,13 +6,18 @@ SPDX-License-Identifier: Apache-2.0
 
 package introduce
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
 
 const (
 	myDIDPropKey    = "myDID"
 	theirDIDPropKey = "theirDID"
 	piidPropKey     = "piid"
 	errorPropKey    = "error"
+	approvePropKey  = "approve"
 )
 
 type eventProps struct {
@@ -20,6 +25,7 @@ type eventProps struct {
 	theirDID string
 	piid     string
 	err      error
+	approve  *bool
 }
 
 func newEventProps(md *metaData) *eventProps {
@@ -28,7 +34,22 @@ func newEventProps(md *metaData) *eventProps {
 		theirDID: md.TheirDID,
 		piid:     md.PIID,
 		err:      md.err,
+		approve:  responseApprove(md.Msg),
+	}
+}
+
+// responseApprove returns the decision of the introducee if the message is a response.
+func responseApprove(msg service.DIDCommMsgMap) *bool {
+	if msg.Type() != ResponseMsgType {
+		return nil
 	}
+
+	r := Response{}
+	if err := msg.Decode(&r); err != nil {
+		return nil
+	}
+
+	return &r.Approve
 }
 
 func (e *eventProps) MyDID() string {
@@ -70,5 +91,9 @@ func (e eventProps) All() map[string]interface{} {
 		all[errorPropKey] = e.Err()
 	}
 
+	if e.approve != nil {
+		all[approvePropKey] = *e.approve
+	}
+
 	return all
 }
@@ -11,6 +11,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 )
 
 func TestEventProps_All(t *testing.T) {
@@ -38,4 +40,11 @@ func TestEventProps_All(t *testing.T) {
 	require.Equal(t, md.PIID, props.PIID())
 	require.Equal(t, nil, props.Err())
 	require.Equal(t, 2, len(props.All()))
+
+	md.Msg = service.NewDIDCommMsgMap(Response{Type: ResponseMsgType, Approve: true})
+
+	props = newEventProps(md)
+
+	require.Equal(t, 3, len(props.All()))
+	require.Equal(t, true, props.All()[approvePropKey])
 }
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -95,6 +96,8 @@ type metaData struct {
 	rejected     bool
 	inbound      bool
 	saveMetadata func(msg service.DIDCommMsgMap, thID string) error
+	// handleOOBMessage passes the out-of-band message to the out-of-band service
+	handleOOBMessage func(msg service.DIDCommMsgMap, myDID, theirDID string) error
 	// err is used to determine whether callback was stopped
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function
@@ -105,10 +108,11 @@ type metaData struct {
 type Service struct {
 	service.Action
 	service.Message
-	store     storage.Store
-	callbacks chan *metaData
-	oobEvent  chan service.StateMsg
-	messenger service.Messenger
+	store      storage.Store
+	callbacks  chan *metaData
+	oobEvent   chan service.StateMsg
+	oobHandler service.InboundHandler
+	messenger  service.Messenger
 }
 
 // Provider contains dependencies for the DID exchange protocol and is typically created by using aries.Context().
@@ -146,6 +150,11 @@ func New(p Provider) (*Service, error) {
 		return nil, fmt.Errorf("oob register msg event: %w", err)
 	}
 
+	// the out-of-band messages attached to the proposals are handled by the out-of-band service
+	if oobHandler, ok := oobSvc.(service.InboundHandler); ok {
+		svc.oobHandler = oobHandler
+	}
+
 	// start the listener
 	go svc.startInternalListener()
 
@@ -243,9 +252,10 @@ func (s *Service) doHandle(msg service.DIDCommMsg, outbound bool) (*metaData, er
 				PIID: piID,
 			},
 		},
-		saveMetadata: s.saveMetadata,
-		state:        next,
-		msgClone:     msgMap.Clone(),
+		saveMetadata:     s.saveMetadata,
+		handleOOBMessage: s.handleOOBMessage,
+		state:            next,
+		msgClone:         msgMap.Clone(),
 	}, nil
 }
 
@@ -392,6 +402,7 @@ func (s *Service) ActionContinue(piID string, opt Opt) error {
 		msgClone:            tPayload.Msg.Clone(),
 		inbound:             true,
 		saveMetadata:        s.saveMetadata,
+		handleOOBMessage:    s.handleOOBMessage,
 	}
 
 	if opt != nil {
@@ -426,6 +437,7 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 		msgClone:            tPayload.Msg.Clone(),
 		inbound:             true,
 		saveMetadata:        s.saveMetadata,
+		handleOOBMessage:    s.handleOOBMessage,
 	}
 
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
@@ -590,6 +602,13 @@ func isNoOp(s state) bool {
 	return ok
 }
 
+// isOOBAttached is a helper function to determine whether the out-of-band message was attached to the proposal.
+func isOOBAttached(md *metaData) bool {
+	attached, ok := md.Msg.Metadata()[metaOOBAttached].(bool)
+
+	return ok && attached
+}
+
 // isSkipProposal is a helper function to determine whether this is skip proposal or not.
 func isSkipProposal(md *metaData) bool {
 	if md.Msg.Metadata()[metaSkipProposal] == nil {
@@ -796,6 +815,18 @@ func (s *Service) populateMetadata(msg service.DIDCommMsgMap) error {
 	return nil
 }
 
+func (s *Service) handleOOBMessage(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	if s.oobHandler == nil {
+		return errors.New("out-of-band service does not handle inbound messages")
+	}
+
+	if _, err := s.oobHandler.HandleInbound(msg, myDID, theirDID); err != nil {
+		return fmt.Errorf("oob handle inbound: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Service) saveMetadata(msg service.DIDCommMsgMap, thID string) error {
 	metadata := msg.Metadata()
 	if len(metadata) == 0 {
@@ -815,6 +846,11 @@ func (s *Service) Name() string {
 	return Introduce
 }
 
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{strings.TrimSuffix(IntroduceSpec, "/")}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
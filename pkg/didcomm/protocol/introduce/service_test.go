@@ -57,10 +57,18 @@ type payload struct {
 func agentSetup(agent string, t *testing.T, ctrl *gomock.Controller, tr map[string]chan payload) *introduce.Service {
 	t.Helper()
 
-	storageProvider := mem.NewProvider()
 	didSvc := serviceMocks.NewMockEvent(ctrl)
 	didSvc.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
 
+	return agentSetupWithOOB(agent, t, ctrl, tr, didSvc)
+}
+
+func agentSetupWithOOB(agent string, t *testing.T, ctrl *gomock.Controller, tr map[string]chan payload,
+	didSvc interface{}) *introduce.Service {
+	t.Helper()
+
+	storageProvider := mem.NewProvider()
+
 	outbound := dispatcherMocks.NewMockOutbound(ctrl)
 	outbound.EXPECT().
 		SendToDID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -269,6 +277,61 @@ func TestService_SkipProposal(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Bob received proposal with invitation from Alice.
+// Alice received response from Bob.
+func TestService_SkipProposalWithOOBAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transport := map[string]chan payload{
+		Alice: make(chan payload),
+		Bob:   make(chan payload),
+	}
+
+	done := make(chan struct{}, len(transport)*2)
+	defer wait(t, done)
+
+	alice := agentSetup(Alice, t, ctrl, transport)
+
+	var bob *introduce.Service
+
+	oobSvc := serviceMocks.NewMockDIDComm(ctrl)
+	oobSvc.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+	oobSvc.EXPECT().HandleInbound(gomock.Any(), Bob, Alice).
+		DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+			require.Equal(t, outofband.RequestMsgType, msg.Type())
+			require.NotEmpty(t, msg.ParentThreadID())
+
+			return "", bob.OOBMessageReceived(service.StateMsg{
+				Type:    service.PostState,
+				StateID: "requested",
+				Msg:     msg,
+			})
+		})
+
+	bob = agentSetupWithOOB(Bob, t, ctrl, transport, oobSvc)
+
+	handle(t, Alice, done, alice, checkStateMsg(t, Alice,
+		"arranging", "arranging",
+		"arranging", "arranging",
+		"done", "done",
+	), nil)
+
+	handle(t, Bob, done, bob, checkStateMsg(t, Bob,
+		"deciding", "deciding",
+		"waiting", "waiting",
+		"done", "done",
+	), checkDIDCommAction(t, Bob, action{Expected: introduce.ProposalMsgType}))
+
+	proposal := introduce.CreateProposal(&introduce.Recipient{To: &introduce.To{Name: Carol}})
+	introduce.WrapWithOOBAttachment(proposal, &outofband.Request{
+		Type: outofband.RequestMsgType,
+	})
+
+	_, err := alice.HandleOutbound(proposal, Alice, Bob)
+	require.NoError(t, err)
+}
+
 // Bob received proposal from Alice.
 // Carol received proposal from Alice.
 // Alice received response from Bob.
@@ -1469,6 +1532,10 @@ func TestService_Name(t *testing.T) {
 	require.Equal(t, introduce.Introduce, (&introduce.Service{}).Name())
 }
 
+func TestService_Protocols(t *testing.T) {
+	require.Equal(t, []string{"https://didcomm.org/introduce/1.0"}, (&introduce.Service{}).Protocols())
+}
+
 func TestService_New(t *testing.T) {
 	const errMsg = "test err"
 
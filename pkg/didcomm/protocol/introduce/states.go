@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 )
 
 const (
@@ -242,6 +243,11 @@ func (s *arranging) ExecuteInbound(messenger service.Messenger, md *metaData) (s
 			return &abandoning{Code: codeNotApproved}, zeroAction, nil
 		}
 
+		// the introducee has already received the out-of-band message with the proposal
+		if isOOBAttached(md) {
+			return &done{}, zeroAction, nil
+		}
+
 		return &delivering{}, zeroAction, nil
 	}
 
@@ -496,15 +502,74 @@ func (s *deciding) ExecuteInbound(messenger service.Messenger, md *metaData) (st
 			}
 		}
 
-		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(Response{
+		err := messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(Response{
 			Type:        ResponseMsgType,
 			OOBMessage:  msg,
 			Approve:     !md.rejected,
 			Attachments: attch,
 		}), md.MyDID, md.TheirDID)
+		if err != nil || md.rejected {
+			return err
+		}
+
+		return handleAttachedOOBMessage(md)
 	}, nil
 }
 
+// handleAttachedOOBMessage passes the out-of-band message attached to the proposal to the out-of-band service
+// the same way as if it was delivered by the introducer.
+func handleAttachedOOBMessage(md *metaData) error {
+	msg, err := attachedOOBMessage(md.Msg)
+	if err != nil || msg == nil {
+		return err
+	}
+
+	thID, err := md.Msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("threadID: %w", err)
+	}
+
+	if msg.ID() == "" {
+		if err = msg.SetID(uuid.New().String()); err != nil {
+			return fmt.Errorf("set ID: %w", err)
+		}
+	}
+
+	// sets parent threadID, the protocol will be finished after the out-of-band message is received
+	msg["~thread"] = map[string]interface{}{"pthid": thID}
+
+	return md.handleOOBMessage(msg, md.MyDID, md.TheirDID)
+}
+
+func attachedOOBMessage(msg service.DIDCommMsgMap) (service.DIDCommMsgMap, error) {
+	if msg.Type() != ProposalMsgType {
+		return nil, nil
+	}
+
+	proposal := Proposal{}
+	if err := msg.Decode(&proposal); err != nil {
+		return nil, fmt.Errorf("decode proposal: %w", err)
+	}
+
+	for _, a := range proposal.Attachments {
+		src, err := a.Data.Fetch()
+		if err != nil {
+			return nil, fmt.Errorf("fetch attachment: %w", err)
+		}
+
+		oobMsg, err := service.ParseDIDCommMsgMap(src)
+		if err != nil {
+			return nil, fmt.Errorf("parse attachment: %w", err)
+		}
+
+		if oobMsg.Type() == outofband.RequestMsgType {
+			return oobMsg, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (s *deciding) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
 	return nil, nil, errors.New("deciding: ExecuteOutbound function is not supposed to be used")
 }
@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const (
+	// AckStatusOK is the ack status of the accepted credentials.
+	AckStatusOK = "OK"
+	// AckStatusFail is the ack status of the rejected credentials.
+	AckStatusFail = "FAIL"
+)
+
+// AddCredential attaches the credential of the given format (e.g. "aries/ld-proof-vc@v1.0") to the issue message.
+// Several credentials may be issued in the same protocol instance, the attachment ID is generated if not set.
+func (m *IssueCredential) AddCredential(format string, attachment decorator.Attachment) {
+	if attachment.ID == "" {
+		attachment.ID = uuid.New().String()
+	}
+
+	m.CredentialsAttach = append(m.CredentialsAttach, attachment)
+
+	if format != "" {
+		m.Formats = append(m.Formats, Format{AttachID: attachment.ID, Format: format})
+	}
+}
+
+// Validate checks the issued credentials can be told apart: when several credentials are issued,
+// the attachments must have unique IDs. The formats must refer to the attachments.
+func (m *IssueCredential) Validate() error {
+	ids := make(map[string]struct{}, len(m.CredentialsAttach))
+
+	for i := range m.CredentialsAttach {
+		id := m.CredentialsAttach[i].ID
+		if id == "" {
+			if len(m.CredentialsAttach) > 1 {
+				return fmt.Errorf("credential attachment %d: attachment id is empty", i)
+			}
+
+			continue
+		}
+
+		if _, ok := ids[id]; ok {
+			return fmt.Errorf("duplicate credential attachment %s", id)
+		}
+
+		ids[id] = struct{}{}
+	}
+
+	for _, f := range m.Formats {
+		if f.AttachID == "" {
+			continue
+		}
+
+		if _, ok := ids[f.AttachID]; !ok {
+			return fmt.Errorf("format %s refers to unknown attachment %s", f.Format, f.AttachID)
+		}
+	}
+
+	return nil
+}
+
+// newAck acknowledges the credentials of the issue message, the status of each credential is reported
+// when several credentials were issued. The rejected credentials are reported with AckStatusFail.
+func newAck(msg service.DIDCommMsg, rejected []string) (*Ack, error) {
+	credential := IssueCredential{}
+
+	if err := msg.Decode(&credential); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if len(credential.CredentialsAttach) < 2 {
+		return &Ack{Type: AckMsgType}, nil
+	}
+
+	skip := make(map[string]struct{}, len(rejected))
+	for _, id := range rejected {
+		skip[id] = struct{}{}
+	}
+
+	ack := &Ack{Type: AckMsgType, Status: AckStatusFail}
+
+	for i := range credential.CredentialsAttach {
+		status := AckStatusOK
+		if _, ok := skip[credential.CredentialsAttach[i].ID]; ok {
+			status = AckStatusFail
+		} else {
+			ack.Status = AckStatusOK
+		}
+
+		ack.Credentials = append(ack.Credentials, CredentialStatus{
+			AttachID: credential.CredentialsAttach[i].ID,
+			Status:   status,
+		})
+	}
+
+	return ack, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+func TestIssueCredential_AddCredential(t *testing.T) {
+	msg := &IssueCredential{Type: IssueCredentialMsgType}
+
+	msg.AddCredential("aries/ld-proof-vc@v1.0", decorator.Attachment{
+		ID:   "degree",
+		Data: decorator.AttachmentData{Base64: "e30="},
+	})
+	msg.AddCredential("", decorator.Attachment{
+		Data: decorator.AttachmentData{Base64: "e30="},
+	})
+
+	require.Len(t, msg.CredentialsAttach, 2)
+	require.Equal(t, "degree", msg.CredentialsAttach[0].ID)
+	require.NotEmpty(t, msg.CredentialsAttach[1].ID)
+	require.Equal(t, []Format{{AttachID: "degree", Format: "aries/ld-proof-vc@v1.0"}}, msg.Formats)
+	require.NoError(t, msg.Validate())
+}
+
+func TestIssueCredential_Validate(t *testing.T) {
+	t.Run("single credential without ID", func(t *testing.T) {
+		msg := &IssueCredential{CredentialsAttach: []decorator.Attachment{{}}}
+		require.NoError(t, msg.Validate())
+	})
+
+	t.Run("empty attachment ID", func(t *testing.T) {
+		msg := &IssueCredential{CredentialsAttach: []decorator.Attachment{{ID: "1"}, {}}}
+		require.EqualError(t, msg.Validate(), "credential attachment 1: attachment id is empty")
+	})
+
+	t.Run("duplicate attachment ID", func(t *testing.T) {
+		msg := &IssueCredential{CredentialsAttach: []decorator.Attachment{{ID: "1"}, {ID: "1"}}}
+		require.EqualError(t, msg.Validate(), "duplicate credential attachment 1")
+	})
+
+	t.Run("unknown format attachment", func(t *testing.T) {
+		msg := &IssueCredential{
+			Formats:           []Format{{AttachID: "2", Format: "aries/ld-proof-vc@v1.0"}},
+			CredentialsAttach: []decorator.Attachment{{ID: "1"}},
+		}
+		require.EqualError(t, msg.Validate(), "format aries/ld-proof-vc@v1.0 refers to unknown attachment 2")
+	})
+}
@@ -24,6 +24,10 @@ func (hf HandlerFunc) Handle(metadata Metadata) error {
 	return hf(metadata)
 }
 
+// AutoAcceptor decides whether an inbound proposal or request is accepted without triggering an action event.
+// When accepted, the returned Opt (if any) is applied in the same way as the one provided to the Continue function.
+type AutoAcceptor func(metadata Metadata) (Opt, bool)
+
 // Metadata provides helpful information for the processing.
 type Metadata interface {
 	// Message contains the original inbound/outbound message
@@ -38,6 +42,8 @@ type Metadata interface {
 	RequestCredential() *RequestCredential
 	// CredentialNames is a slice which contains credential names provided by the user through the Continue function.
 	CredentialNames() []string
+	// RejectedCredentials contains the attachment IDs of the issued credentials rejected by the Holder.
+	RejectedCredentials() []string
 	// StateName provides the state name
 	StateName() string
 	// Properties provides the possibility to set properties
@@ -17,6 +17,8 @@ type ProposeCredential struct {
 	// so the offer can be evaluated by human judgment.
 	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string `json:"comment,omitempty"`
+	// GoalCode is an optional goal code (e.g aries.vc.issue) describing the purpose of the proposal.
+	GoalCode string `json:"goal_code,omitempty"`
 	// CredentialProposal is an optional JSON-LD object that represents
 	// the credential data that the Prover wants to receive.
 	CredentialProposal PreviewCredential `json:"credential_proposal,omitempty"`
@@ -95,8 +97,30 @@ type PreviewCredential struct {
 }
 
 // Attribute describes an attribute for a Preview Credential.
+// The value is base64 encoded unless the MIME type is a text one (text/plain if omitted).
+// The Referent optionally groups the attributes that belong to the same credential.
 type Attribute struct {
 	Name     string `json:"name,omitempty"`
 	MimeType string `json:"mime-type,omitempty"`
 	Value    string `json:"value,omitempty"`
+	Referent string `json:"referent,omitempty"`
+}
+
+// Ack is sent by the Holder to acknowledge the issued credentials.
+// When several credentials are issued in the same protocol instance, the status of each credential is reported.
+type Ack struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Status string            `json:"status,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	// Credentials contains the status of each credential of the credentials~attach array.
+	Credentials []CredentialStatus `json:"credentials,omitempty"`
+}
+
+// CredentialStatus is the status of the issued credential reported by the Holder.
+type CredentialStatus struct {
+	// AttachID is the @id of the credentials~attach entry.
+	AttachID string `json:"attach_id,omitempty"`
+	// Status is either AckStatusOK (the credential was accepted) or AckStatusFail (the credential was rejected).
+	Status string `json:"status,omitempty"`
 }
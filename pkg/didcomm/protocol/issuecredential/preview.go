@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// NewPreviewCredential returns the credential preview with the given attributes.
+func NewPreviewCredential(attributes ...Attribute) PreviewCredential {
+	return PreviewCredential{
+		Type:       CredentialPreviewMsgType,
+		Attributes: attributes,
+	}
+}
+
+// NewAttribute returns a plain text attribute.
+func NewAttribute(name, value string) Attribute {
+	return Attribute{
+		Name:  name,
+		Value: value,
+	}
+}
+
+// NewBase64Attribute returns an attribute with the value of the given MIME type, the value is base64 encoded.
+func NewBase64Attribute(name, mimeType string, value []byte) Attribute {
+	return Attribute{
+		Name:     name,
+		MimeType: mimeType,
+		Value:    base64.StdEncoding.EncodeToString(value),
+	}
+}
+
+// NewImageAttribute returns an attribute with the base64 encoded image, the MIME type is detected from the content.
+func NewImageAttribute(name string, img []byte) (Attribute, error) {
+	mimeType, err := imageMimeType(img)
+	if err != nil {
+		return Attribute{}, err
+	}
+
+	return NewBase64Attribute(name, mimeType, img), nil
+}
+
+// NewImageAttachment returns the attachment with the base64 encoded image (e.g. a photo to be referred to by
+// the credential), the MIME type is detected from the content.
+func NewImageAttachment(id string, img []byte) (decorator.Attachment, error) {
+	mimeType, err := imageMimeType(img)
+	if err != nil {
+		return decorator.Attachment{}, err
+	}
+
+	hash := sha256.Sum256(img)
+
+	return decorator.Attachment{
+		ID:        id,
+		MimeType:  mimeType,
+		ByteCount: int64(len(img)),
+		Data: decorator.AttachmentData{
+			Sha256: hex.EncodeToString(hash[:]),
+			Base64: base64.StdEncoding.EncodeToString(img),
+		},
+	}, nil
+}
+
+func imageMimeType(img []byte) (string, error) {
+	mimeType := http.DetectContentType(img)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return "", fmt.Errorf("not an image: detected MIME type %s", mimeType)
+	}
+
+	return mimeType, nil
+}
+
+// Validate checks the attributes of the credential preview.
+// The attribute names must be unique within the same referent.
+func (p *PreviewCredential) Validate() error {
+	if p.Type != "" && p.Type != CredentialPreviewMsgType {
+		return fmt.Errorf("unsupported credential preview type %s", p.Type)
+	}
+
+	names := make(map[string]struct{}, len(p.Attributes))
+
+	for i := range p.Attributes {
+		if err := p.Attributes[i].Validate(); err != nil {
+			return fmt.Errorf("attribute %d: %w", i, err)
+		}
+
+		key := p.Attributes[i].Referent + "#" + p.Attributes[i].Name
+		if _, ok := names[key]; ok {
+			return fmt.Errorf("duplicate attribute %s", p.Attributes[i].Name)
+		}
+
+		names[key] = struct{}{}
+	}
+
+	return nil
+}
+
+// Validate checks the attribute has a name and a valid MIME type, the non text values must be base64 encoded.
+func (a *Attribute) Validate() error {
+	if a.Name == "" {
+		return errors.New("attribute name is empty")
+	}
+
+	if a.MimeType == "" {
+		return nil
+	}
+
+	if _, _, err := mime.ParseMediaType(a.MimeType); err != nil {
+		return fmt.Errorf("invalid MIME type %s: %w", a.MimeType, err)
+	}
+
+	if _, err := a.DecodedValue(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DecodedValue returns the value of the attribute, the values of the non text MIME types are base64 decoded.
+func (a *Attribute) DecodedValue() ([]byte, error) {
+	if a.MimeType == "" || strings.HasPrefix(a.MimeType, "text/") {
+		return []byte(a.Value), nil
+	}
+
+	value, err := base64.StdEncoding.DecodeString(a.Value)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decode value of attribute %s: %w", a.Name, err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pngImage is the signature of a PNG image, enough to detect the MIME type.
+var pngImage = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestNewPreviewCredential(t *testing.T) {
+	img, err := NewImageAttribute("photo", pngImage)
+	require.NoError(t, err)
+
+	preview := NewPreviewCredential(
+		NewAttribute("first_name", "Alice"),
+		NewBase64Attribute("data", "application/octet-stream", []byte{0x01, 0x02}),
+		img,
+	)
+
+	require.NoError(t, preview.Validate())
+
+	raw, err := json.Marshal(preview)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"@type": "https://didcomm.org/issue-credential/2.0/credential-preview",
+		"attributes": [
+			{"name": "first_name", "value": "Alice"},
+			{"name": "data", "mime-type": "application/octet-stream", "value": "AQI="},
+			{"name": "photo", "mime-type": "image/png", "value": "`+base64.StdEncoding.EncodeToString(pngImage)+`"}
+		]
+	}`, string(raw))
+
+	value, err := preview.Attributes[2].DecodedValue()
+	require.NoError(t, err)
+	require.Equal(t, pngImage, value)
+
+	value, err = preview.Attributes[0].DecodedValue()
+	require.NoError(t, err)
+	require.Equal(t, []byte("Alice"), value)
+}
+
+func TestNewImageAttribute(t *testing.T) {
+	t.Run("not an image", func(t *testing.T) {
+		_, err := NewImageAttribute("photo", []byte("text"))
+		require.EqualError(t, err, "not an image: detected MIME type text/plain; charset=utf-8")
+	})
+}
+
+func TestNewImageAttachment(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		attachment, err := NewImageAttachment("photo", pngImage)
+		require.NoError(t, err)
+		require.Equal(t, "photo", attachment.ID)
+		require.Equal(t, "image/png", attachment.MimeType)
+		require.Equal(t, int64(len(pngImage)), attachment.ByteCount)
+		require.NotEmpty(t, attachment.Data.Sha256)
+
+		data, err := attachment.Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, pngImage, data)
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		_, err := NewImageAttachment("photo", []byte("text"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not an image")
+	})
+}
+
+func TestPreviewCredential_Validate(t *testing.T) {
+	t.Run("unsupported type", func(t *testing.T) {
+		preview := PreviewCredential{Type: "unknown"}
+		require.EqualError(t, preview.Validate(), "unsupported credential preview type unknown")
+	})
+
+	t.Run("empty attribute name", func(t *testing.T) {
+		preview := NewPreviewCredential(NewAttribute("", "value"))
+		require.EqualError(t, preview.Validate(), "attribute 0: attribute name is empty")
+	})
+
+	t.Run("invalid MIME type", func(t *testing.T) {
+		preview := NewPreviewCredential(Attribute{Name: "name", MimeType: "/", Value: "value"})
+
+		err := preview.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid MIME type /")
+	})
+
+	t.Run("value is not base64 encoded", func(t *testing.T) {
+		preview := NewPreviewCredential(Attribute{Name: "photo", MimeType: "image/png", Value: "not base64!"})
+
+		err := preview.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "base64 decode value of attribute photo")
+	})
+
+	t.Run("duplicate attributes", func(t *testing.T) {
+		preview := NewPreviewCredential(NewAttribute("name", "Alice"), NewAttribute("name", "Bob"))
+		require.EqualError(t, preview.Validate(), "duplicate attribute name")
+	})
+
+	t.Run("same attribute of different referents", func(t *testing.T) {
+		alice := NewAttribute("name", "Alice")
+		alice.Referent = "0"
+
+		bob := NewAttribute("name", "Bob")
+		bob.Referent = "1"
+
+		preview := NewPreviewCredential(alice, bob)
+		require.NoError(t, preview.Validate())
+	})
+}
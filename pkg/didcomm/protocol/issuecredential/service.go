@@ -10,12 +10,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 const (
@@ -51,15 +57,24 @@ var (
 		return nil
 	})
 	errProtocolStopped = errors.New("protocol was stopped")
+	// ErrActionExpired is the error of the actions stopped because they were pending longer than the timeout.
+	ErrActionExpired = errors.New("action expired")
 )
 
 // customError is a wrapper to determine custom error against internal error.
 type customError struct{ error }
 
+// Unwrap gives access to the reason of the rejection, e.g. for its problem report code.
+func (e customError) Unwrap() error {
+	return e.error
+}
+
 // transitionalPayload keeps payload needed for Continue function to proceed with the action.
 type transitionalPayload struct {
 	Action
 	StateName string
+	// CreatedAt is the time the action was triggered, used to expire the actions resumed after a restart.
+	CreatedAt time.Time
 }
 
 // metaData type to store data for internal usage.
@@ -70,6 +85,8 @@ type metaData struct {
 	inbound         bool
 	properties      map[string]interface{}
 	credentialNames []string
+	// keeps the attachment IDs of the issued credentials rejected by the Holder.
+	rejectedCredentials []string
 	// keeps offer credential payload,
 	// allows filling the message by providing an option function.
 	offerCredential   *OfferCredential
@@ -106,6 +123,10 @@ func (md *metaData) CredentialNames() []string {
 	return md.credentialNames
 }
 
+func (md *metaData) RejectedCredentials() []string {
+	return md.rejectedCredentials
+}
+
 func (md *metaData) StateName() string {
 	return md.state.Name()
 }
@@ -166,6 +187,17 @@ func WithFriendlyNames(names ...string) Opt {
 	}
 }
 
+// WithRejectedCredentials allows accepting only some of the credentials issued in the same protocol instance.
+// The credentials with the given attachment IDs are not saved and are reported with AckStatusFail in the ack,
+// the friendly names (see WithFriendlyNames) are given to the accepted credentials.
+// USAGE: This function should be used when the Holder receives IssueCredential message.
+func WithRejectedCredentials(attachIDs []string, names ...string) Opt {
+	return func(md *metaData) {
+		md.rejectedCredentials = attachIDs
+		md.credentialNames = names
+	}
+}
+
 // Provider contains dependencies for the protocol and is typically created by using aries.Context().
 type Provider interface {
 	Messenger() service.Messenger
@@ -176,10 +208,15 @@ type Provider interface {
 type Service struct {
 	service.Action
 	service.Message
-	store      storage.Store
-	callbacks  chan *metaData
-	messenger  service.Messenger
-	middleware Handler
+	store        storage.Store
+	callbacks    chan *metaData
+	messenger    service.Messenger
+	middleware   Handler
+	autoAcceptor AutoAcceptor
+	autoAccepter *connection.AutoAccepter
+	metrics      *metrics.Protocol
+	clock        clock.Clock
+	tracing      *tracing.Protocol
 }
 
 // New returns the issuecredential service.
@@ -189,11 +226,20 @@ func New(p Provider) (*Service, error) {
 		return nil, err
 	}
 
+	autoAccepter, err := connection.NewAutoAccepter(p)
+	if err != nil {
+		return nil, err
+	}
+
 	svc := &Service{
-		messenger:  p.Messenger(),
-		store:      store,
-		callbacks:  make(chan *metaData),
-		middleware: initialHandler,
+		messenger:    p.Messenger(),
+		store:        store,
+		callbacks:    make(chan *metaData),
+		middleware:   initialHandler,
+		metrics:      metrics.ForProtocol(p, Name),
+		clock:        clock.Of(p),
+		tracing:      tracing.ForProtocol(p, Name),
+		autoAccepter: autoAccepter,
 	}
 
 	// start the listener
@@ -212,6 +258,12 @@ func (s *Service) Use(items ...Middleware) {
 	s.middleware = handler
 }
 
+// UseAutoAcceptor allows providing a function which accepts inbound proposals and requests
+// without triggering an action event.
+func (s *Service) UseAutoAcceptor(acceptor AutoAcceptor) {
+	s.autoAcceptor = acceptor
+}
+
 // HandleInbound handles inbound message (issuecredential protocol).
 func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
 	aEvent := s.ActionEvent()
@@ -233,6 +285,18 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string)
 
 	// trigger action event based on message type for inbound messages
 	if canTriggerActionEvents(msg) {
+		if opt, ok := s.autoAccept(md); ok {
+			if opt != nil {
+				opt(md)
+			}
+
+			s.processCallback(md)
+
+			return "", nil
+		}
+
+		md.CreatedAt = s.clock.Now()
+
 		err = s.saveTransitionalPayload(md.PIID, md.transitionalPayload)
 		if err != nil {
 			return "", fmt.Errorf("save transitional payload: %w", err)
@@ -306,6 +370,10 @@ func (s *Service) doHandle(msg service.DIDCommMsg, outbound bool) (*metaData, er
 		return nil, fmt.Errorf("invalid state transition: %s -> %s", current.Name(), next.Name())
 	}
 
+	if current.Name() == stateNameStart {
+		s.metrics.Started()
+	}
+
 	return &metaData{
 		transitionalPayload: transitionalPayload{
 			StateName: next.Name(),
@@ -347,21 +415,38 @@ func isNoOp(s state) bool {
 	return ok
 }
 
+// handle traces the transitions of the protocol instance triggered by the message of md.
 func (s *Service) handle(md *metaData) error {
+	span := s.tracing.Start(md.Msg, md.PIID)
+	span.SetAttributes(tracing.Attributes{tracing.StateAttribute: md.state.Name()})
+
+	err := s.transition(md)
+
+	span.End(err)
+
+	return err
+}
+
+func (s *Service) transition(md *metaData) error {
 	var (
 		current   = md.state
 		actions   []stateAction
 		stateName string
+		abandoned bool
 	)
 
 	for !isNoOp(current) {
 		stateName = current.Name()
+		abandoned = abandoned || stateName == stateNameAbandoning
+		startedAt := time.Now()
 
 		next, action, err := s.execute(current, md)
 		if err != nil {
 			return fmt.Errorf("execute: %w", err)
 		}
 
+		s.metrics.Transition(stateName, time.Since(startedAt))
+
 		actions = append(actions, action)
 
 		if !isNoOp(next) && !current.CanTransitionTo(next) {
@@ -381,9 +466,25 @@ func (s *Service) handle(md *metaData) error {
 		}
 	}
 
+	s.recordFinalState(stateName, abandoned)
+
 	return nil
 }
 
+func (s *Service) recordFinalState(stateName string, abandoned bool) {
+	if stateName != stateNameDone {
+		return
+	}
+
+	if abandoned {
+		s.metrics.Failed()
+
+		return
+	}
+
+	s.metrics.Completed()
+}
+
 func getPIID(msg service.DIDCommMsg) (string, error) {
 	if pthID := msg.ParentThreadID(); pthID != "" {
 		return pthID, nil
@@ -477,6 +578,28 @@ func (s *Service) saveTransitionalPayload(id string, data transitionalPayload) e
 	return s.store.Put(fmt.Sprintf(transitionalPayloadKey, id), src)
 }
 
+// autoAccept checks whether the inbound message is accepted by the auto acceptor. The offers are accepted
+// according to the auto-accept configuration of the connection.
+func (s *Service) autoAccept(md *metaData) (Opt, bool) {
+	if md.msgClone.Type() == OfferCredentialMsgType {
+		accept, _ := s.autoAccepter.ResolveByDIDs(connection.AutoAcceptCredentialOffer, md.MyDID, md.TheirDID)
+
+		return nil, accept
+	}
+
+	if s.autoAcceptor == nil {
+		return nil, false
+	}
+
+	if t := md.msgClone.Type(); t != ProposeCredentialMsgType && t != RequestCredentialMsgType {
+		return nil, false
+	}
+
+	md.properties = newEventProps(md).All()
+
+	return s.autoAcceptor(md)
+}
+
 // canTriggerActionEvents checks if the incoming message can trigger an action event.
 func canTriggerActionEvents(msg service.DIDCommMsg) bool {
 	return msg.Type() == ProposeCredentialMsgType ||
@@ -541,22 +664,26 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 		return fmt.Errorf("get transitional payload: %w", err)
 	}
 
+	if cErr == nil {
+		cErr = errProtocolStopped
+	}
+
+	return s.stop(tPayload, cErr, map[string]interface{}{})
+}
+
+func (s *Service) stop(tPayload *transitionalPayload, cErr error, properties map[string]interface{}) error {
 	md := &metaData{
 		transitionalPayload: *tPayload,
 		state:               stateFromName(tPayload.StateName),
 		msgClone:            tPayload.Msg.Clone(),
 		inbound:             true,
-		properties:          map[string]interface{}{},
+		properties:          properties,
 	}
 
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
 		return fmt.Errorf("delete transitional payload: %w", err)
 	}
 
-	if cErr == nil {
-		cErr = errProtocolStopped
-	}
-
 	md.err = customError{error: cErr}
 	s.processCallback(md)
 
@@ -565,28 +692,112 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 
 // Actions returns actions for the async usage.
 func (s *Service) Actions() ([]Action, error) {
+	payloads, err := s.transitionalPayloads()
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []Action
+
+	for _, tPayload := range payloads {
+		actions = append(actions, tPayload.Action)
+	}
+
+	return actions, nil
+}
+
+// ResumeAll triggers the action events for the actions that were pending when the agent was stopped, e.g. to let
+// the new action event consumers handle them after the agent restart. The actions pending longer than the given
+// timeout are stopped (the protocol is abandoned). Zero timeout means the actions never expire.
+func (s *Service) ResumeAll(timeout time.Duration) error {
+	aEvent := s.ActionEvent()
+
+	// throw error if there is no action event registered for inbound messages
+	if aEvent == nil {
+		return errors.New("no clients are registered to handle the message")
+	}
+
+	payloads, err := s.transitionalPayloads()
+	if err != nil {
+		return fmt.Errorf("transitional payloads: %w", err)
+	}
+
+	for _, tPayload := range payloads {
+		if s.expired(tPayload.CreatedAt, timeout) {
+			if err = s.expire(tPayload); err != nil {
+				return fmt.Errorf("stop expired action: %w", err)
+			}
+
+			continue
+		}
+
+		aEvent <- s.newDIDCommActionMsg(&metaData{
+			transitionalPayload: *tPayload,
+			state:               stateFromName(tPayload.StateName),
+			msgClone:            tPayload.Msg.Clone(),
+			inbound:             true,
+			properties:          map[string]interface{}{},
+		})
+	}
+
+	return nil
+}
+
+// ExpireAll stops the actions that were pending longer than the given timeout (the protocol is abandoned), so the
+// transitional payloads do not accumulate in the store. The message events of the expired actions have
+// the "expired" property set.
+func (s *Service) ExpireAll(timeout time.Duration) error {
+	payloads, err := s.transitionalPayloads()
+	if err != nil {
+		return fmt.Errorf("transitional payloads: %w", err)
+	}
+
+	for _, tPayload := range payloads {
+		if !s.expired(tPayload.CreatedAt, timeout) {
+			continue
+		}
+
+		if err = s.expire(tPayload); err != nil {
+			return fmt.Errorf("stop expired action: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) expire(tPayload *transitionalPayload) error {
+	return s.stop(tPayload, ErrActionExpired, map[string]interface{}{expiredPropKey: true})
+}
+
+// expired checks whether the action created at the given time is pending longer than the timeout.
+// Zero timeout means the actions never expire.
+func (s *Service) expired(createdAt time.Time, timeout time.Duration) bool {
+	return timeout > 0 && !createdAt.IsZero() && clock.Since(s.clock, createdAt) > timeout
+}
+
+func (s *Service) transitionalPayloads() ([]*transitionalPayload, error) {
 	records := s.store.Iterator(
 		fmt.Sprintf(transitionalPayloadKey, ""),
 		fmt.Sprintf(transitionalPayloadKey, storage.EndKeySuffix),
 	)
 	defer records.Release()
 
-	var actions []Action
+	var payloads []*transitionalPayload
 
 	for records.Next() {
 		if records.Error() != nil {
 			return nil, records.Error()
 		}
 
-		var action Action
-		if err := json.Unmarshal(records.Value(), &action); err != nil {
+		tPayload := &transitionalPayload{}
+		if err := json.Unmarshal(records.Value(), tPayload); err != nil {
 			return nil, fmt.Errorf("unmarshal: %w", err)
 		}
 
-		actions = append(actions, action)
+		payloads = append(payloads, tPayload)
 	}
 
-	return actions, nil
+	return payloads, nil
 }
 
 func (s *Service) processCallback(msg *metaData) {
@@ -668,6 +879,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
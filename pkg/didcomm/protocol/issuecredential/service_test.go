@@ -16,16 +16,19 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	metricsMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	issuecredentialMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/issuecredential"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 const (
@@ -796,6 +799,91 @@ func TestService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Receive Request Credential (auto accepted)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &IssueCredential{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, IssueCredentialMsgType, r.Type)
+				require.Equal(t, "auto", r.Comment)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+			require.Equal(t, "credential-issued", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		svc.UseAutoAcceptor(func(metadata Metadata) (Opt, bool) {
+			require.Equal(t, RequestCredentialMsgType, metadata.Message().Type())
+			require.Equal(t, Alice, metadata.Properties()["myDID"])
+			require.Equal(t, Bob, metadata.Properties()["theirDID"])
+
+			return WithIssueCredential(&IssueCredential{Comment: "auto"}), true
+		})
+
+		msg := service.NewDIDCommMsgMap(RequestCredential{
+			Type: RequestCredentialMsgType,
+		})
+
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		_, err = svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Request Credential (auto acceptor declined)", func(t *testing.T) {
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		var called bool
+
+		svc.UseAutoAcceptor(func(metadata Metadata) (Opt, bool) {
+			called = true
+
+			return nil, false
+		})
+
+		msg := service.NewDIDCommMsgMap(RequestCredential{
+			Type: RequestCredentialMsgType,
+		})
+
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		_, err = svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+		require.True(t, called)
+
+		action := <-ch
+		require.Equal(t, RequestCredentialMsgType, action.Message.Type())
+	})
+
 	t.Run("Receive Problem Report (continue)", func(t *testing.T) {
 		done := make(chan struct{})
 
@@ -1384,6 +1472,221 @@ func TestService_ActionStop(t *testing.T) {
 	})
 }
 
+func TestService_ResumeAll(t *testing.T) {
+	t.Run("No clients", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		require.EqualError(t, svc.ResumeAll(0), "no clients are registered to handle the message")
+	})
+
+	t.Run("Resumes the pending action after restart", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		done := make(chan struct{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				require.Equal(t, OfferCredentialMsgType, msg.Type())
+
+				return nil
+			})
+
+		storeProvider := mem.NewProvider()
+
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+		provider.EXPECT().StorageProvider().Return(storeProvider).AnyTimes()
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		// the action event is never handled e.g. the agent is stopped
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction, 1)))
+
+		msg := service.NewDIDCommMsgMap(ProposeCredential{Type: ProposeCredentialMsgType})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		_, err = svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		restarted, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, restarted.RegisterActionEvent(ch))
+
+		require.NoError(t, restarted.ResumeAll(time.Hour))
+
+		select {
+		case action := <-ch:
+			require.Equal(t, msg.ID(), action.Message.ID())
+			require.Equal(t, Alice, action.Properties.All()["myDID"])
+
+			action.Continue(WithOfferCredential(&OfferCredential{}))
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		actions, err := restarted.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+
+	t.Run("Stops the expired action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		done := make(chan struct{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, _ *service.NestedReplyOpts) error {
+				defer close(done)
+
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, ProblemReportMsgType, r.Type)
+
+				return nil
+			})
+
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(ProposeCredential{Type: ProposeCredentialMsgType})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		_, err = svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		<-ch
+
+		require.NoError(t, svc.ResumeAll(time.Nanosecond))
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		select {
+		case <-ch:
+			t.Error("expired action should not be resumed")
+		default:
+		}
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+}
+
+func TestService_ExpireAll(t *testing.T) {
+	t.Run("Keeps the actions within the timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(ProposeCredential{Type: ProposeCredentialMsgType})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		_, err = svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		<-ch
+
+		require.NoError(t, svc.ExpireAll(time.Hour))
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+	})
+
+	t.Run("Stops the expired action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).Return(nil)
+
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		events := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		msg := service.NewDIDCommMsgMap(ProposeCredential{Type: ProposeCredentialMsgType})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		_, err = svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		<-ch
+
+		require.NoError(t, svc.ExpireAll(time.Nanosecond))
+
+		for {
+			select {
+			case e := <-events:
+				if e.StateID != stateNameAbandoning || e.Type != service.PostState {
+					continue
+				}
+
+				require.Equal(t, true, e.Properties.All()[expiredPropKey])
+
+				actions, err := svc.Actions()
+				require.NoError(t, err)
+				require.Empty(t, actions)
+
+				return
+			case <-time.After(time.Second):
+				t.Fatal("timeout")
+			}
+		}
+	})
+}
+
 func Test_stateFromName(t *testing.T) {
 	require.Equal(t, stateFromName(stateNameStart), &start{})
 	require.Equal(t, stateFromName(stateNameAbandoning), &abandoning{})
@@ -1463,6 +1766,10 @@ func TestService_Name(t *testing.T) {
 	require.Equal(t, (*Service).Name(nil), Name)
 }
 
+func TestService_Protocols(t *testing.T) {
+	require.Equal(t, []string{"https://didcomm.org/issue-credential/2.0"}, (*Service).Protocols(nil))
+}
+
 func TestService_Accept(t *testing.T) {
 	require.True(t, (*Service).Accept(nil, ProposeCredentialMsgType))
 	require.True(t, (*Service).Accept(nil, OfferCredentialMsgType))
@@ -1492,3 +1799,152 @@ func TestService_canTriggerActionEvents(t *testing.T) {
 
 	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(struct{}{})))
 }
+
+type metricsProvider struct {
+	Provider
+	metrics metrics.Provider
+}
+
+func (p *metricsProvider) Metrics() metrics.Provider {
+	return p.metrics
+}
+
+func TestService_Metrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failed := metricsMocks.NewMockCounter(ctrl)
+	failed.EXPECT().Inc(metrics.Labels{metrics.ProtocolLabel: Name})
+
+	transitions := metricsMocks.NewMockHistogram(ctrl)
+	for _, stateName := range []string{stateNameAbandoning, stateNameDone} {
+		transitions.EXPECT().Observe(gomock.Any(), metrics.Labels{
+			metrics.ProtocolLabel: Name,
+			metrics.StateLabel:    stateName,
+		})
+	}
+
+	metricsProv := metricsMocks.NewMockProvider(ctrl)
+	metricsProv.EXPECT().Counter(metrics.ExchangesStarted).Return(metricsMocks.NewMockCounter(ctrl))
+	metricsProv.EXPECT().Counter(metrics.ExchangesCompleted).Return(metricsMocks.NewMockCounter(ctrl))
+	metricsProv.EXPECT().Counter(metrics.ExchangesFailed).Return(failed)
+	metricsProv.EXPECT().Histogram(metrics.StateTransitionDuration).Return(transitions)
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+	svc, err := New(&metricsProvider{Provider: provider, metrics: metricsProv})
+	require.NoError(t, err)
+
+	msg := service.NewDIDCommMsgMap(model.ProblemReport{Type: ProblemReportMsgType})
+
+	// the abandoned exchange ends in the done state but it is counted as failed
+	require.NoError(t, svc.handle(&metaData{
+		transitionalPayload: transitionalPayload{
+			Action: Action{Msg: msg, PIID: uuid.New().String()},
+		},
+		state:    &abandoning{},
+		msgClone: msg,
+		inbound:  true,
+	}))
+}
+
+type autoAcceptProvider struct {
+	Provider
+	config             *connection.AutoAcceptConfig
+	protocolStateStore storage.Provider
+}
+
+func (p *autoAcceptProvider) AutoAcceptConfig() *connection.AutoAcceptConfig {
+	return p.config
+}
+
+func (p *autoAcceptProvider) ProtocolStateStorageProvider() storage.Provider {
+	return p.protocolStateStore
+}
+
+func TestService_AutoAcceptOffer(t *testing.T) {
+	const Carol = "Carol"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	storeProvider := mem.NewProvider()
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(storeProvider).AnyTimes()
+
+	prov := &autoAcceptProvider{
+		Provider: provider,
+		config: &connection.AutoAcceptConfig{
+			Global: connection.AutoAccept{connection.AutoAcceptCredentialOffer: true},
+		},
+		protocolStateStore: mem.NewProvider(),
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	// the connection with Bob overrides the global configuration
+	record := &connection.Record{
+		ConnectionID: uuid.New().String(),
+		State:        connection.StateNameCompleted,
+		MyDID:        Alice,
+		TheirDID:     Bob,
+		AutoAccept:   connection.AutoAccept{connection.AutoAcceptCredentialOffer: false},
+	}
+	require.NoError(t, recorder.SaveConnectionRecord(record))
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	ch := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(ch))
+
+	newOffer := func() service.DIDCommMsgMap {
+		msg := service.NewDIDCommMsgMap(OfferCredential{Type: OfferCredentialMsgType})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		return msg
+	}
+
+	t.Run("Offer auto accepted", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Carol).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				require.Equal(t, RequestCredentialMsgType, msg.Type())
+
+				return nil
+			})
+
+		_, err = svc.HandleInbound(newOffer(), Alice, Carol)
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+		case <-ch:
+			t.Error("action event triggered for the auto accepted offer")
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Offer not auto accepted for the connection", func(t *testing.T) {
+		_, err = svc.HandleInbound(newOffer(), Alice, Bob)
+		require.NoError(t, err)
+
+		select {
+		case action := <-ch:
+			require.Equal(t, OfferCredentialMsgType, action.Message.Type())
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+}
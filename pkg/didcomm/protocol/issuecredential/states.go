@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/problemcode"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 )
 
@@ -35,8 +36,8 @@ const (
 )
 
 const (
-	codeRejectedError = "rejected"
-	codeInternalError = "internal"
+	codeRejectedError = model.CodeRejected
+	codeInternalError = model.CodeInternal
 )
 
 // state action for network call.
@@ -131,6 +132,13 @@ func (s *abandoning) ExecuteInbound(md *metaData) (state, stateAction, error) {
 		code = model.Code{Code: codeRejectedError}
 	}
 
+	// the expired actions and the typed errors (e.g. an invalid proof) have their own codes
+	if errors.Is(md.err, ErrActionExpired) {
+		code = model.Code{Code: model.CodeRequestTime}
+	} else if c := problemcode.Of(md.err); c != "" {
+		code = model.Code{Code: c}
+	}
+
 	thID, err := md.Msg.ThreadID()
 	if err != nil {
 		return nil, nil, fmt.Errorf("threadID: %w", err)
@@ -331,12 +339,16 @@ func (s *offerReceived) ExecuteInbound(md *metaData) (state, stateAction, error)
 		return nil, nil, fmt.Errorf("decode: %w", err)
 	}
 
+	// the request is kept in the metadata, so the middleware of the next state can adjust it before it is sent
+	if md.requestCredential == nil {
+		md.requestCredential = &RequestCredential{RequestsAttach: offer.OffersAttach}
+	}
+
 	// creates the state's action
 	action := func(messenger service.Messenger) error {
-		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(RequestCredential{
-			Type:           RequestCredentialMsgType,
-			RequestsAttach: offer.OffersAttach,
-		}), md.MyDID, md.TheirDID)
+		// sets message type
+		md.requestCredential.Type = RequestCredentialMsgType
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(md.requestCredential), md.MyDID, md.TheirDID)
 	}
 
 	return &requestSent{}, action, nil
@@ -382,11 +394,14 @@ func (s *credentialReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *credentialReceived) ExecuteInbound(md *metaData) (state, stateAction, error) {
+	ack, err := newAck(md.Msg, md.rejectedCredentials)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ack: %w", err)
+	}
+
 	// creates the state's action
 	action := func(messenger service.Messenger) error {
-		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(model.Ack{
-			Type: AckMsgType,
-		}), md.MyDID, md.TheirDID)
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(ack), md.MyDID, md.TheirDID)
 	}
 
 	return &done{}, action, nil
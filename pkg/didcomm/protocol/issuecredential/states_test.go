@@ -17,6 +17,8 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 )
 
@@ -159,6 +161,42 @@ func TestAbandoning_ExecuteInbound(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("Typed errors", func(t *testing.T) {
+		tests := []struct {
+			err  error
+			code string
+		}{
+			{err: fmt.Errorf("save: %w", verifiable.ErrProofInvalid), code: model.CodeTrustCrypto},
+			{err: customError{error: verifiable.ErrExpired}, code: model.CodeTrust},
+			{err: customError{error: ErrActionExpired}, code: model.CodeRequestTime},
+		}
+
+		for _, tc := range tests {
+			md := &metaData{err: tc.err}
+			md.Msg = service.NewDIDCommMsgMap(struct{}{})
+			require.NoError(t, md.Msg.SetID(uuid.New().String()))
+
+			_, action, err := (&abandoning{Code: codeInternalError}).ExecuteInbound(md)
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+
+			messenger := serviceMocks.NewMockMessenger(ctrl)
+			messenger.EXPECT().
+				ReplyToNested(gomock.Any(), gomock.Any()).
+				Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+					r := &model.ProblemReport{}
+					require.NoError(t, msg.Decode(r))
+					require.Equal(t, tc.code, r.Description.Code)
+
+					return nil
+				})
+
+			require.NoError(t, action(messenger))
+			ctrl.Finish()
+		}
+	})
+
 	t.Run("Without code", func(t *testing.T) {
 		md := &metaData{}
 		md.Msg = service.NewDIDCommMsgMap(struct{}{})
@@ -508,6 +546,31 @@ func TestOfferReceived_ExecuteInbound(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("correct data (with RequestCredential)", func(t *testing.T) {
+		followup, action, err := (&offerReceived{}).ExecuteInbound(&metaData{
+			requestCredential: &RequestCredential{Comment: "request"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &requestSent{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				r := &RequestCredential{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, RequestCredentialMsgType, r.Type)
+				require.Equal(t, "request", r.Comment)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
 	t.Run("Decode error", func(t *testing.T) {
 		followup, action, err := (&offerReceived{}).ExecuteInbound(&metaData{
 			transitionalPayload: transitionalPayload{
@@ -605,6 +668,54 @@ func TestCredentialReceived_ExecuteInbound(t *testing.T) {
 
 		require.NoError(t, action(messenger))
 	})
+
+	t.Run("Reports the status of each credential", func(t *testing.T) {
+		msg := service.NewDIDCommMsgMap(IssueCredential{
+			Type: IssueCredentialMsgType,
+			CredentialsAttach: []decorator.Attachment{
+				{ID: "cred-1", Data: decorator.AttachmentData{Base64: "e30="}},
+				{ID: "cred-2", Data: decorator.AttachmentData{Base64: "e30="}},
+			},
+		})
+
+		md := &metaData{rejectedCredentials: []string{"cred-2"}}
+		md.Msg = msg
+
+		followup, action, err := (&credentialReceived{}).ExecuteInbound(md)
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(msg, gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, reply service.DIDCommMsgMap, _, _ string) error {
+				ack := &Ack{}
+				require.NoError(t, reply.Decode(ack))
+				require.Equal(t, AckMsgType, ack.Type)
+				require.Equal(t, AckStatusOK, ack.Status)
+				require.Equal(t, []CredentialStatus{
+					{AttachID: "cred-1", Status: AckStatusOK},
+					{AttachID: "cred-2", Status: AckStatusFail},
+				}, ack.Credentials)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = service.DIDCommMsgMap{"credentials~attach": "invalid"}
+
+		followup, action, err := (&credentialReceived{}).ExecuteInbound(md)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ack: decode")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
 }
 
 func TestCredentialReceived_ExecuteOutbound(t *testing.T) {
@@ -55,3 +55,77 @@ type UpdateResponse struct {
 	Action       string `json:"action,omitempty"`
 	Result       string `json:"result,omitempty"`
 }
+
+// RequestV2 coordinate mediation 2.0 mediate-request message.
+// https://didcomm.org/coordinate-mediation/2.0/#mediate-request
+type RequestV2 struct {
+	ID          string         `json:"id,omitempty"`
+	Type        string         `json:"type,omitempty"`
+	ExpiresTime int64          `json:"expires_time,omitempty"`
+	Body        *RequestV2Body `json:"body"`
+}
+
+// RequestV2Body is the (empty) body of the mediate-request 2.0 message.
+type RequestV2Body struct{}
+
+// GrantV2 coordinate mediation 2.0 mediate-grant message.
+// https://didcomm.org/coordinate-mediation/2.0/#mediate-grant
+type GrantV2 struct {
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	ThreadID string       `json:"thid,omitempty"`
+	Body     *GrantV2Body `json:"body"`
+}
+
+// GrantV2Body is the body of the mediate-grant 2.0 message.
+type GrantV2Body struct {
+	RoutingDID []string `json:"routing_did"`
+}
+
+// DenyV2 coordinate mediation 2.0 mediate-deny message.
+// https://didcomm.org/coordinate-mediation/2.0/#mediate-deny
+type DenyV2 struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type,omitempty"`
+	ThreadID string `json:"thid,omitempty"`
+}
+
+// KeylistUpdateV2 coordinate mediation 2.0 keylist-update message.
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-update
+type KeylistUpdateV2 struct {
+	ID   string               `json:"id,omitempty"`
+	Type string               `json:"type,omitempty"`
+	Body *KeylistUpdateV2Body `json:"body"`
+}
+
+// KeylistUpdateV2Body is the body of the keylist-update 2.0 message.
+type KeylistUpdateV2Body struct {
+	Updates []UpdateV2 `json:"updates"`
+}
+
+// UpdateV2 route DID update, the recipients are identified by their DIDs in v2.
+type UpdateV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+}
+
+// KeylistUpdateResponseV2 coordinate mediation 2.0 keylist-update-response message.
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-update-response
+type KeylistUpdateResponseV2 struct {
+	ID       string                       `json:"id,omitempty"`
+	Type     string                       `json:"type,omitempty"`
+	ThreadID string                       `json:"thid,omitempty"`
+	Body     *KeylistUpdateResponseV2Body `json:"body"`
+}
+
+// KeylistUpdateResponseV2Body is the body of the keylist-update-response 2.0 message.
+type KeylistUpdateResponseV2Body struct {
+	Updated []UpdateResponseV2 `json:"updated"`
+}
+
+// UpdateResponseV2 route DID update response.
+type UpdateResponseV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+	Result       string `json:"result,omitempty"`
+}
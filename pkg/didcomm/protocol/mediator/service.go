@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 var logger = log.New("aries-framework/route/service")
@@ -53,6 +55,27 @@ const (
 	KeylistUpdateResponseMsgType = CoordinationSpec + "keylist_update_response"
 )
 
+// constants for coordinate mediation 2.0 spec types, the recipients are identified by their DIDs.
+const (
+	// CoordinationSpecV2 defines the coordinate mediation 2.0 spec.
+	CoordinationSpecV2 = "https://didcomm.org/coordinate-mediation/2.0/"
+
+	// RequestV2MsgType defines the coordinate mediation 2.0 request message type.
+	RequestV2MsgType = CoordinationSpecV2 + "mediate-request"
+
+	// GrantV2MsgType defines the coordinate mediation 2.0 request grant message type.
+	GrantV2MsgType = CoordinationSpecV2 + "mediate-grant"
+
+	// DenyV2MsgType defines the coordinate mediation 2.0 request deny message type.
+	DenyV2MsgType = CoordinationSpecV2 + "mediate-deny"
+
+	// KeylistUpdateV2MsgType defines the coordinate mediation 2.0 keylist update message type.
+	KeylistUpdateV2MsgType = CoordinationSpecV2 + "keylist-update"
+
+	// KeylistUpdateResponseV2MsgType defines the coordinate mediation 2.0 keylist update response message type.
+	KeylistUpdateResponseV2MsgType = CoordinationSpecV2 + "keylist-update-response"
+)
+
 // constants for key list update processing
 // https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#keylist-update
 const (
@@ -77,6 +100,8 @@ const (
 	routeConfigDataKey = "route_config_%s"
 
 	routeGrantKey = "grant_%s"
+
+	routeDenyKey = "deny_%s"
 )
 
 const (
@@ -89,6 +114,9 @@ var ErrConnectionNotFound = errors.New("connection not found")
 // ErrRouterNotRegistered router not registered error.
 var ErrRouterNotRegistered = errors.New("router not registered")
 
+// ErrMediationDenied mediation request denied by the router error.
+var ErrMediationDenied = errors.New("mediation denied")
+
 // provider contains dependencies for the Routing protocol and is typically created by using aries.Context().
 type provider interface {
 	OutboundDispatcher() dispatcher.Outbound
@@ -106,9 +134,12 @@ type ClientOption func(opts *ClientOptions)
 // ClientOptions holds options for the router client.
 type ClientOptions struct {
 	Timeout time.Duration
+	// CoordinationV2 registers with the router using coordinate mediation 2.0.
+	CoordinationV2 bool
 }
 
 // Options is a container for route protocol options.
+// The RoutingKeys are granted as the routing DIDs to coordinate mediation 2.0 requests.
 type Options struct {
 	ServiceEndpoint string
 	RoutingKeys     []string
@@ -199,6 +230,11 @@ func (s *Service) listenForCallbacks() {
 			if err != nil {
 				logger.Errorf("failed to handle inbound request: %+v : %w", c.msg, err)
 			}
+		case RequestV2MsgType:
+			err := s.handleInboundRequestV2(c)
+			if err != nil {
+				logger.Errorf("failed to handle inbound request: %+v : %w", c.msg, err)
+			}
 		default:
 			logger.Warnf("ignoring unsupported message type %s", c.msg.Type())
 		}
@@ -207,10 +243,25 @@ func (s *Service) listenForCallbacks() {
 
 func (s *Service) handleUserRejection(c *callback) {
 	logger.Infof("user aborted response action for msgID=%s", c.msg.ID())
+
+	// coordinate mediation 2.0 lets the router tell the recipient that the mediation was denied
+	if c.msg.Type() != RequestV2MsgType {
+		return
+	}
+
+	deny := &DenyV2{
+		ID:       uuid.New().String(),
+		Type:     DenyV2MsgType,
+		ThreadID: c.msg.ID(),
+	}
+
+	if err := s.outbound.SendToDID(deny, c.myDID, c.theirDID); err != nil {
+		logger.Errorf("failed to send mediate deny for msgID=%s : %s", c.msg.ID(), err)
+	}
 }
 
 func triggersActionEvent(msgType string) bool {
-	return msgType == RequestMsgType
+	return msgType == RequestMsgType || msgType == RequestV2MsgType
 }
 
 func (s *Service) sendActionEvent(msg service.DIDCommMsg, myDID, theirDID string) error {
@@ -269,10 +320,18 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string)
 		switch msg.Type() {
 		case GrantMsgType:
 			err = s.saveGrant(msg)
+		case GrantV2MsgType:
+			err = s.saveGrantV2(msg)
+		case DenyV2MsgType:
+			err = s.saveDeny(msg)
 		case KeylistUpdateMsgType:
 			err = s.handleKeylistUpdate(msg, myDID, theirDID)
+		case KeylistUpdateV2MsgType:
+			err = s.handleKeylistUpdateV2(msg, myDID, theirDID)
 		case KeylistUpdateResponseMsgType:
 			err = s.handleKeylistUpdateResponse(msg)
+		case KeylistUpdateResponseV2MsgType:
+			err = s.handleKeylistUpdateResponseV2(msg)
 		case service.ForwardMsgType:
 			err = s.handleForward(msg)
 		}
@@ -326,6 +385,8 @@ func (s *Service) Accept(msgType string) bool {
 	switch msgType {
 	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType, service.ForwardMsgType:
 		return true
+	case RequestV2MsgType, GrantV2MsgType, DenyV2MsgType, KeylistUpdateV2MsgType, KeylistUpdateResponseV2MsgType:
+		return true
 	}
 
 	return false
@@ -336,6 +397,15 @@ func (s *Service) Name() string {
 	return Coordination
 }
 
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{
+		strings.TrimSuffix(CoordinationSpec, "/"),
+		strings.TrimSuffix(CoordinationSpecV2, "/"),
+		strings.TrimSuffix(service.ForwardMsgType, "/forward"),
+	}
+}
+
 func (s *Service) handleInboundRequest(c *callback) error {
 	// unmarshal the payload
 	request := &Request{}
@@ -366,6 +436,36 @@ func (s *Service) handleInboundRequest(c *callback) error {
 	return s.outbound.SendToDID(grant, c.myDID, c.theirDID)
 }
 
+func (s *Service) handleInboundRequestV2(c *callback) error {
+	request := &RequestV2{}
+
+	err := c.msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("handleInboundRequestV2: route request message unmarshal : %w", err)
+	}
+
+	routingDIDs := c.options.RoutingKeys
+
+	if len(routingDIDs) == 0 {
+		_, key, er := s.kms.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		if er != nil {
+			return fmt.Errorf("handleInboundRequestV2: kms failed to create and export ED25519 key: %w", er)
+		}
+
+		didKey, _ := fingerprint.CreateDIDKey(key)
+		routingDIDs = []string{didKey}
+	}
+
+	grant := &GrantV2{
+		ID:       uuid.New().String(),
+		Type:     GrantV2MsgType,
+		ThreadID: c.msg.ID(),
+		Body:     &GrantV2Body{RoutingDID: routingDIDs},
+	}
+
+	return s.outbound.SendToDID(grant, c.myDID, c.theirDID)
+}
+
 func outboundGrant(
 	msgID string, opts *Options,
 	defaultEndpoint string, defaultKey func() (string, error)) (*Grant, error) {
@@ -405,45 +505,85 @@ func (s *Service) handleKeylistUpdate(msg service.DIDCommMsg, myDID, theirDID st
 
 	// update the db
 	for _, v := range keyUpdate.Updates {
-		if v.Action == add {
-			val := theirDID
-			result := success
+		result, ok := s.updateRoute(v.RecipientKey, v.Action, theirDID)
+		if !ok {
+			continue
+		}
 
-			err = s.routeStore.Put(dataKey(v.RecipientKey), []byte(val))
-			if err != nil {
-				logger.Errorf("failed to add the route key to store : %s", err)
+		// construct the response doc
+		updates = append(updates, UpdateResponse{
+			RecipientKey: v.RecipientKey,
+			Action:       v.Action,
+			Result:       result,
+		})
+	}
+
+	// send the key update response
+	updateResponse := &KeylistUpdateResponse{
+		Type:    KeylistUpdateResponseMsgType,
+		ID:      msg.ID(),
+		Updated: updates,
+	}
+
+	return s.outbound.SendToDID(updateResponse, myDID, theirDID)
+}
+
+func (s *Service) handleKeylistUpdateV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	keyUpdate := &KeylistUpdateV2{}
+
+	err := msg.Decode(keyUpdate)
+	if err != nil {
+		return fmt.Errorf("route key list update v2 message unmarshal : %w", err)
+	}
 
-				result = serverError
+	updates := []UpdateResponseV2{}
+
+	if keyUpdate.Body != nil {
+		for _, v := range keyUpdate.Body.Updates {
+			result, ok := s.updateRoute(v.RecipientDID, v.Action, theirDID)
+			if !ok {
+				continue
 			}
 
-			// construct the response doc
-			updates = append(updates, UpdateResponse{
-				RecipientKey: v.RecipientKey,
+			updates = append(updates, UpdateResponseV2{
+				RecipientDID: v.RecipientDID,
 				Action:       v.Action,
 				Result:       result,
 			})
-		} else if v.Action == remove {
-			// TODO remove from the store
-
-			// construct the response doc
-			updates = append(updates, UpdateResponse{
-				RecipientKey: v.RecipientKey,
-				Action:       v.Action,
-				Result:       serverError,
-			})
 		}
 	}
 
-	// send the key update response
-	updateResponse := &KeylistUpdateResponse{
-		Type:    KeylistUpdateResponseMsgType,
-		ID:      msg.ID(),
-		Updated: updates,
+	updateResponse := &KeylistUpdateResponseV2{
+		ID:       uuid.New().String(),
+		Type:     KeylistUpdateResponseV2MsgType,
+		ThreadID: msg.ID(),
+		Body:     &KeylistUpdateResponseV2Body{Updated: updates},
 	}
 
 	return s.outbound.SendToDID(updateResponse, myDID, theirDID)
 }
 
+// updateRoute applies the keylist update action for the recipient (key or DID) and returns the result of the
+// update. False is returned for the unsupported actions.
+func (s *Service) updateRoute(recipient, action, theirDID string) (string, bool) {
+	switch action {
+	case add:
+		err := s.routeStore.Put(dataKey(recipient), []byte(theirDID))
+		if err != nil {
+			logger.Errorf("failed to add the route key to store : %s", err)
+
+			return serverError, true
+		}
+
+		return success, true
+	case remove:
+		// TODO remove from the store
+		return serverError, true
+	}
+
+	return "", false
+}
+
 func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	respMsg := &KeylistUpdateResponse{}
@@ -453,6 +593,41 @@ func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 		return fmt.Errorf("route keylist update response message unmarshal : %w", err)
 	}
 
+	s.notifyKeylistUpdateResponse(respMsg)
+
+	return nil
+}
+
+func (s *Service) handleKeylistUpdateResponseV2(msg service.DIDCommMsg) error {
+	respMsg := &KeylistUpdateResponseV2{}
+
+	err := msg.Decode(respMsg)
+	if err != nil {
+		return fmt.Errorf("route keylist update response v2 message unmarshal : %w", err)
+	}
+
+	// the response is correlated with the keylist update by the thread ID
+	resp := &KeylistUpdateResponse{
+		ID:   respMsg.ThreadID,
+		Type: KeylistUpdateResponseMsgType,
+	}
+
+	if respMsg.Body != nil {
+		for _, v := range respMsg.Body.Updated {
+			resp.Updated = append(resp.Updated, UpdateResponse{
+				RecipientKey: v.RecipientDID,
+				Action:       v.Action,
+				Result:       v.Result,
+			})
+		}
+	}
+
+	s.notifyKeylistUpdateResponse(resp)
+
+	return nil
+}
+
+func (s *Service) notifyKeylistUpdateResponse(respMsg *KeylistUpdateResponse) {
 	// check if there are any channels registered for the message ID
 	keylistUpdateCh := s.getKeyUpdateResponseCh(respMsg.ID)
 
@@ -460,8 +635,6 @@ func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 		// invoke the channel for the incoming message
 		keylistUpdateCh <- respMsg
 	}
-
-	return nil
 }
 
 func (s *Service) handleForward(msg service.DIDCommMsg) error {
@@ -480,6 +653,11 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 		return fmt.Errorf("route key fetch : %w", err)
 	}
 
+	// the recipient polls for the messages, keep them until they are picked up
+	if s.messagePickupSvc != nil && !s.messagePickupSvc.LiveDelivery(string(theirDID)) {
+		return s.messagePickupSvc.AddMessage(forward.Msg, string(theirDID))
+	}
+
 	dest, err := service.GetDestination(string(theirDID), s.vdRegistry)
 	if err != nil {
 		return fmt.Errorf("get destination : %w", err)
@@ -505,6 +683,10 @@ func (s *Service) Register(connectionID string, options ...ClientOption) error {
 
 	opts := parseClientOpts(options...)
 
+	if opts.CoordinationV2 {
+		return s.doRegistrationV2(record, opts.Timeout)
+	}
+
 	return s.doRegistration(
 		record,
 		&Request{
@@ -517,6 +699,26 @@ func (s *Service) Register(connectionID string, options ...ClientOption) error {
 }
 
 func (s *Service) doRegistration(record *connection.Record, req *Request, timeout time.Duration) error {
+	// TODO: would this be better served as time.Now().Add(timeout).Unix() as pkg/doc/verifiable/credential.go
+	// demonstrates? additionally `ExpiresTime` would need to be migrated to int64
+	req.ExpiresTime = time.Now().UTC().Add(timeout)
+
+	return s.register(record, req, req.ID, timeout)
+}
+
+func (s *Service) doRegistrationV2(record *connection.Record, timeout time.Duration) error {
+	req := &RequestV2{
+		ID:          uuid.New().String(),
+		Type:        RequestV2MsgType,
+		ExpiresTime: time.Now().Add(timeout).Unix(),
+		Body:        &RequestV2Body{},
+	}
+
+	return s.register(record, req, req.ID, timeout)
+}
+
+// register sends the mediate request to the router and saves the router config once the request is granted.
+func (s *Service) register(record *connection.Record, req interface{}, reqID string, timeout time.Duration) error {
 	// check if router is already registered
 	err := s.ensureConnectionExists(record.ConnectionID)
 	if err == nil {
@@ -527,10 +729,6 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 		return fmt.Errorf("ensure connection exists: %w", err)
 	}
 
-	// TODO: would this be better served as time.Now().Add(timeout).Unix() as pkg/doc/verifiable/credential.go
-	// demonstrates? additionally `ExpiresTime` would need to be migrated to int64
-	req.ExpiresTime = time.Now().UTC().Add(timeout)
-
 	// send message to the router
 	if err = s.outbound.SendToDID(req, record.MyDID, record.TheirDID); err != nil {
 		return fmt.Errorf("send route request: %w", err)
@@ -538,15 +736,28 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 
 	var grant *Grant
 	// waits until the mediate-grant message is received or timeout was exceeded
-	grant, err = s.getGrant(req.ID, timeout)
+	grant, err = s.getGrant(reqID, timeout)
 	if err != nil {
 		return fmt.Errorf("get grant: %w", err)
 	}
 
-	err = s.saveRouterConfig(record.ConnectionID, &config{
+	conf := &config{
 		RouterEndpoint: grant.Endpoint,
 		RoutingKeys:    grant.RoutingKeys,
-	})
+	}
+
+	if grant.Type == GrantV2MsgType {
+		// the mediate-grant 2.0 has the routing DIDs only, the endpoint is the one of the router's DID
+		dest, e := service.GetDestination(record.TheirDID, s.vdRegistry)
+		if e != nil {
+			return fmt.Errorf("get router destination : %w", e)
+		}
+
+		conf.RouterEndpoint = dest.ServiceEndpoint
+		conf.CoordinationV2 = true
+	}
+
+	err = s.saveRouterConfig(record.ConnectionID, conf)
 	if err != nil {
 		return fmt.Errorf("save route config : %w", err)
 	}
@@ -562,6 +773,10 @@ func (s *Service) getGrant(id string, timeout time.Duration) (*Grant, error) {
 	)
 
 	err = backoff.Retry(func() error {
+		if _, e := s.routeStore.Get(fmt.Sprintf(routeDenyKey, id)); e == nil {
+			return backoff.Permanent(ErrMediationDenied)
+		}
+
 		src, err = s.routeStore.Get(fmt.Sprintf(routeGrantKey, id))
 
 		return err
@@ -590,6 +805,48 @@ func (s *Service) saveGrant(grant service.DIDCommMsg) error {
 	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, grant.ID()), src)
 }
 
+// saveGrantV2 saves the mediate-grant 2.0 as the v1 grant of the request (thread) it was sent for.
+func (s *Service) saveGrantV2(msg service.DIDCommMsg) error {
+	grantV2 := &GrantV2{}
+
+	err := msg.Decode(grantV2)
+	if err != nil {
+		return fmt.Errorf("grant v2 message unmarshal : %w", err)
+	}
+
+	grant := &Grant{
+		ID:   grantV2.ThreadID,
+		Type: GrantV2MsgType,
+	}
+
+	if grantV2.Body != nil {
+		grant.RoutingKeys = grantV2.Body.RoutingDID
+	}
+
+	src, err := json.Marshal(grant)
+	if err != nil {
+		return fmt.Errorf("marshal grant: %w", err)
+	}
+
+	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, grant.ID), src)
+}
+
+func (s *Service) saveDeny(msg service.DIDCommMsg) error {
+	deny := &DenyV2{}
+
+	err := msg.Decode(deny)
+	if err != nil {
+		return fmt.Errorf("deny message unmarshal : %w", err)
+	}
+
+	src, err := json.Marshal(deny)
+	if err != nil {
+		return fmt.Errorf("marshal deny: %w", err)
+	}
+
+	return s.routeStore.Put(fmt.Sprintf(routeDenyKey, deny.ThreadID), src)
+}
+
 // Unregister unregisters the agent with the router.
 func (s *Service) Unregister(connID string) error {
 	// check if router is already registered
@@ -627,7 +884,8 @@ func (s *Service) GetConnections() ([]string, error) {
 }
 
 // AddKey adds a recKey of the agent to the registered router. This method blocks until a response is
-// received from the router or it times out.
+// received from the router or it times out. The routers registered using coordinate mediation 2.0 expect
+// the recipient DID instead of the key.
 // TODO https://github.com/hyperledger/aries-framework-go/issues/1076 Support for multiple routers
 // TODO https://github.com/hyperledger/aries-framework-go/issues/1105 Support to Add multiple
 //  recKeys to the Router
@@ -644,6 +902,11 @@ func (s *Service) AddKey(connID, recKey string) error {
 		return fmt.Errorf("get connection: %w", err)
 	}
 
+	v2, err := s.coordinationV2(connID)
+	if err != nil {
+		return fmt.Errorf("get coordination version: %w", err)
+	}
+
 	// generate message ID
 	msgID := uuid.New().String()
 
@@ -651,7 +914,7 @@ func (s *Service) AddKey(connID, recKey string) error {
 	keyUpdateCh := make(chan *KeylistUpdateResponse)
 	s.setKeyUpdateResponseCh(msgID, keyUpdateCh)
 
-	keyUpdate := &KeylistUpdate{
+	var keyUpdate interface{} = &KeylistUpdate{
 		ID:   msgID,
 		Type: KeylistUpdateMsgType,
 		Updates: []Update{
@@ -662,6 +925,21 @@ func (s *Service) AddKey(connID, recKey string) error {
 		},
 	}
 
+	if v2 {
+		keyUpdate = &KeylistUpdateV2{
+			ID:   msgID,
+			Type: KeylistUpdateV2MsgType,
+			Body: &KeylistUpdateV2Body{
+				Updates: []UpdateV2{
+					{
+						RecipientDID: recKey,
+						Action:       add,
+					},
+				},
+			},
+		}
+	}
+
 	if err := s.outbound.SendToDID(keyUpdate, conn.MyDID, conn.TheirDID); err != nil {
 		return fmt.Errorf("send route request: %w", err)
 	}
@@ -681,6 +959,22 @@ func (s *Service) AddKey(connID, recKey string) error {
 	return nil
 }
 
+// SetLiveDelivery enables or disables the live delivery of the messages by the router. With the live delivery
+// disabled, the router keeps the messages until they are picked up (e.g. the agent has no connectivity to keep
+// the duplex connection open).
+func (s *Service) SetLiveDelivery(connID string, enabled bool) error {
+	// check if router is already registered
+	if err := s.ensureConnectionExists(connID); err != nil {
+		return fmt.Errorf("ensure connection exists: %w", err)
+	}
+
+	if err := s.messagePickupSvc.LiveDeliveryChange(connID, enabled); err != nil {
+		return fmt.Errorf("live delivery change: %w", err)
+	}
+
+	return nil
+}
+
 // Config fetches the router config - endpoint and routingKeys.
 func (s *Service) Config(connID string) (*Config, error) {
 	// check if router is already registered
@@ -739,6 +1033,28 @@ func (s *Service) saveRouterConnectionID(connID string) error {
 type config struct {
 	RouterEndpoint string
 	RoutingKeys    []string
+	CoordinationV2 bool `json:",omitempty"`
+}
+
+// coordinationV2 checks whether the router was registered using coordinate mediation 2.0.
+func (s *Service) coordinationV2(connID string) (bool, error) {
+	val, err := s.routeStore.Get(fmt.Sprintf(routeConfigDataKey, connID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("get router config data : %w", err)
+	}
+
+	conf := &config{}
+
+	err = json.Unmarshal(val, conf)
+	if err != nil {
+		return false, fmt.Errorf("unmarshal router config data : %w", err)
+	}
+
+	return conf.CoordinationV2, nil
 }
 
 func (s *Service) getRouterConfig(connID string) (*Config, error) {
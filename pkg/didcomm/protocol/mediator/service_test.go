@@ -53,6 +53,10 @@ func TestServiceNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.Equal(t, Coordination, svc.Name())
+		require.Equal(t, []string{
+			"https://didcomm.org/coordinatemediation/1.0", "https://didcomm.org/coordinate-mediation/2.0",
+			"https://didcomm.org/routing/1.0",
+		}, svc.Protocols())
 	})
 
 	t.Run("test new service name - failure", func(t *testing.T) {
@@ -75,6 +79,11 @@ func TestServiceAccept(t *testing.T) {
 	require.Equal(t, true, s.Accept(KeylistUpdateMsgType))
 	require.Equal(t, true, s.Accept(KeylistUpdateResponseMsgType))
 	require.Equal(t, true, s.Accept(service.ForwardMsgType))
+	require.Equal(t, true, s.Accept(RequestV2MsgType))
+	require.Equal(t, true, s.Accept(GrantV2MsgType))
+	require.Equal(t, true, s.Accept(DenyV2MsgType))
+	require.Equal(t, true, s.Accept(KeylistUpdateV2MsgType))
+	require.Equal(t, true, s.Accept(KeylistUpdateResponseV2MsgType))
 	require.Equal(t, false, s.Accept("unsupported msg type"))
 }
 
@@ -917,6 +926,52 @@ func TestMessagePickup(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "add error")
 	})
+
+	t.Run("test service handle inbound message pick up - live delivery disabled", func(t *testing.T) {
+		to := randomID()
+
+		content := &model.Envelope{
+			Protected: "eyJ0eXAiOiJwcnMuaHlwZXJsZWRnZXIuYXJpZXMtYXV0aC1t" +
+				"ZXNzYWdlIiwiYWxnIjoiRUNESC1TUytYQzIwUEtXIiwiZW5jIjoiWEMyMFAifQ",
+			IV:         "JS2FxjEKdndnt-J7QX5pEnVwyBTu0_3d",
+			CipherText: "qQyzvajdvCDJbwxM",
+			Tag:        "2FqZMMQuNPYfL0JsSkj8LQ",
+		}
+
+		added := false
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{
+					LiveDeliveryDisabled: true,
+					AddMessageFunc: func(message *model.Envelope, theirDID string) error {
+						require.Equal(t, content, message)
+						require.Equal(t, "did:example:123", theirDID)
+
+						added = true
+
+						return nil
+					},
+				},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateForward: func(_ interface{}, _ *service.Destination) error {
+					return errors.New("live delivery should be skipped")
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		err = svc.routeStore.Put(dataKey(to), []byte("did:example:123"))
+		require.NoError(t, err)
+
+		err = svc.handleForward(generateForwardMsgPayload(t, randomID(), to, content))
+		require.NoError(t, err)
+		require.True(t, added)
+	})
 }
 
 func TestRegister(t *testing.T) {
@@ -1275,6 +1330,71 @@ func TestKeylistUpdate(t *testing.T) {
 	})
 }
 
+func TestSetLiveDelivery(t *testing.T) {
+	t.Run("test set live delivery - success", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{
+					LiveDeliveryChangeFunc: func(connectionID string, liveDelivery bool) error {
+						require.Equal(t, "connID-123", connectionID)
+						require.False(t, liveDelivery)
+
+						return nil
+					},
+				},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.saveRouterConnectionID("connID-123"))
+
+		err = svc.SetLiveDelivery("connID-123", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("test set live delivery - router not registered", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		err = svc.SetLiveDelivery("connID-123", false)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+
+	t.Run("test set live delivery - live delivery change error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{
+					LiveDeliveryChangeErr: errors.New("send error"),
+				},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.saveRouterConnectionID("connID-123"))
+
+		err = svc.SetLiveDelivery("connID-123", true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "live delivery change: send error")
+	})
+}
+
 func TestConfig(t *testing.T) {
 	routingKeys := []string{"abc", "xyz"}
 
@@ -1457,6 +1577,488 @@ func TestGetConnections(t *testing.T) {
 	})
 }
 
+func TestCoordinationV2(t *testing.T) {
+	t.Run("test continuing inbound v2 request dispatches grant with routing DIDs", func(t *testing.T) {
+		routingDIDs := []string{"did:example:router"}
+		grants := make(chan *GrantV2)
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					grant, ok := msg.(*GrantV2)
+					require.True(t, ok)
+
+					grants <- grant
+
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		events := make(chan service.DIDCommAction)
+		require.NoError(t, svc.RegisterActionEvent(events))
+
+		msgID := randomID()
+
+		_, err = svc.HandleInbound(generateV2MsgPayload(t, &RequestV2{
+			ID:   msgID,
+			Type: RequestV2MsgType,
+			Body: &RequestV2Body{},
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		select {
+		case e := <-events:
+			e.Continue(Options{RoutingKeys: routingDIDs})
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+
+		select {
+		case grant := <-grants:
+			require.Equal(t, GrantV2MsgType, grant.Type)
+			require.Equal(t, msgID, grant.ThreadID)
+			require.Equal(t, routingDIDs, grant.Body.RoutingDID)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+	})
+
+	t.Run("test inbound v2 request is granted with a did:key by default", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{CrAndExportPubKeyValue: []byte("key")},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					grant, ok := msg.(*GrantV2)
+					require.True(t, ok)
+					require.Len(t, grant.Body.RoutingDID, 1)
+					require.Contains(t, grant.Body.RoutingDID[0], "did:key:")
+
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		err = svc.handleInboundRequestV2(&callback{
+			msg:     generateV2MsgPayload(t, &RequestV2{ID: randomID(), Type: RequestV2MsgType}),
+			options: &Options{},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("test inbound v2 request - kms failure", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("kms error")},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		err = svc.handleInboundRequestV2(&callback{
+			msg:     generateV2MsgPayload(t, &RequestV2{ID: randomID(), Type: RequestV2MsgType}),
+			options: &Options{},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "kms error")
+
+		err = svc.handleInboundRequestV2(&callback{msg: &service.DIDCommMsgMap{"body": "invalid"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route request message unmarshal")
+	})
+
+	t.Run("test stopping inbound v2 request dispatches deny", func(t *testing.T) {
+		denies := make(chan *DenyV2)
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					deny, ok := msg.(*DenyV2)
+					require.True(t, ok)
+
+					denies <- deny
+
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		events := make(chan service.DIDCommAction)
+		require.NoError(t, svc.RegisterActionEvent(events))
+
+		msgID := randomID()
+
+		_, err = svc.HandleInbound(generateV2MsgPayload(t, &RequestV2{
+			ID:   msgID,
+			Type: RequestV2MsgType,
+			Body: &RequestV2Body{},
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		select {
+		case e := <-events:
+			e.Stop(errors.New("rejected"))
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+
+		select {
+		case deny := <-denies:
+			require.Equal(t, DenyV2MsgType, deny.Type)
+			require.Equal(t, msgID, deny.ThreadID)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+	})
+
+	t.Run("test register route using v2 - success", func(t *testing.T) {
+		msgID := make(chan string)
+		routingDIDs := []string{"did:example:router"}
+
+		s := make(map[string][]byte)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					request, ok := msg.(*RequestV2)
+					require.True(t, ok)
+					require.Equal(t, RequestV2MsgType, request.Type)
+
+					msgID <- request.ID
+					return nil
+				},
+			},
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveFunc: func(didID string, opts ...vdr.ResolveOpts) (doc *did.Doc, e error) {
+					require.Equal(t, THEIRDID, didID)
+
+					return mockdiddoc.GetMockDIDDoc(), nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = connBytes
+
+		go func() {
+			require.NoError(t, svc.saveGrantV2(generateV2MsgPayload(t, &GrantV2{
+				ID:       randomID(),
+				Type:     GrantV2MsgType,
+				ThreadID: <-msgID,
+				Body:     &GrantV2Body{RoutingDID: routingDIDs},
+			})))
+		}()
+
+		err = svc.Register("conn", func(opts *ClientOptions) {
+			opts.CoordinationV2 = true
+		})
+		require.NoError(t, err)
+
+		conf, err := svc.Config("conn")
+		require.NoError(t, err)
+		require.Equal(t, mockdiddoc.GetMockDIDDoc().Service[0].ServiceEndpoint, conf.Endpoint())
+		require.Equal(t, routingDIDs, conf.Keys())
+
+		v2, err := svc.coordinationV2("conn")
+		require.NoError(t, err)
+		require.True(t, v2)
+	})
+
+	t.Run("test register route using v2 - denied", func(t *testing.T) {
+		msgID := make(chan string)
+
+		s := make(map[string][]byte)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					msgID <- msg.(*RequestV2).ID
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = connBytes
+
+		go func() {
+			require.NoError(t, svc.saveDeny(generateV2MsgPayload(t, &DenyV2{
+				ID:       randomID(),
+				Type:     DenyV2MsgType,
+				ThreadID: <-msgID,
+			})))
+		}()
+
+		err = svc.Register("conn", func(opts *ClientOptions) {
+			opts.CoordinationV2 = true
+		})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrMediationDenied))
+
+		err = svc.ensureConnectionExists("conn")
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+
+	t.Run("test register route using v2 - router DID resolution error", func(t *testing.T) {
+		msgID := make(chan string)
+
+		s := make(map[string][]byte)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					msgID <- msg.(*RequestV2).ID
+					return nil
+				},
+			},
+			VDRegistryValue: &mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")},
+		})
+		require.NoError(t, err)
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = connBytes
+
+		go func() {
+			require.NoError(t, svc.saveGrantV2(generateV2MsgPayload(t, &GrantV2{
+				ID:       randomID(),
+				Type:     GrantV2MsgType,
+				ThreadID: <-msgID,
+				Body:     &GrantV2Body{RoutingDID: []string{"did:example:router"}},
+			})))
+		}()
+
+		err = svc.Register("conn", func(opts *ClientOptions) {
+			opts.CoordinationV2 = true
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get router destination")
+	})
+
+	t.Run("test keylist update v2 - routes by recipient DID", func(t *testing.T) {
+		msgID := randomID()
+		recipientDID := "did:example:recipient"
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					res, ok := msg.(*KeylistUpdateResponseV2)
+					require.True(t, ok)
+					require.Equal(t, KeylistUpdateResponseV2MsgType, res.Type)
+					require.Equal(t, msgID, res.ThreadID)
+					require.Equal(t, []UpdateResponseV2{
+						{RecipientDID: recipientDID, Action: add, Result: success},
+						{RecipientDID: recipientDID, Action: remove, Result: serverError},
+					}, res.Body.Updated)
+
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		err = svc.handleKeylistUpdateV2(generateV2MsgPayload(t, &KeylistUpdateV2{
+			ID:   msgID,
+			Type: KeylistUpdateV2MsgType,
+			Body: &KeylistUpdateV2Body{
+				Updates: []UpdateV2{
+					{RecipientDID: recipientDID, Action: add},
+					{RecipientDID: recipientDID, Action: remove},
+					{RecipientDID: recipientDID, Action: "unsupported"},
+				},
+			},
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		theirDID, err := svc.routeStore.Get(dataKey(recipientDID))
+		require.NoError(t, err)
+		require.Equal(t, THEIRDID, string(theirDID))
+	})
+
+	t.Run("test add key using v2 - success", func(t *testing.T) {
+		recipientDID := "did:example:recipient"
+		keyUpdateMsg := make(chan *KeylistUpdateV2)
+
+		s := make(map[string][]byte)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					request, ok := msg.(*KeylistUpdateV2)
+					require.True(t, ok)
+
+					keyUpdateMsg <- request
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.saveRouterConnectionID("conn"))
+		require.NoError(t, svc.saveRouterConfig("conn", &config{
+			RouterEndpoint: ENDPOINT,
+			CoordinationV2: true,
+		}))
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = connBytes
+
+		go func() {
+			updateMsg := <-keyUpdateMsg
+			require.Equal(t, recipientDID, updateMsg.Body.Updates[0].RecipientDID)
+
+			require.NoError(t, svc.handleKeylistUpdateResponseV2(generateV2MsgPayload(t, &KeylistUpdateResponseV2{
+				ID:       randomID(),
+				Type:     KeylistUpdateResponseV2MsgType,
+				ThreadID: updateMsg.ID,
+				Body: &KeylistUpdateResponseV2Body{
+					Updated: []UpdateResponseV2{
+						{RecipientDID: recipientDID, Action: add, Result: success},
+					},
+				},
+			})))
+		}()
+
+		err = svc.AddKey("conn", recipientDID)
+		require.NoError(t, err)
+	})
+
+	t.Run("test add key - invalid router config", func(t *testing.T) {
+		s := make(map[string][]byte)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.saveRouterConnectionID("conn"))
+		require.NoError(t, svc.routeStore.Put(fmt.Sprintf(routeConfigDataKey, "conn"), []byte("invalid")))
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = connBytes
+
+		err = svc.AddKey("conn", "did:example:recipient")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get coordination version")
+	})
+
+	t.Run("test v2 messages - unmarshal errors", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		msg := &service.DIDCommMsgMap{"body": "invalid", "thid": map[string]int{}}
+
+		err = svc.saveGrantV2(msg)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "grant v2 message unmarshal")
+
+		err = svc.saveDeny(msg)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "deny message unmarshal")
+
+		err = svc.handleKeylistUpdateV2(msg, MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route key list update v2 message unmarshal")
+
+		err = svc.handleKeylistUpdateResponseV2(msg)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route keylist update response v2 message unmarshal")
+	})
+}
+
+func generateV2MsgPayload(t *testing.T, msg interface{}) service.DIDCommMsg {
+	msgBytes, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(msgBytes)
+	require.NoError(t, err)
+
+	return didMsg
+}
+
 func generateRequestMsgPayload(t *testing.T, id string) service.DIDCommMsg {
 	requestBytes, err := json.Marshal(&Request{
 		Type: RequestMsgType,
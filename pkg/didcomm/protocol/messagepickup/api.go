@@ -13,4 +13,6 @@ import (
 // ProtocolService service interface for message pickup.
 type ProtocolService interface {
 	AddMessage(message *model.Envelope, theirDID string) error
+	LiveDelivery(theirDID string) bool
+	LiveDeliveryChange(connectionID string, liveDelivery bool) error
 }
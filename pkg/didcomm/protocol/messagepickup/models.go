@@ -60,6 +60,14 @@ type Message struct {
 	Message   *model.Envelope `json:"msg,omitempty"`
 }
 
+// LiveDeliveryChange a request to enable or disable the live delivery of the messages
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0685-pickup-v2#live-mode-change
+type LiveDeliveryChange struct {
+	Type         string `json:"@type,omitempty"`
+	ID           string `json:"@id,omitempty"`
+	LiveDelivery bool   `json:"live_delivery"`
+}
+
 // Noop message
 // https://github.com/hyperledger/aries-rfcs/tree/master/features/0212-pickup#noop
 type Noop struct {
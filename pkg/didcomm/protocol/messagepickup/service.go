@@ -9,6 +9,7 @@ package messagepickup
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -41,6 +42,10 @@ const (
 	BatchMsgType = Spec + "batch"
 	// NoopMsgType defines the protocol request-credential message type.
 	NoopMsgType = Spec + "noop"
+	// SpecV2 defines the pickup 2.0 protocol spec.
+	SpecV2 = "https://didcomm.org/messagepickup/2.0/"
+	// LiveDeliveryChangeMsgType defines the protocol live-delivery-change message type.
+	LiveDeliveryChangeMsgType = SpecV2 + "live-delivery-change"
 )
 
 const (
@@ -125,6 +130,8 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string)
 			err = s.handleBatch(msg)
 		case NoopMsgType:
 			err = s.handleNoop(msg)
+		case LiveDeliveryChangeMsgType:
+			err = s.handleLiveDeliveryChange(msg, theirDID)
 		}
 
 		if err != nil {
@@ -143,7 +150,8 @@ func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, err
 // Accept checks whether the service can handle the message type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
-	case BatchPickupMsgType, BatchMsgType, StatusRequestMsgType, StatusMsgType, NoopMsgType:
+	case BatchPickupMsgType, BatchMsgType, StatusRequestMsgType, StatusMsgType, NoopMsgType,
+		LiveDeliveryChangeMsgType:
 		return true
 	}
 
@@ -155,6 +163,11 @@ func (s *Service) Name() string {
 	return MessagePickup
 }
 
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
 func (s *Service) handleStatus(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	statusMsg := &Status{}
@@ -293,14 +306,42 @@ func (s *Service) handleNoop(msg service.DIDCommMsg) error {
 	return nil
 }
 
+func (s *Service) handleLiveDeliveryChange(msg service.DIDCommMsg, theirDID string) error {
+	s.inboxLock.Lock(theirDID)
+	defer s.inboxLock.Unlock(theirDID)
+
+	// unmarshal the payload
+	request := &LiveDeliveryChange{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("live delivery change message unmarshal : %w", err)
+	}
+
+	outbox, err := s.createInbox(theirDID)
+	if err != nil {
+		return fmt.Errorf("live delivery change get inbox: %w", err)
+	}
+
+	outbox.LiveDeliveryDisabled = !request.LiveDelivery
+
+	err = s.putInbox(theirDID, outbox)
+	if err != nil {
+		return fmt.Errorf("live delivery change put inbox: %w", err)
+	}
+
+	return nil
+}
+
 type inbox struct {
-	DID               string          `json:"DID"`
-	MessageCount      int             `json:"message_count"`
-	LastAddedTime     time.Time       `json:"last_added_time,omitempty"`
-	LastDeliveredTime time.Time       `json:"last_delivered_time,omitempty"`
-	LastRemovedTime   time.Time       `json:"last_removed_time,omitempty"`
-	TotalSize         int             `json:"total_size,omitempty"`
-	Messages          json.RawMessage `json:"messages"`
+	DID                  string          `json:"DID"`
+	MessageCount         int             `json:"message_count"`
+	LastAddedTime        time.Time       `json:"last_added_time,omitempty"`
+	LastDeliveredTime    time.Time       `json:"last_delivered_time,omitempty"`
+	LastRemovedTime      time.Time       `json:"last_removed_time,omitempty"`
+	TotalSize            int             `json:"total_size,omitempty"`
+	LiveDeliveryDisabled bool            `json:"live_delivery_disabled,omitempty"`
+	Messages             json.RawMessage `json:"messages"`
 }
 
 // DecodeMessages Messages.
@@ -369,6 +410,24 @@ func (s *Service) AddMessage(message *model.Envelope, theirDID string) error {
 	return nil
 }
 
+// LiveDelivery checks whether the messages can be delivered to the DID as soon as they are received.
+// The live delivery is enabled unless the recipient disabled it to poll for the messages instead.
+func (s *Service) LiveDelivery(theirDID string) bool {
+	s.inboxLock.Lock(theirDID)
+	defer s.inboxLock.Unlock(theirDID)
+
+	outbox, err := s.getInbox(theirDID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			logger.Warnf("live delivery get inbox: %s", err)
+		}
+
+		return true
+	}
+
+	return !outbox.LiveDeliveryDisabled
+}
+
 func (s *Service) createInbox(theirDID string) (*inbox, error) {
 	msgs, err := s.getInbox(theirDID)
 	if err != nil && err == storage.ErrDataNotFound {
@@ -525,6 +584,28 @@ func (s *Service) Noop(connectionID string) error {
 	return nil
 }
 
+// LiveDeliveryChange asks the router to enable or disable the live delivery of the messages.
+// With the live delivery disabled, the router keeps the messages until they are picked up.
+func (s *Service) LiveDeliveryChange(connectionID string, liveDelivery bool) error {
+	// get the connection record for the ID to fetch DID information
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	req := &LiveDeliveryChange{
+		Type:         LiveDeliveryChangeMsgType,
+		ID:           uuid.New().String(),
+		LiveDelivery: liveDelivery,
+	}
+
+	if err := s.outbound.SendToDID(req, conn.MyDID, conn.TheirDID); err != nil {
+		return fmt.Errorf("send live delivery change request: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Service) getConnection(routerConnID string) (*connection.Record, error) {
 	conn, err := s.connectionLookup.GetConnectionRecord(routerConnID)
 	if err != nil {
@@ -36,6 +36,7 @@ func TestServiceNew(t *testing.T) {
 		svc, err := getService()
 		require.NoError(t, err)
 		require.Equal(t, MessagePickup, svc.Name())
+		require.Equal(t, []string{"https://didcomm.org/messagepickup/1.0"}, svc.Protocols())
 	})
 
 	t.Run("test new service name - store error", func(t *testing.T) {
@@ -434,6 +435,60 @@ func TestHandleInbound(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "noop message unmarshal")
 	})
+
+	t.Run("test MessagePickupService.HandleInbound() - LiveDeliveryChange", func(t *testing.T) {
+		const jsonStr = `{
+			"@id": "123456781",
+			"@type": "https://didcomm.org/messagepickup/2.0/live-delivery-change",
+			"live_delivery": false
+		}`
+
+		svc, err := getService()
+		require.NoError(t, err)
+
+		require.True(t, svc.LiveDelivery(THEIRDID))
+
+		msg, err := service.ParseDIDCommMsgMap([]byte(jsonStr))
+		require.NoError(t, err)
+
+		require.NoError(t, svc.handleLiveDeliveryChange(msg, THEIRDID))
+		require.False(t, svc.LiveDelivery(THEIRDID))
+
+		msg["live_delivery"] = true
+
+		require.NoError(t, svc.handleLiveDeliveryChange(msg, THEIRDID))
+		require.True(t, svc.LiveDelivery(THEIRDID))
+	})
+
+	t.Run("test MessagePickupService.HandleInbound() - LiveDeliveryChange - msg error", func(t *testing.T) {
+		svc, err := getService()
+		require.NoError(t, err)
+
+		msg := &service.DIDCommMsgMap{"@id": map[int]int{}}
+		err = svc.handleLiveDeliveryChange(msg, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "live delivery change message unmarshal")
+	})
+
+	t.Run("test MessagePickupService.HandleInbound() - LiveDeliveryChange - put error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				Store: &mockstore.MockStore{
+					Store:  make(map[string][]byte),
+					ErrPut: errors.New("put error"),
+				},
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		}, &mockTransportProvider{
+			packagerValue: &mockPackager{},
+		})
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&LiveDeliveryChange{Type: LiveDeliveryChangeMsgType})
+		err = svc.handleLiveDeliveryChange(msg, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "put error")
+	})
 }
 
 func TestAccept(t *testing.T) {
@@ -446,6 +501,7 @@ func TestAccept(t *testing.T) {
 		require.True(t, svc.Accept(NoopMsgType))
 		require.True(t, svc.Accept(BatchMsgType))
 		require.True(t, svc.Accept(BatchPickupMsgType))
+		require.True(t, svc.Accept(LiveDeliveryChangeMsgType))
 		require.False(t, svc.Accept("random-msg-type"))
 	})
 }
@@ -954,6 +1010,84 @@ func TestNoop(t *testing.T) {
 	})
 }
 
+func TestLiveDeliveryChange(t *testing.T) {
+	t.Run("test MessagePickupService.LiveDeliveryChange() - success", func(t *testing.T) {
+		provider := &mockprovider.Provider{
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					require.Equal(t, myDID, MYDID)
+					require.Equal(t, theirDID, THEIRDID)
+
+					request, ok := msg.(*LiveDeliveryChange)
+					require.True(t, ok)
+					require.Equal(t, LiveDeliveryChangeMsgType, request.Type)
+					require.False(t, request.LiveDelivery)
+
+					return nil
+				},
+			},
+		}
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "completed",
+		}))
+
+		svc, err := New(provider, &mockTransportProvider{
+			packagerValue: &mockPackager{},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.LiveDeliveryChange("conn", false))
+	})
+
+	t.Run("test MessagePickupService.LiveDeliveryChange() - send to DID error", func(t *testing.T) {
+		provider := &mockprovider.Provider{
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					return errors.New("send error")
+				},
+			},
+		}
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "completed",
+		}))
+
+		svc, err := New(provider, &mockTransportProvider{
+			packagerValue: &mockPackager{},
+		})
+		require.NoError(t, err)
+
+		err = svc.LiveDeliveryChange("conn", true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send live delivery change request")
+	})
+
+	t.Run("test MessagePickupService.LiveDeliveryChange() - connection error", func(t *testing.T) {
+		svc, err := getService()
+		require.NoError(t, err)
+
+		expected := errors.New("get error")
+		svc.connectionLookup = &connectionsStub{
+			getConnRecord: func(string) (*connection.Record, error) {
+				return nil, expected
+			},
+		}
+
+		err = svc.LiveDeliveryChange("conn", true)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+}
+
 func TestGetConnection(t *testing.T) {
 	t.Run("test MessagePickupService.getConnection() - error", func(t *testing.T) {
 		svc, err := getService()
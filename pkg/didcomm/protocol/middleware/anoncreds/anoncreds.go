@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// CredFilterFormat is the format of the propose-credential attachment describing the requested credential.
+	CredFilterFormat = "hlindy/cred-filter@v2.0"
+	// CredAbstractFormat is the format of the offer-credential attachment containing the credential offer.
+	CredAbstractFormat = "hlindy/cred-abstract@v2.0"
+	// CredReqFormat is the format of the request-credential attachment containing the credential request.
+	CredReqFormat = "hlindy/cred-req@v2.0"
+	// CredFormat is the format of the issue-credential attachment containing the issued credential.
+	CredFormat = "hlindy/cred@v2.0"
+	// ProofReqFormat is the format of the request-presentation attachment containing the proof request.
+	ProofReqFormat = "hlindy/proof-req@v2.0"
+	// ProofFormat is the format of the presentation attachment containing the proof.
+	ProofFormat = "hlindy/proof@v2.0"
+
+	formatPrefix = "hlindy/"
+	storeName    = "anoncreds"
+
+	offerKeyPrefix        = "offer_"
+	proofRequestKeyPrefix = "proofreq_"
+	mimeTypeJSON          = "application/json"
+)
+
+// Backend is the pluggable AnonCreds implementation the format handlers delegate to.
+// All the AnonCreds objects are passed as their JSON serialization.
+type Backend interface {
+	// CreateCredentialOffer creates the credential offer for the credential filter proposed by the holder.
+	CreateCredentialOffer(filter []byte) ([]byte, error)
+	// CreateCredentialRequest creates the holder's credential request for the credential offer.
+	CreateCredentialRequest(offer []byte, proverDID string) ([]byte, error)
+	// CreateCredential issues the credential with the given values for the credential request.
+	CreateCredential(offer, request []byte, values map[string]string) ([]byte, error)
+	// StoreCredential stores the issued credential in the holder's wallet.
+	StoreCredential(credential []byte) error
+	// CreateProof creates the proof satisfying the proof request from the prover's wallet.
+	CreateProof(proofRequest []byte) ([]byte, error)
+	// VerifyProof verifies the proof against the proof request.
+	VerifyProof(proofRequest, proof []byte) error
+}
+
+// Provider contains dependencies for the AnonCreds middlewares.
+type Provider interface {
+	StorageProvider() storage.Provider
+}
+
+// IsAnonCredsFormat checks whether the attachment format is one of the AnonCreds formats.
+func IsAnonCredsFormat(format string) bool {
+	return strings.HasPrefix(format, formatPrefix)
+}
+
+// offerRecord keeps the offer sent by the issuer until the holder requests the credential.
+type offerRecord struct {
+	Offer  []byte            `json:"offer"`
+	Values map[string]string `json:"values,omitempty"`
+}
+
+type formatEntry struct {
+	AttachID string
+	Format   string
+}
+
+// attachmentData returns the data of the attachment having the given format, if any.
+func attachmentData(formats []formatEntry, attachments []decorator.Attachment, format string) ([]byte, error) {
+	for _, f := range formats {
+		if f.Format != format {
+			continue
+		}
+
+		for i := range attachments {
+			if attachments[i].ID != f.AttachID {
+				continue
+			}
+
+			data, err := attachments[i].Data.Fetch()
+			if err != nil {
+				return nil, fmt.Errorf("fetch %s: %w", format, err)
+			}
+
+			return data, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func newAttachment(data []byte) decorator.Attachment {
+	return decorator.Attachment{
+		ID:       uuid.New().String(),
+		MimeType: mimeTypeJSON,
+		Data: decorator.AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString(data),
+		},
+	}
+}
+
+func threadID(msg service.DIDCommMsg) (string, error) {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("threadID: %w", err)
+	}
+
+	return thID, nil
+}
+
+func openStore(p Provider) (storage.Store, error) {
+	store, err := p.StorageProvider().OpenStore(storeName)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	return store, nil
+}
+
+func putJSON(store storage.Store, key string, v interface{}) error {
+	src, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	return store.Put(key, src)
+}
+
+func getJSON(store storage.Store, key string, v interface{}) error {
+	src, err := store.Get(key)
+	if err != nil {
+		return fmt.Errorf("store get: %w", err)
+	}
+
+	return json.Unmarshal(src, v)
+}
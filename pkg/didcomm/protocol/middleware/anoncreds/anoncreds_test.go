@@ -0,0 +1,372 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	issuecredentialMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
+	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const (
+	thID   = "thread-1"
+	errMsg = "error"
+)
+
+type backend struct {
+	err         error
+	values      map[string]string
+	proverDID   string
+	credentials [][]byte
+	proofs      [][]byte
+}
+
+func (b *backend) CreateCredentialOffer(filter []byte) ([]byte, error) {
+	return append([]byte("offer:"), filter...), b.err
+}
+
+func (b *backend) CreateCredentialRequest(offer []byte, proverDID string) ([]byte, error) {
+	b.proverDID = proverDID
+
+	return append([]byte("request:"), offer...), b.err
+}
+
+func (b *backend) CreateCredential(offer, request []byte, values map[string]string) ([]byte, error) {
+	b.values = values
+
+	return append(append([]byte("credential:"), offer...), request...), b.err
+}
+
+func (b *backend) StoreCredential(credential []byte) error {
+	b.credentials = append(b.credentials, credential)
+
+	return b.err
+}
+
+func (b *backend) CreateProof(proofRequest []byte) ([]byte, error) {
+	return append([]byte("proof:"), proofRequest...), b.err
+}
+
+func (b *backend) VerifyProof(proofRequest, proof []byte) error {
+	b.proofs = append(b.proofs, proof)
+
+	if b.err != nil {
+		return b.err
+	}
+
+	if string(proof) != "proof:"+string(proofRequest) {
+		return errors.New("invalid proof")
+	}
+
+	return nil
+}
+
+func newProvider() Provider {
+	return &mockprovider.Provider{StorageProviderValue: mem.NewProvider()}
+}
+
+func attach(id, data string) decorator.Attachment {
+	return decorator.Attachment{ID: id, Data: decorator.AttachmentData{JSON: data}}
+}
+
+func newMsg(t *testing.T, v interface{}, thID string) service.DIDCommMsgMap {
+	msg := service.NewDIDCommMsgMap(v)
+	require.NoError(t, msg.SetID("id-"+thID))
+
+	if thID != "" {
+		msg["~thread"] = map[string]interface{}{"thid": thID}
+	}
+
+	return msg
+}
+
+func TestIssueCredential(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newMetadata := func(state string, msg service.DIDCommMsg) *issuecredentialMocks.MockMetadata {
+		metadata := issuecredentialMocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(state).AnyTimes()
+		metadata.EXPECT().Message().Return(msg).AnyTimes()
+		metadata.EXPECT().Properties().Return(map[string]interface{}{"myDID": "did:example:holder"}).AnyTimes()
+
+		return metadata
+	}
+
+	next := issuecredential.HandlerFunc(func(issuecredential.Metadata) error { return nil })
+
+	t.Run("Issuer", func(t *testing.T) {
+		b := &backend{}
+
+		mw, err := IssueCredential(newProvider(), b)
+		require.NoError(t, err)
+
+		offer := &issuecredential.OfferCredential{CredentialPreview: issuecredential.PreviewCredential{
+			Attributes: []issuecredential.Attribute{{Name: "name", Value: "Alice"}},
+		}}
+
+		metadata := newMetadata(stateNameOfferSent, newMsg(t, issuecredential.ProposeCredential{
+			Type:         issuecredential.ProposeCredentialMsgType,
+			Formats:      []issuecredential.Format{{AttachID: "1", Format: CredFilterFormat}},
+			FilterAttach: []decorator.Attachment{attach("1", "filter")},
+		}, thID))
+		metadata.EXPECT().OfferCredential().Return(offer)
+
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Len(t, offer.OffersAttach, 1)
+		require.Equal(t, CredAbstractFormat, offer.Formats[0].Format)
+
+		data, err := offer.OffersAttach[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, `offer:"filter"`, string(data))
+
+		credential := &issuecredential.IssueCredential{}
+
+		metadata = newMetadata(stateNameRequestReceived, newMsg(t, issuecredential.RequestCredential{
+			Type:           issuecredential.RequestCredentialMsgType,
+			Formats:        []issuecredential.Format{{AttachID: "2", Format: CredReqFormat}},
+			RequestsAttach: []decorator.Attachment{attach("2", "request")},
+		}, thID))
+		metadata.EXPECT().IssueCredential().Return(credential).Times(2)
+
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Len(t, credential.CredentialsAttach, 1)
+		require.Equal(t, CredFormat, credential.Formats[0].Format)
+		require.Equal(t, map[string]string{"name": "Alice"}, b.values)
+
+		// the offer is consumed by the issued credential
+		require.Contains(t, mw(next).Handle(metadata).Error(), "get offer")
+	})
+
+	t.Run("Issuer (offer initiated by the issuer)", func(t *testing.T) {
+		b := &backend{}
+
+		mw, err := IssueCredential(newProvider(), b)
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNameOfferSent, newMsg(t, issuecredential.OfferCredential{
+			Type:         issuecredential.OfferCredentialMsgType,
+			Formats:      []issuecredential.Format{{AttachID: "1", Format: CredAbstractFormat}},
+			OffersAttach: []decorator.Attachment{attach("1", "abstract")},
+		}, ""))
+
+		require.NoError(t, mw(next).Handle(metadata))
+
+		credential := &issuecredential.IssueCredential{}
+
+		metadata = newMetadata(stateNameRequestReceived, newMsg(t, issuecredential.RequestCredential{
+			Type:           issuecredential.RequestCredentialMsgType,
+			Formats:        []issuecredential.Format{{AttachID: "2", Format: CredReqFormat}},
+			RequestsAttach: []decorator.Attachment{attach("2", "request")},
+		}, "id-"))
+		metadata.EXPECT().IssueCredential().Return(credential)
+
+		require.NoError(t, mw(next).Handle(metadata))
+
+		data, err := credential.CredentialsAttach[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, `credential:"abstract""request"`, string(data))
+	})
+
+	t.Run("Holder", func(t *testing.T) {
+		b := &backend{}
+
+		mw, err := IssueCredential(newProvider(), b)
+		require.NoError(t, err)
+
+		offerAttach := attach("1", "abstract")
+		request := &issuecredential.RequestCredential{RequestsAttach: []decorator.Attachment{offerAttach}}
+
+		metadata := newMetadata(stateNameRequestSent, newMsg(t, issuecredential.OfferCredential{
+			Type:         issuecredential.OfferCredentialMsgType,
+			Formats:      []issuecredential.Format{{AttachID: "1", Format: CredAbstractFormat}},
+			OffersAttach: []decorator.Attachment{offerAttach},
+		}, thID))
+		metadata.EXPECT().RequestCredential().Return(request)
+
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Len(t, request.RequestsAttach, 1)
+		require.Equal(t, []issuecredential.Format{{AttachID: request.RequestsAttach[0].ID, Format: CredReqFormat}},
+			request.Formats)
+		require.Equal(t, "did:example:holder", b.proverDID)
+
+		metadata = newMetadata(stateNameCredentialReceived, newMsg(t, issuecredential.IssueCredential{
+			Type:              issuecredential.IssueCredentialMsgType,
+			Formats:           []issuecredential.Format{{AttachID: "3", Format: CredFormat}},
+			CredentialsAttach: []decorator.Attachment{attach("3", "credential")},
+		}, thID))
+
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Equal(t, [][]byte{[]byte(`"credential"`)}, b.credentials)
+	})
+
+	t.Run("Ignores other formats", func(t *testing.T) {
+		b := &backend{}
+
+		mw, err := IssueCredential(newProvider(), b)
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNameCredentialReceived, newMsg(t, issuecredential.IssueCredential{
+			Type:              issuecredential.IssueCredentialMsgType,
+			CredentialsAttach: []decorator.Attachment{attach("3", "credential")},
+		}, thID))
+
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Empty(t, b.credentials)
+	})
+
+	t.Run("Backend error", func(t *testing.T) {
+		mw, err := IssueCredential(newProvider(), &backend{err: errors.New(errMsg)})
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNameCredentialReceived, newMsg(t, issuecredential.IssueCredential{
+			Type:              issuecredential.IssueCredentialMsgType,
+			Formats:           []issuecredential.Format{{AttachID: "3", Format: CredFormat}},
+			CredentialsAttach: []decorator.Attachment{attach("3", "credential")},
+		}, thID))
+
+		require.EqualError(t, mw(next).Handle(metadata), "anoncreds: store credential: "+errMsg)
+	})
+
+	t.Run("Open store error", func(t *testing.T) {
+		_, err := IssueCredential(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New(errMsg)},
+		}, &backend{})
+		require.EqualError(t, err, "open store: "+errMsg)
+	})
+}
+
+func TestPresentProof(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newMetadata := func(state string, msg service.DIDCommMsg) *presentproofMocks.MockMetadata {
+		metadata := presentproofMocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(state).AnyTimes()
+		metadata.EXPECT().Message().Return(msg).AnyTimes()
+
+		return metadata
+	}
+
+	next := presentproof.HandlerFunc(func(presentproof.Metadata) error { return nil })
+
+	request := presentproof.RequestPresentation{
+		Type:                       presentproof.RequestPresentationMsgType,
+		Formats:                    []presentproof.Format{{AttachID: "1", Format: ProofReqFormat}},
+		RequestPresentationsAttach: []decorator.Attachment{attach("1", "proof-request")},
+	}
+
+	t.Run("Prover and verifier", func(t *testing.T) {
+		b := &backend{}
+
+		mw, err := PresentProof(newProvider(), b)
+		require.NoError(t, err)
+
+		// the verifier sends the request
+		require.NoError(t, mw(next).Handle(newMetadata(stateNameRequestSent, newMsg(t, request, ""))))
+
+		// the prover creates the proof
+		presentation := &presentproof.Presentation{}
+
+		metadata := newMetadata(stateNamePresentationSent, newMsg(t, request, ""))
+		metadata.EXPECT().Presentation().Return(presentation)
+
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Len(t, presentation.PresentationsAttach, 1)
+
+		// the verifier verifies the proof
+		metadata = newMetadata(stateNamePresentationReceived, newMsg(t, presentation, "id-"))
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Len(t, b.proofs, 1)
+	})
+
+	t.Run("Request in response to a proposal", func(t *testing.T) {
+		mw, err := PresentProof(newProvider(), &backend{})
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNameRequestSent, newMsg(t, presentproof.ProposePresentation{
+			Type: presentproof.ProposePresentationMsgType,
+		}, thID))
+		metadata.EXPECT().RequestPresentation().Return(&request)
+
+		require.NoError(t, mw(next).Handle(metadata))
+
+		metadata = newMetadata(stateNamePresentationReceived, newMsg(t, presentproof.Presentation{
+			Type:                presentproof.PresentationMsgType,
+			Formats:             []presentproof.Format{{AttachID: "2", Format: ProofFormat}},
+			PresentationsAttach: []decorator.Attachment{attach("2", "invalid")},
+		}, thID))
+
+		require.EqualError(t, mw(next).Handle(metadata), "anoncreds: verify proof: invalid proof")
+	})
+
+	t.Run("Proof provided by the user", func(t *testing.T) {
+		b := &backend{}
+
+		mw, err := PresentProof(newProvider(), b)
+		require.NoError(t, err)
+
+		presentation := &presentproof.Presentation{
+			Formats:             []presentproof.Format{{AttachID: "2", Format: ProofFormat}},
+			PresentationsAttach: []decorator.Attachment{attach("2", "proof")},
+		}
+
+		metadata := newMetadata(stateNamePresentationSent, newMsg(t, request, ""))
+		metadata.EXPECT().Presentation().Return(presentation)
+
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Len(t, presentation.PresentationsAttach, 1)
+	})
+
+	t.Run("Proof request not found", func(t *testing.T) {
+		mw, err := PresentProof(newProvider(), &backend{})
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNamePresentationReceived, newMsg(t, presentproof.Presentation{
+			Type:                presentproof.PresentationMsgType,
+			Formats:             []presentproof.Format{{AttachID: "2", Format: ProofFormat}},
+			PresentationsAttach: []decorator.Attachment{attach("2", "proof")},
+		}, thID))
+
+		require.Contains(t, mw(next).Handle(metadata).Error(), "get proof request")
+	})
+
+	t.Run("Backend error", func(t *testing.T) {
+		mw, err := PresentProof(newProvider(), &backend{err: errors.New(errMsg)})
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNamePresentationSent, newMsg(t, request, ""))
+		metadata.EXPECT().Presentation().Return(&presentproof.Presentation{})
+
+		require.EqualError(t, mw(next).Handle(metadata), "anoncreds: create proof: "+errMsg)
+	})
+
+	t.Run("Open store error", func(t *testing.T) {
+		_, err := PresentProof(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New(errMsg)},
+		}, &backend{})
+		require.EqualError(t, err, "open store: "+errMsg)
+	})
+}
+
+func TestIsAnonCredsFormat(t *testing.T) {
+	require.True(t, IsAnonCredsFormat(CredFormat))
+	require.True(t, IsAnonCredsFormat(ProofFormat))
+	require.False(t, IsAnonCredsFormat(presentproof.PresentationSubmissionFormat))
+}
@@ -0,0 +1,253 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	stateNameOfferSent          = "offer-sent"
+	stateNameRequestReceived    = "request-received"
+	stateNameRequestSent        = "request-sent"
+	stateNameCredentialReceived = "credential-received"
+	myDIDKey                    = "myDID"
+)
+
+// IssueCredential returns the middleware for the issue credential protocol which handles the AnonCreds attachment
+// formats by delegating to the backend. The issuer's offers are created from the holder's credential filters and
+// the credentials are issued for the holder's requests. On the holder's side, the credential requests are created
+// for the issuer's offers and the issued credentials are stored.
+// The messages provided by the user through the Continue function are completed with the AnonCreds attachments.
+func IssueCredential(p Provider, backend Backend) (issuecredential.Middleware, error) {
+	store, err := openStore(p)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &credentialHandler{store: store, backend: backend}
+
+	return func(next issuecredential.Handler) issuecredential.Handler {
+		return issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+			if err := h.handle(metadata); err != nil {
+				return fmt.Errorf("anoncreds: %w", err)
+			}
+
+			return next.Handle(metadata)
+		})
+	}, nil
+}
+
+type credentialHandler struct {
+	store   storage.Store
+	backend Backend
+}
+
+func (h *credentialHandler) handle(metadata issuecredential.Metadata) error {
+	switch metadata.StateName() {
+	case stateNameOfferSent:
+		return h.offerSent(metadata)
+	case stateNameRequestReceived:
+		return h.requestReceived(metadata)
+	case stateNameRequestSent:
+		return h.requestSent(metadata)
+	case stateNameCredentialReceived:
+		return h.credentialReceived(metadata)
+	}
+
+	return nil
+}
+
+// offerSent creates the offer for the holder's credential filter and keeps it until the credential is requested.
+func (h *credentialHandler) offerSent(metadata issuecredential.Metadata) error {
+	var offer *issuecredential.OfferCredential
+
+	if metadata.Message().Type() == issuecredential.OfferCredentialMsgType {
+		// the offer is initiated by the issuer
+		offer = &issuecredential.OfferCredential{}
+
+		if err := metadata.Message().Decode(offer); err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
+	} else {
+		offer = metadata.OfferCredential()
+	}
+
+	if offer == nil {
+		return nil
+	}
+
+	data, err := attachmentData(credentialFormats(offer.Formats), offer.OffersAttach, CredAbstractFormat)
+	if err != nil {
+		return err
+	}
+
+	if data == nil && metadata.Message().Type() == issuecredential.ProposeCredentialMsgType {
+		data, err = h.createOffer(metadata, offer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	thID, err := threadID(metadata.Message())
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{}
+	for _, attr := range offer.CredentialPreview.Attributes {
+		values[attr.Name] = attr.Value
+	}
+
+	return putJSON(h.store, offerKeyPrefix+thID, &offerRecord{Offer: data, Values: values})
+}
+
+func (h *credentialHandler) createOffer(metadata issuecredential.Metadata,
+	offer *issuecredential.OfferCredential) ([]byte, error) {
+	proposal := issuecredential.ProposeCredential{}
+	if err := metadata.Message().Decode(&proposal); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	filter, err := attachmentData(credentialFormats(proposal.Formats), proposal.FilterAttach, CredFilterFormat)
+	if err != nil || filter == nil {
+		return nil, err
+	}
+
+	data, err := h.backend.CreateCredentialOffer(filter)
+	if err != nil {
+		return nil, fmt.Errorf("create credential offer: %w", err)
+	}
+
+	attachment := newAttachment(data)
+	offer.Formats = append(offer.Formats, issuecredential.Format{AttachID: attachment.ID, Format: CredAbstractFormat})
+	offer.OffersAttach = append(offer.OffersAttach, attachment)
+
+	return data, nil
+}
+
+// requestReceived issues the credential for the holder's request.
+func (h *credentialHandler) requestReceived(metadata issuecredential.Metadata) error {
+	credential := metadata.IssueCredential()
+	if credential == nil {
+		return nil
+	}
+
+	request := issuecredential.RequestCredential{}
+	if err := metadata.Message().Decode(&request); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	data, err := attachmentData(credentialFormats(request.Formats), request.RequestsAttach, CredReqFormat)
+	if err != nil || data == nil {
+		return err
+	}
+
+	thID, err := threadID(metadata.Message())
+	if err != nil {
+		return err
+	}
+
+	record := &offerRecord{}
+	if err = getJSON(h.store, offerKeyPrefix+thID, record); err != nil {
+		return fmt.Errorf("get offer: %w", err)
+	}
+
+	cred, err := h.backend.CreateCredential(record.Offer, data, record.Values)
+	if err != nil {
+		return fmt.Errorf("create credential: %w", err)
+	}
+
+	attachment := newAttachment(cred)
+	credential.Formats = append(credential.Formats, issuecredential.Format{AttachID: attachment.ID, Format: CredFormat})
+	credential.CredentialsAttach = append(credential.CredentialsAttach, attachment)
+
+	return h.store.Delete(offerKeyPrefix + thID)
+}
+
+// requestSent replaces the offer copied into the holder's request by the credential request.
+func (h *credentialHandler) requestSent(metadata issuecredential.Metadata) error {
+	request := metadata.RequestCredential()
+	if request == nil || metadata.Message().Type() != issuecredential.OfferCredentialMsgType {
+		return nil
+	}
+
+	offer := issuecredential.OfferCredential{}
+	if err := metadata.Message().Decode(&offer); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	data, err := attachmentData(credentialFormats(offer.Formats), offer.OffersAttach, CredAbstractFormat)
+	if err != nil || data == nil {
+		return err
+	}
+
+	// nolint: errcheck
+	myDID, _ := metadata.Properties()[myDIDKey].(string)
+
+	req, err := h.backend.CreateCredentialRequest(data, myDID)
+	if err != nil {
+		return fmt.Errorf("create credential request: %w", err)
+	}
+
+	abstracts := map[string]struct{}{}
+
+	for _, f := range offer.Formats {
+		if f.Format == CredAbstractFormat {
+			abstracts[f.AttachID] = struct{}{}
+		}
+	}
+
+	attachments := request.RequestsAttach[:0:0]
+
+	for _, a := range request.RequestsAttach {
+		if _, ok := abstracts[a.ID]; !ok {
+			attachments = append(attachments, a)
+		}
+	}
+
+	attachment := newAttachment(req)
+	request.Formats = append(request.Formats, issuecredential.Format{AttachID: attachment.ID, Format: CredReqFormat})
+	request.RequestsAttach = append(attachments, attachment)
+
+	return nil
+}
+
+// credentialReceived stores the issued credential in the holder's wallet.
+func (h *credentialHandler) credentialReceived(metadata issuecredential.Metadata) error {
+	credential := issuecredential.IssueCredential{}
+	if err := metadata.Message().Decode(&credential); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	data, err := attachmentData(credentialFormats(credential.Formats), credential.CredentialsAttach, CredFormat)
+	if err != nil || data == nil {
+		return err
+	}
+
+	if err = h.backend.StoreCredential(data); err != nil {
+		return fmt.Errorf("store credential: %w", err)
+	}
+
+	return nil
+}
+
+func credentialFormats(formats []issuecredential.Format) []formatEntry {
+	entries := make([]formatEntry, len(formats))
+	for i, f := range formats {
+		entries[i] = formatEntry(f)
+	}
+
+	return entries
+}
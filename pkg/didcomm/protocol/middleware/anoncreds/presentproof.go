@@ -0,0 +1,166 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	stateNamePresentationSent     = "presentation-sent"
+	stateNamePresentationReceived = "presentation-received"
+)
+
+// PresentProof returns the middleware for the present proof protocol which handles the AnonCreds attachment
+// formats by delegating to the backend. The verifier's proof requests are kept until the presentation is received
+// and the received proofs are verified against them. On the prover's side, the proofs are created for the
+// verifier's proof requests and added to the presentation provided by the user through the Continue function.
+func PresentProof(p Provider, backend Backend) (presentproof.Middleware, error) {
+	store, err := openStore(p)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &proofHandler{store: store, backend: backend}
+
+	return func(next presentproof.Handler) presentproof.Handler {
+		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+			if err := h.handle(metadata); err != nil {
+				return fmt.Errorf("anoncreds: %w", err)
+			}
+
+			return next.Handle(metadata)
+		})
+	}, nil
+}
+
+type proofHandler struct {
+	store   storage.Store
+	backend Backend
+}
+
+func (h *proofHandler) handle(metadata presentproof.Metadata) error {
+	switch metadata.StateName() {
+	case stateNameRequestSent:
+		return h.requestSent(metadata)
+	case stateNamePresentationReceived:
+		return h.presentationReceived(metadata)
+	case stateNamePresentationSent:
+		return h.presentationSent(metadata)
+	}
+
+	return nil
+}
+
+// requestSent keeps the verifier's proof request until the presentation is received.
+func (h *proofHandler) requestSent(metadata presentproof.Metadata) error {
+	var request *presentproof.RequestPresentation
+
+	if metadata.Message().Type() == presentproof.RequestPresentationMsgType {
+		// the request is initiated by the verifier
+		request = &presentproof.RequestPresentation{}
+
+		if err := metadata.Message().Decode(request); err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
+	} else {
+		request = metadata.RequestPresentation()
+	}
+
+	if request == nil {
+		return nil
+	}
+
+	data, err := attachmentData(proofFormats(request.Formats), request.RequestPresentationsAttach, ProofReqFormat)
+	if err != nil || data == nil {
+		return err
+	}
+
+	thID, err := threadID(metadata.Message())
+	if err != nil {
+		return err
+	}
+
+	return h.store.Put(proofRequestKeyPrefix+thID, data)
+}
+
+// presentationReceived verifies the prover's proof against the proof request.
+func (h *proofHandler) presentationReceived(metadata presentproof.Metadata) error {
+	presentation := presentproof.Presentation{}
+	if err := metadata.Message().Decode(&presentation); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	proof, err := attachmentData(proofFormats(presentation.Formats), presentation.PresentationsAttach, ProofFormat)
+	if err != nil || proof == nil {
+		return err
+	}
+
+	thID, err := threadID(metadata.Message())
+	if err != nil {
+		return err
+	}
+
+	request, err := h.store.Get(proofRequestKeyPrefix + thID)
+	if err != nil {
+		return fmt.Errorf("get proof request: %w", err)
+	}
+
+	if err = h.backend.VerifyProof(request, proof); err != nil {
+		return fmt.Errorf("verify proof: %w", err)
+	}
+
+	return h.store.Delete(proofRequestKeyPrefix + thID)
+}
+
+// presentationSent adds the proof for the verifier's proof request to the presentation.
+func (h *proofHandler) presentationSent(metadata presentproof.Metadata) error {
+	presentation := metadata.Presentation()
+	if presentation == nil {
+		return nil
+	}
+
+	request := presentproof.RequestPresentation{}
+	if err := metadata.Message().Decode(&request); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	data, err := attachmentData(proofFormats(request.Formats), request.RequestPresentationsAttach, ProofReqFormat)
+	if err != nil || data == nil {
+		return err
+	}
+
+	for _, f := range presentation.Formats {
+		if f.Format == ProofFormat {
+			// the proof was provided by the user
+			return nil
+		}
+	}
+
+	proof, err := h.backend.CreateProof(data)
+	if err != nil {
+		return fmt.Errorf("create proof: %w", err)
+	}
+
+	attachment := newAttachment(proof)
+	presentation.Formats = append(presentation.Formats, presentproof.Format{AttachID: attachment.ID, Format: ProofFormat})
+	presentation.PresentationsAttach = append(presentation.PresentationsAttach, attachment)
+
+	return nil
+}
+
+func proofFormats(formats []presentproof.Format) []formatEntry {
+	entries := make([]formatEntry, len(formats))
+	for i, f := range formats {
+		entries[i] = formatEntry(f)
+	}
+
+	return entries
+}
@@ -0,0 +1,214 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+)
+
+var logger = log.New("aries-framework/issuecredential/middleware")
+
+// CredentialRequest describes the inbound proposal or request evaluated by the auto-issue rules.
+type CredentialRequest struct {
+	// MsgType is the type of the proposal or request message.
+	MsgType string
+	// MyDID and TheirDID identify the connection the message was received on.
+	MyDID    string
+	TheirDID string
+	// CredentialTypes contains the types of the proposed or requested credentials.
+	CredentialTypes []string
+	// Attributes contains the proposed credential preview attributes and the requested credential subject claims.
+	Attributes map[string]string
+	// Message is the original inbound message.
+	Message interface{}
+}
+
+// Rule is a predicate which must be satisfied for the proposal or request to be accepted automatically.
+type Rule func(req *CredentialRequest) bool
+
+// WithCredentialType accepts proposals and requests for credentials having one of the given types.
+func WithCredentialType(types ...string) Rule {
+	return func(req *CredentialRequest) bool {
+		for _, t := range req.CredentialTypes {
+			if contains(types, t) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// WithConnection accepts proposals and requests received from one of the given DIDs.
+func WithConnection(theirDIDs ...string) Rule {
+	return func(req *CredentialRequest) bool {
+		return contains(theirDIDs, req.TheirDID)
+	}
+}
+
+// WithAttribute accepts proposals and requests having the attribute and the attribute value satisfies the predicate.
+func WithAttribute(name string, predicate func(value string) bool) Rule {
+	return func(req *CredentialRequest) bool {
+		value, ok := req.Attributes[name]
+
+		return ok && predicate(value)
+	}
+}
+
+// TemplateProvider fills in the messages sent by the issuer when a proposal or request is accepted automatically.
+type TemplateProvider interface {
+	// OfferCredential returns the offer sent in response to the proposal.
+	OfferCredential(req *CredentialRequest) (*issuecredential.OfferCredential, error)
+	// IssueCredential returns the credentials issued in response to the request.
+	IssueCredential(req *CredentialRequest) (*issuecredential.IssueCredential, error)
+}
+
+// AutoIssue returns the auto acceptor for the issue credential protocol which accepts proposals and requests
+// satisfying all the given rules and fills in the response from the template provider, enabling unattended issuers.
+// Messages which do not satisfy the rules, or for which the template provider fails, trigger an action event as usual.
+func AutoIssue(templates TemplateProvider, rules ...Rule) issuecredential.AutoAcceptor {
+	return func(metadata issuecredential.Metadata) (issuecredential.Opt, bool) {
+		req, err := toCredentialRequest(metadata)
+		if err != nil {
+			logger.Warnf("auto issue: %s", err)
+
+			return nil, false
+		}
+
+		for _, rule := range rules {
+			if !rule(req) {
+				return nil, false
+			}
+		}
+
+		switch req.MsgType {
+		case issuecredential.ProposeCredentialMsgType:
+			offer, err := templates.OfferCredential(req)
+			if err != nil {
+				logger.Warnf("auto issue: offer credential: %s", err)
+
+				return nil, false
+			}
+
+			return issuecredential.WithOfferCredential(offer), true
+		case issuecredential.RequestCredentialMsgType:
+			credential, err := templates.IssueCredential(req)
+			if err != nil {
+				logger.Warnf("auto issue: issue credential: %s", err)
+
+				return nil, false
+			}
+
+			return issuecredential.WithIssueCredential(credential), true
+		}
+
+		return nil, false
+	}
+}
+
+func toCredentialRequest(metadata issuecredential.Metadata) (*CredentialRequest, error) {
+	properties := metadata.Properties()
+
+	// nolint: errcheck
+	myDID, _ := properties[myDIDKey].(string)
+	// nolint: errcheck
+	theirDID, _ := properties[theirDIDKey].(string)
+
+	req := &CredentialRequest{
+		MsgType:    metadata.Message().Type(),
+		MyDID:      myDID,
+		TheirDID:   theirDID,
+		Attributes: map[string]string{},
+	}
+
+	var attachments []decorator.Attachment
+
+	switch req.MsgType {
+	case issuecredential.ProposeCredentialMsgType:
+		proposal := issuecredential.ProposeCredential{}
+
+		if err := metadata.Message().Decode(&proposal); err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		for _, attr := range proposal.CredentialProposal.Attributes {
+			req.Attributes[attr.Name] = attr.Value
+		}
+
+		req.Message, attachments = &proposal, proposal.FilterAttach
+	case issuecredential.RequestCredentialMsgType:
+		request := issuecredential.RequestCredential{}
+
+		if err := metadata.Message().Decode(&request); err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		req.Message, attachments = &request, request.RequestsAttach
+	default:
+		return nil, fmt.Errorf("unsupported message type %s", req.MsgType)
+	}
+
+	for i := range attachments {
+		if err := req.addAttachment(&attachments[i]); err != nil {
+			return nil, fmt.Errorf("attachment: %w", err)
+		}
+	}
+
+	return req, nil
+}
+
+func (r *CredentialRequest) addAttachment(a *decorator.Attachment) error {
+	raw, err := a.Data.Fetch()
+	if err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+
+	var content struct {
+		Type              interface{}            `json:"type"`
+		CredentialSubject map[string]interface{} `json:"credentialSubject"`
+	}
+
+	if err = json.Unmarshal(raw, &content); err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	switch t := content.Type.(type) {
+	case string:
+		r.CredentialTypes = append(r.CredentialTypes, t)
+	case []interface{}:
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				r.CredentialTypes = append(r.CredentialTypes, s)
+			}
+		}
+	}
+
+	for name, value := range content.CredentialSubject {
+		if s, ok := value.(string); ok {
+			if _, exists := r.Attributes[name]; !exists {
+				r.Attributes[name] = s
+			}
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
+)
+
+type templateProvider struct {
+	offerCredential func(req *CredentialRequest) (*issuecredential.OfferCredential, error)
+	issueCredential func(req *CredentialRequest) (*issuecredential.IssueCredential, error)
+}
+
+func (p *templateProvider) OfferCredential(req *CredentialRequest) (*issuecredential.OfferCredential, error) {
+	return p.offerCredential(req)
+}
+
+func (p *templateProvider) IssueCredential(req *CredentialRequest) (*issuecredential.IssueCredential, error) {
+	return p.issueCredential(req)
+}
+
+func TestAutoIssue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	properties := map[string]interface{}{myDIDKey: "did:example:issuer", theirDIDKey: "did:example:holder"}
+
+	request := service.NewDIDCommMsgMap(issuecredential.RequestCredential{
+		Type: issuecredential.RequestCredentialMsgType,
+		RequestsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{JSON: map[string]interface{}{
+			"type":              []string{"VerifiableCredential", "UniversityDegreeCredential"},
+			"credentialSubject": map[string]interface{}{"degree": "BachelorDegree"},
+		}}}},
+	})
+
+	proposal := service.NewDIDCommMsgMap(issuecredential.ProposeCredential{
+		Type: issuecredential.ProposeCredentialMsgType,
+		CredentialProposal: issuecredential.PreviewCredential{
+			Attributes: []issuecredential.Attribute{{Name: "degree", Value: "MasterDegree"}},
+		},
+		FilterAttach: []decorator.Attachment{{Data: decorator.AttachmentData{JSON: map[string]interface{}{
+			"type": "UniversityDegreeCredential",
+		}}}},
+	})
+
+	newMetadata := func(msg service.DIDCommMsgMap) issuecredential.Metadata {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().Message().Return(msg).AnyTimes()
+		metadata.EXPECT().Properties().Return(properties).AnyTimes()
+
+		return metadata
+	}
+
+	rules := []Rule{
+		WithCredentialType("UniversityDegreeCredential"),
+		WithConnection("did:example:holder"),
+		WithAttribute("degree", func(value string) bool { return value != "" }),
+	}
+
+	t.Run("Request accepted", func(t *testing.T) {
+		templates := &templateProvider{
+			issueCredential: func(req *CredentialRequest) (*issuecredential.IssueCredential, error) {
+				require.Equal(t, "did:example:issuer", req.MyDID)
+				require.Equal(t, "did:example:holder", req.TheirDID)
+				require.Equal(t, "BachelorDegree", req.Attributes["degree"])
+				require.Equal(t, []string{"VerifiableCredential", "UniversityDegreeCredential"}, req.CredentialTypes)
+
+				return &issuecredential.IssueCredential{}, nil
+			},
+		}
+
+		opt, ok := AutoIssue(templates, rules...)(newMetadata(request))
+		require.True(t, ok)
+		require.NotNil(t, opt)
+	})
+
+	t.Run("Proposal accepted", func(t *testing.T) {
+		templates := &templateProvider{
+			offerCredential: func(req *CredentialRequest) (*issuecredential.OfferCredential, error) {
+				require.Equal(t, "MasterDegree", req.Attributes["degree"])
+
+				return &issuecredential.OfferCredential{}, nil
+			},
+		}
+
+		opt, ok := AutoIssue(templates, rules...)(newMetadata(proposal))
+		require.True(t, ok)
+		require.NotNil(t, opt)
+	})
+
+	t.Run("Rule not satisfied", func(t *testing.T) {
+		templates := &templateProvider{}
+
+		_, ok := AutoIssue(templates, WithCredentialType("DriversLicense"))(newMetadata(request))
+		require.False(t, ok)
+
+		_, ok = AutoIssue(templates, WithConnection("did:example:other"))(newMetadata(request))
+		require.False(t, ok)
+
+		_, ok = AutoIssue(templates, WithAttribute("name", func(string) bool { return true }))(newMetadata(request))
+		require.False(t, ok)
+	})
+
+	t.Run("Template error", func(t *testing.T) {
+		templates := &templateProvider{
+			offerCredential: func(*CredentialRequest) (*issuecredential.OfferCredential, error) {
+				return nil, errors.New("error")
+			},
+			issueCredential: func(*CredentialRequest) (*issuecredential.IssueCredential, error) {
+				return nil, errors.New("error")
+			},
+		}
+
+		_, ok := AutoIssue(templates, rules...)(newMetadata(request))
+		require.False(t, ok)
+
+		_, ok = AutoIssue(templates, rules...)(newMetadata(proposal))
+		require.False(t, ok)
+	})
+
+	t.Run("Unsupported message", func(t *testing.T) {
+		templates := &templateProvider{}
+
+		_, ok := AutoIssue(templates)(newMetadata(service.NewDIDCommMsgMap(issuecredential.OfferCredential{
+			Type: issuecredential.OfferCredentialMsgType,
+		})))
+		require.False(t, ok)
+	})
+
+	t.Run("Invalid attachment", func(t *testing.T) {
+		templates := &templateProvider{}
+
+		_, ok := AutoIssue(templates)(newMetadata(service.NewDIDCommMsgMap(issuecredential.RequestCredential{
+			Type:           issuecredential.RequestCredentialMsgType,
+			RequestsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{Base64: "invalid"}}},
+		})))
+		require.False(t, ok)
+	})
+}
@@ -12,8 +12,12 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/anoncreds"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
@@ -37,7 +41,12 @@ type Provider interface {
 
 // SaveCredentials the helper function for the issue credential protocol which saves credentials.
 func SaveCredentials(p Provider) issuecredential.Middleware {
-	vdr := p.VDRegistry()
+	vcOpts := []verifiable.CredentialOpt{
+		verifiable.WithPublicKeyFetcher(verifiable.NewDIDKeyResolver(p.VDRegistry()).PublicKeyFetcher()),
+		verifiable.WithJSONLimits(jsonutil.LimitsOf(p)),
+		verifiable.WithCache(cache.Of(p)),
+		verifiable.WithSignatureSuiteRegistry(suiteregistry.Of(p)),
+	}
 	store := p.VerifiableStore()
 
 	return func(next issuecredential.Handler) issuecredential.Handler {
@@ -53,7 +62,14 @@ func SaveCredentials(p Provider) issuecredential.Middleware {
 				return fmt.Errorf("decode: %w", err)
 			}
 
-			credentials, err := toVerifiableCredentials(vdr, credential.CredentialsAttach)
+			attachments := withoutAnonCreds(credential.Formats,
+				withoutRejected(metadata.RejectedCredentials(), credential.CredentialsAttach))
+			if len(attachments) == 0 && len(credential.CredentialsAttach) != 0 {
+				// AnonCreds credentials are handled by the AnonCreds middleware, the rejected ones are not saved
+				return next.Handle(metadata)
+			}
+
+			credentials, err := toVerifiableCredentials(attachments, vcOpts...)
 			if err != nil {
 				return fmt.Errorf("to verifiable credentials: %w", err)
 			}
@@ -105,7 +121,49 @@ func getName(idx int, id string, metadata issuecredential.Metadata) string {
 	return uuid.New().String()
 }
 
-func toVerifiableCredentials(v vdrapi.Registry, attachments []decorator.Attachment) ([]*verifiable.Credential, error) {
+func withoutAnonCreds(formats []issuecredential.Format, attachments []decorator.Attachment) []decorator.Attachment {
+	skip := map[string]struct{}{}
+
+	for _, f := range formats {
+		if anoncreds.IsAnonCredsFormat(f.Format) {
+			skip[f.AttachID] = struct{}{}
+		}
+	}
+
+	var result []decorator.Attachment
+
+	for _, a := range attachments {
+		if _, ok := skip[a.ID]; !ok {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+func withoutRejected(rejected []string, attachments []decorator.Attachment) []decorator.Attachment {
+	if len(rejected) == 0 {
+		return attachments
+	}
+
+	skip := make(map[string]struct{}, len(rejected))
+	for _, id := range rejected {
+		skip[id] = struct{}{}
+	}
+
+	var result []decorator.Attachment
+
+	for _, a := range attachments {
+		if _, ok := skip[a.ID]; !ok {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+func toVerifiableCredentials(attachments []decorator.Attachment,
+	vcOpts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
 	var credentials []*verifiable.Credential
 
 	for i := range attachments {
@@ -114,9 +172,7 @@ func toVerifiableCredentials(v vdrapi.Registry, attachments []decorator.Attachme
 			return nil, fmt.Errorf("fetch: %w", err)
 		}
 
-		vc, err := verifiable.ParseCredential(rawVC, verifiable.WithPublicKeyFetcher(
-			verifiable.NewDIDKeyResolver(v).PublicKeyFetcher(),
-		))
+		vc, err := verifiable.ParseCredential(rawVC, vcOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("new credential: %w", err)
 		}
@@ -74,6 +74,7 @@ func TestSaveCredentials(t *testing.T) {
 	t.Run("Credentials not provided", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().AnyTimes()
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
 			Type: issuecredential.IssueCredentialMsgType,
 		}))
@@ -85,6 +86,7 @@ func TestSaveCredentials(t *testing.T) {
 	t.Run("Marshal credentials error", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().AnyTimes()
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
 			Type: issuecredential.IssueCredentialMsgType,
 			CredentialsAttach: []decorator.Attachment{
@@ -108,6 +110,7 @@ func TestSaveCredentials(t *testing.T) {
 	t.Run("Invalid credentials", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().AnyTimes()
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
 			Type: issuecredential.IssueCredentialMsgType,
 			CredentialsAttach: []decorator.Attachment{
@@ -121,6 +124,36 @@ func TestSaveCredentials(t *testing.T) {
 		require.Contains(t, fmt.Sprintf("%v", err), "to verifiable credentials")
 	})
 
+	t.Run("Ignores AnonCreds credentials", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().AnyTimes()
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
+			Type:    issuecredential.IssueCredentialMsgType,
+			Formats: []issuecredential.Format{{AttachID: "cred", Format: "hlindy/cred@v2.0"}},
+			CredentialsAttach: []decorator.Attachment{
+				{ID: "cred", Data: decorator.AttachmentData{Base64: "e30="}},
+			},
+		}))
+
+		require.NoError(t, SaveCredentials(provider)(next).Handle(metadata))
+	})
+
+	t.Run("Skips rejected credentials", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().Return([]string{"cred-1", "cred-2"})
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
+			Type: issuecredential.IssueCredentialMsgType,
+			CredentialsAttach: []decorator.Attachment{
+				{ID: "cred-1", Data: decorator.AttachmentData{Base64: "e30="}},
+				{ID: "cred-2", Data: decorator.AttachmentData{Base64: "e30="}},
+			},
+		}))
+
+		require.NoError(t, SaveCredentials(provider)(next).Handle(metadata))
+	})
+
 	t.Run("DB error", func(t *testing.T) {
 		const (
 			vcName = "vc-name"
@@ -129,6 +162,7 @@ func TestSaveCredentials(t *testing.T) {
 
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().AnyTimes()
 		metadata.EXPECT().CredentialNames().Return([]string{vcName}).Times(2)
 		metadata.EXPECT().Properties().Return(map[string]interface{}{
 			myDIDKey:    myDIDKey,
@@ -155,6 +189,7 @@ func TestSaveCredentials(t *testing.T) {
 	t.Run("No DIDs", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().AnyTimes()
 		metadata.EXPECT().Properties().Return(map[string]interface{}{})
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
 			Type: issuecredential.IssueCredentialMsgType,
@@ -180,6 +215,7 @@ func TestSaveCredentials(t *testing.T) {
 
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().AnyTimes()
 		metadata.EXPECT().CredentialNames().Return([]string{vcName}).Times(2)
 		metadata.EXPECT().Properties().Return(props)
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
@@ -212,6 +248,7 @@ func TestSaveCredentials(t *testing.T) {
 
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().AnyTimes()
 		metadata.EXPECT().CredentialNames().Return([]string{})
 		metadata.EXPECT().Properties().Return(props)
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
@@ -281,6 +318,7 @@ func TestSaveCredentials(t *testing.T) {
 
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().RejectedCredentials().AnyTimes()
 		metadata.EXPECT().CredentialNames().Return([]string{vcName}).Times(2)
 		metadata.EXPECT().Properties().Return(props)
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
@@ -12,8 +12,12 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/anoncreds"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
@@ -35,9 +39,26 @@ type Provider interface {
 	VDRegistry() vdrapi.Registry
 }
 
+// verifyOpts are the options of the verification of the presentations and of their credentials.
+type verifyOpts struct {
+	vdr             vdrapi.Registry
+	limits          jsonutil.Limits
+	cache           *cache.Cache
+	signatureSuites *suiteregistry.Registry
+}
+
+func verifyOptsOf(p Provider) *verifyOpts {
+	return &verifyOpts{
+		vdr:             p.VDRegistry(),
+		limits:          jsonutil.LimitsOf(p),
+		cache:           cache.Of(p),
+		signatureSuites: suiteregistry.Of(p),
+	}
+}
+
 // SavePresentation the helper function for the present proof protocol which saves the presentations.
 func SavePresentation(p Provider) presentproof.Middleware {
-	vdr := p.VDRegistry()
+	opts := verifyOptsOf(p)
 	store := p.VerifiableStore()
 
 	return func(next presentproof.Handler) presentproof.Handler {
@@ -51,7 +72,13 @@ func SavePresentation(p Provider) presentproof.Middleware {
 				return fmt.Errorf("decode: %w", err)
 			}
 
-			presentations, err := toVerifiablePresentation(vdr, presentation.PresentationsAttach)
+			attachments := withoutAnonCreds(presentation.Formats, presentation.PresentationsAttach)
+			if len(attachments) == 0 && len(presentation.PresentationsAttach) != 0 {
+				// AnonCreds proofs are handled by the AnonCreds middleware
+				return next.Handle(metadata)
+			}
+
+			presentations, err := toVerifiablePresentation(opts, attachments)
 			if err != nil {
 				return fmt.Errorf("to verifiable presentation: %w", err)
 			}
@@ -103,7 +130,27 @@ func getName(idx int, id string, metadata presentproof.Metadata) string {
 	return uuid.New().String()
 }
 
-func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.Attachment) ([]*verifiable.Presentation, error) {
+func withoutAnonCreds(formats []presentproof.Format, attachments []decorator.Attachment) []decorator.Attachment {
+	skip := map[string]struct{}{}
+
+	for _, f := range formats {
+		if anoncreds.IsAnonCredsFormat(f.Format) {
+			skip[f.AttachID] = struct{}{}
+		}
+	}
+
+	var result []decorator.Attachment
+
+	for _, a := range attachments {
+		if _, ok := skip[a.ID]; !ok {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+func toVerifiablePresentation(opts *verifyOpts, data []decorator.Attachment) ([]*verifiable.Presentation, error) {
 	var presentations []*verifiable.Presentation
 
 	for i := range data {
@@ -113,8 +160,8 @@ func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.Attachment)
 		}
 
 		presentation, err := verifiable.ParsePresentation(raw, verifiable.WithPresPublicKeyFetcher(
-			verifiable.NewDIDKeyResolver(vdr).PublicKeyFetcher(),
-		))
+			verifiable.NewDIDKeyResolver(opts.vdr).PublicKeyFetcher(),
+		), verifiable.WithPresJSONLimits(opts.limits), verifiable.WithPresSignatureSuiteRegistry(opts.signatureSuites))
 		if err != nil {
 			return nil, fmt.Errorf("parse presentation: %w", err)
 		}
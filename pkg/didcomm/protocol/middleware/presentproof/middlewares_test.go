@@ -104,6 +104,20 @@ func TestSavePresentation(t *testing.T) {
 		require.Contains(t, fmt.Sprintf("%v", err), "to verifiable presentation")
 	})
 
+	t.Run("Ignores AnonCreds proofs", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.Presentation{
+			Type:    presentproof.PresentationMsgType,
+			Formats: []presentproof.Format{{AttachID: "proof", Format: "hlindy/proof@v2.0"}},
+			PresentationsAttach: []decorator.Attachment{
+				{ID: "proof", Data: decorator.AttachmentData{Base64: "e30="}},
+			},
+		}))
+
+		require.NoError(t, SavePresentation(provider)(next).Handle(metadata))
+	})
+
 	t.Run("DB error", func(t *testing.T) {
 		const (
 			vcName = "vp-name"
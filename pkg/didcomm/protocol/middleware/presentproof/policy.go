@@ -0,0 +1,199 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// PolicyDecisionPropKey is the action event property which contains the Decision made by the VerifierPolicy
+// auto acceptor for the received presentation.
+const PolicyDecisionPropKey = "policy_decision"
+
+// StatusChecker checks the status of the credential (e.g. revocation), a non nil error rejects the credential.
+type StatusChecker func(vc *verifiable.Credential) error
+
+// Policy declares the rules the verifier applies to every credential of the received presentation.
+// Zero values disable the corresponding rule.
+type Policy struct {
+	// TrustedIssuers is the list of the issuer DIDs the credentials are accepted from.
+	TrustedIssuers []string
+	// MaxCredentialAge is the maximum time passed since the issuance date of the credential.
+	MaxCredentialAge time.Duration
+	// StatusChecker is required to check the credential status, credentials without the status are rejected.
+	StatusChecker StatusChecker
+	// RequireHolderBinding requires the presentation to be signed by the holder who is the subject of
+	// the credentials.
+	RequireHolderBinding bool
+	// Clock is the clock the age of the credentials is checked with, the clock of the system if nil.
+	Clock clock.Clock
+}
+
+// Decision is the result of the policy evaluation.
+type Decision struct {
+	Accepted bool     `json:"accepted"`
+	Reasons  []string `json:"reasons,omitempty"`
+}
+
+// Evaluate evaluates the policy against the presentation and its credentials.
+func (p *Policy) Evaluate(vp *verifiable.Presentation, credentials []*verifiable.Credential) *Decision {
+	var reasons []string
+
+	if len(credentials) == 0 {
+		reasons = append(reasons, "presentation has no credentials")
+	}
+
+	if p.RequireHolderBinding && (vp.Holder == "" || len(vp.Proofs) == 0) {
+		reasons = append(reasons, "presentation is not signed by the holder")
+	}
+
+	for _, vc := range credentials {
+		for _, err := range p.checkCredential(vp, vc) {
+			reasons = append(reasons, fmt.Sprintf("credential %s: %s", vc.ID, err))
+		}
+	}
+
+	return &Decision{Accepted: len(reasons) == 0, Reasons: reasons}
+}
+
+func (p *Policy) checkCredential(vp *verifiable.Presentation, vc *verifiable.Credential) []error {
+	var errs []error
+
+	if len(p.TrustedIssuers) != 0 && !p.isTrustedIssuer(vc.Issuer.ID) {
+		errs = append(errs, fmt.Errorf("issuer %s is not trusted", vc.Issuer.ID))
+	}
+
+	if p.MaxCredentialAge > 0 {
+		if vc.Issued == nil {
+			errs = append(errs, errors.New("issuance date is absent"))
+		} else if clock.Since(clock.OrSystem(p.Clock), vc.Issued.Time) > p.MaxCredentialAge {
+			errs = append(errs, fmt.Errorf("issued more than %s ago", p.MaxCredentialAge))
+		}
+	}
+
+	if p.StatusChecker != nil {
+		if vc.Status == nil {
+			errs = append(errs, errors.New("credential status is absent"))
+		} else if err := p.StatusChecker(vc); err != nil {
+			errs = append(errs, fmt.Errorf("status check: %w", err))
+		}
+	}
+
+	if p.RequireHolderBinding && vp.Holder != "" {
+		if subjectID, err := verifiable.SubjectID(vc.Subject); err != nil || subjectID != vp.Holder {
+			errs = append(errs, fmt.Errorf("subject is not bound to the holder %s", vp.Holder))
+		}
+	}
+
+	return errs
+}
+
+func (p *Policy) isTrustedIssuer(id string) bool {
+	did := strings.Split(id, "#")[0]
+
+	for _, issuer := range p.TrustedIssuers {
+		if issuer == did {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VerifierPolicy returns the auto acceptor for the present proof protocol which evaluates the policy against
+// the received presentations. The presentation is rejected with the reasons of the decision without triggering
+// the action event, otherwise it is accepted. The decision is provided in the PolicyDecisionPropKey property.
+// Presentations that cannot be evaluated (e.g. AnonCreds proofs) are left to the user.
+func VerifierPolicy(p Provider, policy *Policy) presentproof.AutoAcceptor {
+	opts := verifyOptsOf(p)
+
+	return func(metadata presentproof.Metadata) (presentproof.Opt, bool) {
+		if metadata.Message().Type() != presentproof.PresentationMsgType {
+			return nil, false
+		}
+
+		decision, err := evaluate(opts, policy, metadata)
+		if err != nil {
+			logger.Warnf("verifier policy: %s", err)
+
+			return nil, false
+		}
+
+		metadata.Properties()[PolicyDecisionPropKey] = decision
+
+		if !decision.Accepted {
+			return presentproof.WithRejection(fmt.Errorf("presentation rejected by the verifier policy: %s",
+				strings.Join(decision.Reasons, "; "))), true
+		}
+
+		return nil, true
+	}
+}
+
+func evaluate(opts *verifyOpts, policy *Policy, metadata presentproof.Metadata) (*Decision, error) {
+	presentation := presentproof.Presentation{}
+	if err := metadata.Message().Decode(&presentation); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	attachments := withoutAnonCreds(presentation.Formats, presentation.PresentationsAttach)
+	if len(attachments) == 0 {
+		return nil, errors.New("no presentations to evaluate")
+	}
+
+	presentations, err := toVerifiablePresentation(opts, attachments)
+	if err != nil {
+		return &Decision{Reasons: []string{err.Error()}}, nil
+	}
+
+	decision := &Decision{Accepted: true}
+
+	for _, vp := range presentations {
+		credentials, err := parseCredentials(opts, vp)
+		if err != nil {
+			decision.Reasons = append(decision.Reasons, err.Error())
+
+			continue
+		}
+
+		decision.Reasons = append(decision.Reasons, policy.Evaluate(vp, credentials).Reasons...)
+	}
+
+	decision.Accepted = len(decision.Reasons) == 0
+
+	return decision, nil
+}
+
+func parseCredentials(opts *verifyOpts, vp *verifiable.Presentation) ([]*verifiable.Credential, error) {
+	raw, err := vp.MarshalledCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("marshalled credentials: %w", err)
+	}
+
+	credentials := make([]*verifiable.Credential, 0, len(raw))
+
+	for _, vcBytes := range raw {
+		vc, err := verifiable.ParseCredential(vcBytes, verifiable.WithPublicKeyFetcher(
+			verifiable.NewDIDKeyResolver(opts.vdr).PublicKeyFetcher(),
+		), verifiable.WithJSONLimits(opts.limits), verifiable.WithCache(opts.cache),
+			verifiable.WithSignatureSuiteRegistry(opts.signatureSuites))
+		if err != nil {
+			return nil, fmt.Errorf("parse credential: %w", err)
+		}
+
+		credentials = append(credentials, vc)
+	}
+
+	return credentials, nil
+}
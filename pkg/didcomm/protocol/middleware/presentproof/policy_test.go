@@ -0,0 +1,197 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+)
+
+const (
+	issuerDID = "did:example:issuer"
+	holderDID = "did:example:holder"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	newCredential := func() *verifiable.Credential {
+		return &verifiable.Credential{
+			ID:      "http://example.edu/credentials/1872",
+			Issuer:  verifiable.Issuer{ID: issuerDID + "#key-1"},
+			Issued:  util.NewTime(time.Now().Add(-time.Hour)),
+			Status:  &verifiable.TypedID{ID: "https://example.edu/status/24", Type: "CredentialStatusList2017"},
+			Subject: holderDID,
+		}
+	}
+
+	vp := &verifiable.Presentation{Holder: holderDID, Proofs: []verifiable.Proof{{"type": "Ed25519Signature2018"}}}
+
+	policy := &Policy{
+		TrustedIssuers:       []string{issuerDID},
+		MaxCredentialAge:     24 * time.Hour,
+		StatusChecker:        func(*verifiable.Credential) error { return nil },
+		RequireHolderBinding: true,
+	}
+
+	t.Run("Accepted", func(t *testing.T) {
+		decision := policy.Evaluate(vp, []*verifiable.Credential{newCredential()})
+		require.True(t, decision.Accepted)
+		require.Empty(t, decision.Reasons)
+	})
+
+	t.Run("Empty policy", func(t *testing.T) {
+		decision := (&Policy{}).Evaluate(&verifiable.Presentation{}, []*verifiable.Credential{{}})
+		require.True(t, decision.Accepted)
+	})
+
+	t.Run("No credentials", func(t *testing.T) {
+		decision := policy.Evaluate(vp, nil)
+		require.False(t, decision.Accepted)
+		require.Equal(t, []string{"presentation has no credentials"}, decision.Reasons)
+	})
+
+	t.Run("Untrusted issuer", func(t *testing.T) {
+		vc := newCredential()
+		vc.Issuer.ID = "did:example:unknown"
+
+		decision := policy.Evaluate(vp, []*verifiable.Credential{vc})
+		require.False(t, decision.Accepted)
+		require.Equal(t, []string{
+			"credential http://example.edu/credentials/1872: issuer did:example:unknown is not trusted",
+		}, decision.Reasons)
+	})
+
+	t.Run("Credential is too old", func(t *testing.T) {
+		vc := newCredential()
+		vc.Issued = util.NewTime(time.Now().Add(-48 * time.Hour))
+
+		decision := policy.Evaluate(vp, []*verifiable.Credential{vc})
+		require.False(t, decision.Accepted)
+		require.Equal(t, []string{"credential http://example.edu/credentials/1872: issued more than 24h0m0s ago"},
+			decision.Reasons)
+
+		// the age is checked with the clock of the policy
+		clockPolicy := *policy
+		clockPolicy.Clock = mockclock.New(time.Now().Add(-36 * time.Hour))
+
+		decision = clockPolicy.Evaluate(vp, []*verifiable.Credential{vc})
+		require.True(t, decision.Accepted)
+	})
+
+	t.Run("No issuance date", func(t *testing.T) {
+		vc := newCredential()
+		vc.Issued = nil
+
+		decision := policy.Evaluate(vp, []*verifiable.Credential{vc})
+		require.False(t, decision.Accepted)
+		require.Equal(t, []string{"credential http://example.edu/credentials/1872: issuance date is absent"},
+			decision.Reasons)
+	})
+
+	t.Run("Status check", func(t *testing.T) {
+		vc := newCredential()
+
+		decision := (&Policy{StatusChecker: func(*verifiable.Credential) error {
+			return errors.New("revoked")
+		}}).Evaluate(vp, []*verifiable.Credential{vc})
+		require.False(t, decision.Accepted)
+		require.Equal(t, []string{"credential http://example.edu/credentials/1872: status check: revoked"},
+			decision.Reasons)
+
+		vc.Status = nil
+
+		decision = policy.Evaluate(vp, []*verifiable.Credential{vc})
+		require.False(t, decision.Accepted)
+		require.Equal(t, []string{"credential http://example.edu/credentials/1872: credential status is absent"},
+			decision.Reasons)
+	})
+
+	t.Run("Holder binding", func(t *testing.T) {
+		vc := newCredential()
+		vc.Subject = "did:example:other"
+
+		decision := policy.Evaluate(vp, []*verifiable.Credential{vc})
+		require.False(t, decision.Accepted)
+		require.Equal(t, []string{
+			"credential http://example.edu/credentials/1872: subject is not bound to the holder did:example:holder",
+		}, decision.Reasons)
+
+		decision = policy.Evaluate(&verifiable.Presentation{Holder: holderDID}, []*verifiable.Credential{newCredential()})
+		require.False(t, decision.Accepted)
+		require.Equal(t, []string{"presentation is not signed by the holder"}, decision.Reasons)
+	})
+}
+
+func TestVerifierPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().VDRegistry().Return(nil).AnyTimes()
+
+	autoAcceptor := VerifierPolicy(provider, &Policy{TrustedIssuers: []string{issuerDID}})
+
+	newMetadata := func(msg interface{}, properties map[string]interface{}) presentproof.Metadata {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(msg)).AnyTimes()
+		metadata.EXPECT().Properties().Return(properties).AnyTimes()
+
+		return metadata
+	}
+
+	t.Run("Ignores other messages", func(t *testing.T) {
+		_, ok := autoAcceptor(newMetadata(presentproof.RequestPresentation{
+			Type: presentproof.RequestPresentationMsgType,
+		}, nil))
+		require.False(t, ok)
+	})
+
+	t.Run("Ignores AnonCreds proofs", func(t *testing.T) {
+		properties := map[string]interface{}{}
+
+		_, ok := autoAcceptor(newMetadata(presentproof.Presentation{
+			Type:    presentproof.PresentationMsgType,
+			Formats: []presentproof.Format{{AttachID: "proof", Format: "hlindy/proof@v2.0"}},
+			PresentationsAttach: []decorator.Attachment{
+				{ID: "proof", Data: decorator.AttachmentData{Base64: "e30="}},
+			},
+		}, properties))
+		require.False(t, ok)
+		require.Empty(t, properties)
+	})
+
+	t.Run("Rejects invalid presentation", func(t *testing.T) {
+		properties := map[string]interface{}{}
+
+		opt, ok := autoAcceptor(newMetadata(presentproof.Presentation{
+			Type: presentproof.PresentationMsgType,
+			PresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte("invalid"))}},
+			},
+		}, properties))
+		require.True(t, ok)
+		require.NotNil(t, opt)
+
+		decision, ok := properties[PolicyDecisionPropKey].(*Decision)
+		require.True(t, ok)
+		require.False(t, decision.Accepted)
+		require.Len(t, decision.Reasons, 1)
+		require.Contains(t, decision.Reasons[0], "parse presentation")
+	})
+}
@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+// PresentationPropKey is the action event property which contains the presentation message built by
+// the PresentationExchange auto acceptor. The message can be passed as is to presentproof.WithPresentation
+// or replaced by the user.
+const PresentationPropKey = "presentation"
+
+var logger = log.New("aries-framework/presentproof/middleware")
+
+// PresentationExchangeOpt configures the PresentationExchange auto acceptor.
+type PresentationExchangeOpt func(opts *presentationExchangeOpts)
+
+type presentationExchangeOpts struct {
+	autoPresent bool
+}
+
+// WithAutoPresent sends the built presentation without triggering the action event.
+func WithAutoPresent() PresentationExchangeOpt {
+	return func(opts *presentationExchangeOpts) {
+		opts.autoPresent = true
+	}
+}
+
+// PresentationExchange returns the auto acceptor for the present proof protocol which, for requests carrying
+// a presentation definition, selects the matching credentials from the verifiable store and builds the
+// presentation submission. By default, the presentation is only delivered in the PresentationPropKey property
+// of the action event so that the user can review or override it.
+func PresentationExchange(p Provider, opts ...PresentationExchangeOpt) presentproof.AutoAcceptor {
+	store := p.VerifiableStore()
+
+	options := &presentationExchangeOpts{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return func(metadata presentproof.Metadata) (presentproof.Opt, bool) {
+		if metadata.Message().Type() != presentproof.RequestPresentationMsgType {
+			return nil, false
+		}
+
+		msg, err := buildPresentation(store, metadata)
+		if err != nil {
+			// the request is left to the user when it has no definition or the wallet cannot satisfy it
+			if !errors.Is(err, presentproof.ErrNoPresentationDefinition) && !errors.Is(err, presexch.ErrNoCredentials) {
+				logger.Warnf("presentation exchange: %s", err)
+			}
+
+			return nil, false
+		}
+
+		metadata.Properties()[PresentationPropKey] = msg
+
+		if !options.autoPresent {
+			return nil, false
+		}
+
+		return presentproof.WithPresentation(msg), true
+	}
+}
+
+func buildPresentation(store storeverifiable.Store, metadata presentproof.Metadata) (*presentproof.Presentation, error) {
+	request := presentproof.RequestPresentation{}
+	if err := metadata.Message().Decode(&request); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	definition, err := presentproof.GetPresentationDefinition(&request)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := getCredentials(store)
+	if err != nil {
+		return nil, fmt.Errorf("get credentials: %w", err)
+	}
+
+	vp, err := definition.CreateVP(credentials...)
+	if err != nil {
+		return nil, fmt.Errorf("create vp: %w", err)
+	}
+
+	msg := &presentproof.Presentation{}
+	presentproof.AddPresentationSubmission(msg, vp)
+
+	return msg, nil
+}
+
+func getCredentials(store storeverifiable.Store) ([]*verifiable.Credential, error) {
+	records, err := store.GetCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	var credentials []*verifiable.Credential
+
+	for _, record := range records {
+		vc, err := store.GetCredential(record.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get credential %s: %w", record.ID, err)
+		}
+
+		credentials = append(credentials, vc)
+	}
+
+	return credentials, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
+	mocksstore "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const schemaURI = "https://www.w3.org/2018/credentials/examples/v1"
+
+func TestPresentationExchange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	definition := &presexch.PresentationDefinition{
+		ID: "definition",
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID:     "degree",
+			Schema: []presexch.Schema{{URI: schemaURI}},
+		}},
+	}
+
+	request := &presentproof.RequestPresentation{Type: presentproof.RequestPresentationMsgType}
+	presentproof.AddPresentationDefinition(request, definition)
+
+	vc := &verifiable.Credential{
+		ID:      "http://example.edu/credentials/1872",
+		Context: []string{"https://www.w3.org/2018/credentials/v1", schemaURI},
+		Types:   []string{"VerifiableCredential"},
+	}
+
+	newMetadata := func(msg interface{}, properties map[string]interface{}) presentproof.Metadata {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(msg)).AnyTimes()
+		metadata.EXPECT().Properties().Return(properties).AnyTimes()
+
+		return metadata
+	}
+
+	newProvider := func(store storeverifiable.Store) Provider {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().VerifiableStore().Return(store)
+
+		return provider
+	}
+
+	newStore := func() *mocksstore.MockStore {
+		store := mocksstore.NewMockStore(ctrl)
+		store.EXPECT().GetCredentials().Return([]*storeverifiable.Record{{ID: vc.ID}}, nil)
+		store.EXPECT().GetCredential(vc.ID).Return(vc, nil)
+
+		return store
+	}
+
+	t.Run("Presentation delivered with the action event", func(t *testing.T) {
+		properties := map[string]interface{}{}
+
+		_, ok := PresentationExchange(newProvider(newStore()))(newMetadata(request, properties))
+		require.False(t, ok)
+
+		msg, ok := properties[PresentationPropKey].(*presentproof.Presentation)
+		require.True(t, ok)
+		require.Len(t, msg.PresentationsAttach, 1)
+		require.Equal(t, presentproof.PresentationSubmissionFormat, msg.Formats[0].Format)
+
+		vp, ok := msg.PresentationsAttach[0].Data.JSON.(*verifiable.Presentation)
+		require.True(t, ok)
+		require.Len(t, vp.Credentials(), 1)
+	})
+
+	t.Run("Presentation sent automatically", func(t *testing.T) {
+		properties := map[string]interface{}{}
+
+		opt, ok := PresentationExchange(newProvider(newStore()), WithAutoPresent())(newMetadata(request, properties))
+		require.True(t, ok)
+		require.NotNil(t, opt)
+		require.NotNil(t, properties[PresentationPropKey])
+	})
+
+	t.Run("Ignores other messages", func(t *testing.T) {
+		_, ok := PresentationExchange(newProvider(nil))(newMetadata(&presentproof.ProposePresentation{
+			Type: presentproof.ProposePresentationMsgType,
+		}, nil))
+		require.False(t, ok)
+	})
+
+	t.Run("Ignores requests without definition", func(t *testing.T) {
+		properties := map[string]interface{}{}
+
+		_, ok := PresentationExchange(newProvider(nil))(newMetadata(&presentproof.RequestPresentation{
+			Type: presentproof.RequestPresentationMsgType,
+		}, properties))
+		require.False(t, ok)
+		require.Empty(t, properties)
+	})
+
+	t.Run("No matching credentials", func(t *testing.T) {
+		store := mocksstore.NewMockStore(ctrl)
+		store.EXPECT().GetCredentials().Return(nil, nil)
+
+		properties := map[string]interface{}{}
+
+		_, ok := PresentationExchange(newProvider(store), WithAutoPresent())(newMetadata(request, properties))
+		require.False(t, ok)
+		require.Empty(t, properties)
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		store := mocksstore.NewMockStore(ctrl)
+		store.EXPECT().GetCredentials().Return([]*storeverifiable.Record{{ID: vc.ID}}, nil)
+		store.EXPECT().GetCredential(vc.ID).Return(nil, errors.New("error"))
+
+		properties := map[string]interface{}{}
+
+		_, ok := PresentationExchange(newProvider(store), WithAutoPresent())(newMetadata(request, properties))
+		require.False(t, ok)
+		require.Empty(t, properties)
+	})
+}
@@ -17,15 +17,90 @@ type Request struct {
 	GoalCode string                  `json:"goal-code,omitempty"`
 	Requests []*decorator.Attachment `json:"request~attach"`
 	Service  []interface{}           `json:"service"` // Service is an array of either DIDs or 'service' block entries.
+	Accept   []string                `json:"accept,omitempty"`
 }
 
 // Invitation is this protocol's `invitation` message.
+// Requests (e.g a request-presentation) may be attached to be processed by the invitee once connected.
 type Invitation struct {
-	ID        string        `json:"@id"`
-	Type      string        `json:"@type"`
-	Label     string        `json:"label,omitempty"`
-	Goal      string        `json:"goal,omitempty"`
-	GoalCode  string        `json:"goal-code,omitempty"`
-	Service   []interface{} `json:"service"` // Service is an array of either DIDs or 'service' block entries.
-	Protocols []string      `json:"protocols"`
+	ID        string                  `json:"@id"`
+	Type      string                  `json:"@type"`
+	Label     string                  `json:"label,omitempty"`
+	Goal      string                  `json:"goal,omitempty"`
+	GoalCode  string                  `json:"goal-code,omitempty"`
+	Service   []interface{}           `json:"service"` // Service is an array of either DIDs or 'service' block entries.
+	Protocols []string                `json:"protocols"`
+	Requests  []*decorator.Attachment `json:"request~attach,omitempty"`
+	Accept    []string                `json:"accept,omitempty"`
+}
+
+// HandshakeReuse is sent by the invitee, over an existing connection with the inviter, to reuse that connection
+// instead of creating a new one for the invitation identified by the parent thread ID.
+type HandshakeReuse struct {
+	ID     string            `json:"@id"`
+	Type   string            `json:"@type"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// HandshakeReuseAccepted is the inviter's response to the handshake-reuse message.
+type HandshakeReuseAccepted struct {
+	ID     string            `json:"@id"`
+	Type   string            `json:"@type"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// InvitationV2 is the Out-Of-Band 2.0 `invitation` message.
+// Unlike v1, a single message carries both the goal and the attached requests.
+type InvitationV2 struct {
+	ID          string                  `json:"id"`
+	Type        string                  `json:"type"`
+	Label       string                  `json:"label,omitempty"`
+	From        string                  `json:"from"`
+	Body        *InvitationV2Body       `json:"body"`
+	Attachments []*decorator.Attachment `json:"attachments,omitempty"`
+}
+
+// InvitationV2Body is the body of the Out-Of-Band 2.0 `invitation` message.
+type InvitationV2Body struct {
+	Goal     string   `json:"goal,omitempty"`
+	GoalCode string   `json:"goal_code,omitempty"`
+	Accept   []string `json:"accept,omitempty"`
+}
+
+// ToV1 converts the invitation into the equivalent v1 message for invitees that only support v1.
+// An invitation with attachments becomes a v1 Request, otherwise a v1 Invitation offering the given protocols.
+// Both keep the invitation's ID so that the inviter can correlate the subsequent did-exchange.
+func (i *InvitationV2) ToV1(protocols []string) (*Invitation, *Request) {
+	var (
+		goal, goalCode string
+		accept         []string
+	)
+
+	if i.Body != nil {
+		goal, goalCode, accept = i.Body.Goal, i.Body.GoalCode, i.Body.Accept
+	}
+
+	if len(i.Attachments) != 0 {
+		return nil, &Request{
+			ID:       i.ID,
+			Type:     RequestMsgType,
+			Label:    i.Label,
+			Goal:     goal,
+			GoalCode: goalCode,
+			Requests: i.Attachments,
+			Service:  []interface{}{i.From},
+			Accept:   accept,
+		}
+	}
+
+	return &Invitation{
+		ID:        i.ID,
+		Type:      InvitationMsgType,
+		Label:     i.Label,
+		Goal:      goal,
+		GoalCode:  goalCode,
+		Service:   []interface{}{i.From},
+		Protocols: protocols,
+		Accept:    accept,
+	}, nil
 }
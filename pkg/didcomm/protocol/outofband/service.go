@@ -10,15 +10,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
@@ -31,11 +35,19 @@ const (
 	RequestMsgType = "https://didcomm.org/oob-request/1.0/request"
 	// InvitationMsgType is the '@type' for the invitation message.
 	InvitationMsgType = "https://didcomm.org/oob-invitation/1.0/invitation"
+	// InvitationV2MsgType is the 'type' for the Out-Of-Band 2.0 invitation message.
+	InvitationV2MsgType = "https://didcomm.org/out-of-band/2.0/invitation"
+	// HandshakeReuseMsgType is the '@type' for the handshake-reuse message.
+	HandshakeReuseMsgType = "https://didcomm.org/out-of-band/1.1/handshake-reuse"
+	// HandshakeReuseAcceptedMsgType is the '@type' for the handshake-reuse-accepted message.
+	HandshakeReuseAcceptedMsgType = "https://didcomm.org/out-of-band/1.1/handshake-reuse-accepted"
 
 	// StateRequested is one of the possible states of this protocol.
 	StateRequested = "requested"
 	// StateInvited is this protocol's state after accepting an invitation.
 	StateInvited = "invited"
+	// StateConnectionReused is this protocol's state once an existing connection is reused for an invitation.
+	StateConnectionReused = "connection-reused"
 
 	// TODO channel size - https://github.com/hyperledger/aries-framework-go/issues/246
 	callbackChannelSize = 10
@@ -68,7 +80,9 @@ type Service struct {
 	didEvents                  chan service.StateMsg
 	store                      storage.Store
 	connections                *connection.Recorder
-	outboundHandler            service.OutboundHandler
+	inboundHandler             transport.InboundMessageHandler
+	outbound                   dispatcher.Outbound
+	vdRegistry                 vdrapi.Registry
 	chooseRequestFunc          func(*myState) (*decorator.Attachment, bool)
 	extractDIDCommMsgBytesFunc func(*decorator.Attachment) ([]byte, error)
 	listenerFunc               func()
@@ -85,8 +99,11 @@ type myState struct {
 	// ID becomes the parent thread ID of didexchange
 	ID           string
 	ConnectionID string
+	// Reused is true if an existing connection was reused for the invitation.
+	Reused       bool
 	Request      *Request
 	Invitation   *Invitation
+	InvitationV2 *InvitationV2
 	Done         bool
 }
 
@@ -110,7 +127,9 @@ type Provider interface {
 	Service(id string) (interface{}, error)
 	StorageProvider() storage.Provider
 	ProtocolStateStorageProvider() storage.Provider
-	OutboundMessageHandler() service.OutboundHandler
+	InboundMessageHandler() transport.InboundMessageHandler
+	OutboundDispatcher() dispatcher.Outbound
+	VDRegistry() vdrapi.Registry
 }
 
 // New creates a new instance of the out-of-band service.
@@ -141,7 +160,9 @@ func New(p Provider) (*Service, error) {
 		didEvents:                  make(chan service.StateMsg, callbackChannelSize),
 		store:                      store,
 		connections:                connectionRecorder,
-		outboundHandler:            p.OutboundMessageHandler(),
+		inboundHandler:             p.InboundMessageHandler(),
+		outbound:                   p.OutboundDispatcher(),
+		vdRegistry:                 p.VDRegistry(),
 		chooseRequestFunc:          chooseRequest,
 		extractDIDCommMsgBytesFunc: extractDIDCommMsgBytes,
 	}
@@ -167,9 +188,25 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{
+		strings.TrimSuffix(RequestMsgType, "/request"),
+		strings.TrimSuffix(InvitationMsgType, "/invitation"),
+		strings.TrimSuffix(InvitationV2MsgType, "/invitation"),
+		strings.TrimSuffix(HandshakeReuseMsgType, "/handshake-reuse"),
+	}
+}
+
 // Accept determines whether this service can handle the given type of message.
 func (s *Service) Accept(msgType string) bool {
-	return msgType == RequestMsgType || msgType == InvitationMsgType
+	switch msgType {
+	case RequestMsgType, InvitationMsgType, InvitationV2MsgType,
+		HandshakeReuseMsgType, HandshakeReuseAcceptedMsgType:
+		return true
+	}
+
+	return false
 }
 
 // HandleInbound handles inbound messages.
@@ -180,6 +217,13 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string)
 		return "", fmt.Errorf("unsupported message type %s", msg.Type())
 	}
 
+	switch msg.Type() {
+	case HandshakeReuseMsgType:
+		return "", s.handleHandshakeReuse(msg, myDID, theirDID)
+	case HandshakeReuseAcceptedMsgType:
+		return "", s.handleHandshakeReuseAccepted(msg)
+	}
+
 	events := s.ActionEvent()
 	if events == nil {
 		return "", fmt.Errorf("no clients registered to handle action events for %s protocol", Name)
@@ -330,9 +374,12 @@ func sendMsgEvent(t service.StateMsgType, listeners *service.Message,
 	msg service.DIDCommMsg, p service.EventProperties) {
 	var stateName string
 
-	if msg.Type() == RequestMsgType {
+	switch msg.Type() {
+	case RequestMsgType:
 		stateName = StateRequested
-	} else {
+	case HandshakeReuseMsgType, HandshakeReuseAcceptedMsgType:
+		stateName = StateConnectionReused
+	default:
 		stateName = StateInvited
 	}
 
@@ -383,6 +430,51 @@ func (s *Service) AcceptInvitation(i *Invitation, myLabel string, routerConnecti
 	return connID, nil
 }
 
+// AcceptInvitationV2 from another agent and return the connection ID.
+func (s *Service) AcceptInvitationV2(i *InvitationV2, myLabel string, routerConnections []string) (string, error) {
+	connID, err := s.handleCallback(&callback{
+		msg:     service.NewDIDCommMsgMap(i),
+		options: &userOptions{myLabel: myLabel, routerConnections: routerConnections},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to accept invitation v2 : %w", err)
+	}
+
+	return connID, nil
+}
+
+// AcceptImplicitInvitation connects to the agent of the given public DID and return the connection ID.
+// As permitted by the out-of-band spec, no invitation message is needed: the implicit invitation is synthesized
+// from the DIDComm service of the resolved DID document, and identified by the DID itself.
+func (s *Service) AcceptImplicitInvitation(publicDID, myLabel string, routerConnections []string) (string, error) {
+	doc, err := s.vdRegistry.Resolve(publicDID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve public DID %s : %w", publicDID, err)
+	}
+
+	// checks the DID exposes a usable DIDComm service endpoint
+	_, err = service.CreateDestination(doc)
+	if err != nil {
+		return "", fmt.Errorf("public DID %s cannot be used as an implicit invitation : %w", publicDID, err)
+	}
+
+	connID, err := s.handleCallback(&callback{
+		msg: service.NewDIDCommMsgMap(&Invitation{
+			// the pthid of the didexchange thread is the public DID for implicit invitations
+			ID:        publicDID,
+			Type:      InvitationMsgType,
+			Service:   []interface{}{publicDID},
+			Protocols: []string{didexchange.PIURI},
+		}),
+		options: &userOptions{myLabel: myLabel, routerConnections: routerConnections},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to accept implicit invitation : %w", err)
+	}
+
+	return connID, nil
+}
+
 // SaveRequest created by the outofband client.
 func (s *Service) SaveRequest(r *Request) error {
 	// TODO where should we save this request? - https://github.com/hyperledger/aries-framework-go/issues/1547
@@ -401,6 +493,7 @@ func (s *Service) SaveRequest(r *Request) error {
 		ThreadID:   r.ID,
 		TheirLabel: r.Label,
 		Target:     target,
+		GoalCode:   r.GoalCode,
 	})
 	if err != nil {
 		return fmt.Errorf("the didexchange service failed to save the oob invitation : %w", err)
@@ -427,6 +520,7 @@ func (s *Service) SaveInvitation(i *Invitation) error {
 		ThreadID:   i.ID,
 		TheirLabel: i.Label,
 		Target:     target,
+		GoalCode:   i.GoalCode,
 	})
 	if err != nil {
 		return fmt.Errorf("the didexchange service failed to save the oob invitation : %w", err)
@@ -435,6 +529,34 @@ func (s *Service) SaveInvitation(i *Invitation) error {
 	return nil
 }
 
+// SaveInvitationV2 created by the outofband client.
+// The did-exchange invitation is saved under the invitation's ID, so invitees may also respond
+// to the equivalent v1 message (see InvitationV2.ToV1).
+func (s *Service) SaveInvitationV2(i *InvitationV2) error {
+	if i.From == "" {
+		return errors.New("invitation v2 must have a 'from' DID")
+	}
+
+	// TODO where should we save this invitation? - https://github.com/hyperledger/aries-framework-go/issues/1547
+	err := s.connections.SaveInvitation(i.ID+"-TODO", i)
+	if err != nil {
+		return fmt.Errorf("failed to save oob invitation v2 : %w", err)
+	}
+
+	err = s.didSvc.SaveInvitation(&didexchange.OOBInvitation{
+		ID:         uuid.New().String(),
+		ThreadID:   i.ID,
+		TheirLabel: i.Label,
+		Target:     i.From,
+		GoalCode:   goalCodeV2(i),
+	})
+	if err != nil {
+		return fmt.Errorf("the didexchange service failed to save the oob invitation v2 : %w", err)
+	}
+
+	return nil
+}
+
 func listener(
 	callbacks chan *callback,
 	didEvents chan service.StateMsg,
@@ -446,7 +568,7 @@ func listener(
 			select {
 			case c := <-callbacks:
 				switch c.msg.Type() {
-				case RequestMsgType, InvitationMsgType:
+				case RequestMsgType, InvitationMsgType, InvitationV2MsgType:
 					connID, err := handleCallbackFunc(c)
 					if err != nil {
 						logutil.LogError(logger, Name, "handleCallback", err.Error(),
@@ -480,6 +602,8 @@ func (s *Service) handleCallback(c *callback) (string, error) {
 		return s.handleRequestCallback(c)
 	case InvitationMsgType:
 		return s.handleInvitationCallback(c)
+	case InvitationV2MsgType:
+		return s.handleInvitationV2Callback(c)
 	default:
 		return "", fmt.Errorf("unsupported message type: %s", c.msg.Type())
 	}
@@ -501,6 +625,10 @@ func (s *Service) handleRequestCallback(c *callback) (string, error) {
 		Request: req,
 	}
 
+	if connID, reused, err := s.reuseConnection(invitation.Target, state); err != nil || reused {
+		return connID, err
+	}
+
 	err = s.save(state)
 
 	if err != nil {
@@ -533,20 +661,69 @@ func (s *Service) handleInvitationCallback(c *callback) (string, error) {
 		return "", fmt.Errorf("handleInvitationCallback: failed to decode callback message : %w", err)
 	}
 
+	state := &myState{
+		// the pthid of the didexchange thread will equal this invitation's ID as per the RFC
+		ID:         didInv.ThreadID,
+		Invitation: oobInv,
+	}
+
+	if connID, reused, err := s.reuseConnection(didInv.Target, state); err != nil || reused {
+		return connID, err
+	}
+
+	err = s.save(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to save my state : %w", err)
+	}
+
 	connID, err := s.didSvc.RespondTo(didInv, c.options.RouterConnections())
 	if err != nil {
 		return "", fmt.Errorf("didexchange service failed to handle inbound invitation : %w", err)
 	}
 
+	state.ConnectionID = connID
+
+	err = s.save(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to persist state update with connectionID : %w", err)
+	}
+
+	return connID, nil
+}
+
+func (s *Service) handleInvitationV2Callback(c *callback) (string, error) {
+	logger.Debugf("input: %+v", c)
+
+	didInv, inv, err := decodeDIDInvitationAndInvitationV2(c)
+	if err != nil {
+		return "", fmt.Errorf("handleInvitationV2Callback: failed to decode callback message : %w", err)
+	}
+
 	state := &myState{
-		ID:           didInv.ID,
-		ConnectionID: connID,
-		Invitation:   oobInv,
+		// the pthid of the didexchange thread will equal this invitation's ID as per the RFC
+		ID:           didInv.ThreadID,
+		InvitationV2: inv,
+	}
+
+	if connID, reused, err := s.reuseConnection(didInv.Target, state); err != nil || reused {
+		return connID, err
 	}
 
 	err = s.save(state)
 	if err != nil {
-		return "", fmt.Errorf("failed to save my state : %w", err)
+		return "", fmt.Errorf("failed to save new state : %w", err)
+	}
+
+	connID, err := s.didSvc.RespondTo(didInv, c.options.RouterConnections())
+	if err != nil {
+		return "", fmt.Errorf("didexchange service failed to handle inbound invitation v2 : %w", err)
+	}
+
+	state.ConnectionID = connID
+
+	err = s.save(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to persist state update with connectionID : %w", err)
 	}
 
 	return connID, nil
@@ -580,9 +757,14 @@ func (s *Service) handleDIDEvent(e service.StateMsg) error {
 		return fmt.Errorf("service.handleDIDEvent: failed to load state : %w", err)
 	}
 
-	msg, err := s.extractDIDCommMsg(state)
-	if err != nil {
-		return fmt.Errorf("service.handleDIDEvent: failed to extract DIDComm msg : %w", err)
+	var msg []byte
+
+	// invitations may not carry any request, in which case the out-of-band protocol is done once connected
+	if req, found := s.chooseRequestFunc(state); found {
+		msg, err = s.extractDIDCommMsg(req)
+		if err != nil {
+			return fmt.Errorf("service.handleDIDEvent: failed to extract DIDComm msg : %w", err)
+		}
 	}
 
 	state.Done = true
@@ -594,9 +776,178 @@ func (s *Service) handleDIDEvent(e service.StateMsg) error {
 		return fmt.Errorf("service.handleDIDEvent: failed to update state : %w", err)
 	}
 
-	_, err = s.outboundHandler.HandleOutbound(msg, record.MyDID, record.TheirDID)
+	if msg == nil {
+		return nil
+	}
+
+	err = s.dispatchRequest(msg, record)
 	if err != nil {
-		return fmt.Errorf("service.handleDIDEvent: failed to dispatch message : %w", err)
+		return fmt.Errorf("service.handleDIDEvent: %w", err)
+	}
+
+	return nil
+}
+
+// reuseConnection sends a handshake-reuse message over the completed connection with the party identified by
+// the target, if any, instead of starting a new did-exchange. The target must be a DID: connections cannot be
+// matched against inline service blocks. The state is saved with the reused connection ID, so that the
+// attached requests are dispatched once the inviter accepts the reuse.
+func (s *Service) reuseConnection(target interface{}, state *myState) (string, bool, error) {
+	theirDID, ok := target.(string)
+	if !ok {
+		return "", false, nil
+	}
+
+	record, err := s.findConnection(theirDID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to look for an existing connection : %w", err)
+	}
+
+	if record == nil {
+		return "", false, nil
+	}
+
+	state.ConnectionID = record.ConnectionID
+	state.Reused = true
+
+	err = s.save(state)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to save new state : %w", err)
+	}
+
+	reuseID := uuid.New().String()
+
+	err = s.outbound.SendToDID(service.NewDIDCommMsgMap(&HandshakeReuse{
+		ID:   reuseID,
+		Type: HandshakeReuseMsgType,
+		Thread: &decorator.Thread{
+			ID:  reuseID,
+			PID: state.ID,
+		},
+	}), record.MyDID, record.TheirDID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to send handshake-reuse : %w", err)
+	}
+
+	logger.Debugf("reusing connection %s for invitation %s", record.ConnectionID, state.ID)
+
+	return record.ConnectionID, true, nil
+}
+
+// findConnection returns the completed connection with the party identified by theirDID, or nil if there is none.
+func (s *Service) findConnection(theirDID string) (*connection.Record, error) {
+	records, err := s.connections.QueryConnectionRecords()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connection records : %w", err)
+	}
+
+	for _, record := range records {
+		if record.State != connection.StateNameCompleted {
+			continue
+		}
+
+		if record.TheirDID == theirDID || record.InvitationDID == theirDID {
+			return record, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// handleHandshakeReuse accepts the invitee's request to reuse the connection the message was received over.
+func (s *Service) handleHandshakeReuse(msg service.DIDCommMsg, myDID, theirDID string) error {
+	reuse := &HandshakeReuse{}
+
+	err := msg.Decode(reuse)
+	if err != nil {
+		return fmt.Errorf("failed to decode handshake-reuse message : %w", err)
+	}
+
+	if msg.ParentThreadID() == "" {
+		return errors.New("handshake-reuse message has no parent thread ID")
+	}
+
+	connID, err := s.connections.GetConnectionIDByDIDs(myDID, theirDID)
+	if err != nil {
+		return fmt.Errorf("failed to find the connection to reuse : %w", err)
+	}
+
+	thid, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("threadID: %w", err)
+	}
+
+	err = s.outbound.SendToDID(service.NewDIDCommMsgMap(&HandshakeReuseAccepted{
+		ID:   uuid.New().String(),
+		Type: HandshakeReuseAcceptedMsgType,
+		Thread: &decorator.Thread{
+			ID:  thid,
+			PID: msg.ParentThreadID(),
+		},
+	}), myDID, theirDID)
+	if err != nil {
+		return fmt.Errorf("failed to send handshake-reuse-accepted : %w", err)
+	}
+
+	go sendMsgEvent(service.PostState, &s.Message, msg, &eventProps{ConnID: connID})
+
+	return nil
+}
+
+// handleHandshakeReuseAccepted dispatches the requests attached to the invitation over the reused connection.
+func (s *Service) handleHandshakeReuseAccepted(msg service.DIDCommMsg) error {
+	state, err := s.fetchMyState(msg.ParentThreadID())
+	if err != nil {
+		return fmt.Errorf("failed to load state : %w", err)
+	}
+
+	if !state.Reused {
+		return fmt.Errorf("no connection reuse was requested for invitation %s", state.ID)
+	}
+
+	if req, found := s.chooseRequestFunc(state); found {
+		err = s.dispatchReused(req, state)
+		if err != nil {
+			return err
+		}
+	}
+
+	state.Done = true
+
+	err = s.save(state)
+	if err != nil {
+		return fmt.Errorf("failed to update state : %w", err)
+	}
+
+	go sendMsgEvent(service.PostState, &s.Message, msg, &eventProps{ConnID: state.ConnectionID})
+
+	return nil
+}
+
+func (s *Service) dispatchReused(req *decorator.Attachment, state *myState) error {
+	record, err := s.connections.GetConnectionRecord(state.ConnectionID)
+	if err != nil {
+		return fmt.Errorf("failed to get the reused connection record : %w", err)
+	}
+
+	msg, err := s.extractDIDCommMsg(req)
+	if err != nil {
+		return fmt.Errorf("failed to extract DIDComm msg : %w", err)
+	}
+
+	return s.dispatchRequest(msg, record)
+}
+
+// dispatchRequest routes the request attached to the invitation to the protocol service accepting its message type
+// (e.g present-proof for a request-presentation), as if the inviter had sent it over the connection.
+func (s *Service) dispatchRequest(msg []byte, record *connection.Record) error {
+	if s.inboundHandler == nil {
+		return errors.New("no inbound message handler to dispatch the attached request")
+	}
+
+	err := s.inboundHandler(msg, record.MyDID, record.TheirDID)
+	if err != nil {
+		return fmt.Errorf("failed to dispatch message : %w", err)
 	}
 
 	return nil
@@ -637,10 +988,22 @@ func (s *Service) fetchMyState(id string) (*myState, error) {
 //  - https://github.com/hyperledger/aries-rfcs/issues/451
 //  This logic should be injected into the service.
 func chooseRequest(state *myState) (*decorator.Attachment, bool) {
-	if !state.Done {
+	if state.Done {
+		return nil, false
+	}
+
+	if state.Request != nil && len(state.Request.Requests) != 0 {
 		return state.Request.Requests[0], true
 	}
 
+	if state.Invitation != nil && len(state.Invitation.Requests) != 0 {
+		return state.Invitation.Requests[0], true
+	}
+
+	if state.InvitationV2 != nil && len(state.InvitationV2.Attachments) != 0 {
+		return state.InvitationV2.Attachments[0], true
+	}
+
 	return nil, false
 }
 
@@ -653,23 +1016,19 @@ func extractDIDCommMsgBytes(a *decorator.Attachment) ([]byte, error) {
 	return bytes, nil
 }
 
-func (s *Service) extractDIDCommMsg(state *myState) (service.DIDCommMsg, error) {
-	req, found := s.chooseRequestFunc(state)
-	if !found {
-		return nil, fmt.Errorf("no requests found to extract for msgId=%s", state.Request.ID)
-	}
-
+func (s *Service) extractDIDCommMsg(req *decorator.Attachment) ([]byte, error) {
 	bytes, err := s.extractDIDCommMsgBytesFunc(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract didcomm message from attachment : %w", err)
 	}
 
-	msg, err := service.ParseDIDCommMsgMap(bytes)
+	// checks the attached request is a DIDComm message before routing it
+	_, err = service.ParseDIDCommMsgMap(bytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse followup request : %w", err)
 	}
 
-	return msg, nil
+	return bytes, nil
 }
 
 func decodeInvitationAndRequest(c *callback) (*didexchange.OOBInvitation, *Request, error) {
@@ -685,6 +1044,8 @@ func decodeInvitationAndRequest(c *callback) (*didexchange.OOBInvitation, *Reque
 		ThreadID:   req.ID,
 		TheirLabel: req.Label,
 		MyLabel:    c.options.MyLabel(),
+		GoalCode:   req.GoalCode,
+		Accept:     req.Accept,
 	}
 
 	target, err := chooseTarget(req.Service)
@@ -717,11 +1078,75 @@ func decodeDIDInvitationAndOOBInvitation(c *callback) (*didexchange.OOBInvitatio
 		TheirLabel: oobInv.Label,
 		Target:     target,
 		MyLabel:    c.options.MyLabel(),
+		GoalCode:   oobInv.GoalCode,
+		Protocol:   handshakeProtocol(oobInv.Protocols),
+		Accept:     oobInv.Accept,
 	}
 
 	return didInv, oobInv, nil
 }
 
+// handshakeProtocol selects the RFC 0160 connection protocol when the invitation offers it but not did-exchange.
+func handshakeProtocol(protocols []string) string {
+	legacy := false
+
+	for _, protocol := range protocols {
+		switch protocol {
+		case didexchange.PIURI:
+			return ""
+		case didexchange.LegacyPIURI:
+			legacy = true
+		}
+	}
+
+	if legacy {
+		return didexchange.LegacyPIURI
+	}
+
+	return ""
+}
+
+func decodeDIDInvitationAndInvitationV2(c *callback) (*didexchange.OOBInvitation, *InvitationV2, error) {
+	inv := &InvitationV2{}
+
+	err := c.msg.Decode(inv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode out-of-band invitation v2 message : %w", err)
+	}
+
+	if inv.From == "" {
+		return nil, nil, errors.New("invitation v2 does not have a 'from' DID to connect against")
+	}
+
+	didInv := &didexchange.OOBInvitation{
+		ID:         uuid.New().String(),
+		ThreadID:   inv.ID,
+		TheirLabel: inv.Label,
+		Target:     inv.From,
+		MyLabel:    c.options.MyLabel(),
+		GoalCode:   goalCodeV2(inv),
+		Accept:     acceptV2(inv),
+	}
+
+	return didInv, inv, nil
+}
+
+func goalCodeV2(i *InvitationV2) string {
+	if i.Body == nil {
+		return ""
+	}
+
+	return i.Body.GoalCode
+}
+
+func acceptV2(i *InvitationV2) []string {
+	if i.Body == nil {
+		return nil
+	}
+
+	return i.Body.Accept
+}
+
 func chooseTarget(svcs []interface{}) (interface{}, error) {
 	for i := range svcs {
 		switch svc := svcs[i].(type) {
@@ -18,13 +18,18 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
@@ -98,6 +103,17 @@ func TestName(t *testing.T) {
 	require.Equal(t, s.Name(), "out-of-band")
 }
 
+func TestProtocols(t *testing.T) {
+	s, err := New(testProvider())
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"https://didcomm.org/oob-request/1.0",
+		"https://didcomm.org/oob-invitation/1.0",
+		"https://didcomm.org/out-of-band/2.0",
+		"https://didcomm.org/out-of-band/1.1",
+	}, s.Protocols())
+}
+
 func TestAccept(t *testing.T) {
 	t.Run("accepts out-of-band request messages", func(t *testing.T) {
 		s, err := New(testProvider())
@@ -109,6 +125,17 @@ func TestAccept(t *testing.T) {
 		require.NoError(t, err)
 		require.True(t, s.Accept("https://didcomm.org/oob-invitation/1.0/invitation"))
 	})
+	t.Run("accepts out-of-band v2 invitation messages", func(t *testing.T) {
+		s, err := New(testProvider())
+		require.NoError(t, err)
+		require.True(t, s.Accept("https://didcomm.org/out-of-band/2.0/invitation"))
+	})
+	t.Run("accepts handshake-reuse messages", func(t *testing.T) {
+		s, err := New(testProvider())
+		require.NoError(t, err)
+		require.True(t, s.Accept("https://didcomm.org/out-of-band/1.1/handshake-reuse"))
+		require.True(t, s.Accept("https://didcomm.org/out-of-band/1.1/handshake-reuse-accepted"))
+	})
 	t.Run("rejects unsupported messages", func(t *testing.T) {
 		s, err := New(testProvider())
 		require.NoError(t, err)
@@ -430,18 +457,16 @@ func TestHandleRequestCallback(t *testing.T) {
 }
 
 func TestHandleDIDEvent(t *testing.T) {
-	t.Run("invokes outbound msg handler", func(t *testing.T) {
+	t.Run("invokes inbound msg handler", func(t *testing.T) {
 		invoked := make(chan struct{}, 2)
 		connID := uuid.New().String()
 		pthid := uuid.New().String()
 
 		provider := testProvider()
-		provider.OutboundMsgHandler = &outboundMsgHandlerStub{
-			handleFunc: func(service.DIDCommMsg, string, string) (string, error) {
-				invoked <- struct{}{}
-				return "", nil
-			},
-		}
+		provider.InboundMsgHandler = inboundMsgHandler(func(service.DIDCommMsg, string, string) error {
+			invoked <- struct{}{}
+			return nil
+		})
 
 		// setup connection state
 		r, err := connection.NewRecorder(provider)
@@ -539,11 +564,9 @@ func TestHandleDIDEvent(t *testing.T) {
 		connID := uuid.New().String()
 
 		provider := testProvider()
-		provider.OutboundMsgHandler = &outboundMsgHandlerStub{
-			handleFunc: func(service.DIDCommMsg, string, string) (string, error) {
-				return "", expected
-			},
-		}
+		provider.InboundMsgHandler = inboundMsgHandler(func(service.DIDCommMsg, string, string) error {
+			return expected
+		})
 
 		// setup connection state
 		r, err := connection.NewRecorder(provider)
@@ -574,6 +597,39 @@ func TestHandleDIDEvent(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, errors.Is(err, expected))
 	})
+	t.Run("fails if there is no inbound handler to dispatch the request", func(t *testing.T) {
+		pthid := uuid.New().String()
+		connID := uuid.New().String()
+
+		provider := testProvider()
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		err = r.SaveConnectionRecord(&connection.Record{
+			ConnectionID:   connID,
+			MyDID:          myDID,
+			TheirDID:       theirDID,
+			ParentThreadID: pthid,
+		})
+		require.NoError(t, err)
+
+		s := newAutoService(t, provider,
+			withState(t, &myState{
+				ID:           pthid,
+				ConnectionID: connID,
+				Request:      newRequest(),
+			}),
+		)
+		err = s.handleDIDEvent(service.StateMsg{
+			ProtocolName: didexchange.DIDExchange,
+			Type:         service.PostState,
+			Msg:          service.NewDIDCommMsgMap(newAck(pthid)),
+			StateID:      didexchange.StateIDCompleted,
+			Properties:   &mockdidexchange.MockEventProperties{ConnID: connID},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no inbound message handler")
+	})
 	t.Run("wraps error from store when saving state", func(t *testing.T) {
 		expected := errors.New("test")
 		pthid := uuid.New().String()
@@ -935,6 +991,553 @@ func TestAcceptInvitation(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, errors.Is(err, expected))
 	})
+	t.Run("dispatches the attached request to the inbound handler once connected", func(t *testing.T) {
+		const connID = "123456"
+		inv := newInvitation()
+		inv.Requests = newRequest().Requests
+		provider := testProvider()
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(i *didexchange.OOBInvitation, _ []string) (string, error) {
+					require.Equal(t, inv.ID, i.ThreadID)
+					return connID, nil
+				},
+			},
+		}
+		dispatched := make(chan service.DIDCommMsg, 1)
+		provider.InboundMsgHandler = inboundMsgHandler(func(msg service.DIDCommMsg, my, their string) error {
+			require.Equal(t, myDID, my)
+			require.Equal(t, theirDID, their)
+			dispatched <- msg
+
+			return nil
+		})
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		err = r.SaveConnectionRecord(&connection.Record{
+			ConnectionID:   connID,
+			MyDID:          myDID,
+			TheirDID:       theirDID,
+			ParentThreadID: inv.ID,
+		})
+		require.NoError(t, err)
+
+		s := newAutoService(t, provider)
+		result, err := s.AcceptInvitation(inv, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, connID, result)
+
+		err = s.handleDIDEvent(service.StateMsg{
+			ProtocolName: didexchange.DIDExchange,
+			Type:         service.PostState,
+			Msg:          service.NewDIDCommMsgMap(newAck(inv.ID)),
+			StateID:      didexchange.StateIDCompleted,
+			Properties:   &mockdidexchange.MockEventProperties{ConnID: connID},
+		})
+		require.NoError(t, err)
+
+		select {
+		case msg := <-dispatched:
+			require.Equal(t, "test-type", msg.Type())
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		state, err := s.fetchMyState(inv.ID)
+		require.NoError(t, err)
+		require.True(t, state.Done)
+	})
+	t.Run("completes once connected if no request is attached", func(t *testing.T) {
+		const connID = "123456"
+		inv := newInvitation()
+		provider := testProvider()
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(_ *didexchange.OOBInvitation, _ []string) (string, error) {
+					return connID, nil
+				},
+			},
+		}
+		provider.InboundMsgHandler = inboundMsgHandler(func(service.DIDCommMsg, string, string) error {
+			t.Error("unexpected dispatch")
+			return nil
+		})
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		err = r.SaveConnectionRecord(&connection.Record{
+			ConnectionID:   connID,
+			ParentThreadID: inv.ID,
+		})
+		require.NoError(t, err)
+
+		s := newAutoService(t, provider)
+		_, err = s.AcceptInvitation(inv, "", nil)
+		require.NoError(t, err)
+
+		err = s.handleDIDEvent(service.StateMsg{
+			ProtocolName: didexchange.DIDExchange,
+			Type:         service.PostState,
+			Msg:          service.NewDIDCommMsgMap(newAck(inv.ID)),
+			StateID:      didexchange.StateIDCompleted,
+			Properties:   &mockdidexchange.MockEventProperties{ConnID: connID},
+		})
+		require.NoError(t, err)
+
+		state, err := s.fetchMyState(inv.ID)
+		require.NoError(t, err)
+		require.True(t, state.Done)
+	})
+}
+
+func TestAcceptInvitationV2(t *testing.T) {
+	t.Run("returns connectionID and dispatches attachment once connected", func(t *testing.T) {
+		expected := "123456"
+		inv := newInvitationV2()
+		provider := testProvider()
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(i *didexchange.OOBInvitation, _ []string) (string, error) {
+					require.Equal(t, inv.ID, i.ThreadID)
+					require.Equal(t, inv.From, i.Target)
+					return expected, nil
+				},
+			},
+		}
+		dispatched := make(chan service.DIDCommMsg, 1)
+		provider.InboundMsgHandler = inboundMsgHandler(func(msg service.DIDCommMsg, _, _ string) error {
+			dispatched <- msg
+			return nil
+		})
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		err = r.SaveConnectionRecord(&connection.Record{
+			ConnectionID:   expected,
+			MyDID:          myDID,
+			TheirDID:       theirDID,
+			ParentThreadID: inv.ID,
+		})
+		require.NoError(t, err)
+
+		s := newAutoService(t, provider)
+		result, err := s.AcceptInvitationV2(inv, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+
+		err = s.handleDIDEvent(service.StateMsg{
+			ProtocolName: didexchange.DIDExchange,
+			Type:         service.PostState,
+			Msg:          service.NewDIDCommMsgMap(newAck(inv.ID)),
+			StateID:      didexchange.StateIDCompleted,
+			Properties:   &mockdidexchange.MockEventProperties{ConnID: expected},
+		})
+		require.NoError(t, err)
+
+		select {
+		case msg := <-dispatched:
+			require.Equal(t, "test-type", msg.Type())
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+	t.Run("fails without from DID", func(t *testing.T) {
+		inv := newInvitationV2()
+		inv.From = ""
+		s := newAutoService(t, testProvider())
+		_, err := s.AcceptInvitationV2(inv, "", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not have a 'from' DID")
+	})
+	t.Run("wraps error from didexchange service", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := testProvider()
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(_ *didexchange.OOBInvitation, _ []string) (string, error) {
+					return "", expected
+				},
+			},
+		}
+		s := newAutoService(t, provider)
+		_, err := s.AcceptInvitationV2(newInvitationV2(), "", nil)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+	t.Run("wraps error returned by the protocol state store", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := testProvider()
+		provider.ProtocolStateStoreProvider = &mockstore.MockStoreProvider{
+			Store: &mockstore.MockStore{
+				ErrPut: expected,
+			},
+		}
+		s := newAutoService(t, provider)
+		_, err := s.AcceptInvitationV2(newInvitationV2(), "", nil)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+	t.Run("wraps error thrown when decoding the message", func(t *testing.T) {
+		expected := errors.New("test")
+		s := newAutoService(t, testProvider())
+		_, err := s.handleInvitationV2Callback(&callback{msg: &testDIDCommMsg{errDecode: expected}})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+}
+
+func TestAcceptImplicitInvitation(t *testing.T) {
+	const publicDID = "did:example:public"
+
+	t.Run("connects to the public DID", func(t *testing.T) {
+		expected := "123456"
+		provider := testProvider()
+		provider.CustomVDR = &mockvdr.MockVDRegistry{ResolveValue: mockdiddoc.GetMockDIDDoc()}
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(i *didexchange.OOBInvitation, conns []string) (string, error) {
+					require.Equal(t, publicDID, i.Target)
+					require.Equal(t, publicDID, i.ThreadID)
+					require.Equal(t, "Bob", i.MyLabel)
+					require.Equal(t, []string{"router"}, conns)
+
+					return expected, nil
+				},
+			},
+		}
+		s := newAutoService(t, provider)
+		result, err := s.AcceptImplicitInvitation(publicDID, "Bob", []string{"router"})
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	})
+	t.Run("fails to resolve the public DID", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := testProvider()
+		provider.CustomVDR = &mockvdr.MockVDRegistry{ResolveErr: expected}
+		s := newAutoService(t, provider)
+		_, err := s.AcceptImplicitInvitation(publicDID, "", nil)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+	t.Run("fails without DIDComm service", func(t *testing.T) {
+		provider := testProvider()
+		provider.CustomVDR = &mockvdr.MockVDRegistry{ResolveValue: &did.Doc{ID: publicDID}}
+		s := newAutoService(t, provider)
+		_, err := s.AcceptImplicitInvitation(publicDID, "", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot be used as an implicit invitation")
+	})
+	t.Run("wraps error from didexchange service", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := testProvider()
+		provider.CustomVDR = &mockvdr.MockVDRegistry{ResolveValue: mockdiddoc.GetMockDIDDoc()}
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(*didexchange.OOBInvitation, []string) (string, error) {
+					return "", expected
+				},
+			},
+		}
+		s := newAutoService(t, provider)
+		_, err := s.AcceptImplicitInvitation(publicDID, "", nil)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+}
+
+func TestConnectionReuse(t *testing.T) {
+	const existingConnID = "existing-connection"
+
+	saveConnection := func(t *testing.T, provider *protocol.MockProvider, theirDID string) {
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+			ConnectionID: existingConnID,
+			State:        connection.StateNameCompleted,
+			MyDID:        myDID,
+			TheirDID:     theirDID,
+		}))
+	}
+
+	noDIDExchange := &mockdidexchange.MockDIDExchangeSvc{
+		RespondToFunc: func(*didexchange.OOBInvitation, []string) (string, error) {
+			return "", errors.New("unexpected did-exchange")
+		},
+	}
+
+	t.Run("invitee reuses the existing connection", func(t *testing.T) {
+		inv := newInvitation()
+		sent := make(chan service.DIDCommMsgMap, 1)
+		provider := testProvider()
+		provider.ServiceMap[didexchange.DIDExchange] = noDIDExchange
+		provider.CustomOutbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, my, their string) error {
+				require.Equal(t, myDID, my)
+				require.Equal(t, "did:example:1235", their)
+				sent <- msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}
+		saveConnection(t, provider, "did:example:1235")
+
+		s := newAutoService(t, provider)
+		connID, err := s.AcceptInvitation(inv, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, existingConnID, connID)
+
+		reuse := <-sent
+		require.Equal(t, HandshakeReuseMsgType, reuse.Type())
+		require.Equal(t, inv.ID, reuse.ParentThreadID())
+
+		state, err := s.fetchMyState(inv.ID)
+		require.NoError(t, err)
+		require.True(t, state.Reused)
+		require.Equal(t, existingConnID, state.ConnectionID)
+	})
+
+	t.Run("invitee dispatches attachments once the reuse is accepted", func(t *testing.T) {
+		inv := newInvitationV2()
+		provider := testProvider()
+		provider.ServiceMap[didexchange.DIDExchange] = noDIDExchange
+		dispatched := make(chan service.DIDCommMsg, 1)
+		provider.InboundMsgHandler = inboundMsgHandler(func(msg service.DIDCommMsg, my, their string) error {
+			require.Equal(t, myDID, my)
+			require.Equal(t, inv.From, their)
+			dispatched <- msg
+
+			return nil
+		})
+		saveConnection(t, provider, inv.From)
+
+		s := newAutoService(t, provider)
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, s.RegisterMsgEvent(states))
+
+		connID, err := s.AcceptInvitationV2(inv, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, existingConnID, connID)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&HandshakeReuseAccepted{
+			ID:     uuid.New().String(),
+			Type:   HandshakeReuseAcceptedMsgType,
+			Thread: &decorator.Thread{ID: uuid.New().String(), PID: inv.ID},
+		}), myDID, inv.From)
+		require.NoError(t, err)
+
+		select {
+		case msg := <-dispatched:
+			require.Equal(t, "test-type", msg.Type())
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+
+		select {
+		case state := <-states:
+			require.Equal(t, StateConnectionReused, state.StateID)
+			require.Equal(t, existingConnID, state.Properties.(*eventProps).ConnectionID())
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+
+		state, err := s.fetchMyState(inv.ID)
+		require.NoError(t, err)
+		require.True(t, state.Done)
+	})
+
+	t.Run("invitee connects if there is no existing connection", func(t *testing.T) {
+		provider := testProvider()
+		provider.ServiceMap[didexchange.DIDExchange] = &mockdidexchange.MockDIDExchangeSvc{
+			RespondToFunc: func(*didexchange.OOBInvitation, []string) (string, error) {
+				return "new-connection", nil
+			},
+		}
+		saveConnection(t, provider, theirDID)
+
+		s := newAutoService(t, provider)
+		connID, err := s.AcceptRequest(newRequest(), "", nil)
+		require.NoError(t, err)
+		require.Equal(t, "new-connection", connID)
+	})
+
+	t.Run("invitee fails to send handshake-reuse", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := testProvider()
+		provider.CustomOutbound = &mockdispatcher.MockOutbound{SendErr: expected}
+		saveConnection(t, provider, "did:example:1235")
+
+		s := newAutoService(t, provider)
+		_, err := s.AcceptRequest(newRequest(), "", nil)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+
+	t.Run("invitee fails if the reuse was not requested", func(t *testing.T) {
+		inv := newInvitationV2()
+		s := newAutoService(t, testProvider(), withState(t, &myState{ID: inv.ID}))
+
+		_, err := s.HandleInbound(service.NewDIDCommMsgMap(&HandshakeReuseAccepted{
+			ID:     uuid.New().String(),
+			Type:   HandshakeReuseAcceptedMsgType,
+			Thread: &decorator.Thread{ID: uuid.New().String(), PID: inv.ID},
+		}), myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no connection reuse was requested")
+	})
+
+	t.Run("invitee fails to load the state of an unknown invitation", func(t *testing.T) {
+		s := newAutoService(t, testProvider())
+
+		_, err := s.HandleInbound(service.NewDIDCommMsgMap(&HandshakeReuseAccepted{
+			ID:     uuid.New().String(),
+			Type:   HandshakeReuseAcceptedMsgType,
+			Thread: &decorator.Thread{ID: uuid.New().String(), PID: "unknown"},
+		}), myDID, theirDID)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("inviter accepts the reuse", func(t *testing.T) {
+		reuse := &HandshakeReuse{
+			ID:     uuid.New().String(),
+			Type:   HandshakeReuseMsgType,
+			Thread: &decorator.Thread{PID: uuid.New().String()},
+		}
+		reuse.Thread.ID = reuse.ID
+
+		sent := make(chan service.DIDCommMsgMap, 1)
+		provider := testProvider()
+		provider.CustomOutbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}
+		saveConnection(t, provider, theirDID)
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, s.RegisterMsgEvent(states))
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(reuse), myDID, theirDID)
+		require.NoError(t, err)
+
+		accepted := <-sent
+		require.Equal(t, HandshakeReuseAcceptedMsgType, accepted.Type())
+		thid, err := accepted.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, reuse.ID, thid)
+		require.Equal(t, reuse.Thread.PID, accepted.ParentThreadID())
+
+		select {
+		case state := <-states:
+			require.Equal(t, StateConnectionReused, state.StateID)
+			require.Equal(t, existingConnID, state.Properties.(*eventProps).ConnectionID())
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	})
+
+	t.Run("inviter fails without a connection", func(t *testing.T) {
+		s, err := New(testProvider())
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&HandshakeReuse{
+			ID:     uuid.New().String(),
+			Type:   HandshakeReuseMsgType,
+			Thread: &decorator.Thread{PID: uuid.New().String()},
+		}), myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to find the connection to reuse")
+	})
+
+	t.Run("inviter fails without parent thread ID", func(t *testing.T) {
+		s, err := New(testProvider())
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&HandshakeReuse{
+			ID:   uuid.New().String(),
+			Type: HandshakeReuseMsgType,
+		}), myDID, theirDID)
+		require.EqualError(t, err, "handshake-reuse message has no parent thread ID")
+	})
+}
+
+func TestSaveInvitationV2(t *testing.T) {
+	t.Run("saves invitation", func(t *testing.T) {
+		expected := newInvitationV2()
+		provider := testProvider()
+		provider.ServiceMap[didexchange.DIDExchange] = &mockdidexchange.MockDIDExchangeSvc{
+			SaveFunc: func(i *didexchange.OOBInvitation) error {
+				require.NotEmpty(t, i.ID)
+				require.Equal(t, expected.ID, i.ThreadID)
+				require.Equal(t, expected.Label, i.TheirLabel)
+				require.Equal(t, expected.From, i.Target)
+				return nil
+			},
+		}
+		s := newAutoService(t, provider)
+		require.NoError(t, s.SaveInvitationV2(expected))
+	})
+	t.Run("fails without from DID", func(t *testing.T) {
+		inv := newInvitationV2()
+		inv.From = ""
+		s := newAutoService(t, testProvider())
+		require.Error(t, s.SaveInvitationV2(inv))
+	})
+	t.Run("wraps error from store", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := testProvider()
+		provider.StoreProvider = &mockstore.MockStoreProvider{
+			Store: &mockstore.MockStore{
+				ErrPut: expected,
+			},
+		}
+		s := newAutoService(t, provider)
+		err := s.SaveInvitationV2(newInvitationV2())
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+	t.Run("wraps error from didexchange service", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := testProvider()
+		provider.ServiceMap[didexchange.DIDExchange] = &mockdidexchange.MockDIDExchangeSvc{
+			SaveFunc: func(*didexchange.OOBInvitation) error {
+				return expected
+			},
+		}
+		s := newAutoService(t, provider)
+		err := s.SaveInvitationV2(newInvitationV2())
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+}
+
+func TestInvitationV2_ToV1(t *testing.T) {
+	t.Run("converts to invitation", func(t *testing.T) {
+		inv := newInvitationV2()
+		inv.Attachments = nil
+		v1Inv, req := inv.ToV1([]string{didexchange.PIURI})
+		require.Nil(t, req)
+		require.Equal(t, inv.ID, v1Inv.ID)
+		require.Equal(t, []interface{}{inv.From}, v1Inv.Service)
+		require.Equal(t, inv.Body.GoalCode, v1Inv.GoalCode)
+		require.Equal(t, inv.Body.Accept, v1Inv.Accept)
+	})
+	t.Run("converts to request", func(t *testing.T) {
+		inv := newInvitationV2()
+		inv.Body = nil
+		v1Inv, req := inv.ToV1(nil)
+		require.Nil(t, v1Inv)
+		require.Equal(t, inv.ID, req.ID)
+		require.Equal(t, inv.Attachments, req.Requests)
+		require.Empty(t, req.GoalCode)
+		require.Empty(t, req.Accept)
+	})
 }
 
 func TestSaveRequest(t *testing.T) {
@@ -1067,6 +1670,13 @@ func TestSaveInvitation(t *testing.T) {
 	})
 }
 
+func TestHandshakeProtocol(t *testing.T) {
+	require.Empty(t, handshakeProtocol(nil))
+	require.Empty(t, handshakeProtocol([]string{didexchange.PIURI}))
+	require.Empty(t, handshakeProtocol([]string{didexchange.LegacyPIURI, didexchange.PIURI}))
+	require.Equal(t, didexchange.LegacyPIURI, handshakeProtocol([]string{didexchange.LegacyPIURI}))
+}
+
 func TestChooseTarget(t *testing.T) {
 	t.Run("chooses a string", func(t *testing.T) {
 		expected := "abc123"
@@ -1160,6 +1770,30 @@ func newInvitation() *Invitation {
 	}
 }
 
+func newInvitationV2() *InvitationV2 {
+	return &InvitationV2{
+		ID:    uuid.New().String(),
+		Type:  InvitationV2MsgType,
+		Label: "test",
+		From:  "did:example:1235",
+		Body: &InvitationV2Body{
+			Goal:     "test",
+			GoalCode: "test",
+			Accept:   []string{commontransport.MediaTypeProfileDIDCommV2},
+		},
+		Attachments: []*decorator.Attachment{{
+			ID:       uuid.New().String(),
+			MimeType: "application/json",
+			Data: decorator.AttachmentData{
+				JSON: map[string]interface{}{
+					"@id":   "123",
+					"@type": "test-type",
+				},
+			},
+		}},
+	}
+}
+
 func newReqCallback() *callback {
 	return &callback{
 		myDID:    fmt.Sprintf("did:example:%s", uuid.New().String()),
@@ -1273,14 +1907,17 @@ func (s *stubStore) Delete(k string) error {
 	panic("implement me")
 }
 
-type outboundMsgHandlerStub struct {
-	handleFunc func(service.DIDCommMsg, string, string) (string, error)
+func (s *stubStore) Batch(operations []storage.Operation) error {
+	panic("implement me")
 }
 
-func (o *outboundMsgHandlerStub) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
-	if o.handleFunc != nil {
-		return o.handleFunc(msg, myDID, theirDID)
-	}
+func inboundMsgHandler(handle func(service.DIDCommMsg, string, string) error) transport.InboundMessageHandler {
+	return func(message []byte, myDID, theirDID string) error {
+		msg, err := service.ParseDIDCommMsgMap(message)
+		if err != nil {
+			return err
+		}
 
-	return "", nil
+		return handle(msg, myDID, theirDID)
+	}
 }
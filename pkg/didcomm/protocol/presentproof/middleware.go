@@ -24,6 +24,12 @@ func (hf HandlerFunc) Handle(metadata Metadata) error {
 	return hf(metadata)
 }
 
+// AutoAcceptor decides whether an inbound proposal, request or presentation is accepted without triggering
+// an action event. When accepted, the returned Opt (if any) is applied in the same way as the one provided to
+// the Continue function, e.g. WithRejection stops the protocol instead.
+// Properties set on the metadata are delivered with the action event when the message is not accepted.
+type AutoAcceptor func(metadata Metadata) (Opt, bool)
+
 // Metadata provides helpful information for the processing.
 type Metadata interface {
 	// Message contains the original inbound/outbound message
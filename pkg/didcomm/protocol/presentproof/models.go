@@ -16,6 +16,8 @@ type ProposePresentation struct {
 	// Comment is a field that provides some human readable information about the proposed presentation.
 	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string `json:"comment,omitempty"`
+	// GoalCode is an optional goal code (e.g aries.vc.verify) describing the purpose of the proposal.
+	GoalCode string `json:"goal_code,omitempty"`
 	// Formats contains an entry for each proposal~attach array entry, including an optional value of the
 	// attachment @id (if attachments are present) and the verifiable presentation format and version of the attachment.
 	Formats []Format `json:"formats,omitempty"`
@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// PresentationDefinitionFormat is the format of the request-presentation attachment which contains
+	// the DIF Presentation Exchange presentation definition.
+	PresentationDefinitionFormat = "dif/presentation-exchange/definitions@v1.0"
+	// PresentationSubmissionFormat is the format of the presentation attachment which contains
+	// the verifiable presentation with the DIF Presentation Exchange presentation submission.
+	PresentationSubmissionFormat = "dif/presentation-exchange/submission@v1.0"
+
+	mimeTypeJSON = "application/json"
+)
+
+// ErrNoPresentationDefinition is returned when the request-presentation message has no presentation definition.
+var ErrNoPresentationDefinition = errors.New("presentation definition not found")
+
+type presentationDefinitionData struct {
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition"`
+}
+
+// AddPresentationDefinition attaches the presentation definition to the request-presentation message.
+func AddPresentationDefinition(req *RequestPresentation, definition *presexch.PresentationDefinition) {
+	id := uuid.New().String()
+
+	req.Formats = append(req.Formats, Format{AttachID: id, Format: PresentationDefinitionFormat})
+	req.RequestPresentationsAttach = append(req.RequestPresentationsAttach, decorator.Attachment{
+		ID:       id,
+		MimeType: mimeTypeJSON,
+		Data: decorator.AttachmentData{
+			JSON: &presentationDefinitionData{PresentationDefinition: definition},
+		},
+	})
+}
+
+// GetPresentationDefinition returns the presentation definition attached to the request-presentation message.
+func GetPresentationDefinition(req *RequestPresentation) (*presexch.PresentationDefinition, error) {
+	for _, format := range req.Formats {
+		if format.Format != PresentationDefinitionFormat {
+			continue
+		}
+
+		for i := range req.RequestPresentationsAttach {
+			attachment := req.RequestPresentationsAttach[i]
+			if attachment.ID != format.AttachID {
+				continue
+			}
+
+			raw, err := attachment.Data.Fetch()
+			if err != nil {
+				return nil, fmt.Errorf("fetch: %w", err)
+			}
+
+			data := &presentationDefinitionData{}
+
+			if err = json.Unmarshal(raw, data); err != nil {
+				return nil, fmt.Errorf("unmarshal presentation definition: %w", err)
+			}
+
+			if data.PresentationDefinition == nil {
+				return nil, ErrNoPresentationDefinition
+			}
+
+			return data.PresentationDefinition, nil
+		}
+	}
+
+	return nil, ErrNoPresentationDefinition
+}
+
+// AddPresentationSubmission attaches the verifiable presentation containing the presentation submission
+// to the presentation message.
+func AddPresentationSubmission(msg *Presentation, vp *verifiable.Presentation) {
+	id := uuid.New().String()
+
+	msg.Formats = append(msg.Formats, Format{AttachID: id, Format: PresentationSubmissionFormat})
+	msg.PresentationsAttach = append(msg.PresentationsAttach, decorator.Attachment{
+		ID:       id,
+		MimeType: mimeTypeJSON,
+		Data: decorator.AttachmentData{
+			JSON: vp,
+		},
+	})
+}
@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+func TestPresentationDefinition(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		definition := &presexch.PresentationDefinition{
+			ID: "definition",
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID:     "degree",
+				Schema: []presexch.Schema{{URI: "https://www.w3.org/2018/credentials/examples/v1"}},
+			}},
+		}
+
+		req := &RequestPresentation{Type: RequestPresentationMsgType}
+		AddPresentationDefinition(req, definition)
+
+		// the definition is read back from the received message
+		received := &RequestPresentation{}
+		require.NoError(t, service.NewDIDCommMsgMap(req).Decode(received))
+
+		result, err := GetPresentationDefinition(received)
+		require.NoError(t, err)
+		require.Equal(t, definition, result)
+	})
+
+	t.Run("No definition", func(t *testing.T) {
+		_, err := GetPresentationDefinition(&RequestPresentation{})
+		require.True(t, errors.Is(err, ErrNoPresentationDefinition))
+
+		_, err = GetPresentationDefinition(&RequestPresentation{
+			Formats: []Format{{AttachID: "ID", Format: PresentationDefinitionFormat}},
+			RequestPresentationsAttach: []decorator.Attachment{{
+				ID:   "ID",
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{}},
+			}},
+		})
+		require.True(t, errors.Is(err, ErrNoPresentationDefinition))
+	})
+
+	t.Run("Invalid attachment", func(t *testing.T) {
+		_, err := GetPresentationDefinition(&RequestPresentation{
+			Formats: []Format{{AttachID: "ID", Format: PresentationDefinitionFormat}},
+			RequestPresentationsAttach: []decorator.Attachment{{
+				ID:   "ID",
+				Data: decorator.AttachmentData{Base64: "invalid"},
+			}},
+		})
+		require.Contains(t, err.Error(), "fetch")
+	})
+}
+
+func TestAddPresentationSubmission(t *testing.T) {
+	vp := &verifiable.Presentation{Context: []string{presexch.PresentationSubmissionJSONLDContext}}
+
+	msg := &Presentation{}
+	AddPresentationSubmission(msg, vp)
+
+	require.Len(t, msg.PresentationsAttach, 1)
+	require.Equal(t, []Format{{AttachID: msg.PresentationsAttach[0].ID, Format: PresentationSubmissionFormat}},
+		msg.Formats)
+	require.Equal(t, vp, msg.PresentationsAttach[0].Data.JSON)
+}
@@ -13,6 +13,7 @@ const (
 	theirDIDPropKey = "theirDID"
 	piidPropKey     = "piid"
 	errorPropKey    = "error"
+	expiredPropKey  = "expired"
 )
 
 type eventProps struct {
@@ -10,12 +10,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 const (
@@ -49,16 +55,25 @@ var (
 		return nil
 	})
 	errProtocolStopped = errors.New("protocol was stopped")
+	// ErrActionExpired is the error of the actions stopped because they were pending longer than the timeout.
+	ErrActionExpired = errors.New("action expired")
 )
 
 // customError is a wrapper to determine custom error against internal error.
 type customError struct{ error }
 
+// Unwrap gives access to the reason of the rejection, e.g. for its problem report code.
+func (e customError) Unwrap() error {
+	return e.error
+}
+
 // transitionalPayload keeps payload needed for Continue function to proceed with the action.
 type transitionalPayload struct {
 	Action
 	StateName   string
 	AckRequired bool
+	// CreatedAt is the time the action was triggered, used to expire the actions resumed after a restart.
+	CreatedAt time.Time
 }
 
 // metaData type to store data for internal usage.
@@ -148,6 +163,18 @@ func WithFriendlyNames(names ...string) Opt {
 	}
 }
 
+// WithRejection stops the protocol with the given reason in the same way as the Stop function.
+// USAGE: This function allows the AutoAcceptor to reject the inbound message without triggering an action event.
+func WithRejection(reason error) Opt {
+	return func(md *metaData) {
+		if reason == nil {
+			reason = errProtocolStopped
+		}
+
+		md.err = customError{error: reason}
+	}
+}
+
 // Provider contains dependencies for the protocol and is typically created by using aries.Context().
 type Provider interface {
 	Messenger() service.Messenger
@@ -158,10 +185,15 @@ type Provider interface {
 type Service struct {
 	service.Action
 	service.Message
-	store      storage.Store
-	callbacks  chan *metaData
-	messenger  service.Messenger
-	middleware Handler
+	store        storage.Store
+	callbacks    chan *metaData
+	messenger    service.Messenger
+	middleware   Handler
+	autoAcceptor AutoAcceptor
+	autoAccepter *connection.AutoAccepter
+	metrics      *metrics.Protocol
+	clock        clock.Clock
+	tracing      *tracing.Protocol
 }
 
 // New returns the presentproof service.
@@ -171,11 +203,20 @@ func New(p Provider) (*Service, error) {
 		return nil, err
 	}
 
+	autoAccepter, err := connection.NewAutoAccepter(p)
+	if err != nil {
+		return nil, err
+	}
+
 	svc := &Service{
-		messenger:  p.Messenger(),
-		store:      store,
-		callbacks:  make(chan *metaData),
-		middleware: initialHandler,
+		messenger:    p.Messenger(),
+		store:        store,
+		callbacks:    make(chan *metaData),
+		middleware:   initialHandler,
+		metrics:      metrics.ForProtocol(p, Name),
+		clock:        clock.Of(p),
+		tracing:      tracing.ForProtocol(p, Name),
+		autoAccepter: autoAccepter,
 	}
 
 	// start the listener
@@ -194,6 +235,12 @@ func (s *Service) Use(items ...Middleware) {
 	s.middleware = handler
 }
 
+// UseAutoAcceptor allows providing a function which accepts inbound proposals and requests
+// without triggering an action event.
+func (s *Service) UseAutoAcceptor(acceptor AutoAcceptor) {
+	s.autoAcceptor = acceptor
+}
+
 // HandleInbound handles inbound message (presentproof protocol).
 func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
 	logger.Debugf("service.HandleInbound() input: msg=%+v myDID=%s theirDID=%s", msg, myDID, theirDID)
@@ -219,6 +266,18 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string)
 
 	// trigger action event based on message type for inbound messages
 	if canReply && canTriggerActionEvents(msgMap) {
+		if opt, ok := s.autoAccept(md); ok {
+			if opt != nil {
+				opt(md)
+			}
+
+			s.processCallback(md)
+
+			return "", nil
+		}
+
+		md.CreatedAt = s.clock.Now()
+
 		err = s.saveTransitionalPayload(md.PIID, md.transitionalPayload)
 		if err != nil {
 			return "", fmt.Errorf("save transitional payload: %w", err)
@@ -279,6 +338,10 @@ func (s *Service) doHandle(msg service.DIDCommMsgMap) (*metaData, error) {
 		return nil, fmt.Errorf("invalid state transition: %s -> %s", current.Name(), next.Name())
 	}
 
+	if current.Name() == stateNameStart {
+		s.metrics.Started()
+	}
+
 	return &metaData{
 		transitionalPayload: transitionalPayload{
 			StateName:   next.Name(),
@@ -322,10 +385,24 @@ func isNoOp(s state) bool {
 	return ok
 }
 
+// handle traces the transitions of the protocol instance triggered by the message of md.
 func (s *Service) handle(md *metaData) error {
+	span := s.tracing.Start(md.Msg, md.PIID)
+	span.SetAttributes(tracing.Attributes{tracing.StateAttribute: md.state.Name()})
+
+	err := s.transition(md)
+
+	span.End(err)
+
+	return err
+}
+
+func (s *Service) transition(md *metaData) error {
 	current := md.state
 
 	for !isNoOp(current) {
+		startedAt := time.Now()
+
 		next, action, err := s.execute(current, md)
 		if err != nil {
 			return fmt.Errorf("execute: %w", err)
@@ -345,12 +422,25 @@ func (s *Service) handle(md *metaData) error {
 			return fmt.Errorf("action %s: %w", md.state.Name(), err)
 		}
 
+		s.recordTransition(current.Name(), time.Since(startedAt))
+
 		current = next
 	}
 
 	return nil
 }
 
+func (s *Service) recordTransition(stateName string, duration time.Duration) {
+	s.metrics.Transition(stateName, duration)
+
+	switch stateName {
+	case stateNameDone:
+		s.metrics.Completed()
+	case stateNameAbandoned:
+		s.metrics.Failed()
+	}
+}
+
 func getPIID(msg service.DIDCommMsg) (string, error) {
 	if pthID := msg.ParentThreadID(); pthID != "" {
 		return pthID, nil
@@ -454,6 +544,31 @@ func (s *Service) saveTransitionalPayload(id string, data transitionalPayload) e
 	return s.store.Put(fmt.Sprintf(transitionalPayloadKey, id), src)
 }
 
+// autoAccept checks whether the inbound message is accepted by the auto acceptor. The presentation requests
+// are passed to the auto acceptor unless the auto-accept configuration of the connection disables it.
+func (s *Service) autoAccept(md *metaData) (Opt, bool) {
+	if s.autoAcceptor == nil {
+		return nil, false
+	}
+
+	if md.msgClone.Type() == RequestPresentationMsgType {
+		accept, ok := s.autoAccepter.ResolveByDIDs(connection.AutoAcceptPresentationRequest, md.MyDID, md.TheirDID)
+		if ok && !accept {
+			return nil, false
+		}
+	}
+
+	switch md.msgClone.Type() {
+	case ProposePresentationMsgType, RequestPresentationMsgType, PresentationMsgType:
+	default:
+		return nil, false
+	}
+
+	md.properties = newEventProps(md).All()
+
+	return s.autoAcceptor(md)
+}
+
 // canTriggerActionEvents checks if the incoming message can trigger an action event.
 func canTriggerActionEvents(msg service.DIDCommMsg) bool {
 	return msg.Type() == PresentationMsgType ||
@@ -484,28 +599,111 @@ func (s *Service) deleteTransitionalPayload(id string) error {
 
 // Actions returns actions for the async usage.
 func (s *Service) Actions() ([]Action, error) {
+	payloads, err := s.transitionalPayloads()
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []Action
+
+	for _, tPayload := range payloads {
+		actions = append(actions, tPayload.Action)
+	}
+
+	return actions, nil
+}
+
+// ResumeAll triggers the action events for the actions that were pending when the agent was stopped, e.g. to let
+// the new action event consumers handle them after the agent restart. The actions pending longer than the given
+// timeout are stopped (the protocol is abandoned). Zero timeout means the actions never expire.
+func (s *Service) ResumeAll(timeout time.Duration) error {
+	aEvent := s.ActionEvent()
+
+	// throw error if there is no action event registered for inbound messages
+	if aEvent == nil {
+		return errors.New("no clients are registered to handle the message")
+	}
+
+	payloads, err := s.transitionalPayloads()
+	if err != nil {
+		return fmt.Errorf("transitional payloads: %w", err)
+	}
+
+	for _, tPayload := range payloads {
+		if s.expired(tPayload.CreatedAt, timeout) {
+			if err = s.expire(tPayload); err != nil {
+				return fmt.Errorf("stop expired action: %w", err)
+			}
+
+			continue
+		}
+
+		aEvent <- s.newDIDCommActionMsg(&metaData{
+			transitionalPayload: *tPayload,
+			state:               stateFromName(tPayload.StateName),
+			msgClone:            tPayload.Msg.Clone(),
+			properties:          map[string]interface{}{},
+		})
+	}
+
+	return nil
+}
+
+// ExpireAll stops the actions that were pending longer than the given timeout (the protocol is abandoned), so the
+// transitional payloads do not accumulate in the store. The message events of the expired actions have
+// the "expired" property set.
+func (s *Service) ExpireAll(timeout time.Duration) error {
+	payloads, err := s.transitionalPayloads()
+	if err != nil {
+		return fmt.Errorf("transitional payloads: %w", err)
+	}
+
+	for _, tPayload := range payloads {
+		if !s.expired(tPayload.CreatedAt, timeout) {
+			continue
+		}
+
+		if err = s.expire(tPayload); err != nil {
+			return fmt.Errorf("stop expired action: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) expire(tPayload *transitionalPayload) error {
+	return s.stop(tPayload, ErrActionExpired, map[string]interface{}{expiredPropKey: true})
+}
+
+// expired checks whether the action created at the given time is pending longer than the timeout.
+// Zero timeout means the actions never expire.
+func (s *Service) expired(createdAt time.Time, timeout time.Duration) bool {
+	return timeout > 0 && !createdAt.IsZero() && clock.Since(s.clock, createdAt) > timeout
+}
+
+func (s *Service) transitionalPayloads() ([]*transitionalPayload, error) {
 	records := s.store.Iterator(
 		fmt.Sprintf(transitionalPayloadKey, ""),
 		fmt.Sprintf(transitionalPayloadKey, storage.EndKeySuffix),
 	)
 	defer records.Release()
 
-	var actions []Action
+	var payloads []*transitionalPayload
 
 	for records.Next() {
-		var action Action
-		if err := json.Unmarshal(records.Value(), &action); err != nil {
+		tPayload := &transitionalPayload{}
+		if err := json.Unmarshal(records.Value(), tPayload); err != nil {
 			return nil, fmt.Errorf("unmarshal: %w", err)
 		}
 
-		actions = append(actions, action)
+		payloads = append(payloads, tPayload)
 	}
 
 	if records.Error() != nil {
 		return nil, records.Error()
 	}
 
-	return actions, nil
+	return payloads, nil
 }
 
 // ActionContinue allows proceeding with the action by the piID.
@@ -542,21 +740,25 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 		return fmt.Errorf("get transitional payload: %w", err)
 	}
 
+	if cErr == nil {
+		cErr = errProtocolStopped
+	}
+
+	return s.stop(tPayload, cErr, map[string]interface{}{})
+}
+
+func (s *Service) stop(tPayload *transitionalPayload, cErr error, properties map[string]interface{}) error {
 	md := &metaData{
 		transitionalPayload: *tPayload,
 		state:               stateFromName(tPayload.StateName),
 		msgClone:            tPayload.Msg.Clone(),
-		properties:          map[string]interface{}{},
+		properties:          properties,
 	}
 
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
 		return fmt.Errorf("delete transitional payload: %w", err)
 	}
 
-	if cErr == nil {
-		cErr = errProtocolStopped
-	}
-
 	md.err = customError{error: cErr}
 	s.processCallback(md)
 
@@ -637,6 +839,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
@@ -18,14 +18,17 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	metricsMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 const (
@@ -252,6 +255,136 @@ func TestService_ActionStop(t *testing.T) {
 	})
 }
 
+func TestService_ResumeAll(t *testing.T) {
+	t.Run("No clients", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(nil)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		require.EqualError(t, svc.ResumeAll(0), "no clients are registered to handle the message")
+	})
+
+	t.Run("Resumes the pending action after restart", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		done := make(chan struct{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				require.Equal(t, PresentationMsgType, msg.Type())
+
+				return nil
+			})
+
+		storeProvider := mem.NewProvider()
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+		provider.EXPECT().StorageProvider().Return(storeProvider).AnyTimes()
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		// the action event is never handled e.g. the agent is stopped
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction, 1)))
+
+		msg := randomInboundMessage(RequestPresentationMsgType)
+
+		_, err = svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		restarted, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, restarted.RegisterActionEvent(ch))
+
+		require.NoError(t, restarted.ResumeAll(time.Hour))
+
+		select {
+		case action := <-ch:
+			require.Equal(t, msg.ID(), action.Message.ID())
+			require.Equal(t, Alice, action.Properties.All()["myDID"])
+
+			action.Continue(WithPresentation(&Presentation{}))
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		actions, err := restarted.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+
+	t.Run("Stops the expired action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		done := make(chan struct{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, _ *service.NestedReplyOpts) error {
+				defer close(done)
+
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, ProblemReportMsgType, r.Type)
+
+				return nil
+			})
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		_, err = svc.HandleInbound(randomInboundMessage(RequestPresentationMsgType), Alice, Bob)
+		require.NoError(t, err)
+
+		<-ch
+
+		require.NoError(t, svc.ResumeAll(time.Nanosecond))
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		select {
+		case <-ch:
+			t.Error("expired action should not be resumed")
+		default:
+		}
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+}
+
 // nolint: gocyclo
 func TestService_HandleInbound(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -459,6 +592,91 @@ func TestService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Receive Request Presentation (auto accepted)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &Presentation{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, PresentationMsgType, r.Type)
+				require.Equal(t, "auto", r.Comment)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			src, err := json.Marshal(&internalData{StateName: "request-received"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			src, err := json.Marshal(&internalData{StateName: "presentation-sent"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		svc.UseAutoAcceptor(func(metadata Metadata) (Opt, bool) {
+			require.Equal(t, RequestPresentationMsgType, metadata.Message().Type())
+			require.Equal(t, Alice, metadata.Properties()["myDID"])
+			require.Equal(t, Bob, metadata.Properties()["theirDID"])
+
+			return WithPresentation(&Presentation{Comment: "auto"}), true
+		})
+
+		msg := randomInboundMessage(RequestPresentationMsgType)
+		msg["will_confirm"] = true
+
+		_, err = svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Request Presentation (auto acceptor declined)", func(t *testing.T) {
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		svc.UseAutoAcceptor(func(metadata Metadata) (Opt, bool) {
+			metadata.Properties()["presentation"] = &Presentation{}
+
+			return nil, false
+		})
+
+		_, err = svc.HandleInbound(randomInboundMessage(RequestPresentationMsgType), Alice, Bob)
+		require.NoError(t, err)
+
+		action := <-ch
+
+		properties, ok := action.Properties.(*eventProps)
+		require.True(t, ok)
+		require.Equal(t, &Presentation{}, properties.All()["presentation"])
+	})
+
 	t.Run("Receive Request Presentation (continue with presentation) async", func(t *testing.T) {
 		done := make(chan struct{})
 
@@ -897,6 +1115,61 @@ func TestService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Receive Presentation (auto acceptor rejected)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		newMessenger := serviceMocks.NewMockMessenger(ctrl)
+		newMessenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, _ *service.NestedReplyOpts) error {
+				defer close(done)
+
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, ProblemReportMsgType, r.Type)
+				require.Equal(t, codeRejectedError, r.Description.Code)
+
+				return nil
+			})
+
+		newProvider := presentproofMocks.NewMockProvider(ctrl)
+		newProvider.EXPECT().Messenger().Return(newMessenger)
+		newProvider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+		svc, err := New(newProvider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		svc.UseAutoAcceptor(func(metadata Metadata) (Opt, bool) {
+			require.Equal(t, PresentationMsgType, metadata.Message().Type())
+
+			return WithRejection(errors.New("untrusted issuer")), true
+		})
+
+		thID := uuid.New().String()
+		require.NoError(t, svc.saveInternalData(thID, &internalData{StateName: "request-sent"}))
+
+		msg := service.NewDIDCommMsgMap(Presentation{Type: PresentationMsgType})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+		msg["~thread"] = map[string]interface{}{"thid": thID}
+
+		_, err = svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		select {
+		case <-ch:
+			t.Error("rejected presentation should not trigger an action event")
+		default:
+		}
+	})
+
 	t.Run("Receive Ack", func(t *testing.T) {
 		done := make(chan struct{})
 
@@ -1042,6 +1315,81 @@ func TestService_HandleInbound(t *testing.T) {
 	})
 }
 
+func TestService_ExpireAll(t *testing.T) {
+	t.Run("Keeps the actions within the timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		_, err = svc.HandleInbound(randomInboundMessage(RequestPresentationMsgType), Alice, Bob)
+		require.NoError(t, err)
+
+		<-ch
+
+		require.NoError(t, svc.ExpireAll(time.Hour))
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+	})
+
+	t.Run("Stops the expired action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).Return(nil)
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		events := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		_, err = svc.HandleInbound(randomInboundMessage(RequestPresentationMsgType), Alice, Bob)
+		require.NoError(t, err)
+
+		<-ch
+
+		require.NoError(t, svc.ExpireAll(time.Nanosecond))
+
+		for {
+			select {
+			case e := <-events:
+				if e.StateID != stateNameAbandoned || e.Type != service.PostState {
+					continue
+				}
+
+				require.Equal(t, true, e.Properties.All()[expiredPropKey])
+
+				actions, err := svc.Actions()
+				require.NoError(t, err)
+				require.Empty(t, actions)
+
+				return
+			case <-time.After(time.Second):
+				t.Fatal("timeout")
+			}
+		}
+	})
+}
+
 func Test_stateFromName(t *testing.T) {
 	require.Equal(t, stateFromName(stateNameStart), &start{})
 	require.Equal(t, stateFromName(stateNameAbandoned), &abandoned{})
@@ -1059,6 +1407,10 @@ func TestService_Name(t *testing.T) {
 	require.Equal(t, (*Service).Name(nil), Name)
 }
 
+func TestService_Protocols(t *testing.T) {
+	require.Equal(t, []string{"https://didcomm.org/present-proof/2.0"}, (*Service).Protocols(nil))
+}
+
 func TestService_Accept(t *testing.T) {
 	require.True(t, (*Service).Accept(nil, ProposePresentationMsgType))
 	require.True(t, (*Service).Accept(nil, RequestPresentationMsgType))
@@ -1144,3 +1496,171 @@ func Test_nextState(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, next)
 }
+
+type metricsProvider struct {
+	Provider
+	metrics metrics.Provider
+}
+
+func (p *metricsProvider) Metrics() metrics.Provider {
+	return p.metrics
+}
+
+func TestService_Metrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	labels := metrics.Labels{metrics.ProtocolLabel: Name}
+
+	started := metricsMocks.NewMockCounter(ctrl)
+	started.EXPECT().Inc(labels)
+
+	completed := metricsMocks.NewMockCounter(ctrl)
+	completed.EXPECT().Inc(labels)
+
+	transitions := metricsMocks.NewMockHistogram(ctrl)
+	transitions.EXPECT().Observe(gomock.Any(), metrics.Labels{
+		metrics.ProtocolLabel: Name,
+		metrics.StateLabel:    stateNameDone,
+	})
+
+	metricsProv := metricsMocks.NewMockProvider(ctrl)
+	metricsProv.EXPECT().Counter(metrics.ExchangesStarted).Return(started)
+	metricsProv.EXPECT().Counter(metrics.ExchangesCompleted).Return(completed)
+	metricsProv.EXPECT().Counter(metrics.ExchangesFailed).Return(metricsMocks.NewMockCounter(ctrl))
+	metricsProv.EXPECT().Histogram(metrics.StateTransitionDuration).Return(transitions)
+
+	provider := presentproofMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+	svc, err := New(&metricsProvider{Provider: provider, metrics: metricsProv})
+	require.NoError(t, err)
+
+	ch := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(ch))
+
+	t.Run("Counts the started exchange", func(t *testing.T) {
+		msg := service.NewDIDCommMsgMap(ProposePresentation{Type: ProposePresentationMsgType})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+		msg["~thread"] = map[string]interface{}{"thid": uuid.New().String()}
+
+		_, err := svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Counts the completed exchange", func(t *testing.T) {
+		thID := uuid.New().String()
+		require.NoError(t, svc.saveInternalData(thID, &internalData{StateName: stateNamePresentationSent}))
+
+		msg := service.NewDIDCommMsgMap(model.Ack{Type: AckMsgType})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+		msg["~thread"] = map[string]interface{}{"thid": thID}
+
+		_, err := svc.HandleInbound(msg, Alice, Bob)
+		require.NoError(t, err)
+	})
+}
+
+type autoAcceptProvider struct {
+	Provider
+	config             *connection.AutoAcceptConfig
+	protocolStateStore storage.Provider
+}
+
+func (p *autoAcceptProvider) AutoAcceptConfig() *connection.AutoAcceptConfig {
+	return p.config
+}
+
+func (p *autoAcceptProvider) ProtocolStateStorageProvider() storage.Provider {
+	return p.protocolStateStore
+}
+
+func TestService_AutoAcceptRequest(t *testing.T) {
+	const Carol = "Carol"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	storeProvider := mem.NewProvider()
+
+	provider := presentproofMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(storeProvider).AnyTimes()
+
+	prov := &autoAcceptProvider{
+		Provider: provider,
+		config: &connection.AutoAcceptConfig{
+			Global: connection.AutoAccept{connection.AutoAcceptPresentationRequest: true},
+		},
+		protocolStateStore: mem.NewProvider(),
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	// the connection with Bob disables the auto acceptor
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: uuid.New().String(),
+		State:        connection.StateNameCompleted,
+		MyDID:        Alice,
+		TheirDID:     Bob,
+		AutoAccept:   connection.AutoAccept{connection.AutoAcceptPresentationRequest: false},
+	}))
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	ch := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(ch))
+
+	svc.UseAutoAcceptor(func(metadata Metadata) (Opt, bool) {
+		require.Equal(t, Carol, metadata.Properties()["theirDID"])
+
+		return WithPresentation(&Presentation{Comment: "auto"}), true
+	})
+
+	t.Run("Request accepted by the auto acceptor", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Carol).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				require.Equal(t, PresentationMsgType, msg.Type())
+
+				return nil
+			})
+
+		_, err = svc.HandleInbound(randomInboundMessage(RequestPresentationMsgType), Alice, Carol)
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+		case <-ch:
+			t.Error("action event triggered for the auto accepted request")
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Request not auto accepted for the connection", func(t *testing.T) {
+		_, err = svc.HandleInbound(randomInboundMessage(RequestPresentationMsgType), Alice, Bob)
+		require.NoError(t, err)
+
+		select {
+		case action := <-ch:
+			require.Equal(t, RequestPresentationMsgType, action.Message.Type())
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/problemcode"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 )
 
@@ -34,8 +35,8 @@ const (
 
 const (
 	// error codes.
-	codeInternalError = "internal"
-	codeRejectedError = "rejected"
+	codeInternalError = model.CodeInternal
+	codeRejectedError = model.CodeRejected
 
 	jsonThread = "~thread"
 )
@@ -108,6 +109,13 @@ func (s *abandoned) Execute(md *metaData) (state, stateAction, error) {
 		code = model.Code{Code: codeRejectedError}
 	}
 
+	// the expired actions and the typed errors (e.g. an invalid proof) have their own codes
+	if errors.Is(md.err, ErrActionExpired) {
+		code = model.Code{Code: model.CodeRequestTime}
+	} else if c := problemcode.Of(md.err); c != "" {
+		code = model.Code{Code: c}
+	}
+
 	thID, err := md.Msg.ThreadID()
 	if err != nil {
 		return nil, nil, fmt.Errorf("threadID: %w", err)
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 )
 
@@ -132,6 +133,42 @@ func TestAbandoning_Execute(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("Typed errors", func(t *testing.T) {
+		tests := []struct {
+			err  error
+			code string
+		}{
+			{err: fmt.Errorf("save: %w", verifiable.ErrProofInvalid), code: model.CodeTrustCrypto},
+			{err: customError{error: verifiable.ErrExpired}, code: model.CodeTrust},
+			{err: customError{error: ErrActionExpired}, code: model.CodeRequestTime},
+		}
+
+		for _, tc := range tests {
+			md := &metaData{err: tc.err}
+			md.Msg = service.NewDIDCommMsgMap(struct{}{})
+			require.NoError(t, md.Msg.SetID(uuid.New().String()))
+
+			_, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+
+			messenger := serviceMocks.NewMockMessenger(ctrl)
+			messenger.EXPECT().
+				ReplyToNested(gomock.Any(), gomock.Any()).
+				Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+					r := &model.ProblemReport{}
+					require.NoError(t, msg.Decode(r))
+					require.Equal(t, tc.code, r.Description.Code)
+
+					return nil
+				})
+
+			require.NoError(t, action(messenger))
+			ctrl.Finish()
+		}
+	})
+
 	t.Run("No error code", func(t *testing.T) {
 		md := &metaData{}
 		md.Msg = service.NewDIDCommMsgMap(struct{}{})
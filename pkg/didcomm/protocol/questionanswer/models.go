@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Question is sent to ask the other agent (typically its human user) to pick one of the valid responses.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0113-question-answer#question-message-type
+type Question struct {
+	Type           string `json:"@type,omitempty"`
+	ID             string `json:"@id,omitempty"`
+	QuestionText   string `json:"question_text,omitempty"`
+	QuestionDetail string `json:"question_detail,omitempty"`
+	// Nonce must be unique per question, it is part of the signed answer.
+	Nonce             string            `json:"nonce,omitempty"`
+	SignatureRequired bool              `json:"signature_required,omitempty"`
+	ValidResponses    []ValidResponse   `json:"valid_responses,omitempty"`
+	Timing            *decorator.Timing `json:"~timing,omitempty"`
+}
+
+// ValidResponse is one of the responses the question can be answered with.
+type ValidResponse struct {
+	Text string `json:"text,omitempty"`
+}
+
+// Answer is sent in response to the question.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0113-question-answer#answer-message-type
+type Answer struct {
+	Type              string            `json:"@type,omitempty"`
+	ID                string            `json:"@id,omitempty"`
+	Thread            *decorator.Thread `json:"~thread,omitempty"`
+	Response          string            `json:"response,omitempty"`
+	ResponseSignature *Signature        `json:"response~sig,omitempty"`
+}
+
+// Signature is the signature of the response, the signed data is the concatenation of the big-endian
+// 64bit unix epoch of the signing time, the question text, the response and the question nonce.
+type Signature struct {
+	Type string `json:"@type,omitempty"`
+	// Signature is base64URL encoded.
+	Signature string `json:"signature,omitempty"`
+	// SignedData is base64URL encoded.
+	SignedData string `json:"sig_data,omitempty"`
+	// Signers are the base58 encoded public keys of the signers.
+	Signers []string `json:"signers,omitempty"`
+}
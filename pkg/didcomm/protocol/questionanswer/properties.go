@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+const (
+	myDIDPropKey      = "myDID"
+	theirDIDPropKey   = "theirDID"
+	questionIDPropKey = "questionID"
+	responsePropKey   = "response"
+	signedPropKey     = "signed"
+)
+
+// Props are the properties of the action events fired for received questions and of the message events
+// fired for received answers.
+type Props interface {
+	MyDID() string
+	TheirDID() string
+	// QuestionID is the ID of the question, it is used to answer the question.
+	QuestionID() string
+	// Response is the response of the received answer.
+	Response() string
+	// Signed is true when the response of the received answer is signed by the other agent.
+	Signed() bool
+	All() map[string]interface{}
+}
+
+type eventProps struct {
+	myDID      string
+	theirDID   string
+	questionID string
+	response   string
+	signed     bool
+}
+
+func (e *eventProps) MyDID() string {
+	return e.myDID
+}
+
+func (e *eventProps) TheirDID() string {
+	return e.theirDID
+}
+
+func (e *eventProps) QuestionID() string {
+	return e.questionID
+}
+
+func (e *eventProps) Response() string {
+	return e.response
+}
+
+func (e *eventProps) Signed() bool {
+	return e.signed
+}
+
+// All implements EventProperties interface.
+func (e *eventProps) All() map[string]interface{} {
+	all := map[string]interface{}{
+		myDIDPropKey:      e.myDID,
+		theirDIDPropKey:   e.theirDID,
+		questionIDPropKey: e.questionID,
+	}
+
+	if e.response != "" {
+		all[responsePropKey] = e.response
+		all[signedPropKey] = e.signed
+	}
+
+	return all
+}
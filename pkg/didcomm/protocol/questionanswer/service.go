@@ -0,0 +1,498 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// Name defines the protocol name.
+	Name = "questionanswer"
+	// PIURI is the question answer protocol identifier URI.
+	PIURI = "https://didcomm.org/questionanswer/1.0"
+	// QuestionMsgType defines the protocol question message type.
+	QuestionMsgType = PIURI + "/question"
+	// AnswerMsgType defines the protocol answer message type.
+	AnswerMsgType = PIURI + "/answer"
+
+	// StateAnswered is the state ID of the events fired for received answers.
+	StateAnswered = "answered"
+
+	signatureType              = "https://didcomm.org/signature/1.0/ed25519Sha512_single"
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	timestampLen               = 8
+
+	askedKeyPrefix    = "asked_"
+	receivedKeyPrefix = "received_"
+)
+
+var (
+	// ErrQuestionExpired is returned when the question is answered after its expiration time.
+	ErrQuestionExpired = errors.New("question expired")
+	// ErrInvalidResponse is returned when the response is not one of the valid responses of the question.
+	ErrInvalidResponse = errors.New("invalid response")
+)
+
+var logger = log.New("aries-framework/questionanswer")
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+}
+
+// Opt represents an option for answering the question.
+type Opt func(opts *answerOpts)
+
+type answerOpts struct {
+	response string
+}
+
+// WithResponse sets the response of the answer, it must be one of the valid responses of the question.
+func WithResponse(response string) Opt {
+	return func(opts *answerOpts) {
+		opts.response = response
+	}
+}
+
+// record keeps the question along with the DIDs of the connection it was sent over.
+type record struct {
+	Question *Question `json:"question"`
+	MyDID    string    `json:"my_did"`
+	TheirDID string    `json:"their_did"`
+}
+
+// Service for the question answer protocol.
+type Service struct {
+	service.Action
+	service.Message
+	outbound dispatcher.Outbound
+	store    storage.Store
+	vdr      vdrapi.Registry
+	kms      kms.KeyManager
+	crypto   crypto.Crypto
+	clock    clock.Clock
+}
+
+// New returns the question answer service.
+func New(prov provider) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(Name)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	return &Service{
+		outbound: prov.OutboundDispatcher(),
+		store:    store,
+		vdr:      prov.VDRegistry(),
+		kms:      prov.KMS(),
+		crypto:   prov.Crypto(),
+		clock:    clock.Of(prov),
+	}, nil
+}
+
+// HandleInbound handles the received questions and answers. A received question triggers the action event,
+// the question is answered by continuing the action with the WithResponse option. The received answers are
+// validated against the asked question and notified to the message event subscribers.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	switch msg.Type() {
+	case QuestionMsgType:
+		return s.handleQuestion(msg, myDID, theirDID)
+	case AnswerMsgType:
+		return s.handleAnswer(msg, myDID, theirDID)
+	}
+
+	return "", fmt.Errorf("unsupported message type %s", msg.Type())
+}
+
+// HandleOutbound sends the question, the question is kept until it is answered.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if msg.Type() != QuestionMsgType {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	question := &Question{}
+
+	err := msg.Decode(question)
+	if err != nil {
+		return "", fmt.Errorf("question message decode: %w", err)
+	}
+
+	err = validateQuestion(question)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.save(askedKeyPrefix, &record{Question: question, MyDID: myDID, TheirDID: theirDID})
+	if err != nil {
+		return "", fmt.Errorf("save question: %w", err)
+	}
+
+	err = s.outbound.SendToDID(msg, myDID, theirDID)
+	if err != nil {
+		return "", fmt.Errorf("send question: %w", err)
+	}
+
+	return question.ID, nil
+}
+
+// ActionContinue answers the received question with given ID, the response is provided with WithResponse.
+// The response is signed with the key of my DID when the question requires the signature.
+func (s *Service) ActionContinue(questionID string, opt Opt) error {
+	rec, err := s.get(receivedKeyPrefix, questionID)
+	if err != nil {
+		return fmt.Errorf("get question: %w", err)
+	}
+
+	opts := &answerOpts{}
+	if opt != nil {
+		opt(opts)
+	}
+
+	question := rec.Question
+
+	if s.expired(question) {
+		return ErrQuestionExpired
+	}
+
+	if !isValidResponse(question, opts.response) {
+		return fmt.Errorf("%w: %q", ErrInvalidResponse, opts.response)
+	}
+
+	answer := &Answer{
+		Type:     AnswerMsgType,
+		ID:       uuid.New().String(),
+		Thread:   &decorator.Thread{ID: question.ID},
+		Response: opts.response,
+	}
+
+	if question.SignatureRequired {
+		answer.ResponseSignature, err = s.sign(rec.MyDID, s.signedData(question, opts.response))
+		if err != nil {
+			return fmt.Errorf("sign response: %w", err)
+		}
+	}
+
+	err = s.outbound.SendToDID(service.NewDIDCommMsgMap(answer), rec.MyDID, rec.TheirDID)
+	if err != nil {
+		return fmt.Errorf("send answer: %w", err)
+	}
+
+	return s.store.Delete(receivedKeyPrefix + questionID)
+}
+
+// ActionStop declines the received question with given ID, no answer is sent to the other agent.
+func (s *Service) ActionStop(questionID string) error {
+	if _, err := s.get(receivedKeyPrefix, questionID); err != nil {
+		return fmt.Errorf("get question: %w", err)
+	}
+
+	return s.store.Delete(receivedKeyPrefix + questionID)
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == QuestionMsgType || msgType == AnswerMsgType
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return Name
+}
+
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{PIURI}
+}
+
+func (s *Service) handleQuestion(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	aEvent := s.ActionEvent()
+	if aEvent == nil {
+		return "", errors.New("no clients are registered to handle the message")
+	}
+
+	question := &Question{}
+
+	err := msg.Decode(question)
+	if err != nil {
+		return "", fmt.Errorf("question message decode: %w", err)
+	}
+
+	err = validateQuestion(question)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.save(receivedKeyPrefix, &record{Question: question, MyDID: myDID, TheirDID: theirDID})
+	if err != nil {
+		return "", fmt.Errorf("save question: %w", err)
+	}
+
+	aEvent <- service.DIDCommAction{
+		ProtocolName: Name,
+		Message:      msg,
+		Continue: func(args interface{}) {
+			opt, _ := args.(Opt) // nolint: errcheck
+
+			if err := s.ActionContinue(question.ID, opt); err != nil {
+				logger.Errorf("answer question %s: %s", question.ID, err)
+			}
+		},
+		Stop: func(error) {
+			if err := s.ActionStop(question.ID); err != nil {
+				logger.Errorf("decline question %s: %s", question.ID, err)
+			}
+		},
+		Properties: &eventProps{myDID: myDID, theirDID: theirDID, questionID: question.ID},
+	}
+
+	return question.ID, nil
+}
+
+func (s *Service) handleAnswer(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	answer := &Answer{}
+
+	err := msg.Decode(answer)
+	if err != nil {
+		return "", fmt.Errorf("answer message decode: %w", err)
+	}
+
+	questionID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("answer thread ID: %w", err)
+	}
+
+	rec, err := s.get(askedKeyPrefix, questionID)
+	if err != nil {
+		return "", fmt.Errorf("get question: %w", err)
+	}
+
+	question := rec.Question
+
+	if s.expired(question) {
+		return "", ErrQuestionExpired
+	}
+
+	if !isValidResponse(question, answer.Response) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidResponse, answer.Response)
+	}
+
+	signed := answer.ResponseSignature != nil
+
+	if question.SignatureRequired || signed {
+		err = s.verify(theirDID, answer.ResponseSignature, s.signedData(question, answer.Response))
+		if err != nil {
+			return "", fmt.Errorf("verify response signature: %w", err)
+		}
+	}
+
+	err = s.store.Delete(askedKeyPrefix + questionID)
+	if err != nil {
+		return "", fmt.Errorf("delete question: %w", err)
+	}
+
+	props := &eventProps{
+		myDID:      myDID,
+		theirDID:   theirDID,
+		questionID: questionID,
+		response:   answer.Response,
+		signed:     signed,
+	}
+
+	for _, handler := range s.MsgEvents() {
+		handler <- service.StateMsg{
+			ProtocolName: Name,
+			Type:         service.PostState,
+			Msg:          msg,
+			StateID:      StateAnswered,
+			Properties:   props,
+		}
+	}
+
+	return msg.ID(), nil
+}
+
+func (s *Service) sign(myDID string, data []byte) (*Signature, error) {
+	doc, err := s.vdr.Resolve(myDID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", myDID, err)
+	}
+
+	for _, vm := range doc.VerificationMethod {
+		if vm.Type != ed25519VerificationKey2018 {
+			continue
+		}
+
+		kid, err := localkms.CreateKID(vm.Value, kms.ED25519Type)
+		if err != nil {
+			return nil, fmt.Errorf("create KID: %w", err)
+		}
+
+		kh, err := s.kms.Get(kid)
+		if err != nil {
+			// the key of the verification method is not managed by this agent
+			continue
+		}
+
+		signature, err := s.crypto.Sign(data, kh)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Signature{
+			Type:       signatureType,
+			Signature:  base64.URLEncoding.EncodeToString(signature),
+			SignedData: base64.URLEncoding.EncodeToString(data),
+			Signers:    []string{base58.Encode(vm.Value)},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no signing key of %s", myDID)
+}
+
+func (s *Service) verify(theirDID string, sig *Signature, expected []byte) error {
+	if sig == nil {
+		return errors.New("signature is required")
+	}
+
+	if sig.Type != signatureType {
+		return fmt.Errorf("unsupported signature type %s", sig.Type)
+	}
+
+	if len(sig.Signers) != 1 {
+		return errors.New("exactly one signer is expected")
+	}
+
+	data, err := base64.URLEncoding.DecodeString(sig.SignedData)
+	if err != nil {
+		return fmt.Errorf("decode signed data: %w", err)
+	}
+
+	// the signing time is not checked, the question expiration applies instead
+	if len(data) < timestampLen || !bytes.Equal(data[timestampLen:], expected[timestampLen:]) {
+		return errors.New("signed data does not match the question and the response")
+	}
+
+	signature, err := base64.URLEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	doc, err := s.vdr.Resolve(theirDID)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", theirDID, err)
+	}
+
+	signer := base58.Decode(sig.Signers[0])
+
+	for _, vm := range doc.VerificationMethod {
+		if vm.Type != ed25519VerificationKey2018 || !bytes.Equal(vm.Value, signer) {
+			continue
+		}
+
+		if !ed25519.Verify(signer, data, signature) {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("signer is not a key of %s", theirDID)
+}
+
+func (s *Service) save(prefix string, rec *record) error {
+	src, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Put(prefix+rec.Question.ID, src)
+}
+
+func (s *Service) get(prefix, questionID string) (*record, error) {
+	src, err := s.store.Get(prefix + questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &record{}
+	if err := json.Unmarshal(src, rec); err != nil {
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+func validateQuestion(question *Question) error {
+	if question.ID == "" {
+		return errors.New("question: id is mandatory")
+	}
+
+	if question.QuestionText == "" {
+		return errors.New("question: question text is mandatory")
+	}
+
+	if question.Nonce == "" {
+		return errors.New("question: nonce is mandatory")
+	}
+
+	if len(question.ValidResponses) == 0 {
+		return errors.New("question: valid responses are mandatory")
+	}
+
+	return nil
+}
+
+func isValidResponse(question *Question, response string) bool {
+	for _, valid := range question.ValidResponses {
+		if valid.Text == response {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Service) expired(question *Question) bool {
+	return question.Timing != nil && !question.Timing.ExpiresTime.IsZero() &&
+		s.clock.Now().After(question.Timing.ExpiresTime)
+}
+
+// signedData returns the data signed by the responder: the signing time followed by the question text,
+// the response and the question nonce.
+func (s *Service) signedData(question *Question, response string) []byte {
+	data := make([]byte, timestampLen)
+	binary.BigEndian.PutUint64(data, uint64(s.clock.Now().Unix()))
+
+	data = append(data, question.QuestionText...)
+	data = append(data, response...)
+
+	return append(data, question.Nonce...)
+}
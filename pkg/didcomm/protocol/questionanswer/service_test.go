@@ -0,0 +1,473 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	aliceDID = "did:example:alice"
+	bobDID   = "did:example:bob"
+)
+
+type agent struct {
+	svc    *Service
+	kms    kms.KeyManager
+	doc    *did.Doc
+	events chan service.StateMsg
+	action chan service.DIDCommAction
+}
+
+// newAgents returns alice (asking the questions) and bob (answering them), the messages sent by
+// the agents are delivered to each other.
+func newAgents(t *testing.T) (*agent, *agent) {
+	t.Helper()
+
+	alice := newAgent(t, aliceDID)
+	bob := newAgent(t, bobDID)
+
+	docs := map[string]*did.Doc{aliceDID: alice.doc, bobDID: bob.doc}
+	registry := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, _ ...vdrapi.ResolveOpts) (*did.Doc, error) {
+			doc, ok := docs[didID]
+			if !ok {
+				return nil, errors.New("DID not found")
+			}
+
+			return doc, nil
+		},
+	}
+
+	for _, a := range []*agent{alice, bob} {
+		var err error
+
+		a.svc, err = New(&mockprovider.Provider{
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: deliver(t, map[string]*agent{aliceDID: alice, bobDID: bob}),
+			},
+			StorageProviderValue: mockstorage.NewMockStoreProvider(),
+			VDRegistryValue:      registry,
+			KMSValue:             a.kms,
+			CryptoValue:          newCrypto(t),
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, a.svc.RegisterMsgEvent(a.events))
+		require.NoError(t, a.svc.RegisterActionEvent(a.action))
+	}
+
+	return alice, bob
+}
+
+func newAgent(t *testing.T, didID string) *agent {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://test/key/uri",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	_, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	return &agent{
+		kms: km,
+		doc: &did.Doc{
+			ID: didID,
+			VerificationMethod: []did.VerificationMethod{{
+				ID:         didID + "#key-1",
+				Type:       ed25519VerificationKey2018,
+				Controller: didID,
+				Value:      pubKey,
+			}},
+		},
+		events: make(chan service.StateMsg, 1),
+		action: make(chan service.DIDCommAction, 1),
+	}
+}
+
+func newCrypto(t *testing.T) *tinkcrypto.Crypto {
+	t.Helper()
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	return c
+}
+
+func deliver(t *testing.T, agents map[string]*agent) func(msg interface{}, myDID, theirDID string) error {
+	return func(msg interface{}, myDID, theirDID string) error {
+		raw, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		didCommMsg, err := service.ParseDIDCommMsgMap(raw)
+		require.NoError(t, err)
+
+		_, err = agents[theirDID].svc.HandleInbound(didCommMsg, theirDID, myDID)
+		require.NoError(t, err)
+
+		return nil
+	}
+}
+
+func newQuestion() *Question {
+	return &Question{
+		Type:           QuestionMsgType,
+		ID:             uuid.New().String(),
+		QuestionText:   "Alice, are you on the phone with Bob from Faber Bank right now?",
+		QuestionDetail: "This is optional fine-print giving context to the question and its various answers.",
+		Nonce:          uuid.New().String(),
+		ValidResponses: []ValidResponse{{Text: "Yes, it's me"}, {Text: "No, that's not me!"}},
+	}
+}
+
+func TestService_Expired(t *testing.T) {
+	clock := mockclock.New(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	svc, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		ClockValue:           clock,
+	})
+	require.NoError(t, err)
+
+	question := newQuestion()
+	require.False(t, svc.expired(question))
+
+	question.Timing = &decorator.Timing{ExpiresTime: clock.Now().Add(time.Minute)}
+	require.False(t, svc.expired(question))
+
+	clock.Add(2 * time.Minute)
+	require.True(t, svc.expired(question))
+}
+
+func TestService_SignedData(t *testing.T) {
+	clock := mockclock.New(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	svc, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		ClockValue:           clock,
+	})
+	require.NoError(t, err)
+
+	question := newQuestion()
+
+	// the signing time is told by the clock
+	data := svc.signedData(question, "Yes, it's me")
+	require.Equal(t, uint64(clock.Now().Unix()), binary.BigEndian.Uint64(data[:timestampLen]))
+
+	clock.Add(time.Hour)
+
+	later := svc.signedData(question, "Yes, it's me")
+	require.Equal(t, uint64(clock.Now().Unix()), binary.BigEndian.Uint64(later[:timestampLen]))
+	require.Equal(t, data[timestampLen:], later[timestampLen:])
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+		require.NoError(t, err)
+		require.Equal(t, Name, svc.Name())
+		require.Equal(t, []string{PIURI}, svc.Protocols())
+		require.True(t, svc.Accept(QuestionMsgType))
+		require.True(t, svc.Accept(AnswerMsgType))
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("test error"),
+		}})
+		require.EqualError(t, err, "open store: test error")
+	})
+}
+
+func TestService_QuestionAnswer(t *testing.T) {
+	t.Run("answers the question with the signed response", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		question := newQuestion()
+		question.SignatureRequired = true
+
+		id, err := alice.svc.HandleOutbound(service.NewDIDCommMsgMap(question), aliceDID, bobDID)
+		require.NoError(t, err)
+		require.Equal(t, question.ID, id)
+
+		action := <-bob.action
+		require.Equal(t, Name, action.ProtocolName)
+
+		props, ok := action.Properties.(Props)
+		require.True(t, ok)
+		require.Equal(t, question.ID, props.QuestionID())
+		require.Equal(t, bobDID, props.MyDID())
+		require.Equal(t, aliceDID, props.TheirDID())
+
+		action.Continue(WithResponse("Yes, it's me"))
+
+		event := <-alice.events
+		require.Equal(t, StateAnswered, event.StateID)
+
+		props, ok = event.Properties.(Props)
+		require.True(t, ok)
+		require.Equal(t, question.ID, props.QuestionID())
+		require.Equal(t, "Yes, it's me", props.Response())
+		require.True(t, props.Signed())
+		require.Equal(t, map[string]interface{}{
+			myDIDPropKey:      aliceDID,
+			theirDIDPropKey:   bobDID,
+			questionIDPropKey: question.ID,
+			responsePropKey:   "Yes, it's me",
+			signedPropKey:     true,
+		}, props.All())
+
+		_, err = alice.svc.get(askedKeyPrefix, question.ID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = bob.svc.get(receivedKeyPrefix, question.ID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("answers the question without the signature", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		question := newQuestion()
+
+		_, err := alice.svc.HandleOutbound(service.NewDIDCommMsgMap(question), aliceDID, bobDID)
+		require.NoError(t, err)
+
+		<-bob.action
+		require.NoError(t, bob.svc.ActionContinue(question.ID, WithResponse("No, that's not me!")))
+
+		event := <-alice.events
+		props, ok := event.Properties.(Props)
+		require.True(t, ok)
+		require.Equal(t, "No, that's not me!", props.Response())
+		require.False(t, props.Signed())
+	})
+
+	t.Run("declines the question", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		question := newQuestion()
+
+		_, err := alice.svc.HandleOutbound(service.NewDIDCommMsgMap(question), aliceDID, bobDID)
+		require.NoError(t, err)
+
+		action := <-bob.action
+		action.Stop(nil)
+
+		err = bob.svc.ActionContinue(question.ID, WithResponse("Yes, it's me"))
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		err = bob.svc.ActionStop(question.ID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		question := newQuestion()
+
+		_, err := alice.svc.HandleOutbound(service.NewDIDCommMsgMap(question), aliceDID, bobDID)
+		require.NoError(t, err)
+
+		<-bob.action
+
+		err = bob.svc.ActionContinue(question.ID, WithResponse("Maybe"))
+		require.True(t, errors.Is(err, ErrInvalidResponse))
+
+		err = bob.svc.ActionContinue(question.ID, nil)
+		require.True(t, errors.Is(err, ErrInvalidResponse))
+	})
+
+	t.Run("expired question", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		question := newQuestion()
+		question.Timing = &decorator.Timing{ExpiresTime: time.Now().Add(-time.Minute)}
+
+		_, err := alice.svc.HandleOutbound(service.NewDIDCommMsgMap(question), aliceDID, bobDID)
+		require.NoError(t, err)
+
+		<-bob.action
+
+		err = bob.svc.ActionContinue(question.ID, WithResponse("Yes, it's me"))
+		require.True(t, errors.Is(err, ErrQuestionExpired))
+	})
+
+	t.Run("no signing key", func(t *testing.T) {
+		alice, bob := newAgents(t)
+		bob.doc.VerificationMethod[0].Value = alice.doc.VerificationMethod[0].Value
+
+		question := newQuestion()
+		question.SignatureRequired = true
+
+		_, err := alice.svc.HandleOutbound(service.NewDIDCommMsgMap(question), aliceDID, bobDID)
+		require.NoError(t, err)
+
+		<-bob.action
+
+		err = bob.svc.ActionContinue(question.ID, WithResponse("Yes, it's me"))
+		require.EqualError(t, err, "sign response: no signing key of did:example:bob")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("unsupported message type", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		_, err := alice.svc.HandleInbound(service.NewDIDCommMsgMap(&Question{Type: "unknown"}), aliceDID, bobDID)
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+
+	t.Run("no clients are registered", func(t *testing.T) {
+		_, bob := newAgents(t)
+		require.NoError(t, bob.svc.UnregisterActionEvent(bob.action))
+
+		_, err := bob.svc.HandleInbound(service.NewDIDCommMsgMap(newQuestion()), bobDID, aliceDID)
+		require.EqualError(t, err, "no clients are registered to handle the message")
+	})
+
+	t.Run("invalid question", func(t *testing.T) {
+		_, bob := newAgents(t)
+
+		question := newQuestion()
+		question.ValidResponses = nil
+
+		_, err := bob.svc.HandleInbound(service.NewDIDCommMsgMap(question), bobDID, aliceDID)
+		require.EqualError(t, err, "question: valid responses are mandatory")
+	})
+
+	t.Run("answer to unknown question", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		_, err := alice.svc.HandleInbound(service.NewDIDCommMsgMap(&Answer{
+			Type:     AnswerMsgType,
+			ID:       uuid.New().String(),
+			Thread:   &decorator.Thread{ID: uuid.New().String()},
+			Response: "Yes, it's me",
+		}), aliceDID, bobDID)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("invalid answers", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		question := newQuestion()
+		question.SignatureRequired = true
+		question.Timing = &decorator.Timing{ExpiresTime: time.Now().Add(time.Hour)}
+
+		require.NoError(t, alice.svc.save(askedKeyPrefix, &record{Question: question, MyDID: aliceDID, TheirDID: bobDID}))
+
+		signature, err := bob.svc.sign(bobDID, bob.svc.signedData(question, "Yes, it's me"))
+		require.NoError(t, err)
+
+		newAnswer := func(response string, sig *Signature) service.DIDCommMsgMap {
+			return service.NewDIDCommMsgMap(&Answer{
+				Type:              AnswerMsgType,
+				ID:                uuid.New().String(),
+				Thread:            &decorator.Thread{ID: question.ID},
+				Response:          response,
+				ResponseSignature: sig,
+			})
+		}
+
+		_, err = alice.svc.HandleInbound(newAnswer("Maybe", signature), aliceDID, bobDID)
+		require.True(t, errors.Is(err, ErrInvalidResponse))
+
+		_, err = alice.svc.HandleInbound(newAnswer("Yes, it's me", nil), aliceDID, bobDID)
+		require.EqualError(t, err, "verify response signature: signature is required")
+
+		_, err = alice.svc.HandleInbound(newAnswer("No, that's not me!", signature), aliceDID, bobDID)
+		require.EqualError(t, err,
+			"verify response signature: signed data does not match the question and the response")
+
+		forged := *signature
+		forged.Signers = []string{base58.Encode(alice.doc.VerificationMethod[0].Value)}
+
+		_, err = alice.svc.HandleInbound(newAnswer("Yes, it's me", &forged), aliceDID, bobDID)
+		require.EqualError(t, err, "verify response signature: signer is not a key of did:example:bob")
+
+		forged = *signature
+		forged.Signature = signature.Signature[:len(signature.Signature)-4] + "AAA="
+
+		_, err = alice.svc.HandleInbound(newAnswer("Yes, it's me", &forged), aliceDID, bobDID)
+		require.EqualError(t, err, "verify response signature: invalid signature")
+
+		forged = *signature
+		forged.Type = "unknown"
+
+		_, err = alice.svc.HandleInbound(newAnswer("Yes, it's me", &forged), aliceDID, bobDID)
+		require.EqualError(t, err, "verify response signature: unsupported signature type unknown")
+
+		// the valid answer is accepted once
+		_, err = alice.svc.HandleInbound(newAnswer("Yes, it's me", signature), aliceDID, bobDID)
+		require.NoError(t, err)
+		require.Equal(t, StateAnswered, (<-alice.events).StateID)
+
+		_, err = alice.svc.HandleInbound(newAnswer("Yes, it's me", signature), aliceDID, bobDID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
+
+func TestService_HandleOutbound(t *testing.T) {
+	t.Run("unsupported message type", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		_, err := alice.svc.HandleOutbound(service.NewDIDCommMsgMap(&Answer{Type: AnswerMsgType}), aliceDID, bobDID)
+		require.EqualError(t, err, "unsupported message type "+AnswerMsgType)
+	})
+
+	t.Run("invalid question", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		for _, question := range []*Question{
+			{Type: QuestionMsgType},
+			{Type: QuestionMsgType, ID: "id"},
+			{Type: QuestionMsgType, ID: "id", QuestionText: "text"},
+		} {
+			_, err := alice.svc.HandleOutbound(service.NewDIDCommMsgMap(question), aliceDID, bobDID)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "is mandatory")
+		}
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:    mockstorage.NewMockStoreProvider(),
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{SendErr: errors.New("test error")},
+		})
+		require.NoError(t, err)
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(newQuestion()), aliceDID, bobDID)
+		require.EqualError(t, err, "send question: test error")
+	})
+}
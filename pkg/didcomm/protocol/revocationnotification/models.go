@@ -0,0 +1,26 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revocationnotification
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Revoke is sent by the issuer to notify the holder that a credential was revoked.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0721-revocation-notification-v2#revoke
+type Revoke struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+	// RevocationFormat identifies the revocation mechanism the credential ID refers to (e.g. "indy-anoncreds").
+	RevocationFormat string `json:"revocation_format,omitempty"`
+	// CredentialID identifies the revoked credential.
+	CredentialID string `json:"credential_id,omitempty"`
+	// ThreadID is the thread ID of the issue-credential protocol instance the credential was issued in.
+	ThreadID string `json:"thread_id,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
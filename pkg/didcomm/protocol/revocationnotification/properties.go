@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revocationnotification
+
+const (
+	myDIDPropKey            = "myDID"
+	theirDIDPropKey         = "theirDID"
+	credentialIDPropKey     = "credentialID"
+	threadIDPropKey         = "threadID"
+	revocationFormatPropKey = "revocationFormat"
+	revokedPropKey          = "credentialRevoked"
+)
+
+// Props are the properties of the events fired for received revoke messages.
+type Props interface {
+	MyDID() string
+	TheirDID() string
+	// CredentialID of the revoked credential.
+	CredentialID() string
+	// ThreadID of the issue-credential protocol instance the credential was issued in.
+	ThreadID() string
+	RevocationFormat() string
+	// Revoked is true if the credential was found in the credential store and marked as revoked.
+	Revoked() bool
+	All() map[string]interface{}
+}
+
+type eventProps struct {
+	myDID        string
+	theirDID     string
+	credentialID string
+	threadID     string
+	format       string
+	revoked      bool
+}
+
+func (e *eventProps) MyDID() string {
+	return e.myDID
+}
+
+func (e *eventProps) TheirDID() string {
+	return e.theirDID
+}
+
+func (e *eventProps) CredentialID() string {
+	return e.credentialID
+}
+
+func (e *eventProps) ThreadID() string {
+	return e.threadID
+}
+
+func (e *eventProps) RevocationFormat() string {
+	return e.format
+}
+
+func (e *eventProps) Revoked() bool {
+	return e.revoked
+}
+
+// All implements EventProperties interface.
+func (e *eventProps) All() map[string]interface{} {
+	return map[string]interface{}{
+		myDIDPropKey:            e.myDID,
+		theirDIDPropKey:         e.theirDID,
+		credentialIDPropKey:     e.credentialID,
+		threadIDPropKey:         e.threadID,
+		revocationFormatPropKey: e.format,
+		revokedPropKey:          e.revoked,
+	}
+}
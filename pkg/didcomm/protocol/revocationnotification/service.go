@@ -0,0 +1,156 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revocationnotification
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const (
+	// Name defines the protocol name.
+	Name = "revocationnotification"
+	// PIURI is the revocation notification protocol identifier URI.
+	PIURI = "https://didcomm.org/revocation_notification/2.0"
+	// RevokeMsgType defines the protocol revoke message type.
+	RevokeMsgType = PIURI + "/revoke"
+
+	// StateRevoked is the state ID of the events fired for revoke messages.
+	StateRevoked = "revoked"
+)
+
+var logger = log.New("aries-framework/revocationnotification")
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+}
+
+// Opt represents an option for the revocation notification service.
+type Opt func(s *Service)
+
+// WithCredentialStore marks the credentials stored in given store as revoked when a notification is received.
+func WithCredentialStore(store verifiable.Store) Opt {
+	return func(s *Service) {
+		s.credentials = store
+	}
+}
+
+// Service for the revocation notification protocol.
+type Service struct {
+	service.Action
+	service.Message
+	outbound    dispatcher.Outbound
+	credentials verifiable.Store
+}
+
+// New returns the revocation notification service.
+func New(prov provider, opts ...Opt) (*Service, error) {
+	svc := &Service{
+		outbound: prov.OutboundDispatcher(),
+	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc, nil
+}
+
+// HandleInbound handles inbound revoke messages: it marks the credential as revoked (if a credential store is
+// configured) and notifies the message event subscribers.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	revoke := &Revoke{}
+
+	err := msg.Decode(revoke)
+	if err != nil {
+		return "", fmt.Errorf("revoke message decode: %w", err)
+	}
+
+	if revoke.CredentialID == "" {
+		return "", errors.New("revoke message: credential id is mandatory")
+	}
+
+	revoked := false
+
+	if s.credentials != nil {
+		if err = s.credentials.MarkCredentialRevoked(revoke.CredentialID); err != nil {
+			// the holder may not have stored the credential, this is not fatal for the notification
+			logger.Warnf("mark credential %s revoked: %s", revoke.CredentialID, err)
+		} else {
+			revoked = true
+		}
+	}
+
+	s.sendMsgEvents(msg, &eventProps{
+		myDID:        myDID,
+		theirDID:     theirDID,
+		credentialID: revoke.CredentialID,
+		threadID:     revoke.ThreadID,
+		format:       revoke.RevocationFormat,
+		revoked:      revoked,
+	})
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound sends the revoke message to the holder.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if msg.Type() != RevokeMsgType {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	revoke := &Revoke{}
+
+	err := msg.Decode(revoke)
+	if err != nil {
+		return "", fmt.Errorf("revoke message decode: %w", err)
+	}
+
+	if revoke.CredentialID == "" {
+		return "", errors.New("revoke message: credential id is mandatory")
+	}
+
+	err = s.outbound.SendToDID(msg, myDID, theirDID)
+	if err != nil {
+		return "", fmt.Errorf("send revoke message: %w", err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == RevokeMsgType
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return Name
+}
+
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{PIURI}
+}
+
+// sendMsgEvents triggers the message events.
+func (s *Service) sendMsgEvents(msg service.DIDCommMsg, props *eventProps) {
+	for _, handler := range s.MsgEvents() {
+		handler <- service.StateMsg{
+			ProtocolName: Name,
+			Type:         service.PostState,
+			Msg:          msg,
+			StateID:      StateRevoked,
+			Properties:   props,
+		}
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revocationnotification
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+const (
+	myDID        = "did:example:holder"
+	theirDID     = "did:example:issuer"
+	credentialID = "http://example.edu/credentials/1872"
+)
+
+func TestNew(t *testing.T) {
+	svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{}})
+	require.NoError(t, err)
+	require.Equal(t, Name, svc.Name())
+	require.Equal(t, []string{PIURI}, svc.Protocols())
+	require.True(t, svc.Accept(RevokeMsgType))
+	require.False(t, svc.Accept("unknown"))
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("fires event and marks the credential revoked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := verifiableStoreMocks.NewMockStore(ctrl)
+		store.EXPECT().MarkCredentialRevoked(credentialID).Return(nil)
+
+		svc, err := New(&mockprovider.Provider{}, WithCredentialStore(store))
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		msg := service.NewDIDCommMsgMap(&Revoke{
+			Type:             RevokeMsgType,
+			ID:               "revoke-id",
+			RevocationFormat: "indy-anoncreds",
+			CredentialID:     credentialID,
+			ThreadID:         "issue-thread-id",
+		})
+
+		id, err := svc.HandleInbound(msg, myDID, theirDID)
+		require.NoError(t, err)
+		require.Equal(t, "revoke-id", id)
+
+		select {
+		case event := <-events:
+			require.Equal(t, Name, event.ProtocolName)
+			require.Equal(t, service.PostState, event.Type)
+			require.Equal(t, StateRevoked, event.StateID)
+
+			props, ok := event.Properties.(Props)
+			require.True(t, ok)
+			require.Equal(t, myDID, props.MyDID())
+			require.Equal(t, theirDID, props.TheirDID())
+			require.Equal(t, credentialID, props.CredentialID())
+			require.Equal(t, "issue-thread-id", props.ThreadID())
+			require.Equal(t, "indy-anoncreds", props.RevocationFormat())
+			require.True(t, props.Revoked())
+			require.Equal(t, credentialID, props.All()[credentialIDPropKey])
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	})
+
+	t.Run("fires event when the credential cannot be marked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := verifiableStoreMocks.NewMockStore(ctrl)
+		store.EXPECT().MarkCredentialRevoked(credentialID).Return(errors.New("not found"))
+
+		svc, err := New(&mockprovider.Provider{}, WithCredentialStore(store))
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Revoke{
+			Type:         RevokeMsgType,
+			CredentialID: credentialID,
+		}), myDID, theirDID)
+		require.NoError(t, err)
+
+		event := <-events
+		require.False(t, event.Properties.(Props).Revoked())
+	})
+
+	t.Run("without credential store", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Revoke{
+			Type:         RevokeMsgType,
+			CredentialID: credentialID,
+		}), myDID, theirDID)
+		require.NoError(t, err)
+
+		event := <-events
+		require.False(t, event.Properties.(Props).Revoked())
+	})
+
+	t.Run("missing credential ID", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Revoke{Type: RevokeMsgType}), myDID, theirDID)
+		require.EqualError(t, err, "revoke message: credential id is mandatory")
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{
+			"@type":         RevokeMsgType,
+			"credential_id": []int{1},
+		}, myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "revoke message decode")
+	})
+}
+
+func TestService_HandleOutbound(t *testing.T) {
+	t.Run("sends the revoke message", func(t *testing.T) {
+		var sent interface{}
+
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, my, their string) error {
+				require.Equal(t, theirDID, my)
+				require.Equal(t, myDID, their)
+
+				sent = msg
+
+				return nil
+			},
+		}})
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&Revoke{
+			Type:         RevokeMsgType,
+			ID:           "revoke-id",
+			CredentialID: credentialID,
+		})
+
+		id, err := svc.HandleOutbound(msg, theirDID, myDID)
+		require.NoError(t, err)
+		require.Equal(t, "revoke-id", id)
+		require.Equal(t, msg, sent)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			SendErr: errors.New("send error"),
+		}})
+		require.NoError(t, err)
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Revoke{
+			Type:         RevokeMsgType,
+			CredentialID: credentialID,
+		}), theirDID, myDID)
+		require.EqualError(t, err, "send revoke message: send error")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Revoke{Type: "unknown"}), theirDID, myDID)
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+
+	t.Run("missing credential ID", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Revoke{Type: RevokeMsgType}), theirDID, myDID)
+		require.EqualError(t, err, "revoke message: credential id is mandatory")
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleOutbound(service.DIDCommMsgMap{
+			"@type":         RevokeMsgType,
+			"credential_id": []int{1},
+		}, theirDID, myDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "revoke message decode")
+	})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import "time"
+
+const (
+	myDIDPropKey    = "myDID"
+	theirDIDPropKey = "theirDID"
+	pingIDPropKey   = "pingID"
+	latencyPropKey  = "latency"
+)
+
+// Props are the properties of the events fired for received ping responses.
+type Props interface {
+	MyDID() string
+	TheirDID() string
+	// PingID is the ID of the answered ping.
+	PingID() string
+	// Latency is the round-trip time of the ping.
+	Latency() time.Duration
+	All() map[string]interface{}
+}
+
+type eventProps struct {
+	myDID    string
+	theirDID string
+	pingID   string
+	latency  time.Duration
+}
+
+func (e *eventProps) MyDID() string {
+	return e.myDID
+}
+
+func (e *eventProps) TheirDID() string {
+	return e.theirDID
+}
+
+func (e *eventProps) PingID() string {
+	return e.pingID
+}
+
+func (e *eventProps) Latency() time.Duration {
+	return e.latency
+}
+
+// All implements EventProperties interface.
+func (e *eventProps) All() map[string]interface{} {
+	return map[string]interface{}{
+		myDIDPropKey:    e.myDID,
+		theirDIDPropKey: e.theirDID,
+		pingIDPropKey:   e.pingID,
+		latencyPropKey:  e.latency,
+	}
+}
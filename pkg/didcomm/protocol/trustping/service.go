@@ -0,0 +1,241 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const (
+	// Name defines the protocol name.
+	Name = "trustping"
+	// PIURI is the trust ping protocol identifier URI.
+	PIURI = "https://didcomm.org/trust_ping/1.0"
+	// PingMsgType defines the protocol ping message type.
+	PingMsgType = PIURI + "/ping"
+	// PingResponseMsgType defines the protocol ping response message type.
+	PingResponseMsgType = PIURI + "/ping_response"
+
+	// StateResponded is the state ID of the events fired for received ping responses.
+	StateResponded = "responded"
+
+	// pings not answered within pendingTTL are forgotten.
+	pendingTTL = 10 * time.Minute
+)
+
+// ErrNoResponse is returned when the ping is not answered in time.
+var ErrNoResponse = errors.New("no ping response")
+
+var logger = log.New("aries-framework/trustping")
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+}
+
+type pendingPing struct {
+	sentTime time.Time
+	response chan time.Duration
+}
+
+// Service for the trust ping protocol.
+type Service struct {
+	service.Action
+	service.Message
+	outbound dispatcher.Outbound
+	pending  map[string]*pendingPing
+	lock     sync.Mutex
+}
+
+// New returns the trust ping service.
+func New(prov provider) (*Service, error) {
+	return &Service{
+		outbound: prov.OutboundDispatcher(),
+		pending:  map[string]*pendingPing{},
+	}, nil
+}
+
+// Ping sends a ping requesting a response to theirDID and waits for the response.
+// It returns the round-trip latency, or ErrNoResponse if no response is received within the timeout.
+func (s *Service) Ping(myDID, theirDID string, timeout time.Duration) (time.Duration, error) {
+	responseRequested := true
+
+	ping := &Ping{
+		Type:              PingMsgType,
+		ID:                uuid.New().String(),
+		ResponseRequested: &responseRequested,
+	}
+
+	response := make(chan time.Duration, 1)
+
+	s.track(ping.ID, response)
+	defer s.untrack(ping.ID)
+
+	err := s.outbound.SendToDID(service.NewDIDCommMsgMap(ping), myDID, theirDID)
+	if err != nil {
+		return 0, fmt.Errorf("send ping: %w", err)
+	}
+
+	select {
+	case latency := <-response:
+		return latency, nil
+	case <-time.After(timeout):
+		return 0, ErrNoResponse
+	}
+}
+
+// HandleInbound answers the pings requesting a response and notifies the message event subscribers of the
+// ping responses, with the round-trip latency of the pings sent by this agent.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	switch msg.Type() {
+	case PingMsgType:
+		return s.handlePing(msg, myDID, theirDID)
+	case PingResponseMsgType:
+		return s.handlePingResponse(msg, myDID, theirDID)
+	}
+
+	return "", fmt.Errorf("unsupported message type %s", msg.Type())
+}
+
+// HandleOutbound sends the ping message. Responses to the ping are notified to the message event subscribers.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if msg.Type() != PingMsgType {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	ping := &Ping{}
+
+	err := msg.Decode(ping)
+	if err != nil {
+		return "", fmt.Errorf("ping message decode: %w", err)
+	}
+
+	if responseRequested(ping) {
+		s.track(msg.ID(), nil)
+	}
+
+	err = s.outbound.SendToDID(msg, myDID, theirDID)
+	if err != nil {
+		s.untrack(msg.ID())
+
+		return "", fmt.Errorf("send ping: %w", err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == PingMsgType || msgType == PingResponseMsgType
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return Name
+}
+
+// Protocols returns the identifiers (PIURIs) of the protocols handled by the service.
+func (s *Service) Protocols() []string {
+	return []string{PIURI}
+}
+
+func (s *Service) handlePing(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	ping := &Ping{}
+
+	err := msg.Decode(ping)
+	if err != nil {
+		return "", fmt.Errorf("ping message decode: %w", err)
+	}
+
+	if !responseRequested(ping) {
+		return msg.ID(), nil
+	}
+
+	err = s.outbound.SendToDID(&PingResponse{
+		Type:   PingResponseMsgType,
+		ID:     uuid.New().String(),
+		Thread: &decorator.Thread{ID: msg.ID()},
+	}, myDID, theirDID)
+	if err != nil {
+		return "", fmt.Errorf("send ping response: %w", err)
+	}
+
+	return msg.ID(), nil
+}
+
+func (s *Service) handlePingResponse(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	pingID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("ping response thread ID: %w", err)
+	}
+
+	s.lock.Lock()
+	pending, ok := s.pending[pingID]
+	delete(s.pending, pingID)
+	s.lock.Unlock()
+
+	if !ok {
+		logger.Debugf("ignoring ping response to unknown ping %s", pingID)
+
+		return msg.ID(), nil
+	}
+
+	latency := time.Since(pending.sentTime)
+
+	if pending.response != nil {
+		pending.response <- latency
+	}
+
+	props := &eventProps{myDID: myDID, theirDID: theirDID, pingID: pingID, latency: latency}
+
+	for _, handler := range s.MsgEvents() {
+		handler <- service.StateMsg{
+			ProtocolName: Name,
+			Type:         service.PostState,
+			Msg:          msg,
+			StateID:      StateResponded,
+			Properties:   props,
+		}
+	}
+
+	return msg.ID(), nil
+}
+
+func (s *Service) track(pingID string, response chan time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+
+	for id, p := range s.pending {
+		if now.Sub(p.sentTime) > pendingTTL {
+			delete(s.pending, id)
+		}
+	}
+
+	s.pending[pingID] = &pendingPing{sentTime: now, response: response}
+}
+
+func (s *Service) untrack(pingID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.pending, pingID)
+}
+
+func responseRequested(ping *Ping) bool {
+	return ping.ResponseRequested == nil || *ping.ResponseRequested
+}
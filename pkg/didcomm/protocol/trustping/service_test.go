@@ -0,0 +1,220 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+const (
+	myDID    = "did:example:alice"
+	theirDID = "did:example:bob"
+)
+
+func TestNew(t *testing.T) {
+	svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{}})
+	require.NoError(t, err)
+	require.Equal(t, Name, svc.Name())
+	require.Equal(t, []string{PIURI}, svc.Protocols())
+	require.True(t, svc.Accept(PingMsgType))
+	require.True(t, svc.Accept(PingResponseMsgType))
+	require.False(t, svc.Accept("unknown"))
+}
+
+func TestService_Ping(t *testing.T) {
+	t.Run("returns the round-trip latency", func(t *testing.T) {
+		var svc *Service
+
+		outbound := &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				ping := &Ping{}
+				require.NoError(t, msg.(service.DIDCommMsgMap).Decode(ping))
+				require.Equal(t, PingMsgType, ping.Type)
+				require.True(t, *ping.ResponseRequested)
+
+				go func() {
+					_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&PingResponse{
+						Type:   PingResponseMsgType,
+						ID:     "response-id",
+						Thread: &decorator.Thread{ID: ping.ID},
+					}), myDID, theirDID)
+					require.NoError(t, err)
+				}()
+
+				return nil
+			},
+		}
+
+		var err error
+
+		svc, err = New(&mockprovider.Provider{OutboundDispatcherValue: outbound})
+		require.NoError(t, err)
+
+		latency, err := svc.Ping(myDID, theirDID, time.Second)
+		require.NoError(t, err)
+		require.True(t, latency > 0)
+		require.Empty(t, svc.pending)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{}})
+		require.NoError(t, err)
+
+		_, err = svc.Ping(myDID, theirDID, time.Millisecond)
+		require.True(t, errors.Is(err, ErrNoResponse))
+		require.Empty(t, svc.pending)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{SendErr: errors.New("test error")},
+		})
+		require.NoError(t, err)
+
+		_, err = svc.Ping(myDID, theirDID, time.Second)
+		require.EqualError(t, err, "send ping: test error")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("responds to ping", func(t *testing.T) {
+		sent := make(chan *PingResponse, 1)
+
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				sent <- msg.(*PingResponse)
+
+				return nil
+			},
+		}})
+		require.NoError(t, err)
+
+		id, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Ping{Type: PingMsgType, ID: "ping-id"}), myDID, theirDID)
+		require.NoError(t, err)
+		require.Equal(t, "ping-id", id)
+
+		response := <-sent
+		require.Equal(t, PingResponseMsgType, response.Type)
+		require.Equal(t, "ping-id", response.Thread.ID)
+	})
+
+	t.Run("response not requested", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(interface{}, string, string) error {
+				return errors.New("unexpected response")
+			},
+		}})
+		require.NoError(t, err)
+
+		responseRequested := false
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Ping{
+			Type:              PingMsgType,
+			ID:                "ping-id",
+			ResponseRequested: &responseRequested,
+		}), myDID, theirDID)
+		require.NoError(t, err)
+	})
+
+	t.Run("ping response error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{SendErr: errors.New("test error")},
+		})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Ping{Type: PingMsgType, ID: "ping-id"}), myDID, theirDID)
+		require.EqualError(t, err, "send ping response: test error")
+	})
+
+	t.Run("fires event for response to outbound ping", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{}})
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Ping{Type: PingMsgType, ID: "ping-id"}), myDID, theirDID)
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&PingResponse{
+			Type:   PingResponseMsgType,
+			ID:     "response-id",
+			Thread: &decorator.Thread{ID: "ping-id"},
+		}), myDID, theirDID)
+		require.NoError(t, err)
+
+		select {
+		case event := <-events:
+			require.Equal(t, Name, event.ProtocolName)
+			require.Equal(t, StateResponded, event.StateID)
+
+			props, ok := event.Properties.(Props)
+			require.True(t, ok)
+			require.Equal(t, myDID, props.MyDID())
+			require.Equal(t, theirDID, props.TheirDID())
+			require.Equal(t, "ping-id", props.PingID())
+			require.True(t, props.Latency() > 0)
+			require.Equal(t, "ping-id", props.All()[pingIDPropKey])
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	})
+
+	t.Run("ignores response to unknown ping", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{OutboundDispatcherValue: &mockdispatcher.MockOutbound{}})
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&PingResponse{
+			Type:   PingResponseMsgType,
+			ID:     "response-id",
+			Thread: &decorator.Thread{ID: "unknown"},
+		}), myDID, theirDID)
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Ping{Type: "unknown"}), myDID, theirDID)
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+}
+
+func TestService_HandleOutbound(t *testing.T) {
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&PingResponse{Type: PingResponseMsgType}), myDID, theirDID)
+		require.EqualError(t, err, "unsupported message type "+PingResponseMsgType)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{SendErr: errors.New("test error")},
+		})
+		require.NoError(t, err)
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Ping{Type: PingMsgType, ID: "ping-id"}), myDID, theirDID)
+		require.EqualError(t, err, "send ping: test error")
+		require.Empty(t, svc.pending)
+	})
+}
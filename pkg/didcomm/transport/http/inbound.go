@@ -15,10 +15,15 @@ import (
 
 	"github.com/rs/cors"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
 
+// transportName is the transport of the metrics of the received messages.
+const transportName = "http"
+
 var logger = log.New("aries-framework/http")
 
 // TODO https://github.com/hyperledger/aries-framework-go/issues/891 Support for Transport Return Route (Duplex)
@@ -29,20 +34,49 @@ var logger = log.New("aries-framework/http")
 // Arguments:
 // * 'msgHandler' is the handler function that will be executed with the inbound request payload.
 //    Users of this library must manage the handling of all inbound payloads in this function.
+//
+// The requests sent to the endpoint of a tenant (see transport.TenantEndpoint) are processed by the provider of the
+// tenant if prov is a transport.TenantProvider hosting it, and rejected with a 404 status otherwise.
 func NewInboundHandler(prov transport.Provider) (http.Handler, error) {
 	if prov == nil || prov.InboundMessageHandler() == nil {
 		logger.Errorf("Error creating a new inbound handler: message handler function is nil")
 		return nil, errors.New("creation of inbound handler failed")
 	}
 
+	transportMetrics := metrics.ForTransport(prov)
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		processPOSTRequest(w, r, prov)
+		p, ok := requestProvider(prov, r)
+		if !ok {
+			http.Error(w, "Unknown tenant", http.StatusNotFound)
+
+			return
+		}
+
+		processPOSTRequest(w, r, p, transportMetrics)
 	})
 
 	return cors.Default().Handler(handler), nil
 }
 
-func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider) {
+// requestProvider returns the provider processing the request, the provider of the tenant addressed by the request
+// if any.
+func requestProvider(prov transport.Provider, r *http.Request) (transport.Provider, bool) {
+	tenantID, ok := transport.TenantID(r.URL.Path)
+	if !ok {
+		return prov, true
+	}
+
+	tenants, ok := prov.(transport.TenantProvider)
+	if !ok {
+		return nil, false
+	}
+
+	return tenants.Tenant(tenantID)
+}
+
+func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider,
+	transportMetrics *metrics.Transport) {
 	if valid := validateHTTPMethod(w, r); !valid {
 		return
 	}
@@ -63,6 +97,7 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 	if err != nil {
 		logger.Errorf("failed to unpack msg: %s - returning Code: %d", err, http.StatusInternalServerError)
 		http.Error(w, "failed to unpack msg", http.StatusInternalServerError)
+		transportMetrics.Received(transportName, true)
 
 		return
 	}
@@ -70,6 +105,8 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 	messageHandler := prov.InboundMessageHandler()
 
 	err = messageHandler(unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
+	transportMetrics.Received(transportName, err != nil)
+
 	if err != nil {
 		// TODO https://github.com/hyperledger/aries-framework-go/issues/271 HTTP Response Codes based on errors
 		//  from service
@@ -159,13 +196,23 @@ func (i *Inbound) listenAndServe() error {
 
 // Stop the http server.
 func (i *Inbound) Stop() error {
-	if err := i.server.Shutdown(context.Background()); err != nil {
+	return i.Shutdown(context.Background())
+}
+
+// Shutdown stops the server, waiting for the messages being received until ctx is done.
+func (i *Inbound) Shutdown(ctx context.Context) error {
+	if err := i.server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("HTTP server shutdown failed: %w", err)
 	}
 
 	return nil
 }
 
+// HealthCheck checks that the server is listening at its internal address.
+func (i *Inbound) HealthCheck(ctx context.Context) error {
+	return health.Listening(i.server.Addr)(ctx)
+}
+
 // Endpoint provides the http connection details.
 func (i *Inbound) Endpoint() string {
 	// return http prefix as framework only supports http
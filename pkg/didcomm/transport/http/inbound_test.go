@@ -8,6 +8,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -15,6 +16,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -131,6 +133,72 @@ func TestInboundHandler(t *testing.T) {
 	require.NoError(t, resp.Body.Close())
 }
 
+type mockTenantProvider struct {
+	mockProvider
+	tenants map[string]transport.Provider
+}
+
+func (p *mockTenantProvider) Tenant(id string) (transport.Provider, bool) {
+	prov, ok := p.tenants[id]
+
+	return prov, ok
+}
+
+func TestInboundHandler_Tenants(t *testing.T) {
+	var received []string
+
+	tenant := &mockTenantProvider{mockProvider: mockProvider{packagerValue: &mockpackager.Packager{
+		UnpackValue: &commontransport.Envelope{Message: []byte("data"), ToDID: "alice"},
+	}}}
+
+	host := &mockTenantProvider{
+		mockProvider: mockProvider{packagerValue: &mockpackager.Packager{
+			UnpackValue: &commontransport.Envelope{Message: []byte("data"), ToDID: "host"},
+		}},
+		tenants: map[string]transport.Provider{"alice": &recordingProvider{Provider: tenant, received: &received}},
+	}
+
+	hostHandler, err := NewInboundHandler(&recordingProvider{Provider: host, received: &received})
+	require.NoError(t, err)
+
+	post := func(handler http.Handler, path string) int {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString("data"))
+		req.Header.Set("Content-Type", commContentType)
+
+		handler.ServeHTTP(rw, req)
+
+		return rw.Code
+	}
+
+	require.Equal(t, http.StatusAccepted, post(hostHandler, "/"))
+	require.Equal(t, http.StatusAccepted, post(hostHandler, "/didcomm/tenants/alice"))
+	require.Equal(t, http.StatusNotFound, post(hostHandler, "/tenants/bob"))
+	require.Equal(t, []string{"host", "alice"}, received)
+
+	// a provider not hosting tenants rejects the requests sent to a tenant
+	handler, err := NewInboundHandler(&mockProvider{packagerValue: host.packagerValue})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, post(handler, "/tenants/alice"))
+}
+
+type recordingProvider struct {
+	transport.Provider
+	received *[]string
+}
+
+func (p *recordingProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	return func(message []byte, myDID, theirDID string) error {
+		*p.received = append(*p.received, myDID)
+
+		return nil
+	}
+}
+
+func (p *recordingProvider) Tenant(id string) (transport.Provider, bool) {
+	return p.Provider.(transport.TenantProvider).Tenant(id)
+}
+
 func TestInboundTransport(t *testing.T) {
 	t.Run("test inbound transport - with host/port", func(t *testing.T) {
 		port := "26601"
@@ -159,6 +227,19 @@ func TestInboundTransport(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test inbound transport - shutdown", func(t *testing.T) {
+		inbound, err := NewInbound(":26606", "", "", "")
+		require.NoError(t, err)
+		mockPackager := &mockpackager.Packager{UnpackValue: &commontransport.Envelope{Message: []byte("data")}}
+		require.NoError(t, inbound.Start(&mockProvider{packagerValue: mockPackager}))
+		require.NoError(t, listenFor("localhost:26606", time.Second))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		require.NoError(t, inbound.Shutdown(ctx))
+	})
+
 	t.Run("test inbound transport - nil context", func(t *testing.T) {
 		inbound, err := NewInbound(":26604", "", "", "")
 		require.NoError(t, err)
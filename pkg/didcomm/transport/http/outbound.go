@@ -8,13 +8,16 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
@@ -29,7 +32,8 @@ const (
 // outboundCommHTTPOpts holds options for the HTTP transport implementation of CommTransport
 // it has an http.Client instance.
 type outboundCommHTTPOpts struct {
-	client *http.Client
+	client      *http.Client
+	retryPolicy *retry.Policy
 }
 
 // OutboundHTTPOpt is an outbound HTTP transport option.
@@ -49,6 +53,16 @@ func WithOutboundTimeout(timeout time.Duration) OutboundHTTPOpt {
 	}
 }
 
+// WithOutboundRetryPolicy option is for creating an Outbound HTTP transport retrying the POST requests with the
+// policy. The POST requests aren't idempotent: unless the policy classifies the errors itself, a request is retried
+// only if it wasn't sent (the connection to the other agent failed) or it was throttled. The retries are disabled with
+// retry.NoRetry.
+func WithOutboundRetryPolicy(policy retry.Policy) OutboundHTTPOpt {
+	return func(opts *outboundCommHTTPOpts) {
+		opts.retryPolicy = &policy
+	}
+}
+
 // WithOutboundTLSConfig option is for creating an Outbound HTTP transport using a tls.Config instance.
 func WithOutboundTLSConfig(tlsConfig *tls.Config) OutboundHTTPOpt {
 	return func(opts *outboundCommHTTPOpts) {
@@ -62,7 +76,8 @@ func WithOutboundTLSConfig(tlsConfig *tls.Config) OutboundHTTPOpt {
 
 // OutboundHTTPClient represents the Outbound HTTP transport instance.
 type OutboundHTTPClient struct {
-	client *http.Client
+	client      *http.Client
+	retryPolicy retry.Policy
 }
 
 // NewOutbound creates a new instance of Outbound HTTP transport to Post requests to other Agents.
@@ -78,20 +93,75 @@ func NewOutbound(opts ...OutboundHTTPOpt) (*OutboundHTTPClient, error) {
 		return nil, errors.New("creation of outbound transport requires an HTTP client")
 	}
 
+	retryPolicy := retry.DefaultPolicy()
+	if clOpts.retryPolicy != nil {
+		retryPolicy = *clOpts.retryPolicy
+	}
+
+	if retryPolicy.Retryable == nil {
+		retryPolicy.Retryable = retryableUnsent
+	}
+
 	cs := &OutboundHTTPClient{
-		client: clOpts.client,
+		client:      clOpts.client,
+		retryPolicy: retryPolicy,
 	}
 
 	return cs, nil
 }
 
+// retryableUnsent is the default classification of the errors of the POST requests, which aren't idempotent: only
+// the requests which weren't sent, as the connection to the other agent failed, or which were throttled are retried.
+func retryableUnsent(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status == http.StatusTooManyRequests
+	}
+
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// statusError is the error of a POST request answered with an unsuccessful HTTP status.
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
 // Start starts outbound transport.
 func (cs *OutboundHTTPClient) Start(prov transport.Provider) error {
 	return nil
 }
 
-// Send sends a2a exchange data via HTTP (client side).
+// Send sends a2a exchange data via HTTP (client side). The POST request is retried according to the retry policy of
+// the transport, see WithOutboundRetryPolicy.
 func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination) (string, error) {
+	var respData string
+
+	err := retry.Do(context.Background(), cs.retryPolicy, func() error {
+		var postErr error
+
+		respData, postErr = cs.post(data, destination)
+
+		return postErr
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return respData, nil
+}
+
+func (cs *OutboundHTTPClient) post(data []byte, destination *service.Destination) (string, error) {
 	resp, err := cs.client.Post(destination.ServiceEndpoint, commContentType, bytes.NewBuffer(data))
 	if err != nil {
 		logger.Errorf("posting DID envelope to agent failed [%s, %v]", destination.ServiceEndpoint, err)
@@ -123,8 +193,13 @@ func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination
 			logger.Errorf("didcomm failed : transport=http serviceEndpoint=%s status=%v errMsg=%s",
 				destination.ServiceEndpoint, resp.Status, respData)
 
-			return "", fmt.Errorf("received unsuccessful POST HTTP status from agent "+
-				"[%s, %v %s]", destination.ServiceEndpoint, resp.Status, respData)
+			err = &statusError{status: resp.StatusCode, err: fmt.Errorf("received unsuccessful POST HTTP status "+
+				"from agent [%s, %v %s]", destination.ServiceEndpoint, resp.Status, respData)}
+			if !retry.RetryableStatus(resp.StatusCode) {
+				err = retry.Permanent(err)
+			}
+
+			return "", err
 		}
 	}
 
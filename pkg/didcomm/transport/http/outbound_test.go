@@ -9,11 +9,19 @@ package http
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 )
 
@@ -99,6 +107,89 @@ func TestOutboundHTTPTransport(t *testing.T) {
 	require.False(t, ot.Accept("123:22"))
 }
 
+func TestOutboundHTTPTransportRetry(t *testing.T) {
+	posts := 0
+	statuses := []int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := http.StatusAccepted
+		if posts < len(statuses) {
+			status = statuses[posts]
+		}
+
+		posts++
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	retryPolicy := retry.DefaultPolicy()
+	retryPolicy.InitialInterval = time.Millisecond
+
+	send := func(t *testing.T, ot *OutboundHTTPClient, responses ...int) error {
+		t.Helper()
+
+		posts, statuses = 0, responses
+
+		_, err := ot.Send([]byte("Hello World"), prepareDestination(server.URL))
+
+		return err
+	}
+
+	t.Run("throttled requests are retried", func(t *testing.T) {
+		ot, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}), WithOutboundRetryPolicy(retryPolicy))
+		require.NoError(t, err)
+
+		require.NoError(t, send(t, ot, http.StatusTooManyRequests))
+		require.Equal(t, 2, posts)
+	})
+
+	t.Run("server errors aren't retried by default", func(t *testing.T) {
+		ot, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}), WithOutboundRetryPolicy(retryPolicy))
+		require.NoError(t, err)
+
+		err = send(t, ot, http.StatusServiceUnavailable)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "received unsuccessful POST HTTP status from agent")
+		require.Equal(t, 1, posts)
+
+		err = send(t, ot, http.StatusBadRequest)
+		require.Error(t, err)
+		require.Equal(t, 1, posts)
+	})
+
+	t.Run("policy classifying the errors", func(t *testing.T) {
+		policy := retryPolicy
+		policy.Retryable = retry.IsRetryable
+
+		ot, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}), WithOutboundRetryPolicy(policy))
+		require.NoError(t, err)
+
+		require.NoError(t, send(t, ot, http.StatusServiceUnavailable))
+		require.Equal(t, 2, posts)
+
+		// the client errors are permanent
+		require.Error(t, send(t, ot, http.StatusBadRequest))
+		require.Equal(t, 1, posts)
+	})
+
+	t.Run("retries disabled", func(t *testing.T) {
+		ot, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}), WithOutboundRetryPolicy(retry.NoRetry()))
+		require.NoError(t, err)
+
+		require.Error(t, send(t, ot, http.StatusTooManyRequests))
+		require.Equal(t, 1, posts)
+	})
+
+	t.Run("requests not sent are retried", func(t *testing.T) {
+		refused := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+
+		require.True(t, retryableUnsent(&url.Error{Op: "Post", Err: refused}))
+		require.False(t, retryableUnsent(&url.Error{Op: "Post", Err: &net.OpError{Op: "read", Err: io.EOF}}))
+		require.False(t, retryableUnsent(errors.New("unexpected EOF")))
+	})
+}
+
 func prepareDestination(endPoint string) *service.Destination {
 	return &service.Destination{
 		ServiceEndpoint: endPoint,
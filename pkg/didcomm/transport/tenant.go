@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"strings"
+)
+
+// TenantPath precedes the tenant ID in the endpoints of the tenants hosted by an agent, e.g. the endpoint of the
+// tenant "alice" of the agent "https://agent.example.com" is "https://agent.example.com/tenants/alice".
+const TenantPath = "/tenants/"
+
+// TenantProvider is a Provider hosting tenants, each tenant having its own provider. The inbound transports
+// supporting the tenants route the messages sent to the endpoint of a tenant to the provider of the tenant.
+type TenantProvider interface {
+	Provider
+	// Tenant returns the provider of the tenant, false if the tenant isn't hosted by the provider.
+	Tenant(id string) (Provider, bool)
+}
+
+// TenantEndpoint returns the endpoint of the tenant hosted by the agent with the given endpoint.
+func TenantEndpoint(endpoint, tenantID string) string {
+	return strings.TrimSuffix(endpoint, "/") + TenantPath + tenantID
+}
+
+// TenantID returns the ID of the tenant addressed by the path of an inbound request, false if the path doesn't
+// address a tenant. The path can be prefixed, e.g. by a reverse proxy.
+func TenantID(path string) (string, bool) {
+	i := strings.LastIndex(path, TenantPath)
+	if i < 0 {
+		return "", false
+	}
+
+	id := strings.TrimSuffix(path[i+len(TenantPath):], "/")
+
+	return id, id != "" && !strings.Contains(id, "/")
+}
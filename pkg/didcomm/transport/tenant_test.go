@@ -0,0 +1,36 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantEndpoint(t *testing.T) {
+	require.Equal(t, "https://agent.example.com/tenants/alice", TenantEndpoint("https://agent.example.com", "alice"))
+	require.Equal(t, "https://agent.example.com/didcomm/tenants/alice",
+		TenantEndpoint("https://agent.example.com/didcomm/", "alice"))
+}
+
+func TestTenantID(t *testing.T) {
+	for path, id := range map[string]string{
+		"/tenants/alice":         "alice",
+		"/tenants/alice/":        "alice",
+		"/didcomm/tenants/alice": "alice",
+	} {
+		tenantID, ok := TenantID(path)
+		require.True(t, ok, path)
+		require.Equal(t, id, tenantID)
+	}
+
+	for _, path := range []string{"", "/", "/didcomm", "/tenants/", "/tenants/alice/inbox"} {
+		_, ok := TenantID(path)
+		require.False(t, ok, path)
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"nhooyr.io/websocket"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
@@ -77,13 +78,24 @@ func (i *Inbound) listenAndServe() error {
 
 // Stop the http(ws) server.
 func (i *Inbound) Stop() error {
-	if err := i.server.Shutdown(context.Background()); err != nil {
+	return i.Shutdown(context.Background())
+}
+
+// Shutdown stops the server, waiting for the requests being served until ctx is done. The connections already
+// upgraded to websocket aren't waited for.
+func (i *Inbound) Shutdown(ctx context.Context) error {
+	if err := i.server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("websocket server shutdown failed: %w", err)
 	}
 
 	return nil
 }
 
+// HealthCheck checks that the server is listening at its internal address.
+func (i *Inbound) HealthCheck(ctx context.Context) error {
+	return health.Listening(i.server.Addr)(ctx)
+}
+
 // Endpoint provides the http(ws) connection details.
 func (i *Inbound) Endpoint() string {
 	return i.externalAddr
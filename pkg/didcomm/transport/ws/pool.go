@@ -14,6 +14,7 @@ import (
 	"github.com/btcsuite/btcutil/base58"
 	"nhooyr.io/websocket"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	commtransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
@@ -22,6 +23,9 @@ import (
 const (
 	// TODO configure ping request frequency.
 	pingFrequency = 30 * time.Second
+
+	// transportName is the transport of the metrics of the received messages.
+	transportName = webSocketScheme
 )
 
 type connPool struct {
@@ -29,6 +33,7 @@ type connPool struct {
 	sync.RWMutex
 	packager   commtransport.Packager
 	msgHandler transport.InboundMessageHandler
+	metrics    *metrics.Transport
 }
 
 // nolint: gochecknoglobals
@@ -42,6 +47,7 @@ func getConnPool(prov transport.Provider) *connPool {
 			connMap:    make(map[string]*websocket.Conn),
 			packager:   prov.Packager(),
 			msgHandler: prov.InboundMessageHandler(),
+			metrics:    metrics.ForTransport(prov),
 		}
 	}
 
@@ -89,6 +95,7 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 		unpackMsg, err := d.packager.UnpackMessage(message)
 		if err != nil {
 			logger.Errorf("failed to unpack msg: %v", err)
+			d.metrics.Received(transportName, true)
 
 			continue
 		}
@@ -107,6 +114,8 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 		messageHandler := d.msgHandler
 
 		err = messageHandler(unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
+		d.metrics.Received(transportName, err != nil)
+
 		if err != nil {
 			logger.Errorf("incoming msg processing failed: %v", err)
 		}
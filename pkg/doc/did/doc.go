@@ -22,7 +22,9 @@ import (
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
@@ -278,8 +280,32 @@ func (doc *Doc) UnmarshalJSON(data []byte) error {
 	return err
 }
 
+// ParseOption is an option of ParseDocument.
+type ParseOption func(opts *parseOpts)
+
+type parseOpts struct {
+	jsonLimits jsonutil.Limits
+}
+
+// WithJSONLimits sets the limits of the document JSON, jsonutil.DefaultLimits if not set.
+func WithJSONLimits(limits jsonutil.Limits) ParseOption {
+	return func(opts *parseOpts) {
+		opts.jsonLimits = limits
+	}
+}
+
 // ParseDocument creates an instance of DIDDocument by reading a JSON document from bytes.
-func ParseDocument(data []byte) (*Doc, error) {
+func ParseDocument(data []byte, opts ...ParseOption) (*Doc, error) {
+	pOpts := &parseOpts{jsonLimits: jsonutil.DefaultLimits()}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	if err := jsonutil.Check(data, pOpts.jsonLimits); err != nil {
+		return nil, err
+	}
+
 	raw := &rawDoc{}
 
 	err := json.Unmarshal(data, &raw)
@@ -928,6 +954,29 @@ func (doc *Doc) VerifyProof(suites []verifier.SignatureSuite, jsonldOpts ...json
 	return v.Verify(docBytes, append(defaultDocumentLoaderOpt, jsonldOpts...)...)
 }
 
+// VerifyProofWithRegistry verifies document proofs with the suites of the registry accepting their proof types.
+func (doc *Doc) VerifyProofWithRegistry(r *suiteregistry.Registry, jsonldOpts ...jsonld.ProcessorOpts) error {
+	if len(doc.Proof) == 0 {
+		return ErrProofNotFound
+	}
+
+	proofs := make([]map[string]interface{}, len(doc.Proof))
+
+	for i, p := range doc.Proof {
+		proofs[i] = map[string]interface{}{
+			jsonldType:  p.Type,
+			jsonldNonce: base64.RawURLEncoding.EncodeToString(p.Nonce),
+		}
+	}
+
+	suites, err := r.Verifiers(proofs)
+	if err != nil {
+		return fmt.Errorf("get suites: %w", err)
+	}
+
+	return doc.VerifyProof(suites, jsonldOpts...)
+}
+
 // VerificationMethods returns verification methods of DID Doc of certain relationship.
 // If customVerificationRelationships is empty, all verification methods are returned.
 // Public keys which are not referred by any verification method are put into special VerificationRelationshipGeneral
@@ -23,10 +23,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
@@ -217,6 +219,15 @@ func TestParseOfNull(t *testing.T) {
 	require.Contains(t, err.Error(), "document payload is not provided")
 }
 
+func TestParseDocumentJSONLimits(t *testing.T) {
+	_, err := ParseDocument([]byte(validDoc), WithJSONLimits(jsonutil.Limits{MaxArrayLength: 1}))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+
+	_, err = ParseDocument([]byte(validDoc), WithJSONLimits(jsonutil.Limits{}))
+	require.NoError(t, err)
+}
+
 func TestValidWithDocBase(t *testing.T) {
 	docs := []string{validDocWithBase}
 	for _, d := range docs {
@@ -1193,6 +1204,16 @@ func TestVerifyProof(t *testing.T) {
 		err = doc.VerifyProof([]verifier.SignatureSuite{s})
 		require.NoError(t, err)
 
+		// happy path - suites of the registry
+		err = doc.VerifyProofWithRegistry(suiteregistry.New())
+		require.NoError(t, err)
+
+		// error - registry without the suite of the proof
+		registry := suiteregistry.New()
+		registry.Register(ed25519signature2018.SignatureType, suiteregistry.Suite{})
+		err = doc.VerifyProofWithRegistry(registry)
+		require.True(t, errors.Is(err, suiteregistry.ErrUnsupported))
+
 		// error - no suites are passed, verifier is not created
 		err = doc.VerifyProof([]verifier.SignatureSuite{})
 		require.Error(t, err)
@@ -1210,6 +1231,7 @@ func TestVerifyProof(t *testing.T) {
 		require.NotNil(t, doc)
 		err = doc.VerifyProof([]verifier.SignatureSuite{s})
 		require.Equal(t, ErrProofNotFound, err)
+		require.Equal(t, ErrProofNotFound, doc.VerifyProofWithRegistry(suiteregistry.New()))
 		require.Contains(t, err.Error(), "proof not found")
 	}
 }
@@ -14,6 +14,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 )
 
 const (
@@ -233,27 +235,54 @@ func (e *JSONWebEncryption) CompactSerialize(marshal marshalFunc) (string, error
 	return fmt.Sprintf("%s.%s.%s.%s.%s", b64ProtectedHeader, b64EncryptedKey, b64IV, b64Ciphertext, b64Tag), nil
 }
 
+// deserializeOpts holds options for the JWE deserialization.
+type deserializeOpts struct {
+	jsonLimits jsonutil.Limits
+}
+
+// DeserializeOpt is the JWE deserialization option.
+type DeserializeOpt func(opts *deserializeOpts)
+
+// WithJWEJSONLimits option sets the limits of the JSON of the JWE and of its protected headers,
+// jsonutil.DefaultLimits if not set.
+func WithJWEJSONLimits(limits jsonutil.Limits) DeserializeOpt {
+	return func(opts *deserializeOpts) {
+		opts.jsonLimits = limits
+	}
+}
+
 // Deserialize deserializes the given serialized JWE into a JSONWebEncryption object.
-func Deserialize(serializedJWE string) (*JSONWebEncryption, error) {
+func Deserialize(serializedJWE string, opts ...DeserializeOpt) (*JSONWebEncryption, error) {
+	dOpts := &deserializeOpts{jsonLimits: jsonutil.DefaultLimits()}
+
+	for _, opt := range opts {
+		opt(dOpts)
+	}
+
 	if strings.HasPrefix(serializedJWE, "{") {
-		return deserializeFull(serializedJWE)
+		return deserializeFull(serializedJWE, dOpts.jsonLimits)
+	}
+
+	// the compact serialization is base64 encoded, only its size is checked before it's decoded
+	if err := jsonutil.Check([]byte(serializedJWE), jsonutil.Limits{MaxBytes: dOpts.jsonLimits.MaxBytes}); err != nil {
+		return nil, err
 	}
 
-	return deserializeCompact(serializedJWE)
+	return deserializeCompact(serializedJWE, dOpts.jsonLimits)
 }
 
-func deserializeFull(serializedJWE string) (*JSONWebEncryption, error) {
+func deserializeFull(serializedJWE string, limits jsonutil.Limits) (*JSONWebEncryption, error) {
 	rawJWE := rawJSONWebEncryption{}
 
-	err := json.Unmarshal([]byte(serializedJWE), &rawJWE)
+	err := jsonutil.Unmarshal([]byte(serializedJWE), &rawJWE, limits)
 	if err != nil {
 		return nil, err
 	}
 
-	return deserializeFromRawJWE(&rawJWE)
+	return deserializeFromRawJWE(&rawJWE, limits)
 }
 
-func deserializeCompact(serializedJWE string) (*JSONWebEncryption, error) {
+func deserializeCompact(serializedJWE string, limits jsonutil.Limits) (*JSONWebEncryption, error) {
 	parts := strings.Split(serializedJWE, ".")
 	if len(parts) != compactJWERequiredNumOfParts {
 		return nil, errWrongNumberOfCompactJWEParts
@@ -267,11 +296,11 @@ func deserializeCompact(serializedJWE string) (*JSONWebEncryption, error) {
 		B64Tag:                   parts[4],
 	}
 
-	return deserializeFromRawJWE(&rawJWE)
+	return deserializeFromRawJWE(&rawJWE, limits)
 }
 
-func deserializeFromRawJWE(rawJWE *rawJSONWebEncryption) (*JSONWebEncryption, error) {
-	protectedHeaders, unprotectedHeaders, err := deserializeAndDecodeHeaders(rawJWE)
+func deserializeFromRawJWE(rawJWE *rawJSONWebEncryption, limits jsonutil.Limits) (*JSONWebEncryption, error) {
+	protectedHeaders, unprotectedHeaders, err := deserializeAndDecodeHeaders(rawJWE, limits)
 	if err != nil {
 		return nil, err
 	}
@@ -315,7 +344,7 @@ func deserializeFromRawJWE(rawJWE *rawJSONWebEncryption) (*JSONWebEncryption, er
 	return &deserializedJWE, nil
 }
 
-func deserializeAndDecodeHeaders(rawJWE *rawJSONWebEncryption) (*Headers, *Headers, error) {
+func deserializeAndDecodeHeaders(rawJWE *rawJSONWebEncryption, limits jsonutil.Limits) (*Headers, *Headers, error) {
 	protectedHeadersBytes, err := base64.RawURLEncoding.DecodeString(rawJWE.B64ProtectedHeaders)
 	if err != nil {
 		return nil, nil, err
@@ -323,7 +352,7 @@ func deserializeAndDecodeHeaders(rawJWE *rawJSONWebEncryption) (*Headers, *Heade
 
 	var protectedHeaders Headers
 
-	err = json.Unmarshal(protectedHeadersBytes, &protectedHeaders)
+	err = jsonutil.Unmarshal(protectedHeadersBytes, &protectedHeaders, limits)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -14,6 +14,8 @@ import (
 
 	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 )
 
 const (
@@ -694,6 +696,17 @@ func TestDeserialize(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, exampleRealFullJWEWithEPKs, reserializedJWE)
 		})
+		t.Run("JWE exceeding the JSON limits", func(t *testing.T) {
+			_, err := Deserialize(exampleRealFullJWEWithEPKs, WithJWEJSONLimits(jsonutil.Limits{MaxArrayLength: 1}))
+			require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+
+			_, err = Deserialize(`{"protected":"`+base64.RawURLEncoding.EncodeToString([]byte(`{"a": [[]]}`))+`"}`,
+				WithJWEJSONLimits(jsonutil.Limits{MaxDepth: 2}))
+			require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+
+			_, err = Deserialize(exampleRealCompactJWE, WithJWEJSONLimits(jsonutil.Limits{MaxBytes: 10}))
+			require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+		})
 		t.Run("Unable to unmarshal serialized JWE string", func(t *testing.T) {
 			deserializedJWE, err := Deserialize("{")
 			require.EqualError(t, err, "unexpected end of JSON input")
@@ -13,6 +13,8 @@ import (
 	"strings"
 
 	"github.com/square/go-jose/v3/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 )
 
 const (
@@ -186,6 +188,7 @@ func sign(joseHeaders Headers, payload []byte, signer Signer) ([]byte, error) {
 // jwsParseOpts holds options for the JWS Parsing.
 type jwsParseOpts struct {
 	detachedPayload []byte
+	jsonLimits      jsonutil.Limits
 }
 
 // JWSParseOpt is the JWS Parser option.
@@ -198,14 +201,26 @@ func WithJWSDetachedPayload(payload []byte) JWSParseOpt {
 	}
 }
 
+// WithJWSJSONLimits option sets the limits of the size of the JWS and of the JSON of its headers,
+// jsonutil.DefaultLimits if not set.
+func WithJWSJSONLimits(limits jsonutil.Limits) JWSParseOpt {
+	return func(opts *jwsParseOpts) {
+		opts.jsonLimits = limits
+	}
+}
+
 // ParseJWS parses serialized JWS. Currently only JWS Compact Serialization parsing is supported.
 func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSONWebSignature, error) {
-	pOpts := &jwsParseOpts{}
+	pOpts := &jwsParseOpts{jsonLimits: jsonutil.DefaultLimits()}
 
 	for _, opt := range opts {
 		opt(pOpts)
 	}
 
+	if err := jsonutil.Check([]byte(jws), jsonutil.Limits{MaxBytes: pOpts.jsonLimits.MaxBytes}); err != nil {
+		return nil, err
+	}
+
 	if strings.HasPrefix(jws, "{") {
 		// TODO support JWS JSON serialization format
 		//  https://github.com/hyperledger/aries-framework-go/issues/1331
@@ -228,7 +243,7 @@ func parseCompacted(jwsCompact string, verifier SignatureVerifier, opts *jwsPars
 		return nil, errors.New("invalid JWS compact format")
 	}
 
-	joseHeaders, err := parseCompactedHeaders(parts)
+	joseHeaders, err := parseCompactedHeaders(parts, opts.jsonLimits)
 	if err != nil {
 		return nil, err
 	}
@@ -274,12 +289,16 @@ func parseCompactedPayload(jwsPayload string, opts *jwsParseOpts) ([]byte, error
 	return payload, nil
 }
 
-func parseCompactedHeaders(parts []string) (Headers, error) {
+func parseCompactedHeaders(parts []string, limits jsonutil.Limits) (Headers, error) {
 	headersBytes, err := base64.RawURLEncoding.DecodeString(parts[jwsHeaderPart])
 	if err != nil {
 		return nil, fmt.Errorf("decode base64 header: %w", err)
 	}
 
+	if err = jsonutil.Check(headersBytes, limits); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON headers: %w", err)
+	}
+
 	var joseHeaders Headers
 
 	err = json.Unmarshal(headersBytes, &joseHeaders)
@@ -14,6 +14,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 )
 
 func TestHeaders_GetKeyID(t *testing.T) {
@@ -228,6 +230,16 @@ func TestParseJWS(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, "bad signature")
 	require.Nil(t, parsedJWS)
+
+	// JSON limits
+	_, err = ParseJWS(jwsCompact, &testVerifier{}, WithJWSJSONLimits(jsonutil.Limits{MaxBytes: 10}))
+	require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+
+	deepHeaders := base64.RawURLEncoding.EncodeToString([]byte(`{"alg": "EdSDA", "x": [[[]]]}`))
+
+	jwsWithDeepHeaders := fmt.Sprintf("%s.%s.%s", deepHeaders, validJWSParts[1], validJWSParts[2])
+	_, err = ParseJWS(jwsWithDeepHeaders, &testVerifier{}, WithJWSJSONLimits(jsonutil.Limits{MaxDepth: 3}))
+	require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
 }
 
 func TestIsCompactJWS(t *testing.T) {
@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package jsonutil decodes the untrusted JSON documents (credentials, DID documents, JWEs...) within limits of size,
+// nesting depth and array length, so that a document crafted to exhaust the memory or the stack of the parser is
+// rejected before being decoded.
+//
+// The doc packages apply the DefaultLimits unless their parsers are given other limits, and the framework applies
+// the limits of its context (see the aries.WithJSONLimits option) to the documents it receives.
+package jsonutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	defaultMaxBytes       = 8 << 20
+	defaultMaxDepth       = 64
+	defaultMaxArrayLength = 10000
+)
+
+// ErrLimitExceeded is returned when a JSON document exceeds the limits.
+var ErrLimitExceeded = errors.New("JSON document exceeds the limits")
+
+// Limits are the limits of a JSON document. A zero limit is no limit.
+type Limits struct {
+	// MaxBytes is the maximum size of the document.
+	MaxBytes int
+	// MaxDepth is the maximum nesting depth of its objects and arrays.
+	MaxDepth int
+	// MaxArrayLength is the maximum number of elements of its arrays.
+	MaxArrayLength int
+}
+
+// DefaultLimits returns the default limits: 8 MiB, a depth of 64 and arrays of 10000 elements.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxBytes:       defaultMaxBytes,
+		MaxDepth:       defaultMaxDepth,
+		MaxArrayLength: defaultMaxArrayLength,
+	}
+}
+
+// LimitsProvider provides the limits of the JSON documents, e.g. the framework context.
+type LimitsProvider interface {
+	JSONLimits() Limits
+}
+
+// LimitsOf returns the limits of the provider if it's a LimitsProvider, the default limits otherwise.
+func LimitsOf(p interface{}) Limits {
+	if lp, ok := p.(LimitsProvider); ok {
+		return lp.JSONLimits()
+	}
+
+	return DefaultLimits()
+}
+
+// Check checks that the JSON document doesn't exceed the limits, wrapping ErrLimitExceeded if it does. It scans the
+// document without decoding it, the syntax errors being left to the decoder.
+func Check(data []byte, limits Limits) error {
+	if limits.MaxBytes > 0 && len(data) > limits.MaxBytes {
+		return fmt.Errorf("%w: size %d bytes, max %d", ErrLimitExceeded, len(data), limits.MaxBytes)
+	}
+
+	if limits.MaxDepth <= 0 && limits.MaxArrayLength <= 0 {
+		return nil
+	}
+
+	// the containers being scanned, with the number of elements of the arrays
+	type container struct {
+		array    bool
+		elements int
+	}
+
+	var (
+		stack            []container
+		inString, escape bool
+	)
+
+	for _, c := range data {
+		if inString {
+			switch {
+			case escape:
+				escape = false
+			case c == '\\':
+				escape = true
+			case c == '"':
+				inString = false
+			}
+
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			if limits.MaxDepth > 0 && len(stack) == limits.MaxDepth {
+				return fmt.Errorf("%w: depth over %d", ErrLimitExceeded, limits.MaxDepth)
+			}
+
+			stack = append(stack, container{array: c == '['})
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case ',':
+			if len(stack) == 0 || !stack[len(stack)-1].array {
+				continue
+			}
+
+			// a comma starts the second element, and so on
+			top := &stack[len(stack)-1]
+			top.elements++
+
+			if limits.MaxArrayLength > 0 && top.elements >= limits.MaxArrayLength {
+				return fmt.Errorf("%w: array length over %d", ErrLimitExceeded, limits.MaxArrayLength)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Unmarshal checks that the JSON document doesn't exceed the limits, then decodes it into v.
+func Unmarshal(data []byte, v interface{}, limits Limits) error {
+	if err := Check(data, limits); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	limits := Limits{MaxBytes: 100, MaxDepth: 3, MaxArrayLength: 3}
+
+	tests := []struct {
+		name string
+		doc  string
+		err  string
+	}{
+		{name: "valid", doc: `{"a": [1, 2, 3], "b": {"c": [1]}}`},
+		{name: "empty array", doc: `{"a": []}`},
+		{name: "delimiters in strings", doc: `{"a": "[[[[,,,,", "b": "\"[[[[", "c": ["\\", "}}}"]}`},
+		{name: "size", doc: `{"a": "` + strings.Repeat("a", 100) + `"}`, err: "size 109 bytes, max 100"},
+		{name: "depth", doc: `{"a": [{"b": []}]}`, err: "depth over 3"},
+		{name: "array length", doc: `{"a": [1, 2, 3, 4]}`, err: "array length over 3"},
+		{name: "nested array length", doc: `[[1], {"a": 2}, [1, 2, 3, 4]]`, err: "array length over 3"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := Check([]byte(tc.doc), limits)
+			if tc.err == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			require.True(t, errors.Is(err, ErrLimitExceeded))
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	t.Run("no limits", func(t *testing.T) {
+		require.NoError(t, Check([]byte(strings.Repeat("[", 1000)), Limits{}))
+	})
+
+	t.Run("default limits", func(t *testing.T) {
+		require.NoError(t, Check([]byte(strings.Repeat("[", 64)+strings.Repeat("]", 64)), DefaultLimits()))
+		require.Error(t, Check([]byte(strings.Repeat("[", 65)), DefaultLimits()))
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	var v map[string]interface{}
+
+	require.NoError(t, Unmarshal([]byte(`{"a": [1, 2]}`), &v, DefaultLimits()))
+	require.Equal(t, map[string]interface{}{"a": []interface{}{1.0, 2.0}}, v)
+
+	err := Unmarshal([]byte(`{"a": [1, 2]}`), &v, Limits{MaxArrayLength: 1})
+	require.True(t, errors.Is(err, ErrLimitExceeded))
+
+	require.Error(t, Unmarshal([]byte(`{"a": `), &v, DefaultLimits()))
+}
+
+type limitsProvider struct{}
+
+func (limitsProvider) JSONLimits() Limits {
+	return Limits{MaxDepth: 1}
+}
+
+func TestLimitsOf(t *testing.T) {
+	require.Equal(t, Limits{MaxDepth: 1}, LimitsOf(limitsProvider{}))
+	require.Equal(t, DefaultLimits(), LimitsOf(struct{}{}))
+	require.Equal(t, DefaultLimits(), LimitsOf(nil))
+}
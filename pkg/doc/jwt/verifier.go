@@ -26,6 +26,12 @@ const (
 
 	// signatureRS256 defines RS256 alg.
 	signatureRS256 = "RS256"
+
+	// signatureES256 defines ES256 alg.
+	signatureES256 = "ES256"
+
+	// signatureES256K defines ES256K alg.
+	signatureES256K = "ES256K"
 )
 
 const issuerClaim = "iss"
@@ -64,9 +70,15 @@ func NewVerifier(resolver KeyResolver) *BasicVerifier {
 			Alg:      signatureRS256,
 			Verifier: getVerifier(resolver, VerifyRS256),
 		},
+		jose.AlgSignatureVerifier{
+			Alg:      signatureES256,
+			Verifier: getVerifier(resolver, VerifyES256),
+		},
+		jose.AlgSignatureVerifier{
+			Alg:      signatureES256K,
+			Verifier: getVerifier(resolver, VerifyES256K),
+		},
 	)
-	// TODO ECDSA to support NIST P256 curve
-	//  https://github.com/hyperledger/aries-framework-go/issues/1266
 
 	return &BasicVerifier{resolver: resolver, compositeVerifier: compositeVerifier}
 }
@@ -144,6 +156,16 @@ func VerifyRS256(pubKey *verifier.PublicKey, message, signature []byte) error {
 	return rsa.VerifyPKCS1v15(pubKeyRsa, crypto.SHA256, hashed, signature)
 }
 
+// VerifyES256 verifies ES256 signature, the concatenated R and S of ECDSA using P-256 and SHA-256.
+func VerifyES256(pubKey *verifier.PublicKey, message, signature []byte) error {
+	return verifier.NewECDSAES256SignatureVerifier().Verify(pubKey, message, signature)
+}
+
+// VerifyES256K verifies ES256K signature, the concatenated R and S of ECDSA using secp256k1 and SHA-256.
+func VerifyES256K(pubKey *verifier.PublicKey, message, signature []byte) error {
+	return verifier.NewECDSASecp256k1SignatureVerifier().Verify(pubKey, message, signature)
+}
+
 func getIssuerClaim(claims map[string]interface{}) (string, error) {
 	v, ok := claims[issuerClaim]
 	if !ok {
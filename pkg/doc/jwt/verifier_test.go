@@ -8,13 +8,16 @@ package jwt
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"errors"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/square/go-jose/v3/json"
 	"github.com/stretchr/testify/require"
 
@@ -182,3 +185,47 @@ func TestVerifyRS256(t *testing.T) {
 	}, []byte("test message"), signature)
 	r.Error(err)
 }
+
+func TestVerifyES256(t *testing.T) {
+	testVerifyECDSA(t, elliptic.P256(), VerifyES256)
+}
+
+func TestVerifyES256K(t *testing.T) {
+	testVerifyECDSA(t, btcec.S256(), VerifyES256K)
+}
+
+func testVerifyECDSA(t *testing.T, curve elliptic.Curve, verify signatureVerifier) {
+	r := require.New(t)
+
+	privKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	r.NoError(err)
+
+	hashed := crypto.SHA256.New()
+
+	_, err = hashed.Write([]byte("test message"))
+	r.NoError(err)
+
+	sigR, sigS, err := ecdsa.Sign(rand.Reader, privKey, hashed.Sum(nil))
+	r.NoError(err)
+
+	// the JWS signature is the concatenation of R and S, each of the curve's key size
+	signature := make([]byte, 64)
+	sigR.FillBytes(signature[:32])
+	sigS.FillBytes(signature[32:])
+
+	pubKey := &verifier.PublicKey{
+		Type:  "JsonWebKey2020",
+		Value: elliptic.Marshal(curve, privKey.X, privKey.Y),
+	}
+
+	r.NoError(verify(pubKey, []byte("test message"), signature))
+
+	err = verify(pubKey, []byte("other message"), signature)
+	r.EqualError(err, "ecdsa: invalid signature")
+
+	err = verify(pubKey, []byte("test message"), signature[1:])
+	r.EqualError(err, "ecdsa: invalid signature size")
+
+	err = verify(&verifier.PublicKey{Value: []byte("invalid pub key")}, []byte("test message"), signature)
+	r.EqualError(err, "ecdsa: create JWK from public key bytes: invalid public key")
+}
@@ -9,12 +9,15 @@ package presexch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/PaesslerAG/gval"
 	"github.com/PaesslerAG/jsonpath"
+	"github.com/google/uuid"
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
 
@@ -33,6 +36,9 @@ const (
 	// PresentationSubmissionJSONLDType is the JSONLD type of presentation submissions.
 	PresentationSubmissionJSONLDType = "PresentationSubmission"
 
+	verifiableCredentialContext = "https://www.w3.org/2018/credentials/v1"
+	verifiablePresentationType  = "VerifiablePresentation"
+
 	submissionProperty    = "presentation_submission"
 	descriptorMapProperty = "descriptor_map"
 
@@ -128,6 +134,18 @@ type PresentationDefinition struct {
 	InputDescriptors       []*InputDescriptor      `json:"input_descriptors"`
 }
 
+// ParsePresentationDefinition parses a presentation definition, e.g. received from a verifier, from a JSON document
+// within the limits.
+func ParsePresentationDefinition(data []byte, limits jsonutil.Limits) (*PresentationDefinition, error) {
+	pd := &PresentationDefinition{}
+
+	if err := jsonutil.Unmarshal(data, pd, limits); err != nil {
+		return nil, fmt.Errorf("parse presentation definition: %w", err)
+	}
+
+	return pd, nil
+}
+
 // PresentationSubmission is the container for the descriptor_map:
 // https://identity.foundation/presentation-exchange/#presentation-submission.
 type PresentationSubmission struct {
@@ -147,9 +165,17 @@ type InputDescriptorMapping struct {
 	PathNested *InputDescriptorMapping `json:"path_nested,omitempty"`
 }
 
+// ErrNoCredentials is returned when the credentials do not satisfy the presentation definition.
+var ErrNoCredentials = errors.New("credentials do not satisfy requirements")
+
 // MatchOptions is a holder of options that can set when matching a submission against definitions.
 type MatchOptions struct {
 	JSONLDDocumentLoader ld.DocumentLoader
+	// JSONLimits are the limits of the JSON of the presentation and of the credentials it submits,
+	// jsonutil.DefaultLimits if not set.
+	JSONLimits jsonutil.Limits
+	// CredentialOptions are the additional options parsing the submitted credentials, e.g. their public key fetcher.
+	CredentialOptions []verifiable.CredentialOpt
 }
 
 // MatchOption is an option that sets an option for when matching.
@@ -162,10 +188,24 @@ func WithJSONLDDocumentLoader(l ld.DocumentLoader) MatchOption {
 	}
 }
 
+// WithJSONLimits sets the limits of the JSON of the presentation and of the credentials it submits.
+func WithJSONLimits(limits jsonutil.Limits) MatchOption {
+	return func(m *MatchOptions) {
+		m.JSONLimits = limits
+	}
+}
+
+// WithCredentialOptions sets the additional options parsing the submitted credentials.
+func WithCredentialOptions(options ...verifiable.CredentialOpt) MatchOption {
+	return func(m *MatchOptions) {
+		m.CredentialOptions = options
+	}
+}
+
 // Match returns the credentials matched against the InputDescriptors ids.
 func (p *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:gocyclo,funlen
 	options ...MatchOption) (map[string]*verifiable.Credential, error) {
-	opts := &MatchOptions{}
+	opts := &MatchOptions{JSONLimits: jsonutil.DefaultLimits()}
 
 	for i := range options {
 		options[i](opts)
@@ -183,7 +223,7 @@ func (p *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:go
 
 	typelessVP := interface{}(nil)
 
-	err = json.Unmarshal(vpBits, &typelessVP)
+	err = jsonutil.Unmarshal(vpBits, &typelessVP, opts.JSONLimits)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal vp: %w", err)
 	}
@@ -208,7 +248,7 @@ func (p *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:go
 				descriptorMapProperty, mapping.ID)
 		}
 
-		vc, selectErr := selectByPath(builder, typelessVP, mapping.Path, opts.JSONLDDocumentLoader)
+		vc, selectErr := selectByPath(builder, typelessVP, mapping.Path, opts)
 		if selectErr != nil {
 			return nil, fmt.Errorf("failed to select vc from submission: %w", selectErr)
 		}
@@ -229,7 +269,15 @@ func (p *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:go
 				inputDescriptor.ID, inputDescriptor.Schema, vc.Types)
 		}
 
-		// TODO add support for constraints: https://github.com/hyperledger/aries-framework-go/issues/2108
+		if ok, _ := matchConstraints(&inputDescriptor.Constraints, vc); !ok {
+			return nil, fmt.Errorf("input descriptor id [%s]: vc %s does not satisfy the constraints",
+				inputDescriptor.ID, vc.ID)
+		}
+
+		if inputDescriptor.Constraints.LimitDisclosure && !hasSelectiveDisclosureProof(vc) {
+			return nil, fmt.Errorf("input descriptor id [%s] limits disclosure but vc %s has no %s proof",
+				inputDescriptor.ID, vc.ID, bbsSignatureProofType)
+		}
 
 		result[mapping.ID] = vc
 	}
@@ -242,6 +290,166 @@ func (p *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:go
 	return result, nil
 }
 
+// CreateVPOptions holds the options of the verifiable presentation creation.
+type CreateVPOptions struct {
+	// SDPublicKeyFetcher fetches the BBS+ public keys of the credentials whose selective disclosure is derived for
+	// the input descriptors limiting disclosure.
+	SDPublicKeyFetcher verifiable.PublicKeyFetcher
+	// SDNonce is the nonce of the proofs of the selective disclosures.
+	SDNonce              []byte
+	JSONLDDocumentLoader ld.DocumentLoader
+}
+
+// CreateVPOption is an option of the verifiable presentation creation.
+type CreateVPOption func(*CreateVPOptions)
+
+// WithSDPublicKeyFetcher sets the fetcher of the BBS+ public keys of the credentials whose selective disclosure is
+// derived.
+func WithSDPublicKeyFetcher(fetcher verifiable.PublicKeyFetcher) CreateVPOption {
+	return func(o *CreateVPOptions) {
+		o.SDPublicKeyFetcher = fetcher
+	}
+}
+
+// WithSDNonce sets the nonce of the proofs of the selective disclosures.
+func WithSDNonce(nonce []byte) CreateVPOption {
+	return func(o *CreateVPOptions) {
+		o.SDNonce = nonce
+	}
+}
+
+// WithSDDocumentLoader sets the JSON-LD document loader of the selective disclosures.
+func WithSDDocumentLoader(l ld.DocumentLoader) CreateVPOption {
+	return func(o *CreateVPOptions) {
+		o.JSONLDDocumentLoader = l
+	}
+}
+
+// CreateVP creates a verifiable presentation submitting the given credentials against the presentation definition.
+// For every input descriptor, the first credential having one of the descriptor's schema URIs in its context and
+// satisfying the descriptor's constraints is selected. ErrNoCredentials is returned if an input descriptor cannot
+// be satisfied by any of the credentials.
+func (p *PresentationDefinition) CreateVP(credentials ...*verifiable.Credential) (*verifiable.Presentation, error) {
+	return p.CreateVPWithOptions(credentials)
+}
+
+// CreateVPWithOptions creates a verifiable presentation the same way as CreateVP. The credentials selected for
+// the input descriptors limiting disclosure are replaced by their BBS+ selective disclosure of the constrained
+// fields, which requires the SDPublicKeyFetcher option.
+func (p *PresentationDefinition) CreateVPWithOptions(credentials []*verifiable.Credential, // nolint: funlen
+	options ...CreateVPOption) (*verifiable.Presentation, error) {
+	opts := &CreateVPOptions{}
+
+	for i := range options {
+		options[i](opts)
+	}
+
+	submission := &PresentationSubmission{
+		ID:            uuid.New().String(),
+		DefinitionID:  p.ID,
+		DescriptorMap: []*InputDescriptorMapping{},
+	}
+
+	var selected []interface{}
+
+	indexes := make(map[*verifiable.Credential]int)
+
+	for _, descriptor := range p.InputDescriptors {
+		vc, paths := selectByConstraints(descriptor, credentials)
+		if vc == nil {
+			return nil, fmt.Errorf("input descriptor %s: %w", descriptor.ID, ErrNoCredentials)
+		}
+
+		idx, ok := indexes[vc]
+
+		if descriptor.Constraints.LimitDisclosure {
+			derived, err := limitDisclosure(vc, paths, opts)
+			if err != nil {
+				return nil, fmt.Errorf("input descriptor %s: limit disclosure: %w", descriptor.ID, err)
+			}
+
+			idx, ok = len(selected), true
+
+			selected = append(selected, derived)
+		}
+
+		if !ok {
+			idx = len(selected)
+			indexes[vc] = idx
+			selected = append(selected, vc)
+		}
+
+		submission.DescriptorMap = append(submission.DescriptorMap, &InputDescriptorMapping{
+			ID:   descriptor.ID,
+			Path: fmt.Sprintf("$.verifiableCredential[%d]", idx),
+		})
+	}
+
+	// the submission is kept in its JSON form, the same way it is when the presentation is parsed
+	submissionBits, err := json.Marshal(submission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal submission: %w", err)
+	}
+
+	var typelessSubmission map[string]interface{}
+
+	err = json.Unmarshal(submissionBits, &typelessSubmission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal submission: %w", err)
+	}
+
+	vp := &verifiable.Presentation{
+		Context:      []string{verifiableCredentialContext, PresentationSubmissionJSONLDContext},
+		Type:         []string{verifiablePresentationType, PresentationSubmissionJSONLDType},
+		CustomFields: verifiable.CustomFields{submissionProperty: typelessSubmission},
+	}
+
+	if err = vp.SetCredentials(selected...); err != nil {
+		return nil, fmt.Errorf("failed to set credentials: %w", err)
+	}
+
+	return vp, nil
+}
+
+// Evaluate returns the credentials matching each input descriptor of the presentation definition, by input
+// descriptor ID, e.g for the holder to choose the credentials to submit. A credential matches an input descriptor
+// if it has one of the descriptor's schema URIs in its context and satisfies the descriptor's constraints, the input
+// descriptors not matched by any credential being absent from the result.
+func (p *PresentationDefinition) Evaluate(credentials ...*verifiable.Credential) map[string][]*verifiable.Credential {
+	result := make(map[string][]*verifiable.Credential)
+
+	for _, descriptor := range p.InputDescriptors {
+		for _, vc := range credentials {
+			if ok, _ := matches(descriptor, vc); ok {
+				result[descriptor.ID] = append(result[descriptor.ID], vc)
+			}
+		}
+	}
+
+	return result
+}
+
+func selectByConstraints(descriptor *InputDescriptor,
+	credentials []*verifiable.Credential) (*verifiable.Credential, []string) {
+	for _, vc := range credentials {
+		if ok, paths := matches(descriptor, vc); ok {
+			return vc, paths
+		}
+	}
+
+	return nil, nil
+}
+
+func matchesSchema(descriptor *InputDescriptor, vc *verifiable.Credential) bool {
+	for _, schema := range descriptor.Schema {
+		if stringsContain(vc.Context, schema.URI) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Ensures the matched credentials meet the submission requirements.
 func (p *PresentationDefinition) evalSubmissionRequirements(matched map[string]*verifiable.Credential) error {
 	// TODO support submission requirement rules: https://github.com/hyperledger/aries-framework-go/issues/2109
@@ -319,7 +527,7 @@ func descriptorIDs(input []*InputDescriptor) []string {
 // string expression that selects the credential to be submit in relation to the identified Input Descriptor
 // identified, when executed against the top-level of the object the Presentation Submission is embedded within.
 func selectByPath(builder gval.Language, vp interface{}, jsonPath string,
-	loader ld.DocumentLoader) (*verifiable.Credential, error) {
+	opts *MatchOptions) (*verifiable.Credential, error) {
 	path, err := builder.NewEvaluable(jsonPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build new json path evaluator: %w", err)
@@ -335,12 +543,14 @@ func selectByPath(builder gval.Language, vp interface{}, jsonPath string,
 		return nil, fmt.Errorf("failed to marshal credential: %w", err)
 	}
 
-	vcOpts := make([]verifiable.CredentialOpt, 0)
+	vcOpts := []verifiable.CredentialOpt{verifiable.WithJSONLimits(opts.JSONLimits)}
 
-	if loader != nil {
-		vcOpts = append(vcOpts, verifiable.WithJSONLDDocumentLoader(loader))
+	if opts.JSONLDDocumentLoader != nil {
+		vcOpts = append(vcOpts, verifiable.WithJSONLDDocumentLoader(opts.JSONLDDocumentLoader))
 	}
 
+	vcOpts = append(vcOpts, opts.CredentialOptions...)
+
 	vc, err := verifiable.ParseCredential(credBits, vcOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse credential: %w", err)
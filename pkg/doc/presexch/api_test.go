@@ -8,6 +8,7 @@ package presexch
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
@@ -48,6 +50,31 @@ func TestPresentationDefinition_Match(t *testing.T) {
 		require.Equal(t, expected.ID, result.ID)
 	})
 
+	t.Run("error if vp exceeds the JSON limits", func(t *testing.T) {
+		uri := randomURI()
+		defs := &PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Schema: []Schema{{
+					URI: uri,
+				}},
+			}},
+		}
+
+		vp := newVP(t,
+			&PresentationSubmission{DescriptorMap: []*InputDescriptorMapping{{
+				ID:   defs.InputDescriptors[0].ID,
+				Path: "$.verifiableCredential[0]",
+			}}},
+			newVC([]string{uri}),
+		)
+
+		_, err := defs.Match(vp, WithJSONLDDocumentLoader(jsonldContextLoader(t, uri)),
+			WithJSONLimits(jsonutil.Limits{MaxBytes: 100}))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+	})
+
 	t.Run("error if vp does not have the right context", func(t *testing.T) {
 		uri := randomURI()
 		defs := &PresentationDefinition{
@@ -250,6 +277,89 @@ func TestPresentationDefinition_Match(t *testing.T) {
 	})
 }
 
+func TestParsePresentationDefinition(t *testing.T) {
+	pd, err := ParsePresentationDefinition([]byte(`{"id": "pd", "input_descriptors": [{"id": "a"}, {"id": "b"}]}`),
+		jsonutil.DefaultLimits())
+	require.NoError(t, err)
+	require.Equal(t, "pd", pd.ID)
+	require.Len(t, pd.InputDescriptors, 2)
+
+	_, err = ParsePresentationDefinition([]byte(`{"id": "pd", "input_descriptors": [{"id": "a"}, {"id": "b"}]}`),
+		jsonutil.Limits{MaxArrayLength: 1})
+	require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+
+	_, err = ParsePresentationDefinition([]byte(`{"id": `), jsonutil.DefaultLimits())
+	require.Error(t, err)
+}
+
+func TestPresentationDefinition_CreateVP(t *testing.T) {
+	t.Run("creates a submission matching the definition", func(t *testing.T) {
+		uri := randomURI()
+		defs := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:     uuid.New().String(),
+				Schema: []Schema{{URI: uri}},
+			}, {
+				ID:     uuid.New().String(),
+				Schema: []Schema{{URI: uri}},
+			}},
+		}
+
+		expected := newVC([]string{uri})
+
+		vp, err := defs.CreateVP(newVC(nil), expected)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		receivedVP, err := verifiable.ParseUnverifiedPresentation(marshal(t, vp))
+		require.NoError(t, err)
+
+		matched, err := defs.Match(receivedVP, WithJSONLDDocumentLoader(jsonldContextLoader(t, uri)))
+		require.NoError(t, err)
+		require.Len(t, matched, 2)
+		require.Equal(t, expected.ID, matched[defs.InputDescriptors[0].ID].ID)
+		require.Equal(t, expected.ID, matched[defs.InputDescriptors[1].ID].ID)
+	})
+
+	t.Run("error if no credential matches an input descriptor", func(t *testing.T) {
+		defs := &PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{{
+				ID:     uuid.New().String(),
+				Schema: []Schema{{URI: randomURI()}},
+			}},
+		}
+
+		_, err := defs.CreateVP(newVC([]string{randomURI()}))
+		require.True(t, errors.Is(err, ErrNoCredentials))
+	})
+}
+
+func TestPresentationDefinition_Evaluate(t *testing.T) {
+	uri1, uri2 := randomURI(), randomURI()
+	defs := &PresentationDefinition{
+		InputDescriptors: []*InputDescriptor{{
+			ID:     uuid.New().String(),
+			Schema: []Schema{{URI: uri1}},
+		}, {
+			ID:     uuid.New().String(),
+			Schema: []Schema{{URI: uri1}, {URI: uri2}},
+		}, {
+			ID:     uuid.New().String(),
+			Schema: []Schema{{URI: randomURI()}},
+		}},
+	}
+
+	vc1, vc2, vc3 := newVC([]string{uri1}), newVC([]string{uri2}), newVC(nil)
+
+	result := defs.Evaluate(vc1, vc2, vc3)
+	require.Len(t, result, 2)
+	require.Equal(t, []*verifiable.Credential{vc1}, result[defs.InputDescriptors[0].ID])
+	require.Equal(t, []*verifiable.Credential{vc1, vc2}, result[defs.InputDescriptors[1].ID])
+
+	require.Empty(t, defs.Evaluate())
+}
+
 func TestE2E(t *testing.T) {
 	// verifier sends their presentation definitions to the holder
 	verifierDefinitions := &PresentationDefinition{
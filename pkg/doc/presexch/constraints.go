@@ -0,0 +1,284 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	bbsSignatureType      = "BbsBlsSignature2020"
+	bbsSignatureProofType = "BbsBlsSignatureProof2020"
+
+	resolveIDParts = 2
+)
+
+// pathSegment matches a segment of the simple JSONPaths, e.g. ".name", "['name']" or "[0]".
+var pathSegment = regexp.MustCompile(`^(?:\.([^.\[\]]+)|\['([^']+)'\]|\[(\d+)\])`)
+
+// matches returns whether the credential matches the schema and the constraints of the input descriptor, and the
+// JSONPaths of the credential's fields selected by the constraints. A credential matching an input descriptor that
+// limits disclosure must support the derivation of its selective disclosure.
+func matches(descriptor *InputDescriptor, vc *verifiable.Credential) (bool, []string) {
+	if !matchesSchema(descriptor, vc) {
+		return false, nil
+	}
+
+	if descriptor.Constraints.LimitDisclosure && !supportsSelectiveDisclosure(vc) {
+		return false, nil
+	}
+
+	return matchConstraints(&descriptor.Constraints, vc)
+}
+
+// matchConstraints returns whether the credential satisfies the constraints, regardless of the limit disclosure, and
+// the JSONPaths of the credential's fields selected by the constraints. The subject_is_holder constraint isn't
+// supported.
+func matchConstraints(constraints *Constraints, vc *verifiable.Credential) (bool, []string) {
+	if constraints.SubjectIsIssuer == Required && !subjectIsIssuer(vc) {
+		return false, nil
+	}
+
+	if len(constraints.Fields) == 0 {
+		return true, nil
+	}
+
+	typelessVC, err := toTypeless(vc)
+	if err != nil {
+		return false, nil
+	}
+
+	var paths []string
+
+	for i := range constraints.Fields {
+		path, ok := matchField(&constraints.Fields[i], typelessVC)
+		if !ok {
+			return false, nil
+		}
+
+		paths = append(paths, path)
+	}
+
+	return true, paths
+}
+
+// matchField returns the first path of the field resolving to a value of the credential that passes the field's
+// filter.
+func matchField(field *Field, typelessVC interface{}) (string, bool) {
+	for _, path := range field.Path {
+		value, err := jsonpath.Get(path, typelessVC)
+		if err != nil {
+			continue
+		}
+
+		if passesFilter(&field.Filter, value) {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// passesFilter validates the value against the filter, a JSON schema.
+func passesFilter(filter *Filter, value interface{}) bool {
+	filterBits, err := json.Marshal(filter)
+	if err != nil {
+		return false
+	}
+
+	var schema map[string]interface{}
+
+	if err = json.Unmarshal(filterBits, &schema); err != nil {
+		return false
+	}
+
+	// the type of the filter is marshaled even if not defined
+	if schema["type"] == "" {
+		delete(schema, "type")
+	}
+
+	if len(schema) == 0 {
+		return true
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(schema), gojsonschema.NewGoLoader(value))
+	if err != nil {
+		return false
+	}
+
+	return result.Valid()
+}
+
+func subjectIsIssuer(vc *verifiable.Credential) bool {
+	subjectID, err := verifiable.SubjectID(vc.Subject)
+	if err != nil {
+		return false
+	}
+
+	return subjectID == vc.Issuer.ID
+}
+
+// supportsSelectiveDisclosure returns whether a credential limiting the disclosure of its fields can be derived
+// from the credential.
+func supportsSelectiveDisclosure(vc *verifiable.Credential) bool {
+	return len(vc.Proofs) == 1 && vc.Proofs[0]["type"] == bbsSignatureType
+}
+
+// hasSelectiveDisclosureProof returns whether the credential has a proof of a selective disclosure.
+func hasSelectiveDisclosureProof(vc *verifiable.Credential) bool {
+	for _, proof := range vc.Proofs {
+		if proof["type"] == bbsSignatureProofType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// limitDisclosure derives the credential disclosing only the fields of the paths from the BBS+ signed credential.
+func limitDisclosure(vc *verifiable.Credential, paths []string,
+	opts *CreateVPOptions) (*verifiable.Credential, error) {
+	if opts.SDPublicKeyFetcher == nil {
+		return nil, errors.New("public key fetcher of the selective disclosure is not set")
+	}
+
+	revealDoc, err := revealFrame(vc, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationMethod, _ := vc.Proofs[0]["verificationMethod"].(string) // nolint: errcheck
+
+	idSplit := strings.Split(verificationMethod, "#")
+	if len(idSplit) != resolveIDParts {
+		return nil, fmt.Errorf("wrong verification method %s to resolve", verificationMethod)
+	}
+
+	pubKey, err := opts.SDPublicKeyFetcher(idSplit[0], "#"+idSplit[1])
+	if err != nil {
+		return nil, fmt.Errorf("fetch public key %s: %w", verificationMethod, err)
+	}
+
+	var processorOpts []jsonld.ProcessorOpts
+
+	if opts.JSONLDDocumentLoader != nil {
+		processorOpts = append(processorOpts, jsonld.WithDocumentLoader(opts.JSONLDDocumentLoader))
+	}
+
+	return vc.GenerateBBSSelectiveDisclosure(revealDoc, pubKey.Value, opts.SDNonce, processorOpts...)
+}
+
+// revealFrame returns the JSON-LD frame revealing the fields of the paths of the credential, the nested objects
+// of the paths being reduced to the fields of the paths.
+func revealFrame(vc *verifiable.Credential, paths []string) (map[string]interface{}, error) {
+	context := make([]interface{}, 0, len(vc.Context)+len(vc.CustomContext))
+
+	for _, c := range vc.Context {
+		context = append(context, c)
+	}
+
+	context = append(context, vc.CustomContext...)
+
+	types := make([]interface{}, len(vc.Types))
+
+	for i, t := range vc.Types {
+		types[i] = t
+	}
+
+	frame := map[string]interface{}{
+		"@context": context,
+		"type":     types,
+	}
+
+	for _, path := range paths {
+		keys, err := pathKeys(path)
+		if err != nil {
+			return nil, err
+		}
+
+		// the top level fields are all revealed
+		if len(keys) < 2 { // nolint: gomnd
+			continue
+		}
+
+		node := frame
+
+		for i, key := range keys {
+			if i == len(keys)-1 {
+				node[key] = map[string]interface{}{}
+
+				break
+			}
+
+			child, ok := node[key].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{"@explicit": true}
+				node[key] = child
+			} else if len(child) == 0 {
+				// the whole object is already revealed
+				break
+			}
+
+			node = child
+		}
+	}
+
+	return frame, nil
+}
+
+// pathKeys returns the keys of the object fields selected by a simple JSONPath, e.g. "$.credentialSubject.name".
+// The array indexes are skipped, the frame applying to all the elements of the arrays.
+func pathKeys(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("unsupported path %s to limit disclosure", path)
+	}
+
+	var keys []string
+
+	for rest := path[1:]; rest != ""; {
+		segment := pathSegment.FindStringSubmatch(rest)
+		if segment == nil {
+			return nil, fmt.Errorf("unsupported path %s to limit disclosure", path)
+		}
+
+		rest = rest[len(segment[0]):]
+
+		switch {
+		case segment[1] != "":
+			keys = append(keys, segment[1])
+		case segment[2] != "":
+			keys = append(keys, segment[2])
+		}
+	}
+
+	return keys, nil
+}
+
+func toTypeless(vc *verifiable.Credential) (interface{}, error) {
+	vcBits, err := vc.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential: %w", err)
+	}
+
+	var typelessVC interface{}
+
+	if err = json.Unmarshal(vcBits, &typelessVC); err != nil {
+		return nil, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	return typelessVC, nil
+}
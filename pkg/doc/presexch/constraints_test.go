@@ -0,0 +1,310 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/bbs/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	bbsContext  = "https://w3c-ccg.github.io/ldp-bbs2020/context/v1"
+	bbsKeyID    = "did:example:123456#key1"
+	bbsKeyType  = "Bls12381G2Key2020"
+	testIssuer  = "did:example:123456"
+	testSubject = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+)
+
+func TestPresentationDefinition_Evaluate_Constraints(t *testing.T) {
+	uri := randomURI()
+
+	alice := newSubjectVC(uri, map[string]interface{}{"id": testSubject, "name": "Alice", "age": 25.0})
+	bob := newSubjectVC(uri, map[string]interface{}{"id": testSubject, "name": "Bob", "age": 17.0})
+	issuerSubject := newSubjectVC(uri, map[string]interface{}{"id": testIssuer, "name": "Example"})
+	issuerSubject.Issuer = verifiable.Issuer{ID: testIssuer}
+
+	evaluate := func(constraints Constraints) []*verifiable.Credential {
+		defs := &PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{{
+				ID:          uuid.New().String(),
+				Schema:      []Schema{{URI: uri}},
+				Constraints: constraints,
+			}},
+		}
+
+		return defs.Evaluate(alice, bob, issuerSubject)[defs.InputDescriptors[0].ID]
+	}
+
+	t.Run("fields with filters", func(t *testing.T) {
+		require.Equal(t, []*verifiable.Credential{alice}, evaluate(Constraints{
+			Fields: []Field{{
+				Path:   []string{"$.credentialSubject.age"},
+				Filter: Filter{Type: "number", Minimum: 18},
+			}},
+		}))
+
+		require.Equal(t, []*verifiable.Credential{bob}, evaluate(Constraints{
+			Fields: []Field{{
+				Path:   []string{"$.credentialSubject.name"},
+				Filter: Filter{Type: "string", Pattern: "^B"},
+			}, {
+				Path: []string{"$.credentialSubject.age"},
+			}},
+		}))
+	})
+
+	t.Run("field of any path", func(t *testing.T) {
+		require.Equal(t, []*verifiable.Credential{alice, bob, issuerSubject}, evaluate(Constraints{
+			Fields: []Field{{
+				Path: []string{"$.credentialSubject.givenName", "$.credentialSubject.name"},
+			}},
+		}))
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		require.Empty(t, evaluate(Constraints{
+			Fields: []Field{{Path: []string{"$.credentialSubject.givenName"}}},
+		}))
+	})
+
+	t.Run("subject is issuer", func(t *testing.T) {
+		require.Equal(t, []*verifiable.Credential{issuerSubject}, evaluate(Constraints{SubjectIsIssuer: Required}))
+	})
+
+	t.Run("limit disclosure of credentials without BBS+ signature", func(t *testing.T) {
+		require.Empty(t, evaluate(Constraints{LimitDisclosure: true}))
+	})
+}
+
+func TestPresentationDefinition_CreateVPWithOptions(t *testing.T) {
+	uri := randomURI()
+	loader := bbsContextLoader(t, uri)
+
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	vc := newSubjectVC(uri, map[string]interface{}{"id": testSubject, "name": "Alice", "age": 25.0})
+	vc.Context = append(vc.Context, bbsContext)
+	vc.Issuer = verifiable.Issuer{ID: testIssuer}
+
+	signWithBBS(t, vc, privKey, loader)
+
+	defs := &PresentationDefinition{
+		ID: uuid.New().String(),
+		InputDescriptors: []*InputDescriptor{{
+			ID:     uuid.New().String(),
+			Schema: []Schema{{URI: uri}},
+			Constraints: Constraints{
+				LimitDisclosure: true,
+				Fields: []Field{{
+					Path:   []string{"$.credentialSubject.name"},
+					Filter: Filter{Type: "string", Pattern: "^A"},
+				}},
+			},
+		}},
+	}
+
+	t.Run("limits the disclosure to the fields", func(t *testing.T) {
+		vp, err := defs.CreateVPWithOptions([]*verifiable.Credential{vc},
+			WithSDPublicKeyFetcher(verifiable.SingleKey(pubKeyBytes, bbsKeyType)),
+			WithSDNonce([]byte("nonce")),
+			WithSDDocumentLoader(loader))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		receivedVP, err := verifiable.ParseUnverifiedPresentation(marshal(t, vp))
+		require.NoError(t, err)
+
+		matched, err := defs.Match(receivedVP, WithJSONLDDocumentLoader(loader), WithCredentialOptions(
+			verifiable.WithEmbeddedSignatureSuites(bbsblssignatureproof2020.New(suite.WithCompactProof(),
+				suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier([]byte("nonce"))))),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKeyBytes, bbsKeyType))))
+		require.NoError(t, err)
+
+		disclosed := matched[defs.InputDescriptors[0].ID]
+		require.NotNil(t, disclosed)
+		require.Equal(t, bbsSignatureProofType, disclosed.Proofs[0]["type"])
+
+		subject := disclosed.Subject.([]verifiable.Subject)
+		require.Len(t, subject, 1)
+		require.Equal(t, testSubject, subject[0].ID)
+		require.Equal(t, "Alice", subject[0].CustomFields["name"])
+		require.NotContains(t, subject[0].CustomFields, "age")
+	})
+
+	t.Run("error without public key fetcher", func(t *testing.T) {
+		_, err := defs.CreateVPWithOptions([]*verifiable.Credential{vc})
+		require.EqualError(t, err, "input descriptor "+defs.InputDescriptors[0].ID+": limit disclosure: "+
+			"public key fetcher of the selective disclosure is not set")
+	})
+
+	t.Run("error if no credential satisfies the constraints", func(t *testing.T) {
+		_, err := defs.CreateVPWithOptions([]*verifiable.Credential{
+			newSubjectVC(uri, map[string]interface{}{"id": testSubject, "name": "Bob"}),
+		})
+		require.True(t, errors.Is(err, ErrNoCredentials))
+	})
+
+	t.Run("error if submitted credential does not limit disclosure", func(t *testing.T) {
+		vp := newVP(t, &PresentationSubmission{DescriptorMap: []*InputDescriptorMapping{{
+			ID:   defs.InputDescriptors[0].ID,
+			Path: "$.verifiableCredential[0]",
+		}}}, vc)
+
+		_, err := defs.Match(vp, WithJSONLDDocumentLoader(loader), WithCredentialOptions(
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKeyBytes, bbsKeyType))))
+		require.EqualError(t, err, "input descriptor id ["+defs.InputDescriptors[0].ID+"] limits disclosure but vc "+
+			vc.ID+" has no BbsBlsSignatureProof2020 proof")
+	})
+}
+
+func TestPresentationDefinition_Match_Constraints(t *testing.T) {
+	uri := randomURI()
+	defs := &PresentationDefinition{
+		InputDescriptors: []*InputDescriptor{{
+			ID:     uuid.New().String(),
+			Schema: []Schema{{URI: uri}},
+			Constraints: Constraints{
+				Fields: []Field{{
+					Path:   []string{"$.credentialSubject.name"},
+					Filter: Filter{Const: "Alice"},
+				}},
+			},
+		}},
+	}
+
+	vc := newSubjectVC(uri, map[string]interface{}{"id": testSubject, "name": "Bob"})
+
+	_, err := defs.Match(newVP(t, &PresentationSubmission{DescriptorMap: []*InputDescriptorMapping{{
+		ID:   defs.InputDescriptors[0].ID,
+		Path: "$.verifiableCredential[0]",
+	}}}, vc), WithJSONLDDocumentLoader(jsonldContextLoader(t, uri)))
+	require.EqualError(t, err, "input descriptor id ["+defs.InputDescriptors[0].ID+"]: vc "+vc.ID+
+		" does not satisfy the constraints")
+}
+
+func TestRevealFrame(t *testing.T) {
+	vc := newVC(nil)
+
+	frame, err := revealFrame(vc, []string{
+		"$.issuer", "$.credentialSubject.degree.name", "$['credentialSubject']['degree']['type']",
+		"$.credentialSubject.alumniOf[0]", "$.evidence", "$.evidence.id",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"@context": []interface{}{"https://www.w3.org/2018/credentials/v1"},
+		"type":     []interface{}{"VerifiableCredential"},
+		"credentialSubject": map[string]interface{}{
+			"@explicit": true,
+			"degree": map[string]interface{}{
+				"@explicit": true,
+				"name":      map[string]interface{}{},
+				"type":      map[string]interface{}{},
+			},
+			"alumniOf": map[string]interface{}{},
+		},
+		"evidence": map[string]interface{}{
+			"@explicit": true,
+			"id":        map[string]interface{}{},
+		},
+	}, frame)
+
+	_, err = revealFrame(vc, []string{"$.credentialSubject[*].name"})
+	require.EqualError(t, err, "unsupported path $.credentialSubject[*].name to limit disclosure")
+}
+
+func newSubjectVC(uri string, subject map[string]interface{}) *verifiable.Credential {
+	vc := newVC([]string{uri})
+	vc.Subject = subject
+
+	return vc
+}
+
+func signWithBBS(t *testing.T, vc *verifiable.Credential, privKey *bbs12381g2pub.PrivateKey,
+	loader ld.DocumentLoader) {
+	t.Helper()
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           bbsSignatureType,
+		SignatureRepresentation: verifiable.SignatureProofValue,
+		Suite: bbsblssignature2020.New(
+			suite.WithSigner(&bbsSigner{privKeyBytes: privKeyBytes}),
+			suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier())),
+		VerificationMethod: bbsKeyID,
+	}, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+}
+
+type bbsSigner struct {
+	privKeyBytes []byte
+}
+
+func (s *bbsSigner) Sign(data []byte) ([]byte, error) {
+	var msgs [][]byte
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) != "" {
+			msgs = append(msgs, []byte(line))
+		}
+	}
+
+	return bbs12381g2pub.New().Sign(msgs, s.privKeyBytes)
+}
+
+func bbsContextLoader(t *testing.T, contextURL string) *ld.CachingDocumentLoader {
+	const jsonLDContext = `{
+    "@context":{
+      "@version":1.1,
+      "@protected":true,
+      "name":"http://schema.org/name",
+      "age":"http://schema.org/age"
+   }
+}`
+
+	reader, err := ld.DocumentFromReader(strings.NewReader(jsonLDContext))
+	require.NoError(t, err)
+
+	loader := verifiable.CachingJSONLDLoader()
+	loader.AddDocument(contextURL, reader)
+
+	for url, file := range map[string]string{
+		bbsContext:                     "bbs2020.jsonld",
+		"https://w3id.org/security/v1": "security_v1.jsonld",
+		"https://w3id.org/security/v2": "security_v2.jsonld",
+	} {
+		contextBytes, err := ioutil.ReadFile(filepath.Join("testdata", "context", file))
+		require.NoError(t, err)
+
+		reader, err = ld.DocumentFromReader(strings.NewReader(string(contextBytes)))
+		require.NoError(t, err)
+
+		loader.AddDocument(url, reader)
+	}
+
+	return loader
+}
@@ -9,6 +9,9 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 )
@@ -36,6 +39,11 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+
+	// ed25519Signature2020 is the type of the proofs whose proof value is multibase encoded.
+	ed25519Signature2020 = "Ed25519Signature2020"
+	// multibaseBase58BTC is the multibase prefix of the base58-btc encoding.
+	multibaseBase58BTC = "z"
 )
 
 // Proof is cryptographic proof of the integrity of the DID Document.
@@ -71,7 +79,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 	)
 
 	if generalProof, ok := emap[jsonldProofValue]; ok {
-		proofValue, err = decodeBase64(stringEntry(generalProof))
+		proofValue, err = decodeProofValue(stringEntry(generalProof), stringEntry(emap[jsonldType]))
 		if err != nil {
 			return nil, err
 		}
@@ -128,6 +136,25 @@ func decodeCapabilityChain(proof map[string]interface{}) ([]interface{}, error)
 	return capabilityChain, nil
 }
 
+// decodeProofValue decodes the proof value, multibase encoded for the Ed25519Signature2020 proofs and base64 encoded
+// otherwise.
+func decodeProofValue(s, proofType string) ([]byte, error) {
+	if proofType != ed25519Signature2020 {
+		return decodeBase64(s)
+	}
+
+	if !strings.HasPrefix(s, multibaseBase58BTC) {
+		return nil, errors.New("unsupported multibase encoding")
+	}
+
+	value := base58.Decode(strings.TrimPrefix(s, multibaseBase58BTC))
+	if len(value) == 0 {
+		return nil, errors.New("invalid base58 encoding")
+	}
+
+	return value, nil
+}
+
 func decodeBase64(s string) ([]byte, error) {
 	allEncodings := []*base64.Encoding{
 		base64.RawURLEncoding, base64.StdEncoding,
@@ -170,7 +197,7 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	}
 
 	if len(p.ProofValue) > 0 {
-		emap[jsonldProofValue] = base64.RawURLEncoding.EncodeToString(p.ProofValue)
+		emap[jsonldProofValue] = encodeProofValue(p.ProofValue, p.Type)
 	}
 
 	if len(p.JWS) > 0 {
@@ -200,6 +227,15 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	return emap
 }
 
+// encodeProofValue encodes the proof value, see decodeProofValue.
+func encodeProofValue(value []byte, proofType string) string {
+	if proofType == ed25519Signature2020 {
+		return multibaseBase58BTC + base58.Encode(value)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
 // PublicKeyID provides ID of public key to be used to independently verify the proof.
 // "verificationMethod" field is checked first. If not empty, its value is returned.
 // Otherwise, "creator" field is returned if not empty. Otherwise, error is returned.
@@ -147,6 +147,32 @@ func TestInvalidProofValue(t *testing.T) {
 	require.Contains(t, err.Error(), "signature is not defined")
 }
 
+func TestMultibaseProofValue(t *testing.T) {
+	p, err := NewProof(map[string]interface{}{
+		"type":       "Ed25519Signature2020",
+		"created":    "2011-09-23T20:21:34Z",
+		"proofValue": "z3FXQjecWufY46yg5abdVZsXqLhxhueuSoZgNSARiKBk",
+	})
+	require.NoError(t, err)
+	require.Equal(t, SignatureProofValue, p.SignatureRepresentation)
+	require.Equal(t, "z3FXQjecWufY46yg5abdVZsXqLhxhueuSoZgNSARiKBk", p.JSONLdObject()["proofValue"])
+
+	// the proof value must be base58-btc encoded
+	_, err = NewProof(map[string]interface{}{
+		"type":       "Ed25519Signature2020",
+		"created":    "2011-09-23T20:21:34Z",
+		"proofValue": proofValueBase64,
+	})
+	require.EqualError(t, err, "unsupported multibase encoding")
+
+	_, err = NewProof(map[string]interface{}{
+		"type":       "Ed25519Signature2020",
+		"created":    "2011-09-23T20:21:34Z",
+		"proofValue": "z0OIl",
+	})
+	require.EqualError(t, err, "invalid base58 encoding")
+}
+
 func TestInvalidNonce(t *testing.T) {
 	p, err := NewProof(map[string]interface{}{
 		"type":       "Ed25519Signature2018",
@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ed25519signature2020 implements the Ed25519Signature2020 signature suite
+// for the Linked Data Signatures [LD-SIGNATURES] specification.
+// It uses the RDF Dataset Normalization Algorithm [RDF-DATASET-NORMALIZATION]
+// to transform the input document into its canonical form.
+// It uses SHA-256 [RFC6234] as the message digest algorithm and
+// Ed25519 [ED25519] as the signature algorithm.
+// Its signatures are put in the "proofValue" of the proofs, multibase encoded (base58-btc).
+package ed25519signature2020
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+)
+
+// Suite implements ed25519 signature suite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+}
+
+const (
+	// SignatureType is the signature type for ed25519 keys.
+	SignatureType = "Ed25519Signature2020"
+	// ContextURL is the URL of the JSON-LD context defining the terms of the suite, which the signed documents
+	// must include.
+	ContextURL    = "https://w3id.org/security/suites/ed25519-2020/v1"
+	rdfDataSetAlg = "URDNA2015"
+)
+
+// New an instance of ed25519 signature suite.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document
+// Ed25519Signature2020 signature SignatureSuite uses RDF Dataset Normalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Accept will accept only ed25519 signature type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
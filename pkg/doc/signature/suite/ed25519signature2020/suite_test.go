@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ed25519signature2020
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{"dc": "http://purl.org/dc/terms/"},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	digest := New().GetDigest([]byte("test doc"))
+	require.Len(t, digest, 32)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("Ed25519Signature2020"))
+	require.False(t, ss.Accept("Ed25519Signature2018"))
+}
+
+func TestSignatureSuite_SignAndVerify(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ss := New(suite.WithSigner(&ed25519Signer{privKey: privKey}), suite.WithVerifier(NewPublicKeyVerifier()))
+
+	doc := []byte("test doc")
+
+	sig, err := ss.Sign(doc)
+	require.NoError(t, err)
+
+	require.NoError(t, ss.Verify(&verifier.PublicKey{Type: kms.ED25519, Value: pubKey}, doc, sig))
+	require.Error(t, ss.Verify(&verifier.PublicKey{Type: kms.ED25519, Value: pubKey}, []byte("other doc"), sig))
+}
+
+type ed25519Signer struct {
+	privKey ed25519.PrivateKey
+}
+
+func (s *ed25519Signer) Sign(doc []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, doc), nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package suiteregistry provides the registry of the signature suites of the linked data proofs, which maps the proof
+// types (e.g. "Ed25519Signature2018") to the suites verifying and creating them.
+//
+// The registry is consumed by the verification of the credentials, the presentations and the DID documents, and by
+// the signing of the controllers: the applications support new proof types by registering their suites, without
+// changes to the framework.
+//
+// 	r := suiteregistry.New() // the suites of the framework
+// 	r.Register("MyProof2021", suiteregistry.Suite{
+// 	  Verifier: func(map[string]interface{}) verifier.SignatureSuite { return myproof.New(myproof.NewVerifier()) },
+// 	})
+//
+// 	vc, err := verifiable.ParseCredential(vcBytes, verifiable.WithSignatureSuiteRegistry(r), ...)
+package suiteregistry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// Proof types of the suites of the framework.
+const (
+	Ed25519Signature2018        = "Ed25519Signature2018"
+	Ed25519Signature2020        = "Ed25519Signature2020"
+	JSONWebSignature2020        = "JsonWebSignature2020"
+	EcdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
+	BbsBlsSignature2020         = "BbsBlsSignature2020"
+	BbsBlsSignatureProof2020    = "BbsBlsSignatureProof2020"
+)
+
+// ErrUnsupported is returned for the proof types without suite, or without signing suite.
+var ErrUnsupported = errors.New("unsupported proof type")
+
+// Signer signs the canonical documents, e.g. with a key of the KMS.
+type Signer interface {
+	Sign(data []byte) ([]byte, error)
+}
+
+// Suite creates the suites of a proof type.
+type Suite struct {
+	// Verifier creates the suite verifying the proof, given as its JSON object (e.g. for its nonce).
+	Verifier func(proof map[string]interface{}) verifier.SignatureSuite
+	// Signer creates the suite signing with s, nil if the proofs of the type are not created by signing (e.g. the
+	// proofs derived from another proof).
+	Signer func(s Signer) signer.SignatureSuite
+}
+
+// Registry maps the proof types to their suites. It's safe for concurrent use.
+type Registry struct {
+	lock   sync.RWMutex
+	suites map[string]Suite
+}
+
+// New returns a registry of the suites of the framework, to which the applications may register their suites.
+func New() *Registry {
+	r := &Registry{suites: map[string]Suite{}}
+
+	r.Register(Ed25519Signature2018, Suite{
+		Verifier: func(map[string]interface{}) verifier.SignatureSuite {
+			return ed25519signature2018.New(suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+		},
+		Signer: func(s Signer) signer.SignatureSuite {
+			return ed25519signature2018.New(suite.WithSigner(s))
+		},
+	})
+	r.Register(Ed25519Signature2020, Suite{
+		Verifier: func(map[string]interface{}) verifier.SignatureSuite {
+			return ed25519signature2020.New(suite.WithVerifier(ed25519signature2020.NewPublicKeyVerifier()))
+		},
+		Signer: func(s Signer) signer.SignatureSuite {
+			return ed25519signature2020.New(suite.WithSigner(s))
+		},
+	})
+	r.Register(JSONWebSignature2020, Suite{
+		Verifier: func(map[string]interface{}) verifier.SignatureSuite {
+			return jsonwebsignature2020.New(suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier()))
+		},
+		Signer: func(s Signer) signer.SignatureSuite {
+			return jsonwebsignature2020.New(suite.WithSigner(s))
+		},
+	})
+	r.Register(EcdsaSecp256k1Signature2019, Suite{
+		Verifier: func(map[string]interface{}) verifier.SignatureSuite {
+			return ecdsasecp256k1signature2019.New(
+				suite.WithVerifier(ecdsasecp256k1signature2019.NewPublicKeyVerifier()))
+		},
+		Signer: func(s Signer) signer.SignatureSuite {
+			return ecdsasecp256k1signature2019.New(suite.WithSigner(s))
+		},
+	})
+	r.Register(BbsBlsSignature2020, Suite{
+		Verifier: func(map[string]interface{}) verifier.SignatureSuite {
+			return bbsblssignature2020.New(suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier()))
+		},
+		Signer: func(s Signer) signer.SignatureSuite {
+			return bbsblssignature2020.New(suite.WithSigner(s))
+		},
+	})
+	r.Register(BbsBlsSignatureProof2020, Suite{
+		Verifier: func(proof map[string]interface{}) verifier.SignatureSuite {
+			nonce, _ := proof["nonce"].(string) // nolint: errcheck
+
+			return bbsblssignatureproof2020.New(
+				suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier([]byte(nonce))))
+		},
+	})
+
+	return r
+}
+
+// Register sets the suite of the proof type, replacing its suite if any (e.g. a suite of the framework).
+func (r *Registry) Register(proofType string, s Suite) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.suites[proofType] = s
+}
+
+// ProofTypes returns the sorted proof types of the registry.
+func (r *Registry) ProofTypes() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	types := make([]string, 0, len(r.suites))
+
+	for t := range r.suites {
+		types = append(types, t)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// Supports tells whether the registry has a suite verifying the proofs of the type.
+func (r *Registry) Supports(proofType string) bool {
+	s, ok := r.suite(proofType)
+
+	return ok && s.Verifier != nil
+}
+
+// Verifier returns the suite verifying the proof, given as its JSON object.
+func (r *Registry) Verifier(proof map[string]interface{}) (verifier.SignatureSuite, error) {
+	proofType, _ := proof["type"].(string) // nolint: errcheck
+
+	s, ok := r.suite(proofType)
+	if !ok || s.Verifier == nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, proof["type"])
+	}
+
+	return s.Verifier(proof), nil
+}
+
+// Verifiers returns the suites verifying the proofs.
+func (r *Registry) Verifiers(proofs []map[string]interface{}) ([]verifier.SignatureSuite, error) {
+	suites := make([]verifier.SignatureSuite, 0, len(proofs))
+
+	for _, proof := range proofs {
+		v, err := r.Verifier(proof)
+		if err != nil {
+			return nil, err
+		}
+
+		suites = append(suites, v)
+	}
+
+	return suites, nil
+}
+
+// Signer returns the suite creating the proofs of the type by signing with s.
+func (r *Registry) Signer(proofType string, s Signer) (signer.SignatureSuite, error) {
+	rs, ok := r.suite(proofType)
+	if !ok || rs.Signer == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, proofType)
+	}
+
+	return rs.Signer(s), nil
+}
+
+func (r *Registry) suite(proofType string) (Suite, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	s, ok := r.suites[proofType]
+
+	return s, ok
+}
+
+// Provider is implemented by the framework context, whose registry holds the suites registered to the framework.
+type Provider interface {
+	SignatureSuites() *Registry
+}
+
+// Of returns the registry of ctx, a registry of the suites of the framework if ctx is not a Provider or has none.
+func Of(ctx interface{}) *Registry {
+	if p, ok := ctx.(Provider); ok {
+		if r := p.SignatureSuites(); r != nil {
+			return r
+		}
+	}
+
+	return New()
+}
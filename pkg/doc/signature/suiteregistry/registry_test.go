@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package suiteregistry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+type testSigner struct{}
+
+func (s *testSigner) Sign(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+func TestRegistry(t *testing.T) {
+	r := New()
+
+	require.Equal(t, []string{
+		BbsBlsSignature2020, BbsBlsSignatureProof2020, EcdsaSecp256k1Signature2019, Ed25519Signature2018,
+		Ed25519Signature2020, JSONWebSignature2020,
+	}, r.ProofTypes())
+
+	for _, proofType := range r.ProofTypes() {
+		require.True(t, r.Supports(proofType))
+
+		v, err := r.Verifier(map[string]interface{}{"type": proofType, "nonce": "nonce"})
+		require.NoError(t, err)
+		require.True(t, v.Accept(proofType))
+
+		if proofType == BbsBlsSignatureProof2020 {
+			_, err = r.Signer(proofType, &testSigner{})
+			require.True(t, errors.Is(err, ErrUnsupported))
+
+			continue
+		}
+
+		s, err := r.Signer(proofType, &testSigner{})
+		require.NoError(t, err)
+		require.True(t, s.Accept(proofType))
+	}
+
+	t.Run("unsupported proof type", func(t *testing.T) {
+		require.False(t, r.Supports("MyProof2021"))
+
+		_, err := r.Verifier(map[string]interface{}{"type": "MyProof2021"})
+		require.EqualError(t, err, "unsupported proof type: MyProof2021")
+		require.True(t, errors.Is(err, ErrUnsupported))
+
+		_, err = r.Verifiers([]map[string]interface{}{{"type": Ed25519Signature2018}, {}})
+		require.True(t, errors.Is(err, ErrUnsupported))
+
+		_, err = r.Signer("MyProof2021", &testSigner{})
+		require.EqualError(t, err, "unsupported proof type: MyProof2021")
+	})
+
+	t.Run("register suite", func(t *testing.T) {
+		r.Register("MyProof2021", Suite{
+			Verifier: func(map[string]interface{}) verifier.SignatureSuite {
+				return ed25519signature2018.New(suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+			},
+			Signer: func(s Signer) signer.SignatureSuite {
+				return ed25519signature2018.New(suite.WithSigner(s))
+			},
+		})
+
+		require.True(t, r.Supports("MyProof2021"))
+		require.Contains(t, r.ProofTypes(), "MyProof2021")
+
+		suites, err := r.Verifiers([]map[string]interface{}{{"type": "MyProof2021"}, {"type": Ed25519Signature2018}})
+		require.NoError(t, err)
+		require.Len(t, suites, 2)
+
+		_, err = r.Signer("MyProof2021", &testSigner{})
+		require.NoError(t, err)
+
+		// the suites of the framework may be replaced
+		r.Register(Ed25519Signature2018, Suite{})
+		require.False(t, r.Supports(Ed25519Signature2018))
+		require.False(t, New().Supports("MyProof2021"))
+	})
+}
+
+type provider struct {
+	registry *Registry
+}
+
+func (p *provider) SignatureSuites() *Registry {
+	return p.registry
+}
+
+func TestOf(t *testing.T) {
+	r := New()
+
+	require.Equal(t, r, Of(&provider{registry: r}))
+	require.NotNil(t, Of(&provider{}))
+	require.True(t, Of(struct{}{}).Supports(Ed25519Signature2018))
+}
@@ -15,9 +15,7 @@ import (
 )
 
 // NewExpirableSchemaCache creates new instance of ExpirableSchemaCache.
-func NewExpirableSchemaCache(size int, expiration time.Duration) *ExpirableSchemaCache {
-	return &ExpirableSchemaCache{
-		cache:      fastcache.New(size),
-		expiration: expiration,
-	}
+func NewExpirableSchemaCache(size int, expiration time.Duration,
+	opts ...ExpirableSchemaCacheOpt) *ExpirableSchemaCache {
+	return newExpirableSchemaCache(fastcache.New(size), expiration, opts)
 }
@@ -9,10 +9,8 @@ package verifiable
 import "time"
 
 // NewExpirableSchemaCache creates new instance of ExpirableSchemaCache.
-func NewExpirableSchemaCache(size int, expiration time.Duration) *ExpirableSchemaCache {
+func NewExpirableSchemaCache(size int, expiration time.Duration,
+	opts ...ExpirableSchemaCacheOpt) *ExpirableSchemaCache {
 	// TODO Add cache implementation for VC wasm https://github.com/hyperledger/aries-framework-go/issues/1009
-	return &ExpirableSchemaCache{
-		cache:      nil,
-		expiration: expiration,
-	}
+	return newExpirableSchemaCache(nil, expiration, opts)
 }
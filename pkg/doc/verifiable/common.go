@@ -22,12 +22,12 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/errcode"
 )
 
-// TODO https://github.com/square/go-jose/issues/263 support ES256K
-
 // JWSAlgorithm defines JWT signature algorithms of Verifiable Credential.
 type JWSAlgorithm int
 
@@ -37,6 +37,12 @@ const (
 
 	// EdDSA JWT Algorithm.
 	EdDSA
+
+	// ES256 JWT Algorithm, ECDSA using P-256 and SHA-256.
+	ES256
+
+	// ES256K JWT Algorithm, ECDSA using secp256k1 and SHA-256.
+	ES256K
 )
 
 // name return the name of the signature algorithm.
@@ -46,6 +52,10 @@ func (ja JWSAlgorithm) name() (string, error) {
 		return "RS256", nil
 	case EdDSA:
 		return "EdDSA", nil
+	case ES256:
+		return "ES256", nil
+	case ES256K:
+		return "ES256K", nil
 	default:
 		return "", fmt.Errorf("unsupported algorithm: %v", ja)
 	}
@@ -102,7 +112,8 @@ func (r *DIDKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 		}
 	}
 
-	return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
+	return nil, errcode.Wrap(did.ErrKeyNotFound,
+		fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID))
 }
 
 // PublicKeyFetcher returns Public Key Fetcher via DID resolution mechanism.
@@ -269,6 +269,7 @@ func TestDIDKeyResolver_Resolve(t *testing.T) {
 	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, "invalid key")
 	r.Error(err)
 	r.EqualError(err, fmt.Sprintf("public key with KID invalid key is not found for DID %s", didDoc.ID))
+	r.True(errors.Is(err, did.ErrKeyNotFound))
 	r.Nil(pubKey)
 
 	v.ResolveErr = errors.New("resolver error")
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -18,10 +20,16 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	commoncache "github.com/hyperledger/aries-framework-go/pkg/common/cache"
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/errcode"
 )
 
 var logger = log.New("aries-framework/doc/verifiable")
@@ -235,6 +243,22 @@ const (
 	vpType = "VerifiablePresentation"
 )
 
+// DefaultClockSkew is the clock skew tolerated by the expiry check of VC if not defined (see WithClockSkew).
+const DefaultClockSkew = time.Minute
+
+// ErrNotYetValid is returned by the expiry check of VC whose issuance date is in the future.
+var ErrNotYetValid = errors.New("credential is not yet valid")
+
+// ErrExpired is returned by the expiry check of VC whose expiration date is passed.
+var ErrExpired = errors.New("credential is expired")
+
+// ErrSchemaValidation is returned when VC or VP does not conform to its JSON schema, to the constraints put on
+// its @context and types or, in strict mode, to its JSON-LD context.
+var ErrSchemaValidation = errors.New("schema validation failed")
+
+// ErrProofInvalid is returned when the proof of VC or VP (JWS or embedded linked data proof) fails to be verified.
+var ErrProofInvalid = errors.New("proof is invalid")
+
 // vcModelValidationMode defines constraint put on context and type of VC.
 type vcModelValidationMode int
 
@@ -290,6 +314,32 @@ type cache interface {
 type ExpirableSchemaCache struct {
 	cache      cache
 	expiration time.Duration
+	clock      clock.Clock
+}
+
+// ExpirableSchemaCacheOpt configures the ExpirableSchemaCache.
+type ExpirableSchemaCacheOpt func(sc *ExpirableSchemaCache)
+
+// WithSchemaCacheClock sets the clock of the expiration of the cached schemas, the clock of the system if not set.
+func WithSchemaCacheClock(c clock.Clock) ExpirableSchemaCacheOpt {
+	return func(sc *ExpirableSchemaCache) {
+		sc.clock = c
+	}
+}
+
+func newExpirableSchemaCache(c cache, expiration time.Duration, opts []ExpirableSchemaCacheOpt) *ExpirableSchemaCache {
+	sc := &ExpirableSchemaCache{
+		cache:      c,
+		expiration: expiration,
+	}
+
+	for _, opt := range opts {
+		opt(sc)
+	}
+
+	sc.clock = clock.OrSystem(sc.clock)
+
+	return sc
 }
 
 // CredentialSchemaLoader defines expirable cache.
@@ -297,6 +347,7 @@ type CredentialSchemaLoader struct {
 	schemaDownloadClient *http.Client
 	cache                SchemaCache
 	jsonLoader           gojsonschema.JSONLoader
+	retryPolicy          retry.Policy
 }
 
 // CredentialSchemaLoaderBuilder defines a builder of CredentialSchemaLoader.
@@ -307,10 +358,17 @@ type CredentialSchemaLoaderBuilder struct {
 // NewCredentialSchemaLoaderBuilder creates a new instance of CredentialSchemaLoaderBuilder.
 func NewCredentialSchemaLoaderBuilder() *CredentialSchemaLoaderBuilder {
 	return &CredentialSchemaLoaderBuilder{
-		loader: &CredentialSchemaLoader{},
+		loader: &CredentialSchemaLoader{retryPolicy: retry.DefaultPolicy()},
 	}
 }
 
+// SetRetryPolicy sets the retry policy of the schema downloads, retry.DefaultPolicy if not set. The retries are
+// disabled with retry.NoRetry.
+func (b *CredentialSchemaLoaderBuilder) SetRetryPolicy(policy retry.Policy) *CredentialSchemaLoaderBuilder {
+	b.loader.retryPolicy = policy
+	return b
+}
+
 // SetSchemaDownloadClient sets HTTP client to be used to download the schema.
 func (b *CredentialSchemaLoaderBuilder) SetSchemaDownloadClient(client *http.Client) *CredentialSchemaLoaderBuilder {
 	b.loader.schemaDownloadClient = client
@@ -347,7 +405,7 @@ func (b *CredentialSchemaLoaderBuilder) Build() *CredentialSchemaLoader {
 
 // Put element to the cache. It also adds a mark of when the element will expire.
 func (sc *ExpirableSchemaCache) Put(k string, v []byte) {
-	expires := time.Now().Add(sc.expiration).Unix()
+	expires := sc.clock.Now().Add(sc.expiration).Unix()
 
 	const numBytesTime = 8
 
@@ -372,7 +430,7 @@ func (sc *ExpirableSchemaCache) Get(k string) ([]byte, bool) {
 	const numBytesTime = 8
 
 	expires := int64(binary.LittleEndian.Uint64(b[:numBytesTime]))
-	if expires < time.Now().Unix() {
+	if expires < sc.clock.Now().Unix() {
 		// cache expires
 		sc.cache.Del([]byte(k))
 		return nil, false
@@ -573,6 +631,14 @@ type credentialOpts struct {
 	disabledProofCheck    bool
 	strictValidation      bool
 	ldpSuites             []verifier.SignatureSuite
+	signatureSuites       *suiteregistry.Registry
+	expiryCheck           bool
+	statusCheck           bool
+	statusVerifier        StatusVerifier
+	clock                 clock.Clock
+	clockSkew             time.Duration
+	jsonLimits            jsonutil.Limits
+	cache                 *commoncache.Cache
 
 	jsonldCredentialOpts
 }
@@ -580,6 +646,24 @@ type credentialOpts struct {
 // CredentialOpt is the Verifiable Credential decoding option.
 type CredentialOpt func(opts *credentialOpts)
 
+// WithJSONLimits sets the limits of the credential JSON, jsonutil.DefaultLimits if not set.
+func WithJSONLimits(limits jsonutil.Limits) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.jsonLimits = limits
+	}
+}
+
+// WithCache sets the cache of the parsed credentials, e.g. the cache of the framework context. The credentials are
+// cached as their JSON once checked, so that parsing them again skips their proof check and their validation, but
+// not their expiry check. The cache also keeps the JSON-LD contexts loaded by the default JSON-LD document loader.
+// The parsers sharing a cache must check the proofs and the contexts of the credentials alike (e.g. with the same
+// VDR), the cached credentials being distinguished by the proof check, validation mode and strictness only.
+func WithCache(c *commoncache.Cache) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.cache = c
+	}
+}
+
 // WithDisabledProofCheck option for disabling of proof check.
 func WithDisabledProofCheck() CredentialOpt {
 	return func(opts *credentialOpts) {
@@ -611,7 +695,9 @@ func WithCredentialSchemaLoader(loader *CredentialSchemaLoader) CredentialOpt {
 	}
 }
 
-// WithJSONLDValidation uses the JSON LD parser for validation.
+// WithJSONLDValidation uses the JSON LD parser for validation: VC is expanded against its contexts, loaded with the
+// document loader of VC (see WithJSONLDDocumentLoader), and rejected if any of its terms is undefined by the contexts
+// and thus dropped by the expansion.
 func WithJSONLDValidation() CredentialOpt {
 	return func(opts *credentialOpts) {
 		opts.modelValidationMode = jsonldValidation
@@ -691,6 +777,79 @@ func WithEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) CredentialOp
 	}
 }
 
+// WithSignatureSuiteRegistry sets the registry of the suites which are used to check the embedded linked data proofs
+// of VC, by their proof type, a registry of the suites of the framework if not set. The suites defined with
+// WithEmbeddedSignatureSuites are used instead of the registry.
+func WithSignatureSuiteRegistry(r *suiteregistry.Registry) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.signatureSuites = r
+	}
+}
+
+// WithExpiryCheck option checks that the credential is valid at the time of the clock (see WithClock): its issuance
+// date isn't in the future and its expiration date isn't passed, the clock skew being tolerated (see WithClockSkew).
+// The issuance and expiration dates of the credentials in JWT are their "nbf" (or "iat") and "exp" claims.
+// The failed checks return ErrNotYetValid or ErrExpired.
+func WithExpiryCheck() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.expiryCheck = true
+	}
+}
+
+// WithStatusCheck option checks the status of the credential with the status verifier (see WithStatusVerifier): by
+// default, the StatusList2021Entry status is checked against its status list credential, fetched over HTTP and parsed
+// with the options of the credential. The status isn't cached with the credential, it's checked on each parsing.
+// The revoked and suspended credentials return ErrRevoked or ErrSuspended.
+func WithStatusCheck() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.statusCheck = true
+	}
+}
+
+// WithStatusVerifier defines the status verifier of the status check of VC (see WithStatusCheck), e.g. a
+// StatusList2021Verifier with a custom fetcher of the status lists.
+func WithStatusVerifier(v StatusVerifier) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.statusVerifier = v
+	}
+}
+
+// withoutStatusCheck disables the status check, e.g. of the status list credentials.
+func withoutStatusCheck() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.statusCheck = false
+	}
+}
+
+// WithClock defines the clock of the expiry check of VC, the clock of the system if not defined.
+func WithClock(c clock.Clock) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.clock = c
+	}
+}
+
+// WithClockSkew defines the clock skew tolerated by the expiry check of VC, DefaultClockSkew if not defined.
+func WithClockSkew(skew time.Duration) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.clockSkew = skew
+	}
+}
+
+// checkExpiry checks the validity period of VC, see WithExpiryCheck.
+func checkExpiry(vc *Credential, vcOpts *credentialOpts) error {
+	now := vcOpts.clock.Now()
+
+	if vc.Issued != nil && now.Add(vcOpts.clockSkew).Before(vc.Issued.Time) {
+		return fmt.Errorf("%w: issued at %s", ErrNotYetValid, vc.Issued.Time.Format(time.RFC3339))
+	}
+
+	if vc.Expired != nil && now.Add(-vcOpts.clockSkew).After(vc.Expired.Time) {
+		return fmt.Errorf("%w: expired at %s", ErrExpired, vc.Expired.Time.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
 // parseIssuer parses raw issuer.
 //
 // Issuer can be defined by:
@@ -783,16 +942,24 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 	// Apply options.
 	vcOpts := getCredentialOpts(opts)
 
-	// Decode credential (e.g. from JWT).
-	vcDataDecoded, err := decodeRaw(vcData, vcOpts)
-	if err != nil {
-		return nil, fmt.Errorf("decode new credential: %w", err)
+	// The credential checked already is decoded from the cache.
+	cacheKey := credentialCacheKey(vcData, vcOpts, true)
+	vcDataDecoded, cached := cachedCredential(vcOpts.cache, cacheKey)
+
+	if !cached {
+		var err error
+
+		// Decode credential (e.g. from JWT).
+		vcDataDecoded, err = decodeRaw(vcData, vcOpts)
+		if err != nil {
+			return nil, fmt.Errorf("decode new credential: %w", err)
+		}
 	}
 
 	// Unmarshal raw credential from JSON.
 	var raw rawCredential
 
-	err = json.Unmarshal(vcDataDecoded, &raw)
+	err := jsonutil.Unmarshal(vcDataDecoded, &raw, vcOpts.jsonLimits)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal new credential: %w", err)
 	}
@@ -803,14 +970,42 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		return nil, fmt.Errorf("build new credential: %w", err)
 	}
 
-	err = validateCredential(vc, vcDataDecoded, vcOpts)
-	if err != nil {
-		return nil, err
+	if !cached {
+		err = validateCredential(vc, vcDataDecoded, vcOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		vcOpts.cache.Put(cacheKey, vcDataDecoded)
+	}
+
+	if vcOpts.expiryCheck {
+		if err = checkExpiry(vc, vcOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.statusCheck {
+		if err = checkStatus(vc, vcOpts, opts); err != nil {
+			return nil, err
+		}
 	}
 
 	return vc, nil
 }
 
+// checkStatus checks the status of VC, see WithStatusCheck. The status list credentials of the default status
+// verifier are parsed with the options of VC.
+func checkStatus(vc *Credential, vcOpts *credentialOpts, opts []CredentialOpt) error {
+	statusVerifier := vcOpts.statusVerifier
+	if statusVerifier == nil {
+		listOpts := append(append([]CredentialOpt{}, opts...), withoutStatusCheck())
+		statusVerifier = NewStatusList2021Verifier(WithStatusListCredentialOpts(listOpts...))
+	}
+
+	return statusVerifier.VerifyStatus(vc)
+}
+
 // ParseUnverifiedCredential parses Verifiable Credential from bytes which could be marshalled JSON or serialized JWT.
 // It does not make a proof check though. Can be used for purposes of decoding of VC stored in a wallet.
 // Please use this function with caution.
@@ -818,15 +1013,24 @@ func ParseUnverifiedCredential(vcBytes []byte, opts ...CredentialOpt) (*Credenti
 	vcOpts := getCredentialOpts(opts)
 	vcOpts.disabledProofCheck = true
 
-	vcDataDecoded, err := decodeRaw(vcBytes, vcOpts)
-	if err != nil {
-		return nil, fmt.Errorf("decode new credential: %w", err)
+	cacheKey := credentialCacheKey(vcBytes, vcOpts, false)
+	vcDataDecoded, cached := cachedCredential(vcOpts.cache, cacheKey)
+
+	if !cached {
+		var err error
+
+		vcDataDecoded, err = decodeRaw(vcBytes, vcOpts)
+		if err != nil {
+			return nil, fmt.Errorf("decode new credential: %w", err)
+		}
+
+		vcOpts.cache.Put(cacheKey, vcDataDecoded)
 	}
 
 	// Unmarshal raw credential from JSON.
 	var raw rawCredential
 
-	err = json.Unmarshal(vcDataDecoded, &raw)
+	err := jsonutil.Unmarshal(vcDataDecoded, &raw, vcOpts.jsonLimits)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal new credential: %w", err)
 	}
@@ -840,6 +1044,35 @@ func ParseUnverifiedCredential(vcBytes []byte, opts ...CredentialOpt) (*Credenti
 	return vc, nil
 }
 
+// credentialCacheKey returns the key of the credential decoded with the options (and validated if validated is true),
+// empty if the options have no cache.
+func credentialCacheKey(vcData []byte, vcOpts *credentialOpts, validated bool) string {
+	if vcOpts.cache == nil {
+		return ""
+	}
+
+	digest := sha256.Sum256(vcData)
+
+	if !validated {
+		return fmt.Sprintf("%s%x:unverified", commoncache.CredentialKeyPrefix, digest)
+	}
+
+	return fmt.Sprintf("%s%x:%t:%t:%d", commoncache.CredentialKeyPrefix, digest,
+		vcOpts.disabledProofCheck, vcOpts.strictValidation, vcOpts.modelValidationMode)
+}
+
+// cachedCredential returns the decoded JSON of the cached credential.
+func cachedCredential(c *commoncache.Cache, key string) ([]byte, bool) {
+	v, ok := c.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	vcDataDecoded, ok := v.([]byte)
+
+	return vcDataDecoded, ok
+}
+
 func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts) error {
 	// Credential and type constraint.
 	switch vcOpts.modelValidationMode {
@@ -856,7 +1089,7 @@ func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts)
 		return vc.validateJSONLD(vcBytes, vcOpts)
 
 	case jsonldValidation:
-		return vc.validateJSONLD(vcBytes, vcOpts)
+		return validateJSONLDTerms(string(vcBytes), &vcOpts.jsonldCredentialOpts, vcOpts.strictValidation)
 
 	case baseContextValidation:
 		return validateBaseContext(vc, vcBytes, vcOpts)
@@ -871,11 +1104,12 @@ func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts)
 
 func validateBaseContext(vc *Credential, vcBytes []byte, vcOpts *credentialOpts) error {
 	if len(vc.Types) > 1 || vc.Types[0] != vcType {
-		return errors.New("violated type constraint: not base only type defined")
+		return errcode.Wrap(ErrSchemaValidation, errors.New("violated type constraint: not base only type defined"))
 	}
 
 	if len(vc.Context) > 1 || vc.Context[0] != baseContext {
-		return errors.New("violated @context constraint: not base only @context defined")
+		return errcode.Wrap(ErrSchemaValidation,
+			errors.New("violated @context constraint: not base only @context defined"))
 	}
 
 	return vc.validateJSONSchema(vcBytes, vcOpts)
@@ -884,13 +1118,13 @@ func validateBaseContext(vc *Credential, vcBytes []byte, vcOpts *credentialOpts)
 func validateBaseContextWithExtendedValidation(vc *Credential, vcOpts *credentialOpts, vcBytes []byte) error {
 	for _, vcContext := range vc.Context {
 		if _, ok := vcOpts.allowedCustomContexts[vcContext]; !ok {
-			return fmt.Errorf("not allowed @context: %s", vcContext)
+			return errcode.Wrap(ErrSchemaValidation, fmt.Errorf("not allowed @context: %s", vcContext))
 		}
 	}
 
 	for _, vcType := range vc.Types {
 		if _, ok := vcOpts.allowedCustomTypes[vcType]; !ok {
-			return fmt.Errorf("not allowed type: %s", vcType)
+			return errcode.Wrap(ErrSchemaValidation, fmt.Errorf("not allowed type: %s", vcType))
 		}
 	}
 
@@ -1030,6 +1264,10 @@ func parseTypedID(bytes json.RawMessage) ([]TypedID, error) {
 }
 
 func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, error) {
+	if err := jsonutil.Check(vcData, vcOpts.jsonLimits); err != nil {
+		return nil, err
+	}
+
 	vcStr := string(vcData)
 
 	if jwt.IsJWS(vcStr) { // External proof, is checked by JWS.
@@ -1063,6 +1301,7 @@ func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
 		publicKeyFetcher:     vcOpts.publicKeyFetcher,
 		disabledProofCheck:   vcOpts.disabledProofCheck,
 		ldpSuites:            vcOpts.ldpSuites,
+		signatureSuites:      vcOpts.signatureSuites,
 		jsonldCredentialOpts: vcOpts.jsonldCredentialOpts,
 	}
 }
@@ -1070,6 +1309,8 @@ func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
 func getCredentialOpts(opts []CredentialOpt) *credentialOpts {
 	crOpts := &credentialOpts{
 		modelValidationMode: combinedValidation,
+		clockSkew:           DefaultClockSkew,
+		jsonLimits:          jsonutil.DefaultLimits(),
 	}
 
 	for _, opt := range opts {
@@ -1081,9 +1322,11 @@ func getCredentialOpts(opts []CredentialOpt) *credentialOpts {
 	}
 
 	if crOpts.jsonldDocumentLoader == nil {
-		crOpts.jsonldDocumentLoader = CachingJSONLDLoader()
+		crOpts.jsonldDocumentLoader = commoncache.JSONLDLoader(crOpts.cache, CachingJSONLDLoader())
 	}
 
+	crOpts.clock = clock.OrSystem(crOpts.clock)
+
 	return crOpts
 }
 
@@ -1091,6 +1334,7 @@ func newDefaultSchemaLoader() *CredentialSchemaLoader {
 	return &CredentialSchemaLoader{
 		schemaDownloadClient: &http.Client{},
 		jsonLoader:           defaultSchemaLoader(),
+		retryPolicy:          retry.DefaultPolicy(),
 	}
 }
 
@@ -1200,7 +1444,7 @@ func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *cre
 
 	if !result.Valid() {
 		errMsg := describeSchemaValidationError(result, "verifiable credential")
-		return errors.New(errMsg)
+		return errcode.Wrap(ErrSchemaValidation, errors.New(errMsg))
 	}
 
 	return nil
@@ -1238,7 +1482,7 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 	cache := loader.cache
 
 	if cache == nil {
-		return loadJSONSchema(url, loader.schemaDownloadClient)
+		return loadJSONSchema(url, loader)
 	}
 
 	// Check the cache first.
@@ -1246,7 +1490,7 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 		return cachedBytes, nil
 	}
 
-	schemaBytes, err := loadJSONSchema(url, loader.schemaDownloadClient)
+	schemaBytes, err := loadJSONSchema(url, loader)
 	if err != nil {
 		return nil, err
 	}
@@ -1257,7 +1501,21 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 	return schemaBytes, nil
 }
 
-func loadJSONSchema(url string, client *http.Client) ([]byte, error) {
+func loadJSONSchema(url string, loader *CredentialSchemaLoader) ([]byte, error) {
+	var schemaBytes []byte
+
+	err := retry.Do(context.Background(), loader.retryPolicy, func() error {
+		var getErr error
+
+		schemaBytes, getErr = getJSONSchemaOnce(url, loader.schemaDownloadClient)
+
+		return getErr
+	})
+
+	return schemaBytes, err
+}
+
+func getJSONSchemaOnce(url string, client *http.Client) ([]byte, error) {
 	resp, err := client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("load credential schema: %w", err)
@@ -1271,7 +1529,12 @@ func loadJSONSchema(url string, client *http.Client) ([]byte, error) {
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("credential schema endpoint HTTP failure [%v]", resp.StatusCode)
+		err = fmt.Errorf("credential schema endpoint HTTP failure [%v]", resp.StatusCode)
+		if !retry.RetryableStatus(resp.StatusCode) {
+			err = retry.Permanent(err)
+		}
+
+		return nil, err
 	}
 
 	var gotBody []byte
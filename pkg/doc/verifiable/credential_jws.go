@@ -5,6 +5,20 @@ SPDX-License-Identifier: Apache-2.0
 
 package verifiable
 
+import "fmt"
+
+// MarshalJWS serializes the credential into a signed JWT (JWS) of its claims, including the whole credential, with
+// the key ID in the "kid" header. The Signer must produce the signature of the algorithm, e.g. the concatenated R and S
+// of the ECDSA signatures for ES256 and ES256K.
+func (vc *Credential) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
+	jwtClaims, err := vc.JWTClaims(false)
+	if err != nil {
+		return "", fmt.Errorf("build JWT claims: %w", err)
+	}
+
+	return jwtClaims.MarshalJWS(signatureAlg, signer, keyID)
+}
+
 // MarshalJWS serializes JWT into signed form (JWS).
 func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
 	return marshalJWS(jcc, signatureAlg, signer, keyID)
@@ -9,6 +9,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/square/go-jose/v3"
@@ -50,6 +51,60 @@ func TestJWTCredClaimsMarshalJWS(t *testing.T) {
 	})
 }
 
+func TestCredential_MarshalJWS(t *testing.T) {
+	vc, err := parseTestCredential([]byte(validCredential))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		alg     JWSAlgorithm
+		keyType kms.KeyType
+	}{
+		{name: "EdDSA", alg: EdDSA, keyType: kms.ED25519Type},
+		{name: "ES256", alg: ES256, keyType: kms.ECDSAP256TypeIEEEP1363},
+		{name: "ES256K", alg: ES256K, keyType: kms.ECDSASecp256k1TypeIEEEP1363},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := newCryptoSigner(tc.keyType)
+			require.NoError(t, err)
+
+			jws, err := vc.MarshalJWS(tc.alg, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1")
+			require.NoError(t, err)
+
+			parsedVC, err := parseTestCredential([]byte(jws),
+				WithPublicKeyFetcher(func(issuerID, keyID string) (*verifier.PublicKey, error) {
+					require.Equal(t, vc.Issuer.ID, issuerID)
+					require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1", keyID)
+
+					return &verifier.PublicKey{Type: string(tc.keyType), Value: signer.PublicKeyBytes()}, nil
+				}))
+			require.NoError(t, err)
+			require.Equal(t, vc.ID, parsedVC.ID)
+
+			_, err = parseTestCredential([]byte(jws), WithPublicKeyFetcher(
+				func(string, string) (*verifier.PublicKey, error) {
+					otherSigner, err := newCryptoSigner(tc.keyType)
+					require.NoError(t, err)
+
+					return &verifier.PublicKey{Type: string(tc.keyType), Value: otherSigner.PublicKeyBytes()}, nil
+				}))
+			require.True(t, errors.Is(err, ErrProofInvalid))
+		})
+	}
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = vc.MarshalJWS(JWSAlgorithm(-1), signer, "any")
+		require.EqualError(t, err, "unsupported algorithm: -1")
+	})
+}
+
 type invalidCredClaims struct {
 	*jwt.Claims
 
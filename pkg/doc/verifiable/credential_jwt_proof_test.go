@@ -98,6 +98,7 @@ func TestParseCredentialFromJWS(t *testing.T) {
 
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "JWS decoding: unmarshal VC JWT claims")
+		require.True(t, errors.Is(err, ErrProofInvalid))
 		require.Nil(t, vc)
 	})
 
@@ -27,3 +27,40 @@ func (vc *Credential) AddLinkedDataProof(context *LinkedDataProofContext, jsonld
 
 	return nil
 }
+
+// SignLinkedDataProof appends a proof of the type (e.g. "Ed25519Signature2018" or "Ed25519Signature2020") signed
+// with the signer, the suite of the type being taken from the suite registry (see WithProofSuiteRegistry).
+// The verification method of the proof is required (see WithProofVerificationMethod), its purpose is
+// "assertionMethod" and its creation time the current time unless defined by the options.
+// The JSON-LD context of the proof type is added to VC if missing (e.g. for Ed25519Signature2020).
+func (vc *Credential) SignLinkedDataProof(proofType string, s Signer, opts ...LinkedDataProofOpt) error {
+	ldpOpts, err := getLinkedDataProofOpts(proofType, s, vc.Issuer.ID, opts)
+	if err != nil {
+		return fmt.Errorf("add linked data proof to VC: %w", err)
+	}
+
+	vcContext := vc.Context
+
+	if c := proofContext(proofType); c != "" && !hasContext(vc.Context, c) {
+		vc.Context = append(append([]string{}, vc.Context...), c)
+	}
+
+	err = vc.AddLinkedDataProof(&ldpOpts.context, ldpOpts.jsonldOpts...)
+	if err != nil {
+		vc.Context = vcContext
+
+		return err
+	}
+
+	return nil
+}
+
+func hasContext(contexts []string, context string) bool {
+	for _, c := range contexts {
+		if c == context {
+			return true
+		}
+	}
+
+	return false
+}
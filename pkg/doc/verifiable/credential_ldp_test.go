@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -30,9 +31,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 )
 
 func TestParseCredentialFromLinkedDataProof_Ed25519Signature2018(t *testing.T) {
@@ -68,6 +71,73 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2018(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+type countingVerifier struct {
+	verifications int
+}
+
+func (v *countingVerifier) Verify(pubKey *sigverifier.PublicKey, msg, signature []byte) error {
+	v.verifications++
+
+	return ed25519signature2018.NewPublicKeyVerifier().Verify(pubKey, msg, signature)
+}
+
+func TestParseCredentialFromLinkedDataProof_SignatureSuiteRegistry(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	registry := suiteregistry.New()
+
+	sigSuite, err := registry.Signer(suiteregistry.Ed25519Signature2018, signer)
+	r.NoError(err)
+
+	vc, err := parseTestCredential([]byte(validCredential))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           suiteregistry.Ed25519Signature2018,
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonld.WithDocumentLoader(createTestJSONLDDocumentLoader()))
+	r.NoError(err)
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	// the proof is checked by the suite of the registry
+	v := &countingVerifier{}
+	registry.Register(suiteregistry.Ed25519Signature2018, suiteregistry.Suite{
+		Verifier: func(map[string]interface{}) sigverifier.SignatureSuite {
+			return ed25519signature2018.New(suite.WithVerifier(v))
+		},
+	})
+
+	vcWithLdp, err := parseTestCredential(vcBytes,
+		WithSignatureSuiteRegistry(registry),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	r.NoError(err)
+	r.Equal(vc, vcWithLdp)
+	r.Equal(1, v.verifications)
+
+	// the suite of the options is used instead of the registry
+	_, err = parseTestCredential(vcBytes,
+		WithSignatureSuiteRegistry(registry),
+		WithEmbeddedSignatureSuites(ed25519signature2018.New(
+			suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	r.NoError(err)
+	r.Equal(1, v.verifications)
+
+	registry.Register(suiteregistry.Ed25519Signature2018, suiteregistry.Suite{})
+
+	_, err = parseTestCredential(vcBytes,
+		WithSignatureSuiteRegistry(registry),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	r.True(errors.Is(err, suiteregistry.ErrUnsupported))
+}
+
 //nolint:lll
 func TestParseCredentialFromLinkedDataProof_JSONLD_Validation(t *testing.T) {
 	r := require.New(t)
@@ -164,6 +234,7 @@ func TestParseCredentialFromLinkedDataProof_JSONLD_Validation(t *testing.T) {
 		vcWithLdp, err := parseTestCredential([]byte(vcJSON), vcOptions...)
 		r.Error(err)
 		r.EqualError(err, "JSON-LD doc has different structure after compaction")
+		r.True(errors.Is(err, ErrSchemaValidation))
 		r.Nil(vcWithLdp)
 	})
 
@@ -396,6 +467,7 @@ func TestExtraContextWithLDP(t *testing.T) {
 		WithStrictValidation())
 	r.Error(err)
 	r.EqualError(err, "decode new credential: check embedded proof: check linked data proof: invalid JSON-LD context")
+	r.True(errors.Is(err, ErrProofInvalid))
 	r.Nil(vcWithLdp)
 
 	// Use extra context.
@@ -1318,3 +1390,93 @@ func (s *bbsSigner) textToLines(txt string) [][]byte {
 
 	return linesBytes
 }
+
+func TestCredential_SignLinkedDataProof(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	created := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, proofType := range []string{"Ed25519Signature2018", "Ed25519Signature2020"} {
+		proofType := proofType
+
+		t.Run(proofType, func(t *testing.T) {
+			vc, err := parseTestCredential([]byte(validCredential))
+			r.NoError(err)
+
+			err = vc.SignLinkedDataProof(proofType, signer, WithProofVerificationMethod("#key-1"),
+				WithProofCreated(created), WithProofJSONLDOpts(jsonld.WithDocumentLoader(testDocumentLoader)))
+			r.NoError(err)
+
+			r.Len(vc.Proofs, 1)
+			r.Equal(proofType, vc.Proofs[0]["type"])
+			r.Equal("did:example:76e12ec712ebc6f1c221ebfeb1f#key-1", vc.Proofs[0]["verificationMethod"])
+			r.Equal("assertionMethod", vc.Proofs[0]["proofPurpose"])
+			r.Equal("2021-03-01T10:00:00Z", vc.Proofs[0]["created"])
+
+			if proofType == "Ed25519Signature2020" {
+				r.Equal("https://w3id.org/security/suites/ed25519-2020/v1", vc.Context[len(vc.Context)-1])
+				r.True(strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "z"))
+			}
+
+			vcBytes, err := json.Marshal(vc)
+			r.NoError(err)
+
+			_, err = parseTestCredential(vcBytes,
+				WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+			r.NoError(err)
+
+			// the proof is checked
+			vc.ID = "http://example.edu/credentials/1873"
+
+			vcBytes, err = json.Marshal(vc)
+			r.NoError(err)
+
+			_, err = parseTestCredential(vcBytes,
+				WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+			r.True(errors.Is(err, ErrProofInvalid))
+		})
+	}
+
+	t.Run("JWS proof with options", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential))
+		r.NoError(err)
+
+		err = vc.SignLinkedDataProof("Ed25519Signature2018", signer,
+			WithProofVerificationMethod("did:example:xyz#key-1"), WithProofPurpose("authentication"),
+			WithProofChallenge("challenge"), WithProofDomain("issuer.service.com"),
+			WithProofSignatureRepresentation(SignatureJWS), WithProofSuiteRegistry(suiteregistry.New()),
+			WithProofClock(mockclock.New(created.Add(90*time.Minute+500*time.Millisecond))),
+			WithProofJSONLDOpts(jsonld.WithDocumentLoader(testDocumentLoader)))
+		r.NoError(err)
+
+		r.Len(vc.Proofs, 1)
+		r.Contains(vc.Proofs[0], "jws")
+		r.Equal("authentication", vc.Proofs[0]["proofPurpose"])
+		r.Equal("challenge", vc.Proofs[0]["challenge"])
+		r.Equal("issuer.service.com", vc.Proofs[0]["domain"])
+		r.Equal("did:example:xyz#key-1", vc.Proofs[0]["verificationMethod"])
+		// the proof is created at the time of the clock, truncated to the second
+		r.Equal("2021-03-01T11:30:00Z", vc.Proofs[0]["created"])
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential))
+		r.NoError(err)
+
+		err = vc.SignLinkedDataProof("Ed25519Signature2018", signer)
+		r.EqualError(err, "add linked data proof to VC: verification method is required")
+
+		err = vc.SignLinkedDataProof("BbsBlsSignatureProof2020", signer, WithProofVerificationMethod("#key-1"))
+		r.True(errors.Is(err, suiteregistry.ErrUnsupported))
+
+		vc.Issuer.ID = "https://example.edu/issuers/14"
+
+		err = vc.SignLinkedDataProof("Ed25519Signature2020", signer, WithProofVerificationMethod("#key-1"))
+		r.EqualError(err, `add linked data proof to VC: relative verification method #key-1 of issuer `+
+			`"https://example.edu/issuers/14" which isn't a DID`)
+		r.Empty(vc.Proofs)
+	})
+}
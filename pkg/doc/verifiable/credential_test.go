@@ -7,8 +7,10 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,12 +18,16 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/xeipuuv/gojsonschema"
 
+	commoncache "github.com/hyperledger/aries-framework-go/pkg/common/cache"
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 )
 
 const singleCredentialSubject = `
@@ -873,6 +879,62 @@ func TestWithEmbeddedSignatureSuites(t *testing.T) {
 	require.Equal(t, []verifier.SignatureSuite{ss}, opts.ldpSuites)
 }
 
+func TestWithExpiryCheck(t *testing.T) {
+	// the credential is valid from 2010-01-01T19:23:24Z to 2020-01-01T19:23:24Z
+	clock := mockclock.New(time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	vc, err := parseTestCredential([]byte(validCredential), WithExpiryCheck(), WithClock(clock))
+	require.NoError(t, err)
+	require.NotNil(t, vc)
+
+	t.Run("expired credential", func(t *testing.T) {
+		clock.Set(time.Date(2020, time.January, 1, 19, 24, 0, 0, time.UTC))
+
+		// the clock skew is tolerated
+		_, err = parseTestCredential([]byte(validCredential), WithExpiryCheck(), WithClock(clock))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential([]byte(validCredential), WithExpiryCheck(), WithClock(clock),
+			WithClockSkew(time.Second))
+		require.True(t, errors.Is(err, ErrExpired))
+		require.EqualError(t, err, "credential is expired: expired at 2020-01-01T19:23:24Z")
+
+		// the expiry isn't checked by default
+		_, err = parseTestCredential([]byte(validCredential))
+		require.NoError(t, err)
+	})
+
+	t.Run("credential not yet valid", func(t *testing.T) {
+		clock.Set(time.Date(2010, time.January, 1, 19, 23, 0, 0, time.UTC))
+
+		_, err = parseTestCredential([]byte(validCredential), WithExpiryCheck(), WithClock(clock))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential([]byte(validCredential), WithExpiryCheck(), WithClock(clock), WithClockSkew(0))
+		require.True(t, errors.Is(err, ErrNotYetValid))
+	})
+
+	t.Run("credential in JWT", func(t *testing.T) {
+		vc.Expired = nil
+
+		claims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		jwtVC, err := claims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		clock.Set(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+		_, err = parseTestCredential([]byte(jwtVC), WithExpiryCheck(), WithClock(clock))
+		require.NoError(t, err)
+
+		clock.Set(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+		_, err = parseTestCredential([]byte(jwtVC), WithExpiryCheck(), WithClock(clock))
+		require.True(t, errors.Is(err, ErrNotYetValid))
+	})
+}
+
 func TestCustomCredentialJsonSchemaValidator2018(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 		rawMap := make(map[string]interface{})
@@ -1033,17 +1095,18 @@ func TestDownloadCustomSchema(t *testing.T) {
 		require.Equal(t, 1, loadsCount)
 
 		// Check for cache expiration.
+		clock := mockclock.New(time.Now())
 		withCacheOpts = &credentialOpts{schemaLoader: &CredentialSchemaLoader{
 			schemaDownloadClient: httpClient,
 			jsonLoader:           gojsonschema.NewStringLoader(defaultSchema),
-			cache:                NewExpirableSchemaCache(32*1024*1024, time.Second),
+			cache:                NewExpirableSchemaCache(32*1024*1024, time.Second, WithSchemaCacheClock(clock)),
 		}}
 		loadsCount = 0
 		customSchema4, err := getJSONSchema(testServer.URL, withCacheOpts)
 		require.NoError(t, err)
 		require.Equal(t, []byte("custom schema"), customSchema4)
 
-		time.Sleep(2 * time.Second)
+		clock.Add(2 * time.Second)
 		customSchema5, err := getJSONSchema(testServer.URL, withCacheOpts)
 		require.NoError(t, err)
 		require.Equal(t, []byte("custom schema"), customSchema5)
@@ -1076,6 +1139,44 @@ func TestDownloadCustomSchema(t *testing.T) {
 		require.Contains(t, err.Error(), "credential schema endpoint HTTP failure")
 		require.Nil(t, customSchema)
 	})
+
+	t.Run("HTTP GET request to download custom credentialSchema is retried", func(t *testing.T) {
+		loads := 0
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			loads++
+
+			if loads == 1 {
+				res.WriteHeader(http.StatusServiceUnavailable)
+
+				return
+			}
+
+			_, err := res.Write([]byte("custom schema"))
+			require.NoError(t, err)
+		}))
+
+		defer func() { testServer.Close() }()
+
+		retryPolicy := retry.DefaultPolicy()
+		retryPolicy.InitialInterval = time.Millisecond
+
+		loader := NewCredentialSchemaLoaderBuilder().SetRetryPolicy(retryPolicy).Build()
+
+		customSchema, err := getJSONSchema(testServer.URL, &credentialOpts{schemaLoader: loader})
+		require.NoError(t, err)
+		require.Equal(t, []byte("custom schema"), customSchema)
+		require.Equal(t, 2, loads)
+
+		// the retries are disabled
+		loads = 0
+
+		loader = NewCredentialSchemaLoaderBuilder().SetRetryPolicy(retry.NoRetry()).Build()
+
+		_, err = getJSONSchema(testServer.URL, &credentialOpts{schemaLoader: loader})
+		require.Error(t, err)
+		require.Equal(t, 1, loads)
+	})
 }
 
 func Test_SubjectID(t *testing.T) {
@@ -1522,6 +1623,62 @@ func TestContextToSerialize(t *testing.T) {
 			}))
 }
 
+func TestParseCredentialJSONLimits(t *testing.T) {
+	_, err := ParseCredential([]byte(validCredential), WithJSONLimits(jsonutil.Limits{MaxDepth: 2}))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+
+	_, err = ParseUnverifiedCredential([]byte(validCredential), WithJSONLimits(jsonutil.Limits{MaxBytes: 10}))
+	require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+
+	_, err = ParseCredential([]byte(`{"a": `+strings.Repeat("[", 100)+`}`), WithDisabledProofCheck())
+	require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+}
+
+func TestParseCredentialWithCache(t *testing.T) {
+	c := commoncache.New()
+
+	vc, err := parseTestCredential([]byte(validCredential), WithCache(c))
+	require.NoError(t, err)
+	require.Equal(t, 1, c.Len())
+
+	// the cached credential is not decoded and validated again
+	key := credentialCacheKey([]byte(validCredential), getCredentialOpts([]CredentialOpt{WithCache(c)}), true)
+	cachedVC, ok := c.Get(key)
+	require.True(t, ok)
+	c.Put(key, []byte(strings.Replace(string(cachedVC.([]byte)), vc.ID, "http://example.edu/credentials/cached", 1)))
+
+	vc, err = parseTestCredential([]byte(validCredential), WithCache(c))
+	require.NoError(t, err)
+	require.Equal(t, "http://example.edu/credentials/cached", vc.ID)
+
+	t.Run("credential parsed with other options", func(t *testing.T) {
+		vc, err = parseTestCredential([]byte(validCredential), WithCache(c), WithStrictValidation())
+		require.NoError(t, err)
+		require.NotEqual(t, "http://example.edu/credentials/cached", vc.ID)
+
+		vc, err = ParseUnverifiedCredential([]byte(validCredential), WithCache(c))
+		require.NoError(t, err)
+		require.NotEqual(t, "http://example.edu/credentials/cached", vc.ID)
+		require.Equal(t, 3, c.Len())
+	})
+
+	t.Run("expiry of the cached credential is checked", func(t *testing.T) {
+		clock := mockclock.New(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+		_, err = parseTestCredential([]byte(validCredential), WithCache(c), WithExpiryCheck(), WithClock(clock))
+		require.True(t, errors.Is(err, ErrExpired))
+	})
+
+	t.Run("invalid credential is not cached", func(t *testing.T) {
+		c.Clear()
+
+		_, err = parseTestCredential([]byte(`{"id": "http://example.edu/credentials/1872"}`), WithCache(c))
+		require.Error(t, err)
+		require.Equal(t, 0, c.Len())
+	})
+}
+
 func TestParseCredentialFromRaw(t *testing.T) {
 	issuer, err := json.Marshal("did:example:76e12ec712ebc6f1c221ebfeb1f")
 	require.NoError(t, err)
@@ -1659,6 +1816,11 @@ func TestCredential_validateCredential(t *testing.T) {
 			"referenceNumber": 83294847,
 		}
 		r.Error(validateCredential(&Credential{}, vc.byteJSON(t), vcOpts))
+
+		// the undefined terms are rejected in non-strict mode too
+		vcOpts.strictValidation = false
+		err = validateCredential(&Credential{}, vc.byteJSON(t), vcOpts)
+		r.EqualError(err, "JSON-LD doc has undefined terms: referenceNumber")
 	})
 
 	t.Run("test baseContextValidation constraint", func(t *testing.T) {
@@ -1678,6 +1840,7 @@ func TestCredential_validateCredential(t *testing.T) {
 			&credentialOpts{modelValidationMode: baseContextValidation})
 		r.Error(err)
 		r.EqualError(err, "violated type constraint: not base only type defined")
+		r.True(errors.Is(err, ErrSchemaValidation))
 
 		vc.Types = []string{"UniversityDegreeCredential"}
 		vc.Context = []string{"https://www.w3.org/2018/credentials/v1"}
@@ -1741,6 +1904,7 @@ func TestCredential_validateCredential(t *testing.T) {
 			})
 		r.Error(err)
 		r.EqualError(err, "not allowed type: UniversityDegreeCredential")
+		r.True(errors.Is(err, ErrSchemaValidation))
 
 		vc.Types = []string{"VerifiableCredential", "AlumniCredential"}
 		vc.Context = []string{"https://www.w3.org/2018/credentials/v1", "https://www.exaple.org/udc/v1"}
@@ -11,44 +11,40 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
-	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
-	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
-	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
-	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
-	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
-	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/errcode"
 )
 
-const (
-	ed25519Signature2018        = "Ed25519Signature2018"
-	jsonWebSignature2020        = "JsonWebSignature2020"
-	ecdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
-	bbsBlsSignature2020         = "BbsBlsSignature2020"
-	bbsBlsSignatureProof2020    = "BbsBlsSignatureProof2020"
-)
-
-func getProofType(proofMap map[string]interface{}) (string, error) {
+// getProofType returns the type of the proof, supported if the suites or the registry accept it.
+func getProofType(proofMap map[string]interface{}, suites []verifier.SignatureSuite,
+	registry *suiteregistry.Registry) (string, error) {
 	proofType, ok := proofMap["type"]
 	if !ok {
 		return "", errors.New("proof type is missing")
 	}
 
 	proofTypeStr := safeStringValue(proofType)
-	switch proofTypeStr {
-	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
-		bbsBlsSignature2020, bbsBlsSignatureProof2020:
-		return proofTypeStr, nil
-	default:
-		return "", fmt.Errorf("unsupported proof type: %s", proofType)
+
+	for _, s := range suites {
+		if s.Accept(proofTypeStr) {
+			return proofTypeStr, nil
+		}
 	}
+
+	if !registry.Supports(proofTypeStr) {
+		return "", fmt.Errorf("%w: %s", suiteregistry.ErrUnsupported, proofType)
+	}
+
+	return proofTypeStr, nil
 }
 
 type embeddedProofCheckOpts struct {
 	publicKeyFetcher   PublicKeyFetcher
 	disabledProofCheck bool
 
-	ldpSuites []verifier.SignatureSuite
+	ldpSuites       []verifier.SignatureSuite
+	signatureSuites *suiteregistry.Registry
 
 	jsonldCredentialOpts
 }
@@ -94,54 +90,41 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte,
 
 	err = checkLinkedDataProof(checkedDoc, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts)
 	if err != nil {
-		return nil, fmt.Errorf("check embedded proof: %w", err)
+		return nil, fmt.Errorf("check embedded proof: %w", errcode.Wrap(ErrProofInvalid, err))
 	}
 
 	return docBytes, nil
 }
 
+// getSuites returns the suites checking the proofs: the suites of the options if any, otherwise the suites of the
+// registry of the options, or of the framework if none.
 func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([]verifier.SignatureSuite, error) {
 	ldpSuites := opts.ldpSuites
 
+	registry := opts.signatureSuites
+	if registry == nil {
+		registry = suiteregistry.New()
+	}
+
 	for i := range proofs {
-		t, err := getProofType(proofs[i])
+		_, err := getProofType(proofs[i], opts.ldpSuites, registry)
 		if err != nil {
 			return nil, fmt.Errorf("check embedded proof: %w", err)
 		}
 
 		if len(opts.ldpSuites) == 0 {
-			switch t {
-			case ed25519Signature2018:
-				ldpSuites = append(ldpSuites, ed25519signature2018.New(
-					suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier())))
-			case jsonWebSignature2020:
-				ldpSuites = append(ldpSuites, jsonwebsignature2020.New(
-					suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier())))
-			case ecdsaSecp256k1Signature2019:
-				ldpSuites = append(ldpSuites, ecdsasecp256k1signature2019.New(
-					suite.WithVerifier(ecdsasecp256k1signature2019.NewPublicKeyVerifier())))
-			case bbsBlsSignature2020:
-				ldpSuites = append(ldpSuites, bbsblssignature2020.New(
-					suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier())))
-			case bbsBlsSignatureProof2020:
-				nonce := []byte(getNonce(proofs[i]))
-				ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
-					suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
+			s, err := registry.Verifier(proofs[i])
+			if err != nil {
+				return nil, fmt.Errorf("check embedded proof: %w", err)
 			}
+
+			ldpSuites = append(ldpSuites, s)
 		}
 	}
 
 	return ldpSuites, nil
 }
 
-func getNonce(proof map[string]interface{}) string {
-	if nonce, ok := proof["nonce"]; ok {
-		return nonce.(string)
-	}
-
-	return ""
-}
-
 func getProofs(proofElement interface{}) ([]map[string]interface{}, error) {
 	switch p := proofElement.(type) {
 	case map[string]interface{}:
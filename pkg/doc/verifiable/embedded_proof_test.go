@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
@@ -19,26 +20,26 @@ import (
 func Test_parseEmbeddedProof(t *testing.T) {
 	t.Run("parse linked data proof with \"Ed25519Signature2018\" proof type", func(t *testing.T) {
 		s, err := getProofType(map[string]interface{}{
-			"type": ed25519Signature2018,
-		})
+			"type": suiteregistry.Ed25519Signature2018,
+		}, nil, suiteregistry.New())
 		require.NoError(t, err)
-		require.Equal(t, ed25519Signature2018, s)
+		require.Equal(t, suiteregistry.Ed25519Signature2018, s)
 
 		s, err = getProofType(map[string]interface{}{
-			"type": jsonWebSignature2020,
-		})
+			"type": suiteregistry.JSONWebSignature2020,
+		}, nil, suiteregistry.New())
 		require.NoError(t, err)
-		require.Equal(t, jsonWebSignature2020, s)
+		require.Equal(t, suiteregistry.JSONWebSignature2020, s)
 
 		s, err = getProofType(map[string]interface{}{
-			"type": ecdsaSecp256k1Signature2019,
-		})
+			"type": suiteregistry.EcdsaSecp256k1Signature2019,
+		}, nil, suiteregistry.New())
 		require.NoError(t, err)
-		require.Equal(t, ecdsaSecp256k1Signature2019, s)
+		require.Equal(t, suiteregistry.EcdsaSecp256k1Signature2019, s)
 	})
 
 	t.Run("parse embedded proof without \"type\" element", func(t *testing.T) {
-		_, err := getProofType(map[string]interface{}{})
+		_, err := getProofType(map[string]interface{}{}, nil, suiteregistry.New())
 		require.Error(t, err)
 		require.EqualError(t, err, "proof type is missing")
 	})
@@ -46,7 +47,7 @@ func Test_parseEmbeddedProof(t *testing.T) {
 	t.Run("parse embedded proof with unsupported type", func(t *testing.T) {
 		_, err := getProofType(map[string]interface{}{
 			"type": "SomethingUnsupported",
-		})
+		}, nil, suiteregistry.New())
 		require.Error(t, err)
 		require.EqualError(t, err, "unsupported proof type: SomethingUnsupported")
 	})
@@ -209,10 +210,10 @@ func Test_getSuites(t *testing.T) {
 	}
 
 	proofs := []map[string]interface{}{
-		createProofOfTypeFunc(ed25519Signature2018),
-		createProofOfTypeFunc(jsonWebSignature2020),
-		createProofOfTypeFunc(ecdsaSecp256k1Signature2019),
-		createProofOfTypeFunc(bbsBlsSignature2020),
+		createProofOfTypeFunc(suiteregistry.Ed25519Signature2018),
+		createProofOfTypeFunc(suiteregistry.JSONWebSignature2020),
+		createProofOfTypeFunc(suiteregistry.EcdsaSecp256k1Signature2019),
+		createProofOfTypeFunc(suiteregistry.BbsBlsSignature2020),
 	}
 
 	suites, err := getSuites(proofs, &embeddedProofCheckOpts{})
@@ -10,11 +10,14 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/piprate/json-gold/ld"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/errcode"
 )
 
 const vcJSONLD = `
@@ -257,7 +260,8 @@ const vcJSONLD = `
 }
 `
 
-// CachingJSONLDLoader creates JSON_LD CachingDocumentLoader with preloaded base JSON-LD document.
+// CachingJSONLDLoader creates JSON_LD CachingDocumentLoader with preloaded base JSON-LD document and the
+// JSON-LD context of the Ed25519Signature2020 suite.
 func CachingJSONLDLoader() *ld.CachingDocumentLoader {
 	loader := ld.NewCachingDocumentLoader(ld.NewRFC7324CachingDocumentLoader(&http.Client{}))
 
@@ -268,13 +272,55 @@ func CachingJSONLDLoader() *ld.CachingDocumentLoader {
 
 	loader.AddDocument("https://www.w3.org/2018/credentials/v1", reader)
 
+	reader, err = ld.DocumentFromReader(strings.NewReader(ed25519signature2020.JSONLDContext))
+	if err != nil {
+		panic(err)
+	}
+
+	loader.AddDocument(ed25519signature2020.ContextURL, reader)
+
 	return loader
 }
 
 func compactJSONLD(doc string, opts *jsonldCredentialOpts, strict bool) error {
+	docMap, docCompactedMap, err := compactJSONLDDoc(doc, opts)
+	if err != nil {
+		return err
+	}
+
+	if strict && !mapsHaveSameStructure(docMap, docCompactedMap) {
+		return errcode.Wrap(ErrSchemaValidation, errors.New("JSON-LD doc has different structure after compaction"))
+	}
+
+	return nil
+}
+
+// validateJSONLDTerms expands the JSON-LD document against its contexts and compacts it back, the terms undefined by
+// the contexts being dropped: the document is rejected if any of its terms is dropped. In strict mode, the structure
+// of the compacted document is also compared with the original one (see compactJSONLD).
+func validateJSONLDTerms(doc string, opts *jsonldCredentialOpts, strict bool) error {
+	docMap, docCompactedMap, err := compactJSONLDDoc(doc, opts)
+	if err != nil {
+		return err
+	}
+
+	if dropped := droppedTerms(docMap, docCompactedMap, ""); len(dropped) > 0 {
+		return errcode.Wrap(ErrSchemaValidation,
+			fmt.Errorf("JSON-LD doc has undefined terms: %s", strings.Join(dropped, ", ")))
+	}
+
+	if strict && !mapsHaveSameStructure(docMap, docCompactedMap) {
+		return errcode.Wrap(ErrSchemaValidation, errors.New("JSON-LD doc has different structure after compaction"))
+	}
+
+	return nil
+}
+
+// compactJSONLDDoc returns the JSON-LD document and the document compacted with its own contexts.
+func compactJSONLDDoc(doc string, opts *jsonldCredentialOpts) (map[string]interface{}, map[string]interface{}, error) {
 	docMap, err := toMap(doc)
 	if err != nil {
-		return fmt.Errorf("convert JSON-LD doc to map: %w", err)
+		return nil, nil, fmt.Errorf("convert JSON-LD doc to map: %w", err)
 	}
 
 	jsonldProc := jsonld.Default()
@@ -283,14 +329,62 @@ func compactJSONLD(doc string, opts *jsonldCredentialOpts, strict bool) error {
 		nil, jsonld.WithDocumentLoader(opts.jsonldDocumentLoader),
 		jsonld.WithExternalContext(opts.externalContext...))
 	if err != nil {
-		return fmt.Errorf("compact JSON-LD document: %w", err)
+		return nil, nil, fmt.Errorf("compact JSON-LD document: %w", err)
 	}
 
-	if strict && !mapsHaveSameStructure(docMap, docCompactedMap) {
-		return errors.New("JSON-LD doc has different structure after compaction")
-	}
+	return docMap, docCompactedMap, nil
+}
 
-	return nil
+// droppedTerms returns the paths of the terms of the original value which are missing in its compacted value.
+// The keywords and the terms which are IRIs are defined by themselves.
+func droppedTerms(original, compacted interface{}, path string) []string {
+	switch ov := original.(type) {
+	case map[string]interface{}:
+		// the node reduced to its ID, e.g. by a term of "@id" type, drops its other terms
+		cv, _ := compactValue(compacted).(map[string]interface{}) // nolint: errcheck
+
+		var dropped []string
+
+		for k, v := range ov {
+			if strings.HasPrefix(k, "@") || strings.Contains(k, ":") {
+				continue
+			}
+
+			c, ok := cv[k]
+			if !ok {
+				if cv == nil && k == "id" {
+					continue
+				}
+
+				dropped = append(dropped, path+k)
+
+				continue
+			}
+
+			dropped = append(dropped, droppedTerms(v, c, path+k+".")...)
+		}
+
+		sort.Strings(dropped)
+
+		return dropped
+
+	case []interface{}:
+		cv, ok := compacted.([]interface{})
+		if !ok {
+			cv = []interface{}{compacted}
+		}
+
+		var dropped []string
+
+		for i := 0; i < len(ov) && i < len(cv); i++ {
+			dropped = append(dropped, droppedTerms(ov[i], cv[i], path)...)
+		}
+
+		return dropped
+
+	default:
+		return nil
+	}
 }
 
 func mapsHaveSameStructure(originalMap, compactedMap map[string]interface{}) bool {
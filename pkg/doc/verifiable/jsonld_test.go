@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -378,3 +379,53 @@ func Test_compactJSONLD_CornerErrorCases(t *testing.T) {
 func defaultOpts() *jsonldCredentialOpts {
 	return &jsonldCredentialOpts{jsonldDocumentLoader: CachingJSONLDLoader()}
 }
+
+func Test_validateJSONLDTerms(t *testing.T) {
+	opts := &jsonldCredentialOpts{jsonldDocumentLoader: testDocumentLoader}
+
+	t.Run("all terms defined", func(t *testing.T) {
+		require.NoError(t, validateJSONLDTerms(validCredential, opts, false))
+		require.NoError(t, validateJSONLDTerms(validCredential, opts, true))
+	})
+
+	t.Run("undefined terms", func(t *testing.T) {
+		vcJSON := `
+{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1"
+  ],
+  "id": "http://example.com/credentials/4643",
+  "type": "VerifiableCredential",
+  "issuer": "https://example.com/issuers/14",
+  "issuanceDate": "2018-02-24T05:28:04Z",
+  "referenceNumber": 83294847,
+  "https://example.com/vocab#favoriteColor": "blue",
+  "credentialSubject": [
+    {
+      "id": "did:example:abcdef1234567",
+      "name": "Jane Doe"
+    }
+  ],
+  "proof": {
+    "type": "Ed25519Signature2018",
+    "created": "2020-04-10T21:35:35Z",
+    "verificationMethod": "did:key:z6MkjRag",
+    "proofPurpose": "assertionMethod",
+    "jws": "eyJ..l9d0Y",
+    "newProp": "foo"
+  }
+}
+`
+
+		err := validateJSONLDTerms(vcJSON, opts, false)
+		require.True(t, errors.Is(err, ErrSchemaValidation))
+		require.EqualError(t, err, "JSON-LD doc has undefined terms: credentialSubject.name, proof.newProp, "+
+			"referenceNumber")
+	})
+
+	t.Run("invalid JSON-LD", func(t *testing.T) {
+		err := validateJSONLDTerms(`{"@context": 5}`, opts, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "compact JSON-LD document")
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/errcode"
 )
 
 // Signer defines signer interface which is used to sign VC JWT.
@@ -49,6 +50,15 @@ func (v noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
 	return nil
 }
 
+// proofVerifier classifies the failures of the JWT signature verification as ErrProofInvalid.
+type proofVerifier struct {
+	verifier jose.SignatureVerifier
+}
+
+func (v proofVerifier) Verify(joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
+	return errcode.Wrap(ErrProofInvalid, v.verifier.Verify(joseHeaders, payload, signingInput, signature))
+}
+
 // MarshalJWS serializes JWT presentation claims into signed form (JWS).
 func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
 	algName, err := signatureAlg.name()
@@ -72,7 +82,7 @@ func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, clai
 	var verifier jose.SignatureVerifier
 
 	if checkProof {
-		verifier = jwt.NewVerifier(jwt.KeyResolverFunc(fetcher))
+		verifier = proofVerifier{verifier: jwt.NewVerifier(jwt.KeyResolverFunc(fetcher))}
 	} else {
 		verifier = &noVerifier{}
 	}
@@ -7,13 +7,17 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
@@ -66,6 +70,146 @@ type LinkedDataProofContext struct {
 	CapabilityChain []interface{}
 }
 
+// LinkedDataProofOpt is the option of the linked data proofs signed with Credential.SignLinkedDataProof.
+type LinkedDataProofOpt func(opts *linkedDataProofOpts)
+
+type linkedDataProofOpts struct {
+	context    LinkedDataProofContext
+	registry   *suiteregistry.Registry
+	jsonldOpts []jsonld.ProcessorOpts
+	clock      clock.Clock
+}
+
+// WithProofVerificationMethod defines the verification method of the proof, i.e. the DID URL of the key verifying
+// it (e.g. "did:example:123#key-1"). A relative DID URL (e.g. "#key-1") is resolved against the DID of the issuer.
+func WithProofVerificationMethod(verificationMethod string) LinkedDataProofOpt {
+	return func(opts *linkedDataProofOpts) {
+		opts.context.VerificationMethod = verificationMethod
+	}
+}
+
+// WithProofPurpose defines the purpose of the proof, "assertionMethod" if not defined.
+func WithProofPurpose(purpose string) LinkedDataProofOpt {
+	return func(opts *linkedDataProofOpts) {
+		opts.context.Purpose = purpose
+	}
+}
+
+// WithProofCreated defines the creation time of the proof, the current time of the proof clock (truncated to the
+// second) if not defined, see WithProofClock.
+func WithProofCreated(created time.Time) LinkedDataProofOpt {
+	return func(opts *linkedDataProofOpts) {
+		opts.context.Created = &created
+	}
+}
+
+// WithProofClock defines the clock telling the creation time of the proof if not defined by WithProofCreated, the
+// clock of the system if not defined.
+func WithProofClock(c clock.Clock) LinkedDataProofOpt {
+	return func(opts *linkedDataProofOpts) {
+		opts.clock = c
+	}
+}
+
+// WithProofChallenge defines the challenge of the proof.
+func WithProofChallenge(challenge string) LinkedDataProofOpt {
+	return func(opts *linkedDataProofOpts) {
+		opts.context.Challenge = challenge
+	}
+}
+
+// WithProofDomain defines the domain of the proof.
+func WithProofDomain(domain string) LinkedDataProofOpt {
+	return func(opts *linkedDataProofOpts) {
+		opts.context.Domain = domain
+	}
+}
+
+// WithProofSignatureRepresentation defines where the signature is put in the proof, SignatureProofValue if not
+// defined.
+func WithProofSignatureRepresentation(representation SignatureRepresentation) LinkedDataProofOpt {
+	return func(opts *linkedDataProofOpts) {
+		opts.context.SignatureRepresentation = representation
+	}
+}
+
+// WithProofSuiteRegistry defines the registry of the suite creating the proof, a registry of the suites of the
+// framework if not defined.
+func WithProofSuiteRegistry(r *suiteregistry.Registry) LinkedDataProofOpt {
+	return func(opts *linkedDataProofOpts) {
+		opts.registry = r
+	}
+}
+
+// WithProofJSONLDOpts defines the options of the JSON-LD processing of the signed document, e.g. its document loader.
+func WithProofJSONLDOpts(jsonldOpts ...jsonld.ProcessorOpts) LinkedDataProofOpt {
+	return func(opts *linkedDataProofOpts) {
+		opts.jsonldOpts = jsonldOpts
+	}
+}
+
+// getLinkedDataProofOpts returns the context of the proof of the type signed with the signer, whose verification
+// method is resolved against the DID of the issuer.
+func getLinkedDataProofOpts(proofType string, s Signer, issuerID string,
+	opts []LinkedDataProofOpt) (*linkedDataProofOpts, error) {
+	ldpOpts := &linkedDataProofOpts{}
+
+	for _, opt := range opts {
+		opt(ldpOpts)
+	}
+
+	if ldpOpts.registry == nil {
+		ldpOpts.registry = suiteregistry.New()
+	}
+
+	signatureSuite, err := ldpOpts.registry.Signer(proofType, s)
+	if err != nil {
+		return nil, err
+	}
+
+	ldpOpts.context.SignatureType = proofType
+	ldpOpts.context.Suite = signatureSuite
+
+	ldpOpts.context.VerificationMethod, err = resolveVerificationMethod(ldpOpts.context.VerificationMethod, issuerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ldpOpts.context.Created == nil {
+		created := clock.OrSystem(ldpOpts.clock).Now().UTC().Truncate(time.Second)
+		ldpOpts.context.Created = &created
+	}
+
+	return ldpOpts, nil
+}
+
+func resolveVerificationMethod(verificationMethod, issuerID string) (string, error) {
+	if verificationMethod == "" {
+		return "", errors.New("verification method is required")
+	}
+
+	if !strings.HasPrefix(verificationMethod, "#") {
+		return verificationMethod, nil
+	}
+
+	if !strings.HasPrefix(issuerID, "did:") {
+		return "", fmt.Errorf("relative verification method %s of issuer %q which isn't a DID",
+			verificationMethod, issuerID)
+	}
+
+	return issuerID + verificationMethod, nil
+}
+
+// proofContext returns the JSON-LD context defining the terms of the proofs of the type, if not defined by the base
+// context.
+func proofContext(proofType string) string {
+	if proofType == ed25519signature2020.SignatureType {
+		return ed25519signature2020.ContextURL
+	}
+
+	return ""
+}
+
 func checkLinkedDataProof(jsonldBytes []byte, suites []verifier.SignatureSuite,
 	pubKeyFetcher PublicKeyFetcher, jsonldOpts *jsonldCredentialOpts) error {
 	documentVerifier, err := verifier.New(&keyResolverAdapter{pubKeyFetcher}, suites...)
@@ -14,8 +14,11 @@ import (
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/errcode"
 )
 
 const basePresentationSchema = `
@@ -335,9 +338,11 @@ type presentationOpts struct {
 	publicKeyFetcher   PublicKeyFetcher
 	disabledProofCheck bool
 	ldpSuites          []verifier.SignatureSuite
+	signatureSuites    *suiteregistry.Registry
 	strictValidation   bool
 	requireVC          bool
 	requireProof       bool
+	jsonLimits         jsonutil.Limits
 
 	jsonldCredentialOpts
 }
@@ -360,6 +365,14 @@ func WithPresEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) Presenta
 	}
 }
 
+// WithPresSignatureSuiteRegistry sets the registry of the suites which are used to check the embedded linked data
+// proofs of VP, by their proof type, a registry of the suites of the framework if not set.
+func WithPresSignatureSuiteRegistry(r *suiteregistry.Registry) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.signatureSuites = r
+	}
+}
+
 // WithPresDisabledProofCheck option for disabling of proof check.
 func WithPresDisabledProofCheck() PresentationOpt {
 	return func(opts *presentationOpts) {
@@ -384,6 +397,13 @@ func WithPresJSONLDDocumentLoader(documentLoader ld.DocumentLoader) Presentation
 	}
 }
 
+// WithPresJSONLimits sets the limits of the presentation JSON, jsonutil.DefaultLimits if not set.
+func WithPresJSONLimits(limits jsonutil.Limits) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.jsonLimits = limits
+	}
+}
+
 // ParsePresentation creates an instance of Verifiable Presentation by reading a JSON document from bytes.
 // It also applies miscellaneous options like custom decoders or settings of schema validation.
 func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, error) {
@@ -539,6 +559,7 @@ func mapOpts(vpOpts *presentationOpts) *credentialOpts {
 		publicKeyFetcher:   vpOpts.publicKeyFetcher,
 		disabledProofCheck: vpOpts.disabledProofCheck,
 		ldpSuites:          vpOpts.ldpSuites,
+		signatureSuites:    vpOpts.signatureSuites,
 	}
 }
 
@@ -565,7 +586,7 @@ func validateVPJSONSchema(data []byte) error {
 
 	if !result.Valid() {
 		errMsg := describeSchemaValidationError(result, "verifiable presentation")
-		return errors.New(errMsg)
+		return errcode.Wrap(ErrSchemaValidation, errors.New(errMsg))
 	}
 
 	return nil
@@ -573,6 +594,10 @@ func validateVPJSONSchema(data []byte) error {
 
 //nolint:gocyclo
 func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *rawPresentation, error) {
+	if err := jsonutil.Check(vpData, vpOpts.jsonLimits); err != nil {
+		return nil, nil, err
+	}
+
 	vpStr := string(vpData)
 
 	if jwt.IsJWS(vpStr) {
@@ -592,6 +617,7 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 		publicKeyFetcher:     vpOpts.publicKeyFetcher,
 		disabledProofCheck:   vpOpts.disabledProofCheck,
 		ldpSuites:            vpOpts.ldpSuites,
+		signatureSuites:      vpOpts.signatureSuites,
 		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
 	}
 
@@ -639,5 +665,5 @@ func decodeVPFromJSON(vpData []byte) ([]byte, *rawPresentation, error) {
 }
 
 func defaultPresentationOpts() *presentationOpts {
-	return &presentationOpts{}
+	return &presentationOpts{jsonLimits: jsonutil.DefaultLimits()}
 }
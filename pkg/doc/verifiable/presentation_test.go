@@ -7,11 +7,13 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
@@ -529,6 +531,15 @@ func TestWithPresJSONLDDocumentLoader(t *testing.T) {
 	require.Equal(t, documentLoader, opts.jsonldDocumentLoader)
 }
 
+func TestParsePresentationJSONLimits(t *testing.T) {
+	_, err := ParsePresentation([]byte(validPresentation), WithPresJSONLimits(jsonutil.Limits{MaxDepth: 2}))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+
+	_, err = ParseUnverifiedPresentation([]byte(validPresentation), WithPresJSONLimits(jsonutil.Limits{MaxBytes: 10}))
+	require.True(t, errors.Is(err, jsonutil.ErrLimitExceeded))
+}
+
 func TestParseUnverifiedPresentation(t *testing.T) {
 	// happy path
 	vp, err := ParseUnverifiedPresentation([]byte(validPresentation))
@@ -0,0 +1,303 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
+)
+
+const (
+	// StatusList2021EntryType is the type of the credential statuses kept in a StatusList2021 status list, see
+	// https://w3c-ccg.github.io/vc-status-list-2021/.
+	StatusList2021EntryType = "StatusList2021Entry"
+
+	// StatusList2021CredentialType is the type of the status list credentials.
+	StatusList2021CredentialType = "StatusList2021Credential"
+
+	// StatusList2021Context is the JSON-LD context of the status list credentials and of their entries.
+	StatusList2021Context = "https://w3id.org/vc/status-list/2021/v1"
+
+	revocationPurpose = "revocation"
+	suspensionPurpose = "suspension"
+
+	// maxStatusListSize bounds the size of the decompressed status lists.
+	maxStatusListSize = 16 << 20
+)
+
+// ErrRevoked is returned by the status check of VC whose status list marks it as revoked.
+var ErrRevoked = errors.New("credential is revoked")
+
+// ErrSuspended is returned by the status check of VC whose status list marks it as suspended.
+var ErrSuspended = errors.New("credential is suspended")
+
+// StatusVerifier verifies the status of the credentials, see WithStatusCheck.
+type StatusVerifier interface {
+	// VerifyStatus returns ErrRevoked or ErrSuspended if the credential is revoked or suspended, nil if it's neither
+	// or if its status isn't of a type checked by the verifier.
+	VerifyStatus(vc *Credential) error
+}
+
+// StatusListFetcher fetches the status list credential of the URL, e.g. from a cache.
+type StatusListFetcher func(listURL string) ([]byte, error)
+
+// StatusList2021Verifier verifies the StatusList2021Entry statuses of the credentials against their status lists.
+type StatusList2021Verifier struct {
+	fetcher  StatusListFetcher
+	listOpts []CredentialOpt
+}
+
+// StatusList2021VerifierOpt is the option of the StatusList2021Verifier.
+type StatusList2021VerifierOpt func(v *StatusList2021Verifier)
+
+// WithStatusListFetcher defines the fetcher of the status list credentials, HTTPStatusListFetcher with the default
+// HTTP client if not defined.
+func WithStatusListFetcher(fetcher StatusListFetcher) StatusList2021VerifierOpt {
+	return func(v *StatusList2021Verifier) {
+		v.fetcher = fetcher
+	}
+}
+
+// WithStatusListCredentialOpts defines the options parsing the status list credentials, e.g. their public key
+// fetcher. The status list credentials are parsed without option if not defined, their proof being checked.
+func WithStatusListCredentialOpts(opts ...CredentialOpt) StatusList2021VerifierOpt {
+	return func(v *StatusList2021Verifier) {
+		v.listOpts = opts
+	}
+}
+
+// NewStatusList2021Verifier returns the verifier of the StatusList2021Entry statuses.
+func NewStatusList2021Verifier(opts ...StatusList2021VerifierOpt) *StatusList2021Verifier {
+	v := &StatusList2021Verifier{}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.fetcher == nil {
+		v.fetcher = HTTPStatusListFetcher(&http.Client{})
+	}
+
+	return v
+}
+
+// VerifyStatus checks the bit of the credential in its status list, fetched and parsed (its proof checked) once per
+// call. The status list must be issued by the issuer of the credential, for the purpose of its status.
+func (v *StatusList2021Verifier) VerifyStatus(vc *Credential) error {
+	if vc.Status == nil || vc.Status.Type != StatusList2021EntryType {
+		return nil
+	}
+
+	rawPurpose, ok := vc.Status.CustomFields["statusPurpose"]
+	if !ok {
+		return errors.New("check credential status: missing status purpose")
+	}
+
+	purpose, ok := rawPurpose.(string)
+	if !ok {
+		return fmt.Errorf("check credential status: status purpose %v isn't a string", rawPurpose)
+	}
+
+	listURL, _ := vc.Status.CustomFields["statusListCredential"].(string) // nolint: errcheck
+
+	if purpose != revocationPurpose && purpose != suspensionPurpose {
+		return fmt.Errorf("check credential status: unsupported status purpose %q", purpose)
+	}
+
+	if listURL == "" {
+		return errors.New("check credential status: missing status list credential")
+	}
+
+	index, err := statusListIndex(vc.Status.CustomFields["statusListIndex"])
+	if err != nil {
+		return fmt.Errorf("check credential status: %w", err)
+	}
+
+	bits, err := v.getStatusList(listURL, vc.Issuer.ID, purpose)
+	if err != nil {
+		return fmt.Errorf("check credential status: %w", err)
+	}
+
+	if index >= len(bits)*8 {
+		return fmt.Errorf("check credential status: status list index %d out of range", index)
+	}
+
+	// the first index is the most significant bit of the first byte
+	if bits[index/8]&(1<<(7-uint(index%8))) == 0 {
+		return nil
+	}
+
+	if purpose == suspensionPurpose {
+		return fmt.Errorf("%w: status list %s, index %d", ErrSuspended, listURL, index)
+	}
+
+	return fmt.Errorf("%w: status list %s, index %d", ErrRevoked, listURL, index)
+}
+
+// getStatusList returns the decoded bitstring of the status list credential of the URL.
+func (v *StatusList2021Verifier) getStatusList(listURL, issuerID, purpose string) ([]byte, error) {
+	raw, err := v.fetcher(listURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch status list credential %s: %w", listURL, err)
+	}
+
+	listVC, err := ParseCredential(raw, v.listOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse status list credential %s: %w", listURL, err)
+	}
+
+	if !isStatusListCredential(listVC) {
+		return nil, fmt.Errorf("status list credential %s is not of type %s", listURL, StatusList2021CredentialType)
+	}
+
+	if listVC.Issuer.ID != issuerID {
+		return nil, fmt.Errorf("status list credential %s is not issued by %s", listURL, issuerID)
+	}
+
+	subject, ok := listVC.Subject.([]Subject)
+	if !ok || len(subject) != 1 {
+		return nil, fmt.Errorf("status list credential %s must have a single subject", listURL)
+	}
+
+	listPurpose, _ := subject[0].CustomFields["statusPurpose"].(string) // nolint: errcheck
+	if listPurpose != purpose {
+		return nil, fmt.Errorf("status list %s is for %s, not %s", listURL, listPurpose, purpose)
+	}
+
+	encodedList, _ := subject[0].CustomFields["encodedList"].(string) // nolint: errcheck
+
+	bits, err := decodeStatusList(encodedList)
+	if err != nil {
+		return nil, fmt.Errorf("status list credential %s: %w", listURL, err)
+	}
+
+	return bits, nil
+}
+
+func isStatusListCredential(vc *Credential) bool {
+	for _, t := range vc.Types {
+		if t == StatusList2021CredentialType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeStatusList decodes the base64url encoded GZIP compressed bitstring of a status list.
+func decodeStatusList(encodedList string) ([]byte, error) {
+	compressed, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encodedList, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode encoded list: %w", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress encoded list: %w", err)
+	}
+
+	bits, err := ioutil.ReadAll(io.LimitReader(reader, maxStatusListSize))
+	if err != nil {
+		return nil, fmt.Errorf("decompress encoded list: %w", err)
+	}
+
+	return bits, nil
+}
+
+func statusListIndex(value interface{}) (int, error) {
+	switch index := value.(type) {
+	case string:
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 {
+			return 0, fmt.Errorf("invalid status list index %q", index)
+		}
+
+		return i, nil
+	case float64:
+		if index < 0 || index != float64(int(index)) {
+			return 0, fmt.Errorf("invalid status list index %v", index)
+		}
+
+		return int(index), nil
+	default:
+		return 0, errors.New("missing status list index")
+	}
+}
+
+// HTTPStatusListFetcherOpt is the option of HTTPStatusListFetcher.
+type HTTPStatusListFetcherOpt func(opts *httpStatusListFetcherOpts)
+
+type httpStatusListFetcherOpts struct {
+	retryPolicy retry.Policy
+}
+
+// WithStatusListRetryPolicy defines the retry policy of the status list downloads, retry.DefaultPolicy if not
+// defined. The retries are disabled with retry.NoRetry.
+func WithStatusListRetryPolicy(policy retry.Policy) HTTPStatusListFetcherOpt {
+	return func(opts *httpStatusListFetcherOpts) {
+		opts.retryPolicy = policy
+	}
+}
+
+// HTTPStatusListFetcher returns the fetcher getting the status list credentials with the HTTP client, retrying the
+// transient failures.
+func HTTPStatusListFetcher(client *http.Client, opts ...HTTPStatusListFetcherOpt) StatusListFetcher {
+	fetcherOpts := &httpStatusListFetcherOpts{retryPolicy: retry.DefaultPolicy()}
+
+	for _, opt := range opts {
+		opt(fetcherOpts)
+	}
+
+	return func(listURL string) ([]byte, error) {
+		var listBytes []byte
+
+		err := retry.Do(context.Background(), fetcherOpts.retryPolicy, func() error {
+			var getErr error
+
+			listBytes, getErr = getStatusListOnce(client, listURL)
+
+			return getErr
+		})
+
+		return listBytes, err
+	}
+}
+
+func getStatusListOnce(client *http.Client, listURL string) ([]byte, error) {
+	resp, err := client.Get(listURL)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Errorf("closing response body failed [%v]", e)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("status list endpoint HTTP failure [%v]", resp.StatusCode)
+		if !retry.RetryableStatus(resp.StatusCode) {
+			err = retry.Permanent(err)
+		}
+
+		return nil, err
+	}
+
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxStatusListSize))
+}
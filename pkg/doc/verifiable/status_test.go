@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
+)
+
+const testIssuerID = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+func TestWithStatusCheck(t *testing.T) {
+	// the bits 1 and 10 of the list are set
+	list := statusListCredential(t, testIssuerID, revocationPurpose, 1, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_, err := rw.Write(list)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	t.Run("valid credential", func(t *testing.T) {
+		vc, err := parseTestCredential(statusListEntryCredential(t, server.URL, 2), WithDisabledProofCheck(),
+			WithStatusCheck())
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("revoked credential", func(t *testing.T) {
+		vcBytes := statusListEntryCredential(t, server.URL, 10)
+
+		_, err := parseTestCredential(vcBytes, WithDisabledProofCheck(), WithStatusCheck())
+		require.True(t, errors.Is(err, ErrRevoked))
+
+		// the status isn't checked by default
+		_, err = parseTestCredential(vcBytes, WithDisabledProofCheck())
+		require.NoError(t, err)
+	})
+
+	t.Run("credential status of other type", func(t *testing.T) {
+		_, err := parseTestCredential([]byte(validCredential), WithDisabledProofCheck(), WithStatusCheck())
+		require.NoError(t, err)
+	})
+
+	t.Run("status list not found", func(t *testing.T) {
+		_, err := parseTestCredential(statusListEntryCredential(t, server.URL+"/missing", 2),
+			WithStatusCheck(), WithDisabledProofCheck(), WithStatusVerifier(NewStatusList2021Verifier(
+				WithStatusListFetcher(func(string) ([]byte, error) { return nil, errors.New("not found") }))))
+		require.EqualError(t, err, fmt.Sprintf("check credential status: fetch status list credential "+
+			"%s/missing: not found", server.URL))
+	})
+}
+
+func TestStatusList2021Verifier_VerifyStatus(t *testing.T) {
+	lists := map[string][]byte{
+		"https://example.com/revocation": statusListCredential(t, testIssuerID, revocationPurpose, 3),
+		"https://example.com/suspension": statusListCredential(t, testIssuerID, suspensionPurpose, 3),
+		"https://example.com/other":      statusListCredential(t, "did:example:other", revocationPurpose, 3),
+	}
+
+	v := NewStatusList2021Verifier(
+		WithStatusListFetcher(func(listURL string) ([]byte, error) {
+			return lists[listURL], nil
+		}),
+		WithStatusListCredentialOpts(WithJSONLDDocumentLoader(testDocumentLoader), WithDisabledProofCheck()))
+
+	newCredential := func(purpose, listURL string, index interface{}) *Credential {
+		return &Credential{
+			Issuer: Issuer{ID: testIssuerID},
+			Status: &TypedID{
+				ID:   listURL + "#1",
+				Type: StatusList2021EntryType,
+				CustomFields: CustomFields{
+					"statusPurpose":        purpose,
+					"statusListIndex":      index,
+					"statusListCredential": listURL,
+				},
+			},
+		}
+	}
+
+	require.NoError(t, v.VerifyStatus(&Credential{}))
+	require.NoError(t, v.VerifyStatus(newCredential(revocationPurpose, "https://example.com/revocation", "4")))
+	require.NoError(t, v.VerifyStatus(newCredential(revocationPurpose, "https://example.com/revocation", 4.0)))
+
+	err := v.VerifyStatus(newCredential(revocationPurpose, "https://example.com/revocation", "3"))
+	require.True(t, errors.Is(err, ErrRevoked))
+
+	err = v.VerifyStatus(newCredential(suspensionPurpose, "https://example.com/suspension", "3"))
+	require.True(t, errors.Is(err, ErrSuspended))
+
+	err = v.VerifyStatus(newCredential(suspensionPurpose, "https://example.com/revocation", "3"))
+	require.EqualError(t, err, "check credential status: status list https://example.com/revocation is for "+
+		"revocation, not suspension")
+
+	err = v.VerifyStatus(newCredential(revocationPurpose, "https://example.com/other", "3"))
+	require.EqualError(t, err, "check credential status: status list credential https://example.com/other is not "+
+		"issued by "+testIssuerID)
+
+	err = v.VerifyStatus(newCredential("refresh", "https://example.com/revocation", "3"))
+	require.EqualError(t, err, `check credential status: unsupported status purpose "refresh"`)
+
+	vc := newCredential(revocationPurpose, "https://example.com/revocation", "3")
+	delete(vc.Status.CustomFields, "statusPurpose")
+
+	err = v.VerifyStatus(vc)
+	require.EqualError(t, err, "check credential status: missing status purpose")
+
+	vc.Status.CustomFields["statusPurpose"] = 1.0
+
+	err = v.VerifyStatus(vc)
+	require.EqualError(t, err, "check credential status: status purpose 1 isn't a string")
+
+	err = v.VerifyStatus(newCredential(revocationPurpose, "", "3"))
+	require.EqualError(t, err, "check credential status: missing status list credential")
+
+	err = v.VerifyStatus(newCredential(revocationPurpose, "https://example.com/revocation", "-1"))
+	require.EqualError(t, err, `check credential status: invalid status list index "-1"`)
+
+	err = v.VerifyStatus(newCredential(revocationPurpose, "https://example.com/revocation", nil))
+	require.EqualError(t, err, "check credential status: missing status list index")
+
+	err = v.VerifyStatus(newCredential(revocationPurpose, "https://example.com/revocation", "200000"))
+	require.EqualError(t, err, "check credential status: status list index 200000 out of range")
+}
+
+// statusListEntryCredential returns validCredential with a StatusList2021Entry status in the list of the URL.
+func statusListEntryCredential(t *testing.T, listURL string, index int) []byte {
+	t.Helper()
+
+	var raw map[string]interface{}
+
+	require.NoError(t, json.Unmarshal([]byte(validCredential), &raw))
+
+	raw["credentialStatus"] = map[string]interface{}{
+		"id":                   fmt.Sprintf("%s#%d", listURL, index),
+		"type":                 StatusList2021EntryType,
+		"statusPurpose":        revocationPurpose,
+		"statusListIndex":      fmt.Sprint(index),
+		"statusListCredential": listURL,
+	}
+
+	vcBytes, err := json.Marshal(raw)
+	require.NoError(t, err)
+
+	return vcBytes
+}
+
+// statusListCredential returns an unsigned status list credential of 16KB whose given bits are set.
+func statusListCredential(t *testing.T, issuerID, purpose string, indexes ...int) []byte {
+	t.Helper()
+
+	bits := make([]byte, 16*1024)
+	for _, i := range indexes {
+		bits[i/8] |= 1 << (7 - uint(i%8))
+	}
+
+	var compressed bytes.Buffer
+
+	w := gzip.NewWriter(&compressed)
+	_, err := w.Write(bits)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	listBytes, err := json.Marshal(map[string]interface{}{
+		"@context":     []string{"https://www.w3.org/2018/credentials/v1"},
+		"id":           "https://example.com/credentials/status/3",
+		"type":         []string{"VerifiableCredential", StatusList2021CredentialType},
+		"issuer":       issuerID,
+		"issuanceDate": "2021-04-05T14:27:40Z",
+		"credentialSubject": map[string]interface{}{
+			"id":            "https://example.com/status/3#list",
+			"type":          "StatusList2021",
+			"statusPurpose": purpose,
+			"encodedList":   base64.RawURLEncoding.EncodeToString(compressed.Bytes()),
+		},
+	})
+	require.NoError(t, err)
+
+	return listBytes
+}
+
+func TestHTTPStatusListFetcher(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, err := rw.Write([]byte("list"))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	retryPolicy := retry.DefaultPolicy()
+	retryPolicy.InitialInterval = time.Millisecond
+
+	list, err := HTTPStatusListFetcher(server.Client(), WithStatusListRetryPolicy(retryPolicy))(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, []byte("list"), list)
+	require.Equal(t, 2, requests)
+
+	// the retries are disabled
+	requests = 0
+
+	_, err = HTTPStatusListFetcher(server.Client(), WithStatusListRetryPolicy(retry.NoRetry()))(server.URL)
+	require.EqualError(t, err, "status list endpoint HTTP failure [503]")
+	require.Equal(t, 1, requests)
+}
@@ -20,6 +20,9 @@ import (
 // ErrNotFound is returned when a DID resolver does not find the DID.
 var ErrNotFound = errors.New("DID not found")
 
+// ErrMethodNotSupported is returned when the registry has no VDR for the DID method.
+var ErrMethodNotSupported = errors.New("DID method not supported")
+
 // DIDCommServiceType default DID Communication service endpoint type.
 const DIDCommServiceType = "did-communication"
 
@@ -0,0 +1,440 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/ws"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/redis"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	// MemStorage is the in-memory storage type (the default).
+	MemStorage = "mem"
+	// RedisStorage is the Redis storage type.
+	RedisStorage = "redis"
+
+	// HTTPTransport is the HTTP transport scheme.
+	HTTPTransport = "http"
+	// WebSocketTransport is the WebSocket transport scheme.
+	WebSocketTransport = "ws"
+)
+
+// Config is the declarative configuration of the framework, see NewFromConfig. It's typically loaded from a JSON
+// file with LoadConfig, e.g.:
+//
+//	{
+//	  "storage": {"type": "redis", "address": "localhost:6379"},
+//	  "kms": {"secretLockKeyPath": "/etc/agent/master.key"},
+//	  "transports": {
+//	    "inbound": [{"scheme": "http", "internalAddr": ":8080", "externalAddr": "https://agent.example.com"}],
+//	    "outbound": ["http", "ws"]
+//	  },
+//	  "protocols": {"stateExpirations": {"didexchange": "24h"}},
+//	  "mediator": {"connections": ["6a4ad6f4-d52e-4e26-b8f3-e1e4b1dc0ad5"], "timeout": "10s"},
+//	  "logLevels": {"aries-framework/out-of-band": "DEBUG"}
+//	}
+type Config struct {
+	Storage    StorageConfig     `json:"storage"`
+	KMS        KMSConfig         `json:"kms"`
+	Transports TransportsConfig  `json:"transports"`
+	Protocols  ProtocolsConfig   `json:"protocols"`
+	Mediator   MediatorConfig    `json:"mediator"`
+	LogLevels  map[string]string `json:"logLevels,omitempty"`
+}
+
+// StorageConfig configures the store provider of the framework.
+type StorageConfig struct {
+	// Type is the storage type, MemStorage if empty.
+	Type string `json:"type,omitempty"`
+	// Address is the address of the Redis server.
+	Address string `json:"address,omitempty"`
+}
+
+// KMSConfig configures the local KMS of the framework.
+type KMSConfig struct {
+	// MasterKeyURI is the URI of the master key of the KMS.
+	MasterKeyURI string `json:"masterKeyURI,omitempty"`
+	// SecretLockKeyPath is the path of the file holding the master key of the secret lock protecting the keys,
+	// the keys aren't protected if empty.
+	SecretLockKeyPath string `json:"secretLockKeyPath,omitempty"`
+}
+
+// TransportsConfig configures the transports of the framework.
+type TransportsConfig struct {
+	Inbound []InboundTransportConfig `json:"inbound,omitempty"`
+	// Outbound are the schemes of the outbound transports, HTTP only if empty.
+	Outbound []string `json:"outbound,omitempty"`
+	// ReturnRoute is the return route option of the transport decorator of the outbound messages.
+	ReturnRoute string `json:"returnRoute,omitempty"`
+}
+
+// InboundTransportConfig configures an inbound transport.
+type InboundTransportConfig struct {
+	// Scheme is HTTPTransport or WebSocketTransport.
+	Scheme string `json:"scheme"`
+	// InternalAddr is the address the transport listens on.
+	InternalAddr string `json:"internalAddr"`
+	// ExternalAddr is the endpoint of the agent, the internal address if empty.
+	ExternalAddr string `json:"externalAddr,omitempty"`
+	CertFile     string `json:"certFile,omitempty"`
+	KeyFile      string `json:"keyFile,omitempty"`
+}
+
+// ProtocolsConfig configures the protocol services.
+type ProtocolsConfig struct {
+	AutoAccept *connection.AutoAcceptConfig `json:"autoAccept,omitempty"`
+	// StateExpirations are the expiration timeouts of the pending actions per protocol name.
+	StateExpirations map[string]Duration `json:"stateExpirations,omitempty"`
+}
+
+// MediatorConfig configures the registration of the agent with its mediators.
+type MediatorConfig struct {
+	// Connections are the IDs of the connections with the mediators the agent registers with once started.
+	Connections []string `json:"connections,omitempty"`
+	// Timeout is the timeout of the registrations.
+	Timeout Duration `json:"timeout,omitempty"`
+	// CoordinationV2 registers with the mediators using coordinate mediation 2.0.
+	CoordinationV2 bool `json:"coordinationV2,omitempty"`
+}
+
+// Duration is a time.Duration (un)marshaled as a string such as "1m30s".
+type Duration time.Duration
+
+// MarshalJSON marshals the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON unmarshals the duration from a string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+
+	return nil
+}
+
+// LoadConfig reads the JSON configuration file at path. The unknown fields are rejected.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	cfg := &Config{}
+
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Validate checks the configuration without creating any component.
+func (c *Config) Validate() error {
+	switch c.Storage.Type {
+	case "", MemStorage:
+	case RedisStorage:
+		if c.Storage.Address == "" {
+			return errors.New("invalid storage config: redis address is required")
+		}
+	default:
+		return fmt.Errorf("invalid storage config: unsupported type [%s]", c.Storage.Type)
+	}
+
+	if err := c.Transports.validate(); err != nil {
+		return fmt.Errorf("invalid transports config: %w", err)
+	}
+
+	for name, timeout := range c.Protocols.StateExpirations {
+		if timeout <= 0 {
+			return fmt.Errorf("invalid protocols config: %s state expiration must be positive", name)
+		}
+	}
+
+	for _, connectionID := range c.Mediator.Connections {
+		if connectionID == "" {
+			return errors.New("invalid mediator config: empty connection ID")
+		}
+	}
+
+	if c.Mediator.Timeout < 0 {
+		return errors.New("invalid mediator config: negative timeout")
+	}
+
+	for module, level := range c.LogLevels {
+		if _, err := log.ParseLevel(level); err != nil {
+			return fmt.Errorf("invalid log level of module %s: %w", module, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *TransportsConfig) validate() error {
+	schemes := map[string]bool{}
+
+	for _, inbound := range c.Inbound {
+		if inbound.Scheme != HTTPTransport && inbound.Scheme != WebSocketTransport {
+			return fmt.Errorf("inbound transport [%s] not supported", inbound.Scheme)
+		}
+
+		if schemes[inbound.Scheme] {
+			return fmt.Errorf("duplicate %s inbound transport", inbound.Scheme)
+		}
+
+		schemes[inbound.Scheme] = true
+
+		if inbound.InternalAddr == "" {
+			return fmt.Errorf("%s inbound transport internal address is required", inbound.Scheme)
+		}
+
+		if (inbound.CertFile == "") != (inbound.KeyFile == "") {
+			return fmt.Errorf("%s inbound transport requires both the certificate and the key files", inbound.Scheme)
+		}
+	}
+
+	for _, scheme := range c.Outbound {
+		if scheme != HTTPTransport && scheme != WebSocketTransport {
+			return fmt.Errorf("outbound transport [%s] not supported", scheme)
+		}
+	}
+
+	return nil
+}
+
+// NewFromConfig validates the configuration and initializes the framework from it. The options are applied after
+// the configuration, e.g. to inject the components which can't be configured declaratively (such as the store
+// providers of the component/storage modules). Once the framework is started, the agent registers with the
+// mediators of the configuration which it's not registered with yet. The registrations failing, e.g. while a
+// mediator is unavailable, are logged and skipped.
+func NewFromConfig(cfg *Config, opts ...Option) (*Aries, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	framework, err := New(append(cfg.options(), opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := registerWithMediators(framework, &cfg.Mediator); err != nil {
+		if closeErr := framework.Close(); closeErr != nil {
+			logger.Warnf("failed to close the framework: %s", closeErr)
+		}
+
+		return nil, err
+	}
+
+	return framework, nil
+}
+
+func (c *Config) options() []Option {
+	var opts []Option
+
+	if c.Storage.Type == RedisStorage {
+		opts = append(opts, withRedisStorage(c.Storage.Address))
+	}
+
+	if c.KMS.SecretLockKeyPath != "" {
+		opts = append(opts, withSecretLockKeyPath(c.KMS.SecretLockKeyPath))
+	}
+
+	if c.KMS.MasterKeyURI != "" {
+		opts = append(opts, WithKMS(func(provider kms.Provider) (kms.KeyManager, error) {
+			return localkms.New(c.KMS.MasterKeyURI, provider)
+		}))
+	}
+
+	for _, inbound := range c.Transports.Inbound {
+		opts = append(opts, withInboundTransport(inbound))
+	}
+
+	if len(c.Transports.Outbound) > 0 {
+		opts = append(opts, withOutboundTransports(c.Transports.Outbound))
+	}
+
+	if c.Transports.ReturnRoute != "" {
+		opts = append(opts, WithTransportReturnRoute(c.Transports.ReturnRoute))
+	}
+
+	if c.Protocols.AutoAccept != nil {
+		opts = append(opts, WithAutoAcceptConfig(c.Protocols.AutoAccept))
+	}
+
+	for name, timeout := range c.Protocols.StateExpirations {
+		opts = append(opts, WithProtocolStateExpiration(name, time.Duration(timeout)))
+	}
+
+	for module, level := range c.LogLevels {
+		l, _ := log.ParseLevel(level) // nolint: errcheck // validated
+
+		opts = append(opts, WithLogLevel(module, l))
+	}
+
+	return opts
+}
+
+func withRedisStorage(address string) Option {
+	return func(opts *Aries) error {
+		provider, err := redis.NewProvider(address)
+		if err != nil {
+			return fmt.Errorf("redis storage initialization failed: %w", err)
+		}
+
+		return WithStoreProvider(provider)(opts)
+	}
+}
+
+func withSecretLockKeyPath(path string) Option {
+	return func(opts *Aries) error {
+		masterKeyReader, err := local.MasterKeyFromPath(path)
+		if err != nil {
+			return fmt.Errorf("secret lock master key: %w", err)
+		}
+
+		secretLock, err := local.NewService(masterKeyReader, nil)
+		if err != nil {
+			return fmt.Errorf("secret lock initialization failed: %w", err)
+		}
+
+		return WithSecretLock(secretLock)(opts)
+	}
+}
+
+func withInboundTransport(cfg InboundTransportConfig) Option {
+	return func(opts *Aries) error {
+		var (
+			inbound transport.InboundTransport
+			err     error
+		)
+
+		switch cfg.Scheme {
+		case HTTPTransport:
+			inbound, err = arieshttp.NewInbound(cfg.InternalAddr, cfg.ExternalAddr, cfg.CertFile, cfg.KeyFile)
+		case WebSocketTransport:
+			inbound, err = ws.NewInbound(cfg.InternalAddr, cfg.ExternalAddr, cfg.CertFile, cfg.KeyFile)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s inbound transport initialization failed: %w", cfg.Scheme, err)
+		}
+
+		return WithInboundTransport(inbound)(opts)
+	}
+}
+
+func withOutboundTransports(schemes []string) Option {
+	return func(opts *Aries) error {
+		transports := make([]transport.OutboundTransport, 0, len(schemes))
+
+		for _, scheme := range schemes {
+			switch scheme {
+			case HTTPTransport:
+				outbound, err := arieshttp.NewOutbound(arieshttp.WithOutboundHTTPClient(&http.Client{}))
+				if err != nil {
+					return fmt.Errorf("http outbound transport initialization failed: %w", err)
+				}
+
+				transports = append(transports, outbound)
+			case WebSocketTransport:
+				transports = append(transports, ws.NewOutbound())
+			}
+		}
+
+		return WithOutboundTransports(transports...)(opts)
+	}
+}
+
+type mediatorRegistrar interface {
+	Register(connectionID string, options ...mediator.ClientOption) error
+	GetConnections() ([]string, error)
+}
+
+func registerWithMediators(framework *Aries, cfg *MediatorConfig) error {
+	if len(cfg.Connections) == 0 {
+		return nil
+	}
+
+	ctx, err := framework.Context()
+	if err != nil {
+		return err
+	}
+
+	svc, err := ctx.Service(mediator.Coordination)
+	if err != nil {
+		return fmt.Errorf("mediator registration: %w", err)
+	}
+
+	registrar, ok := svc.(mediatorRegistrar)
+	if !ok {
+		return errors.New("mediator registration: cast to the route coordination service failed")
+	}
+
+	registered, err := registrar.GetConnections()
+	if err != nil {
+		return fmt.Errorf("mediator registration: %w", err)
+	}
+
+	for _, connectionID := range cfg.Connections {
+		if contains(registered, connectionID) {
+			continue
+		}
+
+		if err := registrar.Register(connectionID, cfg.clientOptions); err != nil {
+			logger.Warnf("failed to register with the mediator of connection %s: %s", connectionID, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *MediatorConfig) clientOptions(opts *mediator.ClientOptions) {
+	if c.Timeout > 0 {
+		opts.Timeout = time.Duration(c.Timeout)
+	}
+
+	opts.CoordinationV2 = c.CoordinationV2
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
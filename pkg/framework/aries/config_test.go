@@ -0,0 +1,254 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, os.RemoveAll(dir)) }()
+
+	t.Run("loads the config", func(t *testing.T) {
+		cfg, err := LoadConfig(writeFile(t, dir, "config.json", `{
+			"storage": {"type": "redis", "address": "localhost:6379"},
+			"kms": {"masterKeyURI": "local-lock://agent/master/key/", "secretLockKeyPath": "/etc/agent/master.key"},
+			"transports": {
+				"inbound": [{"scheme": "ws", "internalAddr": ":8080", "externalAddr": "wss://agent.example.com"}],
+				"outbound": ["http", "ws"],
+				"returnRoute": "all"
+			},
+			"protocols": {
+				"autoAccept": {"global": {"didexchange-request": true}},
+				"stateExpirations": {"didexchange": "24h"}
+			},
+			"mediator": {"connections": ["conn1"], "timeout": "10s", "coordinationV2": true},
+			"logLevels": {"aries-framework/out-of-band": "DEBUG"}
+		}`))
+		require.NoError(t, err)
+		require.Equal(t, &Config{
+			Storage: StorageConfig{Type: RedisStorage, Address: "localhost:6379"},
+			KMS: KMSConfig{
+				MasterKeyURI:      "local-lock://agent/master/key/",
+				SecretLockKeyPath: "/etc/agent/master.key",
+			},
+			Transports: TransportsConfig{
+				Inbound: []InboundTransportConfig{{
+					Scheme:       WebSocketTransport,
+					InternalAddr: ":8080",
+					ExternalAddr: "wss://agent.example.com",
+				}},
+				Outbound:    []string{HTTPTransport, WebSocketTransport},
+				ReturnRoute: "all",
+			},
+			Protocols: ProtocolsConfig{
+				AutoAccept: &connection.AutoAcceptConfig{
+					Global: connection.AutoAccept{connection.AutoAcceptDIDExchangeRequest: true},
+				},
+				StateExpirations: map[string]Duration{"didexchange": Duration(24 * time.Hour)},
+			},
+			Mediator: MediatorConfig{
+				Connections:    []string{"conn1"},
+				Timeout:        Duration(10 * time.Second),
+				CoordinationV2: true,
+			},
+			LogLevels: map[string]string{"aries-framework/out-of-band": "DEBUG"},
+		}, cfg)
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("rejects the unknown fields", func(t *testing.T) {
+		_, err := LoadConfig(writeFile(t, dir, "unknown.json", `{"storage": {"kind": "mem"}}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `unknown field "kind"`)
+	})
+
+	t.Run("rejects the invalid durations", func(t *testing.T) {
+		_, err := LoadConfig(writeFile(t, dir, "duration.json", `{"mediator": {"timeout": "ten seconds"}}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid duration")
+
+		_, err = LoadConfig(writeFile(t, dir, "number.json", `{"mediator": {"timeout": 10}}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "duration must be a string")
+	})
+
+	t.Run("fails to read the file", func(t *testing.T) {
+		_, err := LoadConfig(filepath.Join(dir, "missing.json"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read config")
+	})
+}
+
+func TestDuration(t *testing.T) {
+	data, err := json.Marshal(Duration(90 * time.Second))
+	require.NoError(t, err)
+	require.Equal(t, `"1m30s"`, string(data))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{{
+		name: "unsupported storage",
+		cfg:  Config{Storage: StorageConfig{Type: "couchdb"}},
+		err:  "invalid storage config: unsupported type [couchdb]",
+	}, {
+		name: "redis without address",
+		cfg:  Config{Storage: StorageConfig{Type: RedisStorage}},
+		err:  "invalid storage config: redis address is required",
+	}, {
+		name: "unsupported inbound transport",
+		cfg:  Config{Transports: TransportsConfig{Inbound: []InboundTransportConfig{{Scheme: "udp"}}}},
+		err:  "invalid transports config: inbound transport [udp] not supported",
+	}, {
+		name: "duplicate inbound transport",
+		cfg: Config{Transports: TransportsConfig{Inbound: []InboundTransportConfig{
+			{Scheme: HTTPTransport, InternalAddr: ":8080"}, {Scheme: HTTPTransport, InternalAddr: ":8081"},
+		}}},
+		err: "invalid transports config: duplicate http inbound transport",
+	}, {
+		name: "inbound transport without address",
+		cfg:  Config{Transports: TransportsConfig{Inbound: []InboundTransportConfig{{Scheme: HTTPTransport}}}},
+		err:  "invalid transports config: http inbound transport internal address is required",
+	}, {
+		name: "inbound transport without key",
+		cfg: Config{Transports: TransportsConfig{Inbound: []InboundTransportConfig{
+			{Scheme: WebSocketTransport, InternalAddr: ":8080", CertFile: "cert.pem"},
+		}}},
+		err: "invalid transports config: ws inbound transport requires both the certificate and the key files",
+	}, {
+		name: "unsupported outbound transport",
+		cfg:  Config{Transports: TransportsConfig{Outbound: []string{"udp"}}},
+		err:  "invalid transports config: outbound transport [udp] not supported",
+	}, {
+		name: "invalid state expiration",
+		cfg:  Config{Protocols: ProtocolsConfig{StateExpirations: map[string]Duration{"didexchange": 0}}},
+		err:  "invalid protocols config: didexchange state expiration must be positive",
+	}, {
+		name: "empty mediator connection",
+		cfg:  Config{Mediator: MediatorConfig{Connections: []string{""}}},
+		err:  "invalid mediator config: empty connection ID",
+	}, {
+		name: "negative mediator timeout",
+		cfg:  Config{Mediator: MediatorConfig{Timeout: -1}},
+		err:  "invalid mediator config: negative timeout",
+	}, {
+		name: "invalid log level",
+		cfg:  Config{LogLevels: map[string]string{"aries-framework/test": "LOUD"}},
+		err:  "invalid log level of module aries-framework/test",
+	}}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, os.RemoveAll(dir)) }()
+
+	masterKey := make([]byte, sha256.Size)
+	_, err = rand.Read(masterKey)
+	require.NoError(t, err)
+
+	masterKeyPath := writeFile(t, dir, "master.key", base64.URLEncoding.EncodeToString(masterKey))
+
+	t.Run("initializes the framework", func(t *testing.T) {
+		const module = "aries-framework/config-test"
+
+		defer log.SetLevel(module, log.INFO)
+
+		autoAccept := &connection.AutoAcceptConfig{
+			Global: connection.AutoAccept{connection.AutoAcceptCredentialOffer: true},
+		}
+
+		a, err := NewFromConfig(&Config{
+			KMS: KMSConfig{MasterKeyURI: "local-lock://config-test/", SecretLockKeyPath: masterKeyPath},
+			Transports: TransportsConfig{
+				Inbound:     []InboundTransportConfig{{Scheme: HTTPTransport, InternalAddr: "127.0.0.1:0"}},
+				Outbound:    []string{HTTPTransport, WebSocketTransport},
+				ReturnRoute: "all",
+			},
+			Protocols: ProtocolsConfig{
+				AutoAccept:       autoAccept,
+				StateExpirations: map[string]Duration{"didexchange": Duration(time.Hour)},
+			},
+			// the registrations failing are skipped
+			Mediator:  MediatorConfig{Connections: []string{"unknown"}, Timeout: Duration(time.Second)},
+			LogLevels: map[string]string{module: "DEBUG"},
+		})
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, a.Close()) }()
+
+		require.IsType(t, &local.Lock{}, a.secretLock)
+		require.Len(t, a.inboundTransports, 1)
+		require.Len(t, a.outboundTransports, 2)
+		require.Equal(t, "all", a.transportReturnRoute)
+		require.Equal(t, autoAccept, a.autoAcceptConfig)
+		require.Equal(t, map[string]time.Duration{"didexchange": time.Hour}, a.expirations)
+		require.Equal(t, log.DEBUG, log.GetLevel(module))
+	})
+
+	t.Run("applies the options after the config", func(t *testing.T) {
+		a, err := NewFromConfig(&Config{Transports: TransportsConfig{ReturnRoute: "all"}},
+			WithTransportReturnRoute("none"))
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, a.Close()) }()
+
+		require.Equal(t, "none", a.transportReturnRoute)
+	})
+
+	t.Run("validates the config up front", func(t *testing.T) {
+		_, err := NewFromConfig(&Config{Storage: StorageConfig{Type: "couchdb"}})
+		require.EqualError(t, err, "invalid storage config: unsupported type [couchdb]")
+	})
+
+	t.Run("fails to create the secret lock", func(t *testing.T) {
+		_, err := NewFromConfig(&Config{KMS: KMSConfig{SecretLockKeyPath: filepath.Join(dir, "missing.key")}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "secret lock master key")
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
@@ -28,11 +29,16 @@ import (
 	mdpresentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/revocationnotification"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
 	didcommtransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/plugin"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
@@ -56,6 +62,14 @@ func defFrameworkOpts(frameworkOpts *Aries) error {
 		frameworkOpts.storeProvider = storeProvider()
 	}
 
+	if len(frameworkOpts.signatureSuites) > 0 {
+		frameworkOpts.suiteRegistry = suiteregistry.New()
+
+		for proofType, s := range frameworkOpts.signatureSuites {
+			frameworkOpts.suiteRegistry.Register(proofType, s)
+		}
+	}
+
 	err := assignVerifiableStoreIfNeeded(frameworkOpts, frameworkOpts.storeProvider)
 	if err != nil {
 		return err
@@ -66,9 +80,17 @@ func defFrameworkOpts(frameworkOpts *Aries) error {
 	// - DIDExchange depends on Route
 	// - OutOfBand depends on DIDExchange
 	// - Introduce depends on OutOfBand
+	// - DiscoverFeatures discloses the protocols of all services
 	frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators,
 		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(), newOutOfBandSvc(),
-		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc())
+		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc(), newRevocationNotificationSvc(),
+		newTrustPingSvc(), newQuestionAnswerSvc(), newDiscoverFeaturesSvc())
+
+	// the protocol services of the plugins are loaded after those of the framework
+	frameworkOpts.plugins = plugin.Plugins()
+	for _, p := range frameworkOpts.plugins {
+		frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators, p.Service)
+	}
 
 	if frameworkOpts.secretLock == nil && frameworkOpts.kmsCreator == nil {
 		err = createDefSecretLock(frameworkOpts)
@@ -121,11 +143,38 @@ func newPresentProofSvc() api.ProtocolSvcCreator {
 
 		// sets default middleware to the service
 		service.Use(mdpresentproof.SavePresentation(prv))
+		// proposes a presentation for requests carrying a presentation definition
+		service.UseAutoAcceptor(mdpresentproof.PresentationExchange(prv))
 
 		return service, nil
 	}
 }
 
+func newRevocationNotificationSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		// marks the credentials saved by the issue credential middleware as revoked
+		return revocationnotification.New(prv, revocationnotification.WithCredentialStore(prv.VerifiableStore()))
+	}
+}
+
+func newTrustPingSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return trustping.New(prv)
+	}
+}
+
+func newQuestionAnswerSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return questionanswer.New(prv)
+	}
+}
+
+func newDiscoverFeaturesSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return discoverfeatures.New(prv)
+	}
+}
+
 func newRouteSvc() api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
 		return mediator.New(prv)
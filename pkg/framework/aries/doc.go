@@ -18,5 +18,20 @@ Usage:
 
 	// initialize the aries clients
 	didexchangeClient, err := didexchange.New(ctx)
+
+The framework can also be initialized from a declarative configuration (storage, KMS, transports, protocols and
+mediators), validated before any component is created:
+
+	cfg, err := aries.LoadConfig("agent.json")
+
+	framework, err := aries.NewFromConfig(cfg)
+
+A framework can host tenants, each with its own stores, keys and connections, reachable at the endpoint of the
+framework followed by "/tenants/" and the tenant ID:
+
+	tenant, err := framework.NewTenant("alice")
+
+	// get the context of the tenant
+	ctx, err := tenant.Context()
 */
 package aries
@@ -9,9 +9,16 @@ package aries
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
@@ -21,12 +28,18 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/plugin"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/instrumented"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	instrumentedstorage "github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/instrumented"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
@@ -36,8 +49,12 @@ import (
 const (
 	defaultEndpoint     = "didcomm:transport/queue"
 	defaultMasterKeyURI = "local-lock://default/master/key/"
+	// maxExpirationSweepInterval is the longest interval between the protocol state expiration sweeps.
+	maxExpirationSweepInterval = time.Minute
 )
 
+var logger = log.New("aries-framework/framework")
+
 // Aries provides access to the context being managed by the framework. The context can be used to create aries clients.
 type Aries struct {
 	storeProvider              storage.Provider
@@ -62,8 +79,28 @@ type Aries struct {
 	vdrRegistry                vdrapi.Registry
 	vdr                        []vdrapi.VDR
 	verifiableStore            verifiable.Store
+	metricsProvider            metrics.Provider
+	tracer                     tracing.Tracer
+	clock                      clock.Clock
+	outboundRateLimit          dispatcher.RateLimit
+	autoAcceptConfig           *connection.AutoAcceptConfig
+	jsonLimits                 *jsonutil.Limits
+	cache                      *cache.Cache
+	signatureSuites            map[string]suiteregistry.Suite
+	suiteRegistry              *suiteregistry.Registry
 	transportReturnRoute       string
 	id                         string
+	expirations                map[string]time.Duration
+	stopExpiration             chan struct{}
+	plugins                    []plugin.Plugin
+	hooks                      []Hook
+	startedHooks               []Hook
+	stopped                    bool
+	host                       *Aries
+	tenantID                   string
+	tenants                    map[string]*Aries
+	tenantsLock                sync.RWMutex
+	transportProvider          transport.Provider
 }
 
 // Option configures the framework.
@@ -103,6 +140,12 @@ func New(opts ...Option) (*Aries, error) {
 
 func initializeServices(frameworkOpts *Aries) (*Aries, error) {
 	// Order of initializing service is important
+	// Trace the stores
+	if frameworkOpts.tracer != nil {
+		frameworkOpts.storeProvider = instrumentedstorage.NewProvider(frameworkOpts.storeProvider, nil,
+			instrumentedstorage.WithTracer(frameworkOpts.tracer))
+	}
+
 	// Create kms
 	if e := createKMS(frameworkOpts); e != nil {
 		return nil, e
@@ -138,6 +181,16 @@ func initializeServices(frameworkOpts *Aries) (*Aries, error) {
 		return nil, err
 	}
 
+	// Start protocol state expiration sweepers (must be done after services are loaded)
+	if err := startExpirationSweepers(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Start the custom services (must be done once the framework is started)
+	if err := startHooks(frameworkOpts); err != nil {
+		return nil, err
+	}
+
 	return frameworkOpts, nil
 }
 
@@ -248,6 +301,112 @@ func WithMessageServiceProvider(msv api.MessageServiceProvider) Option {
 	}
 }
 
+// WithMetricsProvider injects a metrics provider into the Aries framework. The protocol services record
+// the number of started, completed and failed exchanges and the durations of their state transitions, the
+// transports and the outbound dispatcher the number of received and sent messages, and the KMS the durations
+// of its operations.
+func WithMetricsProvider(p metrics.Provider) Option {
+	return func(opts *Aries) error {
+		opts.metricsProvider = p
+		return nil
+	}
+}
+
+// WithTracer traces the framework with the tracer, such as an OpenTelemetry tracer exporting the spans to a
+// collector (see the component/tracing/otel module). The transports, the outbound dispatcher and the protocol
+// services trace the DIDComm messages, propagating the trace context to the other agents in the ~trace_context
+// decorator, and the KMS and the stores trace their operations.
+func WithTracer(t tracing.Tracer) Option {
+	return func(opts *Aries) error {
+		opts.tracer = t
+		return nil
+	}
+}
+
+// WithOutboundRateLimit limits the rate of the messages sent to each counterparty (each endpoint, e.g. the mediator of
+// the counterparty, or each connection), the messages exceeding the rate being queued and delayed. See
+// dispatcher.RateLimit.
+func WithOutboundRateLimit(limit dispatcher.RateLimit) Option {
+	return func(opts *Aries) error {
+		opts.outboundRateLimit = limit
+		return nil
+	}
+}
+
+// WithClock sets the clock of the time-dependent checks of the protocol services, such as the expiry of their
+// pending actions, the clock of the system if not set.
+func WithClock(c clock.Clock) Option {
+	return func(opts *Aries) error {
+		opts.clock = c
+		return nil
+	}
+}
+
+// WithLoggerProvider routes the framework logs to the logger provider, such as an adapter of the logging library of
+// the application (see the component/log modules). The contextual fields of the messages are kept apart by the
+// loggers implementing log.StructuredLogger. The logger provider is global: it replaces the default logger, or the
+// provider of another framework instance, for all the framework logs from then on.
+func WithLoggerProvider(p log.LoggerProvider) Option {
+	return func(opts *Aries) error {
+		log.Initialize(p)
+		return nil
+	}
+}
+
+// WithLogLevel sets the log level of the module (e.g. "aries-framework/framework"), of all the modules without
+// a level of their own if the module is empty. The log levels are global to the framework instances.
+func WithLogLevel(module string, level log.Level) Option {
+	return func(opts *Aries) error {
+		log.SetLevel(module, level)
+		return nil
+	}
+}
+
+// WithAutoAcceptConfig configures which inbound didexchange requests, credential offers and presentation
+// requests are accepted without triggering an action event, globally and per invitation goal code.
+// The configuration can be overridden per connection (see connection.Recorder SaveAutoAccept).
+func WithAutoAcceptConfig(config *connection.AutoAcceptConfig) Option {
+	return func(opts *Aries) error {
+		opts.autoAcceptConfig = config
+		return nil
+	}
+}
+
+// WithJSONLimits sets the limits of the JSON documents received by the agent: the envelopes unpacked by the packers,
+// the DID documents of the DID exchanges and the credentials and presentations verified by the controllers.
+// The limits are jsonutil.DefaultLimits if not set.
+func WithJSONLimits(limits jsonutil.Limits) Option {
+	return func(opts *Aries) error {
+		opts.jsonLimits = &limits
+		return nil
+	}
+}
+
+// WithCache sets the cache shared by the framework components: the credentials parsed by the protocol services,
+// the controllers and the wallet, the DID documents resolved by the VDR registry and the JSON-LD contexts they load.
+// Nothing is cached if not set. The tenants don't share the cache of their host, their DID documents being their own.
+func WithCache(c *cache.Cache) Option {
+	return func(opts *Aries) error {
+		opts.cache = c
+		return nil
+	}
+}
+
+// WithSignatureSuite registers the suite of a proof type of the linked data proofs, so that the credentials,
+// presentations and DID documents with such proofs are verified, and signed by the controllers, by the framework.
+// The suite replaces the suite of the framework of the same proof type, if any.
+func WithSignatureSuite(proofType string, s suiteregistry.Suite) Option {
+	return func(opts *Aries) error {
+		if opts.signatureSuites == nil {
+			opts.signatureSuites = map[string]suiteregistry.Suite{}
+		}
+
+		opts.signatureSuites[proofType] = s
+
+		return nil
+	}
+}
+
 // WithPacker injects at least one Packer service into the Aries framework,
 // with the primary Packer being used for inbound/outbound communication
 // and the additional packers being available for unpacking inbound messages.
@@ -268,6 +427,26 @@ func WithVerifiableStore(store verifiable.Store) Option {
 	}
 }
 
+// WithProtocolStateExpiration abandons the actions of the given protocol (e.g. didexchange requests) that were
+// neither accepted nor rejected within the timeout. The pending actions are swept in the background and
+// the protocol service fires the message events of the expired actions. The protocol service must support
+// the expiration (e.g. didexchange, issuecredential, presentproof).
+func WithProtocolStateExpiration(protocolName string, timeout time.Duration) Option {
+	return func(opts *Aries) error {
+		if timeout <= 0 {
+			return fmt.Errorf("invalid %s protocol state expiration timeout %s", protocolName, timeout)
+		}
+
+		if opts.expirations == nil {
+			opts.expirations = map[string]time.Duration{}
+		}
+
+		opts.expirations[protocolName] = timeout
+
+		return nil
+	}
+}
+
 // Context provides a handle to the framework context.
 func (a *Aries) Context() (*context.Provider, error) {
 	return context.New(
@@ -289,6 +468,13 @@ func (a *Aries) Context() (*context.Provider, error) {
 		context.WithAriesFrameworkID(a.id),
 		context.WithMessageServiceProvider(a.msgSvcProvider),
 		context.WithVerifiableStore(a.verifiableStore),
+		context.WithMetricsProvider(a.metricsProvider),
+		context.WithTracer(a.tracer),
+		context.WithClock(a.clock),
+		context.WithAutoAcceptConfig(a.autoAcceptConfig),
+		context.WithJSONLimits(a.jsonLimits),
+		context.WithCache(a.cache),
+		context.WithSignatureSuites(a.suiteRegistry),
 	)
 }
 
@@ -298,41 +484,6 @@ func (a *Aries) Messenger() service.Messenger {
 	return a.messenger
 }
 
-// Close frees resources being maintained by the framework.
-func (a *Aries) Close() error {
-	if a.storeProvider != nil {
-		err := a.storeProvider.Close()
-		if err != nil {
-			return fmt.Errorf("failed to close the store: %w", err)
-		}
-	}
-
-	if a.protocolStateStoreProvider != nil {
-		err := a.protocolStateStoreProvider.Close()
-		if err != nil {
-			return fmt.Errorf("failed to close the store: %w", err)
-		}
-	}
-
-	for _, inbound := range a.inboundTransports {
-		if err := inbound.Stop(); err != nil {
-			return fmt.Errorf("inbound transport close failed: %w", err)
-		}
-	}
-
-	return a.closeVDR()
-}
-
-func (a *Aries) closeVDR() error {
-	if a.vdrRegistry != nil {
-		if err := a.vdrRegistry.Close(); err != nil {
-			return fmt.Errorf("vdr registry close failed: %w", err)
-		}
-	}
-
-	return nil
-}
-
 func createKMS(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithStorageProvider(frameworkOpts.storeProvider),
@@ -347,6 +498,11 @@ func createKMS(frameworkOpts *Aries) error {
 		return fmt.Errorf("create KMS failed: %w", err)
 	}
 
+	if frameworkOpts.metricsProvider != nil || frameworkOpts.tracer != nil {
+		frameworkOpts.kms = instrumented.New(frameworkOpts.kms, frameworkOpts.metricsProvider,
+			instrumented.WithTracer(frameworkOpts.tracer))
+	}
+
 	return nil
 }
 
@@ -375,6 +531,7 @@ func createVDR(frameworkOpts *Aries) error {
 		vdr.WithVDR(p),
 		vdr.WithDefaultServiceType(vdrapi.DIDCommServiceType),
 		vdr.WithDefaultServiceEndpoint(ctx.ServiceEndpoint()),
+		vdr.WithCache(frameworkOpts.cache),
 	)
 
 	k := key.New()
@@ -411,12 +568,21 @@ func createOutboundDispatcher(frameworkOpts *Aries) error {
 		context.WithPackager(frameworkOpts.packager),
 		context.WithTransportReturnRoute(frameworkOpts.transportReturnRoute),
 		context.WithVDRegistry(frameworkOpts.vdrRegistry),
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+		context.WithMetricsProvider(frameworkOpts.metricsProvider),
+		context.WithTracer(frameworkOpts.tracer),
+		context.WithClock(frameworkOpts.clock),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
 	}
 
-	frameworkOpts.outboundDispatcher = dispatcher.NewOutbound(ctx)
+	frameworkOpts.outboundDispatcher, err = dispatcher.NewOutbound(ctx,
+		dispatcher.WithRateLimit(frameworkOpts.outboundRateLimit))
+	if err != nil {
+		return fmt.Errorf("failed to init outbound dispatcher: %w", err)
+	}
 
 	return nil
 }
@@ -429,18 +595,26 @@ func startTransports(frameworkOpts *Aries) error {
 		context.WithAriesFrameworkID(frameworkOpts.id),
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 		context.WithMessengerHandler(frameworkOpts.messenger),
+		context.WithMetricsProvider(frameworkOpts.metricsProvider),
+		context.WithTracer(frameworkOpts.tracer),
+		context.WithClock(frameworkOpts.clock),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
 	}
 
 	for _, inbound := range frameworkOpts.inboundTransports {
-		// Start the inbound transport
-		if err = inbound.Start(ctx); err != nil {
+		// Start the inbound transport, routing the messages sent to the tenants
+		if err = inbound.Start(&tenantHost{Provider: ctx, aries: frameworkOpts}); err != nil {
 			return fmt.Errorf("inbound transport start failed: %w", err)
 		}
 	}
 
+	// the outbound transports of a tenant are those of its host, already started
+	if frameworkOpts.host != nil {
+		return nil
+	}
+
 	// Start the outbound transport
 	for _, outbound := range frameworkOpts.outboundTransports {
 		if err = outbound.Start(ctx); err != nil {
@@ -465,6 +639,13 @@ func loadServices(frameworkOpts *Aries) error {
 		context.WithVDRegistry(frameworkOpts.vdrRegistry),
 		context.WithVerifiableStore(frameworkOpts.verifiableStore),
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
+		context.WithMetricsProvider(frameworkOpts.metricsProvider),
+		context.WithTracer(frameworkOpts.tracer),
+		context.WithClock(frameworkOpts.clock),
+		context.WithAutoAcceptConfig(frameworkOpts.autoAcceptConfig),
+		context.WithJSONLimits(frameworkOpts.jsonLimits),
+		context.WithCache(frameworkOpts.cache),
+		context.WithSignatureSuites(frameworkOpts.suiteRegistry),
 	)
 	if err != nil {
 		return fmt.Errorf("create context failed: %w", err)
@@ -484,14 +665,99 @@ func loadServices(frameworkOpts *Aries) error {
 		}
 	}
 
+	return checkPlugins(frameworkOpts)
+}
+
+// checkPlugins checks that the message types of the plugins aren't handled by the services loaded before them,
+// the protocol services of the plugins being the last ones.
+func checkPlugins(frameworkOpts *Aries) error {
+	first := len(frameworkOpts.services) - len(frameworkOpts.plugins)
+
+	for i, p := range frameworkOpts.plugins {
+		for _, msgType := range p.MessageTypes {
+			for _, svc := range frameworkOpts.services[:first+i] {
+				if svc.Accept(msgType) {
+					return fmt.Errorf("message type %s of the %s plugin is already handled by the %s service",
+						msgType, p.Name, svc.Name())
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
+// expirable is implemented by the protocol services supporting the protocol state expiration.
+type expirable interface {
+	ExpireAll(timeout time.Duration) error
+}
+
+func startExpirationSweepers(frameworkOpts *Aries) error {
+	if len(frameworkOpts.expirations) == 0 {
+		return nil
+	}
+
+	sweepers := make(map[expirable]time.Duration, len(frameworkOpts.expirations))
+
+	for name, timeout := range frameworkOpts.expirations {
+		var svc dispatcher.ProtocolService
+
+		for _, v := range frameworkOpts.services {
+			if v.Name() == name {
+				svc = v
+				break
+			}
+		}
+
+		if svc == nil {
+			return fmt.Errorf("protocol state expiration: protocol %s is not loaded", name)
+		}
+
+		sweeper, ok := svc.(expirable)
+		if !ok {
+			return fmt.Errorf("protocol state expiration: protocol %s does not support expiration", name)
+		}
+
+		sweepers[sweeper] = timeout
+	}
+
+	frameworkOpts.stopExpiration = make(chan struct{})
+
+	for sweeper, timeout := range sweepers {
+		go sweepExpired(sweeper, timeout, frameworkOpts.stopExpiration)
+	}
+
+	return nil
+}
+
+// sweepExpired periodically expires the pending actions of the protocol until the stop channel is closed.
+func sweepExpired(svc expirable, timeout time.Duration, stop <-chan struct{}) {
+	interval := timeout
+	if interval > maxExpirationSweepInterval {
+		interval = maxExpirationSweepInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := svc.ExpireAll(timeout); err != nil {
+				logger.Warnf("protocol state expiration: %s", err)
+			}
+		}
+	}
+}
+
 func createPackersAndPackager(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithCrypto(frameworkOpts.crypto),
 		context.WithStorageProvider(frameworkOpts.storeProvider),
 		context.WithKMS(frameworkOpts.kms),
+		context.WithJSONLimits(frameworkOpts.jsonLimits),
 	)
 	if err != nil {
 		return fmt.Errorf("create packer context failed: %w", err)
@@ -538,6 +804,10 @@ func routingEndpoint(frameworkOpts *Aries) string {
 }
 
 func fetchEndpoint(frameworkOpts *Aries, defaultScheme string) string {
+	if frameworkOpts.host != nil {
+		return transport.TenantEndpoint(fetchEndpoint(frameworkOpts.host, defaultScheme), frameworkOpts.tenantID)
+	}
+
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/1161 Select Service and Router
 	//  endpoint from Multiple Inbound Transports
 	for _, inbound := range frameworkOpts.inboundTransports {
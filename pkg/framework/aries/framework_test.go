@@ -18,22 +18,36 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log/mocklogger"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/plugin"
+	metricsMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/instrumented"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
@@ -42,12 +56,15 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	locallock "github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	instrumentedstorage "github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/instrumented"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
 )
 
@@ -196,6 +213,38 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test protocol svc - discover features discloses the framework protocols", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		raw, err := ctx.Service(discoverfeatures.Name)
+		require.NoError(t, err)
+
+		disclosures := raw.(*discoverfeatures.Service).Disclose(&discoverfeatures.Query{
+			FeatureType: discoverfeatures.FeatureTypeProtocol,
+			Match:       "https://didcomm.org/didexchange/*",
+		})
+		require.Len(t, disclosures, 1)
+		require.Equal(t, didexchange.PIURI, disclosures[0].ID)
+
+		disclosures = raw.(*discoverfeatures.Service).Disclose(&discoverfeatures.Query{
+			FeatureType: discoverfeatures.FeatureTypeProtocol,
+			Match:       trustping.PIURI,
+		})
+		require.Len(t, disclosures, 1)
+
+		disclosures = raw.(*discoverfeatures.Service).Disclose(&discoverfeatures.Query{
+			FeatureType: discoverfeatures.FeatureTypeProtocol,
+			Match:       questionanswer.PIURI,
+		})
+		require.Len(t, disclosures, 1)
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test protocol svc - with user provided protocol", func(t *testing.T) {
 		newMockSvc := func(prv api.Provider) (dispatcher.ProtocolService, error) {
 			return &mockdidexchange.MockDIDExchangeSvc{
@@ -239,6 +288,96 @@ func TestFramework(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("test protocol state expiration", func(t *testing.T) {
+		const timeout = 10 * time.Millisecond
+
+		expired := make(chan time.Duration, 1)
+
+		newMockSvc := func(prv api.Provider) (dispatcher.ProtocolService, error) {
+			return &mockdidexchange.MockDIDExchangeSvc{
+				ProtocolName: "mockProtocolSvc",
+				ExpireAllFunc: func(timeout time.Duration) error {
+					select {
+					case expired <- timeout:
+					default:
+					}
+
+					return nil
+				},
+			}, nil
+		}
+
+		aries, err := New(WithProtocols(newMockSvc), WithInboundTransport(&mockInboundTransport{}),
+			WithProtocolStateExpiration("mockProtocolSvc", timeout))
+		require.NoError(t, err)
+
+		select {
+		case v := <-expired:
+			require.Equal(t, timeout, v)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test protocol state expiration - invalid timeout", func(t *testing.T) {
+		_, err := New(WithProtocolStateExpiration(didexchange.DIDExchange, 0))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid didexchange protocol state expiration timeout 0s")
+	})
+
+	t.Run("test protocol state expiration - protocol is not loaded", func(t *testing.T) {
+		_, err := New(WithProtocolStateExpiration("unknown", time.Hour))
+		require.EqualError(t, err, "protocol state expiration: protocol unknown is not loaded")
+	})
+
+	t.Run("test protocol state expiration - protocol does not support expiration", func(t *testing.T) {
+		_, err := New(WithProtocolStateExpiration(trustping.Name, time.Hour))
+		require.EqualError(t, err, "protocol state expiration: protocol trustping does not support expiration")
+	})
+
+	t.Run("test protocol svc - with plugin", func(t *testing.T) {
+		plugin.Register(plugin.Plugin{
+			Name:         "loyalty",
+			MessageTypes: []string{"https://example.com/loyalty/1.0/points"},
+			Service: func(prv api.Provider) (dispatcher.ProtocolService, error) {
+				return &mockdidexchange.MockDIDExchangeSvc{
+					ProtocolName: "loyalty",
+					AcceptFunc: func(msgType string) bool {
+						return strings.HasPrefix(msgType, "https://example.com/loyalty/")
+					},
+				}, nil
+			},
+		})
+		defer plugin.Unregister("loyalty")
+
+		aries, err := New()
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		_, err = ctx.Service("loyalty")
+		require.NoError(t, err)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test protocol svc - with plugin handling the messages of another service", func(t *testing.T) {
+		plugin.Register(plugin.Plugin{
+			Name:         "ping",
+			MessageTypes: []string{trustping.PingMsgType},
+			Service: func(prv api.Provider) (dispatcher.ProtocolService, error) {
+				return &mockdidexchange.MockDIDExchangeSvc{ProtocolName: "ping"}, nil
+			},
+		})
+		defer plugin.Unregister("ping")
+
+		_, err := New()
+		require.EqualError(t, err, fmt.Sprintf(
+			"message type %s of the ping plugin is already handled by the trustping service", trustping.PingMsgType))
+	})
+
 	t.Run("test error from protocol service", func(t *testing.T) {
 		newMockSvc := func(prv api.Provider) (dispatcher.ProtocolService, error) {
 			return nil, errors.New("error creating the protocol")
@@ -598,6 +737,153 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, mockStore, aries.verifiableStore)
 	})
+
+	t.Run("test metrics provider option", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		metricsProvider := metricsMocks.NewMockProvider(ctrl)
+		metricsProvider.EXPECT().Counter(gomock.Any()).Return(metricsMocks.NewMockCounter(ctrl)).AnyTimes()
+		metricsProvider.EXPECT().Histogram(gomock.Any()).Return(metricsMocks.NewMockHistogram(ctrl)).AnyTimes()
+
+		aries, err := New(WithMetricsProvider(metricsProvider))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, metricsProvider, ctx.Metrics())
+		require.IsType(t, &instrumented.KeyManager{}, ctx.KMS())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test tracer option", func(t *testing.T) {
+		tracer := &mocktracing.Tracer{}
+
+		aries, err := New(WithTracer(tracer))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, tracer, ctx.Tracer())
+		require.IsType(t, &instrumented.KeyManager{}, ctx.KMS())
+		require.IsType(t, &instrumentedstorage.Provider{}, ctx.StorageProvider())
+
+		_, _, err = ctx.KMS().Create(kms.ED25519Type)
+		require.NoError(t, err)
+		require.NotEmpty(t, tracer.Spans(tracing.KMSSpan))
+		require.NotEmpty(t, tracer.Spans(tracing.StorageSpan))
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test outbound rate limit option", func(t *testing.T) {
+		limit := dispatcher.RateLimit{Rate: 5, Burst: 10, PerConnection: true}
+
+		aries, err := New(WithOutboundRateLimit(limit))
+		require.NoError(t, err)
+		require.Equal(t, limit, aries.outboundRateLimit)
+
+		tenant, err := aries.NewTenant("tenant-1")
+		require.NoError(t, err)
+		require.Equal(t, limit, tenant.outboundRateLimit)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test log level option", func(t *testing.T) {
+		const module = "aries-framework/framework-test"
+
+		defer log.SetLevel(module, log.INFO)
+
+		aries, err := New(WithLogLevel(module, log.DEBUG))
+		require.NoError(t, err)
+		require.Equal(t, log.DEBUG, log.GetLevel(module))
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test logger provider option", func(t *testing.T) {
+		const module = "aries-framework/framework-test"
+
+		defer log.Initialize(nil)
+
+		logger := log.New(module)
+		logger.Infof("logged by the default logger")
+
+		provider := &mocklogger.Provider{MockLogger: &mocklogger.MockLogger{}}
+
+		aries, err := New(WithLoggerProvider(provider))
+		require.NoError(t, err)
+
+		logger.Infof("logged by the logger provider")
+		require.Contains(t, provider.MockLogger.InfoLogContents, "logged by the logger provider")
+		require.NotContains(t, provider.MockLogger.AllLogContents, "logged by the default logger")
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test auto accept config option", func(t *testing.T) {
+		config := &connection.AutoAcceptConfig{
+			GoalCodes: map[string]connection.AutoAccept{
+				"aries.vc.issue": {connection.AutoAcceptCredentialOffer: true},
+			},
+		}
+
+		aries, err := New(WithAutoAcceptConfig(config))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, config, ctx.AutoAcceptConfig())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test cache option", func(t *testing.T) {
+		c := cache.New()
+
+		aries, err := New(WithCache(c))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, c, ctx.Cache())
+
+		didDoc, err := ctx.VDRegistry().Create(peer.DIDMethod)
+		require.NoError(t, err)
+
+		_, err = ctx.VDRegistry().Resolve(didDoc.ID)
+		require.NoError(t, err)
+
+		_, ok := c.Get(cache.DIDDocKeyPrefix + didDoc.ID)
+		require.True(t, ok)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test signature suite option", func(t *testing.T) {
+		aries, err := New()
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Nil(t, ctx.SignatureSuites())
+		require.NoError(t, aries.Close())
+
+		aries, err = New(WithSignatureSuite("MyProof2021", suiteregistry.Suite{
+			Verifier: func(map[string]interface{}) verifier.SignatureSuite {
+				return ed25519signature2018.New()
+			},
+		}))
+		require.NoError(t, err)
+
+		ctx, err = aries.Context()
+		require.NoError(t, err)
+		require.True(t, ctx.SignatureSuites().Supports("MyProof2021"))
+		require.True(t, ctx.SignatureSuites().Supports(suiteregistry.Ed25519Signature2018))
+
+		tenant, err := aries.NewTenant("tenant")
+		require.NoError(t, err)
+
+		ctx, err = tenant.Context()
+		require.NoError(t, err)
+		require.True(t, ctx.SignatureSuites().Supports("MyProof2021"))
+		require.NoError(t, aries.Close())
+	})
 }
 
 func Test_Packager(t *testing.T) {
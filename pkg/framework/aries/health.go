@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+// healthChecker is an inbound transport checking that it's listening.
+type healthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// mediatorConnections returns the connections to the mediators the agent is registered with.
+type mediatorConnections interface {
+	GetConnections() ([]string, error)
+}
+
+// HealthChecker returns a health checker probing the components of the framework, e.g. for the liveness and
+// readiness probes of Kubernetes (see the health command and REST endpoints of the controller):
+//   - the framework is live until it's stopped
+//   - it's ready if its stores are reachable, its KMS is unlocked, its inbound transports are listening and its
+//     connections to the mediators it's registered with are completed.
+//
+// The probes of the custom components of the agent can be registered with the checker.
+func (a *Aries) HealthChecker(opts ...health.Option) *health.Checker {
+	checker := health.NewChecker(opts...)
+
+	checker.Register("framework", health.Liveness, func(context.Context) error {
+		a.tenantsLock.RLock()
+		defer a.tenantsLock.RUnlock()
+
+		if a.stopped {
+			return errors.New("the framework is stopped")
+		}
+
+		return nil
+	})
+
+	checker.Register("storage", health.Readiness, health.Storage(a.storeProvider))
+	checker.Register("protocolStateStorage", health.Readiness, health.Storage(a.protocolStateStoreProvider))
+	checker.Register("kms", health.Readiness, health.KMS(a.kms, a.storeProvider))
+
+	for _, inbound := range a.inboundTransports {
+		if probe, ok := inbound.(healthChecker); ok {
+			checker.Register("inbound "+inbound.Endpoint(), health.Readiness, probe.HealthCheck)
+		}
+	}
+
+	checker.Register("mediator", health.Readiness, a.checkMediators)
+
+	return checker
+}
+
+// checkMediators checks that the connections to the mediators the agent is registered with are completed.
+func (a *Aries) checkMediators(context.Context) error {
+	var svc mediatorConnections
+
+	for _, s := range a.services {
+		if s.Name() == mediator.Coordination {
+			svc, _ = s.(mediatorConnections)
+
+			break
+		}
+	}
+
+	if svc == nil {
+		return nil
+	}
+
+	connIDs, err := svc.GetConnections()
+	if err != nil {
+		return fmt.Errorf("get mediator connections: %w", err)
+	}
+
+	ctx, err := a.Context()
+	if err != nil {
+		return fmt.Errorf("create context: %w", err)
+	}
+
+	lookup, err := connection.NewLookup(ctx)
+	if err != nil {
+		return fmt.Errorf("create connection lookup: %w", err)
+	}
+
+	for _, connID := range connIDs {
+		record, e := lookup.GetConnectionRecord(connID)
+		if e != nil {
+			return fmt.Errorf("get mediator connection %s: %w", connID, e)
+		}
+
+		if record.State != didexchange.StateIDCompleted {
+			return fmt.Errorf("mediator connection %s is %s", connID, record.State)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/health"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/transportutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+func TestAries_HealthChecker(t *testing.T) {
+	t.Run("probes the components of the framework", func(t *testing.T) {
+		address := fmt.Sprintf("127.0.0.1:%d", transportutil.GetRandomPort(1))
+
+		inbound, err := arieshttp.NewInbound(address, "http://agent.example.com", "", "")
+		require.NoError(t, err)
+
+		a, err := New(WithInboundTransport(inbound), WithStoreProvider(mem.NewProvider()))
+		require.NoError(t, err)
+
+		checker := a.HealthChecker()
+
+		require.Eventually(t, func() bool {
+			return checker.Ready(context.Background()).Status == health.StatusUp
+		}, time.Second, 10*time.Millisecond)
+
+		require.Equal(t, &health.Report{
+			Status: health.StatusUp,
+			Components: map[string]health.ComponentStatus{
+				"framework":                        {Status: health.StatusUp},
+				"storage":                          {Status: health.StatusUp},
+				"protocolStateStorage":             {Status: health.StatusUp},
+				"kms":                              {Status: health.StatusUp},
+				"inbound http://agent.example.com": {Status: health.StatusUp},
+				"mediator":                         {Status: health.StatusUp},
+			},
+		}, checker.Ready(context.Background()))
+
+		require.NoError(t, a.Close())
+
+		report := checker.Live(context.Background())
+		require.Equal(t, health.StatusDown, report.Status)
+		require.Equal(t, health.ComponentStatus{Status: health.StatusDown, Error: "the framework is stopped"},
+			report.Components["framework"])
+		report = checker.Ready(context.Background())
+		require.Equal(t, health.StatusDown, report.Components["inbound http://agent.example.com"].Status)
+	})
+
+	t.Run("probes the mediator connections", func(t *testing.T) {
+		a, err := New(WithStoreProvider(mem.NewProvider()))
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, a.Close()) }()
+
+		a.services = append([]dispatcher.ProtocolService{&mediatorService{connections: []string{"conn1"}}},
+			a.services...)
+
+		require.EqualError(t, a.checkMediators(context.Background()),
+			"get mediator connection conn1: data not found")
+
+		ctx, err := a.Context()
+		require.NoError(t, err)
+
+		recorder, err := connection.NewRecorder(ctx)
+		require.NoError(t, err)
+
+		record := &connection.Record{ConnectionID: "conn1", State: didexchange.StateIDRequested}
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		require.EqualError(t, a.checkMediators(context.Background()), "mediator connection conn1 is requested")
+
+		record.State = didexchange.StateIDCompleted
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		require.NoError(t, a.checkMediators(context.Background()))
+	})
+}
+
+type mediatorService struct {
+	dispatcher.ProtocolService
+	connections []string
+}
+
+func (m *mediatorService) Name() string {
+	return mediator.Coordination
+}
+
+func (m *mediatorService) GetConnections() ([]string, error) {
+	return m.connections, nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// Hook is a custom service started and stopped with the framework.
+type Hook interface {
+	// OnStart is called once the framework is started, the framework initialization fails on error
+	OnStart(framework *Aries) error
+	// OnStop is called on the shutdown of the framework, once the inbound transports are stopped and before the
+	// outbound messages are drained, so the service can still send messages
+	OnStop(ctx context.Context) error
+}
+
+// WithHooks starts the custom services once the framework is started, in order, and stops them on its shutdown,
+// in the reverse order.
+func WithHooks(hooks ...Hook) Option {
+	return func(opts *Aries) error {
+		opts.hooks = append(opts.hooks, hooks...)
+		return nil
+	}
+}
+
+// gracefulStopper is an inbound transport or an outbound dispatcher waiting for the messages being received or
+// sent while shutting down.
+type gracefulStopper interface {
+	Shutdown(ctx context.Context) error
+}
+
+// flusher is a store provider buffering the writes.
+type flusher interface {
+	Flush() error
+}
+
+// Shutdown stops the framework gracefully, so it can be cycled without losing messages:
+//   - it stops the inbound transports, waiting for the messages being received
+//   - it stops the tenants (see NewTenant)
+//   - it stops the custom services (see WithHooks)
+//   - it drains the outbound dispatcher, waiting for the messages being sent
+//   - it flushes and closes the stores, then closes the KMS and the VDR registry.
+//
+// The steps waiting for the messages give up once ctx is done, the remaining steps are performed anyway. The first
+// error is returned. A tenant framework is removed from the tenants of its host.
+func (a *Aries) Shutdown(ctx context.Context) error {
+	if a.host != nil {
+		defer a.host.removeTenant(a.tenantID)
+	}
+
+	return a.shutdown(ctx)
+}
+
+func (a *Aries) shutdown(ctx context.Context) error {
+	a.tenantsLock.Lock()
+	stopped := a.stopped
+	a.stopped = true
+	a.tenantsLock.Unlock()
+
+	if stopped {
+		return nil
+	}
+
+	if a.stopExpiration != nil {
+		close(a.stopExpiration)
+		a.stopExpiration = nil
+	}
+
+	var errs []error
+
+	errs = append(errs, a.stopInbound(ctx)...)
+	errs = append(errs, a.stopTenants(ctx)...)
+	errs = append(errs, a.stopHooks(ctx)...)
+
+	if outbound, ok := a.outboundDispatcher.(gracefulStopper); ok {
+		if err := outbound.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, closeStore(a.storeProvider), closeStore(a.protocolStateStoreProvider))
+
+	if closer, ok := a.kms.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close the KMS: %w", err))
+		}
+	}
+
+	errs = append(errs, a.closeVDR())
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close frees resources being maintained by the framework, see Shutdown.
+func (a *Aries) Close() error {
+	return a.Shutdown(context.Background())
+}
+
+func (a *Aries) stopInbound(ctx context.Context) []error {
+	var errs []error
+
+	for _, inbound := range a.inboundTransports {
+		var err error
+
+		if graceful, ok := inbound.(gracefulStopper); ok {
+			err = graceful.Shutdown(ctx)
+		} else {
+			err = inbound.Stop()
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("inbound transport close failed: %w", err))
+		}
+	}
+
+	return errs
+}
+
+func (a *Aries) stopHooks(ctx context.Context) []error {
+	var errs []error
+
+	for i := len(a.startedHooks) - 1; i >= 0; i-- {
+		if err := a.startedHooks[i].OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop the custom service: %w", err))
+		}
+	}
+
+	a.startedHooks = nil
+
+	return errs
+}
+
+func (a *Aries) closeVDR() error {
+	if a.vdrRegistry != nil {
+		if err := a.vdrRegistry.Close(); err != nil {
+			return fmt.Errorf("vdr registry close failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func closeStore(provider storage.Provider) error {
+	if provider == nil {
+		return nil
+	}
+
+	if f, ok := provider.(flusher); ok {
+		if err := f.Flush(); err != nil {
+			return fmt.Errorf("failed to flush the store: %w", err)
+		}
+	}
+
+	if err := provider.Close(); err != nil {
+		return fmt.Errorf("failed to close the store: %w", err)
+	}
+
+	return nil
+}
+
+// startHooks starts the custom services, the services already started are stopped if one fails to start.
+func startHooks(frameworkOpts *Aries) error {
+	for _, hook := range frameworkOpts.hooks {
+		if err := hook.OnStart(frameworkOpts); err != nil {
+			for _, stopErr := range frameworkOpts.stopHooks(context.Background()) {
+				logger.Warnf("%s", stopErr)
+			}
+
+			return fmt.Errorf("failed to start the custom service: %w", err)
+		}
+
+		frameworkOpts.startedHooks = append(frameworkOpts.startedHooks, hook)
+	}
+
+	return nil
+}
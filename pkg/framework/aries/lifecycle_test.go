@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestAries_Shutdown(t *testing.T) {
+	t.Run("stops the framework in order", func(t *testing.T) {
+		var events []string
+
+		a, err := New(
+			WithInboundTransport(&recordingInbound{events: &events}),
+			WithHooks(&recordingHook{name: "hook1", events: &events}, &recordingHook{name: "hook2", events: &events}),
+			WithStoreProvider(&recordingStoreProvider{Provider: mem.NewProvider(), events: &events}),
+			WithKMS(func(kms.Provider) (kms.KeyManager, error) {
+				return &recordingKeyManager{KeyManager: &mockkms.KeyManager{}, events: &events}, nil
+			}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"hook1 started", "hook2 started"}, events)
+
+		require.NoError(t, a.Shutdown(context.Background()))
+		require.Equal(t, []string{
+			"hook1 started", "hook2 started", "inbound stopped", "hook2 stopped", "hook1 stopped",
+			"store flushed", "store closed", "kms closed",
+		}, events)
+
+		err = a.outboundDispatcher.Send("data", "", &service.Destination{ServiceEndpoint: "url"})
+		require.True(t, errors.Is(err, dispatcher.ErrShutdown))
+
+		// the framework is stopped once
+		require.NoError(t, a.Close())
+		require.Len(t, events, 8)
+	})
+
+	t.Run("stops the started hooks if a hook fails to start", func(t *testing.T) {
+		var events []string
+
+		_, err := New(WithHooks(
+			&recordingHook{name: "hook1", events: &events},
+			&recordingHook{name: "hook2", events: &events, startErr: errors.New("start error")},
+		))
+		require.EqualError(t, err, "failed to start the custom service: start error")
+		require.Equal(t, []string{"hook1 started", "hook1 stopped"}, events)
+	})
+
+	t.Run("performs all the steps and returns the first error", func(t *testing.T) {
+		var events []string
+
+		a, err := New(
+			WithHooks(&recordingHook{name: "hook", events: &events, stopErr: errors.New("stop error")}),
+			WithStoreProvider(&recordingStoreProvider{
+				Provider: mem.NewProvider(), events: &events, flushErr: errors.New("flush error"),
+			}),
+			WithKMS(func(kms.Provider) (kms.KeyManager, error) {
+				return &recordingKeyManager{KeyManager: &mockkms.KeyManager{}, events: &events,
+					closeErr: errors.New("close error")}, nil
+			}),
+		)
+		require.NoError(t, err)
+
+		err = a.Shutdown(context.Background())
+		require.EqualError(t, err, "failed to stop the custom service: stop error")
+		require.Equal(t, []string{"hook started", "hook stopped", "store flushed", "kms closed"}, events)
+	})
+}
+
+type recordingInbound struct {
+	mockInboundTransport
+	events *[]string
+}
+
+func (r *recordingInbound) Shutdown(context.Context) error {
+	*r.events = append(*r.events, "inbound stopped")
+
+	return nil
+}
+
+type recordingHook struct {
+	name     string
+	events   *[]string
+	startErr error
+	stopErr  error
+}
+
+func (r *recordingHook) OnStart(framework *Aries) error {
+	if r.startErr != nil {
+		return r.startErr
+	}
+
+	if _, err := framework.Context(); err != nil {
+		return err
+	}
+
+	*r.events = append(*r.events, r.name+" started")
+
+	return nil
+}
+
+func (r *recordingHook) OnStop(context.Context) error {
+	*r.events = append(*r.events, r.name+" stopped")
+
+	return r.stopErr
+}
+
+type recordingStoreProvider struct {
+	storage.Provider
+	events   *[]string
+	flushErr error
+}
+
+func (r *recordingStoreProvider) Flush() error {
+	*r.events = append(*r.events, "store flushed")
+
+	return r.flushErr
+}
+
+func (r *recordingStoreProvider) Close() error {
+	*r.events = append(*r.events, "store closed")
+
+	return r.Provider.Close()
+}
+
+type recordingKeyManager struct {
+	*mockkms.KeyManager
+	events   *[]string
+	closeErr error
+}
+
+func (r *recordingKeyManager) Close() error {
+	*r.events = append(*r.events, "kms closed")
+
+	return r.closeErr
+}
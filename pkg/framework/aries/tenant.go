@@ -0,0 +1,257 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	tenantstorage "github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/tenant"
+)
+
+// NewTenant creates the framework of a tenant hosted by the framework, so that a single agent process serves many
+// tenants (e.g. the customers of an agent host):
+//   - the stores of the tenant are opened in the storage providers of the host under the name of the store prefixed
+//     with the tenant ID (see the tenant storage wrapper), so the connections, credentials and protocol states of a
+//     tenant are never visible to the others
+//   - the KMS of the tenant is created with the KMS creator and the secret lock of the host, it keeps the keys of the
+//     tenant in the stores of the tenant
+//   - the messages sent to the endpoint of the tenant, the endpoint of the host followed by "/tenants/" and the tenant
+//     ID, are routed to the tenant by the HTTP inbound transports of the host (see transport.TenantEndpoint)
+//   - the tenant sends its messages with the outbound transports of the host.
+//
+// The tenant inherits the crypto, the packers, the metrics provider, the tracer, the clock, the auto-accept config,
+// the transport return route, the outbound rate limit (the messages of the tenant are limited apart from those of the
+// host), the protocol state expirations and the signature suites of the host. The custom protocol services, VDRs, message services and
+// hooks of the tenant are given with opts, the transports can't be. The events of the tenant, e.g. to notify its
+// webhooks with the controller, are those of the context of the tenant framework.
+//
+// The tenant ID is made of letters, digits and dashes (e.g. a UUID). The tenant framework is stopped with its
+// Shutdown method, or with the host.
+func (a *Aries) NewTenant(id string, opts ...Option) (*Aries, error) {
+	storeProvider, err := tenantstorage.NewProvider(a.storeProvider, id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	protocolStateStoreProvider, err := tenantstorage.NewProvider(a.protocolStateStoreProvider, id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	t := &Aries{
+		storeProvider:              storeProvider,
+		protocolStateStoreProvider: protocolStateStoreProvider,
+		kmsCreator:                 a.kmsCreator,
+		secretLock:                 a.secretLock,
+		crypto:                     a.crypto,
+		packagerCreator:            a.packagerCreator,
+		packerCreator:              a.packerCreator,
+		packerCreators:             append([]packer.Creator(nil), a.packerCreators...),
+		metricsProvider:            a.metricsProvider,
+		tracer:                     a.tracer,
+		clock:                      a.clock,
+		outboundRateLimit:          a.outboundRateLimit,
+		autoAcceptConfig:           a.autoAcceptConfig,
+		transportReturnRoute:       a.transportReturnRoute,
+		host:                       a,
+		tenantID:                   id,
+	}
+
+	for name, timeout := range a.expirations {
+		if e := WithProtocolStateExpiration(name, timeout)(t); e != nil {
+			return nil, e
+		}
+	}
+
+	for proofType, s := range a.signatureSuites {
+		if e := WithSignatureSuite(proofType, s)(t); e != nil {
+			return nil, e
+		}
+	}
+
+	for _, option := range opts {
+		if e := option(t); e != nil {
+			return nil, fmt.Errorf("error in option passed to NewTenant: %w", e)
+		}
+	}
+
+	if len(t.inboundTransports) > 0 || len(t.outboundTransports) > 0 {
+		return nil, errors.New("the transports of a tenant are those of its host")
+	}
+
+	t.outboundTransports = a.outboundTransports
+	t.id = uuid.New().String()
+
+	if err = a.addTenant(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Tenant returns the framework of the tenant hosted by the framework, false if there's no such tenant.
+func (a *Aries) Tenant(id string) (*Aries, bool) {
+	a.tenantsLock.RLock()
+	defer a.tenantsLock.RUnlock()
+
+	t := a.tenants[id]
+
+	return t, t != nil
+}
+
+// Tenants returns the IDs of the tenants hosted by the framework.
+func (a *Aries) Tenants() []string {
+	a.tenantsLock.RLock()
+	defer a.tenantsLock.RUnlock()
+
+	ids := make([]string, 0, len(a.tenants))
+
+	for id, t := range a.tenants {
+		if t != nil {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// TenantID returns the ID of the tenant, an empty string if the framework isn't a tenant framework.
+func (a *Aries) TenantID() string {
+	return a.tenantID
+}
+
+// addTenant initializes the tenant framework and adds it to the tenants of the host. The tenant ID is reserved
+// while the tenant is initialized, out of the lock since the hooks of the tenant may use the host.
+func (a *Aries) addTenant(t *Aries) error {
+	if err := a.reserveTenant(t.tenantID); err != nil {
+		return err
+	}
+
+	if err := initializeTenant(t); err != nil {
+		a.removeTenant(t.tenantID)
+
+		return err
+	}
+
+	a.tenantsLock.Lock()
+	defer a.tenantsLock.Unlock()
+
+	if a.stopped {
+		delete(a.tenants, t.tenantID)
+
+		if err := t.shutdown(context.Background()); err != nil {
+			logger.Warnf("failed to stop tenant %s: %s", t.tenantID, err)
+		}
+
+		return errors.New("the host framework is stopped")
+	}
+
+	a.tenants[t.tenantID] = t
+
+	return nil
+}
+
+func (a *Aries) reserveTenant(id string) error {
+	a.tenantsLock.Lock()
+	defer a.tenantsLock.Unlock()
+
+	if a.stopped {
+		return errors.New("the host framework is stopped")
+	}
+
+	if _, ok := a.tenants[id]; ok {
+		return fmt.Errorf("tenant %s already exists", id)
+	}
+
+	if a.tenants == nil {
+		a.tenants = map[string]*Aries{}
+	}
+
+	// the tenants being initialized are reserved with a nil framework
+	a.tenants[id] = nil
+
+	return nil
+}
+
+func initializeTenant(t *Aries) error {
+	if err := defFrameworkOpts(t); err != nil {
+		return fmt.Errorf("default option initialization failed: %w", err)
+	}
+
+	_, err := initializeServices(t)
+	if err == nil {
+		t.transportProvider, err = t.Context()
+	}
+
+	if err != nil {
+		if closeErr := t.shutdown(context.Background()); closeErr != nil {
+			logger.Warnf("failed to close tenant %s: %s", t.tenantID, closeErr)
+		}
+
+		return fmt.Errorf("failed to initialize tenant %s: %w", t.tenantID, err)
+	}
+
+	return nil
+}
+
+// removeTenant removes the tenant from the tenants of the host once the tenant is stopped.
+func (a *Aries) removeTenant(id string) {
+	a.tenantsLock.Lock()
+	defer a.tenantsLock.Unlock()
+
+	delete(a.tenants, id)
+}
+
+// stopTenants stops the tenants of the host.
+func (a *Aries) stopTenants(ctx context.Context) []error {
+	a.tenantsLock.RLock()
+
+	tenants := make([]*Aries, 0, len(a.tenants))
+	for _, t := range a.tenants {
+		if t != nil {
+			tenants = append(tenants, t)
+		}
+	}
+
+	a.tenantsLock.RUnlock()
+
+	var errs []error
+
+	for _, t := range tenants {
+		if err := t.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop tenant %s: %w", t.tenantID, err))
+		}
+	}
+
+	return errs
+}
+
+// tenantHost is the transport provider of the host, routing the inbound messages sent to the endpoint of a tenant
+// to the tenant.
+type tenantHost struct {
+	transport.Provider
+	aries *Aries
+}
+
+// Tenant returns the transport provider of the tenant.
+func (h *tenantHost) Tenant(id string) (transport.Provider, bool) {
+	t, ok := h.aries.Tenant(id)
+	if !ok {
+		return nil, false
+	}
+
+	return t.transportProvider, true
+}
@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestAries_NewTenant(t *testing.T) {
+	t.Run("isolates the tenants", func(t *testing.T) {
+		inbound := &tenantInbound{endpoint: "http://agent.example.com/"}
+
+		host, err := New(WithInboundTransport(inbound), WithStoreProvider(mem.NewProvider()))
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, host.Close()) }()
+
+		alice, err := host.NewTenant("alice")
+		require.NoError(t, err)
+		require.Equal(t, "alice", alice.TenantID())
+
+		bob, err := host.NewTenant("bob")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"alice", "bob"}, host.Tenants())
+
+		aliceCtx, err := alice.Context()
+		require.NoError(t, err)
+		require.Equal(t, "http://agent.example.com/tenants/alice", aliceCtx.ServiceEndpoint())
+
+		bobCtx, err := bob.Context()
+		require.NoError(t, err)
+
+		// the keys of a tenant aren't visible to the others
+		kid, _, err := aliceCtx.KMS().Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = aliceCtx.KMS().Get(kid)
+		require.NoError(t, err)
+
+		_, err = bobCtx.KMS().Get(kid)
+		require.Error(t, err)
+
+		// nor are their records
+		aliceStore, err := aliceCtx.StorageProvider().OpenStore("test")
+		require.NoError(t, err)
+		require.NoError(t, aliceStore.Put("key", []byte("alice")))
+
+		bobStore, err := bobCtx.StorageProvider().OpenStore("test")
+		require.NoError(t, err)
+
+		_, err = bobStore.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		// the inbound transports of the host route the messages to the tenants
+		tenants, ok := inbound.prov.(transport.TenantProvider)
+		require.True(t, ok)
+
+		prov, ok := tenants.Tenant("alice")
+		require.True(t, ok)
+		require.Equal(t, aliceCtx.AriesFrameworkID(), prov.AriesFrameworkID())
+		require.NotEqual(t, inbound.prov.AriesFrameworkID(), prov.AriesFrameworkID())
+
+		_, ok = tenants.Tenant("carol")
+		require.False(t, ok)
+
+		// a stopped tenant is removed from its host
+		require.NoError(t, alice.Shutdown(context.Background()))
+		require.Equal(t, []string{"bob"}, host.Tenants())
+
+		_, ok = tenants.Tenant("alice")
+		require.False(t, ok)
+	})
+
+	t.Run("stops the tenants with the host", func(t *testing.T) {
+		host, err := New(WithStoreProvider(mem.NewProvider()))
+		require.NoError(t, err)
+
+		alice, err := host.NewTenant("alice")
+		require.NoError(t, err)
+
+		var events []string
+
+		_, err = host.NewTenant("bob", WithHooks(&recordingHook{name: "bob", events: &events}))
+		require.NoError(t, err)
+
+		require.NoError(t, host.Close())
+		require.Equal(t, []string{"bob started", "bob stopped"}, events)
+		require.True(t, alice.stopped)
+		require.Empty(t, host.Tenants())
+
+		_, err = host.NewTenant("carol")
+		require.EqualError(t, err, "the host framework is stopped")
+	})
+
+	t.Run("fails to create the tenant", func(t *testing.T) {
+		host, err := New(WithStoreProvider(mem.NewProvider()))
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, host.Close()) }()
+
+		_, err = host.NewTenant("alice_1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid tenant")
+
+		_, err = host.NewTenant("alice", WithInboundTransport(&mockInboundTransport{}))
+		require.EqualError(t, err, "the transports of a tenant are those of its host")
+
+		_, err = host.NewTenant("alice", WithTransportReturnRoute("thread"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error in option passed to NewTenant")
+
+		_, err = host.NewTenant("alice", WithHooks(&recordingHook{startErr: errors.New("start error")}))
+		require.EqualError(t, err, "failed to initialize tenant alice: failed to start the custom service: start error")
+
+		_, err = host.NewTenant("alice")
+		require.NoError(t, err)
+
+		_, err = host.NewTenant("alice")
+		require.EqualError(t, err, "tenant alice already exists")
+	})
+}
+
+type tenantInbound struct {
+	mockInboundTransport
+	endpoint string
+	prov     transport.Provider
+}
+
+func (i *tenantInbound) Start(prov transport.Provider) error {
+	i.prov = prov
+
+	return nil
+}
+
+func (i *tenantInbound) Endpoint() string {
+	return i.endpoint
+}
@@ -9,17 +9,24 @@ package context
 import (
 	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
@@ -45,6 +52,13 @@ type Provider struct {
 	outboundTransports         []transport.OutboundTransport
 	vdr                        vdrapi.Registry
 	verifiableStore            verifiable.Store
+	metrics                    metrics.Provider
+	tracer                     tracing.Tracer
+	clock                      clock.Clock
+	jsonLimits                 *jsonutil.Limits
+	cache                      *cache.Cache
+	signatureSuites            *suiteregistry.Registry
+	autoAcceptConfig           *connection.AutoAcceptConfig
 	transportReturnRoute       string
 	frameworkID                string
 }
@@ -98,6 +112,11 @@ func (p *Provider) Service(id string) (interface{}, error) {
 	return nil, api.ErrSvcNotFound
 }
 
+// AllServices returns all the protocol services registered in the context.
+func (p *Provider) AllServices() []dispatcher.ProtocolService {
+	return append([]dispatcher.ProtocolService(nil), p.services...)
+}
+
 // KMS returns a Key Management Service.
 func (p *Provider) KMS() kms.KeyManager {
 	return p.kms
@@ -165,34 +184,43 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 			return err
 		}
 
-		// find the service which accepts the message type
-		for _, svc := range p.services {
-			if svc.Accept(msg.Type()) {
-				_, err = svc.HandleInbound(msg, myDID, theirDID)
+		span := tracing.ForDIDComm(p).Start(msg, tracing.ReceiveSpan)
+
+		err = p.handleInbound(msg, myDID, theirDID)
 
-				return err
-			}
+		span.End(err)
+
+		return err
+	}
+}
+
+func (p *Provider) handleInbound(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	// find the service which accepts the message type
+	for _, svc := range p.services {
+		if svc.Accept(msg.Type()) {
+			_, err := svc.HandleInbound(msg, myDID, theirDID)
+
+			return err
 		}
+	}
+
+	// in case of no services are registered for given message type,
+	// find generic inbound services registered for given message header
+	for _, svc := range p.msgSvcProvider.Services() {
+		h := struct {
+			Purpose []string `json:"~purpose"`
+		}{}
 
-		// in case of no services are registered for given message type,
-		// find generic inbound services registered for given message header
-		for _, svc := range p.msgSvcProvider.Services() {
-			h := struct {
-				Purpose []string `json:"~purpose"`
-			}{}
-			err = msg.Decode(&h)
-
-			if err != nil {
-				return err
-			}
-
-			if svc.Accept(msg.Type(), h.Purpose) {
-				return p.tryToHandle(svc, msg, myDID, theirDID)
-			}
+		if err := msg.Decode(&h); err != nil {
+			return err
 		}
 
-		return fmt.Errorf("no message handlers found for the message type: %s", msg.Type())
+		if svc.Accept(msg.Type(), h.Purpose) {
+			return p.tryToHandle(svc, msg, myDID, theirDID)
+		}
 	}
+
+	return fmt.Errorf("no message handlers found for the message type: %s", msg.Type())
 }
 
 // OutboundMessageHandler returns a handler composed of all registered protocol services.
@@ -233,6 +261,49 @@ func (p *Provider) VerifiableStore() verifiable.Store {
 	return p.verifiableStore
 }
 
+// Metrics returns the metrics provider, the protocol services record their metrics with it.
+func (p *Provider) Metrics() metrics.Provider {
+	return p.metrics
+}
+
+// Tracer returns the tracer, the protocol services and the outbound dispatcher trace the messages with it.
+func (p *Provider) Tracer() tracing.Tracer {
+	return p.tracer
+}
+
+// Clock returns the clock, the protocol services check their timeouts with it. It's the clock of the system if the
+// context has none.
+func (p *Provider) Clock() clock.Clock {
+	return clock.OrSystem(p.clock)
+}
+
+// JSONLimits returns the limits of the JSON documents received by the agent (envelopes, DID documents, credentials),
+// jsonutil.DefaultLimits if the context has none.
+func (p *Provider) JSONLimits() jsonutil.Limits {
+	if p.jsonLimits == nil {
+		return jsonutil.DefaultLimits()
+	}
+
+	return *p.jsonLimits
+}
+
+// Cache returns the cache of the parsed credentials, the resolved DID documents and the loaded JSON-LD contexts,
+// nil (i.e. no caching) if the context has none.
+func (p *Provider) Cache() *cache.Cache {
+	return p.cache
+}
+
+// SignatureSuites returns the registry of the signature suites of the linked data proofs, nil (i.e. the suites of
+// the framework) if the context has none.
+func (p *Provider) SignatureSuites() *suiteregistry.Registry {
+	return p.signatureSuites
+}
+
+// AutoAcceptConfig returns the auto-accept configuration honored by the protocol services.
+func (p *Provider) AutoAcceptConfig() *connection.AutoAcceptConfig {
+	return p.autoAcceptConfig
+}
+
 // ProviderOption configures the framework.
 type ProviderOption func(opts *Provider) error
 
@@ -385,3 +456,59 @@ func WithVerifiableStore(store verifiable.Store) ProviderOption {
 		return nil
 	}
 }
+
+// WithMetricsProvider injects a metrics provider into the context.
+func WithMetricsProvider(p metrics.Provider) ProviderOption {
+	return func(opts *Provider) error {
+		opts.metrics = p
+		return nil
+	}
+}
+
+// WithTracer injects a tracer into the context.
+func WithTracer(t tracing.Tracer) ProviderOption {
+	return func(opts *Provider) error {
+		opts.tracer = t
+		return nil
+	}
+}
+
+// WithClock injects a clock into the context.
+func WithClock(c clock.Clock) ProviderOption {
+	return func(opts *Provider) error {
+		opts.clock = c
+		return nil
+	}
+}
+
+// WithJSONLimits injects the limits of the JSON documents into the context.
+func WithJSONLimits(limits *jsonutil.Limits) ProviderOption {
+	return func(opts *Provider) error {
+		opts.jsonLimits = limits
+		return nil
+	}
+}
+
+// WithSignatureSuites injects a registry of signature suites into the context.
+func WithSignatureSuites(r *suiteregistry.Registry) ProviderOption {
+	return func(opts *Provider) error {
+		opts.signatureSuites = r
+		return nil
+	}
+}
+
+// WithCache injects a cache into the context.
+func WithCache(c *cache.Cache) ProviderOption {
+	return func(opts *Provider) error {
+		opts.cache = c
+		return nil
+	}
+}
+
+// WithAutoAcceptConfig injects the auto-accept configuration into the context.
+func WithAutoAcceptConfig(config *connection.AutoAcceptConfig) ProviderOption {
+	return func(opts *Provider) error {
+		opts.autoAcceptConfig = config
+		return nil
+	}
+}
@@ -16,9 +16,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
+	metricsMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
@@ -31,7 +35,9 @@ import (
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mocklock "github.com/hyperledger/aries-framework-go/pkg/mock/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -71,6 +77,9 @@ func TestNewProvider(t *testing.T) {
 
 		_, err = prov.Service("mockProtocolSvc1")
 		require.Error(t, err)
+
+		require.Len(t, prov.AllServices(), 1)
+		require.Equal(t, "mockProtocolSvc", prov.AllServices()[0].Name())
 	})
 
 	t.Run("test inbound message handlers/dispatchers", func(t *testing.T) {
@@ -381,6 +390,80 @@ func TestNewProvider(t *testing.T) {
 		require.Equal(t, verifiableStore, prov.VerifiableStore())
 	})
 
+	t.Run("test new with metrics provider", func(t *testing.T) {
+		metricsProvider := metricsMocks.NewMockProvider(ctrl)
+		prov, err := New(WithMetricsProvider(metricsProvider))
+		require.NoError(t, err)
+		require.Equal(t, metricsProvider, prov.Metrics())
+	})
+
+	t.Run("test new with tracer", func(t *testing.T) {
+		tracer := &mocktracing.Tracer{}
+		prov, err := New(WithTracer(tracer))
+		require.NoError(t, err)
+		require.Equal(t, tracer, prov.Tracer())
+	})
+
+	t.Run("test inbound message handler traces the messages", func(t *testing.T) {
+		tracer := &mocktracing.Tracer{}
+
+		var traceContext interface{}
+
+		prov, err := New(WithTracer(tracer), WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return msgType == "valid-message-type"
+			},
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				traceContext = msg.(service.DIDCommMsgMap)[tracing.Decorator]
+
+				return "", errors.New("error handling the message")
+			},
+		}))
+		require.NoError(t, err)
+
+		err = prov.InboundMessageHandler()([]byte(`{
+			"@id": "msg-id",
+			"@type": "valid-message-type",
+			"~trace_context": {"traceparent": "remote-span"}
+		}`), "", "")
+		require.EqualError(t, err, "error handling the message")
+
+		spans := tracer.Spans(tracing.ReceiveSpan)
+		require.Len(t, spans, 1)
+		require.Equal(t, "remote-span", spans[0].ParentID)
+		require.Equal(t, "msg-id", spans[0].Attributes[tracing.MessageIDAttribute])
+		require.EqualError(t, spans[0].Err, "error handling the message")
+
+		// the protocol service gets the trace context of the span
+		require.Equal(t, map[string]interface{}{"traceparent": spans[0].ID}, traceContext)
+	})
+
+	t.Run("test new with auto accept config", func(t *testing.T) {
+		config := &connection.AutoAcceptConfig{Global: connection.AutoAccept{connection.AutoAcceptCredentialOffer: true}}
+		prov, err := New(WithAutoAcceptConfig(config))
+		require.NoError(t, err)
+		require.Equal(t, config, prov.AutoAcceptConfig())
+	})
+
+	t.Run("test new with cache", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+		require.Nil(t, prov.Cache())
+
+		c := cache.New()
+		prov, err = New(WithCache(c))
+		require.NoError(t, err)
+		require.Equal(t, c, prov.Cache())
+	})
+
+	t.Run("test new with signature suites", func(t *testing.T) {
+		r := suiteregistry.New()
+		prov, err := New(WithSignatureSuites(r))
+		require.NoError(t, err)
+		require.Equal(t, r, prov.SignatureSuites())
+	})
+
 	t.Run("test new with bad (fake) option", func(t *testing.T) {
 		prov, err := New(func(opts *Provider) error {
 			return fmt.Errorf("bad option")
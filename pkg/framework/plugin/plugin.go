@@ -0,0 +1,153 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package plugin is the extension point of the third-party DIDComm protocol services. The package of a protocol
+// service registers it from its init function, so the agents include it at build time by importing the package
+// for its side effects:
+//
+//	import _ "example.com/acme/didcomm/protocol/loyalty"
+//
+// The framework loads the protocol services of the registered plugins after its own services (see aries.New),
+// and the controller exposes their commands and REST handlers along with its own (see controller.GetRESTHandlers
+// and controller.GetCommandHandlers).
+package plugin
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+)
+
+// Plugin is a third-party DIDComm protocol service.
+type Plugin struct {
+	// Name identifies the plugin, it's unique among the registered plugins.
+	Name string
+	// MessageTypes are the types of the messages handled by the protocol service. The framework fails to start if
+	// one of them is already handled by another service.
+	MessageTypes []string
+	// Stores are the names of the stores of the protocol service, e.g. to back them up (see backup.Export) or to
+	// migrate them (see migration.Migrate) with those of the framework.
+	Stores []string
+	// Service creates the protocol service with the framework context. The protocol service keeps its state in the
+	// stores of the protocol state storage provider of the context.
+	Service api.ProtocolSvcCreator
+	// Commands creates the controller commands of the protocol service, if any.
+	Commands func(ctx *context.Provider, notifier command.Notifier) ([]command.Handler, error)
+	// REST creates the REST handlers of the protocol service, if any.
+	REST func(ctx *context.Provider, notifier command.Notifier) ([]rest.Handler, error)
+}
+
+// nolint:gochecknoglobals
+var (
+	plugins []*Plugin
+	lock    sync.RWMutex
+)
+
+// Register registers the plugin. Like database/sql.Register, it panics if the plugin has no name or no protocol
+// service, or if a plugin with the same name is already registered.
+func Register(p Plugin) {
+	if p.Name == "" {
+		panic("plugin: Register plugin without a name")
+	}
+
+	if p.Service == nil {
+		panic(fmt.Sprintf("plugin: Register plugin %s without a protocol service", p.Name))
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, registered := range plugins {
+		if registered.Name == p.Name {
+			panic(fmt.Sprintf("plugin: Register called twice for plugin %s", p.Name))
+		}
+	}
+
+	plugins = append(plugins, &p)
+}
+
+// Unregister unregisters the plugin with the given name, if any. It's meant for the tests of the plugins.
+func Unregister(name string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	for i, p := range plugins {
+		if p.Name == name {
+			plugins = append(plugins[:i], plugins[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Plugins returns the registered plugins, in the order of their registration.
+func Plugins() []Plugin {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	result := make([]Plugin, len(plugins))
+
+	for i, p := range plugins {
+		result[i] = *p
+	}
+
+	return result
+}
+
+// Stores returns the names of the stores of the registered plugins.
+func Stores() []string {
+	var stores []string
+
+	for _, p := range Plugins() {
+		stores = append(stores, p.Stores...)
+	}
+
+	return stores
+}
+
+// CommandHandlers returns the controller commands of the registered plugins.
+func CommandHandlers(ctx *context.Provider, notifier command.Notifier) ([]command.Handler, error) {
+	var handlers []command.Handler
+
+	for _, p := range Plugins() {
+		if p.Commands == nil {
+			continue
+		}
+
+		h, err := p.Commands(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create %s plugin commands: %w", p.Name, err)
+		}
+
+		handlers = append(handlers, h...)
+	}
+
+	return handlers, nil
+}
+
+// RESTHandlers returns the REST handlers of the registered plugins.
+func RESTHandlers(ctx *context.Provider, notifier command.Notifier) ([]rest.Handler, error) {
+	var handlers []rest.Handler
+
+	for _, p := range Plugins() {
+		if p.REST == nil {
+			continue
+		}
+
+		h, err := p.REST(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create %s plugin REST handlers: %w", p.Name, err)
+		}
+
+		handlers = append(handlers, h...)
+	}
+
+	return handlers, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/plugin"
+	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+)
+
+func newService(api.Provider) (dispatcher.ProtocolService, error) {
+	return &mockdidexchange.MockDIDExchangeSvc{ProtocolName: "loyalty"}, nil
+}
+
+type commandHandler struct {
+	name string
+}
+
+func (h *commandHandler) Name() string {
+	return h.name
+}
+
+func (h *commandHandler) Method() string {
+	return "Get"
+}
+
+func (h *commandHandler) Handle() command.Exec {
+	return nil
+}
+
+type restHandler struct {
+	path string
+}
+
+func (h *restHandler) Path() string {
+	return h.path
+}
+
+func (h *restHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *restHandler) Handle() http.HandlerFunc {
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	plugin.Register(plugin.Plugin{Name: "loyalty", Stores: []string{"loyalty"}, Service: newService})
+	defer plugin.Unregister("loyalty")
+
+	plugin.Register(plugin.Plugin{Name: "payments", Stores: []string{"payments", "receipts"}, Service: newService})
+	defer plugin.Unregister("payments")
+
+	plugins := plugin.Plugins()
+	require.Len(t, plugins, 2)
+	require.Equal(t, "loyalty", plugins[0].Name)
+	require.Equal(t, "payments", plugins[1].Name)
+	require.Equal(t, []string{"loyalty", "payments", "receipts"}, plugin.Stores())
+
+	require.PanicsWithValue(t, "plugin: Register called twice for plugin loyalty", func() {
+		plugin.Register(plugin.Plugin{Name: "loyalty", Service: newService})
+	})
+	require.PanicsWithValue(t, "plugin: Register plugin without a name", func() {
+		plugin.Register(plugin.Plugin{Service: newService})
+	})
+	require.PanicsWithValue(t, "plugin: Register plugin rewards without a protocol service", func() {
+		plugin.Register(plugin.Plugin{Name: "rewards"})
+	})
+
+	plugin.Unregister("loyalty")
+	plugin.Unregister("unknown")
+
+	plugins = plugin.Plugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "payments", plugins[0].Name)
+}
+
+func TestHandlers(t *testing.T) {
+	plugin.Register(plugin.Plugin{
+		Name:    "loyalty",
+		Service: newService,
+		Commands: func(*context.Provider, command.Notifier) ([]command.Handler, error) {
+			return []command.Handler{&commandHandler{name: "points"}}, nil
+		},
+		REST: func(*context.Provider, command.Notifier) ([]rest.Handler, error) {
+			return []rest.Handler{&restHandler{path: "/points"}}, nil
+		},
+	})
+	defer plugin.Unregister("loyalty")
+
+	// the plugins without commands nor REST handlers are skipped
+	plugin.Register(plugin.Plugin{Name: "payments", Service: newService})
+	defer plugin.Unregister("payments")
+
+	commands, err := plugin.CommandHandlers(&context.Provider{}, nil)
+	require.NoError(t, err)
+	require.Len(t, commands, 1)
+	require.Equal(t, "points", commands[0].Name())
+
+	handlers, err := plugin.RESTHandlers(&context.Provider{}, nil)
+	require.NoError(t, err)
+	require.Len(t, handlers, 1)
+	require.Equal(t, "/points", handlers[0].Path())
+
+	plugin.Register(plugin.Plugin{
+		Name:    "rewards",
+		Service: newService,
+		Commands: func(*context.Provider, command.Notifier) ([]command.Handler, error) {
+			return nil, errors.New("commands error")
+		},
+		REST: func(*context.Provider, command.Notifier) ([]rest.Handler, error) {
+			return nil, errors.New("REST error")
+		},
+	})
+	defer plugin.Unregister("rewards")
+
+	_, err = plugin.CommandHandlers(&context.Provider{}, nil)
+	require.EqualError(t, err, "create rewards plugin commands: commands error")
+
+	_, err = plugin.RESTHandlers(&context.Provider{}, nil)
+	require.EqualError(t, err, "create rewards plugin REST handlers: REST error")
+}
@@ -0,0 +1,37 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package errcode classifies the errors of the public packages with their sentinel errors (e.g.
+// verifiable.ErrProofInvalid, kms.ErrKeyNotFound) without changing their messages, so that the callers can branch
+// with errors.Is on the class of an error while errors.Is and errors.As still see the causes it wraps.
+package errcode
+
+// Wrap returns err classified as code: its message is the one of err, it is code for errors.Is and it unwraps to err.
+// It returns nil if err is nil.
+func Wrap(code, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &codedError{code: code, err: err}
+}
+
+type codedError struct {
+	code error
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Is(target error) bool {
+	return target == e.code
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,37 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type causeError struct{}
+
+func (causeError) Error() string {
+	return "cause"
+}
+
+func TestWrap(t *testing.T) {
+	errCode := errors.New("code")
+	errOther := errors.New("other")
+
+	err := fmt.Errorf("context: %w", Wrap(errCode, fmt.Errorf("wrapped: %w", causeError{})))
+
+	require.EqualError(t, err, "context: wrapped: cause")
+	require.True(t, errors.Is(err, errCode))
+	require.False(t, errors.Is(err, errOther))
+
+	var cause causeError
+	require.True(t, errors.As(err, &cause))
+
+	require.NoError(t, Wrap(errCode, nil))
+}
@@ -101,6 +101,21 @@ func (m *MockOobService) EXPECT() *MockOobServiceMockRecorder {
 	return m.recorder
 }
 
+// AcceptImplicitInvitation mocks base method
+func (m *MockOobService) AcceptImplicitInvitation(arg0, arg1 string, arg2 []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptImplicitInvitation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptImplicitInvitation indicates an expected call of AcceptImplicitInvitation
+func (mr *MockOobServiceMockRecorder) AcceptImplicitInvitation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptImplicitInvitation", reflect.TypeOf((*MockOobService)(nil).AcceptImplicitInvitation), arg0, arg1, arg2)
+}
+
 // AcceptInvitation mocks base method
 func (m *MockOobService) AcceptInvitation(arg0 *outofband.Invitation, arg1 string, arg2 []string) (string, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +131,21 @@ func (mr *MockOobServiceMockRecorder) AcceptInvitation(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockOobService)(nil).AcceptInvitation), arg0, arg1, arg2)
 }
 
+// AcceptInvitationV2 mocks base method
+func (m *MockOobService) AcceptInvitationV2(arg0 *outofband.InvitationV2, arg1 string, arg2 []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitationV2", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitationV2 indicates an expected call of AcceptInvitationV2
+func (mr *MockOobServiceMockRecorder) AcceptInvitationV2(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitationV2", reflect.TypeOf((*MockOobService)(nil).AcceptInvitationV2), arg0, arg1, arg2)
+}
+
 // AcceptRequest mocks base method
 func (m *MockOobService) AcceptRequest(arg0 *outofband.Request, arg1 string, arg2 []string) (string, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +246,20 @@ func (mr *MockOobServiceMockRecorder) SaveInvitation(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvitation", reflect.TypeOf((*MockOobService)(nil).SaveInvitation), arg0)
 }
 
+// SaveInvitationV2 mocks base method
+func (m *MockOobService) SaveInvitationV2(arg0 *outofband.InvitationV2) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInvitationV2", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInvitationV2 indicates an expected call of SaveInvitationV2
+func (mr *MockOobServiceMockRecorder) SaveInvitationV2(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvitationV2", reflect.TypeOf((*MockOobService)(nil).SaveInvitationV2), arg0)
+}
+
 // SaveRequest mocks base method
 func (m *MockOobService) SaveRequest(arg0 *outofband.Request) error {
 	m.ctrl.T.Helper()
@@ -9,6 +9,7 @@ import (
 	service "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	presentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	reflect "reflect"
+	time "time"
 )
 
 // MockProvider is a mock of Provider interface
@@ -173,6 +174,20 @@ func (mr *MockProtocolServiceMockRecorder) RegisterMsgEvent(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterMsgEvent", reflect.TypeOf((*MockProtocolService)(nil).RegisterMsgEvent), arg0)
 }
 
+// ResumeAll mocks base method
+func (m *MockProtocolService) ResumeAll(arg0 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeAll", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeAll indicates an expected call of ResumeAll
+func (mr *MockProtocolServiceMockRecorder) ResumeAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeAll", reflect.TypeOf((*MockProtocolService)(nil).ResumeAll), arg0)
+}
+
 // UnregisterActionEvent mocks base method
 func (m *MockProtocolService) UnregisterActionEvent(arg0 chan<- service.DIDCommAction) error {
 	m.ctrl.T.Helper()
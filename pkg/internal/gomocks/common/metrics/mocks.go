@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hyperledger/aries-framework-go/pkg/common/metrics (interfaces: Provider,Counter,Histogram,Gauge)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	metrics "github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	reflect "reflect"
+)
+
+// MockProvider is a mock of Provider interface
+type MockProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderMockRecorder
+}
+
+// MockProviderMockRecorder is the mock recorder for MockProvider
+type MockProviderMockRecorder struct {
+	mock *MockProvider
+}
+
+// NewMockProvider creates a new mock instance
+func NewMockProvider(ctrl *gomock.Controller) *MockProvider {
+	mock := &MockProvider{ctrl: ctrl}
+	mock.recorder = &MockProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProvider) EXPECT() *MockProviderMockRecorder {
+	return m.recorder
+}
+
+// Counter mocks base method
+func (m *MockProvider) Counter(arg0 string) metrics.Counter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Counter", arg0)
+	ret0, _ := ret[0].(metrics.Counter)
+	return ret0
+}
+
+// Counter indicates an expected call of Counter
+func (mr *MockProviderMockRecorder) Counter(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counter", reflect.TypeOf((*MockProvider)(nil).Counter), arg0)
+}
+
+// Gauge mocks base method
+func (m *MockProvider) Gauge(arg0 string) metrics.Gauge {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gauge", arg0)
+	ret0, _ := ret[0].(metrics.Gauge)
+	return ret0
+}
+
+// Gauge indicates an expected call of Gauge
+func (mr *MockProviderMockRecorder) Gauge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gauge", reflect.TypeOf((*MockProvider)(nil).Gauge), arg0)
+}
+
+// Histogram mocks base method
+func (m *MockProvider) Histogram(arg0 string) metrics.Histogram {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Histogram", arg0)
+	ret0, _ := ret[0].(metrics.Histogram)
+	return ret0
+}
+
+// Histogram indicates an expected call of Histogram
+func (mr *MockProviderMockRecorder) Histogram(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Histogram", reflect.TypeOf((*MockProvider)(nil).Histogram), arg0)
+}
+
+// MockCounter is a mock of Counter interface
+type MockCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterMockRecorder
+}
+
+// MockCounterMockRecorder is the mock recorder for MockCounter
+type MockCounterMockRecorder struct {
+	mock *MockCounter
+}
+
+// NewMockCounter creates a new mock instance
+func NewMockCounter(ctrl *gomock.Controller) *MockCounter {
+	mock := &MockCounter{ctrl: ctrl}
+	mock.recorder = &MockCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCounter) EXPECT() *MockCounterMockRecorder {
+	return m.recorder
+}
+
+// Inc mocks base method
+func (m *MockCounter) Inc(arg0 metrics.Labels) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Inc", arg0)
+}
+
+// Inc indicates an expected call of Inc
+func (mr *MockCounterMockRecorder) Inc(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inc", reflect.TypeOf((*MockCounter)(nil).Inc), arg0)
+}
+
+// MockHistogram is a mock of Histogram interface
+type MockHistogram struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistogramMockRecorder
+}
+
+// MockHistogramMockRecorder is the mock recorder for MockHistogram
+type MockHistogramMockRecorder struct {
+	mock *MockHistogram
+}
+
+// NewMockHistogram creates a new mock instance
+func NewMockHistogram(ctrl *gomock.Controller) *MockHistogram {
+	mock := &MockHistogram{ctrl: ctrl}
+	mock.recorder = &MockHistogramMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockHistogram) EXPECT() *MockHistogramMockRecorder {
+	return m.recorder
+}
+
+// Observe mocks base method
+func (m *MockHistogram) Observe(arg0 float64, arg1 metrics.Labels) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Observe", arg0, arg1)
+}
+
+// Observe indicates an expected call of Observe
+func (mr *MockHistogramMockRecorder) Observe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Observe", reflect.TypeOf((*MockHistogram)(nil).Observe), arg0, arg1)
+}
+
+// MockGauge is a mock of Gauge interface
+type MockGauge struct {
+	ctrl     *gomock.Controller
+	recorder *MockGaugeMockRecorder
+}
+
+// MockGaugeMockRecorder is the mock recorder for MockGauge
+type MockGaugeMockRecorder struct {
+	mock *MockGauge
+}
+
+// NewMockGauge creates a new mock instance
+func NewMockGauge(ctrl *gomock.Controller) *MockGauge {
+	mock := &MockGauge{ctrl: ctrl}
+	mock.recorder = &MockGaugeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGauge) EXPECT() *MockGaugeMockRecorder {
+	return m.recorder
+}
+
+// Set mocks base method
+func (m *MockGauge) Set(arg0 float64, arg1 metrics.Labels) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", arg0, arg1)
+}
+
+// Set indicates an expected call of Set
+func (mr *MockGaugeMockRecorder) Set(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockGauge)(nil).Set), arg0, arg1)
+}
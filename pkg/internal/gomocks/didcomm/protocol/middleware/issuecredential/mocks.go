@@ -171,6 +171,20 @@ func (mr *MockMetadataMockRecorder) ProposeCredential() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeCredential", reflect.TypeOf((*MockMetadata)(nil).ProposeCredential))
 }
 
+// RejectedCredentials mocks base method
+func (m *MockMetadata) RejectedCredentials() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectedCredentials")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// RejectedCredentials indicates an expected call of RejectedCredentials
+func (mr *MockMetadataMockRecorder) RejectedCredentials() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectedCredentials", reflect.TypeOf((*MockMetadata)(nil).RejectedCredentials))
+}
+
 // RequestCredential mocks base method
 func (m *MockMetadata) RequestCredential() *issuecredential.RequestCredential {
 	m.ctrl.T.Helper()
@@ -99,6 +99,20 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// Batch mocks base method
+func (m *MockStore) Batch(arg0 []storage.Operation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Batch indicates an expected call of Batch
+func (mr *MockStoreMockRecorder) Batch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockStore)(nil).Batch), arg0)
+}
+
 // Delete mocks base method
 func (m *MockStore) Delete(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -79,6 +79,21 @@ func (mr *MockStoreMockRecorder) GetCredentials() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockStore)(nil).GetCredentials))
 }
 
+// GetCredentialsPage mocks base method
+func (m *MockStore) GetCredentialsPage(arg0, arg1 int) (*verifiable0.RecordPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentialsPage", arg0, arg1)
+	ret0, _ := ret[0].(*verifiable0.RecordPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialsPage indicates an expected call of GetCredentialsPage
+func (mr *MockStoreMockRecorder) GetCredentialsPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialsPage", reflect.TypeOf((*MockStore)(nil).GetCredentialsPage), arg0, arg1)
+}
+
 // GetPresentation mocks base method
 func (m *MockStore) GetPresentation(arg0 string) (*verifiable.Presentation, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +139,35 @@ func (mr *MockStoreMockRecorder) GetPresentations() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresentations", reflect.TypeOf((*MockStore)(nil).GetPresentations))
 }
 
+// GetPresentationsPage mocks base method
+func (m *MockStore) GetPresentationsPage(arg0, arg1 int) (*verifiable0.RecordPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresentationsPage", arg0, arg1)
+	ret0, _ := ret[0].(*verifiable0.RecordPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresentationsPage indicates an expected call of GetPresentationsPage
+func (mr *MockStoreMockRecorder) GetPresentationsPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresentationsPage", reflect.TypeOf((*MockStore)(nil).GetPresentationsPage), arg0, arg1)
+}
+
+// MarkCredentialRevoked mocks base method
+func (m *MockStore) MarkCredentialRevoked(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCredentialRevoked", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkCredentialRevoked indicates an expected call of MarkCredentialRevoked
+func (mr *MockStoreMockRecorder) MarkCredentialRevoked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCredentialRevoked", reflect.TypeOf((*MockStore)(nil).MarkCredentialRevoked), arg0)
+}
+
 // RemoveCredentialByName mocks base method
 func (m *MockStore) RemoveCredentialByName(arg0 string) error {
 	m.ctrl.T.Helper()
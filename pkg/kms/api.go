@@ -7,6 +7,7 @@
 package kms
 
 import (
+	"errors"
 	"io"
 
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
@@ -68,6 +69,9 @@ type KeyManager interface {
 	ImportPrivateKey(privKey interface{}, kt KeyType, opts ...PrivateKeyOpts) (string, interface{}, error)
 }
 
+// ErrKeyNotFound is returned when the key manager does not find the key.
+var ErrKeyNotFound = errors.New("key not found")
+
 // Provider for KeyManager builder/constructor.
 type Provider interface {
 	StorageProvider() storage.Provider
@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package instrumented offers a kms.KeyManager wrapper recording the latency and the errors of the operations
+// with a metrics.Provider, and tracing them with a tracing.Tracer.
+package instrumented
+
+import (
+	"io"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// Key manager operations, used as the operation label of the metrics.
+const (
+	createOperation           = "create"
+	getOperation              = "get"
+	rotateOperation           = "rotate"
+	exportOperation           = "export_pub_key"
+	createAndExportOperation  = "create_and_export_pub_key"
+	pubKeyToHandleOperation   = "pub_key_to_handle"
+	importPrivateKeyOperation = "import_private_key"
+)
+
+// KeyManager is a key manager wrapper recording the metrics of the underlying key manager.
+type KeyManager struct {
+	km      kms.KeyManager
+	metrics *metrics.KMS
+	tracing *tracing.KMS
+}
+
+// Option configures the KeyManager.
+type Option func(k *KeyManager)
+
+// WithTracer traces the operations of the key manager with the tracer.
+func WithTracer(t tracing.Tracer) Option {
+	return func(k *KeyManager) {
+		k.tracing = tracing.ForKMS(t)
+	}
+}
+
+// New returns a KeyManager recording the metrics of km with metricsProvider.
+func New(km kms.KeyManager, metricsProvider metrics.Provider, opts ...Option) *KeyManager {
+	k := &KeyManager{km: km, metrics: metrics.ForKMS(metricsProvider), tracing: tracing.ForKMS(nil)}
+
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	return k
+}
+
+// Create creates a new key of type kt with the underlying key manager.
+func (k *KeyManager) Create(kt kms.KeyType) (string, interface{}, error) {
+	start := time.Now()
+
+	keyID, handle, err := k.km.Create(kt)
+
+	k.record(createOperation, start, err)
+
+	return keyID, handle, err
+}
+
+// Get gets the key handle of keyID from the underlying key manager.
+func (k *KeyManager) Get(keyID string) (interface{}, error) {
+	start := time.Now()
+
+	handle, err := k.km.Get(keyID)
+
+	k.record(getOperation, start, err)
+
+	return handle, err
+}
+
+// Rotate rotates the key of keyID with the underlying key manager.
+func (k *KeyManager) Rotate(kt kms.KeyType, keyID string) (string, interface{}, error) {
+	start := time.Now()
+
+	newKeyID, handle, err := k.km.Rotate(kt, keyID)
+
+	k.record(rotateOperation, start, err)
+
+	return newKeyID, handle, err
+}
+
+// ExportPubKeyBytes exports the public key of keyID from the underlying key manager.
+func (k *KeyManager) ExportPubKeyBytes(keyID string) ([]byte, error) {
+	start := time.Now()
+
+	pubKey, err := k.km.ExportPubKeyBytes(keyID)
+
+	k.record(exportOperation, start, err)
+
+	return pubKey, err
+}
+
+// CreateAndExportPubKeyBytes creates a key of type kt and exports its public key with the underlying key manager.
+func (k *KeyManager) CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, error) {
+	start := time.Now()
+
+	keyID, pubKey, err := k.km.CreateAndExportPubKeyBytes(kt)
+
+	k.record(createAndExportOperation, start, err)
+
+	return keyID, pubKey, err
+}
+
+// PubKeyBytesToHandle transforms pubKey into a key handle with the underlying key manager.
+func (k *KeyManager) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType) (interface{}, error) {
+	start := time.Now()
+
+	handle, err := k.km.PubKeyBytesToHandle(pubKey, kt)
+
+	k.record(pubKeyToHandleOperation, start, err)
+
+	return handle, err
+}
+
+// ImportPrivateKey imports privKey into the underlying key manager.
+func (k *KeyManager) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	start := time.Now()
+
+	keyID, handle, err := k.km.ImportPrivateKey(privKey, kt, opts...)
+
+	k.record(importPrivateKeyOperation, start, err)
+
+	return keyID, handle, err
+}
+
+// Close closes the underlying key manager, if it holds resources (i.e. implements io.Closer).
+func (k *KeyManager) Close() error {
+	if closer, ok := k.km.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func (k *KeyManager) record(operation string, start time.Time, err error) {
+	k.metrics.Operation(operation, time.Since(start), err != nil)
+	k.tracing.Operation(operation, start, err)
+}
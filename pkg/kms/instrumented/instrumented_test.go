@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package instrumented
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
+)
+
+func labels(operation string) metrics.Labels {
+	return metrics.Labels{metrics.OperationLabel: operation}
+}
+
+func TestKeyManager(t *testing.T) {
+	t.Run("Records the operations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		durations := mocks.NewMockHistogram(ctrl)
+		errs := mocks.NewMockCounter(ctrl)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Histogram(metrics.KMSOperationDuration).Return(durations)
+		provider.EXPECT().Counter(metrics.KMSOperationErrors).Return(errs)
+
+		for _, operation := range []string{createOperation, getOperation, rotateOperation, exportOperation,
+			createAndExportOperation, pubKeyToHandleOperation, importPrivateKeyOperation} {
+			durations.EXPECT().Observe(gomock.Any(), labels(operation))
+		}
+
+		km := New(&mockkms.KeyManager{
+			CreateKeyID:         "key1",
+			RotateKeyID:         "key2",
+			CrAndExportPubKeyID: "key3",
+			ImportPrivateKeyID:  "key4",
+		}, provider)
+
+		keyID, _, err := km.Create(kms.ED25519Type)
+		require.NoError(t, err)
+		require.Equal(t, "key1", keyID)
+
+		_, err = km.Get("key1")
+		require.NoError(t, err)
+
+		keyID, _, err = km.Rotate(kms.ED25519Type, "key1")
+		require.NoError(t, err)
+		require.Equal(t, "key2", keyID)
+
+		_, err = km.ExportPubKeyBytes("key2")
+		require.NoError(t, err)
+
+		keyID, _, err = km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+		require.Equal(t, "key3", keyID)
+
+		_, err = km.PubKeyBytesToHandle([]byte("key"), kms.ED25519Type)
+		require.NoError(t, err)
+
+		keyID, _, err = km.ImportPrivateKey(nil, kms.ED25519Type)
+		require.NoError(t, err)
+		require.Equal(t, "key4", keyID)
+	})
+
+	t.Run("Counts the failed operations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		durations := mocks.NewMockHistogram(ctrl)
+		durations.EXPECT().Observe(gomock.Any(), labels(getOperation))
+
+		errs := mocks.NewMockCounter(ctrl)
+		errs.EXPECT().Inc(labels(getOperation))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Histogram(metrics.KMSOperationDuration).Return(durations)
+		provider.EXPECT().Counter(metrics.KMSOperationErrors).Return(errs)
+
+		_, err := New(&mockkms.KeyManager{GetKeyErr: errors.New("get error")}, provider).Get("key1")
+		require.EqualError(t, err, "get error")
+	})
+
+	t.Run("Traces the operations", func(t *testing.T) {
+		tracer := &mocktracing.Tracer{}
+		km := New(&mockkms.KeyManager{GetKeyErr: errors.New("get error")}, nil, WithTracer(tracer))
+
+		_, err := km.Get("key1")
+		require.EqualError(t, err, "get error")
+
+		spans := tracer.Spans(tracing.KMSSpan)
+		require.Len(t, spans, 1)
+		require.Equal(t, tracing.Attributes{tracing.OperationAttribute: getOperation}, spans[0].Attributes)
+		require.EqualError(t, spans[0].Err, "get error")
+		require.True(t, spans[0].Ended)
+	})
+	t.Run("Closes the key manager", func(t *testing.T) {
+		require.NoError(t, New(&mockkms.KeyManager{}, nil).Close())
+
+		km := &closingKeyManager{KeyManager: &mockkms.KeyManager{}}
+		require.EqualError(t, New(km, nil).Close(), "close error")
+		require.True(t, km.closed)
+	})
+}
+
+type closingKeyManager struct {
+	*mockkms.KeyManager
+	closed bool
+}
+
+func (k *closingKeyManager) Close() error {
+	k.closed = true
+
+	return errors.New("close error")
+}
@@ -25,6 +25,7 @@ import (
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/errcode"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
@@ -281,6 +282,10 @@ func (l *LocalKMS) getKeySet(id string) (*keyset.Handle, error) {
 	// Read reads the encrypted keyset handle back from the io.reader implementation
 	// and decrypts it using primaryKeyEnvAEAD.
 	kh, err := keyset.Read(jsonKeysetReader, l.primaryKeyEnvAEAD)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		err = errcode.Wrap(kms.ErrKeyNotFound, err)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("getKeySet: failed to read json keyset from reader: %w", err)
 	}
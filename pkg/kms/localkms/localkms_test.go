@@ -206,6 +206,18 @@ func TestCreateGetRotateKey_Failure(t *testing.T) {
 			"failed to get public keyset handle: keyset.Handle: keyset.Handle: keyset contains a non-private key")
 	})
 
+	t.Run("get unknown key", func(t *testing.T) {
+		kmsStorage, err := New(testMasterKeyURI, &mockProvider{
+			storage:    mockstorage.NewMockStoreProvider(),
+			secretLock: &noop.NoLock{},
+		})
+		require.NoError(t, err)
+
+		_, err = kmsStorage.Get("unknown")
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
 	t.Run("create And Export invalid key", func(t *testing.T) {
 		storeData := map[string][]byte{}
 		kmsStorage, err := New(testMasterKeyURI, &mockProvider{
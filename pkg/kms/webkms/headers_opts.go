@@ -10,6 +10,8 @@ import (
 	"net/http"
 
 	"github.com/bluele/gcache"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 )
 
 // addHeaders function supports adding custom http headers.
@@ -19,6 +21,8 @@ type addHeaders func(req *http.Request) (*http.Header, error)
 type Opts struct {
 	HeadersFunc     addHeaders
 	ComputeMACCache gcache.Cache
+	// RetryPolicy is the retry policy of the requests to the key server, retry.DefaultPolicy if nil.
+	RetryPolicy *retry.Policy
 }
 
 // NewOpt creates a new empty option.
@@ -45,3 +49,20 @@ func WithCache(cacheSize int) Opt {
 		opts.ComputeMACCache = gcache.New(cacheSize).Build()
 	}
 }
+
+// WithRetryPolicy defines the retry policy of the requests to the key server, retry.DefaultPolicy if not defined.
+// The retries are disabled with retry.NoRetry.
+func WithRetryPolicy(policy retry.Policy) Opt {
+	return func(opts *Opts) {
+		opts.RetryPolicy = &policy
+	}
+}
+
+// GetRetryPolicy returns the retry policy of the options, retry.DefaultPolicy if not defined.
+func (o *Opts) GetRetryPolicy() retry.Policy {
+	if o.RetryPolicy == nil {
+		return retry.DefaultPolicy()
+	}
+
+	return *o.RetryPolicy
+}
@@ -8,6 +8,7 @@ package webkms
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -19,6 +20,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -63,6 +65,7 @@ type RemoteKMS struct {
 	marshalFunc   marshalFunc
 	unmarshalFunc unmarshalFunc
 	opts          *Opts
+	retryPolicy   retry.Policy
 }
 
 // CreateKeyStore calls the key server's create keystore REST function and returns the resulting keystoreURL value.
@@ -148,6 +151,7 @@ func New(keystoreURL string, client *http.Client, opts ...Opt) *RemoteKMS {
 		marshalFunc:   json.Marshal,
 		unmarshalFunc: json.Unmarshal,
 		opts:          kOpts,
+		retryPolicy:   kOpts.GetRetryPolicy(),
 	}
 }
 
@@ -159,9 +163,45 @@ func (r *RemoteKMS) getHTTPRequest(destination string) (*http.Response, error) {
 	return r.doHTTPRequest(http.MethodGet, destination, nil)
 }
 
+// doHTTPRequest sends the request, retrying it on the network errors and on the statuses of the transient server
+// failures. If the server keeps failing, the response of the last attempt is returned.
 func (r *RemoteKMS) doHTTPRequest(method, destination string, mReq []byte) (*http.Response, error) {
 	start := time.Now()
 
+	var resp *http.Response
+
+	err := retry.Do(context.Background(), r.retryPolicy, func() error {
+		if resp != nil {
+			closeResponseBody(resp.Body, logger, "retried "+method)
+		}
+
+		httpReq, err := r.newHTTPRequest(method, destination, mReq)
+		if err != nil {
+			return retry.Permanent(err)
+		}
+
+		resp, err = r.httpClient.Do(httpReq)
+		if err != nil {
+			return err
+		}
+
+		if retry.RetryableStatus(resp.StatusCode) {
+			return fmt.Errorf("HTTP %s %s: %s", method, destination, resp.Status)
+		}
+
+		return nil
+	})
+
+	logger.Infof("  HTTP %s %s call duration: %s", method, destination, time.Since(start))
+
+	if resp != nil {
+		return resp, nil
+	}
+
+	return nil, err
+}
+
+func (r *RemoteKMS) newHTTPRequest(method, destination string, mReq []byte) (*http.Request, error) {
 	var (
 		httpReq *http.Request
 		err     error
@@ -194,11 +234,7 @@ func (r *RemoteKMS) doHTTPRequest(method, destination string, mReq []byte) (*htt
 		}
 	}
 
-	resp, err := r.httpClient.Do(httpReq)
-
-	logger.Infof("  HTTP %s %s call duration: %s", method, destination, time.Since(start))
-
-	return resp, err
+	return httpReq, nil
 }
 
 // Create a new key/keyset/key handle for the type kt remotely
@@ -294,6 +330,10 @@ func (r *RemoteKMS) ExportPubKeyBytes(keyID string) ([]byte, error) {
 	// handle response
 	defer closeResponseBody(resp.Body, logger, "ExportPubKeyBytes")
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: ExportPubKeyBytes of %s", kms.ErrKeyNotFound, keyID)
+	}
+
 	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("read key response for ExportPubKeyBytes failed [%s, %w]", destination, err)
@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -264,6 +266,72 @@ func TestRemoteKeyStoreWithHeadersFunc(t *testing.T) {
 	})
 }
 
+func TestDoHTTPRequestRetry(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		if r.URL.Path == "/bad" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	retryPolicy := retry.DefaultPolicy()
+	retryPolicy.InitialInterval = time.Millisecond
+
+	remoteKMS := New(server.URL, &http.Client{}, WithRetryPolicy(retryPolicy))
+
+	resp, err := remoteKMS.getHTTPRequest(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 2, requests)
+	require.NoError(t, resp.Body.Close())
+
+	t.Run("client error isn't retried", func(t *testing.T) {
+		requests = 1
+
+		resp, err := remoteKMS.getHTTPRequest(server.URL + "/bad")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Equal(t, 2, requests)
+		require.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("retries disabled", func(t *testing.T) {
+		requests = 0
+
+		resp, err := New(server.URL, &http.Client{}, WithRetryPolicy(retry.NoRetry())).getHTTPRequest(server.URL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, 1, requests)
+		require.NoError(t, resp.Body.Close())
+	})
+}
+
+func TestExportPubKeyBytesNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	remoteKMS := New(server.URL, &http.Client{})
+
+	_, err := remoteKMS.ExportPubKeyBytes("unknown")
+	require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+}
+
 func TestCloseResponseBody(t *testing.T) {
 	closeResponseBody(&errFailingCloser{}, logger, "testing close fail should log: errFailingCloser always fails")
 }
@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a clock whose time is set by the tests, it's safe for concurrent use.
+type Clock struct {
+	lock sync.RWMutex
+	now  time.Time
+}
+
+// New returns a new clock set to the given time.
+func New(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *Clock) Now() time.Time {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.now
+}
+
+// Set sets the time of the clock.
+func (c *Clock) Set(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = now
+}
+
+// Add moves the clock forward by d, backward if d is negative.
+func (c *Clock) Add(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(d)
+}
@@ -10,6 +10,8 @@
 package didexchange
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
@@ -41,6 +43,8 @@ type MockDIDExchangeSvc struct {
 	RespondToFunc            func(*didexchange.OOBInvitation, []string) (string, error)
 	SaveFunc                 func(invitation *didexchange.OOBInvitation) error
 	CreateConnRecordFunc     func(*connection.Record, *did.Doc) error
+	ResumeAllErr             error
+	ExpireAllFunc            func(timeout time.Duration) error
 }
 
 // HandleInbound msg.
@@ -169,6 +173,20 @@ func (m *MockDIDExchangeSvc) CreateConnection(r *connection.Record, theirDID *di
 	return nil
 }
 
+// ResumeAll triggers the action events for the pending invitations and requests.
+func (m *MockDIDExchangeSvc) ResumeAll(timeout time.Duration) error {
+	return m.ResumeAllErr
+}
+
+// ExpireAll abandons the invitations and requests pending longer than the timeout.
+func (m *MockDIDExchangeSvc) ExpireAll(timeout time.Duration) error {
+	if m.ExpireAllFunc != nil {
+		return m.ExpireAllFunc(timeout)
+	}
+
+	return nil
+}
+
 // MockProvider is provider for DIDExchange Service.
 type MockProvider struct {
 	StoreProvider              *mockstore.MockStoreProvider
@@ -30,6 +30,7 @@ type MockMediatorSvc struct {
 	Connections        []string
 	GetConnectionsErr  error
 	AddKeyFunc         func(string) error
+	SetLiveDeliveryErr error
 }
 
 // HandleInbound msg.
@@ -117,3 +118,8 @@ func (m *MockMediatorSvc) GetConnections() ([]string, error) {
 
 	return m.Connections, nil
 }
+
+// SetLiveDelivery enables or disables the live delivery of the messages by the router.
+func (m *MockMediatorSvc) SetLiveDelivery(connID string, enabled bool) error {
+	return m.SetLiveDeliveryErr
+}
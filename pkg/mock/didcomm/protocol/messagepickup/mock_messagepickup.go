@@ -27,6 +27,10 @@ type MockMessagePickupSvc struct {
 	AcceptFunc         func(msgType string) bool
 	NoopErr            error
 	NoopFunc           func(connectionID string) error
+	// LiveDeliveryDisabled disables the live delivery for all DIDs
+	LiveDeliveryDisabled   bool
+	LiveDeliveryChangeErr  error
+	LiveDeliveryChangeFunc func(connectionID string, liveDelivery bool) error
 }
 
 // Name return service name.
@@ -107,3 +111,21 @@ func (m *MockMessagePickupSvc) Noop(connectionID string) error {
 
 	return nil
 }
+
+// LiveDelivery checks whether the live delivery is enabled.
+func (m *MockMessagePickupSvc) LiveDelivery(_ string) bool {
+	return !m.LiveDeliveryDisabled
+}
+
+// LiveDeliveryChange perform LiveDeliveryChange.
+func (m *MockMessagePickupSvc) LiveDeliveryChange(connectionID string, liveDelivery bool) error {
+	if m.LiveDeliveryChangeErr != nil {
+		return m.LiveDeliveryChangeErr
+	}
+
+	if m.LiveDeliveryChangeFunc != nil {
+		return m.LiveDeliveryChangeFunc(connectionID, liveDelivery)
+	}
+
+	return nil
+}
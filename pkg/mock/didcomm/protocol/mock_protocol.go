@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package protocol
 
 import (
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
@@ -38,6 +39,7 @@ type MockProvider struct {
 	ServiceMap                 map[string]interface{}
 	InboundMsgHandler          transport.InboundMessageHandler
 	OutboundMsgHandler         service.OutboundHandler
+	CustomClock                clock.Clock
 }
 
 // OutboundDispatcher is mock outbound dispatcher for DID exchange service.
@@ -126,3 +128,8 @@ func (p *MockProvider) InboundMessageHandler() transport.InboundMessageHandler {
 func (p *MockProvider) OutboundMessageHandler() service.OutboundHandler {
 	return p.OutboundMsgHandler
 }
+
+// Clock returns the custom clock, the services use the clock of the system if it's nil.
+func (p *MockProvider) Clock() clock.Clock {
+	return p.CustomClock
+}
@@ -13,6 +13,8 @@ import (
 // MockOobService is a mock of OobService interface.
 type MockOobService struct {
 	AcceptInvitationHandle      func(*outofband.Invitation, string, []string) (string, error)
+	AcceptInvitationV2Handle    func(*outofband.InvitationV2, string, []string) (string, error)
+	AcceptImplicitHandle        func(string, string, []string) (string, error)
 	AcceptRequestHandle         func(*outofband.Request, string, []string) (string, error)
 	ActionContinueHandle        func(string, outofband.Options) error
 	ActionStopHandle            func(string, error) error
@@ -20,6 +22,7 @@ type MockOobService struct {
 	RegisterActionEventHandle   func(chan<- service.DIDCommAction) error
 	RegisterMsgEventHandle      func(chan<- service.StateMsg) error
 	SaveInvitationHandle        func(*outofband.Invitation) error
+	SaveInvitationV2Handle      func(*outofband.InvitationV2) error
 	SaveRequestHandle           func(*outofband.Request) error
 	UnregisterActionEventHandle func(chan<- service.DIDCommAction) error
 	UnregisterMsgEventHandle    func(chan<- service.StateMsg) error
@@ -34,6 +37,24 @@ func (m *MockOobService) AcceptInvitation(arg0 *outofband.Invitation, arg1 strin
 	return "", nil
 }
 
+// AcceptInvitationV2 mock implementation.
+func (m *MockOobService) AcceptInvitationV2(arg0 *outofband.InvitationV2, arg1 string, arg2 []string) (string, error) {
+	if m.AcceptInvitationV2Handle != nil {
+		return m.AcceptInvitationV2Handle(arg0, arg1, arg2)
+	}
+
+	return "", nil
+}
+
+// AcceptImplicitInvitation mock implementation.
+func (m *MockOobService) AcceptImplicitInvitation(arg0, arg1 string, arg2 []string) (string, error) {
+	if m.AcceptImplicitHandle != nil {
+		return m.AcceptImplicitHandle(arg0, arg1, arg2)
+	}
+
+	return "", nil
+}
+
 // AcceptRequest mock implementation.
 func (m *MockOobService) AcceptRequest(arg0 *outofband.Request, arg1 string, arg2 []string) (string, error) {
 	if m.AcceptRequestHandle != nil {
@@ -97,6 +118,15 @@ func (m *MockOobService) SaveInvitation(arg0 *outofband.Invitation) error {
 	return nil
 }
 
+// SaveInvitationV2 mock implementation.
+func (m *MockOobService) SaveInvitationV2(arg0 *outofband.InvitationV2) error {
+	if m.SaveInvitationV2Handle != nil {
+		return m.SaveInvitationV2Handle(arg0)
+	}
+
+	return nil
+}
+
 // SaveRequest mock implementation.
 func (m *MockOobService) SaveRequest(arg0 *outofband.Request) error {
 	if m.SaveRequestHandle != nil {
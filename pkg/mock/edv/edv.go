@@ -90,17 +90,27 @@ func (o *MockServerOperation) mockCreateDocumentHandler(rw http.ResponseWriter,
 }
 
 func (o *MockServerOperation) mockReadDocumentHandler(rw http.ResponseWriter, req *http.Request) {
-	rw.WriteHeader(o.ReadDocumentReturnStatusCode)
-
 	if o.ReadDocumentReturnStatusCode == http.StatusOK && o.UseDB {
 		docID, err := url.PathUnescape(mux.Vars(req)[docIDPathVariable])
 		require.NoError(o.T, err)
 
-		documentBytes := o.DB[docID]
+		documentBytes, ok := o.DB[docID]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+
+			_, err = rw.Write([]byte("document not found"))
+			require.NoError(o.T, err)
+
+			return
+		}
+
+		rw.WriteHeader(o.ReadDocumentReturnStatusCode)
 
 		_, err = rw.Write(documentBytes)
 		require.NoError(o.T, err)
 	} else {
+		rw.WriteHeader(o.ReadDocumentReturnStatusCode)
+
 		_, err := rw.Write(o.ReadDocumentReturnBody)
 		require.NoError(o.T, err)
 	}
@@ -128,7 +138,9 @@ func (o *MockServerOperation) mockQueryVaultHandler(rw http.ResponseWriter, req
 				err := json.Unmarshal(documentBytes, &document)
 				require.NoError(o.T, err)
 
-				allDocuments = append(allDocuments, document)
+				if matchesQuery(&document, &incomingQuery) {
+					allDocuments = append(allDocuments, document)
+				}
 			}
 
 			allDocumentsBytes, err := json.Marshal(allDocuments)
@@ -139,8 +151,15 @@ func (o *MockServerOperation) mockQueryVaultHandler(rw http.ResponseWriter, req
 		} else {
 			allDocumentLocations := make([]string, 0)
 
-			for docID := range o.DB {
-				allDocumentLocations = append(allDocumentLocations, "SomeURLPart/"+docID)
+			for docID, documentBytes := range o.DB {
+				var document models.EncryptedDocument
+
+				err := json.Unmarshal(documentBytes, &document)
+				require.NoError(o.T, err)
+
+				if matchesQuery(&document, &incomingQuery) {
+					allDocumentLocations = append(allDocumentLocations, "SomeURLPart/"+docID)
+				}
 			}
 
 			allDocumentLocationsBytes, err := json.Marshal(allDocumentLocations)
@@ -217,7 +236,35 @@ func (o *MockServerOperation) mockDeleteDocumentHandler(rw http.ResponseWriter,
 }
 
 func (o *MockServerOperation) mockUpdateDocumentHandler(rw http.ResponseWriter, req *http.Request) {
+	if o.UpdateDocumentReturnStatusCode == http.StatusOK && o.UseDB {
+		docID, err := url.PathUnescape(mux.Vars(req)[docIDPathVariable])
+		require.NoError(o.T, err)
+
+		requestBody, err := ioutil.ReadAll(req.Body)
+		require.NoError(o.T, err)
+
+		o.DB[docID] = requestBody
+	}
+
 	rw.WriteHeader(o.UpdateDocumentReturnStatusCode)
 	_, err := rw.Write(o.UpdateDocumentReturnBody)
 	require.NoError(o.T, err)
 }
+
+// matchesQuery tells whether the document has the indexed attribute of the query, documents without indexed
+// attributes matching all the queries.
+func matchesQuery(document *models.EncryptedDocument, query *models.Query) bool {
+	if len(document.IndexedAttributeCollections) == 0 {
+		return true
+	}
+
+	for _, collection := range document.IndexedAttributeCollections {
+		for _, attribute := range collection.IndexedAttributes {
+			if attribute.Name == query.Name && attribute.Value == query.Value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -6,9 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 package provider
 
 import (
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suiteregistry"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -28,6 +32,10 @@ type Provider struct {
 	OutboundDispatcherValue           dispatcher.Outbound
 	VDRegistryValue                   vdrapi.Registry
 	CryptoValue                       crypto.Crypto
+	ClockValue                        clock.Clock
+	JSONLimitsValue                   *jsonutil.Limits
+	CacheValue                        *cache.Cache
+	SignatureSuitesValue              *suiteregistry.Registry
 }
 
 // Service return service.
@@ -92,3 +100,27 @@ func (p *Provider) OutboundDispatcher() dispatcher.Outbound {
 func (p *Provider) VDRegistry() vdrapi.Registry {
 	return p.VDRegistryValue
 }
+
+// Clock returns the clock, the services use the clock of the system if it's nil.
+func (p *Provider) Clock() clock.Clock {
+	return p.ClockValue
+}
+
+// JSONLimits returns the limits of the JSON documents, the default limits if JSONLimitsValue is nil.
+func (p *Provider) JSONLimits() jsonutil.Limits {
+	if p.JSONLimitsValue == nil {
+		return jsonutil.DefaultLimits()
+	}
+
+	return *p.JSONLimitsValue
+}
+
+// Cache returns the cache, nothing is cached if it's nil.
+func (p *Provider) Cache() *cache.Cache {
+	return p.CacheValue
+}
+
+// SignatureSuites returns the registry of signature suites, the services use the suites of the framework if it's nil.
+func (p *Provider) SignatureSuites() *suiteregistry.Registry {
+	return p.SignatureSuitesValue
+}
@@ -8,6 +8,7 @@ package storage
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -68,6 +69,7 @@ type MockStore struct {
 	ErrGet    error
 	ErrItr    error
 	ErrDelete error
+	ErrBatch  error
 }
 
 // Put stores the key and the record.
@@ -121,6 +123,11 @@ func (s *MockStore) Iterator(start, limit string) storage.StoreIterator {
 		}
 	}
 
+	// keys are sorted like in the real stores, so that the records can be paged
+	sort.Slice(batch, func(i, j int) bool {
+		return batch[i][0] < batch[j][0]
+	})
+
 	return NewMockIterator(batch)
 }
 
@@ -133,6 +140,26 @@ func (s *MockStore) Delete(k string) error {
 	return s.ErrDelete
 }
 
+// Batch performs the batch operations.
+func (s *MockStore) Batch(operations []storage.Operation) error {
+	if s.ErrBatch != nil {
+		return s.ErrBatch
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, op := range operations {
+		if op.Value == nil {
+			delete(s.Store, op.Key)
+		} else {
+			s.Store[op.Key] = op.Value
+		}
+	}
+
+	return nil
+}
+
 // NewMockIterator returns new mock iterator for given batch.
 func NewMockIterator(batch [][]string) *MockIterator {
 	if len(batch) == 0 {
@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+)
+
+// traceParentKey is the carrier key of the ID of the parent span.
+const traceParentKey = "traceparent"
+
+type spanKey struct{}
+
+// Span is a span recorded by the mock tracer.
+type Span struct {
+	ID         string
+	ParentID   string
+	Name       string
+	Start      time.Time
+	Attributes tracing.Attributes
+	Err        error
+	Ended      bool
+
+	tracer *Tracer
+}
+
+// SetAttributes adds the attributes to the span.
+func (s *Span) SetAttributes(attrs tracing.Attributes) {
+	s.tracer.lock.Lock()
+	defer s.tracer.lock.Unlock()
+
+	for k, v := range attrs {
+		s.Attributes[k] = v
+	}
+}
+
+// End ends the span.
+func (s *Span) End(err error) {
+	s.tracer.lock.Lock()
+	defer s.tracer.lock.Unlock()
+
+	s.Err = err
+	s.Ended = true
+}
+
+// Tracer is a mock tracer recording the spans, the carriers holding the ID of the span as the trace parent.
+type Tracer struct {
+	spans []*Span
+	lock  sync.Mutex
+}
+
+// Start starts the span as a child of the span of ctx.
+func (t *Tracer) Start(ctx context.Context, name string, start time.Time) (context.Context, tracing.Span) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	parentID, _ := ctx.Value(spanKey{}).(string) // nolint: errcheck
+
+	span := &Span{
+		ID:         fmt.Sprintf("span-%d", len(t.spans)+1),
+		ParentID:   parentID,
+		Name:       name,
+		Start:      start,
+		Attributes: tracing.Attributes{},
+		tracer:     t,
+	}
+
+	t.spans = append(t.spans, span)
+
+	return context.WithValue(ctx, spanKey{}, span.ID), span
+}
+
+// Inject writes the ID of the span of ctx to the carrier.
+func (t *Tracer) Inject(ctx context.Context, carrier tracing.Carrier) {
+	if id, ok := ctx.Value(spanKey{}).(string); ok {
+		carrier[traceParentKey] = id
+	}
+}
+
+// Extract returns the context holding the span ID of the carrier.
+func (t *Tracer) Extract(ctx context.Context, carrier tracing.Carrier) context.Context {
+	if id, ok := carrier[traceParentKey]; ok {
+		return context.WithValue(ctx, spanKey{}, id)
+	}
+
+	return ctx
+}
+
+// Spans returns copies of the spans recorded, optionally only those of the given name.
+func (t *Tracer) Spans(name ...string) []Span {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var spans []Span
+
+	for _, span := range t.spans {
+		if len(name) == 0 || span.Name == name[0] {
+			copied := *span
+			copied.Attributes = tracing.Attributes{}
+
+			for k, v := range span.Attributes {
+				copied.Attributes[k] = v
+			}
+
+			spans = append(spans, copied)
+		}
+	}
+
+	return spans
+}
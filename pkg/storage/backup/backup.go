@@ -0,0 +1,186 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package backup exports the stores of a storage provider (e.g the stores of a wallet) into a portable encrypted
+// archive, and restores them into a fresh provider, e.g to migrate a wallet to another device or to recover it.
+//
+// The archive is a JSON document holding its format version and the records of the stores, encrypted with an AEAD
+// key handle of a crypto.Crypto: the archive can be restored wherever the key is available, whatever the storage
+// providers exporting and restoring it.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// Version is the version of the archive format written by Export.
+const Version = 1
+
+// aadPrefix prefixes the archive version in the additional authenticated data of the archive encryption, so the
+// version can't be altered.
+const aadPrefix = "aries-storage-backup-v"
+
+type archive struct {
+	Version    int    `json:"version"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type archiveContent struct {
+	Stores []storeContent `json:"stores"`
+}
+
+type storeContent struct {
+	Name    string   `json:"name"`
+	Records []record `json:"records"`
+}
+
+type record struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Export writes the records of the stores with the given names into w as an archive encrypted with crypto and the
+// AEAD key handle kh. The stores are listed by the caller since the providers don't list their stores, which also
+// filters the stores exported.
+func Export(w io.Writer, provider storage.Provider, storeNames []string, crypto crypto.Crypto, kh interface{}) error {
+	var content archiveContent
+
+	for _, name := range storeNames {
+		store, err := exportStore(provider, name)
+		if err != nil {
+			return fmt.Errorf("failed to export store %s: %w", name, err)
+		}
+
+		content.Stores = append(content.Stores, *store)
+	}
+
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive content: %w", err)
+	}
+
+	ciphertext, nonce, err := crypto.Encrypt(plaintext, aad(Version), kh)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt archive: %w", err)
+	}
+
+	err = json.NewEncoder(w).Encode(archive{Version: Version, Nonce: nonce, Ciphertext: ciphertext})
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return nil
+}
+
+// Restore reads the archive from r, decrypts it with crypto and the AEAD key handle kh, and writes the records of
+// its stores into provider. Since the records of the archive would be mixed with the existing ones, the stores of
+// the archive must be empty in provider.
+func Restore(r io.Reader, provider storage.Provider, crypto crypto.Crypto, kh interface{}) error {
+	var a archive
+
+	if err := json.NewDecoder(r).Decode(&a); err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	if a.Version != Version {
+		return fmt.Errorf("unsupported archive version %d", a.Version)
+	}
+
+	plaintext, err := crypto.Decrypt(a.Ciphertext, aad(a.Version), a.Nonce, kh)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt archive: %w", err)
+	}
+
+	var content archiveContent
+
+	if err = json.Unmarshal(plaintext, &content); err != nil {
+		return fmt.Errorf("failed to unmarshal archive content: %w", err)
+	}
+
+	stores := make([]storage.Store, len(content.Stores))
+
+	// check all the stores before restoring any of them
+	for i, s := range content.Stores {
+		stores[i], err = openEmptyStore(provider, s.Name)
+		if err != nil {
+			return fmt.Errorf("failed to restore store %s: %w", s.Name, err)
+		}
+	}
+
+	for i, s := range content.Stores {
+		if len(s.Records) == 0 {
+			continue
+		}
+
+		operations := make([]storage.Operation, len(s.Records))
+		for j, rec := range s.Records {
+			operations[j] = storage.Operation{Key: rec.Key, Value: rec.Value}
+		}
+
+		if err = stores[i].Batch(operations); err != nil {
+			return fmt.Errorf("failed to restore store %s: %w", s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func exportStore(provider storage.Provider, name string) (*storeContent, error) {
+	store, err := provider.OpenStore(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+
+	itr := store.Iterator("", storage.EndKeySuffix)
+	defer itr.Release()
+
+	content := &storeContent{Name: name, Records: []record{}}
+
+	for itr.Next() {
+		content.Records = append(content.Records, record{
+			Key:   string(itr.Key()),
+			Value: append([]byte(nil), itr.Value()...),
+		})
+	}
+
+	if err = itr.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate store: %w", err)
+	}
+
+	return content, nil
+}
+
+func openEmptyStore(provider storage.Provider, name string) (storage.Store, error) {
+	store, err := provider.OpenStore(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+
+	itr := store.Iterator("", storage.EndKeySuffix)
+	defer itr.Release()
+
+	if itr.Next() {
+		return nil, errors.New("store is not empty")
+	}
+
+	if err = itr.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate store: %w", err)
+	}
+
+	return store, nil
+}
+
+func aad(version int) []byte {
+	return []byte(aadPrefix + strconv.Itoa(version))
+}
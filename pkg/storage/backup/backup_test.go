@@ -0,0 +1,206 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+var errTest = errors.New("test error")
+
+func newCrypto(t *testing.T) (*tinkcrypto.Crypto, *keyset.Handle) {
+	t.Helper()
+
+	crypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kh, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	require.NoError(t, err)
+
+	return crypto, kh
+}
+
+func newSource(t *testing.T, records map[string]int) storage.Provider {
+	t.Helper()
+
+	source := mem.NewProvider()
+
+	for name, count := range records {
+		store, err := source.OpenStore(name)
+		require.NoError(t, err)
+
+		for i := 0; i < count; i++ {
+			require.NoError(t, store.Put(fmt.Sprintf("key_%02d", i), []byte(fmt.Sprintf("%s-%d", name, i))))
+		}
+	}
+
+	return source
+}
+
+func TestExportRestore(t *testing.T) {
+	t.Run("Test stores restored", func(t *testing.T) {
+		crypto, kh := newCrypto(t)
+		source := newSource(t, map[string]int{"connections": 3, "credentials": 5, "empty": 0, "other": 2})
+
+		archive := &bytes.Buffer{}
+		require.NoError(t, Export(archive, source, []string{"connections", "credentials", "empty"}, crypto, kh))
+
+		// the records are encrypted
+		require.NotContains(t, archive.String(), "connections-0")
+
+		target := mem.NewProvider()
+		require.NoError(t, Restore(archive, target, crypto, kh))
+
+		for name, count := range map[string]int{"connections": 3, "credentials": 5, "empty": 0, "other": 0} {
+			store, err := target.OpenStore(name)
+			require.NoError(t, err)
+
+			restored, err := storage.Count(store, "", storage.EndKeySuffix)
+			require.NoError(t, err)
+			require.Equal(t, count, restored, name)
+
+			for i := 0; i < count; i++ {
+				v, err := store.Get(fmt.Sprintf("key_%02d", i))
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf("%s-%d", name, i), string(v))
+			}
+		}
+	})
+
+	t.Run("Test restore into non empty stores", func(t *testing.T) {
+		crypto, kh := newCrypto(t)
+		source := newSource(t, map[string]int{"connections": 3, "credentials": 5})
+
+		archive := &bytes.Buffer{}
+		require.NoError(t, Export(archive, source, []string{"connections", "credentials"}, crypto, kh))
+
+		target := newSource(t, map[string]int{"credentials": 1})
+
+		err := Restore(archive, target, crypto, kh)
+		require.EqualError(t, err, "failed to restore store credentials: store is not empty")
+
+		// no store was restored
+		store, err := target.OpenStore("connections")
+		require.NoError(t, err)
+		require.False(t, store.Iterator("", storage.EndKeySuffix).Next())
+	})
+
+	t.Run("Test restore with another key", func(t *testing.T) {
+		crypto, kh := newCrypto(t)
+
+		archive := &bytes.Buffer{}
+		require.NoError(t, Export(archive, newSource(t, map[string]int{"connections": 3}), []string{"connections"},
+			crypto, kh))
+
+		_, otherKH := newCrypto(t)
+
+		err := Restore(archive, mem.NewProvider(), crypto, otherKH)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt archive")
+	})
+
+	t.Run("Test archive version", func(t *testing.T) {
+		crypto, kh := newCrypto(t)
+
+		archive := &bytes.Buffer{}
+		require.NoError(t, Export(archive, newSource(t, map[string]int{"connections": 3}), []string{"connections"},
+			crypto, kh))
+
+		var a map[string]interface{}
+		require.NoError(t, json.Unmarshal(archive.Bytes(), &a))
+		require.EqualValues(t, Version, a["version"])
+
+		a["version"] = Version + 1
+
+		altered, err := json.Marshal(a)
+		require.NoError(t, err)
+
+		err = Restore(bytes.NewReader(altered), mem.NewProvider(), crypto, kh)
+		require.EqualError(t, err, fmt.Sprintf("unsupported archive version %d", Version+1))
+	})
+}
+
+func TestExport_Errors(t *testing.T) {
+	crypto, kh := newCrypto(t)
+
+	t.Run("Test open store error", func(t *testing.T) {
+		err := Export(&bytes.Buffer{}, &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errTest},
+			[]string{"connections"}, crypto, kh)
+		require.EqualError(t, err, "failed to export store connections: failed to open store: test error")
+	})
+
+	t.Run("Test iterator error", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+		provider.Store.ErrItr = errTest
+
+		err := Export(&bytes.Buffer{}, provider, []string{"connections"}, crypto, kh)
+		require.EqualError(t, err, "failed to export store connections: failed to iterate store: test error")
+	})
+
+	t.Run("Test encryption error", func(t *testing.T) {
+		err := Export(&bytes.Buffer{}, mem.NewProvider(), []string{"connections"},
+			&mockcrypto.Crypto{EncryptErr: errTest}, nil)
+		require.EqualError(t, err, "failed to encrypt archive: test error")
+	})
+}
+
+func TestRestore_Errors(t *testing.T) {
+	crypto, kh := newCrypto(t)
+
+	archive := &bytes.Buffer{}
+	require.NoError(t, Export(archive, newSource(t, map[string]int{"connections": 3}), []string{"connections"},
+		crypto, kh))
+
+	t.Run("Test invalid archive", func(t *testing.T) {
+		err := Restore(bytes.NewBufferString("{"), mem.NewProvider(), crypto, kh)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read archive")
+	})
+
+	t.Run("Test invalid archive content", func(t *testing.T) {
+		err := Restore(bytes.NewReader(archive.Bytes()), mem.NewProvider(),
+			&mockcrypto.Crypto{DecryptValue: []byte("{")}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal archive content")
+	})
+
+	t.Run("Test open store error", func(t *testing.T) {
+		err := Restore(bytes.NewReader(archive.Bytes()), &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errTest},
+			crypto, kh)
+		require.EqualError(t, err, "failed to restore store connections: failed to open store: test error")
+	})
+
+	t.Run("Test iterator error", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+		provider.Store.ErrItr = errTest
+
+		err := Restore(bytes.NewReader(archive.Bytes()), provider, crypto, kh)
+		require.EqualError(t, err, "failed to restore store connections: failed to iterate store: test error")
+	})
+
+	t.Run("Test batch error", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+		provider.Store.ErrBatch = errTest
+
+		err := Restore(bytes.NewReader(archive.Bytes()), provider, crypto, kh)
+		require.EqualError(t, err, "failed to restore store connections: test error")
+	})
+}
@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package composite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// Option configures the composite provider.
+type Option func(*Provider)
+
+// WithStoreProvider opens the stores of the given names with the given provider, e.g a redis provider
+// for the ephemeral data like sessions, outbound queues or nonces.
+func WithStoreProvider(provider storage.Provider, names ...string) Option {
+	return func(p *Provider) {
+		for _, name := range names {
+			p.providers[strings.ToLower(name)] = provider
+		}
+	}
+}
+
+// Provider implementation of storage.Provider interface selecting the underlying provider per store:
+// the stores are opened with the default (durable) provider, unless another provider is set for their name.
+type Provider struct {
+	defaultProvider storage.Provider
+	providers       map[string]storage.Provider
+}
+
+// NewProvider instantiates Provider.
+//
+// Usage:
+//
+//	redisProvider, err := redis.NewProvider("localhost:6379", redis.WithStoreTTL("nonces", time.Hour))
+//	provider := composite.NewProvider(leveldb.NewProvider(dbPath),
+//	    composite.WithStoreProvider(redisProvider, "nonces"))
+func NewProvider(defaultProvider storage.Provider, opts ...Option) *Provider {
+	p := &Provider{defaultProvider: defaultProvider, providers: make(map[string]storage.Provider)}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// OpenStore opens and returns a store for given name space with the provider selected for the name.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	return p.provider(name).OpenStore(name)
+}
+
+// CloseStore closes the store of given name with the provider selected for the name.
+func (p *Provider) CloseStore(name string) error {
+	return p.provider(name).CloseStore(name)
+}
+
+// Close closes all the underlying providers.
+func (p *Provider) Close() error {
+	var errs []error
+
+	closed := map[storage.Provider]bool{}
+
+	for _, provider := range append([]storage.Provider{p.defaultProvider}, p.values()...) {
+		if closed[provider] {
+			continue
+		}
+
+		closed[provider] = true
+
+		if err := provider.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close providers, %v", errs)
+	}
+
+	return nil
+}
+
+func (p *Provider) provider(name string) storage.Provider {
+	if provider, ok := p.providers[strings.ToLower(name)]; ok {
+		return provider
+	}
+
+	return p.defaultProvider
+}
+
+func (p *Provider) values() []storage.Provider {
+	providers := make([]storage.Provider, 0, len(p.providers))
+
+	for _, provider := range p.providers {
+		providers = append(providers, provider)
+	}
+
+	return providers
+}
@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package composite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestCompositeProvider(t *testing.T) {
+	t.Run("selects the provider per store", func(t *testing.T) {
+		durable := mem.NewProvider()
+		ephemeral := mem.NewProvider()
+
+		prov := NewProvider(durable, WithStoreProvider(ephemeral, "Sessions", "nonces"))
+
+		for name, expected := range map[string]storage.Provider{
+			"sessions":    ephemeral,
+			"nonces":      ephemeral,
+			"connections": durable,
+		} {
+			store, err := prov.OpenStore(name)
+			require.NoError(t, err)
+			require.NoError(t, store.Put("k1", []byte(name)))
+
+			expectedStore, err := expected.OpenStore(name)
+			require.NoError(t, err)
+
+			v, err := expectedStore.Get("k1")
+			require.NoError(t, err)
+			require.Equal(t, name, string(v))
+		}
+
+		require.NoError(t, prov.CloseStore("sessions"))
+
+		store, err := ephemeral.OpenStore("sessions")
+		require.NoError(t, err)
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("close errors", func(t *testing.T) {
+		prov := NewProvider(mem.NewProvider(), WithStoreProvider(&mockstorage.MockStoreProvider{
+			ErrClose: errors.New("close error"),
+		}, "sessions"))
+
+		err := prov.Close()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "close error")
+	})
+}
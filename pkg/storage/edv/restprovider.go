@@ -294,6 +294,11 @@ func (r *RESTStore) Delete(k string) error {
 	return nil
 }
 
+// Batch performs the batch operations one by one, since the data vault HTTPS API has no atomic batch.
+func (r *RESTStore) Batch(operations []storage.Operation) error {
+	return storage.ApplyOperations(r, operations)
+}
+
 // GenerateEDVCompatibleID generates the EDV document ID based on k and the MAC crypto key.
 // TODO (#2376) Revisit how we're generating EDV document IDs, since it's technically not 100% in line with the spec.
 //  (Spec requires randomly generated IDs)
@@ -502,6 +507,7 @@ type restClient struct {
 	edvServerURL string
 	httpClient   *http.Client
 	headersFunc  addHeaders
+	zcap         *zcapInvoker
 }
 
 // createDocument sends the EDV server a request to store the specified document.
@@ -719,6 +725,12 @@ func (c *restClient) sendHTTPRequest(method, endpoint string, body []byte,
 		req.Header.Set("Content-Type", contentTypeApplicationJSON)
 	}
 
+	if c.zcap != nil {
+		if err = c.zcap.invoke(req, body); err != nil {
+			return -1, nil, nil, err
+		}
+	}
+
 	resp, err := c.httpClient.Do(req) //nolint: bodyclose
 	if err != nil {
 		return -1, nil, nil, fmt.Errorf(failSendRequest, err)
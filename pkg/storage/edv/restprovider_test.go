@@ -264,7 +264,6 @@ func TestRestStore_Put(t *testing.T) {
 		mockEDVServerOperation := edv.MockServerOperation{
 			T:                              t,
 			DB:                             make(map[string][]byte),
-			UseDB:                          true,
 			ReadDocumentReturnStatusCode:   http.StatusOK,
 			ReadDocumentReturnBody:         []byte("SomeDocumentID"),
 			CreateDocumentReturnLocation:   "documentLocation",
@@ -0,0 +1,147 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package edv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	capabilityInvocationHeader = "Capability-Invocation"
+	digestHeader               = "Digest"
+	authorizationHeader        = "Authorization"
+
+	zcapActionRead  = "read"
+	zcapActionWrite = "write"
+
+	// zcapSignatureTTL is how long the HTTP signature of a capability invocation is valid.
+	zcapSignatureTTL = 5 * time.Minute
+
+	failCompressCapability = "failed to compress authorization capability: %w"
+	failSignZCAPInvocation = "failed to sign capability invocation: %w"
+)
+
+// ZCAPSigner signs the HTTP signature of the requests invoking an authorization capability.
+type ZCAPSigner interface {
+	// Sign signs the data with the key of the capability invoker.
+	Sign(data []byte) ([]byte, error)
+}
+
+// WithZCAPAuthorization option is for authorizing the requests with an authorization capability (ZCAP-LD)
+// delegated to the agent for the vault, e.g by the wallet owner. capability is the JSON-LD capability document,
+// invoked by signing the requests (HTTP signatures) with the capability invocation key keyID.
+func WithZCAPAuthorization(capability []byte, keyID string, signer ZCAPSigner) Option {
+	return func(opts *RESTProvider) {
+		opts.restClient.zcap = &zcapInvoker{
+			capability: capability,
+			keyID:      keyID,
+			signer:     signer,
+			now:        time.Now,
+		}
+	}
+}
+
+// zcapInvoker adds the capability invocation and its HTTP signature to the requests sent to the EDV server,
+// as defined in https://w3c-ccg.github.io/zcap-ld and https://tools.ietf.org/html/draft-cavage-http-signatures-12.
+type zcapInvoker struct {
+	capability []byte
+	keyID      string
+	signer     ZCAPSigner
+	now        func() time.Time
+}
+
+// invoke sets the capability invocation, digest and authorization headers of the request.
+func (z *zcapInvoker) invoke(req *http.Request, body []byte) error {
+	capability, err := compressCapability(z.capability)
+	if err != nil {
+		return fmt.Errorf(failCompressCapability, err)
+	}
+
+	action := zcapActionWrite
+	if req.Method == http.MethodGet {
+		action = zcapActionRead
+	}
+
+	req.Header.Set(capabilityInvocationHeader, fmt.Sprintf(`zcap capability="%s",action="%s"`, capability, action))
+
+	headers := []string{"(key-id)", "(created)", "(expires)", "(request-target)", "host", "capability-invocation"}
+
+	if len(body) > 0 {
+		digest := sha256.Sum256(body)
+		req.Header.Set(digestHeader, "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]))
+
+		headers = append(headers, "digest")
+	}
+
+	created := z.now()
+	expires := created.Add(zcapSignatureTTL)
+
+	signature, err := z.signer.Sign([]byte(signingString(req, headers, z.keyID, created, expires)))
+	if err != nil {
+		return fmt.Errorf(failSignZCAPInvocation, err)
+	}
+
+	req.Header.Set(authorizationHeader, fmt.Sprintf(
+		`Signature keyId="%s",headers="%s",signature="%s",created="%d",expires="%d"`,
+		z.keyID, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature),
+		created.Unix(), expires.Unix()))
+
+	return nil
+}
+
+// signingString builds the HTTP signature signing string of the request with the given headers.
+func signingString(req *http.Request, headers []string, keyID string, created, expires time.Time) string {
+	lines := make([]string, len(headers))
+
+	for i, h := range headers {
+		var value string
+
+		switch h {
+		case "(key-id)":
+			value = keyID
+		case "(created)":
+			value = fmt.Sprint(created.Unix())
+		case "(expires)":
+			value = fmt.Sprint(expires.Unix())
+		case "(request-target)":
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			value = req.Host
+		default:
+			value = req.Header.Get(h)
+		}
+
+		lines[i] = h + ": " + value
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// compressCapability encodes the capability as defined by the capability invocation header: gzip compressed and
+// base64url encoded.
+func compressCapability(capability []byte) (string, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if _, err := w.Write(capability); err != nil {
+		return "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package edv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const testCapability = `{"@context":"https://w3id.org/security/v2","id":"urn:zcap:123","invoker":"did:example:123#key1"}`
+
+type ed25519Signer struct {
+	privateKey ed25519.PrivateKey
+	err        error
+}
+
+func (s *ed25519Signer) Sign(data []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return ed25519.Sign(s.privateKey, data), nil
+}
+
+func TestWithZCAPAuthorization(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("signs the capability invocations", func(t *testing.T) {
+		requests := make(chan *http.Request, 1)
+		bodies := make(chan []byte, 1)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, errRead := ioutil.ReadAll(r.Body)
+			require.NoError(t, errRead)
+
+			requests <- r
+			bodies <- body
+
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		provider, err := NewRESTProvider(srv.URL, "vaultID", newMACCrypto(t),
+			WithZCAPAuthorization([]byte(testCapability), "did:example:123#key1", &ed25519Signer{privateKey: privKey}))
+		require.NoError(t, err)
+
+		store, err := provider.OpenStore("store")
+		require.NoError(t, err)
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		req := <-requests
+		<-bodies
+
+		require.Equal(t, zcapActionRead, capabilityAction(t, req))
+		require.Equal(t, testCapability, decompressCapability(t, req))
+		verifySignature(t, req, pubKey, "(key-id) (created) (expires) (request-target) host capability-invocation")
+
+		require.Error(t, provider.Batch(nil))
+
+		req = <-requests
+		body := <-bodies
+
+		digest := sha256.Sum256(body)
+		require.Equal(t, zcapActionWrite, capabilityAction(t, req))
+		require.Equal(t, "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]), req.Header.Get(digestHeader))
+		verifySignature(t, req, pubKey,
+			"(key-id) (created) (expires) (request-target) host capability-invocation digest")
+	})
+
+	t.Run("fails to sign the capability invocation", func(t *testing.T) {
+		provider, err := NewRESTProvider("http://localhost", "vaultID", newMACCrypto(t),
+			WithZCAPAuthorization([]byte(testCapability), "did:example:123#key1", &ed25519Signer{err: errTest}))
+		require.NoError(t, err)
+
+		store, err := provider.OpenStore("store")
+		require.NoError(t, err)
+
+		_, err = store.Get("key")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), fmt.Errorf(failSignZCAPInvocation, errTest).Error())
+	})
+}
+
+func capabilityAction(t *testing.T, req *http.Request) string {
+	t.Helper()
+
+	matches := regexp.MustCompile(`action="([a-z]+)"`).FindStringSubmatch(req.Header.Get(capabilityInvocationHeader))
+	require.Len(t, matches, 2)
+
+	return matches[1]
+}
+
+func decompressCapability(t *testing.T, req *http.Request) string {
+	t.Helper()
+
+	matches := regexp.MustCompile(`^zcap capability="([^"]+)"`).FindStringSubmatch(
+		req.Header.Get(capabilityInvocationHeader))
+	require.Len(t, matches, 2)
+
+	compressed, err := base64.RawURLEncoding.DecodeString(matches[1])
+	require.NoError(t, err)
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+
+	capability, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(capability)
+}
+
+func verifySignature(t *testing.T, req *http.Request, pubKey ed25519.PublicKey, headers string) {
+	t.Helper()
+
+	params := map[string]string{}
+
+	for _, m := range regexp.MustCompile(`([a-zA-Z]+)="([^"]*)"`).FindAllStringSubmatch(
+		strings.TrimPrefix(req.Header.Get(authorizationHeader), "Signature "), -1) {
+		params[m[1]] = m[2]
+	}
+
+	require.Equal(t, "did:example:123#key1", params["keyId"])
+	require.Equal(t, headers, params["headers"])
+
+	var created, expires int64
+
+	_, err := fmt.Sscan(params["created"], &created)
+	require.NoError(t, err)
+
+	_, err = fmt.Sscan(params["expires"], &expires)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(zcapSignatureTTL/time.Second), expires-created)
+
+	signature, err := base64.StdEncoding.DecodeString(params["signature"])
+	require.NoError(t, err)
+
+	data := signingString(req, strings.Split(headers, " "), params["keyId"],
+		time.Unix(created, 0), time.Unix(expires, 0))
+	require.True(t, ed25519.Verify(pubKey, []byte(data), signature))
+}
@@ -0,0 +1,42 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrExpirationNotSupported is returned when a record with an expiration is put in a store not supporting it.
+var ErrExpirationNotSupported = errors.New("store does not support record expiration")
+
+// ExpiringStore is implemented by the stores supporting records expiration, either natively (e.g Redis or MongoDB
+// stores) or with a sweeper (see the expiring storage wrapper).
+type ExpiringStore interface {
+	// PutWithTTL stores the key and the record, which expires after the ttl duration
+	PutWithTTL(k string, v []byte, ttl time.Duration) error
+}
+
+// PutWithTTL stores the key and the record in the store, the record expiring after the ttl duration: once expired,
+// the record is no longer returned and is eventually deleted. The record doesn't expire when ttl is zero.
+// ErrExpirationNotSupported is returned if the store is not an ExpiringStore.
+func PutWithTTL(store Store, k string, v []byte, ttl time.Duration) error {
+	if ttl == 0 {
+		return store.Put(k, v)
+	}
+
+	if ttl < 0 {
+		return errors.New("ttl must be positive")
+	}
+
+	s, ok := store.(ExpiringStore)
+	if !ok {
+		return ErrExpirationNotSupported
+	}
+
+	return s.PutWithTTL(k, v, ttl)
+}
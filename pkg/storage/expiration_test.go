@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+type expiringStore struct {
+	storage.Store
+	ttl time.Duration
+}
+
+func (s *expiringStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	s.ttl = ttl
+
+	return s.Put(k, v)
+}
+
+func TestPutWithTTL(t *testing.T) {
+	store := mockstorage.NewMockStoreProvider().Store
+
+	err := storage.PutWithTTL(store, "k1", []byte("v1"), time.Minute)
+	require.True(t, errors.Is(err, storage.ErrExpirationNotSupported))
+
+	// the records without ttl are put in any store
+	require.NoError(t, storage.PutWithTTL(store, "k1", []byte("v1"), 0))
+
+	v, err := store.Get("k1")
+	require.NoError(t, err)
+	require.Equal(t, "v1", string(v))
+
+	expiring := &expiringStore{Store: store}
+
+	require.NoError(t, storage.PutWithTTL(expiring, "k2", []byte("v2"), time.Minute))
+	require.Equal(t, time.Minute, expiring.ttl)
+
+	require.EqualError(t, storage.PutWithTTL(expiring, "k2", []byte("v2"), -time.Minute), "ttl must be positive")
+}
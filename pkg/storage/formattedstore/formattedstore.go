@@ -27,6 +27,7 @@ const (
 	failParseFormattedValue            = "failed to parse formatted value: %w"
 	failDeleteInUnderlyingStore        = "failed to delete key-value pair in underlying store: %w"
 	failGetIteratorFromUnderlyingStore = "failed to get iterator from underlying store: %w"
+	failBatchInUnderlyingStore         = "failed to perform batch operations in underlying store: %w"
 )
 
 var logger = log.New("formatted-store")
@@ -316,3 +317,35 @@ func (s *formatStore) Delete(k string) error {
 
 	return nil
 }
+
+// Batch formats the values of the put operations and performs the batch in the underlying store.
+func (s *formatStore) Batch(operations []storage.Operation) error {
+	if s.batchSvc != nil {
+		return storage.ApplyOperations(s, operations)
+	}
+
+	formattedOperations := make([]storage.Operation, len(operations))
+
+	for i, op := range operations {
+		formattedOperations[i] = storage.Operation{Key: op.Key}
+
+		if op.Value != nil {
+			formattedValue, err := s.formatter.FormatPair(op.Key, op.Value)
+			if err != nil {
+				return fmt.Errorf(failFormat, err)
+			}
+
+			formattedOperations[i].Value = formattedValue
+		}
+	}
+
+	if err := s.store.Batch(formattedOperations); err != nil {
+		return fmt.Errorf(failBatchInUnderlyingStore, err)
+	}
+
+	if s.cacheStore != nil {
+		return s.cacheStore.Batch(operations)
+	}
+
+	return nil
+}
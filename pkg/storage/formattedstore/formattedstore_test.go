@@ -686,6 +686,75 @@ func Test_formatStore_Delete(t *testing.T) {
 	})
 }
 
+func Test_formatStore_Batch(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		macCrypto := newMACCrypto(t)
+
+		cacheProvider := mem.NewProvider()
+
+		provider := formattedstore.NewFormattedProvider(mem.NewProvider(), createEDVFormatter(t, macCrypto), true,
+			formattedstore.WithCacheProvider(cacheProvider))
+		require.NotNil(t, provider)
+
+		store, err := provider.OpenStore("testName")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		err = store.Batch([]storage.Operation{{Key: "k1"}, {Key: testKey, Value: []byte(testValue)}})
+		require.NoError(t, err)
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		cacheStore, err := cacheProvider.OpenStore("testName")
+		require.NoError(t, err)
+
+		v, err := cacheStore.Get(testKey)
+		require.NoError(t, err)
+		require.Equal(t, testValue, string(v))
+	})
+	t.Run("Success batch", func(t *testing.T) {
+		macCrypto := newMACCrypto(t)
+
+		provider := formattedstore.NewFormattedProvider(newMockStoreProvider(), createEDVFormatter(t, macCrypto), true,
+			formattedstore.WithBatchWrite(1))
+		require.NotNil(t, provider)
+
+		store, err := provider.OpenStore("testName")
+		require.NoError(t, err)
+
+		err = store.Batch([]storage.Operation{{Key: testKey, Value: []byte(testValue)}, {Key: testKey}})
+		require.NoError(t, err)
+	})
+	t.Run("Fail to format value", func(t *testing.T) {
+		provider := formattedstore.NewFormattedProvider(mem.NewProvider(), &failingFormatter{}, true)
+		require.NotNil(t, provider)
+
+		store, err := provider.OpenStore("testName")
+		require.NoError(t, err)
+
+		err = store.Batch([]storage.Operation{{Key: testKey, Value: []byte(testValue)}})
+		require.EqualError(t, err,
+			fmt.Errorf("failed to format value: %w", errFailingFormatter).Error())
+	})
+	t.Run("Fail to perform batch in underlying store", func(t *testing.T) {
+		mockStoreProvider := mockstorage.NewMockStoreProvider()
+		mockStoreProvider.Store.ErrBatch = errTest
+
+		provider := formattedstore.NewFormattedProvider(mockStoreProvider, createEDVFormatter(t, newMACCrypto(t)),
+			true)
+		require.NotNil(t, provider)
+
+		store, err := provider.OpenStore("testName")
+		require.NoError(t, err)
+
+		err = store.Batch([]storage.Operation{{Key: testKey}})
+		require.EqualError(t, err,
+			fmt.Errorf("failed to perform batch operations in underlying store: %w", errTest).Error())
+	})
+}
+
 func createEDVFormatter(t *testing.T, macCrypto *edv.MACCrypto) formattedstore.Formatter {
 	encrypter, decrypter := createEncrypterAndDecrypter(t)
 
@@ -840,6 +909,10 @@ func (s *mockStore) Delete(k string) error {
 	return s.mock.Delete(k)
 }
 
+func (s *mockStore) Batch(operations []storage.Operation) error {
+	return s.mock.Batch(operations)
+}
+
 func (s *mockStore) AddEncryptedIndexTagForStoreName(encryptedDocumentBytes []byte) (*models.EncryptedDocument, error) {
 	return &models.EncryptedDocument{}, nil
 }
@@ -66,7 +66,9 @@ func (p *Provider) Close() error {
 	defer p.lock.Unlock()
 
 	for _, memStore := range p.dbs {
+		memStore.Lock()
 		memStore.db = make(map[string][]byte)
+		memStore.Unlock()
 	}
 
 	p.dbs = make(map[string]*memStore)
@@ -74,6 +76,61 @@ func (p *Provider) Close() error {
 	return nil
 }
 
+// Snapshot is a copy of the records of the stores of a Provider, by store name. Since the JSON encoding sorts the
+// map keys, a snapshot is marshalled deterministically, e.g to be saved as a test fixture.
+type Snapshot map[string]map[string][]byte
+
+// Snapshot returns a copy of the records of the open stores, e.g to checkpoint the state of an agent in a test.
+func (p *Provider) Snapshot() Snapshot {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	snapshot := make(Snapshot, len(p.dbs))
+
+	for name, store := range p.dbs {
+		store.RLock()
+		snapshot[name] = copyRecords(store.db)
+		store.RUnlock()
+	}
+
+	return snapshot
+}
+
+// Restore replaces the records of the stores with the records of the snapshot, the stores missing from the snapshot
+// being emptied. The stores already opened are restored in place, so their handles see the restored records.
+func (p *Provider) Restore(snapshot Snapshot) {
+	// the store names are case insensitive
+	stores := make(Snapshot, len(snapshot))
+	for name, records := range snapshot {
+		stores[strings.ToLower(name)] = records
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for name, store := range p.dbs {
+		store.Lock()
+		store.db = copyRecords(stores[name])
+		store.Unlock()
+	}
+
+	for name, records := range stores {
+		if _, ok := p.dbs[name]; !ok {
+			p.dbs[name] = &memStore{db: copyRecords(records)}
+		}
+	}
+}
+
+func copyRecords(records map[string][]byte) map[string][]byte {
+	c := make(map[string][]byte, len(records))
+
+	for k, v := range records {
+		c[k] = append([]byte(nil), v...)
+	}
+
+	return c
+}
+
 // CloseStore closes level db store of given name.
 func (p *Provider) CloseStore(name string) error {
 	p.lock.Lock()
@@ -126,7 +183,38 @@ func (s *memStore) Get(k string) ([]byte, error) {
 	return data, nil
 }
 
-// Iterator returns iterator for the latest snapshot of the underlying db.
+// GetWithVersion fetches the record based on key and returns its current version, derived from its content.
+func (s *memStore) GetWithVersion(k string) ([]byte, string, error) {
+	data, err := s.Get(k)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, storage.ContentVersion(data), nil
+}
+
+// PutIfVersion stores the key and the record if the current version of the record is version, an empty version
+// meaning the record must not exist.
+func (s *memStore) PutIfVersion(k string, v []byte, version string) (string, error) {
+	if k == "" || v == nil {
+		return "", errors.New("key and value are mandatory")
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	data, ok := s.db[k]
+
+	if !ok && version != "" || ok && version != storage.ContentVersion(data) {
+		return "", storage.ErrVersionMismatch
+	}
+
+	s.db[k] = v
+
+	return storage.ContentVersion(v), nil
+}
+
+// Iterator returns iterator for the latest snapshot of the underlying db, the records being iterated in key order.
 func (s *memStore) Iterator(start, limit string) storage.StoreIterator {
 	if limit == "" {
 		return NewMemIterator(nil, nil)
@@ -191,6 +279,28 @@ func (s *memStore) Delete(k string) error {
 	return nil
 }
 
+// Batch performs the batch operations atomically.
+func (s *memStore) Batch(operations []storage.Operation) error {
+	for _, op := range operations {
+		if op.Key == "" {
+			return errors.New("key is mandatory")
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	for _, op := range operations {
+		if op.Value == nil {
+			delete(s.db, op.Key)
+		} else {
+			s.db[op.Key] = op.Value
+		}
+	}
+
+	return nil
+}
+
 type memIterator struct {
 	currentIndex int
 	currentItem  []string
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package mem
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -285,3 +286,148 @@ func TestMemStore_Delete(t *testing.T) {
 	require.EqualError(t, err, storage.ErrDataNotFound.Error())
 	require.Empty(t, doc)
 }
+
+func TestMemStore_Batch(t *testing.T) {
+	prov := NewProvider()
+
+	store, err := prov.OpenStore("test")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("k1", []byte("v1")))
+
+	err = store.Batch([]storage.Operation{
+		{Key: "k2", Value: []byte("v2")},
+		{Key: "k1"},
+		{Key: "k3", Value: []byte("v3")},
+		{Key: "k3", Value: []byte("v3-updated")},
+	})
+	require.NoError(t, err)
+
+	_, err = store.Get("k1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	doc, err := store.Get("k2")
+	require.NoError(t, err)
+	require.Equal(t, "v2", string(doc))
+
+	doc, err = store.Get("k3")
+	require.NoError(t, err)
+	require.Equal(t, "v3-updated", string(doc))
+
+	// none of the operations is performed if one is invalid
+	err = store.Batch([]storage.Operation{{Key: "k4", Value: []byte("v4")}, {Key: ""}})
+	require.EqualError(t, err, "key is mandatory")
+
+	_, err = store.Get("k4")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+}
+
+func TestMemStore_PutIfVersion(t *testing.T) {
+	prov := NewProvider()
+	store, err := prov.OpenStore("test")
+	require.NoError(t, err)
+
+	version, err := storage.PutIfVersion(store, "k1", []byte("v1"), "")
+	require.NoError(t, err)
+
+	// the record already exists
+	_, err = storage.PutIfVersion(store, "k1", []byte("v2"), "")
+	require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+	// the record is updated concurrently
+	require.NoError(t, store.Put("k1", []byte("v1-updated")))
+
+	_, err = storage.PutIfVersion(store, "k1", []byte("v2"), version)
+	require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+	doc, version, err := storage.GetWithVersion(store, "k1")
+	require.NoError(t, err)
+	require.Equal(t, "v1-updated", string(doc))
+
+	version, err = storage.PutIfVersion(store, "k1", []byte("v2"), version)
+	require.NoError(t, err)
+
+	_, current, err := storage.GetWithVersion(store, "k1")
+	require.NoError(t, err)
+	require.Equal(t, version, current)
+
+	// the record was deleted
+	require.NoError(t, store.Delete("k1"))
+
+	_, err = storage.PutIfVersion(store, "k1", []byte("v3"), version)
+	require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+	_, _, err = storage.GetWithVersion(store, "k1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, err = storage.PutIfVersion(store, "", []byte("v1"), "")
+	require.EqualError(t, err, "key and value are mandatory")
+}
+
+func TestProvider_Snapshot(t *testing.T) {
+	prov := NewProvider()
+
+	connections, err := prov.OpenStore("connections")
+	require.NoError(t, err)
+
+	credentials, err := prov.OpenStore("credentials")
+	require.NoError(t, err)
+
+	value := []byte("conn-1")
+	require.NoError(t, connections.Put("k1", value))
+	require.NoError(t, credentials.Put("k1", []byte("cred-1")))
+
+	snapshot := prov.Snapshot()
+	require.Equal(t, Snapshot{
+		"connections": {"k1": []byte("conn-1")},
+		"credentials": {"k1": []byte("cred-1")},
+	}, snapshot)
+
+	// the snapshot is a copy of the records
+	value[0] = 'x'
+	require.NoError(t, connections.Put("k2", []byte("conn-2")))
+	require.NoError(t, credentials.Delete("k1"))
+	require.Equal(t, Snapshot{
+		"connections": {"k1": []byte("conn-1")},
+		"credentials": {"k1": []byte("cred-1")},
+	}, snapshot)
+
+	// the snapshots are marshalled deterministically, e.g as test fixtures
+	fixture, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	require.Equal(t, `{"connections":{"k1":"Y29ubi0x"},"credentials":{"k1":"Y3JlZC0x"}}`, string(fixture))
+
+	var loaded Snapshot
+	require.NoError(t, json.Unmarshal(fixture, &loaded))
+
+	others, err := prov.OpenStore("others")
+	require.NoError(t, err)
+	require.NoError(t, others.Put("k1", []byte("other-1")))
+
+	// the open store handles see the restored records
+	prov.Restore(loaded)
+
+	requireRecords := func(store storage.Store, expected ...string) {
+		itr := store.Iterator("", storage.EndKeySuffix)
+		defer itr.Release()
+
+		var records []string
+		for itr.Next() {
+			records = append(records, fmt.Sprintf("%s=%s", itr.Key(), itr.Value()))
+		}
+
+		require.Equal(t, expected, records)
+	}
+
+	requireRecords(connections, "k1=conn-1")
+	requireRecords(credentials, "k1=cred-1")
+	requireRecords(others)
+
+	// the stores of the snapshot which are not open are created
+	prov = NewProvider()
+	prov.Restore(Snapshot{"Connections": {"k1": []byte("conn-1")}})
+
+	connections, err = prov.OpenStore("connections")
+	require.NoError(t, err)
+	requireRecords(connections, "k1=conn-1")
+}
@@ -0,0 +1,247 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package migration copies the stores of a storage provider to another one, e.g to move a deployment from LevelDB
+// to another database.
+//
+// The records are copied in batches following the key order of the source stores. When a checkpoint store is given,
+// the last key copied in each store is saved after each batch, so that an interrupted migration resumes where it
+// stopped instead of starting over.
+package migration
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	defaultBatchSize = 100
+
+	// completed is the checkpoint of the stores fully migrated.
+	completed = storage.EndKeySuffix
+)
+
+// Progress reports the progress of the migration of a store.
+type Progress struct {
+	// Store is the name of the store
+	Store string
+	// Migrated is the number of records copied so far in this run
+	Migrated int
+	// Done is set once all the records of the store are copied
+	Done bool
+}
+
+// Option configures the migration.
+type Option func(m *migrator)
+
+// WithBatchSize sets the number of records copied per batch (100 by default).
+func WithBatchSize(size int) Option {
+	return func(m *migrator) {
+		m.batchSize = size
+	}
+}
+
+// WithProgress sets the function notified after each batch of records is copied.
+func WithProgress(progress func(Progress)) Option {
+	return func(m *migrator) {
+		m.progress = progress
+	}
+}
+
+// WithCheckpointStore sets the store in which the migration progress is saved, so that it can be resumed.
+func WithCheckpointStore(store storage.Store) Option {
+	return func(m *migrator) {
+		m.checkpoints = store
+	}
+}
+
+// WithVerification verifies that each store was fully copied once its records are migrated.
+func WithVerification() Option {
+	return func(m *migrator) {
+		m.verify = true
+	}
+}
+
+type migrator struct {
+	source      storage.Provider
+	target      storage.Provider
+	batchSize   int
+	progress    func(Progress)
+	checkpoints storage.Store
+	verify      bool
+}
+
+// Migrate copies the records of the stores with the given names from the source provider to the target provider.
+// The stores are listed by the caller since the providers don't list their stores.
+func Migrate(source, target storage.Provider, storeNames []string, opts ...Option) error {
+	m := &migrator{
+		source:    source,
+		target:    target,
+		batchSize: defaultBatchSize,
+		progress:  func(Progress) {},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if m.batchSize <= 0 {
+		return errors.New("batch size must be positive")
+	}
+
+	for _, name := range storeNames {
+		if err := m.migrateStore(name); err != nil {
+			return fmt.Errorf("failed to migrate store %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *migrator) migrateStore(name string) error {
+	source, err := m.source.OpenStore(name)
+	if err != nil {
+		return fmt.Errorf("failed to open source store: %w", err)
+	}
+
+	target, err := m.target.OpenStore(name)
+	if err != nil {
+		return fmt.Errorf("failed to open target store: %w", err)
+	}
+
+	checkpoint, err := m.checkpoint(name)
+	if err != nil {
+		return err
+	}
+
+	if checkpoint != completed {
+		if err = m.copyRecords(name, source, target, checkpoint); err != nil {
+			return err
+		}
+	}
+
+	if m.verify {
+		if err = verify(source, target); err != nil {
+			return err
+		}
+	}
+
+	m.progress(Progress{Store: name, Done: true})
+
+	return nil
+}
+
+// copyRecords copies the records of the source store following the checkpoint key.
+func (m *migrator) copyRecords(name string, source, target storage.Store, checkpoint string) error {
+	itr := source.Iterator(checkpoint, storage.EndKeySuffix)
+	defer itr.Release()
+
+	var (
+		batch    []storage.Operation
+		migrated int
+	)
+
+	for itr.Next() {
+		// the checkpoint key was copied by the previous run
+		if checkpoint != "" && string(itr.Key()) == checkpoint {
+			continue
+		}
+
+		batch = append(batch, storage.Operation{
+			Key:   string(itr.Key()),
+			Value: append([]byte(nil), itr.Value()...),
+		})
+
+		if len(batch) == m.batchSize {
+			if err := m.copyBatch(name, target, batch); err != nil {
+				return err
+			}
+
+			migrated += len(batch)
+			batch = nil
+
+			m.progress(Progress{Store: name, Migrated: migrated})
+		}
+	}
+
+	if err := itr.Error(); err != nil {
+		return fmt.Errorf("failed to iterate source store: %w", err)
+	}
+
+	if len(batch) > 0 {
+		if err := m.copyBatch(name, target, batch); err != nil {
+			return err
+		}
+
+		m.progress(Progress{Store: name, Migrated: migrated + len(batch)})
+	}
+
+	return m.saveCheckpoint(name, completed)
+}
+
+func (m *migrator) copyBatch(name string, target storage.Store, batch []storage.Operation) error {
+	if err := target.Batch(batch); err != nil {
+		return fmt.Errorf("failed to copy records: %w", err)
+	}
+
+	return m.saveCheckpoint(name, batch[len(batch)-1].Key)
+}
+
+// checkpoint returns the last key copied in the store by a previous run, or an empty key.
+func (m *migrator) checkpoint(name string) (string, error) {
+	if m.checkpoints == nil {
+		return "", nil
+	}
+
+	checkpoint, err := m.checkpoints.Get(name)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get checkpoint: %w", err)
+	}
+
+	return string(checkpoint), nil
+}
+
+func (m *migrator) saveCheckpoint(name, key string) error {
+	if m.checkpoints == nil {
+		return nil
+	}
+
+	if err := m.checkpoints.Put(name, []byte(key)); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// verify checks that the records of the source store are in the target store.
+func verify(source, target storage.Store) error {
+	itr := source.Iterator("", storage.EndKeySuffix)
+	defer itr.Release()
+
+	for itr.Next() {
+		v, err := target.Get(string(itr.Key()))
+		if err != nil {
+			return fmt.Errorf("verification failed for key %s: %w", itr.Key(), err)
+		}
+
+		if !bytes.Equal(v, itr.Value()) {
+			return fmt.Errorf("verification failed for key %s: values differ", itr.Key())
+		}
+	}
+
+	if err := itr.Error(); err != nil {
+		return fmt.Errorf("failed to iterate source store: %w", err)
+	}
+
+	return nil
+}
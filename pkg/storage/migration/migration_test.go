@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+var errTest = errors.New("test error")
+
+// failingStore fails the batches once failAfter batches were performed.
+type failingStore struct {
+	storage.Store
+	batches   int
+	failAfter int
+}
+
+func (s *failingStore) Batch(operations []storage.Operation) error {
+	if s.batches == s.failAfter {
+		return errTest
+	}
+
+	s.batches++
+
+	return s.Store.Batch(operations)
+}
+
+type failingProvider struct {
+	storage.Provider
+	store *failingStore
+}
+
+func (p *failingProvider) OpenStore(string) (storage.Store, error) {
+	return p.store, nil
+}
+
+func newSource(t *testing.T, records map[string]int) storage.Provider {
+	t.Helper()
+
+	source := mem.NewProvider()
+
+	for name, count := range records {
+		store, err := source.OpenStore(name)
+		require.NoError(t, err)
+
+		for i := 0; i < count; i++ {
+			require.NoError(t, store.Put(fmt.Sprintf("key_%02d", i), []byte(fmt.Sprintf("%s-%d", name, i))))
+		}
+	}
+
+	return source
+}
+
+func requireMigrated(t *testing.T, source, target storage.Provider, name string, count int) {
+	t.Helper()
+
+	sourceStore, err := source.OpenStore(name)
+	require.NoError(t, err)
+
+	targetStore, err := target.OpenStore(name)
+	require.NoError(t, err)
+
+	itr := targetStore.Iterator("", storage.EndKeySuffix)
+	defer itr.Release()
+
+	migrated := 0
+
+	for itr.Next() {
+		v, err := sourceStore.Get(string(itr.Key()))
+		require.NoError(t, err)
+		require.Equal(t, v, itr.Value())
+
+		migrated++
+	}
+
+	require.Equal(t, count, migrated)
+}
+
+func TestMigrate(t *testing.T) {
+	t.Run("migrate stores", func(t *testing.T) {
+		source := newSource(t, map[string]int{"connections": 25, "credentials": 3, "empty": 0})
+		target := mem.NewProvider()
+
+		var progress []Progress
+
+		err := Migrate(source, target, []string{"connections", "credentials", "empty"},
+			WithBatchSize(10),
+			WithVerification(),
+			WithProgress(func(p Progress) {
+				progress = append(progress, p)
+			}))
+		require.NoError(t, err)
+
+		requireMigrated(t, source, target, "connections", 25)
+		requireMigrated(t, source, target, "credentials", 3)
+		requireMigrated(t, source, target, "empty", 0)
+
+		require.Equal(t, []Progress{
+			{Store: "connections", Migrated: 10},
+			{Store: "connections", Migrated: 20},
+			{Store: "connections", Migrated: 25},
+			{Store: "connections", Done: true},
+			{Store: "credentials", Migrated: 3},
+			{Store: "credentials", Done: true},
+			{Store: "empty", Done: true},
+		}, progress)
+	})
+
+	t.Run("resume migration", func(t *testing.T) {
+		source := newSource(t, map[string]int{"connections": 25, "credentials": 3})
+		targetStore, err := mem.NewProvider().OpenStore("connections")
+		require.NoError(t, err)
+
+		target := &failingProvider{store: &failingStore{Store: targetStore, failAfter: 2}}
+		checkpoints := mockstorage.NewMockStoreProvider().Store
+
+		err = Migrate(source, target, []string{"connections"}, WithBatchSize(10), WithCheckpointStore(checkpoints))
+		require.EqualError(t, err, "failed to migrate store connections: failed to copy records: test error")
+		require.Equal(t, "key_19", string(checkpoints.Store["connections"]))
+
+		var progress []Progress
+
+		target.store.failAfter = -1
+
+		err = Migrate(source, target, []string{"connections"},
+			WithBatchSize(10),
+			WithCheckpointStore(checkpoints),
+			WithVerification(),
+			WithProgress(func(p Progress) {
+				progress = append(progress, p)
+			}))
+		require.NoError(t, err)
+
+		requireMigrated(t, source, target, "connections", 25)
+		require.Equal(t, []Progress{
+			{Store: "connections", Migrated: 5},
+			{Store: "connections", Done: true},
+		}, progress)
+		require.Equal(t, 3, target.store.batches)
+
+		// the migrated stores are skipped
+		require.NoError(t, Migrate(source, target, []string{"connections"}, WithCheckpointStore(checkpoints)))
+		require.Equal(t, 3, target.store.batches)
+	})
+
+	t.Run("verification failure", func(t *testing.T) {
+		source := newSource(t, map[string]int{"connections": 3})
+		target := mem.NewProvider()
+		checkpoints := mockstorage.NewMockStoreProvider().Store
+
+		require.NoError(t, Migrate(source, target, []string{"connections"}, WithCheckpointStore(checkpoints)))
+
+		targetStore, err := target.OpenStore("connections")
+		require.NoError(t, err)
+
+		require.NoError(t, targetStore.Put("key_01", []byte("other")))
+
+		err = Migrate(source, target, []string{"connections"}, WithCheckpointStore(checkpoints), WithVerification())
+		require.EqualError(t, err, "failed to migrate store connections: verification failed for key key_01: "+
+			"values differ")
+
+		require.NoError(t, targetStore.Delete("key_01"))
+
+		err = Migrate(source, target, []string{"connections"}, WithCheckpointStore(checkpoints), WithVerification())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification failed for key key_01: data not found")
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		err := Migrate(mem.NewProvider(), mem.NewProvider(), []string{"connections"}, WithBatchSize(0))
+		require.EqualError(t, err, "batch size must be positive")
+	})
+
+	t.Run("provider errors", func(t *testing.T) {
+		err := Migrate(&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errTest}, mem.NewProvider(),
+			[]string{"connections"})
+		require.EqualError(t, err, "failed to migrate store connections: failed to open source store: test error")
+
+		err = Migrate(mem.NewProvider(), &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errTest},
+			[]string{"connections"})
+		require.EqualError(t, err, "failed to migrate store connections: failed to open target store: test error")
+	})
+
+	t.Run("source iterator error", func(t *testing.T) {
+		source := mockstorage.NewMockStoreProvider()
+		source.Store.ErrItr = errTest
+
+		err := Migrate(source, mem.NewProvider(), []string{"connections"})
+		require.EqualError(t, err, "failed to migrate store connections: failed to iterate source store: test error")
+
+		checkpoints := mockstorage.NewMockStoreProvider().Store
+		require.NoError(t, checkpoints.Put("connections", []byte(completed)))
+
+		err = Migrate(source, mem.NewProvider(), []string{"connections"}, WithCheckpointStore(checkpoints),
+			WithVerification())
+		require.EqualError(t, err, "failed to migrate store connections: failed to iterate source store: test error")
+	})
+
+	t.Run("checkpoint store errors", func(t *testing.T) {
+		source := newSource(t, map[string]int{"connections": 3})
+		checkpoints := mockstorage.NewMockStoreProvider().Store
+		checkpoints.ErrGet = errTest
+
+		err := Migrate(source, mem.NewProvider(), []string{"connections"}, WithCheckpointStore(checkpoints))
+		require.EqualError(t, err, "failed to migrate store connections: failed to get checkpoint: test error")
+
+		checkpoints = mockstorage.NewMockStoreProvider().Store
+		checkpoints.ErrPut = errTest
+
+		err = Migrate(source, mem.NewProvider(), []string{"connections"}, WithCheckpointStore(checkpoints))
+		require.EqualError(t, err, "failed to migrate store connections: failed to save checkpoint: test error")
+
+		err = Migrate(source, mem.NewProvider(), []string{"connections"}, WithCheckpointStore(checkpoints),
+			WithBatchSize(1))
+		require.EqualError(t, err, "failed to migrate store connections: failed to save checkpoint: test error")
+	})
+}
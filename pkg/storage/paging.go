@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+// Counter is implemented by the stores able to count the records in a key range without iterating over them
+// (e.g SQL or MongoDB stores).
+type Counter interface {
+	// Count returns the number of records in the key range, the end key being excluded
+	Count(startKey, endKey string) (int, error)
+}
+
+// Count returns the number of records of the store in the given key range. The records are counted by the store
+// if it is a Counter, otherwise they are counted by iterating over the range.
+func Count(store Store, startKey, endKey string) (int, error) {
+	if s, ok := store.(Counter); ok {
+		return s.Count(startKey, endKey)
+	}
+
+	itr := store.Iterator(startKey, endKey)
+	defer itr.Release()
+
+	count := 0
+
+	for itr.Next() {
+		count++
+	}
+
+	if err := itr.Error(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// NewPagedIterator returns an iterator over a page of the records of itr: the offset first records are skipped and
+// at most limit records are returned. A limit of zero (or less) returns all the records following the offset.
+func NewPagedIterator(itr StoreIterator, offset, limit int) StoreIterator {
+	if offset < 0 {
+		offset = 0
+	}
+
+	return &pagedIterator{StoreIterator: itr, offset: offset, limit: limit}
+}
+
+type pagedIterator struct {
+	StoreIterator
+	offset   int
+	limit    int
+	returned int
+}
+
+func (i *pagedIterator) Next() bool {
+	for ; i.offset > 0; i.offset-- {
+		if !i.StoreIterator.Next() {
+			i.offset = 0
+
+			return false
+		}
+	}
+
+	if i.limit > 0 && i.returned >= i.limit {
+		return false
+	}
+
+	if !i.StoreIterator.Next() {
+		return false
+	}
+
+	i.returned++
+
+	return true
+}
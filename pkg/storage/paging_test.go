@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+type countingStore struct {
+	storage.Store
+	count int
+}
+
+func (s *countingStore) Count(string, string) (int, error) {
+	return s.count, nil
+}
+
+func TestCount(t *testing.T) {
+	store, err := mem.NewProvider().OpenStore("test")
+	require.NoError(t, err)
+
+	for _, k := range []string{"abc_1", "abc_2", "abc_3", "xyz_1"} {
+		require.NoError(t, store.Put(k, []byte("value")))
+	}
+
+	count, err := storage.Count(store, "abc_", "abc_"+storage.EndKeySuffix)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	count, err = storage.Count(store, "def_", "def_"+storage.EndKeySuffix)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	count, err = storage.Count(&countingStore{Store: store, count: 10}, "abc_", "abc_"+storage.EndKeySuffix)
+	require.NoError(t, err)
+	require.Equal(t, 10, count)
+
+	_, err = storage.Count(&errIteratorStore{Store: store}, "abc_", "abc_"+storage.EndKeySuffix)
+	require.EqualError(t, err, "iterator error")
+}
+
+func TestNewPagedIterator(t *testing.T) {
+	store, err := mem.NewProvider().OpenStore("test")
+	require.NoError(t, err)
+
+	for _, k := range []string{"k1", "k2", "k3", "k4", "k5"} {
+		require.NoError(t, store.Put(k, []byte("value-"+k)))
+	}
+
+	page := func(offset, limit int) []string {
+		itr := storage.NewPagedIterator(store.Iterator("k", "k"+storage.EndKeySuffix), offset, limit)
+		defer itr.Release()
+
+		var keys []string
+
+		for itr.Next() {
+			keys = append(keys, string(itr.Key()))
+			require.Equal(t, "value-"+string(itr.Key()), string(itr.Value()))
+		}
+
+		require.NoError(t, itr.Error())
+
+		return keys
+	}
+
+	require.Equal(t, []string{"k1", "k2"}, page(0, 2))
+	require.Equal(t, []string{"k3", "k4"}, page(2, 2))
+	require.Equal(t, []string{"k5"}, page(4, 2))
+	require.Empty(t, page(5, 2))
+	require.Empty(t, page(10, 2))
+	require.Equal(t, []string{"k2", "k3", "k4", "k5"}, page(1, 0))
+	require.Equal(t, []string{"k1", "k2", "k3", "k4", "k5"}, page(-1, 0))
+
+	itr := storage.NewPagedIterator(mem.NewMemIterator(nil, errors.New("iterator error")), 1, 1)
+	require.False(t, itr.Next())
+	require.EqualError(t, itr.Error(), "iterator error")
+}
+
+type errIteratorStore struct {
+	storage.Store
+}
+
+func (s *errIteratorStore) Iterator(string, string) storage.StoreIterator {
+	return mem.NewMemIterator(nil, errors.New("iterator error"))
+}
@@ -0,0 +1,612 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const (
+	defaultPoolSize    = 10
+	defaultDialTimeout = 5 * time.Second
+	defaultIOTimeout   = 5 * time.Second
+	scanCount          = 100
+	keySeparator       = ":"
+)
+
+var errProviderClosed = errors.New("redis provider is closed")
+
+// Option configures the redis provider.
+type Option func(*Provider)
+
+// WithPassword sets the password used to authenticate the connections.
+func WithPassword(password string) Option {
+	return func(p *Provider) {
+		p.password = password
+	}
+}
+
+// WithDB selects the database of the connections.
+func WithDB(db int) Option {
+	return func(p *Provider) {
+		p.db = db
+	}
+}
+
+// WithPoolSize sets the maximum number of idle connections kept open.
+func WithPoolSize(size int) Option {
+	return func(p *Provider) {
+		p.poolSize = size
+	}
+}
+
+// WithTimeout sets the timeouts used to connect to the server and to run the commands.
+func WithTimeout(dialTimeout, ioTimeout time.Duration) Option {
+	return func(p *Provider) {
+		p.dialTimeout = dialTimeout
+		p.ioTimeout = ioTimeout
+	}
+}
+
+// WithTTL sets the time to live of the records of all the stores, unless overridden with WithStoreTTL.
+// The records never expire by default.
+func WithTTL(ttl time.Duration) Option {
+	return func(p *Provider) {
+		p.ttl = ttl
+	}
+}
+
+// WithStoreTTL sets the time to live of the records of the given store (e.g the session or the nonce store).
+func WithStoreTTL(name string, ttl time.Duration) Option {
+	return func(p *Provider) {
+		p.storeTTLs[strings.ToLower(name)] = ttl
+	}
+}
+
+// Provider redis implementation of storage.Provider interface, suited to ephemeral data like sessions,
+// outbound queues or nonces. The records of a store are the keys prefixed with the store name and expire
+// after the time to live of the store.
+type Provider struct {
+	addr        string
+	password    string
+	db          int
+	poolSize    int
+	dialTimeout time.Duration
+	ioTimeout   time.Duration
+	ttl         time.Duration
+	storeTTLs   map[string]time.Duration
+	pool        chan *conn
+	dbs         map[string]*Store
+	closed      bool
+	lock        sync.RWMutex
+}
+
+// NewProvider instantiates Provider connecting to the redis server at the given address (host:port).
+func NewProvider(addr string, opts ...Option) (*Provider, error) {
+	p := &Provider{
+		addr:        addr,
+		poolSize:    defaultPoolSize,
+		dialTimeout: defaultDialTimeout,
+		ioTimeout:   defaultIOTimeout,
+		storeTTLs:   make(map[string]time.Duration),
+		dbs:         make(map[string]*Store),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.pool = make(chan *conn, p.poolSize)
+
+	// checks that the server is reachable
+	c, err := p.conn()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis server: %w", err)
+	}
+
+	p.release(c)
+
+	return p, nil
+}
+
+// OpenStore opens and returns a store for given name space.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return nil, errProviderClosed
+	}
+
+	k := strings.ToLower(name)
+
+	store, ok := p.dbs[k]
+	if !ok {
+		ttl, ok := p.storeTTLs[k]
+		if !ok {
+			ttl = p.ttl
+		}
+
+		store = &Store{provider: p, prefix: k + keySeparator, ttl: ttl}
+		p.dbs[k] = store
+	}
+
+	return store, nil
+}
+
+// Close closes all stores created under this store provider, along with the connections to the server.
+// The records are left on the server until they expire.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return nil
+	}
+
+	p.closed = true
+	p.dbs = make(map[string]*Store)
+
+	close(p.pool)
+
+	var errs []error
+
+	for c := range p.pool {
+		if err := c.close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close connections, %v", errs)
+	}
+
+	return nil
+}
+
+// CloseStore closes redis store of given name.
+func (p *Provider) CloseStore(name string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.dbs, strings.ToLower(name))
+
+	return nil
+}
+
+// do runs the commands in a single round trip on a pooled connection.
+func (p *Provider) do(cmds ...[]interface{}) ([]interface{}, error) {
+	c, err := p.conn()
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := c.do(cmds...)
+	if err != nil {
+		// the state of the connection is unknown
+		_ = c.close() //nolint:errcheck
+
+		return nil, err
+	}
+
+	p.release(c)
+
+	return replies, nil
+}
+
+// conn returns an idle connection of the pool or a new connection.
+func (p *Provider) conn() (*conn, error) {
+	p.lock.RLock()
+	closed := p.closed
+	p.lock.RUnlock()
+
+	if closed {
+		return nil, errProviderClosed
+	}
+
+	select {
+	case c, ok := <-p.pool:
+		if ok {
+			return c, nil
+		}
+
+		return nil, errProviderClosed
+	default:
+	}
+
+	netConn, err := net.DialTimeout("tcp", p.addr, p.dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newConn(netConn, p.ioTimeout)
+
+	var cmds [][]interface{}
+
+	if p.password != "" {
+		cmds = append(cmds, []interface{}{"AUTH", p.password})
+	}
+
+	if p.db != 0 {
+		cmds = append(cmds, []interface{}{"SELECT", p.db})
+	}
+
+	cmds = append(cmds, []interface{}{"PING"})
+
+	replies, err := c.do(cmds...)
+	if err == nil {
+		err = firstError(replies)
+	}
+
+	if err != nil {
+		_ = c.close() //nolint:errcheck
+
+		return nil, fmt.Errorf("failed to initialize connection: %w", err)
+	}
+
+	return c, nil
+}
+
+// release puts the connection back in the pool, or closes it if the pool is full or closed.
+func (p *Provider) release(c *conn) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if !p.closed {
+		select {
+		case p.pool <- c:
+			return
+		default:
+		}
+	}
+
+	_ = c.close() //nolint:errcheck
+}
+
+// Store redis implementation of storage.Store interface.
+type Store struct {
+	provider *Provider
+	prefix   string
+	ttl      time.Duration
+}
+
+// Put stores the key and the record, which expires after the time to live of the store.
+func (s *Store) Put(k string, v []byte) error {
+	return s.PutAll(map[string][]byte{k: v})
+}
+
+// PutAll stores the records in a single round trip.
+func (s *Store) PutAll(records map[string][]byte) error {
+	cmds := make([][]interface{}, 0, len(records))
+
+	for k, v := range records {
+		if k == "" || v == nil {
+			return errors.New("key and value are mandatory")
+		}
+
+		cmds = append(cmds, s.setCommand(k, v))
+	}
+
+	replies, err := s.provider.do(cmds...)
+	if err != nil {
+		return fmt.Errorf("failed to put records: %w", err)
+	}
+
+	if err = firstError(replies); err != nil {
+		return fmt.Errorf("failed to put records: %w", err)
+	}
+
+	return nil
+}
+
+// PutWithTTL stores the record, which is expired by Redis after the ttl duration instead of the TTL of the store.
+func (s *Store) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+
+	replies, err := s.provider.do(s.setWithTTLCommand(k, v, ttl))
+	if err != nil {
+		return fmt.Errorf("failed to put record: %w", err)
+	}
+
+	if err = firstError(replies); err != nil {
+		return fmt.Errorf("failed to put record: %w", err)
+	}
+
+	return nil
+}
+
+// Batch performs the batch operations atomically within a MULTI/EXEC transaction, in a single round trip.
+func (s *Store) Batch(operations []storage.Operation) error {
+	cmds := [][]interface{}{{"MULTI"}}
+
+	for _, op := range operations {
+		if op.Key == "" {
+			return errors.New("key is mandatory")
+		}
+
+		if op.Value == nil {
+			cmds = append(cmds, []interface{}{"DEL", s.prefix + op.Key})
+		} else {
+			cmds = append(cmds, s.setCommand(op.Key, op.Value))
+		}
+	}
+
+	replies, err := s.provider.do(append(cmds, []interface{}{"EXEC"})...)
+	if err != nil {
+		return fmt.Errorf("failed to perform batch operations: %w", err)
+	}
+
+	if err = firstError(replies); err != nil {
+		return fmt.Errorf("failed to perform batch operations: %w", err)
+	}
+
+	// the reply of EXEC holds the replies of the operations
+	results, ok := replies[len(replies)-1].([]interface{})
+	if !ok || results == nil {
+		return errors.New("failed to perform batch operations: transaction aborted")
+	}
+
+	if err = firstError(results); err != nil {
+		return fmt.Errorf("failed to perform batch operations: %w", err)
+	}
+
+	return nil
+}
+
+// Get fetches the record based on key.
+func (s *Store) Get(k string) ([]byte, error) {
+	if k == "" {
+		return nil, errors.New("key is mandatory")
+	}
+
+	replies, err := s.provider.do([]interface{}{"GET", s.prefix + k})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get record: %w", err)
+	}
+
+	switch v := replies[0].(type) {
+	case []byte:
+		if v == nil {
+			return nil, storage.ErrDataNotFound
+		}
+
+		return v, nil
+	case Error:
+		return nil, fmt.Errorf("failed to get record: %w", v)
+	}
+
+	return nil, fmt.Errorf("unexpected reply %T", replies[0])
+}
+
+// GetWithVersion fetches the record based on key and returns its current version, derived from its content.
+func (s *Store) GetWithVersion(k string) ([]byte, string, error) {
+	v, err := s.Get(k)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return v, storage.ContentVersion(v), nil
+}
+
+// PutIfVersion stores the key and the record if the current version of the record is version, an empty version
+// meaning the record must not exist. The record is set within a transaction watching it, which is aborted by Redis
+// if the record is modified after its version is checked.
+func (s *Store) PutIfVersion(k string, v []byte, version string) (string, error) {
+	if k == "" || v == nil {
+		return "", errors.New("key and value are mandatory")
+	}
+
+	c, err := s.provider.conn()
+	if err != nil {
+		return "", fmt.Errorf("failed to put record: %w", err)
+	}
+
+	swapped, err := s.compareAndSwap(c, k, v, version)
+	if err != nil {
+		// the connection may still watch the record
+		_ = c.close() //nolint:errcheck
+
+		return "", fmt.Errorf("failed to put record: %w", err)
+	}
+
+	s.provider.release(c)
+
+	if !swapped {
+		return "", storage.ErrVersionMismatch
+	}
+
+	return storage.ContentVersion(v), nil
+}
+
+func (s *Store) compareAndSwap(c *conn, k string, v []byte, version string) (bool, error) {
+	replies, err := c.do([]interface{}{"WATCH", s.prefix + k}, []interface{}{"GET", s.prefix + k})
+	if err != nil {
+		return false, err
+	}
+
+	if err = firstError(replies); err != nil {
+		return false, err
+	}
+
+	current, ok := replies[1].([]byte)
+	if !ok {
+		return false, fmt.Errorf("unexpected reply %T", replies[1])
+	}
+
+	if current == nil && version != "" || current != nil && storage.ContentVersion(current) != version {
+		_, err = c.do([]interface{}{"UNWATCH"})
+
+		return false, err
+	}
+
+	replies, err = c.do([]interface{}{"MULTI"}, s.setCommand(k, v), []interface{}{"EXEC"})
+	if err != nil {
+		return false, err
+	}
+
+	if err = firstError(replies); err != nil {
+		return false, err
+	}
+
+	// EXEC replies nil when the transaction is aborted because the record was modified
+	results, ok := replies[len(replies)-1].([]interface{})
+	if !ok || results == nil {
+		return false, nil
+	}
+
+	return true, firstError(results)
+}
+
+// Iterator returns iterator for the records of the store in the given key range, the end key being excluded.
+// The keys of the store are scanned, which is meant for stores holding a limited number of records.
+func (s *Store) Iterator(start, limit string) storage.StoreIterator {
+	limit = strings.ReplaceAll(limit, storage.EndKeySuffix, "~")
+
+	keys, err := s.scan(func(k string) bool {
+		return k >= start && k < limit
+	})
+	if err != nil || len(keys) == 0 {
+		return mem.NewMemIterator(nil, err)
+	}
+
+	sort.Strings(keys)
+
+	args := []interface{}{"MGET"}
+	for _, k := range keys {
+		args = append(args, s.prefix+k)
+	}
+
+	replies, err := s.provider.do(args)
+	if err != nil {
+		return mem.NewMemIterator(nil, fmt.Errorf("failed to get records: %w", err))
+	}
+
+	values, ok := replies[0].([]interface{})
+	if !ok || len(values) != len(keys) {
+		return mem.NewMemIterator(nil, fmt.Errorf("unexpected reply %v", replies[0]))
+	}
+
+	var batch [][]string
+
+	for i, k := range keys {
+		// the record expired since the keys were scanned
+		if v, ok := values[i].([]byte); ok && v != nil {
+			batch = append(batch, []string{k, string(v)})
+		}
+	}
+
+	return mem.NewMemIterator(batch, nil)
+}
+
+// Delete will delete record with k key.
+func (s *Store) Delete(k string) error {
+	if k == "" {
+		return errors.New("key is mandatory")
+	}
+
+	replies, err := s.provider.do([]interface{}{"DEL", s.prefix + k})
+	if err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return firstError(replies)
+}
+
+// setCommand returns the command storing the record, which expires after the time to live of the store.
+func (s *Store) setCommand(k string, v []byte) []interface{} {
+	return s.setWithTTLCommand(k, v, s.ttl)
+}
+
+func (s *Store) setWithTTLCommand(k string, v []byte, ttl time.Duration) []interface{} {
+	cmd := []interface{}{"SET", s.prefix + k, v}
+	if ttl > 0 {
+		cmd = append(cmd, "PX", ttl.Milliseconds())
+	}
+
+	return cmd
+}
+
+// scan returns the keys of the store, without the store prefix, accepted by the filter.
+func (s *Store) scan(filter func(string) bool) ([]string, error) {
+	var (
+		keys   []string
+		cursor = "0"
+	)
+
+	for {
+		replies, err := s.provider.do([]interface{}{
+			"SCAN", cursor, "MATCH", escapePattern(s.prefix) + "*", "COUNT", scanCount,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan keys: %w", err)
+		}
+
+		reply, ok := replies[0].([]interface{})
+		if !ok || len(reply) != 2 {
+			return nil, fmt.Errorf("failed to scan keys: unexpected reply %v", replies[0])
+		}
+
+		next, ok := reply[0].([]byte)
+		if !ok {
+			return nil, fmt.Errorf("failed to scan keys: unexpected cursor %v", reply[0])
+		}
+
+		items, _ := reply[1].([]interface{}) //nolint:errcheck
+
+		for _, item := range items {
+			if b, ok := item.([]byte); ok {
+				if k := strings.TrimPrefix(string(b), s.prefix); filter(k) {
+					keys = append(keys, k)
+				}
+			}
+		}
+
+		if cursor = string(next); cursor == "0" {
+			return keys, nil
+		}
+	}
+}
+
+// escapePattern escapes the glob-style special characters of the SCAN pattern.
+func escapePattern(s string) string {
+	var sb strings.Builder
+
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			sb.WriteRune('\\')
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+func firstError(replies []interface{}) error {
+	for _, reply := range replies {
+		if err, ok := reply.(Error); ok {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,644 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const testPassword = "secret"
+
+func TestRedisStore(t *testing.T) {
+	t.Run("Test redis store put and get", func(t *testing.T) {
+		prov := newTestProvider(t)
+
+		store, err := prov.OpenStore("Test")
+		require.NoError(t, err)
+
+		const key = "did:example:123"
+		data := []byte("value")
+
+		require.NoError(t, store.Put(key, data))
+
+		doc, err := store.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, data, doc)
+
+		// test update
+		data = []byte(`{"key1":"value1"}`)
+		require.NoError(t, store.Put(key, data))
+
+		doc, err = store.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, data, doc)
+
+		_, err = store.Get("did:example:789")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		// the store names are case insensitive
+		store2, err := prov.OpenStore("test")
+		require.NoError(t, err)
+		require.Equal(t, store, store2)
+
+		// the stores don't share their records
+		store3, err := prov.OpenStore("other")
+		require.NoError(t, err)
+
+		_, err = store3.Get(key)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.EqualError(t, store.Put("", data), "key and value are mandatory")
+		require.EqualError(t, store.Put(key, nil), "key and value are mandatory")
+		_, err = store.Get("")
+		require.EqualError(t, err, "key is mandatory")
+	})
+
+	t.Run("Test redis store put all", func(t *testing.T) {
+		prov := newTestProvider(t)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		redisStore, ok := store.(*Store)
+		require.True(t, ok)
+
+		require.NoError(t, redisStore.PutAll(map[string][]byte{"k1": []byte("v1"), "k2": []byte("v2")}))
+
+		for k, v := range map[string]string{"k1": "v1", "k2": "v2"} {
+			doc, err := store.Get(k)
+			require.NoError(t, err)
+			require.Equal(t, v, string(doc))
+		}
+	})
+
+	t.Run("Test redis store batch", func(t *testing.T) {
+		prov := newTestProvider(t)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		err = store.Batch([]storage.Operation{
+			{Key: "k2", Value: []byte("v2")},
+			{Key: "k1"},
+		})
+		require.NoError(t, err)
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		doc, err := store.Get("k2")
+		require.NoError(t, err)
+		require.Equal(t, "v2", string(doc))
+
+		require.EqualError(t, store.Batch([]storage.Operation{{Key: ""}}), "key is mandatory")
+	})
+
+	t.Run("Test redis store delete", func(t *testing.T) {
+		prov := newTestProvider(t)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, store.Delete("k1"))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.EqualError(t, store.Delete(""), "key is mandatory")
+	})
+
+	t.Run("Test redis store iterator", func(t *testing.T) {
+		prov := newTestProvider(t)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		other, err := prov.OpenStore("test-other")
+		require.NoError(t, err)
+
+		for _, k := range []string{"abc_1", "abc_2", "abc_3", "abd_1", "xyz_1"} {
+			require.NoError(t, store.Put(k, []byte("value-"+k)))
+		}
+
+		require.NoError(t, other.Put("abc_4", []byte("value")))
+
+		itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+
+		var keys []string
+
+		for itr.Next() {
+			keys = append(keys, string(itr.Key()))
+			require.Equal(t, "value-"+string(itr.Key()), string(itr.Value()))
+		}
+
+		require.NoError(t, itr.Error())
+		require.Equal(t, []string{"abc_1", "abc_2", "abc_3"}, keys)
+
+		itr = store.Iterator("xyz_2", "xyz_"+storage.EndKeySuffix)
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+	})
+
+	t.Run("Test redis store ttl", func(t *testing.T) {
+		prov := newTestProvider(t, WithTTL(time.Hour), WithStoreTTL("Nonces", 50*time.Millisecond))
+
+		nonces, err := prov.OpenStore("nonces")
+		require.NoError(t, err)
+
+		durable, err := prov.OpenStore("sessions")
+		require.NoError(t, err)
+
+		require.NoError(t, nonces.Put("k1", []byte("v1")))
+		require.NoError(t, durable.Put("k1", []byte("v1")))
+
+		_, err = nonces.Get("k1")
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, err = nonces.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = durable.Get("k1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Test redis store put with ttl", func(t *testing.T) {
+		prov := newTestProvider(t, WithTTL(time.Hour))
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTTL(store, "k1", []byte("v1"), 50*time.Millisecond))
+		require.NoError(t, store.Put("k2", []byte("v2")))
+
+		_, err = store.Get("k1")
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = store.Get("k2")
+		require.NoError(t, err)
+
+		rs, ok := store.(*Store)
+		require.True(t, ok)
+
+		require.EqualError(t, rs.PutWithTTL("", []byte("v1"), time.Second), "key and value are mandatory")
+		require.EqualError(t, rs.PutWithTTL("k1", []byte("v1"), 0), "ttl must be positive")
+	})
+
+	t.Run("Test redis store put if version", func(t *testing.T) {
+		srv := newTestServer(t)
+		prov, err := NewProvider(srv.addr())
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		version, err := storage.PutIfVersion(store, "k1", []byte("v1"), "")
+		require.NoError(t, err)
+
+		// the record already exists
+		_, err = storage.PutIfVersion(store, "k1", []byte("v2"), "")
+		require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+		// the record was updated
+		require.NoError(t, store.Put("k1", []byte("v1-updated")))
+
+		_, err = storage.PutIfVersion(store, "k1", []byte("v2"), version)
+		require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+		doc, version, err := storage.GetWithVersion(store, "k1")
+		require.NoError(t, err)
+		require.Equal(t, "v1-updated", string(doc))
+
+		// the record is updated while the transaction is performed
+		srv.mu.Lock()
+		srv.beforeExec = func() {
+			srv.records["test:k1"] = testRecord{value: []byte("v1-concurrent")}
+			srv.writes["test:k1"]++
+		}
+		srv.mu.Unlock()
+
+		_, err = storage.PutIfVersion(store, "k1", []byte("v2"), version)
+		require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+		srv.mu.Lock()
+		srv.beforeExec = nil
+		srv.mu.Unlock()
+
+		doc, version, err = storage.GetWithVersion(store, "k1")
+		require.NoError(t, err)
+		require.Equal(t, "v1-concurrent", string(doc))
+
+		version, err = storage.PutIfVersion(store, "k1", []byte("v2"), version)
+		require.NoError(t, err)
+
+		doc, current, err := storage.GetWithVersion(store, "k1")
+		require.NoError(t, err)
+		require.Equal(t, "v2", string(doc))
+		require.Equal(t, version, current)
+
+		_, _, err = storage.GetWithVersion(store, "k2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = storage.PutIfVersion(store, "k1", nil, version)
+		require.EqualError(t, err, "key and value are mandatory")
+	})
+
+	t.Run("Test redis store server error", func(t *testing.T) {
+		srv := newTestServer(t)
+		prov, err := NewProvider(srv.addr())
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		srv.failWith("ERR server failure")
+
+		err = store.Put("k1", []byte("v1"))
+		require.EqualError(t, err, "failed to put records: ERR server failure")
+
+		var redisErr Error
+		require.True(t, errors.As(err, &redisErr))
+
+		_, err = store.Get("k1")
+		require.EqualError(t, err, "failed to get record: ERR server failure")
+
+		require.EqualError(t, store.Delete("k1"), "ERR server failure")
+		require.EqualError(t, store.Batch([]storage.Operation{{Key: "k1"}}),
+			"failed to perform batch operations: ERR server failure")
+
+		itr := store.Iterator("k", "k"+storage.EndKeySuffix)
+		require.False(t, itr.Next())
+		require.Contains(t, itr.Error().Error(), "failed to scan keys")
+
+		_, err = storage.PutIfVersion(store, "k1", []byte("v1"), "")
+		require.EqualError(t, err, "failed to put record: ERR server failure")
+	})
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("authenticates the connections", func(t *testing.T) {
+		srv := newTestServer(t)
+
+		_, err := NewProvider(srv.addr(), WithPassword("invalid"))
+		require.EqualError(t, err,
+			"failed to connect to redis server: failed to initialize connection: WRONGPASS invalid password")
+
+		prov, err := NewProvider(srv.addr(), WithPassword(testPassword), WithDB(1), WithPoolSize(1),
+			WithTimeout(time.Second, time.Second))
+		require.NoError(t, err)
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("fails to connect", func(t *testing.T) {
+		_, err := NewProvider("127.0.0.1:1", WithTimeout(100*time.Millisecond, time.Second))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to connect to redis server")
+	})
+
+	t.Run("reconnects after a broken connection", func(t *testing.T) {
+		srv := newTestServer(t)
+
+		prov, err := NewProvider(srv.addr(), WithPassword(testPassword))
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		srv.closeConns()
+
+		require.Error(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, store.Put("k1", []byte("v1")))
+	})
+
+	t.Run("close", func(t *testing.T) {
+		prov := newTestProvider(t)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, prov.CloseStore("test"))
+		require.NoError(t, prov.Close())
+		require.NoError(t, prov.Close())
+
+		_, err = prov.OpenStore("test")
+		require.EqualError(t, err, errProviderClosed.Error())
+
+		require.True(t, errors.Is(store.Put("k1", []byte("v1")), errProviderClosed))
+
+		_, err = storage.PutIfVersion(store, "k1", []byte("v1"), "")
+		require.True(t, errors.Is(err, errProviderClosed))
+	})
+}
+
+func TestEscapePattern(t *testing.T) {
+	require.Equal(t, `store:`, escapePattern("store:"))
+	require.Equal(t, `a\*b\?c\[d\]e\\`, escapePattern(`a*b?c[d]e\`))
+}
+
+func newTestProvider(t *testing.T, opts ...Option) *Provider {
+	t.Helper()
+
+	prov, err := NewProvider(newTestServer(t).addr(), append([]Option{WithPassword(testPassword)}, opts...)...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, prov.Close())
+	})
+
+	return prov
+}
+
+type testRecord struct {
+	value    []byte
+	expireAt time.Time
+}
+
+// testServer is a minimal in-memory redis server supporting the commands used by the provider.
+type testServer struct {
+	listener net.Listener
+	records  map[string]testRecord
+	// writes counts the writes of each key, to abort the transactions watching them
+	writes     map[string]int
+	beforeExec func()
+	conns      []net.Conn
+	failure    string
+	mu         sync.Mutex
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &testServer{listener: listener, records: map[string]testRecord{}, writes: map[string]int{}}
+
+	go srv.serve()
+
+	t.Cleanup(func() {
+		require.NoError(t, listener.Close())
+		srv.closeConns()
+	})
+
+	return srv
+}
+
+func (s *testServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *testServer) failWith(failure string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failure = failure
+}
+
+func (s *testServer) closeConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.conns {
+		_ = c.Close() //nolint:errcheck
+	}
+
+	s.conns = nil
+}
+
+func (s *testServer) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns = append(s.conns, c)
+		s.mu.Unlock()
+
+		go s.handle(c)
+	}
+}
+
+func (s *testServer) handle(c net.Conn) {
+	r, w := bufio.NewReader(c), bufio.NewWriter(c)
+
+	var (
+		multi   bool
+		queued  [][]string
+		watched = map[string]int{}
+	)
+
+	for {
+		cmd, err := readReply(r)
+		if err != nil {
+			return
+		}
+
+		items, ok := cmd.([]interface{})
+		if !ok {
+			return
+		}
+
+		args := make([]string, len(items))
+		for i, item := range items {
+			args[i] = string(item.([]byte))
+		}
+
+		var reply string
+
+		s.mu.Lock()
+
+		switch {
+		case strings.EqualFold(args[0], "MULTI"):
+			multi, reply = true, "+OK\r\n"
+		case strings.EqualFold(args[0], "WATCH"):
+			for _, k := range args[1:] {
+				watched[k] = s.writes[k]
+			}
+
+			reply = "+OK\r\n"
+		case strings.EqualFold(args[0], "UNWATCH"):
+			watched, reply = map[string]int{}, "+OK\r\n"
+		case strings.EqualFold(args[0], "EXEC"):
+			reply = s.execQueued(queued, watched)
+			multi, queued, watched = false, nil, map[string]int{}
+		case multi:
+			queued, reply = append(queued, args), "+QUEUED\r\n"
+		default:
+			reply = s.exec(args)
+		}
+
+		s.mu.Unlock()
+
+		if _, err = w.WriteString(reply); err != nil {
+			return
+		}
+
+		if err = w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// execQueued executes the commands of the transaction, unless one of the watched keys was written.
+func (s *testServer) execQueued(queued [][]string, watched map[string]int) string {
+	if s.beforeExec != nil {
+		s.beforeExec()
+	}
+
+	for k, writes := range watched {
+		if s.writes[k] != writes {
+			return "*-1\r\n"
+		}
+	}
+
+	reply := fmt.Sprintf("*%d\r\n", len(queued))
+	for _, q := range queued {
+		reply += s.exec(q)
+	}
+
+	return reply
+}
+
+func (s *testServer) exec(args []string) string { // nolint:gocyclo
+	if s.failure != "" {
+		return "-" + s.failure + "\r\n"
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "AUTH":
+		if args[1] != testPassword {
+			return "-WRONGPASS invalid password\r\n"
+		}
+
+		return "+OK\r\n"
+	case "PING":
+		return "+PONG\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "SET":
+		record := testRecord{value: []byte(args[2])}
+
+		if len(args) == 5 && strings.EqualFold(args[3], "PX") {
+			ms, err := strconv.Atoi(args[4])
+			if err != nil {
+				return "-ERR value is not an integer\r\n"
+			}
+
+			record.expireAt = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+
+		s.records[args[1]] = record
+		s.writes[args[1]]++
+
+		return "+OK\r\n"
+	case "GET":
+		return bulkString(s.get(args[1]))
+	case "MGET":
+		reply := fmt.Sprintf("*%d\r\n", len(args)-1)
+		for _, k := range args[1:] {
+			reply += bulkString(s.get(k))
+		}
+
+		return reply
+	case "DEL":
+		_, ok := s.records[args[1]]
+		delete(s.records, args[1])
+		s.writes[args[1]]++
+
+		if ok {
+			return ":1\r\n"
+		}
+
+		return ":0\r\n"
+	case "SCAN":
+		return s.scan(args)
+	}
+
+	return "-ERR unknown command\r\n"
+}
+
+func (s *testServer) get(k string) []byte {
+	record, ok := s.records[k]
+	if !ok {
+		return nil
+	}
+
+	if !record.expireAt.IsZero() && time.Now().After(record.expireAt) {
+		delete(s.records, k)
+		return nil
+	}
+
+	return record.value
+}
+
+// scan returns the matching keys two at a time, the cursor being the index of the next key.
+func (s *testServer) scan(args []string) string {
+	cursor, err := strconv.Atoi(args[1])
+	if err != nil {
+		return "-ERR invalid cursor\r\n"
+	}
+
+	keys := make([]string, 0, len(s.records))
+	for k := range s.records {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	next, page := 0, keys[cursor:]
+	if len(page) > 2 {
+		next, page = cursor+2, page[:2]
+	}
+
+	var matched []string
+
+	for _, k := range page {
+		if ok, _ := path.Match(args[3], k); ok { //nolint:errcheck
+			matched = append(matched, k)
+		}
+	}
+
+	reply := fmt.Sprintf("*2\r\n%s*%d\r\n", bulkString([]byte(strconv.Itoa(next))), len(matched))
+	for _, k := range matched {
+		reply += bulkString([]byte(k))
+	}
+
+	return reply
+}
+
+func bulkString(b []byte) string {
+	if b == nil {
+		return "$-1\r\n"
+	}
+
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(b), b)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// RESP (REdis Serialization Protocol) reply type prefixes.
+const (
+	simpleStringPrefix = '+'
+	errorPrefix        = '-'
+	integerPrefix      = ':'
+	bulkStringPrefix   = '$'
+	arrayPrefix        = '*'
+)
+
+// Error is an error reply of the Redis server.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// conn is a connection to the Redis server speaking RESP. Commands are pipelined: they are all written before
+// their replies are read.
+type conn struct {
+	netConn net.Conn
+	r       *bufio.Reader
+	w       *bufio.Writer
+	timeout time.Duration
+}
+
+func newConn(netConn net.Conn, timeout time.Duration) *conn {
+	return &conn{
+		netConn: netConn,
+		r:       bufio.NewReader(netConn),
+		w:       bufio.NewWriter(netConn),
+		timeout: timeout,
+	}
+}
+
+// do sends the commands in a single round trip and returns their replies. The error replies of the server are
+// returned as Error values within the replies; the returned error is set when the connection is broken.
+func (c *conn) do(cmds ...[]interface{}) ([]interface{}, error) {
+	if c.timeout > 0 {
+		if err := c.netConn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+			return nil, fmt.Errorf("set deadline: %w", err)
+		}
+	}
+
+	for _, cmd := range cmds {
+		if err := writeCommand(c.w, cmd...); err != nil {
+			return nil, fmt.Errorf("write command: %w", err)
+		}
+	}
+
+	if err := c.w.Flush(); err != nil {
+		return nil, fmt.Errorf("flush commands: %w", err)
+	}
+
+	replies := make([]interface{}, len(cmds))
+
+	for i := range cmds {
+		reply, err := readReply(c.r)
+		if err != nil {
+			return nil, fmt.Errorf("read reply: %w", err)
+		}
+
+		replies[i] = reply
+	}
+
+	return replies, nil
+}
+
+func (c *conn) close() error {
+	return c.netConn.Close()
+}
+
+// writeCommand writes the command as an array of bulk strings.
+func writeCommand(w *bufio.Writer, args ...interface{}) error {
+	if _, err := fmt.Fprintf(w, "%c%d\r\n", arrayPrefix, len(args)); err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		var b []byte
+
+		switch v := arg.(type) {
+		case string:
+			b = []byte(v)
+		case []byte:
+			b = v
+		case int:
+			b = []byte(strconv.Itoa(v))
+		case int64:
+			b = []byte(strconv.FormatInt(v, 10))
+		default:
+			return fmt.Errorf("unsupported argument type %T", arg)
+		}
+
+		if _, err := fmt.Fprintf(w, "%c%d\r\n", bulkStringPrefix, len(b)); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(append(b, '\r', '\n')); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readReply reads a reply, which is a string, an Error, an int64, a []byte (nil for the null bulk string)
+// or a []interface{} (nil for the null array).
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) == 0 {
+		return nil, errors.New("empty reply")
+	}
+
+	switch line[0] {
+	case simpleStringPrefix:
+		return string(line[1:]), nil
+	case errorPrefix:
+		return Error(line[1:]), nil
+	case integerPrefix:
+		return strconv.ParseInt(string(line[1:]), 10, 64)
+	case bulkStringPrefix:
+		return readBulkString(r, line[1:])
+	case arrayPrefix:
+		return readArray(r, line[1:])
+	}
+
+	return nil, fmt.Errorf("unexpected reply type %q", line[0])
+}
+
+func readBulkString(r *bufio.Reader, header []byte) (interface{}, error) {
+	n, err := strconv.Atoi(string(header))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bulk string length: %w", err)
+	}
+
+	if n < 0 {
+		return []byte(nil), nil
+	}
+
+	b := make([]byte, n+2)
+
+	if _, err = io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	return b[:n], nil
+}
+
+func readArray(r *bufio.Reader, header []byte) (interface{}, error) {
+	n, err := strconv.Atoi(string(header))
+	if err != nil {
+		return nil, fmt.Errorf("invalid array length: %w", err)
+	}
+
+	if n < 0 {
+		return []interface{}(nil), nil
+	}
+
+	items := make([]interface{}, n)
+
+	for i := range items {
+		if items[i], err = readReply(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, errors.New("malformed reply line")
+	}
+
+	return line[:len(line)-2], nil
+}
@@ -6,7 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package storage
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // EndKeySuffix end key suffix.
 const EndKeySuffix = "!!"
@@ -49,6 +52,37 @@ type Store interface {
 
 	// Delete will delete a record with k key
 	Delete(k string) error
+
+	// Batch performs the put and delete operations in order. The stores supporting it apply the operations
+	// atomically, the others apply them one by one (see ApplyOperations).
+	Batch(operations []Operation) error
+}
+
+// Operation is a write operation of a batch: the record with the key is deleted when Value is nil,
+// otherwise Value is stored under the key.
+type Operation struct {
+	Key   string
+	Value []byte
+}
+
+// ApplyOperations performs the batch operations one by one on the store, for the stores not supporting batches.
+// The operations preceding a failed operation are not rolled back.
+func ApplyOperations(store Store, operations []Operation) error {
+	for _, op := range operations {
+		var err error
+
+		if op.Value == nil {
+			err = store.Delete(op.Key)
+		} else {
+			err = store.Put(op.Key, op.Value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to apply operation on key %s: %w", op.Key, err)
+		}
+	}
+
+	return nil
 }
 
 // StoreIterator is the iterator for the latest snapshot of the underlying store.
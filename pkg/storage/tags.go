@@ -0,0 +1,49 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import "errors"
+
+// ErrTagsNotSupported is returned when a record is tagged in a store not supporting it.
+var ErrTagsNotSupported = errors.New("store does not support record tags")
+
+// TaggedStore is implemented by the stores indexing their records by tag (see the tagged storage wrapper), so that
+// all the records related to something (e.g a connection or a subject) are deleted at once.
+type TaggedStore interface {
+	// PutWithTags stores the key and the record, indexed by the tags
+	PutWithTags(k string, v []byte, tags ...string) error
+
+	// DeleteByTag deletes all the records having the tag
+	DeleteByTag(tag string) error
+}
+
+// PutWithTags stores the key and the record in the store, the record being deleted along with the other records
+// having one of its tags by DeleteByTag. The record is put in any store when there are no tags.
+// ErrTagsNotSupported is returned if the store is not a TaggedStore.
+func PutWithTags(store Store, k string, v []byte, tags ...string) error {
+	if len(tags) == 0 {
+		return store.Put(k, v)
+	}
+
+	s, ok := store.(TaggedStore)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+
+	return s.PutWithTags(k, v, tags...)
+}
+
+// DeleteByTag deletes all the records of the store having the tag. ErrTagsNotSupported is returned if the store is
+// not a TaggedStore.
+func DeleteByTag(store Store, tag string) error {
+	s, ok := store.(TaggedStore)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+
+	return s.DeleteByTag(tag)
+}
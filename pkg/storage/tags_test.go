@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+type taggedStore struct {
+	storage.Store
+	tags    []string
+	deleted string
+}
+
+func (s *taggedStore) PutWithTags(k string, v []byte, tags ...string) error {
+	s.tags = tags
+
+	return s.Put(k, v)
+}
+
+func (s *taggedStore) DeleteByTag(tag string) error {
+	s.deleted = tag
+
+	return nil
+}
+
+func TestTags(t *testing.T) {
+	store := mockstorage.NewMockStoreProvider().Store
+
+	err := storage.PutWithTags(store, "k1", []byte("v1"), "tag1")
+	require.True(t, errors.Is(err, storage.ErrTagsNotSupported))
+	require.True(t, errors.Is(storage.DeleteByTag(store, "tag1"), storage.ErrTagsNotSupported))
+
+	// the records without tags are put in any store
+	require.NoError(t, storage.PutWithTags(store, "k1", []byte("v1")))
+
+	v, err := store.Get("k1")
+	require.NoError(t, err)
+	require.Equal(t, "v1", string(v))
+
+	tagged := &taggedStore{Store: store}
+
+	require.NoError(t, storage.PutWithTags(tagged, "k2", []byte("v2"), "tag1", "tag2"))
+	require.Equal(t, []string{"tag1", "tag2"}, tagged.tags)
+
+	require.NoError(t, storage.DeleteByTag(tagged, "tag1"))
+	require.Equal(t, "tag1", tagged.deleted)
+}
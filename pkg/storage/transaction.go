@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrTransactionDone is returned when the transaction is used after it was committed or rolled back.
+var ErrTransactionDone = errors.New("transaction has already been committed or rolled back")
+
+// Transactional is implemented by the stores supporting transactions natively (e.g SQL or MongoDB stores).
+type Transactional interface {
+	// Begin begins a new transaction on the store
+	Begin() (Transaction, error)
+}
+
+// Transaction groups the updates of several records, which are all applied on commit or all discarded on rollback.
+type Transaction interface {
+	// Put stores the key and the record within the transaction
+	Put(k string, v []byte) error
+
+	// Get fetches the record based on key, including the updates made within the transaction
+	Get(k string) ([]byte, error)
+
+	// Delete will delete a record with k key within the transaction
+	Delete(k string) error
+
+	// Commit applies the updates made within the transaction
+	Commit() error
+
+	// Rollback discards the updates made within the transaction
+	Rollback() error
+}
+
+// Begin begins a transaction on the store. The transaction of the store is returned if the store is Transactional,
+// otherwise the transaction is emulated: the updates are kept in memory until they are applied with a single
+// Batch on commit. The emulated transactions are as atomic as the Batch of the store and, unlike the native ones,
+// don't isolate the updates made outside of the transaction.
+func Begin(store Store) (Transaction, error) {
+	if s, ok := store.(Transactional); ok {
+		return s.Begin()
+	}
+
+	return &batchTransaction{store: store, updates: make(map[string]int)}, nil
+}
+
+// batchTransaction emulates a transaction with a batch of operations.
+type batchTransaction struct {
+	store      Store
+	operations []Operation
+	// updates maps the updated keys to the index of their latest operation
+	updates map[string]int
+	done    bool
+	lock    sync.RWMutex
+}
+
+func (t *batchTransaction) Put(k string, v []byte) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	return t.add(Operation{Key: k, Value: v})
+}
+
+func (t *batchTransaction) Get(k string) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.done {
+		return nil, ErrTransactionDone
+	}
+
+	if i, ok := t.updates[k]; ok {
+		if t.operations[i].Value == nil {
+			return nil, ErrDataNotFound
+		}
+
+		return t.operations[i].Value, nil
+	}
+
+	return t.store.Get(k)
+}
+
+func (t *batchTransaction) Delete(k string) error {
+	if k == "" {
+		return errors.New("key is mandatory")
+	}
+
+	return t.add(Operation{Key: k})
+}
+
+func (t *batchTransaction) Commit() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.done {
+		return ErrTransactionDone
+	}
+
+	t.done = true
+
+	if len(t.operations) == 0 {
+		return nil
+	}
+
+	return t.store.Batch(t.operations)
+}
+
+func (t *batchTransaction) Rollback() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.done {
+		return ErrTransactionDone
+	}
+
+	t.done = true
+	t.operations = nil
+
+	return nil
+}
+
+func (t *batchTransaction) add(op Operation) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.done {
+		return ErrTransactionDone
+	}
+
+	t.updates[op.Key] = len(t.operations)
+	t.operations = append(t.operations, op)
+
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+type transactionalStore struct {
+	storage.Store
+	tx storage.Transaction
+}
+
+func (s *transactionalStore) Begin() (storage.Transaction, error) {
+	return s.tx, nil
+}
+
+func TestBegin(t *testing.T) {
+	t.Run("commit", func(t *testing.T) {
+		store, err := mem.NewProvider().OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, store.Put("k2", []byte("v2")))
+
+		tx, err := storage.Begin(store)
+		require.NoError(t, err)
+
+		require.NoError(t, tx.Put("k3", []byte("v3")))
+		require.NoError(t, tx.Delete("k1"))
+
+		// the updates are visible within the transaction only
+		v, err := tx.Get("k3")
+		require.NoError(t, err)
+		require.Equal(t, "v3", string(v))
+
+		v, err = tx.Get("k2")
+		require.NoError(t, err)
+		require.Equal(t, "v2", string(v))
+
+		_, err = tx.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = store.Get("k3")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, tx.Commit())
+
+		v, err = store.Get("k3")
+		require.NoError(t, err)
+		require.Equal(t, "v3", string(v))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.True(t, errors.Is(tx.Commit(), storage.ErrTransactionDone))
+		require.True(t, errors.Is(tx.Rollback(), storage.ErrTransactionDone))
+		require.True(t, errors.Is(tx.Put("k4", []byte("v4")), storage.ErrTransactionDone))
+
+		_, err = tx.Get("k3")
+		require.True(t, errors.Is(err, storage.ErrTransactionDone))
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		store, err := mem.NewProvider().OpenStore("test")
+		require.NoError(t, err)
+
+		tx, err := storage.Begin(store)
+		require.NoError(t, err)
+
+		require.NoError(t, tx.Put("k1", []byte("v1")))
+		require.NoError(t, tx.Rollback())
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.True(t, errors.Is(tx.Delete("k1"), storage.ErrTransactionDone))
+	})
+
+	t.Run("invalid operations", func(t *testing.T) {
+		tx, err := storage.Begin(mockstorage.NewMockStoreProvider().Store)
+		require.NoError(t, err)
+
+		require.EqualError(t, tx.Put("", []byte("v1")), "key and value are mandatory")
+		require.EqualError(t, tx.Put("k1", nil), "key and value are mandatory")
+		require.EqualError(t, tx.Delete(""), "key is mandatory")
+
+		// nothing to commit
+		require.NoError(t, tx.Commit())
+	})
+
+	t.Run("commit error", func(t *testing.T) {
+		store := mockstorage.NewMockStoreProvider().Store
+		store.ErrBatch = errors.New("batch error")
+
+		tx, err := storage.Begin(store)
+		require.NoError(t, err)
+
+		require.NoError(t, tx.Put("k1", []byte("v1")))
+		require.EqualError(t, tx.Commit(), "batch error")
+	})
+
+	t.Run("native transaction", func(t *testing.T) {
+		nativeTx, err := storage.Begin(mockstorage.NewMockStoreProvider().Store)
+		require.NoError(t, err)
+
+		tx, err := storage.Begin(&transactionalStore{tx: nativeTx})
+		require.NoError(t, err)
+		require.Equal(t, nativeTx, tx)
+	})
+}
+
+func TestApplyOperations(t *testing.T) {
+	store := mockstorage.NewMockStoreProvider().Store
+	require.NoError(t, store.Put("k1", []byte("v1")))
+
+	err := storage.ApplyOperations(store, []storage.Operation{{Key: "k2", Value: []byte("v2")}, {Key: "k1"}})
+	require.NoError(t, err)
+
+	_, err = store.Get("k1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	v, err := store.Get("k2")
+	require.NoError(t, err)
+	require.Equal(t, "v2", string(v))
+
+	store.ErrPut = errors.New("put error")
+
+	err = storage.ApplyOperations(store, []storage.Operation{{Key: "k3", Value: []byte("v3")}})
+	require.EqualError(t, err, "failed to apply operation on key k3: put error")
+}
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// ErrVersioningNotSupported is returned when the version of a record is used with a store not supporting it.
+var ErrVersioningNotSupported = errors.New("store does not support record versions")
+
+// ErrVersionMismatch is returned when a record is put with a version which is not the current version of the
+// record, i.e the record was updated (or created, or deleted) since the version was read.
+var ErrVersionMismatch = errors.New("record version mismatch")
+
+// VersionedStore is implemented by the stores supporting optimistic concurrency control, with the versions of their
+// records (e.g the revisions of CouchDB documents). The versions are opaque strings, changing whenever the record
+// is updated.
+type VersionedStore interface {
+	// GetWithVersion fetches the record based on key and returns its current version
+	GetWithVersion(k string) ([]byte, string, error)
+
+	// PutIfVersion stores the key and the record if the current version of the record is version, an empty version
+	// meaning the record must not exist, and returns the new version of the record
+	PutIfVersion(k string, v []byte, version string) (string, error)
+}
+
+// GetWithVersion fetches the record from the store and returns its current version, to be passed to PutIfVersion
+// when the record is updated. ErrVersioningNotSupported is returned if the store is not a VersionedStore.
+func GetWithVersion(store Store, k string) ([]byte, string, error) {
+	s, ok := store.(VersionedStore)
+	if !ok {
+		return nil, "", ErrVersioningNotSupported
+	}
+
+	return s.GetWithVersion(k)
+}
+
+// PutIfVersion stores the key and the record in the store if the current version of the record is version (compare
+// and swap), an empty version meaning the record must not exist. ErrVersionMismatch is returned when the record was
+// updated concurrently, the caller should then read the record again and retry its update. ErrVersioningNotSupported
+// is returned if the store is not a VersionedStore.
+func PutIfVersion(store Store, k string, v []byte, version string) (string, error) {
+	s, ok := store.(VersionedStore)
+	if !ok {
+		return "", ErrVersioningNotSupported
+	}
+
+	return s.PutIfVersion(k, v, version)
+}
+
+// ContentVersion returns a version of the record derived from its content, for the stores which don't keep versions
+// of their records. Since a record is only put if it has the expected content, such versions are safe to use for
+// compare and swap.
+func ContentVersion(v []byte) string {
+	hash := sha256.Sum256(v)
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,49 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestVersioning(t *testing.T) {
+	t.Run("Test store not supporting versions", func(t *testing.T) {
+		store := mockstorage.NewMockStoreProvider().Store
+
+		_, _, err := storage.GetWithVersion(store, "k1")
+		require.True(t, errors.Is(err, storage.ErrVersioningNotSupported))
+
+		_, err = storage.PutIfVersion(store, "k1", []byte("v1"), "")
+		require.True(t, errors.Is(err, storage.ErrVersioningNotSupported))
+	})
+
+	t.Run("Test versioned store", func(t *testing.T) {
+		store, err := mem.NewProvider().OpenStore("test")
+		require.NoError(t, err)
+
+		version, err := storage.PutIfVersion(store, "k1", []byte("v1"), "")
+		require.NoError(t, err)
+		require.Equal(t, storage.ContentVersion([]byte("v1")), version)
+
+		v, current, err := storage.GetWithVersion(store, "k1")
+		require.NoError(t, err)
+		require.Equal(t, "v1", string(v))
+		require.Equal(t, version, current)
+	})
+}
+
+func TestContentVersion(t *testing.T) {
+	require.Equal(t, storage.ContentVersion([]byte("v1")), storage.ContentVersion([]byte("v1")))
+	require.NotEqual(t, storage.ContentVersion([]byte("v1")), storage.ContentVersion([]byte("v2")))
+}
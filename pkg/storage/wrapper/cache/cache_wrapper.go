@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package cache offers a storage.Provider wrapper caching the records read from the stores of the underlying
+// provider in memory, cutting the latency of the hot records (e.g connection records or DID documents) when the
+// underlying provider is a slow remote one (e.g CouchDB or EDV).
+//
+// The records are cached on read, in a LRU cache per store. The cached records are invalidated when they are written
+// through the wrapper, the wrapper must then be the only writer of the underlying stores. Since the iterators and
+// the counts are served by the underlying stores, they are not cached.
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bluele/gcache"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const defaultSize = 1000
+
+// Option configures the caching provider.
+type Option func(p *Provider)
+
+// WithSize sets the maximum number of records cached for each store (1000 by default), the least recently used
+// records being evicted first.
+func WithSize(size int) Option {
+	return func(p *Provider) {
+		p.size = size
+	}
+}
+
+// WithTTL sets the duration the records stay in the cache, after which they are read again from the underlying
+// store. By default, the records stay in the cache until they are evicted or invalidated.
+func WithTTL(ttl time.Duration) Option {
+	return func(p *Provider) {
+		p.ttl = ttl
+	}
+}
+
+// Provider is a storage provider wrapper caching the records of the stores of the underlying provider.
+type Provider struct {
+	provider storage.Provider
+	size     int
+	ttl      time.Duration
+	clock    gcache.Clock
+	stores   map[string]*cachedStore
+	lock     sync.RWMutex
+}
+
+// NewProvider instantiates a Provider caching the records of the stores of provider.
+func NewProvider(provider storage.Provider, opts ...Option) (*Provider, error) {
+	p := &Provider{
+		provider: provider,
+		size:     defaultSize,
+		clock:    gcache.NewRealClock(),
+		stores:   make(map[string]*cachedStore),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.size <= 0 {
+		return nil, errors.New("cache size must be positive")
+	}
+
+	if p.ttl < 0 {
+		return nil, errors.New("cache ttl must be positive")
+	}
+
+	return p, nil
+}
+
+// OpenStore opens the store with the given name in the underlying provider and returns a caching handle to it.
+// The handles of a store share the same cache.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if store, ok := p.stores[name]; ok {
+		return store, nil
+	}
+
+	store, err := p.provider.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := gcache.New(p.size).LRU().Clock(p.clock)
+	if p.ttl > 0 {
+		builder = builder.Expiration(p.ttl)
+	}
+
+	cached := &cachedStore{store: store, cache: builder.Build()}
+	p.stores[name] = cached
+
+	return cached, nil
+}
+
+// CloseStore closes the store with the given name in the underlying provider and drops its cache.
+func (p *Provider) CloseStore(name string) error {
+	p.lock.Lock()
+	delete(p.stores, name)
+	p.lock.Unlock()
+
+	return p.provider.CloseStore(name)
+}
+
+// Close closes all stores created in the underlying provider and drops their caches.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	p.stores = make(map[string]*cachedStore)
+	p.lock.Unlock()
+
+	return p.provider.Close()
+}
+
+type cachedStore struct {
+	store storage.Store
+	cache gcache.Cache
+	// lock prevents a record read on a cache miss from being cached after a concurrent write invalidated it.
+	lock sync.RWMutex
+}
+
+// Put stores the record in the underlying store and invalidates the cached one.
+func (s *cachedStore) Put(k string, v []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cache.Remove(k)
+
+	return s.store.Put(k, v)
+}
+
+// Get returns the cached record, or reads it from the underlying store and caches it. The records not found are not
+// cached.
+func (s *cachedStore) Get(k string) ([]byte, error) {
+	if v, err := s.cache.Get(k); err == nil {
+		return copyValue(v.([]byte)), nil
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	v, err := s.store.Get(k)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(k, copyValue(v)); err != nil {
+		return nil, fmt.Errorf("failed to cache record: %w", err)
+	}
+
+	return v, nil
+}
+
+// Iterator returns an iterator of the underlying store, the records iterated are not cached.
+func (s *cachedStore) Iterator(start, limit string) storage.StoreIterator {
+	return s.store.Iterator(start, limit)
+}
+
+// Delete deletes the record from the underlying store and invalidates the cached one.
+func (s *cachedStore) Delete(k string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cache.Remove(k)
+
+	return s.store.Delete(k)
+}
+
+// Batch performs the operations in the underlying store and invalidates the cached records they write.
+func (s *cachedStore) Batch(operations []storage.Operation) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, op := range operations {
+		s.cache.Remove(op.Key)
+	}
+
+	return s.store.Batch(operations)
+}
+
+// PutWithTTL puts the record with an expiration if the underlying store supports it, and invalidates the cached
+// one. Note that once read again, the record may be returned from the cache after it expired in the underlying
+// store, until it leaves the cache: the cache TTL should then be short when records expire.
+func (s *cachedStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cache.Remove(k)
+
+	return storage.PutWithTTL(s.store, k, v, ttl)
+}
+
+// GetWithVersion fetches the record and its version from the underlying store if it supports versions, bypassing
+// the cache so the version is current.
+func (s *cachedStore) GetWithVersion(k string) ([]byte, string, error) {
+	return storage.GetWithVersion(s.store, k)
+}
+
+// PutIfVersion puts the record if the underlying store supports versions and has the record at version, and
+// invalidates the cached one.
+func (s *cachedStore) PutIfVersion(k string, v []byte, version string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cache.Remove(k)
+
+	return storage.PutIfVersion(s.store, k, v, version)
+}
+
+// Count counts the records of the key range, natively if the underlying store supports it.
+func (s *cachedStore) Count(startKey, endKey string) (int, error) {
+	return storage.Count(s.store, startKey, endKey)
+}
+
+// copyValue copies the record so the cached one can't be altered by the callers.
+func copyValue(v []byte) []byte {
+	return append([]byte(nil), v...)
+}
@@ -0,0 +1,283 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bluele/gcache"
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/expiring"
+)
+
+var errTest = errors.New("test error")
+
+// setup returns a store of the caching provider and the underlying store, written directly to tell whether the
+// records are read from the cache.
+func setup(t *testing.T, opts ...Option) (storage.Store, storage.Store, *Provider) {
+	t.Helper()
+
+	underlying := mem.NewProvider()
+
+	p, err := NewProvider(underlying, opts...)
+	require.NoError(t, err)
+
+	store, err := p.OpenStore("test")
+	require.NoError(t, err)
+
+	underlyingStore, err := underlying.OpenStore("test")
+	require.NoError(t, err)
+
+	return store, underlyingStore, p
+}
+
+func requireValue(t *testing.T, store storage.Store, k, expected string) {
+	t.Helper()
+
+	v, err := store.Get(k)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(v))
+}
+
+func TestCachedStore(t *testing.T) {
+	t.Run("Test records cached on read", func(t *testing.T) {
+		store, underlyingStore, _ := setup(t)
+
+		require.NoError(t, underlyingStore.Put("k1", []byte("v1")))
+		requireValue(t, store, "k1", "v1")
+
+		// the record is now read from the cache
+		require.NoError(t, underlyingStore.Put("k1", []byte("v2")))
+		requireValue(t, store, "k1", "v1")
+
+		// the records not found are not cached
+		_, err := store.Get("k2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, underlyingStore.Put("k2", []byte("v2")))
+		requireValue(t, store, "k2", "v2")
+
+		// the cached record can't be altered by the callers
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+
+		v[0] = 'x'
+		requireValue(t, store, "k1", "v1")
+	})
+
+	t.Run("Test cached records invalidated on write", func(t *testing.T) {
+		store, underlyingStore, _ := setup(t)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		requireValue(t, store, "k1", "v1")
+
+		require.NoError(t, store.Put("k1", []byte("v2")))
+		requireValue(t, store, "k1", "v2")
+
+		require.NoError(t, store.Delete("k1"))
+
+		_, err := store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, underlyingStore.Put("k1", []byte("v1")))
+		require.NoError(t, underlyingStore.Put("k2", []byte("v2")))
+		requireValue(t, store, "k1", "v1")
+		requireValue(t, store, "k2", "v2")
+
+		require.NoError(t, store.Batch([]storage.Operation{
+			{Key: "k1"},
+			{Key: "k2", Value: []byte("v3")},
+		}))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		requireValue(t, store, "k2", "v3")
+	})
+
+	t.Run("Test cache size", func(t *testing.T) {
+		store, underlyingStore, _ := setup(t, WithSize(2))
+
+		for _, k := range []string{"k1", "k2", "k3"} {
+			require.NoError(t, underlyingStore.Put(k, []byte("v1")))
+			requireValue(t, store, k, "v1")
+			require.NoError(t, underlyingStore.Put(k, []byte("v2")))
+		}
+
+		// the least recently used record was evicted
+		requireValue(t, store, "k1", "v2")
+		requireValue(t, store, "k3", "v1")
+	})
+
+	t.Run("Test cache ttl", func(t *testing.T) {
+		clock := gcache.NewFakeClock()
+
+		store, underlyingStore, _ := setup(t, WithTTL(time.Minute), func(p *Provider) {
+			p.clock = clock
+		})
+
+		require.NoError(t, underlyingStore.Put("k1", []byte("v1")))
+		requireValue(t, store, "k1", "v1")
+
+		require.NoError(t, underlyingStore.Put("k1", []byte("v2")))
+		requireValue(t, store, "k1", "v1")
+
+		clock.Advance(time.Minute + time.Second)
+		requireValue(t, store, "k1", "v2")
+	})
+
+	t.Run("Test iterator and count served by the underlying store", func(t *testing.T) {
+		store, underlyingStore, _ := setup(t)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, underlyingStore.Put("k2", []byte("v2")))
+
+		itr := store.Iterator("k", "k"+storage.EndKeySuffix)
+		defer itr.Release()
+
+		var keys []string
+		for itr.Next() {
+			keys = append(keys, string(itr.Key()))
+		}
+
+		require.NoError(t, itr.Error())
+		require.ElementsMatch(t, []string{"k1", "k2"}, keys)
+
+		count, err := storage.Count(store, "k", "k"+storage.EndKeySuffix)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("Test put with ttl", func(t *testing.T) {
+		store, _, _ := setup(t)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		requireValue(t, store, "k1", "v1")
+
+		require.True(t, errors.Is(storage.PutWithTTL(store, "k1", []byte("v2"), time.Minute),
+			storage.ErrExpirationNotSupported))
+
+		requireValue(t, store, "k1", "v1")
+
+		require.NoError(t, storage.PutWithTTL(store, "k1", []byte("v2"), 0))
+		requireValue(t, store, "k1", "v2")
+
+		expiringProvider := expiring.NewProvider(mem.NewProvider(), expiring.WithSweepInterval(0))
+
+		defer func() {
+			require.NoError(t, expiringProvider.Close())
+		}()
+
+		p, err := NewProvider(expiringProvider)
+		require.NoError(t, err)
+
+		store, err = p.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		requireValue(t, store, "k1", "v1")
+
+		require.NoError(t, storage.PutWithTTL(store, "k1", []byte("v2"), time.Minute))
+		requireValue(t, store, "k1", "v2")
+	})
+
+	t.Run("Test put if version", func(t *testing.T) {
+		store, underlyingStore, _ := setup(t)
+
+		version, err := storage.PutIfVersion(store, "k1", []byte("v1"), "")
+		require.NoError(t, err)
+		requireValue(t, store, "k1", "v1")
+
+		// the version is read from the underlying store
+		require.NoError(t, underlyingStore.Put("k1", []byte("v2")))
+
+		_, current, err := storage.GetWithVersion(store, "k1")
+		require.NoError(t, err)
+		require.NotEqual(t, version, current)
+
+		_, err = storage.PutIfVersion(store, "k1", []byte("v3"), version)
+		require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+		_, err = storage.PutIfVersion(store, "k1", []byte("v3"), current)
+		require.NoError(t, err)
+		requireValue(t, store, "k1", "v3")
+	})
+
+	t.Run("Test underlying store errors", func(t *testing.T) {
+		underlying := mockstorage.NewMockStoreProvider()
+		underlying.Store.ErrGet = errTest
+
+		p, err := NewProvider(underlying)
+		require.NoError(t, err)
+
+		store, err := p.OpenStore("test")
+		require.NoError(t, err)
+
+		_, err = store.Get("k1")
+		require.EqualError(t, err, errTest.Error())
+	})
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("Test store handles share the cache", func(t *testing.T) {
+		underlying := mockstorage.NewMockStoreProvider()
+
+		p, err := NewProvider(underlying)
+		require.NoError(t, err)
+
+		store, err := p.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, underlying.Store.Put("k1", []byte("v1")))
+		requireValue(t, store, "k1", "v1")
+
+		store2, err := p.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store2.Put("k1", []byte("v2")))
+		requireValue(t, store, "k1", "v2")
+
+		// the cache is dropped when the store is closed
+		require.NoError(t, underlying.Store.Put("k1", []byte("v3")))
+		require.NoError(t, p.CloseStore("test"))
+
+		store, err = p.OpenStore("test")
+		require.NoError(t, err)
+		requireValue(t, store, "k1", "v3")
+
+		require.NoError(t, p.Close())
+		require.Empty(t, p.stores)
+	})
+
+	t.Run("Test invalid options", func(t *testing.T) {
+		_, err := NewProvider(mem.NewProvider(), WithSize(0))
+		require.EqualError(t, err, "cache size must be positive")
+
+		_, err = NewProvider(mem.NewProvider(), WithTTL(-time.Second))
+		require.EqualError(t, err, "cache ttl must be positive")
+	})
+
+	t.Run("Test underlying provider errors", func(t *testing.T) {
+		p, err := NewProvider(&mockstorage.MockStoreProvider{
+			Store:              mockstorage.NewMockStoreProvider().Store,
+			ErrOpenStoreHandle: errTest,
+			ErrCloseStore:      errTest,
+			ErrClose:           errTest,
+		})
+		require.NoError(t, err)
+
+		_, err = p.OpenStore("test")
+		require.EqualError(t, err, errTest.Error())
+		require.EqualError(t, p.CloseStore("test"), errTest.Error())
+		require.EqualError(t, p.Close(), errTest.Error())
+	})
+}
@@ -0,0 +1,330 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package compressed offers a storage.Provider wrapper compressing the large values (e.g JSON-LD credentials or DID
+// documents) of any underlying provider, reducing the storage footprint of the credential-heavy wallets.
+//
+// Each value is stored prefixed with a byte telling the algorithm it is compressed with, so the algorithm and the
+// threshold of a store can be changed without affecting the values already stored. The wrapper must be used from
+// the creation of the stores, since the values stored without it have no such prefix.
+package compressed
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+// Algorithm is a compression algorithm, its value prefixes the values it compresses.
+type Algorithm byte
+
+const (
+	// None stores the values uncompressed.
+	None Algorithm = iota
+	// Gzip compresses the values with gzip.
+	Gzip
+	// Zstd compresses the values with Zstandard, faster than gzip for a similar ratio.
+	Zstd
+)
+
+const (
+	defaultThreshold = 1024
+
+	failCompressValue   = "failed to compress value: %w"
+	failDecompressValue = "failed to decompress value: %w"
+)
+
+// Config is the compression configuration of a store.
+type Config struct {
+	// Algorithm compressing the values
+	Algorithm Algorithm
+	// Threshold is the size in bytes from which the values are compressed, the smaller values being stored as is
+	Threshold int
+}
+
+func (c Config) validate() error {
+	if c.Algorithm > Zstd {
+		return fmt.Errorf("unknown compression algorithm %d", c.Algorithm)
+	}
+
+	if c.Threshold < 0 {
+		return errors.New("compression threshold must be positive")
+	}
+
+	return nil
+}
+
+// Option configures the compressing provider.
+type Option func(p *Provider)
+
+// WithConfig sets the compression configuration of the stores (gzip from 1 KB by default).
+func WithConfig(config Config) Option {
+	return func(p *Provider) {
+		p.config = config
+	}
+}
+
+// WithStoreConfig sets the compression configuration of the given store, e.g to disable the compression of a store
+// of small or already compressed values.
+func WithStoreConfig(name string, config Config) Option {
+	return func(p *Provider) {
+		p.storeConfigs[strings.ToLower(name)] = config
+	}
+}
+
+// Provider is a storage provider wrapper compressing the values of the underlying provider.
+type Provider struct {
+	provider     storage.Provider
+	config       Config
+	storeConfigs map[string]Config
+	codec        *codec
+}
+
+// NewProvider instantiates a Provider compressing the values of provider.
+func NewProvider(provider storage.Provider, opts ...Option) (*Provider, error) {
+	p := &Provider{
+		provider:     provider,
+		config:       Config{Algorithm: Gzip, Threshold: defaultThreshold},
+		storeConfigs: make(map[string]Config),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if err := p.config.validate(); err != nil {
+		return nil, err
+	}
+
+	for name, config := range p.storeConfigs {
+		if err := config.validate(); err != nil {
+			return nil, fmt.Errorf("invalid compression configuration of store %s: %w", name, err)
+		}
+	}
+
+	var err error
+
+	if p.codec, err = newCodec(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// OpenStore opens the store with the given name in the underlying provider and returns a compressing handle to it.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	store, err := p.provider.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	config, ok := p.storeConfigs[strings.ToLower(name)]
+	if !ok {
+		config = p.config
+	}
+
+	return &compressedStore{store: store, config: config, codec: p.codec}, nil
+}
+
+// CloseStore closes the store with the given name in the underlying provider.
+func (p *Provider) CloseStore(name string) error {
+	return p.provider.CloseStore(name)
+}
+
+// Close closes all stores created in the underlying provider.
+func (p *Provider) Close() error {
+	p.codec.zstdDecoder.Close()
+
+	return p.provider.Close()
+}
+
+type compressedStore struct {
+	store  storage.Store
+	config Config
+	codec  *codec
+}
+
+// Put compresses the value and stores it in the underlying store.
+func (s *compressedStore) Put(k string, v []byte) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	compressed, err := s.compress(v)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Put(k, compressed)
+}
+
+// Get fetches the value from the underlying store and decompresses it.
+func (s *compressedStore) Get(k string) ([]byte, error) {
+	v, err := s.store.Get(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.codec.decompress(v)
+}
+
+// Iterator returns an iterator over the decompressed records of the given key range.
+func (s *compressedStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	itr := s.store.Iterator(startKey, endKey)
+	defer itr.Release()
+
+	var batch [][]string
+
+	for itr.Next() {
+		v, err := s.codec.decompress(itr.Value())
+		if err != nil {
+			return mem.NewMemIterator(nil, err)
+		}
+
+		batch = append(batch, []string{string(itr.Key()), string(v)})
+	}
+
+	return mem.NewMemIterator(batch, itr.Error())
+}
+
+// Delete deletes the record from the underlying store.
+func (s *compressedStore) Delete(k string) error {
+	return s.store.Delete(k)
+}
+
+// Batch compresses the values of the put operations and performs the batch in the underlying store.
+func (s *compressedStore) Batch(operations []storage.Operation) error {
+	compressedOperations := make([]storage.Operation, len(operations))
+
+	for i, op := range operations {
+		compressedOperations[i] = storage.Operation{Key: op.Key}
+
+		if op.Value != nil {
+			var err error
+
+			if compressedOperations[i].Value, err = s.compress(op.Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.store.Batch(compressedOperations)
+}
+
+// PutWithTTL compresses the value and puts it with an expiration if the underlying store supports it.
+func (s *compressedStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	compressed, err := s.compress(v)
+	if err != nil {
+		return err
+	}
+
+	return storage.PutWithTTL(s.store, k, compressed, ttl)
+}
+
+// Count counts the records of the key range, natively if the underlying store supports it.
+func (s *compressedStore) Count(startKey, endKey string) (int, error) {
+	return storage.Count(s.store, startKey, endKey)
+}
+
+// compress compresses the value with the algorithm of the store if it reaches the threshold.
+func (s *compressedStore) compress(v []byte) ([]byte, error) {
+	if len(v) < s.config.Threshold {
+		return s.codec.compress(None, v)
+	}
+
+	return s.codec.compress(s.config.Algorithm, v)
+}
+
+// codec compresses and decompresses the values, its zstd encoder and decoder are safe for concurrent use.
+type codec struct {
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+}
+
+func newCodec() (*codec, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+
+	return &codec{zstdEncoder: encoder, zstdDecoder: decoder}, nil
+}
+
+// compress compresses the value with the algorithm, prefixing it with the algorithm.
+func (c *codec) compress(algorithm Algorithm, v []byte) ([]byte, error) {
+	switch algorithm {
+	case None:
+		return append([]byte{byte(None)}, v...), nil
+	case Gzip:
+		buf := bytes.NewBuffer([]byte{byte(Gzip)})
+		w := gzip.NewWriter(buf)
+
+		if _, err := w.Write(v); err != nil {
+			return nil, fmt.Errorf(failCompressValue, err)
+		}
+
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf(failCompressValue, err)
+		}
+
+		return buf.Bytes(), nil
+	case Zstd:
+		return c.zstdEncoder.EncodeAll(v, []byte{byte(Zstd)}), nil
+	}
+
+	return nil, fmt.Errorf(failCompressValue, fmt.Errorf("unknown algorithm %d", algorithm))
+}
+
+// decompress decompresses the value with the algorithm of its prefix.
+func (c *codec) decompress(v []byte) ([]byte, error) {
+	if len(v) == 0 {
+		return nil, fmt.Errorf(failDecompressValue, errors.New("missing algorithm"))
+	}
+
+	switch Algorithm(v[0]) {
+	case None:
+		return v[1:], nil
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(v[1:]))
+		if err != nil {
+			return nil, fmt.Errorf(failDecompressValue, err)
+		}
+
+		decompressed, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf(failDecompressValue, err)
+		}
+
+		return decompressed, nil
+	case Zstd:
+		decompressed, err := c.zstdDecoder.DecodeAll(v[1:], nil)
+		if err != nil {
+			return nil, fmt.Errorf(failDecompressValue, err)
+		}
+
+		return decompressed, nil
+	}
+
+	return nil, fmt.Errorf(failDecompressValue, fmt.Errorf("unknown algorithm %d", v[0]))
+}
@@ -0,0 +1,223 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package compressed
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/expiring"
+)
+
+var errTest = errors.New("test error")
+
+// largeValue is a compressible value above the default threshold.
+var largeValue = bytes.Repeat([]byte(`{"@context":"https://www.w3.org/2018/credentials/v1"}`), 100)
+
+func newProvider(t *testing.T, underlying storage.Provider, opts ...Option) *Provider {
+	t.Helper()
+
+	p, err := NewProvider(underlying, opts...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, p.Close())
+	})
+
+	return p
+}
+
+func TestCompressedStore(t *testing.T) {
+	for _, algorithm := range []Algorithm{None, Gzip, Zstd} {
+		underlying := mem.NewProvider()
+
+		store, err := newProvider(t, underlying, WithConfig(Config{Algorithm: algorithm, Threshold: 10})).
+			OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", largeValue))
+		require.NoError(t, store.Put("k2", []byte("small")))
+		require.NoError(t, store.Batch([]storage.Operation{
+			{Key: "k3", Value: largeValue},
+			{Key: "k2"},
+		}))
+
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, largeValue, v)
+
+		_, err = store.Get("k2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		itr := store.Iterator("k", "k"+storage.EndKeySuffix)
+
+		var keys []string
+		for itr.Next() {
+			keys = append(keys, string(itr.Key()))
+			require.Equal(t, largeValue, itr.Value())
+		}
+
+		require.NoError(t, itr.Error())
+		require.Equal(t, []string{"k1", "k3"}, keys)
+
+		count, err := storage.Count(store, "k", "k"+storage.EndKeySuffix)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		underlyingStore, err := underlying.OpenStore("test")
+		require.NoError(t, err)
+
+		stored, err := underlyingStore.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, byte(algorithm), stored[0])
+
+		if algorithm != None {
+			require.Less(t, len(stored), len(largeValue)/10)
+		}
+
+		require.NoError(t, store.Delete("k1"))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	}
+}
+
+func TestCompressedStore_Threshold(t *testing.T) {
+	underlying := mem.NewProvider()
+
+	store, err := newProvider(t, underlying).OpenStore("test")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("k1", []byte("small")))
+	require.NoError(t, store.Put("k2", largeValue))
+
+	underlyingStore, err := underlying.OpenStore("test")
+	require.NoError(t, err)
+
+	stored, err := underlyingStore.Get("k1")
+	require.NoError(t, err)
+	require.Equal(t, append([]byte{byte(None)}, "small"...), stored)
+
+	stored, err = underlyingStore.Get("k2")
+	require.NoError(t, err)
+	require.Equal(t, byte(Gzip), stored[0])
+
+	v, err := store.Get("k1")
+	require.NoError(t, err)
+	require.Equal(t, "small", string(v))
+}
+
+func TestCompressedStore_StoreConfig(t *testing.T) {
+	underlying := mem.NewProvider()
+	p := newProvider(t, underlying, WithStoreConfig("Credentials", Config{Algorithm: Zstd}))
+
+	for name, algorithm := range map[string]Algorithm{"credentials": Zstd, "connections": Gzip} {
+		store, err := p.OpenStore(name)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", largeValue))
+
+		underlyingStore, err := underlying.OpenStore(name)
+		require.NoError(t, err)
+
+		stored, err := underlyingStore.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, byte(algorithm), stored[0])
+	}
+
+	// the values stored with another algorithm are still decompressed
+	store, err := newProvider(t, underlying).OpenStore("credentials")
+	require.NoError(t, err)
+
+	v, err := store.Get("k1")
+	require.NoError(t, err)
+	require.Equal(t, largeValue, v)
+}
+
+func TestCompressedStore_PutWithTTL(t *testing.T) {
+	expiringProvider := expiring.NewProvider(mem.NewProvider(), expiring.WithSweepInterval(0))
+
+	store, err := newProvider(t, expiringProvider).OpenStore("test")
+	require.NoError(t, err)
+
+	require.NoError(t, storage.PutWithTTL(store, "k1", largeValue, time.Minute))
+
+	v, err := store.Get("k1")
+	require.NoError(t, err)
+	require.Equal(t, largeValue, v)
+
+	require.EqualError(t, storage.PutWithTTL(store, "", largeValue, time.Minute), "key and value are mandatory")
+}
+
+func TestCompressedStore_Errors(t *testing.T) {
+	underlying := mockstorage.NewMockStoreProvider()
+
+	store, err := newProvider(t, underlying).OpenStore("test")
+	require.NoError(t, err)
+
+	require.EqualError(t, store.Put("", []byte("v1")), "key and value are mandatory")
+
+	invalidValues := [][]byte{{}, {byte(Zstd + 1)}, {byte(Gzip), 1}, append([]byte{byte(Zstd)}, "invalid frame"...)}
+
+	for _, v := range invalidValues {
+		underlying.Store.Store["k1"] = v
+
+		_, err = store.Get("k1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decompress value")
+
+		itr := store.Iterator("k", "k"+storage.EndKeySuffix)
+		require.False(t, itr.Next())
+		require.Contains(t, itr.Error().Error(), "failed to decompress value")
+	}
+
+	underlying.Store.ErrGet = errTest
+	_, err = store.Get("k1")
+	require.EqualError(t, err, errTest.Error())
+
+	cs, ok := store.(*compressedStore)
+	require.True(t, ok)
+
+	cs.config.Algorithm = Zstd + 1
+	require.EqualError(t, store.Put("k1", largeValue), "failed to compress value: unknown algorithm 3")
+	require.EqualError(t, storage.PutWithTTL(store, "k1", largeValue, time.Minute),
+		"failed to compress value: unknown algorithm 3")
+	require.EqualError(t, store.Batch([]storage.Operation{{Key: "k1", Value: largeValue}}),
+		"failed to compress value: unknown algorithm 3")
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("Test invalid configurations", func(t *testing.T) {
+		_, err := NewProvider(mem.NewProvider(), WithConfig(Config{Algorithm: Zstd + 1}))
+		require.EqualError(t, err, "unknown compression algorithm 3")
+
+		_, err = NewProvider(mem.NewProvider(), WithStoreConfig("test", Config{Threshold: -1}))
+		require.EqualError(t, err,
+			"invalid compression configuration of store test: compression threshold must be positive")
+	})
+
+	t.Run("Test underlying provider errors", func(t *testing.T) {
+		p, err := NewProvider(&mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errTest,
+			ErrCloseStore:      errTest,
+			ErrClose:           errTest,
+		})
+		require.NoError(t, err)
+
+		_, err = p.OpenStore("test")
+		require.EqualError(t, err, errTest.Error())
+		require.EqualError(t, p.CloseStore("test"), errTest.Error())
+		require.EqualError(t, p.Close(), errTest.Error())
+	})
+}
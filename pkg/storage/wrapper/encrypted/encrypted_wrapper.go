@@ -0,0 +1,481 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package encrypted offers a storage.Provider wrapper encrypting the records of any underlying provider
+// (e.g leveldb or a remote database) with KMS keys, so that the provider never sees plaintext data.
+//
+// The values are encrypted along with their key with an AEAD key, and the keys are replaced by their MAC computed
+// with a MAC key: the records can be fetched with their key (equality lookup) without disclosing it.
+//
+// With data keys, each record is encrypted with its own data key, itself encrypted with the AEAD key and kept apart
+// from the records. Deleting a record destroys its data key, so the record can no longer be decrypted from any copy
+// of the underlying store (crypto-shredding).
+package encrypted
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const (
+	failOpenUnderlyingStore            = "failed to open underlying store: %w"
+	failComputeKeyMAC                  = "failed to compute MAC of key: %w"
+	failEncryptRecord                  = "failed to encrypt record: %w"
+	failDecryptRecord                  = "failed to decrypt record: %w"
+	failPutInUnderlyingStore           = "failed to put encrypted record in underlying store: %w"
+	failGetFromUnderlyingStore         = "failed to get encrypted record from underlying store: %w"
+	failDeleteInUnderlyingStore        = "failed to delete encrypted record in underlying store: %w"
+	failGetIteratorFromUnderlyingStore = "failed to get iterator from underlying store: %w"
+	failBatchInUnderlyingStore         = "failed to perform batch operations in underlying store: %w"
+	failOpenDataKeyStore               = "failed to open data key store: %w"
+	failPutDataKeys                    = "failed to put data keys: %w"
+	failGetDataKey                     = "failed to get data key: %w"
+	failDeleteDataKeys                 = "failed to delete data keys: %w"
+
+	dataKeySize = 32
+)
+
+var (
+	errKeyMismatch = errors.New("decrypted record key does not match its MAC")
+	// errDataKeyDestroyed is returned for the records whose data key was destroyed, which are deemed deleted.
+	errDataKeyDestroyed = fmt.Errorf("data key destroyed: %w", storage.ErrDataNotFound)
+)
+
+// Option configures the encrypted provider.
+type Option func(p *Provider)
+
+// WithDataKeys encrypts each record with its own data key, encrypted with the AEAD key and kept in the store of
+// keyProvider with the same name. The data keys must be kept apart from the records (e.g the key provider is a local
+// one when the underlying provider is a remote one) since destroying a data key is what makes its record
+// unrecoverable. The data keys must be used from the creation of the stores.
+func WithDataKeys(keyProvider storage.Provider) Option {
+	return func(p *Provider) {
+		p.keyProvider = keyProvider
+	}
+}
+
+// Provider is a storage provider wrapper encrypting the records of the underlying provider.
+type Provider struct {
+	provider    storage.Provider
+	keyProvider storage.Provider
+	crypto      crypto.Crypto
+	encKH       interface{}
+	macKH       interface{}
+}
+
+// NewProvider instantiates a Provider encrypting the records of provider with crypto: encKH is the AEAD key handle
+// (e.g created by the KMS with kms.AES256GCMType) encrypting the records and macKH is the MAC key handle
+// (e.g created with kms.HMACSHA256Tag256Type) hiding their keys.
+func NewProvider(provider storage.Provider, crypto crypto.Crypto, encKH, macKH interface{}, opts ...Option) *Provider {
+	p := &Provider{
+		provider: provider,
+		crypto:   crypto,
+		encKH:    encKH,
+		macKH:    macKH,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// OpenStore opens the store with the given name in the underlying provider and returns an encrypting handle to it.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	store, err := p.provider.OpenStore(name)
+	if err != nil {
+		return nil, fmt.Errorf(failOpenUnderlyingStore, err)
+	}
+
+	s := &encryptedStore{store: store, provider: p}
+
+	if p.keyProvider != nil {
+		if s.dataKeys, err = p.keyProvider.OpenStore(name); err != nil {
+			return nil, fmt.Errorf(failOpenDataKeyStore, err)
+		}
+	}
+
+	return s, nil
+}
+
+// CloseStore closes the store with the given name in the underlying provider, and its data key store.
+func (p *Provider) CloseStore(name string) error {
+	if err := p.provider.CloseStore(name); err != nil {
+		return err
+	}
+
+	if p.keyProvider != nil {
+		return p.keyProvider.CloseStore(name)
+	}
+
+	return nil
+}
+
+// Close closes all stores created in the underlying provider, and in the data key provider.
+func (p *Provider) Close() error {
+	if err := p.provider.Close(); err != nil {
+		return err
+	}
+
+	if p.keyProvider != nil {
+		return p.keyProvider.Close()
+	}
+
+	return nil
+}
+
+// encryptedRecord is the record stored in the underlying store.
+type encryptedRecord struct {
+	Ciphertext []byte `json:"ciphertext"`
+	Nonce      []byte `json:"nonce"`
+}
+
+// plaintextRecord is the encrypted content of a record.
+type plaintextRecord struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type encryptedStore struct {
+	store    storage.Store
+	dataKeys storage.Store
+	provider *Provider
+}
+
+// Put encrypts the record and stores it under the MAC of k in the underlying store.
+func (s *encryptedStore) Put(k string, v []byte) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	macKey, err := s.macKey(k)
+	if err != nil {
+		return err
+	}
+
+	record, dataKey, err := s.encrypt(macKey, k, v)
+	if err != nil {
+		return err
+	}
+
+	if dataKey != nil {
+		// the data key is stored first, so that a record is never left without it
+		if err = s.dataKeys.Put(macKey, dataKey); err != nil {
+			return fmt.Errorf(failPutDataKeys, err)
+		}
+	}
+
+	if err = s.store.Put(macKey, record); err != nil {
+		return fmt.Errorf(failPutInUnderlyingStore, err)
+	}
+
+	return nil
+}
+
+// Get fetches the record stored under the MAC of k and decrypts it.
+func (s *encryptedStore) Get(k string) ([]byte, error) {
+	if k == "" {
+		return nil, errors.New("key is mandatory")
+	}
+
+	macKey, err := s.macKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.store.Get(macKey)
+	if err != nil {
+		return nil, fmt.Errorf(failGetFromUnderlyingStore, err)
+	}
+
+	plaintext, err := s.decrypt(macKey, record)
+	if err != nil {
+		return nil, err
+	}
+
+	if plaintext.Key != k {
+		return nil, fmt.Errorf(failDecryptRecord, errKeyMismatch)
+	}
+
+	return plaintext.Value, nil
+}
+
+// Iterator returns an iterator over the records in the given key range. Since the underlying store only knows
+// the MACs of the keys, all the records of the store are decrypted to select the ones in the range.
+func (s *encryptedStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	// the MACs are base64url encoded, which sorts them before the end key
+	itr := s.store.Iterator("", storage.EndKeySuffix)
+	defer itr.Release()
+
+	if err := itr.Error(); err != nil {
+		return mem.NewMemIterator(nil, fmt.Errorf(failGetIteratorFromUnderlyingStore, err))
+	}
+
+	var batch [][]string
+
+	for itr.Next() {
+		plaintext, err := s.decrypt(string(itr.Key()), itr.Value())
+		if errors.Is(err, errDataKeyDestroyed) {
+			continue
+		}
+
+		if err != nil {
+			return mem.NewMemIterator(nil, err)
+		}
+
+		if inRange(plaintext.Key, startKey, endKey) {
+			batch = append(batch, []string{plaintext.Key, string(plaintext.Value)})
+		}
+	}
+
+	if err := itr.Error(); err != nil {
+		return mem.NewMemIterator(nil, fmt.Errorf(failGetIteratorFromUnderlyingStore, err))
+	}
+
+	sort.Slice(batch, func(i, j int) bool {
+		return batch[i][0] < batch[j][0]
+	})
+
+	return mem.NewMemIterator(batch, nil)
+}
+
+// Delete deletes the record stored under the MAC of k, destroying its data key first.
+func (s *encryptedStore) Delete(k string) error {
+	if k == "" {
+		return errors.New("key is mandatory")
+	}
+
+	macKey, err := s.macKey(k)
+	if err != nil {
+		return err
+	}
+
+	if s.dataKeys != nil {
+		if err = s.dataKeys.Delete(macKey); err != nil {
+			return fmt.Errorf(failDeleteDataKeys, err)
+		}
+	}
+
+	if err = s.store.Delete(macKey); err != nil {
+		return fmt.Errorf(failDeleteInUnderlyingStore, err)
+	}
+
+	return nil
+}
+
+// Batch encrypts the records of the put operations and performs the batch in the underlying store. The data keys
+// of the records are put or destroyed first.
+func (s *encryptedStore) Batch(operations []storage.Operation) error {
+	encryptedOperations := make([]storage.Operation, len(operations))
+	dataKeyOperations := make([]storage.Operation, len(operations))
+
+	for i, op := range operations {
+		if op.Key == "" {
+			return errors.New("key is mandatory")
+		}
+
+		macKey, err := s.macKey(op.Key)
+		if err != nil {
+			return err
+		}
+
+		encryptedOperations[i] = storage.Operation{Key: macKey}
+		dataKeyOperations[i] = storage.Operation{Key: macKey}
+
+		if op.Value != nil {
+			encryptedOperations[i].Value, dataKeyOperations[i].Value, err = s.encrypt(macKey, op.Key, op.Value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.dataKeys != nil {
+		if err := s.dataKeys.Batch(dataKeyOperations); err != nil {
+			return fmt.Errorf(failPutDataKeys, err)
+		}
+	}
+
+	if err := s.store.Batch(encryptedOperations); err != nil {
+		return fmt.Errorf(failBatchInUnderlyingStore, err)
+	}
+
+	return nil
+}
+
+// macKey returns the key of the record in the underlying store.
+func (s *encryptedStore) macKey(k string) (string, error) {
+	mac, err := s.provider.crypto.ComputeMAC([]byte(k), s.provider.macKH)
+	if err != nil {
+		return "", fmt.Errorf(failComputeKeyMAC, err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(mac), nil
+}
+
+// encrypt encrypts the record with the AEAD key, or with a new data key returned encrypted with the AEAD key.
+func (s *encryptedStore) encrypt(macKey, k string, v []byte) ([]byte, []byte, error) {
+	plaintext, err := json.Marshal(&plaintextRecord{Key: k, Value: v})
+	if err != nil {
+		return nil, nil, fmt.Errorf(failEncryptRecord, err)
+	}
+
+	var (
+		ciphertext, nonce, dataKey []byte
+		// the MAC of the key is the associated data, so that the record can't be moved to another key
+		aad = []byte(macKey)
+	)
+
+	if s.dataKeys == nil {
+		ciphertext, nonce, err = s.provider.crypto.Encrypt(plaintext, aad, s.provider.encKH)
+	} else {
+		ciphertext, nonce, dataKey, err = s.encryptWithDataKey(plaintext, aad)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf(failEncryptRecord, err)
+	}
+
+	record, err := json.Marshal(&encryptedRecord{Ciphertext: ciphertext, Nonce: nonce})
+	if err != nil {
+		return nil, nil, fmt.Errorf(failEncryptRecord, err)
+	}
+
+	return record, dataKey, nil
+}
+
+func (s *encryptedStore) decrypt(macKey string, recordBytes []byte) (*plaintextRecord, error) {
+	record := &encryptedRecord{}
+
+	if err := json.Unmarshal(recordBytes, record); err != nil {
+		return nil, fmt.Errorf(failDecryptRecord, err)
+	}
+
+	var (
+		plaintextBytes []byte
+		err            error
+	)
+
+	if s.dataKeys == nil {
+		plaintextBytes, err = s.provider.crypto.Decrypt(record.Ciphertext, []byte(macKey), record.Nonce,
+			s.provider.encKH)
+	} else {
+		plaintextBytes, err = s.decryptWithDataKey(record, []byte(macKey))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(failDecryptRecord, err)
+	}
+
+	plaintext := &plaintextRecord{}
+
+	if err = json.Unmarshal(plaintextBytes, plaintext); err != nil {
+		return nil, fmt.Errorf(failDecryptRecord, err)
+	}
+
+	return plaintext, nil
+}
+
+// encryptWithDataKey encrypts the plaintext with a new data key, and returns the data key encrypted with the AEAD key.
+func (s *encryptedStore) encryptWithDataKey(plaintext, aad []byte) ([]byte, []byte, []byte, error) {
+	key := make([]byte, dataKeySize)
+
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, nil, nil, err
+	}
+
+	encryptedKey, keyNonce, err := s.provider.crypto.Encrypt(key, aad, s.provider.encKH)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	dataKey, err := json.Marshal(&encryptedRecord{Ciphertext: encryptedKey, Nonce: keyNonce})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return gcm.Seal(nil, nonce, plaintext, aad), nonce, dataKey, nil
+}
+
+// decryptWithDataKey decrypts the record with its data key, errDataKeyDestroyed is returned if it was destroyed.
+func (s *encryptedStore) decryptWithDataKey(record *encryptedRecord, aad []byte) ([]byte, error) {
+	dataKeyBytes, err := s.dataKeys.Get(string(aad))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, errDataKeyDestroyed
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(failGetDataKey, err)
+	}
+
+	dataKey := &encryptedRecord{}
+
+	if err = json.Unmarshal(dataKeyBytes, dataKey); err != nil {
+		return nil, fmt.Errorf(failGetDataKey, err)
+	}
+
+	key, err := s.provider.crypto.Decrypt(dataKey.Ciphertext, aad, dataKey.Nonce, s.provider.encKH)
+	if err != nil {
+		return nil, fmt.Errorf(failGetDataKey, err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, fmt.Errorf(failGetDataKey, err)
+	}
+
+	// gcm panics on nonces of another size
+	if len(record.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+
+	return gcm.Open(nil, record.Nonce, record.Ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// inRange checks whether the key is in the range of the iterator, the end key being excluded.
+func inRange(k, startKey, endKey string) bool {
+	if endKey == "" {
+		return false
+	}
+
+	if strings.HasSuffix(endKey, storage.EndKeySuffix) {
+		return k >= startKey && strings.HasPrefix(k, strings.TrimSuffix(endKey, storage.EndKeySuffix))
+	}
+
+	return k >= startKey && k < endKey
+}
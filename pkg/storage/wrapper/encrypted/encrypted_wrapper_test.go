@@ -0,0 +1,375 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encrypted
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/mac"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+var errTest = errors.New("test error")
+
+func TestEncryptedStore(t *testing.T) {
+	t.Run("Test encrypted store put and get", func(t *testing.T) {
+		underlying := mem.NewProvider()
+		prov := newProvider(t, underlying)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		const key = "did:example:123"
+
+		require.NoError(t, store.Put(key, []byte("value")))
+
+		v, err := store.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, "value", string(v))
+
+		_, err = store.Get("did:example:789")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		// the underlying store has neither the key nor the value in plaintext
+		underlyingStore, err := underlying.OpenStore("test")
+		require.NoError(t, err)
+
+		_, err = underlyingStore.Get(key)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		itr := underlyingStore.Iterator("", storage.EndKeySuffix)
+		require.True(t, itr.Next())
+		require.NotContains(t, string(itr.Key()), key)
+		require.NotContains(t, string(itr.Value()), key)
+		require.NotContains(t, string(itr.Value()), "value")
+		require.False(t, itr.Next())
+
+		require.EqualError(t, store.Put("", []byte("value")), "key and value are mandatory")
+		require.EqualError(t, store.Put(key, nil), "key and value are mandatory")
+
+		_, err = store.Get("")
+		require.EqualError(t, err, "key is mandatory")
+	})
+
+	t.Run("Test encrypted store records can't be moved to another key", func(t *testing.T) {
+		underlying := mem.NewProvider()
+		prov := newProvider(t, underlying)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, store.Put("k2", []byte("v2")))
+
+		underlyingStore, err := underlying.OpenStore("test")
+		require.NoError(t, err)
+
+		es, ok := store.(*encryptedStore)
+		require.True(t, ok)
+
+		macK1, err := es.macKey("k1")
+		require.NoError(t, err)
+
+		macK2, err := es.macKey("k2")
+		require.NoError(t, err)
+
+		record, err := underlyingStore.Get(macK1)
+		require.NoError(t, err)
+		require.NoError(t, underlyingStore.Put(macK2, record))
+
+		_, err = store.Get("k2")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt record")
+	})
+
+	t.Run("Test encrypted store iterator", func(t *testing.T) {
+		prov := newProvider(t, mem.NewProvider())
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		for _, k := range []string{"abc_3", "abc_1", "abc_2", "abd_1", "xyz_1"} {
+			require.NoError(t, store.Put(k, []byte("value-"+k)))
+		}
+
+		itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+
+		var keys []string
+
+		for itr.Next() {
+			keys = append(keys, string(itr.Key()))
+			require.Equal(t, "value-"+string(itr.Key()), string(itr.Value()))
+		}
+
+		require.NoError(t, itr.Error())
+		require.Equal(t, []string{"abc_1", "abc_2", "abc_3"}, keys)
+
+		itr = store.Iterator("abc_2", "abd_2")
+		keys = nil
+
+		for itr.Next() {
+			keys = append(keys, string(itr.Key()))
+		}
+
+		require.Equal(t, []string{"abc_2", "abc_3", "abd_1"}, keys)
+
+		require.False(t, store.Iterator("abc_", "").Next())
+	})
+
+	t.Run("Test encrypted store delete", func(t *testing.T) {
+		prov := newProvider(t, mem.NewProvider())
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, store.Delete("k1"))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.EqualError(t, store.Delete(""), "key is mandatory")
+	})
+
+	t.Run("Test encrypted store crypto errors", func(t *testing.T) {
+		store := &encryptedStore{
+			store:    mockstorage.NewMockStoreProvider().Store,
+			provider: NewProvider(nil, &mockcrypto.Crypto{ComputeMACErr: errTest}, nil, nil),
+		}
+
+		require.True(t, errors.Is(store.Put("k1", []byte("v1")), errTest))
+
+		_, err := store.Get("k1")
+		require.True(t, errors.Is(err, errTest))
+		require.True(t, errors.Is(store.Delete("k1"), errTest))
+
+		store.provider.crypto = &mockcrypto.Crypto{ComputeMACValue: []byte("mac"), EncryptErr: errTest}
+		require.EqualError(t, store.Put("k1", []byte("v1")), "failed to encrypt record: test error")
+
+		store.provider.crypto = &mockcrypto.Crypto{ComputeMACValue: []byte("mac"), DecryptErr: errTest}
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		_, err = store.Get("k1")
+		require.EqualError(t, err, "failed to decrypt record: test error")
+
+		itr := store.Iterator("k", "k"+storage.EndKeySuffix)
+		require.False(t, itr.Next())
+		require.EqualError(t, itr.Error(), "failed to decrypt record: test error")
+	})
+
+	t.Run("Test encrypted provider", func(t *testing.T) {
+		prov := NewProvider(&mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errTest,
+			ErrCloseStore:      errTest,
+			ErrClose:           errTest,
+		}, nil, nil, nil)
+
+		_, err := prov.OpenStore("test")
+		require.EqualError(t, err, "failed to open underlying store: test error")
+		require.EqualError(t, prov.CloseStore("test"), errTest.Error())
+		require.EqualError(t, prov.Close(), errTest.Error())
+	})
+}
+
+func newProvider(t *testing.T, underlying storage.Provider, opts ...Option) *Provider {
+	t.Helper()
+
+	encKH, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	require.NoError(t, err)
+
+	macKH, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	require.NoError(t, err)
+
+	crypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	return NewProvider(underlying, crypto, encKH, macKH, opts...)
+}
+
+func TestEncryptedStore_Batch(t *testing.T) {
+	underlying := mockstorage.NewMockStoreProvider()
+	prov := newProvider(t, underlying)
+
+	store, err := prov.OpenStore("test")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("k1", []byte("v1")))
+
+	require.NoError(t, store.Batch([]storage.Operation{{Key: "k1"}, {Key: "k2", Value: []byte("v2")}}))
+
+	_, err = store.Get("k1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	v, err := store.Get("k2")
+	require.NoError(t, err)
+	require.Equal(t, "v2", string(v))
+
+	require.Len(t, underlying.Store.Store, 1)
+
+	require.EqualError(t, store.Batch([]storage.Operation{{Key: ""}}), "key is mandatory")
+
+	underlying.Store.ErrBatch = errTest
+	require.EqualError(t, store.Batch([]storage.Operation{{Key: "k2"}}),
+		"failed to perform batch operations in underlying store: test error")
+
+	es, ok := store.(*encryptedStore)
+	require.True(t, ok)
+
+	es.provider.crypto = &mockcrypto.Crypto{ComputeMACValue: []byte("mac"), EncryptErr: errTest}
+	require.EqualError(t, store.Batch([]storage.Operation{{Key: "k2", Value: []byte("v2")}}),
+		"failed to encrypt record: test error")
+
+	es.provider.crypto = &mockcrypto.Crypto{ComputeMACErr: errTest}
+	require.True(t, errors.Is(store.Batch([]storage.Operation{{Key: "k2"}}), errTest))
+}
+
+func TestEncryptedStore_DataKeys(t *testing.T) {
+	t.Run("Test records encrypted with data keys", func(t *testing.T) {
+		underlying, keys := mem.NewProvider(), mem.NewProvider()
+		prov := newProvider(t, underlying, WithDataKeys(keys))
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, store.Batch([]storage.Operation{
+			{Key: "k2", Value: []byte("v2")},
+			{Key: "k3", Value: []byte("v3")},
+		}))
+
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, "v1", string(v))
+
+		keyStore, err := keys.OpenStore("test")
+		require.NoError(t, err)
+
+		count, err := storage.Count(keyStore, "", storage.EndKeySuffix)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		// a copy of the underlying store, e.g a backup
+		underlyingStore, err := underlying.OpenStore("test")
+		require.NoError(t, err)
+
+		itr := underlyingStore.Iterator("", storage.EndKeySuffix)
+
+		backup := make(map[string][]byte)
+		for itr.Next() {
+			backup[string(itr.Key())] = append([]byte(nil), itr.Value()...)
+		}
+
+		require.NoError(t, store.Delete("k1"))
+		require.NoError(t, store.Batch([]storage.Operation{{Key: "k2"}}))
+
+		count, err = storage.Count(keyStore, "", storage.EndKeySuffix)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		// the records restored from the copy can't be decrypted, their data keys were destroyed
+		for k, record := range backup {
+			require.NoError(t, underlyingStore.Put(k, record))
+		}
+
+		for _, k := range []string{"k1", "k2"} {
+			_, err = store.Get(k)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		}
+
+		v, err = store.Get("k3")
+		require.NoError(t, err)
+		require.Equal(t, "v3", string(v))
+
+		itr = store.Iterator("k", "k"+storage.EndKeySuffix)
+		require.True(t, itr.Next())
+		require.Equal(t, "k3", string(itr.Key()))
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+
+		require.NoError(t, prov.CloseStore("test"))
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("Test data key store errors", func(t *testing.T) {
+		keys := mockstorage.NewMockStoreProvider()
+		prov := newProvider(t, mem.NewProvider(), WithDataKeys(keys))
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		keys.Store.ErrPut = errTest
+		require.EqualError(t, store.Put("k1", []byte("v1")), "failed to put data keys: test error")
+
+		keys.Store.ErrBatch = errTest
+		require.EqualError(t, store.Batch([]storage.Operation{{Key: "k1"}}), "failed to put data keys: test error")
+
+		keys.Store.ErrDelete = errTest
+		require.EqualError(t, store.Delete("k1"), "failed to delete data keys: test error")
+
+		keys.Store.ErrGet = errTest
+		_, err = store.Get("k1")
+		require.EqualError(t, err, "failed to decrypt record: failed to get data key: test error")
+
+		keys.Store.ErrPut, keys.Store.ErrGet = nil, nil
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		for k := range keys.Store.Store {
+			keys.Store.Store[k] = []byte("{")
+		}
+
+		_, err = store.Get("k1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get data key")
+
+		_, err = NewProvider(mem.NewProvider(), nil, nil, nil, WithDataKeys(&mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errTest,
+		})).OpenStore("test")
+		require.EqualError(t, err, "failed to open data key store: test error")
+
+		prov = NewProvider(mem.NewProvider(), nil, nil, nil, WithDataKeys(&mockstorage.MockStoreProvider{
+			ErrCloseStore: errTest,
+			ErrClose:      errTest,
+		}))
+		require.EqualError(t, prov.CloseStore("test"), errTest.Error())
+		require.EqualError(t, prov.Close(), errTest.Error())
+	})
+
+	t.Run("Test data key crypto errors", func(t *testing.T) {
+		store := &encryptedStore{
+			store:    mockstorage.NewMockStoreProvider().Store,
+			dataKeys: mockstorage.NewMockStoreProvider().Store,
+			provider: NewProvider(nil, &mockcrypto.Crypto{ComputeMACValue: []byte("mac"), EncryptErr: errTest},
+				nil, nil),
+		}
+
+		require.EqualError(t, store.Put("k1", []byte("v1")), "failed to encrypt record: test error")
+
+		store.provider.crypto = &mockcrypto.Crypto{ComputeMACValue: []byte("mac"), DecryptErr: errTest}
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		_, err := store.Get("k1")
+		require.EqualError(t, err, "failed to decrypt record: failed to get data key: test error")
+
+		// the decrypted data key has an invalid size
+		store.provider.crypto = &mockcrypto.Crypto{ComputeMACValue: []byte("mac"), DecryptValue: []byte("key")}
+
+		_, err = store.Get("k1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get data key")
+	})
+}
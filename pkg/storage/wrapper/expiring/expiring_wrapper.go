@@ -0,0 +1,373 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package expiring offers a storage.Provider wrapper adding records expiration to any underlying provider
+// (e.g leveldb), for the providers not supporting it natively like Redis.
+//
+// The expiration time of the records put with a TTL is kept in a companion store of the underlying provider. The
+// expired records are no longer returned and are deleted by a sweeper running periodically.
+package expiring
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const (
+	// expirationStoreSuffix is appended to the name of a store to name the store of its records expiration time.
+	expirationStoreSuffix = "_expiration"
+
+	defaultSweepInterval = time.Minute
+
+	failOpenUnderlyingStore  = "failed to open underlying store: %w"
+	failPutExpiration        = "failed to put record expiration: %w"
+	failGetExpiration        = "failed to get record expiration: %w"
+	failDeleteExpiration     = "failed to delete record expiration: %w"
+	failDeleteExpiredRecords = "failed to delete expired records: %w"
+)
+
+var logger = log.New("aries-framework/storage/expiring")
+
+// Option configures the expiring provider.
+type Option func(p *Provider)
+
+// WithSweepInterval sets the interval between two deletions of the expired records (one minute by default).
+// A zero interval disables the sweeper: the expired records are then deleted when accessed or by Sweep.
+func WithSweepInterval(interval time.Duration) Option {
+	return func(p *Provider) {
+		p.sweepInterval = interval
+	}
+}
+
+// Provider is a storage provider wrapper adding records expiration to the underlying provider.
+type Provider struct {
+	provider      storage.Provider
+	sweepInterval time.Duration
+	now           func() time.Time
+	stores        map[string]*expiringStore
+	lock          sync.RWMutex
+	done          chan struct{}
+	closeOnce     sync.Once
+	sweeping      sync.WaitGroup
+}
+
+// NewProvider instantiates a Provider adding records expiration to provider and starts its sweeper.
+func NewProvider(provider storage.Provider, opts ...Option) *Provider {
+	p := &Provider{
+		provider:      provider,
+		sweepInterval: defaultSweepInterval,
+		now:           time.Now,
+		stores:        make(map[string]*expiringStore),
+		done:          make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.sweepInterval > 0 {
+		p.sweeping.Add(1)
+
+		go p.sweeper()
+	}
+
+	return p
+}
+
+// OpenStore opens the store with the given name and its expiration store in the underlying provider.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if store, ok := p.stores[name]; ok {
+		return store, nil
+	}
+
+	store, err := p.provider.OpenStore(name)
+	if err != nil {
+		return nil, fmt.Errorf(failOpenUnderlyingStore, err)
+	}
+
+	expirations, err := p.provider.OpenStore(name + expirationStoreSuffix)
+	if err != nil {
+		return nil, fmt.Errorf(failOpenUnderlyingStore, err)
+	}
+
+	p.stores[name] = &expiringStore{store: store, expirations: expirations, now: p.now}
+
+	return p.stores[name], nil
+}
+
+// CloseStore closes the store with the given name and its expiration store in the underlying provider.
+func (p *Provider) CloseStore(name string) error {
+	p.lock.Lock()
+	delete(p.stores, name)
+	p.lock.Unlock()
+
+	if err := p.provider.CloseStore(name); err != nil {
+		return err
+	}
+
+	return p.provider.CloseStore(name + expirationStoreSuffix)
+}
+
+// Close stops the sweeper and closes all stores created in the underlying provider.
+func (p *Provider) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+
+	// the stores are not closed while being swept
+	p.sweeping.Wait()
+
+	p.lock.Lock()
+	p.stores = make(map[string]*expiringStore)
+	p.lock.Unlock()
+
+	return p.provider.Close()
+}
+
+// Sweep deletes the expired records of the open stores.
+func (p *Provider) Sweep() error {
+	p.lock.RLock()
+
+	stores := make([]*expiringStore, 0, len(p.stores))
+	for _, store := range p.stores {
+		stores = append(stores, store)
+	}
+
+	p.lock.RUnlock()
+
+	for _, store := range stores {
+		if err := store.sweep(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) sweeper() {
+	defer p.sweeping.Done()
+
+	ticker := time.NewTicker(p.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.Sweep(); err != nil {
+				logger.Warnf("sweep expired records: %s", err)
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+type expiringStore struct {
+	store       storage.Store
+	expirations storage.Store
+	now         func() time.Time
+}
+
+// Put stores the record, which doesn't expire.
+func (s *expiringStore) Put(k string, v []byte) error {
+	if err := s.store.Put(k, v); err != nil {
+		return err
+	}
+
+	if err := s.expirations.Delete(k); err != nil {
+		return fmt.Errorf(failDeleteExpiration, err)
+	}
+
+	return nil
+}
+
+// PutWithTTL stores the record, which expires after the ttl duration.
+func (s *expiringStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+
+	expiration, err := s.now().Add(ttl).MarshalText()
+	if err != nil {
+		return fmt.Errorf(failPutExpiration, err)
+	}
+
+	// the expiration is stored first, so that the record is never left without it
+	if err = s.expirations.Put(k, expiration); err != nil {
+		return fmt.Errorf(failPutExpiration, err)
+	}
+
+	return s.store.Put(k, v)
+}
+
+// Get fetches the record, ErrDataNotFound is returned if it expired.
+func (s *expiringStore) Get(k string) ([]byte, error) {
+	v, err := s.store.Get(k)
+	if err != nil {
+		return nil, err
+	}
+
+	expired, err := s.expired(k)
+	if err != nil {
+		return nil, err
+	}
+
+	if expired {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return v, nil
+}
+
+// Iterator returns an iterator over the records of the given key range which didn't expire.
+func (s *expiringStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	// the keys of the expiration store are the keys of the records, the range of the records covers theirs
+	expired, err := s.expiredKeys(startKey, endKey)
+	if err != nil {
+		return mem.NewMemIterator(nil, err)
+	}
+
+	if len(expired) == 0 {
+		return s.store.Iterator(startKey, endKey)
+	}
+
+	itr := s.store.Iterator(startKey, endKey)
+	defer itr.Release()
+
+	var batch [][]string
+
+	for itr.Next() {
+		if _, ok := expired[string(itr.Key())]; !ok {
+			batch = append(batch, []string{string(itr.Key()), string(itr.Value())})
+		}
+	}
+
+	return mem.NewMemIterator(batch, itr.Error())
+}
+
+// Delete deletes the record and its expiration.
+func (s *expiringStore) Delete(k string) error {
+	if err := s.store.Delete(k); err != nil {
+		return err
+	}
+
+	if err := s.expirations.Delete(k); err != nil {
+		return fmt.Errorf(failDeleteExpiration, err)
+	}
+
+	return nil
+}
+
+// Batch performs the batch operations, the records put by the batch don't expire.
+func (s *expiringStore) Batch(operations []storage.Operation) error {
+	if err := s.store.Batch(operations); err != nil {
+		return err
+	}
+
+	deletions := make([]storage.Operation, len(operations))
+
+	for i, op := range operations {
+		deletions[i] = storage.Operation{Key: op.Key}
+	}
+
+	if err := s.expirations.Batch(deletions); err != nil {
+		return fmt.Errorf(failDeleteExpiration, err)
+	}
+
+	return nil
+}
+
+// expired checks whether the record with the key expired.
+func (s *expiringStore) expired(k string) (bool, error) {
+	expiration, err := s.expirations.Get(k)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf(failGetExpiration, err)
+	}
+
+	return isExpired(expiration, s.now())
+}
+
+// expiredKeys returns the keys of the expired records in the key range.
+func (s *expiringStore) expiredKeys(startKey, endKey string) (map[string]struct{}, error) {
+	itr := s.expirations.Iterator(startKey, endKey)
+	defer itr.Release()
+
+	now := s.now()
+	expired := make(map[string]struct{})
+
+	for itr.Next() {
+		ok, err := isExpired(itr.Value(), now)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			expired[string(itr.Key())] = struct{}{}
+		}
+	}
+
+	if err := itr.Error(); err != nil {
+		return nil, fmt.Errorf(failGetExpiration, err)
+	}
+
+	return expired, nil
+}
+
+// sweep deletes the expired records of the store.
+func (s *expiringStore) sweep() error {
+	expired, err := s.expiredKeys("", storage.EndKeySuffix)
+	if err != nil {
+		return err
+	}
+
+	if len(expired) == 0 {
+		return nil
+	}
+
+	deletions := make([]storage.Operation, 0, len(expired))
+
+	for k := range expired {
+		deletions = append(deletions, storage.Operation{Key: k})
+	}
+
+	// the records are deleted first, so that a record is never left without its expiration
+	if err = s.store.Batch(deletions); err != nil {
+		return fmt.Errorf(failDeleteExpiredRecords, err)
+	}
+
+	if err = s.expirations.Batch(deletions); err != nil {
+		return fmt.Errorf(failDeleteExpiration, err)
+	}
+
+	return nil
+}
+
+func isExpired(expiration []byte, now time.Time) (bool, error) {
+	var t time.Time
+
+	if err := t.UnmarshalText(expiration); err != nil {
+		return false, fmt.Errorf(failGetExpiration, err)
+	}
+
+	return !now.Before(t), nil
+}
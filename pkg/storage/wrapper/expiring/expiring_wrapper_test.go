@@ -0,0 +1,311 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expiring
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+var errTest = errors.New("test error")
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newProvider(underlying storage.Provider) (*Provider, *clock) {
+	c := &clock{now: time.Now()}
+
+	prov := NewProvider(underlying, WithSweepInterval(0))
+	prov.now = c.Now
+
+	return prov, c
+}
+
+func TestExpiringStore(t *testing.T) {
+	t.Run("Test expiring store put with ttl and get", func(t *testing.T) {
+		prov, c := newProvider(mem.NewProvider())
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTTL(store, "k1", []byte("v1"), time.Minute))
+		require.NoError(t, store.Put("k2", []byte("v2")))
+
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, "v1", string(v))
+
+		c.now = c.now.Add(time.Minute)
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		v, err = store.Get("k2")
+		require.NoError(t, err)
+		require.Equal(t, "v2", string(v))
+
+		_, err = store.Get("k3")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("Test expiring store put removes expiration", func(t *testing.T) {
+		prov, c := newProvider(mem.NewProvider())
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTTL(store, "k1", []byte("v1"), time.Minute))
+		require.NoError(t, store.Put("k1", []byte("v2")))
+
+		c.now = c.now.Add(time.Hour)
+
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, "v2", string(v))
+
+		require.NoError(t, storage.PutWithTTL(store, "k2", []byte("v2"), time.Minute))
+		require.NoError(t, store.Batch([]storage.Operation{{Key: "k2", Value: []byte("v3")}}))
+
+		c.now = c.now.Add(time.Hour)
+
+		v, err = store.Get("k2")
+		require.NoError(t, err)
+		require.Equal(t, "v3", string(v))
+	})
+
+	t.Run("Test expiring store iterator", func(t *testing.T) {
+		prov, c := newProvider(mem.NewProvider())
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("abc_1", []byte("v1")))
+		require.NoError(t, storage.PutWithTTL(store, "abc_2", []byte("v2"), time.Minute))
+		require.NoError(t, storage.PutWithTTL(store, "abc_3", []byte("v3"), time.Hour))
+
+		keys := func() []string {
+			itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+			defer itr.Release()
+
+			var keys []string
+
+			for itr.Next() {
+				keys = append(keys, string(itr.Key()))
+			}
+
+			require.NoError(t, itr.Error())
+
+			return keys
+		}
+
+		require.Equal(t, []string{"abc_1", "abc_2", "abc_3"}, keys())
+
+		c.now = c.now.Add(time.Minute)
+
+		require.Equal(t, []string{"abc_1", "abc_3"}, keys())
+	})
+
+	t.Run("Test expiring store delete", func(t *testing.T) {
+		underlying := mem.NewProvider()
+		prov, _ := newProvider(underlying)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTTL(store, "k1", []byte("v1"), time.Minute))
+		require.NoError(t, store.Delete("k1"))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		expirations, err := underlying.OpenStore("test" + expirationStoreSuffix)
+		require.NoError(t, err)
+
+		_, err = expirations.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, storage.PutWithTTL(store, "k2", []byte("v2"), time.Minute))
+		require.NoError(t, store.Batch([]storage.Operation{{Key: "k2"}}))
+
+		_, err = expirations.Get("k2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("Test expiring store sweep", func(t *testing.T) {
+		underlying := mem.NewProvider()
+		prov, c := newProvider(underlying)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTTL(store, "k1", []byte("v1"), time.Minute))
+		require.NoError(t, storage.PutWithTTL(store, "k2", []byte("v2"), time.Hour))
+		require.NoError(t, store.Put("k3", []byte("v3")))
+
+		// nothing to sweep
+		require.NoError(t, prov.Sweep())
+
+		c.now = c.now.Add(time.Minute)
+
+		require.NoError(t, prov.Sweep())
+
+		underlyingStore, err := underlying.OpenStore("test")
+		require.NoError(t, err)
+
+		_, err = underlyingStore.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = underlyingStore.Get("k2")
+		require.NoError(t, err)
+
+		_, err = underlyingStore.Get("k3")
+		require.NoError(t, err)
+
+		expirations, err := underlying.OpenStore("test" + expirationStoreSuffix)
+		require.NoError(t, err)
+
+		_, err = expirations.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("Test expiring store sweeper", func(t *testing.T) {
+		underlying := mem.NewProvider()
+		prov := NewProvider(underlying, WithSweepInterval(10*time.Millisecond))
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTTL(store, "k1", []byte("v1"), time.Millisecond))
+
+		underlyingStore, err := underlying.OpenStore("test")
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			_, err = underlyingStore.Get("k1")
+
+			return errors.Is(err, storage.ErrDataNotFound)
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, prov.Close())
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("Test expiring store invalid put with ttl", func(t *testing.T) {
+		prov, _ := newProvider(mem.NewProvider())
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		es, ok := store.(*expiringStore)
+		require.True(t, ok)
+
+		require.EqualError(t, es.PutWithTTL("", []byte("v1"), time.Minute), "key and value are mandatory")
+		require.EqualError(t, es.PutWithTTL("k1", []byte("v1"), 0), "ttl must be positive")
+		require.EqualError(t, storage.PutWithTTL(store, "k1", []byte("v1"), -time.Minute), "ttl must be positive")
+	})
+
+	t.Run("Test expiring store errors", func(t *testing.T) {
+		expirations := mockstorage.NewMockStoreProvider().Store
+		store := &expiringStore{
+			store:       mockstorage.NewMockStoreProvider().Store,
+			expirations: expirations,
+			now:         time.Now,
+		}
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, expirations.Put("k1", []byte("invalid")))
+
+		_, err := store.Get("k1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get record expiration")
+
+		itr := store.Iterator("k", "k"+storage.EndKeySuffix)
+		require.False(t, itr.Next())
+		require.Error(t, itr.Error())
+
+		expirations.ErrGet = errTest
+
+		_, err = store.Get("k1")
+		require.EqualError(t, err, "failed to get record expiration: test error")
+
+		expirations.ErrPut = errTest
+		require.EqualError(t, store.PutWithTTL("k1", []byte("v1"), time.Minute),
+			"failed to put record expiration: test error")
+
+		expirations.ErrDelete = errTest
+		require.EqualError(t, store.Put("k1", []byte("v1")), "failed to delete record expiration: test error")
+		require.EqualError(t, store.Delete("k1"), "failed to delete record expiration: test error")
+
+		expirations.ErrBatch = errTest
+		require.EqualError(t, store.Batch([]storage.Operation{{Key: "k1"}}),
+			"failed to delete record expiration: test error")
+
+		expirations.ErrItr = errTest
+		itr = store.Iterator("k", "k"+storage.EndKeySuffix)
+		require.False(t, itr.Next())
+		require.EqualError(t, itr.Error(), "failed to get record expiration: test error")
+	})
+
+	t.Run("Test expiring store sweep errors", func(t *testing.T) {
+		records := mockstorage.NewMockStoreProvider().Store
+		expirations := mockstorage.NewMockStoreProvider().Store
+		store := &expiringStore{store: records, expirations: expirations, now: time.Now}
+
+		require.NoError(t, store.PutWithTTL("k1", []byte("v1"), time.Nanosecond))
+
+		records.ErrBatch = errTest
+		require.EqualError(t, store.sweep(), "failed to delete expired records: test error")
+
+		records.ErrBatch = nil
+		expirations.ErrBatch = errTest
+		require.EqualError(t, store.sweep(), "failed to delete record expiration: test error")
+
+		expirations.ErrItr = errTest
+		require.EqualError(t, store.sweep(), "failed to get record expiration: test error")
+	})
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("Test expiring provider open store", func(t *testing.T) {
+		prov, _ := newProvider(mem.NewProvider())
+
+		store1, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		store2, err := prov.OpenStore("test")
+		require.NoError(t, err)
+		require.Equal(t, store1, store2)
+
+		require.NoError(t, prov.CloseStore("test"))
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("Test expiring provider errors", func(t *testing.T) {
+		prov, _ := newProvider(&mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errTest,
+			ErrCloseStore:      errTest,
+			ErrClose:           errTest,
+		})
+
+		_, err := prov.OpenStore("test")
+		require.EqualError(t, err, "failed to open underlying store: test error")
+		require.EqualError(t, prov.CloseStore("test"), errTest.Error())
+		require.EqualError(t, prov.Close(), errTest.Error())
+	})
+}
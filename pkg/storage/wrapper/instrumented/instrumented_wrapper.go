@@ -0,0 +1,249 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package instrumented offers a storage.Provider wrapper recording the latency and the errors of the operations
+// of each store, and the number of records of the stores, with a metrics.Provider, and tracing the operations with
+// a tracing.Tracer.
+package instrumented
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// Store operations, used as the operation label of the metrics.
+const (
+	putOperation        = "put"
+	getOperation        = "get"
+	iteratorOperation   = "iterator"
+	deleteOperation     = "delete"
+	batchOperation      = "batch"
+	putTTLOperation     = "put_with_ttl"
+	countOperation      = "count"
+	getVersionOperation = "get_with_version"
+	putVersionOperation = "put_if_version"
+)
+
+// Provider is a storage provider wrapper recording the metrics of the stores of the underlying provider.
+type Provider struct {
+	provider storage.Provider
+	metrics  *metrics.Storage
+	tracing  *tracing.Storage
+	stores   map[string]storage.Store
+	lock     sync.RWMutex
+}
+
+// Option configures the Provider.
+type Option func(p *Provider)
+
+// WithTracer traces the operations of the stores with the tracer.
+func WithTracer(t tracing.Tracer) Option {
+	return func(p *Provider) {
+		p.tracing = tracing.ForStorage(t)
+	}
+}
+
+// NewProvider instantiates a Provider recording the metrics of the stores of provider with metricsProvider.
+func NewProvider(provider storage.Provider, metricsProvider metrics.Provider, opts ...Option) *Provider {
+	p := &Provider{
+		provider: provider,
+		metrics:  metrics.ForStorage(metricsProvider),
+		tracing:  tracing.ForStorage(nil),
+		stores:   make(map[string]storage.Store),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// OpenStore opens the store with the given name in the underlying provider and returns an instrumented handle to it.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	store, err := p.provider.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	p.stores[name] = store
+	p.lock.Unlock()
+
+	return &instrumentedStore{store: store, name: name, metrics: p.metrics, tracing: p.tracing}, nil
+}
+
+// CloseStore closes the store with the given name in the underlying provider.
+func (p *Provider) CloseStore(name string) error {
+	p.lock.Lock()
+	delete(p.stores, name)
+	p.lock.Unlock()
+
+	return p.provider.CloseStore(name)
+}
+
+// Close closes all stores created in the underlying provider.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	p.stores = make(map[string]storage.Store)
+	p.lock.Unlock()
+
+	return p.provider.Close()
+}
+
+// Flush flushes the pending writes of the underlying provider, if it buffers them (e.g. a formattedstore provider
+// with batch writes).
+func (p *Provider) Flush() error {
+	if flusher, ok := p.provider.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+
+	return nil
+}
+
+// RecordStoreSizes records the number of records of each open store. Since counting the records may require to
+// iterate over them (see storage.Count), it is meant to be called periodically rather than on each operation.
+func (p *Provider) RecordStoreSizes() error {
+	p.lock.RLock()
+
+	stores := make(map[string]storage.Store, len(p.stores))
+	for name, store := range p.stores {
+		stores[name] = store
+	}
+
+	p.lock.RUnlock()
+
+	for name, store := range stores {
+		count, err := storage.Count(store, "", storage.EndKeySuffix)
+		if err != nil {
+			return fmt.Errorf("failed to count records of store %s: %w", name, err)
+		}
+
+		p.metrics.Records(name, count)
+	}
+
+	return nil
+}
+
+type instrumentedStore struct {
+	store   storage.Store
+	name    string
+	metrics *metrics.Storage
+	tracing *tracing.Storage
+}
+
+func (s *instrumentedStore) Put(k string, v []byte) error {
+	start := time.Now()
+
+	err := s.store.Put(k, v)
+
+	s.record(putOperation, start, err)
+
+	return err
+}
+
+func (s *instrumentedStore) Get(k string) ([]byte, error) {
+	start := time.Now()
+
+	v, err := s.store.Get(k)
+
+	s.record(getOperation, start, err)
+
+	return v, err
+}
+
+func (s *instrumentedStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	start := time.Now()
+
+	itr := s.store.Iterator(startKey, endKey)
+
+	s.record(iteratorOperation, start, itr.Error())
+
+	return itr
+}
+
+func (s *instrumentedStore) Delete(k string) error {
+	start := time.Now()
+
+	err := s.store.Delete(k)
+
+	s.record(deleteOperation, start, err)
+
+	return err
+}
+
+func (s *instrumentedStore) Batch(operations []storage.Operation) error {
+	start := time.Now()
+
+	err := s.store.Batch(operations)
+
+	s.record(batchOperation, start, err)
+
+	return err
+}
+
+// PutWithTTL puts the record with an expiration if the underlying store supports it.
+func (s *instrumentedStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	start := time.Now()
+
+	err := storage.PutWithTTL(s.store, k, v, ttl)
+
+	s.record(putTTLOperation, start, err)
+
+	return err
+}
+
+// Count counts the records of the key range, natively if the underlying store supports it.
+func (s *instrumentedStore) Count(startKey, endKey string) (int, error) {
+	start := time.Now()
+
+	count, err := storage.Count(s.store, startKey, endKey)
+
+	s.record(countOperation, start, err)
+
+	return count, err
+}
+
+// GetWithVersion fetches the record and its version if the underlying store supports versions.
+func (s *instrumentedStore) GetWithVersion(k string) ([]byte, string, error) {
+	start := time.Now()
+
+	v, version, err := storage.GetWithVersion(s.store, k)
+
+	s.record(getVersionOperation, start, err)
+
+	return v, version, err
+}
+
+// PutIfVersion puts the record if the underlying store supports versions and has the record at version.
+func (s *instrumentedStore) PutIfVersion(k string, v []byte, version string) (string, error) {
+	start := time.Now()
+
+	newVersion, err := storage.PutIfVersion(s.store, k, v, version)
+
+	s.record(putVersionOperation, start, err)
+
+	return newVersion, err
+}
+
+// record records the operation, records not found and version mismatches are not counted as errors.
+func (s *instrumentedStore) record(operation string, start time.Time, err error) {
+	failed := err != nil && !errors.Is(err, storage.ErrDataNotFound) && !errors.Is(err, storage.ErrVersionMismatch)
+
+	s.metrics.Operation(s.name, operation, time.Since(start), failed)
+
+	if !failed {
+		err = nil
+	}
+
+	s.tracing.Operation(s.name, operation, start, err)
+}
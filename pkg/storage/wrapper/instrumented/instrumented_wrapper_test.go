@@ -0,0 +1,232 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package instrumented
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/common/metrics"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+var errTest = errors.New("test error")
+
+type recorders struct {
+	durations *mocks.MockHistogram
+	errors    *mocks.MockCounter
+	records   *mocks.MockGauge
+}
+
+func newMetricsProvider(ctrl *gomock.Controller) (*mocks.MockProvider, *recorders) {
+	r := &recorders{
+		durations: mocks.NewMockHistogram(ctrl),
+		errors:    mocks.NewMockCounter(ctrl),
+		records:   mocks.NewMockGauge(ctrl),
+	}
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Histogram(metrics.StorageOperationDuration).Return(r.durations)
+	provider.EXPECT().Counter(metrics.StorageOperationErrors).Return(r.errors)
+	provider.EXPECT().Gauge(metrics.StorageRecords).Return(r.records)
+
+	return provider, r
+}
+
+func labels(operation string) metrics.Labels {
+	return metrics.Labels{metrics.StoreLabel: "test", metrics.OperationLabel: operation}
+}
+
+func TestInstrumentedStore(t *testing.T) {
+	t.Run("Test instrumented store operations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		metricsProvider, r := newMetricsProvider(ctrl)
+
+		for _, operation := range []string{putOperation, getOperation, getOperation, iteratorOperation,
+			deleteOperation, batchOperation, countOperation, putTTLOperation, putVersionOperation, putVersionOperation,
+			getVersionOperation} {
+			r.durations.EXPECT().Observe(gomock.Any(), labels(operation))
+		}
+
+		// only the put with ttl fails, the records not found and the version mismatches are not errors
+		r.errors.EXPECT().Inc(labels(putTTLOperation))
+
+		store, err := NewProvider(mem.NewProvider(), metricsProvider).OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, "v1", string(v))
+
+		_, err = store.Get("k2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		itr := store.Iterator("k", "k"+storage.EndKeySuffix)
+		require.True(t, itr.Next())
+		require.Equal(t, "k1", string(itr.Key()))
+
+		require.NoError(t, store.Delete("k1"))
+		require.NoError(t, store.Batch([]storage.Operation{{Key: "k2", Value: []byte("v2")}}))
+
+		count, err := storage.Count(store, "k", "k"+storage.EndKeySuffix)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		err = storage.PutWithTTL(store, "k3", []byte("v3"), time.Minute)
+		require.True(t, errors.Is(err, storage.ErrExpirationNotSupported))
+
+		version, err := storage.PutIfVersion(store, "k3", []byte("v3"), "")
+		require.NoError(t, err)
+
+		_, err = storage.PutIfVersion(store, "k3", []byte("v3"), "")
+		require.True(t, errors.Is(err, storage.ErrVersionMismatch))
+
+		_, current, err := storage.GetWithVersion(store, "k3")
+		require.NoError(t, err)
+		require.Equal(t, version, current)
+	})
+
+	t.Run("Test instrumented store errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		metricsProvider, r := newMetricsProvider(ctrl)
+
+		for _, operation := range []string{putOperation, getOperation, deleteOperation, batchOperation} {
+			r.durations.EXPECT().Observe(gomock.Any(), labels(operation))
+			r.errors.EXPECT().Inc(labels(operation))
+		}
+
+		underlying := mockstorage.NewMockStoreProvider()
+		underlying.Store.ErrPut = errTest
+		underlying.Store.ErrGet = errTest
+		underlying.Store.ErrDelete = errTest
+		underlying.Store.ErrBatch = errTest
+
+		store, err := NewProvider(underlying, metricsProvider).OpenStore("test")
+		require.NoError(t, err)
+
+		require.True(t, errors.Is(store.Put("k1", []byte("v1")), errTest))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, errTest))
+
+		require.True(t, errors.Is(store.Delete("k1"), errTest))
+		require.True(t, errors.Is(store.Batch([]storage.Operation{{Key: "k1"}}), errTest))
+	})
+
+	t.Run("Test instrumented store traces the operations", func(t *testing.T) {
+		tracer := &mocktracing.Tracer{}
+
+		underlying := mockstorage.NewMockStoreProvider()
+		underlying.Store.ErrGet = errTest
+
+		store, err := NewProvider(underlying, nil, WithTracer(tracer)).OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, errTest))
+
+		spans := tracer.Spans(tracing.StorageSpan)
+		require.Len(t, spans, 2)
+		require.Equal(t, tracing.Attributes{
+			tracing.StoreAttribute: "test", tracing.OperationAttribute: putOperation,
+		}, spans[0].Attributes)
+		require.NoError(t, spans[0].Err)
+		require.True(t, spans[0].Ended)
+		require.Equal(t, getOperation, spans[1].Attributes[tracing.OperationAttribute])
+		require.True(t, errors.Is(spans[1].Err, errTest))
+	})
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("Test instrumented provider store sizes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		metricsProvider, r := newMetricsProvider(ctrl)
+		r.durations.EXPECT().Observe(gomock.Any(), gomock.Any()).AnyTimes()
+		r.records.EXPECT().Set(float64(2), metrics.Labels{metrics.StoreLabel: "connections"})
+		r.records.EXPECT().Set(float64(0), metrics.Labels{metrics.StoreLabel: "credentials"})
+
+		prov := NewProvider(mem.NewProvider(), metricsProvider)
+
+		connections, err := prov.OpenStore("connections")
+		require.NoError(t, err)
+
+		require.NoError(t, connections.Put("k1", []byte("v1")))
+		require.NoError(t, connections.Put("k2", []byte("v2")))
+
+		_, err = prov.OpenStore("credentials")
+		require.NoError(t, err)
+
+		_, err = prov.OpenStore("closed")
+		require.NoError(t, err)
+		require.NoError(t, prov.CloseStore("closed"))
+
+		require.NoError(t, prov.RecordStoreSizes())
+
+		require.NoError(t, prov.Close())
+		require.NoError(t, prov.RecordStoreSizes())
+	})
+
+	t.Run("Test instrumented provider errors", func(t *testing.T) {
+		underlying := mockstorage.NewMockStoreProvider()
+		prov := NewProvider(underlying, nil)
+
+		_, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		underlying.Store.ErrItr = errTest
+		require.EqualError(t, prov.RecordStoreSizes(), "failed to count records of store test: test error")
+
+		prov = NewProvider(&mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errTest,
+			ErrCloseStore:      errTest,
+			ErrClose:           errTest,
+		}, nil)
+
+		_, err = prov.OpenStore("test")
+		require.EqualError(t, err, errTest.Error())
+		require.EqualError(t, prov.CloseStore("test"), errTest.Error())
+		require.EqualError(t, prov.Close(), errTest.Error())
+	})
+	t.Run("Test instrumented provider flush", func(t *testing.T) {
+		require.NoError(t, NewProvider(mem.NewProvider(), nil).Flush())
+
+		underlying := &flushingProvider{Provider: mem.NewProvider(), err: errTest}
+		require.EqualError(t, NewProvider(underlying, nil).Flush(), errTest.Error())
+		require.Equal(t, 1, underlying.flushes)
+	})
+}
+
+type flushingProvider struct {
+	storage.Provider
+	flushes int
+	err     error
+}
+
+func (p *flushingProvider) Flush() error {
+	p.flushes++
+
+	return p.err
+}
@@ -82,3 +82,18 @@ func (b *StorePrefixWrapper) Delete(k string) error {
 
 	return b.store.Delete(k)
 }
+
+// Batch performs the batch operations on the records with the keys prefixed with IDPrefix.
+func (b *StorePrefixWrapper) Batch(operations []storage.Operation) error {
+	prefixed := make([]storage.Operation, len(operations))
+
+	for i, op := range operations {
+		if op.Key != "" {
+			op.Key = b.prefix + op.Key
+		}
+
+		prefixed[i] = op
+	}
+
+	return b.store.Batch(prefixed)
+}
@@ -262,3 +262,24 @@ func TestStorePrefixWrapper_Delete(t *testing.T) {
 	require.EqualError(t, err, storage.ErrDataNotFound.Error())
 	require.Empty(t, doc)
 }
+
+func TestStorePrefixWrapper_Batch(t *testing.T) {
+	memStore, err := mem.NewProvider().OpenStore(uuid.New().String())
+	require.NoError(t, err)
+
+	store, err := NewPrefixStoreWrapper(memStore, "p")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("k1", []byte("v1")))
+
+	require.NoError(t, store.Batch([]storage.Operation{{Key: "k1"}, {Key: "k2", Value: []byte("v2")}}))
+
+	_, err = memStore.Get("pk1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	v, err := memStore.Get("pk2")
+	require.NoError(t, err)
+	require.Equal(t, "v2", string(v))
+
+	require.EqualError(t, store.Batch([]storage.Operation{{Key: ""}}), "key is mandatory")
+}
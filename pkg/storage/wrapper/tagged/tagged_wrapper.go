@@ -0,0 +1,270 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tagged offers a storage.Provider wrapper indexing the records of any underlying provider by tag, so that
+// all the records related to something (e.g a connection or a subject) are deleted at once, without scanning the
+// records of the stores.
+//
+// The tag index is kept in a companion store of the underlying provider. Combined with the encrypted wrapper and its
+// data keys (the tagged provider wrapping the encrypted one), deleting the records of a tag destroys their data keys:
+// the records can no longer be decrypted from any copy of the underlying stores (crypto-shredding).
+package tagged
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// tagStoreSuffix is appended to the name of a store to name the store of its tag index.
+	tagStoreSuffix = "_tags"
+
+	// separator separates the tags from the keys in the tag index, it is not allowed in the tags.
+	separator = "\x1f"
+
+	// the tag index maps each tag to the keys of its records, and each tagged record to its tags.
+	tagPrefix    = "t" + separator
+	recordPrefix = "r" + separator
+
+	failOpenUnderlyingStore = "failed to open underlying store: %w"
+	failPutTags             = "failed to put record tags: %w"
+	failGetTags             = "failed to get record tags: %w"
+	failDeleteTags          = "failed to delete record tags: %w"
+	failDeleteTaggedRecords = "failed to delete tagged records: %w"
+)
+
+// Provider is a storage provider wrapper indexing the records of the underlying provider by tag.
+type Provider struct {
+	provider storage.Provider
+}
+
+// NewProvider instantiates a Provider indexing the records of provider by tag.
+func NewProvider(provider storage.Provider) *Provider {
+	return &Provider{provider: provider}
+}
+
+// OpenStore opens the store with the given name and its tag store in the underlying provider.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	store, err := p.provider.OpenStore(name)
+	if err != nil {
+		return nil, fmt.Errorf(failOpenUnderlyingStore, err)
+	}
+
+	tags, err := p.provider.OpenStore(name + tagStoreSuffix)
+	if err != nil {
+		return nil, fmt.Errorf(failOpenUnderlyingStore, err)
+	}
+
+	return &taggedStore{store: store, tags: tags}, nil
+}
+
+// CloseStore closes the store with the given name and its tag store in the underlying provider.
+func (p *Provider) CloseStore(name string) error {
+	if err := p.provider.CloseStore(name); err != nil {
+		return err
+	}
+
+	return p.provider.CloseStore(name + tagStoreSuffix)
+}
+
+// Close closes all stores created in the underlying provider.
+func (p *Provider) Close() error {
+	return p.provider.Close()
+}
+
+type taggedStore struct {
+	store storage.Store
+	tags  storage.Store
+}
+
+// Put stores the record, which has no tags.
+func (s *taggedStore) Put(k string, v []byte) error {
+	if err := s.store.Put(k, v); err != nil {
+		return err
+	}
+
+	return s.untag(k)
+}
+
+// PutWithTags stores the record, indexed by the tags.
+func (s *taggedStore) PutWithTags(k string, v []byte, tags ...string) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	for _, tag := range tags {
+		if tag == "" || strings.Contains(tag, separator) {
+			return errors.New("tags must be non-empty and can't contain the unit separator character")
+		}
+	}
+
+	operations, err := s.untagOperations(k)
+	if err != nil {
+		return err
+	}
+
+	tags = dedupe(tags)
+
+	tagsBytes, err := json.Marshal(tags)
+	if err != nil {
+		return fmt.Errorf(failPutTags, err)
+	}
+
+	for _, tag := range tags {
+		operations = append(operations, storage.Operation{Key: tagKey(tag, k), Value: []byte(k)})
+	}
+
+	operations = append(operations, storage.Operation{Key: recordPrefix + k, Value: tagsBytes})
+
+	// the tags are stored first, so that a tagged record is never left out of the index
+	if err = s.tags.Batch(operations); err != nil {
+		return fmt.Errorf(failPutTags, err)
+	}
+
+	return s.store.Put(k, v)
+}
+
+// Get fetches the record based on key.
+func (s *taggedStore) Get(k string) ([]byte, error) {
+	return s.store.Get(k)
+}
+
+// Iterator returns an iterator over the records of the given key range.
+func (s *taggedStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	return s.store.Iterator(startKey, endKey)
+}
+
+// Delete deletes the record and its tags.
+func (s *taggedStore) Delete(k string) error {
+	if err := s.store.Delete(k); err != nil {
+		return err
+	}
+
+	return s.untag(k)
+}
+
+// Batch performs the batch operations, the records put by the batch have no tags.
+func (s *taggedStore) Batch(operations []storage.Operation) error {
+	if err := s.store.Batch(operations); err != nil {
+		return err
+	}
+
+	keys := make([]string, len(operations))
+
+	for i, op := range operations {
+		keys[i] = op.Key
+	}
+
+	return s.untag(keys...)
+}
+
+// DeleteByTag deletes the records having the tag, found in the tag index.
+func (s *taggedStore) DeleteByTag(tag string) error {
+	itr := s.tags.Iterator(tagPrefix+tag+separator, tagPrefix+tag+separator+storage.EndKeySuffix)
+	defer itr.Release()
+
+	var (
+		keys      []string
+		deletions []storage.Operation
+	)
+
+	for itr.Next() {
+		keys = append(keys, string(itr.Value()))
+		deletions = append(deletions, storage.Operation{Key: string(itr.Value())})
+	}
+
+	if err := itr.Error(); err != nil {
+		return fmt.Errorf(failGetTags, err)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// the records are deleted first, so that a tagged record is never left out of the index
+	if err := s.store.Batch(deletions); err != nil {
+		return fmt.Errorf(failDeleteTaggedRecords, err)
+	}
+
+	return s.untag(keys...)
+}
+
+// Count counts the records of the key range, natively if the underlying store supports it.
+func (s *taggedStore) Count(startKey, endKey string) (int, error) {
+	return storage.Count(s.store, startKey, endKey)
+}
+
+// untag removes the records with the keys from the tag index.
+func (s *taggedStore) untag(keys ...string) error {
+	var operations []storage.Operation
+
+	for _, k := range keys {
+		ops, err := s.untagOperations(k)
+		if err != nil {
+			return err
+		}
+
+		operations = append(operations, ops...)
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	if err := s.tags.Batch(operations); err != nil {
+		return fmt.Errorf(failDeleteTags, err)
+	}
+
+	return nil
+}
+
+// untagOperations returns the operations removing the record with the key from the tag index.
+func (s *taggedStore) untagOperations(k string) ([]storage.Operation, error) {
+	tagsBytes, err := s.tags.Get(recordPrefix + k)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(failGetTags, err)
+	}
+
+	var tags []string
+
+	if err = json.Unmarshal(tagsBytes, &tags); err != nil {
+		return nil, fmt.Errorf(failGetTags, err)
+	}
+
+	operations := make([]storage.Operation, 0, len(tags)+1)
+
+	for _, tag := range tags {
+		operations = append(operations, storage.Operation{Key: tagKey(tag, k)})
+	}
+
+	return append(operations, storage.Operation{Key: recordPrefix + k}), nil
+}
+
+func tagKey(tag, k string) string {
+	return tagPrefix + tag + separator + k
+}
+
+func dedupe(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	unique := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		if _, ok := seen[tag]; !ok {
+			seen[tag] = struct{}{}
+			unique = append(unique, tag)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,201 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tagged
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/mac"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/encrypted"
+)
+
+var errTest = errors.New("test error")
+
+func requireKeys(t *testing.T, store storage.Store, expected ...string) {
+	t.Helper()
+
+	itr := store.Iterator("", storage.EndKeySuffix)
+	defer itr.Release()
+
+	var keys []string
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+
+	require.NoError(t, itr.Error())
+	require.ElementsMatch(t, expected, keys)
+}
+
+func TestTaggedStore(t *testing.T) {
+	t.Run("Test delete by tag", func(t *testing.T) {
+		store, err := NewProvider(mem.NewProvider()).OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTags(store, "k1", []byte("v1"), "conn1", "subject1"))
+		require.NoError(t, storage.PutWithTags(store, "k2", []byte("v2"), "conn1", "conn1"))
+		require.NoError(t, storage.PutWithTags(store, "k3", []byte("v3"), "conn2", "subject1"))
+		require.NoError(t, store.Put("k4", []byte("v4")))
+
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, "v1", string(v))
+
+		require.NoError(t, storage.DeleteByTag(store, "conn1"))
+		requireKeys(t, store, "k3", "k4")
+
+		// the tags of the deleted records were removed
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, storage.DeleteByTag(store, "subject1"))
+		requireKeys(t, store, "k1", "k4")
+
+		require.NoError(t, storage.DeleteByTag(store, "unknown"))
+		requireKeys(t, store, "k1", "k4")
+
+		count, err := storage.Count(store, "", storage.EndKeySuffix)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("Test tags updated with the records", func(t *testing.T) {
+		underlying := mem.NewProvider()
+
+		store, err := NewProvider(underlying).OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTags(store, "k1", []byte("v1"), "tag1", "tag2"))
+		require.NoError(t, storage.PutWithTags(store, "k1", []byte("v1"), "tag2", "tag3"))
+
+		tags, err := underlying.OpenStore("test" + tagStoreSuffix)
+		require.NoError(t, err)
+		requireKeys(t, tags, recordPrefix+"k1", tagKey("tag2", "k1"), tagKey("tag3", "k1"))
+
+		// the records put without tags lose their tags
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		requireKeys(t, tags)
+
+		require.NoError(t, storage.PutWithTags(store, "k1", []byte("v1"), "tag1"))
+		require.NoError(t, storage.PutWithTags(store, "k2", []byte("v2"), "tag1"))
+		require.NoError(t, storage.PutWithTags(store, "k3", []byte("v3"), "tag1"))
+
+		require.NoError(t, store.Delete("k1"))
+		require.NoError(t, store.Batch([]storage.Operation{{Key: "k2", Value: []byte("v2")}}))
+		requireKeys(t, tags, recordPrefix+"k3", tagKey("tag1", "k3"))
+
+		require.EqualError(t, storage.PutWithTags(store, "", []byte("v1"), "tag1"), "key and value are mandatory")
+
+		for _, tag := range []string{"", "tag" + separator} {
+			require.EqualError(t, storage.PutWithTags(store, "k1", []byte("v1"), tag),
+				"tags must be non-empty and can't contain the unit separator character")
+		}
+	})
+
+	t.Run("Test crypto-shredding by tag", func(t *testing.T) {
+		encKH, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+		require.NoError(t, err)
+
+		macKH, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+		require.NoError(t, err)
+
+		crypto, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		underlying, keys := mem.NewProvider(), mem.NewProvider()
+
+		store, err := NewProvider(encrypted.NewProvider(underlying, crypto, encKH, macKH,
+			encrypted.WithDataKeys(keys))).OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTags(store, "k1", []byte("v1"), "conn1"))
+		require.NoError(t, storage.PutWithTags(store, "k2", []byte("v2"), "conn2"))
+
+		keyStore, err := keys.OpenStore("test")
+		require.NoError(t, err)
+
+		count, err := storage.Count(keyStore, "", storage.EndKeySuffix)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		require.NoError(t, storage.DeleteByTag(store, "conn1"))
+		requireKeys(t, store, "k2")
+
+		// the data key of the deleted record was destroyed
+		count, err = storage.Count(keyStore, "", storage.EndKeySuffix)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("Test underlying store errors", func(t *testing.T) {
+		underlying := mockstorage.NewMockStoreProvider()
+
+		store, err := NewProvider(underlying).OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, storage.PutWithTags(store, "k1", []byte("v1"), "tag1"))
+
+		underlying.Store.ErrGet = errTest
+		require.EqualError(t, store.Put("k1", []byte("v1")), "failed to get record tags: test error")
+		require.EqualError(t, storage.PutWithTags(store, "k1", []byte("v1"), "tag1"),
+			"failed to get record tags: test error")
+
+		underlying.Store.ErrGet = nil
+		underlying.Store.ErrBatch = errTest
+		require.EqualError(t, storage.PutWithTags(store, "k1", []byte("v1"), "tag1"),
+			"failed to put record tags: test error")
+		require.EqualError(t, store.Delete("k1"), "failed to delete record tags: test error")
+		require.EqualError(t, storage.DeleteByTag(store, "tag1"), "failed to delete tagged records: test error")
+		require.EqualError(t, store.Batch(nil), errTest.Error())
+
+		underlying.Store.ErrBatch = nil
+		underlying.Store.Store[recordPrefix+"k1"] = []byte("{")
+		require.Contains(t, store.Delete("k1").Error(), "failed to get record tags")
+
+		underlying.Store.ErrItr = errTest
+		require.EqualError(t, storage.DeleteByTag(store, "tag1"), "failed to get record tags: test error")
+
+		underlying.Store.ErrPut = errTest
+		require.EqualError(t, store.Put("k1", []byte("v1")), errTest.Error())
+
+		underlying.Store.ErrDelete = errTest
+		require.EqualError(t, store.Delete("k1"), errTest.Error())
+	})
+}
+
+func TestProvider(t *testing.T) {
+	p := NewProvider(mockstorage.NewMockStoreProvider())
+
+	_, err := p.OpenStore("test")
+	require.NoError(t, err)
+	require.NoError(t, p.CloseStore("test"))
+	require.NoError(t, p.Close())
+
+	p = NewProvider(&mockstorage.MockStoreProvider{
+		Store:              mockstorage.NewMockStoreProvider().Store,
+		ErrOpenStoreHandle: errTest,
+		ErrCloseStore:      errTest,
+	})
+
+	_, err = p.OpenStore("test")
+	require.EqualError(t, err, "failed to open underlying store: test error")
+	require.EqualError(t, p.CloseStore("test"), errTest.Error())
+
+	p = NewProvider(&mockstorage.MockStoreProvider{
+		Store:         mockstorage.NewMockStoreProvider().Store,
+		FailNamespace: "test" + tagStoreSuffix,
+	})
+
+	_, err = p.OpenStore("test")
+	require.Error(t, err)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tenant offers storage.Provider wrappers isolating the stores of the tenants (e.g wallets) hosted by a
+// single agent process in a shared underlying provider.
+//
+// The stores of a tenant are opened in the underlying provider under the name of the store prefixed with the tenant
+// ID, so the records of a tenant are never visible to the others. Combined with the encrypted wrapper, each tenant
+// encrypts its records with its own keys.
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/encrypted"
+)
+
+// separator separates the tenant ID from the store name, it is not allowed in the tenant IDs.
+const separator = "_"
+
+// tenantIDPattern is the format of the tenant IDs. Since the tenant IDs don't contain the separator, the names of the
+// stores of two tenants never collide.
+var tenantIDPattern = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+// KeysFunc returns the AEAD and MAC key handles encrypting the records of the tenant (see encrypted.NewProvider).
+type KeysFunc func(tenantID string) (encKH, macKH interface{}, err error)
+
+// Option configures the tenant manager.
+type Option func(m *Manager)
+
+// WithEncryption encrypts the records of each tenant with crypto and the keys of the tenant returned by keys.
+func WithEncryption(crypto crypto.Crypto, keys KeysFunc) Option {
+	return func(m *Manager) {
+		m.crypto = crypto
+		m.keys = keys
+	}
+}
+
+// Manager returns the storage providers of the tenants sharing the underlying provider.
+type Manager struct {
+	provider storage.Provider
+	crypto   crypto.Crypto
+	keys     KeysFunc
+}
+
+// NewManager instantiates a Manager of the tenants sharing provider.
+func NewManager(provider storage.Provider, opts ...Option) *Manager {
+	m := &Manager{provider: provider}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Provider returns the storage provider of the tenant, encrypting its records if the manager was created
+// WithEncryption.
+func (m *Manager) Provider(tenantID string) (storage.Provider, error) {
+	p, err := NewProvider(m.provider, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.keys == nil {
+		return p, nil
+	}
+
+	encKH, macKH, err := m.keys(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keys of tenant %s: %w", tenantID, err)
+	}
+
+	return encrypted.NewProvider(p, m.crypto, encKH, macKH), nil
+}
+
+// Provider is a storage provider wrapper opening the stores of a tenant in the underlying provider.
+type Provider struct {
+	provider storage.Provider
+	tenantID string
+	stores   map[string]struct{}
+	lock     sync.Mutex
+}
+
+// NewProvider instantiates a Provider opening the stores of the tenant in provider. The tenant ID is made of
+// letters, digits and dashes (e.g a UUID).
+func NewProvider(provider storage.Provider, tenantID string) (*Provider, error) {
+	if !tenantIDPattern.MatchString(tenantID) {
+		return nil, errors.New("tenant ID must be made of letters, digits and dashes")
+	}
+
+	return &Provider{
+		provider: provider,
+		tenantID: tenantID,
+		stores:   make(map[string]struct{}),
+	}, nil
+}
+
+// OpenStore opens the store of the tenant with the given name in the underlying provider.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	store, err := p.provider.OpenStore(p.storeName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	p.stores[name] = struct{}{}
+	p.lock.Unlock()
+
+	return store, nil
+}
+
+// CloseStore closes the store of the tenant with the given name in the underlying provider.
+func (p *Provider) CloseStore(name string) error {
+	p.lock.Lock()
+	delete(p.stores, name)
+	p.lock.Unlock()
+
+	return p.provider.CloseStore(p.storeName(name))
+}
+
+// Close closes the stores of the tenant, the stores of the other tenants are left open.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for name := range p.stores {
+		if err := p.provider.CloseStore(p.storeName(name)); err != nil {
+			return fmt.Errorf("failed to close store %s: %w", name, err)
+		}
+
+		delete(p.stores, name)
+	}
+
+	return nil
+}
+
+func (p *Provider) storeName(name string) string {
+	return p.tenantID + separator + name
+}
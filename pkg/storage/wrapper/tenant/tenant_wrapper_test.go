@@ -0,0 +1,163 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tenant
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/mac"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+var errTest = errors.New("test error")
+
+func TestProvider(t *testing.T) {
+	t.Run("Test tenant stores isolation", func(t *testing.T) {
+		underlying := mem.NewProvider()
+
+		alice, err := NewProvider(underlying, "alice")
+		require.NoError(t, err)
+
+		bob, err := NewProvider(underlying, "bob")
+		require.NoError(t, err)
+
+		aliceStore, err := alice.OpenStore("connections")
+		require.NoError(t, err)
+
+		bobStore, err := bob.OpenStore("connections")
+		require.NoError(t, err)
+
+		require.NoError(t, aliceStore.Put("k1", []byte("alice")))
+
+		_, err = bobStore.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		require.False(t, bobStore.Iterator("", storage.EndKeySuffix).Next())
+
+		require.NoError(t, bobStore.Put("k1", []byte("bob")))
+
+		v, err := aliceStore.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, "alice", string(v))
+
+		underlyingStore, err := underlying.OpenStore("alice_connections")
+		require.NoError(t, err)
+
+		v, err = underlyingStore.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, "alice", string(v))
+	})
+
+	t.Run("Test tenant provider close", func(t *testing.T) {
+		underlying := mockstorage.NewMockStoreProvider()
+
+		p, err := NewProvider(underlying, "alice")
+		require.NoError(t, err)
+
+		_, err = p.OpenStore("connections")
+		require.NoError(t, err)
+
+		_, err = p.OpenStore("credentials")
+		require.NoError(t, err)
+
+		require.NoError(t, p.CloseStore("credentials"))
+		require.NoError(t, p.Close())
+		require.Empty(t, p.stores)
+	})
+
+	t.Run("Test invalid tenant ID", func(t *testing.T) {
+		for _, tenantID := range []string{"", "alice_1", "alice/1"} {
+			_, err := NewProvider(mem.NewProvider(), tenantID)
+			require.EqualError(t, err, "tenant ID must be made of letters, digits and dashes")
+		}
+	})
+
+	t.Run("Test tenant provider errors", func(t *testing.T) {
+		p, err := NewProvider(&mockstorage.MockStoreProvider{
+			Store:              mockstorage.NewMockStoreProvider().Store,
+			ErrOpenStoreHandle: errTest,
+			ErrCloseStore:      errTest,
+		}, "alice")
+		require.NoError(t, err)
+
+		_, err = p.OpenStore("connections")
+		require.EqualError(t, err, errTest.Error())
+		require.EqualError(t, p.CloseStore("connections"), errTest.Error())
+
+		p.stores["connections"] = struct{}{}
+		require.EqualError(t, p.Close(), "failed to close store connections: test error")
+	})
+}
+
+func TestManager(t *testing.T) {
+	t.Run("Test tenant providers", func(t *testing.T) {
+		p, err := NewManager(mem.NewProvider()).Provider("alice")
+		require.NoError(t, err)
+		require.IsType(t, &Provider{}, p)
+
+		_, err = NewManager(mem.NewProvider()).Provider("alice_1")
+		require.Error(t, err)
+	})
+
+	t.Run("Test encrypted tenant providers", func(t *testing.T) {
+		crypto, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		keys := make(map[string][]interface{})
+
+		for _, tenantID := range []string{"alice", "bob"} {
+			encKH, e := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+			require.NoError(t, e)
+
+			macKH, e := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+			require.NoError(t, e)
+
+			keys[tenantID] = []interface{}{encKH, macKH}
+		}
+
+		underlying := mem.NewProvider()
+		manager := NewManager(underlying, WithEncryption(crypto, func(tenantID string) (interface{}, interface{}, error) {
+			if k, ok := keys[tenantID]; ok {
+				return k[0], k[1], nil
+			}
+
+			return nil, nil, errTest
+		}))
+
+		alice, err := manager.Provider("alice")
+		require.NoError(t, err)
+
+		aliceStore, err := alice.OpenStore("connections")
+		require.NoError(t, err)
+
+		require.NoError(t, aliceStore.Put("k1", []byte("alice")))
+
+		v, err := aliceStore.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, "alice", string(v))
+
+		// the record is encrypted with the keys of the tenant
+		underlyingStore, err := underlying.OpenStore("alice_connections")
+		require.NoError(t, err)
+
+		_, err = underlyingStore.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = manager.Provider("carol")
+		require.EqualError(t, err, "failed to get keys of tenant carol: test error")
+
+		_, err = manager.Provider("alice_1")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+// AutoAcceptKind is the kind of inbound protocol message which may be accepted without triggering an action event.
+type AutoAcceptKind string
+
+const (
+	// AutoAcceptDIDExchangeRequest is the didexchange request received by the inviter.
+	AutoAcceptDIDExchangeRequest AutoAcceptKind = "didexchange-request"
+	// AutoAcceptCredentialOffer is the issuecredential offer received by the holder.
+	AutoAcceptCredentialOffer AutoAcceptKind = "credential-offer"
+	// AutoAcceptPresentationRequest is the presentproof request received by the prover.
+	AutoAcceptPresentationRequest AutoAcceptKind = "presentation-request"
+)
+
+// AutoAccept tells for every configured kind of message whether it is accepted automatically.
+// The kinds which are not in the map fall back to the next configuration level.
+type AutoAccept map[AutoAcceptKind]bool
+
+// AutoAcceptConfig is the auto-accept configuration of the agent. The auto-accept behavior is resolved from
+// the AutoAccept of the connection record first, then from the goal code of the invitation the connection
+// was established from and finally from the global configuration.
+type AutoAcceptConfig struct {
+	// Global applies to all the connections.
+	Global AutoAccept
+	// GoalCodes apply to the connections established from the invitations with the given goal codes.
+	GoalCodes map[string]AutoAccept
+}
+
+// Resolve resolves whether the given kind of message is accepted automatically for the connection record.
+// The second return value is false when the kind of message is not configured at any level.
+func (c *AutoAcceptConfig) Resolve(kind AutoAcceptKind, record *Record) (bool, bool) {
+	if record != nil {
+		if accept, ok := record.AutoAccept[kind]; ok {
+			return accept, true
+		}
+	}
+
+	if c == nil {
+		return false, false
+	}
+
+	if record != nil && record.GoalCode != "" {
+		if accept, ok := c.GoalCodes[record.GoalCode][kind]; ok {
+			return accept, true
+		}
+	}
+
+	accept, ok := c.Global[kind]
+
+	return accept, ok
+}
+
+// AutoAcceptSource is implemented by the providers supplying the auto-accept configuration (e.g the framework
+// context).
+type AutoAcceptSource interface {
+	AutoAcceptConfig() *AutoAcceptConfig
+}
+
+// AutoAccepter resolves the auto-accept behavior for the protocol services.
+type AutoAccepter struct {
+	config *AutoAcceptConfig
+	lookup *Lookup
+}
+
+// NewAutoAccepter returns the auto-accepter of the given provider. The configuration is taken from
+// the provider when it is an AutoAcceptSource, the connection records are looked up when it supplies
+// the connection stores. Otherwise, nothing is accepted automatically.
+func NewAutoAccepter(p interface{}) (*AutoAccepter, error) {
+	a := &AutoAccepter{}
+
+	if src, ok := p.(AutoAcceptSource); ok {
+		a.config = src.AutoAcceptConfig()
+	}
+
+	if prov, ok := p.(provider); ok {
+		lookup, err := NewLookup(prov)
+		if err != nil {
+			return nil, err
+		}
+
+		a.lookup = lookup
+	}
+
+	return a, nil
+}
+
+// Resolve resolves whether the given kind of message is accepted automatically for the connection record.
+// The second return value is false when the kind of message is not configured at any level.
+func (a *AutoAccepter) Resolve(kind AutoAcceptKind, record *Record) (bool, bool) {
+	return a.config.Resolve(kind, record)
+}
+
+// ResolveByDIDs resolves whether the given kind of message is accepted automatically for the connection
+// between myDID and theirDID. The second return value is false when the kind of message is not configured
+// at any level.
+func (a *AutoAccepter) ResolveByDIDs(kind AutoAcceptKind, myDID, theirDID string) (bool, bool) {
+	return a.config.Resolve(kind, a.recordByDIDs(myDID, theirDID))
+}
+
+func (a *AutoAccepter) recordByDIDs(myDID, theirDID string) *Record {
+	if a.lookup == nil {
+		return nil
+	}
+
+	connectionID, err := a.lookup.GetConnectionIDByDIDs(myDID, theirDID)
+	if err != nil {
+		return nil
+	}
+
+	record, err := a.lookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		return nil
+	}
+
+	return record
+}
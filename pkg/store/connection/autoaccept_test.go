@@ -0,0 +1,168 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+const goalCode = "aries.vc.issue"
+
+type autoAcceptProvider struct {
+	mockProvider
+	config *AutoAcceptConfig
+}
+
+func (p *autoAcceptProvider) AutoAcceptConfig() *AutoAcceptConfig {
+	return p.config
+}
+
+func TestAutoAcceptConfig_Resolve(t *testing.T) {
+	config := &AutoAcceptConfig{
+		Global: AutoAccept{AutoAcceptCredentialOffer: true, AutoAcceptPresentationRequest: true},
+		GoalCodes: map[string]AutoAccept{
+			goalCode: {AutoAcceptCredentialOffer: false, AutoAcceptDIDExchangeRequest: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		config   *AutoAcceptConfig
+		kind     AutoAcceptKind
+		record   *Record
+		accept   bool
+		resolved bool
+	}{{
+		name:     "global",
+		config:   config,
+		kind:     AutoAcceptCredentialOffer,
+		accept:   true,
+		resolved: true,
+	}, {
+		name:     "not configured",
+		config:   config,
+		kind:     AutoAcceptDIDExchangeRequest,
+		record:   &Record{GoalCode: "unknown"},
+		resolved: false,
+	}, {
+		name:     "goal code",
+		config:   config,
+		kind:     AutoAcceptCredentialOffer,
+		record:   &Record{GoalCode: goalCode},
+		accept:   false,
+		resolved: true,
+	}, {
+		name:     "goal code falls back to global",
+		config:   config,
+		kind:     AutoAcceptPresentationRequest,
+		record:   &Record{GoalCode: goalCode},
+		accept:   true,
+		resolved: true,
+	}, {
+		name:     "connection",
+		config:   config,
+		kind:     AutoAcceptDIDExchangeRequest,
+		record:   &Record{GoalCode: goalCode, AutoAccept: AutoAccept{AutoAcceptDIDExchangeRequest: false}},
+		accept:   false,
+		resolved: true,
+	}, {
+		name:     "connection without config",
+		kind:     AutoAcceptPresentationRequest,
+		record:   &Record{AutoAccept: AutoAccept{AutoAcceptPresentationRequest: true}},
+		accept:   true,
+		resolved: true,
+	}, {
+		name: "no config",
+		kind: AutoAcceptPresentationRequest,
+	}}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			accept, resolved := tc.config.Resolve(tc.kind, tc.record)
+			require.Equal(t, tc.accept, accept)
+			require.Equal(t, tc.resolved, resolved)
+		})
+	}
+}
+
+func TestAutoAccepter(t *testing.T) {
+	t.Run("resolve by DIDs", func(t *testing.T) {
+		prov := &autoAcceptProvider{
+			mockProvider: mockProvider{
+				store:              mockstorage.NewMockStoreProvider().Store,
+				protocolStateStore: mockstorage.NewMockStoreProvider().Store,
+			},
+			config: &AutoAcceptConfig{Global: AutoAccept{AutoAcceptCredentialOffer: true}},
+		}
+
+		recorder, err := NewRecorder(prov)
+		require.NoError(t, err)
+
+		record := &Record{
+			ThreadID:     threadIDValue,
+			ConnectionID: uuid.New().String(),
+			State:        StateNameCompleted,
+			Namespace:    TheirNSPrefix,
+			MyDID:        "did:mydid:123",
+			TheirDID:     "did:theirdid:123",
+		}
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		accepter, err := NewAutoAccepter(prov)
+		require.NoError(t, err)
+
+		accept, resolved := accepter.ResolveByDIDs(AutoAcceptCredentialOffer, record.MyDID, record.TheirDID)
+		require.True(t, resolved)
+		require.True(t, accept)
+
+		require.NoError(t, recorder.SaveAutoAccept(record.ConnectionID, AutoAccept{AutoAcceptCredentialOffer: false}))
+
+		accept, resolved = accepter.ResolveByDIDs(AutoAcceptCredentialOffer, record.MyDID, record.TheirDID)
+		require.True(t, resolved)
+		require.False(t, accept)
+
+		accept, resolved = accepter.Resolve(AutoAcceptCredentialOffer, nil)
+		require.True(t, resolved)
+		require.True(t, accept)
+
+		// unknown connection falls back to the global configuration
+		accept, resolved = accepter.ResolveByDIDs(AutoAcceptCredentialOffer, "did:mydid:456", record.TheirDID)
+		require.True(t, resolved)
+		require.True(t, accept)
+	})
+
+	t.Run("provider without configuration", func(t *testing.T) {
+		accepter, err := NewAutoAccepter(struct{}{})
+		require.NoError(t, err)
+
+		_, resolved := accepter.ResolveByDIDs(AutoAcceptCredentialOffer, "did:mydid:123", "did:theirdid:123")
+		require.False(t, resolved)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		accepter, err := NewAutoAccepter(&mockProvider{storeError: errors.New("store error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store error")
+		require.Nil(t, accepter)
+	})
+
+	t.Run("save auto accept - connection not found", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		err = recorder.SaveAutoAccept("invalid", AutoAccept{AutoAcceptCredentialOffer: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+}
@@ -57,6 +57,23 @@ type Record struct {
 	InvitationDID   string
 	Implicit        bool
 	Namespace       string
+	// MediaTypeProfiles are the DIDComm media type profiles negotiated with the other party, in order of preference.
+	MediaTypeProfiles []string
+	// PreferredTransport is the URL scheme (eg. "ws", "http") of the transport to use first when sending.
+	PreferredTransport string
+	// LastSeenEndpoint is the last service endpoint a message was successfully delivered to.
+	LastSeenEndpoint string
+	// LastSeenRecipientKeys and LastSeenRoutingKeys are the keys of the last seen endpoint, which messages are
+	// routed with until a delivery fails.
+	LastSeenRecipientKeys []string
+	LastSeenRoutingKeys   []string
+	// GoalCode is the goal code of the out-of-band invitation the connection was established from.
+	GoalCode string
+	// AutoAccept overrides the auto-accept configuration of the agent for this connection.
+	AutoAccept AutoAccept
+	// Protocol is the identifier URI of the protocol the connection was established with (e.g the RFC 0160
+	// connection protocol), empty for did-exchange.
+	Protocol string
 }
 
 // NewLookup returns new connection lookup instance.
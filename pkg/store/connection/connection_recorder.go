@@ -88,6 +88,48 @@ func (c *Recorder) SaveConnectionRecord(record *Record) error {
 	return nil
 }
 
+// SaveTransportPreferences saves negotiated media type profiles and preferred transport for given connection ID.
+func (c *Recorder) SaveTransportPreferences(connectionID string, mediaTypeProfiles []string,
+	preferredTransport string) error {
+	record, err := c.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("save transport preferences: get connection record: %w", err)
+	}
+
+	record.MediaTypeProfiles = mediaTypeProfiles
+	record.PreferredTransport = preferredTransport
+
+	return c.SaveConnectionRecord(record)
+}
+
+// SaveLastSeenDestination saves the service endpoint a message was last delivered to, with its recipient and routing
+// keys, for given connection ID.
+func (c *Recorder) SaveLastSeenDestination(connectionID, endpoint string, recipientKeys, routingKeys []string) error {
+	record, err := c.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("save last seen destination: get connection record: %w", err)
+	}
+
+	record.LastSeenEndpoint = endpoint
+	record.LastSeenRecipientKeys = recipientKeys
+	record.LastSeenRoutingKeys = routingKeys
+
+	return c.SaveConnectionRecord(record)
+}
+
+// SaveAutoAccept saves the auto-accept configuration for given connection ID, it overrides the goal code
+// and the global configuration of the agent.
+func (c *Recorder) SaveAutoAccept(connectionID string, autoAccept AutoAccept) error {
+	record, err := c.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("save auto accept: get connection record: %w", err)
+	}
+
+	record.AutoAccept = autoAccept
+
+	return c.SaveConnectionRecord(record)
+}
+
 // SaveConnectionRecordWithMappings saves newly created connection record against the connection id in the store
 // and it creates mapping from namespaced ThreadID to connection ID.
 func (c *Recorder) SaveConnectionRecordWithMappings(record *Record) error {
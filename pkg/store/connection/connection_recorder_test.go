@@ -17,7 +17,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
-	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
@@ -30,7 +29,7 @@ const (
 
 func Test_NewConnectionRecorder(t *testing.T) {
 	t.Run("create create new recorder - success", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 	})
@@ -73,7 +72,7 @@ func Test_ComputeHash(t *testing.T) {
 
 func Test_RemoveMappings(t *testing.T) {
 	t.Run("test success", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -85,7 +84,7 @@ func Test_RemoveMappings(t *testing.T) {
 		require.NoError(t, err)
 	})
 	t.Run("test failed - empty bytes", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -99,11 +98,11 @@ func Test_RemoveMappings(t *testing.T) {
 	})
 	t.Run("test failed to delete the record", func(t *testing.T) {
 		const errMsg = "get error"
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			StoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+		recorder, err := NewRecorder(&mockProvider{
+			store: &mockstorage.MockStore{
 				Store:     make(map[string][]byte),
 				ErrDelete: fmt.Errorf(errMsg),
-			}),
+			},
 		})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
@@ -137,8 +136,8 @@ func Test_RemoveConnectionRecordsForStates(t *testing.T) {
 			record, store)
 		require.NoError(t, err)
 
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			ProtocolStateStoreProvider: mockstorage.NewCustomMockStoreProvider(store),
+		recorder, err := NewRecorder(&mockProvider{
+			protocolStateStore: store,
 		})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
@@ -154,8 +153,8 @@ func Test_RemoveConnectionRecordsForStates(t *testing.T) {
 			ErrItr: fmt.Errorf(errMsg),
 		}
 
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			ProtocolStateStoreProvider: mockstorage.NewCustomMockStoreProvider(store),
+		recorder, err := NewRecorder(&mockProvider{
+			protocolStateStore: store,
 		})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
@@ -184,8 +183,8 @@ func Test_RemoveConnectionRecordsForStates(t *testing.T) {
 			record, store)
 		require.NoError(t, err)
 
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			ProtocolStateStoreProvider: mockstorage.NewCustomMockStoreProvider(store),
+		recorder, err := NewRecorder(&mockProvider{
+			protocolStateStore: store,
 		})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
@@ -201,8 +200,8 @@ func TestConnectionStore_SaveInvitation(t *testing.T) {
 
 	t.Run("test save invitation success", func(t *testing.T) {
 		store := &mockstorage.MockStore{Store: make(map[string][]byte)}
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			StoreProvider: mockstorage.NewCustomMockStoreProvider(store),
+		recorder, err := NewRecorder(&mockProvider{
+			store: store,
 		})
 		require.NoError(t, err)
 
@@ -237,8 +236,8 @@ func TestConnectionStore_SaveInvitation(t *testing.T) {
 
 	t.Run("test save invitation failure due to invalid key", func(t *testing.T) {
 		store := &mockstorage.MockStore{Store: make(map[string][]byte)}
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			StoreProvider: mockstorage.NewCustomMockStoreProvider(store),
+		recorder, err := NewRecorder(&mockProvider{
+			store: store,
 		})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
@@ -254,7 +253,7 @@ func TestConnectionStore_SaveInvitation(t *testing.T) {
 
 func TestConnectionStore_GetInvitation(t *testing.T) {
 	t.Run("test get invitation - success", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -273,7 +272,7 @@ func TestConnectionStore_GetInvitation(t *testing.T) {
 	})
 
 	t.Run("test get invitation - not found scenario", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -284,7 +283,7 @@ func TestConnectionStore_GetInvitation(t *testing.T) {
 	})
 
 	t.Run("test get invitation - invalid key scenario", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -297,7 +296,7 @@ func TestConnectionStore_GetInvitation(t *testing.T) {
 
 func TestConnectionStore_SaveAndGetEventData(t *testing.T) {
 	t.Run("test save and get event data - success", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -312,7 +311,7 @@ func TestConnectionStore_SaveAndGetEventData(t *testing.T) {
 	})
 
 	t.Run("test get invitation - not found scenario", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -323,7 +322,7 @@ func TestConnectionStore_SaveAndGetEventData(t *testing.T) {
 	})
 
 	t.Run("test get invitation - invalid key scenario", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -335,7 +334,7 @@ func TestConnectionStore_SaveAndGetEventData(t *testing.T) {
 }
 
 func TestConnectionRecordByState(t *testing.T) {
-	recorder, err := NewRecorder(&protocol.MockProvider{})
+	recorder, err := NewRecorder(&mockProvider{})
 	require.NoError(t, err)
 
 	connRec := &Record{
@@ -374,7 +373,7 @@ func TestConnectionRecordByState(t *testing.T) {
 
 func TestConnectionRecorder_SaveConnectionRecord(t *testing.T) {
 	t.Run("save connection record with invited state - success", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -403,7 +402,7 @@ func TestConnectionRecorder_SaveConnectionRecord(t *testing.T) {
 	})
 
 	t.Run("save connection record with invited state - completed", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -437,11 +436,11 @@ func TestConnectionRecorder_SaveConnectionRecord(t *testing.T) {
 
 	t.Run("save connection record error scenario 1", func(t *testing.T) {
 		const errMsg = "get error"
-		record, err := NewRecorder(&protocol.MockProvider{
-			ProtocolStateStoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+		record, err := NewRecorder(&mockProvider{
+			protocolStateStore: &mockstorage.MockStore{
 				Store:  make(map[string][]byte),
 				ErrPut: fmt.Errorf(errMsg),
-			}),
+			},
 		})
 		require.NoError(t, err)
 		connRec := &Record{
@@ -454,11 +453,11 @@ func TestConnectionRecorder_SaveConnectionRecord(t *testing.T) {
 
 	t.Run("save connection record error scenario 2", func(t *testing.T) {
 		const errMsg = "get error"
-		record, err := NewRecorder(&protocol.MockProvider{
-			StoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+		record, err := NewRecorder(&mockProvider{
+			store: &mockstorage.MockStore{
 				Store:  make(map[string][]byte),
 				ErrPut: fmt.Errorf(errMsg),
-			}),
+			},
 		})
 		require.NoError(t, err)
 		connRec := &Record{
@@ -472,7 +471,7 @@ func TestConnectionRecorder_SaveConnectionRecord(t *testing.T) {
 
 func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 	t.Run("save and remove connection record with invited state - completed", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -522,7 +521,7 @@ func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 		require.Contains(t, err.Error(), "data not found")
 	})
 	t.Run("try to remove unexisting connection record", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -541,11 +540,11 @@ func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 	})
 	t.Run("save and remove connection record - failed to delete from the store", func(t *testing.T) {
 		const errMsg = "get error"
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			StoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+		recorder, err := NewRecorder(&mockProvider{
+			store: &mockstorage.MockStore{
 				Store:     make(map[string][]byte),
 				ErrDelete: fmt.Errorf(errMsg),
-			}),
+			},
 		})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
@@ -567,11 +566,11 @@ func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 	})
 	t.Run("save and remove connection record - failed to delete from the protocol state store", func(t *testing.T) {
 		const errMsg = "get error"
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			ProtocolStateStoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+		recorder, err := NewRecorder(&mockProvider{
+			protocolStateStore: &mockstorage.MockStore{
 				Store:     make(map[string][]byte),
 				ErrDelete: fmt.Errorf(errMsg),
-			}),
+			},
 		})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
@@ -593,11 +592,11 @@ func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 	})
 	t.Run("save and remove connection record - failed to iterate connection states records", func(t *testing.T) {
 		const errMsg = "get error"
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			ProtocolStateStoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+		recorder, err := NewRecorder(&mockProvider{
+			protocolStateStore: &mockstorage.MockStore{
 				Store:  make(map[string][]byte),
 				ErrItr: fmt.Errorf(errMsg),
-			}),
+			},
 		})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
@@ -618,7 +617,7 @@ func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 		require.Contains(t, err.Error(), errMsg)
 	})
 	t.Run("save and remove connection record - failed to delete connection mapping record", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
@@ -641,7 +640,7 @@ func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 
 func TestConnectionRecorder_ConnectionRecordMappings(t *testing.T) {
 	t.Run("get connection record by namespace threadID in my namespace", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 
 		require.NotNil(t, recorder)
@@ -660,7 +659,7 @@ func TestConnectionRecorder_ConnectionRecordMappings(t *testing.T) {
 		require.Equal(t, connRec, storedRecord)
 	})
 	t.Run("get connection record by namespace threadID their namespace", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 		connRec := &Record{
@@ -678,7 +677,7 @@ func TestConnectionRecorder_ConnectionRecordMappings(t *testing.T) {
 		require.Equal(t, connRec, storedRecord)
 	})
 	t.Run("save connection record with mapping - validation failure", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 
 		require.NotNil(t, recorder)
@@ -692,11 +691,11 @@ func TestConnectionRecorder_ConnectionRecordMappings(t *testing.T) {
 	})
 	t.Run("save connection record with mapping - store failure", func(t *testing.T) {
 		const errMsg = "put error"
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			ProtocolStateStoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+		recorder, err := NewRecorder(&mockProvider{
+			protocolStateStore: &mockstorage.MockStore{
 				Store:  make(map[string][]byte),
 				ErrPut: fmt.Errorf(errMsg),
-			}),
+			},
 		})
 
 		require.NotNil(t, recorder)
@@ -711,7 +710,7 @@ func TestConnectionRecorder_ConnectionRecordMappings(t *testing.T) {
 		require.Contains(t, err.Error(), errMsg)
 	})
 	t.Run("save connection record with mapping - namespace error", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 
 		require.NotNil(t, recorder)
@@ -724,7 +723,7 @@ func TestConnectionRecorder_ConnectionRecordMappings(t *testing.T) {
 		require.Contains(t, err.Error(), "namespace not supported")
 	})
 	t.Run("data not found error due to missing input parameter", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 		connRec, err := recorder.GetConnectionRecordByNSThreadID("")
@@ -747,7 +746,7 @@ func TestConnectionRecorder_CreateNSKeys(t *testing.T) {
 
 func TestConnectionRecorder_SaveNamespaceThreadID(t *testing.T) {
 	t.Run("missing required parameters", func(t *testing.T) {
-		recorder, err := NewRecorder(&protocol.MockProvider{})
+		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
 
 		require.NotNil(t, recorder)
@@ -835,3 +834,46 @@ type mockInvitation struct {
 	Type            string            `json:"@type,omitempty"`
 	Thread          *decorator.Thread `json:"~thread,omitempty"`
 }
+
+func TestConnectionRecorder_SaveTransportPreferences(t *testing.T) {
+	t.Run("save transport preferences and last seen endpoint - success", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		record := &Record{
+			ThreadID:     threadIDValue,
+			ConnectionID: uuid.New().String(),
+			State:        StateNameCompleted,
+			Namespace:    TheirNSPrefix,
+			MyDID:        "did:mydid:123",
+			TheirDID:     "did:theirdid:123",
+		}
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		require.NoError(t, recorder.SaveTransportPreferences(record.ConnectionID,
+			[]string{"didcomm/aip2;env=rfc19"}, "ws"))
+		require.NoError(t, recorder.SaveLastSeenDestination(record.ConnectionID, "ws://example.com",
+			[]string{"recipient-key"}, []string{"routing-key"}))
+
+		recordFound, err := recorder.GetConnectionRecord(record.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"didcomm/aip2;env=rfc19"}, recordFound.MediaTypeProfiles)
+		require.Equal(t, "ws", recordFound.PreferredTransport)
+		require.Equal(t, "ws://example.com", recordFound.LastSeenEndpoint)
+		require.Equal(t, []string{"recipient-key"}, recordFound.LastSeenRecipientKeys)
+		require.Equal(t, []string{"routing-key"}, recordFound.LastSeenRoutingKeys)
+	})
+
+	t.Run("save transport preferences - connection not found", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		err = recorder.SaveTransportPreferences("invalid", nil, "http")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+
+		err = recorder.SaveLastSeenDestination("invalid", "http://example.com", nil, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+}
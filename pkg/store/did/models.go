@@ -11,3 +11,10 @@ type Record struct {
 	Name string `json:"name,omitempty"`
 	ID   string `json:"id,omitempty"`
 }
+
+// RecordPage is a page of did records.
+type RecordPage struct {
+	Records []*Record `json:"records,omitempty"`
+	// Total number of did records.
+	Total int `json:"total"`
+}
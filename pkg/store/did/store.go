@@ -120,6 +120,35 @@ func (s *Store) GetDIDRecords() []*Record {
 	return records
 }
 
+// GetDIDRecordsPage retrieves a page of the didDoc records, skipping the offset first records and returning at most
+// limit records. A limit of zero returns all the records following the offset.
+func (s *Store) GetDIDRecordsPage(offset, limit int) (*RecordPage, error) {
+	searchKey := didNameDataKey("")
+
+	total, err := storage.Count(s.store, searchKey, fmt.Sprintf(limitPattern, searchKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count did records : %w", err)
+	}
+
+	itr := storage.NewPagedIterator(s.store.Iterator(searchKey, fmt.Sprintf(limitPattern, searchKey)), offset, limit)
+	defer itr.Release()
+
+	page := &RecordPage{Total: total}
+
+	for itr.Next() {
+		page.Records = append(page.Records, &Record{
+			Name: getDIDName(string(itr.Key())),
+			ID:   string(itr.Value()),
+		})
+	}
+
+	if err := itr.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate did records : %w", err)
+	}
+
+	return page, nil
+}
+
 func didNameDataKey(name string) string {
 	return fmt.Sprintf(didNameKeyPattern, name)
 }
@@ -8,6 +8,7 @@ package did
 import (
 	"crypto/ed25519"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"strconv"
 	"testing"
@@ -215,6 +216,50 @@ func TestGetCredentials(t *testing.T) {
 		records = s.GetDIDRecords()
 		require.Equal(t, 1+n, len(records))
 	})
+
+	t.Run("test get dids page", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{Store: &mockstore.MockStore{
+				Store: make(map[string][]byte),
+			}},
+		})
+		require.NoError(t, err)
+
+		page, err := s.GetDIDRecordsPage(0, 2)
+		require.NoError(t, err)
+		require.Empty(t, page.Records)
+		require.Zero(t, page.Total)
+
+		n := 5
+		for i := 0; i < n; i++ {
+			err = s.SaveDID(sampleDIDName+strconv.Itoa(i), &did.Doc{ID: sampleDIDID + strconv.Itoa(i)})
+			require.NoError(t, err)
+		}
+
+		page, err = s.GetDIDRecordsPage(2, 2)
+		require.NoError(t, err)
+		require.Equal(t, n, page.Total)
+		require.Len(t, page.Records, 2)
+		require.Equal(t, sampleDIDName+"2", page.Records[0].Name)
+		require.Equal(t, sampleDIDID+"3", page.Records[1].ID)
+
+		page, err = s.GetDIDRecordsPage(4, 0)
+		require.NoError(t, err)
+		require.Len(t, page.Records, 1)
+	})
+
+	t.Run("test get dids page - iterator error", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{Store: &mockstore.MockStore{
+				Store:  make(map[string][]byte),
+				ErrItr: errors.New("iterator error"),
+			}},
+		})
+		require.NoError(t, err)
+
+		_, err = s.GetDIDRecordsPage(0, 1)
+		require.EqualError(t, err, "failed to count did records : iterator error")
+	})
 }
 
 func createDIDDoc() *did.Doc {
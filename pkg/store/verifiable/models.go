@@ -17,4 +17,13 @@ type Record struct {
 	// of issuing a credential or presentation.
 	MyDID    string `json:"my_did,omitempty"`
 	TheirDID string `json:"their_did,omitempty"`
+	// Revoked is set once the issuer notified that the credential was revoked.
+	Revoked bool `json:"revoked,omitempty"`
+}
+
+// RecordPage is a page of credential or presentation records.
+type RecordPage struct {
+	Records []*Record `json:"records,omitempty"`
+	// Total number of credential or presentation records.
+	Total int `json:"total"`
 }
@@ -62,8 +62,11 @@ type Store interface {
 	GetPresentationIDByName(name string) (string, error)
 	GetCredentials() ([]*Record, error)
 	GetPresentations() ([]*Record, error)
+	GetCredentialsPage(offset, limit int) (*RecordPage, error)
+	GetPresentationsPage(offset, limit int) (*RecordPage, error)
 	RemoveCredentialByName(name string) error
 	RemovePresentationByName(name string) error
+	MarkCredentialRevoked(id string) error
 }
 
 // StoreImplementation stores vc.
@@ -263,6 +266,18 @@ func (s *StoreImplementation) GetPresentations() ([]*Record, error) {
 	return s.getAllRecords(presentationNameDataKey(""))
 }
 
+// GetCredentialsPage retrieves a page of the verifiable credential records, skipping the offset first records and
+// returning at most limit records. A limit of zero returns all the records following the offset.
+func (s *StoreImplementation) GetCredentialsPage(offset, limit int) (*RecordPage, error) {
+	return s.getRecordPage(credentialNameDataKey(""), offset, limit)
+}
+
+// GetPresentationsPage retrieves a page of the verifiable presentation records, skipping the offset first records
+// and returning at most limit records. A limit of zero returns all the records following the offset.
+func (s *StoreImplementation) GetPresentationsPage(offset, limit int) (*RecordPage, error) {
+	return s.getRecordPage(presentationNameDataKey(""), offset, limit)
+}
+
 // RemoveCredentialByName removes the verifiable credential and its records containing given name.
 func (s *StoreImplementation) RemoveCredentialByName(name string) error {
 	if name == "" {
@@ -301,6 +316,49 @@ func (s *StoreImplementation) RemovePresentationByName(name string) error {
 	return nil
 }
 
+// MarkCredentialRevoked flags the records of the verifiable credential with given ID as revoked.
+func (s *StoreImplementation) MarkCredentialRevoked(id string) error {
+	if id == "" {
+		return errors.New("credential id is mandatory")
+	}
+
+	records, err := s.GetCredentials()
+	if err != nil {
+		return fmt.Errorf("get credential records : %w", err)
+	}
+
+	found := false
+
+	for _, r := range records {
+		if r.ID != id {
+			continue
+		}
+
+		found = true
+
+		if r.Revoked {
+			continue
+		}
+
+		r.Revoked = true
+
+		recordBytes, e := json.Marshal(r)
+		if e != nil {
+			return fmt.Errorf("failed to marshal record: %w", e)
+		}
+
+		if e := s.store.Put(credentialNameDataKey(r.Name), recordBytes); e != nil {
+			return fmt.Errorf("failed to put record: %w", e)
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("mark credential revoked : %w", storage.ErrDataNotFound)
+	}
+
+	return nil
+}
+
 func (s *StoreImplementation) remove(id, recordKey string) error {
 	err := s.store.Delete(id)
 	if err != nil {
@@ -335,6 +393,35 @@ func (s *StoreImplementation) getAllRecords(searchKey string) ([]*Record, error)
 	return records, nil
 }
 
+func (s *StoreImplementation) getRecordPage(searchKey string, offset, limit int) (*RecordPage, error) {
+	total, err := storage.Count(s.store, searchKey, fmt.Sprintf(limitPattern, searchKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count records : %w", err)
+	}
+
+	itr := storage.NewPagedIterator(s.store.Iterator(searchKey, fmt.Sprintf(limitPattern, searchKey)), offset, limit)
+	defer itr.Release()
+
+	page := &RecordPage{Total: total}
+
+	for itr.Next() {
+		var r *Record
+
+		err = json.Unmarshal(itr.Value(), &r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal record : %w", err)
+		}
+
+		page.Records = append(page.Records, r)
+	}
+
+	if err = itr.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate records : %w", err)
+	}
+
+	return page, nil
+}
+
 func getVCSubjectID(vc *verifiable.Credential) string {
 	if subjectID, err := verifiable.SubjectID(vc.Subject); err == nil {
 		return subjectID
@@ -7,16 +7,22 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"testing"
 
+	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
 const (
@@ -24,6 +30,7 @@ const (
 	sampleCredentialID     = "sampleVCID"
 	samplePresentationName = "sampleVPName"
 	samplePresentationID   = "sampleVPID"
+	jsonldContextPrefix    = "../../doc/verifiable/testdata/context"
 )
 
 //nolint:gochecknoglobals,lll
@@ -471,6 +478,61 @@ func TestGetCredentials(t *testing.T) {
 		require.Equal(t, 1+n, len(records))
 		require.NoError(t, err)
 	})
+
+	t.Run("test get credentials page", func(t *testing.T) {
+		store := make(map[string][]byte)
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: store}},
+		})
+		require.NoError(t, err)
+
+		page, err := s.GetCredentialsPage(0, 2)
+		require.NoError(t, err)
+		require.Empty(t, page.Records)
+		require.Zero(t, page.Total)
+
+		n := 5
+		for i := 0; i < n; i++ {
+			err = s.SaveCredential(sampleCredentialName+strconv.Itoa(i),
+				&verifiable.Credential{ID: sampleCredentialID + strconv.Itoa(i)})
+			require.NoError(t, err)
+		}
+
+		page, err = s.GetCredentialsPage(2, 2)
+		require.NoError(t, err)
+		require.Equal(t, n, page.Total)
+		require.Len(t, page.Records, 2)
+		require.Equal(t, sampleCredentialName+"2", page.Records[0].Name)
+		require.Equal(t, sampleCredentialName+"3", page.Records[1].Name)
+
+		page, err = s.GetCredentialsPage(4, 2)
+		require.NoError(t, err)
+		require.Equal(t, n, page.Total)
+		require.Len(t, page.Records, 1)
+
+		page, err = s.GetCredentialsPage(0, 0)
+		require.NoError(t, err)
+		require.Len(t, page.Records, n)
+
+		store[credentialNameDataKey("invalid")] = []byte("{")
+
+		_, err = s.GetCredentialsPage(0, 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal record")
+	})
+
+	t.Run("test get credentials page - iterator error", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{Store: &mockstore.MockStore{
+				Store:  make(map[string][]byte),
+				ErrItr: errors.New("iterator error"),
+			}},
+		})
+		require.NoError(t, err)
+
+		_, err = s.GetCredentialsPage(0, 1)
+		require.EqualError(t, err, "failed to count records : iterator error")
+	})
 }
 
 func TestSaveVP(t *testing.T) {
@@ -707,6 +769,12 @@ func TestGetPresentations(t *testing.T) {
 		records, err = s.GetPresentations()
 		require.NoError(t, err)
 		require.Len(t, records, 1+n)
+
+		page, err := s.GetPresentationsPage(1, 3)
+		require.NoError(t, err)
+		require.Equal(t, 1+n, page.Total)
+		require.Len(t, page.Records, 3)
+		require.Equal(t, records[1:4], page.Records)
 	})
 
 	t.Run("test get presentations", func(t *testing.T) {
@@ -897,3 +965,87 @@ func TestRemoveVP(t *testing.T) {
 		require.Contains(t, err.Error(), "get presentation id using name")
 	})
 }
+
+func TestMarkCredentialRevoked(t *testing.T) {
+	t.Run("test mark vc revoked - success", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		udVC, err := verifiable.ParseCredential([]byte(udCredential),
+			verifiable.WithDisabledProofCheck(), verifiable.WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, udVC))
+
+		id, err := s.GetCredentialIDByName(sampleCredentialName)
+		require.NoError(t, err)
+
+		require.NoError(t, s.MarkCredentialRevoked(id))
+		// marking twice is a no-op
+		require.NoError(t, s.MarkCredentialRevoked(id))
+
+		records, err := s.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.True(t, records[0].Revoked)
+	})
+	t.Run("test mark vc revoked - empty id", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		err = s.MarkCredentialRevoked("")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential id is mandatory")
+	})
+	t.Run("test mark vc revoked - credential not found", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		err = s.MarkCredentialRevoked("http://example.edu/credentials/1872")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+	t.Run("test mark vc revoked - error from store put", func(t *testing.T) {
+		store := &mockstore.MockStore{Store: make(map[string][]byte)}
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(store),
+		})
+		require.NoError(t, err)
+		udVC, err := verifiable.ParseCredential([]byte(udCredential),
+			verifiable.WithDisabledProofCheck(), verifiable.WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, udVC))
+
+		store.ErrPut = fmt.Errorf("error put")
+		err = s.MarkCredentialRevoked(udVC.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to put record")
+	})
+}
+
+// createTestDocumentLoader returns a JSON-LD document loader caching the contexts of the sample credentials, for
+// the tests to parse them offline.
+func createTestDocumentLoader(t *testing.T) *ld.CachingDocumentLoader {
+	t.Helper()
+
+	loader := verifiable.CachingJSONLDLoader()
+
+	contexts := map[string]string{
+		"https://www.w3.org/2018/credentials/examples/v1": "vc_example.jsonld",
+		"https://www.w3.org/ns/odrl.jsonld":               "odrl.jsonld",
+	}
+
+	for contextURL, contextFile := range contexts {
+		content, err := ioutil.ReadFile(filepath.Clean(filepath.Join(jsonldContextPrefix, contextFile)))
+		require.NoError(t, err)
+
+		reader, err := ld.DocumentFromReader(bytes.NewReader(content))
+		require.NoError(t, err)
+
+		loader.AddDocument(contextURL, reader)
+	}
+
+	return loader
+}
@@ -0,0 +1,272 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/bbs/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// Suite is the signature suite of a generated credential.
+type Suite string
+
+const (
+	// Ed25519Signature2018 signs the credential with the Ed25519 key of the issuer.
+	Ed25519Signature2018 Suite = "Ed25519Signature2018"
+	// EcdsaSecp256k1Signature2019 signs the credential with the ECDSA secp256k1 key of the issuer.
+	EcdsaSecp256k1Signature2019 Suite = "EcdsaSecp256k1Signature2019"
+	// BbsBlsSignature2020 signs the credential with the BLS12-381 G2 key of the issuer. The BBS+ context,
+	// BBSContext, is added to the credential: it has to be cached by the document loader (see WithDocumentLoader).
+	BbsBlsSignature2020 Suite = "BbsBlsSignature2020"
+
+	// Vocab is the vocabulary defining the types and claims of the credentials which aren't defined by their
+	// contexts, so that the fixtures don't need contexts of their own.
+	Vocab = "https://example.com/testutil#"
+
+	// BBSContext is the JSON-LD context of the BBS+ signatures.
+	BBSContext = "https://w3c-ccg.github.io/ldp-bbs2020/context/v1"
+
+	secp256k1KeySize = 32
+)
+
+// CredentialOpt configures a generated credential.
+type CredentialOpt func(opts *credentialOpts)
+
+type credentialOpts struct {
+	id       string
+	types    []string
+	contexts []string
+	claims   map[string]interface{}
+	issued   time.Time
+	expired  *time.Time
+	loader   ld.DocumentLoader
+}
+
+// WithID sets the ID of the credential, a UUID URN derived from the seed if not set.
+func WithID(id string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.id = id
+	}
+}
+
+// WithTypes adds types to the "VerifiableCredential" type of the credential, the types not defined by the contexts
+// of the credential being terms of the Vocab vocabulary.
+func WithTypes(types ...string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.types = append(opts.types, types...)
+	}
+}
+
+// WithContexts adds JSON-LD contexts to the credentials context, e.g. the contexts defining the types and claims of
+// the credential. The contexts have to be cached by the document loader.
+func WithContexts(contexts ...string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.contexts = append(opts.contexts, contexts...)
+	}
+}
+
+// WithClaims adds claims to the ID of the subject of the credential, the claims not defined by the contexts of the
+// credential being terms of the Vocab vocabulary.
+func WithClaims(claims map[string]interface{}) CredentialOpt {
+	return func(opts *credentialOpts) {
+		for k, v := range claims {
+			opts.claims[k] = v
+		}
+	}
+}
+
+// WithIssued sets the issuance date of the credential, and the creation date of its proof, Epoch if not set.
+func WithIssued(issued time.Time) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.issued = issued
+	}
+}
+
+// WithExpired sets the expiration date of the credential, the credential doesn't expire if not set.
+func WithExpired(expired time.Time) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.expired = &expired
+	}
+}
+
+// WithDocumentLoader sets the JSON-LD document loader signing the credential, verifiable.CachingJSONLDLoader if not
+// set (which caches the credentials context only).
+func WithDocumentLoader(loader ld.DocumentLoader) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.loader = loader
+	}
+}
+
+// Credential returns a credential issued by the issuer to the subject (e.g. the DID of another identity), signed
+// with a linked data proof of the suite. The proof is verified with the PublicKeyFetcher of the generator.
+func (g *Generator) Credential(s Suite, issuer *Identity, subject string,
+	opts ...CredentialOpt) (*verifiable.Credential, error) {
+	credOpts := g.credentialOpts(opts)
+
+	signatureSuite, keyID, err := signatureSuite(s, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	if s == BbsBlsSignature2020 {
+		credOpts.contexts = append(credOpts.contexts, BBSContext)
+	}
+
+	vc := newCredential(issuer, subject, credOpts)
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           string(s),
+		Suite:                   signatureSuite,
+		SignatureRepresentation: verifiable.SignatureProofValue,
+		Created:                 &credOpts.issued,
+		VerificationMethod:      issuer.VerificationMethod(keyID),
+		Purpose:                 "assertionMethod",
+	}, jsonld.WithDocumentLoader(credOpts.loader))
+	if err != nil {
+		return nil, fmt.Errorf("sign credential with %s: %w", s, err)
+	}
+
+	return vc, nil
+}
+
+// CredentialJWT returns a credential issued by the issuer to the subject as a JWT signed with the Ed25519 key of the
+// issuer (EdDSA).
+func (g *Generator) CredentialJWT(issuer *Identity, subject string, opts ...CredentialOpt) (string, error) {
+	vc := newCredential(issuer, subject, g.credentialOpts(opts))
+
+	jws, err := vc.MarshalJWS(verifiable.EdDSA,
+		signature.GetEd25519Signer(issuer.Ed25519PrivateKey, issuer.Ed25519PublicKey()),
+		issuer.VerificationMethod(Ed25519KeyID))
+	if err != nil {
+		return "", fmt.Errorf("sign credential JWT: %w", err)
+	}
+
+	return jws, nil
+}
+
+func (g *Generator) credentialOpts(opts []CredentialOpt) *credentialOpts {
+	credOpts := &credentialOpts{
+		claims: map[string]interface{}{},
+		issued: Epoch,
+		loader: verifiable.CachingJSONLDLoader(),
+	}
+
+	for _, opt := range opts {
+		opt(credOpts)
+	}
+
+	if credOpts.id == "" {
+		g.lock.Lock()
+		g.credentials++
+		n := g.credentials
+		g.lock.Unlock()
+
+		credOpts.id = uuid.NewSHA1(uuid.NameSpaceURL, g.derive("credential", fmt.Sprint(n))).URN()
+	}
+
+	return credOpts
+}
+
+func newCredential(issuer *Identity, subject string, opts *credentialOpts) *verifiable.Credential {
+	claims := map[string]interface{}{"id": subject}
+	for k, v := range opts.claims {
+		claims[k] = v
+	}
+
+	vc := &verifiable.Credential{
+		Context:       append([]string{"https://www.w3.org/2018/credentials/v1"}, opts.contexts...),
+		CustomContext: []interface{}{map[string]interface{}{"@vocab": Vocab}},
+		ID:            opts.id,
+		Types:         append([]string{"VerifiableCredential"}, opts.types...),
+		Subject:       claims,
+		Issuer:        verifiable.Issuer{ID: issuer.DID},
+		Issued:        util.NewTime(opts.issued),
+	}
+
+	if opts.expired != nil {
+		vc.Expired = util.NewTime(*opts.expired)
+	}
+
+	return vc
+}
+
+// signatureSuite returns the signature suite signing with the key of the issuer, and the ID of the key.
+func signatureSuite(s Suite, issuer *Identity) (signer.SignatureSuite, string, error) {
+	switch s {
+	case Ed25519Signature2018:
+		return ed25519signature2018.New(suite.WithSigner(
+			signature.GetEd25519Signer(issuer.Ed25519PrivateKey, issuer.Ed25519PublicKey()))), Ed25519KeyID, nil
+	case EcdsaSecp256k1Signature2019:
+		return ecdsasecp256k1signature2019.New(suite.WithSigner(
+			&secp256k1Signer{privateKey: issuer.Secp256k1PrivateKey})), Secp256k1KeyID, nil
+	case BbsBlsSignature2020:
+		return bbsblssignature2020.New(suite.WithSigner(
+			&bbsSigner{privateKey: issuer.BLS12381G2PrivateKey})), BLS12381G2KeyID, nil
+	default:
+		return nil, "", fmt.Errorf("unsupported signature suite %s", s)
+	}
+}
+
+// secp256k1Signer signs with an ECDSA secp256k1 key, the signature being the concatenation of R and S.
+type secp256k1Signer struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+func (s *secp256k1Signer) Sign(data []byte) ([]byte, error) {
+	hashed := sha256.Sum256(data)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privateKey, hashed[:])
+	if err != nil {
+		return nil, err
+	}
+
+	signatureBytes := make([]byte, 2*secp256k1KeySize)
+	r.FillBytes(signatureBytes[:secp256k1KeySize])
+	sig.FillBytes(signatureBytes[secp256k1KeySize:])
+
+	return signatureBytes, nil
+}
+
+// bbsSigner signs with a BLS12-381 G2 key, the messages being the lines of the canonical document.
+type bbsSigner struct {
+	privateKey *bbs12381g2pub.PrivateKey
+}
+
+func (s *bbsSigner) Sign(data []byte) ([]byte, error) {
+	privateKeyBytes, err := s.privateKey.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	var messages [][]byte
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) != "" {
+			messages = append(messages, []byte(line))
+		}
+	}
+
+	return bbs12381g2pub.New().Sign(messages, privateKeyBytes)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testutil
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+func TestGenerator_Credential(t *testing.T) {
+	gen := NewGenerator("test")
+
+	issuer, err := gen.Identity("issuer")
+	require.NoError(t, err)
+
+	holder, err := gen.Identity("holder")
+	require.NoError(t, err)
+
+	for _, s := range []Suite{Ed25519Signature2018, EcdsaSecp256k1Signature2019, BbsBlsSignature2020} {
+		s := s
+
+		t.Run(string(s), func(t *testing.T) {
+			loader := bbsDocumentLoader(t)
+
+			vc, err := gen.Credential(s, issuer, holder.DID, WithTypes("UniversityDegreeCredential"),
+				WithDocumentLoader(loader))
+			require.NoError(t, err)
+			require.Len(t, vc.Proofs, 1)
+			require.Equal(t, string(s), vc.Proofs[0]["type"])
+
+			vcBytes, err := vc.MarshalJSON()
+			require.NoError(t, err)
+
+			parsed, err := verifiable.ParseCredential(vcBytes, verifiable.WithPublicKeyFetcher(gen.PublicKeyFetcher()),
+				verifiable.WithJSONLDDocumentLoader(loader))
+			require.NoError(t, err)
+			require.Equal(t, issuer.DID, parsed.Issuer.ID)
+			require.Equal(t, []string{"VerifiableCredential", "UniversityDegreeCredential"}, parsed.Types)
+			require.Equal(t, Epoch, parsed.Issued.Time)
+
+			// the signature doesn't match another issuer
+			other, err := NewGenerator("other").Identity("issuer")
+			require.NoError(t, err)
+
+			_, err = verifiable.ParseCredential(
+				[]byte(strings.ReplaceAll(string(vcBytes), issuer.DID, other.DID)),
+				verifiable.WithPublicKeyFetcher(gen.PublicKeyFetcher()),
+				verifiable.WithJSONLDDocumentLoader(loader))
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		issued := time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC)
+		opts := []CredentialOpt{WithIssued(issued), WithExpired(issued.AddDate(1, 0, 0)),
+			WithClaims(map[string]interface{}{"name": "Jayden Doe"})}
+
+		generate := func() []byte {
+			g := NewGenerator("test")
+
+			i, err := g.Identity("issuer")
+			require.NoError(t, err)
+
+			vc, err := g.Credential(Ed25519Signature2018, i, holder.DID, opts...)
+			require.NoError(t, err)
+
+			vcBytes, err := vc.MarshalJSON()
+			require.NoError(t, err)
+
+			return vcBytes
+		}
+
+		vcBytes := generate()
+		require.Equal(t, vcBytes, generate())
+		require.Contains(t, string(vcBytes), `"name":"Jayden Doe"`)
+		require.Contains(t, string(vcBytes), `"expirationDate":"2021-06-01T00:00:00Z"`)
+
+		// the IDs of the next credentials differ
+		vc1, err := gen.Credential(Ed25519Signature2018, issuer, holder.DID)
+		require.NoError(t, err)
+
+		vc2, err := gen.Credential(Ed25519Signature2018, issuer, holder.DID)
+		require.NoError(t, err)
+		require.NotEqual(t, vc1.ID, vc2.ID)
+
+		vc3, err := gen.Credential(Ed25519Signature2018, issuer, holder.DID, WithID("http://example.edu/credentials/1"))
+		require.NoError(t, err)
+		require.Equal(t, "http://example.edu/credentials/1", vc3.ID)
+	})
+
+	t.Run("fails to sign", func(t *testing.T) {
+		_, err := gen.Credential("unknown", issuer, holder.DID)
+		require.EqualError(t, err, "unsupported signature suite unknown")
+
+		_, err = gen.Credential(Ed25519Signature2018, issuer, holder.DID, WithContexts("invalid context"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "sign credential with Ed25519Signature2018")
+	})
+}
+
+func TestGenerator_CredentialJWT(t *testing.T) {
+	gen := NewGenerator("test")
+
+	issuer, err := gen.Identity("issuer")
+	require.NoError(t, err)
+
+	jwt, err := gen.CredentialJWT(issuer, "did:example:holder")
+	require.NoError(t, err)
+
+	vc, err := verifiable.ParseCredential([]byte(jwt), verifiable.WithPublicKeyFetcher(gen.PublicKeyFetcher()),
+		verifiable.WithJSONLDDocumentLoader(verifiable.CachingJSONLDLoader()))
+	require.NoError(t, err)
+	require.Equal(t, issuer.DID, vc.Issuer.ID)
+}
+
+// bbsDocumentLoader returns a document loader caching the BBS+ context in addition to the credentials context.
+func bbsDocumentLoader(t *testing.T) ld.DocumentLoader {
+	t.Helper()
+
+	loader := verifiable.CachingJSONLDLoader()
+
+	context, err := ioutil.ReadFile(filepath.Join("..", "doc", "verifiable", "testdata", "context", "bss2020.jsonld"))
+	require.NoError(t, err)
+
+	doc, err := ld.DocumentFromReader(strings.NewReader(string(context)))
+	require.NoError(t, err)
+
+	loader.AddDocument(BBSContext, doc)
+
+	return loader
+}
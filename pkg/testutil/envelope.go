@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testutil
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+// ImportKey imports the Ed25519 key of the identity in the key manager, e.g. the KMS of the agent under test, so that
+// the agent unpacks the envelopes sent to the identity and packs the envelopes it sends. It returns the ID of the key.
+func (i *Identity) ImportKey(km kms.KeyManager) (string, error) {
+	kid, err := localkms.CreateKID(i.Ed25519PublicKey(), kms.ED25519Type)
+	if err != nil {
+		return "", fmt.Errorf("create key ID: %w", err)
+	}
+
+	if _, err = km.Get(kid); err == nil {
+		return kid, nil
+	}
+
+	if _, _, err = km.ImportPrivateKey(i.Ed25519PrivateKey, kms.ED25519Type, kms.WithKeyID(kid)); err != nil {
+		return "", fmt.Errorf("import key: %w", err)
+	}
+
+	return kid, nil
+}
+
+// Envelope packs the message sent by the sender to the recipients with the authcrypt packer of Aries RFC 0019, with
+// the Ed25519 keys of the identities (the recipient keys of their DIDComm services). The envelope is unpacked by the
+// agents holding the key of a recipient (see ImportKey).
+func (g *Generator) Envelope(message []byte, sender *Identity, recipients ...*Identity) ([]byte, error) {
+	km, err := localkms.New("local-lock://testutil", &kmsProvider{
+		storageProvider: mem.NewProvider(),
+		secretLock:      &noop.NoLock{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create KMS: %w", err)
+	}
+
+	if _, err = sender.ImportKey(km); err != nil {
+		return nil, fmt.Errorf("import sender key: %w", err)
+	}
+
+	recipientKeys := make([][]byte, len(recipients))
+	for i, recipient := range recipients {
+		recipientKeys[i] = recipient.Ed25519PublicKey()
+	}
+
+	envelope, err := authcrypt.New(&kmsProvider{km: km}).Pack(message, sender.Ed25519PublicKey(), recipientKeys)
+	if err != nil {
+		return nil, fmt.Errorf("pack envelope: %w", err)
+	}
+
+	return envelope, nil
+}
+
+// kmsProvider is the provider of the KMS and of the packer of the envelopes.
+type kmsProvider struct {
+	storageProvider storage.Provider
+	secretLock      secretlock.Service
+	km              kms.KeyManager
+}
+
+func (p *kmsProvider) StorageProvider() storage.Provider {
+	return p.storageProvider
+}
+
+func (p *kmsProvider) SecretLock() secretlock.Service {
+	return p.secretLock
+}
+
+func (p *kmsProvider) KMS() kms.KeyManager {
+	return p.km
+}
+
+func (p *kmsProvider) Crypto() crypto.Crypto {
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestGenerator_Envelope(t *testing.T) {
+	gen := NewGenerator(t.Name())
+
+	alice, err := gen.Identity("Alice")
+	require.NoError(t, err)
+
+	bob, err := gen.Identity("Bob")
+	require.NoError(t, err)
+
+	carol, err := gen.Identity("Carol")
+	require.NoError(t, err)
+
+	message := []byte(`{"@type":"https://didcomm.org/basicmessage/1.0/message","content":"hello"}`)
+
+	envelope, err := gen.Envelope(message, alice, bob)
+	require.NoError(t, err)
+
+	t.Run("unpacked by the recipient", func(t *testing.T) {
+		packer := authcrypt.New(&kmsProvider{km: newKMS(t, bob)})
+
+		unpacked, err := packer.Unpack(envelope)
+		require.NoError(t, err)
+		require.Equal(t, message, unpacked.Message)
+		require.Equal(t, []byte(alice.Ed25519PublicKey()), unpacked.FromKey)
+		require.Equal(t, []byte(bob.Ed25519PublicKey()), unpacked.ToKey)
+	})
+
+	t.Run("not unpacked by the other identities", func(t *testing.T) {
+		packer := authcrypt.New(&kmsProvider{km: newKMS(t, carol)})
+
+		_, err := packer.Unpack(envelope)
+		require.Error(t, err)
+	})
+}
+
+func TestIdentity_ImportKey(t *testing.T) {
+	alice, err := NewGenerator(t.Name()).Identity("Alice")
+	require.NoError(t, err)
+
+	km := newKMS(t)
+
+	kid, err := alice.ImportKey(km)
+	require.NoError(t, err)
+
+	// the key is imported once
+	again, err := alice.ImportKey(km)
+	require.NoError(t, err)
+	require.Equal(t, kid, again)
+
+	pub, err := km.ExportPubKeyBytes(kid)
+	require.NoError(t, err)
+	require.Equal(t, []byte(alice.Ed25519PublicKey()), pub)
+}
+
+func newKMS(t *testing.T, identities ...*Identity) *localkms.LocalKMS {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://testutil", &kmsProvider{
+		storageProvider: mem.NewProvider(),
+		secretLock:      &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	for _, identity := range identities {
+		_, err = identity.ImportKey(km)
+		require.NoError(t, err)
+	}
+
+	return km
+}
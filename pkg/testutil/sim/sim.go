@@ -0,0 +1,460 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sim simulates the network between the agents of an integration test: the framework instances are wired
+// together over an in-memory transport, the messages are delivered with the latency, the jitter and the losses of the
+// simulated links, and the agents share a virtual clock advanced by the deliveries.
+//
+// The simulation is deterministic: the conditions of each message are drawn from the seed of the network, the link
+// and the position of the message on the link, so a network created with the same seed delivers the messages in the
+// same order at the same virtual times, which makes the protocol conformance and the chaos tests reproducible.
+//
+// Usage:
+//
+//	net := sim.New(42, sim.WithConditions(sim.Conditions{Latency: 50 * time.Millisecond}))
+//
+//	alice, err := net.Agent("alice")
+//	bob, err := net.Agent("bob")
+//
+//	net.SetLink("bob", "alice", sim.Conditions{Drop: 1}) // bob can't reach alice
+//
+//	// ... start a protocol between alice and bob, then deliver the messages until the agents are idle
+//	err = net.Run()
+//
+//	for _, e := range net.Trace() {
+//	    fmt.Println(e.At, e.From, e.To, e.Type, e.Dropped)
+//	}
+package sim
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const (
+	// Scheme is the scheme of the endpoints of the simulated agents, e.g. "sim://alice".
+	Scheme = "sim://"
+
+	defaultSettle   = 100 * time.Millisecond
+	defaultMaxSteps = 10000
+)
+
+// Epoch is the default start time of the virtual clock of the networks.
+// nolint:gochecknoglobals
+var Epoch = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrMaxSteps is returned by Run when the agents are still exchanging messages after the maximum number of steps,
+// e.g. when a protocol loops.
+var ErrMaxSteps = errors.New("simulation didn't settle")
+
+// Conditions are the conditions of a simulated link.
+type Conditions struct {
+	// Latency is the time taken by the messages to be delivered.
+	Latency time.Duration
+	// Jitter is the maximum random time added to the latency of each message. The messages sent on a link within the
+	// jitter of each other can be reordered.
+	Jitter time.Duration
+	// Drop is the probability, between 0 and 1, that a message is lost.
+	Drop float64
+}
+
+// Event is a message sent on the simulated network.
+type Event struct {
+	// From and To are the names of the sender and of the recipient agents.
+	From, To string
+	// Sent is the virtual time the message was sent, At the virtual time it was delivered (or would have been, had
+	// it not been dropped).
+	Sent, At time.Time
+	// Type is the type of the message, empty if it wasn't delivered.
+	Type string
+	// Dropped is true if the message was lost.
+	Dropped bool
+	// Err is the error returned by the recipient processing the message.
+	Err error
+}
+
+// Opt configures a network.
+type Opt func(n *Network)
+
+// WithConditions sets the conditions of the links without specific conditions, see SetLink. The links are perfect
+// by default: no latency, no jitter and no losses.
+func WithConditions(c Conditions) Opt {
+	return func(n *Network) {
+		n.conditions = c
+	}
+}
+
+// WithStart sets the start time of the virtual clock, Epoch by default.
+func WithStart(t time.Time) Opt {
+	return func(n *Network) {
+		n.clock.Set(t)
+	}
+}
+
+// WithSettle sets the real time waited for the agents to send the messages triggered asynchronously by a delivery,
+// before the next message is delivered. Defaults to 100ms.
+func WithSettle(d time.Duration) Opt {
+	return func(n *Network) {
+		n.settle = d
+	}
+}
+
+// WithMaxSteps sets the maximum number of messages delivered by Run, 10000 by default.
+func WithMaxSteps(steps int) Opt {
+	return func(n *Network) {
+		n.maxSteps = steps
+	}
+}
+
+// Network is a simulated network of agents, it's safe for concurrent use.
+type Network struct {
+	seed     int64
+	clock    *mockclock.Clock
+	settle   time.Duration
+	maxSteps int
+
+	lock       sync.Mutex
+	conditions Conditions
+	links      map[link]Conditions
+	sent       map[link]uint64
+	agents     map[string]*Transport
+	queue      deliveries
+	trace      []Event
+	// notify is signaled whenever a message is sent.
+	notify chan struct{}
+}
+
+type link struct {
+	from, to string
+}
+
+// New returns a network whose conditions are drawn from the given seed.
+func New(seed int64, opts ...Opt) *Network {
+	n := &Network{
+		seed:     seed,
+		clock:    mockclock.New(Epoch),
+		settle:   defaultSettle,
+		maxSteps: defaultMaxSteps,
+		links:    map[link]Conditions{},
+		sent:     map[link]uint64{},
+		agents:   map[string]*Transport{},
+		notify:   make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
+}
+
+// Clock returns the virtual clock of the network, shared by its agents.
+func (n *Network) Clock() clock.Clock {
+	return n.clock
+}
+
+// Now returns the virtual time of the network.
+func (n *Network) Now() time.Time {
+	return n.clock.Now()
+}
+
+// Endpoint returns the endpoint of the agent with the given name.
+func Endpoint(name string) string {
+	return Scheme + name
+}
+
+// Transport returns the transport of a new agent joining the network. Agent creates the transport of the agents
+// it creates, Transport is for the agents created with specific transport options.
+func (n *Network) Transport(name string) (*Transport, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.agents[name]; ok {
+		return nil, fmt.Errorf("agent %s already joined the network", name)
+	}
+
+	t := &Transport{name: name, network: n}
+	n.agents[name] = t
+
+	return t, nil
+}
+
+// Agent creates a framework joining the network with the given name. The framework gets the transport and the
+// clock of the network, and in-memory stores; the options override the defaults.
+func (n *Network) Agent(name string, opts ...aries.Option) (*aries.Aries, error) {
+	t, err := n.Transport(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := []aries.Option{
+		aries.WithInboundTransport(t),
+		aries.WithOutboundTransports(t),
+		aries.WithStoreProvider(mem.NewProvider()),
+		aries.WithProtocolStateStoreProvider(mem.NewProvider()),
+		aries.WithClock(n.clock),
+	}
+
+	a, err := aries.New(append(defaults, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("create agent %s: %w", name, err)
+	}
+
+	return a, nil
+}
+
+// SetLink sets the conditions of the link from an agent to another (the reverse link isn't affected), e.g. a drop
+// probability of 1 cuts the link.
+func (n *Network) SetLink(from, to string, c Conditions) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.links[link{from: from, to: to}] = c
+}
+
+// ResetLink resets the link from an agent to another to the default conditions of the network.
+func (n *Network) ResetLink(from, to string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.links, link{from: from, to: to})
+}
+
+// Trace returns the messages sent on the network, in the order they were delivered or dropped.
+func (n *Network) Trace() []Event {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return append([]Event(nil), n.trace...)
+}
+
+// Pending returns the number of messages in flight.
+func (n *Network) Pending() int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.queue.Len()
+}
+
+// Step delivers the next message in flight, advancing the virtual clock to its delivery time. It returns false if
+// no message is in flight.
+func (n *Network) Step() bool {
+	n.lock.Lock()
+
+	if n.queue.Len() == 0 {
+		n.lock.Unlock()
+
+		return false
+	}
+
+	d := heap.Pop(&n.queue).(*delivery) // nolint:errcheck,forcetypeassert
+	to := n.agents[d.event.To]
+
+	n.lock.Unlock()
+
+	if d.event.At.After(n.clock.Now()) {
+		n.clock.Set(d.event.At)
+	}
+
+	d.event.Type, d.event.Err = to.deliver(d.message)
+
+	n.record(d.event)
+
+	return true
+}
+
+// Advance delivers the messages due within d, then advances the virtual clock by d, e.g. to expire the protocols.
+func (n *Network) Advance(d time.Duration) {
+	until := n.clock.Now().Add(d)
+
+	for {
+		n.lock.Lock()
+		due := n.queue.Len() > 0 && !n.queue[0].event.At.After(until)
+		n.lock.Unlock()
+
+		if !due {
+			break
+		}
+
+		n.Step()
+	}
+
+	if until.After(n.clock.Now()) {
+		n.clock.Set(until)
+	}
+}
+
+// Run delivers the messages in flight, and those sent by the agents in turn, until the agents are idle. Before each
+// delivery, it waits for the agents to have sent the messages triggered by the previous one, so that they're
+// delivered in order of their virtual delivery time.
+func (n *Network) Run() error {
+	for i := 0; ; i++ {
+		n.waitSettled()
+
+		if n.Pending() == 0 {
+			return nil
+		}
+
+		if i == n.maxSteps {
+			return fmt.Errorf("%w after %d messages", ErrMaxSteps, n.maxSteps)
+		}
+
+		n.Step()
+	}
+}
+
+// waitSettled waits until no message was sent for the settle time.
+func (n *Network) waitSettled() {
+	timer := time.NewTimer(n.settle)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-n.notify:
+			if !timer.Stop() {
+				<-timer.C
+			}
+
+			timer.Reset(n.settle)
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+// send puts a message sent by an agent in flight, or drops it.
+func (n *Network) send(from string, message []byte, endpoint string) error {
+	to := strings.TrimPrefix(endpoint, Scheme)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.agents[to]; !ok {
+		return fmt.Errorf("no agent at %s", endpoint)
+	}
+
+	l := link{from: from, to: to}
+
+	c, ok := n.links[l]
+	if !ok {
+		c = n.conditions
+	}
+
+	seq := n.sent[l]
+	n.sent[l] = seq + 1
+
+	r := n.rand(l, seq)
+	now := n.clock.Now()
+
+	latency := c.Latency
+	if c.Jitter > 0 {
+		latency += time.Duration(r.Int63n(int64(c.Jitter)))
+	}
+
+	e := Event{From: from, To: to, Sent: now, At: now.Add(latency)}
+
+	if c.Drop > 0 && r.Float64() < c.Drop {
+		e.Dropped = true
+		n.trace = append(n.trace, e)
+	} else {
+		heap.Push(&n.queue, &delivery{event: e, seq: seq, message: message})
+	}
+
+	select {
+	case n.notify <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (n *Network) record(e Event) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.trace = append(n.trace, e)
+}
+
+// rand returns the source of the conditions of the seq-th message of the link: the conditions of a message don't
+// depend on the order the agents send messages on the other links.
+func (n *Network) rand(l link, seq uint64) *rand.Rand {
+	h := fnv.New64a()
+
+	var b [8]byte
+
+	binary.BigEndian.PutUint64(b[:], uint64(n.seed))
+	_, _ = h.Write(b[:])
+	_, _ = h.Write([]byte(l.from + "\x00" + l.to + "\x00"))
+	binary.BigEndian.PutUint64(b[:], seq)
+	_, _ = h.Write(b[:])
+
+	return rand.New(rand.NewSource(int64(h.Sum64()))) // nolint:gosec
+}
+
+type delivery struct {
+	event   Event
+	seq     uint64
+	message []byte
+}
+
+// deliveries is a heap of the messages in flight, ordered by delivery time. The messages due at the same time are
+// ordered by link, then by their position on the link, so that the order doesn't depend on the order of the sends.
+type deliveries []*delivery
+
+func (d deliveries) Len() int { return len(d) }
+
+func (d deliveries) Less(i, j int) bool {
+	a, b := d[i], d[j]
+
+	switch {
+	case !a.event.At.Equal(b.event.At):
+		return a.event.At.Before(b.event.At)
+	case a.event.From != b.event.From:
+		return a.event.From < b.event.From
+	case a.event.To != b.event.To:
+		return a.event.To < b.event.To
+	default:
+		return a.seq < b.seq
+	}
+}
+
+func (d deliveries) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+
+func (d *deliveries) Push(x interface{}) {
+	*d = append(*d, x.(*delivery)) // nolint:forcetypeassert
+}
+
+func (d *deliveries) Pop() interface{} {
+	old := *d
+	x := old[len(old)-1]
+	old[len(old)-1] = nil
+	*d = old[:len(old)-1]
+
+	return x
+}
+
+// messageType returns the type of a DIDComm message, empty if the message can't be parsed.
+func messageType(message []byte) string {
+	msg, err := service.ParseDIDCommMsgMap(message)
+	if err != nil {
+		return ""
+	}
+
+	return msg.Type()
+}
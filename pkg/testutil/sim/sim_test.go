@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sim_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	didexsvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/testutil/sim"
+)
+
+const latency = 50 * time.Millisecond
+
+func TestNetwork_DIDExchange(t *testing.T) {
+	t.Run("conformance", func(t *testing.T) {
+		net := sim.New(1, sim.WithConditions(sim.Conditions{Latency: latency}))
+
+		alice, bob := newClient(t, net, "alice"), newClient(t, net, "bob")
+		connect(t, alice, bob)
+
+		require.NoError(t, net.Run())
+
+		requireState(t, alice, didexsvc.StateIDCompleted)
+		requireState(t, bob, didexsvc.StateIDCompleted)
+
+		trace := net.Trace()
+		require.Equal(t, []string{
+			"bob->alice " + didexsvc.RequestMsgType,
+			"alice->bob " + didexsvc.ResponseMsgType,
+			"bob->alice " + didexsvc.AckMsgType,
+		}, flows(trace))
+
+		for i, e := range trace {
+			require.NoError(t, e.Err)
+			require.Equal(t, sim.Epoch.Add(time.Duration(i+1)*latency), e.At)
+		}
+
+		require.Equal(t, sim.Epoch.Add(3*latency), net.Now())
+	})
+
+	t.Run("reproducible", func(t *testing.T) {
+		run := func(seed int64) []sim.Event {
+			net := sim.New(seed, sim.WithConditions(sim.Conditions{Latency: latency, Jitter: time.Second}))
+
+			alice, bob := newClient(t, net, "alice"), newClient(t, net, "bob")
+			connect(t, alice, bob)
+
+			require.NoError(t, net.Run())
+
+			return net.Trace()
+		}
+
+		first, second := run(7), run(7)
+		require.Len(t, second, len(first))
+
+		for i := range first {
+			require.Equal(t, first[i].At, second[i].At)
+			require.Equal(t, first[i].Type, second[i].Type)
+		}
+
+		require.NotEqual(t, first[0].At, run(8)[0].At)
+	})
+
+	t.Run("partition", func(t *testing.T) {
+		net := sim.New(1)
+		net.SetLink("alice", "bob", sim.Conditions{Drop: 1})
+
+		alice, bob := newClient(t, net, "alice"), newClient(t, net, "bob")
+		connect(t, alice, bob)
+
+		require.NoError(t, net.Run())
+
+		requireState(t, alice, didexsvc.StateIDResponded)
+		requireState(t, bob, didexsvc.StateIDRequested)
+
+		trace := net.Trace()
+		require.Len(t, trace, 2)
+		require.False(t, trace[0].Dropped)
+		require.True(t, trace[1].Dropped)
+		require.Equal(t, "alice", trace[1].From)
+	})
+}
+
+func TestNetwork_Advance(t *testing.T) {
+	net := sim.New(1, sim.WithConditions(sim.Conditions{Latency: time.Second}))
+
+	alice, bob := newClient(t, net, "alice"), newClient(t, net, "bob")
+	connect(t, alice, bob)
+
+	// waits for bob to send the request
+	require.Eventually(t, func() bool { return net.Pending() == 1 }, time.Second, 10*time.Millisecond)
+
+	net.Advance(500 * time.Millisecond)
+	require.Equal(t, 1, net.Pending())
+	require.Equal(t, sim.Epoch.Add(500*time.Millisecond), net.Now())
+
+	net.Advance(500 * time.Millisecond)
+	require.NoError(t, net.Trace()[0].Err)
+	require.Equal(t, sim.Epoch.Add(time.Second), net.Now())
+
+	require.NoError(t, net.Run())
+	require.Equal(t, sim.Epoch.Add(3*time.Second), net.Now())
+}
+
+func TestNetwork_Errors(t *testing.T) {
+	net := sim.New(1, sim.WithMaxSteps(1))
+
+	_, err := net.Agent("alice")
+	require.NoError(t, err)
+
+	_, err = net.Agent("alice")
+	require.EqualError(t, err, "agent alice already joined the network")
+
+	tr, err := net.Transport("bob")
+	require.NoError(t, err)
+	require.Equal(t, "sim://bob", tr.Endpoint())
+	require.True(t, tr.Accept("sim://alice"))
+	require.False(t, tr.Accept("http://alice"))
+	require.False(t, tr.AcceptRecipient([]string{"key"}))
+
+	_, err = tr.Send([]byte("{}"), &service.Destination{ServiceEndpoint: "sim://carol"})
+	require.EqualError(t, err, "sim send: no agent at sim://carol")
+
+	for i := 0; i < 2; i++ {
+		_, err = tr.Send([]byte("{}"), &service.Destination{ServiceEndpoint: "sim://alice"})
+		require.NoError(t, err)
+	}
+
+	require.True(t, errors.Is(net.Run(), sim.ErrMaxSteps))
+
+	trace := net.Trace()
+	require.Len(t, trace, 1)
+	require.Contains(t, trace[0].Err.Error(), "failed to unpack msg")
+
+	require.NoError(t, tr.Stop())
+	require.NoError(t, net.Run())
+}
+
+func newClient(t *testing.T, net *sim.Network, name string) *didexchange.Client {
+	t.Helper()
+
+	a, err := net.Agent(name)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, a.Close())
+	})
+
+	ctx, err := a.Context()
+	require.NoError(t, err)
+
+	c, err := didexchange.New(ctx)
+	require.NoError(t, err)
+
+	actions := make(chan service.DIDCommAction)
+	require.NoError(t, c.RegisterActionEvent(actions))
+
+	go service.AutoExecuteActionEvent(actions)
+
+	return c
+}
+
+// connect has the invitee handle an invitation of the inviter.
+func connect(t *testing.T, inviter, invitee *didexchange.Client) {
+	t.Helper()
+
+	inv, err := inviter.CreateInvitation("inviter")
+	require.NoError(t, err)
+
+	_, err = invitee.HandleInvitation(inv)
+	require.NoError(t, err)
+}
+
+// requireState checks the state of the only connection of the client.
+func requireState(t *testing.T, c *didexchange.Client, state string) {
+	t.Helper()
+
+	conns, err := c.QueryConnections(&didexchange.QueryConnectionsParams{})
+	require.NoError(t, err)
+	require.Len(t, conns, 1)
+	require.Equal(t, state, conns[0].State)
+}
+
+func flows(trace []sim.Event) []string {
+	var f []string
+
+	for _, e := range trace {
+		f = append(f, e.From+"->"+e.To+" "+e.Type)
+	}
+
+	return f
+}
@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+// errNotStarted is returned for the messages delivered to an agent whose transport isn't started.
+var errNotStarted = errors.New("transport not started")
+
+// Transport is the inbound and outbound transport of an agent of the simulated network.
+type Transport struct {
+	name    string
+	network *Network
+
+	lock sync.RWMutex
+	prov transport.Provider
+}
+
+// Start starts the transport.
+func (t *Transport) Start(prov transport.Provider) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.prov = prov
+
+	return nil
+}
+
+// Stop stops the transport, the messages delivered to the agent meanwhile fail.
+func (t *Transport) Stop() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.prov = nil
+
+	return nil
+}
+
+// Endpoint returns the endpoint of the agent.
+func (t *Transport) Endpoint() string {
+	return Endpoint(t.name)
+}
+
+// Send puts the message in flight to the destination agent. The message is sent even if it's later dropped, like
+// on a real network, but the send fails if no agent of the network has the endpoint of the destination.
+func (t *Transport) Send(data []byte, destination *service.Destination) (string, error) {
+	if err := t.network.send(t.name, data, destination.ServiceEndpoint); err != nil {
+		return "", fmt.Errorf("sim send: %w", err)
+	}
+
+	return "", nil
+}
+
+// AcceptRecipient returns false, the transport doesn't keep connections open.
+func (t *Transport) AcceptRecipient([]string) bool {
+	return false
+}
+
+// Accept accepts the endpoints of the simulated agents.
+func (t *Transport) Accept(url string) bool {
+	return strings.HasPrefix(url, Scheme)
+}
+
+// deliver unpacks a message delivered to the agent and hands it to the framework, returning its type.
+func (t *Transport) deliver(message []byte) (string, error) {
+	t.lock.RLock()
+	prov := t.prov
+	t.lock.RUnlock()
+
+	if prov == nil {
+		return "", errNotStarted
+	}
+
+	unpacked, err := prov.Packager().UnpackMessage(message)
+	if err != nil {
+		return "", fmt.Errorf("failed to unpack msg: %w", err)
+	}
+
+	msgType := messageType(unpacked.Message)
+
+	err = prov.InboundMessageHandler()(unpacked.Message, unpacked.ToDID, unpacked.FromDID)
+	if err != nil {
+		return msgType, fmt.Errorf("incoming msg processing failed: %w", err)
+	}
+
+	return msgType, nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package testutil generates the fixtures of the integration tests of the agents built with the framework: the
+// identities (keys and DID documents), the signed credentials and the packed envelopes are generated from a seed,
+// instead of being copied from the testdata of the framework.
+//
+// The fixtures are deterministic: a generator created with the same seed generates the same identities and, for the
+// same sequence of calls, the same credentials (except for their ECDSA and BBS+ signatures and for the envelopes,
+// which are randomized).
+//
+// Usage:
+//
+//	gen := testutil.NewGenerator("my-test")
+//
+//	issuer, err := gen.Identity("issuer")
+//	holder, err := gen.Identity("holder")
+//
+//	vc, err := gen.Credential(testutil.Ed25519Signature2018, issuer, holder.DID)
+//
+//	// the credential is verified with the keys of the generated DID documents
+//	_, err = verifiable.ParseCredential(vcBytes, verifiable.WithPublicKeyFetcher(gen.PublicKeyFetcher()),
+//	    verifiable.WithJSONLDDocumentLoader(verifiable.CachingJSONLDLoader()))
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/bbs/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+)
+
+const (
+	// Ed25519KeyID is the ID, relative to the DID, of the Ed25519 verification method of the identities.
+	Ed25519KeyID = "#ed25519"
+	// Secp256k1KeyID is the ID, relative to the DID, of the ECDSA secp256k1 verification method of the identities.
+	Secp256k1KeyID = "#secp256k1"
+	// BLS12381G2KeyID is the ID, relative to the DID, of the BLS12-381 G2 verification method of the identities.
+	BLS12381G2KeyID = "#bls12381g2"
+
+	ed25519KeyType    = "Ed25519VerificationKey2018"
+	secp256k1KeyType  = "EcdsaSecp256k1VerificationKey2019"
+	bls12381G2KeyType = "Bls12381G2Key2020"
+)
+
+// Epoch is the creation time of the generated DID documents, and the default issuance date of the credentials.
+// nolint:gochecknoglobals
+var Epoch = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Identity is a generated identity: its keys and its did:peer DID document. The document has a verification method
+// for each key, and a DIDComm service whose recipient key is the Ed25519 key.
+type Identity struct {
+	Name string
+	DID  string
+	Doc  *did.Doc
+
+	Ed25519PrivateKey    ed25519.PrivateKey
+	Secp256k1PrivateKey  *ecdsa.PrivateKey
+	BLS12381G2PrivateKey *bbs12381g2pub.PrivateKey
+}
+
+// Ed25519PublicKey returns the Ed25519 public key of the identity.
+func (i *Identity) Ed25519PublicKey() ed25519.PublicKey {
+	return i.Ed25519PrivateKey.Public().(ed25519.PublicKey)
+}
+
+// VerificationMethod returns the absolute ID of the verification method of the identity, given its relative ID
+// (e.g. Ed25519KeyID).
+func (i *Identity) VerificationMethod(keyID string) string {
+	return i.DID + keyID
+}
+
+// Generator generates the fixtures from a seed.
+type Generator struct {
+	seed string
+
+	lock        sync.Mutex
+	identities  map[string]*Identity
+	credentials int
+}
+
+// NewGenerator returns a generator of the fixtures derived from the seed, e.g. the name of the test.
+func NewGenerator(seed string) *Generator {
+	return &Generator{
+		seed:       seed,
+		identities: map[string]*Identity{},
+	}
+}
+
+// Identity returns the identity with the given name, generated on the first call.
+func (g *Generator) Identity(name string) (*Identity, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if identity, ok := g.identities[name]; ok {
+		return identity, nil
+	}
+
+	identity, err := g.newIdentity(name)
+	if err != nil {
+		return nil, fmt.Errorf("generate identity %s: %w", name, err)
+	}
+
+	g.identities[name] = identity
+
+	return identity, nil
+}
+
+func (g *Generator) newIdentity(name string) (*Identity, error) {
+	identity := &Identity{
+		Name:                name,
+		Ed25519PrivateKey:   ed25519.NewKeyFromSeed(g.derive(name, "ed25519")),
+		Secp256k1PrivateKey: secp256k1Key(g.derive(name, "secp256k1")),
+	}
+
+	blsPublicKey, blsPrivateKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, g.derive(name, "bls12381g2"))
+	if err != nil {
+		return nil, fmt.Errorf("generate BLS12-381 G2 key: %w", err)
+	}
+
+	identity.BLS12381G2PrivateKey = blsPrivateKey
+
+	blsPublicKeyBytes, err := blsPublicKey.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("marshal BLS12-381 G2 key: %w", err)
+	}
+
+	secp256k1PublicKey := identity.Secp256k1PrivateKey.PublicKey
+
+	methods := []did.VerificationMethod{
+		*did.NewVerificationMethodFromBytes(Ed25519KeyID, ed25519KeyType, "#id", identity.Ed25519PublicKey()),
+		*did.NewVerificationMethodFromBytes(Secp256k1KeyID, secp256k1KeyType, "#id",
+			elliptic.Marshal(secp256k1PublicKey.Curve, secp256k1PublicKey.X, secp256k1PublicKey.Y)),
+		*did.NewVerificationMethodFromBytes(BLS12381G2KeyID, bls12381G2KeyType, "#id", blsPublicKeyBytes),
+	}
+
+	assertions := make([]did.Verification, len(methods))
+	for i := range methods {
+		assertions[i] = did.Verification{VerificationMethod: methods[i], Relationship: did.AssertionMethod}
+	}
+
+	doc, err := peer.NewDoc(methods,
+		did.WithAuthentication([]did.Verification{{VerificationMethod: methods[0], Relationship: did.Authentication}}),
+		did.WithAssertion(assertions),
+		did.WithService([]did.Service{{
+			ID:              "#didcomm",
+			Type:            vdrapi.DIDCommServiceType,
+			RecipientKeys:   []string{base58.Encode(identity.Ed25519PublicKey())},
+			ServiceEndpoint: fmt.Sprintf("https://%s.example.com", strings.ToLower(name)),
+		}}),
+		did.WithCreatedTime(Epoch),
+		did.WithUpdatedTime(Epoch),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create DID document: %w", err)
+	}
+
+	identity.Doc = doc
+	identity.DID = doc.ID
+
+	return identity, nil
+}
+
+// PublicKeyFetcher returns the fetcher of the public keys of the generated identities, verifying the credentials
+// they issue.
+func (g *Generator) PublicKeyFetcher() verifiable.PublicKeyFetcher {
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		g.lock.Lock()
+		defer g.lock.Unlock()
+
+		for _, identity := range g.identities {
+			if identity.DID != issuerID {
+				continue
+			}
+
+			// the key ID is either relative to the DID or absolute
+			keyID = strings.TrimPrefix(keyID, issuerID)
+
+			for _, method := range identity.Doc.VerificationMethod {
+				if method.ID == keyID {
+					return &verifier.PublicKey{Type: method.Type, Value: method.Value}, nil
+				}
+			}
+
+			return nil, fmt.Errorf("key %s of %s not found", keyID, issuerID)
+		}
+
+		return nil, fmt.Errorf("identity %s not found", issuerID)
+	}
+}
+
+// derive derives a 32 bytes secret from the seed for the purpose.
+func (g *Generator) derive(parts ...string) []byte {
+	h := sha256.Sum256([]byte(strings.Join(append([]string{g.seed}, parts...), "/")))
+
+	return h[:]
+}
+
+// secp256k1Key returns the secp256k1 key whose private scalar is derived from the secret, in [1, N-1].
+func secp256k1Key(secret []byte) *ecdsa.PrivateKey {
+	curve := btcec.S256()
+
+	d := new(big.Int).SetBytes(secret)
+	d.Mod(d, new(big.Int).Sub(curve.N, big.NewInt(1)))
+	d.Add(d, big.NewInt(1))
+
+	privateKey, _ := btcec.PrivKeyFromBytes(curve, d.Bytes())
+
+	return privateKey.ToECDSA()
+}
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+func TestGenerator_Identity(t *testing.T) {
+	gen := NewGenerator("test")
+
+	alice, err := gen.Identity("Alice")
+	require.NoError(t, err)
+	require.Equal(t, "Alice", alice.Name)
+	require.Regexp(t, "^did:peer:1z", alice.DID)
+
+	// the identities are generated once
+	again, err := gen.Identity("Alice")
+	require.NoError(t, err)
+	require.Same(t, alice, again)
+
+	// and derived from the seed
+	same, err := NewGenerator("test").Identity("Alice")
+	require.NoError(t, err)
+	require.Equal(t, alice.DID, same.DID)
+	require.Equal(t, alice.Ed25519PrivateKey, same.Ed25519PrivateKey)
+	require.Equal(t, alice.Secp256k1PrivateKey.D, same.Secp256k1PrivateKey.D)
+
+	other, err := NewGenerator("other").Identity("Alice")
+	require.NoError(t, err)
+	require.NotEqual(t, alice.DID, other.DID)
+
+	bob, err := gen.Identity("Bob")
+	require.NoError(t, err)
+	require.NotEqual(t, alice.DID, bob.DID)
+
+	// the DID document is valid
+	docBytes, err := alice.Doc.JSONBytes()
+	require.NoError(t, err)
+
+	doc, err := did.ParseDocument(docBytes)
+	require.NoError(t, err)
+	require.Equal(t, alice.DID, doc.ID)
+	require.Len(t, doc.VerificationMethod, 3)
+	require.Len(t, doc.Authentication, 1)
+	require.Len(t, doc.AssertionMethod, 3)
+
+	svc, ok := did.LookupService(doc, vdrapi.DIDCommServiceType)
+	require.True(t, ok)
+	require.Equal(t, "https://alice.example.com", svc.ServiceEndpoint)
+
+	raw := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(docBytes, &raw))
+	require.Equal(t, "2021-01-01T00:00:00Z", raw["created"])
+}
+
+func TestGenerator_PublicKeyFetcher(t *testing.T) {
+	gen := NewGenerator("test")
+
+	alice, err := gen.Identity("alice")
+	require.NoError(t, err)
+
+	fetcher := gen.PublicKeyFetcher()
+
+	key, err := fetcher(alice.DID, Ed25519KeyID)
+	require.NoError(t, err)
+	require.Equal(t, "Ed25519VerificationKey2018", key.Type)
+	require.Equal(t, []byte(alice.Ed25519PublicKey()), key.Value)
+
+	_, err = fetcher(alice.DID, "#unknown")
+	require.EqualError(t, err, "key #unknown of "+alice.DID+" not found")
+
+	_, err = fetcher("did:example:unknown", Ed25519KeyID)
+	require.EqualError(t, err, "identity did:example:unknown not found")
+}
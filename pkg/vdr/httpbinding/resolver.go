@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package httpbinding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -14,6 +15,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
@@ -42,6 +44,25 @@ func (v *VDR) resolveDID(uri string) ([]byte, error) {
 		req.Header.Add("Authorization", v.resolveAuthToken)
 	}
 
+	var gotBody []byte
+
+	err = retry.Do(context.Background(), v.retryPolicy, func() error {
+		var getErr error
+
+		gotBody, getErr = v.get(req)
+
+		return getErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return gotBody, nil
+}
+
+// get sends the DID resolution request, the errors returned for the responses that aren't worth retrying are
+// permanent.
+func (v *VDR) get(req *http.Request) ([]byte, error) {
 	resp, err := v.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP Get request failed: %w", err)
@@ -49,9 +70,7 @@ func (v *VDR) resolveDID(uri string) ([]byte, error) {
 
 	defer closeResponseBody(resp.Body)
 
-	var gotBody []byte
-
-	gotBody, err = ioutil.ReadAll(resp.Body)
+	gotBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("reading response body failed: %w", err)
 	}
@@ -59,11 +78,16 @@ func (v *VDR) resolveDID(uri string) ([]byte, error) {
 	if resp.StatusCode == http.StatusOK && strings.Contains(resp.Header.Get("Content-type"), didLDJson) {
 		return gotBody, nil
 	} else if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("DID does not exist for request: %s", uri)
+		return nil, retry.Permanent(fmt.Errorf("DID does not exist for request: %s", req.URL))
 	}
 
-	return nil, fmt.Errorf("unsupported response from DID resolver [%v] header [%s] body [%s]",
+	err = fmt.Errorf("unsupported response from DID resolver [%v] header [%s] body [%s]",
 		resp.StatusCode, resp.Header.Get("Content-type"), gotBody)
+	if !retry.RetryableStatus(resp.StatusCode) {
+		return nil, retry.Permanent(err)
+	}
+
+	return nil, err
 }
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
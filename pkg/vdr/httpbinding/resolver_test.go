@@ -13,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
@@ -258,6 +259,69 @@ func TestRead_UnsupportedStatus(t *testing.T) {
 	require.Contains(t, err.Error(), "unsupported response from DID resolver")
 }
 
+func TestRead_Retry(t *testing.T) {
+	requests := 0
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		requests++
+
+		if requests == 1 {
+			res.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		res.Header().Add("Content-type", "application/did+ld+json")
+		_, err := res.Write([]byte(doc))
+		require.NoError(t, err)
+	}))
+
+	defer func() { testServer.Close() }()
+
+	retryPolicy := retry.DefaultPolicy()
+	retryPolicy.InitialInterval = time.Millisecond
+
+	resolver, err := New(testServer.URL, WithRetryPolicy(retryPolicy))
+	require.NoError(t, err)
+
+	gotDocument, err := resolver.Read("did:example:334455")
+	require.NoError(t, err)
+	require.Equal(t, "did:peer:21tDAKCERh95uGgKbJNHYp", gotDocument.ID)
+	require.Equal(t, 2, requests)
+
+	t.Run("not found isn't retried", func(t *testing.T) {
+		requests = 0
+
+		testServer.Config.Handler = http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			requests++
+
+			res.WriteHeader(http.StatusNotFound)
+		})
+
+		_, err = resolver.Read("did:example:334455")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DID does not exist")
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("retries disabled", func(t *testing.T) {
+		requests = 0
+
+		testServer.Config.Handler = http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			requests++
+
+			res.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		noRetry, err := New(testServer.URL, WithRetryPolicy(retry.NoRetry()))
+		require.NoError(t, err)
+
+		_, err = noRetry.Read("did:example:334455")
+		require.Error(t, err)
+		require.Equal(t, 1, requests)
+	})
+}
+
 func TestRead_HTTPGetFailed(t *testing.T) {
 	// HTTP GET failed
 	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
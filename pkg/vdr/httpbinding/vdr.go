@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/retry"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
@@ -27,6 +28,7 @@ type VDR struct {
 	client           *http.Client
 	accept           Accept
 	resolveAuthToken string
+	retryPolicy      retry.Policy
 }
 
 // Accept is method to accept did method.
@@ -34,7 +36,11 @@ type Accept func(method string) bool
 
 // New creates new DID Resolver.
 func New(endpointURL string, opts ...Option) (*VDR, error) {
-	v := &VDR{client: &http.Client{}, accept: func(method string) bool { return true }}
+	v := &VDR{
+		client:      &http.Client{},
+		accept:      func(method string) bool { return true },
+		retryPolicy: retry.DefaultPolicy(),
+	}
 
 	for _, opt := range opts {
 		opt(v)
@@ -98,6 +104,14 @@ func WithAccept(accept Accept) Option {
 	}
 }
 
+// WithRetryPolicy option is for definition of the retry policy of the resolutions, retry.DefaultPolicy if not
+// defined. The retries are disabled with retry.NoRetry.
+func WithRetryPolicy(policy retry.Policy) Option {
+	return func(opts *VDR) {
+		opts.retryPolicy = policy
+	}
+}
+
 // WithResolveAuthToken add auth token for resolve.
 func WithResolveAuthToken(authToken string) Option {
 	return func(opts *VDR) {
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -34,6 +35,7 @@ type Registry struct {
 	kms                kms.KeyManager
 	defServiceEndpoint string
 	defServiceType     string
+	cache              *cache.Cache
 }
 
 // New return new instance of vdr.
@@ -61,6 +63,14 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.ResolveOpts) (*diddoc.Doc,
 		return nil, err
 	}
 
+	cacheable := !resolveOpts.NoCache && resolveOpts.VersionID == nil && resolveOpts.VersionTime == ""
+
+	if cacheable {
+		if doc, ok := r.cache.Get(cache.DIDDocKeyPrefix + did); ok {
+			return diddoc.ParseDocument(doc.([]byte)) // nolint: errcheck
+		}
+	}
+
 	// resolve did method
 	method, err := r.resolveVDR(didMethod)
 	if err != nil {
@@ -82,6 +92,13 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.ResolveOpts) (*diddoc.Doc,
 		return nil, errors.New("result type 'resolution-result' not supported")
 	}
 
+	if cacheable && r.cache != nil && didDoc != nil {
+		// the document is cached as JSON, so that the resolved documents are not shared
+		if doc, err := didDoc.JSONBytes(); err == nil {
+			r.cache.Put(cache.DIDDocKeyPrefix+did, doc)
+		}
+	}
+
 	return didDoc, nil
 }
 
@@ -145,6 +162,8 @@ func (r *Registry) Store(doc *diddoc.Doc) error {
 		return err
 	}
 
+	r.cache.Invalidate(cache.DIDDocKeyPrefix + doc.ID)
+
 	return method.Store(doc, nil)
 }
 
@@ -166,7 +185,7 @@ func (r *Registry) resolveVDR(method string) (vdrapi.VDR, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("did method %s not supported for vdr", method)
+	return nil, fmt.Errorf("did method %s not supported for vdr: %w", method, vdrapi.ErrMethodNotSupported)
 }
 
 // WithVDR adds did method implementation for store.
@@ -176,6 +195,14 @@ func WithVDR(method vdrapi.VDR) Option {
 	}
 }
 
+// WithCache sets the cache of the resolved DID documents, e.g. the cache of the framework context. The documents
+// resolved with the vdrapi.WithNoCache option or for a version are not cached.
+func WithCache(c *cache.Cache) Option {
+	return func(opts *Registry) {
+		opts.cache = c
+	}
+}
+
 // WithDefaultServiceType is default service type for this creator.
 func WithDefaultServiceType(serviceType string) Option {
 	return func(opts *Registry) {
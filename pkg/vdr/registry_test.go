@@ -7,13 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package vdr
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
@@ -63,6 +66,7 @@ func TestRegistry_Resolve(t *testing.T) {
 		doc, err := registry.Resolve("1:id:123")
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "did method id not supported for vdr")
+		require.True(t, errors.Is(err, vdrapi.ErrMethodNotSupported))
 		require.Nil(t, doc)
 	})
 
@@ -121,6 +125,54 @@ func TestRegistry_Resolve(t *testing.T) {
 	})
 }
 
+func TestRegistry_ResolveWithCache(t *testing.T) {
+	didDoc := mockdiddoc.GetMockDIDDoc()
+	reads := 0
+
+	c := cache.New()
+	registry := New(&mockprovider.Provider{}, WithCache(c), WithVDR(&mockvdr.MockVDR{
+		AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.ResolveOpts) (*did.Doc, error) {
+			reads++
+
+			return didDoc, nil
+		},
+	}))
+
+	for i := 0; i < 2; i++ {
+		doc, err := registry.Resolve(didDoc.ID)
+		require.NoError(t, err)
+		require.Equal(t, didDoc.ID, doc.ID)
+		require.Len(t, doc.VerificationMethod, len(didDoc.VerificationMethod))
+	}
+
+	require.Equal(t, 1, reads)
+
+	t.Run("document resolved without cache", func(t *testing.T) {
+		_, err := registry.Resolve(didDoc.ID, vdrapi.WithNoCache(true))
+		require.NoError(t, err)
+		require.Equal(t, 2, reads)
+
+		_, err = registry.Resolve(didDoc.ID, vdrapi.WithVersionID("1"))
+		require.NoError(t, err)
+		require.Equal(t, 3, reads)
+	})
+
+	t.Run("stored document is invalidated", func(t *testing.T) {
+		var invalidated []string
+
+		c.OnInvalidate(func(key string) {
+			invalidated = append(invalidated, key)
+		})
+
+		require.NoError(t, registry.Store(didDoc))
+		require.Equal(t, []string{cache.DIDDocKeyPrefix + didDoc.ID}, invalidated)
+
+		_, err := registry.Resolve(didDoc.ID)
+		require.NoError(t, err)
+		require.Equal(t, 4, reads)
+	})
+}
+
 func TestRegistry_Store(t *testing.T) {
 	t.Run("test invalid did input", func(t *testing.T) {
 		registry := New(&mockprovider.Provider{})
@@ -49,6 +49,10 @@ func (v *VDR) Read(didID string, opts ...vdr.ResolveOpts) (*did.Doc, error) {
 
 	defer closeResponseBody(resp.Body)
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, vdr.ErrNotFound
+	}
+
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving did:web did --> error reading http response body: %s --> %w", body, err)
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -101,6 +102,17 @@ func TestResolveDID(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "error parsing did doc")
 	})
+	t.Run("test resolve did not found", func(t *testing.T) {
+		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer s.Close()
+		did := fmt.Sprintf("did:web:%s", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+		v := New()
+		doc, err := v.Read(did, vdr.WithHTTPClient(s.Client()))
+		require.Nil(t, doc)
+		require.True(t, errors.Is(err, vdr.ErrNotFound))
+	})
 	t.Run("test resolve did success", func(t *testing.T) {
 		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			_, err := w.Write([]byte(validDoc))
@@ -0,0 +1,412 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	auditKeyPrefix     = "audit_"
+	auditHeadKeyPrefix = "audithead_"
+)
+
+// wallet operations disclosing credentials, recorded in the audit log.
+const (
+	// PresentProofOperation is the presentation of credentials to a verifier over the present-proof protocol.
+	PresentProofOperation = "PresentProof"
+	// QueryOperation is the presentation of the credentials matching a verifiable presentation request.
+	QueryOperation = "Query"
+	// DeriveOperation is the derivation of a selective disclosure of a credential.
+	DeriveOperation = "Derive"
+)
+
+// ErrAuditLogTampered is returned when the records of the audit log were removed, replaced or reordered.
+var ErrAuditLogTampered = errors.New("wallet audit log was tampered with")
+
+// DisclosureRecord is the record of the audit log of a disclosure of wallet credentials, or of a request the user
+// declined.
+type DisclosureRecord struct {
+	// Seq is the position of the record in the audit log, from 1.
+	Seq  int       `json:"seq"`
+	Time time.Time `json:"time"`
+	// Operation is the wallet operation which disclosed the credentials.
+	Operation string `json:"operation"`
+	// Recipient is who the credentials were disclosed to, the DID of the verifier for PresentProof.
+	Recipient string `json:"recipient,omitempty"`
+	// RequestID is the ID of the request, the present-proof protocol instance ID for PresentProof.
+	RequestID string `json:"requestID,omitempty"`
+	// Request is the request the credentials were disclosed under: the presentation definition for PresentProof, the
+	// queries for Query and the frame for Derive.
+	Request     json.RawMessage       `json:"request,omitempty"`
+	Credentials []DisclosedCredential `json:"credentials,omitempty"`
+	// Declined is the reason the request was declined, no credential being disclosed.
+	Declined string `json:"declined,omitempty"`
+	// Previous is the hash of the previous record, chaining the records of the log.
+	Previous string `json:"previous,omitempty"`
+}
+
+// DisclosedCredential is a credential disclosed, with the attributes of its subject which were revealed.
+type DisclosedCredential struct {
+	ID     string   `json:"id"`
+	Types  []string `json:"types,omitempty"`
+	Issuer string   `json:"issuer,omitempty"`
+	// Attributes are the paths of the subject attributes revealed, such as "degree.type".
+	Attributes []string `json:"attributes,omitempty"`
+}
+
+// auditHead is the position and hash of the last record of the audit log.
+type auditHead struct {
+	Seq  int    `json:"seq"`
+	Hash string `json:"hash"`
+}
+
+// AuditLogOpt filters the records returned by AuditLog.
+type AuditLogOpt func(filter *auditFilter)
+
+type auditFilter struct {
+	recipient    string
+	credentialID string
+	from, to     time.Time
+}
+
+// DisclosedTo returns only the records of the disclosures to the recipient.
+func DisclosedTo(recipient string) AuditLogOpt {
+	return func(filter *auditFilter) {
+		filter.recipient = recipient
+	}
+}
+
+// DisclosingCredential returns only the records of the disclosures of the credential.
+func DisclosingCredential(credentialID string) AuditLogOpt {
+	return func(filter *auditFilter) {
+		filter.credentialID = credentialID
+	}
+}
+
+// DisclosedBetween returns only the records between from and to, a zero time leaving the period open.
+func DisclosedBetween(from, to time.Time) AuditLogOpt {
+	return func(filter *auditFilter) {
+		filter.from, filter.to = from, to
+	}
+}
+
+func (f *auditFilter) matches(record *DisclosureRecord) bool {
+	if f.recipient != "" && f.recipient != record.Recipient {
+		return false
+	}
+
+	if !f.from.IsZero() && record.Time.Before(f.from) || !f.to.IsZero() && record.Time.After(f.to) {
+		return false
+	}
+
+	if f.credentialID == "" {
+		return true
+	}
+
+	for _, credential := range record.Credentials {
+		if credential.ID == f.credentialID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuditLog returns the records of the audit log of the unlocked wallet, oldest first, optionally filtered. The
+// records are appended by the operations disclosing credentials, PresentProof also recording the requests the user
+// declined, and can't be changed or removed: ErrAuditLogTampered is returned if the chain of records is broken.
+func (c *Wallet) AuditLog(authToken string, options ...AuditLogOpt) ([]*DisclosureRecord, error) {
+	filter := &auditFilter{}
+
+	for _, opt := range options {
+		opt(filter)
+	}
+
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	c.auditLock.Lock()
+	defer c.auditLock.Unlock()
+
+	records, err := c.auditRecords(aead)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*DisclosureRecord, 0, len(records))
+
+	for _, record := range records {
+		if filter.matches(record) {
+			filtered = append(filtered, record)
+		}
+	}
+
+	return filtered, nil
+}
+
+// ExportAuditLog writes the records of the audit log of the unlocked wallet to w as a JSON array, for the user to
+// keep or hand over a copy of the history of the disclosures.
+func (c *Wallet) ExportAuditLog(authToken string, w io.Writer) error {
+	records, err := c.AuditLog(authToken)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(records); err != nil {
+		return fmt.Errorf("failed to export wallet audit log : %w", err)
+	}
+
+	return nil
+}
+
+// recordDisclosure appends the record of the disclosure of the credentials to the audit log, before they're disclosed
+// so that no disclosure goes unrecorded.
+func (c *Wallet) recordDisclosure(authToken string, record *DisclosureRecord, vcs ...interface{}) error {
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return err
+	}
+
+	for _, vc := range vcs {
+		credential, err := disclosedCredential(vc)
+		if err != nil {
+			return err
+		}
+
+		record.Credentials = append(record.Credentials, *credential)
+	}
+
+	c.auditLock.Lock()
+	defer c.auditLock.Unlock()
+
+	head, err := c.getAuditHead(aead)
+	if err != nil {
+		return err
+	}
+
+	record.Seq = head.Seq + 1
+	record.Time = time.Now().UTC()
+	record.Previous = head.Hash
+
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallet audit record : %w", err)
+	}
+
+	if err := c.putAuditRecord(aead, c.auditKey(record.Seq), raw); err != nil {
+		return err
+	}
+
+	raw, err = json.Marshal(&auditHead{Seq: record.Seq, Hash: auditHash(raw)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallet audit log head : %w", err)
+	}
+
+	return c.putAuditRecord(aead, auditHeadKeyPrefix+userKey(c.userID), raw)
+}
+
+// auditRecords returns the records of the audit log, checking the chain of their hashes up to the head.
+func (c *Wallet) auditRecords(aead cipher.AEAD) ([]*DisclosureRecord, error) {
+	head, err := c.getAuditHead(aead)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*DisclosureRecord, head.Seq)
+	previous := ""
+
+	for seq := 1; seq <= head.Seq; seq++ {
+		key := c.auditKey(seq)
+
+		raw, err := c.getAuditRecord(aead, key)
+		if err != nil {
+			return nil, err
+		}
+
+		record := &DisclosureRecord{}
+
+		if err := json.Unmarshal(raw, record); err != nil {
+			return nil, fmt.Errorf("invalid wallet audit record %d : %w", seq, err)
+		}
+
+		if record.Seq != seq || record.Previous != previous {
+			return nil, fmt.Errorf("record %d : %w", seq, ErrAuditLogTampered)
+		}
+
+		records[seq-1] = record
+		previous = auditHash(raw)
+	}
+
+	if previous != head.Hash {
+		return nil, fmt.Errorf("record %d : %w", head.Seq, ErrAuditLogTampered)
+	}
+
+	return records, nil
+}
+
+func (c *Wallet) getAuditHead(aead cipher.AEAD) (*auditHead, error) {
+	head := &auditHead{}
+
+	raw, err := c.getAuditRecord(aead, auditHeadKeyPrefix+userKey(c.userID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return head, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(raw, head); err != nil {
+		return nil, fmt.Errorf("invalid wallet audit log head : %w", err)
+	}
+
+	return head, nil
+}
+
+func (c *Wallet) putAuditRecord(aead cipher.AEAD, key string, raw []byte) error {
+	nonce := random.GetRandomBytes(uint32(aead.NonceSize()))
+
+	if err := c.store.Put(key, aead.Seal(nonce, nonce, raw, []byte(key))); err != nil {
+		return fmt.Errorf("failed to save wallet audit record : %w", err)
+	}
+
+	return nil
+}
+
+func (c *Wallet) getAuditRecord(aead cipher.AEAD, key string) ([]byte, error) {
+	encrypted, err := c.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) && !strings.HasPrefix(key, auditHeadKeyPrefix) {
+		return nil, fmt.Errorf("%s : %w", key, ErrAuditLogTampered)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet audit record : %w", err)
+	}
+
+	return decrypt(aead, key, encrypted)
+}
+
+// auditKey returns the store key of the record, the sequence being padded so that the records are sorted.
+func (c *Wallet) auditKey(seq int) string {
+	return fmt.Sprintf("%s%s_%016d", auditKeyPrefix, userKey(c.userID), seq)
+}
+
+func auditHash(raw []byte) string {
+	hash := sha256.Sum256(raw)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// disclosedCredential returns the ID, types, issuer and revealed subject attributes of the disclosed credential.
+func disclosedCredential(vc interface{}) (*DisclosedCredential, error) {
+	raw, err := json.Marshal(vc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal disclosed credential : %w", err)
+	}
+
+	var doc struct {
+		ID      string      `json:"id"`
+		Type    interface{} `json:"type"`
+		Issuer  interface{} `json:"issuer"`
+		Subject interface{} `json:"credentialSubject"`
+	}
+
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("invalid disclosed credential : %w", err)
+	}
+
+	credential := &DisclosedCredential{ID: doc.ID}
+
+	switch t := doc.Type.(type) {
+	case string:
+		credential.Types = []string{t}
+	case []interface{}:
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				credential.Types = append(credential.Types, s)
+			}
+		}
+	}
+
+	switch issuer := doc.Issuer.(type) {
+	case string:
+		credential.Issuer = issuer
+	case map[string]interface{}:
+		credential.Issuer, _ = issuer["id"].(string) // nolint: errcheck
+	}
+
+	attributes := make(map[string]struct{})
+	subjectAttributes("", doc.Subject, attributes)
+
+	for attribute := range attributes {
+		credential.Attributes = append(credential.Attributes, attribute)
+	}
+
+	sort.Strings(credential.Attributes)
+
+	return credential, nil
+}
+
+// subjectAttributes collects the paths of the attributes of the subjects, the JSON-LD keywords excluded.
+func subjectAttributes(path string, subject interface{}, attributes map[string]struct{}) {
+	switch s := subject.(type) {
+	case []interface{}:
+		for _, v := range s {
+			subjectAttributes(path, v, attributes)
+		}
+	case map[string]interface{}:
+		for k, v := range s {
+			if strings.HasPrefix(k, "@") {
+				continue
+			}
+
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+
+			if _, nested := v.(map[string]interface{}); nested {
+				subjectAttributes(p, v, attributes)
+
+				continue
+			}
+
+			attributes[p] = struct{}{}
+		}
+	}
+}
+
+// credentialsOf returns the credentials of the presentations.
+func credentialsOf(vps ...*verifiable.Presentation) []interface{} {
+	var vcs []interface{}
+
+	for _, vp := range vps {
+		vcs = append(vcs, vp.Credentials()...)
+	}
+
+	return vcs
+}
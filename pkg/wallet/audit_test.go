@@ -0,0 +1,138 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallet_AuditLog(t *testing.T) {
+	t.Run("record the disclosures", func(t *testing.T) {
+		wallet, token, _ := newPresentProofWallet(t, degreeRequest())
+
+		records, err := wallet.AuditLog(token)
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		_, err = wallet.PresentProof(token, samplePIID)
+		require.NoError(t, err)
+
+		_, err = wallet.Query(token, []*QueryParams{{
+			Type:            QueryByExample,
+			CredentialQuery: json.RawMessage(`{"example": {"type": "VerifiableCredential"}}`),
+		}}, WithQueryRecipient("did:example:verifier"))
+		require.NoError(t, err)
+
+		records, err = wallet.AuditLog(token)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		require.Equal(t, 1, records[0].Seq)
+		require.Equal(t, PresentProofOperation, records[0].Operation)
+		require.Equal(t, "their-did", records[0].Recipient)
+		require.Equal(t, samplePIID, records[0].RequestID)
+		require.Contains(t, string(records[0].Request), `"id":"degree"`)
+		require.Equal(t, []DisclosedCredential{{
+			ID:         "http://example.edu/credentials/3732",
+			Types:      []string{"VerifiableCredential", "UniversityDegreeCredential"},
+			Issuer:     "did:example:university",
+			Attributes: []string{"degree.name", "degree.type", "id"},
+		}}, records[0].Credentials)
+		require.Empty(t, records[0].Previous)
+		require.WithinDuration(t, time.Now(), records[0].Time, time.Minute)
+
+		require.Equal(t, QueryOperation, records[1].Operation)
+		require.Equal(t, "did:example:verifier", records[1].Recipient)
+		require.Len(t, records[1].Credentials, 2)
+		require.NotEmpty(t, records[1].Previous)
+
+		// the records can be filtered
+		records, err = wallet.AuditLog(token, DisclosedTo("their-did"))
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+
+		records, err = wallet.AuditLog(token, DisclosingCredential("http://example.edu/credentials/1872"))
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, QueryOperation, records[0].Operation)
+
+		records, err = wallet.AuditLog(token, DisclosedBetween(time.Now().Add(time.Minute), time.Time{}))
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		// and exported
+		var exported bytes.Buffer
+		require.NoError(t, wallet.ExportAuditLog(token, &exported))
+
+		var exportedRecords []*DisclosureRecord
+		require.NoError(t, json.Unmarshal(exported.Bytes(), &exportedRecords))
+		require.Len(t, exportedRecords, 2)
+	})
+
+	t.Run("record the declined requests", func(t *testing.T) {
+		wallet, token, _ := newPresentProofWallet(t, degreeRequest())
+
+		consents := make(chan ConsentRequest)
+		require.NoError(t, wallet.RegisterConsentEvent(consents))
+
+		go func() {
+			(<-consents).Stop(errors.New("not now"))
+		}()
+
+		vp, err := wallet.PresentProof(token, samplePIID)
+		require.NoError(t, err)
+		require.Nil(t, vp)
+
+		records, err := wallet.AuditLog(token)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, "not now", records[0].Declined)
+		require.Empty(t, records[0].Credentials)
+	})
+
+	t.Run("detect tampering", func(t *testing.T) {
+		wallet, token, _ := newPresentProofWallet(t, degreeRequest())
+
+		for i := 0; i < 3; i++ {
+			_, err := wallet.Query(token, []*QueryParams{{
+				Type:            QueryByExample,
+				CredentialQuery: json.RawMessage(`{"example": {"type": "UniversityDegreeCredential"}}`),
+			}})
+			require.NoError(t, err)
+		}
+
+		first, err := wallet.store.Get(wallet.auditKey(1))
+		require.NoError(t, err)
+
+		// the records are bound to their position
+		require.NoError(t, wallet.store.Put(wallet.auditKey(2), first))
+
+		_, err = wallet.AuditLog(token)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt wallet content")
+
+		require.NoError(t, wallet.store.Delete(wallet.auditKey(2)))
+
+		_, err = wallet.AuditLog(token)
+		require.True(t, errors.Is(err, ErrAuditLogTampered))
+
+		require.True(t, errors.Is(wallet.ExportAuditLog(token, &bytes.Buffer{}), ErrAuditLogTampered))
+	})
+
+	t.Run("locked wallet", func(t *testing.T) {
+		wallet := newWallet(t, newProvider())
+
+		_, err := wallet.AuditLog("unknown")
+		require.True(t, errors.Is(err, ErrWalletLocked))
+	})
+}
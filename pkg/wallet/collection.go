@@ -0,0 +1,192 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const contentMetaKeyPrefix = "contentmeta_"
+
+// contentMeta is the collection and tags of a wallet content, kept encrypted next to the content. The contents of the
+// synchronized wallets also have the vector clock of their changes and the device which made the last change, their
+// metadata being kept when they're removed. The credentials found revoked or suspended by the credential monitor are
+// flagged.
+type contentMeta struct {
+	Collection string         `json:"collection,omitempty"`
+	Tags       []string       `json:"tags,omitempty"`
+	Clock      vectorClock    `json:"clock,omitempty"`
+	Device     string         `json:"device,omitempty"`
+	Removed    bool           `json:"removed,omitempty"`
+	Flag       CredentialFlag `json:"flag,omitempty"`
+}
+
+func (m *contentMeta) empty() bool {
+	return m.Collection == "" && len(m.Tags) == 0 && len(m.Clock) == 0 && m.Flag == ""
+}
+
+// matches tells whether the content is in the collection, if any, and has all the tags.
+func (m *contentMeta) matches(filter *contentMeta) bool {
+	if filter.Collection != "" && filter.Collection != m.Collection {
+		return false
+	}
+
+	for _, tag := range filter.Tags {
+		found := false
+
+		for _, t := range m.Tags {
+			if t == tag {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddOpt configures the content added to the wallet.
+type AddOpt func(meta *contentMeta)
+
+// AddToCollection adds the content to the collection of the given ID, a content of type Collection of the wallet.
+func AddToCollection(collectionID string) AddOpt {
+	return func(meta *contentMeta) {
+		meta.Collection = collectionID
+	}
+}
+
+// WithTags tags the content, the contents can then be searched by tags with FilterByTags.
+func WithTags(tags ...string) AddOpt {
+	return func(meta *contentMeta) {
+		meta.Tags = tags
+	}
+}
+
+// GetAllOpt filters the contents returned by GetAll.
+type GetAllOpt func(filter *contentMeta)
+
+// FilterByCollection returns only the contents of the collection of the given ID.
+func FilterByCollection(collectionID string) GetAllOpt {
+	return func(filter *contentMeta) {
+		filter.Collection = collectionID
+	}
+}
+
+// FilterByTags returns only the contents having all the given tags.
+func FilterByTags(tags ...string) GetAllOpt {
+	return func(filter *contentMeta) {
+		filter.Tags = tags
+	}
+}
+
+// putContentMeta saves the collection and tags of a content under its metadata key, removing those it had if it has
+// none.
+func (c *Wallet) putContentMeta(aead cipher.AEAD, key string, meta *contentMeta) error {
+	if meta.empty() {
+		if err := c.store.Delete(key); err != nil {
+			return fmt.Errorf("failed to remove wallet content metadata : %w", err)
+		}
+
+		return nil
+	}
+
+	raw, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallet content metadata : %w", err)
+	}
+
+	nonce := random.GetRandomBytes(uint32(aead.NonceSize()))
+
+	if err := c.store.Put(key, aead.Seal(nonce, nonce, raw, []byte(key))); err != nil {
+		return fmt.Errorf("failed to save wallet content metadata : %w", err)
+	}
+
+	return nil
+}
+
+// getContentMeta returns the collection and tags of the content, empty if it has none.
+func (c *Wallet) getContentMeta(aead cipher.AEAD, contentType ContentType, contentID string) (*contentMeta, error) {
+	key := c.contentMetaKey(contentType, contentID)
+
+	encrypted, err := c.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &contentMeta{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet content metadata : %w", err)
+	}
+
+	return decryptContentMeta(aead, key, encrypted)
+}
+
+// clearCollection removes the contents of all types from the removed collection, the contents themselves are kept.
+func (c *Wallet) clearCollection(aead cipher.AEAD, collectionID string) error {
+	prefix := contentMetaKeyPrefix + userKey(c.userID) + "_"
+
+	iter := c.store.Iterator(prefix, prefix+storage.EndKeySuffix)
+	defer iter.Release()
+
+	updates := make(map[string]*contentMeta)
+
+	for iter.Next() {
+		key := string(iter.Key())
+
+		meta, err := decryptContentMeta(aead, key, iter.Value())
+		if err != nil {
+			return err
+		}
+
+		if meta.Collection == collectionID {
+			meta.Collection = ""
+			updates[key] = meta
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to get wallet content metadata : %w", err)
+	}
+
+	for key, meta := range updates {
+		if err := c.putContentMeta(aead, key, meta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Wallet) contentMetaKey(contentType ContentType, contentID string) string {
+	return contentMetaKeyPrefix + userKey(c.userID) + "_" + string(contentType) + "_" + contentID
+}
+
+func decryptContentMeta(aead cipher.AEAD, key string, encrypted []byte) (*contentMeta, error) {
+	raw, err := decrypt(aead, key, encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &contentMeta{}
+
+	if err := json.Unmarshal(raw, meta); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wallet content metadata %s : %w", key, err)
+	}
+
+	return meta, nil
+}
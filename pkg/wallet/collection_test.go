@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sampleCollection = `{"id": "work", "type": "collection", "name": "Work"}`
+	sampleDisplay    = `{"id": "display-1872", "credential": "http://example.edu/credentials/1872", "color": "#0055aa"}`
+)
+
+func TestWallet_Collections(t *testing.T) {
+	wallet := newWallet(t, newProvider())
+	token := open(t, wallet, samplePassphrase)
+
+	require.NoError(t, wallet.Add(token, Collection, json.RawMessage(sampleCollection)))
+	require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleCredential),
+		AddToCollection("work"), WithTags("employment", "verified")))
+	require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleUniversityDegree),
+		WithTags("education", "verified")))
+	require.NoError(t, wallet.Add(token, Metadata, json.RawMessage(sampleDisplay), AddToCollection("work")))
+
+	t.Run("filter by collection", func(t *testing.T) {
+		credentials, err := wallet.GetAll(token, Credential, FilterByCollection("work"))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		require.Contains(t, credentials, "http://example.edu/credentials/1872")
+
+		metadata, err := wallet.GetAll(token, Metadata, FilterByCollection("work"))
+		require.NoError(t, err)
+		require.JSONEq(t, sampleDisplay, string(metadata["display-1872"]))
+
+		credentials, err = wallet.GetAll(token, Credential, FilterByCollection("health"))
+		require.NoError(t, err)
+		require.Empty(t, credentials)
+	})
+
+	t.Run("filter by tags", func(t *testing.T) {
+		credentials, err := wallet.GetAll(token, Credential, FilterByTags("verified"))
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+
+		credentials, err = wallet.GetAll(token, Credential, FilterByTags("verified", "education"))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		require.Contains(t, credentials, "http://example.edu/credentials/3732")
+
+		credentials, err = wallet.GetAll(token, Credential, FilterByCollection("work"), FilterByTags("education"))
+		require.NoError(t, err)
+		require.Empty(t, credentials)
+	})
+
+	t.Run("unknown collection", func(t *testing.T) {
+		err := wallet.Add(token, Credential, json.RawMessage(sampleCredential), AddToCollection("health"))
+		require.True(t, errors.Is(err, ErrContentNotFound))
+		require.Contains(t, err.Error(), "collection health")
+	})
+
+	t.Run("replace content", func(t *testing.T) {
+		// the collection and tags of the content are replaced as well
+		require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleUniversityDegree)))
+
+		credentials, err := wallet.GetAll(token, Credential, FilterByTags("education"))
+		require.NoError(t, err)
+		require.Empty(t, credentials)
+
+		require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleUniversityDegree), WithTags("education")))
+	})
+
+	t.Run("remove collection", func(t *testing.T) {
+		require.NoError(t, wallet.Remove(token, Collection, "work"))
+
+		// the contents of the collection are kept, with their tags
+		credentials, err := wallet.GetAll(token, Credential, FilterByTags("employment"))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+
+		require.NoError(t, wallet.Add(token, Collection, json.RawMessage(sampleCollection)))
+
+		credentials, err = wallet.GetAll(token, Credential, FilterByCollection("work"))
+		require.NoError(t, err)
+		require.Empty(t, credentials)
+
+		metadata, err := wallet.GetAll(token, Metadata)
+		require.NoError(t, err)
+		require.Len(t, metadata, 1)
+	})
+
+	t.Run("remove content", func(t *testing.T) {
+		require.NoError(t, wallet.Remove(token, Credential, "http://example.edu/credentials/3732"))
+		require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleUniversityDegree)))
+
+		credentials, err := wallet.GetAll(token, Credential, FilterByTags("education"))
+		require.NoError(t, err)
+		require.Empty(t, credentials)
+	})
+}
@@ -0,0 +1,505 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/google/tink/go/subtle/random"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/edv"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/formattedstore"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const (
+	// vaultProfileStoreName is the name of the store of the profiles in the vault.
+	vaultProfileStoreName = "profiles"
+
+	// the infos of the keys expanded for the vault
+	vaultEncryptionInfo        = "vcwallet-edv-encryption"
+	vaultMACInfo               = "vcwallet-edv-mac"
+	vaultProfileEncryptionInfo = "vcwallet-edv-profile-encryption"
+	vaultProfileMACInfo        = "vcwallet-edv-profile-mac"
+
+	jweAlgDirect  = "dir"
+	jweEncA256GCM = "A256GCM"
+)
+
+// ErrContentConflict is returned when saving a wallet content which another device sharing the EDV vault of the
+// profile changed since this device last read it. The local copy is refreshed, so that the change can be made again
+// on the latest content.
+var ErrContentConflict = errors.New("wallet content changed in the EDV vault since last read")
+
+var errVaultDecrypt = errors.New("failed to decrypt vault record")
+
+// ProfileOpt configures the wallet profile created by CreateProfile.
+type ProfileOpt func(opts *profileOpts)
+
+type profileOpts struct {
+	edv *edvConf
+}
+
+// edvConf is the EDV vault the contents and keys of a profile are kept in.
+type edvConf struct {
+	ServerURL string `json:"serverURL"`
+	VaultID   string `json:"vaultID"`
+}
+
+// WithEDVStorage keeps the contents and keys of the profile in the vault of the EDV server instead of the storage of
+// the agent, so that the wallet can be opened from every device sharing the vault. The records are encrypted and
+// their keys hidden with keys of the profile before being sent to the vault, and cached in the storage of the agent.
+// The profile is shared through the vault as well, encrypted with the passphrase: creating the profile of a user who
+// already has one in the vault gives access to the existing wallet, provided the passphrase is the same.
+func WithEDVStorage(serverURL, vaultID string) ProfileOpt {
+	return func(opts *profileOpts) {
+		opts.edv = &edvConf{ServerURL: serverURL, VaultID: vaultID}
+	}
+}
+
+// shareProfile saves the profile in the vault, returning instead the profile the user already has in the vault.
+func (e *edvConf) shareProfile(p *profile, passphrase string) (*profile, error) {
+	if e.ServerURL == "" || e.VaultID == "" {
+		return nil, errors.New("EDV server URL and vault ID are mandatory")
+	}
+
+	// the document ID of the profile can't depend on the passphrase, so that a wrong one is detected
+	salt := []byte(userKey(p.UserID))
+
+	provider, err := e.provider(expandKey([]byte(passphrase), salt, vaultProfileEncryptionInfo),
+		expandKey([]byte(e.VaultID), salt, vaultProfileMACInfo))
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := provider.OpenStore(vaultProfileStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vault profile store : %w", err)
+	}
+
+	profileBytes, err := store.Get(profileKey(p.UserID))
+
+	switch {
+	case err == nil:
+		shared := &profile{}
+
+		if err := json.Unmarshal(profileBytes, shared); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal vault profile : %w", err)
+		}
+
+		// the server of the vault may be reached at another URL from this device
+		shared.EDV = e
+
+		return shared, nil
+	case errors.Is(err, errVaultDecrypt):
+		return nil, ErrInvalidPassphrase
+	case !errors.Is(err, storage.ErrDataNotFound):
+		return nil, fmt.Errorf("failed to get vault profile : %w", err)
+	}
+
+	p.EDV = e
+
+	profileBytes, err = json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wallet profile : %w", err)
+	}
+
+	if err := store.Put(profileKey(p.UserID), profileBytes); err != nil {
+		return nil, fmt.Errorf("failed to save vault profile : %w", err)
+	}
+
+	return p, nil
+}
+
+// contentProvider returns the storage of the vault of the profile, whose keys are expanded from the content key.
+func (e *edvConf) contentProvider(contentKey []byte) (storage.Provider, error) {
+	return e.provider(expandKey(contentKey, nil, vaultEncryptionInfo), expandKey(contentKey, nil, vaultMACInfo))
+}
+
+// provider returns the storage of the vault, whose records are encrypted with encryptionKey and whose document IDs
+// are MACs of the record keys with macKey.
+func (e *edvConf) provider(encryptionKey, macKey []byte) (storage.Provider, error) {
+	aead, err := newAEAD(encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault cipher : %w", err)
+	}
+
+	macCrypto := edv.NewMACCrypto(macKey, hmacDigester{})
+
+	restProvider, err := edv.NewRESTProvider(e.ServerURL, e.VaultID, macCrypto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EDV provider : %w", err)
+	}
+
+	formatter := edv.NewEncryptedFormatter(&directJWE{aead: aead}, &directJWE{aead: aead}, macCrypto)
+
+	return formattedstore.NewFormattedProvider(restProvider, formatter, false), nil
+}
+
+func expandKey(secret, salt []byte, info string) []byte {
+	key := make([]byte, contentKeySize)
+
+	// reading from HKDF-SHA256 only fails past 255 hashes of output
+	_, _ = io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(info)), key)
+
+	return key
+}
+
+// hmacDigester computes the MACs of the vault with HMAC-SHA256.
+type hmacDigester struct{}
+
+func (hmacDigester) ComputeMAC(data []byte, kh interface{}) ([]byte, error) {
+	key, ok := kh.([]byte)
+	if !ok {
+		return nil, errors.New("invalid MAC key")
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(data)
+
+	return mac.Sum(nil), nil
+}
+
+// directJWE encrypts the documents of the vault into JWEs with the AES-GCM key of the vault used directly as content
+// encryption key, see https://tools.ietf.org/html/rfc7518#section-4.5.
+type directJWE struct {
+	aead cipher.AEAD
+}
+
+func (j *directJWE) Encrypt(plaintext []byte) (*jose.JSONWebEncryption, error) {
+	return j.EncryptWithAuthData(plaintext, nil)
+}
+
+func (j *directJWE) EncryptWithAuthData(plaintext, aad []byte) (*jose.JSONWebEncryption, error) {
+	headers := jose.Headers{jose.HeaderAlgorithm: jweAlgDirect, jose.HeaderEncryption: jweEncA256GCM}
+
+	protected, err := json.Marshal(headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JWE headers : %w", err)
+	}
+
+	b64Protected := base64.RawURLEncoding.EncodeToString(protected)
+	nonce := random.GetRandomBytes(uint32(j.aead.NonceSize()))
+	sealed := j.aead.Seal(nil, nonce, plaintext, jweAuthData(b64Protected, aad))
+	tagStart := len(sealed) - j.aead.Overhead()
+
+	return &jose.JSONWebEncryption{
+		ProtectedHeaders:   headers,
+		OrigProtectedHders: b64Protected,
+		AAD:                string(aad),
+		IV:                 string(nonce),
+		Ciphertext:         string(sealed[:tagStart]),
+		Tag:                string(sealed[tagStart:]),
+	}, nil
+}
+
+func (j *directJWE) Decrypt(jwe *jose.JSONWebEncryption) ([]byte, error) {
+	if enc, _ := jwe.ProtectedHeaders.Encryption(); enc != jweEncA256GCM || len(jwe.IV) != j.aead.NonceSize() {
+		return nil, errVaultDecrypt
+	}
+
+	plaintext, err := j.aead.Open(nil, []byte(jwe.IV), []byte(jwe.Ciphertext+jwe.Tag),
+		jweAuthData(jwe.OrigProtectedHders, []byte(jwe.AAD)))
+	if err != nil {
+		return nil, errVaultDecrypt
+	}
+
+	return plaintext, nil
+}
+
+// jweAuthData returns the additional authenticated data of a JWE, see https://tools.ietf.org/html/rfc7516#section-5.1.
+func jweAuthData(b64Protected string, aad []byte) []byte {
+	if len(aad) == 0 {
+		return []byte(b64Protected)
+	}
+
+	return []byte(b64Protected + "." + base64.RawURLEncoding.EncodeToString(aad))
+}
+
+// vaultRecord is a record of the vault, whose revision changes on every update so that the devices sharing the vault
+// can tell whether their cached copy is the latest.
+type vaultRecord struct {
+	Revision string `json:"revision"`
+	Value    []byte `json:"value"`
+}
+
+func parseVaultRecord(raw []byte) (*vaultRecord, error) {
+	record := &vaultRecord{}
+
+	if err := json.Unmarshal(raw, record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal vault record : %w", err)
+	}
+
+	return record, nil
+}
+
+// vaultStorage is the storage of a profile kept in an EDV vault, the local stores of the profile caching the records
+// of the vault. The vault is only reachable while connected, with the keys of the unlocked profile.
+type vaultStorage struct {
+	local  storage.Provider
+	remote storage.Provider
+	lock   sync.RWMutex
+}
+
+// OpenStore opens the store of the vault, cached in the local store of the same name.
+func (s *vaultStorage) OpenStore(name string) (storage.Store, error) {
+	cache, err := s.local.OpenStore(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache of vault store %s : %w", name, err)
+	}
+
+	return &vaultStore{name: name, vault: s, cache: cache}, nil
+}
+
+// CloseStore closes the cache of the store.
+func (s *vaultStorage) CloseStore(name string) error {
+	return s.local.CloseStore(name)
+}
+
+// Close closes the caches of the stores.
+func (s *vaultStorage) Close() error {
+	return s.local.Close()
+}
+
+// connect sets the storage of the vault, nil disconnecting it.
+func (s *vaultStorage) connect(remote storage.Provider) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.remote = remote
+}
+
+func (s *vaultStorage) openRemote(name string) (storage.Store, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.remote == nil {
+		return nil, ErrWalletLocked
+	}
+
+	store, err := s.remote.OpenStore(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vault store %s : %w", name, err)
+	}
+
+	return store, nil
+}
+
+// vaultStore is a store of the vault whose records are read from the local cache first, the cache being refreshed with
+// the records of the vault on iteration.
+type vaultStore struct {
+	name  string
+	vault *vaultStorage
+	cache storage.Store
+}
+
+// Put saves the record in the vault, failing with ErrContentConflict when another device changed it since this device
+// last read or wrote it.
+func (s *vaultStore) Put(k string, v []byte) error {
+	remote, err := s.vault.openRemote(s.name)
+	if err != nil {
+		return err
+	}
+
+	latest, err := remote.Get(k)
+
+	switch {
+	case err == nil:
+		if err := s.checkRevision(k, latest); err != nil {
+			return err
+		}
+	case !errors.Is(err, storage.ErrDataNotFound):
+		return fmt.Errorf("failed to get vault record : %w", err)
+	}
+
+	record, err := json.Marshal(&vaultRecord{Revision: uuid.New().String(), Value: v})
+	if err != nil {
+		return fmt.Errorf("failed to marshal vault record : %w", err)
+	}
+
+	if err := remote.Put(k, record); err != nil {
+		return fmt.Errorf("failed to save vault record : %w", err)
+	}
+
+	if err := s.cache.Put(k, record); err != nil {
+		return fmt.Errorf("failed to cache vault record : %w", err)
+	}
+
+	return nil
+}
+
+// checkRevision compares the revision of the latest record of the vault with that of the cached copy, which is
+// refreshed if they differ.
+func (s *vaultStore) checkRevision(k string, latest []byte) error {
+	latestRecord, err := parseVaultRecord(latest)
+	if err != nil {
+		return err
+	}
+
+	cached, err := s.cache.Get(k)
+
+	switch {
+	case err == nil:
+		cachedRecord, err := parseVaultRecord(cached)
+		if err != nil {
+			return err
+		}
+
+		if cachedRecord.Revision == latestRecord.Revision {
+			return nil
+		}
+	case !errors.Is(err, storage.ErrDataNotFound):
+		return fmt.Errorf("failed to get cached vault record : %w", err)
+	}
+
+	if err := s.cache.Put(k, latest); err != nil {
+		return fmt.Errorf("failed to cache vault record : %w", err)
+	}
+
+	return ErrContentConflict
+}
+
+// Get returns the cached record, fetching it from the vault if it isn't cached yet.
+func (s *vaultStore) Get(k string) ([]byte, error) {
+	record, err := s.cache.Get(k)
+	if err == nil {
+		return recordValue(record)
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("failed to get cached vault record : %w", err)
+	}
+
+	remote, err := s.vault.openRemote(s.name)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err = remote.Get(k)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vault record : %w", err)
+	}
+
+	if err := s.cache.Put(k, record); err != nil {
+		return nil, fmt.Errorf("failed to cache vault record : %w", err)
+	}
+
+	return recordValue(record)
+}
+
+// Iterator iterates over the records of the vault in the key range, replacing the cached records of the range with
+// them.
+func (s *vaultStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	remote, err := s.vault.openRemote(s.name)
+	if err != nil {
+		return mem.NewMemIterator(nil, err)
+	}
+
+	iter := remote.Iterator(startKey, endKey)
+	defer iter.Release()
+
+	latest := make(map[string][]byte)
+
+	var batch [][]string
+
+	for iter.Next() {
+		key := string(iter.Key())
+
+		value, err := recordValue(iter.Value())
+		if err != nil {
+			return mem.NewMemIterator(nil, err)
+		}
+
+		latest[key] = iter.Value()
+		batch = append(batch, []string{key, string(value)})
+	}
+
+	if err := iter.Error(); err != nil {
+		return mem.NewMemIterator(nil, fmt.Errorf("failed to iterate vault store %s : %w", s.name, err))
+	}
+
+	if err := s.refresh(startKey, endKey, latest); err != nil {
+		return mem.NewMemIterator(nil, err)
+	}
+
+	sort.Slice(batch, func(i, j int) bool { return batch[i][0] < batch[j][0] })
+
+	return mem.NewMemIterator(batch, nil)
+}
+
+// refresh replaces the cached records of the key range with the latest records of the vault.
+func (s *vaultStore) refresh(startKey, endKey string, latest map[string][]byte) error {
+	var operations []storage.Operation
+
+	iter := s.cache.Iterator(startKey, endKey)
+	defer iter.Release()
+
+	for iter.Next() {
+		if _, ok := latest[string(iter.Key())]; !ok {
+			operations = append(operations, storage.Operation{Key: string(iter.Key())})
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to iterate cache of vault store %s : %w", s.name, err)
+	}
+
+	for key, record := range latest {
+		operations = append(operations, storage.Operation{Key: key, Value: record})
+	}
+
+	if err := s.cache.Batch(operations); err != nil {
+		return fmt.Errorf("failed to refresh cache of vault store %s : %w", s.name, err)
+	}
+
+	return nil
+}
+
+// Delete removes the record from the vault and the cache.
+func (s *vaultStore) Delete(k string) error {
+	remote, err := s.vault.openRemote(s.name)
+	if err != nil {
+		return err
+	}
+
+	if err := remote.Delete(k); err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("failed to delete vault record : %w", err)
+	}
+
+	if err := s.cache.Delete(k); err != nil {
+		return fmt.Errorf("failed to delete cached vault record : %w", err)
+	}
+
+	return nil
+}
+
+// Batch performs the operations one by one, since the vault has no atomic batch.
+func (s *vaultStore) Batch(operations []storage.Operation) error {
+	return storage.ApplyOperations(s, operations)
+}
+
+func recordValue(raw []byte) ([]byte, error) {
+	record, err := parseVaultRecord(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.Value, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockedv "github.com/hyperledger/aries-framework-go/pkg/mock/edv"
+)
+
+const sampleVaultID = "sample-vault"
+
+func TestWallet_EDVStorage(t *testing.T) {
+	t.Run("share the wallet between devices", func(t *testing.T) {
+		server := newEDVServer(t)
+		defer server.Close()
+
+		laptop, phone := newProvider(), newProvider()
+
+		laptopWallet := newEDVWallet(t, laptop, server.URL)
+		laptopToken := open(t, laptopWallet, samplePassphrase)
+
+		require.NoError(t, laptopWallet.Add(laptopToken, Credential, json.RawMessage(sampleCredential)))
+
+		keyManager, err := laptopWallet.KMS(laptopToken)
+		require.NoError(t, err)
+
+		keyID, _, err := keyManager.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		err = CreateProfile(sampleUserID, "other-passphrase", phone, WithEDVStorage(server.URL, sampleVaultID))
+		require.True(t, errors.Is(err, ErrInvalidPassphrase))
+
+		// the phone joins the wallet of the vault
+		phoneWallet := newEDVWallet(t, phone, server.URL)
+		phoneToken := open(t, phoneWallet, samplePassphrase)
+
+		credential, err := phoneWallet.Get(phoneToken, Credential, "http://example.edu/credentials/1872")
+		require.NoError(t, err)
+		require.JSONEq(t, sampleCredential, string(credential))
+
+		keyManager, err = phoneWallet.KMS(phoneToken)
+		require.NoError(t, err)
+
+		_, err = keyManager.Get(keyID)
+		require.NoError(t, err)
+
+		require.NoError(t, phoneWallet.Add(phoneToken, Credential, json.RawMessage(sampleUniversityDegree)))
+
+		contents, err := laptopWallet.GetAll(laptopToken, Credential)
+		require.NoError(t, err)
+		require.Len(t, contents, 2)
+
+		require.NoError(t, phoneWallet.Remove(phoneToken, Credential, "http://example.edu/credentials/3732"))
+
+		contents, err = laptopWallet.GetAll(laptopToken, Credential)
+		require.NoError(t, err)
+		require.Len(t, contents, 1)
+
+		// the records are encrypted in the vault
+		for _, document := range server.DB {
+			require.NotContains(t, string(document), "example.edu")
+		}
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		server := newEDVServer(t)
+		defer server.Close()
+
+		laptop, phone := newProvider(), newProvider()
+
+		laptopWallet, phoneWallet := newEDVWallet(t, laptop, server.URL), newEDVWallet(t, phone, server.URL)
+		laptopToken, phoneToken := open(t, laptopWallet, samplePassphrase), open(t, phoneWallet, samplePassphrase)
+
+		require.NoError(t, laptopWallet.Add(laptopToken, Credential, json.RawMessage(sampleCredential)))
+
+		// the phone didn't read the credential before replacing it
+		err := phoneWallet.Add(phoneToken, Credential, json.RawMessage(sampleCredential), WithTags("phone"))
+		require.True(t, errors.Is(err, ErrContentConflict))
+
+		require.NoError(t, phoneWallet.Add(phoneToken, Credential, json.RawMessage(sampleCredential), WithTags("phone")))
+
+		// the laptop copy is stale
+		err = laptopWallet.Add(laptopToken, Credential, json.RawMessage(sampleCredential), WithTags("laptop"))
+		require.True(t, errors.Is(err, ErrContentConflict))
+
+		contents, err := laptopWallet.GetAll(laptopToken, Credential, FilterByTags("phone"))
+		require.NoError(t, err)
+		require.Len(t, contents, 1)
+	})
+
+	t.Run("locked wallet", func(t *testing.T) {
+		server := newEDVServer(t)
+		defer server.Close()
+
+		wallet := newEDVWallet(t, newProvider(), server.URL)
+		token := open(t, wallet, samplePassphrase)
+
+		require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleCredential)))
+		require.True(t, wallet.Close())
+
+		_, err := wallet.store.Get(wallet.contentKey(Credential, "http://example.edu/credentials/1872"))
+		require.NoError(t, err, "cached records are still readable, encrypted")
+
+		err = wallet.store.Put("key", []byte("value"))
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.True(t, errors.Is(wallet.store.Delete("key"), ErrWalletLocked))
+		require.True(t, errors.Is(wallet.store.Iterator("", "").Error(), ErrWalletLocked))
+	})
+
+	t.Run("invalid EDV", func(t *testing.T) {
+		err := CreateProfile(sampleUserID, samplePassphrase, newProvider(), WithEDVStorage("", sampleVaultID))
+		require.EqualError(t, err, "EDV server URL and vault ID are mandatory")
+
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		err = CreateProfile(sampleUserID, samplePassphrase, newProvider(), WithEDVStorage(server.URL, sampleVaultID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get vault profile")
+	})
+}
+
+func newEDVWallet(t *testing.T, ctx provider, serverURL string) *Wallet {
+	t.Helper()
+
+	require.NoError(t, CreateProfile(sampleUserID, samplePassphrase, ctx, WithEDVStorage(serverURL, sampleVaultID)))
+
+	wallet, err := New(sampleUserID, ctx)
+	require.NoError(t, err)
+
+	return wallet
+}
+
+type edvServer struct {
+	*httptest.Server
+	DB map[string][]byte
+}
+
+func newEDVServer(t *testing.T) *edvServer {
+	t.Helper()
+
+	operation := &mockedv.MockServerOperation{
+		T:                              t,
+		DB:                             make(map[string][]byte),
+		UseDB:                          true,
+		CreateDocumentReturnStatusCode: http.StatusCreated,
+		ReadDocumentReturnStatusCode:   http.StatusOK,
+		UpdateDocumentReturnStatusCode: http.StatusOK,
+		QueryVaultReturnStatusCode:     http.StatusOK,
+		DeleteDocumentReturnStatusCode: http.StatusOK,
+	}
+
+	return &edvServer{Server: operation.StartNewMockEDVServer(), DB: operation.DB}
+}
@@ -0,0 +1,369 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/subtle/random"
+	"github.com/google/tink/go/tink"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// ExportVersion is the version of the archive format written by Export.
+const ExportVersion = 1
+
+// exportAADPrefix prefixes the archive version in the additional authenticated data of the archive encryption, so
+// the version can't be altered.
+const exportAADPrefix = "aries-wallet-export-v"
+
+// contentTypes are the content types exported, in order: the collections are imported before their contents.
+// nolint:gochecknoglobals
+var contentTypes = []ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key}
+
+// exportArchive is the portable wallet archive: the contents are encrypted with a random key, itself encrypted with
+// the key derived from the export passphrase and salt, as the content key of the wallet profiles.
+type exportArchive struct {
+	Version    int            `json:"version"`
+	Salt       []byte         `json:"salt"`
+	KDF        *keyDerivation `json:"kdf"`
+	ArchiveKey string         `json:"archiveKey"`
+	Nonce      []byte         `json:"nonce"`
+	Ciphertext []byte         `json:"ciphertext"`
+}
+
+type exportContent struct {
+	Contents []typedContent `json:"contents"`
+	// Keysets are the keysets of the wallet KMS, encrypted with the archive key instead of the KMS master key
+	Keysets []exportKeyset `json:"keysets,omitempty"`
+}
+
+type exportKeyset struct {
+	ID     string          `json:"id"`
+	Keyset json.RawMessage `json:"keyset"`
+}
+
+type typedContent struct {
+	Type       ContentType     `json:"type"`
+	Content    json.RawMessage `json:"content"`
+	Collection string          `json:"collection,omitempty"`
+	Tags       []string        `json:"tags,omitempty"`
+}
+
+// Export writes the contents of the unlocked wallet, including its keys and the collections and tags of the contents,
+// into w as an archive protected with the given passphrase. The archive can be imported into another wallet, e.g. on
+// another device, with Import.
+func (c *Wallet) Export(authToken string, w io.Writer, passphrase string) error {
+	contentAEAD, kmsLock, err := c.sessionCiphers(authToken)
+	if err != nil {
+		return err
+	}
+
+	var content exportContent
+
+	for _, contentType := range contentTypes {
+		contents, err := c.GetAll(authToken, contentType)
+		if err != nil {
+			return err
+		}
+
+		for id, v := range contents {
+			meta, err := c.getContentMeta(contentAEAD, contentType, id)
+			if err != nil {
+				return err
+			}
+
+			content.Contents = append(content.Contents, typedContent{
+				Type: contentType, Content: v, Collection: meta.Collection, Tags: meta.Tags,
+			})
+		}
+	}
+
+	archiveKey := random.GetRandomBytes(contentKeySize)
+
+	archiveLock, err := keysetLock(archiveKey)
+	if err != nil {
+		return err
+	}
+
+	if content.Keysets, err = c.exportKeysets(kmsLock, archiveLock); err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallet archive content : %w", err)
+	}
+
+	salt := random.GetRandomBytes(saltSize)
+	kdf := defaultKeyDerivation
+
+	lock, err := passphraseLock(passphrase, salt, &kdf)
+	if err != nil {
+		return err
+	}
+
+	encryptedKey, err := lock.Encrypt("", &secretlock.EncryptRequest{Plaintext: string(archiveKey)})
+	if err != nil {
+		return fmt.Errorf("failed to encrypt wallet archive key : %w", err)
+	}
+
+	aead, err := newAEAD(archiveKey)
+	if err != nil {
+		return err
+	}
+
+	nonce := random.GetRandomBytes(uint32(aead.NonceSize()))
+
+	err = json.NewEncoder(w).Encode(&exportArchive{
+		Version:    ExportVersion,
+		Salt:       salt,
+		KDF:        &kdf,
+		ArchiveKey: encryptedKey.Ciphertext,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, exportAAD(ExportVersion)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write wallet archive : %w", err)
+	}
+
+	return nil
+}
+
+// Import reads the archive written by Export from r, decrypts it with the passphrase it was exported with, and adds
+// its contents and keys to the unlocked wallet, replacing the contents of the same type and ID.
+func (c *Wallet) Import(authToken string, r io.Reader, passphrase string) error {
+	_, kmsLock, err := c.sessionCiphers(authToken)
+	if err != nil {
+		return err
+	}
+
+	var archive exportArchive
+
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return fmt.Errorf("failed to read wallet archive : %w", err)
+	}
+
+	if archive.Version != ExportVersion {
+		return fmt.Errorf("unsupported wallet archive version %d", archive.Version)
+	}
+
+	lock, err := passphraseLock(passphrase, archive.Salt, archive.KDF)
+	if err != nil {
+		return err
+	}
+
+	archiveKey, err := lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: archive.ArchiveKey})
+	if err != nil {
+		return ErrInvalidPassphrase
+	}
+
+	aead, err := newAEAD([]byte(archiveKey.Plaintext))
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := aead.Open(nil, archive.Nonce, archive.Ciphertext, exportAAD(archive.Version))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt wallet archive : %w", err)
+	}
+
+	var content exportContent
+
+	if err := json.Unmarshal(plaintext, &content); err != nil {
+		return fmt.Errorf("failed to unmarshal wallet archive content : %w", err)
+	}
+
+	// check all the contents before adding any of them
+	for _, tc := range content.Contents {
+		if err := validateContentType(tc.Type); err != nil {
+			return fmt.Errorf("invalid wallet archive : %w", err)
+		}
+
+		if _, err := contentID(tc.Type, tc.Content); err != nil {
+			return fmt.Errorf("invalid wallet archive : %w", err)
+		}
+	}
+
+	archiveLock, err := keysetLock([]byte(archiveKey.Plaintext))
+	if err != nil {
+		return err
+	}
+
+	keysets := make(map[string][]byte, len(content.Keysets))
+
+	for _, k := range content.Keysets {
+		if keysets[k.ID], err = rewrapKeyset(k.Keyset, archiveLock, kmsLock); err != nil {
+			return fmt.Errorf("invalid wallet archive : %w", err)
+		}
+	}
+
+	if err := c.importKeysets(keysets); err != nil {
+		return err
+	}
+
+	for _, tc := range content.Contents {
+		if err := c.Add(authToken, tc.Type, tc.Content, AddToCollection(tc.Collection), WithTags(tc.Tags...)); err != nil {
+			return fmt.Errorf("failed to import wallet content : %w", err)
+		}
+	}
+
+	return nil
+}
+
+// sessionCiphers returns the content cipher and the secret lock of the KMS keys of the unlocked wallet.
+func (c *Wallet) sessionCiphers(authToken string) (cipher.AEAD, secretlock.Service, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.useSession(authToken); err != nil {
+		return nil, nil, err
+	}
+
+	return c.aead, c.kmsLock, nil
+}
+
+// exportKeysets returns the keysets of the wallet KMS, encrypted with the archive lock.
+func (c *Wallet) exportKeysets(kmsLock, archiveLock secretlock.Service) ([]exportKeyset, error) {
+	store, err := c.storage.OpenStore(localkms.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wallet KMS store : %w", err)
+	}
+
+	var keysets []exportKeyset
+
+	iter := store.Iterator("", storage.EndKeySuffix)
+	defer iter.Release()
+
+	for iter.Next() {
+		keyset, err := rewrapKeyset(iter.Value(), kmsLock, archiveLock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export wallet keyset : %w", err)
+		}
+
+		keysets = append(keysets, exportKeyset{ID: string(iter.Key()), Keyset: keyset})
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate wallet KMS store : %w", err)
+	}
+
+	return keysets, nil
+}
+
+// importKeysets adds the keysets, encrypted with the KMS master key, to the wallet KMS.
+func (c *Wallet) importKeysets(keysets map[string][]byte) error {
+	store, err := c.storage.OpenStore(localkms.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to open wallet KMS store : %w", err)
+	}
+
+	for id, keyset := range keysets {
+		if err := store.Put(id, keyset); err != nil {
+			return fmt.Errorf("failed to import wallet keyset : %w", err)
+		}
+	}
+
+	return nil
+}
+
+// keysetLock returns the secret lock encrypting the exported keysets with the archive key.
+func keysetLock(archiveKey []byte) (secretlock.Service, error) {
+	lock, err := local.NewService(strings.NewReader(base64.URLEncoding.EncodeToString(archiveKey)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet archive keyset lock : %w", err)
+	}
+
+	return lock, nil
+}
+
+// rewrapKeyset decrypts the keyset, as written by the KMS, with the from lock and encrypts it again with the to lock.
+func rewrapKeyset(encrypted []byte, from, to secretlock.Service) ([]byte, error) {
+	kh, err := keyset.Read(keyset.NewJSONReader(bytes.NewReader(encrypted)), keysetAEAD(from))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keyset : %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := kh.Write(keyset.NewJSONWriter(&buf), keysetAEAD(to)); err != nil {
+		return nil, fmt.Errorf("failed to encrypt keyset : %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// keysetAEAD returns the envelope encryption of the keysets with the secret lock, as done by the local KMS.
+func keysetAEAD(lock secretlock.Service) tink.AEAD {
+	return aead.NewKMSEnvelopeAEAD2(aead.AES256GCMKeyTemplate(), &lockAEAD{lock: lock})
+}
+
+// lockAEAD encrypts the keys of the keyset envelopes with a secret lock, the data being base64 encoded for the lock.
+type lockAEAD struct {
+	lock secretlock.Service
+}
+
+func (a *lockAEAD) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	resp, err := a.lock.Encrypt("", &secretlock.EncryptRequest{
+		Plaintext:                   base64.URLEncoding.EncodeToString(plaintext),
+		AdditionalAuthenticatedData: base64.URLEncoding.EncodeToString(additionalData),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.URLEncoding.DecodeString(resp.Ciphertext)
+}
+
+func (a *lockAEAD) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	resp, err := a.lock.Decrypt("", &secretlock.DecryptRequest{
+		Ciphertext:                  base64.URLEncoding.EncodeToString(ciphertext),
+		AdditionalAuthenticatedData: base64.URLEncoding.EncodeToString(additionalData),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.URLEncoding.DecodeString(resp.Plaintext)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != contentKeySize {
+		return nil, errors.New("invalid key size")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher : %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher : %w", err)
+	}
+
+	return aead, nil
+}
+
+func exportAAD(version int) []byte {
+	return []byte(exportAADPrefix + strconv.Itoa(version))
+}
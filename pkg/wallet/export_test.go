@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	exportPassphrase = "export-passphrase"
+	sampleKey        = `{"id": "key-1", "type": "Ed25519VerificationKey2018", "privateKeyBase58": "2MP5gWCn"}`
+)
+
+func TestWallet_ExportImport(t *testing.T) {
+	source := newWallet(t, newProvider())
+	sourceToken := open(t, source, samplePassphrase)
+
+	require.NoError(t, source.Add(sourceToken, Collection, json.RawMessage(sampleCollection)))
+	require.NoError(t, source.Add(sourceToken, Credential, json.RawMessage(sampleCredential),
+		AddToCollection("work"), WithTags("employment")))
+	require.NoError(t, source.Add(sourceToken, DIDResolutionResponse, json.RawMessage(sampleDIDResolution)))
+	require.NoError(t, source.Add(sourceToken, Key, json.RawMessage(sampleKey)))
+
+	sourceKMS, err := source.KMS(sourceToken)
+	require.NoError(t, err)
+
+	keyID, pubKey, err := sourceKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+
+	require.NoError(t, source.Export(sourceToken, &archive, exportPassphrase))
+	require.NotContains(t, archive.String(), "privateKeyBase58")
+
+	t.Run("import into a wallet on another device", func(t *testing.T) {
+		// the archive passphrase differs from that of the wallets
+		target := newWallet(t, newProvider())
+		targetToken := open(t, target, samplePassphrase)
+
+		require.NoError(t, target.Import(targetToken, bytes.NewReader(archive.Bytes()), exportPassphrase))
+
+		credential, err := target.Get(targetToken, Credential, "http://example.edu/credentials/1872")
+		require.NoError(t, err)
+		require.JSONEq(t, sampleCredential, string(credential))
+
+		key, err := target.Get(targetToken, Key, "key-1")
+		require.NoError(t, err)
+		require.JSONEq(t, sampleKey, string(key))
+
+		dids, err := target.GetAll(targetToken, DIDResolutionResponse)
+		require.NoError(t, err)
+		require.Len(t, dids, 1)
+
+		credentials, err := target.GetAll(targetToken, Credential, FilterByCollection("work"), FilterByTags("employment"))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+
+		// the keys of the source wallet sign in the target wallet
+		targetKMS, err := target.KMS(targetToken)
+		require.NoError(t, err)
+
+		kh, err := targetKMS.Get(keyID)
+		require.NoError(t, err)
+
+		c, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		msg := []byte("signed in the target wallet")
+
+		signature, err := c.Sign(msg, kh)
+		require.NoError(t, err)
+		require.True(t, ed25519.Verify(pubKey, msg, signature))
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		target := newWallet(t, newProvider())
+		targetToken := open(t, target, samplePassphrase)
+
+		err := target.Import(targetToken, bytes.NewReader(archive.Bytes()), "wrong-passphrase")
+		require.True(t, errors.Is(err, ErrInvalidPassphrase))
+	})
+
+	t.Run("locked wallets", func(t *testing.T) {
+		target := newWallet(t, newProvider())
+
+		require.True(t, errors.Is(target.Export("", &bytes.Buffer{}, exportPassphrase), ErrWalletLocked))
+		require.True(t, errors.Is(target.Import("", bytes.NewReader(archive.Bytes()), exportPassphrase), ErrWalletLocked))
+	})
+
+	t.Run("invalid archives", func(t *testing.T) {
+		target := newWallet(t, newProvider())
+		targetToken := open(t, target, samplePassphrase)
+
+		exported := &exportArchive{}
+		require.NoError(t, json.Unmarshal(archive.Bytes(), exported))
+		require.Equal(t, &defaultKeyDerivation, exported.KDF)
+
+		tests := []struct {
+			name   string
+			modify func(a *exportArchive)
+			err    string
+		}{
+			{
+				name:   "unsupported key derivation",
+				modify: func(a *exportArchive) { a.KDF = nil },
+				err:    "unsupported passphrase key derivation",
+			},
+			{
+				name:   "unsupported version",
+				modify: func(a *exportArchive) { a.Version = 2 },
+				err:    "unsupported wallet archive version 2",
+			},
+			{name: "altered ciphertext", modify: func(a *exportArchive) {
+				a.Ciphertext = append([]byte{a.Ciphertext[0] ^ 1}, a.Ciphertext[1:]...)
+			}, err: "failed to decrypt wallet archive"},
+		}
+
+		for _, tc := range tests {
+			a := *exported
+			tc.modify(&a)
+
+			archiveBytes, err := json.Marshal(&a)
+			require.NoError(t, err)
+
+			err = target.Import(targetToken, bytes.NewReader(archiveBytes), exportPassphrase)
+			require.Error(t, err, tc.name)
+			require.Contains(t, err.Error(), tc.err, tc.name)
+		}
+
+		err := target.Import(targetToken, bytes.NewBufferString("{"), exportPassphrase)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read wallet archive")
+
+		err = target.Export(targetToken, &bytes.Buffer{}, "")
+		require.EqualError(t, err, "passphrase is mandatory")
+	})
+}
@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// sessionKMS is the KMS of a wallet session: the auth token is checked by each operation, so the keys can't be used
+// once the session has expired or is closed.
+type sessionKMS struct {
+	wallet    *Wallet
+	authToken string
+}
+
+// keyManager returns the KMS of the wallet if the session is still open.
+func (k *sessionKMS) keyManager() (kms.KeyManager, error) {
+	k.wallet.lock.Lock()
+	defer k.wallet.lock.Unlock()
+
+	if err := k.wallet.useSession(k.authToken); err != nil {
+		return nil, err
+	}
+
+	return k.wallet.keyManager, nil
+}
+
+func (k *sessionKMS) Create(kt kms.KeyType) (string, interface{}, error) {
+	keyManager, err := k.keyManager()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyManager.Create(kt)
+}
+
+func (k *sessionKMS) Get(keyID string) (interface{}, error) {
+	keyManager, err := k.keyManager()
+	if err != nil {
+		return nil, err
+	}
+
+	return keyManager.Get(keyID)
+}
+
+func (k *sessionKMS) Rotate(kt kms.KeyType, keyID string) (string, interface{}, error) {
+	keyManager, err := k.keyManager()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyManager.Rotate(kt, keyID)
+}
+
+func (k *sessionKMS) ExportPubKeyBytes(keyID string) ([]byte, error) {
+	keyManager, err := k.keyManager()
+	if err != nil {
+		return nil, err
+	}
+
+	return keyManager.ExportPubKeyBytes(keyID)
+}
+
+func (k *sessionKMS) CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, error) {
+	keyManager, err := k.keyManager()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyManager.CreateAndExportPubKeyBytes(kt)
+}
+
+func (k *sessionKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType) (interface{}, error) {
+	keyManager, err := k.keyManager()
+	if err != nil {
+		return nil, err
+	}
+
+	return keyManager.PubKeyBytesToHandle(pubKey, kt)
+}
+
+func (k *sessionKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	keyManager, err := k.keyManager()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyManager.ImportPrivateKey(privKey, kt, opts...)
+}
@@ -0,0 +1,432 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// defaultScanInterval is how often the monitor scans the credentials by default.
+	defaultScanInterval = time.Hour
+	// defaultExpiryWarning is how long before their expiration the credentials are refreshed by default.
+	defaultExpiryWarning = 7 * 24 * time.Hour
+)
+
+var logger = log.New("aries-framework/wallet")
+
+// ErrMonitorRunning is returned when starting the credential monitor of a wallet while it's already running.
+var ErrMonitorRunning = errors.New("credential monitor is already running")
+
+// CredentialEventType is the type of the events of the credential monitor.
+type CredentialEventType string
+
+// credential event types.
+const (
+	// CredentialExpiring is sent for a credential expiring soon, which can't be refreshed.
+	CredentialExpiring CredentialEventType = "expiring"
+	// CredentialExpired is sent for an expired credential, which can't be refreshed.
+	CredentialExpired CredentialEventType = "expired"
+	// CredentialRefreshed is sent for a credential replaced by its refreshed credential.
+	CredentialRefreshed CredentialEventType = "refreshed"
+	// CredentialRefreshFailed is sent when the refresh of a credential fails.
+	CredentialRefreshFailed CredentialEventType = "refreshFailed"
+	// CredentialRevoked is sent for a credential revoked by its issuer.
+	CredentialRevoked CredentialEventType = "revoked"
+	// CredentialSuspended is sent for a credential suspended by its issuer.
+	CredentialSuspended CredentialEventType = "suspended"
+	// CredentialReinstated is sent for a suspended credential which isn't suspended anymore.
+	CredentialReinstated CredentialEventType = "reinstated"
+	// CredentialStatusCheckFailed is sent when the status of a credential can't be checked.
+	CredentialStatusCheckFailed CredentialEventType = "statusCheckFailed"
+)
+
+// CredentialEvent notifies the user of the state of a wallet credential, see RegisterCredentialEvent.
+type CredentialEvent struct {
+	Type CredentialEventType
+	// CredentialID is the ID of the credential, whose refreshed credential may have another ID.
+	CredentialID string
+	// Expires is the expiration date of the credential.
+	Expires time.Time
+	// Refreshed is the refreshed credential of a CredentialRefreshed event.
+	Refreshed *verifiable.Credential
+	// Err is the reason of a CredentialRefreshFailed or CredentialStatusCheckFailed event.
+	Err error
+}
+
+// CredentialRefresher refreshes credentials with their refresh service, see
+// https://www.w3.org/TR/vc-data-model/#refreshing.
+type CredentialRefresher interface {
+	// Refresh returns the refreshed credential, issued again by the refresh service.
+	Refresh(credential *verifiable.Credential, refreshService *verifiable.TypedID) (json.RawMessage, error)
+}
+
+// MonitorOpt configures the credential monitor.
+type MonitorOpt func(opts *monitorOpts)
+
+type monitorOpts struct {
+	scanInterval  time.Duration
+	expiryWarning time.Duration
+	refreshers    map[string]CredentialRefresher
+	httpClient    *http.Client
+}
+
+// WithScanInterval sets how often the credentials are scanned, every hour by default.
+func WithScanInterval(interval time.Duration) MonitorOpt {
+	return func(opts *monitorOpts) {
+		opts.scanInterval = interval
+	}
+}
+
+// WithExpiryWarning sets how long before their expiration the credentials are refreshed, or the user notified that
+// they expire, 7 days by default.
+func WithExpiryWarning(warning time.Duration) MonitorOpt {
+	return func(opts *monitorOpts) {
+		opts.expiryWarning = warning
+	}
+}
+
+// WithCredentialRefresher refreshes the credentials having a refresh service of the given type with the refresher.
+func WithCredentialRefresher(refreshServiceType string, refresher CredentialRefresher) MonitorOpt {
+	return func(opts *monitorOpts) {
+		opts.refreshers[refreshServiceType] = refresher
+	}
+}
+
+// WithStatusHTTPClient sets the HTTP client the status list credentials are fetched with, http.DefaultClient by
+// default.
+func WithStatusHTTPClient(client *http.Client) MonitorOpt {
+	return func(opts *monitorOpts) {
+		opts.httpClient = client
+	}
+}
+
+// credentialMonitor is a running credential monitor, with the last event sent for each credential so that the user is
+// notified once of each change.
+type credentialMonitor struct {
+	opts     *monitorOpts
+	stop     chan struct{}
+	notified map[string]CredentialEventType
+	// rescan triggers a scan before the scan interval, on revocation notifications
+	rescan       chan struct{}
+	statusFailed map[string]bool
+}
+
+// RegisterCredentialEvent registers the channel the events of the credential monitor are sent to. Only one channel can
+// be registered for the credential events.
+func (c *Wallet) RegisterCredentialEvent(ch chan<- CredentialEvent) error {
+	if ch == nil {
+		return service.ErrNilChannel
+	}
+
+	c.credentialEventLock.Lock()
+	defer c.credentialEventLock.Unlock()
+
+	if c.credentialEvent != nil {
+		return service.ErrChannelRegistered
+	}
+
+	c.credentialEvent = ch
+
+	return nil
+}
+
+// UnregisterCredentialEvent unregisters the channel of the credential events, see RegisterCredentialEvent.
+func (c *Wallet) UnregisterCredentialEvent(ch chan<- CredentialEvent) error {
+	if ch == nil {
+		return service.ErrNilChannel
+	}
+
+	c.credentialEventLock.Lock()
+	defer c.credentialEventLock.Unlock()
+
+	if c.credentialEvent != ch {
+		return service.ErrInvalidChannel
+	}
+
+	c.credentialEvent = nil
+
+	return nil
+}
+
+// StartMonitor monitors the credentials of the unlocked wallet in the background, scanning them right away and then
+// at the scan interval. The credentials expiring within the expiry warning are refreshed with the refresher of their
+// refresh service, if any, and replaced by their refreshed credential along with its collection and tags. The user is
+// notified of the refreshed credentials, of the failed refreshes and of the credentials expiring or expired which
+// can't be refreshed through the CredentialEvent channel. The credentials with a StatusList2021 status are checked
+// against their status list, at each scan and when the revocation notification service of the agent receives a revoke
+// message: the user is notified when they're revoked, suspended or reinstated, and they're flagged, see
+// CredentialFlags. The revoked credentials aren't refreshed. The monitor doesn't keep the wallet unlocked: it stops
+// when the wallet locks, or when StopMonitor is called.
+func (c *Wallet) StartMonitor(authToken string, options ...MonitorOpt) error {
+	opts := &monitorOpts{
+		scanInterval:  defaultScanInterval,
+		expiryWarning: defaultExpiryWarning,
+		refreshers:    make(map[string]CredentialRefresher),
+		httpClient:    http.DefaultClient,
+	}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	if opts.scanInterval <= 0 {
+		return fmt.Errorf("invalid scan interval %s", opts.scanInterval)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.useSession(authToken); err != nil {
+		return err
+	}
+
+	if c.monitor != nil {
+		return ErrMonitorRunning
+	}
+
+	c.monitor = &credentialMonitor{
+		opts:         opts,
+		stop:         make(chan struct{}),
+		notified:     make(map[string]CredentialEventType),
+		rescan:       make(chan struct{}, 1),
+		statusFailed: make(map[string]bool),
+	}
+
+	c.watchRevocationNotifications(c.monitor)
+
+	go c.runMonitor(c.monitor)
+
+	return nil
+}
+
+// StopMonitor stops the credential monitor, returning false if it wasn't running.
+func (c *Wallet) StopMonitor() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	running := c.monitor != nil
+	c.stopMonitor()
+
+	return running
+}
+
+// stopMonitor stops the credential monitor, if running. The wallet lock must be held.
+func (c *Wallet) stopMonitor() {
+	if c.monitor != nil {
+		close(c.monitor.stop)
+		c.monitor = nil
+	}
+}
+
+func (c *Wallet) runMonitor(m *credentialMonitor) {
+	ticker := time.NewTicker(m.opts.scanInterval)
+	defer ticker.Stop()
+
+	for {
+		aead := c.monitorCipher(m)
+		if aead == nil {
+			return
+		}
+
+		if err := c.scanCredentials(aead, m); err != nil {
+			logger.Warnf("failed to scan wallet credentials : %s", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-m.rescan:
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// monitorCipher returns the content cipher while the monitor runs, nil once it's stopped. The session isn't marked
+// as used, so that the monitor doesn't keep the wallet unlocked.
+func (c *Wallet) monitorCipher(m *credentialMonitor) cipher.AEAD {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.monitor != m {
+		return nil
+	}
+
+	if !c.unlocked() {
+		c.clearSession()
+
+		return nil
+	}
+
+	return c.aead
+}
+
+// scanCredentials checks the statuses of the credentials and refreshes those expiring within the expiry warning,
+// notifying the user of their state.
+func (c *Wallet) scanCredentials(aead cipher.AEAD, m *credentialMonitor) error {
+	contents, err := c.getAll(aead, Credential, &contentMeta{})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(contents))
+
+	for id := range contents {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	// the status lists are fetched once per scan
+	verifier := c.statusVerifier(m)
+
+	for _, id := range ids {
+		vc, err := verifiable.ParseUnverifiedCredential(contents[id], verifiable.WithCache(c.credentialCache))
+		if err != nil {
+			logger.Warnf("failed to parse wallet credential %s : %s", id, err)
+
+			continue
+		}
+
+		if vc.Status != nil && vc.Status.Type == verifiable.StatusList2021EntryType &&
+			c.checkStatus(aead, m, verifier, id, vc) == FlagRevoked {
+			continue
+		}
+
+		if vc.Expired == nil || vc.Expired.Time.Sub(c.clock.Now()) > m.opts.expiryWarning {
+			delete(m.notified, id)
+
+			continue
+		}
+
+		event := c.refreshCredential(aead, m, id, vc)
+
+		if m.notified[id] == event.Type {
+			continue
+		}
+
+		m.notified[id] = event.Type
+
+		if event.Type == CredentialRefreshed {
+			delete(m.notified, id)
+		}
+
+		c.sendCredentialEvent(m, event)
+	}
+
+	return nil
+}
+
+// refreshCredential refreshes the credential with the refresher of its refresh service, returning the event to
+// notify the user of.
+func (c *Wallet) refreshCredential(aead cipher.AEAD, m *credentialMonitor, id string,
+	vc *verifiable.Credential) *CredentialEvent {
+	event := &CredentialEvent{CredentialID: id, Expires: vc.Expired.Time}
+
+	for i := range vc.RefreshService {
+		refresher, ok := m.opts.refreshers[vc.RefreshService[i].Type]
+		if !ok {
+			continue
+		}
+
+		raw, err := refresher.Refresh(vc, &vc.RefreshService[i])
+		if err == nil {
+			event.Refreshed, err = c.replaceCredential(aead, id, vc, raw)
+		}
+
+		if err != nil {
+			event.Type = CredentialRefreshFailed
+			event.Err = err
+
+			return event
+		}
+
+		event.Type = CredentialRefreshed
+
+		return event
+	}
+
+	event.Type = CredentialExpiring
+
+	if !event.Expires.After(c.clock.Now()) {
+		event.Type = CredentialExpired
+	}
+
+	return event
+}
+
+// replaceCredential replaces the credential with its refreshed credential, which keeps its collection and tags.
+func (c *Wallet) replaceCredential(aead cipher.AEAD, id string, vc *verifiable.Credential,
+	raw json.RawMessage) (*verifiable.Credential, error) {
+	refreshed, err := verifiable.ParseUnverifiedCredential(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid refreshed credential : %w", err)
+	}
+
+	if refreshed.ID == "" {
+		return nil, errors.New("invalid refreshed credential : missing id")
+	}
+
+	if refreshed.Expired != nil && !refreshed.Expired.After(vc.Expired.Time) {
+		return nil, errors.New("the refreshed credential doesn't expire later")
+	}
+
+	meta, err := c.getContentMeta(aead, Credential, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the status of the refreshed credential is checked at the next scan
+	meta.Flag = ""
+
+	if err := c.putContent(aead, Credential, refreshed.ID, raw); err != nil {
+		return nil, err
+	}
+
+	if err := c.putContentMeta(aead, c.contentMetaKey(Credential, refreshed.ID), meta); err != nil {
+		return nil, err
+	}
+
+	if refreshed.ID == id {
+		return refreshed, nil
+	}
+
+	if err := c.store.Delete(c.contentKey(Credential, id)); err != nil {
+		return nil, fmt.Errorf("failed to remove wallet content : %w", err)
+	}
+
+	if err := c.store.Delete(c.contentMetaKey(Credential, id)); err != nil {
+		return nil, fmt.Errorf("failed to remove wallet content metadata : %w", err)
+	}
+
+	return refreshed, nil
+}
+
+func (c *Wallet) sendCredentialEvent(m *credentialMonitor, event *CredentialEvent) {
+	c.credentialEventLock.RLock()
+	ch := c.credentialEvent
+	c.credentialEventLock.RUnlock()
+
+	if ch == nil {
+		return
+	}
+
+	select {
+	case ch <- *event:
+	case <-m.stop:
+	}
+}
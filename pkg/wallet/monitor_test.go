@@ -0,0 +1,247 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const sampleRefreshServiceType = "SampleRefreshService"
+
+func TestWallet_Monitor(t *testing.T) {
+	t.Run("notify the expiring credentials", func(t *testing.T) {
+		wallet := newWallet(t, newProvider())
+		token := open(t, wallet, samplePassphrase)
+
+		require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleCredential)))
+		require.NoError(t, wallet.Add(token, Credential, expiringCredential("urn:vc:1", time.Hour, "")))
+		require.NoError(t, wallet.Add(token, Credential, expiringCredential("urn:vc:2", -time.Hour, "")))
+		require.NoError(t, wallet.Add(token, Credential, expiringCredential("urn:vc:3", 365*24*time.Hour, "")))
+		// no refresher is registered for the refresh service
+		require.NoError(t, wallet.Add(token, Credential,
+			expiringCredential("urn:vc:4", time.Hour, sampleRefreshServiceType)))
+
+		events := make(chan CredentialEvent)
+		require.NoError(t, wallet.RegisterCredentialEvent(events))
+		require.NoError(t, wallet.StartMonitor(token, WithScanInterval(10*time.Millisecond)))
+
+		event := nextEvent(t, events)
+		require.Equal(t, CredentialExpiring, event.Type)
+		require.Equal(t, "urn:vc:1", event.CredentialID)
+		require.WithinDuration(t, time.Now().Add(time.Hour), event.Expires, time.Minute)
+
+		event = nextEvent(t, events)
+		require.Equal(t, CredentialExpired, event.Type)
+		require.Equal(t, "urn:vc:2", event.CredentialID)
+
+		require.Equal(t, CredentialExpiring, nextEvent(t, events).Type)
+
+		// the user is notified once
+		select {
+		case event = <-events:
+			require.Fail(t, "unexpected credential event", event)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		require.True(t, errors.Is(wallet.StartMonitor(token), ErrMonitorRunning))
+		require.True(t, wallet.StopMonitor())
+		require.False(t, wallet.StopMonitor())
+	})
+
+	t.Run("expiry told by the clock", func(t *testing.T) {
+		clock := mockclock.New(time.Now())
+		wallet := newWallet(t, &mockprovider.Provider{StorageProviderValue: mem.NewProvider(), ClockValue: clock})
+
+		token, err := wallet.Open(samplePassphrase, WithIdleTimeout(24*time.Hour))
+		require.NoError(t, err)
+
+		require.NoError(t, wallet.Add(token, Credential, expiringCredential("urn:vc:1", 3*time.Hour, "")))
+
+		events := make(chan CredentialEvent)
+		require.NoError(t, wallet.RegisterCredentialEvent(events))
+		require.NoError(t, wallet.StartMonitor(token, WithScanInterval(10*time.Millisecond),
+			WithExpiryWarning(time.Hour)))
+
+		defer wallet.StopMonitor()
+
+		select {
+		case event := <-events:
+			require.Fail(t, "unexpected credential event", event)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		clock.Add(150 * time.Minute)
+		require.Equal(t, CredentialExpiring, nextEvent(t, events).Type)
+
+		clock.Add(time.Hour)
+		require.Equal(t, CredentialExpired, nextEvent(t, events).Type)
+	})
+
+	t.Run("refresh the expiring credentials", func(t *testing.T) {
+		wallet := newWallet(t, newProvider())
+		token := open(t, wallet, samplePassphrase)
+
+		require.NoError(t, wallet.Add(token, Collection, json.RawMessage(sampleCollection)))
+		require.NoError(t, wallet.Add(token, Credential,
+			expiringCredential("urn:vc:1", time.Hour, sampleRefreshServiceType),
+			AddToCollection("work"), WithTags("license")))
+		require.NoError(t, wallet.Add(token, Credential,
+			expiringCredential("urn:vc:2", 2*time.Hour, sampleRefreshServiceType)))
+		require.NoError(t, wallet.Add(token, Credential,
+			expiringCredential("urn:vc:3", 3*time.Hour, sampleRefreshServiceType)))
+
+		refresher := &mockRefresher{credentials: map[string]json.RawMessage{
+			"urn:vc:1": expiringCredential("urn:vc:1", 365*24*time.Hour, sampleRefreshServiceType),
+			"urn:vc:2": expiringCredential("urn:vc:2-renewed", 365*24*time.Hour, sampleRefreshServiceType),
+			"urn:vc:3": expiringCredential("urn:vc:3", time.Hour, sampleRefreshServiceType),
+		}}
+
+		events := make(chan CredentialEvent)
+		require.NoError(t, wallet.RegisterCredentialEvent(events))
+		require.NoError(t, wallet.StartMonitor(token, WithExpiryWarning(24*time.Hour),
+			WithCredentialRefresher(sampleRefreshServiceType, refresher)))
+
+		defer wallet.StopMonitor()
+
+		event := nextEvent(t, events)
+		require.Equal(t, CredentialRefreshed, event.Type)
+		require.Equal(t, "urn:vc:1", event.Refreshed.ID)
+
+		event = nextEvent(t, events)
+		require.Equal(t, CredentialRefreshed, event.Type)
+		require.Equal(t, "urn:vc:2", event.CredentialID)
+		require.Equal(t, "urn:vc:2-renewed", event.Refreshed.ID)
+
+		event = nextEvent(t, events)
+		require.Equal(t, CredentialRefreshFailed, event.Type)
+		require.EqualError(t, event.Err, "the refreshed credential doesn't expire later")
+
+		// the refreshed credentials keep their collection and tags
+		contents, err := wallet.GetAll(token, Credential, FilterByCollection("work"), FilterByTags("license"))
+		require.NoError(t, err)
+		require.Len(t, contents, 1)
+		require.JSONEq(t, string(refresher.credentials["urn:vc:1"]), string(contents["urn:vc:1"]))
+
+		_, err = wallet.Get(token, Credential, "urn:vc:2")
+		require.True(t, errors.Is(err, ErrContentNotFound))
+
+		_, err = wallet.Get(token, Credential, "urn:vc:2-renewed")
+		require.NoError(t, err)
+	})
+
+	t.Run("refresh errors", func(t *testing.T) {
+		wallet := newWallet(t, newProvider())
+		token := open(t, wallet, samplePassphrase)
+
+		require.NoError(t, wallet.Add(token, Credential,
+			expiringCredential("urn:vc:1", time.Hour, sampleRefreshServiceType)))
+		require.NoError(t, wallet.Add(token, Credential,
+			expiringCredential("urn:vc:2", time.Hour, sampleRefreshServiceType)))
+
+		refresher := &mockRefresher{
+			credentials: map[string]json.RawMessage{"urn:vc:2": json.RawMessage(`{}`)},
+			err:         map[string]error{"urn:vc:1": errors.New("refresh error")},
+		}
+
+		events := make(chan CredentialEvent)
+		require.NoError(t, wallet.RegisterCredentialEvent(events))
+		require.NoError(t, wallet.StartMonitor(token, WithCredentialRefresher(sampleRefreshServiceType, refresher)))
+
+		defer wallet.StopMonitor()
+
+		event := nextEvent(t, events)
+		require.Equal(t, CredentialRefreshFailed, event.Type)
+		require.EqualError(t, event.Err, "refresh error")
+
+		event = nextEvent(t, events)
+		require.Equal(t, CredentialRefreshFailed, event.Type)
+		require.Contains(t, event.Err.Error(), "invalid refreshed credential")
+	})
+
+	t.Run("stop when the wallet locks", func(t *testing.T) {
+		wallet := newWallet(t, newProvider())
+
+		require.True(t, errors.Is(wallet.StartMonitor(""), ErrWalletLocked))
+
+		token := open(t, wallet, samplePassphrase)
+
+		require.True(t, errors.Is(wallet.StartMonitor("unknown"), ErrInvalidAuthToken))
+		require.EqualError(t, wallet.StartMonitor(token, WithScanInterval(0)), "invalid scan interval 0s")
+
+		require.NoError(t, wallet.StartMonitor(token))
+		require.True(t, wallet.Close())
+		require.False(t, wallet.StopMonitor())
+	})
+
+	t.Run("credential event registration", func(t *testing.T) {
+		wallet := newWallet(t, newProvider())
+		events := make(chan CredentialEvent)
+
+		require.True(t, errors.Is(wallet.RegisterCredentialEvent(nil), service.ErrNilChannel))
+		require.True(t, errors.Is(wallet.UnregisterCredentialEvent(nil), service.ErrNilChannel))
+		require.True(t, errors.Is(wallet.UnregisterCredentialEvent(events), service.ErrInvalidChannel))
+
+		require.NoError(t, wallet.RegisterCredentialEvent(events))
+		require.True(t, errors.Is(wallet.RegisterCredentialEvent(make(chan CredentialEvent)), service.ErrChannelRegistered))
+		require.NoError(t, wallet.UnregisterCredentialEvent(events))
+	})
+}
+
+type mockRefresher struct {
+	credentials map[string]json.RawMessage
+	err         map[string]error
+}
+
+func (r *mockRefresher) Refresh(credential *verifiable.Credential, _ *verifiable.TypedID) (json.RawMessage, error) {
+	return r.credentials[credential.ID], r.err[credential.ID]
+}
+
+func nextEvent(t *testing.T, events chan CredentialEvent) CredentialEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		require.Fail(t, "no credential event")
+	}
+
+	return CredentialEvent{}
+}
+
+// expiringCredential returns a credential expiring after the given duration, with a refresh service of the given type
+// if any.
+func expiringCredential(id string, expiresIn time.Duration, refreshServiceType string) json.RawMessage {
+	var refreshService string
+
+	if refreshServiceType != "" {
+		refreshService = fmt.Sprintf(`, "refreshService": {"id": "https://example.edu/refresh", "type": %q}`,
+			refreshServiceType)
+	}
+
+	return json.RawMessage(fmt.Sprintf(`{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"id": %q,
+		"type": ["VerifiableCredential"],
+		"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"expirationDate": %q,
+		"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}%s
+	}`, id, time.Now().Add(expiresIn).UTC().Format(time.RFC3339), refreshService))
+}
@@ -0,0 +1,704 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	// CredentialOfferScheme is the scheme of the OpenID4VCI credential offer URLs.
+	CredentialOfferScheme = "openid-credential-offer"
+	// PreAuthorizedCodeGrantType is the grant type of the OpenID4VCI pre-authorized code flow.
+	PreAuthorizedCodeGrantType = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+	// LDPVCFormat is the format of the JSON-LD credentials with linked data proofs, the format the wallet requests.
+	LDPVCFormat = "ldp_vc"
+
+	credentialIssuerMetadataPath    = "/.well-known/openid-credential-issuer"
+	authorizationServerMetadataPath = "/.well-known/oauth-authorization-server"
+	proofJWTType                    = "openid4vci-proof+jwt"
+	invalidProofError               = "invalid_or_missing_proof"
+	codeVerifierSize                = 32
+	stateSize                       = 16
+)
+
+// CredentialOffer is the offer of an OpenID4VCI credential issuer, see
+// https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-credential-offer.
+type CredentialOffer struct {
+	CredentialIssuer string              `json:"credential_issuer"`
+	Credentials      []OfferedCredential `json:"credentials"`
+	Grants           CredentialGrants    `json:"grants"`
+}
+
+// OfferedCredential is a credential of the offer, given by the ID of the credential in the metadata of the issuer or
+// by its format and types.
+type OfferedCredential struct {
+	ID     string   `json:"-"`
+	Format string   `json:"format,omitempty"`
+	Types  []string `json:"types,omitempty"`
+}
+
+// UnmarshalJSON unmarshals the offered credential, given by ID or by format and types.
+func (c *OfferedCredential) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &c.ID); err == nil {
+		return nil
+	}
+
+	type offeredCredential OfferedCredential
+
+	return json.Unmarshal(data, (*offeredCredential)(c))
+}
+
+// CredentialGrants are the grants of the credential offer, the flows the credentials can be issued with.
+type CredentialGrants struct {
+	AuthorizationCode *AuthorizationCodeGrant `json:"authorization_code,omitempty"`
+	PreAuthorizedCode *PreAuthorizedCodeGrant `json:"urn:ietf:params:oauth:grant-type:pre-authorized_code,omitempty"`
+}
+
+// AuthorizationCodeGrant is the grant of the authorization code flow, the user authenticating with the authorization
+// server of the issuer.
+type AuthorizationCodeGrant struct {
+	IssuerState string `json:"issuer_state,omitempty"`
+}
+
+// PreAuthorizedCodeGrant is the grant of the pre-authorized code flow, the user being already authenticated by the
+// issuer, which may require a PIN sent to the user through another channel.
+type PreAuthorizedCodeGrant struct {
+	PreAuthorizedCode string `json:"pre-authorized_code"`
+	UserPINRequired   bool   `json:"user_pin_required,omitempty"`
+}
+
+// AuthorizationRequest is the request of the authorization code flow: the user is redirected to URL, and the
+// authorization server of the issuer redirects the user back to the redirect URI with the authorization code and the
+// state, which has to be checked. The request is given with the code to AcceptCredentialOffer.
+type AuthorizationRequest struct {
+	URL          string
+	State        string
+	CodeVerifier string
+	ClientID     string
+	RedirectURI  string
+}
+
+// OIDC4VCIOpt configures the OpenID4VCI exchanges with the credential issuers.
+type OIDC4VCIOpt func(opts *oidc4vciOpts)
+
+type oidc4vciOpts struct {
+	httpClient *http.Client
+	clientID   string
+	userPIN    string
+	authCode   string
+	authReq    *AuthorizationRequest
+	keyID      string
+	keyType    kms.KeyType
+	kid        string
+}
+
+// WithHTTPClient sets the HTTP client of the exchanges with the issuers, http.DefaultClient by default.
+func WithHTTPClient(client *http.Client) OIDC4VCIOpt {
+	return func(opts *oidc4vciOpts) {
+		opts.httpClient = client
+	}
+}
+
+// WithClientID sets the OAuth client ID of the wallet, required by the authorization code flow.
+func WithClientID(clientID string) OIDC4VCIOpt {
+	return func(opts *oidc4vciOpts) {
+		opts.clientID = clientID
+	}
+}
+
+// WithUserPIN sets the PIN the issuer sent to the user, when the pre-authorized code grant requires one.
+func WithUserPIN(pin string) OIDC4VCIOpt {
+	return func(opts *oidc4vciOpts) {
+		opts.userPIN = pin
+	}
+}
+
+// WithAuthorizationCode sets the authorization code the user was redirected with after the authorization request,
+// using the authorization code flow rather than the pre-authorized code flow.
+func WithAuthorizationCode(request *AuthorizationRequest, code string) OIDC4VCIOpt {
+	return func(opts *oidc4vciOpts) {
+		opts.authReq = request
+		opts.authCode = code
+	}
+}
+
+// WithProofKey sets the wallet key the credentials are bound to, which signs the proofs of possession: its KMS key ID,
+// its type, ED25519 or ECDSA in IEEE P1363 format, and its key ID for the issuer, typically a DID URL.
+func WithProofKey(keyID string, keyType kms.KeyType, kid string) OIDC4VCIOpt {
+	return func(opts *oidc4vciOpts) {
+		opts.keyID = keyID
+		opts.keyType = keyType
+		opts.kid = kid
+	}
+}
+
+func newOIDC4VCIOpts(options []OIDC4VCIOpt) *oidc4vciOpts {
+	opts := &oidc4vciOpts{httpClient: http.DefaultClient}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	return opts
+}
+
+// credentialIssuerMetadata is the metadata of an OpenID4VCI credential issuer.
+type credentialIssuerMetadata struct {
+	CredentialIssuer     string                `json:"credential_issuer"`
+	AuthorizationServer  string                `json:"authorization_server,omitempty"`
+	CredentialEndpoint   string                `json:"credential_endpoint"`
+	TokenEndpoint        string                `json:"token_endpoint,omitempty"`
+	CredentialsSupported []supportedCredential `json:"credentials_supported"`
+}
+
+type supportedCredential struct {
+	ID     string   `json:"id"`
+	Format string   `json:"format"`
+	Types  []string `json:"types"`
+}
+
+type authorizationServerMetadata struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	CNonce      string `json:"c_nonce,omitempty"`
+}
+
+type credentialRequest struct {
+	Format string           `json:"format"`
+	Types  []string         `json:"types"`
+	Proof  *credentialProof `json:"proof,omitempty"`
+}
+
+type credentialProof struct {
+	ProofType string `json:"proof_type"`
+	JWT       string `json:"jwt"`
+}
+
+type credentialResponse struct {
+	Format     string          `json:"format"`
+	Credential json.RawMessage `json:"credential"`
+	CNonce     string          `json:"c_nonce,omitempty"`
+}
+
+// oauthError is the error response of the token and credential endpoints.
+type oauthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+	CNonce           string `json:"c_nonce,omitempty"`
+}
+
+// ParseCredentialOffer parses the credential offer of an OpenID4VCI issuer, given as an openid-credential-offer URL
+// with the offer by value or by reference, or as JSON.
+func ParseCredentialOffer(offer string, options ...OIDC4VCIOpt) (*CredentialOffer, error) {
+	opts := newOIDC4VCIOpts(options)
+
+	raw := []byte(offer)
+
+	if !strings.HasPrefix(strings.TrimSpace(offer), "{") {
+		u, err := url.Parse(offer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential offer URL : %w", err)
+		}
+
+		switch {
+		case u.Query().Get("credential_offer") != "":
+			raw = []byte(u.Query().Get("credential_offer"))
+		case u.Query().Get("credential_offer_uri") != "":
+			if raw, err = getJSON(opts.httpClient, u.Query().Get("credential_offer_uri")); err != nil {
+				return nil, fmt.Errorf("failed to get credential offer : %w", err)
+			}
+		default:
+			return nil, errors.New("invalid credential offer URL : missing credential offer")
+		}
+	}
+
+	credentialOffer := &CredentialOffer{}
+
+	if err := json.Unmarshal(raw, credentialOffer); err != nil {
+		return nil, fmt.Errorf("invalid credential offer : %w", err)
+	}
+
+	if credentialOffer.CredentialIssuer == "" || len(credentialOffer.Credentials) == 0 {
+		return nil, errors.New("invalid credential offer : missing credential issuer or credentials")
+	}
+
+	return credentialOffer, nil
+}
+
+// NewAuthorizationRequest returns the authorization request of the authorization code flow of the credential offer,
+// to redirect the user to. The wallet authenticates with PKCE, its client ID being set with WithClientID.
+func NewAuthorizationRequest(offer *CredentialOffer, redirectURI string,
+	options ...OIDC4VCIOpt) (*AuthorizationRequest, error) {
+	opts := newOIDC4VCIOpts(options)
+
+	if opts.clientID == "" {
+		return nil, errors.New("client ID is mandatory")
+	}
+
+	metadata, err := getIssuerMetadata(opts.httpClient, offer.CredentialIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	asMetadata, err := getAuthorizationServerMetadata(opts.httpClient, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if asMetadata.AuthorizationEndpoint == "" {
+		return nil, errors.New("the issuer has no authorization endpoint")
+	}
+
+	credentials, err := offeredCredentials(offer, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]map[string]interface{}, len(credentials))
+
+	for i, credential := range credentials {
+		details[i] = map[string]interface{}{
+			"type":      "openid_credential",
+			"format":    credential.Format,
+			"types":     credential.Types,
+			"locations": []string{offer.CredentialIssuer},
+		}
+	}
+
+	authorizationDetails, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal authorization details : %w", err)
+	}
+
+	request := &AuthorizationRequest{
+		State:        base64.RawURLEncoding.EncodeToString(random.GetRandomBytes(stateSize)),
+		CodeVerifier: base64.RawURLEncoding.EncodeToString(random.GetRandomBytes(codeVerifierSize)),
+		ClientID:     opts.clientID,
+		RedirectURI:  redirectURI,
+	}
+
+	challenge := sha256.Sum256([]byte(request.CodeVerifier))
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {opts.clientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {request.State},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+		"authorization_details": {string(authorizationDetails)},
+	}
+
+	if offer.Grants.AuthorizationCode != nil && offer.Grants.AuthorizationCode.IssuerState != "" {
+		query.Set("issuer_state", offer.Grants.AuthorizationCode.IssuerState)
+	}
+
+	separator := "?"
+	if strings.Contains(asMetadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	request.URL = asMetadata.AuthorizationEndpoint + separator + query.Encode()
+
+	return request, nil
+}
+
+// AcceptCredentialOffer gets the credentials of the offer from the OpenID4VCI issuer and adds them to the unlocked
+// wallet. The access token is requested with the pre-authorized code of the offer, or with the authorization code set
+// with WithAuthorizationCode. The credentials are requested in the ldp_vc format, bound to the wallet key set with
+// WithProofKey, whose proofs of possession are signed with the KMS of the wallet. The credentials issued are returned.
+func (c *Wallet) AcceptCredentialOffer(authToken string, offer *CredentialOffer,
+	options ...OIDC4VCIOpt) ([]*verifiable.Credential, error) {
+	opts := newOIDC4VCIOpts(options)
+
+	keyManager, err := c.KMS(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := getIssuerMetadata(opts.httpClient, offer.CredentialIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := offeredCredentials(offer, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := requestToken(offer, metadata, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := newProofSigner(keyManager, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := make([]*verifiable.Credential, 0, len(credentials))
+	nonce := token.CNonce
+
+	for _, credential := range credentials {
+		var raw json.RawMessage
+
+		raw, nonce, err = requestCredential(metadata, credential, token.AccessToken, nonce, signer, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		vc, err := verifiable.ParseUnverifiedCredential(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issued credential : %w", err)
+		}
+
+		if err := c.Add(authToken, Credential, raw); err != nil {
+			return nil, fmt.Errorf("failed to add issued credential : %w", err)
+		}
+
+		issued = append(issued, vc)
+	}
+
+	return issued, nil
+}
+
+// offeredCredentials returns the offered credentials, those given by ID resolved with the metadata of the issuer.
+func offeredCredentials(offer *CredentialOffer, metadata *credentialIssuerMetadata) ([]OfferedCredential, error) {
+	credentials := make([]OfferedCredential, len(offer.Credentials))
+
+	for i, credential := range offer.Credentials {
+		if credential.ID != "" {
+			found := false
+
+			for _, supported := range metadata.CredentialsSupported {
+				if supported.ID == credential.ID {
+					credential.Format, credential.Types, found = supported.Format, supported.Types, true
+
+					break
+				}
+			}
+
+			if !found {
+				return nil, fmt.Errorf("offered credential %s isn't supported by the issuer", credential.ID)
+			}
+		}
+
+		if credential.Format != LDPVCFormat {
+			return nil, fmt.Errorf("unsupported credential format %q", credential.Format)
+		}
+
+		credentials[i] = credential
+	}
+
+	return credentials, nil
+}
+
+func requestToken(offer *CredentialOffer, metadata *credentialIssuerMetadata,
+	opts *oidc4vciOpts) (*tokenResponse, error) {
+	form := url.Values{}
+
+	switch {
+	case opts.authReq != nil:
+		form.Set("grant_type", "authorization_code")
+		form.Set("code", opts.authCode)
+		form.Set("redirect_uri", opts.authReq.RedirectURI)
+		form.Set("client_id", opts.authReq.ClientID)
+		form.Set("code_verifier", opts.authReq.CodeVerifier)
+	case offer.Grants.PreAuthorizedCode != nil:
+		form.Set("grant_type", PreAuthorizedCodeGrantType)
+		form.Set("pre-authorized_code", offer.Grants.PreAuthorizedCode.PreAuthorizedCode)
+
+		if offer.Grants.PreAuthorizedCode.UserPINRequired {
+			if opts.userPIN == "" {
+				return nil, errors.New("the issuer requires the PIN of the user")
+			}
+
+			form.Set("user_pin", opts.userPIN)
+		}
+	default:
+		return nil, errors.New("the credential offer requires an authorization code")
+	}
+
+	tokenEndpoint := metadata.TokenEndpoint
+
+	if tokenEndpoint == "" {
+		asMetadata, err := getAuthorizationServerMetadata(opts.httpClient, metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		tokenEndpoint = asMetadata.TokenEndpoint
+	}
+
+	resp, err := opts.httpClient.PostForm(tokenEndpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request access token : %w", err)
+	}
+
+	token := &tokenResponse{}
+
+	if _, err := readResponse(resp, token); err != nil {
+		return nil, fmt.Errorf("failed to request access token : %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, errors.New("failed to request access token : missing access token")
+	}
+
+	return token, nil
+}
+
+// requestCredential requests the credential with a proof of possession of the nonce, once more with the nonce of the
+// issuer if it rejects the proof. It returns the credential and the nonce of the next proof.
+func requestCredential(metadata *credentialIssuerMetadata, credential OfferedCredential, accessToken, nonce string,
+	signer *proofSigner, opts *oidc4vciOpts) (json.RawMessage, string, error) {
+	for retried := false; ; retried = true {
+		proof, err := signer.proof(opts.clientID, metadata.CredentialIssuer, nonce)
+		if err != nil {
+			return nil, "", err
+		}
+
+		reqBytes, err := json.Marshal(&credentialRequest{
+			Format: credential.Format,
+			Types:  credential.Types,
+			Proof:  &credentialProof{ProofType: "jwt", JWT: proof},
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal credential request : %w", err)
+		}
+
+		req, err := http.NewRequest(http.MethodPost, metadata.CredentialEndpoint, bytes.NewReader(reqBytes))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to request credential : %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		resp, err := opts.httpClient.Do(req)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to request credential : %w", err)
+		}
+
+		response := &credentialResponse{}
+
+		oauthErr, err := readResponse(resp, response)
+		if oauthErr != nil && oauthErr.Error == invalidProofError && oauthErr.CNonce != "" && !retried {
+			nonce = oauthErr.CNonce
+
+			continue
+		}
+
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to request credential : %w", err)
+		}
+
+		return response.Credential, response.CNonce, nil
+	}
+}
+
+// proofSigner signs the proofs of possession of the wallet key the credentials are bound to.
+type proofSigner struct {
+	sign    func(data []byte) ([]byte, error)
+	headers jose.Headers
+}
+
+func newProofSigner(keyManager kms.KeyManager, opts *oidc4vciOpts) (*proofSigner, error) {
+	if opts.keyID == "" || opts.kid == "" {
+		return nil, errors.New("proof key is mandatory")
+	}
+
+	var alg string
+
+	switch opts.keyType {
+	case kms.ED25519Type:
+		alg = "EdDSA"
+	case kms.ECDSAP256TypeIEEEP1363:
+		alg = "ES256"
+	case kms.ECDSAP384TypeIEEEP1363:
+		alg = "ES384"
+	default:
+		return nil, fmt.Errorf("unsupported proof key type %q", opts.keyType)
+	}
+
+	kh, err := keyManager.Get(opts.keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proof key : %w", err)
+	}
+
+	crypto, err := tinkcrypto.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create crypto : %w", err)
+	}
+
+	return &proofSigner{
+		sign: func(data []byte) ([]byte, error) {
+			return crypto.Sign(data, kh)
+		},
+		headers: jose.Headers{
+			jose.HeaderAlgorithm: alg,
+			jose.HeaderType:      proofJWTType,
+			jose.HeaderKeyID:     opts.kid,
+		},
+	}, nil
+}
+
+func (s *proofSigner) Sign(data []byte) ([]byte, error) {
+	return s.sign(data)
+}
+
+func (s *proofSigner) Headers() jose.Headers {
+	return s.headers
+}
+
+// proof returns the proof of possession JWT of the nonce for the issuer.
+func (s *proofSigner) proof(clientID, issuer, nonce string) (string, error) {
+	claims := map[string]interface{}{
+		"aud": issuer,
+		"iat": time.Now().Unix(),
+	}
+
+	if clientID != "" {
+		claims["iss"] = clientID
+	}
+
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	token, err := jwt.NewSigned(claims, nil, s)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign proof of possession : %w", err)
+	}
+
+	proof, err := token.Serialize(false)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize proof of possession : %w", err)
+	}
+
+	return proof, nil
+}
+
+func getIssuerMetadata(client *http.Client, issuer string) (*credentialIssuerMetadata, error) {
+	raw, err := getJSON(client, strings.TrimSuffix(issuer, "/")+credentialIssuerMetadataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential issuer metadata : %w", err)
+	}
+
+	metadata := &credentialIssuerMetadata{}
+
+	if err := json.Unmarshal(raw, metadata); err != nil {
+		return nil, fmt.Errorf("invalid credential issuer metadata : %w", err)
+	}
+
+	if metadata.CredentialEndpoint == "" {
+		return nil, errors.New("invalid credential issuer metadata : missing credential endpoint")
+	}
+
+	if metadata.CredentialIssuer == "" {
+		metadata.CredentialIssuer = issuer
+	}
+
+	return metadata, nil
+}
+
+// getAuthorizationServerMetadata returns the metadata of the authorization server of the issuer, the issuer itself if
+// it has none.
+func getAuthorizationServerMetadata(client *http.Client,
+	metadata *credentialIssuerMetadata) (*authorizationServerMetadata, error) {
+	server := metadata.AuthorizationServer
+	if server == "" {
+		server = metadata.CredentialIssuer
+	}
+
+	raw, err := getJSON(client, strings.TrimSuffix(server, "/")+authorizationServerMetadataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorization server metadata : %w", err)
+	}
+
+	asMetadata := &authorizationServerMetadata{}
+
+	if err := json.Unmarshal(raw, asMetadata); err != nil {
+		return nil, fmt.Errorf("invalid authorization server metadata : %w", err)
+	}
+
+	if asMetadata.TokenEndpoint == "" {
+		return nil, errors.New("invalid authorization server metadata : missing token endpoint")
+	}
+
+	return asMetadata, nil
+}
+
+func getJSON(client *http.Client, u string) (json.RawMessage, error) {
+	resp, err := client.Get(u) // nolint: noctx
+	if err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+
+	if _, err := readResponse(resp, &raw); err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+// readResponse unmarshals the JSON response into v, returning the OAuth error of the error responses.
+func readResponse(resp *http.Response, v interface{}) (*oauthError, error) {
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warnf("failed to close response body : %s", err)
+		}
+	}()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20)) // nolint: gomnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response : %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		oauthErr := &oauthError{}
+
+		if json.Unmarshal(body, oauthErr) == nil && oauthErr.Error != "" {
+			return oauthErr, fmt.Errorf("%s : %s", oauthErr.Error, oauthErr.ErrorDescription)
+		}
+
+		return nil, fmt.Errorf("status %d : %s", resp.StatusCode, body)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, fmt.Errorf("invalid response : %w", err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,393 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	sampleDegreeCredentialID = "UniversityDegree_LDP"
+	samplePreAuthorizedCode  = "pre-authorized-code"
+	sampleUserPIN            = "493536"
+	sampleProofKID           = "did:example:holder#key-1"
+)
+
+func TestParseCredentialOffer(t *testing.T) {
+	offer := `{"credential_issuer":"https://issuer.example.com","credentials":["UniversityDegree_LDP",
+		{"format":"ldp_vc","types":["VerifiableCredential","UniversityDegreeCredential"]}],
+		"grants":{"urn:ietf:params:oauth:grant-type:pre-authorized_code":{"pre-authorized_code":"code",
+		"user_pin_required":true}}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(offer))
+	}))
+	defer server.Close()
+
+	for _, raw := range []string{
+		offer,
+		CredentialOfferScheme + "://?credential_offer=" + url.QueryEscape(offer),
+		CredentialOfferScheme + "://?credential_offer_uri=" + url.QueryEscape(server.URL+"/offer"),
+	} {
+		credentialOffer, err := ParseCredentialOffer(raw)
+		require.NoError(t, err)
+		require.Equal(t, "https://issuer.example.com", credentialOffer.CredentialIssuer)
+		require.Equal(t, []OfferedCredential{{ID: sampleDegreeCredentialID}, {
+			Format: LDPVCFormat, Types: []string{"VerifiableCredential", "UniversityDegreeCredential"},
+		}}, credentialOffer.Credentials)
+		require.Nil(t, credentialOffer.Grants.AuthorizationCode)
+		require.Equal(t, &PreAuthorizedCodeGrant{PreAuthorizedCode: "code", UserPINRequired: true},
+			credentialOffer.Grants.PreAuthorizedCode)
+	}
+
+	_, err := ParseCredentialOffer(CredentialOfferScheme + "://?other=value")
+	require.EqualError(t, err, "invalid credential offer URL : missing credential offer")
+
+	_, err = ParseCredentialOffer(`{"credential_issuer":"https://issuer.example.com"}`)
+	require.EqualError(t, err, "invalid credential offer : missing credential issuer or credentials")
+
+	_, err = ParseCredentialOffer(`{"credentials":[1]}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid credential offer")
+
+	_, err = ParseCredentialOffer(CredentialOfferScheme+"://?credential_offer_uri="+
+		url.QueryEscape(server.URL+"/offer"), WithHTTPClient(&http.Client{Transport: failingTransport{}}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to get credential offer")
+}
+
+func TestWallet_AcceptCredentialOffer(t *testing.T) {
+	t.Run("pre-authorized code flow", func(t *testing.T) {
+		issuer := newOIDC4VCIIssuer(t)
+		defer issuer.Close()
+
+		wallet, token, options := issuer.holder(t)
+
+		credentials, err := wallet.AcceptCredentialOffer(token, issuer.preAuthorizedOffer(true),
+			append(options, WithUserPIN(sampleUserPIN))...)
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+		require.Equal(t, "http://example.edu/credentials/1872", credentials[0].ID)
+		require.Equal(t, []string{"nonce-1", "nonce-2"}, issuer.nonces, "each proof has the last nonce of the issuer")
+
+		_, err = wallet.Get(token, Credential, "http://example.edu/credentials/1872")
+		require.NoError(t, err)
+
+		_, err = wallet.Get(token, Credential, "http://example.edu/credentials/3732")
+		require.NoError(t, err)
+	})
+
+	t.Run("authorization code flow", func(t *testing.T) {
+		issuer := newOIDC4VCIIssuer(t)
+		defer issuer.Close()
+
+		wallet, token, options := issuer.holder(t)
+
+		offer := issuer.preAuthorizedOffer(false)
+		offer.Grants = CredentialGrants{AuthorizationCode: &AuthorizationCodeGrant{IssuerState: "issuer-state"}}
+
+		_, err := NewAuthorizationRequest(offer, "https://wallet.example.com/cb")
+		require.EqualError(t, err, "client ID is mandatory")
+
+		request, err := NewAuthorizationRequest(offer, "https://wallet.example.com/cb", options...)
+		require.NoError(t, err)
+
+		authURL, err := url.Parse(request.URL)
+		require.NoError(t, err)
+		require.Equal(t, "/authorize", authURL.Path)
+		require.Equal(t, "issuer-state", authURL.Query().Get("issuer_state"))
+		require.Equal(t, request.State, authURL.Query().Get("state"))
+		require.Contains(t, authURL.Query().Get("authorization_details"), "UniversityDegreeCredential")
+
+		issuer.codeChallenge = authURL.Query().Get("code_challenge")
+
+		_, err = wallet.AcceptCredentialOffer(token, offer, options...)
+		require.EqualError(t, err, "the credential offer requires an authorization code")
+
+		credentials, err := wallet.AcceptCredentialOffer(token, offer,
+			append(options, WithAuthorizationCode(request, "authorization-code"))...)
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+
+		_, err = wallet.AcceptCredentialOffer(token, offer,
+			append(options, WithAuthorizationCode(request, "wrong code"))...)
+		require.EqualError(t, err, "failed to request access token : invalid_grant : invalid authorization code")
+	})
+
+	t.Run("proof rejected without nonce", func(t *testing.T) {
+		issuer := newOIDC4VCIIssuer(t)
+		defer issuer.Close()
+
+		issuer.tokenNonce = false
+
+		wallet, token, options := issuer.holder(t)
+
+		credentials, err := wallet.AcceptCredentialOffer(token, issuer.preAuthorizedOffer(false), options...)
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+		require.Equal(t, []string{"", "nonce-1", "nonce-2"}, issuer.nonces)
+	})
+
+	t.Run("accept offer errors", func(t *testing.T) {
+		issuer := newOIDC4VCIIssuer(t)
+		defer issuer.Close()
+
+		wallet, token, options := issuer.holder(t)
+
+		_, err := wallet.AcceptCredentialOffer("unknown", issuer.preAuthorizedOffer(false), options...)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		_, err = wallet.AcceptCredentialOffer(token, issuer.preAuthorizedOffer(true), options...)
+		require.EqualError(t, err, "the issuer requires the PIN of the user")
+
+		_, err = wallet.AcceptCredentialOffer(token, issuer.preAuthorizedOffer(true),
+			append(options, WithUserPIN("000000"))...)
+		require.EqualError(t, err, "failed to request access token : invalid_grant : invalid user PIN")
+
+		_, err = wallet.AcceptCredentialOffer(token, issuer.preAuthorizedOffer(false))
+		require.EqualError(t, err, "proof key is mandatory")
+
+		_, err = wallet.AcceptCredentialOffer(token, issuer.preAuthorizedOffer(false),
+			append(options, WithProofKey("unknown", kms.ED25519Type, sampleProofKID))...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get proof key")
+
+		_, err = wallet.AcceptCredentialOffer(token, issuer.preAuthorizedOffer(false),
+			append(options, WithProofKey("unknown", kms.RSARS256Type, sampleProofKID))...)
+		require.EqualError(t, err, `unsupported proof key type "RSARS256"`)
+
+		offer := issuer.preAuthorizedOffer(false)
+		offer.Credentials = []OfferedCredential{{ID: "unknown"}}
+
+		_, err = wallet.AcceptCredentialOffer(token, offer, options...)
+		require.EqualError(t, err, "offered credential unknown isn't supported by the issuer")
+
+		offer.Credentials = []OfferedCredential{{Format: "jwt_vc_json", Types: []string{"VerifiableCredential"}}}
+
+		_, err = wallet.AcceptCredentialOffer(token, offer, options...)
+		require.EqualError(t, err, `unsupported credential format "jwt_vc_json"`)
+
+		offer = issuer.preAuthorizedOffer(false)
+		offer.CredentialIssuer = issuer.URL + "/unknown"
+
+		_, err = wallet.AcceptCredentialOffer(token, offer, options...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get credential issuer metadata : status 404")
+
+		issuer.credential = `{"id":"http://example.edu/credentials/1"}`
+
+		_, err = wallet.AcceptCredentialOffer(token, issuer.preAuthorizedOffer(false), options...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid issued credential")
+	})
+}
+
+// oidc4vciIssuer is an OpenID4VCI issuer of university degrees, its own authorization server, which accepts the
+// proofs of possession of the holder key.
+type oidc4vciIssuer struct {
+	*httptest.Server
+	t             *testing.T
+	holderKey     ed25519.PublicKey
+	codeChallenge string
+	tokenNonce    bool
+	nonce         int
+	nonces        []string
+	issued        int
+	credential    string
+}
+
+func newOIDC4VCIIssuer(t *testing.T) *oidc4vciIssuer {
+	t.Helper()
+
+	issuer := &oidc4vciIssuer{t: t, tokenNonce: true}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(credentialIssuerMetadataPath, issuer.metadata)
+	mux.HandleFunc(authorizationServerMetadataPath, issuer.authorizationServerMetadata)
+	mux.HandleFunc("/token", issuer.token)
+	mux.HandleFunc("/credential", issuer.issue)
+
+	issuer.Server = httptest.NewServer(mux)
+
+	return issuer
+}
+
+// holder returns the unlocked wallet of the holder and the options to accept the offers of the issuer with.
+func (i *oidc4vciIssuer) holder(t *testing.T) (*Wallet, string, []OIDC4VCIOpt) {
+	t.Helper()
+
+	wallet := newWallet(t, newProvider())
+	token := open(t, wallet, samplePassphrase)
+
+	keyManager, err := wallet.KMS(token)
+	require.NoError(t, err)
+
+	keyID, pubKey, err := keyManager.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	i.holderKey = pubKey
+
+	return wallet, token, []OIDC4VCIOpt{
+		WithClientID("wallet"),
+		WithHTTPClient(i.Client()),
+		WithProofKey(keyID, kms.ED25519Type, sampleProofKID),
+	}
+}
+
+func (i *oidc4vciIssuer) preAuthorizedOffer(pinRequired bool) *CredentialOffer {
+	return &CredentialOffer{
+		CredentialIssuer: i.URL,
+		Credentials: []OfferedCredential{{ID: sampleDegreeCredentialID}, {
+			Format: LDPVCFormat, Types: []string{"VerifiableCredential", "UniversityDegreeCredential"},
+		}},
+		Grants: CredentialGrants{PreAuthorizedCode: &PreAuthorizedCodeGrant{
+			PreAuthorizedCode: samplePreAuthorizedCode,
+			UserPINRequired:   pinRequired,
+		}},
+	}
+}
+
+func (i *oidc4vciIssuer) metadata(w http.ResponseWriter, _ *http.Request) {
+	i.write(w, http.StatusOK, &credentialIssuerMetadata{
+		CredentialIssuer:   i.URL,
+		CredentialEndpoint: i.URL + "/credential",
+		CredentialsSupported: []supportedCredential{{
+			ID:     sampleDegreeCredentialID,
+			Format: LDPVCFormat,
+			Types:  []string{"VerifiableCredential", "UniversityDegreeCredential"},
+		}},
+	})
+}
+
+func (i *oidc4vciIssuer) authorizationServerMetadata(w http.ResponseWriter, _ *http.Request) {
+	i.write(w, http.StatusOK, &authorizationServerMetadata{
+		AuthorizationEndpoint: i.URL + "/authorize",
+		TokenEndpoint:         i.URL + "/token",
+	})
+}
+
+func (i *oidc4vciIssuer) token(w http.ResponseWriter, r *http.Request) {
+	require.NoError(i.t, r.ParseForm())
+
+	switch r.Form.Get("grant_type") {
+	case PreAuthorizedCodeGrantType:
+		require.Equal(i.t, samplePreAuthorizedCode, r.Form.Get("pre-authorized_code"))
+
+		if pin := r.Form.Get("user_pin"); pin != "" && pin != sampleUserPIN {
+			i.write(w, http.StatusBadRequest, &oauthError{Error: "invalid_grant", ErrorDescription: "invalid user PIN"})
+
+			return
+		}
+	case "authorization_code":
+		challenge := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		require.Equal(i.t, i.codeChallenge, base64.RawURLEncoding.EncodeToString(challenge[:]))
+		require.Equal(i.t, "wallet", r.Form.Get("client_id"))
+
+		if r.Form.Get("code") != "authorization-code" {
+			i.write(w, http.StatusBadRequest, &oauthError{
+				Error: "invalid_grant", ErrorDescription: "invalid authorization code",
+			})
+
+			return
+		}
+	default:
+		i.t.Fatalf("unexpected grant type %s", r.Form.Get("grant_type"))
+	}
+
+	response := &tokenResponse{AccessToken: "access-token", TokenType: "bearer"}
+	if i.tokenNonce {
+		response.CNonce = i.nextNonce()
+	}
+
+	i.write(w, http.StatusOK, response)
+}
+
+func (i *oidc4vciIssuer) issue(w http.ResponseWriter, r *http.Request) {
+	require.Equal(i.t, "Bearer access-token", r.Header.Get("Authorization"))
+
+	request := &credentialRequest{}
+	require.NoError(i.t, json.NewDecoder(r.Body).Decode(request))
+	require.Equal(i.t, LDPVCFormat, request.Format)
+	require.Equal(i.t, "jwt", request.Proof.ProofType)
+
+	proof, err := jose.ParseJWS(request.Proof.JWT, jose.SignatureVerifierFunc(
+		func(headers jose.Headers, _, signingInput, signature []byte) error {
+			require.Equal(i.t, proofJWTType, headers[jose.HeaderType])
+			require.Equal(i.t, "EdDSA", headers[jose.HeaderAlgorithm])
+			require.Equal(i.t, sampleProofKID, headers[jose.HeaderKeyID])
+
+			if !ed25519.Verify(i.holderKey, signingInput, signature) {
+				return errors.New("invalid signature")
+			}
+
+			return nil
+		}))
+	require.NoError(i.t, err)
+
+	claims := map[string]interface{}{}
+	require.NoError(i.t, json.Unmarshal(proof.Payload, &claims))
+	require.Equal(i.t, i.URL, claims["aud"])
+	require.Equal(i.t, "wallet", claims["iss"])
+
+	nonce, _ := claims["nonce"].(string) // nolint: errcheck
+	i.nonces = append(i.nonces, nonce)
+
+	if nonce == "" || nonce != i.currentNonce() {
+		i.write(w, http.StatusBadRequest, &oauthError{Error: invalidProofError, CNonce: i.nextNonce()})
+
+		return
+	}
+
+	credential := i.credential
+	if credential == "" {
+		credential = []string{sampleCredential, sampleUniversityDegree}[i.issued%2]
+	}
+
+	i.issued++
+
+	i.write(w, http.StatusOK, &credentialResponse{
+		Format:     LDPVCFormat,
+		Credential: json.RawMessage(credential),
+		CNonce:     i.nextNonce(),
+	})
+}
+
+func (i *oidc4vciIssuer) currentNonce() string {
+	return "nonce-" + strconv.Itoa(i.nonce)
+}
+
+func (i *oidc4vciIssuer) nextNonce() string {
+	i.nonce++
+
+	return i.currentNonce()
+}
+
+func (i *oidc4vciIssuer) write(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(i.t, json.NewEncoder(w).Encode(v))
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("network failure")
+}
@@ -0,0 +1,379 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// defaultConsentTimeout is how long PresentProof waits for the consent of the user by default.
+const defaultConsentTimeout = 5 * time.Minute
+
+// ErrConsentTimeout is returned when the user doesn't answer the consent request in time, the request presentation
+// being left pending.
+var ErrConsentTimeout = errors.New("consent timeout")
+
+// presentProofService is the present-proof protocol service the wallet answers the requests of the verifiers with.
+type presentProofService interface {
+	Actions() ([]presentproof.Action, error)
+	ActionContinue(piID string, opt presentproof.Opt) error
+	ActionStop(piID string, err error) error
+}
+
+// ConsentRequest is the event asking the user to select the credentials presented to a verifier, see
+// RegisterConsentEvent.
+type ConsentRequest struct {
+	// PIID is the ID of the present-proof protocol instance.
+	PIID     string
+	MyDID    string
+	TheirDID string
+	// Definition is the presentation definition requested by the verifier.
+	Definition *presexch.PresentationDefinition
+	// Matches are the wallet credentials matching the input descriptors of the definition, by input descriptor ID.
+	Matches map[string][]*verifiable.Credential
+	// Continue presents the selected credentials to the verifier.
+	Continue func(selected ...*verifiable.Credential)
+	// Stop declines the request presentation with the given reason.
+	Stop func(reason error)
+}
+
+type consent struct {
+	selected []*verifiable.Credential
+	declined error
+}
+
+// PresentProofOpt configures the answer of the wallet to a request presentation.
+type PresentProofOpt func(opts *presentProofOpts)
+
+type presentProofOpts struct {
+	selected       []string
+	proof          *verifiable.LinkedDataProofContext
+	consentTimeout time.Duration
+}
+
+// WithSelectedCredentials presents the wallet credentials of the given IDs, the user having already consented to
+// their presentation: no consent request is sent.
+func WithSelectedCredentials(ids ...string) PresentProofOpt {
+	return func(opts *presentProofOpts) {
+		opts.selected = ids
+	}
+}
+
+// WithPresentationProof signs the presentation with the given linked data proof, the presentation is unsigned if not
+// set.
+func WithPresentationProof(proof *verifiable.LinkedDataProofContext) PresentProofOpt {
+	return func(opts *presentProofOpts) {
+		opts.proof = proof
+	}
+}
+
+// WithConsentTimeout sets how long to wait for the consent of the user, 5 minutes by default.
+func WithConsentTimeout(timeout time.Duration) PresentProofOpt {
+	return func(opts *presentProofOpts) {
+		opts.consentTimeout = timeout
+	}
+}
+
+// RegisterConsentEvent registers the channel the consent requests of PresentProof are sent to. Only one channel can be
+// registered for the consent requests.
+func (c *Wallet) RegisterConsentEvent(ch chan<- ConsentRequest) error {
+	if ch == nil {
+		return service.ErrNilChannel
+	}
+
+	c.consentLock.Lock()
+	defer c.consentLock.Unlock()
+
+	if c.consentEvent != nil {
+		return service.ErrChannelRegistered
+	}
+
+	c.consentEvent = ch
+
+	return nil
+}
+
+// UnregisterConsentEvent unregisters the channel of the consent requests, see RegisterConsentEvent.
+func (c *Wallet) UnregisterConsentEvent(ch chan<- ConsentRequest) error {
+	if ch == nil {
+		return service.ErrNilChannel
+	}
+
+	c.consentLock.Lock()
+	defer c.consentLock.Unlock()
+
+	if c.consentEvent != ch {
+		return service.ErrInvalidChannel
+	}
+
+	c.consentEvent = nil
+
+	return nil
+}
+
+// PresentProof answers the pending request presentation of the present-proof protocol instance piID with the
+// credentials of the unlocked wallet satisfying its presentation definition, on the connection the request was
+// received on. The user selects the credentials through a ConsentRequest if a consent channel is registered and no
+// credentials are selected with the options, the first credentials matching each input descriptor being presented
+// otherwise. The request is declined when the wallet has no credentials for one of the input descriptors, or when the
+// user declines it. The presentation sent is returned, nil if the request is declined. The presentation, or the
+// reason the request was declined, is recorded in the audit log first.
+func (c *Wallet) PresentProof(authToken, piID string, options ...PresentProofOpt) (*verifiable.Presentation, error) {
+	opts := &presentProofOpts{consentTimeout: defaultConsentTimeout}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	credentials, err := c.credentials(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.presentProofService()
+	if err != nil {
+		return nil, err
+	}
+
+	action, definition, err := requestPresentation(svc, piID)
+	if err != nil {
+		return nil, err
+	}
+
+	vcs := make([]*verifiable.Credential, len(credentials))
+
+	for i, credential := range credentials {
+		vcs[i] = credential.vc
+	}
+
+	matches := definition.Evaluate(vcs...)
+
+	for _, descriptor := range definition.InputDescriptors {
+		if len(matches[descriptor.ID]) > 0 {
+			continue
+		}
+
+		err = fmt.Errorf("input descriptor %s: %w", descriptor.ID, ErrNoQueryResults)
+
+		if e := c.recordPresentProof(authToken, action, definition, err); e != nil {
+			return nil, e
+		}
+
+		if e := svc.ActionStop(piID, err); e != nil {
+			return nil, fmt.Errorf("failed to decline request presentation : %w", e)
+		}
+
+		return nil, err
+	}
+
+	answer, err := c.selectCredentials(action, definition, matches, vcs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if answer.declined != nil {
+		if err = c.recordPresentProof(authToken, action, definition, answer.declined); err != nil {
+			return nil, err
+		}
+
+		if err = svc.ActionStop(piID, answer.declined); err != nil {
+			return nil, fmt.Errorf("failed to decline request presentation : %w", err)
+		}
+
+		return nil, nil
+	}
+
+	vp, err := definition.CreateVP(answer.selected...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create presentation : %w", err)
+	}
+
+	if opts.proof != nil {
+		if err = vp.AddLinkedDataProof(opts.proof); err != nil {
+			return nil, fmt.Errorf("failed to sign presentation : %w", err)
+		}
+	}
+
+	if err = c.recordPresentProof(authToken, action, definition, nil, vp.Credentials()...); err != nil {
+		return nil, err
+	}
+
+	msg := &presentproof.Presentation{}
+	presentproof.AddPresentationSubmission(msg, vp)
+
+	if err = svc.ActionContinue(piID, presentproof.WithPresentation(msg)); err != nil {
+		return nil, fmt.Errorf("failed to send presentation : %w", err)
+	}
+
+	return vp, nil
+}
+
+// selectCredentials returns the credentials selected by the options or the user, or the reason the user declined the
+// request presentation.
+func (c *Wallet) selectCredentials(action *presentproof.Action, definition *presexch.PresentationDefinition,
+	matches map[string][]*verifiable.Credential, vcs []*verifiable.Credential,
+	opts *presentProofOpts) (*consent, error) {
+	if len(opts.selected) > 0 {
+		selected, err := selectByID(vcs, opts.selected)
+		if err != nil {
+			return nil, err
+		}
+
+		return &consent{selected: selected}, nil
+	}
+
+	c.consentLock.RLock()
+	ch := c.consentEvent
+	c.consentLock.RUnlock()
+
+	if ch == nil {
+		return &consent{selected: vcs}, nil
+	}
+
+	answer := make(chan *consent, 1)
+	timeout := time.After(opts.consentTimeout)
+
+	var once sync.Once
+
+	request := ConsentRequest{
+		PIID:       action.PIID,
+		MyDID:      action.MyDID,
+		TheirDID:   action.TheirDID,
+		Definition: definition,
+		Matches:    matches,
+		Continue: func(selected ...*verifiable.Credential) {
+			once.Do(func() { answer <- &consent{selected: selected} })
+		},
+		Stop: func(reason error) {
+			if reason == nil {
+				reason = errors.New("request presentation declined")
+			}
+
+			once.Do(func() { answer <- &consent{declined: reason} })
+		},
+	}
+
+	select {
+	case ch <- request:
+	case <-timeout:
+		return nil, ErrConsentTimeout
+	}
+
+	select {
+	case a := <-answer:
+		return a, nil
+	case <-timeout:
+		return nil, ErrConsentTimeout
+	}
+}
+
+// recordPresentProof records the presentation of the credentials to the verifier in the audit log, or the reason the
+// request presentation was declined.
+func (c *Wallet) recordPresentProof(authToken string, action *presentproof.Action,
+	definition *presexch.PresentationDefinition, declined error, vcs ...interface{}) error {
+	request, err := json.Marshal(definition)
+	if err != nil {
+		return fmt.Errorf("failed to marshal presentation definition : %w", err)
+	}
+
+	record := &DisclosureRecord{
+		Operation: PresentProofOperation,
+		Recipient: action.TheirDID,
+		RequestID: action.PIID,
+		Request:   request,
+	}
+
+	if declined != nil {
+		record.Declined = declined.Error()
+	}
+
+	return c.recordDisclosure(authToken, record, vcs...)
+}
+
+func (c *Wallet) presentProofService() (presentProofService, error) {
+	raw, err := c.ctx.Service(presentproof.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up present-proof service : %w", err)
+	}
+
+	svc, ok := raw.(presentProofService)
+	if !ok {
+		return nil, errors.New("cast service to present-proof service failed")
+	}
+
+	return svc, nil
+}
+
+// requestPresentation returns the pending request presentation action of the protocol instance, and its presentation
+// definition.
+func requestPresentation(svc presentProofService,
+	piID string) (*presentproof.Action, *presexch.PresentationDefinition, error) {
+	actions, err := svc.Actions()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get present-proof actions : %w", err)
+	}
+
+	for i := range actions {
+		action := actions[i]
+
+		if action.PIID != piID {
+			continue
+		}
+
+		if action.Msg.Type() != presentproof.RequestPresentationMsgType {
+			return nil, nil, fmt.Errorf("present-proof action %s is not a request presentation", piID)
+		}
+
+		request := &presentproof.RequestPresentation{}
+
+		if err = action.Msg.Decode(request); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode request presentation : %w", err)
+		}
+
+		definition, err := presentproof.GetPresentationDefinition(request)
+		if err != nil {
+			return nil, nil, fmt.Errorf("request presentation %s : %w", piID, err)
+		}
+
+		return &action, definition, nil
+	}
+
+	return nil, nil, fmt.Errorf("no pending request presentation %s", piID)
+}
+
+func selectByID(vcs []*verifiable.Credential, ids []string) ([]*verifiable.Credential, error) {
+	selected := make([]*verifiable.Credential, 0, len(ids))
+
+	for _, id := range ids {
+		var vc *verifiable.Credential
+
+		for _, v := range vcs {
+			if v.ID == id {
+				vc = v
+
+				break
+			}
+		}
+
+		if vc == nil {
+			return nil, fmt.Errorf("credential %s : %w", id, ErrContentNotFound)
+		}
+
+		selected = append(selected, vc)
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const samplePIID = "sample-piid"
+
+func TestWallet_PresentProof(t *testing.T) {
+	t.Run("present the matching credentials", func(t *testing.T) {
+		wallet, token, svc := newPresentProofWallet(t, degreeRequest())
+
+		vp, err := wallet.PresentProof(token, samplePIID)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+		require.Equal(t, "http://example.edu/credentials/3732", credentialID(t, vp.Credentials()[0]))
+		require.Contains(t, vp.CustomFields, "presentation_submission")
+		require.True(t, svc.presented)
+	})
+
+	t.Run("present the credentials selected by the user", func(t *testing.T) {
+		wallet, token, svc := newPresentProofWallet(t, degreeRequest())
+
+		consents := make(chan ConsentRequest)
+		require.NoError(t, wallet.RegisterConsentEvent(consents))
+
+		go func() {
+			consent := <-consents
+			require.Equal(t, samplePIID, consent.PIID)
+			require.Equal(t, "their-did", consent.TheirDID)
+			require.Len(t, consent.Matches["degree"], 1)
+
+			consent.Continue(consent.Matches["degree"]...)
+		}()
+
+		vp, err := wallet.PresentProof(token, samplePIID)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+		require.True(t, svc.presented)
+	})
+
+	t.Run("decline", func(t *testing.T) {
+		wallet, token, svc := newPresentProofWallet(t, degreeRequest())
+
+		consents := make(chan ConsentRequest)
+		require.NoError(t, wallet.RegisterConsentEvent(consents))
+
+		go func() {
+			(<-consents).Stop(nil)
+		}()
+
+		vp, err := wallet.PresentProof(token, samplePIID)
+		require.NoError(t, err)
+		require.Nil(t, vp)
+		require.False(t, svc.presented)
+		require.EqualError(t, svc.declined, "request presentation declined")
+	})
+
+	t.Run("consent timeout", func(t *testing.T) {
+		wallet, token, svc := newPresentProofWallet(t, degreeRequest())
+
+		consents := make(chan ConsentRequest)
+		require.NoError(t, wallet.RegisterConsentEvent(consents))
+
+		_, err := wallet.PresentProof(token, samplePIID, WithConsentTimeout(10*time.Millisecond))
+		require.True(t, errors.Is(err, ErrConsentTimeout))
+		require.False(t, svc.presented)
+		require.Nil(t, svc.declined)
+
+		require.NoError(t, wallet.UnregisterConsentEvent(consents))
+	})
+
+	t.Run("selected credentials", func(t *testing.T) {
+		wallet, token, svc := newPresentProofWallet(t, degreeRequest())
+
+		_, err := wallet.PresentProof(token, samplePIID, WithSelectedCredentials("http://example.edu/credentials/1872"))
+		require.True(t, errors.Is(err, presexch.ErrNoCredentials))
+
+		_, err = wallet.PresentProof(token, samplePIID, WithSelectedCredentials("unknown"))
+		require.True(t, errors.Is(err, ErrContentNotFound))
+
+		vp, err := wallet.PresentProof(token, samplePIID, WithSelectedCredentials("http://example.edu/credentials/3732"))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+		require.True(t, svc.presented)
+	})
+
+	t.Run("no matching credentials", func(t *testing.T) {
+		request := &presentproof.RequestPresentation{}
+		presentproof.AddPresentationDefinition(request, &presexch.PresentationDefinition{
+			ID: "license",
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID: "license", Schema: []presexch.Schema{{URI: "https://example.org/license/v1"}},
+			}},
+		})
+
+		wallet, token, svc := newPresentProofWallet(t, request)
+
+		_, err := wallet.PresentProof(token, samplePIID)
+		require.True(t, errors.Is(err, ErrNoQueryResults))
+		require.True(t, errors.Is(svc.declined, ErrNoQueryResults))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		wallet, token, svc := newPresentProofWallet(t, &presentproof.RequestPresentation{})
+
+		_, err := wallet.PresentProof(token, "unknown")
+		require.EqualError(t, err, "no pending request presentation unknown")
+
+		_, err = wallet.PresentProof(token, samplePIID)
+		require.True(t, errors.Is(err, presentproof.ErrNoPresentationDefinition))
+
+		svc.actions[0].Msg = service.NewDIDCommMsgMap(&presentproof.Presentation{Type: presentproof.PresentationMsgType})
+
+		_, err = wallet.PresentProof(token, samplePIID)
+		require.EqualError(t, err, "present-proof action sample-piid is not a request presentation")
+
+		svc.actionsErr = errors.New("actions error")
+
+		_, err = wallet.PresentProof(token, samplePIID)
+		require.Contains(t, err.Error(), "actions error")
+	})
+
+	t.Run("service errors", func(t *testing.T) {
+		provider := &mockprovider.Provider{StorageProviderValue: mem.NewProvider(), ServiceErr: errors.New("no service")}
+		wallet := newWallet(t, provider)
+		token := open(t, wallet, samplePassphrase)
+
+		_, err := wallet.PresentProof(token, samplePIID)
+		require.Contains(t, err.Error(), "no service")
+
+		provider.ServiceErr = nil
+		provider.ServiceValue = struct{}{}
+
+		_, err = wallet.PresentProof(token, samplePIID)
+		require.EqualError(t, err, "cast service to present-proof service failed")
+
+		_, err = newWallet(t, newProvider()).PresentProof("", samplePIID)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+	})
+
+	t.Run("consent event registration", func(t *testing.T) {
+		wallet := newWallet(t, newProvider())
+		consents := make(chan ConsentRequest)
+
+		require.True(t, errors.Is(wallet.RegisterConsentEvent(nil), service.ErrNilChannel))
+		require.True(t, errors.Is(wallet.UnregisterConsentEvent(nil), service.ErrNilChannel))
+		require.True(t, errors.Is(wallet.UnregisterConsentEvent(consents), service.ErrInvalidChannel))
+
+		require.NoError(t, wallet.RegisterConsentEvent(consents))
+		require.True(t, errors.Is(wallet.RegisterConsentEvent(make(chan ConsentRequest)), service.ErrChannelRegistered))
+		require.NoError(t, wallet.UnregisterConsentEvent(consents))
+	})
+}
+
+type mockPresentProofService struct {
+	actions    []presentproof.Action
+	actionsErr error
+	presented  bool
+	declined   error
+}
+
+func (s *mockPresentProofService) Actions() ([]presentproof.Action, error) {
+	return s.actions, s.actionsErr
+}
+
+func (s *mockPresentProofService) ActionContinue(_ string, opt presentproof.Opt) error {
+	s.presented = opt != nil
+
+	return nil
+}
+
+func (s *mockPresentProofService) ActionStop(_ string, err error) error {
+	s.declined = err
+
+	return nil
+}
+
+func newPresentProofWallet(t *testing.T,
+	request *presentproof.RequestPresentation) (*Wallet, string, *mockPresentProofService) {
+	t.Helper()
+
+	request.Type = presentproof.RequestPresentationMsgType
+
+	svc := &mockPresentProofService{actions: []presentproof.Action{{
+		PIID: samplePIID, Msg: service.NewDIDCommMsgMap(request), MyDID: "my-did", TheirDID: "their-did",
+	}}}
+
+	wallet := newWallet(t, &mockprovider.Provider{
+		StorageProviderValue: mem.NewProvider(),
+		ServiceMap:           map[string]interface{}{presentproof.Name: svc},
+	})
+	token := open(t, wallet, samplePassphrase)
+
+	require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleCredential)))
+	require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleUniversityDegree)))
+
+	return wallet, token, svc
+}
+
+func degreeRequest() *presentproof.RequestPresentation {
+	request := &presentproof.RequestPresentation{}
+	presentproof.AddPresentationDefinition(request, &presexch.PresentationDefinition{
+		ID: "degree",
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID: "degree", Schema: []presexch.Schema{{URI: "https://www.w3.org/2018/credentials/examples/v1"}},
+		}},
+	})
+
+	return request
+}
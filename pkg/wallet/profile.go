@@ -0,0 +1,322 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/tink/go/subtle/random"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/wrapper/tenant"
+)
+
+const (
+	// StoreName is the name of the store of the wallet profiles.
+	StoreName = "vcwallet"
+	// contentStoreName is the name of the store of the contents of a profile, among the stores of the profile.
+	contentStoreName = "contents"
+
+	profileKeyPrefix = "profile_"
+	tenantIDPrefix   = "vcwallet-"
+	saltSize         = 32
+
+	// scryptKDF is the name of the scrypt key derivation, the only one supported.
+	scryptKDF = "scrypt"
+	// maxScryptCost bounds the cost of the scrypt derivations read from the stored profiles and archives.
+	maxScryptCost = 1 << 20
+)
+
+// defaultKeyDerivation is the scrypt derivation of the keys protected by a passphrase, with the cost recommended for
+// interactive logins.
+// nolint:gochecknoglobals
+var defaultKeyDerivation = keyDerivation{Name: scryptKDF, N: 1 << 15, R: 8, P: 1}
+
+// keyDerivation holds the parameters deriving a key from a passphrase, kept with the secrets the key protects so that
+// the default cost can be raised without locking them.
+type keyDerivation struct {
+	Name string `json:"name"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+// profile is the wallet profile of a user, with the content key and the master key of its KMS encrypted with the key
+// expanded from the passphrase.
+type profile struct {
+	UserID     string `json:"userID"`
+	Salt       string `json:"salt"`
+	ContentKey string `json:"contentKey"`
+	KMSKey     string `json:"kmsKey"`
+	// KDF derives the key encrypting the content key and the master key from the passphrase
+	KDF *keyDerivation `json:"kdf"`
+	// EDV is the vault the contents and keys of the profile are kept in, nil if they are kept in the agent storage
+	EDV *edvConf `json:"edv,omitempty"`
+}
+
+// CreateProfile creates the wallet profile of the user, whose contents and keys are encrypted with keys only
+// available once the wallet is unlocked with the passphrase. The contents and keys of each profile are kept in
+// stores of their own, so that many users can share the storage of an agent.
+func CreateProfile(userID, passphrase string, ctx provider, options ...ProfileOpt) error {
+	opts := &profileOpts{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	if userID == "" {
+		return errors.New("user ID is mandatory")
+	}
+
+	store, err := ctx.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return fmt.Errorf("failed to open wallet store : %w", err)
+	}
+
+	_, err = store.Get(profileKey(userID))
+
+	switch {
+	case err == nil:
+		return ErrProfileExists
+	case !errors.Is(err, storage.ErrDataNotFound):
+		return fmt.Errorf("failed to get wallet profile : %w", err)
+	}
+
+	p, err := newProfile(userID, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if opts.edv != nil {
+		if p, err = opts.edv.shareProfile(p, passphrase); err != nil {
+			return err
+		}
+	}
+
+	profileBytes, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallet profile : %w", err)
+	}
+
+	if err := store.Put(profileKey(userID), profileBytes); err != nil {
+		return fmt.Errorf("failed to save wallet profile : %w", err)
+	}
+
+	return nil
+}
+
+// RemoveProfile removes the wallet profile of the user, with all its contents and keys. The passphrase of the profile
+// is required, so that only its user can remove it. The vault of a profile kept in an EDV is left to the other devices
+// sharing it, only the local copies of its records being removed.
+func RemoveProfile(userID, passphrase string, ctx provider) error {
+	store, err := ctx.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return fmt.Errorf("failed to open wallet store : %w", err)
+	}
+
+	p, err := getProfile(store, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err = p.unlock(passphrase); err != nil {
+		return err
+	}
+
+	profileStorage, err := tenantStorage(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{contentStoreName, localkms.Namespace} {
+		if err := clearStore(profileStorage, name); err != nil {
+			return err
+		}
+	}
+
+	if err := store.Delete(profileKey(userID)); err != nil {
+		return fmt.Errorf("failed to remove wallet profile : %w", err)
+	}
+
+	return nil
+}
+
+// newProfile returns a new profile, with its keys encrypted with the key derived from the passphrase.
+func newProfile(userID, passphrase string) (*profile, error) {
+	salt := random.GetRandomBytes(saltSize)
+	kdf := defaultKeyDerivation
+
+	lock, err := passphraseLock(passphrase, salt, &kdf)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := lock.Encrypt("", &secretlock.EncryptRequest{
+		Plaintext: string(random.GetRandomBytes(contentKeySize)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt content key : %w", err)
+	}
+
+	kmsKey, err := lock.Encrypt("", &secretlock.EncryptRequest{
+		Plaintext: string(random.GetRandomBytes(contentKeySize)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt KMS key : %w", err)
+	}
+
+	return &profile{
+		UserID:     userID,
+		Salt:       base64.RawURLEncoding.EncodeToString(salt),
+		ContentKey: encrypted.Ciphertext,
+		KMSKey:     kmsKey.Ciphertext,
+		KDF:        &kdf,
+	}, nil
+}
+
+func getProfile(store storage.Store, userID string) (*profile, error) {
+	profileBytes, err := store.Get(profileKey(userID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrProfileNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet profile : %w", err)
+	}
+
+	p := &profile{}
+
+	if err := json.Unmarshal(profileBytes, p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wallet profile : %w", err)
+	}
+
+	return p, nil
+}
+
+// unlock returns the content key of the profile and the secret lock of its KMS, decrypted with the key derived from
+// the passphrase.
+func (p *profile) unlock(passphrase string) ([]byte, secretlock.Service, error) {
+	salt, err := base64.RawURLEncoding.DecodeString(p.Salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid wallet profile salt : %w", err)
+	}
+
+	lock, err := passphraseLock(passphrase, salt, p.KDF)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decrypted, err := lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: p.ContentKey})
+	if err != nil {
+		return nil, nil, ErrInvalidPassphrase
+	}
+
+	// the KMS keys are encrypted with the master key of the profile, itself protected by the passphrase
+	kmsLock, err := local.NewService(strings.NewReader(p.KMSKey), lock)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create wallet KMS secret lock : %w", err)
+	}
+
+	return []byte(decrypted.Plaintext), kmsLock, nil
+}
+
+// passphraseLock returns the lock of the secrets protected by the passphrase, with the key derived from the passphrase
+// and salt by the memory-hard key derivation.
+func passphraseLock(passphrase string, salt []byte, kdf *keyDerivation) (secretlock.Service, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is mandatory")
+	}
+
+	if kdf == nil || kdf.Name != scryptKDF {
+		return nil, errors.New("unsupported passphrase key derivation")
+	}
+
+	if kdf.N > maxScryptCost || kdf.R*kdf.P > maxScryptCost {
+		return nil, errors.New("passphrase key derivation cost too high")
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, kdf.N, kdf.R, kdf.P, contentKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive passphrase key : %w", err)
+	}
+
+	return masterLock(string(key), salt)
+}
+
+// masterLock returns the lock of the secrets protected by a random secret, such as a pairing code, with the key
+// expanded from the secret and salt.
+func masterLock(passphrase string, salt []byte) (secretlock.Service, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is mandatory")
+	}
+
+	lock, err := hkdf.NewMasterLock(passphrase, sha256.New, salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create master lock : %w", err)
+	}
+
+	return lock, nil
+}
+
+// userKey encodes the user ID within the store keys, so that the keys of different users don't share prefixes.
+func userKey(userID string) string {
+	return hex.EncodeToString([]byte(userID))
+}
+
+func profileKey(userID string) string {
+	return profileKeyPrefix + userKey(userID)
+}
+
+// tenantStorage returns the storage of the stores of the profile, isolated from those of the other profiles.
+func tenantStorage(ctx provider, userID string) (storage.Provider, error) {
+	p, err := tenant.NewProvider(ctx.StorageProvider(), tenantIDPrefix+userKey(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet profile storage : %w", err)
+	}
+
+	return p, nil
+}
+
+// clearStore deletes the records of the store.
+func clearStore(p storage.Provider, name string) error {
+	store, err := p.OpenStore(name)
+	if err != nil {
+		return fmt.Errorf("failed to open wallet profile store %s : %w", name, err)
+	}
+
+	var keys []string
+
+	iter := store.Iterator("", storage.EndKeySuffix)
+	defer iter.Release()
+
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to iterate wallet profile store %s : %w", name, err)
+	}
+
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil {
+			return fmt.Errorf("failed to delete wallet profile record : %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,568 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	presentationContext = "https://www.w3.org/2018/credentials/v1"
+	presentationType    = "VerifiablePresentation"
+	bbsProofType        = "BbsBlsSignature2020"
+)
+
+// QueryType is the type of a query of a verifiable presentation request, see https://w3c-ccg.github.io/vp-request-spec/.
+type QueryType string
+
+// supported query types.
+const (
+	// QueryByExample matches the credentials having the context, type, subject and schema of an example credential.
+	QueryByExample QueryType = "QueryByExample"
+	// QueryByFrame derives BBS+ selective disclosures of the credentials matching a JSON-LD frame.
+	QueryByFrame QueryType = "QueryByFrame"
+	// PresentationExchange submits the credentials satisfying a presentation definition.
+	PresentationExchange QueryType = "PresentationExchange"
+)
+
+// QueryParams is a query of a verifiable presentation request.
+type QueryParams struct {
+	// Type of the query.
+	Type QueryType `json:"type"`
+	// CredentialQuery is one or a list of credential queries for QueryByExample and QueryByFrame, of the form
+	// {"example": {...}, "trustedIssuer": [...]} and {"frame": {...}, "trustedIssuer": [...]} respectively, or the
+	// presentation definition for PresentationExchange.
+	CredentialQuery json.RawMessage `json:"credentialQuery"`
+}
+
+// QueryOpt configures the query of the wallet credentials.
+type QueryOpt func(opts *queryOpts)
+
+type queryOpts struct {
+	nonce     []byte
+	recipient string
+}
+
+// WithDisclosureNonce sets the nonce of the BBS+ selective disclosures derived by QueryByFrame, typically the
+// challenge of the presentation request.
+func WithDisclosureNonce(nonce []byte) QueryOpt {
+	return func(opts *queryOpts) {
+		opts.nonce = nonce
+	}
+}
+
+// WithQueryRecipient sets who the presentations are for, typically the verifier of the presentation request, as
+// recorded in the audit log.
+func WithQueryRecipient(recipient string) QueryOpt {
+	return func(opts *queryOpts) {
+		opts.recipient = recipient
+	}
+}
+
+// DeriveOpt configures the selective disclosure derived by Derive.
+type DeriveOpt func(opts *deriveOpts)
+
+type deriveOpts struct {
+	nonce     []byte
+	recipient string
+}
+
+// WithDeriveNonce sets the nonce of the selective disclosure derived by Derive, typically the challenge of the
+// verifier.
+func WithDeriveNonce(nonce []byte) DeriveOpt {
+	return func(opts *deriveOpts) {
+		opts.nonce = nonce
+	}
+}
+
+// WithDeriveRecipient sets who the selective disclosure is for, as recorded in the audit log.
+func WithDeriveRecipient(recipient string) DeriveOpt {
+	return func(opts *deriveOpts) {
+		opts.recipient = recipient
+	}
+}
+
+type trustedIssuer struct {
+	Issuer   string `json:"issuer"`
+	Required bool   `json:"required"`
+}
+
+type exampleQuery struct {
+	Example       map[string]interface{} `json:"example"`
+	TrustedIssuer []trustedIssuer        `json:"trustedIssuer"`
+}
+
+type frameQuery struct {
+	Frame         map[string]interface{} `json:"frame"`
+	TrustedIssuer []trustedIssuer        `json:"trustedIssuer"`
+}
+
+// walletCredential is a credential of the wallet with its JSON form, examples being matched against the latter.
+type walletCredential struct {
+	vc  *verifiable.Credential
+	doc map[string]interface{}
+}
+
+// Query runs the queries of a verifiable presentation request over the credentials of the unlocked wallet. The
+// credentials matched by the QueryByExample queries, and the selective disclosures derived by the QueryByFrame
+// queries, are returned in one presentation, followed by a presentation per PresentationExchange query, carrying
+// its presentation submission. The presentations are unsigned. ErrNoQueryResults is returned if a query doesn't
+// match any credential. The credentials presented are recorded in the audit log.
+func (c *Wallet) Query(authToken string, queries []*QueryParams,
+	options ...QueryOpt) ([]*verifiable.Presentation, error) {
+	opts := &queryOpts{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	if len(queries) == 0 {
+		return nil, errors.New("no query")
+	}
+
+	credentials, err := c.credentials(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		matched       []interface{}
+		presentations []*verifiable.Presentation
+	)
+
+	for _, query := range queries {
+		switch query.Type {
+		case QueryByExample:
+			vcs, err := queryByExample(query.CredentialQuery, credentials)
+			if err != nil {
+				return nil, err
+			}
+
+			matched = appendUnique(matched, vcs...)
+		case QueryByFrame:
+			vcs, err := c.queryByFrame(query.CredentialQuery, credentials, opts.nonce)
+			if err != nil {
+				return nil, err
+			}
+
+			matched = append(matched, vcs...)
+		case PresentationExchange:
+			vp, err := queryPresentationExchange(query.CredentialQuery, credentials)
+			if err != nil {
+				return nil, err
+			}
+
+			presentations = append(presentations, vp)
+		default:
+			return nil, fmt.Errorf("unsupported query type %q", query.Type)
+		}
+	}
+
+	if len(matched) > 0 {
+		vp := &verifiable.Presentation{Context: []string{presentationContext}, Type: []string{presentationType}}
+
+		if err := vp.SetCredentials(matched...); err != nil {
+			return nil, fmt.Errorf("failed to create presentation : %w", err)
+		}
+
+		presentations = append([]*verifiable.Presentation{vp}, presentations...)
+	}
+
+	request, err := json.Marshal(queries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal queries : %w", err)
+	}
+
+	err = c.recordDisclosure(authToken, &DisclosureRecord{
+		Operation: QueryOperation,
+		Recipient: opts.recipient,
+		Request:   request,
+	}, credentialsOf(presentations...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return presentations, nil
+}
+
+// appendUnique appends the credentials not in the list yet, a credential matched by several queries being presented
+// once.
+func appendUnique(list []interface{}, vcs ...interface{}) []interface{} {
+	for _, vc := range vcs {
+		found := false
+
+		for _, v := range list {
+			if v == vc {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			list = append(list, vc)
+		}
+	}
+
+	return list
+}
+
+// credentials returns the credentials of the wallet, ordered by ID.
+func (c *Wallet) credentials(authToken string) ([]*walletCredential, error) {
+	contents, err := c.GetAll(authToken, Credential)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(contents))
+
+	for id := range contents {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	credentials := make([]*walletCredential, len(ids))
+
+	for i, id := range ids {
+		vc, err := verifiable.ParseUnverifiedCredential(contents[id], verifiable.WithCache(c.credentialCache))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse wallet credential %s : %w", id, err)
+		}
+
+		credentials[i] = &walletCredential{vc: vc}
+
+		if err := json.Unmarshal(contents[id], &credentials[i].doc); err != nil {
+			return nil, fmt.Errorf("failed to parse wallet credential %s : %w", id, err)
+		}
+	}
+
+	return credentials, nil
+}
+
+func queryByExample(credentialQuery json.RawMessage, credentials []*walletCredential) ([]interface{}, error) {
+	rawQueries, err := credentialQueries(QueryByExample, credentialQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []interface{}
+
+	for _, raw := range rawQueries {
+		var query exampleQuery
+
+		if err := json.Unmarshal(raw, &query); err != nil {
+			return nil, fmt.Errorf("invalid %s query : %w", QueryByExample, err)
+		}
+
+		if query.Example == nil {
+			return nil, fmt.Errorf("invalid %s query : missing example", QueryByExample)
+		}
+
+		count := len(matched)
+
+		for _, credential := range credentials {
+			if trusted(credential.vc, query.TrustedIssuer) && matchesExample(credential.doc, query.Example) {
+				matched = append(matched, credential.vc)
+			}
+		}
+
+		if len(matched) == count {
+			return nil, fmt.Errorf("%s query : %w", QueryByExample, ErrNoQueryResults)
+		}
+	}
+
+	return matched, nil
+}
+
+func (c *Wallet) queryByFrame(credentialQuery json.RawMessage, credentials []*walletCredential,
+	nonce []byte) ([]interface{}, error) {
+	rawQueries, err := credentialQueries(QueryByFrame, credentialQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var derived []interface{}
+
+	for _, raw := range rawQueries {
+		var query frameQuery
+
+		if err := json.Unmarshal(raw, &query); err != nil {
+			return nil, fmt.Errorf("invalid %s query : %w", QueryByFrame, err)
+		}
+
+		if query.Frame == nil {
+			return nil, fmt.Errorf("invalid %s query : missing frame", QueryByFrame)
+		}
+
+		count := len(derived)
+
+		for _, credential := range credentials {
+			// only the credentials signed with BBS+ can be disclosed selectively
+			if !trusted(credential.vc, query.TrustedIssuer) || !bbsSigned(credential.vc) ||
+				!matchesExample(credential.doc, map[string]interface{}{"type": query.Frame["type"]}) {
+				continue
+			}
+
+			vc, err := c.deriveCredential(credential.vc, query.Frame, nonce)
+			if err != nil {
+				return nil, err
+			}
+
+			derived = append(derived, vc)
+		}
+
+		if len(derived) == count {
+			return nil, fmt.Errorf("%s query : %w", QueryByFrame, ErrNoQueryResults)
+		}
+	}
+
+	return derived, nil
+}
+
+// Derive derives the BBS+ selective disclosure of the credential of the unlocked wallet revealing only the fields of
+// the JSON-LD frame, so that holder applications can disclose credentials selectively without handling proofs. The
+// issuer key is resolved with the VDR. ErrNotDerivable is returned for the credentials not signed with BBS+. The
+// selective disclosure is recorded in the audit log.
+//
+// The SD-JWT credentials, whose selective disclosure is made of the disclosures of their claims rather than of a
+// frame, are not supported yet: ErrUnsupportedCredentialFormat is returned for them.
+func (c *Wallet) Derive(authToken, credentialID string, frame map[string]interface{},
+	options ...DeriveOpt) (*verifiable.Credential, error) {
+	opts := &deriveOpts{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	if frame == nil {
+		return nil, errors.New("frame is mandatory")
+	}
+
+	content, err := c.Get(authToken, Credential, credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sdJWTCredential(content) {
+		return nil, fmt.Errorf("credential %s : SD-JWT : %w", credentialID, ErrUnsupportedCredentialFormat)
+	}
+
+	vc, err := verifiable.ParseUnverifiedCredential(content, verifiable.WithCache(c.credentialCache))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse wallet credential %s : %w", credentialID, err)
+	}
+
+	if !bbsSigned(vc) {
+		return nil, fmt.Errorf("credential %s : %w", credentialID, ErrNotDerivable)
+	}
+
+	derived, err := c.deriveCredential(vc, frame, opts.nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := json.Marshal(frame)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal frame : %w", err)
+	}
+
+	err = c.recordDisclosure(authToken, &DisclosureRecord{
+		Operation: DeriveOperation,
+		Recipient: opts.recipient,
+		Request:   request,
+	}, derived)
+	if err != nil {
+		return nil, err
+	}
+
+	return derived, nil
+}
+
+func (c *Wallet) deriveCredential(vc *verifiable.Credential, frame map[string]interface{},
+	nonce []byte) (*verifiable.Credential, error) {
+	if c.vdr == nil {
+		return nil, errors.New("failed to derive credential : no VDR to resolve the issuer key")
+	}
+
+	keyID, _ := vc.Proofs[0]["verificationMethod"].(string) // nolint: errcheck
+	if i := strings.Index(keyID, "#"); i >= 0 {
+		keyID = keyID[i:]
+	}
+
+	pubKey, err := verifiable.NewDIDKeyResolver(c.vdr).PublicKeyFetcher()(vc.Issuer.ID, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive credential %s : %w", vc.ID, err)
+	}
+
+	derived, err := vc.GenerateBBSSelectiveDisclosure(frame, pubKey.Value, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive credential %s : %w", vc.ID, err)
+	}
+
+	return derived, nil
+}
+
+func queryPresentationExchange(credentialQuery json.RawMessage,
+	credentials []*walletCredential) (*verifiable.Presentation, error) {
+	var definition presexch.PresentationDefinition
+
+	if err := json.Unmarshal(credentialQuery, &definition); err != nil {
+		return nil, fmt.Errorf("invalid %s query : %w", PresentationExchange, err)
+	}
+
+	vcs := make([]*verifiable.Credential, len(credentials))
+
+	for i, credential := range credentials {
+		vcs[i] = credential.vc
+	}
+
+	vp, err := definition.CreateVP(vcs...)
+	if errors.Is(err, presexch.ErrNoCredentials) {
+		return nil, fmt.Errorf("%s query : %w : %s", PresentationExchange, ErrNoQueryResults, err)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s query : %w", PresentationExchange, err)
+	}
+
+	return vp, nil
+}
+
+// credentialQueries returns the credential queries of a query, which has one or a list of them.
+func credentialQueries(queryType QueryType, credentialQuery json.RawMessage) ([]json.RawMessage, error) {
+	credentialQuery = bytes.TrimSpace(credentialQuery)
+
+	if len(credentialQuery) == 0 {
+		return nil, fmt.Errorf("invalid %s query : missing credential query", queryType)
+	}
+
+	if credentialQuery[0] != '[' {
+		return []json.RawMessage{credentialQuery}, nil
+	}
+
+	var queries []json.RawMessage
+
+	if err := json.Unmarshal(credentialQuery, &queries); err != nil {
+		return nil, fmt.Errorf("invalid %s query : %w", queryType, err)
+	}
+
+	return queries, nil
+}
+
+// trusted tells whether the credential is issued by one of the required trusted issuers, if any.
+func trusted(vc *verifiable.Credential, issuers []trustedIssuer) bool {
+	required := false
+
+	for _, issuer := range issuers {
+		if !issuer.Required {
+			continue
+		}
+
+		if issuer.Issuer == vc.Issuer.ID {
+			return true
+		}
+
+		required = true
+	}
+
+	return !required
+}
+
+func bbsSigned(vc *verifiable.Credential) bool {
+	return len(vc.Proofs) == 1 && vc.Proofs[0]["type"] == bbsProofType
+}
+
+// sdJWTCredential tells whether the credential is an SD-JWT, the digests of its selectively disclosable claims being
+// in the "_sd" claims and their hash algorithm in the "_sd_alg" claim, of the credential or of its "vc" claim.
+func sdJWTCredential(content json.RawMessage) bool {
+	var claims map[string]json.RawMessage
+
+	if err := json.Unmarshal(content, &claims); err != nil {
+		return false
+	}
+
+	if _, ok := claims["_sd_alg"]; ok {
+		return true
+	}
+
+	if _, ok := claims["_sd"]; ok {
+		return true
+	}
+
+	if vc, ok := claims["vc"]; ok {
+		return sdJWTCredential(vc)
+	}
+
+	return false
+}
+
+// matchesExample tells whether the credential has the fields of the example, other than the trusted issuers.
+func matchesExample(doc, example map[string]interface{}) bool {
+	for k, v := range example {
+		if k == "trustedIssuer" || v == nil {
+			continue
+		}
+
+		if !contains(doc[k], v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// contains tells whether the JSON value has the fields and values of the example value. An array contains the
+// values contained by one of its elements, and all the elements of an example array.
+func contains(value, example interface{}) bool {
+	if values, ok := value.([]interface{}); ok {
+		if _, ok := example.([]interface{}); !ok {
+			for _, v := range values {
+				if contains(v, example) {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	switch e := example.(type) {
+	case map[string]interface{}:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for k, v := range e {
+			if !contains(m[k], v) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		for _, v := range e {
+			if !contains(value, v) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(value, example)
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const (
+	sampleUniversityDegree = `{
+		"@context": ["https://www.w3.org/2018/credentials/v1", "https://www.w3.org/2018/credentials/examples/v1"],
+		"id": "http://example.edu/credentials/3732",
+		"type": ["VerifiableCredential", "UniversityDegreeCredential"],
+		"issuer": "did:example:university",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"credentialSubject": {
+			"id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"degree": {"type": "BachelorDegree", "name": "Bachelor of Science and Arts"}
+		}
+	}`
+	sampleBBSCredential = `{
+		"@context": ["https://www.w3.org/2018/credentials/v1", "https://w3id.org/citizenship/v1"],
+		"id": "https://issuer.oidp.uscis.gov/credentials/83627465",
+		"type": ["VerifiableCredential", "PermanentResidentCard"],
+		"issuer": "did:example:489398593",
+		"issuanceDate": "2019-12-03T12:19:52Z",
+		"credentialSubject": {"id": "did:example:b34ca6cd37bbf23", "givenName": "JOHN"},
+		"proof": {
+			"type": "BbsBlsSignature2020",
+			"created": "2020-12-06T19:23:10Z",
+			"proofPurpose": "assertionMethod",
+			"proofValue": "qPrB+1BLsVSeOo1ci8dMF+iR6aa5Q6iwV/VzXo2dw94ctgnQGxaUgwb8Hd68IiYTVabQXR+ZPuwJA//GOv1OwXRHkHqXg9xPsl8HcaXaoWERanxYClgHCfy4j76Vudr14U5AhT3v8k8f0oZD+zBIUQ==",
+			"verificationMethod": "did:example:489398593#test"
+		}
+	}`
+)
+
+func TestWallet_Query(t *testing.T) {
+	wallet := newWallet(t, newProvider())
+	token := open(t, wallet, samplePassphrase)
+
+	require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleCredential)))
+	require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleUniversityDegree)))
+
+	t.Run("query by example", func(t *testing.T) {
+		results, err := wallet.Query(token, []*QueryParams{{
+			Type: QueryByExample,
+			CredentialQuery: json.RawMessage(`{
+				"reason": "Please present your university degree.",
+				"example": {
+					"@context": "https://www.w3.org/2018/credentials/examples/v1",
+					"type": ["UniversityDegreeCredential"],
+					"credentialSubject": {"degree": {"type": "BachelorDegree"}}
+				}
+			}`),
+		}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, []string{"https://www.w3.org/2018/credentials/v1"}, results[0].Context)
+		require.Len(t, results[0].Credentials(), 1)
+		require.Equal(t, "http://example.edu/credentials/3732", credentialID(t, results[0].Credentials()[0]))
+
+		// both credentials are issued to the subject, one of them by the trusted issuer, and are presented once
+		results, err = wallet.Query(token, []*QueryParams{{
+			Type: QueryByExample,
+			CredentialQuery: json.RawMessage(`[{
+				"example": {"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}}
+			}, {
+				"example": {"type": "VerifiableCredential"},
+				"trustedIssuer": [{"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f", "required": true}]
+			}]`),
+		}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Len(t, results[0].Credentials(), 2)
+		require.Equal(t, "http://example.edu/credentials/1872", credentialID(t, results[0].Credentials()[0]))
+
+		_, err = wallet.Query(token, []*QueryParams{{
+			Type:            QueryByExample,
+			CredentialQuery: json.RawMessage(`{"example": {"type": "DriversLicense"}}`),
+		}})
+		require.True(t, errors.Is(err, ErrNoQueryResults))
+	})
+
+	t.Run("query with cache", func(t *testing.T) {
+		provider := newProvider()
+		provider.CacheValue = cache.New()
+
+		cachingWallet := newWallet(t, provider)
+		cachingToken := open(t, cachingWallet, samplePassphrase)
+
+		require.NoError(t, cachingWallet.Add(cachingToken, Credential, json.RawMessage(sampleCredential)))
+
+		query := []*QueryParams{{
+			Type:            QueryByExample,
+			CredentialQuery: json.RawMessage(`{"example": {"type": "VerifiableCredential"}}`),
+		}}
+
+		for i := 0; i < 2; i++ {
+			results, err := cachingWallet.Query(cachingToken, query)
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.Len(t, results[0].Credentials(), 1)
+		}
+
+		require.Equal(t, 1, provider.CacheValue.Len())
+	})
+
+	t.Run("query by frame", func(t *testing.T) {
+		frameQuery := []*QueryParams{{
+			Type: QueryByFrame,
+			CredentialQuery: json.RawMessage(`{
+				"frame": {
+					"@context": ["https://www.w3.org/2018/credentials/v1", "https://w3id.org/citizenship/v1"],
+					"type": ["VerifiableCredential", "PermanentResidentCard"],
+					"credentialSubject": {"@explicit": true, "givenName": {}}
+				}
+			}`),
+		}}
+
+		// the credentials without BBS+ signature can't be disclosed selectively
+		_, err := wallet.Query(token, frameQuery)
+		require.True(t, errors.Is(err, ErrNoQueryResults))
+
+		require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleBBSCredential)))
+		defer func() {
+			require.NoError(t, wallet.Remove(token, Credential, "https://issuer.oidp.uscis.gov/credentials/83627465"))
+		}()
+
+		_, err = wallet.Query(token, frameQuery, WithDisclosureNonce([]byte("nonce")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no VDR to resolve the issuer key")
+
+		wallet.vdr = &mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")}
+		defer func() { wallet.vdr = nil }()
+
+		_, err = wallet.Query(token, frameQuery)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve error")
+	})
+
+	t.Run("presentation exchange", func(t *testing.T) {
+		results, err := wallet.Query(token, []*QueryParams{{
+			Type: QueryByExample, CredentialQuery: json.RawMessage(`{"example": {"type": "VerifiableCredential"}}`),
+		}, {
+			Type: PresentationExchange,
+			CredentialQuery: json.RawMessage(`{
+				"id": "degree-definition",
+				"input_descriptors": [{
+					"id": "degree",
+					"schema": [{"uri": "https://www.w3.org/2018/credentials/examples/v1"}]
+				}]
+			}`),
+		}})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.Len(t, results[0].Credentials(), 2)
+		require.Len(t, results[1].Credentials(), 1)
+		require.Contains(t, results[1].CustomFields, "presentation_submission")
+
+		_, err = wallet.Query(token, []*QueryParams{{
+			Type: PresentationExchange,
+			CredentialQuery: json.RawMessage(`{
+				"id": "license-definition",
+				"input_descriptors": [{"id": "license", "schema": [{"uri": "https://example.org/license/v1"}]}]
+			}`),
+		}})
+		require.True(t, errors.Is(err, ErrNoQueryResults))
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		tests := []struct {
+			query *QueryParams
+			err   string
+		}{
+			{&QueryParams{Type: "QueryByMagic"}, `unsupported query type "QueryByMagic"`},
+			{&QueryParams{Type: QueryByExample}, "invalid QueryByExample query : missing credential query"},
+			{&QueryParams{Type: QueryByExample, CredentialQuery: json.RawMessage(`{}`)}, "missing example"},
+			{&QueryParams{Type: QueryByExample, CredentialQuery: json.RawMessage(`[1]`)}, "invalid QueryByExample"},
+			{&QueryParams{Type: QueryByFrame, CredentialQuery: json.RawMessage(`{}`)}, "missing frame"},
+			{&QueryParams{Type: QueryByFrame, CredentialQuery: json.RawMessage(`"frame"`)}, "invalid QueryByFrame"},
+			{&QueryParams{Type: PresentationExchange, CredentialQuery: json.RawMessage(`[]`)}, "invalid PresentationExchange"},
+		}
+
+		for _, tc := range tests {
+			_, err := wallet.Query(token, []*QueryParams{tc.query})
+			require.Error(t, err, tc.err)
+			require.Contains(t, err.Error(), tc.err)
+		}
+
+		_, err := wallet.Query(token, nil)
+		require.EqualError(t, err, "no query")
+	})
+
+	t.Run("locked wallet", func(t *testing.T) {
+		locked := newWallet(t, newProvider())
+
+		_, err := locked.Query("", []*QueryParams{{Type: QueryByExample}})
+		require.True(t, errors.Is(err, ErrWalletLocked))
+	})
+}
+
+func TestWallet_Derive(t *testing.T) {
+	wallet := newWallet(t, newProvider())
+	token := open(t, wallet, samplePassphrase)
+
+	require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleCredential)))
+	require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleBBSCredential)))
+
+	frame := map[string]interface{}{
+		"@context":          []interface{}{"https://www.w3.org/2018/credentials/v1", "https://w3id.org/citizenship/v1"},
+		"type":              []interface{}{"VerifiableCredential", "PermanentResidentCard"},
+		"credentialSubject": map[string]interface{}{"@explicit": true, "givenName": map[string]interface{}{}},
+	}
+
+	t.Run("derive the selective disclosure", func(t *testing.T) {
+		_, err := wallet.Derive(token, "https://issuer.oidp.uscis.gov/credentials/83627465", frame,
+			WithDeriveNonce([]byte("nonce")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no VDR to resolve the issuer key")
+
+		wallet.vdr = &mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")}
+		defer func() { wallet.vdr = nil }()
+
+		_, err = wallet.Derive(token, "https://issuer.oidp.uscis.gov/credentials/83627465", frame)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve error")
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		_, err := wallet.Derive(token, "http://example.edu/credentials/1872", frame)
+		require.True(t, errors.Is(err, ErrNotDerivable))
+
+		_, err = wallet.Derive(token, "http://example.edu/credentials/unknown", frame)
+		require.True(t, errors.Is(err, ErrContentNotFound))
+
+		require.NoError(t, wallet.Add(token, Credential, []byte(`{
+			"id": "http://example.edu/credentials/sd-jwt",
+			"iss": "https://example.edu/issuers/14",
+			"_sd_alg": "sha-256",
+			"_sd": ["CrQe7S5kqBAHt-nMYXgc6bdt2SH5aTY1sU_M-PgkjPI"]
+		}`)))
+
+		_, err = wallet.Derive(token, "http://example.edu/credentials/sd-jwt", frame)
+		require.True(t, errors.Is(err, ErrUnsupportedCredentialFormat))
+		require.Contains(t, err.Error(), "SD-JWT")
+
+		_, err = wallet.Derive(token, "http://example.edu/credentials/1872", nil)
+		require.EqualError(t, err, "frame is mandatory")
+	})
+
+	t.Run("locked wallet", func(t *testing.T) {
+		locked := newWallet(t, newProvider())
+
+		_, err := locked.Derive("", "http://example.edu/credentials/1872", frame)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+	})
+}
+
+func credentialID(t *testing.T, credential interface{}) string {
+	t.Helper()
+
+	vc, ok := credential.(*verifiable.Credential)
+	require.True(t, ok)
+
+	return vc.ID
+}
+
+func TestSDJWTCredential(t *testing.T) {
+	require.True(t, sdJWTCredential([]byte(`{"_sd_alg": "sha-256"}`)))
+	require.True(t, sdJWTCredential([]byte(`{"_sd": ["digest"]}`)))
+	require.True(t, sdJWTCredential([]byte(`{"vc": {"credentialSubject": {}, "_sd": ["digest"]}}`)))
+	require.False(t, sdJWTCredential([]byte(`{"vc": {"credentialSubject": {}}}`)))
+	require.False(t, sdJWTCredential([]byte(`{"id": "http://example.edu/credentials/1872"}`)))
+	require.False(t, sdJWTCredential([]byte(`"eyJhbGciOiJFUzI1NiJ9"`)))
+}
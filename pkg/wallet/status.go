@@ -0,0 +1,220 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/revocationnotification"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// revocationEventBuffer is the number of revocation notifications buffered while the credentials are scanned.
+	revocationEventBuffer = 16
+)
+
+// CredentialFlag flags the wallet credentials that their status list marks as revoked or suspended.
+type CredentialFlag string
+
+// credential flags.
+const (
+	// FlagRevoked flags a revoked credential.
+	FlagRevoked CredentialFlag = "revoked"
+	// FlagSuspended flags a suspended credential.
+	FlagSuspended CredentialFlag = "suspended"
+)
+
+// msgEventService is the revocation notification protocol service, whose revoke messages trigger a status check.
+type msgEventService interface {
+	RegisterMsgEvent(ch chan<- service.StateMsg) error
+	UnregisterMsgEvent(ch chan<- service.StateMsg) error
+}
+
+// CredentialFlags returns the flags of the credentials of the unlocked wallet which the credential monitor found
+// revoked or suspended, by credential ID, for the user interfaces to badge them.
+func (c *Wallet) CredentialFlags(authToken string) (map[string]CredentialFlag, error) {
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := c.contentMetaKey(Credential, "")
+
+	iter := c.store.Iterator(prefix, prefix+storage.EndKeySuffix)
+	defer iter.Release()
+
+	flags := make(map[string]CredentialFlag)
+
+	for iter.Next() {
+		meta, err := decryptContentMeta(aead, string(iter.Key()), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		if meta.Flag != "" && !meta.Removed {
+			flags[strings.TrimPrefix(string(iter.Key()), prefix)] = meta.Flag
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to get wallet content metadata : %w", err)
+	}
+
+	return flags, nil
+}
+
+// checkStatus checks the StatusList2021 status of the credential, flagging it and notifying the user when it changes.
+// It returns the flag of the credential.
+func (c *Wallet) checkStatus(aead cipher.AEAD, m *credentialMonitor, verifier verifiable.StatusVerifier, id string,
+	vc *verifiable.Credential) CredentialFlag {
+	meta, err := c.getContentMeta(aead, Credential, id)
+	if err != nil {
+		logger.Warnf("failed to get wallet credential %s metadata : %s", id, err)
+
+		return ""
+	}
+
+	flag, err := credentialStatus(verifier, vc)
+	if err != nil {
+		// the user is notified once of the failures, until a check succeeds
+		if !m.statusFailed[id] {
+			m.statusFailed[id] = true
+			c.sendCredentialEvent(m, &CredentialEvent{Type: CredentialStatusCheckFailed, CredentialID: id, Err: err})
+		}
+
+		return meta.Flag
+	}
+
+	delete(m.statusFailed, id)
+
+	if flag == meta.Flag {
+		return flag
+	}
+
+	previous := meta.Flag
+	meta.Flag = flag
+
+	if err := c.putContentMeta(aead, c.contentMetaKey(Credential, id), meta); err != nil {
+		logger.Warnf("failed to flag wallet credential %s : %s", id, err)
+
+		return previous
+	}
+
+	event := &CredentialEvent{CredentialID: id}
+
+	switch flag {
+	case FlagRevoked:
+		event.Type = CredentialRevoked
+	case FlagSuspended:
+		event.Type = CredentialSuspended
+	default:
+		event.Type = CredentialReinstated
+	}
+
+	c.sendCredentialEvent(m, event)
+
+	return flag
+}
+
+// credentialStatus returns the flag of the credential in its status list, empty if it's neither revoked nor
+// suspended.
+func credentialStatus(verifier verifiable.StatusVerifier, vc *verifiable.Credential) (CredentialFlag, error) {
+	err := verifier.VerifyStatus(vc)
+
+	switch {
+	case err == nil:
+		return "", nil
+	case errors.Is(err, verifiable.ErrRevoked):
+		return FlagRevoked, nil
+	case errors.Is(err, verifiable.ErrSuspended):
+		return FlagSuspended, nil
+	default:
+		return "", err
+	}
+}
+
+// statusVerifier returns the verifier of the credential statuses of a scan, fetching each status list once. The
+// proofs of the status lists aren't verified: the flags notify the user, the verifiers check the statuses themselves.
+// The status lists are validated against the base context, the wallet having no JSON-LD document loader.
+func (c *Wallet) statusVerifier(m *credentialMonitor) verifiable.StatusVerifier {
+	fetch := verifiable.HTTPStatusListFetcher(m.opts.httpClient)
+	lists := make(map[string][]byte)
+
+	return verifiable.NewStatusList2021Verifier(
+		verifiable.WithStatusListFetcher(func(listURL string) ([]byte, error) {
+			if raw, ok := lists[listURL]; ok {
+				return raw, nil
+			}
+
+			raw, err := fetch(listURL)
+			if err != nil {
+				return nil, err
+			}
+
+			lists[listURL] = raw
+
+			return raw, nil
+		}),
+		verifiable.WithStatusListCredentialOpts(verifiable.WithDisabledProofCheck(),
+			verifiable.WithBaseContextExtendedValidation([]string{verifiable.StatusList2021Context},
+				[]string{verifiable.StatusList2021CredentialType})),
+	)
+}
+
+// watchRevocationNotifications triggers a scan of the credentials when the revocation notification service of the
+// agent, if any, receives a revoke message, until the monitor stops.
+func (c *Wallet) watchRevocationNotifications(m *credentialMonitor) {
+	raw, err := c.ctx.Service(revocationnotification.Name)
+	if err != nil {
+		logger.Debugf("no revocation notification service : %s", err)
+
+		return
+	}
+
+	svc, ok := raw.(msgEventService)
+	if !ok {
+		return
+	}
+
+	events := make(chan service.StateMsg, revocationEventBuffer)
+
+	if err := svc.RegisterMsgEvent(events); err != nil {
+		logger.Warnf("failed to register revocation notification events : %s", err)
+
+		return
+	}
+
+	go func() {
+		defer func() {
+			if err := svc.UnregisterMsgEvent(events); err != nil {
+				logger.Warnf("failed to unregister revocation notification events : %s", err)
+			}
+		}()
+
+		for {
+			select {
+			case msg := <-events:
+				if msg.StateID != revocationnotification.StateRevoked {
+					continue
+				}
+
+				select {
+				case m.rescan <- struct{}{}:
+				default:
+				}
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
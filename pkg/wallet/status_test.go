@@ -0,0 +1,311 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/revocationnotification"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const sampleStatusIssuer = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+func TestWallet_CredentialStatus(t *testing.T) {
+	t.Run("flag the revoked and suspended credentials", func(t *testing.T) {
+		issuer := newStatusListIssuer(t)
+		defer issuer.Close()
+
+		issuer.set("revocation", 3)
+		issuer.set("suspension", 5)
+
+		wallet := newWallet(t, newProvider())
+		token := open(t, wallet, samplePassphrase)
+
+		// the revoked credential expiring soon isn't refreshed nor notified as expiring
+		require.NoError(t, wallet.Add(token, Credential, issuer.credential("urn:vc:1", "revocation", "3", time.Hour)))
+		require.NoError(t, wallet.Add(token, Credential, issuer.credential("urn:vc:2", "suspension", "5", 0)))
+		require.NoError(t, wallet.Add(token, Credential, issuer.credential("urn:vc:3", "revocation", "4", 0)))
+		require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleCredential)))
+
+		events := make(chan CredentialEvent)
+		require.NoError(t, wallet.RegisterCredentialEvent(events))
+		require.NoError(t, wallet.StartMonitor(token, WithScanInterval(10*time.Millisecond),
+			WithStatusHTTPClient(issuer.Client())))
+
+		defer wallet.StopMonitor()
+
+		event := nextEvent(t, events)
+		require.Equal(t, CredentialRevoked, event.Type)
+		require.Equal(t, "urn:vc:1", event.CredentialID)
+
+		event = nextEvent(t, events)
+		require.Equal(t, CredentialSuspended, event.Type)
+		require.Equal(t, "urn:vc:2", event.CredentialID)
+
+		flags, err := wallet.CredentialFlags(token)
+		require.NoError(t, err)
+		require.Equal(t, map[string]CredentialFlag{"urn:vc:1": FlagRevoked, "urn:vc:2": FlagSuspended}, flags)
+
+		// the suspension is lifted
+		issuer.set("suspension")
+
+		event = nextEvent(t, events)
+		require.Equal(t, CredentialReinstated, event.Type)
+		require.Equal(t, "urn:vc:2", event.CredentialID)
+
+		// the user is notified once
+		select {
+		case event = <-events:
+			require.Fail(t, "unexpected credential event", event)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		flags, err = wallet.CredentialFlags(token)
+		require.NoError(t, err)
+		require.Equal(t, map[string]CredentialFlag{"urn:vc:1": FlagRevoked}, flags)
+
+		// the flags are kept with the other metadata of the credentials
+		contents, err := wallet.GetAll(token, Credential)
+		require.NoError(t, err)
+		require.Len(t, contents, 4)
+
+		_, err = wallet.CredentialFlags("unknown")
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+	})
+
+	t.Run("check the statuses on revocation notifications", func(t *testing.T) {
+		issuer := newStatusListIssuer(t)
+		defer issuer.Close()
+
+		notifications, err := revocationnotification.New(&mockprovider.Provider{})
+		require.NoError(t, err)
+
+		wallet := newWallet(t, &mockprovider.Provider{
+			StorageProviderValue: mem.NewProvider(),
+			ServiceMap:           map[string]interface{}{revocationnotification.Name: notifications},
+		})
+		token := open(t, wallet, samplePassphrase)
+
+		require.NoError(t, wallet.Add(token, Credential, issuer.credential("urn:vc:1", "revocation", "7", 0)))
+
+		events := make(chan CredentialEvent)
+		require.NoError(t, wallet.RegisterCredentialEvent(events))
+		require.NoError(t, wallet.StartMonitor(token, WithStatusHTTPClient(issuer.Client())))
+
+		// the first scan finds the credential valid, the next one is due in an hour
+		require.Eventually(t, func() bool { return issuer.requests() > 0 }, time.Second, 10*time.Millisecond)
+
+		issuer.set("revocation", 7)
+
+		_, err = notifications.HandleInbound(service.NewDIDCommMsgMap(&revocationnotification.Revoke{
+			Type: revocationnotification.RevokeMsgType, CredentialID: "urn:vc:1",
+		}), "my-did", "their-did")
+		require.NoError(t, err)
+
+		event := nextEvent(t, events)
+		require.Equal(t, CredentialRevoked, event.Type)
+		require.Equal(t, "urn:vc:1", event.CredentialID)
+
+		require.True(t, wallet.StopMonitor())
+
+		// the monitor doesn't listen to the notifications anymore
+		require.Eventually(t, func() bool { return len(notifications.MsgEvents()) == 0 }, time.Second,
+			10*time.Millisecond)
+	})
+
+	t.Run("status check errors", func(t *testing.T) {
+		issuer := newStatusListIssuer(t)
+		defer issuer.Close()
+
+		wallet := newWallet(t, newProvider())
+		token := open(t, wallet, samplePassphrase)
+
+		for id, status := range map[string]string{
+			"urn:vc:1": `{"type": "StatusList2021Entry", "statusListCredential": %q, "statusPurpose": "revocation"}`,
+			"urn:vc:2": `{"type": "StatusList2021Entry", "statusListIndex": "1", "statusListCredential": %q,
+				"statusPurpose": "other"}`,
+			"urn:vc:3": `{"type": "StatusList2021Entry", "statusListIndex": "1", "statusListCredential": "%s/unknown",
+				"statusPurpose": "revocation"}`,
+			"urn:vc:4": `{"type": "StatusList2021Entry", "statusListIndex": 99999999, "statusListCredential": %q,
+				"statusPurpose": "revocation"}`,
+			"urn:vc:5": `{"type": "StatusList2021Entry", "statusListIndex": "1", "statusListCredential": %q,
+				"statusPurpose": "suspension"}`,
+			"urn:vc:7": `{"type": "StatusList2021Entry", "statusListIndex": "1", "statusListCredential": %q}`,
+			"urn:vc:8": `{"type": "StatusList2021Entry", "statusListIndex": "1", "statusListCredential": %q,
+				"statusPurpose": 1}`,
+		} {
+			listURL := issuer.URL + "/revocation"
+			if strings.Contains(status, "/unknown") {
+				listURL = issuer.URL
+			}
+
+			require.NoError(t, wallet.Add(token, Credential, statusCredential(id, sampleStatusIssuer,
+				fmt.Sprintf(status, listURL), 0)))
+		}
+
+		// the status list of another issuer is rejected
+		require.NoError(t, wallet.Add(token, Credential, statusCredential("urn:vc:6", "did:example:other",
+			fmt.Sprintf(`{"type": "StatusList2021Entry", "statusListIndex": "1", "statusListCredential": %q,
+				"statusPurpose": "revocation"}`, issuer.URL+"/revocation"), 0)))
+
+		events := make(chan CredentialEvent)
+		require.NoError(t, wallet.RegisterCredentialEvent(events))
+		require.NoError(t, wallet.StartMonitor(token, WithScanInterval(10*time.Millisecond),
+			WithStatusHTTPClient(issuer.Client())))
+
+		defer wallet.StopMonitor()
+
+		for _, expected := range []string{
+			"missing status list index",
+			`unsupported status purpose "other"`,
+			"fetch status list credential",
+			"status list index 99999999 out of range",
+			"is for revocation, not suspension",
+			"is not issued by did:example:other",
+			"missing status purpose",
+			"status purpose 1 isn't a string",
+		} {
+			event := nextEvent(t, events)
+			require.Equal(t, CredentialStatusCheckFailed, event.Type)
+			require.Contains(t, event.Err.Error(), expected)
+		}
+
+		select {
+		case event := <-events:
+			require.Fail(t, "unexpected credential event", event)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
+// statusListIssuer serves the revocation and suspension status lists of its credentials.
+type statusListIssuer struct {
+	*httptest.Server
+	t     *testing.T
+	lock  sync.Mutex
+	lists map[string][]int
+	count int
+}
+
+func newStatusListIssuer(t *testing.T) *statusListIssuer {
+	t.Helper()
+
+	issuer := &statusListIssuer{t: t, lists: make(map[string][]int)}
+
+	issuer.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		purpose := strings.TrimPrefix(r.URL.Path, "/")
+		if purpose != "revocation" && purpose != "suspension" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		issuer.lock.Lock()
+		indexes := issuer.lists[purpose]
+		issuer.count++
+		issuer.lock.Unlock()
+
+		_, err := fmt.Fprintf(w, `{
+			"@context": ["https://www.w3.org/2018/credentials/v1", "https://w3id.org/vc/status-list/2021/v1"],
+			"id": "%s%s",
+			"type": ["VerifiableCredential", "StatusList2021Credential"],
+			"issuer": %q,
+			"issuanceDate": "2021-04-05T14:27:40Z",
+			"credentialSubject": {
+				"id": "%s%s#list",
+				"type": "StatusList2021",
+				"statusPurpose": %q,
+				"encodedList": %q
+			}
+		}`, issuer.URL, r.URL.Path, sampleStatusIssuer, issuer.URL, r.URL.Path, purpose,
+			encodeStatusList(t, indexes...))
+		require.NoError(t, err)
+	}))
+
+	return issuer
+}
+
+// set sets the indexes of the status list of the purpose, the other indexes being cleared.
+func (i *statusListIssuer) set(purpose string, indexes ...int) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.lists[purpose] = indexes
+}
+
+func (i *statusListIssuer) requests() int {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return i.count
+}
+
+// credential returns a credential whose status is at the index of the status list of the purpose, expiring after
+// the given duration if any.
+func (i *statusListIssuer) credential(id, purpose, index string, expiresIn time.Duration) json.RawMessage {
+	status := fmt.Sprintf(`{"id": "%s/%s#%s", "type": %q, "statusPurpose": %q, "statusListIndex": %q,
+		"statusListCredential": "%s/%s"}`, i.URL, purpose, index, verifiable.StatusList2021EntryType, purpose, index, i.URL, purpose)
+
+	return statusCredential(id, sampleStatusIssuer, status, expiresIn)
+}
+
+func statusCredential(id, issuer, status string, expiresIn time.Duration) json.RawMessage {
+	var expiration string
+
+	if expiresIn != 0 {
+		expiration = fmt.Sprintf(`"expirationDate": %q,`, time.Now().Add(expiresIn).UTC().Format(time.RFC3339))
+	}
+
+	return json.RawMessage(fmt.Sprintf(`{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"id": %q,
+		"type": ["VerifiableCredential"],
+		"issuer": %q,
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		%s
+		"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+		"credentialStatus": %s
+	}`, id, issuer, expiration, status))
+}
+
+// encodeStatusList returns the encoded list of a 16KB bitstring with the given indexes set.
+func encodeStatusList(t *testing.T, indexes ...int) string {
+	t.Helper()
+
+	bits := make([]byte, 16*1024)
+
+	for _, index := range indexes {
+		bits[index/8] |= 1 << (7 - uint(index%8))
+	}
+
+	var compressed bytes.Buffer
+
+	writer := gzip.NewWriter(&compressed)
+
+	_, err := writer.Write(bits)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return base64.RawURLEncoding.EncodeToString(compressed.Bytes())
+}
@@ -0,0 +1,1297 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/tink/go/subtle/random"
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// SyncServiceName is the name of the message service of the wallet sync protocol.
+	SyncServiceName = "wallet-sync"
+
+	syncProtocol = "https://didcomm.org/wallet-sync/1.0/"
+	// SyncJoinMsgType is the message a device joining the wallet sends with the pairing code, see JoinDevice.
+	SyncJoinMsgType = syncProtocol + "join"
+	// SyncPairMsgType is the answer to the join message, with the sync key encrypted with the pairing code.
+	SyncPairMsgType = syncProtocol + "pair"
+	// SyncChangesMsgType is the message with the content changes of a device, encrypted with the sync key.
+	SyncChangesMsgType = syncProtocol + "changes"
+
+	syncDevicesKeyPrefix = "syncdevices_"
+	syncSecretsKeyPrefix = "syncsecrets_"
+	syncInboxKeyPrefix   = "syncinbox_"
+	pairingCodeSize      = 16
+
+	// defaultPairingTimeout is how long a pairing code is valid by default.
+	defaultPairingTimeout = 10 * time.Minute
+)
+
+// states of the paired devices.
+const (
+	// devicePairing is a device given a pairing code, whose join message is awaited.
+	devicePairing = "pairing"
+	// deviceJoining is a device joined with a pairing code, whose pair message is awaited.
+	deviceJoining = "joining"
+	devicePaired  = "paired"
+)
+
+// wallet sync errors.
+var (
+	// ErrSyncDisabled is returned when pairing a wallet whose sync isn't enabled, see EnableSync.
+	ErrSyncDisabled = errors.New("wallet sync is not enabled")
+	// ErrWalletSynced is returned when joining a wallet already synchronized with other devices to another device.
+	ErrWalletSynced = errors.New("wallet is already synchronized with other devices")
+	// ErrInvalidPairingCode is returned when the pairing code of a device is wrong or expired.
+	ErrInvalidPairingCode = errors.New("invalid pairing code")
+
+	// errSyncPending is returned for the sync messages which can't be processed until a pairing completes.
+	errSyncPending = errors.New("pairing pending")
+)
+
+// SyncService is the message service of the wallet sync protocol, through which the wallets synchronize their
+// contents with the other devices of their users. It's registered with the message service provider of the agent,
+// typically a msghandler.Registrar, and routes the sync messages to the wallets enabled with EnableSync by the
+// connection they are received on.
+type SyncService struct {
+	messenger service.Messenger
+	wallets   []*Wallet
+	lock      sync.RWMutex
+}
+
+// NewSyncService returns the wallet sync service, sending the sync messages with the messenger of the agent.
+func NewSyncService(messenger service.Messenger) *SyncService {
+	return &SyncService{messenger: messenger}
+}
+
+// Name of the wallet sync service.
+func (s *SyncService) Name() string {
+	return SyncServiceName
+}
+
+// Accept accepts the messages of the wallet sync protocol.
+func (s *SyncService) Accept(msgType string, _ []string) bool {
+	return strings.HasPrefix(msgType, syncProtocol)
+}
+
+// HandleInbound keeps the sync message in the inbox of the wallet paired on the connection, and processes the inbox if
+// the wallet is unlocked. The messages received while the wallet is locked are processed when it's unlocked.
+func (s *SyncService) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	w, err := s.wallet(myDID, theirDID)
+	if err != nil {
+		return "", err
+	}
+
+	m := &syncMessage{}
+
+	if err := msg.Decode(m); err != nil {
+		return "", fmt.Errorf("failed to decode wallet sync message : %w", err)
+	}
+
+	if err := w.receiveSyncMessage(&inboxMessage{MyDID: myDID, TheirDID: theirDID, Message: m}); err != nil {
+		return "", err
+	}
+
+	w.processSyncInbox()
+
+	return "", nil
+}
+
+func (s *SyncService) register(w *Wallet) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, registered := range s.wallets {
+		if registered == w {
+			return
+		}
+	}
+
+	s.wallets = append(s.wallets, w)
+}
+
+// wallet returns the wallet paired with a device on the connection.
+func (s *SyncService) wallet(myDID, theirDID string) (*Wallet, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, w := range s.wallets {
+		devices, err := w.getSyncDevices()
+		if err != nil {
+			return nil, err
+		}
+
+		if devices.find(myDID, theirDID) != nil {
+			return w, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no wallet paired on the connection of %s and %s", myDID, theirDID)
+}
+
+// syncMessage is a message of the wallet sync protocol. The binary fields are base64 encoded.
+type syncMessage struct {
+	ID   string `json:"@id"`
+	Type string `json:"@type"`
+	// DeviceID is the ID of the sending device
+	DeviceID string `json:"deviceID"`
+	// Salt expands the pairing code into the key encrypting the Proof of the join messages, authenticating the device
+	// ID, and the sync Key of the pair messages
+	Salt  string `json:"salt,omitempty"`
+	Proof string `json:"proof,omitempty"`
+	Key   string `json:"key,omitempty"`
+	// Nonce and Changes are the changes of the changes messages, encrypted with the sync key
+	Nonce   string `json:"nonce,omitempty"`
+	Changes string `json:"changes,omitempty"`
+}
+
+// inboxMessage is a sync message received, kept in the inbox of the wallet until it's processed.
+type inboxMessage struct {
+	MyDID    string       `json:"myDID"`
+	TheirDID string       `json:"theirDID"`
+	Message  *syncMessage `json:"message"`
+}
+
+// outboxMessage is a sync message to send, once the locks are released.
+type outboxMessage struct {
+	myDID    string
+	theirDID string
+	message  *syncMessage
+}
+
+// syncChange is the change of a wallet content sent to the paired devices, its removal if Removed.
+type syncChange struct {
+	Type       ContentType     `json:"type"`
+	ID         string          `json:"id"`
+	Content    json.RawMessage `json:"content,omitempty"`
+	Collection string          `json:"collection,omitempty"`
+	Tags       []string        `json:"tags,omitempty"`
+	Clock      vectorClock     `json:"clock"`
+	Device     string          `json:"device"`
+	Removed    bool            `json:"removed,omitempty"`
+}
+
+func newSyncChange(contentType ContentType, id string, content json.RawMessage, meta *contentMeta) *syncChange {
+	return &syncChange{
+		Type:       contentType,
+		ID:         id,
+		Content:    content,
+		Collection: meta.Collection,
+		Tags:       meta.Tags,
+		Clock:      meta.Clock,
+		Device:     meta.Device,
+		Removed:    meta.Removed,
+	}
+}
+
+// vectorClock counts the changes of a wallet content made by each device of the user, by device ID.
+type vectorClock map[string]uint64
+
+type clockOrder int
+
+const (
+	clockEqual clockOrder = iota
+	clockBefore
+	clockAfter
+	clockConcurrent
+)
+
+// compare tells whether the clock is equal to, before, after or concurrent with the other clock.
+func (v vectorClock) compare(other vectorClock) clockOrder {
+	var before, after bool
+
+	for device, n := range v {
+		if n > other[device] {
+			after = true
+		}
+	}
+
+	for device, n := range other {
+		if n > v[device] {
+			before = true
+		}
+	}
+
+	switch {
+	case before && after:
+		return clockConcurrent
+	case before:
+		return clockBefore
+	case after:
+		return clockAfter
+	default:
+		return clockEqual
+	}
+}
+
+// merge returns the clock after both clocks.
+func (v vectorClock) merge(other vectorClock) vectorClock {
+	merged := make(vectorClock, len(v))
+
+	for device, n := range v {
+		merged[device] = n
+	}
+
+	for device, n := range other {
+		if n > merged[device] {
+			merged[device] = n
+		}
+	}
+
+	return merged
+}
+
+// tick returns the clock of a change of the device.
+func (v vectorClock) tick(deviceID string) vectorClock {
+	ticked := v.merge(nil)
+	ticked[deviceID]++
+
+	return ticked
+}
+
+// syncDevices are the ID of the device of the wallet and the devices it's paired with. They're kept in clear, so that
+// the sync messages can be routed to the wallet while it's locked.
+type syncDevices struct {
+	DeviceID string        `json:"deviceID"`
+	Devices  []*syncDevice `json:"devices,omitempty"`
+}
+
+// syncDevice is a device of the user, paired on the connection of MyDID and TheirDID.
+type syncDevice struct {
+	MyDID    string `json:"myDID"`
+	TheirDID string `json:"theirDID"`
+	DeviceID string `json:"deviceID,omitempty"`
+	State    string `json:"state"`
+}
+
+func (d *syncDevices) find(myDID, theirDID string) *syncDevice {
+	for _, device := range d.Devices {
+		if device.MyDID == myDID && device.TheirDID == theirDID {
+			return device
+		}
+	}
+
+	return nil
+}
+
+// set adds the device, replacing the device paired on the same connection.
+func (d *syncDevices) set(device *syncDevice) {
+	d.remove(device.TheirDID)
+	d.Devices = append(d.Devices, device)
+}
+
+func (d *syncDevices) remove(theirDID string) {
+	devices := d.Devices[:0]
+
+	for _, device := range d.Devices {
+		if device.TheirDID != theirDID {
+			devices = append(devices, device)
+		}
+	}
+
+	d.Devices = devices
+}
+
+func (d *syncDevices) paired() bool {
+	for _, device := range d.Devices {
+		if device.State == devicePaired {
+			return true
+		}
+	}
+
+	return false
+}
+
+// syncSecrets are the sync key shared by the devices of the user and the pairing codes of the devices being paired,
+// by their DID. They're kept encrypted with the content key.
+type syncSecrets struct {
+	Key   []byte                  `json:"key,omitempty"`
+	Codes map[string]*pairingCode `json:"codes,omitempty"`
+}
+
+type pairingCode struct {
+	Code    string    `json:"code"`
+	Expires time.Time `json:"expires"`
+}
+
+// code returns the pairing code of the device, ErrInvalidPairingCode if it expired at the given time.
+func (s *syncSecrets) code(theirDID string, now time.Time) (string, error) {
+	code, ok := s.Codes[theirDID]
+	if !ok || now.After(code.Expires) {
+		return "", ErrInvalidPairingCode
+	}
+
+	return code.Code, nil
+}
+
+// PairOpt configures the pairing of a device.
+type PairOpt func(opts *pairOpts)
+
+type pairOpts struct {
+	timeout time.Duration
+}
+
+// WithPairingTimeout sets how long the pairing code is valid, 10 minutes by default.
+func WithPairingTimeout(timeout time.Duration) PairOpt {
+	return func(opts *pairOpts) {
+		opts.timeout = timeout
+	}
+}
+
+// EnableSync enables the synchronization of the wallet contents with the other devices of the user through the sync
+// service, which routes the sync messages of the paired devices to the wallet. The wallets whose contents are kept in
+// an EDV vault are already shared between devices, and aren't synchronized.
+func (c *Wallet) EnableSync(svc *SyncService) error {
+	if c.vault != nil {
+		return errors.New("the contents kept in an EDV vault are already shared between devices")
+	}
+
+	c.lock.Lock()
+	c.syncService = svc
+	c.lock.Unlock()
+
+	svc.register(c)
+
+	return nil
+}
+
+// PairDevice starts pairing the unlocked wallet with another device of the user, over the DIDComm connection of myDID
+// and theirDID. It returns the one-time pairing code to join the wallet with on the other device, see JoinDevice. Once
+// paired, the devices exchange their contents, and then the changes of their contents as they're made: the contents
+// are encrypted end to end with a sync key shared by the devices of the user, and the concurrent changes of a content
+// are resolved the same way on all the devices with vector clocks.
+func (c *Wallet) PairDevice(authToken, myDID, theirDID string, options ...PairOpt) (string, error) {
+	opts := &pairOpts{timeout: defaultPairingTimeout}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return "", err
+	}
+
+	if c.syncMessenger() == nil {
+		return "", ErrSyncDisabled
+	}
+
+	c.syncLock.Lock()
+	defer c.syncLock.Unlock()
+
+	devices, secrets, err := c.syncState(aead)
+	if err != nil {
+		return "", err
+	}
+
+	if secrets.Key == nil {
+		secrets.Key = random.GetRandomBytes(contentKeySize)
+	}
+
+	code := base64.RawURLEncoding.EncodeToString(random.GetRandomBytes(pairingCodeSize))
+
+	devices.set(&syncDevice{MyDID: myDID, TheirDID: theirDID, State: devicePairing})
+	secrets.Codes[theirDID] = &pairingCode{Code: code, Expires: c.clock.Now().Add(opts.timeout)}
+
+	if err := c.putSyncState(aead, devices, secrets); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// JoinDevice joins the unlocked wallet to the device of the user which gave the pairing code, over the DIDComm
+// connection of myDID and theirDID. The pairing completes when the other device answers, see PairDevice. A wallet
+// already synchronized with other devices can't join another device, which has to join it instead.
+func (c *Wallet) JoinDevice(authToken, myDID, theirDID, pairingCode string, options ...PairOpt) error {
+	opts := &pairOpts{timeout: defaultPairingTimeout}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return err
+	}
+
+	messenger := c.syncMessenger()
+	if messenger == nil {
+		return ErrSyncDisabled
+	}
+
+	msg, err := c.join(aead, myDID, theirDID, pairingCode, opts)
+	if err != nil {
+		return err
+	}
+
+	return sendSyncMessages(messenger, msg)
+}
+
+func (c *Wallet) join(aead cipher.AEAD, myDID, theirDID, code string, opts *pairOpts) (*outboxMessage, error) {
+	c.syncLock.Lock()
+	defer c.syncLock.Unlock()
+
+	devices, secrets, err := c.syncState(aead)
+	if err != nil {
+		return nil, err
+	}
+
+	if devices.paired() {
+		return nil, ErrWalletSynced
+	}
+
+	salt := random.GetRandomBytes(saltSize)
+
+	lock, err := masterLock(code, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	// the proof authenticates the device ID with the pairing code
+	proof, err := lock.Encrypt("", &secretlock.EncryptRequest{
+		Plaintext:                   string(random.GetRandomBytes(contentKeySize)),
+		AdditionalAuthenticatedData: devices.DeviceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt pairing proof : %w", err)
+	}
+
+	devices.set(&syncDevice{MyDID: myDID, TheirDID: theirDID, State: deviceJoining})
+	secrets.Codes[theirDID] = &pairingCode{Code: code, Expires: c.clock.Now().Add(opts.timeout)}
+
+	if err := c.putSyncState(aead, devices, secrets); err != nil {
+		return nil, err
+	}
+
+	return &outboxMessage{myDID: myDID, theirDID: theirDID, message: &syncMessage{
+		ID:       uuid.New().String(),
+		Type:     SyncJoinMsgType,
+		DeviceID: devices.DeviceID,
+		Salt:     base64.RawURLEncoding.EncodeToString(salt),
+		Proof:    proof.Ciphertext,
+	}}, nil
+}
+
+// UnpairDevice stops synchronizing the unlocked wallet with the device paired on the connection of theirDID. The sync
+// key isn't changed: the contents already sent to the device stay readable with it.
+func (c *Wallet) UnpairDevice(authToken, theirDID string) error {
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return err
+	}
+
+	c.syncLock.Lock()
+	defer c.syncLock.Unlock()
+
+	devices, secrets, err := c.syncState(aead)
+	if err != nil {
+		return err
+	}
+
+	devices.remove(theirDID)
+	delete(secrets.Codes, theirDID)
+
+	return c.putSyncState(aead, devices, secrets)
+}
+
+// Sync sends all the contents of the unlocked wallet to its paired devices, which keep the latest changes of each
+// content. The changes are sent as they're made, Sync catches up with the changes the devices missed.
+func (c *Wallet) Sync(authToken string) error {
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return err
+	}
+
+	messenger := c.syncMessenger()
+	if messenger == nil {
+		return ErrSyncDisabled
+	}
+
+	messages, err := c.syncSnapshot(aead)
+	if err != nil {
+		return err
+	}
+
+	return sendSyncMessages(messenger, messages...)
+}
+
+func (c *Wallet) syncSnapshot(aead cipher.AEAD) ([]*outboxMessage, error) {
+	c.syncLock.Lock()
+	defer c.syncLock.Unlock()
+
+	devices, secrets, err := c.syncState(aead)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := c.snapshot(aead, devices.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.changesMessages(devices, secrets, changes, "")
+}
+
+// stampChange ticks the vector clock of the changed content for the device of the wallet, returning the change to
+// send to the paired devices, nil if the wallet isn't synchronized.
+func (c *Wallet) stampChange(aead cipher.AEAD, contentType ContentType, id string,
+	meta *contentMeta) (*syncChange, error) {
+	devices, err := c.getSyncDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	if devices.DeviceID == "" {
+		return nil, nil // nolint: nilnil
+	}
+
+	prev, err := c.getContentMeta(aead, contentType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.Clock = prev.Clock.tick(devices.DeviceID)
+	meta.Device = devices.DeviceID
+
+	return newSyncChange(contentType, id, nil, meta), nil
+}
+
+// sendChange sends the change of a content to the paired devices, the failures are logged: the devices catch up with
+// Sync.
+func (c *Wallet) sendChange(aead cipher.AEAD, change *syncChange) {
+	messenger := c.syncMessenger()
+	if change == nil || messenger == nil {
+		return
+	}
+
+	messages, err := c.changeMessages(aead, change)
+	if err != nil {
+		logger.Warnf("failed to send wallet content change : %s", err)
+
+		return
+	}
+
+	if err := sendSyncMessages(messenger, messages...); err != nil {
+		logger.Warnf("failed to send wallet content change : %s", err)
+	}
+}
+
+func (c *Wallet) changeMessages(aead cipher.AEAD, change *syncChange) ([]*outboxMessage, error) {
+	c.syncLock.Lock()
+	defer c.syncLock.Unlock()
+
+	devices, secrets, err := c.syncState(aead)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.changesMessages(devices, secrets, []*syncChange{change}, "")
+}
+
+// changesMessages returns the changes messages to the paired devices, but the device of exceptDID.
+func (c *Wallet) changesMessages(devices *syncDevices, secrets *syncSecrets, changes []*syncChange,
+	exceptDID string) ([]*outboxMessage, error) {
+	var messages []*outboxMessage
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	for _, device := range devices.Devices {
+		if device.State != devicePaired || device.TheirDID == exceptDID {
+			continue
+		}
+
+		msg, err := sealChanges(secrets.Key, devices.DeviceID, changes)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, &outboxMessage{myDID: device.MyDID, theirDID: device.TheirDID, message: msg})
+	}
+
+	return messages, nil
+}
+
+// snapshot returns the changes of all the contents of the wallet, including the removed contents. The contents
+// changed before the wallet was synchronized are stamped with the clock of the device.
+func (c *Wallet) snapshot(aead cipher.AEAD, deviceID string) ([]*syncChange, error) {
+	var changes []*syncChange
+
+	for _, contentType := range contentTypes {
+		contents, err := c.getAll(aead, contentType, &contentMeta{})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, 0, len(contents))
+
+		for id := range contents {
+			ids = append(ids, id)
+		}
+
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			meta, err := c.getContentMeta(aead, contentType, id)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(meta.Clock) == 0 {
+				meta.Clock = meta.Clock.tick(deviceID)
+				meta.Device = deviceID
+
+				if err := c.putContentMeta(aead, c.contentMetaKey(contentType, id), meta); err != nil {
+					return nil, err
+				}
+			}
+
+			changes = append(changes, newSyncChange(contentType, id, contents[id], meta))
+		}
+
+		removed, err := c.removedContents(aead, contentType)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, removed...)
+	}
+
+	return changes, nil
+}
+
+// removedContents returns the removals of the contents of the type, whose metadata is kept with their clock.
+func (c *Wallet) removedContents(aead cipher.AEAD, contentType ContentType) ([]*syncChange, error) {
+	prefix := c.contentMetaKey(contentType, "")
+
+	iter := c.store.Iterator(prefix, prefix+storage.EndKeySuffix)
+	defer iter.Release()
+
+	var changes []*syncChange
+
+	for iter.Next() {
+		key := string(iter.Key())
+
+		meta, err := decryptContentMeta(aead, key, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		if meta.Removed {
+			changes = append(changes, newSyncChange(contentType, strings.TrimPrefix(key, prefix), nil, meta))
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to get wallet content metadata : %w", err)
+	}
+
+	return changes, nil
+}
+
+// receiveSyncMessage keeps the sync message in the inbox of the wallet, encrypted as it was received.
+func (c *Wallet) receiveSyncMessage(in *inboxMessage) error {
+	if in.Message.ID == "" {
+		in.Message.ID = uuid.New().String()
+	}
+
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallet sync message : %w", err)
+	}
+
+	if err := c.store.Put(c.syncKey(syncInboxKeyPrefix)+"_"+in.Message.ID, raw); err != nil {
+		return fmt.Errorf("failed to save wallet sync message : %w", err)
+	}
+
+	return nil
+}
+
+// processSyncInbox processes the sync messages of the inbox if the wallet is unlocked and sends the answers, the
+// failures are logged. The messages awaiting a pairing stay in the inbox.
+func (c *Wallet) processSyncInbox() {
+	messenger := c.syncMessenger()
+	if messenger == nil {
+		return
+	}
+
+	messages, err := c.processInbox()
+	if err != nil {
+		logger.Warnf("failed to process wallet sync messages : %s", err)
+	}
+
+	if err := sendSyncMessages(messenger, messages...); err != nil {
+		logger.Warnf("failed to send wallet sync messages : %s", err)
+	}
+}
+
+func (c *Wallet) processInbox() ([]*outboxMessage, error) {
+	c.syncLock.Lock()
+	defer c.syncLock.Unlock()
+
+	aead := c.syncCipher()
+	if aead == nil {
+		return nil, nil
+	}
+
+	var outbox []*outboxMessage
+
+	// the messages processed may complete a pairing, which the pending messages await
+	for processed := true; processed; {
+		processed = false
+
+		inbox, err := c.syncInbox()
+		if err != nil {
+			return outbox, err
+		}
+
+		for key, in := range inbox {
+			messages, err := c.handleSyncMessage(aead, in)
+			if errors.Is(err, errSyncPending) {
+				continue
+			}
+
+			if err != nil {
+				logger.Warnf("failed to process wallet sync message %s : %s", in.Message.Type, err)
+			}
+
+			if err := c.store.Delete(key); err != nil {
+				return outbox, fmt.Errorf("failed to remove wallet sync message : %w", err)
+			}
+
+			outbox = append(outbox, messages...)
+			processed = true
+		}
+	}
+
+	return outbox, nil
+}
+
+// syncInbox returns the sync messages of the inbox, by store key.
+func (c *Wallet) syncInbox() (map[string]*inboxMessage, error) {
+	prefix := c.syncKey(syncInboxKeyPrefix) + "_"
+
+	iter := c.store.Iterator(prefix, prefix+storage.EndKeySuffix)
+	defer iter.Release()
+
+	inbox := make(map[string]*inboxMessage)
+
+	for iter.Next() {
+		in := &inboxMessage{}
+
+		if err := json.Unmarshal(iter.Value(), in); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal wallet sync message : %w", err)
+		}
+
+		inbox[string(iter.Key())] = in
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to get wallet sync messages : %w", err)
+	}
+
+	return inbox, nil
+}
+
+func (c *Wallet) handleSyncMessage(aead cipher.AEAD, in *inboxMessage) ([]*outboxMessage, error) {
+	devices, secrets, err := c.syncState(aead)
+	if err != nil {
+		return nil, err
+	}
+
+	device := devices.find(in.MyDID, in.TheirDID)
+	if device == nil {
+		return nil, fmt.Errorf("no device paired on the connection of %s and %s", in.MyDID, in.TheirDID)
+	}
+
+	switch in.Message.Type {
+	case SyncJoinMsgType:
+		return c.handleJoin(aead, devices, secrets, device, in.Message)
+	case SyncPairMsgType:
+		return c.handlePair(aead, devices, secrets, device, in.Message)
+	case SyncChangesMsgType:
+		return c.handleChanges(aead, devices, secrets, device, in.Message)
+	default:
+		return nil, fmt.Errorf("unsupported wallet sync message type %s", in.Message.Type)
+	}
+}
+
+// handleJoin checks the pairing proof of the joining device, and answers with the sync key and the contents.
+func (c *Wallet) handleJoin(aead cipher.AEAD, devices *syncDevices, secrets *syncSecrets, device *syncDevice,
+	msg *syncMessage) ([]*outboxMessage, error) {
+	if device.State != devicePairing {
+		return nil, fmt.Errorf("device %s isn't being paired", device.TheirDID)
+	}
+
+	// the pairing code can't be tried again
+	code, err := secrets.code(device.TheirDID, c.clock.Now())
+	delete(secrets.Codes, device.TheirDID)
+
+	if err == nil {
+		err = checkPairingProof(code, msg)
+	}
+
+	if err != nil {
+		devices.remove(device.TheirDID)
+
+		if e := c.putSyncState(aead, devices, secrets); e != nil {
+			return nil, e
+		}
+
+		return nil, err
+	}
+
+	salt := random.GetRandomBytes(saltSize)
+
+	lock, err := masterLock(code, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := lock.Encrypt("", &secretlock.EncryptRequest{Plaintext: string(secrets.Key)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt sync key : %w", err)
+	}
+
+	device.DeviceID = msg.DeviceID
+	device.State = devicePaired
+
+	if err := c.putSyncState(aead, devices, secrets); err != nil {
+		return nil, err
+	}
+
+	pair := &outboxMessage{myDID: device.MyDID, theirDID: device.TheirDID, message: &syncMessage{
+		ID:       uuid.New().String(),
+		Type:     SyncPairMsgType,
+		DeviceID: devices.DeviceID,
+		Salt:     base64.RawURLEncoding.EncodeToString(salt),
+		Key:      key.Ciphertext,
+	}}
+
+	contents, err := c.contentsMessage(aead, devices.DeviceID, secrets.Key, device)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*outboxMessage{pair}, contents...), nil
+}
+
+func checkPairingProof(code string, msg *syncMessage) error {
+	lock, err := pairingLock(code, msg.Salt)
+	if err != nil {
+		return err
+	}
+
+	_, err = lock.Decrypt("", &secretlock.DecryptRequest{
+		Ciphertext:                  msg.Proof,
+		AdditionalAuthenticatedData: msg.DeviceID,
+	})
+	if err != nil || msg.DeviceID == "" {
+		return ErrInvalidPairingCode
+	}
+
+	return nil
+}
+
+// handlePair adopts the sync key of the device the wallet joined, and sends it the contents.
+func (c *Wallet) handlePair(aead cipher.AEAD, devices *syncDevices, secrets *syncSecrets, device *syncDevice,
+	msg *syncMessage) ([]*outboxMessage, error) {
+	if device.State != deviceJoining {
+		return nil, fmt.Errorf("device %s isn't being joined", device.TheirDID)
+	}
+
+	code, err := secrets.code(device.TheirDID, c.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := pairingLock(code, msg.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: msg.Key})
+	if err != nil {
+		return nil, ErrInvalidPairingCode
+	}
+
+	delete(secrets.Codes, device.TheirDID)
+
+	secrets.Key = []byte(key.Plaintext)
+	device.DeviceID = msg.DeviceID
+	device.State = devicePaired
+
+	if err := c.putSyncState(aead, devices, secrets); err != nil {
+		return nil, err
+	}
+
+	return c.contentsMessage(aead, devices.DeviceID, secrets.Key, device)
+}
+
+// contentsMessage returns the message sending all the contents of the wallet to the device.
+func (c *Wallet) contentsMessage(aead cipher.AEAD, deviceID string, key []byte,
+	device *syncDevice) ([]*outboxMessage, error) {
+	changes, err := c.snapshot(aead, deviceID)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+
+	msg, err := sealChanges(key, deviceID, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*outboxMessage{{myDID: device.MyDID, theirDID: device.TheirDID, message: msg}}, nil
+}
+
+// handleChanges applies the changes of the device, and forwards those applied to the other paired devices. When a
+// concurrent local change wins, it's sent back to the device.
+func (c *Wallet) handleChanges(aead cipher.AEAD, devices *syncDevices, secrets *syncSecrets, device *syncDevice,
+	msg *syncMessage) ([]*outboxMessage, error) {
+	if device.State == deviceJoining {
+		return nil, errSyncPending
+	}
+
+	if device.State != devicePaired || device.DeviceID != msg.DeviceID {
+		return nil, fmt.Errorf("device %s isn't paired", msg.DeviceID)
+	}
+
+	changes, err := openChanges(secrets.Key, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied, rejected []*syncChange
+
+	for _, change := range changes {
+		winner, err := c.applyChange(aead, change)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply %s %s change : %w", change.Type, change.ID, err)
+		}
+
+		switch {
+		case winner == change:
+			applied = append(applied, change)
+		case winner != nil:
+			rejected = append(rejected, winner)
+		}
+	}
+
+	messages, err := c.changesMessages(devices, secrets, applied, device.TheirDID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rejected) > 0 {
+		msg, err := sealChanges(secrets.Key, devices.DeviceID, rejected)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, &outboxMessage{myDID: device.MyDID, theirDID: device.TheirDID, message: msg})
+	}
+
+	return messages, nil
+}
+
+// applyChange applies the change of a content unless the local content changed after it, returning the change which
+// wins: the change applied, the local content when a concurrent local change wins, nil when the change is outdated.
+// The concurrent changes are resolved the same way on all the devices: the change of the greatest device ID wins,
+// with the clock of both changes.
+func (c *Wallet) applyChange(aead cipher.AEAD, change *syncChange) (*syncChange, error) {
+	if err := validateContentType(change.Type); err != nil {
+		return nil, err
+	}
+
+	metaKey := c.contentMetaKey(change.Type, change.ID)
+
+	local, err := c.getContentMeta(aead, change.Type, change.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch local.Clock.compare(change.Clock) {
+	case clockEqual, clockAfter:
+		return nil, nil
+	case clockConcurrent:
+		if local.Device > change.Device {
+			local.Clock = local.Clock.merge(change.Clock)
+
+			return c.localChange(aead, change.Type, change.ID, local)
+		}
+
+		change.Clock = change.Clock.merge(local.Clock)
+	}
+
+	meta := &contentMeta{
+		Collection: change.Collection,
+		Tags:       change.Tags,
+		Clock:      change.Clock,
+		Device:     change.Device,
+		Removed:    change.Removed,
+	}
+
+	if change.Removed {
+		if err := c.store.Delete(c.contentKey(change.Type, change.ID)); err != nil {
+			return nil, fmt.Errorf("failed to remove wallet content : %w", err)
+		}
+	} else {
+		if id, err := contentID(change.Type, change.Content); err != nil || id != change.ID {
+			return nil, fmt.Errorf("invalid %s content %s", change.Type, change.ID)
+		}
+
+		if err := c.putContent(aead, change.Type, change.ID, change.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.putContentMeta(aead, metaKey, meta); err != nil {
+		return nil, err
+	}
+
+	if change.Removed && change.Type == Collection {
+		if err := c.clearCollection(aead, change.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return change, nil
+}
+
+// localChange saves the metadata of the local content, and returns its change.
+func (c *Wallet) localChange(aead cipher.AEAD, contentType ContentType, id string,
+	meta *contentMeta) (*syncChange, error) {
+	if err := c.putContentMeta(aead, c.contentMetaKey(contentType, id), meta); err != nil {
+		return nil, err
+	}
+
+	var content json.RawMessage
+
+	if !meta.Removed {
+		key := c.contentKey(contentType, id)
+
+		encrypted, err := c.store.Get(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wallet content : %w", err)
+		}
+
+		if content, err = decrypt(aead, key, encrypted); err != nil {
+			return nil, err
+		}
+	}
+
+	return newSyncChange(contentType, id, content, meta), nil
+}
+
+func sealChanges(key []byte, deviceID string, changes []*syncChange) (*syncMessage, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wallet content changes : %w", err)
+	}
+
+	nonce := random.GetRandomBytes(uint32(aead.NonceSize()))
+
+	return &syncMessage{
+		ID:       uuid.New().String(),
+		Type:     SyncChangesMsgType,
+		DeviceID: deviceID,
+		Nonce:    base64.RawURLEncoding.EncodeToString(nonce),
+		Changes:  base64.RawURLEncoding.EncodeToString(aead.Seal(nil, nonce, raw, changesAAD(deviceID))),
+	}, nil
+}
+
+func openChanges(key []byte, msg *syncMessage) ([]*syncChange, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := base64.RawURLEncoding.DecodeString(msg.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet sync nonce : %w", err)
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(msg.Changes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet sync changes : %w", err)
+	}
+
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid wallet sync nonce size")
+	}
+
+	raw, err := aead.Open(nil, nonce, ciphertext, changesAAD(msg.DeviceID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt wallet sync changes : %w", err)
+	}
+
+	var changes []*syncChange
+
+	if err := json.Unmarshal(raw, &changes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wallet sync changes : %w", err)
+	}
+
+	return changes, nil
+}
+
+// changesAAD binds the changes to the device which sent them.
+func changesAAD(deviceID string) []byte {
+	return []byte(SyncChangesMsgType + "#" + deviceID)
+}
+
+func pairingLock(code, salt string) (secretlock.Service, error) {
+	saltBytes, err := base64.RawURLEncoding.DecodeString(salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pairing salt : %w", err)
+	}
+
+	return masterLock(code, saltBytes)
+}
+
+func sendSyncMessages(messenger service.Messenger, messages ...*outboxMessage) error {
+	for _, m := range messages {
+		if err := messenger.Send(service.NewDIDCommMsgMap(m.message), m.myDID, m.theirDID); err != nil {
+			return fmt.Errorf("failed to send wallet sync message : %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Wallet) syncMessenger() service.Messenger {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.syncService == nil {
+		return nil
+	}
+
+	return c.syncService.messenger
+}
+
+// syncCipher returns the content cipher if the wallet is unlocked, nil otherwise. The session isn't marked as used, so
+// that the sync messages don't keep the wallet unlocked.
+func (c *Wallet) syncCipher() cipher.AEAD {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.unlocked() {
+		c.clearSession()
+
+		return nil
+	}
+
+	return c.aead
+}
+
+func (c *Wallet) syncKey(prefix string) string {
+	return prefix + userKey(c.userID)
+}
+
+// syncState returns the devices and secrets of the wallet sync, creating the ID of the device of the wallet if it has
+// none yet. The sync lock must be held.
+func (c *Wallet) syncState(aead cipher.AEAD) (*syncDevices, *syncSecrets, error) {
+	devices, err := c.getSyncDevices()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if devices.DeviceID == "" {
+		devices.DeviceID = uuid.New().String()
+	}
+
+	secrets := &syncSecrets{}
+	key := c.syncKey(syncSecretsKeyPrefix)
+
+	encrypted, err := c.store.Get(key)
+
+	switch {
+	case errors.Is(err, storage.ErrDataNotFound):
+	case err != nil:
+		return nil, nil, fmt.Errorf("failed to get wallet sync secrets : %w", err)
+	default:
+		raw, err := decrypt(aead, key, encrypted)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := json.Unmarshal(raw, secrets); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal wallet sync secrets : %w", err)
+		}
+	}
+
+	if secrets.Codes == nil {
+		secrets.Codes = make(map[string]*pairingCode)
+	}
+
+	return devices, secrets, nil
+}
+
+func (c *Wallet) getSyncDevices() (*syncDevices, error) {
+	raw, err := c.store.Get(c.syncKey(syncDevicesKeyPrefix))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &syncDevices{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet sync devices : %w", err)
+	}
+
+	devices := &syncDevices{}
+
+	if err := json.Unmarshal(raw, devices); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wallet sync devices : %w", err)
+	}
+
+	return devices, nil
+}
+
+func (c *Wallet) putSyncState(aead cipher.AEAD, devices *syncDevices, secrets *syncSecrets) error {
+	raw, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallet sync secrets : %w", err)
+	}
+
+	key := c.syncKey(syncSecretsKeyPrefix)
+	nonce := random.GetRandomBytes(uint32(aead.NonceSize()))
+
+	if err := c.store.Put(key, aead.Seal(nonce, nonce, raw, []byte(key))); err != nil {
+		return fmt.Errorf("failed to save wallet sync secrets : %w", err)
+	}
+
+	if raw, err = json.Marshal(devices); err != nil {
+		return fmt.Errorf("failed to marshal wallet sync devices : %w", err)
+	}
+
+	if err := c.store.Put(c.syncKey(syncDevicesKeyPrefix), raw); err != nil {
+		return fmt.Errorf("failed to save wallet sync devices : %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+const (
+	laptopDID = "did:example:laptop"
+	phoneDID  = "did:example:phone"
+	tabletDID = "did:example:tablet"
+)
+
+func TestWallet_Sync(t *testing.T) {
+	t.Run("sync the contents of the paired devices", func(t *testing.T) {
+		network := newSyncNetwork()
+		laptop, laptopToken := network.device(t, laptopDID)
+		phone, phoneToken := network.device(t, phoneDID)
+
+		require.NoError(t, laptop.Add(laptopToken, Credential, json.RawMessage(sampleCredential), WithTags("id")))
+		require.NoError(t, phone.Add(phoneToken, Credential, json.RawMessage(sampleUniversityDegree)))
+
+		pair(t, laptop, laptopToken, laptopDID, phone, phoneToken, phoneDID)
+
+		// the devices exchange their contents once paired
+		contents, err := phone.GetAll(phoneToken, Credential, FilterByTags("id"))
+		require.NoError(t, err)
+		require.Len(t, contents, 1)
+		require.JSONEq(t, sampleCredential, string(contents["http://example.edu/credentials/1872"]))
+
+		_, err = laptop.Get(laptopToken, Credential, "http://example.edu/credentials/3732")
+		require.NoError(t, err)
+
+		// then the changes, as they're made
+		require.NoError(t, phone.Add(phoneToken, Collection, json.RawMessage(sampleCollection)))
+		require.NoError(t, phone.Add(phoneToken, Credential, json.RawMessage(sampleUniversityDegree),
+			AddToCollection("work")))
+
+		contents, err = laptop.GetAll(laptopToken, Credential, FilterByCollection("work"))
+		require.NoError(t, err)
+		require.Len(t, contents, 1)
+
+		require.NoError(t, laptop.Remove(laptopToken, Collection, "work"))
+		require.NoError(t, laptop.Remove(laptopToken, Credential, "http://example.edu/credentials/1872"))
+
+		_, err = phone.Get(phoneToken, Collection, "work")
+		require.True(t, errors.Is(err, ErrContentNotFound))
+
+		_, err = phone.Get(phoneToken, Credential, "http://example.edu/credentials/1872")
+		require.True(t, errors.Is(err, ErrContentNotFound))
+
+		contents, err = phone.GetAll(phoneToken, Credential, FilterByCollection("work"))
+		require.NoError(t, err)
+		require.Empty(t, contents)
+
+		// the changes are relayed to the devices paired with another device
+		tablet, tabletToken := network.device(t, tabletDID)
+		pair(t, laptop, laptopToken, laptopDID, tablet, tabletToken, tabletDID)
+
+		require.NoError(t, phone.Add(phoneToken, Credential, json.RawMessage(sampleCredential)))
+
+		_, err = tablet.Get(tabletToken, Credential, "http://example.edu/credentials/1872")
+		require.NoError(t, err)
+
+		// the unpaired devices aren't synchronized anymore
+		require.NoError(t, laptop.UnpairDevice(laptopToken, tabletDID))
+		require.NoError(t, laptop.Remove(laptopToken, Credential, "http://example.edu/credentials/1872"))
+
+		_, err = tablet.Get(tabletToken, Credential, "http://example.edu/credentials/1872")
+		require.NoError(t, err)
+	})
+
+	t.Run("sync a locked wallet once unlocked", func(t *testing.T) {
+		network := newSyncNetwork()
+		laptop, laptopToken := network.device(t, laptopDID)
+		phone, phoneToken := network.device(t, phoneDID)
+
+		code, err := laptop.PairDevice(laptopToken, laptopDID, phoneDID)
+		require.NoError(t, err)
+		require.NoError(t, phone.JoinDevice(phoneToken, phoneDID, laptopDID, code))
+
+		require.True(t, phone.Close())
+		require.NoError(t, laptop.Add(laptopToken, Credential, json.RawMessage(sampleCredential)))
+
+		// the changes are kept encrypted until the wallet is unlocked
+		inbox, err := phone.syncInbox()
+		require.NoError(t, err)
+		require.Len(t, inbox, 1)
+
+		for _, in := range inbox {
+			require.NotContains(t, in.Message.Changes, "example.edu")
+		}
+
+		phoneToken = open(t, phone, samplePassphrase)
+
+		_, err = phone.Get(phoneToken, Credential, "http://example.edu/credentials/1872")
+		require.NoError(t, err)
+
+		inbox, err = phone.syncInbox()
+		require.NoError(t, err)
+		require.Empty(t, inbox)
+	})
+
+	t.Run("resolve the concurrent changes", func(t *testing.T) {
+		network := newSyncNetwork()
+		laptop, laptopToken := network.device(t, laptopDID)
+		phone, phoneToken := network.device(t, phoneDID)
+
+		pair(t, laptop, laptopToken, laptopDID, phone, phoneToken, phoneDID)
+
+		require.NoError(t, laptop.Add(laptopToken, Credential, json.RawMessage(sampleCredential)))
+
+		// the devices change the credential while disconnected
+		network.err = errors.New("offline")
+
+		require.NoError(t, laptop.Add(laptopToken, Credential, json.RawMessage(sampleCredential), WithTags("laptop")))
+		require.NoError(t, phone.Add(phoneToken, Credential, json.RawMessage(sampleCredential), WithTags("phone")))
+
+		network.err = nil
+
+		require.NoError(t, laptop.Sync(laptopToken))
+		require.NoError(t, phone.Sync(phoneToken))
+
+		laptopDevices, err := laptop.getSyncDevices()
+		require.NoError(t, err)
+
+		phoneDevices, err := phone.getSyncDevices()
+		require.NoError(t, err)
+
+		winner := "phone"
+		if laptopDevices.DeviceID > phoneDevices.DeviceID {
+			winner = "laptop"
+		}
+
+		for _, device := range []struct {
+			wallet *Wallet
+			token  string
+		}{{laptop, laptopToken}, {phone, phoneToken}} {
+			contents, err := device.wallet.GetAll(device.token, Credential, FilterByTags(winner))
+			require.NoError(t, err)
+			require.Len(t, contents, 1)
+		}
+	})
+
+	t.Run("invalid pairing code", func(t *testing.T) {
+		network := newSyncNetwork()
+		laptop, laptopToken := network.device(t, laptopDID)
+		phone, phoneToken := network.device(t, phoneDID)
+
+		require.NoError(t, laptop.Add(laptopToken, Credential, json.RawMessage(sampleCredential)))
+
+		_, err := laptop.PairDevice(laptopToken, laptopDID, phoneDID)
+		require.NoError(t, err)
+		require.NoError(t, phone.JoinDevice(phoneToken, phoneDID, laptopDID, "wrong code"))
+
+		// the pairing code can't be tried again
+		err = phone.JoinDevice(phoneToken, phoneDID, laptopDID, "wrong code")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no wallet paired on the connection")
+
+		code, err := laptop.PairDevice(laptopToken, laptopDID, phoneDID, WithPairingTimeout(-time.Minute))
+		require.NoError(t, err)
+		require.NoError(t, phone.JoinDevice(phoneToken, phoneDID, laptopDID, code))
+
+		devices, err := laptop.getSyncDevices()
+		require.NoError(t, err)
+		require.False(t, devices.paired())
+
+		_, err = phone.Get(phoneToken, Credential, "http://example.edu/credentials/1872")
+		require.True(t, errors.Is(err, ErrContentNotFound))
+	})
+
+	t.Run("sync errors", func(t *testing.T) {
+		network := newSyncNetwork()
+		laptop, laptopToken := network.device(t, laptopDID)
+		phone, phoneToken := network.device(t, phoneDID)
+
+		pair(t, laptop, laptopToken, laptopDID, phone, phoneToken, phoneDID)
+
+		require.True(t, errors.Is(phone.JoinDevice(phoneToken, phoneDID, tabletDID, "code"), ErrWalletSynced))
+
+		_, err := laptop.PairDevice("unknown", laptopDID, tabletDID)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		require.NoError(t, laptop.Add(laptopToken, Credential, json.RawMessage(sampleCredential)))
+
+		network.err = errors.New("offline")
+		require.EqualError(t, laptop.Sync(laptopToken), "failed to send wallet sync message : offline")
+
+		unsynced := newWallet(t, newProvider())
+		token := open(t, unsynced, samplePassphrase)
+
+		_, err = unsynced.PairDevice(token, laptopDID, phoneDID)
+		require.True(t, errors.Is(err, ErrSyncDisabled))
+		require.True(t, errors.Is(unsynced.JoinDevice(token, laptopDID, phoneDID, "code"), ErrSyncDisabled))
+		require.True(t, errors.Is(unsynced.Sync(token), ErrSyncDisabled))
+
+		_, err = network.services[laptopDID].HandleInbound(service.NewDIDCommMsgMap(&syncMessage{
+			Type: SyncChangesMsgType,
+		}), laptopDID, "did:example:unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no wallet paired on the connection")
+
+		server := newEDVServer(t)
+		defer server.Close()
+
+		err = newEDVWallet(t, newProvider(), server.URL).EnableSync(NewSyncService(nil))
+		require.EqualError(t, err, "the contents kept in an EDV vault are already shared between devices")
+	})
+}
+
+func TestVectorClock(t *testing.T) {
+	clock := vectorClock{}.tick("a")
+
+	require.Equal(t, clockEqual, clock.compare(vectorClock{"a": 1}))
+	require.Equal(t, clockAfter, clock.tick("a").compare(clock))
+	require.Equal(t, clockBefore, clock.compare(clock.tick("b")))
+	require.Equal(t, clockConcurrent, clock.tick("a").compare(clock.tick("b")))
+	require.Equal(t, vectorClock{"a": 2, "b": 1}, clock.tick("a").merge(clock.tick("b")))
+	require.Equal(t, vectorClock{"a": 1}, clock, "the clocks are immutable")
+}
+
+func pair(t *testing.T, initiator *Wallet, initiatorToken, initiatorDID string, joiner *Wallet,
+	joinerToken, joinerDID string) {
+	t.Helper()
+
+	code, err := initiator.PairDevice(initiatorToken, initiatorDID, joinerDID)
+	require.NoError(t, err)
+	require.NoError(t, joiner.JoinDevice(joinerToken, joinerDID, initiatorDID, code))
+
+	devices, err := joiner.getSyncDevices()
+	require.NoError(t, err)
+	require.True(t, devices.paired())
+}
+
+// syncNetwork delivers the sync messages to the sync service of the device of the recipient DID, each device having
+// one DID for all its connections.
+type syncNetwork struct {
+	services map[string]*SyncService
+	err      error
+}
+
+func newSyncNetwork() *syncNetwork {
+	return &syncNetwork{services: make(map[string]*SyncService)}
+}
+
+// device returns the unlocked wallet of a new device with the DID.
+func (n *syncNetwork) device(t *testing.T, did string) (*Wallet, string) {
+	t.Helper()
+
+	svc := NewSyncService(&syncMessenger{network: n})
+	n.services[did] = svc
+
+	wallet := newWallet(t, newProvider())
+	require.NoError(t, wallet.EnableSync(svc))
+
+	return wallet, open(t, wallet, samplePassphrase)
+}
+
+type syncMessenger struct {
+	service.Messenger
+	network *syncNetwork
+}
+
+func (m *syncMessenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	if m.network.err != nil {
+		return m.network.err
+	}
+
+	svc, ok := m.network.services[theirDID]
+	if !ok {
+		return errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	_, err := svc.HandleInbound(msg, theirDID, myDID)
+
+	return err
+}
@@ -0,0 +1,597 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/cache"
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	contentKeyPrefix = "content_"
+	contentKeySize   = 32
+	kmsPrimaryKeyURI = "local-lock://vcwallet/"
+	authTokenSize    = 32
+
+	// defaultIdleTimeout is how long the session of the wallet stays open without being used by default.
+	defaultIdleTimeout = 10 * time.Minute
+)
+
+// ContentType is the type of the wallet contents, see https://w3c-ccg.github.io/universal-wallet-interop-spec/.
+type ContentType string
+
+// wallet content types.
+const (
+	Collection            ContentType = "collection"
+	Credential            ContentType = "credential"
+	DIDResolutionResponse ContentType = "didResolutionResponse"
+	Metadata              ContentType = "metadata"
+	Connection            ContentType = "connection"
+	Key                   ContentType = "key"
+)
+
+// wallet errors.
+var (
+	// ErrProfileExists is returned when creating a wallet profile which already exists.
+	ErrProfileExists = errors.New("wallet profile already exists")
+	// ErrProfileNotFound is returned when the wallet profile of the user doesn't exist.
+	ErrProfileNotFound = errors.New("wallet profile not found")
+	// ErrWalletLocked is returned when accessing the contents of a locked wallet.
+	ErrWalletLocked = errors.New("wallet is locked")
+	// ErrWalletOpen is returned when opening a wallet whose session is already open.
+	ErrWalletOpen = errors.New("wallet is already open")
+	// ErrInvalidAuthToken is returned when accessing the wallet with an auth token other than that of its session.
+	ErrInvalidAuthToken = errors.New("invalid auth token")
+	// ErrInvalidPassphrase is returned when unlocking the wallet with a wrong passphrase.
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+	// ErrContentNotFound is returned when the content is not in the wallet.
+	ErrContentNotFound = errors.New("content not found")
+	// ErrNoQueryResults is returned when a query doesn't match any credential of the wallet.
+	ErrNoQueryResults = errors.New("no credentials matching the query")
+	// ErrNotDerivable is returned when deriving a selective disclosure of a credential not signed with BBS+.
+	ErrNotDerivable = errors.New("credential can't be disclosed selectively")
+	// ErrUnsupportedCredentialFormat is returned when deriving a selective disclosure of a credential in a format the
+	// wallet doesn't support yet, such as SD-JWT.
+	ErrUnsupportedCredentialFormat = errors.New("unsupported credential format")
+)
+
+// provider contains dependencies for the wallet and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+	Service(id string) (interface{}, error)
+}
+
+// kmsProvider provides the stores and secret lock of the KMS of a profile.
+type kmsProvider struct {
+	storage    storage.Provider
+	secretLock secretlock.Service
+}
+
+func (p *kmsProvider) StorageProvider() storage.Provider {
+	return p.storage
+}
+
+func (p *kmsProvider) SecretLock() secretlock.Service {
+	return p.secretLock
+}
+
+// UnlockOpt configures the session opened on the wallet.
+type UnlockOpt func(opts *unlockOpts)
+
+type unlockOpts struct {
+	idleTimeout time.Duration
+}
+
+// WithIdleTimeout locks the wallet when its auth token isn't used for the given duration, 10 minutes by default.
+func WithIdleTimeout(timeout time.Duration) UnlockOpt {
+	return func(opts *unlockOpts) {
+		opts.idleTimeout = timeout
+	}
+}
+
+// Wallet is the Universal Wallet of a user, holding credentials, DIDs, keys, connections and metadata. Its contents
+// are encrypted at rest, and can only be accessed with the auth token of the session opened with the passphrase of the
+// profile.
+type Wallet struct {
+	userID string
+	ctx    provider
+	// storage opens the stores of the profile, kept in the vault if the profile uses an EDV
+	storage storage.Provider
+	store   storage.Store
+	vault   *vaultStorage
+	vdr     vdrapi.Registry
+	profile *profile
+	// clock tells the time of the idle timeout, the credential expiry and the pairing code expiry
+	clock clock.Clock
+	// credentialCache caches the stored credentials parsed by the queries and the monitor, nil if not caching
+	credentialCache *cache.Cache
+	// aead encrypts the contents, keyManager manages the keys and kmsLock encrypts them, nil while the wallet is locked
+	aead       cipher.AEAD
+	keyManager kms.KeyManager
+	kmsLock    secretlock.Service
+	// authToken is the token of the session, which expires when unused for idleTimeout
+	authToken   string
+	idleTimeout time.Duration
+	lastUsed    time.Time
+	lock        sync.Mutex
+	// consentEvent receives the consent requests of PresentProof
+	consentEvent chan<- ConsentRequest
+	consentLock  sync.RWMutex
+	// monitor is the running credential monitor, which stops when the wallet locks
+	monitor             *credentialMonitor
+	credentialEvent     chan<- CredentialEvent
+	credentialEventLock sync.RWMutex
+	// syncService sends the content changes to the paired devices, nil until EnableSync
+	syncService *SyncService
+	syncLock    sync.Mutex
+	// auditLock serializes the appends to the audit log of the disclosures
+	auditLock sync.Mutex
+}
+
+// New returns the wallet of the user, locked. Its profile must have been created with CreateProfile.
+func New(userID string, ctx provider) (*Wallet, error) {
+	profileStore, err := ctx.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wallet store : %w", err)
+	}
+
+	p, err := getProfile(profileStore, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profileStorage, err := tenantStorage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var vault *vaultStorage
+
+	if p.EDV != nil {
+		// the local stores of the profile cache the records of the vault
+		vault = &vaultStorage{local: profileStorage}
+		profileStorage = vault
+	}
+
+	store, err := profileStorage.OpenStore(contentStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wallet content store : %w", err)
+	}
+
+	return &Wallet{
+		userID:  userID,
+		ctx:     ctx,
+		storage: profileStorage,
+		store:   store,
+		vault:   vault,
+		vdr:     ctx.VDRegistry(),
+		profile: p,
+		clock:   clock.Of(ctx),
+
+		credentialCache: cache.Of(ctx),
+	}, nil
+}
+
+// Open unlocks the wallet with the passphrase of its profile, returning the auth token of the session required by the
+// operations on the wallet contents and keys. The wallet locks again, its token expiring, when the token isn't used for
+// the idle timeout or when Close is called. ErrWalletOpen is returned if a session is already open.
+func (c *Wallet) Open(passphrase string, options ...UnlockOpt) (string, error) {
+	opts := &unlockOpts{idleTimeout: defaultIdleTimeout}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	key, secretLock, err := c.profile.unlock(passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to create content cipher : %w", err)
+	}
+
+	keyManager, err := localkms.New(kmsPrimaryKeyURI, &kmsProvider{storage: c.storage, secretLock: secretLock})
+	if err != nil {
+		return "", fmt.Errorf("failed to create wallet KMS : %w", err)
+	}
+
+	var vault storage.Provider
+
+	if c.vault != nil {
+		if vault, err = c.profile.EDV.contentProvider(key); err != nil {
+			return "", err
+		}
+	}
+
+	token, err := c.openSession(aead, keyManager, secretLock, vault, opts)
+	if err != nil {
+		return "", err
+	}
+
+	// the sync messages received while the wallet was locked are processed once it's unlocked
+	c.processSyncInbox()
+
+	return token, nil
+}
+
+func (c *Wallet) openSession(aead cipher.AEAD, keyManager kms.KeyManager, kmsLock secretlock.Service,
+	vault storage.Provider, opts *unlockOpts) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.unlocked() {
+		return "", ErrWalletOpen
+	}
+
+	if c.vault != nil {
+		c.vault.connect(vault)
+	}
+
+	c.aead = aead
+	c.keyManager = keyManager
+	c.kmsLock = kmsLock
+	c.authToken = newAuthToken()
+	c.idleTimeout = opts.idleTimeout
+	c.lastUsed = c.clock.Now()
+
+	return c.authToken, nil
+}
+
+// Refresh replaces the auth token of the open session with a new one, the given token expiring.
+func (c *Wallet) Refresh(authToken string) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.useSession(authToken); err != nil {
+		return "", err
+	}
+
+	c.authToken = newAuthToken()
+
+	return c.authToken, nil
+}
+
+// Close locks the wallet, expiring the auth token of its session, returning false if it was already locked. No auth
+// token is required, so that a session can always be closed.
+func (c *Wallet) Close() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	unlocked := c.unlocked()
+	c.clearSession()
+
+	return unlocked
+}
+
+// Locked tells whether the wallet is locked.
+func (c *Wallet) Locked() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return !c.unlocked()
+}
+
+// KMS returns the KMS of the unlocked wallet, whose keys are kept in the stores of the profile and encrypted with its
+// master key. The KMS is bound to the session: each of its operations requires the auth token to be valid.
+func (c *Wallet) KMS(authToken string) (kms.KeyManager, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.useSession(authToken); err != nil {
+		return nil, err
+	}
+
+	return &sessionKMS{wallet: c, authToken: authToken}, nil
+}
+
+// useSession checks the auth token of the session, clearing the keys of an expired session, and marks the session as
+// used. The wallet lock must be held.
+func (c *Wallet) useSession(authToken string) error {
+	if !c.unlocked() {
+		c.clearSession()
+
+		return ErrWalletLocked
+	}
+
+	if subtle.ConstantTimeCompare([]byte(authToken), []byte(c.authToken)) != 1 {
+		return ErrInvalidAuthToken
+	}
+
+	c.lastUsed = c.clock.Now()
+
+	return nil
+}
+
+func (c *Wallet) clearSession() {
+	c.aead = nil
+	c.keyManager = nil
+	c.kmsLock = nil
+	c.authToken = ""
+	c.stopMonitor()
+
+	if c.vault != nil {
+		c.vault.connect(nil)
+	}
+}
+
+func (c *Wallet) unlocked() bool {
+	return c.aead != nil && clock.Since(c.clock, c.lastUsed) < c.idleTimeout
+}
+
+func newAuthToken() string {
+	return base64.RawURLEncoding.EncodeToString(random.GetRandomBytes(authTokenSize))
+}
+
+// Add adds the content to the wallet, replacing the content of the same type and ID along with its collection and
+// tags. The content ID is its "id", or the ID of the DID document of a DID resolution response.
+func (c *Wallet) Add(authToken string, contentType ContentType, content json.RawMessage, options ...AddOpt) error {
+	if err := validateContentType(contentType); err != nil {
+		return err
+	}
+
+	meta := &contentMeta{}
+
+	for _, opt := range options {
+		opt(meta)
+	}
+
+	id, err := contentID(contentType, content)
+	if err != nil {
+		return err
+	}
+
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return err
+	}
+
+	if meta.Collection != "" {
+		if _, err := c.Get(authToken, Collection, meta.Collection); err != nil {
+			return fmt.Errorf("collection %s : %w", meta.Collection, err)
+		}
+	}
+
+	change, err := c.stampChange(aead, contentType, id, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := c.putContent(aead, contentType, id, content); err != nil {
+		return err
+	}
+
+	if err := c.putContentMeta(aead, c.contentMetaKey(contentType, id), meta); err != nil {
+		return err
+	}
+
+	if change != nil {
+		change.Content = content
+		c.sendChange(aead, change)
+	}
+
+	return nil
+}
+
+func (c *Wallet) putContent(aead cipher.AEAD, contentType ContentType, id string, content json.RawMessage) error {
+	key := c.contentKey(contentType, id)
+	nonce := random.GetRandomBytes(uint32(aead.NonceSize()))
+
+	// the store key is authenticated, binding the encrypted content to its user, type and ID
+	if err := c.store.Put(key, aead.Seal(nonce, nonce, content, []byte(key))); err != nil {
+		return fmt.Errorf("failed to save wallet content : %w", err)
+	}
+
+	return nil
+}
+
+// Remove removes the content from the wallet. The contents of a removed collection are kept, outside of any
+// collection.
+func (c *Wallet) Remove(authToken string, contentType ContentType, contentID string) error {
+	if err := validateContentType(contentType); err != nil {
+		return err
+	}
+
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return err
+	}
+
+	// the metadata of the contents removed from a synchronized wallet is kept with their clock
+	removed := &contentMeta{Removed: true}
+
+	change, err := c.stampChange(aead, contentType, contentID, removed)
+	if err != nil {
+		return err
+	}
+
+	if err := c.store.Delete(c.contentKey(contentType, contentID)); err != nil {
+		return fmt.Errorf("failed to remove wallet content : %w", err)
+	}
+
+	if err := c.putContentMeta(aead, c.contentMetaKey(contentType, contentID), removed); err != nil {
+		return err
+	}
+
+	if contentType == Collection {
+		if err := c.clearCollection(aead, contentID); err != nil {
+			return err
+		}
+	}
+
+	c.sendChange(aead, change)
+
+	return nil
+}
+
+// Get returns the content of the wallet.
+func (c *Wallet) Get(authToken string, contentType ContentType, contentID string) (json.RawMessage, error) {
+	if err := validateContentType(contentType); err != nil {
+		return nil, err
+	}
+
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	key := c.contentKey(contentType, contentID)
+
+	encrypted, err := c.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrContentNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet content : %w", err)
+	}
+
+	return decrypt(aead, key, encrypted)
+}
+
+// GetAll returns the contents of the type in the wallet, by ID, optionally filtered by collection and tags.
+func (c *Wallet) GetAll(authToken string, contentType ContentType,
+	options ...GetAllOpt) (map[string]json.RawMessage, error) {
+	if err := validateContentType(contentType); err != nil {
+		return nil, err
+	}
+
+	filter := &contentMeta{}
+
+	for _, opt := range options {
+		opt(filter)
+	}
+
+	aead, err := c.contentCipher(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getAll(aead, contentType, filter)
+}
+
+func (c *Wallet) getAll(aead cipher.AEAD, contentType ContentType,
+	filter *contentMeta) (map[string]json.RawMessage, error) {
+	prefix := c.contentKey(contentType, "")
+
+	iter := c.store.Iterator(prefix, prefix+storage.EndKeySuffix)
+	defer iter.Release()
+
+	contents := make(map[string]json.RawMessage)
+
+	for iter.Next() {
+		key := string(iter.Key())
+
+		id := strings.TrimPrefix(key, prefix)
+
+		if !filter.empty() {
+			meta, err := c.getContentMeta(aead, contentType, id)
+			if err != nil {
+				return nil, err
+			}
+
+			if !meta.matches(filter) {
+				continue
+			}
+		}
+
+		content, err := decrypt(aead, key, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		contents[id] = content
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to get wallet contents : %w", err)
+	}
+
+	return contents, nil
+}
+
+func (c *Wallet) contentCipher(authToken string) (cipher.AEAD, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.useSession(authToken); err != nil {
+		return nil, err
+	}
+
+	return c.aead, nil
+}
+
+func (c *Wallet) contentKey(contentType ContentType, contentID string) string {
+	return contentKeyPrefix + userKey(c.userID) + "_" + string(contentType) + "_" + contentID
+}
+
+func decrypt(aead cipher.AEAD, key string, encrypted []byte) (json.RawMessage, error) {
+	if len(encrypted) < aead.NonceSize() {
+		return nil, fmt.Errorf("failed to decrypt wallet content %s : invalid ciphertext", key)
+	}
+
+	nonce, ciphertext := encrypted[:aead.NonceSize()], encrypted[aead.NonceSize():]
+
+	content, err := aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt wallet content %s : %w", key, err)
+	}
+
+	return content, nil
+}
+
+func validateContentType(contentType ContentType) error {
+	switch contentType {
+	case Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key:
+		return nil
+	default:
+		return fmt.Errorf("unsupported content type %q", contentType)
+	}
+}
+
+// contentID returns the ID of the content.
+func contentID(contentType ContentType, content json.RawMessage) (string, error) {
+	var c struct {
+		ID          string `json:"id"`
+		DIDDocument *struct {
+			ID string `json:"id"`
+		} `json:"didDocument"`
+	}
+
+	if err := json.Unmarshal(content, &c); err != nil {
+		return "", fmt.Errorf("invalid %s content : %w", contentType, err)
+	}
+
+	if contentType == DIDResolutionResponse && c.DIDDocument != nil && c.DIDDocument.ID != "" {
+		return c.DIDDocument.ID, nil
+	}
+
+	if c.ID == "" {
+		return "", fmt.Errorf("invalid %s content : missing id", contentType)
+	}
+
+	return c.ID, nil
+}
@@ -0,0 +1,408 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const (
+	sampleUserID     = "sample-user"
+	samplePassphrase = "sample-passphrase"
+
+	sampleCredential = `{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"id": "http://example.edu/credentials/1872",
+		"type": ["VerifiableCredential"],
+		"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+	}`
+	sampleDIDResolution = `{
+		"@context": "https://w3id.org/did-resolution/v1",
+		"didDocument": {"@context": ["https://w3id.org/did/v1"], "id": "did:example:123"}
+	}`
+)
+
+func TestMain(m *testing.M) {
+	// the passphrase keys are derived at a low cost, so that the tests opening many wallets stay fast
+	defaultKeyDerivation.N = 1 << 10
+
+	os.Exit(m.Run())
+}
+
+func TestCreateProfile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctx := newProvider()
+
+		require.NoError(t, CreateProfile(sampleUserID, samplePassphrase, ctx))
+
+		err := CreateProfile(sampleUserID, samplePassphrase, ctx)
+		require.True(t, errors.Is(err, ErrProfileExists))
+
+		wallet, err := New(sampleUserID, ctx)
+		require.NoError(t, err)
+		require.True(t, wallet.Locked())
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		err := CreateProfile("", samplePassphrase, newProvider())
+		require.EqualError(t, err, "user ID is mandatory")
+
+		err = CreateProfile(sampleUserID, "", newProvider())
+		require.EqualError(t, err, "passphrase is mandatory")
+	})
+
+	t.Run("storage errors", func(t *testing.T) {
+		err := CreateProfile(sampleUserID, samplePassphrase, &mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open error")
+
+		storageProvider := mockstorage.NewMockStoreProvider()
+		storageProvider.Store.ErrPut = errors.New("put error")
+
+		err = CreateProfile(sampleUserID, samplePassphrase,
+			&mockprovider.Provider{StorageProviderValue: storageProvider})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "put error")
+	})
+
+	t.Run("profile not found", func(t *testing.T) {
+		_, err := New(sampleUserID, newProvider())
+		require.True(t, errors.Is(err, ErrProfileNotFound))
+	})
+
+	t.Run("key derivation", func(t *testing.T) {
+		wallet := newWallet(t, newProvider())
+		require.Equal(t, &defaultKeyDerivation, wallet.profile.KDF)
+
+		kdf := *wallet.profile.KDF
+
+		wallet.profile.KDF = &keyDerivation{Name: "hkdf"}
+		_, err := wallet.Open(samplePassphrase)
+		require.EqualError(t, err, "unsupported passphrase key derivation")
+
+		wallet.profile.KDF = &keyDerivation{Name: scryptKDF, N: maxScryptCost << 1, R: kdf.R, P: kdf.P}
+		_, err = wallet.Open(samplePassphrase)
+		require.EqualError(t, err, "passphrase key derivation cost too high")
+
+		// the stored parameters derive the key, not the default ones
+		wallet.profile.KDF = &kdf
+		defaultKeyDerivation.N <<= 1
+
+		defer func() { defaultKeyDerivation.N >>= 1 }()
+
+		open(t, wallet, samplePassphrase)
+	})
+}
+
+func TestRemoveProfile(t *testing.T) {
+	ctx := newProvider()
+
+	wallet := newWallet(t, ctx)
+	token := open(t, wallet, samplePassphrase)
+	require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleCredential)))
+
+	keyManager, err := wallet.KMS(token)
+	require.NoError(t, err)
+
+	_, _, err = keyManager.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	require.True(t, errors.Is(RemoveProfile(sampleUserID, "wrong-passphrase", ctx), ErrInvalidPassphrase))
+	require.NoError(t, RemoveProfile(sampleUserID, samplePassphrase, ctx))
+	require.True(t, errors.Is(RemoveProfile(sampleUserID, samplePassphrase, ctx), ErrProfileNotFound))
+
+	_, err = New(sampleUserID, ctx)
+	require.True(t, errors.Is(err, ErrProfileNotFound))
+
+	// the contents and keys of the profile are removed with it
+	for _, name := range []string{"contents", "kmsdb"} {
+		store, err := ctx.StorageProvider().OpenStore("vcwallet-" + userKey(sampleUserID) + "_" + name)
+		require.NoError(t, err)
+
+		iter := store.Iterator("", storage.EndKeySuffix)
+		require.False(t, iter.Next(), name)
+		iter.Release()
+	}
+
+	// the profile can be created again, empty
+	wallet = newWallet(t, ctx)
+	token = open(t, wallet, samplePassphrase)
+
+	credentials, err := wallet.GetAll(token, Credential)
+	require.NoError(t, err)
+	require.Empty(t, credentials)
+}
+
+func TestWallet_KMS(t *testing.T) {
+	ctx := newProvider()
+	wallet := newWallet(t, ctx)
+
+	_, err := wallet.KMS("")
+	require.True(t, errors.Is(err, ErrWalletLocked))
+
+	token := open(t, wallet, samplePassphrase)
+
+	_, err = wallet.KMS("other-token")
+	require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+	keyManager, err := wallet.KMS(token)
+	require.NoError(t, err)
+
+	keyID, _, err := keyManager.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	t.Run("keys of the profile", func(t *testing.T) {
+		// the KMS of a closed session can't be used
+		require.True(t, wallet.Close())
+
+		_, err = keyManager.Get(keyID)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+
+		// the keys are available once the wallet is opened again
+		token := open(t, wallet, samplePassphrase)
+
+		keyManager, err = wallet.KMS(token)
+		require.NoError(t, err)
+
+		_, err = keyManager.Get(keyID)
+		require.NoError(t, err)
+
+		// other profiles have their own keys
+		require.NoError(t, CreateProfile("other-user", "other-passphrase", ctx))
+
+		other, err := New("other-user", ctx)
+		require.NoError(t, err)
+		otherToken := open(t, other, "other-passphrase")
+
+		otherKeyManager, err := other.KMS(otherToken)
+		require.NoError(t, err)
+
+		_, err = otherKeyManager.Get(keyID)
+		require.Error(t, err)
+	})
+}
+
+func TestWallet_Open(t *testing.T) {
+	wallet := newWallet(t, newProvider())
+
+	_, err := wallet.Open("wrong-passphrase")
+	require.True(t, errors.Is(err, ErrInvalidPassphrase))
+	require.True(t, wallet.Locked())
+
+	token := open(t, wallet, samplePassphrase)
+	require.NotEmpty(t, token)
+	require.False(t, wallet.Locked())
+
+	_, err = wallet.Open(samplePassphrase)
+	require.True(t, errors.Is(err, ErrWalletOpen))
+
+	t.Run("auth token", func(t *testing.T) {
+		_, err = wallet.GetAll("other-token", Credential)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		refreshed, err := wallet.Refresh(token)
+		require.NoError(t, err)
+		require.NotEqual(t, token, refreshed)
+
+		// the refreshed token replaces the previous one
+		_, err = wallet.GetAll(token, Credential)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		_, err = wallet.Refresh(token)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		_, err = wallet.GetAll(refreshed, Credential)
+		require.NoError(t, err)
+
+		token = refreshed
+	})
+
+	t.Run("close", func(t *testing.T) {
+		require.True(t, wallet.Close())
+		require.True(t, wallet.Locked())
+		require.False(t, wallet.Close())
+
+		_, err = wallet.GetAll(token, Credential)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+
+		_, err = wallet.Refresh(token)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+	})
+
+	t.Run("idle timeout", func(t *testing.T) {
+		clock := mockclock.New(time.Now())
+		wallet := newWallet(t, &mockprovider.Provider{StorageProviderValue: mem.NewProvider(), ClockValue: clock})
+
+		token, err := wallet.Open(samplePassphrase, WithIdleTimeout(time.Minute))
+		require.NoError(t, err)
+
+		// using the session keeps it open
+		for i := 0; i < 3; i++ {
+			clock.Add(30 * time.Second)
+
+			_, err = wallet.GetAll(token, Credential)
+			require.NoError(t, err)
+		}
+
+		clock.Add(time.Minute)
+		require.True(t, wallet.Locked())
+
+		_, err = wallet.GetAll(token, Credential)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+
+		// the wallet can be opened again once the session has expired
+		_, err = wallet.Open(samplePassphrase)
+		require.NoError(t, err)
+	})
+}
+
+func TestWallet_Contents(t *testing.T) {
+	ctx := newProvider()
+	wallet := newWallet(t, ctx)
+
+	t.Run("locked wallet", func(t *testing.T) {
+		require.True(t, errors.Is(wallet.Add("", Credential, json.RawMessage(sampleCredential)), ErrWalletLocked))
+		require.True(t, errors.Is(wallet.Remove("", Credential, "id"), ErrWalletLocked))
+
+		_, err := wallet.Get("", Credential, "id")
+		require.True(t, errors.Is(err, ErrWalletLocked))
+	})
+
+	token := open(t, wallet, samplePassphrase)
+
+	t.Run("add, get and remove contents", func(t *testing.T) {
+		require.NoError(t, wallet.Add(token, Credential, json.RawMessage(sampleCredential)))
+		require.NoError(t, wallet.Add(token, DIDResolutionResponse, json.RawMessage(sampleDIDResolution)))
+		require.NoError(t, wallet.Add(token, Metadata, json.RawMessage(`{"id": "m1", "name": "work"}`)))
+		require.NoError(t, wallet.Add(token, Metadata, json.RawMessage(`{"id": "m2", "name": "home"}`)))
+
+		credential, err := wallet.Get(token, Credential, "http://example.edu/credentials/1872")
+		require.NoError(t, err)
+		require.JSONEq(t, sampleCredential, string(credential))
+
+		didResolution, err := wallet.Get(token, DIDResolutionResponse, "did:example:123")
+		require.NoError(t, err)
+		require.JSONEq(t, sampleDIDResolution, string(didResolution))
+
+		metadata, err := wallet.GetAll(token, Metadata)
+		require.NoError(t, err)
+		require.Len(t, metadata, 2)
+		require.JSONEq(t, `{"id": "m2", "name": "home"}`, string(metadata["m2"]))
+
+		// the same ID replaces the content
+		require.NoError(t, wallet.Add(token, Metadata, json.RawMessage(`{"id": "m2", "name": "office"}`)))
+
+		m2, err := wallet.Get(token, Metadata, "m2")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id": "m2", "name": "office"}`, string(m2))
+
+		require.NoError(t, wallet.Remove(token, Metadata, "m1"))
+
+		_, err = wallet.Get(token, Metadata, "m1")
+		require.True(t, errors.Is(err, ErrContentNotFound))
+
+		connections, err := wallet.GetAll(token, Connection)
+		require.NoError(t, err)
+		require.Empty(t, connections)
+	})
+
+	t.Run("contents isolated by user", func(t *testing.T) {
+		// the user ID of the other wallet starts with that of the first one
+		require.NoError(t, CreateProfile(sampleUserID+"_credential", samplePassphrase, ctx))
+
+		other, err := New(sampleUserID+"_credential", ctx)
+		require.NoError(t, err)
+		otherToken := open(t, other, samplePassphrase)
+
+		credentials, err := other.GetAll(otherToken, Credential)
+		require.NoError(t, err)
+		require.Empty(t, credentials)
+
+		_, err = other.Get(otherToken, Credential, "http://example.edu/credentials/1872")
+		require.True(t, errors.Is(err, ErrContentNotFound))
+	})
+
+	t.Run("contents encrypted at rest", func(t *testing.T) {
+		// the contents are in a store of the profile
+		store, err := ctx.StorageProvider().OpenStore("vcwallet-" + userKey(sampleUserID) + "_contents")
+		require.NoError(t, err)
+
+		key := wallet.contentKey(Credential, "http://example.edu/credentials/1872")
+
+		encrypted, err := store.Get(key)
+		require.NoError(t, err)
+		require.NotContains(t, string(encrypted), "did:example:ebfeb1f712ebc6f1c276e12ec21")
+
+		// the content is bound to its key
+		require.NoError(t, store.Put(wallet.contentKey(Credential, "other"), encrypted))
+
+		_, err = wallet.Get(token, Credential, "other")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt wallet content")
+	})
+
+	t.Run("invalid contents", func(t *testing.T) {
+		err := wallet.Add(token, "unknown", json.RawMessage(sampleCredential))
+		require.EqualError(t, err, `unsupported content type "unknown"`)
+
+		err = wallet.Add(token, Credential, json.RawMessage(`{"type": "VerifiableCredential"}`))
+		require.EqualError(t, err, "invalid credential content : missing id")
+
+		err = wallet.Add(token, Key, json.RawMessage(`[]`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid key content")
+
+		_, err = wallet.Get(token, "unknown", "id")
+		require.Error(t, err)
+
+		_, err = wallet.GetAll(token, "unknown")
+		require.Error(t, err)
+
+		require.Error(t, wallet.Remove(token, "unknown", "id"))
+	})
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{StorageProviderValue: mem.NewProvider()}
+}
+
+func newWallet(t *testing.T, ctx provider) *Wallet {
+	t.Helper()
+
+	require.NoError(t, CreateProfile(sampleUserID, samplePassphrase, ctx))
+
+	wallet, err := New(sampleUserID, ctx)
+	require.NoError(t, err)
+
+	return wallet
+}
+
+func open(t *testing.T, wallet *Wallet, passphrase string) string {
+	t.Helper()
+
+	token, err := wallet.Open(passphrase)
+	require.NoError(t, err)
+
+	return token
+}